@@ -0,0 +1,1707 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ConsistencyIssue_Type int32
+
+const (
+	ConsistencyIssue_UNSPECIFIED ConsistencyIssue_Type = 0
+	// A run whose workflow isn't stored. It's repaired by storing its
+	// workflow again, if it still exists.
+	ConsistencyIssue_RUN_WITHOUT_MANIFEST ConsistencyIssue_Type = 1
+	// A pipeline whose package isn't in the object store. It's repaired by
+	// deleting the pipeline.
+	ConsistencyIssue_PIPELINE_WITHOUT_PACKAGE ConsistencyIssue_Type = 2
+	// An object of the object store whose pipeline or run doesn't exist. It's
+	// repaired by deleting the object.
+	ConsistencyIssue_DANGLING_OBJECT ConsistencyIssue_Type = 3
+)
+
+var ConsistencyIssue_Type_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "RUN_WITHOUT_MANIFEST",
+	2: "PIPELINE_WITHOUT_PACKAGE",
+	3: "DANGLING_OBJECT",
+}
+
+var ConsistencyIssue_Type_value = map[string]int32{
+	"UNSPECIFIED":              0,
+	"RUN_WITHOUT_MANIFEST":     1,
+	"PIPELINE_WITHOUT_PACKAGE": 2,
+	"DANGLING_OBJECT":          3,
+}
+
+func (x ConsistencyIssue_Type) String() string {
+	return proto.EnumName(ConsistencyIssue_Type_name, int32(x))
+}
+
+func (ConsistencyIssue_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2, 0}
+}
+
+type StorageUsage_Category int32
+
+const (
+	StorageUsage_UNSPECIFIED StorageUsage_Category = 0
+	// The packages of the pipelines and their compiled workflows. The pipelines
+	// are shared, so that their usage has no namespace nor experiment.
+	StorageUsage_PIPELINE_PACKAGES StorageUsage_Category = 1
+	// The pipeline specs and the workflows of the runs stored in the database.
+	StorageUsage_RUN_MANIFESTS StorageUsage_Category = 2
+	// The output artifacts of the steps of the runs stored in the bucket of the
+	// API server.
+	StorageUsage_ARTIFACTS StorageUsage_Category = 3
+	// The archived logs of the steps of the runs.
+	StorageUsage_LOGS StorageUsage_Category = 4
+)
+
+var StorageUsage_Category_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "PIPELINE_PACKAGES",
+	2: "RUN_MANIFESTS",
+	3: "ARTIFACTS",
+	4: "LOGS",
+}
+
+var StorageUsage_Category_value = map[string]int32{
+	"UNSPECIFIED":       0,
+	"PIPELINE_PACKAGES": 1,
+	"RUN_MANIFESTS":     2,
+	"ARTIFACTS":         3,
+	"LOGS":              4,
+}
+
+func (x StorageUsage_Category) String() string {
+	return proto.EnumName(StorageUsage_Category_name, int32(x))
+}
+
+func (StorageUsage_Category) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{13, 0}
+}
+
+type CheckConsistencyRequest struct {
+	// Whether the issues found are repaired. Otherwise, they're only reported.
+	Repair               bool     `protobuf:"varint,1,opt,name=repair,proto3" json:"repair,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckConsistencyRequest) Reset()         { *m = CheckConsistencyRequest{} }
+func (m *CheckConsistencyRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckConsistencyRequest) ProtoMessage()    {}
+func (*CheckConsistencyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+func (m *CheckConsistencyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckConsistencyRequest.Unmarshal(m, b)
+}
+func (m *CheckConsistencyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckConsistencyRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckConsistencyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckConsistencyRequest.Merge(m, src)
+}
+func (m *CheckConsistencyRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckConsistencyRequest.Size(m)
+}
+func (m *CheckConsistencyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckConsistencyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckConsistencyRequest proto.InternalMessageInfo
+
+func (m *CheckConsistencyRequest) GetRepair() bool {
+	if m != nil {
+		return m.Repair
+	}
+	return false
+}
+
+type GetConsistencyReportRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConsistencyReportRequest) Reset()         { *m = GetConsistencyReportRequest{} }
+func (m *GetConsistencyReportRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConsistencyReportRequest) ProtoMessage()    {}
+func (*GetConsistencyReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{1}
+}
+
+func (m *GetConsistencyReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConsistencyReportRequest.Unmarshal(m, b)
+}
+func (m *GetConsistencyReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConsistencyReportRequest.Marshal(b, m, deterministic)
+}
+func (m *GetConsistencyReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConsistencyReportRequest.Merge(m, src)
+}
+func (m *GetConsistencyReportRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConsistencyReportRequest.Size(m)
+}
+func (m *GetConsistencyReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConsistencyReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConsistencyReportRequest proto.InternalMessageInfo
+
+type ConsistencyIssue struct {
+	Type ConsistencyIssue_Type `protobuf:"varint,1,opt,name=type,proto3,enum=api.ConsistencyIssue_Type" json:"type,omitempty"`
+	// The ID of the run or of the pipeline, or the key of the object.
+	ResourceId  string `protobuf:"bytes,2,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Repaired    bool   `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	// Why the issue couldn't be repaired, if it couldn't.
+	RepairError          string   `protobuf:"bytes,5,opt,name=repair_error,json=repairError,proto3" json:"repair_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConsistencyIssue) Reset()         { *m = ConsistencyIssue{} }
+func (m *ConsistencyIssue) String() string { return proto.CompactTextString(m) }
+func (*ConsistencyIssue) ProtoMessage()    {}
+func (*ConsistencyIssue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2}
+}
+
+func (m *ConsistencyIssue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsistencyIssue.Unmarshal(m, b)
+}
+func (m *ConsistencyIssue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsistencyIssue.Marshal(b, m, deterministic)
+}
+func (m *ConsistencyIssue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsistencyIssue.Merge(m, src)
+}
+func (m *ConsistencyIssue) XXX_Size() int {
+	return xxx_messageInfo_ConsistencyIssue.Size(m)
+}
+func (m *ConsistencyIssue) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsistencyIssue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsistencyIssue proto.InternalMessageInfo
+
+func (m *ConsistencyIssue) GetType() ConsistencyIssue_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ConsistencyIssue_UNSPECIFIED
+}
+
+func (m *ConsistencyIssue) GetResourceId() string {
+	if m != nil {
+		return m.ResourceId
+	}
+	return ""
+}
+
+func (m *ConsistencyIssue) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ConsistencyIssue) GetRepaired() bool {
+	if m != nil {
+		return m.Repaired
+	}
+	return false
+}
+
+func (m *ConsistencyIssue) GetRepairError() string {
+	if m != nil {
+		return m.RepairError
+	}
+	return ""
+}
+
+type ConsistencyReport struct {
+	CheckedAt *timestamp.Timestamp `protobuf:"bytes,1,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// Whether the issues found were repaired.
+	Repair               bool                `protobuf:"varint,2,opt,name=repair,proto3" json:"repair,omitempty"`
+	Issues               []*ConsistencyIssue `protobuf:"bytes,3,rep,name=issues,proto3" json:"issues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ConsistencyReport) Reset()         { *m = ConsistencyReport{} }
+func (m *ConsistencyReport) String() string { return proto.CompactTextString(m) }
+func (*ConsistencyReport) ProtoMessage()    {}
+func (*ConsistencyReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3}
+}
+
+func (m *ConsistencyReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsistencyReport.Unmarshal(m, b)
+}
+func (m *ConsistencyReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsistencyReport.Marshal(b, m, deterministic)
+}
+func (m *ConsistencyReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsistencyReport.Merge(m, src)
+}
+func (m *ConsistencyReport) XXX_Size() int {
+	return xxx_messageInfo_ConsistencyReport.Size(m)
+}
+func (m *ConsistencyReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsistencyReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsistencyReport proto.InternalMessageInfo
+
+func (m *ConsistencyReport) GetCheckedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CheckedAt
+	}
+	return nil
+}
+
+func (m *ConsistencyReport) GetRepair() bool {
+	if m != nil {
+		return m.Repair
+	}
+	return false
+}
+
+func (m *ConsistencyReport) GetIssues() []*ConsistencyIssue {
+	if m != nil {
+		return m.Issues
+	}
+	return nil
+}
+
+type ReindexPipelinesRequest struct {
+	// Whether to return at once the ID of an operation reindexing the pipelines
+	// in the background, polled with the OperationService.
+	Async                bool     `protobuf:"varint,1,opt,name=async,proto3" json:"async,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReindexPipelinesRequest) Reset()         { *m = ReindexPipelinesRequest{} }
+func (m *ReindexPipelinesRequest) String() string { return proto.CompactTextString(m) }
+func (*ReindexPipelinesRequest) ProtoMessage()    {}
+func (*ReindexPipelinesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{4}
+}
+
+func (m *ReindexPipelinesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReindexPipelinesRequest.Unmarshal(m, b)
+}
+func (m *ReindexPipelinesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReindexPipelinesRequest.Marshal(b, m, deterministic)
+}
+func (m *ReindexPipelinesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReindexPipelinesRequest.Merge(m, src)
+}
+func (m *ReindexPipelinesRequest) XXX_Size() int {
+	return xxx_messageInfo_ReindexPipelinesRequest.Size(m)
+}
+func (m *ReindexPipelinesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReindexPipelinesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReindexPipelinesRequest proto.InternalMessageInfo
+
+func (m *ReindexPipelinesRequest) GetAsync() bool {
+	if m != nil {
+		return m.Async
+	}
+	return false
+}
+
+type RecomputeRunStatusesRequest struct {
+	// Whether to return at once the ID of an operation recomputing the statuses
+	// in the background, polled with the OperationService.
+	Async                bool     `protobuf:"varint,1,opt,name=async,proto3" json:"async,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecomputeRunStatusesRequest) Reset()         { *m = RecomputeRunStatusesRequest{} }
+func (m *RecomputeRunStatusesRequest) String() string { return proto.CompactTextString(m) }
+func (*RecomputeRunStatusesRequest) ProtoMessage()    {}
+func (*RecomputeRunStatusesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{5}
+}
+
+func (m *RecomputeRunStatusesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecomputeRunStatusesRequest.Unmarshal(m, b)
+}
+func (m *RecomputeRunStatusesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecomputeRunStatusesRequest.Marshal(b, m, deterministic)
+}
+func (m *RecomputeRunStatusesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecomputeRunStatusesRequest.Merge(m, src)
+}
+func (m *RecomputeRunStatusesRequest) XXX_Size() int {
+	return xxx_messageInfo_RecomputeRunStatusesRequest.Size(m)
+}
+func (m *RecomputeRunStatusesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecomputeRunStatusesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecomputeRunStatusesRequest proto.InternalMessageInfo
+
+func (m *RecomputeRunStatusesRequest) GetAsync() bool {
+	if m != nil {
+		return m.Async
+	}
+	return false
+}
+
+type FlushCachesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FlushCachesRequest) Reset()         { *m = FlushCachesRequest{} }
+func (m *FlushCachesRequest) String() string { return proto.CompactTextString(m) }
+func (*FlushCachesRequest) ProtoMessage()    {}
+func (*FlushCachesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{6}
+}
+
+func (m *FlushCachesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FlushCachesRequest.Unmarshal(m, b)
+}
+func (m *FlushCachesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FlushCachesRequest.Marshal(b, m, deterministic)
+}
+func (m *FlushCachesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlushCachesRequest.Merge(m, src)
+}
+func (m *FlushCachesRequest) XXX_Size() int {
+	return xxx_messageInfo_FlushCachesRequest.Size(m)
+}
+func (m *FlushCachesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlushCachesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlushCachesRequest proto.InternalMessageInfo
+
+type MaintenanceResult struct {
+	// The number of resources processed, e.g. the pipelines reindexed.
+	Processed int32 `protobuf:"varint,1,opt,name=processed,proto3" json:"processed,omitempty"`
+	// The errors of the resources which failed to be processed.
+	Errors []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	// The ID of the operation processing the resources in the background, set
+	// instead of the above for the asynchronous requests.
+	OperationId          string   `protobuf:"bytes,3,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MaintenanceResult) Reset()         { *m = MaintenanceResult{} }
+func (m *MaintenanceResult) String() string { return proto.CompactTextString(m) }
+func (*MaintenanceResult) ProtoMessage()    {}
+func (*MaintenanceResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{7}
+}
+
+func (m *MaintenanceResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MaintenanceResult.Unmarshal(m, b)
+}
+func (m *MaintenanceResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MaintenanceResult.Marshal(b, m, deterministic)
+}
+func (m *MaintenanceResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenanceResult.Merge(m, src)
+}
+func (m *MaintenanceResult) XXX_Size() int {
+	return xxx_messageInfo_MaintenanceResult.Size(m)
+}
+func (m *MaintenanceResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenanceResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintenanceResult proto.InternalMessageInfo
+
+func (m *MaintenanceResult) GetProcessed() int32 {
+	if m != nil {
+		return m.Processed
+	}
+	return 0
+}
+
+func (m *MaintenanceResult) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func (m *MaintenanceResult) GetOperationId() string {
+	if m != nil {
+		return m.OperationId
+	}
+	return ""
+}
+
+type SetReadOnlyModeRequest struct {
+	ReadOnly bool `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// The message the writes fail with, e.g. the end of the maintenance window.
+	// A default message is used if empty.
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetReadOnlyModeRequest) Reset()         { *m = SetReadOnlyModeRequest{} }
+func (m *SetReadOnlyModeRequest) String() string { return proto.CompactTextString(m) }
+func (*SetReadOnlyModeRequest) ProtoMessage()    {}
+func (*SetReadOnlyModeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{8}
+}
+
+func (m *SetReadOnlyModeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetReadOnlyModeRequest.Unmarshal(m, b)
+}
+func (m *SetReadOnlyModeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetReadOnlyModeRequest.Marshal(b, m, deterministic)
+}
+func (m *SetReadOnlyModeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReadOnlyModeRequest.Merge(m, src)
+}
+func (m *SetReadOnlyModeRequest) XXX_Size() int {
+	return xxx_messageInfo_SetReadOnlyModeRequest.Size(m)
+}
+func (m *SetReadOnlyModeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReadOnlyModeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReadOnlyModeRequest proto.InternalMessageInfo
+
+func (m *SetReadOnlyModeRequest) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *SetReadOnlyModeRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GetReadOnlyModeRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetReadOnlyModeRequest) Reset()         { *m = GetReadOnlyModeRequest{} }
+func (m *GetReadOnlyModeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetReadOnlyModeRequest) ProtoMessage()    {}
+func (*GetReadOnlyModeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{9}
+}
+
+func (m *GetReadOnlyModeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReadOnlyModeRequest.Unmarshal(m, b)
+}
+func (m *GetReadOnlyModeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReadOnlyModeRequest.Marshal(b, m, deterministic)
+}
+func (m *GetReadOnlyModeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReadOnlyModeRequest.Merge(m, src)
+}
+func (m *GetReadOnlyModeRequest) XXX_Size() int {
+	return xxx_messageInfo_GetReadOnlyModeRequest.Size(m)
+}
+func (m *GetReadOnlyModeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReadOnlyModeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReadOnlyModeRequest proto.InternalMessageInfo
+
+type ReadOnlyMode struct {
+	ReadOnly bool `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// The message the writes fail with in read-only mode.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Whether the read-only mode is forced on by the configuration of the API
+	// server, e.g. during a schema migration. It can't be turned off then.
+	Forced    bool                 `protobuf:"varint,3,opt,name=forced,proto3" json:"forced,omitempty"`
+	UpdatedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// The admin who turned the read-only mode on or off last.
+	UpdatedBy            string   `protobuf:"bytes,5,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadOnlyMode) Reset()         { *m = ReadOnlyMode{} }
+func (m *ReadOnlyMode) String() string { return proto.CompactTextString(m) }
+func (*ReadOnlyMode) ProtoMessage()    {}
+func (*ReadOnlyMode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{10}
+}
+
+func (m *ReadOnlyMode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadOnlyMode.Unmarshal(m, b)
+}
+func (m *ReadOnlyMode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadOnlyMode.Marshal(b, m, deterministic)
+}
+func (m *ReadOnlyMode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadOnlyMode.Merge(m, src)
+}
+func (m *ReadOnlyMode) XXX_Size() int {
+	return xxx_messageInfo_ReadOnlyMode.Size(m)
+}
+func (m *ReadOnlyMode) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadOnlyMode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadOnlyMode proto.InternalMessageInfo
+
+func (m *ReadOnlyMode) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *ReadOnlyMode) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ReadOnlyMode) GetForced() bool {
+	if m != nil {
+		return m.Forced
+	}
+	return false
+}
+
+func (m *ReadOnlyMode) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *ReadOnlyMode) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
+}
+
+type ComputeStorageUsageRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ComputeStorageUsageRequest) Reset()         { *m = ComputeStorageUsageRequest{} }
+func (m *ComputeStorageUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*ComputeStorageUsageRequest) ProtoMessage()    {}
+func (*ComputeStorageUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{11}
+}
+
+func (m *ComputeStorageUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComputeStorageUsageRequest.Unmarshal(m, b)
+}
+func (m *ComputeStorageUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComputeStorageUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *ComputeStorageUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeStorageUsageRequest.Merge(m, src)
+}
+func (m *ComputeStorageUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_ComputeStorageUsageRequest.Size(m)
+}
+func (m *ComputeStorageUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeStorageUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeStorageUsageRequest proto.InternalMessageInfo
+
+type GetStorageUsageRequest struct {
+	// Only returns the usage of the namespace, if set.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Only returns the usage of the experiment, if set.
+	ExperimentId         string   `protobuf:"bytes,2,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStorageUsageRequest) Reset()         { *m = GetStorageUsageRequest{} }
+func (m *GetStorageUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStorageUsageRequest) ProtoMessage()    {}
+func (*GetStorageUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{12}
+}
+
+func (m *GetStorageUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStorageUsageRequest.Unmarshal(m, b)
+}
+func (m *GetStorageUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStorageUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStorageUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStorageUsageRequest.Merge(m, src)
+}
+func (m *GetStorageUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStorageUsageRequest.Size(m)
+}
+func (m *GetStorageUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStorageUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStorageUsageRequest proto.InternalMessageInfo
+
+func (m *GetStorageUsageRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GetStorageUsageRequest) GetExperimentId() string {
+	if m != nil {
+		return m.ExperimentId
+	}
+	return ""
+}
+
+type StorageUsage struct {
+	Namespace            string                `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ExperimentId         string                `protobuf:"bytes,2,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	Category             StorageUsage_Category `protobuf:"varint,3,opt,name=category,proto3,enum=api.StorageUsage_Category" json:"category,omitempty"`
+	Bytes                int64                 `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *StorageUsage) Reset()         { *m = StorageUsage{} }
+func (m *StorageUsage) String() string { return proto.CompactTextString(m) }
+func (*StorageUsage) ProtoMessage()    {}
+func (*StorageUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{13}
+}
+
+func (m *StorageUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageUsage.Unmarshal(m, b)
+}
+func (m *StorageUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageUsage.Marshal(b, m, deterministic)
+}
+func (m *StorageUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageUsage.Merge(m, src)
+}
+func (m *StorageUsage) XXX_Size() int {
+	return xxx_messageInfo_StorageUsage.Size(m)
+}
+func (m *StorageUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageUsage proto.InternalMessageInfo
+
+func (m *StorageUsage) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StorageUsage) GetExperimentId() string {
+	if m != nil {
+		return m.ExperimentId
+	}
+	return ""
+}
+
+func (m *StorageUsage) GetCategory() StorageUsage_Category {
+	if m != nil {
+		return m.Category
+	}
+	return StorageUsage_UNSPECIFIED
+}
+
+func (m *StorageUsage) GetBytes() int64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+type StorageUsageReport struct {
+	ComputedAt *timestamp.Timestamp `protobuf:"bytes,1,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	// The usages by namespace, experiment and category, in this order.
+	Usages []*StorageUsage `protobuf:"bytes,2,rep,name=usages,proto3" json:"usages,omitempty"`
+	// The sum of the bytes of the usages.
+	TotalBytes           int64    `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StorageUsageReport) Reset()         { *m = StorageUsageReport{} }
+func (m *StorageUsageReport) String() string { return proto.CompactTextString(m) }
+func (*StorageUsageReport) ProtoMessage()    {}
+func (*StorageUsageReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{14}
+}
+
+func (m *StorageUsageReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageUsageReport.Unmarshal(m, b)
+}
+func (m *StorageUsageReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageUsageReport.Marshal(b, m, deterministic)
+}
+func (m *StorageUsageReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageUsageReport.Merge(m, src)
+}
+func (m *StorageUsageReport) XXX_Size() int {
+	return xxx_messageInfo_StorageUsageReport.Size(m)
+}
+func (m *StorageUsageReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageUsageReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageUsageReport proto.InternalMessageInfo
+
+func (m *StorageUsageReport) GetComputedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ComputedAt
+	}
+	return nil
+}
+
+func (m *StorageUsageReport) GetUsages() []*StorageUsage {
+	if m != nil {
+		return m.Usages
+	}
+	return nil
+}
+
+func (m *StorageUsageReport) GetTotalBytes() int64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+type ListDeadLetterReportsRequest struct {
+	// Whether the workflows reported are returned.
+	IncludeWorkflows     bool     `protobuf:"varint,1,opt,name=include_workflows,json=includeWorkflows,proto3" json:"include_workflows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDeadLetterReportsRequest) Reset()         { *m = ListDeadLetterReportsRequest{} }
+func (m *ListDeadLetterReportsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLetterReportsRequest) ProtoMessage()    {}
+func (*ListDeadLetterReportsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{15}
+}
+
+func (m *ListDeadLetterReportsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLetterReportsRequest.Unmarshal(m, b)
+}
+func (m *ListDeadLetterReportsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLetterReportsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLetterReportsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLetterReportsRequest.Merge(m, src)
+}
+func (m *ListDeadLetterReportsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLetterReportsRequest.Size(m)
+}
+func (m *ListDeadLetterReportsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLetterReportsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLetterReportsRequest proto.InternalMessageInfo
+
+func (m *ListDeadLetterReportsRequest) GetIncludeWorkflows() bool {
+	if m != nil {
+		return m.IncludeWorkflows
+	}
+	return false
+}
+
+type DeadLetterReport struct {
+	// The ID of the run, i.e. the UID of the workflow reported.
+	RunId        string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Namespace    string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	WorkflowName string `protobuf:"bytes,3,opt,name=workflow_name,json=workflowName,proto3" json:"workflow_name,omitempty"`
+	// The workflow reported, as JSON, if requested.
+	Workflow string `protobuf:"bytes,4,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	// The error of the last attempt to apply the report.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// The number of attempts to apply the report which failed.
+	Attempts             int32                `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	FailedAt             *timestamp.Timestamp `protobuf:"bytes,7,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeadLetterReport) Reset()         { *m = DeadLetterReport{} }
+func (m *DeadLetterReport) String() string { return proto.CompactTextString(m) }
+func (*DeadLetterReport) ProtoMessage()    {}
+func (*DeadLetterReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{16}
+}
+
+func (m *DeadLetterReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeadLetterReport.Unmarshal(m, b)
+}
+func (m *DeadLetterReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeadLetterReport.Marshal(b, m, deterministic)
+}
+func (m *DeadLetterReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeadLetterReport.Merge(m, src)
+}
+func (m *DeadLetterReport) XXX_Size() int {
+	return xxx_messageInfo_DeadLetterReport.Size(m)
+}
+func (m *DeadLetterReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeadLetterReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeadLetterReport proto.InternalMessageInfo
+
+func (m *DeadLetterReport) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *DeadLetterReport) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeadLetterReport) GetWorkflowName() string {
+	if m != nil {
+		return m.WorkflowName
+	}
+	return ""
+}
+
+func (m *DeadLetterReport) GetWorkflow() string {
+	if m != nil {
+		return m.Workflow
+	}
+	return ""
+}
+
+func (m *DeadLetterReport) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *DeadLetterReport) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *DeadLetterReport) GetFailedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FailedAt
+	}
+	return nil
+}
+
+type ListDeadLetterReportsResponse struct {
+	// The reports, the oldest failure first.
+	Reports              []*DeadLetterReport `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ListDeadLetterReportsResponse) Reset()         { *m = ListDeadLetterReportsResponse{} }
+func (m *ListDeadLetterReportsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDeadLetterReportsResponse) ProtoMessage()    {}
+func (*ListDeadLetterReportsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{17}
+}
+
+func (m *ListDeadLetterReportsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDeadLetterReportsResponse.Unmarshal(m, b)
+}
+func (m *ListDeadLetterReportsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDeadLetterReportsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDeadLetterReportsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDeadLetterReportsResponse.Merge(m, src)
+}
+func (m *ListDeadLetterReportsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDeadLetterReportsResponse.Size(m)
+}
+func (m *ListDeadLetterReportsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDeadLetterReportsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDeadLetterReportsResponse proto.InternalMessageInfo
+
+func (m *ListDeadLetterReportsResponse) GetReports() []*DeadLetterReport {
+	if m != nil {
+		return m.Reports
+	}
+	return nil
+}
+
+type ReplayDeadLetterReportsRequest struct {
+	// The IDs of the runs whose reports are replayed. All the reports are
+	// replayed if empty.
+	RunIds               []string `protobuf:"bytes,1,rep,name=run_ids,json=runIds,proto3" json:"run_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayDeadLetterReportsRequest) Reset()         { *m = ReplayDeadLetterReportsRequest{} }
+func (m *ReplayDeadLetterReportsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayDeadLetterReportsRequest) ProtoMessage()    {}
+func (*ReplayDeadLetterReportsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{18}
+}
+
+func (m *ReplayDeadLetterReportsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayDeadLetterReportsRequest.Unmarshal(m, b)
+}
+func (m *ReplayDeadLetterReportsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayDeadLetterReportsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReplayDeadLetterReportsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayDeadLetterReportsRequest.Merge(m, src)
+}
+func (m *ReplayDeadLetterReportsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplayDeadLetterReportsRequest.Size(m)
+}
+func (m *ReplayDeadLetterReportsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayDeadLetterReportsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayDeadLetterReportsRequest proto.InternalMessageInfo
+
+func (m *ReplayDeadLetterReportsRequest) GetRunIds() []string {
+	if m != nil {
+		return m.RunIds
+	}
+	return nil
+}
+
+type DeleteDeadLetterReportRequest struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteDeadLetterReportRequest) Reset()         { *m = DeleteDeadLetterReportRequest{} }
+func (m *DeleteDeadLetterReportRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteDeadLetterReportRequest) ProtoMessage()    {}
+func (*DeleteDeadLetterReportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{19}
+}
+
+func (m *DeleteDeadLetterReportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteDeadLetterReportRequest.Unmarshal(m, b)
+}
+func (m *DeleteDeadLetterReportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteDeadLetterReportRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteDeadLetterReportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteDeadLetterReportRequest.Merge(m, src)
+}
+func (m *DeleteDeadLetterReportRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteDeadLetterReportRequest.Size(m)
+}
+func (m *DeleteDeadLetterReportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteDeadLetterReportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteDeadLetterReportRequest proto.InternalMessageInfo
+
+func (m *DeleteDeadLetterReportRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("api.ConsistencyIssue_Type", ConsistencyIssue_Type_name, ConsistencyIssue_Type_value)
+	proto.RegisterEnum("api.StorageUsage_Category", StorageUsage_Category_name, StorageUsage_Category_value)
+	proto.RegisterType((*CheckConsistencyRequest)(nil), "api.CheckConsistencyRequest")
+	proto.RegisterType((*GetConsistencyReportRequest)(nil), "api.GetConsistencyReportRequest")
+	proto.RegisterType((*ConsistencyIssue)(nil), "api.ConsistencyIssue")
+	proto.RegisterType((*ConsistencyReport)(nil), "api.ConsistencyReport")
+	proto.RegisterType((*ReindexPipelinesRequest)(nil), "api.ReindexPipelinesRequest")
+	proto.RegisterType((*RecomputeRunStatusesRequest)(nil), "api.RecomputeRunStatusesRequest")
+	proto.RegisterType((*FlushCachesRequest)(nil), "api.FlushCachesRequest")
+	proto.RegisterType((*MaintenanceResult)(nil), "api.MaintenanceResult")
+	proto.RegisterType((*SetReadOnlyModeRequest)(nil), "api.SetReadOnlyModeRequest")
+	proto.RegisterType((*GetReadOnlyModeRequest)(nil), "api.GetReadOnlyModeRequest")
+	proto.RegisterType((*ReadOnlyMode)(nil), "api.ReadOnlyMode")
+	proto.RegisterType((*ComputeStorageUsageRequest)(nil), "api.ComputeStorageUsageRequest")
+	proto.RegisterType((*GetStorageUsageRequest)(nil), "api.GetStorageUsageRequest")
+	proto.RegisterType((*StorageUsage)(nil), "api.StorageUsage")
+	proto.RegisterType((*StorageUsageReport)(nil), "api.StorageUsageReport")
+	proto.RegisterType((*ListDeadLetterReportsRequest)(nil), "api.ListDeadLetterReportsRequest")
+	proto.RegisterType((*DeadLetterReport)(nil), "api.DeadLetterReport")
+	proto.RegisterType((*ListDeadLetterReportsResponse)(nil), "api.ListDeadLetterReportsResponse")
+	proto.RegisterType((*ReplayDeadLetterReportsRequest)(nil), "api.ReplayDeadLetterReportsRequest")
+	proto.RegisterType((*DeleteDeadLetterReportRequest)(nil), "api.DeleteDeadLetterReportRequest")
+}
+
+func init() { proto.RegisterFile("admin.proto", fileDescriptor_73a7fc70dcc2027c) }
+
+var fileDescriptor_73a7fc70dcc2027c = []byte{
+	// 1475 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdd, 0x72, 0xdb, 0x44,
+	0x14, 0xae, 0xed, 0xc4, 0xb1, 0x8f, 0x13, 0x62, 0x6f, 0xf3, 0x63, 0x1c, 0xa7, 0x71, 0x54, 0x5a,
+	0x42, 0xda, 0xda, 0x24, 0x1d, 0xca, 0x34, 0x5c, 0x39, 0x8e, 0x63, 0x4c, 0xf3, 0x37, 0xb2, 0x33,
+	0x9d, 0x81, 0x19, 0x3c, 0x1b, 0xe9, 0xc4, 0x11, 0xb5, 0x25, 0x21, 0xad, 0xda, 0xba, 0x4c, 0x6f,
+	0xb8, 0x80, 0xe1, 0x16, 0x86, 0x4b, 0x5e, 0x80, 0x17, 0xe0, 0x41, 0xb8, 0xe0, 0x05, 0x78, 0x03,
+	0x1e, 0x00, 0x66, 0x57, 0x2b, 0xdb, 0xb5, 0x65, 0xa7, 0x30, 0x5c, 0xd9, 0xe7, 0xec, 0xd9, 0xf3,
+	0xb3, 0xdf, 0xd9, 0xef, 0xac, 0x20, 0x45, 0xf5, 0xae, 0x61, 0x16, 0x6d, 0xc7, 0x62, 0x16, 0x89,
+	0x51, 0xdb, 0xc8, 0xe5, 0xdb, 0x96, 0xd5, 0xee, 0x60, 0x89, 0xda, 0x46, 0x89, 0x9a, 0xa6, 0xc5,
+	0x28, 0x33, 0x2c, 0xd3, 0xf5, 0x4d, 0x72, 0x6b, 0x72, 0x55, 0x48, 0x17, 0xde, 0x65, 0x09, 0xbb,
+	0x36, 0xeb, 0xc9, 0xc5, 0x8d, 0xd1, 0x45, 0x66, 0x74, 0xd1, 0x65, 0xb4, 0x6b, 0x4b, 0x83, 0xfb,
+	0xe2, 0x47, 0x7b, 0xd0, 0x46, 0xf3, 0x81, 0xfb, 0x82, 0xb6, 0xdb, 0xe8, 0x94, 0x2c, 0x5b, 0xf8,
+	0x1f, 0x8f, 0xa5, 0xec, 0xc0, 0x6a, 0xe5, 0x0a, 0xb5, 0x67, 0x15, 0xcb, 0x74, 0x0d, 0x97, 0xa1,
+	0xa9, 0xf5, 0x54, 0xfc, 0xda, 0x43, 0x97, 0x91, 0x15, 0x88, 0x3b, 0x68, 0x53, 0xc3, 0xc9, 0x46,
+	0x0a, 0x91, 0xad, 0x84, 0x2a, 0x25, 0x65, 0x1d, 0xd6, 0x6a, 0xc8, 0xde, 0xd8, 0x60, 0x5b, 0x0e,
+	0x93, 0xdb, 0x94, 0x5f, 0xa3, 0x90, 0x1e, 0x5a, 0xac, 0xbb, 0xae, 0x87, 0xa4, 0x08, 0x33, 0xac,
+	0x67, 0xa3, 0xf0, 0xf4, 0xce, 0x6e, 0xae, 0x48, 0x6d, 0xa3, 0x38, 0x6a, 0x54, 0x6c, 0xf6, 0x6c,
+	0x54, 0x85, 0x1d, 0xd9, 0x80, 0x94, 0x83, 0xae, 0xe5, 0x39, 0x1a, 0xb6, 0x0c, 0x3d, 0x1b, 0x2d,
+	0x44, 0xb6, 0x92, 0x2a, 0x04, 0xaa, 0xba, 0x4e, 0x0a, 0x90, 0xd2, 0xd1, 0xd5, 0x1c, 0x43, 0x54,
+	0x96, 0x8d, 0x09, 0x83, 0x61, 0x15, 0xc9, 0x41, 0xc2, 0x4f, 0x18, 0xf5, 0xec, 0x8c, 0x28, 0xa0,
+	0x2f, 0x93, 0x4d, 0x98, 0xf7, 0xff, 0xb7, 0xd0, 0x71, 0x2c, 0x27, 0x3b, 0xeb, 0x6f, 0xf7, 0x75,
+	0x55, 0xae, 0x52, 0x74, 0x98, 0xe1, 0xf9, 0x90, 0x45, 0x48, 0x9d, 0x9f, 0x34, 0xce, 0xaa, 0x95,
+	0xfa, 0x61, 0xbd, 0x7a, 0x90, 0xbe, 0x41, 0xb2, 0xb0, 0xa4, 0x9e, 0x9f, 0xb4, 0x9e, 0xd6, 0x9b,
+	0x9f, 0x9e, 0x9e, 0x37, 0x5b, 0xc7, 0xe5, 0x93, 0xfa, 0x61, 0xb5, 0xd1, 0x4c, 0x47, 0x48, 0x1e,
+	0xb2, 0x67, 0xf5, 0xb3, 0xea, 0x51, 0xfd, 0xa4, 0xda, 0x5f, 0x3e, 0x2b, 0x57, 0x9e, 0x94, 0x6b,
+	0xd5, 0x74, 0x94, 0xdc, 0x84, 0xc5, 0x83, 0xf2, 0x49, 0xed, 0xa8, 0x7e, 0x52, 0x6b, 0x9d, 0xee,
+	0x7f, 0x56, 0xad, 0x34, 0xd3, 0x31, 0xe5, 0xe7, 0x08, 0x64, 0xc6, 0x4e, 0x92, 0x3c, 0x06, 0xd0,
+	0x38, 0x28, 0xa8, 0xb7, 0x28, 0x13, 0x67, 0x96, 0xda, 0xcd, 0x15, 0x7d, 0xe0, 0x8b, 0x01, 0xf0,
+	0xc5, 0x66, 0x00, 0xbc, 0x9a, 0x94, 0xd6, 0xe5, 0x61, 0xd0, 0xa2, 0xc3, 0xa0, 0x91, 0x07, 0x10,
+	0x37, 0xf8, 0x21, 0xbb, 0xd9, 0x58, 0x21, 0xb6, 0x95, 0xda, 0x5d, 0x0e, 0x85, 0x40, 0x95, 0x46,
+	0x4a, 0x09, 0x56, 0x55, 0x34, 0x4c, 0x1d, 0x5f, 0x9e, 0x19, 0x36, 0x76, 0x0c, 0x13, 0xdd, 0xa0,
+	0x2d, 0x96, 0x60, 0x96, 0xba, 0x3d, 0x53, 0x93, 0x5d, 0xe1, 0x0b, 0xca, 0x43, 0x58, 0x53, 0x51,
+	0xb3, 0xba, 0xb6, 0xc7, 0x50, 0xf5, 0xcc, 0x06, 0xa3, 0xcc, 0x73, 0xaf, 0xdb, 0xb4, 0x04, 0xe4,
+	0xb0, 0xe3, 0xb9, 0x57, 0x15, 0xaa, 0x5d, 0xf5, 0x6d, 0x95, 0x0e, 0x64, 0x8e, 0xa9, 0x61, 0x32,
+	0x34, 0xa9, 0xa9, 0xa1, 0x8a, 0xae, 0xd7, 0x61, 0x24, 0x0f, 0x49, 0xdb, 0xb1, 0x34, 0x74, 0x5d,
+	0xd4, 0x85, 0x93, 0x59, 0x75, 0xa0, 0xe0, 0x55, 0x0b, 0x20, 0xdd, 0x6c, 0xb4, 0x10, 0xdb, 0x4a,
+	0xaa, 0x52, 0xe2, 0x38, 0x5b, 0x36, 0x3a, 0xa2, 0xe3, 0x79, 0x1f, 0xc9, 0x36, 0xe9, 0xeb, 0xea,
+	0xba, 0x72, 0x0a, 0x2b, 0x0d, 0x64, 0x2a, 0x52, 0xfd, 0xd4, 0xec, 0xf4, 0x8e, 0x2d, 0x1d, 0x83,
+	0x9c, 0xd7, 0x20, 0xe9, 0x20, 0xd5, 0x5b, 0x96, 0xd9, 0xe9, 0xc9, 0xbc, 0x13, 0x8e, 0xb4, 0x23,
+	0x59, 0x98, 0xeb, 0xa2, 0xeb, 0xd2, 0x36, 0xca, 0xe6, 0x0c, 0x44, 0x25, 0x0b, 0x2b, 0xb5, 0x50,
+	0x87, 0xca, 0x6f, 0x11, 0x98, 0x1f, 0xd6, 0xff, 0xc7, 0x08, 0xbc, 0xda, 0x4b, 0xcb, 0xd1, 0xd0,
+	0xaf, 0x27, 0xa1, 0x4a, 0x89, 0xb7, 0x8d, 0x67, 0xeb, 0x94, 0xf9, 0x6d, 0x33, 0x73, 0x7d, 0xdb,
+	0x48, 0xeb, 0x32, 0x23, 0xeb, 0x83, 0xad, 0x17, 0x3d, 0x79, 0x1d, 0x82, 0xe5, 0xfd, 0x9e, 0x92,
+	0x87, 0x5c, 0xc5, 0xc7, 0xb6, 0xc1, 0x2c, 0x87, 0xb6, 0xf1, 0x9c, 0x27, 0x12, 0xd4, 0xf5, 0x85,
+	0xa8, 0x38, 0x64, 0x85, 0xa3, 0x66, 0xd2, 0x2e, 0xba, 0x36, 0xd5, 0xfc, 0xbb, 0x9f, 0x54, 0x07,
+	0x0a, 0x72, 0x1b, 0x16, 0xf0, 0xa5, 0x8d, 0x8e, 0xd1, 0x45, 0x93, 0x0d, 0xae, 0xf9, 0xfc, 0x40,
+	0x59, 0xd7, 0x95, 0xbf, 0x23, 0x30, 0x3f, 0xec, 0xfa, 0x7f, 0xf0, 0x49, 0x1e, 0x41, 0x42, 0xa3,
+	0x0c, 0xdb, 0x96, 0xd3, 0xcb, 0xc6, 0x86, 0x18, 0x69, 0x38, 0x4e, 0xb1, 0x22, 0x2d, 0xd4, 0xbe,
+	0x2d, 0xef, 0xe2, 0x8b, 0x1e, 0x43, 0x57, 0x9c, 0x6d, 0x4c, 0xf5, 0x05, 0xe5, 0x4b, 0x48, 0x04,
+	0xb6, 0xe3, 0x6c, 0xb1, 0x0c, 0x99, 0x3e, 0x27, 0x48, 0x2e, 0x68, 0xa4, 0x23, 0x24, 0x03, 0x0b,
+	0x9c, 0x44, 0x02, 0xf2, 0x68, 0xa4, 0xa3, 0x64, 0x01, 0x92, 0x65, 0xb5, 0x59, 0x3f, 0x2c, 0x57,
+	0x9a, 0x8d, 0x74, 0x8c, 0x24, 0x60, 0xe6, 0xe8, 0xb4, 0xd6, 0x48, 0xcf, 0x28, 0xbf, 0x44, 0x80,
+	0xbc, 0x79, 0xb8, 0x82, 0x24, 0x3e, 0x81, 0x94, 0xbc, 0x6f, 0x6f, 0xc9, 0x12, 0x10, 0x98, 0x97,
+	0x19, 0xf9, 0x00, 0xe2, 0x1e, 0xf7, 0xe5, 0x5f, 0x98, 0xd4, 0x6e, 0x66, 0xac, 0x7e, 0x55, 0x1a,
+	0x70, 0x2a, 0x66, 0x16, 0xa3, 0x9d, 0x96, 0x5f, 0x7a, 0x4c, 0x94, 0x0e, 0x42, 0xb5, 0x2f, 0xea,
+	0x7f, 0x02, 0xf9, 0x23, 0xc3, 0x65, 0x07, 0x48, 0xf5, 0x23, 0x64, 0x0c, 0x1d, 0x3f, 0xc1, 0xfe,
+	0xdd, 0xbf, 0x07, 0x19, 0xc3, 0xd4, 0x3a, 0x9e, 0x8e, 0xad, 0x17, 0x96, 0xf3, 0xec, 0xb2, 0x63,
+	0xbd, 0x70, 0x65, 0xb7, 0xa7, 0xe5, 0xc2, 0xd3, 0x40, 0xaf, 0xfc, 0x15, 0x81, 0xf4, 0xa8, 0x27,
+	0xb2, 0x0c, 0x71, 0xc7, 0x13, 0x17, 0xd8, 0xc7, 0x7b, 0xd6, 0xf1, 0xcc, 0xba, 0xfe, 0x66, 0x27,
+	0x44, 0x43, 0x3a, 0x21, 0x08, 0xd7, 0xe2, 0x5a, 0x79, 0xf9, 0xe7, 0x03, 0xe5, 0x09, 0xed, 0x22,
+	0x1f, 0x12, 0x81, 0x2c, 0x40, 0x4d, 0xaa, 0x7d, 0x99, 0xa3, 0x3d, 0x3c, 0x1d, 0x7c, 0x81, 0xef,
+	0xa0, 0x8c, 0xf1, 0x89, 0xec, 0x66, 0xe3, 0x82, 0x87, 0xfa, 0x32, 0xf9, 0x18, 0x92, 0x97, 0xd4,
+	0xe8, 0xf8, 0x80, 0xcc, 0x5d, 0x0b, 0x48, 0xc2, 0x37, 0x2e, 0x33, 0xe5, 0x0c, 0xd6, 0x27, 0x1c,
+	0xa1, 0x6b, 0x5b, 0xa6, 0x8b, 0xa4, 0x04, 0x73, 0x8e, 0xaf, 0xca, 0x46, 0x86, 0xf8, 0x7b, 0x74,
+	0x83, 0x1a, 0x58, 0x29, 0x8f, 0xe1, 0x96, 0x8a, 0x76, 0x87, 0xf6, 0x26, 0xc2, 0xb2, 0x0a, 0x73,
+	0xfe, 0xa1, 0xfa, 0x2e, 0x93, 0x6a, 0x5c, 0x9c, 0xaa, 0xab, 0x3c, 0x82, 0xf5, 0x03, 0xec, 0x20,
+	0xc3, 0x31, 0xef, 0x72, 0x67, 0x38, 0x1c, 0xbb, 0x7f, 0xa4, 0x60, 0xbe, 0xcc, 0x5f, 0x3a, 0x0d,
+	0x74, 0x9e, 0x1b, 0x1a, 0x92, 0x57, 0x90, 0x1e, 0x7d, 0x5b, 0x90, 0xbc, 0x3f, 0x77, 0xc2, 0x9f,
+	0x1c, 0xb9, 0x95, 0xd1, 0xa9, 0xe4, 0x07, 0x56, 0x3e, 0xfc, 0xf6, 0xf7, 0x3f, 0x7f, 0x8a, 0x6e,
+	0x2b, 0x77, 0xf8, 0x8b, 0xc9, 0x2d, 0x3d, 0xdf, 0xb9, 0x40, 0x46, 0x77, 0x4a, 0xe2, 0x5d, 0x55,
+	0xd2, 0x06, 0xe6, 0x7b, 0x62, 0x10, 0xee, 0x45, 0xb6, 0x49, 0x0f, 0x96, 0xc2, 0x1e, 0x29, 0xa4,
+	0x20, 0x22, 0x4c, 0x79, 0xbf, 0x4c, 0xcc, 0xe1, 0x7d, 0x91, 0xc3, 0x26, 0xd9, 0xb8, 0x26, 0x07,
+	0x5e, 0xf6, 0xe8, 0xec, 0x94, 0x65, 0x4f, 0x18, 0xa9, 0x32, 0xe4, 0xd8, 0xd0, 0x9b, 0x5e, 0xb6,
+	0x1d, 0x78, 0xd9, 0x73, 0x7c, 0xb7, 0xbc, 0xec, 0xef, 0x23, 0xb0, 0x14, 0x36, 0x87, 0x65, 0xdd,
+	0x53, 0x46, 0xf4, 0xc4, 0x24, 0x3e, 0x12, 0x49, 0x94, 0x94, 0xed, 0xb0, 0x24, 0x1c, 0xcf, 0xe4,
+	0xf1, 0xb5, 0x60, 0x38, 0xf8, 0x2e, 0x79, 0x26, 0xcf, 0x20, 0x35, 0x34, 0xdb, 0xc9, 0xaa, 0xf0,
+	0x3e, 0x3e, 0xed, 0x27, 0x86, 0xbd, 0x27, 0xc2, 0xde, 0x51, 0x0a, 0xa1, 0xc7, 0x2d, 0x5c, 0xec,
+	0x5d, 0x72, 0x77, 0x3c, 0xd8, 0x57, 0xb0, 0x38, 0x32, 0xc4, 0xc9, 0x9a, 0xcf, 0x68, 0xa1, 0x93,
+	0x38, 0x97, 0x91, 0xa7, 0x31, 0x58, 0x09, 0xe0, 0x55, 0xf2, 0xa1, 0x65, 0x22, 0xd5, 0xf9, 0x90,
+	0xe6, 0xb1, 0xda, 0xb0, 0x58, 0x0b, 0x8d, 0x55, 0x7b, 0xeb, 0x58, 0xef, 0x89, 0x58, 0xb7, 0xc8,
+	0xd4, 0x58, 0xe4, 0x35, 0xdc, 0x0c, 0x19, 0xba, 0x64, 0x43, 0xf6, 0xe7, 0xa4, 0x71, 0x9c, 0x5b,
+	0x1d, 0xe7, 0x72, 0xbf, 0x83, 0xef, 0x8b, 0xb0, 0x77, 0x95, 0xcd, 0xb0, 0xb0, 0x82, 0xed, 0xf7,
+	0x24, 0x90, 0xf2, 0x4c, 0x47, 0xa6, 0xfa, 0xa0, 0xce, 0x7f, 0x15, 0x76, 0x53, 0x84, 0x5d, 0x23,
+	0xef, 0x4e, 0x0c, 0xcb, 0xdb, 0x76, 0x39, 0x94, 0x00, 0xc9, 0xa6, 0xf0, 0x3a, 0x6d, 0xbe, 0xe4,
+	0x94, 0x69, 0x26, 0x3e, 0x7f, 0x4e, 0xbf, 0xbc, 0x3a, 0x52, 0xbd, 0x23, 0xb6, 0xb9, 0xe4, 0x87,
+	0x08, 0xac, 0x4e, 0x20, 0x4e, 0x72, 0x5b, 0x22, 0x39, 0x8d, 0x56, 0x27, 0xf6, 0xf3, 0x8e, 0xc8,
+	0xe0, 0x9e, 0x72, 0xf7, 0x9a, 0x0c, 0xf6, 0x1c, 0xe1, 0x9f, 0x23, 0xf0, 0x5d, 0x04, 0x56, 0xc2,
+	0x99, 0x98, 0x28, 0x92, 0xfe, 0xa7, 0xd0, 0x74, 0x6e, 0x65, 0x6c, 0xf4, 0x54, 0xf9, 0x77, 0x64,
+	0xc0, 0x2a, 0xdb, 0x5b, 0xd7, 0x64, 0x52, 0xfa, 0xc6, 0x67, 0xf9, 0xd7, 0xfb, 0x67, 0x3f, 0x96,
+	0x8f, 0xd5, 0x3c, 0xcc, 0xe9, 0x78, 0x49, 0xf9, 0x63, 0x3c, 0x43, 0x16, 0x61, 0x21, 0x97, 0x92,
+	0x80, 0xf3, 0x3b, 0xff, 0xf9, 0x06, 0xac, 0x43, 0x7c, 0x1f, 0xa9, 0x83, 0x0e, 0xb9, 0x99, 0x88,
+	0xe6, 0x16, 0xa8, 0xc7, 0xae, 0x2c, 0xc7, 0x78, 0x25, 0xde, 0xd9, 0x85, 0xe8, 0xc5, 0x3c, 0x40,
+	0xdf, 0xe0, 0xc6, 0x45, 0x5c, 0xe4, 0xf4, 0xf0, 0x9f, 0x01, 0x00, 0x39, 0x90, 0x10, 0x38, 0x1b,
+	0x0f, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// Checks that the database and the object store are consistent, and repairs
+	// the issues found if requested. The check can be run periodically by the
+	// API server, or by a CronJob calling this method.
+	CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*ConsistencyReport, error)
+	// Returns the report of the last consistency check.
+	GetConsistencyReport(ctx context.Context, in *GetConsistencyReportRequest, opts ...grpc.CallOption) (*ConsistencyReport, error)
+	// Indexes the steps of the ready pipelines again from their templates.
+	ReindexPipelines(ctx context.Context, in *ReindexPipelinesRequest, opts ...grpc.CallOption) (*MaintenanceResult, error)
+	// Updates the status of the unfinished runs from their live workflows, e.g.
+	// after the persistence agent was down.
+	RecomputeRunStatuses(ctx context.Context, in *RecomputeRunStatusesRequest, opts ...grpc.CallOption) (*MaintenanceResult, error)
+	// Flushes the caches of the pipeline templates and of the namespace
+	// configurations of the API server replica serving the call.
+	FlushCaches(ctx context.Context, in *FlushCachesRequest, opts ...grpc.CallOption) (*MaintenanceResult, error)
+	// Turns the read-only mode on or off, on all the replicas of the API server.
+	// In read-only mode, the calls which write fail with FAILED_PRECONDITION and
+	// the maintenance message, while the reads keep working.
+	SetReadOnlyMode(ctx context.Context, in *SetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error)
+	// Returns whether the read-only mode is on.
+	GetReadOnlyMode(ctx context.Context, in *GetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error)
+	// Computes the bytes stored per namespace and experiment, e.g. for chargeback
+	// or quotas, and stores the report. The usage can be computed periodically by
+	// the API server, or by a CronJob calling this method.
+	ComputeStorageUsage(ctx context.Context, in *ComputeStorageUsageRequest, opts ...grpc.CallOption) (*StorageUsageReport, error)
+	// Returns the storage usage of the last computation, of a namespace or of an
+	// experiment if requested.
+	GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*StorageUsageReport, error)
+	// Lists the reports of workflows which the persistence agent sent but which
+	// couldn't be applied, e.g. because of bad data or a schema mismatch. A
+	// report is kept once it's invalid, or once it failed a number of times in a
+	// row, after which the persistence agent stops retrying it.
+	ListDeadLetterReports(ctx context.Context, in *ListDeadLetterReportsRequest, opts ...grpc.CallOption) (*ListDeadLetterReportsResponse, error)
+	// Applies the reports kept as dead letters again, e.g. once a fix is
+	// deployed. The reports applied are deleted, the others are kept with their
+	// new error.
+	ReplayDeadLetterReports(ctx context.Context, in *ReplayDeadLetterReportsRequest, opts ...grpc.CallOption) (*MaintenanceResult, error)
+	// Discards the report of a run kept as a dead letter.
+	DeleteDeadLetterReport(ctx context.Context, in *DeleteDeadLetterReportRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) CheckConsistency(ctx context.Context, in *CheckConsistencyRequest, opts ...grpc.CallOption) (*ConsistencyReport, error) {
+	out := new(ConsistencyReport)
+	err := c.cc.Invoke(ctx, "/api.AdminService/CheckConsistency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetConsistencyReport(ctx context.Context, in *GetConsistencyReportRequest, opts ...grpc.CallOption) (*ConsistencyReport, error) {
+	out := new(ConsistencyReport)
+	err := c.cc.Invoke(ctx, "/api.AdminService/GetConsistencyReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReindexPipelines(ctx context.Context, in *ReindexPipelinesRequest, opts ...grpc.CallOption) (*MaintenanceResult, error) {
+	out := new(MaintenanceResult)
+	err := c.cc.Invoke(ctx, "/api.AdminService/ReindexPipelines", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RecomputeRunStatuses(ctx context.Context, in *RecomputeRunStatusesRequest, opts ...grpc.CallOption) (*MaintenanceResult, error) {
+	out := new(MaintenanceResult)
+	err := c.cc.Invoke(ctx, "/api.AdminService/RecomputeRunStatuses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) FlushCaches(ctx context.Context, in *FlushCachesRequest, opts ...grpc.CallOption) (*MaintenanceResult, error) {
+	out := new(MaintenanceResult)
+	err := c.cc.Invoke(ctx, "/api.AdminService/FlushCaches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetReadOnlyMode(ctx context.Context, in *SetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error) {
+	out := new(ReadOnlyMode)
+	err := c.cc.Invoke(ctx, "/api.AdminService/SetReadOnlyMode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetReadOnlyMode(ctx context.Context, in *GetReadOnlyModeRequest, opts ...grpc.CallOption) (*ReadOnlyMode, error) {
+	out := new(ReadOnlyMode)
+	err := c.cc.Invoke(ctx, "/api.AdminService/GetReadOnlyMode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ComputeStorageUsage(ctx context.Context, in *ComputeStorageUsageRequest, opts ...grpc.CallOption) (*StorageUsageReport, error) {
+	out := new(StorageUsageReport)
+	err := c.cc.Invoke(ctx, "/api.AdminService/ComputeStorageUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetStorageUsage(ctx context.Context, in *GetStorageUsageRequest, opts ...grpc.CallOption) (*StorageUsageReport, error) {
+	out := new(StorageUsageReport)
+	err := c.cc.Invoke(ctx, "/api.AdminService/GetStorageUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListDeadLetterReports(ctx context.Context, in *ListDeadLetterReportsRequest, opts ...grpc.CallOption) (*ListDeadLetterReportsResponse, error) {
+	out := new(ListDeadLetterReportsResponse)
+	err := c.cc.Invoke(ctx, "/api.AdminService/ListDeadLetterReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReplayDeadLetterReports(ctx context.Context, in *ReplayDeadLetterReportsRequest, opts ...grpc.CallOption) (*MaintenanceResult, error) {
+	out := new(MaintenanceResult)
+	err := c.cc.Invoke(ctx, "/api.AdminService/ReplayDeadLetterReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteDeadLetterReport(ctx context.Context, in *DeleteDeadLetterReportRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.AdminService/DeleteDeadLetterReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// Checks that the database and the object store are consistent, and repairs
+	// the issues found if requested. The check can be run periodically by the
+	// API server, or by a CronJob calling this method.
+	CheckConsistency(context.Context, *CheckConsistencyRequest) (*ConsistencyReport, error)
+	// Returns the report of the last consistency check.
+	GetConsistencyReport(context.Context, *GetConsistencyReportRequest) (*ConsistencyReport, error)
+	// Indexes the steps of the ready pipelines again from their templates.
+	ReindexPipelines(context.Context, *ReindexPipelinesRequest) (*MaintenanceResult, error)
+	// Updates the status of the unfinished runs from their live workflows, e.g.
+	// after the persistence agent was down.
+	RecomputeRunStatuses(context.Context, *RecomputeRunStatusesRequest) (*MaintenanceResult, error)
+	// Flushes the caches of the pipeline templates and of the namespace
+	// configurations of the API server replica serving the call.
+	FlushCaches(context.Context, *FlushCachesRequest) (*MaintenanceResult, error)
+	// Turns the read-only mode on or off, on all the replicas of the API server.
+	// In read-only mode, the calls which write fail with FAILED_PRECONDITION and
+	// the maintenance message, while the reads keep working.
+	SetReadOnlyMode(context.Context, *SetReadOnlyModeRequest) (*ReadOnlyMode, error)
+	// Returns whether the read-only mode is on.
+	GetReadOnlyMode(context.Context, *GetReadOnlyModeRequest) (*ReadOnlyMode, error)
+	// Computes the bytes stored per namespace and experiment, e.g. for chargeback
+	// or quotas, and stores the report. The usage can be computed periodically by
+	// the API server, or by a CronJob calling this method.
+	ComputeStorageUsage(context.Context, *ComputeStorageUsageRequest) (*StorageUsageReport, error)
+	// Returns the storage usage of the last computation, of a namespace or of an
+	// experiment if requested.
+	GetStorageUsage(context.Context, *GetStorageUsageRequest) (*StorageUsageReport, error)
+	// Lists the reports of workflows which the persistence agent sent but which
+	// couldn't be applied, e.g. because of bad data or a schema mismatch. A
+	// report is kept once it's invalid, or once it failed a number of times in a
+	// row, after which the persistence agent stops retrying it.
+	ListDeadLetterReports(context.Context, *ListDeadLetterReportsRequest) (*ListDeadLetterReportsResponse, error)
+	// Applies the reports kept as dead letters again, e.g. once a fix is
+	// deployed. The reports applied are deleted, the others are kept with their
+	// new error.
+	ReplayDeadLetterReports(context.Context, *ReplayDeadLetterReportsRequest) (*MaintenanceResult, error)
+	// Discards the report of a run kept as a dead letter.
+	DeleteDeadLetterReport(context.Context, *DeleteDeadLetterReportRequest) (*empty.Empty, error)
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_CheckConsistency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckConsistencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CheckConsistency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/CheckConsistency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CheckConsistency(ctx, req.(*CheckConsistencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetConsistencyReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsistencyReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetConsistencyReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/GetConsistencyReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetConsistencyReport(ctx, req.(*GetConsistencyReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReindexPipelines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexPipelinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReindexPipelines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/ReindexPipelines",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReindexPipelines(ctx, req.(*ReindexPipelinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RecomputeRunStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecomputeRunStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RecomputeRunStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/RecomputeRunStatuses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RecomputeRunStatuses(ctx, req.(*RecomputeRunStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_FlushCaches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlushCachesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).FlushCaches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/FlushCaches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).FlushCaches(ctx, req.(*FlushCachesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetReadOnlyMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReadOnlyModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetReadOnlyMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/SetReadOnlyMode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetReadOnlyMode(ctx, req.(*SetReadOnlyModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetReadOnlyMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadOnlyModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetReadOnlyMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/GetReadOnlyMode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetReadOnlyMode(ctx, req.(*GetReadOnlyModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ComputeStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComputeStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ComputeStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/ComputeStorageUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ComputeStorageUsage(ctx, req.(*ComputeStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetStorageUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStorageUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetStorageUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/GetStorageUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetStorageUsage(ctx, req.(*GetStorageUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListDeadLetterReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLetterReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListDeadLetterReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/ListDeadLetterReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListDeadLetterReports(ctx, req.(*ListDeadLetterReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReplayDeadLetterReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLetterReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReplayDeadLetterReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/ReplayDeadLetterReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReplayDeadLetterReports(ctx, req.(*ReplayDeadLetterReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteDeadLetterReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeadLetterReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteDeadLetterReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.AdminService/DeleteDeadLetterReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteDeadLetterReport(ctx, req.(*DeleteDeadLetterReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CheckConsistency",
+			Handler:    _AdminService_CheckConsistency_Handler,
+		},
+		{
+			MethodName: "GetConsistencyReport",
+			Handler:    _AdminService_GetConsistencyReport_Handler,
+		},
+		{
+			MethodName: "ReindexPipelines",
+			Handler:    _AdminService_ReindexPipelines_Handler,
+		},
+		{
+			MethodName: "RecomputeRunStatuses",
+			Handler:    _AdminService_RecomputeRunStatuses_Handler,
+		},
+		{
+			MethodName: "FlushCaches",
+			Handler:    _AdminService_FlushCaches_Handler,
+		},
+		{
+			MethodName: "SetReadOnlyMode",
+			Handler:    _AdminService_SetReadOnlyMode_Handler,
+		},
+		{
+			MethodName: "GetReadOnlyMode",
+			Handler:    _AdminService_GetReadOnlyMode_Handler,
+		},
+		{
+			MethodName: "ComputeStorageUsage",
+			Handler:    _AdminService_ComputeStorageUsage_Handler,
+		},
+		{
+			MethodName: "GetStorageUsage",
+			Handler:    _AdminService_GetStorageUsage_Handler,
+		},
+		{
+			MethodName: "ListDeadLetterReports",
+			Handler:    _AdminService_ListDeadLetterReports_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetterReports",
+			Handler:    _AdminService_ReplayDeadLetterReports_Handler,
+		},
+		{
+			MethodName: "DeleteDeadLetterReport",
+			Handler:    _AdminService_DeleteDeadLetterReport_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
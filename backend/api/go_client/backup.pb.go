@@ -0,0 +1,208 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: backup.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type BackupManifest struct {
+	// The version of the archive format.
+	Version    int32                `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	ExportedAt *timestamp.Timestamp `protobuf:"bytes,2,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	// Whether the archive contains the runs. Their workflows aren't recreated by
+	// the import, the imported runs only keep their status and metrics.
+	IncludeRuns          bool     `protobuf:"varint,3,opt,name=include_runs,json=includeRuns,proto3" json:"include_runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupManifest) Reset()         { *m = BackupManifest{} }
+func (m *BackupManifest) String() string { return proto.CompactTextString(m) }
+func (*BackupManifest) ProtoMessage()    {}
+func (*BackupManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{0}
+}
+
+func (m *BackupManifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupManifest.Unmarshal(m, b)
+}
+func (m *BackupManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupManifest.Marshal(b, m, deterministic)
+}
+func (m *BackupManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupManifest.Merge(m, src)
+}
+func (m *BackupManifest) XXX_Size() int {
+	return xxx_messageInfo_BackupManifest.Size(m)
+}
+func (m *BackupManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupManifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupManifest proto.InternalMessageInfo
+
+func (m *BackupManifest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *BackupManifest) GetExportedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExportedAt
+	}
+	return nil
+}
+
+func (m *BackupManifest) GetIncludeRuns() bool {
+	if m != nil {
+		return m.IncludeRuns
+	}
+	return false
+}
+
+// The resources are imported with new IDs. The references between them are
+// remapped to the new IDs.
+type ImportBackupResponse struct {
+	// The IDs of the imported resources, by their IDs in the archive.
+	PipelineIds   map[string]string `protobuf:"bytes,1,rep,name=pipeline_ids,json=pipelineIds,proto3" json:"pipeline_ids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExperimentIds map[string]string `protobuf:"bytes,2,rep,name=experiment_ids,json=experimentIds,proto3" json:"experiment_ids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	JobIds        map[string]string `protobuf:"bytes,3,rep,name=job_ids,json=jobIds,proto3" json:"job_ids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	RunIds        map[string]string `protobuf:"bytes,4,rep,name=run_ids,json=runIds,proto3" json:"run_ids,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The resources which couldn't be imported. The import continues after them.
+	Errors               []string `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportBackupResponse) Reset()         { *m = ImportBackupResponse{} }
+func (m *ImportBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportBackupResponse) ProtoMessage()    {}
+func (*ImportBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{1}
+}
+
+func (m *ImportBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportBackupResponse.Unmarshal(m, b)
+}
+func (m *ImportBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportBackupResponse.Merge(m, src)
+}
+func (m *ImportBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportBackupResponse.Size(m)
+}
+func (m *ImportBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportBackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportBackupResponse proto.InternalMessageInfo
+
+func (m *ImportBackupResponse) GetPipelineIds() map[string]string {
+	if m != nil {
+		return m.PipelineIds
+	}
+	return nil
+}
+
+func (m *ImportBackupResponse) GetExperimentIds() map[string]string {
+	if m != nil {
+		return m.ExperimentIds
+	}
+	return nil
+}
+
+func (m *ImportBackupResponse) GetJobIds() map[string]string {
+	if m != nil {
+		return m.JobIds
+	}
+	return nil
+}
+
+func (m *ImportBackupResponse) GetRunIds() map[string]string {
+	if m != nil {
+		return m.RunIds
+	}
+	return nil
+}
+
+func (m *ImportBackupResponse) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BackupManifest)(nil), "api.BackupManifest")
+	proto.RegisterType((*ImportBackupResponse)(nil), "api.ImportBackupResponse")
+	proto.RegisterMapType((map[string]string)(nil), "api.ImportBackupResponse.ExperimentIdsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "api.ImportBackupResponse.JobIdsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "api.ImportBackupResponse.PipelineIdsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "api.ImportBackupResponse.RunIdsEntry")
+}
+
+func init() { proto.RegisterFile("backup.proto", fileDescriptor_65240d19de191688) }
+
+var fileDescriptor_65240d19de191688 = []byte{
+	// 372 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xdd, 0xaa, 0xda, 0x40,
+	0x10, 0xc7, 0x89, 0x39, 0x7a, 0xea, 0xc4, 0x1e, 0x0e, 0x8b, 0x94, 0x90, 0x9b, 0xa6, 0x42, 0x21,
+	0x94, 0x12, 0xc1, 0xde, 0xf4, 0x03, 0xa4, 0x2d, 0x78, 0x61, 0x41, 0x28, 0xdb, 0xde, 0x4b, 0x62,
+	0x46, 0x59, 0x4d, 0x76, 0x97, 0xfd, 0x10, 0x7d, 0x85, 0x3e, 0x5a, 0x9f, 0xaa, 0xb8, 0x26, 0x2a,
+	0x6d, 0xe5, 0xe0, 0x5d, 0x66, 0xf8, 0xff, 0x7e, 0x93, 0xc9, 0x04, 0x7a, 0x79, 0xb6, 0xd8, 0x58,
+	0x99, 0x4a, 0x25, 0x8c, 0x20, 0x7e, 0x26, 0x59, 0xf4, 0x72, 0x25, 0xc4, 0xaa, 0xc4, 0xa1, 0x6b,
+	0xe5, 0x76, 0x39, 0x34, 0xac, 0x42, 0x6d, 0xb2, 0xaa, 0x4e, 0x0d, 0x7e, 0x79, 0xf0, 0xf0, 0xd5,
+	0x61, 0xb3, 0x8c, 0xb3, 0x25, 0x6a, 0x43, 0x42, 0xb8, 0xdf, 0xa2, 0xd2, 0x4c, 0xf0, 0xd0, 0x8b,
+	0xbd, 0xa4, 0x4d, 0x9b, 0x92, 0x7c, 0x82, 0x00, 0x77, 0x52, 0x28, 0x83, 0xc5, 0x3c, 0x33, 0x61,
+	0x2b, 0xf6, 0x92, 0x60, 0x14, 0xa5, 0xc7, 0x19, 0x69, 0x33, 0x23, 0xfd, 0xd9, 0xcc, 0xa0, 0xd0,
+	0xc4, 0xbf, 0x18, 0xf2, 0x0a, 0x7a, 0x8c, 0x2f, 0x4a, 0x5b, 0xe0, 0x5c, 0x59, 0xae, 0x43, 0x3f,
+	0xf6, 0x92, 0x67, 0x34, 0xa8, 0x7b, 0xd4, 0x72, 0x3d, 0xf8, 0x7d, 0x07, 0xfd, 0x69, 0x75, 0x20,
+	0x8e, 0xaf, 0x44, 0x51, 0x4b, 0xc1, 0x35, 0x92, 0x19, 0xf4, 0x24, 0x93, 0x58, 0x32, 0x8e, 0x73,
+	0x56, 0xe8, 0xd0, 0x8b, 0xfd, 0x24, 0x18, 0xbd, 0x49, 0x33, 0xc9, 0xd2, 0xff, 0x01, 0xe9, 0xf7,
+	0x3a, 0x3d, 0x2d, 0xf4, 0x84, 0x1b, 0xb5, 0xa7, 0x81, 0x3c, 0x77, 0xc8, 0x0f, 0x78, 0xc0, 0x9d,
+	0x44, 0xc5, 0x2a, 0xe4, 0xc6, 0x09, 0x5b, 0x4e, 0xf8, 0xf6, 0xba, 0x70, 0x72, 0xca, 0x9f, 0x94,
+	0xcf, 0xf1, 0xb2, 0x47, 0xc6, 0x70, 0xbf, 0x16, 0xb9, 0xb3, 0xf9, 0xce, 0xf6, 0xfa, 0xba, 0xed,
+	0x9b, 0xc8, 0x4f, 0x9a, 0xce, 0x5a, 0xe4, 0x35, 0xaf, 0x2c, 0x77, 0xfc, 0xdd, 0x53, 0x3c, 0xb5,
+	0xfc, 0xcc, 0x2b, 0x57, 0x90, 0x17, 0xd0, 0x41, 0xa5, 0x84, 0xd2, 0x61, 0x3b, 0xf6, 0x93, 0x2e,
+	0xad, 0xab, 0x68, 0x0c, 0x8f, 0x7f, 0x7f, 0x0d, 0xf2, 0x08, 0xfe, 0x06, 0xf7, 0xee, 0xbc, 0x5d,
+	0x7a, 0x78, 0x24, 0x7d, 0x68, 0x6f, 0xb3, 0xd2, 0xa2, 0x3b, 0x6a, 0x97, 0x1e, 0x8b, 0x8f, 0xad,
+	0xf7, 0x5e, 0xf4, 0x19, 0xc8, 0xbf, 0xcb, 0xdf, 0x64, 0xf8, 0x00, 0xc1, 0xc5, 0xc2, 0xb7, 0xa2,
+	0x17, 0xbb, 0xde, 0x82, 0xe6, 0x1d, 0xf7, 0x3f, 0xbe, 0xfb, 0x33, 0x00, 0xa6, 0x34, 0x2d, 0x20,
+	0x16, 0x03, 0x00, 0x00,
+}
@@ -0,0 +1,545 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: execution_target.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type CreateExecutionTargetRequest struct {
+	ExecutionTarget      *ExecutionTarget `protobuf:"bytes,1,opt,name=execution_target,json=executionTarget,proto3" json:"execution_target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *CreateExecutionTargetRequest) Reset()         { *m = CreateExecutionTargetRequest{} }
+func (m *CreateExecutionTargetRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateExecutionTargetRequest) ProtoMessage()    {}
+func (*CreateExecutionTargetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f5cb66a5b7278fa, []int{0}
+}
+
+func (m *CreateExecutionTargetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateExecutionTargetRequest.Unmarshal(m, b)
+}
+func (m *CreateExecutionTargetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateExecutionTargetRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateExecutionTargetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateExecutionTargetRequest.Merge(m, src)
+}
+func (m *CreateExecutionTargetRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateExecutionTargetRequest.Size(m)
+}
+func (m *CreateExecutionTargetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateExecutionTargetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateExecutionTargetRequest proto.InternalMessageInfo
+
+func (m *CreateExecutionTargetRequest) GetExecutionTarget() *ExecutionTarget {
+	if m != nil {
+		return m.ExecutionTarget
+	}
+	return nil
+}
+
+type GetExecutionTargetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetExecutionTargetRequest) Reset()         { *m = GetExecutionTargetRequest{} }
+func (m *GetExecutionTargetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetExecutionTargetRequest) ProtoMessage()    {}
+func (*GetExecutionTargetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f5cb66a5b7278fa, []int{1}
+}
+
+func (m *GetExecutionTargetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExecutionTargetRequest.Unmarshal(m, b)
+}
+func (m *GetExecutionTargetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExecutionTargetRequest.Marshal(b, m, deterministic)
+}
+func (m *GetExecutionTargetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExecutionTargetRequest.Merge(m, src)
+}
+func (m *GetExecutionTargetRequest) XXX_Size() int {
+	return xxx_messageInfo_GetExecutionTargetRequest.Size(m)
+}
+func (m *GetExecutionTargetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExecutionTargetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExecutionTargetRequest proto.InternalMessageInfo
+
+func (m *GetExecutionTargetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListExecutionTargetsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListExecutionTargetsRequest) Reset()         { *m = ListExecutionTargetsRequest{} }
+func (m *ListExecutionTargetsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListExecutionTargetsRequest) ProtoMessage()    {}
+func (*ListExecutionTargetsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f5cb66a5b7278fa, []int{2}
+}
+
+func (m *ListExecutionTargetsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExecutionTargetsRequest.Unmarshal(m, b)
+}
+func (m *ListExecutionTargetsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExecutionTargetsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListExecutionTargetsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExecutionTargetsRequest.Merge(m, src)
+}
+func (m *ListExecutionTargetsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListExecutionTargetsRequest.Size(m)
+}
+func (m *ListExecutionTargetsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExecutionTargetsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExecutionTargetsRequest proto.InternalMessageInfo
+
+type ListExecutionTargetsResponse struct {
+	ExecutionTargets     []*ExecutionTarget `protobuf:"bytes,1,rep,name=execution_targets,json=executionTargets,proto3" json:"execution_targets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ListExecutionTargetsResponse) Reset()         { *m = ListExecutionTargetsResponse{} }
+func (m *ListExecutionTargetsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListExecutionTargetsResponse) ProtoMessage()    {}
+func (*ListExecutionTargetsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f5cb66a5b7278fa, []int{3}
+}
+
+func (m *ListExecutionTargetsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListExecutionTargetsResponse.Unmarshal(m, b)
+}
+func (m *ListExecutionTargetsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListExecutionTargetsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListExecutionTargetsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListExecutionTargetsResponse.Merge(m, src)
+}
+func (m *ListExecutionTargetsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListExecutionTargetsResponse.Size(m)
+}
+func (m *ListExecutionTargetsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListExecutionTargetsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListExecutionTargetsResponse proto.InternalMessageInfo
+
+func (m *ListExecutionTargetsResponse) GetExecutionTargets() []*ExecutionTarget {
+	if m != nil {
+		return m.ExecutionTargets
+	}
+	return nil
+}
+
+type DeleteExecutionTargetRequest struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteExecutionTargetRequest) Reset()         { *m = DeleteExecutionTargetRequest{} }
+func (m *DeleteExecutionTargetRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteExecutionTargetRequest) ProtoMessage()    {}
+func (*DeleteExecutionTargetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f5cb66a5b7278fa, []int{4}
+}
+
+func (m *DeleteExecutionTargetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteExecutionTargetRequest.Unmarshal(m, b)
+}
+func (m *DeleteExecutionTargetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteExecutionTargetRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteExecutionTargetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteExecutionTargetRequest.Merge(m, src)
+}
+func (m *DeleteExecutionTargetRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteExecutionTargetRequest.Size(m)
+}
+func (m *DeleteExecutionTargetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteExecutionTargetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteExecutionTargetRequest proto.InternalMessageInfo
+
+func (m *DeleteExecutionTargetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ExecutionTarget struct {
+	// Required input field. Unique name of the target, referenced by the runs.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional input field. Describes the target.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Required input field. The namespace of the remote cluster the workflows
+	// are created in. A persistence agent of that namespace reports their status.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Required input field. The Secret of the namespace of the API server which
+	// holds the kubeconfig of the remote cluster. It must be labeled
+	// pipelines.kubeflow.org/executionTargetKubeconfig=true.
+	KubeconfigSecretName string `protobuf:"bytes,4,opt,name=kubeconfig_secret_name,json=kubeconfigSecretName,proto3" json:"kubeconfig_secret_name,omitempty"`
+	// Optional input field. The key of the kubeconfig in the Secret. "kubeconfig"
+	// by default.
+	KubeconfigSecretKey string `protobuf:"bytes,5,opt,name=kubeconfig_secret_key,json=kubeconfigSecretKey,proto3" json:"kubeconfig_secret_key,omitempty"`
+	// Output. The time that the target was created.
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ExecutionTarget) Reset()         { *m = ExecutionTarget{} }
+func (m *ExecutionTarget) String() string { return proto.CompactTextString(m) }
+func (*ExecutionTarget) ProtoMessage()    {}
+func (*ExecutionTarget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7f5cb66a5b7278fa, []int{5}
+}
+
+func (m *ExecutionTarget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionTarget.Unmarshal(m, b)
+}
+func (m *ExecutionTarget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionTarget.Marshal(b, m, deterministic)
+}
+func (m *ExecutionTarget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionTarget.Merge(m, src)
+}
+func (m *ExecutionTarget) XXX_Size() int {
+	return xxx_messageInfo_ExecutionTarget.Size(m)
+}
+func (m *ExecutionTarget) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionTarget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionTarget proto.InternalMessageInfo
+
+func (m *ExecutionTarget) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExecutionTarget) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ExecutionTarget) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ExecutionTarget) GetKubeconfigSecretName() string {
+	if m != nil {
+		return m.KubeconfigSecretName
+	}
+	return ""
+}
+
+func (m *ExecutionTarget) GetKubeconfigSecretKey() string {
+	if m != nil {
+		return m.KubeconfigSecretKey
+	}
+	return ""
+}
+
+func (m *ExecutionTarget) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CreateExecutionTargetRequest)(nil), "api.CreateExecutionTargetRequest")
+	proto.RegisterType((*GetExecutionTargetRequest)(nil), "api.GetExecutionTargetRequest")
+	proto.RegisterType((*ListExecutionTargetsRequest)(nil), "api.ListExecutionTargetsRequest")
+	proto.RegisterType((*ListExecutionTargetsResponse)(nil), "api.ListExecutionTargetsResponse")
+	proto.RegisterType((*DeleteExecutionTargetRequest)(nil), "api.DeleteExecutionTargetRequest")
+	proto.RegisterType((*ExecutionTarget)(nil), "api.ExecutionTarget")
+}
+
+func init() { proto.RegisterFile("execution_target.proto", fileDescriptor_7f5cb66a5b7278fa) }
+
+var fileDescriptor_7f5cb66a5b7278fa = []byte{
+	// 571 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x5c,
+	0x10, 0xfd, 0x9c, 0xe6, 0x0b, 0x64, 0x42, 0x95, 0xf6, 0x36, 0x89, 0x8c, 0xeb, 0x92, 0xc4, 0x0b,
+	0xa8, 0x10, 0xb5, 0xd5, 0x94, 0x4d, 0xd9, 0xa0, 0x14, 0x2a, 0x16, 0xfc, 0x08, 0x25, 0x5d, 0xb1,
+	0x89, 0x6e, 0x9c, 0x89, 0xb1, 0x9a, 0xd8, 0xc6, 0xf7, 0xba, 0x90, 0x22, 0x36, 0x15, 0x2b, 0xd8,
+	0xc1, 0x7b, 0xf0, 0x32, 0xbc, 0x02, 0x0f, 0x82, 0x3c, 0x71, 0x28, 0xd8, 0x4e, 0x0a, 0x2b, 0xdb,
+	0xf7, 0x9c, 0x99, 0x39, 0x9e, 0x39, 0x73, 0xa1, 0x81, 0xef, 0xd0, 0x8e, 0xa4, 0xeb, 0x7b, 0x03,
+	0xc9, 0x43, 0x07, 0xa5, 0x19, 0x84, 0xbe, 0xf4, 0xd9, 0x1a, 0x0f, 0x5c, 0x4d, 0x77, 0x7c, 0xdf,
+	0x99, 0xa0, 0xc5, 0x03, 0xd7, 0xe2, 0x9e, 0xe7, 0x4b, 0x1e, 0x13, 0xc5, 0x9c, 0xa2, 0x6d, 0x27,
+	0x28, 0x7d, 0x0d, 0xa3, 0xb1, 0x85, 0xd3, 0x40, 0xce, 0x12, 0xb0, 0x99, 0x06, 0xa5, 0x3b, 0x45,
+	0x21, 0xf9, 0x34, 0x48, 0x08, 0xf7, 0xe8, 0x61, 0xef, 0x39, 0xe8, 0xed, 0x89, 0xb7, 0xdc, 0x71,
+	0x30, 0xb4, 0xfc, 0x80, 0xf2, 0x67, 0x6b, 0x19, 0x03, 0xd0, 0x1f, 0x85, 0xc8, 0x25, 0x1e, 0x2f,
+	0xe4, 0x9e, 0x90, 0xda, 0x1e, 0xbe, 0x89, 0x50, 0x48, 0xf6, 0x10, 0x36, 0xd2, 0x3f, 0xa2, 0x2a,
+	0x2d, 0x65, 0xb7, 0xd2, 0xa9, 0x99, 0x3c, 0x70, 0xcd, 0x74, 0x58, 0x15, 0xff, 0x3c, 0x30, 0x2c,
+	0xb8, 0xf9, 0x04, 0xe5, 0x92, 0xec, 0x0c, 0x8a, 0x1e, 0x9f, 0x22, 0x65, 0x2c, 0xf7, 0xe8, 0xdd,
+	0xd8, 0x81, 0xed, 0x67, 0xae, 0x48, 0x47, 0x88, 0x24, 0xc4, 0xe0, 0xa0, 0xe7, 0xc3, 0x22, 0xf0,
+	0x3d, 0x81, 0xac, 0x0b, 0x9b, 0x69, 0xc1, 0x42, 0x55, 0x5a, 0x6b, 0x4b, 0x15, 0x6f, 0xa4, 0x14,
+	0x0b, 0xa3, 0x03, 0xfa, 0x63, 0x9c, 0xa0, 0xc4, 0x7f, 0x50, 0xfd, 0xa9, 0x00, 0xd5, 0x14, 0x3d,
+	0x8f, 0xc7, 0x5a, 0x50, 0x19, 0xa1, 0xb0, 0x43, 0x97, 0x26, 0xa2, 0x16, 0x08, 0xfa, 0xfd, 0x88,
+	0xe9, 0x50, 0x8e, 0x99, 0x22, 0xe0, 0x36, 0xaa, 0x6b, 0x84, 0x5f, 0x1e, 0xb0, 0xfb, 0xd0, 0x38,
+	0x8d, 0x86, 0x68, 0xfb, 0xde, 0xd8, 0x75, 0x06, 0x02, 0xed, 0x10, 0xe5, 0x80, 0xaa, 0x14, 0x89,
+	0x5a, 0xbb, 0x44, 0xfb, 0x04, 0xbe, 0x88, 0xab, 0x76, 0xa0, 0x9e, 0x8d, 0x3a, 0xc5, 0x99, 0xfa,
+	0x3f, 0x05, 0x6d, 0xa5, 0x83, 0x9e, 0xe2, 0x8c, 0x1d, 0x02, 0xd8, 0xe4, 0x8c, 0xd1, 0x80, 0x4b,
+	0xb5, 0x44, 0x33, 0xd7, 0xcc, 0xb9, 0xfb, 0xcc, 0x85, 0xfb, 0xcc, 0x93, 0x85, 0xfb, 0x7a, 0xe5,
+	0x84, 0xdd, 0x95, 0x9d, 0x6f, 0x45, 0x68, 0xa4, 0x9a, 0xd1, 0xc7, 0xf0, 0xcc, 0xb5, 0x91, 0x7d,
+	0x56, 0xa0, 0x9e, 0x6b, 0x38, 0xd6, 0xa6, 0xe9, 0xac, 0x32, 0xa3, 0x96, 0x3b, 0x40, 0xe3, 0xf0,
+	0xe2, 0xfb, 0x8f, 0xaf, 0x85, 0x03, 0xa3, 0x19, 0xaf, 0x93, 0xb0, 0xce, 0xf6, 0x87, 0x28, 0xf9,
+	0xbe, 0x95, 0x71, 0xc1, 0x83, 0x8c, 0x93, 0xd9, 0x39, 0xb0, 0xac, 0x39, 0xd9, 0x2d, 0x2a, 0xb3,
+	0xd4, 0xb5, 0x4b, 0x64, 0x98, 0x24, 0x63, 0x97, 0xdd, 0xbe, 0x42, 0x86, 0xf5, 0x3e, 0x1e, 0xd7,
+	0x07, 0xf6, 0x51, 0x81, 0x5a, 0x9e, 0x93, 0x59, 0x8b, 0xd2, 0xaf, 0xd8, 0x01, 0xad, 0xbd, 0x82,
+	0x31, 0x5f, 0x03, 0xe3, 0x0e, 0xa9, 0x69, 0xb3, 0xab, 0x9a, 0xc2, 0x2e, 0x14, 0xa8, 0xe7, 0xba,
+	0x3d, 0x19, 0xc8, 0xaa, 0x4d, 0xd0, 0x1a, 0x19, 0x3f, 0x1c, 0xc7, 0x57, 0xd5, 0xa2, 0x17, 0x77,
+	0xff, 0xb2, 0x17, 0x47, 0x2f, 0xbf, 0x74, 0x9f, 0xf7, 0x74, 0xb8, 0x36, 0xc2, 0x31, 0x8f, 0x26,
+	0x92, 0x6d, 0xb2, 0x2a, 0xac, 0x6b, 0x15, 0x12, 0xd0, 0x97, 0x5c, 0x46, 0xe2, 0x55, 0x13, 0x76,
+	0xa0, 0x74, 0x84, 0x3c, 0xc4, 0x90, 0x6d, 0x5d, 0x2f, 0x68, 0xeb, 0x3c, 0x92, 0xaf, 0xfd, 0xd0,
+	0x3d, 0xa7, 0xeb, 0xac, 0x55, 0x18, 0xde, 0x00, 0xf8, 0x45, 0xf8, 0x6f, 0x58, 0x22, 0x45, 0x07,
+	0x3f, 0x07, 0x00, 0x19, 0xe7, 0x80, 0xd1, 0x87, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ExecutionTargetServiceClient is the client API for ExecutionTargetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ExecutionTargetServiceClient interface {
+	// Registers a namespace of a remote cluster the runs can be dispatched to.
+	CreateExecutionTarget(ctx context.Context, in *CreateExecutionTargetRequest, opts ...grpc.CallOption) (*ExecutionTarget, error)
+	GetExecutionTarget(ctx context.Context, in *GetExecutionTargetRequest, opts ...grpc.CallOption) (*ExecutionTarget, error)
+	ListExecutionTargets(ctx context.Context, in *ListExecutionTargetsRequest, opts ...grpc.CallOption) (*ListExecutionTargetsResponse, error)
+	// Deletes an execution target. It fails while runs dispatched to the target
+	// haven't finished.
+	DeleteExecutionTarget(ctx context.Context, in *DeleteExecutionTargetRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type executionTargetServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewExecutionTargetServiceClient(cc *grpc.ClientConn) ExecutionTargetServiceClient {
+	return &executionTargetServiceClient{cc}
+}
+
+func (c *executionTargetServiceClient) CreateExecutionTarget(ctx context.Context, in *CreateExecutionTargetRequest, opts ...grpc.CallOption) (*ExecutionTarget, error) {
+	out := new(ExecutionTarget)
+	err := c.cc.Invoke(ctx, "/api.ExecutionTargetService/CreateExecutionTarget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionTargetServiceClient) GetExecutionTarget(ctx context.Context, in *GetExecutionTargetRequest, opts ...grpc.CallOption) (*ExecutionTarget, error) {
+	out := new(ExecutionTarget)
+	err := c.cc.Invoke(ctx, "/api.ExecutionTargetService/GetExecutionTarget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionTargetServiceClient) ListExecutionTargets(ctx context.Context, in *ListExecutionTargetsRequest, opts ...grpc.CallOption) (*ListExecutionTargetsResponse, error) {
+	out := new(ListExecutionTargetsResponse)
+	err := c.cc.Invoke(ctx, "/api.ExecutionTargetService/ListExecutionTargets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executionTargetServiceClient) DeleteExecutionTarget(ctx context.Context, in *DeleteExecutionTargetRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.ExecutionTargetService/DeleteExecutionTarget", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExecutionTargetServiceServer is the server API for ExecutionTargetService service.
+type ExecutionTargetServiceServer interface {
+	// Registers a namespace of a remote cluster the runs can be dispatched to.
+	CreateExecutionTarget(context.Context, *CreateExecutionTargetRequest) (*ExecutionTarget, error)
+	GetExecutionTarget(context.Context, *GetExecutionTargetRequest) (*ExecutionTarget, error)
+	ListExecutionTargets(context.Context, *ListExecutionTargetsRequest) (*ListExecutionTargetsResponse, error)
+	// Deletes an execution target. It fails while runs dispatched to the target
+	// haven't finished.
+	DeleteExecutionTarget(context.Context, *DeleteExecutionTargetRequest) (*empty.Empty, error)
+}
+
+func RegisterExecutionTargetServiceServer(s *grpc.Server, srv ExecutionTargetServiceServer) {
+	s.RegisterService(&_ExecutionTargetService_serviceDesc, srv)
+}
+
+func _ExecutionTargetService_CreateExecutionTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExecutionTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionTargetServiceServer).CreateExecutionTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ExecutionTargetService/CreateExecutionTarget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionTargetServiceServer).CreateExecutionTarget(ctx, req.(*CreateExecutionTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionTargetService_GetExecutionTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionTargetServiceServer).GetExecutionTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ExecutionTargetService/GetExecutionTarget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionTargetServiceServer).GetExecutionTarget(ctx, req.(*GetExecutionTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionTargetService_ListExecutionTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExecutionTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionTargetServiceServer).ListExecutionTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ExecutionTargetService/ListExecutionTargets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionTargetServiceServer).ListExecutionTargets(ctx, req.(*ListExecutionTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExecutionTargetService_DeleteExecutionTarget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExecutionTargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutionTargetServiceServer).DeleteExecutionTarget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ExecutionTargetService/DeleteExecutionTarget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutionTargetServiceServer).DeleteExecutionTarget(ctx, req.(*DeleteExecutionTargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ExecutionTargetService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.ExecutionTargetService",
+	HandlerType: (*ExecutionTargetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateExecutionTarget",
+			Handler:    _ExecutionTargetService_CreateExecutionTarget_Handler,
+		},
+		{
+			MethodName: "GetExecutionTarget",
+			Handler:    _ExecutionTargetService_GetExecutionTarget_Handler,
+		},
+		{
+			MethodName: "ListExecutionTargets",
+			Handler:    _ExecutionTargetService_ListExecutionTargets_Handler,
+		},
+		{
+			MethodName: "DeleteExecutionTarget",
+			Handler:    _ExecutionTargetService_DeleteExecutionTarget_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "execution_target.proto",
+}
@@ -122,10 +122,13 @@ type ListExperimentsRequest struct {
 	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// Can be format of "field_name", "field_name asc" or "field_name des"
 	// Ascending by default.
-	SortBy               string   `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SortBy string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// Lists only the experiments with a matching name, e.g.
+	// name_filter.name=xgboost&name_filter.match=PREFIX
+	NameFilter           *NameFilter `protobuf:"bytes,4,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ListExperimentsRequest) Reset()         { *m = ListExperimentsRequest{} }
@@ -174,6 +177,13 @@ func (m *ListExperimentsRequest) GetSortBy() string {
 	return ""
 }
 
+func (m *ListExperimentsRequest) GetNameFilter() *NameFilter {
+	if m != nil {
+		return m.NameFilter
+	}
+	return nil
+}
+
 type ListExperimentsResponse struct {
 	Experiments          []*Experiment `protobuf:"bytes,1,rep,name=experiments,proto3" json:"experiments,omitempty"`
 	NextPageToken        string        `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
@@ -299,40 +309,42 @@ func init() {
 func init() { proto.RegisterFile("experiment.proto", fileDescriptor_7daedc28b4b25757) }
 
 var fileDescriptor_7daedc28b4b25757 = []byte{
-	// 528 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x25, 0x4e, 0x49, 0x9b, 0x09, 0x69, 0xda, 0x01, 0x91, 0xd4, 0x49, 0xa9, 0xf1, 0x21, 0xf4,
-	0x40, 0x6c, 0xa5, 0x9c, 0xe0, 0xd6, 0x20, 0x84, 0x84, 0x40, 0xaa, 0x92, 0x9e, 0xb8, 0x44, 0x9b,
-	0x78, 0x6a, 0x56, 0x34, 0x5e, 0xb3, 0xbb, 0x2e, 0x6d, 0x10, 0x17, 0xee, 0x5c, 0xe0, 0xa7, 0x71,
-	0xe3, 0xcc, 0x0f, 0x41, 0xde, 0x38, 0x8d, 0x9b, 0x8f, 0x53, 0xb2, 0xb3, 0x6f, 0xdf, 0xbc, 0x99,
-	0xf7, 0x0c, 0x7b, 0x74, 0x1d, 0x93, 0xe4, 0x13, 0x8a, 0xb4, 0x17, 0x4b, 0xa1, 0x05, 0x16, 0x59,
-	0xcc, 0xed, 0x56, 0x28, 0x44, 0x78, 0x49, 0x3e, 0x8b, 0xb9, 0xcf, 0xa2, 0x48, 0x68, 0xa6, 0xb9,
-	0x88, 0xd4, 0x0c, 0x62, 0x1f, 0x65, 0xb7, 0xe6, 0x34, 0x4a, 0x2e, 0x7c, 0xcd, 0x27, 0xa4, 0x34,
-	0x9b, 0xc4, 0x19, 0xe0, 0xb9, 0xf9, 0x19, 0x77, 0x42, 0x8a, 0x3a, 0xea, 0x2b, 0x0b, 0x43, 0x92,
-	0xbe, 0x88, 0x0d, 0xc5, 0x2a, 0x9d, 0xfb, 0x0e, 0xea, 0xaf, 0x25, 0x31, 0x4d, 0x6f, 0x6e, 0xb5,
-	0xf4, 0xe9, 0x4b, 0x42, 0x4a, 0xa3, 0x0f, 0xb0, 0x10, 0xd8, 0x28, 0x38, 0x85, 0xe3, 0xca, 0x49,
-	0xcd, 0x63, 0x31, 0xf7, 0x72, 0xd8, 0x1c, 0xc4, 0x6d, 0xc3, 0xa3, 0xb7, 0xa4, 0x57, 0x89, 0x76,
-	0xc1, 0xe2, 0x81, 0x21, 0x28, 0xf7, 0x2d, 0x1e, 0xb8, 0x13, 0x78, 0xfc, 0x9e, 0xab, 0x1c, 0x50,
-	0xcd, 0x91, 0x87, 0x00, 0x31, 0x0b, 0x69, 0xa8, 0xc5, 0x67, 0x8a, 0xb2, 0x17, 0xe5, 0xb4, 0x72,
-	0x9e, 0x16, 0xb0, 0x09, 0xe6, 0x30, 0x54, 0x7c, 0x4a, 0x0d, 0xcb, 0x29, 0x1c, 0xdf, 0xef, 0xef,
-	0xa4, 0x85, 0x01, 0x9f, 0x12, 0xd6, 0x61, 0x5b, 0x09, 0xa9, 0x87, 0xa3, 0x9b, 0x46, 0xd1, 0x3c,
-	0x2c, 0xa5, 0xc7, 0xde, 0x8d, 0xab, 0xa1, 0xbe, 0xd2, 0x4e, 0xc5, 0x22, 0x52, 0x84, 0x5d, 0xa8,
-	0x2c, 0xf4, 0xab, 0x46, 0xc1, 0x29, 0xae, 0x9b, 0x31, 0x8f, 0xc1, 0x36, 0xd4, 0x22, 0xba, 0xd6,
-	0xc3, 0x9c, 0x4e, 0xcb, 0xb4, 0xab, 0xa6, 0xe5, 0xb3, 0xb9, 0x56, 0xf7, 0x67, 0x01, 0x60, 0xc1,
-	0xb1, 0xbc, 0x03, 0x44, 0xd8, 0x8a, 0xd8, 0x84, 0xb2, 0xb7, 0xe6, 0x3f, 0x3a, 0x50, 0x09, 0x48,
-	0x8d, 0x25, 0x37, 0x6e, 0x65, 0x53, 0xe4, 0x4b, 0xf8, 0x12, 0x60, 0x6c, 0xdc, 0x0a, 0x86, 0x4c,
-	0x37, 0xb6, 0x8c, 0x25, 0xb6, 0x37, 0x4b, 0x84, 0x37, 0x4f, 0x84, 0x77, 0x3e, 0x4f, 0x44, 0xbf,
-	0x9c, 0xa1, 0x4f, 0xf5, 0xc9, 0x5f, 0x0b, 0xf6, 0x17, 0x7a, 0x06, 0x24, 0xaf, 0xf8, 0x98, 0x30,
-	0x86, 0xbd, 0x65, 0xfb, 0xb1, 0x65, 0xe6, 0xdf, 0x90, 0x0a, 0x7b, 0x79, 0x3b, 0x6e, 0xe7, 0xc7,
-	0x9f, 0x7f, 0xbf, 0xad, 0x67, 0xee, 0x41, 0x1a, 0x58, 0xe5, 0x5f, 0x75, 0x47, 0xa4, 0x59, 0xd7,
-	0xcf, 0xed, 0xec, 0x55, 0x2e, 0x24, 0x38, 0x86, 0xea, 0x9d, 0x90, 0xe0, 0x81, 0x21, 0x5c, 0x17,
-	0x9c, 0xd5, 0x5e, 0x6d, 0xd3, 0xcb, 0xc1, 0x27, 0x1b, 0x7b, 0xf9, 0xdf, 0x78, 0xf0, 0x1d, 0x23,
-	0xd8, 0xbd, 0x6b, 0x39, 0x36, 0x0d, 0xd5, 0xfa, 0xd8, 0xd9, 0xad, 0xf5, 0x97, 0xb3, 0x90, 0xb8,
-	0x4f, 0x4d, 0xd3, 0x26, 0x6e, 0x1e, 0xb0, 0x77, 0xf6, 0xeb, 0xf4, 0x43, 0xbf, 0x05, 0xdb, 0x01,
-	0x5d, 0xb0, 0xe4, 0x52, 0xe3, 0x3e, 0xd6, 0xa0, 0x6a, 0x57, 0x0c, 0xed, 0x40, 0x33, 0x9d, 0xa8,
-	0x8f, 0x47, 0x70, 0x08, 0xa5, 0x1e, 0x31, 0x49, 0x12, 0x1f, 0xee, 0x58, 0x76, 0x95, 0x25, 0xfa,
-	0x93, 0x90, 0x7c, 0x6a, 0x3e, 0x47, 0xc7, 0x1a, 0x3d, 0x00, 0xb8, 0x05, 0xdc, 0x1b, 0x95, 0x8c,
-	0x9b, 0x2f, 0xfe, 0x07, 0x00, 0x00, 0xff, 0xff, 0xd7, 0x97, 0x33, 0xcd, 0x24, 0x04, 0x00, 0x00,
+	// 556 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x4f, 0x73, 0xd2, 0x5e,
+	0x14, 0xfd, 0x25, 0xed, 0x8f, 0x96, 0x4b, 0x29, 0xed, 0xd5, 0x11, 0x1a, 0xa8, 0x8d, 0x59, 0x60,
+	0x17, 0x92, 0x48, 0x5d, 0xe9, 0xae, 0x38, 0xea, 0x8c, 0xa3, 0x4e, 0x27, 0x74, 0xe5, 0x26, 0xf3,
+	0x20, 0x97, 0xf8, 0x46, 0x48, 0x62, 0xde, 0xa3, 0xb6, 0x38, 0x6e, 0xdc, 0xbb, 0xd1, 0x0f, 0xe0,
+	0x87, 0x72, 0xe7, 0xda, 0x0f, 0xe2, 0xe4, 0x11, 0x20, 0xe5, 0xcf, 0x8a, 0xbc, 0xf3, 0xce, 0x3b,
+	0xf7, 0x9e, 0x7b, 0x0f, 0x70, 0x40, 0xd7, 0x31, 0x25, 0x7c, 0x44, 0xa1, 0xb4, 0xe3, 0x24, 0x92,
+	0x11, 0x6e, 0xb1, 0x98, 0x1b, 0x7b, 0x03, 0x3e, 0x94, 0x94, 0x4c, 0x21, 0xa3, 0x11, 0x44, 0x51,
+	0x30, 0x24, 0x87, 0xc5, 0xdc, 0x61, 0x61, 0x18, 0x49, 0x26, 0x79, 0x14, 0x8a, 0xec, 0xf6, 0x24,
+	0xbb, 0x55, 0xa7, 0xde, 0x78, 0xe0, 0x48, 0x3e, 0x22, 0x21, 0xd9, 0x28, 0xce, 0x08, 0x8f, 0xd4,
+	0x4f, 0xbf, 0x15, 0x50, 0xd8, 0x12, 0x9f, 0x59, 0x10, 0x50, 0xe2, 0x44, 0xb1, 0x92, 0x58, 0x95,
+	0xb3, 0x5e, 0x43, 0xf5, 0x79, 0x42, 0x4c, 0xd2, 0x8b, 0x79, 0x67, 0x2e, 0x7d, 0x1a, 0x93, 0x90,
+	0xe8, 0x00, 0x2c, 0xda, 0xad, 0x69, 0xa6, 0x76, 0x5a, 0x3a, 0xab, 0xd8, 0x2c, 0xe6, 0x76, 0x8e,
+	0x9b, 0xa3, 0x58, 0x4d, 0xb8, 0xfb, 0x8a, 0xe4, 0xaa, 0xd0, 0x3e, 0xe8, 0xdc, 0x57, 0x02, 0x45,
+	0x57, 0xe7, 0xbe, 0xf5, 0x4b, 0x83, 0x7b, 0x6f, 0xb8, 0xc8, 0x31, 0xc5, 0x8c, 0x7a, 0x0c, 0x10,
+	0xb3, 0x80, 0x3c, 0x19, 0x7d, 0xa4, 0x30, 0x7b, 0x52, 0x4c, 0x91, 0xcb, 0x14, 0xc0, 0x3a, 0xa8,
+	0x83, 0x27, 0xf8, 0x84, 0x6a, 0xba, 0xa9, 0x9d, 0xfe, 0xef, 0xee, 0xa6, 0x40, 0x97, 0x4f, 0x08,
+	0xab, 0xb0, 0x23, 0xa2, 0x44, 0x7a, 0xbd, 0x9b, 0xda, 0x96, 0x7a, 0x58, 0x48, 0x8f, 0x9d, 0x1b,
+	0x7c, 0x0c, 0xa5, 0x90, 0x8d, 0xc8, 0x9b, 0x4e, 0xb9, 0xb6, 0x9d, 0x73, 0xf2, 0x8e, 0x8d, 0xe8,
+	0xa5, 0x82, 0x5d, 0x08, 0xe7, 0xdf, 0x96, 0x84, 0xea, 0x4a, 0x83, 0x22, 0x8e, 0x42, 0x41, 0xd8,
+	0x86, 0xd2, 0xc2, 0xb2, 0xa8, 0x69, 0xe6, 0xd6, 0xba, 0xb1, 0xe4, 0x39, 0xd8, 0x84, 0x4a, 0x48,
+	0xd7, 0xd2, 0xcb, 0x39, 0xd3, 0x55, 0x83, 0xe5, 0x14, 0xbe, 0x98, 0xb9, 0xb3, 0xbe, 0x6b, 0x00,
+	0x0b, 0x8d, 0xe5, 0xb1, 0x21, 0xc2, 0x76, 0xda, 0x62, 0xf6, 0x56, 0x7d, 0xa3, 0x09, 0x25, 0x9f,
+	0x44, 0x3f, 0xe1, 0x6a, 0xc1, 0x99, 0xef, 0x3c, 0x84, 0x4f, 0x01, 0xfa, 0x6a, 0xc1, 0xbe, 0xc7,
+	0x64, 0xe6, 0xdd, 0xb0, 0xa7, 0x21, 0xb2, 0x67, 0x21, 0xb2, 0x2f, 0x67, 0x21, 0x72, 0x8b, 0x19,
+	0xfb, 0x5c, 0x9e, 0xfd, 0xd1, 0xe1, 0x70, 0xd1, 0x4f, 0x97, 0x92, 0x2b, 0xde, 0x27, 0x8c, 0xe1,
+	0x60, 0x39, 0x31, 0xd8, 0x50, 0xfe, 0x37, 0x04, 0xc9, 0x58, 0x9e, 0x8e, 0xd5, 0xfa, 0xf6, 0xfb,
+	0xef, 0x4f, 0xfd, 0xa1, 0x75, 0x94, 0x66, 0x5c, 0x38, 0x57, 0xed, 0x1e, 0x49, 0xd6, 0x76, 0x72,
+	0x33, 0x7b, 0x96, 0xcb, 0x15, 0xf6, 0xa1, 0x7c, 0x2b, 0x57, 0x78, 0xa4, 0x04, 0xd7, 0x65, 0x6d,
+	0xb5, 0x56, 0x53, 0xd5, 0x32, 0xf1, 0xfe, 0xc6, 0x5a, 0xce, 0x17, 0xee, 0x7f, 0xc5, 0x10, 0xf6,
+	0x6f, 0xaf, 0x1c, 0xeb, 0x4a, 0x6a, 0x7d, 0x50, 0x8d, 0xc6, 0xfa, 0xcb, 0x69, 0x48, 0xac, 0x07,
+	0xaa, 0x68, 0x1d, 0x37, 0x1b, 0xec, 0x5c, 0xfc, 0x38, 0x7f, 0xeb, 0x36, 0x60, 0xc7, 0xa7, 0x01,
+	0x1b, 0x0f, 0x25, 0x1e, 0x62, 0x05, 0xca, 0x46, 0x49, 0xc9, 0x76, 0x25, 0x93, 0x63, 0xf1, 0xfe,
+	0x04, 0x8e, 0xa1, 0xd0, 0x21, 0x96, 0x50, 0x82, 0x77, 0x76, 0x75, 0xa3, 0xcc, 0xc6, 0xf2, 0x43,
+	0x94, 0xf0, 0x89, 0xfa, 0x07, 0x9b, 0x7a, 0x6f, 0x0f, 0x60, 0x4e, 0xf8, 0xaf, 0x57, 0x50, 0xdb,
+	0x7c, 0xf2, 0x6f, 0x00, 0xf1, 0x71, 0x3d, 0xdc, 0x65, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
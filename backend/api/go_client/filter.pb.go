@@ -0,0 +1,136 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: filter.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type NameFilter_Match int32
+
+const (
+	// The names equal to the name, in the same case.
+	NameFilter_EXACT NameFilter_Match = 0
+	// The names equal to the name, in any case.
+	NameFilter_CASE_INSENSITIVE NameFilter_Match = 1
+	// The names starting with the name, in any case.
+	NameFilter_PREFIX NameFilter_Match = 2
+)
+
+var NameFilter_Match_name = map[int32]string{
+	0: "EXACT",
+	1: "CASE_INSENSITIVE",
+	2: "PREFIX",
+}
+
+var NameFilter_Match_value = map[string]int32{
+	"EXACT":            0,
+	"CASE_INSENSITIVE": 1,
+	"PREFIX":           2,
+}
+
+func (x NameFilter_Match) String() string {
+	return proto.EnumName(NameFilter_Match_name, int32(x))
+}
+
+func (NameFilter_Match) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1f5303cab7a20d6f, []int{0, 0}
+}
+
+// NameFilter lists only the resources with a matching name.
+type NameFilter struct {
+	// The name, or the prefix of the names, to match.
+	Name                 string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Match                NameFilter_Match `protobuf:"varint,2,opt,name=match,proto3,enum=api.NameFilter_Match" json:"match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *NameFilter) Reset()         { *m = NameFilter{} }
+func (m *NameFilter) String() string { return proto.CompactTextString(m) }
+func (*NameFilter) ProtoMessage()    {}
+func (*NameFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1f5303cab7a20d6f, []int{0}
+}
+
+func (m *NameFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NameFilter.Unmarshal(m, b)
+}
+func (m *NameFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NameFilter.Marshal(b, m, deterministic)
+}
+func (m *NameFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NameFilter.Merge(m, src)
+}
+func (m *NameFilter) XXX_Size() int {
+	return xxx_messageInfo_NameFilter.Size(m)
+}
+func (m *NameFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_NameFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NameFilter proto.InternalMessageInfo
+
+func (m *NameFilter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NameFilter) GetMatch() NameFilter_Match {
+	if m != nil {
+		return m.Match
+	}
+	return NameFilter_EXACT
+}
+
+func init() {
+	proto.RegisterEnum("api.NameFilter_Match", NameFilter_Match_name, NameFilter_Match_value)
+	proto.RegisterType((*NameFilter)(nil), "api.NameFilter")
+}
+
+func init() { proto.RegisterFile("filter.proto", fileDescriptor_1f5303cab7a20d6f) }
+
+var fileDescriptor_1f5303cab7a20d6f = []byte{
+	// 155 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x49, 0xcb, 0xcc, 0x29,
+	0x49, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4e, 0x2c, 0xc8, 0x54, 0x6a, 0x66,
+	0xe4, 0xe2, 0xf2, 0x4b, 0xcc, 0x4d, 0x75, 0x03, 0xcb, 0x08, 0x09, 0x71, 0xb1, 0xe4, 0x25, 0xe6,
+	0xa6, 0x4a, 0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x81, 0xd9, 0x42, 0xda, 0x5c, 0xac, 0xb9, 0x89,
+	0x25, 0xc9, 0x19, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0x7c, 0x46, 0xa2, 0x7a, 0x89, 0x05, 0x99, 0x7a,
+	0x08, 0x3d, 0x7a, 0xbe, 0x20, 0xc9, 0x20, 0x88, 0x1a, 0x25, 0x13, 0x2e, 0x56, 0x30, 0x5f, 0x88,
+	0x93, 0x8b, 0xd5, 0x35, 0xc2, 0xd1, 0x39, 0x44, 0x80, 0x41, 0x48, 0x84, 0x4b, 0xc0, 0xd9, 0x31,
+	0xd8, 0x35, 0xde, 0xd3, 0x2f, 0xd8, 0xd5, 0x2f, 0xd8, 0x33, 0xc4, 0x33, 0xcc, 0x55, 0x80, 0x51,
+	0x88, 0x8b, 0x8b, 0x2d, 0x20, 0xc8, 0xd5, 0xcd, 0x33, 0x42, 0x80, 0x29, 0x89, 0x0d, 0xec, 0x22,
+	0x63, 0xc0, 0x00, 0x29, 0x79, 0x66, 0x99, 0xa1, 0x00, 0x00, 0x00,
+}
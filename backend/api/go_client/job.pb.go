@@ -40,6 +40,33 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ListJobsRequest_View int32
+
+const (
+	// The jobs without the workflow and pipeline manifests of their pipeline specs.
+	ListJobsRequest_BASIC ListJobsRequest_View = 0
+	// The jobs with the manifests, as returned by GetJob.
+	ListJobsRequest_FULL ListJobsRequest_View = 1
+)
+
+var ListJobsRequest_View_name = map[int32]string{
+	0: "BASIC",
+	1: "FULL",
+}
+
+var ListJobsRequest_View_value = map[string]int32{
+	"BASIC": 0,
+	"FULL":  1,
+}
+
+func (x ListJobsRequest_View) String() string {
+	return proto.EnumName(ListJobsRequest_View_name, int32(x))
+}
+
+func (ListJobsRequest_View) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32c477d91a04ead, []int{2, 0}
+}
+
 // Required input.
 type Job_Mode int32
 
@@ -158,9 +185,15 @@ type ListJobsRequest struct {
 	// E.g. If listing job for an experiment, the query string would be
 	// resource_reference_key.type=EXPERIMENT&resource_reference_key.id=123
 	ResourceReferenceKey *ResourceKey `protobuf:"bytes,4,opt,name=resource_reference_key,json=resourceReferenceKey,proto3" json:"resource_reference_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	// The fields of the jobs to return. The manifests are left out by default, as they
+	// make up most of the size of a job.
+	View ListJobsRequest_View `protobuf:"varint,5,opt,name=view,proto3,enum=api.ListJobsRequest_View" json:"view,omitempty"`
+	// Lists only the jobs with a matching display name, e.g.
+	// name_filter.name=nightly&name_filter.match=PREFIX
+	NameFilter           *NameFilter `protobuf:"bytes,6,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
@@ -216,6 +249,20 @@ func (m *ListJobsRequest) GetResourceReferenceKey() *ResourceKey {
 	return nil
 }
 
+func (m *ListJobsRequest) GetView() ListJobsRequest_View {
+	if m != nil {
+		return m.View
+	}
+	return ListJobsRequest_BASIC
+}
+
+func (m *ListJobsRequest) GetNameFilter() *NameFilter {
+	if m != nil {
+		return m.NameFilter
+	}
+	return nil
+}
+
 type ListJobsResponse struct {
 	Jobs                 []*Job   `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
 	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
@@ -666,8 +713,16 @@ type Job struct {
 	// In case any error happens retrieving a job field, only job ID
 	// and the error message is returned. Client has the flexibility of choosing
 	// how to handle error. This is especially useful during listing call.
-	Error                string   `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
-	Enabled              bool     `protobuf:"varint,16,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Error   string `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	Enabled bool   `protobuf:"varint,16,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Optional input field. The service account the pods of the runs of the job
+	// run as, instead of the one of the namespace or of the compiled workflow. It
+	// must be allowed by the server.
+	ServiceAccount string `protobuf:"bytes,17,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
+	// Output. The warnings of the creation of the job, e.g. its pipeline being
+	// deprecated or its interval being shorter than the typical duration of the
+	// runs of its pipeline.
+	Warnings             []string `protobuf:"bytes,18,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -789,7 +844,22 @@ func (m *Job) GetEnabled() bool {
 	return false
 }
 
+func (m *Job) GetServiceAccount() string {
+	if m != nil {
+		return m.ServiceAccount
+	}
+	return ""
+}
+
+func (m *Job) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("api.ListJobsRequest_View", ListJobsRequest_View_name, ListJobsRequest_View_value)
 	proto.RegisterEnum("api.Job_Mode", Job_Mode_name, Job_Mode_value)
 	proto.RegisterType((*CreateJobRequest)(nil), "api.CreateJobRequest")
 	proto.RegisterType((*GetJobRequest)(nil), "api.GetJobRequest")
@@ -807,73 +877,79 @@ func init() {
 func init() { proto.RegisterFile("job.proto", fileDescriptor_f32c477d91a04ead) }
 
 var fileDescriptor_f32c477d91a04ead = []byte{
-	// 1044 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0x5b, 0x72, 0x1b, 0x45,
-	0x17, 0xb6, 0x2e, 0xd1, 0xe5, 0x58, 0x8a, 0xc7, 0xed, 0x4b, 0xe6, 0x57, 0x9c, 0xdf, 0xca, 0x50,
-	0xe5, 0xb8, 0x28, 0x22, 0x55, 0x9c, 0x82, 0x02, 0xde, 0x6c, 0xcb, 0x38, 0xd8, 0xb1, 0xe3, 0x1a,
-	0x99, 0x82, 0x82, 0x87, 0xa9, 0x9e, 0x99, 0x63, 0x65, 0x1c, 0x69, 0x7a, 0xe8, 0x6e, 0x19, 0xcb,
-	0x14, 0x2f, 0x2c, 0x01, 0xd8, 0x00, 0x0b, 0x80, 0x17, 0x96, 0xc2, 0x16, 0x58, 0x08, 0xd5, 0x3d,
-	0x3d, 0xb2, 0x2e, 0x18, 0x3f, 0xf2, 0xa4, 0x39, 0x5f, 0x7f, 0xa7, 0xfb, 0xeb, 0x73, 0x6b, 0x41,
-	0xf5, 0x92, 0xf9, 0xad, 0x84, 0x33, 0xc9, 0x48, 0x81, 0x26, 0x51, 0x63, 0xa3, 0xc7, 0x58, 0xaf,
-	0x8f, 0x6d, 0x9a, 0x44, 0x6d, 0x1a, 0xc7, 0x4c, 0x52, 0x19, 0xb1, 0x58, 0xa4, 0x94, 0xc6, 0xa6,
-	0x59, 0xd5, 0x96, 0x3f, 0xbc, 0x68, 0xcb, 0x68, 0x80, 0x42, 0xd2, 0x41, 0x62, 0x08, 0x8f, 0x67,
-	0x09, 0x38, 0x48, 0xe4, 0xc8, 0x2c, 0x2e, 0x25, 0x94, 0xd3, 0x01, 0x4a, 0xe4, 0x06, 0x58, 0x49,
-	0xa2, 0x04, 0xfb, 0x51, 0x8c, 0x9e, 0x48, 0x30, 0x30, 0xa0, 0xcd, 0x51, 0xb0, 0x21, 0x0f, 0xd0,
-	0xe3, 0x78, 0x81, 0x1c, 0xe3, 0x00, 0xcd, 0x4a, 0x95, 0x0f, 0x63, 0xf3, 0xf9, 0x81, 0xfe, 0x09,
-	0x9e, 0xf7, 0x30, 0x7e, 0x2e, 0xbe, 0xa3, 0xbd, 0x1e, 0xf2, 0x36, 0x4b, 0xb4, 0xd4, 0x79, 0xd9,
-	0x4e, 0x0b, 0xac, 0x7d, 0x8e, 0x54, 0xe2, 0x11, 0xf3, 0x5d, 0xfc, 0x76, 0x88, 0x42, 0x92, 0x06,
-	0x14, 0x2e, 0x99, 0x6f, 0xe7, 0x9a, 0xb9, 0xed, 0xc5, 0x9d, 0x4a, 0x8b, 0x26, 0x51, 0x4b, 0xad,
-	0x2a, 0xd0, 0xd9, 0x84, 0xfa, 0x21, 0xca, 0x09, 0xf2, 0x43, 0xc8, 0x47, 0xa1, 0xe6, 0x56, 0xdd,
-	0x7c, 0x14, 0x3a, 0xbf, 0xe7, 0x60, 0xe9, 0x75, 0x24, 0x14, 0x45, 0x64, 0x9c, 0x27, 0x00, 0x09,
-	0xed, 0xa1, 0x27, 0xd9, 0x3b, 0x8c, 0x0d, 0xb7, 0xaa, 0x90, 0x73, 0x05, 0x90, 0xc7, 0xa0, 0x0d,
-	0x4f, 0x44, 0x37, 0x68, 0xe7, 0x9b, 0xb9, 0xed, 0x07, 0x6e, 0x45, 0x01, 0xdd, 0xe8, 0x06, 0xc9,
-	0x23, 0x28, 0x0b, 0xc6, 0xa5, 0xe7, 0x8f, 0xec, 0x82, 0x76, 0x2c, 0x29, 0x73, 0x6f, 0x44, 0x3e,
-	0x83, 0xf5, 0xf9, 0x70, 0x78, 0xef, 0x70, 0x64, 0x17, 0xb5, 0x70, 0x4b, 0x0b, 0x77, 0x0d, 0xe5,
-	0x18, 0x47, 0xee, 0x6a, 0xc6, 0x77, 0x33, 0xfa, 0x31, 0x8e, 0x9c, 0xaf, 0xc0, 0xba, 0xd5, 0x2b,
-	0x12, 0x16, 0x0b, 0x24, 0x1b, 0x50, 0xbc, 0x64, 0xbe, 0xb0, 0x73, 0xcd, 0xc2, 0x54, 0x08, 0x34,
-	0x4a, 0xb6, 0x60, 0x29, 0xc6, 0x6b, 0xe9, 0x4d, 0xdc, 0x29, 0xaf, 0xa5, 0xd5, 0x15, 0x7c, 0x96,
-	0xdd, 0xcb, 0x71, 0xc0, 0xea, 0x60, 0x1f, 0xa7, 0x62, 0x3b, 0x1b, 0x2e, 0x07, 0xac, 0x83, 0x98,
-	0xfa, 0xfd, 0x7f, 0xe3, 0xbc, 0x07, 0xcb, 0x9d, 0x48, 0xdc, 0x43, 0xfa, 0x25, 0x07, 0xb5, 0x7d,
-	0xce, 0xe2, 0x6e, 0xf0, 0x16, 0xc3, 0x61, 0x1f, 0xc9, 0x27, 0x00, 0x42, 0x52, 0x2e, 0x3d, 0x55,
-	0x88, 0x26, 0x99, 0x8d, 0x56, 0x5a, 0x84, 0xad, 0xac, 0x08, 0x5b, 0xe7, 0x59, 0x95, 0xba, 0x55,
-	0xcd, 0x56, 0x36, 0xf9, 0x10, 0x2a, 0x18, 0x87, 0xa9, 0x63, 0xfe, 0x5e, 0xc7, 0x32, 0xc6, 0xa1,
-	0x76, 0x23, 0x50, 0x0c, 0x38, 0x8b, 0x4d, 0x9e, 0xf4, 0xb7, 0xf3, 0x5b, 0x0e, 0xac, 0x33, 0xe4,
-	0x11, 0x0b, 0xa3, 0xe0, 0x3f, 0x94, 0xf6, 0x0c, 0x96, 0xa2, 0x58, 0x22, 0xbf, 0xa2, 0x7d, 0x4f,
-	0x60, 0xc0, 0xe2, 0x50, 0xab, 0x2c, 0xb8, 0x0f, 0x33, 0xb8, 0xab, 0x51, 0x15, 0xc6, 0xf2, 0x39,
-	0x8f, 0x54, 0xd7, 0x90, 0x8f, 0xa1, 0xae, 0xee, 0xe0, 0x09, 0xa3, 0xdb, 0x28, 0x5d, 0xd6, 0xe5,
-	0x30, 0x19, 0xeb, 0x57, 0x0b, 0x6e, 0x2d, 0x98, 0x8c, 0x7d, 0x07, 0x96, 0x13, 0x73, 0xe9, 0x5b,
-	0xef, 0x54, 0xee, 0x9a, 0xf6, 0x9e, 0x0d, 0xc9, 0xab, 0x05, 0xd7, 0x4a, 0x66, 0xb0, 0xbd, 0x2a,
-	0x94, 0x65, 0x2a, 0xc5, 0xf9, 0xa3, 0x08, 0x85, 0x23, 0xe6, 0xcf, 0x66, 0x5d, 0x85, 0x3c, 0xa6,
-	0x26, 0x14, 0x55, 0x57, 0x7f, 0x93, 0x26, 0x2c, 0x86, 0x28, 0x02, 0x1e, 0xe9, 0xa6, 0x37, 0xd9,
-	0x98, 0x84, 0xc8, 0x47, 0x50, 0x9f, 0x1a, 0x2f, 0xa6, 0x63, 0xd2, 0x8b, 0x9d, 0x99, 0x95, 0x6e,
-	0x82, 0x81, 0x5b, 0x4b, 0x26, 0x2c, 0x72, 0x08, 0x2b, 0xf3, 0x2d, 0x27, 0xec, 0x07, 0xba, 0x4b,
-	0xd6, 0xa7, 0xfa, 0x6d, 0xdc, 0x62, 0x2e, 0x99, 0xeb, 0x3a, 0xa1, 0xd2, 0x31, 0xa0, 0xd7, 0x5e,
-	0xc0, 0xe2, 0x60, 0xc8, 0x15, 0x36, 0xb2, 0x4b, 0x69, 0x3a, 0x06, 0xf4, 0x7a, 0xff, 0x16, 0x25,
-	0x5b, 0xe3, 0x10, 0xd8, 0x65, 0xad, 0xb1, 0xa6, 0x4f, 0x31, 0x19, 0x72, 0xb3, 0x45, 0xf2, 0x14,
-	0x8a, 0x03, 0x16, 0xa2, 0x5d, 0x69, 0xe6, 0xb6, 0x1f, 0xee, 0xd4, 0xb3, 0x86, 0x6d, 0x9d, 0xb0,
-	0x10, 0x5d, 0xbd, 0xa4, 0x8a, 0x2e, 0xd0, 0x93, 0x2e, 0xf4, 0xa8, 0xb4, 0xab, 0xf7, 0x17, 0x9d,
-	0x61, 0xef, 0x4a, 0xe5, 0x3a, 0x4c, 0xc2, 0xcc, 0x15, 0xee, 0x77, 0x35, 0xec, 0x5d, 0x49, 0xd6,
-	0xa1, 0x24, 0x24, 0x95, 0x43, 0x61, 0x2f, 0x9a, 0xe9, 0xa5, 0x2d, 0xb2, 0x0a, 0x0f, 0x90, 0x73,
-	0xc6, 0xed, 0x9a, 0x86, 0x53, 0x83, 0xd8, 0x50, 0x46, 0x3d, 0x0d, 0x42, 0xdb, 0x6a, 0xe6, 0xb6,
-	0x2b, 0x6e, 0x66, 0x3a, 0x2f, 0xa1, 0xa8, 0xee, 0x42, 0x2c, 0xa8, 0x7d, 0x71, 0x7a, 0x7c, 0xfa,
-	0xe6, 0xcb, 0x53, 0xef, 0xe4, 0x4d, 0xe7, 0xc0, 0x5a, 0x20, 0x8b, 0x50, 0x3e, 0x38, 0xdd, 0xdd,
-	0x7b, 0x7d, 0xd0, 0xb1, 0x72, 0xa4, 0x06, 0x95, 0xce, 0xe7, 0xdd, 0xd4, 0xca, 0xef, 0xfc, 0x5a,
-	0x04, 0x38, 0x62, 0x7e, 0x17, 0xf9, 0x55, 0x14, 0x20, 0x39, 0x81, 0xea, 0x78, 0xd6, 0x93, 0x35,
-	0x53, 0xc5, 0xd3, 0xb3, 0xbf, 0x31, 0x9e, 0x75, 0xce, 0xe6, 0x8f, 0x7f, 0xfe, 0xf5, 0x73, 0xfe,
-	0x7f, 0x0e, 0x51, 0x0f, 0x9e, 0x68, 0x5f, 0xbd, 0xf0, 0x51, 0xd2, 0x17, 0x6d, 0x35, 0x01, 0x3f,
-	0x55, 0x4f, 0x01, 0x39, 0x84, 0x52, 0xfa, 0x14, 0x10, 0xa2, 0x9d, 0xa6, 0xde, 0x85, 0xf9, 0x8d,
-	0xc8, 0xa3, 0xf9, 0x8d, 0xda, 0xdf, 0x47, 0xe1, 0x0f, 0xa4, 0x0b, 0x95, 0x6c, 0x02, 0x93, 0x55,
-	0xed, 0x36, 0xf3, 0x80, 0x34, 0xd6, 0x66, 0xd0, 0x74, 0x4c, 0x3b, 0x0d, 0xbd, 0xf3, 0x2a, 0xf9,
-	0x07, 0x89, 0xc4, 0x87, 0xea, 0x78, 0xb0, 0x9a, 0xcb, 0xce, 0x0e, 0xda, 0xc6, 0xfa, 0x5c, 0x0e,
-	0x0f, 0xd4, 0x9b, 0xec, 0x6c, 0xe9, 0x7d, 0x9b, 0xce, 0xff, 0xef, 0x50, 0xdc, 0x4e, 0xb3, 0x42,
-	0x10, 0xe0, 0x76, 0x30, 0x93, 0xb4, 0x01, 0xe6, 0x26, 0xf5, 0x9d, 0xa7, 0x3c, 0xd3, 0xa7, 0x3c,
-	0x75, 0x36, 0xef, 0x3a, 0x25, 0x4c, 0xb7, 0x22, 0xdf, 0x40, 0x75, 0xfc, 0x8e, 0x98, 0xab, 0xcc,
-	0xbe, 0x2b, 0x77, 0x1e, 0x62, 0x82, 0xff, 0xfe, 0x5d, 0xc1, 0xdf, 0x3b, 0xfb, 0x69, 0xf7, 0xc4,
-	0xdd, 0x80, 0x72, 0x88, 0x17, 0x74, 0xd8, 0x97, 0x64, 0x99, 0x2c, 0x41, 0xbd, 0xb1, 0xa8, 0x4f,
-	0xe9, 0xea, 0x5a, 0xfd, 0x7a, 0x13, 0x9e, 0x40, 0x69, 0x0f, 0x29, 0x47, 0x4e, 0x56, 0x2a, 0xf9,
-	0x46, 0x9d, 0x0e, 0xe5, 0x5b, 0xc6, 0xa3, 0x1b, 0xfd, 0x4f, 0xa2, 0x99, 0xf7, 0x6b, 0x00, 0x63,
-	0xc2, 0x82, 0x5f, 0xd2, 0x12, 0x5e, 0xfe, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x76, 0x07, 0x23,
-	0x40, 0x09, 0x00, 0x00,
+	// 1150 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xcd, 0x72, 0x1b, 0x45,
+	0x10, 0xb6, 0x7e, 0x6c, 0x49, 0x6d, 0xc9, 0x5e, 0x4f, 0x1c, 0x67, 0xa3, 0x24, 0x58, 0x59, 0xaa,
+	0x12, 0x17, 0x45, 0x24, 0x92, 0x14, 0x14, 0x70, 0xf3, 0x5f, 0x7e, 0x6d, 0xc7, 0xb5, 0x4a, 0x80,
+	0x82, 0xc3, 0xd6, 0xec, 0x6e, 0x5b, 0x99, 0x44, 0xda, 0x59, 0x66, 0x46, 0x76, 0x64, 0x8a, 0x0b,
+	0x8f, 0x00, 0xbc, 0x00, 0x77, 0x78, 0x1a, 0x5e, 0x81, 0x87, 0xe0, 0x48, 0xcd, 0xec, 0xac, 0x2c,
+	0x4b, 0x71, 0x7c, 0xe4, 0xa4, 0xed, 0xaf, 0xbf, 0x9e, 0xee, 0xe9, 0xe9, 0x1f, 0x41, 0xed, 0x0d,
+	0x0f, 0xdb, 0xa9, 0xe0, 0x8a, 0x93, 0x12, 0x4d, 0x59, 0xb3, 0x7e, 0xc4, 0xfa, 0x0a, 0x45, 0x06,
+	0x35, 0x6f, 0xf6, 0x38, 0xef, 0xf5, 0xb1, 0x43, 0x53, 0xd6, 0xa1, 0x49, 0xc2, 0x15, 0x55, 0x8c,
+	0x27, 0xd2, 0x6a, 0xd7, 0xad, 0xd6, 0x48, 0xe1, 0xf0, 0xa8, 0xa3, 0xd8, 0x00, 0xa5, 0xa2, 0x83,
+	0xd4, 0x12, 0x6e, 0x4c, 0x13, 0x70, 0x90, 0xaa, 0x91, 0x55, 0x2e, 0xa7, 0x54, 0xd0, 0x01, 0x9e,
+	0x39, 0xbb, 0x92, 0xb2, 0x14, 0xfb, 0x2c, 0xc1, 0x40, 0xa6, 0x18, 0x59, 0xd0, 0x15, 0x28, 0xf9,
+	0x50, 0x44, 0x18, 0x08, 0x3c, 0x42, 0x81, 0x49, 0x84, 0x56, 0x53, 0x13, 0xc3, 0xc4, 0x7e, 0x7e,
+	0x6a, 0x7e, 0xa2, 0x7b, 0x3d, 0x4c, 0xee, 0xc9, 0x13, 0xda, 0xeb, 0xa1, 0xe8, 0xf0, 0xd4, 0x84,
+	0x3a, 0x1b, 0xb6, 0xd7, 0x06, 0x67, 0x5b, 0x20, 0x55, 0xf8, 0x8c, 0x87, 0x3e, 0xfe, 0x38, 0x44,
+	0xa9, 0x48, 0x13, 0x4a, 0x6f, 0x78, 0xe8, 0x16, 0x5a, 0x85, 0x8d, 0xc5, 0x07, 0xd5, 0x36, 0x4d,
+	0x59, 0x5b, 0x6b, 0x35, 0xe8, 0xad, 0x43, 0xe3, 0x31, 0xaa, 0x09, 0xf2, 0x12, 0x14, 0x59, 0x6c,
+	0xb8, 0x35, 0xbf, 0xc8, 0x62, 0xef, 0xcf, 0x22, 0x2c, 0xef, 0x31, 0xa9, 0x29, 0x32, 0xe7, 0xdc,
+	0x02, 0x48, 0x69, 0x0f, 0x03, 0xc5, 0xdf, 0x62, 0x62, 0xb9, 0x35, 0x8d, 0xbc, 0xd4, 0x00, 0xb9,
+	0x01, 0x46, 0x08, 0x24, 0x3b, 0x45, 0xb7, 0xd8, 0x2a, 0x6c, 0xcc, 0xfb, 0x55, 0x0d, 0x74, 0xd9,
+	0x29, 0x92, 0x6b, 0x50, 0x91, 0x5c, 0xa8, 0x20, 0x1c, 0xb9, 0x25, 0x63, 0xb8, 0xa0, 0xc5, 0xad,
+	0x11, 0x79, 0x04, 0x6b, 0xb3, 0xe9, 0x08, 0xde, 0xe2, 0xc8, 0x2d, 0x9b, 0xc0, 0x1d, 0x13, 0xb8,
+	0x6f, 0x29, 0xcf, 0x71, 0xe4, 0xaf, 0xe6, 0x7c, 0x3f, 0xa7, 0x3f, 0xc7, 0x11, 0xb9, 0x07, 0xe5,
+	0x63, 0x86, 0x27, 0xee, 0x7c, 0xab, 0xb0, 0xb1, 0xf4, 0xe0, 0xba, 0xb1, 0x9a, 0xba, 0x40, 0xfb,
+	0x1b, 0x86, 0x27, 0xbe, 0xa1, 0x91, 0xcf, 0x60, 0x31, 0xa1, 0x03, 0x0c, 0xb2, 0xd2, 0x70, 0x17,
+	0x8c, 0xaf, 0x65, 0x63, 0x75, 0x40, 0x07, 0xf8, 0xc8, 0xc0, 0x3e, 0x24, 0xe3, 0x6f, 0xef, 0x06,
+	0x94, 0xb5, 0x3d, 0xa9, 0xc1, 0xfc, 0xd6, 0x66, 0xf7, 0xe9, 0xb6, 0x33, 0x47, 0xaa, 0x50, 0x7e,
+	0xf4, 0x6a, 0x6f, 0xcf, 0x29, 0x78, 0xdf, 0x81, 0x73, 0xe6, 0x4c, 0xa6, 0x3c, 0x91, 0x48, 0x6e,
+	0x42, 0xf9, 0x0d, 0x0f, 0xa5, 0x5b, 0x68, 0x95, 0xce, 0x3d, 0x80, 0x41, 0xc9, 0x1d, 0x58, 0x4e,
+	0xf0, 0x9d, 0x0a, 0x26, 0x32, 0x5a, 0x34, 0x89, 0x69, 0x68, 0xf8, 0x30, 0xcf, 0xaa, 0xe7, 0x81,
+	0xb3, 0x83, 0x7d, 0x54, 0xf8, 0x81, 0xc7, 0xf2, 0xc0, 0xd9, 0x4d, 0x68, 0xd8, 0xff, 0x10, 0xe7,
+	0x63, 0x58, 0xd9, 0x61, 0xf2, 0x12, 0xd2, 0xef, 0x05, 0xa8, 0x6f, 0x0b, 0x9e, 0x74, 0xa3, 0xd7,
+	0x18, 0x0f, 0xfb, 0x48, 0xbe, 0x02, 0x90, 0x8a, 0x0a, 0x15, 0xe8, 0x36, 0xb0, 0xa5, 0xd4, 0x6c,
+	0x67, 0x2d, 0xd0, 0xce, 0x5b, 0xa0, 0xfd, 0x32, 0xef, 0x11, 0xbf, 0x66, 0xd8, 0x5a, 0x26, 0x9f,
+	0x43, 0x15, 0x93, 0x38, 0x33, 0x2c, 0x5e, 0x6a, 0x58, 0xc1, 0x24, 0x36, 0x66, 0x04, 0xca, 0x91,
+	0xe0, 0x89, 0xad, 0x12, 0xf3, 0xed, 0xfd, 0x55, 0x00, 0xe7, 0x10, 0x05, 0xe3, 0x31, 0x8b, 0xfe,
+	0xc7, 0xd0, 0xee, 0xc2, 0x32, 0x4b, 0x14, 0x8a, 0x63, 0xda, 0x0f, 0x24, 0x46, 0x3c, 0x89, 0x4d,
+	0x94, 0x25, 0x7f, 0x29, 0x87, 0xbb, 0x06, 0xd5, 0x69, 0xac, 0xbc, 0x14, 0x4c, 0xf7, 0x2c, 0xf9,
+	0x12, 0x1a, 0xfa, 0x0e, 0x81, 0xb4, 0x71, 0xdb, 0x48, 0x57, 0x4c, 0x39, 0x4c, 0xe6, 0xfa, 0xc9,
+	0x9c, 0x5f, 0x8f, 0x26, 0x73, 0xbf, 0x03, 0x2b, 0xa9, 0xbd, 0xf4, 0x99, 0x75, 0x16, 0xee, 0x55,
+	0x63, 0x3d, 0x9d, 0x92, 0x27, 0x73, 0xbe, 0x93, 0x4e, 0x61, 0x5b, 0x35, 0xa8, 0xa8, 0x2c, 0x14,
+	0xef, 0xdf, 0x32, 0x94, 0x9e, 0xf1, 0x70, 0xfa, 0xd5, 0x75, 0xca, 0x75, 0x9d, 0xdb, 0xfa, 0x33,
+	0xdf, 0xa4, 0x05, 0x8b, 0x31, 0xca, 0x48, 0x30, 0x33, 0x72, 0xec, 0x6b, 0x4c, 0x42, 0xe4, 0x0b,
+	0x68, 0x9c, 0x1b, 0x6e, 0x6e, 0x79, 0xe2, 0x62, 0x87, 0x56, 0xd3, 0x4d, 0x31, 0xf2, 0xeb, 0xe9,
+	0x84, 0x44, 0x1e, 0xc3, 0x95, 0xd9, 0x86, 0x97, 0xee, 0xbc, 0xe9, 0x92, 0xb5, 0x73, 0xdd, 0x3e,
+	0x6e, 0x70, 0x9f, 0xcc, 0xf4, 0xbc, 0xd4, 0xcf, 0x31, 0xa0, 0xef, 0x82, 0x88, 0x27, 0xd1, 0x50,
+	0x68, 0x6c, 0x64, 0xda, 0xb8, 0xe4, 0x2f, 0x0d, 0xe8, 0xbb, 0xed, 0x33, 0x94, 0xdc, 0x19, 0xa7,
+	0xc0, 0xad, 0x98, 0x18, 0xeb, 0xc6, 0x8b, 0x7d, 0x21, 0x3f, 0x57, 0x92, 0xdb, 0x50, 0x1e, 0xf0,
+	0x18, 0xdd, 0xaa, 0x19, 0x21, 0x8d, 0xbc, 0x61, 0xdb, 0xfb, 0x3c, 0x46, 0xdf, 0xa8, 0x74, 0xd1,
+	0x45, 0x66, 0xce, 0xc6, 0x01, 0x55, 0x6e, 0xed, 0xf2, 0xa2, 0xb3, 0xec, 0x4d, 0xa5, 0x4d, 0x87,
+	0x69, 0x9c, 0x9b, 0xc2, 0xe5, 0xa6, 0x96, 0xbd, 0xa9, 0xc8, 0x1a, 0x2c, 0x48, 0x45, 0xd5, 0x50,
+	0xba, 0x8b, 0x76, 0x76, 0x1a, 0x89, 0xac, 0xc2, 0x3c, 0x0a, 0xc1, 0x85, 0x5b, 0x37, 0x70, 0x26,
+	0x10, 0x17, 0x2a, 0x68, 0xa6, 0x41, 0xec, 0x3a, 0xad, 0xc2, 0x46, 0xd5, 0xcf, 0x45, 0x9d, 0x31,
+	0x89, 0xe2, 0x98, 0x45, 0x18, 0xd0, 0x28, 0xe2, 0xc3, 0x44, 0xb9, 0x2b, 0xc6, 0x72, 0xc9, 0xc2,
+	0x9b, 0x19, 0x4a, 0x9a, 0x50, 0x3d, 0xa1, 0x22, 0x61, 0x49, 0x4f, 0xba, 0xa4, 0x55, 0xda, 0xa8,
+	0xf9, 0x63, 0xd9, 0x7b, 0x08, 0x65, 0x9d, 0x10, 0xe2, 0x40, 0xfd, 0xd5, 0xc1, 0xf3, 0x83, 0x17,
+	0xdf, 0x1e, 0x04, 0xfb, 0x2f, 0x76, 0x76, 0x9d, 0x39, 0xb2, 0x08, 0x95, 0xdd, 0x83, 0xcd, 0xad,
+	0xbd, 0xdd, 0x1d, 0xa7, 0x40, 0xea, 0x50, 0xdd, 0x79, 0xda, 0xcd, 0xa4, 0xe2, 0x83, 0x3f, 0xca,
+	0x00, 0xcf, 0x78, 0xd8, 0xcd, 0xdc, 0x90, 0x7d, 0xa8, 0x8d, 0xd7, 0x15, 0xb9, 0x6a, 0x5b, 0xe1,
+	0xfc, 0xfa, 0x6a, 0x8e, 0x07, 0xa6, 0xb7, 0xfe, 0xcb, 0xdf, 0xff, 0xfc, 0x56, 0xbc, 0xee, 0x11,
+	0xbd, 0xb3, 0x65, 0xe7, 0xf8, 0x7e, 0x88, 0x8a, 0xde, 0xef, 0xe8, 0x31, 0xfa, 0xb5, 0xde, 0x66,
+	0xe4, 0x31, 0x2c, 0x64, 0xdb, 0x8c, 0x10, 0x63, 0x74, 0x6e, 0xb5, 0xcd, 0x1e, 0x44, 0xae, 0xcd,
+	0x1e, 0xd4, 0xf9, 0x89, 0xc5, 0x3f, 0x93, 0x2e, 0x54, 0xf3, 0x31, 0x4e, 0x56, 0xdf, 0xb7, 0x42,
+	0x9a, 0x57, 0xa7, 0xd0, 0x6c, 0xd6, 0x7b, 0x4d, 0x73, 0xf2, 0x2a, 0x79, 0x4f, 0x88, 0x24, 0x84,
+	0xda, 0x78, 0x3a, 0xdb, 0xcb, 0x4e, 0x4f, 0xeb, 0xe6, 0xda, 0x4c, 0x21, 0xec, 0xea, 0xbf, 0x15,
+	0xde, 0x1d, 0x73, 0x6e, 0xcb, 0xfb, 0xe8, 0x82, 0x88, 0x3b, 0xd9, 0xd3, 0x12, 0x04, 0x38, 0x9b,
+	0xee, 0x24, 0xeb, 0xa2, 0x99, 0x71, 0x7f, 0xa1, 0x97, 0xbb, 0xc6, 0xcb, 0x6d, 0x6f, 0xfd, 0x22,
+	0x2f, 0x71, 0x76, 0x14, 0xf9, 0x01, 0x6a, 0xe3, 0x65, 0x64, 0xaf, 0x32, 0xbd, 0x9c, 0x2e, 0x74,
+	0x62, 0x93, 0xff, 0xc9, 0x45, 0xc9, 0xdf, 0x3a, 0xfc, 0x75, 0x73, 0xdf, 0xbf, 0x09, 0x95, 0x18,
+	0x8f, 0xe8, 0xb0, 0xaf, 0xc8, 0x0a, 0x59, 0x86, 0x46, 0x73, 0xd1, 0x78, 0xe9, 0x9a, 0x82, 0xff,
+	0x7e, 0x1d, 0x6e, 0xc1, 0xc2, 0x16, 0x52, 0x81, 0x82, 0x5c, 0xa9, 0x16, 0x9b, 0x0d, 0x3a, 0x54,
+	0xaf, 0xb9, 0x60, 0xa7, 0xe6, 0xcf, 0x50, 0xab, 0x18, 0xd6, 0x01, 0xc6, 0x84, 0xb9, 0x70, 0xc1,
+	0x84, 0xf0, 0xf0, 0xbf, 0x01, 0x00, 0xa2, 0xba, 0xae, 0x7e, 0x11, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -0,0 +1,746 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: lineage.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type LineageEvent_Type int32
+
+const (
+	LineageEvent_UNKNOWN_TYPE LineageEvent_Type = 0
+	// The execution consumed the artifact.
+	LineageEvent_INPUT LineageEvent_Type = 1
+	// The execution produced the artifact.
+	LineageEvent_OUTPUT LineageEvent_Type = 2
+)
+
+var LineageEvent_Type_name = map[int32]string{
+	0: "UNKNOWN_TYPE",
+	1: "INPUT",
+	2: "OUTPUT",
+}
+
+var LineageEvent_Type_value = map[string]int32{
+	"UNKNOWN_TYPE": 0,
+	"INPUT":        1,
+	"OUTPUT":       2,
+}
+
+func (x LineageEvent_Type) String() string {
+	return proto.EnumName(LineageEvent_Type_name, int32(x))
+}
+
+func (LineageEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{4, 0}
+}
+
+type GetRunLineageRequest struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRunLineageRequest) Reset()         { *m = GetRunLineageRequest{} }
+func (m *GetRunLineageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRunLineageRequest) ProtoMessage()    {}
+func (*GetRunLineageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{0}
+}
+
+func (m *GetRunLineageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunLineageRequest.Unmarshal(m, b)
+}
+func (m *GetRunLineageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunLineageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRunLineageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunLineageRequest.Merge(m, src)
+}
+func (m *GetRunLineageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRunLineageRequest.Size(m)
+}
+func (m *GetRunLineageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunLineageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunLineageRequest proto.InternalMessageInfo
+
+func (m *GetRunLineageRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type RunLineage struct {
+	Executions []*LineageExecution `protobuf:"bytes,1,rep,name=executions,proto3" json:"executions,omitempty"`
+	Artifacts  []*LineageArtifact  `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// The events linking the executions to the artifacts they consumed and
+	// produced.
+	Events               []*LineageEvent `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RunLineage) Reset()         { *m = RunLineage{} }
+func (m *RunLineage) String() string { return proto.CompactTextString(m) }
+func (*RunLineage) ProtoMessage()    {}
+func (*RunLineage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{1}
+}
+
+func (m *RunLineage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunLineage.Unmarshal(m, b)
+}
+func (m *RunLineage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunLineage.Marshal(b, m, deterministic)
+}
+func (m *RunLineage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunLineage.Merge(m, src)
+}
+func (m *RunLineage) XXX_Size() int {
+	return xxx_messageInfo_RunLineage.Size(m)
+}
+func (m *RunLineage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunLineage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunLineage proto.InternalMessageInfo
+
+func (m *RunLineage) GetExecutions() []*LineageExecution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+func (m *RunLineage) GetArtifacts() []*LineageArtifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+func (m *RunLineage) GetEvents() []*LineageEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type LineageExecution struct {
+	// The ID of the execution in ML Metadata.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The ID of the node of the step in the workflow of the run.
+	NodeId       string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DisplayName  string `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	TemplateName string `protobuf:"bytes,4,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	// The state of the execution: COMPLETE, FAILED, RUNNING, etc.
+	State                string               `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	StartedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *LineageExecution) Reset()         { *m = LineageExecution{} }
+func (m *LineageExecution) String() string { return proto.CompactTextString(m) }
+func (*LineageExecution) ProtoMessage()    {}
+func (*LineageExecution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{2}
+}
+
+func (m *LineageExecution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineageExecution.Unmarshal(m, b)
+}
+func (m *LineageExecution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineageExecution.Marshal(b, m, deterministic)
+}
+func (m *LineageExecution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineageExecution.Merge(m, src)
+}
+func (m *LineageExecution) XXX_Size() int {
+	return xxx_messageInfo_LineageExecution.Size(m)
+}
+func (m *LineageExecution) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineageExecution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LineageExecution proto.InternalMessageInfo
+
+func (m *LineageExecution) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *LineageExecution) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *LineageExecution) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *LineageExecution) GetTemplateName() string {
+	if m != nil {
+		return m.TemplateName
+	}
+	return ""
+}
+
+func (m *LineageExecution) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *LineageExecution) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *LineageExecution) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+type LineageArtifact struct {
+	// The ID of the artifact in ML Metadata.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name of the artifact in the outputs of the step that produced it.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The URI of the location the artifact is stored at, e.g. s3://bucket/key.
+	Uri                  string   `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LineageArtifact) Reset()         { *m = LineageArtifact{} }
+func (m *LineageArtifact) String() string { return proto.CompactTextString(m) }
+func (*LineageArtifact) ProtoMessage()    {}
+func (*LineageArtifact) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{3}
+}
+
+func (m *LineageArtifact) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineageArtifact.Unmarshal(m, b)
+}
+func (m *LineageArtifact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineageArtifact.Marshal(b, m, deterministic)
+}
+func (m *LineageArtifact) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineageArtifact.Merge(m, src)
+}
+func (m *LineageArtifact) XXX_Size() int {
+	return xxx_messageInfo_LineageArtifact.Size(m)
+}
+func (m *LineageArtifact) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineageArtifact.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LineageArtifact proto.InternalMessageInfo
+
+func (m *LineageArtifact) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *LineageArtifact) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LineageArtifact) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+type LineageEvent struct {
+	ExecutionId int64             `protobuf:"varint,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	ArtifactId  int64             `protobuf:"varint,2,opt,name=artifact_id,json=artifactId,proto3" json:"artifact_id,omitempty"`
+	Type        LineageEvent_Type `protobuf:"varint,3,opt,name=type,proto3,enum=api.LineageEvent_Type" json:"type,omitempty"`
+	// The name of the artifact in the inputs or outputs of the execution.
+	Path                 string   `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LineageEvent) Reset()         { *m = LineageEvent{} }
+func (m *LineageEvent) String() string { return proto.CompactTextString(m) }
+func (*LineageEvent) ProtoMessage()    {}
+func (*LineageEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{4}
+}
+
+func (m *LineageEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineageEvent.Unmarshal(m, b)
+}
+func (m *LineageEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineageEvent.Marshal(b, m, deterministic)
+}
+func (m *LineageEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineageEvent.Merge(m, src)
+}
+func (m *LineageEvent) XXX_Size() int {
+	return xxx_messageInfo_LineageEvent.Size(m)
+}
+func (m *LineageEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineageEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LineageEvent proto.InternalMessageInfo
+
+func (m *LineageEvent) GetExecutionId() int64 {
+	if m != nil {
+		return m.ExecutionId
+	}
+	return 0
+}
+
+func (m *LineageEvent) GetArtifactId() int64 {
+	if m != nil {
+		return m.ArtifactId
+	}
+	return 0
+}
+
+func (m *LineageEvent) GetType() LineageEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return LineageEvent_UNKNOWN_TYPE
+}
+
+func (m *LineageEvent) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type GetArtifactLineageRequest struct {
+	// The URI of the location the artifact is stored at, e.g. s3://bucket/key.
+	Uri string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	// The maximum number of steps walked upstream and downstream of the
+	// artifact. Defaults to 10.
+	Depth                int32    `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetArtifactLineageRequest) Reset()         { *m = GetArtifactLineageRequest{} }
+func (m *GetArtifactLineageRequest) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactLineageRequest) ProtoMessage()    {}
+func (*GetArtifactLineageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{5}
+}
+
+func (m *GetArtifactLineageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactLineageRequest.Unmarshal(m, b)
+}
+func (m *GetArtifactLineageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactLineageRequest.Marshal(b, m, deterministic)
+}
+func (m *GetArtifactLineageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactLineageRequest.Merge(m, src)
+}
+func (m *GetArtifactLineageRequest) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactLineageRequest.Size(m)
+}
+func (m *GetArtifactLineageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactLineageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArtifactLineageRequest proto.InternalMessageInfo
+
+func (m *GetArtifactLineageRequest) GetUri() string {
+	if m != nil {
+		return m.Uri
+	}
+	return ""
+}
+
+func (m *GetArtifactLineageRequest) GetDepth() int32 {
+	if m != nil {
+		return m.Depth
+	}
+	return 0
+}
+
+type ArtifactLineage struct {
+	// The edges of the graph upstream of the artifact.
+	Upstream []*ArtifactLineageEdge `protobuf:"bytes,1,rep,name=upstream,proto3" json:"upstream,omitempty"`
+	// The edges of the graph downstream of the artifact.
+	Downstream           []*ArtifactLineageEdge `protobuf:"bytes,2,rep,name=downstream,proto3" json:"downstream,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ArtifactLineage) Reset()         { *m = ArtifactLineage{} }
+func (m *ArtifactLineage) String() string { return proto.CompactTextString(m) }
+func (*ArtifactLineage) ProtoMessage()    {}
+func (*ArtifactLineage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{6}
+}
+
+func (m *ArtifactLineage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactLineage.Unmarshal(m, b)
+}
+func (m *ArtifactLineage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactLineage.Marshal(b, m, deterministic)
+}
+func (m *ArtifactLineage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactLineage.Merge(m, src)
+}
+func (m *ArtifactLineage) XXX_Size() int {
+	return xxx_messageInfo_ArtifactLineage.Size(m)
+}
+func (m *ArtifactLineage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactLineage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArtifactLineage proto.InternalMessageInfo
+
+func (m *ArtifactLineage) GetUpstream() []*ArtifactLineageEdge {
+	if m != nil {
+		return m.Upstream
+	}
+	return nil
+}
+
+func (m *ArtifactLineage) GetDownstream() []*ArtifactLineageEdge {
+	if m != nil {
+		return m.Downstream
+	}
+	return nil
+}
+
+// An edge of the lineage graph of an artifact, linking a step of a run to an
+// artifact it consumed or produced.
+type ArtifactLineageEdge struct {
+	ArtifactUri string `protobuf:"bytes,1,opt,name=artifact_uri,json=artifactUri,proto3" json:"artifact_uri,omitempty"`
+	// The name of the artifact in the inputs or outputs of the step.
+	ArtifactName string `protobuf:"bytes,2,opt,name=artifact_name,json=artifactName,proto3" json:"artifact_name,omitempty"`
+	RunId        string `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The ID of the node of the step in the workflow of the run.
+	NodeId string `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The display name of the step.
+	StepName             string            `protobuf:"bytes,5,opt,name=step_name,json=stepName,proto3" json:"step_name,omitempty"`
+	Type                 LineageEvent_Type `protobuf:"varint,6,opt,name=type,proto3,enum=api.LineageEvent_Type" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ArtifactLineageEdge) Reset()         { *m = ArtifactLineageEdge{} }
+func (m *ArtifactLineageEdge) String() string { return proto.CompactTextString(m) }
+func (*ArtifactLineageEdge) ProtoMessage()    {}
+func (*ArtifactLineageEdge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_31d926871a65201c, []int{7}
+}
+
+func (m *ArtifactLineageEdge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactLineageEdge.Unmarshal(m, b)
+}
+func (m *ArtifactLineageEdge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactLineageEdge.Marshal(b, m, deterministic)
+}
+func (m *ArtifactLineageEdge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactLineageEdge.Merge(m, src)
+}
+func (m *ArtifactLineageEdge) XXX_Size() int {
+	return xxx_messageInfo_ArtifactLineageEdge.Size(m)
+}
+func (m *ArtifactLineageEdge) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactLineageEdge.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArtifactLineageEdge proto.InternalMessageInfo
+
+func (m *ArtifactLineageEdge) GetArtifactUri() string {
+	if m != nil {
+		return m.ArtifactUri
+	}
+	return ""
+}
+
+func (m *ArtifactLineageEdge) GetArtifactName() string {
+	if m != nil {
+		return m.ArtifactName
+	}
+	return ""
+}
+
+func (m *ArtifactLineageEdge) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ArtifactLineageEdge) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ArtifactLineageEdge) GetStepName() string {
+	if m != nil {
+		return m.StepName
+	}
+	return ""
+}
+
+func (m *ArtifactLineageEdge) GetType() LineageEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return LineageEvent_UNKNOWN_TYPE
+}
+
+func init() {
+	proto.RegisterEnum("api.LineageEvent_Type", LineageEvent_Type_name, LineageEvent_Type_value)
+	proto.RegisterType((*GetRunLineageRequest)(nil), "api.GetRunLineageRequest")
+	proto.RegisterType((*RunLineage)(nil), "api.RunLineage")
+	proto.RegisterType((*LineageExecution)(nil), "api.LineageExecution")
+	proto.RegisterType((*LineageArtifact)(nil), "api.LineageArtifact")
+	proto.RegisterType((*LineageEvent)(nil), "api.LineageEvent")
+	proto.RegisterType((*GetArtifactLineageRequest)(nil), "api.GetArtifactLineageRequest")
+	proto.RegisterType((*ArtifactLineage)(nil), "api.ArtifactLineage")
+	proto.RegisterType((*ArtifactLineageEdge)(nil), "api.ArtifactLineageEdge")
+}
+
+func init() { proto.RegisterFile("lineage.proto", fileDescriptor_31d926871a65201c) }
+
+var fileDescriptor_31d926871a65201c = []byte{
+	// 787 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdb, 0x6e, 0xf3, 0x44,
+	0x10, 0xfe, 0x6d, 0x27, 0x6e, 0x33, 0x49, 0x9a, 0x74, 0x9b, 0x82, 0x1b, 0x0a, 0x49, 0x5d, 0x21,
+	0xca, 0xa1, 0xb1, 0x1a, 0x40, 0x02, 0x71, 0x95, 0xa2, 0xaa, 0x8a, 0x80, 0xb4, 0x72, 0x13, 0x21,
+	0xb8, 0x89, 0x36, 0xf1, 0x24, 0x59, 0x29, 0xb1, 0x8d, 0x77, 0xdd, 0x52, 0x10, 0x17, 0xf0, 0x06,
+	0xc0, 0x1b, 0xf0, 0x2a, 0x3c, 0x02, 0x12, 0x4f, 0xc0, 0x3d, 0xaf, 0xf0, 0xcb, 0xeb, 0x43, 0x8e,
+	0x52, 0xaf, 0xbc, 0x3b, 0xf3, 0xcd, 0x8c, 0xe7, 0xfb, 0x66, 0x07, 0xca, 0x73, 0xe6, 0x22, 0x9d,
+	0x62, 0xcb, 0x0f, 0x3c, 0xe1, 0x11, 0x8d, 0xfa, 0xac, 0x7e, 0x3a, 0xf5, 0xbc, 0xe9, 0x1c, 0x2d,
+	0xea, 0x33, 0x8b, 0xba, 0xae, 0x27, 0xa8, 0x60, 0x9e, 0xcb, 0x63, 0x48, 0xbd, 0x91, 0x78, 0xe5,
+	0x6d, 0x14, 0x4e, 0x2c, 0xc1, 0x16, 0xc8, 0x05, 0x5d, 0xf8, 0x09, 0xe0, 0x23, 0xf9, 0x19, 0x5f,
+	0x4e, 0xd1, 0xbd, 0xe4, 0x4f, 0x74, 0x3a, 0xc5, 0xc0, 0xf2, 0x7c, 0x99, 0x62, 0x3b, 0x9d, 0x79,
+	0x09, 0xb5, 0x5b, 0x14, 0x76, 0xe8, 0x7e, 0x1d, 0xff, 0x88, 0x8d, 0x3f, 0x84, 0xc8, 0x05, 0x39,
+	0x06, 0x3d, 0x08, 0xdd, 0x21, 0x73, 0x0c, 0xa5, 0xa9, 0x5c, 0x14, 0xec, 0x7c, 0x10, 0xba, 0x5d,
+	0xc7, 0xfc, 0x4b, 0x01, 0x58, 0x82, 0xc9, 0xa7, 0x00, 0xf8, 0x23, 0x8e, 0x43, 0x99, 0xd1, 0x50,
+	0x9a, 0xda, 0x45, 0xb1, 0x7d, 0xdc, 0xa2, 0x3e, 0x6b, 0x25, 0x88, 0x9b, 0xd4, 0x6b, 0xaf, 0x00,
+	0x49, 0x1b, 0x0a, 0x34, 0x10, 0x6c, 0x42, 0xc7, 0x82, 0x1b, 0xaa, 0x8c, 0xaa, 0xad, 0x46, 0x75,
+	0x12, 0xa7, 0xbd, 0x84, 0x91, 0xf7, 0x41, 0xc7, 0x47, 0x74, 0x05, 0x37, 0x34, 0x19, 0x70, 0xb8,
+	0x56, 0x26, 0xf2, 0xd8, 0x09, 0xc0, 0xfc, 0x5d, 0x85, 0xea, 0x66, 0x7d, 0x72, 0x00, 0x6a, 0xd2,
+	0x8c, 0x66, 0xab, 0xcc, 0x21, 0x6f, 0xc2, 0x9e, 0xeb, 0x39, 0x18, 0x75, 0xa8, 0xca, 0x0e, 0xf5,
+	0xe8, 0xda, 0x75, 0xc8, 0x19, 0x94, 0x1c, 0xc6, 0xfd, 0x39, 0x7d, 0x1e, 0xba, 0x74, 0x81, 0x86,
+	0x26, 0xbd, 0xc5, 0xc4, 0xd6, 0xa3, 0x0b, 0x24, 0xe7, 0x50, 0x16, 0xb8, 0xf0, 0xe7, 0x54, 0x60,
+	0x8c, 0xc9, 0x49, 0x4c, 0x29, 0x35, 0x4a, 0x50, 0x0d, 0xf2, 0x5c, 0x50, 0x81, 0x46, 0x3e, 0x26,
+	0x50, 0x5e, 0xc8, 0xe7, 0x00, 0x5c, 0xd0, 0x40, 0xa0, 0x33, 0xa4, 0xc2, 0xd0, 0x9b, 0xca, 0x45,
+	0xb1, 0x5d, 0x6f, 0xc5, 0x9a, 0xb6, 0x52, 0x4d, 0x5b, 0xfd, 0x54, 0x53, 0xbb, 0x90, 0xa0, 0x3b,
+	0x82, 0x7c, 0x01, 0xc5, 0x09, 0x73, 0x19, 0x9f, 0xc5, 0xb1, 0x7b, 0x2f, 0xc6, 0x42, 0x0a, 0xef,
+	0x08, 0xf3, 0x16, 0x2a, 0x1b, 0xe4, 0x6e, 0x31, 0x42, 0x20, 0x27, 0x9b, 0x89, 0xe9, 0x90, 0x67,
+	0x52, 0x05, 0x2d, 0x0c, 0x58, 0xc2, 0x41, 0x74, 0x34, 0xff, 0x56, 0xa0, 0xb4, 0xca, 0x7a, 0xc4,
+	0x57, 0x26, 0xed, 0x30, 0x4b, 0x58, 0xcc, 0x6c, 0x5d, 0x87, 0x34, 0xa0, 0x98, 0x0a, 0x99, 0xf2,
+	0xad, 0xd9, 0x90, 0x9a, 0xba, 0x0e, 0xf9, 0x00, 0x72, 0xe2, 0xd9, 0x8f, 0xb9, 0x3e, 0x68, 0xbf,
+	0xb1, 0x25, 0x6d, 0xab, 0xff, 0xec, 0xa3, 0x2d, 0x31, 0xd1, 0x6f, 0xfa, 0x54, 0xcc, 0x12, 0xce,
+	0xe5, 0xd9, 0xb4, 0x20, 0x17, 0x21, 0x48, 0x15, 0x4a, 0x83, 0xde, 0x57, 0xbd, 0xbb, 0x6f, 0x7b,
+	0xc3, 0xfe, 0x77, 0xf7, 0x37, 0xd5, 0x57, 0xa4, 0x00, 0xf9, 0x6e, 0xef, 0x7e, 0xd0, 0xaf, 0x2a,
+	0x04, 0x40, 0xbf, 0x1b, 0xf4, 0xa3, 0xb3, 0x6a, 0x7e, 0x09, 0x27, 0xb7, 0x28, 0x52, 0x2a, 0x36,
+	0x66, 0x3f, 0x69, 0x5a, 0xc9, 0x9a, 0x8e, 0xb4, 0x74, 0xd0, 0x17, 0x33, 0xf9, 0xeb, 0x79, 0x3b,
+	0xbe, 0x98, 0xbf, 0x2a, 0x50, 0xd9, 0x48, 0x41, 0x3e, 0x81, 0xfd, 0xd0, 0xe7, 0x22, 0x40, 0xba,
+	0x48, 0xde, 0x83, 0x21, 0xbb, 0xd9, 0xc0, 0xdd, 0x38, 0x53, 0xb4, 0x33, 0x24, 0xf9, 0x0c, 0xc0,
+	0xf1, 0x9e, 0xdc, 0x24, 0x4e, 0x7d, 0x21, 0x6e, 0x05, 0x6b, 0xfe, 0xab, 0xc0, 0xd1, 0x0e, 0x4c,
+	0xa4, 0x4a, 0x46, 0xf9, 0xb2, 0x99, 0x4c, 0x86, 0x41, 0xc0, 0xa2, 0x29, 0xce, 0x20, 0x2b, 0xc2,
+	0x67, 0x71, 0x72, 0x8a, 0x97, 0x7b, 0x40, 0x5b, 0xd9, 0x03, 0xab, 0xaf, 0x27, 0xb7, 0xf6, 0x7a,
+	0xde, 0x82, 0x02, 0x17, 0xe8, 0xc7, 0x09, 0xe3, 0xc9, 0xdf, 0x8f, 0x0c, 0x32, 0x59, 0x2a, 0xb3,
+	0xfe, 0xb2, 0xcc, 0xed, 0xff, 0x15, 0x38, 0x48, 0x7c, 0x0f, 0x18, 0x3c, 0xb2, 0x31, 0x92, 0x19,
+	0x94, 0xd7, 0x76, 0x15, 0x39, 0x91, 0x19, 0x76, 0xed, 0xaf, 0x7a, 0x45, 0xba, 0x96, 0x76, 0xf3,
+	0xc3, 0xdf, 0xfe, 0xf9, 0xef, 0x4f, 0xf5, 0x5d, 0x72, 0x1e, 0xed, 0x55, 0x6e, 0x3d, 0x5e, 0x8d,
+	0x50, 0xd0, 0x2b, 0x2b, 0x08, 0x5d, 0x6e, 0xfd, 0x1c, 0xb7, 0xf8, 0x8b, 0x95, 0x6c, 0x63, 0xc2,
+	0x81, 0x6c, 0x8f, 0x07, 0x79, 0x27, 0x2d, 0xb7, 0x7b, 0x6e, 0xea, 0xb5, 0x5d, 0x8a, 0x99, 0xef,
+	0xc9, 0xc2, 0x67, 0xa4, 0xb1, 0x5e, 0x38, 0xdb, 0x6c, 0x69, 0xd1, 0xeb, 0xfb, 0x3f, 0x3a, 0xdf,
+	0x8c, 0x4a, 0x00, 0xa0, 0x5f, 0x23, 0x0d, 0x30, 0x20, 0xaf, 0xec, 0x53, 0xd8, 0x73, 0x70, 0x42,
+	0xc3, 0xb9, 0x20, 0x87, 0xa4, 0x02, 0xe5, 0x7a, 0x51, 0x96, 0x78, 0x10, 0x54, 0x84, 0xfc, 0xfb,
+	0x06, 0xbc, 0x9d, 0x61, 0x8f, 0xf6, 0xd5, 0xa6, 0x5a, 0x2f, 0xd3, 0x50, 0xcc, 0xbc, 0x80, 0xfd,
+	0x24, 0x77, 0xfc, 0x48, 0x97, 0x4b, 0xe1, 0xe3, 0xd7, 0x03, 0x00, 0xad, 0xf7, 0x68, 0xd5, 0x66,
+	0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// LineageServiceClient is the client API for LineageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type LineageServiceClient interface {
+	// Returns the executions of the steps of a run, and the artifacts they
+	// consumed and produced, as recorded in ML Metadata when the run completed.
+	GetRunLineage(ctx context.Context, in *GetRunLineageRequest, opts ...grpc.CallOption) (*RunLineage, error)
+	// Returns the lineage graph of an artifact, as indexed from the status of
+	// the workflows of the runs: the steps that produced it and the artifacts
+	// they consumed, and the steps that consumed it and the artifacts they
+	// produced, recursively.
+	GetArtifactLineage(ctx context.Context, in *GetArtifactLineageRequest, opts ...grpc.CallOption) (*ArtifactLineage, error)
+}
+
+type lineageServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLineageServiceClient(cc *grpc.ClientConn) LineageServiceClient {
+	return &lineageServiceClient{cc}
+}
+
+func (c *lineageServiceClient) GetRunLineage(ctx context.Context, in *GetRunLineageRequest, opts ...grpc.CallOption) (*RunLineage, error) {
+	out := new(RunLineage)
+	err := c.cc.Invoke(ctx, "/api.LineageService/GetRunLineage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lineageServiceClient) GetArtifactLineage(ctx context.Context, in *GetArtifactLineageRequest, opts ...grpc.CallOption) (*ArtifactLineage, error) {
+	out := new(ArtifactLineage)
+	err := c.cc.Invoke(ctx, "/api.LineageService/GetArtifactLineage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LineageServiceServer is the server API for LineageService service.
+type LineageServiceServer interface {
+	// Returns the executions of the steps of a run, and the artifacts they
+	// consumed and produced, as recorded in ML Metadata when the run completed.
+	GetRunLineage(context.Context, *GetRunLineageRequest) (*RunLineage, error)
+	// Returns the lineage graph of an artifact, as indexed from the status of
+	// the workflows of the runs: the steps that produced it and the artifacts
+	// they consumed, and the steps that consumed it and the artifacts they
+	// produced, recursively.
+	GetArtifactLineage(context.Context, *GetArtifactLineageRequest) (*ArtifactLineage, error)
+}
+
+func RegisterLineageServiceServer(s *grpc.Server, srv LineageServiceServer) {
+	s.RegisterService(&_LineageService_serviceDesc, srv)
+}
+
+func _LineageService_GetRunLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunLineageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LineageServiceServer).GetRunLineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LineageService/GetRunLineage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LineageServiceServer).GetRunLineage(ctx, req.(*GetRunLineageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LineageService_GetArtifactLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactLineageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LineageServiceServer).GetArtifactLineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.LineageService/GetArtifactLineage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LineageServiceServer).GetArtifactLineage(ctx, req.(*GetArtifactLineageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _LineageService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.LineageService",
+	HandlerType: (*LineageServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRunLineage",
+			Handler:    _LineageService_GetRunLineage_Handler,
+		},
+		{
+			MethodName: "GetArtifactLineage",
+			Handler:    _LineageService_GetArtifactLineage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "lineage.proto",
+}
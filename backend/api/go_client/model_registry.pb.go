@@ -0,0 +1,464 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: model_registry.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type GetModelVersionRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetModelVersionRequest) Reset()         { *m = GetModelVersionRequest{} }
+func (m *GetModelVersionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetModelVersionRequest) ProtoMessage()    {}
+func (*GetModelVersionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2af05d1559878953, []int{0}
+}
+
+func (m *GetModelVersionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetModelVersionRequest.Unmarshal(m, b)
+}
+func (m *GetModelVersionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetModelVersionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetModelVersionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetModelVersionRequest.Merge(m, src)
+}
+func (m *GetModelVersionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetModelVersionRequest.Size(m)
+}
+func (m *GetModelVersionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetModelVersionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetModelVersionRequest proto.InternalMessageInfo
+
+func (m *GetModelVersionRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListModelVersionsRequest struct {
+	// Optional. Only the versions of this model are listed.
+	ModelName string `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize  int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Can be format of "field_name", "field_name asc" or "field_name des"
+	// Ascending by default.
+	SortBy               string   `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListModelVersionsRequest) Reset()         { *m = ListModelVersionsRequest{} }
+func (m *ListModelVersionsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListModelVersionsRequest) ProtoMessage()    {}
+func (*ListModelVersionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2af05d1559878953, []int{1}
+}
+
+func (m *ListModelVersionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListModelVersionsRequest.Unmarshal(m, b)
+}
+func (m *ListModelVersionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListModelVersionsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListModelVersionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListModelVersionsRequest.Merge(m, src)
+}
+func (m *ListModelVersionsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListModelVersionsRequest.Size(m)
+}
+func (m *ListModelVersionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListModelVersionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListModelVersionsRequest proto.InternalMessageInfo
+
+func (m *ListModelVersionsRequest) GetModelName() string {
+	if m != nil {
+		return m.ModelName
+	}
+	return ""
+}
+
+func (m *ListModelVersionsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListModelVersionsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListModelVersionsRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+type ListModelVersionsResponse struct {
+	ModelVersions        []*ModelVersion `protobuf:"bytes,1,rep,name=model_versions,json=modelVersions,proto3" json:"model_versions,omitempty"`
+	NextPageToken        string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListModelVersionsResponse) Reset()         { *m = ListModelVersionsResponse{} }
+func (m *ListModelVersionsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListModelVersionsResponse) ProtoMessage()    {}
+func (*ListModelVersionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2af05d1559878953, []int{2}
+}
+
+func (m *ListModelVersionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListModelVersionsResponse.Unmarshal(m, b)
+}
+func (m *ListModelVersionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListModelVersionsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListModelVersionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListModelVersionsResponse.Merge(m, src)
+}
+func (m *ListModelVersionsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListModelVersionsResponse.Size(m)
+}
+func (m *ListModelVersionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListModelVersionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListModelVersionsResponse proto.InternalMessageInfo
+
+func (m *ListModelVersionsResponse) GetModelVersions() []*ModelVersion {
+	if m != nil {
+		return m.ModelVersions
+	}
+	return nil
+}
+
+func (m *ListModelVersionsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ModelVersion struct {
+	// Output. Unique model version ID. Generated by API server.
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ModelName string `protobuf:"bytes,2,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	// The versions of a model are numbered from 1, in the order they are
+	// registered.
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// The URI of the location the model is stored at, e.g. s3://bucket/key.
+	ArtifactUri string `protobuf:"bytes,4,opt,name=artifact_uri,json=artifactUri,proto3" json:"artifact_uri,omitempty"`
+	// The name of the artifact in the outputs of the step that produced it.
+	ArtifactName string `protobuf:"bytes,5,opt,name=artifact_name,json=artifactName,proto3" json:"artifact_name,omitempty"`
+	// The run and the node of the step that produced the model.
+	RunId  string `protobuf:"bytes,6,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	NodeId string `protobuf:"bytes,7,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The metrics reported by the step that produced the model.
+	Metrics              []*RunMetric         `protobuf:"bytes,8,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ModelVersion) Reset()         { *m = ModelVersion{} }
+func (m *ModelVersion) String() string { return proto.CompactTextString(m) }
+func (*ModelVersion) ProtoMessage()    {}
+func (*ModelVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2af05d1559878953, []int{3}
+}
+
+func (m *ModelVersion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelVersion.Unmarshal(m, b)
+}
+func (m *ModelVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelVersion.Marshal(b, m, deterministic)
+}
+func (m *ModelVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelVersion.Merge(m, src)
+}
+func (m *ModelVersion) XXX_Size() int {
+	return xxx_messageInfo_ModelVersion.Size(m)
+}
+func (m *ModelVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelVersion proto.InternalMessageInfo
+
+func (m *ModelVersion) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ModelVersion) GetModelName() string {
+	if m != nil {
+		return m.ModelName
+	}
+	return ""
+}
+
+func (m *ModelVersion) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *ModelVersion) GetArtifactUri() string {
+	if m != nil {
+		return m.ArtifactUri
+	}
+	return ""
+}
+
+func (m *ModelVersion) GetArtifactName() string {
+	if m != nil {
+		return m.ArtifactName
+	}
+	return ""
+}
+
+func (m *ModelVersion) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ModelVersion) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ModelVersion) GetMetrics() []*RunMetric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *ModelVersion) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetModelVersionRequest)(nil), "api.GetModelVersionRequest")
+	proto.RegisterType((*ListModelVersionsRequest)(nil), "api.ListModelVersionsRequest")
+	proto.RegisterType((*ListModelVersionsResponse)(nil), "api.ListModelVersionsResponse")
+	proto.RegisterType((*ModelVersion)(nil), "api.ModelVersion")
+}
+
+func init() { proto.RegisterFile("model_registry.proto", fileDescriptor_2af05d1559878953) }
+
+var fileDescriptor_2af05d1559878953 = []byte{
+	// 600 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xdd, 0x4e, 0xd4, 0x40,
+	0x14, 0xb6, 0x5d, 0xd9, 0x65, 0xcf, 0xb2, 0x10, 0x46, 0xd4, 0x5a, 0x58, 0x59, 0xeb, 0x4f, 0x1a,
+	0x23, 0x6d, 0xc0, 0x1b, 0xbd, 0x84, 0x1b, 0x43, 0x22, 0x86, 0x14, 0xf4, 0xc2, 0x9b, 0x66, 0x76,
+	0x7b, 0xa8, 0x13, 0xe9, 0x4c, 0x9d, 0x99, 0xa2, 0x8b, 0x21, 0x31, 0x3e, 0x80, 0x17, 0xfa, 0x2a,
+	0xbe, 0x89, 0xaf, 0xe0, 0x83, 0x98, 0x4e, 0x5b, 0x02, 0xbb, 0xe0, 0xd5, 0xee, 0xf9, 0xce, 0x37,
+	0xdf, 0x9c, 0xf3, 0xf5, 0x1b, 0x58, 0xc9, 0x44, 0x82, 0xc7, 0xb1, 0xc4, 0x94, 0x29, 0x2d, 0x27,
+	0x41, 0x2e, 0x85, 0x16, 0xa4, 0x45, 0x73, 0xe6, 0xae, 0xa5, 0x42, 0xa4, 0xc7, 0x18, 0xd2, 0x9c,
+	0x85, 0x94, 0x73, 0xa1, 0xa9, 0x66, 0x82, 0xab, 0x8a, 0xe2, 0xae, 0xd7, 0x5d, 0x53, 0x8d, 0x8a,
+	0xa3, 0x50, 0xb3, 0x0c, 0x95, 0xa6, 0x59, 0x5e, 0x13, 0xba, 0xb2, 0xe0, 0xf5, 0xdf, 0x67, 0xe6,
+	0x67, 0xbc, 0x91, 0x22, 0xdf, 0x50, 0x9f, 0x69, 0x9a, 0xa2, 0x0c, 0x45, 0x6e, 0xd4, 0x66, 0x95,
+	0x3d, 0x1f, 0xee, 0xbc, 0x42, 0xbd, 0x57, 0xce, 0xf5, 0x0e, 0xa5, 0x62, 0x82, 0x47, 0xf8, 0xa9,
+	0x40, 0xa5, 0xc9, 0x22, 0xd8, 0x2c, 0x71, 0xac, 0xa1, 0xe5, 0x77, 0x23, 0x9b, 0x25, 0xde, 0x0f,
+	0x0b, 0x9c, 0xd7, 0x4c, 0x5d, 0xe2, 0xaa, 0x86, 0x3c, 0x00, 0xa8, 0x76, 0xe3, 0x34, 0xc3, 0xfa,
+	0x50, 0xd7, 0x20, 0x6f, 0x68, 0x86, 0x65, 0x3b, 0xa7, 0x29, 0xc6, 0x5a, 0x7c, 0x44, 0xee, 0xd8,
+	0x55, 0xbb, 0x44, 0x0e, 0x4b, 0x80, 0xac, 0x82, 0x29, 0x62, 0xc5, 0x4e, 0xd1, 0x69, 0x0d, 0x2d,
+	0x7f, 0x2e, 0x9a, 0x2f, 0x81, 0x03, 0x76, 0x8a, 0xe4, 0x2e, 0x74, 0x94, 0x90, 0x3a, 0x1e, 0x4d,
+	0x9c, 0x9b, 0xe6, 0x60, 0xbb, 0x2c, 0x77, 0x26, 0xde, 0x19, 0xdc, 0xbb, 0x62, 0x1e, 0x95, 0x0b,
+	0xae, 0x90, 0xbc, 0x80, 0xc5, 0x6a, 0xa0, 0x93, 0xba, 0xe3, 0x58, 0xc3, 0x96, 0xdf, 0xdb, 0x5a,
+	0x0e, 0x68, 0xce, 0x82, 0x4b, 0xfb, 0xf6, 0xb3, 0x8b, 0x0a, 0xe4, 0x09, 0x2c, 0x71, 0xfc, 0xa2,
+	0xe3, 0x99, 0x81, 0xfb, 0x25, 0xbc, 0xdf, 0x0c, 0xed, 0xfd, 0xb6, 0x61, 0xe1, 0xa2, 0xce, 0xb4,
+	0x61, 0x53, 0x9e, 0xd8, 0xd3, 0x9e, 0x38, 0xd0, 0xa9, 0x67, 0x33, 0x2b, 0xb7, 0xa2, 0xa6, 0x24,
+	0x0f, 0x60, 0x81, 0x4a, 0xcd, 0x8e, 0xe8, 0x58, 0xc7, 0x85, 0x64, 0xf5, 0xda, 0xbd, 0x06, 0x7b,
+	0x2b, 0x19, 0x79, 0x08, 0xfd, 0x73, 0x8a, 0x91, 0x9f, 0x33, 0x9c, 0xf3, 0x73, 0xe6, 0x86, 0xdb,
+	0xd0, 0x96, 0x05, 0x8f, 0x59, 0xe2, 0xb4, 0x4d, 0x77, 0x4e, 0x16, 0x7c, 0x37, 0x29, 0x0d, 0xe5,
+	0x22, 0xc1, 0x12, 0xef, 0x54, 0x86, 0x96, 0xe5, 0x6e, 0x42, 0x7c, 0xe8, 0x64, 0xa8, 0x25, 0x1b,
+	0x2b, 0x67, 0xde, 0x98, 0xb5, 0x68, 0xcc, 0x8a, 0x0a, 0xbe, 0x67, 0xe0, 0xa8, 0x69, 0x93, 0x97,
+	0x00, 0x63, 0x89, 0x54, 0x63, 0x12, 0x53, 0xed, 0x74, 0x87, 0x96, 0xdf, 0xdb, 0x72, 0x83, 0x2a,
+	0xa4, 0x41, 0x13, 0xd2, 0xe0, 0xb0, 0x09, 0x69, 0xd4, 0xad, 0xd9, 0xdb, 0x7a, 0xeb, 0x9b, 0x0d,
+	0x2b, 0xc6, 0xb6, 0xa8, 0x7e, 0x05, 0x07, 0x28, 0x4f, 0xd8, 0x18, 0x49, 0x06, 0x4b, 0x53, 0x49,
+	0x24, 0xab, 0xe6, 0xfe, 0xab, 0xf3, 0xe9, 0xce, 0x7e, 0x49, 0xef, 0xe9, 0xf7, 0x3f, 0x7f, 0x7f,
+	0xd9, 0x8f, 0x88, 0x57, 0x3e, 0x23, 0x15, 0x9e, 0x6c, 0x8e, 0x50, 0xd3, 0xcd, 0xd0, 0x78, 0xae,
+	0xc2, 0x26, 0x09, 0xe1, 0x57, 0x96, 0x9c, 0x91, 0x09, 0x2c, 0xcf, 0xa4, 0x87, 0x0c, 0x8c, 0xe6,
+	0x75, 0x29, 0x77, 0xef, 0x5f, 0xd7, 0xae, 0x42, 0xe7, 0x3d, 0x36, 0xf7, 0xaf, 0x93, 0xc1, 0x7f,
+	0xef, 0xdf, 0xd9, 0xff, 0xb9, 0xbd, 0x17, 0xad, 0x41, 0x27, 0xc1, 0x23, 0x5a, 0x1c, 0x6b, 0xb2,
+	0x4c, 0x96, 0xa0, 0xef, 0xf6, 0x8c, 0xf8, 0x81, 0xa6, 0xba, 0x50, 0xef, 0xd7, 0x61, 0x00, 0xed,
+	0x1d, 0xa4, 0x12, 0x25, 0xb9, 0x35, 0xb4, 0xdd, 0x3e, 0x2d, 0xf4, 0x07, 0x21, 0xd9, 0xa9, 0x79,
+	0xbc, 0xf3, 0xf6, 0x68, 0x01, 0xe0, 0x9c, 0x70, 0x63, 0xd4, 0x36, 0x9e, 0x3f, 0xff, 0x37, 0x00,
+	0xec, 0xaf, 0xb3, 0x1b, 0x61, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ModelRegistryServiceClient is the client API for ModelRegistryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ModelRegistryServiceClient interface {
+	GetModelVersion(ctx context.Context, in *GetModelVersionRequest, opts ...grpc.CallOption) (*ModelVersion, error)
+	// Lists the versions of the models registered from the output artifacts of
+	// the succeeded runs. The artifacts registered are declared in the
+	// pipelines.kubeflow.org/models annotation of the workflow.
+	ListModelVersions(ctx context.Context, in *ListModelVersionsRequest, opts ...grpc.CallOption) (*ListModelVersionsResponse, error)
+}
+
+type modelRegistryServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewModelRegistryServiceClient(cc *grpc.ClientConn) ModelRegistryServiceClient {
+	return &modelRegistryServiceClient{cc}
+}
+
+func (c *modelRegistryServiceClient) GetModelVersion(ctx context.Context, in *GetModelVersionRequest, opts ...grpc.CallOption) (*ModelVersion, error) {
+	out := new(ModelVersion)
+	err := c.cc.Invoke(ctx, "/api.ModelRegistryService/GetModelVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modelRegistryServiceClient) ListModelVersions(ctx context.Context, in *ListModelVersionsRequest, opts ...grpc.CallOption) (*ListModelVersionsResponse, error) {
+	out := new(ListModelVersionsResponse)
+	err := c.cc.Invoke(ctx, "/api.ModelRegistryService/ListModelVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ModelRegistryServiceServer is the server API for ModelRegistryService service.
+type ModelRegistryServiceServer interface {
+	GetModelVersion(context.Context, *GetModelVersionRequest) (*ModelVersion, error)
+	// Lists the versions of the models registered from the output artifacts of
+	// the succeeded runs. The artifacts registered are declared in the
+	// pipelines.kubeflow.org/models annotation of the workflow.
+	ListModelVersions(context.Context, *ListModelVersionsRequest) (*ListModelVersionsResponse, error)
+}
+
+func RegisterModelRegistryServiceServer(s *grpc.Server, srv ModelRegistryServiceServer) {
+	s.RegisterService(&_ModelRegistryService_serviceDesc, srv)
+}
+
+func _ModelRegistryService_GetModelVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetModelVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryServiceServer).GetModelVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ModelRegistryService/GetModelVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryServiceServer).GetModelVersion(ctx, req.(*GetModelVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModelRegistryService_ListModelVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModelVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelRegistryServiceServer).ListModelVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ModelRegistryService/ListModelVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModelRegistryServiceServer).ListModelVersions(ctx, req.(*ListModelVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ModelRegistryService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.ModelRegistryService",
+	HandlerType: (*ModelRegistryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetModelVersion",
+			Handler:    _ModelRegistryService_GetModelVersion_Handler,
+		},
+		{
+			MethodName: "ListModelVersions",
+			Handler:    _ModelRegistryService_ListModelVersions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "model_registry.proto",
+}
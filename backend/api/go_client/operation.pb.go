@@ -0,0 +1,593 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: operation.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Operation_Type int32
+
+const (
+	Operation_TYPE_UNSPECIFIED Operation_Type = 0
+	// Indexes the steps of the ready pipelines again from their templates.
+	Operation_REINDEX_PIPELINES Operation_Type = 1
+	// Updates the status of the unfinished runs from their live workflows.
+	Operation_RECOMPUTE_RUN_STATUSES Operation_Type = 2
+)
+
+var Operation_Type_name = map[int32]string{
+	0: "TYPE_UNSPECIFIED",
+	1: "REINDEX_PIPELINES",
+	2: "RECOMPUTE_RUN_STATUSES",
+}
+
+var Operation_Type_value = map[string]int32{
+	"TYPE_UNSPECIFIED":       0,
+	"REINDEX_PIPELINES":      1,
+	"RECOMPUTE_RUN_STATUSES": 2,
+}
+
+func (x Operation_Type) String() string {
+	return proto.EnumName(Operation_Type_name, int32(x))
+}
+
+func (Operation_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{4, 0}
+}
+
+type Operation_State int32
+
+const (
+	Operation_STATE_UNSPECIFIED Operation_State = 0
+	Operation_RUNNING           Operation_State = 1
+	// Every resource was processed, some of them possibly with errors.
+	Operation_SUCCEEDED Operation_State = 2
+	// The operation couldn't be carried on, as described by the last error.
+	Operation_FAILED    Operation_State = 3
+	Operation_CANCELLED Operation_State = 4
+)
+
+var Operation_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "RUNNING",
+	2: "SUCCEEDED",
+	3: "FAILED",
+	4: "CANCELLED",
+}
+
+var Operation_State_value = map[string]int32{
+	"STATE_UNSPECIFIED": 0,
+	"RUNNING":           1,
+	"SUCCEEDED":         2,
+	"FAILED":            3,
+	"CANCELLED":         4,
+}
+
+func (x Operation_State) String() string {
+	return proto.EnumName(Operation_State_name, int32(x))
+}
+
+func (Operation_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{4, 1}
+}
+
+type GetOperationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetOperationRequest) Reset()         { *m = GetOperationRequest{} }
+func (m *GetOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetOperationRequest) ProtoMessage()    {}
+func (*GetOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{0}
+}
+
+func (m *GetOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetOperationRequest.Unmarshal(m, b)
+}
+func (m *GetOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetOperationRequest.Merge(m, src)
+}
+func (m *GetOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetOperationRequest.Size(m)
+}
+func (m *GetOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetOperationRequest proto.InternalMessageInfo
+
+func (m *GetOperationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListOperationsRequest struct {
+	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Can be format of "field_name", "field_name asc" or "field_name des"
+	// Ascending by default.
+	SortBy               string   `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOperationsRequest) Reset()         { *m = ListOperationsRequest{} }
+func (m *ListOperationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsRequest) ProtoMessage()    {}
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{1}
+}
+
+func (m *ListOperationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsRequest.Unmarshal(m, b)
+}
+func (m *ListOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsRequest.Merge(m, src)
+}
+func (m *ListOperationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsRequest.Size(m)
+}
+func (m *ListOperationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsRequest proto.InternalMessageInfo
+
+func (m *ListOperationsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListOperationsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListOperationsRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+type ListOperationsResponse struct {
+	Operations           []*Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	NextPageToken        string       `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListOperationsResponse) Reset()         { *m = ListOperationsResponse{} }
+func (m *ListOperationsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsResponse) ProtoMessage()    {}
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{2}
+}
+
+func (m *ListOperationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsResponse.Unmarshal(m, b)
+}
+func (m *ListOperationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsResponse.Merge(m, src)
+}
+func (m *ListOperationsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsResponse.Size(m)
+}
+func (m *ListOperationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsResponse proto.InternalMessageInfo
+
+func (m *ListOperationsResponse) GetOperations() []*Operation {
+	if m != nil {
+		return m.Operations
+	}
+	return nil
+}
+
+func (m *ListOperationsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type CancelOperationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOperationRequest) Reset()         { *m = CancelOperationRequest{} }
+func (m *CancelOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOperationRequest) ProtoMessage()    {}
+func (*CancelOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{3}
+}
+
+func (m *CancelOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOperationRequest.Unmarshal(m, b)
+}
+func (m *CancelOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOperationRequest.Merge(m, src)
+}
+func (m *CancelOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOperationRequest.Size(m)
+}
+func (m *CancelOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOperationRequest proto.InternalMessageInfo
+
+func (m *CancelOperationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Operation struct {
+	// Output. Unique operation ID. Generated by API server.
+	Id        string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type      Operation_Type       `protobuf:"varint,2,opt,name=type,proto3,enum=api.Operation_Type" json:"type,omitempty"`
+	State     Operation_State      `protobuf:"varint,3,opt,name=state,proto3,enum=api.Operation_State" json:"state,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The time the operation succeeded, failed or was cancelled, unset until
+	// then.
+	FinishedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// The number of resources to process, 0 until known, and the number of
+	// them processed so far, including the ones which failed. The total changes
+	// as resources are added or removed while the operation runs.
+	Total     int32 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	Processed int32 `protobuf:"varint,7,opt,name=processed,proto3" json:"processed,omitempty"`
+	// The errors of the first resources which failed to be processed.
+	Errors               []string `protobuf:"bytes,8,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Operation) Reset()         { *m = Operation{} }
+func (m *Operation) String() string { return proto.CompactTextString(m) }
+func (*Operation) ProtoMessage()    {}
+func (*Operation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_619dee0fded31cb3, []int{4}
+}
+
+func (m *Operation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Operation.Unmarshal(m, b)
+}
+func (m *Operation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Operation.Marshal(b, m, deterministic)
+}
+func (m *Operation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Operation.Merge(m, src)
+}
+func (m *Operation) XXX_Size() int {
+	return xxx_messageInfo_Operation.Size(m)
+}
+func (m *Operation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Operation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Operation proto.InternalMessageInfo
+
+func (m *Operation) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Operation) GetType() Operation_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Operation_TYPE_UNSPECIFIED
+}
+
+func (m *Operation) GetState() Operation_State {
+	if m != nil {
+		return m.State
+	}
+	return Operation_STATE_UNSPECIFIED
+}
+
+func (m *Operation) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Operation) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *Operation) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Operation) GetProcessed() int32 {
+	if m != nil {
+		return m.Processed
+	}
+	return 0
+}
+
+func (m *Operation) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.Operation_Type", Operation_Type_name, Operation_Type_value)
+	proto.RegisterEnum("api.Operation_State", Operation_State_name, Operation_State_value)
+	proto.RegisterType((*GetOperationRequest)(nil), "api.GetOperationRequest")
+	proto.RegisterType((*ListOperationsRequest)(nil), "api.ListOperationsRequest")
+	proto.RegisterType((*ListOperationsResponse)(nil), "api.ListOperationsResponse")
+	proto.RegisterType((*CancelOperationRequest)(nil), "api.CancelOperationRequest")
+	proto.RegisterType((*Operation)(nil), "api.Operation")
+}
+
+func init() { proto.RegisterFile("operation.proto", fileDescriptor_619dee0fded31cb3) }
+
+var fileDescriptor_619dee0fded31cb3 = []byte{
+	// 719 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xcf, 0x4e, 0xfb, 0x46,
+	0x10, 0xc6, 0xce, 0x3f, 0x32, 0x21, 0x89, 0x59, 0x20, 0xb5, 0x0c, 0x88, 0xc8, 0x2a, 0x6d, 0x8a,
+	0x8a, 0x2d, 0xd2, 0x53, 0xe9, 0x29, 0x24, 0x06, 0x45, 0x0a, 0x26, 0xb2, 0x13, 0xa9, 0xed, 0xc5,
+	0xda, 0x24, 0x4b, 0xb0, 0x08, 0x5e, 0xd7, 0xbb, 0xa1, 0x0d, 0x55, 0x2f, 0x7d, 0x84, 0xf6, 0xc9,
+	0xaa, 0x5e, 0x7b, 0xec, 0x83, 0x54, 0x5e, 0xe7, 0x0f, 0x84, 0xf4, 0xc7, 0x69, 0x35, 0x33, 0xdf,
+	0x7c, 0x33, 0xb3, 0xf3, 0x0d, 0x94, 0x69, 0x48, 0x22, 0xcc, 0x7d, 0x1a, 0x18, 0x61, 0x44, 0x39,
+	0x45, 0x29, 0x1c, 0xfa, 0xda, 0xd1, 0x98, 0xd2, 0xf1, 0x84, 0x98, 0x38, 0xf4, 0x4d, 0x1c, 0x04,
+	0x94, 0x0b, 0x04, 0x4b, 0x20, 0xda, 0xc9, 0x3c, 0x2a, 0xac, 0xc1, 0xf4, 0xde, 0xe4, 0xfe, 0x13,
+	0x61, 0x1c, 0x3f, 0x85, 0x73, 0xc0, 0xd7, 0xe2, 0x19, 0x9e, 0x8f, 0x49, 0x70, 0xce, 0x7e, 0xc6,
+	0xe3, 0x31, 0x89, 0x4c, 0x1a, 0x0a, 0x8a, 0xf7, 0x74, 0xfa, 0x29, 0xec, 0xdd, 0x10, 0x7e, 0xb7,
+	0xe8, 0xc3, 0x21, 0x3f, 0x4d, 0x09, 0xe3, 0xa8, 0x04, 0xb2, 0x3f, 0x52, 0xa5, 0xaa, 0x54, 0xcb,
+	0x3b, 0xb2, 0x3f, 0xd2, 0x27, 0x70, 0xd0, 0xf1, 0xd9, 0x0a, 0xc7, 0x16, 0xc0, 0x63, 0x80, 0x10,
+	0x8f, 0x89, 0xc7, 0xe9, 0x23, 0x09, 0xe6, 0x09, 0xf9, 0xd8, 0xd3, 0x8b, 0x1d, 0xe8, 0x10, 0x84,
+	0xe1, 0x31, 0xff, 0x85, 0xa8, 0x72, 0x55, 0xaa, 0x65, 0x9c, 0xed, 0xd8, 0xe1, 0xfa, 0x2f, 0x04,
+	0x7d, 0x06, 0x39, 0x46, 0x23, 0xee, 0x0d, 0x66, 0x6a, 0x4a, 0x24, 0x66, 0x63, 0xf3, 0x6a, 0xa6,
+	0x87, 0x50, 0x59, 0xaf, 0xc6, 0x42, 0x1a, 0x30, 0x82, 0x0c, 0x80, 0xe5, 0x9f, 0x31, 0x55, 0xaa,
+	0xa6, 0x6a, 0x85, 0x7a, 0xc9, 0xc0, 0xa1, 0x6f, 0xac, 0x46, 0x78, 0x85, 0x40, 0x5f, 0x40, 0x39,
+	0x20, 0xbf, 0x70, 0xef, 0x55, 0x8f, 0xb2, 0x28, 0x55, 0x8c, 0xdd, 0xdd, 0x45, 0x9f, 0x7a, 0x0d,
+	0x2a, 0x4d, 0x1c, 0x0c, 0xc9, 0xe4, 0xc3, 0x9f, 0xf8, 0x27, 0x05, 0xf9, 0x25, 0x68, 0x3d, 0x8a,
+	0xbe, 0x84, 0x34, 0x9f, 0x85, 0xc9, 0xa8, 0xa5, 0xfa, 0xde, 0xdb, 0xce, 0x8c, 0xde, 0x2c, 0x24,
+	0x8e, 0x00, 0xa0, 0x33, 0xc8, 0x30, 0x8e, 0x39, 0x11, 0x93, 0x97, 0xea, 0xfb, 0x6b, 0x48, 0x37,
+	0x8e, 0x39, 0x09, 0x04, 0x7d, 0x0b, 0x30, 0x8c, 0x08, 0xe6, 0x64, 0xe4, 0x61, 0xae, 0xa6, 0xab,
+	0x52, 0xad, 0x50, 0xd7, 0x8c, 0x44, 0x07, 0xc6, 0x42, 0x07, 0x46, 0x6f, 0xa1, 0x03, 0x27, 0x3f,
+	0x47, 0x37, 0x38, 0xfa, 0x0e, 0x0a, 0xf7, 0x7e, 0xe0, 0xb3, 0x87, 0x24, 0x37, 0xf3, 0x61, 0x2e,
+	0x2c, 0xe0, 0x0d, 0x8e, 0xf6, 0x21, 0xc3, 0x29, 0xc7, 0x13, 0x35, 0x2b, 0x16, 0x97, 0x18, 0xe8,
+	0x08, 0xf2, 0x61, 0x44, 0x87, 0x84, 0x31, 0x32, 0x52, 0x73, 0x22, 0xb2, 0x72, 0xa0, 0x0a, 0x64,
+	0x49, 0x14, 0xd1, 0x88, 0xa9, 0xdb, 0xd5, 0x54, 0xbc, 0xd2, 0xc4, 0xd2, 0xef, 0x20, 0x1d, 0x4f,
+	0x8f, 0xf6, 0x41, 0xe9, 0xfd, 0xd0, 0xb5, 0xbc, 0xbe, 0xed, 0x76, 0xad, 0x66, 0xfb, 0xba, 0x6d,
+	0xb5, 0x94, 0x2d, 0x74, 0x00, 0xbb, 0x8e, 0xd5, 0xb6, 0x5b, 0xd6, 0xf7, 0x5e, 0xb7, 0xdd, 0xb5,
+	0x3a, 0x6d, 0xdb, 0x72, 0x15, 0x09, 0x69, 0x50, 0x71, 0xac, 0xe6, 0xdd, 0x6d, 0xb7, 0xdf, 0xb3,
+	0x3c, 0xa7, 0x6f, 0x7b, 0x6e, 0xaf, 0xd1, 0xeb, 0xbb, 0x96, 0xab, 0xc8, 0x7a, 0x1f, 0x32, 0xe2,
+	0x93, 0xe2, 0xdc, 0xd8, 0xbd, 0x4e, 0x59, 0x80, 0x9c, 0xd3, 0xb7, 0xed, 0xb6, 0x7d, 0xa3, 0x48,
+	0xa8, 0x08, 0x79, 0xb7, 0xdf, 0x6c, 0x5a, 0x56, 0xcb, 0x6a, 0x29, 0x32, 0x02, 0xc8, 0x5e, 0x37,
+	0xda, 0x1d, 0xab, 0xa5, 0xa4, 0xe2, 0x50, 0xb3, 0x61, 0x37, 0xad, 0x4e, 0x6c, 0xa6, 0xeb, 0x7f,
+	0xc9, 0xa0, 0x2c, 0xd7, 0xe0, 0x92, 0xe8, 0xd9, 0x1f, 0x12, 0xe4, 0xc1, 0xce, 0xeb, 0x23, 0x41,
+	0xaa, 0xd8, 0xd6, 0x86, 0xbb, 0xd1, 0xd6, 0xb4, 0xa8, 0x9f, 0xfe, 0xfe, 0xf7, 0xbf, 0x7f, 0xca,
+	0x27, 0xe8, 0x38, 0xbe, 0x66, 0x66, 0x3e, 0x5f, 0x0c, 0x08, 0xc7, 0x17, 0xe6, 0x4a, 0xa1, 0xe6,
+	0xaf, 0xfe, 0xe8, 0x37, 0xf4, 0x08, 0xa5, 0xb7, 0x82, 0x47, 0x9a, 0x20, 0xda, 0x78, 0x73, 0xda,
+	0xe1, 0xc6, 0x58, 0x72, 0x21, 0x7a, 0x55, 0x54, 0xd4, 0x90, 0xfa, 0x7f, 0x15, 0x11, 0x85, 0xf2,
+	0x9a, 0xd6, 0x51, 0xc2, 0xb8, 0xf9, 0x02, 0xde, 0xcd, 0x64, 0x8a, 0x0a, 0x5f, 0xe9, 0x9f, 0x7f,
+	0x72, 0xa6, 0xcb, 0xa1, 0x60, 0xbb, 0x94, 0xce, 0xae, 0xba, 0x7f, 0x34, 0x6e, 0x9d, 0x23, 0xc8,
+	0x8d, 0xc8, 0x3d, 0x9e, 0x4e, 0x38, 0xda, 0x45, 0x65, 0x28, 0x6a, 0x05, 0x41, 0x1b, 0xef, 0x70,
+	0xca, 0x7e, 0x3c, 0x81, 0x63, 0xc8, 0x5e, 0x11, 0x1c, 0x91, 0x08, 0xed, 0x6d, 0xcb, 0x5a, 0x11,
+	0x4f, 0xf9, 0x03, 0x8d, 0xfc, 0x17, 0xc1, 0x58, 0x95, 0x07, 0x3b, 0x00, 0x4b, 0xc0, 0xd6, 0x20,
+	0x2b, 0x94, 0xfb, 0xcd, 0x7f, 0x03, 0x00, 0x14, 0x30, 0x85, 0x99, 0x41, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OperationServiceClient is the client API for OperationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OperationServiceClient interface {
+	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*Operation, error)
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	// Cancels a running operation. The resources already processed are left as
+	// they are.
+	CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*Operation, error)
+}
+
+type operationServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOperationServiceClient(cc *grpc.ClientConn) OperationServiceClient {
+	return &operationServiceClient{cc}
+}
+
+func (c *operationServiceClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*Operation, error) {
+	out := new(Operation)
+	err := c.cc.Invoke(ctx, "/api.OperationService/GetOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operationServiceClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, "/api.OperationService/ListOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *operationServiceClient) CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*Operation, error) {
+	out := new(Operation)
+	err := c.cc.Invoke(ctx, "/api.OperationService/CancelOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OperationServiceServer is the server API for OperationService service.
+type OperationServiceServer interface {
+	GetOperation(context.Context, *GetOperationRequest) (*Operation, error)
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	// Cancels a running operation. The resources already processed are left as
+	// they are.
+	CancelOperation(context.Context, *CancelOperationRequest) (*Operation, error)
+}
+
+func RegisterOperationServiceServer(s *grpc.Server, srv OperationServiceServer) {
+	s.RegisterService(&_OperationService_serviceDesc, srv)
+}
+
+func _OperationService_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperationServiceServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.OperationService/GetOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperationServiceServer).GetOperation(ctx, req.(*GetOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperationService_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperationServiceServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.OperationService/ListOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperationServiceServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OperationService_CancelOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OperationServiceServer).CancelOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.OperationService/CancelOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OperationServiceServer).CancelOperation(ctx, req.(*CancelOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OperationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.OperationService",
+	HandlerType: (*OperationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOperation",
+			Handler:    _OperationService_GetOperation_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _OperationService_ListOperations_Handler,
+		},
+		{
+			MethodName: "CancelOperation",
+			Handler:    _OperationService_CancelOperation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "operation.proto",
+}
@@ -34,6 +34,50 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ParameterSchema_Type int32
+
+const (
+	ParameterSchema_STRING  ParameterSchema_Type = 0
+	ParameterSchema_INTEGER ParameterSchema_Type = 1
+	ParameterSchema_FLOAT   ParameterSchema_Type = 2
+	ParameterSchema_BOOLEAN ParameterSchema_Type = 3
+	ParameterSchema_JSON    ParameterSchema_Type = 4
+	// A string restricted to the allowed values.
+	ParameterSchema_ENUM ParameterSchema_Type = 5
+	// A string whose value the clients shouldn't display. It has no default
+	// value in the workflow. The API server stores its values hashed and
+	// returns them masked.
+	ParameterSchema_SECRET ParameterSchema_Type = 6
+)
+
+var ParameterSchema_Type_name = map[int32]string{
+	0: "STRING",
+	1: "INTEGER",
+	2: "FLOAT",
+	3: "BOOLEAN",
+	4: "JSON",
+	5: "ENUM",
+	6: "SECRET",
+}
+
+var ParameterSchema_Type_value = map[string]int32{
+	"STRING":  0,
+	"INTEGER": 1,
+	"FLOAT":   2,
+	"BOOLEAN": 3,
+	"JSON":    4,
+	"ENUM":    5,
+	"SECRET":  6,
+}
+
+func (x ParameterSchema_Type) String() string {
+	return proto.EnumName(ParameterSchema_Type_name, int32(x))
+}
+
+func (ParameterSchema_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7aacf5f9506e2787, []int{1, 0}
+}
+
 type Parameter struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -81,18 +125,98 @@ func (m *Parameter) GetValue() string {
 	return ""
 }
 
+// The type and the validation rules of a parameter, as declared in the
+// pipelines.kubeflow.org/parameters annotation of the workflow.
+type ParameterSchema struct {
+	Name string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type ParameterSchema_Type `protobuf:"varint,2,opt,name=type,proto3,enum=api.ParameterSchema_Type" json:"type,omitempty"`
+	// The values the parameter is restricted to, if any.
+	AllowedValues []string `protobuf:"bytes,3,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
+	// Whether the parameter has no default value, so that the runs and the jobs
+	// must set it.
+	Required             bool     `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParameterSchema) Reset()         { *m = ParameterSchema{} }
+func (m *ParameterSchema) String() string { return proto.CompactTextString(m) }
+func (*ParameterSchema) ProtoMessage()    {}
+func (*ParameterSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7aacf5f9506e2787, []int{1}
+}
+
+func (m *ParameterSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParameterSchema.Unmarshal(m, b)
+}
+func (m *ParameterSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParameterSchema.Marshal(b, m, deterministic)
+}
+func (m *ParameterSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParameterSchema.Merge(m, src)
+}
+func (m *ParameterSchema) XXX_Size() int {
+	return xxx_messageInfo_ParameterSchema.Size(m)
+}
+func (m *ParameterSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParameterSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParameterSchema proto.InternalMessageInfo
+
+func (m *ParameterSchema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ParameterSchema) GetType() ParameterSchema_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ParameterSchema_STRING
+}
+
+func (m *ParameterSchema) GetAllowedValues() []string {
+	if m != nil {
+		return m.AllowedValues
+	}
+	return nil
+}
+
+func (m *ParameterSchema) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
 func init() {
+	proto.RegisterEnum("api.ParameterSchema_Type", ParameterSchema_Type_name, ParameterSchema_Type_value)
 	proto.RegisterType((*Parameter)(nil), "api.Parameter")
+	proto.RegisterType((*ParameterSchema)(nil), "api.ParameterSchema")
 }
 
 func init() { proto.RegisterFile("parameter.proto", fileDescriptor_7aacf5f9506e2787) }
 
 var fileDescriptor_7aacf5f9506e2787 = []byte{
-	// 89 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2f, 0x48, 0x2c, 0x4a,
-	0xcc, 0x4d, 0x2d, 0x49, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4e, 0x2c, 0xc8,
-	0x54, 0x32, 0xe5, 0xe2, 0x0c, 0x80, 0x89, 0x0b, 0x09, 0x71, 0xb1, 0xe4, 0x25, 0xe6, 0xa6, 0x4a,
-	0x30, 0x2a, 0x30, 0x6a, 0x70, 0x06, 0x81, 0xd9, 0x42, 0x22, 0x5c, 0xac, 0x65, 0x89, 0x39, 0xa5,
-	0xa9, 0x12, 0x4c, 0x60, 0x41, 0x08, 0x27, 0x89, 0x0d, 0x6c, 0x84, 0x31, 0x20, 0x00, 0x00, 0xff,
-	0xff, 0xc1, 0x58, 0x26, 0xb7, 0x55, 0x00, 0x00, 0x00,
+	// 252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x41, 0x4b, 0xc3, 0x30,
+	0x18, 0x86, 0xed, 0x9a, 0xd6, 0xf6, 0x13, 0xb7, 0xf0, 0xe1, 0xa1, 0x7a, 0x2a, 0x05, 0xa1, 0x17,
+	0x7b, 0x50, 0xfc, 0x01, 0x53, 0xe2, 0x98, 0xcc, 0x54, 0xd2, 0xa8, 0x47, 0x89, 0x2e, 0x60, 0xa1,
+	0xb5, 0x31, 0x76, 0xca, 0xfe, 0xb5, 0x3f, 0x41, 0x9a, 0xe9, 0x0e, 0xe2, 0xed, 0x7d, 0x1f, 0xf2,
+	0xbc, 0x81, 0x0f, 0x26, 0x46, 0x59, 0xd5, 0xea, 0x5e, 0xdb, 0xc2, 0xd8, 0xae, 0xef, 0xd0, 0x57,
+	0xa6, 0xce, 0xce, 0x21, 0xbe, 0xfd, 0xe5, 0x88, 0x40, 0x5e, 0x55, 0xab, 0x13, 0x2f, 0xf5, 0xf2,
+	0x58, 0xb8, 0x8c, 0x07, 0x10, 0x7c, 0xa8, 0x66, 0xa5, 0x93, 0x91, 0x83, 0x9b, 0x92, 0x7d, 0x79,
+	0x30, 0xd9, 0x7a, 0xd5, 0xf3, 0x8b, 0x6e, 0xd5, 0xbf, 0xf6, 0x09, 0x90, 0x7e, 0x6d, 0x36, 0xf2,
+	0xf8, 0xf4, 0xb0, 0x50, 0xa6, 0x2e, 0xfe, 0x78, 0x85, 0x5c, 0x1b, 0x2d, 0xdc, 0x33, 0x3c, 0x86,
+	0xb1, 0x6a, 0x9a, 0xee, 0x53, 0x2f, 0x1f, 0xdd, 0x3f, 0xef, 0x89, 0x9f, 0xfa, 0x79, 0x2c, 0xf6,
+	0x7f, 0xe8, 0xbd, 0x83, 0x78, 0x04, 0x91, 0xd5, 0x6f, 0xab, 0xda, 0xea, 0x65, 0x42, 0x52, 0x2f,
+	0x8f, 0xc4, 0xb6, 0x67, 0x0f, 0x40, 0x86, 0x41, 0x04, 0x08, 0x2b, 0x29, 0xe6, 0x7c, 0x46, 0x77,
+	0x70, 0x0f, 0x76, 0xe7, 0x5c, 0xb2, 0x19, 0x13, 0xd4, 0xc3, 0x18, 0x82, 0xab, 0x45, 0x39, 0x95,
+	0x74, 0x34, 0xf0, 0x8b, 0xb2, 0x5c, 0xb0, 0x29, 0xa7, 0x3e, 0x46, 0x40, 0xae, 0xab, 0x92, 0x53,
+	0x32, 0x24, 0xc6, 0xef, 0x6e, 0x68, 0xe0, 0x46, 0xd8, 0xa5, 0x60, 0x92, 0x86, 0x4f, 0xa1, 0xbb,
+	0xda, 0xd9, 0xf7, 0x00, 0x64, 0x10, 0x43, 0x21, 0x48, 0x01, 0x00, 0x00,
 }
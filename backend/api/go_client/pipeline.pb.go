@@ -40,6 +40,102 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type CreatePipelineRequest_OnConflict int32
+
+const (
+	// Fails with ALREADY_EXISTS.
+	CreatePipelineRequest_FAIL CreatePipelineRequest_OnConflict = 0
+	// Creates the pipeline under the name suffixed with the first free " (n)".
+	CreatePipelineRequest_AUTO_SUFFIX CreatePipelineRequest_OnConflict = 1
+	// Replaces the file of the existing pipeline, which keeps its ID. The pipeline is
+	// kept as is if the file is the same.
+	CreatePipelineRequest_NEW_VERSION CreatePipelineRequest_OnConflict = 2
+)
+
+var CreatePipelineRequest_OnConflict_name = map[int32]string{
+	0: "FAIL",
+	1: "AUTO_SUFFIX",
+	2: "NEW_VERSION",
+}
+
+var CreatePipelineRequest_OnConflict_value = map[string]int32{
+	"FAIL":        0,
+	"AUTO_SUFFIX": 1,
+	"NEW_VERSION": 2,
+}
+
+func (x CreatePipelineRequest_OnConflict) String() string {
+	return proto.EnumName(CreatePipelineRequest_OnConflict_name, int32(x))
+}
+
+func (CreatePipelineRequest_OnConflict) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{1, 0}
+}
+
+type PipelineDiff_Change int32
+
+const (
+	PipelineDiff_UNSPECIFIED PipelineDiff_Change = 0
+	// Only in the target pipeline.
+	PipelineDiff_ADDED PipelineDiff_Change = 1
+	// Only in the base pipeline.
+	PipelineDiff_REMOVED PipelineDiff_Change = 2
+	// In both pipelines, with differences.
+	PipelineDiff_MODIFIED PipelineDiff_Change = 3
+)
+
+var PipelineDiff_Change_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "ADDED",
+	2: "REMOVED",
+	3: "MODIFIED",
+}
+
+var PipelineDiff_Change_value = map[string]int32{
+	"UNSPECIFIED": 0,
+	"ADDED":       1,
+	"REMOVED":     2,
+	"MODIFIED":    3,
+}
+
+func (x PipelineDiff_Change) String() string {
+	return proto.EnumName(PipelineDiff_Change_name, int32(x))
+}
+
+func (PipelineDiff_Change) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{13, 0}
+}
+
+type PolicyViolation_Mode int32
+
+const (
+	PolicyViolation_UNSPECIFIED PolicyViolation_Mode = 0
+	// The violation is reported only.
+	PolicyViolation_WARN PolicyViolation_Mode = 1
+	// The pipelines with the violation are rejected at upload.
+	PolicyViolation_ENFORCE PolicyViolation_Mode = 2
+)
+
+var PolicyViolation_Mode_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "WARN",
+	2: "ENFORCE",
+}
+
+var PolicyViolation_Mode_value = map[string]int32{
+	"UNSPECIFIED": 0,
+	"WARN":        1,
+	"ENFORCE":     2,
+}
+
+func (x PolicyViolation_Mode) String() string {
+	return proto.EnumName(PolicyViolation_Mode_name, int32(x))
+}
+
+func (PolicyViolation_Mode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{20, 0}
+}
+
 type Url struct {
 	PipelineUrl          string   `protobuf:"bytes,1,opt,name=pipeline_url,json=pipelineUrl,proto3" json:"pipeline_url,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -83,11 +179,14 @@ func (m *Url) GetPipelineUrl() string {
 // and optionally a pipeline name. If name is not provided, file name is used as
 // pipeline name by default. Maximum size of 32MB is supported.
 type CreatePipelineRequest struct {
-	Url                  *Url     `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Url  *Url   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// What to do if a pipeline with the same name exists. The upload endpoint takes it as
+	// the on_conflict query parameter too.
+	OnConflict           CreatePipelineRequest_OnConflict `protobuf:"varint,3,opt,name=on_conflict,json=onConflict,proto3,enum=api.CreatePipelineRequest_OnConflict" json:"on_conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
 }
 
 func (m *CreatePipelineRequest) Reset()         { *m = CreatePipelineRequest{} }
@@ -129,6 +228,13 @@ func (m *CreatePipelineRequest) GetName() string {
 	return ""
 }
 
+func (m *CreatePipelineRequest) GetOnConflict() CreatePipelineRequest_OnConflict {
+	if m != nil {
+		return m.OnConflict
+	}
+	return CreatePipelineRequest_FAIL
+}
+
 type GetPipelineRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -173,10 +279,17 @@ type ListPipelinesRequest struct {
 	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	// Can be format of "field_name", "field_name asc" or "field_name des"
 	// Ascending by default.
-	SortBy               string   `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	SortBy string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// Lists only the pipelines starred by the authenticated user.
+	OnlyStarred bool `protobuf:"varint,4,opt,name=only_starred,json=onlyStarred,proto3" json:"only_starred,omitempty"`
+	// Excludes the deprecated pipelines.
+	ExcludeDeprecated bool `protobuf:"varint,5,opt,name=exclude_deprecated,json=excludeDeprecated,proto3" json:"exclude_deprecated,omitempty"`
+	// Lists only the pipelines with a matching name, e.g.
+	// name_filter.name=xgboost&name_filter.match=PREFIX
+	NameFilter           *NameFilter `protobuf:"bytes,6,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *ListPipelinesRequest) Reset()         { *m = ListPipelinesRequest{} }
@@ -225,6 +338,27 @@ func (m *ListPipelinesRequest) GetSortBy() string {
 	return ""
 }
 
+func (m *ListPipelinesRequest) GetOnlyStarred() bool {
+	if m != nil {
+		return m.OnlyStarred
+	}
+	return false
+}
+
+func (m *ListPipelinesRequest) GetExcludeDeprecated() bool {
+	if m != nil {
+		return m.ExcludeDeprecated
+	}
+	return false
+}
+
+func (m *ListPipelinesRequest) GetNameFilter() *NameFilter {
+	if m != nil {
+		return m.NameFilter
+	}
+	return nil
+}
+
 type ListPipelinesResponse struct {
 	Pipelines            []*Pipeline `protobuf:"bytes,1,rep,name=pipelines,proto3" json:"pipelines,omitempty"`
 	NextPageToken        string      `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
@@ -311,6 +445,180 @@ func (m *DeletePipelineRequest) GetId() string {
 	return ""
 }
 
+type StarPipelineRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StarPipelineRequest) Reset()         { *m = StarPipelineRequest{} }
+func (m *StarPipelineRequest) String() string { return proto.CompactTextString(m) }
+func (*StarPipelineRequest) ProtoMessage()    {}
+func (*StarPipelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{6}
+}
+
+func (m *StarPipelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StarPipelineRequest.Unmarshal(m, b)
+}
+func (m *StarPipelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StarPipelineRequest.Marshal(b, m, deterministic)
+}
+func (m *StarPipelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StarPipelineRequest.Merge(m, src)
+}
+func (m *StarPipelineRequest) XXX_Size() int {
+	return xxx_messageInfo_StarPipelineRequest.Size(m)
+}
+func (m *StarPipelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StarPipelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StarPipelineRequest proto.InternalMessageInfo
+
+func (m *StarPipelineRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type UnstarPipelineRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnstarPipelineRequest) Reset()         { *m = UnstarPipelineRequest{} }
+func (m *UnstarPipelineRequest) String() string { return proto.CompactTextString(m) }
+func (*UnstarPipelineRequest) ProtoMessage()    {}
+func (*UnstarPipelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{7}
+}
+
+func (m *UnstarPipelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnstarPipelineRequest.Unmarshal(m, b)
+}
+func (m *UnstarPipelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnstarPipelineRequest.Marshal(b, m, deterministic)
+}
+func (m *UnstarPipelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnstarPipelineRequest.Merge(m, src)
+}
+func (m *UnstarPipelineRequest) XXX_Size() int {
+	return xxx_messageInfo_UnstarPipelineRequest.Size(m)
+}
+func (m *UnstarPipelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnstarPipelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnstarPipelineRequest proto.InternalMessageInfo
+
+func (m *UnstarPipelineRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeprecatePipelineRequest struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The pipeline to use instead, if any.
+	ReplacementPipelineId string `protobuf:"bytes,2,opt,name=replacement_pipeline_id,json=replacementPipelineId,proto3" json:"replacement_pipeline_id,omitempty"`
+	// When the pipeline is no longer supported, if set.
+	SunsetAt             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeprecatePipelineRequest) Reset()         { *m = DeprecatePipelineRequest{} }
+func (m *DeprecatePipelineRequest) String() string { return proto.CompactTextString(m) }
+func (*DeprecatePipelineRequest) ProtoMessage()    {}
+func (*DeprecatePipelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{8}
+}
+
+func (m *DeprecatePipelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeprecatePipelineRequest.Unmarshal(m, b)
+}
+func (m *DeprecatePipelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeprecatePipelineRequest.Marshal(b, m, deterministic)
+}
+func (m *DeprecatePipelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeprecatePipelineRequest.Merge(m, src)
+}
+func (m *DeprecatePipelineRequest) XXX_Size() int {
+	return xxx_messageInfo_DeprecatePipelineRequest.Size(m)
+}
+func (m *DeprecatePipelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeprecatePipelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeprecatePipelineRequest proto.InternalMessageInfo
+
+func (m *DeprecatePipelineRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeprecatePipelineRequest) GetReplacementPipelineId() string {
+	if m != nil {
+		return m.ReplacementPipelineId
+	}
+	return ""
+}
+
+func (m *DeprecatePipelineRequest) GetSunsetAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.SunsetAt
+	}
+	return nil
+}
+
+type UndeprecatePipelineRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UndeprecatePipelineRequest) Reset()         { *m = UndeprecatePipelineRequest{} }
+func (m *UndeprecatePipelineRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeprecatePipelineRequest) ProtoMessage()    {}
+func (*UndeprecatePipelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{9}
+}
+
+func (m *UndeprecatePipelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UndeprecatePipelineRequest.Unmarshal(m, b)
+}
+func (m *UndeprecatePipelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UndeprecatePipelineRequest.Marshal(b, m, deterministic)
+}
+func (m *UndeprecatePipelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UndeprecatePipelineRequest.Merge(m, src)
+}
+func (m *UndeprecatePipelineRequest) XXX_Size() int {
+	return xxx_messageInfo_UndeprecatePipelineRequest.Size(m)
+}
+func (m *UndeprecatePipelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UndeprecatePipelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UndeprecatePipelineRequest proto.InternalMessageInfo
+
+func (m *UndeprecatePipelineRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 type GetTemplateRequest struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -322,7 +630,7 @@ func (m *GetTemplateRequest) Reset()         { *m = GetTemplateRequest{} }
 func (m *GetTemplateRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTemplateRequest) ProtoMessage()    {}
 func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7ac67a7adf3df9c7, []int{6}
+	return fileDescriptor_7ac67a7adf3df9c7, []int{10}
 }
 
 func (m *GetTemplateRequest) XXX_Unmarshal(b []byte) error {
@@ -361,7 +669,7 @@ func (m *GetTemplateResponse) Reset()         { *m = GetTemplateResponse{} }
 func (m *GetTemplateResponse) String() string { return proto.CompactTextString(m) }
 func (*GetTemplateResponse) ProtoMessage()    {}
 func (*GetTemplateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7ac67a7adf3df9c7, []int{7}
+	return fileDescriptor_7ac67a7adf3df9c7, []int{11}
 }
 
 func (m *GetTemplateResponse) XXX_Unmarshal(b []byte) error {
@@ -389,177 +697,1080 @@ func (m *GetTemplateResponse) GetTemplate() string {
 	return ""
 }
 
-type Pipeline struct {
-	Id          string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	Name        string               `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description string               `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Parameters  []*Parameter         `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
-	// In case any error happens retrieving a pipeline field, only pipeline ID
-	// and the error message is returned. Client has the flexibility of choosing
-	// how to handle error. This is especially useful during listing call.
-	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+type ComparePipelinesRequest struct {
+	// The pipeline compared against.
+	BaseId string `protobuf:"bytes,1,opt,name=base_id,json=baseId,proto3" json:"base_id,omitempty"`
+	// The pipeline whose changes are returned.
+	TargetId             string   `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Pipeline) Reset()         { *m = Pipeline{} }
-func (m *Pipeline) String() string { return proto.CompactTextString(m) }
-func (*Pipeline) ProtoMessage()    {}
-func (*Pipeline) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7ac67a7adf3df9c7, []int{8}
+func (m *ComparePipelinesRequest) Reset()         { *m = ComparePipelinesRequest{} }
+func (m *ComparePipelinesRequest) String() string { return proto.CompactTextString(m) }
+func (*ComparePipelinesRequest) ProtoMessage()    {}
+func (*ComparePipelinesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{12}
 }
 
-func (m *Pipeline) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Pipeline.Unmarshal(m, b)
+func (m *ComparePipelinesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComparePipelinesRequest.Unmarshal(m, b)
 }
-func (m *Pipeline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Pipeline.Marshal(b, m, deterministic)
+func (m *ComparePipelinesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComparePipelinesRequest.Marshal(b, m, deterministic)
 }
-func (m *Pipeline) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Pipeline.Merge(m, src)
+func (m *ComparePipelinesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComparePipelinesRequest.Merge(m, src)
 }
-func (m *Pipeline) XXX_Size() int {
-	return xxx_messageInfo_Pipeline.Size(m)
+func (m *ComparePipelinesRequest) XXX_Size() int {
+	return xxx_messageInfo_ComparePipelinesRequest.Size(m)
 }
-func (m *Pipeline) XXX_DiscardUnknown() {
-	xxx_messageInfo_Pipeline.DiscardUnknown(m)
+func (m *ComparePipelinesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComparePipelinesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Pipeline proto.InternalMessageInfo
+var xxx_messageInfo_ComparePipelinesRequest proto.InternalMessageInfo
 
-func (m *Pipeline) GetId() string {
+func (m *ComparePipelinesRequest) GetBaseId() string {
 	if m != nil {
-		return m.Id
+		return m.BaseId
 	}
 	return ""
 }
 
-func (m *Pipeline) GetCreatedAt() *timestamp.Timestamp {
+func (m *ComparePipelinesRequest) GetTargetId() string {
 	if m != nil {
-		return m.CreatedAt
+		return m.TargetId
 	}
-	return nil
+	return ""
 }
 
-func (m *Pipeline) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
+// The differences between the parameters and the steps of two pipelines. The
+// unchanged ones are omitted.
+type PipelineDiff struct {
+	Parameters           []*PipelineDiff_Parameter `protobuf:"bytes,1,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Steps                []*PipelineDiff_Step      `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
-func (m *Pipeline) GetDescription() string {
-	if m != nil {
-		return m.Description
-	}
-	return ""
+func (m *PipelineDiff) Reset()         { *m = PipelineDiff{} }
+func (m *PipelineDiff) String() string { return proto.CompactTextString(m) }
+func (*PipelineDiff) ProtoMessage()    {}
+func (*PipelineDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{13}
 }
 
-func (m *Pipeline) GetParameters() []*Parameter {
+func (m *PipelineDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PipelineDiff.Unmarshal(m, b)
+}
+func (m *PipelineDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PipelineDiff.Marshal(b, m, deterministic)
+}
+func (m *PipelineDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PipelineDiff.Merge(m, src)
+}
+func (m *PipelineDiff) XXX_Size() int {
+	return xxx_messageInfo_PipelineDiff.Size(m)
+}
+func (m *PipelineDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_PipelineDiff.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PipelineDiff proto.InternalMessageInfo
+
+func (m *PipelineDiff) GetParameters() []*PipelineDiff_Parameter {
 	if m != nil {
 		return m.Parameters
 	}
 	return nil
 }
 
-func (m *Pipeline) GetError() string {
+func (m *PipelineDiff) GetSteps() []*PipelineDiff_Step {
 	if m != nil {
-		return m.Error
+		return m.Steps
 	}
-	return ""
+	return nil
 }
 
-func init() {
-	proto.RegisterType((*Url)(nil), "api.Url")
-	proto.RegisterType((*CreatePipelineRequest)(nil), "api.CreatePipelineRequest")
-	proto.RegisterType((*GetPipelineRequest)(nil), "api.GetPipelineRequest")
-	proto.RegisterType((*ListPipelinesRequest)(nil), "api.ListPipelinesRequest")
-	proto.RegisterType((*ListPipelinesResponse)(nil), "api.ListPipelinesResponse")
-	proto.RegisterType((*DeletePipelineRequest)(nil), "api.DeletePipelineRequest")
-	proto.RegisterType((*GetTemplateRequest)(nil), "api.GetTemplateRequest")
-	proto.RegisterType((*GetTemplateResponse)(nil), "api.GetTemplateResponse")
-	proto.RegisterType((*Pipeline)(nil), "api.Pipeline")
+type PipelineDiff_Parameter struct {
+	Name   string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Change PipelineDiff_Change `protobuf:"varint,2,opt,name=change,proto3,enum=api.PipelineDiff_Change" json:"change,omitempty"`
+	// The default values of the parameter.
+	BaseValue            string   `protobuf:"bytes,3,opt,name=base_value,json=baseValue,proto3" json:"base_value,omitempty"`
+	TargetValue          string   `protobuf:"bytes,4,opt,name=target_value,json=targetValue,proto3" json:"target_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("pipeline.proto", fileDescriptor_7ac67a7adf3df9c7) }
-
-var fileDescriptor_7ac67a7adf3df9c7 = []byte{
-	// 682 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xcb, 0x52, 0x13, 0x41,
-	0x14, 0x35, 0x09, 0x84, 0xe4, 0x86, 0x24, 0x65, 0x03, 0x32, 0x0c, 0x20, 0x71, 0x8a, 0xc2, 0x94,
-	0xca, 0x4c, 0x11, 0x57, 0xba, 0x03, 0xb5, 0xd8, 0x68, 0x15, 0x15, 0x60, 0xa3, 0x8b, 0x54, 0x27,
-	0xb9, 0xc4, 0x96, 0xc9, 0x4c, 0xdb, 0xdd, 0x41, 0xc1, 0x72, 0xe3, 0x27, 0xe8, 0xc6, 0xdf, 0xb2,
-	0xfc, 0x05, 0x3f, 0xc4, 0x9a, 0x9e, 0xee, 0x90, 0x27, 0xab, 0xe4, 0x9e, 0x7b, 0xe6, 0xdc, 0xd7,
-	0x99, 0x81, 0x0a, 0x67, 0x1c, 0x43, 0x16, 0xa1, 0xcf, 0x45, 0xac, 0x62, 0x92, 0xa3, 0x9c, 0xb9,
-	0x5b, 0xbd, 0x38, 0xee, 0x85, 0x18, 0x50, 0xce, 0x02, 0x1a, 0x45, 0xb1, 0xa2, 0x8a, 0xc5, 0x91,
-	0x4c, 0x29, 0xee, 0x8e, 0xc9, 0xea, 0xa8, 0x3d, 0xb8, 0x08, 0x14, 0xeb, 0xa3, 0x54, 0xb4, 0xcf,
-	0x0d, 0x61, 0x73, 0x92, 0x80, 0x7d, 0xae, 0xae, 0x4d, 0xb2, 0xca, 0xa9, 0xa0, 0x7d, 0x54, 0x28,
-	0x0c, 0xf0, 0x4c, 0xff, 0x74, 0xf6, 0x7b, 0x18, 0xed, 0xcb, 0x2f, 0xb4, 0xd7, 0x43, 0x11, 0xc4,
-	0x5c, 0x17, 0x9c, 0x2e, 0xee, 0xd5, 0x21, 0x77, 0x2e, 0x42, 0xf2, 0x08, 0x96, 0x6d, 0xe3, 0xad,
-	0x81, 0x08, 0x9d, 0x4c, 0x2d, 0x53, 0x2f, 0x36, 0x4b, 0x16, 0x3b, 0x17, 0xa1, 0x77, 0x0c, 0x6b,
-	0xaf, 0x04, 0x52, 0x85, 0x27, 0x06, 0x6c, 0xe2, 0xe7, 0x01, 0x4a, 0x45, 0x5c, 0xc8, 0xd9, 0x47,
-	0x4a, 0x8d, 0x82, 0x4f, 0x39, 0xf3, 0xcf, 0x45, 0xd8, 0x4c, 0x40, 0x42, 0x60, 0x21, 0xa2, 0x7d,
-	0x74, 0xb2, 0x5a, 0x4f, 0xff, 0xf7, 0x76, 0x81, 0x1c, 0xa3, 0x9a, 0x54, 0xa9, 0x40, 0x96, 0x75,
-	0x4d, 0xdd, 0x2c, 0xeb, 0x7a, 0x97, 0xb0, 0xfa, 0x96, 0xc9, 0x21, 0x4d, 0x5a, 0xde, 0x36, 0x00,
-	0xa7, 0x3d, 0x6c, 0xa9, 0xf8, 0x12, 0x23, 0xc3, 0x2f, 0x26, 0xc8, 0x59, 0x02, 0x90, 0x4d, 0xd0,
-	0x41, 0x4b, 0xb2, 0x9b, 0xb4, 0xea, 0x62, 0xb3, 0x90, 0x00, 0xa7, 0xec, 0x06, 0xc9, 0x3a, 0x2c,
-	0xc9, 0x58, 0xa8, 0x56, 0xfb, 0xda, 0xc9, 0xe9, 0x07, 0xf3, 0x49, 0x78, 0x74, 0xed, 0x85, 0xb0,
-	0x36, 0x51, 0x4c, 0xf2, 0x38, 0x92, 0x48, 0x9e, 0x42, 0xd1, 0xee, 0x40, 0x3a, 0x99, 0x5a, 0xae,
-	0x5e, 0x6a, 0x94, 0xf5, 0x84, 0xc3, 0xf6, 0x6f, 0xf3, 0x64, 0x0f, 0xaa, 0x11, 0x7e, 0x55, 0xad,
-	0x91, 0xfe, 0xd2, 0xb9, 0xcb, 0x09, 0x7c, 0x62, 0x7b, 0xf4, 0x1e, 0xc3, 0xda, 0x6b, 0x0c, 0x71,
-	0x7a, 0x93, 0x93, 0x3b, 0x48, 0x37, 0x75, 0x86, 0x7d, 0x1e, 0x52, 0x35, 0x97, 0x75, 0x00, 0x2b,
-	0x63, 0x2c, 0xd3, 0xba, 0x0b, 0x05, 0x65, 0x30, 0x43, 0x1e, 0xc6, 0xde, 0x9f, 0x0c, 0x14, 0x6c,
-	0xf1, 0x49, 0x3d, 0xf2, 0x02, 0xa0, 0xa3, 0x0f, 0xdd, 0x6d, 0x51, 0xa5, 0x27, 0x28, 0x35, 0x5c,
-	0x3f, 0xf5, 0xa0, 0x6f, 0x3d, 0xe8, 0x9f, 0x59, 0x93, 0x36, 0x8b, 0x86, 0x7d, 0xa8, 0x86, 0xe7,
-	0xce, 0xdd, 0x9e, 0x9b, 0xd4, 0xa0, 0xd4, 0x45, 0xd9, 0x11, 0x4c, 0x7b, 0xd0, 0x59, 0x48, 0x9d,
-	0x35, 0x02, 0x11, 0x3f, 0x39, 0xa9, 0x31, 0xb1, 0x74, 0x16, 0xf5, 0x96, 0x2b, 0xe9, 0x96, 0x2d,
-	0xdc, 0x1c, 0x61, 0x90, 0x55, 0x58, 0x44, 0x21, 0x62, 0xe1, 0xe4, 0xb5, 0x56, 0x1a, 0x34, 0x7e,
-	0x2f, 0x40, 0xd5, 0xce, 0x74, 0x8a, 0xe2, 0x8a, 0x75, 0x90, 0x50, 0xa8, 0x8c, 0x7b, 0x96, 0xb8,
-	0x5a, 0x77, 0xa6, 0x91, 0xdd, 0xf1, 0xcb, 0x7a, 0xbb, 0x3f, 0xfe, 0xfe, 0xfb, 0x95, 0x7d, 0xe8,
-	0xad, 0x27, 0xef, 0xad, 0x0c, 0xae, 0x0e, 0xda, 0xa8, 0xe8, 0x41, 0x30, 0xbc, 0xf7, 0x4b, 0xed,
-	0xf0, 0x0f, 0x50, 0x1a, 0x71, 0x33, 0x59, 0xd7, 0x1a, 0xd3, 0xfe, 0x9e, 0x23, 0x4e, 0xb6, 0xe6,
-	0x88, 0x07, 0xdf, 0x58, 0xf7, 0x3b, 0xe9, 0x41, 0x79, 0xcc, 0x97, 0x64, 0x43, 0xab, 0xcc, 0x7a,
-	0x31, 0x5c, 0x77, 0x56, 0x2a, 0xf5, 0x82, 0xb7, 0xa3, 0xab, 0x6d, 0x90, 0x79, 0xa3, 0x90, 0x4f,
-	0x50, 0x19, 0xb7, 0xa4, 0x59, 0xd4, 0x4c, 0x9f, 0xba, 0x0f, 0xa6, 0xdc, 0xf0, 0x26, 0xf9, 0x22,
-	0xd9, 0xa1, 0x9e, 0xdc, 0x3d, 0x14, 0xd7, 0x1b, 0xb3, 0x7e, 0xbd, 0xdd, 0xd8, 0x84, 0xcf, 0x5d,
-	0x67, 0x3a, 0x61, 0xc6, 0xf1, 0x75, 0x9d, 0x3a, 0xd9, 0xbb, 0xab, 0x4e, 0x60, 0xdd, 0x2e, 0x8f,
-	0x4e, 0x7e, 0x1e, 0xbe, 0x6b, 0x6e, 0xc1, 0x52, 0x17, 0x2f, 0xe8, 0x20, 0x54, 0xe4, 0x3e, 0xa9,
-	0x42, 0xd9, 0x2d, 0x69, 0xfd, 0x53, 0x45, 0xd5, 0x40, 0xbe, 0xdf, 0x81, 0x6d, 0xc8, 0x1f, 0x21,
-	0x15, 0x28, 0xc8, 0x4a, 0x21, 0xeb, 0x96, 0xe9, 0x40, 0x7d, 0x8c, 0x05, 0xbb, 0xd1, 0x5f, 0xcb,
-	0x5a, 0xb6, 0xbd, 0x0c, 0x30, 0x24, 0xdc, 0x6b, 0xe7, 0xf5, 0xe4, 0xcf, 0xff, 0x07, 0x00, 0x00,
-	0xff, 0xff, 0x31, 0xe3, 0x02, 0xa4, 0xef, 0x05, 0x00, 0x00,
+func (m *PipelineDiff_Parameter) Reset()         { *m = PipelineDiff_Parameter{} }
+func (m *PipelineDiff_Parameter) String() string { return proto.CompactTextString(m) }
+func (*PipelineDiff_Parameter) ProtoMessage()    {}
+func (*PipelineDiff_Parameter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{13, 0}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *PipelineDiff_Parameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PipelineDiff_Parameter.Unmarshal(m, b)
+}
+func (m *PipelineDiff_Parameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PipelineDiff_Parameter.Marshal(b, m, deterministic)
+}
+func (m *PipelineDiff_Parameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PipelineDiff_Parameter.Merge(m, src)
+}
+func (m *PipelineDiff_Parameter) XXX_Size() int {
+	return xxx_messageInfo_PipelineDiff_Parameter.Size(m)
+}
+func (m *PipelineDiff_Parameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_PipelineDiff_Parameter.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_PipelineDiff_Parameter proto.InternalMessageInfo
 
-// PipelineServiceClient is the client API for PipelineService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PipelineServiceClient interface {
-	CreatePipeline(ctx context.Context, in *CreatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error)
-	GetPipeline(ctx context.Context, in *GetPipelineRequest, opts ...grpc.CallOption) (*Pipeline, error)
-	ListPipelines(ctx context.Context, in *ListPipelinesRequest, opts ...grpc.CallOption) (*ListPipelinesResponse, error)
-	DeletePipeline(ctx context.Context, in *DeletePipelineRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error)
+func (m *PipelineDiff_Parameter) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-type pipelineServiceClient struct {
-	cc *grpc.ClientConn
+func (m *PipelineDiff_Parameter) GetChange() PipelineDiff_Change {
+	if m != nil {
+		return m.Change
+	}
+	return PipelineDiff_UNSPECIFIED
 }
 
-func NewPipelineServiceClient(cc *grpc.ClientConn) PipelineServiceClient {
-	return &pipelineServiceClient{cc}
+func (m *PipelineDiff_Parameter) GetBaseValue() string {
+	if m != nil {
+		return m.BaseValue
+	}
+	return ""
 }
 
-func (c *pipelineServiceClient) CreatePipeline(ctx context.Context, in *CreatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error) {
+func (m *PipelineDiff_Parameter) GetTargetValue() string {
+	if m != nil {
+		return m.TargetValue
+	}
+	return ""
+}
+
+// A step is a template of the Argo workflow.
+type PipelineDiff_Step struct {
+	Name   string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Change PipelineDiff_Change `protobuf:"varint,2,opt,name=change,proto3,enum=api.PipelineDiff_Change" json:"change,omitempty"`
+	// The images of the container or script steps.
+	BaseImage   string `protobuf:"bytes,3,opt,name=base_image,json=baseImage,proto3" json:"base_image,omitempty"`
+	TargetImage string `protobuf:"bytes,4,opt,name=target_image,json=targetImage,proto3" json:"target_image,omitempty"`
+	// The fields of a modified step that changed, e.g. "image", "command" or
+	// "inputs".
+	ChangedFields        []string `protobuf:"bytes,5,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PipelineDiff_Step) Reset()         { *m = PipelineDiff_Step{} }
+func (m *PipelineDiff_Step) String() string { return proto.CompactTextString(m) }
+func (*PipelineDiff_Step) ProtoMessage()    {}
+func (*PipelineDiff_Step) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{13, 1}
+}
+
+func (m *PipelineDiff_Step) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PipelineDiff_Step.Unmarshal(m, b)
+}
+func (m *PipelineDiff_Step) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PipelineDiff_Step.Marshal(b, m, deterministic)
+}
+func (m *PipelineDiff_Step) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PipelineDiff_Step.Merge(m, src)
+}
+func (m *PipelineDiff_Step) XXX_Size() int {
+	return xxx_messageInfo_PipelineDiff_Step.Size(m)
+}
+func (m *PipelineDiff_Step) XXX_DiscardUnknown() {
+	xxx_messageInfo_PipelineDiff_Step.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PipelineDiff_Step proto.InternalMessageInfo
+
+func (m *PipelineDiff_Step) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PipelineDiff_Step) GetChange() PipelineDiff_Change {
+	if m != nil {
+		return m.Change
+	}
+	return PipelineDiff_UNSPECIFIED
+}
+
+func (m *PipelineDiff_Step) GetBaseImage() string {
+	if m != nil {
+		return m.BaseImage
+	}
+	return ""
+}
+
+func (m *PipelineDiff_Step) GetTargetImage() string {
+	if m != nil {
+		return m.TargetImage
+	}
+	return ""
+}
+
+func (m *PipelineDiff_Step) GetChangedFields() []string {
+	if m != nil {
+		return m.ChangedFields
+	}
+	return nil
+}
+
+type GetPipelineStepsRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPipelineStepsRequest) Reset()         { *m = GetPipelineStepsRequest{} }
+func (m *GetPipelineStepsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPipelineStepsRequest) ProtoMessage()    {}
+func (*GetPipelineStepsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{14}
+}
+
+func (m *GetPipelineStepsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPipelineStepsRequest.Unmarshal(m, b)
+}
+func (m *GetPipelineStepsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPipelineStepsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPipelineStepsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPipelineStepsRequest.Merge(m, src)
+}
+func (m *GetPipelineStepsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPipelineStepsRequest.Size(m)
+}
+func (m *GetPipelineStepsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPipelineStepsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPipelineStepsRequest proto.InternalMessageInfo
+
+func (m *GetPipelineStepsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// A step is a template of the Argo workflow.
+type PipelineStep struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The type of the template, e.g. "container", "script", "dag" or "steps".
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// The image of the container or script steps.
+	Image string `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	// The names of the templates the step invokes.
+	Children             []string `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PipelineStep) Reset()         { *m = PipelineStep{} }
+func (m *PipelineStep) String() string { return proto.CompactTextString(m) }
+func (*PipelineStep) ProtoMessage()    {}
+func (*PipelineStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{15}
+}
+
+func (m *PipelineStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PipelineStep.Unmarshal(m, b)
+}
+func (m *PipelineStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PipelineStep.Marshal(b, m, deterministic)
+}
+func (m *PipelineStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PipelineStep.Merge(m, src)
+}
+func (m *PipelineStep) XXX_Size() int {
+	return xxx_messageInfo_PipelineStep.Size(m)
+}
+func (m *PipelineStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_PipelineStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PipelineStep proto.InternalMessageInfo
+
+func (m *PipelineStep) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PipelineStep) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PipelineStep) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *PipelineStep) GetChildren() []string {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+type GetPipelineStepsResponse struct {
+	// The steps in the order of the workflow templates.
+	Steps                []*PipelineStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *GetPipelineStepsResponse) Reset()         { *m = GetPipelineStepsResponse{} }
+func (m *GetPipelineStepsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPipelineStepsResponse) ProtoMessage()    {}
+func (*GetPipelineStepsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{16}
+}
+
+func (m *GetPipelineStepsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPipelineStepsResponse.Unmarshal(m, b)
+}
+func (m *GetPipelineStepsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPipelineStepsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPipelineStepsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPipelineStepsResponse.Merge(m, src)
+}
+func (m *GetPipelineStepsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPipelineStepsResponse.Size(m)
+}
+func (m *GetPipelineStepsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPipelineStepsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPipelineStepsResponse proto.InternalMessageInfo
+
+func (m *GetPipelineStepsResponse) GetSteps() []*PipelineStep {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type GetPipelineReadmeRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPipelineReadmeRequest) Reset()         { *m = GetPipelineReadmeRequest{} }
+func (m *GetPipelineReadmeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPipelineReadmeRequest) ProtoMessage()    {}
+func (*GetPipelineReadmeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{17}
+}
+
+func (m *GetPipelineReadmeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPipelineReadmeRequest.Unmarshal(m, b)
+}
+func (m *GetPipelineReadmeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPipelineReadmeRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPipelineReadmeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPipelineReadmeRequest.Merge(m, src)
+}
+func (m *GetPipelineReadmeRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPipelineReadmeRequest.Size(m)
+}
+func (m *GetPipelineReadmeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPipelineReadmeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPipelineReadmeRequest proto.InternalMessageInfo
+
+func (m *GetPipelineReadmeRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type GetPipelineReadmeResponse struct {
+	// The sanitized README, in Markdown.
+	Readme               string   `protobuf:"bytes,1,opt,name=readme,proto3" json:"readme,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPipelineReadmeResponse) Reset()         { *m = GetPipelineReadmeResponse{} }
+func (m *GetPipelineReadmeResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPipelineReadmeResponse) ProtoMessage()    {}
+func (*GetPipelineReadmeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{18}
+}
+
+func (m *GetPipelineReadmeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPipelineReadmeResponse.Unmarshal(m, b)
+}
+func (m *GetPipelineReadmeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPipelineReadmeResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPipelineReadmeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPipelineReadmeResponse.Merge(m, src)
+}
+func (m *GetPipelineReadmeResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPipelineReadmeResponse.Size(m)
+}
+func (m *GetPipelineReadmeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPipelineReadmeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPipelineReadmeResponse proto.InternalMessageInfo
+
+func (m *GetPipelineReadmeResponse) GetReadme() string {
+	if m != nil {
+		return m.Readme
+	}
+	return ""
+}
+
+// Exactly one of the ID of an uploaded pipeline and a pipeline file is set.
+type ValidatePipelineRequest struct {
+	PipelineId string `protobuf:"bytes,1,opt,name=pipeline_id,json=pipelineId,proto3" json:"pipeline_id,omitempty"`
+	// The content of a pipeline file, as uploaded.
+	Template             string   `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatePipelineRequest) Reset()         { *m = ValidatePipelineRequest{} }
+func (m *ValidatePipelineRequest) String() string { return proto.CompactTextString(m) }
+func (*ValidatePipelineRequest) ProtoMessage()    {}
+func (*ValidatePipelineRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{19}
+}
+
+func (m *ValidatePipelineRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatePipelineRequest.Unmarshal(m, b)
+}
+func (m *ValidatePipelineRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatePipelineRequest.Marshal(b, m, deterministic)
+}
+func (m *ValidatePipelineRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatePipelineRequest.Merge(m, src)
+}
+func (m *ValidatePipelineRequest) XXX_Size() int {
+	return xxx_messageInfo_ValidatePipelineRequest.Size(m)
+}
+func (m *ValidatePipelineRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatePipelineRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatePipelineRequest proto.InternalMessageInfo
+
+func (m *ValidatePipelineRequest) GetPipelineId() string {
+	if m != nil {
+		return m.PipelineId
+	}
+	return ""
+}
+
+func (m *ValidatePipelineRequest) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+type PolicyViolation struct {
+	// The rule violated, e.g. "allowed_registries".
+	Rule string               `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Mode PolicyViolation_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=api.PolicyViolation_Mode" json:"mode,omitempty"`
+	// The template of the workflow violating the rule, if not the whole workflow.
+	Template             string   `protobuf:"bytes,3,opt,name=template,proto3" json:"template,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PolicyViolation) Reset()         { *m = PolicyViolation{} }
+func (m *PolicyViolation) String() string { return proto.CompactTextString(m) }
+func (*PolicyViolation) ProtoMessage()    {}
+func (*PolicyViolation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{20}
+}
+
+func (m *PolicyViolation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PolicyViolation.Unmarshal(m, b)
+}
+func (m *PolicyViolation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PolicyViolation.Marshal(b, m, deterministic)
+}
+func (m *PolicyViolation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PolicyViolation.Merge(m, src)
+}
+func (m *PolicyViolation) XXX_Size() int {
+	return xxx_messageInfo_PolicyViolation.Size(m)
+}
+func (m *PolicyViolation) XXX_DiscardUnknown() {
+	xxx_messageInfo_PolicyViolation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PolicyViolation proto.InternalMessageInfo
+
+func (m *PolicyViolation) GetRule() string {
+	if m != nil {
+		return m.Rule
+	}
+	return ""
+}
+
+func (m *PolicyViolation) GetMode() PolicyViolation_Mode {
+	if m != nil {
+		return m.Mode
+	}
+	return PolicyViolation_UNSPECIFIED
+}
+
+func (m *PolicyViolation) GetTemplate() string {
+	if m != nil {
+		return m.Template
+	}
+	return ""
+}
+
+func (m *PolicyViolation) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ValidatePipelineResponse struct {
+	Violations []*PolicyViolation `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	// Whether the pipeline can be uploaded, i.e. no enforced rule is violated.
+	Valid                bool     `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ValidatePipelineResponse) Reset()         { *m = ValidatePipelineResponse{} }
+func (m *ValidatePipelineResponse) String() string { return proto.CompactTextString(m) }
+func (*ValidatePipelineResponse) ProtoMessage()    {}
+func (*ValidatePipelineResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{21}
+}
+
+func (m *ValidatePipelineResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValidatePipelineResponse.Unmarshal(m, b)
+}
+func (m *ValidatePipelineResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValidatePipelineResponse.Marshal(b, m, deterministic)
+}
+func (m *ValidatePipelineResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidatePipelineResponse.Merge(m, src)
+}
+func (m *ValidatePipelineResponse) XXX_Size() int {
+	return xxx_messageInfo_ValidatePipelineResponse.Size(m)
+}
+func (m *ValidatePipelineResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidatePipelineResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidatePipelineResponse proto.InternalMessageInfo
+
+func (m *ValidatePipelineResponse) GetViolations() []*PolicyViolation {
+	if m != nil {
+		return m.Violations
+	}
+	return nil
+}
+
+func (m *ValidatePipelineResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+type GetPipelineStatsRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPipelineStatsRequest) Reset()         { *m = GetPipelineStatsRequest{} }
+func (m *GetPipelineStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPipelineStatsRequest) ProtoMessage()    {}
+func (*GetPipelineStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{22}
+}
+
+func (m *GetPipelineStatsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPipelineStatsRequest.Unmarshal(m, b)
+}
+func (m *GetPipelineStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPipelineStatsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPipelineStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPipelineStatsRequest.Merge(m, src)
+}
+func (m *GetPipelineStatsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPipelineStatsRequest.Size(m)
+}
+func (m *GetPipelineStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPipelineStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPipelineStatsRequest proto.InternalMessageInfo
+
+func (m *GetPipelineStatsRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type PipelineStats struct {
+	PipelineId string `protobuf:"bytes,1,opt,name=pipeline_id,json=pipelineId,proto3" json:"pipeline_id,omitempty"`
+	// The number of the finished runs aggregated, which succeeded or failed.
+	Runs       int32 `protobuf:"varint,2,opt,name=runs,proto3" json:"runs,omitempty"`
+	FailedRuns int32 `protobuf:"varint,3,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	// The ratio of the failed runs to the runs, between 0 and 1.
+	FailureRate float64 `protobuf:"fixed64,4,opt,name=failure_rate,json=failureRate,proto3" json:"failure_rate,omitempty"`
+	// The percentiles of the durations of the runs which succeeded.
+	P50DurationSeconds   int64    `protobuf:"varint,5,opt,name=p50_duration_seconds,json=p50DurationSeconds,proto3" json:"p50_duration_seconds,omitempty"`
+	P95DurationSeconds   int64    `protobuf:"varint,6,opt,name=p95_duration_seconds,json=p95DurationSeconds,proto3" json:"p95_duration_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PipelineStats) Reset()         { *m = PipelineStats{} }
+func (m *PipelineStats) String() string { return proto.CompactTextString(m) }
+func (*PipelineStats) ProtoMessage()    {}
+func (*PipelineStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{23}
+}
+
+func (m *PipelineStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PipelineStats.Unmarshal(m, b)
+}
+func (m *PipelineStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PipelineStats.Marshal(b, m, deterministic)
+}
+func (m *PipelineStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PipelineStats.Merge(m, src)
+}
+func (m *PipelineStats) XXX_Size() int {
+	return xxx_messageInfo_PipelineStats.Size(m)
+}
+func (m *PipelineStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PipelineStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PipelineStats proto.InternalMessageInfo
+
+func (m *PipelineStats) GetPipelineId() string {
+	if m != nil {
+		return m.PipelineId
+	}
+	return ""
+}
+
+func (m *PipelineStats) GetRuns() int32 {
+	if m != nil {
+		return m.Runs
+	}
+	return 0
+}
+
+func (m *PipelineStats) GetFailedRuns() int32 {
+	if m != nil {
+		return m.FailedRuns
+	}
+	return 0
+}
+
+func (m *PipelineStats) GetFailureRate() float64 {
+	if m != nil {
+		return m.FailureRate
+	}
+	return 0
+}
+
+func (m *PipelineStats) GetP50DurationSeconds() int64 {
+	if m != nil {
+		return m.P50DurationSeconds
+	}
+	return 0
+}
+
+func (m *PipelineStats) GetP95DurationSeconds() int64 {
+	if m != nil {
+		return m.P95DurationSeconds
+	}
+	return 0
+}
+
+type Pipeline struct {
+	Id          string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Name        string               `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string               `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Parameters  []*Parameter         `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// In case any error happens retrieving a pipeline field, only pipeline ID
+	// and the error message is returned. Client has the flexibility of choosing
+	// how to handle error. This is especially useful during listing call.
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Whether the pipeline is deprecated. The runs of a deprecated pipeline are
+	// created with a warning, and refused after its sunset if the server is
+	// configured so.
+	Deprecated bool `protobuf:"varint,7,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
+	// The pipeline to use instead of the deprecated pipeline, if any.
+	ReplacementPipelineId string `protobuf:"bytes,8,opt,name=replacement_pipeline_id,json=replacementPipelineId,proto3" json:"replacement_pipeline_id,omitempty"`
+	// When the deprecated pipeline is no longer supported, if set.
+	SunsetAt *timestamp.Timestamp `protobuf:"bytes,9,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	// The schema of the parameters declared by the pipeline, in the order of the
+	// declarations. The parameters of its runs and jobs are validated against
+	// it.
+	ParameterSchema      []*ParameterSchema `protobuf:"bytes,10,rep,name=parameter_schema,json=parameterSchema,proto3" json:"parameter_schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Pipeline) Reset()         { *m = Pipeline{} }
+func (m *Pipeline) String() string { return proto.CompactTextString(m) }
+func (*Pipeline) ProtoMessage()    {}
+func (*Pipeline) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ac67a7adf3df9c7, []int{24}
+}
+
+func (m *Pipeline) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pipeline.Unmarshal(m, b)
+}
+func (m *Pipeline) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pipeline.Marshal(b, m, deterministic)
+}
+func (m *Pipeline) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pipeline.Merge(m, src)
+}
+func (m *Pipeline) XXX_Size() int {
+	return xxx_messageInfo_Pipeline.Size(m)
+}
+func (m *Pipeline) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pipeline.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pipeline proto.InternalMessageInfo
+
+func (m *Pipeline) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Pipeline) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Pipeline) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Pipeline) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Pipeline) GetParameters() []*Parameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
+}
+
+func (m *Pipeline) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Pipeline) GetDeprecated() bool {
+	if m != nil {
+		return m.Deprecated
+	}
+	return false
+}
+
+func (m *Pipeline) GetReplacementPipelineId() string {
+	if m != nil {
+		return m.ReplacementPipelineId
+	}
+	return ""
+}
+
+func (m *Pipeline) GetSunsetAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.SunsetAt
+	}
+	return nil
+}
+
+func (m *Pipeline) GetParameterSchema() []*ParameterSchema {
+	if m != nil {
+		return m.ParameterSchema
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.CreatePipelineRequest_OnConflict", CreatePipelineRequest_OnConflict_name, CreatePipelineRequest_OnConflict_value)
+	proto.RegisterEnum("api.PipelineDiff_Change", PipelineDiff_Change_name, PipelineDiff_Change_value)
+	proto.RegisterEnum("api.PolicyViolation_Mode", PolicyViolation_Mode_name, PolicyViolation_Mode_value)
+	proto.RegisterType((*Url)(nil), "api.Url")
+	proto.RegisterType((*CreatePipelineRequest)(nil), "api.CreatePipelineRequest")
+	proto.RegisterType((*GetPipelineRequest)(nil), "api.GetPipelineRequest")
+	proto.RegisterType((*ListPipelinesRequest)(nil), "api.ListPipelinesRequest")
+	proto.RegisterType((*ListPipelinesResponse)(nil), "api.ListPipelinesResponse")
+	proto.RegisterType((*DeletePipelineRequest)(nil), "api.DeletePipelineRequest")
+	proto.RegisterType((*StarPipelineRequest)(nil), "api.StarPipelineRequest")
+	proto.RegisterType((*UnstarPipelineRequest)(nil), "api.UnstarPipelineRequest")
+	proto.RegisterType((*DeprecatePipelineRequest)(nil), "api.DeprecatePipelineRequest")
+	proto.RegisterType((*UndeprecatePipelineRequest)(nil), "api.UndeprecatePipelineRequest")
+	proto.RegisterType((*GetTemplateRequest)(nil), "api.GetTemplateRequest")
+	proto.RegisterType((*GetTemplateResponse)(nil), "api.GetTemplateResponse")
+	proto.RegisterType((*ComparePipelinesRequest)(nil), "api.ComparePipelinesRequest")
+	proto.RegisterType((*PipelineDiff)(nil), "api.PipelineDiff")
+	proto.RegisterType((*PipelineDiff_Parameter)(nil), "api.PipelineDiff.Parameter")
+	proto.RegisterType((*PipelineDiff_Step)(nil), "api.PipelineDiff.Step")
+	proto.RegisterType((*GetPipelineStepsRequest)(nil), "api.GetPipelineStepsRequest")
+	proto.RegisterType((*PipelineStep)(nil), "api.PipelineStep")
+	proto.RegisterType((*GetPipelineStepsResponse)(nil), "api.GetPipelineStepsResponse")
+	proto.RegisterType((*GetPipelineReadmeRequest)(nil), "api.GetPipelineReadmeRequest")
+	proto.RegisterType((*GetPipelineReadmeResponse)(nil), "api.GetPipelineReadmeResponse")
+	proto.RegisterType((*ValidatePipelineRequest)(nil), "api.ValidatePipelineRequest")
+	proto.RegisterType((*PolicyViolation)(nil), "api.PolicyViolation")
+	proto.RegisterType((*ValidatePipelineResponse)(nil), "api.ValidatePipelineResponse")
+	proto.RegisterType((*GetPipelineStatsRequest)(nil), "api.GetPipelineStatsRequest")
+	proto.RegisterType((*PipelineStats)(nil), "api.PipelineStats")
+	proto.RegisterType((*Pipeline)(nil), "api.Pipeline")
+}
+
+func init() { proto.RegisterFile("pipeline.proto", fileDescriptor_7ac67a7adf3df9c7) }
+
+var fileDescriptor_7ac67a7adf3df9c7 = []byte{
+	// 1778 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x72, 0xdb, 0xc8,
+	0x11, 0x36, 0x48, 0x8a, 0x22, 0x9b, 0x14, 0x45, 0x8d, 0x25, 0x0b, 0x86, 0x2d, 0x4b, 0xc6, 0xda,
+	0x6b, 0x59, 0xb6, 0x49, 0x59, 0x1b, 0x27, 0xb1, 0x72, 0xd8, 0x92, 0x45, 0x2a, 0xc5, 0xaa, 0xb5,
+	0xa4, 0x82, 0x2c, 0x6d, 0x2a, 0x39, 0xa0, 0x46, 0xc4, 0x90, 0x42, 0x16, 0x04, 0xb0, 0xc0, 0x50,
+	0xbb, 0xf2, 0x66, 0x2b, 0x55, 0x39, 0xe5, 0x1c, 0x1f, 0x73, 0xcc, 0x33, 0xe4, 0x92, 0x37, 0xc8,
+	0x21, 0xa7, 0xbc, 0x42, 0x8e, 0xa9, 0xca, 0x2b, 0xa4, 0xe6, 0x07, 0x10, 0x40, 0x10, 0x94, 0x0e,
+	0x7b, 0x22, 0xa7, 0xbb, 0xa7, 0x7b, 0xba, 0xe7, 0xeb, 0xc6, 0x37, 0xd0, 0xf0, 0x6d, 0x9f, 0x38,
+	0xb6, 0x4b, 0x5a, 0x7e, 0xe0, 0x51, 0x0f, 0x15, 0xb1, 0x6f, 0x6b, 0x35, 0x12, 0x04, 0x5e, 0x20,
+	0x24, 0x5a, 0x7d, 0x60, 0x3b, 0x94, 0x44, 0xab, 0x87, 0x43, 0xcf, 0x1b, 0x3a, 0xa4, 0x8d, 0x7d,
+	0xbb, 0x8d, 0x5d, 0xd7, 0xa3, 0x98, 0xda, 0x9e, 0x1b, 0x4a, 0xed, 0xba, 0xd4, 0xf2, 0xd5, 0xf9,
+	0x78, 0xd0, 0xa6, 0xf6, 0x88, 0x84, 0x14, 0x8f, 0x7c, 0x69, 0xf0, 0x60, 0xd2, 0x80, 0x8c, 0x7c,
+	0x7a, 0x25, 0x95, 0x8b, 0x3e, 0x0e, 0xf0, 0x88, 0x5c, 0x07, 0x7b, 0xc9, 0x7f, 0xfa, 0xaf, 0x86,
+	0xc4, 0x7d, 0x15, 0x7e, 0x87, 0x87, 0x43, 0x12, 0xb4, 0x3d, 0x9f, 0x07, 0xcc, 0x06, 0xd7, 0x37,
+	0xa1, 0x78, 0x1a, 0x38, 0xe8, 0x31, 0xd4, 0xa3, 0x9c, 0xcc, 0x71, 0xe0, 0xa8, 0xca, 0x86, 0xb2,
+	0x59, 0x35, 0x6a, 0x91, 0xec, 0x34, 0x70, 0xf4, 0x7f, 0x2a, 0xb0, 0xb2, 0x1f, 0x10, 0x4c, 0xc9,
+	0xb1, 0x94, 0x1a, 0xe4, 0xdb, 0x31, 0x09, 0x29, 0xd2, 0xa0, 0x18, 0xed, 0xa9, 0xed, 0x54, 0x5a,
+	0xd8, 0xb7, 0x5b, 0xa7, 0x81, 0x63, 0x30, 0x21, 0x42, 0x50, 0x72, 0xf1, 0x88, 0xa8, 0x05, 0xee,
+	0x90, 0xff, 0x47, 0x07, 0x50, 0xf3, 0x5c, 0xb3, 0xef, 0xb9, 0x03, 0xc7, 0xee, 0x53, 0xb5, 0xb8,
+	0xa1, 0x6c, 0x36, 0x76, 0x9e, 0xf2, 0x7d, 0x53, 0x03, 0xb4, 0x8e, 0xdc, 0x7d, 0x69, 0x6c, 0x80,
+	0x17, 0xff, 0xd7, 0x7f, 0x09, 0x70, 0xad, 0x41, 0x15, 0x28, 0x1d, 0xec, 0xf5, 0xbe, 0x6a, 0xde,
+	0x41, 0x8b, 0x50, 0xdb, 0x3b, 0xfd, 0x70, 0x64, 0x9e, 0x9c, 0x1e, 0x1c, 0xf4, 0x7e, 0xd3, 0x54,
+	0x98, 0xe0, 0xb0, 0xfb, 0xb5, 0x79, 0xd6, 0x35, 0x4e, 0x7a, 0x47, 0x87, 0xcd, 0x82, 0xfe, 0x04,
+	0xd0, 0xaf, 0x09, 0x9d, 0xcc, 0xa3, 0x01, 0x05, 0xdb, 0x92, 0xa9, 0x17, 0x6c, 0x4b, 0xff, 0x9f,
+	0x02, 0xcb, 0x5f, 0xd9, 0x61, 0x6c, 0x17, 0x46, 0x86, 0x6b, 0x00, 0x3e, 0x1e, 0x12, 0x93, 0x7a,
+	0xdf, 0x10, 0x57, 0x6e, 0xa8, 0x32, 0xc9, 0x07, 0x26, 0x40, 0x0f, 0x80, 0x2f, 0xcc, 0xd0, 0xfe,
+	0x28, 0x12, 0x9f, 0x33, 0x2a, 0x4c, 0x70, 0x62, 0x7f, 0x24, 0x68, 0x15, 0xe6, 0x43, 0x2f, 0xa0,
+	0xe6, 0xf9, 0x15, 0x4f, 0xbc, 0x6a, 0x94, 0xd9, 0xf2, 0xdd, 0x15, 0xbb, 0x02, 0xcf, 0x75, 0xae,
+	0xcc, 0x90, 0xe2, 0x20, 0x20, 0x96, 0x5a, 0xda, 0x50, 0x36, 0x2b, 0x46, 0x8d, 0xc9, 0x4e, 0x84,
+	0x08, 0xbd, 0x02, 0x44, 0xbe, 0xef, 0x3b, 0x63, 0x8b, 0x98, 0x16, 0xf1, 0x03, 0xd2, 0xc7, 0x94,
+	0x58, 0xea, 0x1c, 0x37, 0x5c, 0x92, 0x9a, 0x4e, 0xac, 0x40, 0xdb, 0x50, 0x63, 0xf5, 0x36, 0x05,
+	0x16, 0xd5, 0x32, 0xbf, 0x9f, 0x45, 0x5e, 0xe7, 0x43, 0x3c, 0x22, 0x07, 0x5c, 0x6c, 0x80, 0x1b,
+	0xff, 0xd7, 0x1d, 0x58, 0x99, 0x48, 0x38, 0xf4, 0x3d, 0x37, 0x24, 0xe8, 0x05, 0x54, 0x23, 0x2c,
+	0x84, 0xaa, 0xb2, 0x51, 0xdc, 0xac, 0xed, 0x2c, 0x70, 0x47, 0x71, 0x0d, 0xaf, 0xf5, 0xe8, 0x73,
+	0x58, 0x74, 0xc9, 0xf7, 0xd4, 0x4c, 0xd4, 0x48, 0x5c, 0xff, 0x02, 0x13, 0x1f, 0x47, 0x75, 0xd2,
+	0x9f, 0xc1, 0x4a, 0x87, 0x38, 0x84, 0x92, 0x9b, 0x2e, 0xe2, 0x29, 0xdc, 0x65, 0x25, 0xb8, 0xc9,
+	0xec, 0x19, 0xac, 0x9c, 0xba, 0xe1, 0x2d, 0x0c, 0xff, 0xaa, 0x80, 0x1a, 0xd7, 0xe9, 0x06, 0x63,
+	0xf4, 0x73, 0x58, 0x0d, 0x88, 0xef, 0xe0, 0x3e, 0x19, 0x11, 0x97, 0x9a, 0x71, 0x9b, 0xd8, 0x96,
+	0xcc, 0x6a, 0x25, 0xa1, 0x8e, 0x9c, 0xf5, 0x2c, 0xf4, 0x0b, 0xa8, 0x86, 0x63, 0x37, 0x24, 0xd4,
+	0xc4, 0x02, 0xe3, 0xb5, 0x1d, 0xad, 0x25, 0x3a, 0xb9, 0x15, 0x75, 0x72, 0xeb, 0x43, 0xd4, 0xea,
+	0x46, 0x45, 0x18, 0xef, 0x51, 0xfd, 0x25, 0x68, 0xa7, 0xae, 0x75, 0xcb, 0xe3, 0x49, 0x28, 0x7f,
+	0x20, 0x23, 0xdf, 0xc1, 0x34, 0xd7, 0xea, 0x35, 0xdc, 0x4d, 0x59, 0xc9, 0x6b, 0xd5, 0xa0, 0x42,
+	0xa5, 0x4c, 0x1a, 0xc7, 0x6b, 0xfd, 0x08, 0x56, 0xf7, 0xbd, 0x91, 0x8f, 0x03, 0x92, 0xc1, 0xff,
+	0x2a, 0xcc, 0x9f, 0xe3, 0x90, 0x97, 0x40, 0xec, 0x2a, 0xb3, 0x65, 0xcf, 0x62, 0xc8, 0xa7, 0x38,
+	0x18, 0x12, 0x7a, 0x5d, 0x9d, 0x8a, 0x10, 0xf4, 0x2c, 0xfd, 0xcf, 0x25, 0xa8, 0x47, 0xae, 0x3a,
+	0xf6, 0x60, 0x80, 0x7e, 0x05, 0x10, 0x0f, 0xaf, 0x08, 0x55, 0x0f, 0x52, 0xa8, 0x62, 0x66, 0xad,
+	0xe3, 0xc8, 0xc6, 0x48, 0x98, 0xa3, 0x97, 0x30, 0x17, 0x52, 0xe2, 0x87, 0x6a, 0x81, 0xef, 0xbb,
+	0x97, 0xdd, 0x77, 0x42, 0x89, 0x6f, 0x08, 0x23, 0xed, 0x93, 0x02, 0xd5, 0xd8, 0x4f, 0x3c, 0x94,
+	0x94, 0xc4, 0x50, 0xda, 0x86, 0x72, 0xff, 0x02, 0xbb, 0x43, 0xd1, 0xb1, 0x8d, 0x1d, 0x35, 0xeb,
+	0x70, 0x9f, 0xeb, 0x0d, 0x69, 0xc7, 0xa6, 0x00, 0xaf, 0xc2, 0x25, 0x76, 0xc6, 0x44, 0x36, 0x73,
+	0x95, 0x49, 0xce, 0x98, 0x80, 0xf5, 0xb3, 0xac, 0x85, 0x30, 0x28, 0x89, 0x91, 0x2a, 0x64, 0xdc,
+	0x44, 0xfb, 0xbb, 0x02, 0x25, 0x76, 0xca, 0x9f, 0xf8, 0x40, 0xf6, 0x08, 0x0f, 0x53, 0x07, 0xea,
+	0x31, 0x41, 0xe2, 0x40, 0xc2, 0x20, 0x75, 0x20, 0x61, 0xf2, 0x14, 0x1a, 0xc2, 0x97, 0x65, 0x0e,
+	0x6c, 0xe2, 0x58, 0xa1, 0x3a, 0xb7, 0x51, 0x64, 0x8d, 0x2b, 0xa5, 0x07, 0x5c, 0xa8, 0x7f, 0x09,
+	0x65, 0x11, 0x9a, 0x4d, 0xd6, 0xd3, 0xc3, 0x93, 0xe3, 0xee, 0x7e, 0xef, 0xa0, 0xd7, 0xed, 0x34,
+	0xef, 0xa0, 0x2a, 0xcc, 0xed, 0x75, 0x3a, 0xdd, 0x4e, 0x53, 0x41, 0x35, 0x98, 0x37, 0xba, 0xef,
+	0x8f, 0xce, 0xba, 0x9d, 0x66, 0x01, 0xd5, 0xa1, 0xf2, 0xfe, 0xa8, 0x23, 0xac, 0x8a, 0xfa, 0x73,
+	0x58, 0x4d, 0xcc, 0x5f, 0x56, 0x82, 0x30, 0x0f, 0xb9, 0x17, 0x50, 0x4f, 0xda, 0x4d, 0x2d, 0x15,
+	0x82, 0x12, 0xbd, 0xf2, 0xe3, 0x8f, 0x0c, 0xfb, 0x8f, 0x96, 0x61, 0x2e, 0x59, 0x07, 0xb1, 0x60,
+	0x80, 0xef, 0x5f, 0xd8, 0x8e, 0x15, 0x10, 0x57, 0x2d, 0xf1, 0xd4, 0xe2, 0xb5, 0xbe, 0x0f, 0x6a,
+	0xf6, 0x50, 0xb2, 0x51, 0x9e, 0x45, 0x68, 0x13, 0x28, 0x5d, 0x4a, 0xdd, 0x45, 0x02, 0x68, 0xfa,
+	0x56, 0xca, 0x89, 0x41, 0xb0, 0x35, 0xca, 0x6d, 0xca, 0x2f, 0xe0, 0xfe, 0x14, 0x5b, 0x19, 0xf1,
+	0x1e, 0x94, 0x03, 0x2e, 0x89, 0x5a, 0x4c, 0xac, 0xf4, 0x33, 0x58, 0x3d, 0xc3, 0x8e, 0x6d, 0x4d,
+	0x19, 0x0d, 0xeb, 0x50, 0x4b, 0x4e, 0x27, 0xb1, 0x0f, 0xfc, 0xeb, 0x91, 0x94, 0x6c, 0xf7, 0xc2,
+	0x44, 0xbb, 0xff, 0x43, 0x81, 0xc5, 0x63, 0xcf, 0xb1, 0xfb, 0x57, 0x67, 0xb6, 0xe7, 0x70, 0x8e,
+	0xc0, 0xea, 0x1a, 0x8c, 0x9d, 0xb8, 0xd6, 0xec, 0x3f, 0x7a, 0x05, 0xa5, 0x91, 0x67, 0x45, 0xa0,
+	0xbc, 0x2f, 0x0a, 0x91, 0xde, 0xd7, 0x7a, 0xef, 0x59, 0xc4, 0xe0, 0x66, 0xa9, 0x90, 0xc5, 0x74,
+	0x48, 0xa4, 0xc2, 0xfc, 0x88, 0x84, 0xe1, 0x35, 0x16, 0xa3, 0xa5, 0xde, 0x82, 0x12, 0xf3, 0x91,
+	0x85, 0x57, 0x05, 0x4a, 0x5f, 0xef, 0x19, 0x87, 0x02, 0x5d, 0xdd, 0xc3, 0x83, 0x23, 0x63, 0xbf,
+	0xdb, 0x2c, 0xe8, 0x03, 0x50, 0xb3, 0x45, 0x91, 0x85, 0xfc, 0x19, 0xc0, 0x65, 0x74, 0xb2, 0xe8,
+	0xfe, 0x96, 0xa7, 0x1d, 0xdb, 0x48, 0xd8, 0x31, 0xf8, 0x5c, 0x32, 0x8f, 0x3c, 0xcf, 0x8a, 0x21,
+	0x16, 0x19, 0xdc, 0x62, 0x9a, 0x8b, 0xdb, 0xff, 0x2a, 0xb0, 0x90, 0x32, 0xbc, 0xf9, 0x7a, 0x78,
+	0xb9, 0xdd, 0x50, 0x52, 0x06, 0xfe, 0x9f, 0x6d, 0x1a, 0x60, 0xdb, 0x21, 0x96, 0xc9, 0x55, 0x45,
+	0xae, 0x02, 0x21, 0x32, 0x98, 0xc1, 0x63, 0xa8, 0xb3, 0xd5, 0x38, 0x20, 0x66, 0x80, 0xa9, 0xa8,
+	0xa4, 0x62, 0xd4, 0xa4, 0xcc, 0x60, 0x75, 0xde, 0x86, 0x65, 0xff, 0xcd, 0xb6, 0x69, 0x8d, 0x03,
+	0x9e, 0x9c, 0x19, 0x92, 0xbe, 0xe7, 0xf2, 0xde, 0x56, 0x36, 0x8b, 0x06, 0xf2, 0xdf, 0x6c, 0x77,
+	0xa4, 0xea, 0x44, 0x68, 0xf8, 0x8e, 0xb7, 0x6f, 0xb2, 0x3b, 0xca, 0x72, 0xc7, 0xdb, 0x37, 0x13,
+	0x3b, 0xf4, 0xbf, 0x15, 0xa1, 0x12, 0xa5, 0x9b, 0xf9, 0x84, 0xbe, 0x05, 0xe8, 0x73, 0x62, 0x67,
+	0xb1, 0x6f, 0x61, 0xe1, 0xc6, 0x6f, 0x61, 0x55, 0x5a, 0xef, 0xd1, 0xb8, 0xdd, 0x8b, 0x89, 0x76,
+	0xdf, 0x80, 0x9a, 0x45, 0xc2, 0x7e, 0x60, 0x73, 0x56, 0x1b, 0xcd, 0xb1, 0x84, 0x08, 0xb5, 0x52,
+	0x5f, 0x96, 0x39, 0x7e, 0xe7, 0x0d, 0x71, 0xe7, 0x53, 0x3f, 0x26, 0xcb, 0x30, 0xc7, 0xd9, 0x3b,
+	0x4f, 0xb0, 0x6a, 0x88, 0x05, 0x7a, 0x04, 0x90, 0xa0, 0x59, 0xf3, 0x1c, 0x08, 0x09, 0xc9, 0x2c,
+	0x66, 0x50, 0xb9, 0x35, 0x33, 0xa8, 0xde, 0x9e, 0x19, 0xa0, 0x2f, 0xa1, 0x19, 0x1f, 0xda, 0x0c,
+	0xfb, 0x17, 0x64, 0x84, 0x55, 0x48, 0x02, 0x3a, 0x52, 0x9e, 0x70, 0x9d, 0xb1, 0xe8, 0xa7, 0x05,
+	0x3b, 0xff, 0xaa, 0xc3, 0x62, 0x0c, 0x4a, 0x12, 0x5c, 0xda, 0x7d, 0x82, 0x30, 0x34, 0xd2, 0xac,
+	0x1b, 0x69, 0xf9, 0x54, 0x5c, 0x4b, 0xb3, 0x3e, 0xfd, 0xc9, 0x9f, 0xfe, 0xfd, 0x9f, 0x4f, 0x85,
+	0x47, 0xfa, 0x2a, 0x7b, 0xdb, 0x84, 0xed, 0xcb, 0xd7, 0xe7, 0x84, 0xe2, 0xd7, 0xed, 0x98, 0x0b,
+	0xee, 0xf2, 0x47, 0xc0, 0xef, 0xa0, 0x96, 0x68, 0x1b, 0xb4, 0xca, 0x7d, 0x64, 0x09, 0x78, 0x8e,
+	0x73, 0xf4, 0x30, 0xc7, 0x79, 0xfb, 0x07, 0xdb, 0xfa, 0x11, 0x0d, 0x61, 0x21, 0xc5, 0x59, 0x91,
+	0x98, 0x49, 0xd3, 0x88, 0xbb, 0xa6, 0x4d, 0x53, 0x89, 0x39, 0xa1, 0xaf, 0xf3, 0x68, 0xf7, 0x51,
+	0x5e, 0x2a, 0xe8, 0xf7, 0xd0, 0x48, 0xd3, 0x55, 0x59, 0xa8, 0xa9, 0x1c, 0x56, 0xbb, 0x97, 0xb9,
+	0xd1, 0x2e, 0x7b, 0xb5, 0x45, 0x49, 0x6d, 0xcd, 0x4e, 0xca, 0x87, 0x5a, 0x82, 0xaf, 0x5d, 0x57,
+	0x6c, 0x82, 0xe7, 0x69, 0x6a, 0x56, 0x21, 0xd3, 0x69, 0xf1, 0x38, 0x9b, 0xe8, 0xf3, 0x59, 0x71,
+	0xda, 0xd1, 0x2c, 0x0e, 0xd1, 0x25, 0x34, 0x27, 0xe9, 0x1e, 0x7a, 0x28, 0x80, 0x30, 0x9d, 0x05,
+	0x6a, 0x4b, 0x19, 0x42, 0xa2, 0xbf, 0xe6, 0x41, 0x5f, 0xa0, 0xe7, 0xb9, 0x41, 0x25, 0x6f, 0xfc,
+	0x71, 0xb7, 0x2f, 0xbc, 0xa2, 0x1f, 0xa0, 0x39, 0xf9, 0xd5, 0x95, 0x71, 0x73, 0x18, 0x82, 0xb6,
+	0x96, 0xa3, 0x95, 0x89, 0x6f, 0xf1, 0x33, 0x3c, 0x41, 0xfa, 0xcc, 0xc4, 0xf9, 0xd7, 0x1a, 0x7d,
+	0x03, 0xf5, 0xe4, 0xc3, 0x02, 0x89, 0x72, 0x4e, 0x79, 0x6b, 0xe4, 0x5e, 0xe7, 0x73, 0x1e, 0xed,
+	0x33, 0xfd, 0xf1, 0xac, 0x68, 0xbb, 0xec, 0x51, 0x82, 0xbe, 0x85, 0x46, 0xfa, 0x79, 0x22, 0xf1,
+	0x33, 0xf5, 0xcd, 0x92, 0x1b, 0xf0, 0x05, 0x0f, 0xf8, 0x54, 0xff, 0x6c, 0x66, 0xc0, 0x31, 0xf7,
+	0x89, 0x28, 0x2c, 0x65, 0xde, 0x39, 0x68, 0x4d, 0xa2, 0x76, 0xfa, 0x03, 0x63, 0xb2, 0x09, 0xe5,
+	0x95, 0xea, 0x33, 0x71, 0xb4, 0x1b, 0x4f, 0xc5, 0x5d, 0x65, 0x0b, 0x7d, 0x07, 0x77, 0xa7, 0x3c,
+	0x60, 0xd0, 0xba, 0xcc, 0xd6, 0xba, 0x65, 0xe4, 0x6d, 0x1e, 0x79, 0x4b, 0xdf, 0xbc, 0x21, 0xd3,
+	0xd8, 0x1f, 0xfa, 0x03, 0x34, 0x27, 0x69, 0x80, 0xc4, 0x52, 0x0e, 0x65, 0xd2, 0xd6, 0x72, 0xb4,
+	0x12, 0x4b, 0x51, 0xb1, 0x37, 0xf2, 0xc6, 0xdb, 0xa5, 0xdc, 0xc9, 0xd2, 0xf6, 0x27, 0x90, 0xcc,
+	0xbe, 0xf9, 0x53, 0x90, 0x7c, 0xcd, 0x19, 0x34, 0x34, 0x41, 0x23, 0x31, 0x0d, 0x6f, 0x0d, 0x5f,
+	0xe6, 0xfd, 0x8f, 0xb0, 0x94, 0x21, 0x90, 0x68, 0x2d, 0x3b, 0x5d, 0x13, 0x24, 0x54, 0x7b, 0x94,
+	0xa7, 0x4e, 0xa7, 0x8c, 0x66, 0xe2, 0xab, 0x2d, 0xc8, 0xe8, 0xbb, 0xe3, 0xbf, 0xec, 0xbd, 0x37,
+	0x1e, 0xc2, 0xbc, 0x45, 0x06, 0x78, 0xec, 0x50, 0xb4, 0x84, 0x16, 0x61, 0x41, 0xab, 0x45, 0x5d,
+	0x44, 0xc7, 0xe1, 0x6f, 0xd7, 0x61, 0x0d, 0xca, 0xef, 0x08, 0x0e, 0x48, 0x80, 0xee, 0x56, 0x0a,
+	0xda, 0x02, 0x1e, 0xd3, 0x0b, 0x2f, 0xb0, 0x3f, 0x72, 0xfa, 0xb0, 0x51, 0x38, 0xaf, 0x03, 0xc4,
+	0x06, 0x77, 0xce, 0xcb, 0x1c, 0xee, 0x5f, 0xfc, 0x7f, 0x00, 0x5f, 0x7c, 0x07, 0x21, 0x63, 0x13,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PipelineServiceClient is the client API for PipelineService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PipelineServiceClient interface {
+	CreatePipeline(ctx context.Context, in *CreatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error)
+	GetPipeline(ctx context.Context, in *GetPipelineRequest, opts ...grpc.CallOption) (*Pipeline, error)
+	ListPipelines(ctx context.Context, in *ListPipelinesRequest, opts ...grpc.CallOption) (*ListPipelinesResponse, error)
+	DeletePipeline(ctx context.Context, in *DeletePipelineRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error)
+	// ComparePipelines returns the differences between the templates of two
+	// pipelines, e.g. two versions of the same pipeline.
+	ComparePipelines(ctx context.Context, in *ComparePipelinesRequest, opts ...grpc.CallOption) (*PipelineDiff, error)
+	// GetPipelineSteps returns the steps of a pipeline, as indexed when the
+	// pipeline was uploaded.
+	GetPipelineSteps(ctx context.Context, in *GetPipelineStepsRequest, opts ...grpc.CallOption) (*GetPipelineStepsResponse, error)
+	// StarPipeline adds a pipeline to the favorites of the authenticated user,
+	// which can be listed with only_starred.
+	StarPipeline(ctx context.Context, in *StarPipelineRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// UnstarPipeline removes a pipeline from the favorites of the authenticated
+	// user.
+	UnstarPipeline(ctx context.Context, in *UnstarPipelineRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// DeprecatePipeline marks a pipeline as deprecated, optionally with the
+	// pipeline replacing it and the date after which it is no longer supported.
+	// The runs of a deprecated pipeline are created with a warning.
+	DeprecatePipeline(ctx context.Context, in *DeprecatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error)
+	// UndeprecatePipeline clears the deprecation of a pipeline.
+	UndeprecatePipeline(ctx context.Context, in *UndeprecatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error)
+	// ValidatePipeline checks a pipeline, uploaded or not, against the policies
+	// of the organization, e.g. the registries its images may be pulled from.
+	ValidatePipeline(ctx context.Context, in *ValidatePipelineRequest, opts ...grpc.CallOption) (*ValidatePipelineResponse, error)
+	// GetPipelineStats aggregates the durations and the failure rate of the last
+	// finished runs of a pipeline.
+	GetPipelineStats(ctx context.Context, in *GetPipelineStatsRequest, opts ...grpc.CallOption) (*PipelineStats, error)
+	// GetPipelineReadme returns the README.md documenting a pipeline, uploaded
+	// in the tarball of the pipeline. It's sanitized: the raw HTML and the
+	// links to unsafe URLs are removed from the Markdown.
+	GetPipelineReadme(ctx context.Context, in *GetPipelineReadmeRequest, opts ...grpc.CallOption) (*GetPipelineReadmeResponse, error)
+}
+
+type pipelineServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPipelineServiceClient(cc *grpc.ClientConn) PipelineServiceClient {
+	return &pipelineServiceClient{cc}
+}
+
+func (c *pipelineServiceClient) CreatePipeline(ctx context.Context, in *CreatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error) {
 	out := new(Pipeline)
 	err := c.cc.Invoke(ctx, "/api.PipelineService/CreatePipeline", in, out, opts...)
 	if err != nil {
@@ -604,6 +1815,87 @@ func (c *pipelineServiceClient) GetTemplate(ctx context.Context, in *GetTemplate
 	return out, nil
 }
 
+func (c *pipelineServiceClient) ComparePipelines(ctx context.Context, in *ComparePipelinesRequest, opts ...grpc.CallOption) (*PipelineDiff, error) {
+	out := new(PipelineDiff)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/ComparePipelines", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetPipelineSteps(ctx context.Context, in *GetPipelineStepsRequest, opts ...grpc.CallOption) (*GetPipelineStepsResponse, error) {
+	out := new(GetPipelineStepsResponse)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/GetPipelineSteps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) StarPipeline(ctx context.Context, in *StarPipelineRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/StarPipeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) UnstarPipeline(ctx context.Context, in *UnstarPipelineRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/UnstarPipeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) DeprecatePipeline(ctx context.Context, in *DeprecatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error) {
+	out := new(Pipeline)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/DeprecatePipeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) UndeprecatePipeline(ctx context.Context, in *UndeprecatePipelineRequest, opts ...grpc.CallOption) (*Pipeline, error) {
+	out := new(Pipeline)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/UndeprecatePipeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) ValidatePipeline(ctx context.Context, in *ValidatePipelineRequest, opts ...grpc.CallOption) (*ValidatePipelineResponse, error) {
+	out := new(ValidatePipelineResponse)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/ValidatePipeline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetPipelineStats(ctx context.Context, in *GetPipelineStatsRequest, opts ...grpc.CallOption) (*PipelineStats, error) {
+	out := new(PipelineStats)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/GetPipelineStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pipelineServiceClient) GetPipelineReadme(ctx context.Context, in *GetPipelineReadmeRequest, opts ...grpc.CallOption) (*GetPipelineReadmeResponse, error) {
+	out := new(GetPipelineReadmeResponse)
+	err := c.cc.Invoke(ctx, "/api.PipelineService/GetPipelineReadme", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PipelineServiceServer is the server API for PipelineService service.
 type PipelineServiceServer interface {
 	CreatePipeline(context.Context, *CreatePipelineRequest) (*Pipeline, error)
@@ -611,6 +1903,34 @@ type PipelineServiceServer interface {
 	ListPipelines(context.Context, *ListPipelinesRequest) (*ListPipelinesResponse, error)
 	DeletePipeline(context.Context, *DeletePipelineRequest) (*empty.Empty, error)
 	GetTemplate(context.Context, *GetTemplateRequest) (*GetTemplateResponse, error)
+	// ComparePipelines returns the differences between the templates of two
+	// pipelines, e.g. two versions of the same pipeline.
+	ComparePipelines(context.Context, *ComparePipelinesRequest) (*PipelineDiff, error)
+	// GetPipelineSteps returns the steps of a pipeline, as indexed when the
+	// pipeline was uploaded.
+	GetPipelineSteps(context.Context, *GetPipelineStepsRequest) (*GetPipelineStepsResponse, error)
+	// StarPipeline adds a pipeline to the favorites of the authenticated user,
+	// which can be listed with only_starred.
+	StarPipeline(context.Context, *StarPipelineRequest) (*empty.Empty, error)
+	// UnstarPipeline removes a pipeline from the favorites of the authenticated
+	// user.
+	UnstarPipeline(context.Context, *UnstarPipelineRequest) (*empty.Empty, error)
+	// DeprecatePipeline marks a pipeline as deprecated, optionally with the
+	// pipeline replacing it and the date after which it is no longer supported.
+	// The runs of a deprecated pipeline are created with a warning.
+	DeprecatePipeline(context.Context, *DeprecatePipelineRequest) (*Pipeline, error)
+	// UndeprecatePipeline clears the deprecation of a pipeline.
+	UndeprecatePipeline(context.Context, *UndeprecatePipelineRequest) (*Pipeline, error)
+	// ValidatePipeline checks a pipeline, uploaded or not, against the policies
+	// of the organization, e.g. the registries its images may be pulled from.
+	ValidatePipeline(context.Context, *ValidatePipelineRequest) (*ValidatePipelineResponse, error)
+	// GetPipelineStats aggregates the durations and the failure rate of the last
+	// finished runs of a pipeline.
+	GetPipelineStats(context.Context, *GetPipelineStatsRequest) (*PipelineStats, error)
+	// GetPipelineReadme returns the README.md documenting a pipeline, uploaded
+	// in the tarball of the pipeline. It's sanitized: the raw HTML and the
+	// links to unsafe URLs are removed from the Markdown.
+	GetPipelineReadme(context.Context, *GetPipelineReadmeRequest) (*GetPipelineReadmeResponse, error)
 }
 
 func RegisterPipelineServiceServer(s *grpc.Server, srv PipelineServiceServer) {
@@ -707,6 +2027,168 @@ func _PipelineService_GetTemplate_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PipelineService_ComparePipelines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComparePipelinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).ComparePipelines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/ComparePipelines",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).ComparePipelines(ctx, req.(*ComparePipelinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetPipelineSteps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineStepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetPipelineSteps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/GetPipelineSteps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetPipelineSteps(ctx, req.(*GetPipelineStepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_StarPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StarPipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).StarPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/StarPipeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).StarPipeline(ctx, req.(*StarPipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_UnstarPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnstarPipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).UnstarPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/UnstarPipeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).UnstarPipeline(ctx, req.(*UnstarPipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_DeprecatePipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeprecatePipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).DeprecatePipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/DeprecatePipeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).DeprecatePipeline(ctx, req.(*DeprecatePipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_UndeprecatePipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeprecatePipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).UndeprecatePipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/UndeprecatePipeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).UndeprecatePipeline(ctx, req.(*UndeprecatePipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_ValidatePipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePipelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).ValidatePipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/ValidatePipeline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).ValidatePipeline(ctx, req.(*ValidatePipelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetPipelineStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetPipelineStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/GetPipelineStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetPipelineStats(ctx, req.(*GetPipelineStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PipelineService_GetPipelineReadme_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineReadmeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PipelineServiceServer).GetPipelineReadme(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.PipelineService/GetPipelineReadme",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PipelineServiceServer).GetPipelineReadme(ctx, req.(*GetPipelineReadmeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PipelineService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "api.PipelineService",
 	HandlerType: (*PipelineServiceServer)(nil),
@@ -731,6 +2213,42 @@ var _PipelineService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetTemplate",
 			Handler:    _PipelineService_GetTemplate_Handler,
 		},
+		{
+			MethodName: "ComparePipelines",
+			Handler:    _PipelineService_ComparePipelines_Handler,
+		},
+		{
+			MethodName: "GetPipelineSteps",
+			Handler:    _PipelineService_GetPipelineSteps_Handler,
+		},
+		{
+			MethodName: "StarPipeline",
+			Handler:    _PipelineService_StarPipeline_Handler,
+		},
+		{
+			MethodName: "UnstarPipeline",
+			Handler:    _PipelineService_UnstarPipeline_Handler,
+		},
+		{
+			MethodName: "DeprecatePipeline",
+			Handler:    _PipelineService_DeprecatePipeline_Handler,
+		},
+		{
+			MethodName: "UndeprecatePipeline",
+			Handler:    _PipelineService_UndeprecatePipeline_Handler,
+		},
+		{
+			MethodName: "ValidatePipeline",
+			Handler:    _PipelineService_ValidatePipeline_Handler,
+		},
+		{
+			MethodName: "GetPipelineStats",
+			Handler:    _PipelineService_GetPipelineStats_Handler,
+		},
+		{
+			MethodName: "GetPipelineReadme",
+			Handler:    _PipelineService_GetPipelineReadme_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pipeline.proto",
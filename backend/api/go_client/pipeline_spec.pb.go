@@ -34,6 +34,132 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type WorkflowOptions_PodGCStrategy int32
+
+const (
+	WorkflowOptions_POD_GC_STRATEGY_UNSPECIFIED WorkflowOptions_PodGCStrategy = 0
+	// The pods are kept until the workflow is deleted.
+	WorkflowOptions_KEEP_PODS WorkflowOptions_PodGCStrategy = 1
+	// The pods are deleted once the workflow completes.
+	WorkflowOptions_ON_WORKFLOW_COMPLETION WorkflowOptions_PodGCStrategy = 2
+	// The pods are deleted once the workflow succeeds, and kept for debugging
+	// if it fails.
+	WorkflowOptions_ON_WORKFLOW_SUCCESS WorkflowOptions_PodGCStrategy = 3
+)
+
+var WorkflowOptions_PodGCStrategy_name = map[int32]string{
+	0: "POD_GC_STRATEGY_UNSPECIFIED",
+	1: "KEEP_PODS",
+	2: "ON_WORKFLOW_COMPLETION",
+	3: "ON_WORKFLOW_SUCCESS",
+}
+
+var WorkflowOptions_PodGCStrategy_value = map[string]int32{
+	"POD_GC_STRATEGY_UNSPECIFIED": 0,
+	"KEEP_PODS":                   1,
+	"ON_WORKFLOW_COMPLETION":      2,
+	"ON_WORKFLOW_SUCCESS":         3,
+}
+
+func (x WorkflowOptions_PodGCStrategy) String() string {
+	return proto.EnumName(WorkflowOptions_PodGCStrategy_name, int32(x))
+}
+
+func (WorkflowOptions_PodGCStrategy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{1, 0}
+}
+
+type WorkflowOptions_ArtifactArchive int32
+
+const (
+	WorkflowOptions_ARTIFACT_ARCHIVE_UNSPECIFIED WorkflowOptions_ArtifactArchive = 0
+	// The output artifacts are stored as tar.gz archives.
+	WorkflowOptions_TAR WorkflowOptions_ArtifactArchive = 1
+	// The output artifacts are stored as they are. The metrics and the UI
+	// metadata of the steps are always archived, since they're read as archives.
+	WorkflowOptions_NO_ARCHIVE WorkflowOptions_ArtifactArchive = 2
+)
+
+var WorkflowOptions_ArtifactArchive_name = map[int32]string{
+	0: "ARTIFACT_ARCHIVE_UNSPECIFIED",
+	1: "TAR",
+	2: "NO_ARCHIVE",
+}
+
+var WorkflowOptions_ArtifactArchive_value = map[string]int32{
+	"ARTIFACT_ARCHIVE_UNSPECIFIED": 0,
+	"TAR":                          1,
+	"NO_ARCHIVE":                   2,
+}
+
+func (x WorkflowOptions_ArtifactArchive) String() string {
+	return proto.EnumName(WorkflowOptions_ArtifactArchive_name, int32(x))
+}
+
+func (WorkflowOptions_ArtifactArchive) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{1, 1}
+}
+
+type WorkflowOptions_LogArchive int32
+
+const (
+	WorkflowOptions_LOG_ARCHIVE_UNSPECIFIED WorkflowOptions_LogArchive = 0
+	// The logs of the steps are archived to the artifact repository.
+	WorkflowOptions_ARCHIVE_LOGS WorkflowOptions_LogArchive = 1
+	// The logs of the steps are only kept in their pods.
+	WorkflowOptions_NO_LOG_ARCHIVE WorkflowOptions_LogArchive = 2
+)
+
+var WorkflowOptions_LogArchive_name = map[int32]string{
+	0: "LOG_ARCHIVE_UNSPECIFIED",
+	1: "ARCHIVE_LOGS",
+	2: "NO_LOG_ARCHIVE",
+}
+
+var WorkflowOptions_LogArchive_value = map[string]int32{
+	"LOG_ARCHIVE_UNSPECIFIED": 0,
+	"ARCHIVE_LOGS":            1,
+	"NO_LOG_ARCHIVE":          2,
+}
+
+func (x WorkflowOptions_LogArchive) String() string {
+	return proto.EnumName(WorkflowOptions_LogArchive_name, int32(x))
+}
+
+func (WorkflowOptions_LogArchive) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{1, 2}
+}
+
+type WorkflowOptions_PreemptionPolicy int32
+
+const (
+	WorkflowOptions_PREEMPTION_POLICY_UNSPECIFIED WorkflowOptions_PreemptionPolicy = 0
+	// The pods preempt the pods of lower priority to be scheduled.
+	WorkflowOptions_PREEMPT_LOWER_PRIORITY WorkflowOptions_PreemptionPolicy = 1
+	// The pods wait for the resources to free up instead of preempting others.
+	WorkflowOptions_PREEMPT_NEVER WorkflowOptions_PreemptionPolicy = 2
+)
+
+var WorkflowOptions_PreemptionPolicy_name = map[int32]string{
+	0: "PREEMPTION_POLICY_UNSPECIFIED",
+	1: "PREEMPT_LOWER_PRIORITY",
+	2: "PREEMPT_NEVER",
+}
+
+var WorkflowOptions_PreemptionPolicy_value = map[string]int32{
+	"PREEMPTION_POLICY_UNSPECIFIED": 0,
+	"PREEMPT_LOWER_PRIORITY":        1,
+	"PREEMPT_NEVER":                 2,
+}
+
+func (x WorkflowOptions_PreemptionPolicy) String() string {
+	return proto.EnumName(WorkflowOptions_PreemptionPolicy_name, int32(x))
+}
+
+func (WorkflowOptions_PreemptionPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{1, 3}
+}
+
 type PipelineSpec struct {
 	// Optional input field. The ID of the pipeline user uploaded before.
 	PipelineId string `protobuf:"bytes,1,opt,name=pipeline_id,json=pipelineId,proto3" json:"pipeline_id,omitempty"`
@@ -45,10 +171,27 @@ type PipelineSpec struct {
 	// The parameter user provide to inject to the pipeline JSON.
 	// If a default value of a parameter exist in the JSON,
 	// the value user provided here will replace.
-	Parameters           []*Parameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	// The values may use macros, which the server substitutes when the run or
+	// the job is created: [[RunName]], [[RunID]], [[ExperimentID]],
+	// [[ExperimentName]], [[PipelineID]], [[Parameters.<name>]] for the value of
+	// another parameter, and [[ScheduledTime]] or [[CurrentTime]], optionally
+	// with a Go time layout like [[ScheduledTime.2006-01-02]]. The time macros
+	// of a job are evaluated for each of its runs.
+	Parameters []*Parameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// Optional input field. How the workflow of the run, or of the runs of the job,
+	// archives its artifacts and logs, and cleans up its pods. The options not set
+	// take the defaults of the server.
+	WorkflowOptions *WorkflowOptions `protobuf:"bytes,5,opt,name=workflow_options,json=workflowOptions,proto3" json:"workflow_options,omitempty"`
+	// Optional input field. The root of the artifacts of the run, or of the runs
+	// of the job, in the object store of the compiled workflow, as
+	// minio://<bucket>/<path> or s3://<bucket>/<path>. The artifacts are stored
+	// under the path in the bucket. Unset, the root of the namespace is used,
+	// then the one of the server. Must not be set with the artifact repository of
+	// the workflow options.
+	PipelineRoot         string   `protobuf:"bytes,6,opt,name=pipeline_root,json=pipelineRoot,proto3" json:"pipeline_root,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *PipelineSpec) Reset()         { *m = PipelineSpec{} }
@@ -104,23 +247,569 @@ func (m *PipelineSpec) GetParameters() []*Parameter {
 	return nil
 }
 
+func (m *PipelineSpec) GetWorkflowOptions() *WorkflowOptions {
+	if m != nil {
+		return m.WorkflowOptions
+	}
+	return nil
+}
+
+func (m *PipelineSpec) GetPipelineRoot() string {
+	if m != nil {
+		return m.PipelineRoot
+	}
+	return ""
+}
+
+type WorkflowOptions struct {
+	PodGcStrategy   WorkflowOptions_PodGCStrategy   `protobuf:"varint,1,opt,name=pod_gc_strategy,json=podGcStrategy,proto3,enum=api.WorkflowOptions_PodGCStrategy" json:"pod_gc_strategy,omitempty"`
+	ArtifactArchive WorkflowOptions_ArtifactArchive `protobuf:"varint,2,opt,name=artifact_archive,json=artifactArchive,proto3,enum=api.WorkflowOptions_ArtifactArchive" json:"artifact_archive,omitempty"`
+	LogArchive      WorkflowOptions_LogArchive      `protobuf:"varint,3,opt,name=log_archive,json=logArchive,proto3,enum=api.WorkflowOptions_LogArchive" json:"log_archive,omitempty"`
+	// The retry strategy of the container and script steps not setting their own.
+	DefaultRetryStrategy *RetryStrategy `protobuf:"bytes,4,opt,name=default_retry_strategy,json=defaultRetryStrategy,proto3" json:"default_retry_strategy,omitempty"`
+	// The maximum number of pods of the run running at once, to throttle wide
+	// fan-outs. Zero keeps the parallelism of the compiled workflow.
+	Parallelism int64 `protobuf:"varint,5,opt,name=parallelism,proto3" json:"parallelism,omitempty"`
+	// Where the output artifacts of the steps with an S3 location are stored,
+	// e.g. to route the artifacts of a team to its bucket. Whether they are
+	// archived is set by artifact_archive.
+	ArtifactRepository *ArtifactRepository `protobuf:"bytes,6,opt,name=artifact_repository,json=artifactRepository,proto3" json:"artifact_repository,omitempty"`
+	// Where the pods of the steps are scheduled, e.g. on the nodes with GPUs or
+	// on spot instances. The constraints are merged into the ones of each step.
+	PodScheduling *PodScheduling `protobuf:"bytes,7,opt,name=pod_scheduling,json=podScheduling,proto3" json:"pod_scheduling,omitempty"`
+	// The environment variables set in the containers of all the steps,
+	// replacing the variables of the steps with the same names.
+	Env []*EnvVar `protobuf:"bytes,8,rep,name=env,proto3" json:"env,omitempty"`
+	// The priority class of the pods of the steps, e.g. so that the pods of the
+	// production pipelines aren't preempted by the ones of the experiments.
+	// Requires Argo v2.3 or later, and isn't supported by the jobs.
+	PriorityClassName string `protobuf:"bytes,9,opt,name=priority_class_name,json=priorityClassName,proto3" json:"priority_class_name,omitempty"`
+	// The preemption policy of the pods of the steps. Kubernetes rejects the pods
+	// whose policy differs from the one of their priority class. Requires Argo
+	// v2.3 or later, and isn't supported by the jobs.
+	PreemptionPolicy     WorkflowOptions_PreemptionPolicy `protobuf:"varint,10,opt,name=preemption_policy,json=preemptionPolicy,proto3,enum=api.WorkflowOptions_PreemptionPolicy" json:"preemption_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *WorkflowOptions) Reset()         { *m = WorkflowOptions{} }
+func (m *WorkflowOptions) String() string { return proto.CompactTextString(m) }
+func (*WorkflowOptions) ProtoMessage()    {}
+func (*WorkflowOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{1}
+}
+
+func (m *WorkflowOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkflowOptions.Unmarshal(m, b)
+}
+func (m *WorkflowOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkflowOptions.Marshal(b, m, deterministic)
+}
+func (m *WorkflowOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkflowOptions.Merge(m, src)
+}
+func (m *WorkflowOptions) XXX_Size() int {
+	return xxx_messageInfo_WorkflowOptions.Size(m)
+}
+func (m *WorkflowOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkflowOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkflowOptions proto.InternalMessageInfo
+
+func (m *WorkflowOptions) GetPodGcStrategy() WorkflowOptions_PodGCStrategy {
+	if m != nil {
+		return m.PodGcStrategy
+	}
+	return WorkflowOptions_POD_GC_STRATEGY_UNSPECIFIED
+}
+
+func (m *WorkflowOptions) GetArtifactArchive() WorkflowOptions_ArtifactArchive {
+	if m != nil {
+		return m.ArtifactArchive
+	}
+	return WorkflowOptions_ARTIFACT_ARCHIVE_UNSPECIFIED
+}
+
+func (m *WorkflowOptions) GetLogArchive() WorkflowOptions_LogArchive {
+	if m != nil {
+		return m.LogArchive
+	}
+	return WorkflowOptions_LOG_ARCHIVE_UNSPECIFIED
+}
+
+func (m *WorkflowOptions) GetDefaultRetryStrategy() *RetryStrategy {
+	if m != nil {
+		return m.DefaultRetryStrategy
+	}
+	return nil
+}
+
+func (m *WorkflowOptions) GetParallelism() int64 {
+	if m != nil {
+		return m.Parallelism
+	}
+	return 0
+}
+
+func (m *WorkflowOptions) GetArtifactRepository() *ArtifactRepository {
+	if m != nil {
+		return m.ArtifactRepository
+	}
+	return nil
+}
+
+func (m *WorkflowOptions) GetPodScheduling() *PodScheduling {
+	if m != nil {
+		return m.PodScheduling
+	}
+	return nil
+}
+
+func (m *WorkflowOptions) GetEnv() []*EnvVar {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *WorkflowOptions) GetPriorityClassName() string {
+	if m != nil {
+		return m.PriorityClassName
+	}
+	return ""
+}
+
+func (m *WorkflowOptions) GetPreemptionPolicy() WorkflowOptions_PreemptionPolicy {
+	if m != nil {
+		return m.PreemptionPolicy
+	}
+	return WorkflowOptions_PREEMPTION_POLICY_UNSPECIFIED
+}
+
+type ArtifactRepository struct {
+	// The bucket the artifacts are stored in, in the object store of the
+	// compiled workflow. Empty keeps the bucket of the compiled workflow.
+	Bucket string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// The prefix of the keys of the artifacts, e.g. the name of a team.
+	KeyPrefix            string   `protobuf:"bytes,2,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArtifactRepository) Reset()         { *m = ArtifactRepository{} }
+func (m *ArtifactRepository) String() string { return proto.CompactTextString(m) }
+func (*ArtifactRepository) ProtoMessage()    {}
+func (*ArtifactRepository) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{2}
+}
+
+func (m *ArtifactRepository) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactRepository.Unmarshal(m, b)
+}
+func (m *ArtifactRepository) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactRepository.Marshal(b, m, deterministic)
+}
+func (m *ArtifactRepository) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactRepository.Merge(m, src)
+}
+func (m *ArtifactRepository) XXX_Size() int {
+	return xxx_messageInfo_ArtifactRepository.Size(m)
+}
+func (m *ArtifactRepository) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactRepository.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArtifactRepository proto.InternalMessageInfo
+
+func (m *ArtifactRepository) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *ArtifactRepository) GetKeyPrefix() string {
+	if m != nil {
+		return m.KeyPrefix
+	}
+	return ""
+}
+
+type PodScheduling struct {
+	// The labels of the nodes the pods are scheduled on, overriding the labels
+	// also selected by a step.
+	NodeSelector map[string]string `protobuf:"bytes,1,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The taints of the nodes the pods tolerate, added to the ones a step
+	// tolerates.
+	Tolerations []*Toleration `protobuf:"bytes,2,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	// The affinity of the pods, as the JSON of a Kubernetes Affinity, e.g.
+	// {"nodeAffinity": {"requiredDuringSchedulingIgnoredDuringExecution": ...}}.
+	// It replaces the affinity of the steps.
+	Affinity             string   `protobuf:"bytes,3,opt,name=affinity,proto3" json:"affinity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PodScheduling) Reset()         { *m = PodScheduling{} }
+func (m *PodScheduling) String() string { return proto.CompactTextString(m) }
+func (*PodScheduling) ProtoMessage()    {}
+func (*PodScheduling) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{3}
+}
+
+func (m *PodScheduling) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PodScheduling.Unmarshal(m, b)
+}
+func (m *PodScheduling) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PodScheduling.Marshal(b, m, deterministic)
+}
+func (m *PodScheduling) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PodScheduling.Merge(m, src)
+}
+func (m *PodScheduling) XXX_Size() int {
+	return xxx_messageInfo_PodScheduling.Size(m)
+}
+func (m *PodScheduling) XXX_DiscardUnknown() {
+	xxx_messageInfo_PodScheduling.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PodScheduling proto.InternalMessageInfo
+
+func (m *PodScheduling) GetNodeSelector() map[string]string {
+	if m != nil {
+		return m.NodeSelector
+	}
+	return nil
+}
+
+func (m *PodScheduling) GetTolerations() []*Toleration {
+	if m != nil {
+		return m.Tolerations
+	}
+	return nil
+}
+
+func (m *PodScheduling) GetAffinity() string {
+	if m != nil {
+		return m.Affinity
+	}
+	return ""
+}
+
+type Toleration struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Exists, or Equal if empty.
+	Operator string `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Value    string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// NoSchedule, PreferNoSchedule or NoExecute. Empty tolerates all the effects.
+	Effect string `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"`
+	// How long the pods tolerate a NoExecute taint before they are evicted. 0
+	// tolerates it forever.
+	TolerationSeconds    int64    `protobuf:"varint,5,opt,name=toleration_seconds,json=tolerationSeconds,proto3" json:"toleration_seconds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Toleration) Reset()         { *m = Toleration{} }
+func (m *Toleration) String() string { return proto.CompactTextString(m) }
+func (*Toleration) ProtoMessage()    {}
+func (*Toleration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{4}
+}
+
+func (m *Toleration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Toleration.Unmarshal(m, b)
+}
+func (m *Toleration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Toleration.Marshal(b, m, deterministic)
+}
+func (m *Toleration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Toleration.Merge(m, src)
+}
+func (m *Toleration) XXX_Size() int {
+	return xxx_messageInfo_Toleration.Size(m)
+}
+func (m *Toleration) XXX_DiscardUnknown() {
+	xxx_messageInfo_Toleration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Toleration proto.InternalMessageInfo
+
+func (m *Toleration) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Toleration) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *Toleration) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Toleration) GetEffect() string {
+	if m != nil {
+		return m.Effect
+	}
+	return ""
+}
+
+func (m *Toleration) GetTolerationSeconds() int64 {
+	if m != nil {
+		return m.TolerationSeconds
+	}
+	return 0
+}
+
+type EnvVar struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Exactly one of the value, the ConfigMap key and the secret key is set.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// The key of a ConfigMap of the namespace of the run holding the value.
+	ConfigMapKey *KeySelector `protobuf:"bytes,3,opt,name=config_map_key,json=configMapKey,proto3" json:"config_map_key,omitempty"`
+	// The key of a secret of the namespace of the run holding the value.
+	SecretKey            *KeySelector `protobuf:"bytes,4,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *EnvVar) Reset()         { *m = EnvVar{} }
+func (m *EnvVar) String() string { return proto.CompactTextString(m) }
+func (*EnvVar) ProtoMessage()    {}
+func (*EnvVar) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{5}
+}
+
+func (m *EnvVar) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnvVar.Unmarshal(m, b)
+}
+func (m *EnvVar) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnvVar.Marshal(b, m, deterministic)
+}
+func (m *EnvVar) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnvVar.Merge(m, src)
+}
+func (m *EnvVar) XXX_Size() int {
+	return xxx_messageInfo_EnvVar.Size(m)
+}
+func (m *EnvVar) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnvVar.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnvVar proto.InternalMessageInfo
+
+func (m *EnvVar) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EnvVar) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *EnvVar) GetConfigMapKey() *KeySelector {
+	if m != nil {
+		return m.ConfigMapKey
+	}
+	return nil
+}
+
+func (m *EnvVar) GetSecretKey() *KeySelector {
+	if m != nil {
+		return m.SecretKey
+	}
+	return nil
+}
+
+type KeySelector struct {
+	// The name of the ConfigMap or of the secret.
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeySelector) Reset()         { *m = KeySelector{} }
+func (m *KeySelector) String() string { return proto.CompactTextString(m) }
+func (*KeySelector) ProtoMessage()    {}
+func (*KeySelector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{6}
+}
+
+func (m *KeySelector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeySelector.Unmarshal(m, b)
+}
+func (m *KeySelector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeySelector.Marshal(b, m, deterministic)
+}
+func (m *KeySelector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeySelector.Merge(m, src)
+}
+func (m *KeySelector) XXX_Size() int {
+	return xxx_messageInfo_KeySelector.Size(m)
+}
+func (m *KeySelector) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeySelector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeySelector proto.InternalMessageInfo
+
+func (m *KeySelector) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *KeySelector) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type RetryStrategy struct {
+	// The maximum number of times a failed step is retried.
+	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetryStrategy) Reset()         { *m = RetryStrategy{} }
+func (m *RetryStrategy) String() string { return proto.CompactTextString(m) }
+func (*RetryStrategy) ProtoMessage()    {}
+func (*RetryStrategy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7ae2a94ab58e513c, []int{7}
+}
+
+func (m *RetryStrategy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetryStrategy.Unmarshal(m, b)
+}
+func (m *RetryStrategy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetryStrategy.Marshal(b, m, deterministic)
+}
+func (m *RetryStrategy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryStrategy.Merge(m, src)
+}
+func (m *RetryStrategy) XXX_Size() int {
+	return xxx_messageInfo_RetryStrategy.Size(m)
+}
+func (m *RetryStrategy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryStrategy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetryStrategy proto.InternalMessageInfo
+
+func (m *RetryStrategy) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("api.WorkflowOptions_PodGCStrategy", WorkflowOptions_PodGCStrategy_name, WorkflowOptions_PodGCStrategy_value)
+	proto.RegisterEnum("api.WorkflowOptions_ArtifactArchive", WorkflowOptions_ArtifactArchive_name, WorkflowOptions_ArtifactArchive_value)
+	proto.RegisterEnum("api.WorkflowOptions_LogArchive", WorkflowOptions_LogArchive_name, WorkflowOptions_LogArchive_value)
+	proto.RegisterEnum("api.WorkflowOptions_PreemptionPolicy", WorkflowOptions_PreemptionPolicy_name, WorkflowOptions_PreemptionPolicy_value)
 	proto.RegisterType((*PipelineSpec)(nil), "api.PipelineSpec")
+	proto.RegisterType((*WorkflowOptions)(nil), "api.WorkflowOptions")
+	proto.RegisterType((*ArtifactRepository)(nil), "api.ArtifactRepository")
+	proto.RegisterType((*PodScheduling)(nil), "api.PodScheduling")
+	proto.RegisterMapType((map[string]string)(nil), "api.PodScheduling.NodeSelectorEntry")
+	proto.RegisterType((*Toleration)(nil), "api.Toleration")
+	proto.RegisterType((*EnvVar)(nil), "api.EnvVar")
+	proto.RegisterType((*KeySelector)(nil), "api.KeySelector")
+	proto.RegisterType((*RetryStrategy)(nil), "api.RetryStrategy")
 }
 
 func init() { proto.RegisterFile("pipeline_spec.proto", fileDescriptor_7ae2a94ab58e513c) }
 
 var fileDescriptor_7ae2a94ab58e513c = []byte{
-	// 169 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2e, 0xc8, 0x2c, 0x48,
-	0xcd, 0xc9, 0xcc, 0x4b, 0x8d, 0x2f, 0x2e, 0x48, 0x4d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
-	0x62, 0x4e, 0x2c, 0xc8, 0x94, 0xe2, 0x2f, 0x48, 0x2c, 0x4a, 0xcc, 0x4d, 0x2d, 0x49, 0x2d, 0x82,
-	0x88, 0x2a, 0xed, 0x64, 0xe4, 0xe2, 0x09, 0x80, 0xaa, 0x0e, 0x2e, 0x48, 0x4d, 0x16, 0x92, 0xe7,
-	0xe2, 0x86, 0xeb, 0xce, 0x4c, 0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0xe2, 0x82, 0x09, 0x79,
-	0xa6, 0x08, 0x69, 0x73, 0x09, 0x96, 0xe7, 0x17, 0x65, 0xa7, 0xe5, 0xe4, 0x97, 0xc7, 0xe7, 0x26,
-	0xe6, 0x65, 0xa6, 0xa5, 0x16, 0x97, 0x48, 0x30, 0x81, 0x95, 0x09, 0xc0, 0x24, 0x7c, 0xa1, 0xe2,
-	0x20, 0xc5, 0x70, 0xd3, 0xe0, 0x8a, 0x99, 0x21, 0x8a, 0x61, 0x12, 0x70, 0xc5, 0x7a, 0x5c, 0x5c,
-	0x70, 0xe7, 0x15, 0x4b, 0xb0, 0x28, 0x30, 0x6b, 0x70, 0x1b, 0xf1, 0xe9, 0x25, 0x16, 0x64, 0xea,
-	0x05, 0xc0, 0x84, 0x83, 0x90, 0x54, 0x24, 0xb1, 0x81, 0xbd, 0x60, 0x0c, 0x08, 0x00, 0x00, 0xff,
-	0xff, 0x7f, 0xd3, 0x17, 0x98, 0xef, 0x00, 0x00, 0x00,
+	// 1000 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0x5d, 0x6f, 0xe2, 0x46,
+	0x17, 0x7e, 0x8d, 0xf3, 0xc5, 0x21, 0x80, 0x99, 0x44, 0x09, 0x62, 0xdf, 0x28, 0xa9, 0xbb, 0x2b,
+	0x45, 0xaa, 0x4a, 0xd5, 0xac, 0x54, 0xb5, 0xbd, 0xd9, 0x22, 0xe2, 0x10, 0x0a, 0xc1, 0xd6, 0x98,
+	0x4d, 0xb4, 0x57, 0x23, 0xaf, 0x3d, 0xb0, 0x56, 0x8c, 0xc7, 0x1a, 0x4f, 0x92, 0xfa, 0x97, 0xf4,
+	0xa2, 0x17, 0xfd, 0x7f, 0xfd, 0x13, 0xad, 0x3c, 0xfe, 0xe0, 0x23, 0xec, 0x9d, 0xcf, 0xf3, 0x3c,
+	0xe7, 0xcc, 0xf9, 0xe2, 0x00, 0x47, 0x91, 0x1f, 0xd1, 0xc0, 0x0f, 0x29, 0x89, 0x23, 0xea, 0x76,
+	0x23, 0xce, 0x04, 0x43, 0xaa, 0x13, 0xf9, 0x9d, 0x66, 0xe4, 0x70, 0x67, 0x41, 0x05, 0xe5, 0x19,
+	0xaa, 0xff, 0x5d, 0x81, 0x43, 0x2b, 0x57, 0xdb, 0x11, 0x75, 0xd1, 0x39, 0xd4, 0x4a, 0x6f, 0xdf,
+	0x6b, 0x2b, 0x17, 0xca, 0x65, 0x15, 0x43, 0x01, 0x0d, 0x3d, 0xf4, 0x1d, 0xb4, 0x5e, 0x18, 0x7f,
+	0x9c, 0x05, 0xec, 0x85, 0x2c, 0x9c, 0xd0, 0x9f, 0xd1, 0x58, 0xb4, 0x2b, 0x52, 0xa6, 0x15, 0xc4,
+	0x5d, 0x8e, 0xa7, 0xe2, 0x32, 0x5a, 0x29, 0x56, 0x33, 0x71, 0x41, 0x94, 0xe2, 0x2e, 0x40, 0x99,
+	0x5e, 0xdc, 0xde, 0xb9, 0x50, 0x2f, 0x6b, 0x57, 0x8d, 0xae, 0x13, 0xf9, 0x5d, 0xab, 0x80, 0xf1,
+	0x8a, 0x02, 0x7d, 0x80, 0xf2, 0x41, 0xc2, 0x22, 0xe1, 0xb3, 0x30, 0x6e, 0xef, 0x5e, 0x28, 0x97,
+	0xb5, 0xab, 0x63, 0xe9, 0xf5, 0x90, 0x93, 0x66, 0xc6, 0xe1, 0xe6, 0xcb, 0x3a, 0x80, 0xbe, 0x85,
+	0x7a, 0x99, 0x1d, 0x67, 0x4c, 0xb4, 0xf7, 0x64, 0x66, 0x87, 0x05, 0x88, 0x19, 0x13, 0xfa, 0xbf,
+	0xfb, 0xd0, 0xdc, 0x88, 0x84, 0x7e, 0x87, 0x66, 0xc4, 0x3c, 0x32, 0x77, 0x49, 0x2c, 0xb8, 0x23,
+	0xe8, 0x3c, 0x91, 0x8d, 0x6a, 0x5c, 0xe9, 0xdb, 0x1e, 0xee, 0x5a, 0xcc, 0x1b, 0xf4, 0xed, 0x5c,
+	0x89, 0xeb, 0x11, 0xf3, 0x06, 0x6e, 0x61, 0x22, 0x13, 0x34, 0x87, 0x0b, 0x7f, 0xe6, 0xb8, 0x82,
+	0x38, 0xdc, 0xfd, 0xe2, 0x3f, 0x53, 0xd9, 0xce, 0xc6, 0xd5, 0xdb, 0xad, 0xc1, 0x7a, 0xb9, 0xb8,
+	0x97, 0x69, 0x71, 0xd3, 0x59, 0x07, 0xd0, 0x6f, 0x50, 0x0b, 0xd8, 0xbc, 0x8c, 0xa5, 0xca, 0x58,
+	0xe7, 0x5b, 0x63, 0x8d, 0xd9, 0xbc, 0x08, 0x03, 0x41, 0xf9, 0x8d, 0x6e, 0xe1, 0xc4, 0xa3, 0x33,
+	0xe7, 0x29, 0x10, 0x84, 0x53, 0xc1, 0x93, 0x65, 0x95, 0x3b, 0xb2, 0xbd, 0x48, 0x06, 0xc3, 0x29,
+	0x55, 0x56, 0x75, 0x9c, 0x7b, 0xac, 0xa1, 0xe8, 0x02, 0x6a, 0xe9, 0xc0, 0x82, 0x80, 0x06, 0x7e,
+	0xbc, 0x90, 0xd3, 0x51, 0xf1, 0x2a, 0x84, 0x6e, 0xe1, 0xa8, 0x2c, 0x9f, 0xd3, 0x88, 0xc5, 0xbe,
+	0x60, 0x3c, 0x91, 0x93, 0xa8, 0x5d, 0x9d, 0xca, 0x87, 0x8a, 0x8a, 0x71, 0x49, 0x63, 0xe4, 0xbc,
+	0xc2, 0xd0, 0x2f, 0xd0, 0x48, 0x87, 0x12, 0xbb, 0x5f, 0xa8, 0xf7, 0x14, 0xf8, 0xe1, 0xbc, 0xbd,
+	0xbf, 0x92, 0xad, 0xc5, 0x3c, 0xbb, 0x64, 0xe4, 0x0c, 0x96, 0x26, 0x3a, 0x03, 0x95, 0x86, 0xcf,
+	0xed, 0x03, 0xb9, 0x72, 0x35, 0xa9, 0x37, 0xc2, 0xe7, 0x7b, 0x87, 0xe3, 0x14, 0x47, 0x5d, 0x38,
+	0x8a, 0xb8, 0xcf, 0xb8, 0x2f, 0x12, 0xe2, 0x06, 0x4e, 0x1c, 0x93, 0xd0, 0x59, 0xd0, 0x76, 0x55,
+	0x6e, 0x4b, 0xab, 0xa0, 0xfa, 0x29, 0x33, 0x71, 0x16, 0x14, 0x61, 0x68, 0x45, 0x9c, 0xd2, 0x85,
+	0xec, 0x32, 0x89, 0x58, 0xe0, 0xbb, 0x49, 0x1b, 0xe4, 0x1c, 0xde, 0x6d, 0x5f, 0x90, 0x52, 0x6d,
+	0x49, 0x31, 0xd6, 0xa2, 0x0d, 0x44, 0x17, 0x50, 0x5f, 0x5b, 0x23, 0x74, 0x0e, 0x6f, 0x2c, 0xf3,
+	0x9a, 0x0c, 0xfa, 0xc4, 0x9e, 0xe2, 0xde, 0xd4, 0x18, 0x7c, 0x22, 0x1f, 0x27, 0xb6, 0x65, 0xf4,
+	0x87, 0x37, 0x43, 0xe3, 0x5a, 0xfb, 0x1f, 0xaa, 0x43, 0x75, 0x64, 0x18, 0x16, 0xb1, 0xcc, 0x6b,
+	0x5b, 0x53, 0x50, 0x07, 0x4e, 0xcc, 0x09, 0x79, 0x30, 0xf1, 0xe8, 0x66, 0x6c, 0x3e, 0x90, 0xbe,
+	0x79, 0x67, 0x8d, 0x8d, 0xe9, 0xd0, 0x9c, 0x68, 0x15, 0x74, 0x0a, 0x47, 0xab, 0x9c, 0xfd, 0xb1,
+	0xdf, 0x37, 0x6c, 0x5b, 0x53, 0xf5, 0x31, 0x34, 0x37, 0xf6, 0x0d, 0x5d, 0xc0, 0xff, 0x7b, 0x78,
+	0x3a, 0xbc, 0xe9, 0xf5, 0xa7, 0xa4, 0x87, 0xfb, 0xb7, 0xc3, 0x7b, 0x63, 0xe3, 0xe1, 0x7d, 0x50,
+	0xa7, 0x3d, 0xac, 0x29, 0xa8, 0x01, 0x30, 0x31, 0x0b, 0x91, 0x56, 0xd1, 0x4d, 0x80, 0xe5, 0xc6,
+	0xa1, 0x37, 0x70, 0x3a, 0x36, 0x07, 0x5f, 0x89, 0xa1, 0xc1, 0x61, 0x41, 0x8c, 0xcd, 0x41, 0x9a,
+	0x3f, 0x82, 0xc6, 0xc4, 0x24, 0x2b, 0x1e, 0x5a, 0x45, 0xf7, 0x40, 0xdb, 0x6c, 0x1d, 0xfa, 0x06,
+	0xce, 0x2c, 0x6c, 0x18, 0x77, 0x56, 0x5a, 0x1b, 0xb1, 0xcc, 0xf1, 0xb0, 0xbf, 0xd9, 0x99, 0x0e,
+	0x9c, 0xe4, 0x12, 0x32, 0x36, 0x1f, 0x0c, 0x4c, 0x2c, 0x3c, 0x34, 0xf1, 0x70, 0xfa, 0x49, 0x53,
+	0x50, 0x0b, 0xea, 0x05, 0x37, 0x31, 0xee, 0x0d, 0xac, 0x55, 0xf4, 0x11, 0xa0, 0xd7, 0x2b, 0x88,
+	0x4e, 0x60, 0xef, 0xf3, 0x93, 0xfb, 0x48, 0x45, 0x7e, 0x23, 0x73, 0x0b, 0x9d, 0x01, 0x3c, 0xd2,
+	0x84, 0x44, 0x9c, 0xce, 0xfc, 0x3f, 0xf2, 0xc3, 0x58, 0x7d, 0xa4, 0x89, 0x25, 0x01, 0xfd, 0x1f,
+	0x45, 0x0e, 0x72, 0x65, 0xf9, 0x86, 0x50, 0x0f, 0x99, 0x47, 0x49, 0x4c, 0x03, 0xea, 0x0a, 0xc6,
+	0xdb, 0x8a, 0x5c, 0xc3, 0xb7, 0xaf, 0xd7, 0xb6, 0x3b, 0x61, 0x1e, 0xb5, 0x73, 0x99, 0x11, 0x0a,
+	0x9e, 0xe0, 0xc3, 0x70, 0x05, 0x42, 0x3f, 0x42, 0x4d, 0xb0, 0x80, 0x72, 0x27, 0x3b, 0x86, 0x15,
+	0x19, 0xa8, 0x29, 0x03, 0x4d, 0x4b, 0x1c, 0xaf, 0x6a, 0x50, 0x07, 0x0e, 0x9c, 0xd9, 0xcc, 0x0f,
+	0x7d, 0x91, 0xe4, 0x87, 0xb9, 0xb4, 0x3b, 0x1f, 0xa0, 0xf5, 0xea, 0x45, 0xa4, 0x81, 0xfa, 0x48,
+	0x93, 0xbc, 0xe8, 0xf4, 0x13, 0x1d, 0xc3, 0xee, 0xb3, 0x13, 0x3c, 0xd1, 0xbc, 0xd8, 0xcc, 0xf8,
+	0xb5, 0xf2, 0xb3, 0xa2, 0xff, 0xa9, 0x00, 0x2c, 0x1f, 0xde, 0xe2, 0xda, 0x81, 0x03, 0x16, 0xa5,
+	0x34, 0xe3, 0xb9, 0x77, 0x69, 0x2f, 0xc3, 0xaa, 0x2b, 0x61, 0xd3, 0xb6, 0xd3, 0xd9, 0x8c, 0xba,
+	0x42, 0xde, 0xa2, 0x2a, 0xce, 0x2d, 0xf4, 0x3d, 0xa0, 0x65, 0x59, 0x24, 0xa6, 0x2e, 0x0b, 0xbd,
+	0x38, 0x3f, 0x38, 0xad, 0x25, 0x63, 0x67, 0x84, 0xfe, 0x97, 0x02, 0x7b, 0xd9, 0x4f, 0x1c, 0x21,
+	0xd8, 0x91, 0x3f, 0xe7, 0x2c, 0x2d, 0xf9, 0xbd, 0xbd, 0x24, 0xf4, 0x13, 0x34, 0x5c, 0x16, 0xce,
+	0xfc, 0x39, 0x59, 0x38, 0x11, 0x49, 0x4b, 0x51, 0xe5, 0x85, 0xd1, 0x64, 0x87, 0x47, 0x34, 0x29,
+	0x3a, 0x85, 0x0f, 0x33, 0xdd, 0x9d, 0x13, 0x8d, 0x68, 0x82, 0x7e, 0x00, 0x88, 0xa9, 0xcb, 0xa9,
+	0x90, 0x3e, 0x3b, 0x5f, 0xf1, 0xa9, 0x66, 0x9a, 0x11, 0x4d, 0xf4, 0xf7, 0x50, 0x5b, 0x61, 0xb6,
+	0x66, 0x98, 0xf7, 0xb2, 0x52, 0xf6, 0x52, 0x7f, 0x07, 0xf5, 0xf5, 0xe3, 0x7b, 0x0c, 0xbb, 0x81,
+	0xbf, 0xf0, 0xb3, 0x05, 0xdd, 0xc5, 0x99, 0xf1, 0x79, 0x4f, 0xfe, 0xf1, 0xbf, 0xff, 0x6f, 0x00,
+	0xe8, 0x55, 0xdb, 0x1f, 0x25, 0x08, 0x00, 0x00,
 }
@@ -38,6 +38,41 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ReportWorkflowsResponse_ReportWorkflowResult_Status int32
+
+const (
+	// Default value if not present.
+	ReportWorkflowsResponse_ReportWorkflowResult_UNSPECIFIED ReportWorkflowsResponse_ReportWorkflowResult_Status = 0
+	// Indicates successful reporting.
+	ReportWorkflowsResponse_ReportWorkflowResult_OK ReportWorkflowsResponse_ReportWorkflowResult_Status = 1
+	// Indicates that the workflow is invalid. Reporting it again won't succeed.
+	ReportWorkflowsResponse_ReportWorkflowResult_INVALID_ARGUMENT ReportWorkflowsResponse_ReportWorkflowResult_Status = 2
+	// Indicates that something went wrong in the server.
+	ReportWorkflowsResponse_ReportWorkflowResult_INTERNAL_ERROR ReportWorkflowsResponse_ReportWorkflowResult_Status = 3
+)
+
+var ReportWorkflowsResponse_ReportWorkflowResult_Status_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "OK",
+	2: "INVALID_ARGUMENT",
+	3: "INTERNAL_ERROR",
+}
+
+var ReportWorkflowsResponse_ReportWorkflowResult_Status_value = map[string]int32{
+	"UNSPECIFIED":      0,
+	"OK":               1,
+	"INVALID_ARGUMENT": 2,
+	"INTERNAL_ERROR":   3,
+}
+
+func (x ReportWorkflowsResponse_ReportWorkflowResult_Status) String() string {
+	return proto.EnumName(ReportWorkflowsResponse_ReportWorkflowResult_Status_name, int32(x))
+}
+
+func (ReportWorkflowsResponse_ReportWorkflowResult_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{2, 0, 0}
+}
+
 type ReportWorkflowRequest struct {
 	// Workflow is a workflow custom resource marshalled into a json string.
 	Workflow             string   `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
@@ -78,6 +113,139 @@ func (m *ReportWorkflowRequest) GetWorkflow() string {
 	return ""
 }
 
+type ReportWorkflowsRequest struct {
+	// Workflows are workflow custom resources marshalled into json strings.
+	Workflows            []string `protobuf:"bytes,1,rep,name=workflows,proto3" json:"workflows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportWorkflowsRequest) Reset()         { *m = ReportWorkflowsRequest{} }
+func (m *ReportWorkflowsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportWorkflowsRequest) ProtoMessage()    {}
+func (*ReportWorkflowsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{1}
+}
+
+func (m *ReportWorkflowsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportWorkflowsRequest.Unmarshal(m, b)
+}
+func (m *ReportWorkflowsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportWorkflowsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportWorkflowsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportWorkflowsRequest.Merge(m, src)
+}
+func (m *ReportWorkflowsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportWorkflowsRequest.Size(m)
+}
+func (m *ReportWorkflowsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportWorkflowsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportWorkflowsRequest proto.InternalMessageInfo
+
+func (m *ReportWorkflowsRequest) GetWorkflows() []string {
+	if m != nil {
+		return m.Workflows
+	}
+	return nil
+}
+
+type ReportWorkflowsResponse struct {
+	// The results of the workflows, in the order of the request.
+	Results              []*ReportWorkflowsResponse_ReportWorkflowResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
+	XXX_unrecognized     []byte                                          `json:"-"`
+	XXX_sizecache        int32                                           `json:"-"`
+}
+
+func (m *ReportWorkflowsResponse) Reset()         { *m = ReportWorkflowsResponse{} }
+func (m *ReportWorkflowsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportWorkflowsResponse) ProtoMessage()    {}
+func (*ReportWorkflowsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{2}
+}
+
+func (m *ReportWorkflowsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportWorkflowsResponse.Unmarshal(m, b)
+}
+func (m *ReportWorkflowsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportWorkflowsResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportWorkflowsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportWorkflowsResponse.Merge(m, src)
+}
+func (m *ReportWorkflowsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportWorkflowsResponse.Size(m)
+}
+func (m *ReportWorkflowsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportWorkflowsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportWorkflowsResponse proto.InternalMessageInfo
+
+func (m *ReportWorkflowsResponse) GetResults() []*ReportWorkflowsResponse_ReportWorkflowResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ReportWorkflowsResponse_ReportWorkflowResult struct {
+	// Output. The status of the workflow reporting.
+	Status ReportWorkflowsResponse_ReportWorkflowResult_Status `protobuf:"varint,1,opt,name=status,proto3,enum=api.ReportWorkflowsResponse_ReportWorkflowResult_Status" json:"status,omitempty"`
+	// Output. The detailed message of the error of the reporting.
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) Reset() {
+	*m = ReportWorkflowsResponse_ReportWorkflowResult{}
+}
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) String() string {
+	return proto.CompactTextString(m)
+}
+func (*ReportWorkflowsResponse_ReportWorkflowResult) ProtoMessage() {}
+func (*ReportWorkflowsResponse_ReportWorkflowResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3eedb623aa6ca98c, []int{2, 0}
+}
+
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportWorkflowsResponse_ReportWorkflowResult.Unmarshal(m, b)
+}
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportWorkflowsResponse_ReportWorkflowResult.Marshal(b, m, deterministic)
+}
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportWorkflowsResponse_ReportWorkflowResult.Merge(m, src)
+}
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) XXX_Size() int {
+	return xxx_messageInfo_ReportWorkflowsResponse_ReportWorkflowResult.Size(m)
+}
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportWorkflowsResponse_ReportWorkflowResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportWorkflowsResponse_ReportWorkflowResult proto.InternalMessageInfo
+
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) GetStatus() ReportWorkflowsResponse_ReportWorkflowResult_Status {
+	if m != nil {
+		return m.Status
+	}
+	return ReportWorkflowsResponse_ReportWorkflowResult_UNSPECIFIED
+}
+
+func (m *ReportWorkflowsResponse_ReportWorkflowResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 type ReportScheduledWorkflowRequest struct {
 	// ScheduledWorkflow a ScheduledWorkflow resource marshalled into a json string.
 	ScheduledWorkflow    string   `protobuf:"bytes,1,opt,name=scheduled_workflow,json=scheduledWorkflow,proto3" json:"scheduled_workflow,omitempty"`
@@ -90,7 +258,7 @@ func (m *ReportScheduledWorkflowRequest) Reset()         { *m = ReportScheduledW
 func (m *ReportScheduledWorkflowRequest) String() string { return proto.CompactTextString(m) }
 func (*ReportScheduledWorkflowRequest) ProtoMessage()    {}
 func (*ReportScheduledWorkflowRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3eedb623aa6ca98c, []int{1}
+	return fileDescriptor_3eedb623aa6ca98c, []int{3}
 }
 
 func (m *ReportScheduledWorkflowRequest) XXX_Unmarshal(b []byte) error {
@@ -119,31 +287,48 @@ func (m *ReportScheduledWorkflowRequest) GetScheduledWorkflow() string {
 }
 
 func init() {
+	proto.RegisterEnum("api.ReportWorkflowsResponse_ReportWorkflowResult_Status", ReportWorkflowsResponse_ReportWorkflowResult_Status_name, ReportWorkflowsResponse_ReportWorkflowResult_Status_value)
 	proto.RegisterType((*ReportWorkflowRequest)(nil), "api.ReportWorkflowRequest")
+	proto.RegisterType((*ReportWorkflowsRequest)(nil), "api.ReportWorkflowsRequest")
+	proto.RegisterType((*ReportWorkflowsResponse)(nil), "api.ReportWorkflowsResponse")
+	proto.RegisterType((*ReportWorkflowsResponse_ReportWorkflowResult)(nil), "api.ReportWorkflowsResponse.ReportWorkflowResult")
 	proto.RegisterType((*ReportScheduledWorkflowRequest)(nil), "api.ReportScheduledWorkflowRequest")
 }
 
 func init() { proto.RegisterFile("report.proto", fileDescriptor_3eedb623aa6ca98c) }
 
 var fileDescriptor_3eedb623aa6ca98c = []byte{
-	// 263 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0x4a, 0x2d, 0xc8,
-	0x2f, 0x2a, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x4e, 0x2c, 0xc8, 0x94, 0x92, 0x49,
-	0xcf, 0xcf, 0x4f, 0xcf, 0x49, 0xd5, 0x4f, 0x2c, 0xc8, 0xd4, 0x4f, 0xcc, 0xcb, 0xcb, 0x2f, 0x49,
-	0x2c, 0xc9, 0xcc, 0xcf, 0x2b, 0x86, 0x28, 0x91, 0x92, 0x86, 0xca, 0x82, 0x79, 0x49, 0xa5, 0x69,
-	0xfa, 0xa9, 0xb9, 0x05, 0x25, 0x95, 0x10, 0x49, 0x25, 0x63, 0x2e, 0xd1, 0x20, 0xb0, 0x79, 0xe1,
-	0xf9, 0x45, 0xd9, 0x69, 0x39, 0xf9, 0xe5, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x42, 0x52,
-	0x5c, 0x1c, 0xe5, 0x50, 0x21, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xce, 0x20, 0x38, 0x5f, 0xc9, 0x9f,
-	0x4b, 0x0e, 0xa2, 0x29, 0x38, 0x39, 0x23, 0x35, 0xa5, 0x34, 0x27, 0x35, 0x05, 0x5d, 0xb7, 0x2e,
-	0x97, 0x50, 0x31, 0x4c, 0x2e, 0x1e, 0xcd, 0x1c, 0xc1, 0x62, 0x74, 0x5d, 0x46, 0xd3, 0x99, 0xb8,
-	0x78, 0xa1, 0x26, 0xa6, 0x16, 0x95, 0x65, 0x26, 0xa7, 0x0a, 0xe5, 0x73, 0xf1, 0xa1, 0xba, 0x4b,
-	0x48, 0x4a, 0x2f, 0xb1, 0x20, 0x53, 0x0f, 0xab, 0x63, 0xa5, 0xc4, 0xf4, 0x20, 0x7e, 0xd4, 0x83,
-	0xf9, 0x51, 0xcf, 0x15, 0xe4, 0x47, 0x25, 0xcd, 0xa6, 0xcb, 0x4f, 0x26, 0x33, 0x29, 0x2b, 0x89,
-	0x83, 0x82, 0xa6, 0x58, 0xbf, 0xcc, 0x30, 0x29, 0xb5, 0x24, 0xd1, 0x50, 0x1f, 0xe6, 0xa0, 0x62,
-	0x2b, 0xb8, 0x9f, 0x84, 0xa6, 0x30, 0x72, 0x89, 0xe3, 0xf0, 0x94, 0x90, 0x32, 0x92, 0xd5, 0xb8,
-	0xbc, 0x8c, 0xd3, 0x0d, 0x36, 0x60, 0x37, 0x98, 0x29, 0x29, 0xa0, 0xba, 0x01, 0x1e, 0x08, 0x08,
-	0xc7, 0x60, 0x09, 0xb2, 0x24, 0x36, 0xb0, 0x69, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e,
-	0x77, 0x24, 0xba, 0xf6, 0x01, 0x00, 0x00,
+	// 472 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x8e, 0x94, 0x92, 0x29, 0xa4, 0x66, 0x54, 0x9a, 0xc8, 0x8d, 0x50, 0xe4, 0x5c, 0x02,
+	0x12, 0x6b, 0x25, 0x95, 0x2a, 0x14, 0x71, 0x89, 0xa8, 0x41, 0x56, 0x8a, 0x53, 0x6d, 0x5a, 0x38,
+	0x46, 0x9b, 0x74, 0x9b, 0x5a, 0xa4, 0x59, 0xe3, 0x5d, 0xb7, 0x42, 0xe2, 0xc4, 0x2b, 0x00, 0xef,
+	0xc2, 0x5b, 0x70, 0xe0, 0x15, 0x78, 0x10, 0x14, 0xff, 0x34, 0xc4, 0xc4, 0x48, 0x3d, 0xee, 0xcc,
+	0x7c, 0xf3, 0xcd, 0x7e, 0xdf, 0x07, 0x0f, 0x42, 0x1e, 0x88, 0x50, 0x91, 0x20, 0x14, 0x4a, 0x60,
+	0x89, 0x05, 0xbe, 0xd9, 0x98, 0x09, 0x31, 0x9b, 0x73, 0x9b, 0x05, 0xbe, 0xcd, 0x16, 0x0b, 0xa1,
+	0x98, 0xf2, 0xc5, 0x42, 0x26, 0x23, 0xe6, 0x7e, 0xda, 0x8d, 0x5f, 0x93, 0xe8, 0xc2, 0xe6, 0x57,
+	0x81, 0xfa, 0x94, 0x34, 0xad, 0x03, 0x78, 0x4c, 0xe3, 0x7d, 0xef, 0x45, 0xf8, 0xe1, 0x62, 0x2e,
+	0x6e, 0x28, 0xff, 0x18, 0x71, 0xa9, 0xd0, 0x84, 0xfb, 0x37, 0x69, 0xa9, 0xae, 0x35, 0xb5, 0x76,
+	0x85, 0xde, 0xbe, 0xad, 0x43, 0xd8, 0x5b, 0x07, 0xc9, 0x0c, 0xd5, 0x80, 0x4a, 0x36, 0x25, 0xeb,
+	0x5a, 0xb3, 0xd4, 0xae, 0xd0, 0x55, 0xc1, 0xfa, 0xa1, 0x43, 0xed, 0x1f, 0xa0, 0x0c, 0xc4, 0x42,
+	0x72, 0x1c, 0xc0, 0x56, 0xc8, 0x65, 0x34, 0x57, 0x09, 0x6e, 0xbb, 0xdb, 0x21, 0x2c, 0xf0, 0x49,
+	0xc1, 0x38, 0xc9, 0x1f, 0xbd, 0x44, 0xd2, 0x6c, 0x83, 0xf9, 0x53, 0x83, 0xdd, 0x4d, 0x13, 0x78,
+	0x02, 0x65, 0xa9, 0x98, 0x8a, 0x64, 0xfc, 0xa7, 0x6a, 0xf7, 0xc5, 0x9d, 0x49, 0xc8, 0x28, 0xc6,
+	0xd3, 0x74, 0x0f, 0xd6, 0x61, 0xeb, 0x8a, 0x4b, 0xc9, 0x66, 0xbc, 0xae, 0xc7, 0x32, 0x65, 0x4f,
+	0x6b, 0x00, 0xe5, 0x64, 0x16, 0x77, 0x60, 0xfb, 0xcc, 0x1b, 0x9d, 0x38, 0xaf, 0xdc, 0xd7, 0xae,
+	0x73, 0x64, 0xdc, 0xc3, 0x32, 0xe8, 0xc3, 0x81, 0xa1, 0xe1, 0x2e, 0x18, 0xae, 0xf7, 0xae, 0x7f,
+	0xec, 0x1e, 0x8d, 0xfb, 0xf4, 0xcd, 0xd9, 0x5b, 0xc7, 0x3b, 0x35, 0x74, 0x44, 0xa8, 0xba, 0xde,
+	0xa9, 0x43, 0xbd, 0xfe, 0xf1, 0xd8, 0xa1, 0x74, 0x48, 0x8d, 0x92, 0x35, 0x84, 0x27, 0xc9, 0x35,
+	0xa3, 0xe9, 0x25, 0x3f, 0x8f, 0xe6, 0xfc, 0x3c, 0x6f, 0xd8, 0x73, 0x40, 0x99, 0xf5, 0xc6, 0x39,
+	0xeb, 0x1e, 0xc9, 0x3c, 0xaa, 0xfb, 0xbd, 0x04, 0x0f, 0xd3, 0x8d, 0x3c, 0xbc, 0xf6, 0xa7, 0x1c,
+	0x05, 0x54, 0xd7, 0x3f, 0x8c, 0xe6, 0x06, 0x75, 0x52, 0x3a, 0x73, 0x8f, 0x24, 0xb1, 0x22, 0x59,
+	0xac, 0x88, 0xb3, 0x8c, 0x95, 0xf5, 0xf4, 0xcb, 0xaf, 0xdf, 0x5f, 0xf5, 0x56, 0x6f, 0x95, 0x96,
+	0xda, 0x32, 0x97, 0xd2, 0xbe, 0xee, 0x4c, 0xb8, 0x62, 0x1d, 0x3b, 0xab, 0x4b, 0xfc, 0x0c, 0x3b,
+	0x39, 0xe5, 0x71, 0x7f, 0xb3, 0x1f, 0x09, 0x65, 0xe3, 0x7f, 0x66, 0x59, 0x24, 0x26, 0x6e, 0x5b,
+	0xad, 0x02, 0xba, 0xde, 0x84, 0xa9, 0xe9, 0x65, 0x82, 0xee, 0x69, 0xcf, 0xf0, 0x9b, 0x06, 0xb5,
+	0x02, 0x49, 0xb1, 0xf5, 0x17, 0x53, 0x91, 0xe0, 0x85, 0x0a, 0xbc, 0x8c, 0x0f, 0x39, 0xb4, 0x9a,
+	0xeb, 0x87, 0xdc, 0x5a, 0xb0, 0xba, 0x68, 0x83, 0x61, 0x93, 0x72, 0xbc, 0xed, 0xe0, 0xcf, 0x00,
+	0x58, 0x88, 0x1d, 0x91, 0xe7, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -159,6 +344,9 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type ReportServiceClient interface {
 	ReportWorkflow(ctx context.Context, in *ReportWorkflowRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Reports several workflows at once. Each workflow is stored independently of the others, and
+	// the outcome of each is returned in the order of the request.
+	ReportWorkflows(ctx context.Context, in *ReportWorkflowsRequest, opts ...grpc.CallOption) (*ReportWorkflowsResponse, error)
 	ReportScheduledWorkflow(ctx context.Context, in *ReportScheduledWorkflowRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
@@ -179,6 +367,15 @@ func (c *reportServiceClient) ReportWorkflow(ctx context.Context, in *ReportWork
 	return out, nil
 }
 
+func (c *reportServiceClient) ReportWorkflows(ctx context.Context, in *ReportWorkflowsRequest, opts ...grpc.CallOption) (*ReportWorkflowsResponse, error) {
+	out := new(ReportWorkflowsResponse)
+	err := c.cc.Invoke(ctx, "/api.ReportService/ReportWorkflows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *reportServiceClient) ReportScheduledWorkflow(ctx context.Context, in *ReportScheduledWorkflowRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/api.ReportService/ReportScheduledWorkflow", in, out, opts...)
@@ -191,6 +388,9 @@ func (c *reportServiceClient) ReportScheduledWorkflow(ctx context.Context, in *R
 // ReportServiceServer is the server API for ReportService service.
 type ReportServiceServer interface {
 	ReportWorkflow(context.Context, *ReportWorkflowRequest) (*empty.Empty, error)
+	// Reports several workflows at once. Each workflow is stored independently of the others, and
+	// the outcome of each is returned in the order of the request.
+	ReportWorkflows(context.Context, *ReportWorkflowsRequest) (*ReportWorkflowsResponse, error)
 	ReportScheduledWorkflow(context.Context, *ReportScheduledWorkflowRequest) (*empty.Empty, error)
 }
 
@@ -216,6 +416,24 @@ func _ReportService_ReportWorkflow_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ReportService_ReportWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportWorkflowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).ReportWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ReportService/ReportWorkflows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).ReportWorkflows(ctx, req.(*ReportWorkflowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ReportService_ReportScheduledWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReportScheduledWorkflowRequest)
 	if err := dec(in); err != nil {
@@ -242,6 +460,10 @@ var _ReportService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReportWorkflow",
 			Handler:    _ReportService_ReportWorkflow_Handler,
 		},
+		{
+			MethodName: "ReportWorkflows",
+			Handler:    _ReportService_ReportWorkflows_Handler,
+		},
 		{
 			MethodName: "ReportScheduledWorkflow",
 			Handler:    _ReportService_ReportScheduledWorkflow_Handler,
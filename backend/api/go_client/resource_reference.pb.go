@@ -141,6 +141,10 @@ func (m *ResourceKey) GetId() string {
 
 type ResourceReference struct {
 	Key *ResourceKey `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// The name of the resource referred to. A run may refer to the experiment
+	// owning it by its name instead of its ID, the experiment being created if
+	// none has the name. The ID takes precedence over the name.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 	// Required field. The relationship from referred resource to the object.
 	Relationship         Relationship `protobuf:"varint,2,opt,name=relationship,proto3,enum=api.Relationship" json:"relationship,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
@@ -180,6 +184,13 @@ func (m *ResourceReference) GetKey() *ResourceKey {
 	return nil
 }
 
+func (m *ResourceReference) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 func (m *ResourceReference) GetRelationship() Relationship {
 	if m != nil {
 		return m.Relationship
@@ -197,22 +208,23 @@ func init() {
 func init() { proto.RegisterFile("resource_reference.proto", fileDescriptor_52a3fd5cc3dcce29) }
 
 var fileDescriptor_52a3fd5cc3dcce29 = []byte{
-	// 264 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x41, 0x4b, 0xc3, 0x40,
-	0x10, 0x46, 0x9b, 0x54, 0x2d, 0x9d, 0x96, 0xb2, 0x5d, 0x14, 0xe2, 0xad, 0x04, 0x84, 0xd2, 0x43,
-	0x0e, 0x15, 0xef, 0xb6, 0x75, 0xc1, 0x18, 0xdd, 0x0d, 0xd3, 0x94, 0xea, 0x29, 0xc4, 0x76, 0xc4,
-	0x45, 0x49, 0x96, 0x6d, 0x3c, 0xe4, 0xdf, 0x4b, 0x83, 0x21, 0xf6, 0xfc, 0x1e, 0xef, 0x1b, 0x06,
-	0x3c, 0x4b, 0x87, 0xe2, 0xc7, 0xee, 0x28, 0xb5, 0xf4, 0x41, 0x96, 0xf2, 0x1d, 0x05, 0xc6, 0x16,
-	0x65, 0xc1, 0xbb, 0x99, 0xd1, 0xfe, 0x03, 0x0c, 0xf0, 0x4f, 0x88, 0xa8, 0xe2, 0x37, 0x70, 0x56,
-	0x56, 0x86, 0x3c, 0x67, 0xe2, 0x4c, 0x47, 0xf3, 0x71, 0x90, 0x19, 0x1d, 0x34, 0x3c, 0xa9, 0x0c,
-	0x61, 0x8d, 0xf9, 0x08, 0x5c, 0xbd, 0xf7, 0xdc, 0x89, 0x33, 0xed, 0xa3, 0xab, 0xf7, 0x7e, 0x0e,
-	0xe3, 0xc6, 0xc2, 0x66, 0x85, 0xfb, 0xd0, 0xfd, 0xa2, 0xaa, 0x4e, 0x0d, 0xe6, 0xec, 0x24, 0x15,
-	0x51, 0x85, 0x47, 0xc8, 0xef, 0x60, 0x68, 0xe9, 0x3b, 0x2b, 0x75, 0x91, 0x1f, 0x3e, 0xb5, 0xa9,
-	0x93, 0xed, 0x6e, 0x0b, 0xf0, 0x44, 0x9b, 0x2d, 0x61, 0xf8, 0xff, 0x2a, 0x7e, 0x0d, 0x57, 0x1b,
-	0x19, 0x49, 0xb5, 0x95, 0x29, 0x8a, 0xb5, 0xda, 0xe0, 0x4a, 0xa4, 0xc9, 0x5b, 0x2c, 0x58, 0x87,
-	0x8f, 0x00, 0xc4, 0x6b, 0x2c, 0x30, 0x7c, 0x11, 0x32, 0x61, 0x0e, 0xef, 0x41, 0xf7, 0x49, 0x2d,
-	0x99, 0x3b, 0xbb, 0x3f, 0x36, 0xda, 0x26, 0xf7, 0xe0, 0xb2, 0x6d, 0x3c, 0x2f, 0x92, 0x50, 0xc9,
-	0xf5, 0x63, 0x18, 0xb3, 0x0e, 0xef, 0xc3, 0xb9, 0xda, 0x4a, 0x81, 0xcc, 0xe1, 0x03, 0xe8, 0xad,
-	0x50, 0x2c, 0x12, 0x85, 0xcc, 0x7d, 0xbf, 0xa8, 0xff, 0x78, 0xfb, 0x1b, 0x00, 0x00, 0xff, 0xff,
-	0x35, 0xb6, 0x7b, 0xd3, 0x63, 0x01, 0x00, 0x00,
+	// 275 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xc1, 0x4b, 0x72, 0x41,
+	0x14, 0x47, 0x7d, 0xf3, 0xfc, 0x3e, 0xf1, 0x2a, 0x32, 0x5e, 0x0a, 0xa6, 0x9d, 0x08, 0x81, 0xb8,
+	0x70, 0x61, 0xb4, 0x4f, 0x6d, 0x20, 0xb3, 0x66, 0xe4, 0xfa, 0xc4, 0x5a, 0xc9, 0x4b, 0x6f, 0x34,
+	0x54, 0xbe, 0x61, 0xb4, 0xc5, 0xdb, 0xf6, 0x97, 0x87, 0x8f, 0xc4, 0xdc, 0x0d, 0x9c, 0xc3, 0x99,
+	0x1f, 0x17, 0x54, 0xe0, 0x6d, 0xf6, 0x15, 0x56, 0xbc, 0x0c, 0xfc, 0xca, 0x81, 0x37, 0x2b, 0xee,
+	0xf9, 0x90, 0xed, 0x32, 0x8c, 0x53, 0xef, 0xda, 0xb7, 0x50, 0xa3, 0x5f, 0x61, 0xc2, 0x39, 0x5e,
+	0x42, 0x79, 0x97, 0x7b, 0x56, 0x51, 0x2b, 0xea, 0x34, 0xfa, 0xcd, 0x5e, 0xea, 0x5d, 0xef, 0xc0,
+	0x93, 0xdc, 0x33, 0x15, 0x18, 0x1b, 0x20, 0xdc, 0x5a, 0x89, 0x56, 0xd4, 0xa9, 0x92, 0x70, 0xeb,
+	0xf6, 0x77, 0x04, 0xcd, 0x83, 0x46, 0x87, 0x6f, 0xb0, 0x0d, 0xf1, 0x3b, 0xe7, 0x45, 0xab, 0xd6,
+	0x97, 0x27, 0xad, 0x09, 0xe7, 0xb4, 0x87, 0x88, 0x50, 0xde, 0xa4, 0x9f, 0xac, 0xe2, 0xa2, 0x55,
+	0xbc, 0xf1, 0x1a, 0xea, 0x81, 0x3f, 0xd2, 0x9d, 0xcb, 0x36, 0xdb, 0x37, 0xe7, 0x95, 0x38, 0x19,
+	0x73, 0x04, 0x74, 0xa2, 0x75, 0x87, 0x50, 0xff, 0x3b, 0x15, 0x2f, 0xe0, 0x7c, 0x6e, 0x26, 0xc6,
+	0x2e, 0xcc, 0x92, 0xf4, 0xcc, 0xce, 0x69, 0xa4, 0x97, 0xc9, 0xf3, 0x54, 0xcb, 0x12, 0x36, 0x00,
+	0xf4, 0xd3, 0x54, 0xd3, 0xf8, 0x51, 0x9b, 0x44, 0x46, 0x58, 0x81, 0xf8, 0xde, 0x0e, 0xa5, 0xe8,
+	0xde, 0xec, 0x1b, 0xc7, 0x26, 0x2a, 0x38, 0x3b, 0x36, 0x1e, 0x06, 0xc9, 0xd8, 0x9a, 0xd9, 0xdd,
+	0x78, 0x2a, 0x4b, 0x58, 0x85, 0x7f, 0x76, 0x61, 0x34, 0xc9, 0x08, 0x6b, 0x50, 0x19, 0x91, 0x1e,
+	0x24, 0x96, 0xa4, 0x78, 0xf9, 0x5f, 0x1c, 0xf7, 0xea, 0x67, 0x00, 0x97, 0x10, 0x89, 0x62, 0x78,
+	0x01, 0x00, 0x00,
 }
@@ -20,7 +20,7 @@ package api
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
-	_ "github.com/golang/protobuf/ptypes/empty"
+	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
 	context "golang.org/x/net/context"
@@ -40,6 +40,131 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type TerminateResult_Status int32
+
+const (
+	// The run isn't processed yet.
+	TerminateResult_PENDING    TerminateResult_Status = 0
+	TerminateResult_TERMINATED TerminateResult_Status = 1
+	// The run finished before it was terminated.
+	TerminateResult_ALREADY_FINISHED TerminateResult_Status = 2
+	// The run couldn't be terminated, as described by the error.
+	TerminateResult_FAILED TerminateResult_Status = 3
+)
+
+var TerminateResult_Status_name = map[int32]string{
+	0: "PENDING",
+	1: "TERMINATED",
+	2: "ALREADY_FINISHED",
+	3: "FAILED",
+}
+
+var TerminateResult_Status_value = map[string]int32{
+	"PENDING":          0,
+	"TERMINATED":       1,
+	"ALREADY_FINISHED": 2,
+	"FAILED":           3,
+}
+
+func (x TerminateResult_Status) String() string {
+	return proto.EnumName(TerminateResult_Status_name, int32(x))
+}
+
+func (TerminateResult_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{14, 0}
+}
+
+type ListRunsRequest_View int32
+
+const (
+	// The runs without the workflow and pipeline manifests of their pipeline specs.
+	ListRunsRequest_BASIC ListRunsRequest_View = 0
+	// The runs with the manifests, as returned by GetRun.
+	ListRunsRequest_FULL ListRunsRequest_View = 1
+)
+
+var ListRunsRequest_View_name = map[int32]string{
+	0: "BASIC",
+	1: "FULL",
+}
+
+var ListRunsRequest_View_value = map[string]int32{
+	"BASIC": 0,
+	"FULL":  1,
+}
+
+func (x ListRunsRequest_View) String() string {
+	return proto.EnumName(ListRunsRequest_View_name, int32(x))
+}
+
+func (ListRunsRequest_View) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{20, 0}
+}
+
+type DeploymentStatus_State int32
+
+const (
+	DeploymentStatus_UNSPECIFIED DeploymentStatus_State = 0
+	// The resource isn't ready yet.
+	DeploymentStatus_PENDING DeploymentStatus_State = 1
+	DeploymentStatus_READY   DeploymentStatus_State = 2
+	// The resource failed, or wasn't ready within the timeout of the tracking.
+	DeploymentStatus_FAILED DeploymentStatus_State = 3
+)
+
+var DeploymentStatus_State_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "PENDING",
+	2: "READY",
+	3: "FAILED",
+}
+
+var DeploymentStatus_State_value = map[string]int32{
+	"UNSPECIFIED": 0,
+	"PENDING":     1,
+	"READY":       2,
+	"FAILED":      3,
+}
+
+func (x DeploymentStatus_State) String() string {
+	return proto.EnumName(DeploymentStatus_State_name, int32(x))
+}
+
+func (DeploymentStatus_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{28, 0}
+}
+
+type ApprovalGate_State int32
+
+const (
+	ApprovalGate_STATE_UNSPECIFIED ApprovalGate_State = 0
+	ApprovalGate_WAITING           ApprovalGate_State = 1
+	ApprovalGate_APPROVED          ApprovalGate_State = 2
+	ApprovalGate_REJECTED          ApprovalGate_State = 3
+)
+
+var ApprovalGate_State_name = map[int32]string{
+	0: "STATE_UNSPECIFIED",
+	1: "WAITING",
+	2: "APPROVED",
+	3: "REJECTED",
+}
+
+var ApprovalGate_State_value = map[string]int32{
+	"STATE_UNSPECIFIED": 0,
+	"WAITING":           1,
+	"APPROVED":          2,
+	"REJECTED":          3,
+}
+
+func (x ApprovalGate_State) String() string {
+	return proto.EnumName(ApprovalGate_State_name, int32(x))
+}
+
+func (ApprovalGate_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{31, 0}
+}
+
 type RunMetric_Format int32
 
 const (
@@ -68,7 +193,7 @@ func (x RunMetric_Format) String() string {
 }
 
 func (RunMetric_Format) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{7, 0}
+	return fileDescriptor_e3419bc3417bf873, []int{34, 0}
 }
 
 type ReportRunMetricsResponse_ReportRunMetricResult_Status int32
@@ -107,1115 +232,4783 @@ func (x ReportRunMetricsResponse_ReportRunMetricResult_Status) String() string {
 }
 
 func (ReportRunMetricsResponse_ReportRunMetricResult_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{9, 0, 0}
+	return fileDescriptor_e3419bc3417bf873, []int{36, 0, 0}
 }
 
-type CreateRunRequest struct {
-	Run                  *Run     `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type CreateRunSweepRequest struct {
+	Sweep                *RunSweep `protobuf:"bytes,1,opt,name=sweep,proto3" json:"sweep,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *CreateRunRequest) Reset()         { *m = CreateRunRequest{} }
-func (m *CreateRunRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateRunRequest) ProtoMessage()    {}
-func (*CreateRunRequest) Descriptor() ([]byte, []int) {
+func (m *CreateRunSweepRequest) Reset()         { *m = CreateRunSweepRequest{} }
+func (m *CreateRunSweepRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRunSweepRequest) ProtoMessage()    {}
+func (*CreateRunSweepRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e3419bc3417bf873, []int{0}
 }
 
-func (m *CreateRunRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateRunRequest.Unmarshal(m, b)
+func (m *CreateRunSweepRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRunSweepRequest.Unmarshal(m, b)
 }
-func (m *CreateRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateRunRequest.Marshal(b, m, deterministic)
+func (m *CreateRunSweepRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRunSweepRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateRunRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateRunRequest.Merge(m, src)
+func (m *CreateRunSweepRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRunSweepRequest.Merge(m, src)
 }
-func (m *CreateRunRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateRunRequest.Size(m)
+func (m *CreateRunSweepRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRunSweepRequest.Size(m)
 }
-func (m *CreateRunRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateRunRequest.DiscardUnknown(m)
+func (m *CreateRunSweepRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRunSweepRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateRunRequest proto.InternalMessageInfo
+var xxx_messageInfo_CreateRunSweepRequest proto.InternalMessageInfo
 
-func (m *CreateRunRequest) GetRun() *Run {
+func (m *CreateRunSweepRequest) GetSweep() *RunSweep {
 	if m != nil {
-		return m.Run
+		return m.Sweep
 	}
 	return nil
 }
 
-type GetRunRequest struct {
-	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+type GetRunSweepRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetRunRequest) Reset()         { *m = GetRunRequest{} }
-func (m *GetRunRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRunRequest) ProtoMessage()    {}
-func (*GetRunRequest) Descriptor() ([]byte, []int) {
+func (m *GetRunSweepRequest) Reset()         { *m = GetRunSweepRequest{} }
+func (m *GetRunSweepRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRunSweepRequest) ProtoMessage()    {}
+func (*GetRunSweepRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e3419bc3417bf873, []int{1}
 }
 
-func (m *GetRunRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRunRequest.Unmarshal(m, b)
+func (m *GetRunSweepRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunSweepRequest.Unmarshal(m, b)
 }
-func (m *GetRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetRunRequest.Marshal(b, m, deterministic)
+func (m *GetRunSweepRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunSweepRequest.Marshal(b, m, deterministic)
 }
-func (m *GetRunRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRunRequest.Merge(m, src)
+func (m *GetRunSweepRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunSweepRequest.Merge(m, src)
 }
-func (m *GetRunRequest) XXX_Size() int {
-	return xxx_messageInfo_GetRunRequest.Size(m)
+func (m *GetRunSweepRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRunSweepRequest.Size(m)
 }
-func (m *GetRunRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRunRequest.DiscardUnknown(m)
+func (m *GetRunSweepRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunSweepRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRunRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetRunSweepRequest proto.InternalMessageInfo
 
-func (m *GetRunRequest) GetRunId() string {
+func (m *GetRunSweepRequest) GetId() string {
 	if m != nil {
-		return m.RunId
+		return m.Id
 	}
 	return ""
 }
 
-type ListRunsRequest struct {
-	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	// Can be format of "field_name", "field_name asc" or "field_name des"
-	// Ascending by default.
-	SortBy string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
-	// What resource reference to filter on.
-	// E.g. If listing run for an experiment, the query string would be
-	// resource_reference_key.type=EXPERIMENT&resource_reference_key.id=123
-	ResourceReferenceKey *ResourceKey `protobuf:"bytes,4,opt,name=resource_reference_key,json=resourceReferenceKey,proto3" json:"resource_reference_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+// A parameter of a sweep takes either a list of values or a range of numbers.
+type SweepParameter struct {
+	Name                 string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values               []string              `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	Range                *SweepParameter_Range `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *ListRunsRequest) Reset()         { *m = ListRunsRequest{} }
-func (m *ListRunsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListRunsRequest) ProtoMessage()    {}
-func (*ListRunsRequest) Descriptor() ([]byte, []int) {
+func (m *SweepParameter) Reset()         { *m = SweepParameter{} }
+func (m *SweepParameter) String() string { return proto.CompactTextString(m) }
+func (*SweepParameter) ProtoMessage()    {}
+func (*SweepParameter) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e3419bc3417bf873, []int{2}
 }
 
-func (m *ListRunsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListRunsRequest.Unmarshal(m, b)
+func (m *SweepParameter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SweepParameter.Unmarshal(m, b)
 }
-func (m *ListRunsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListRunsRequest.Marshal(b, m, deterministic)
+func (m *SweepParameter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SweepParameter.Marshal(b, m, deterministic)
 }
-func (m *ListRunsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListRunsRequest.Merge(m, src)
+func (m *SweepParameter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SweepParameter.Merge(m, src)
 }
-func (m *ListRunsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListRunsRequest.Size(m)
+func (m *SweepParameter) XXX_Size() int {
+	return xxx_messageInfo_SweepParameter.Size(m)
 }
-func (m *ListRunsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListRunsRequest.DiscardUnknown(m)
+func (m *SweepParameter) XXX_DiscardUnknown() {
+	xxx_messageInfo_SweepParameter.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListRunsRequest proto.InternalMessageInfo
+var xxx_messageInfo_SweepParameter proto.InternalMessageInfo
 
-func (m *ListRunsRequest) GetPageToken() string {
+func (m *SweepParameter) GetName() string {
 	if m != nil {
-		return m.PageToken
+		return m.Name
 	}
 	return ""
 }
 
-func (m *ListRunsRequest) GetPageSize() int32 {
-	if m != nil {
-		return m.PageSize
-	}
-	return 0
-}
-
-func (m *ListRunsRequest) GetSortBy() string {
+func (m *SweepParameter) GetValues() []string {
 	if m != nil {
-		return m.SortBy
+		return m.Values
 	}
-	return ""
+	return nil
 }
 
-func (m *ListRunsRequest) GetResourceReferenceKey() *ResourceKey {
+func (m *SweepParameter) GetRange() *SweepParameter_Range {
 	if m != nil {
-		return m.ResourceReferenceKey
+		return m.Range
 	}
 	return nil
 }
 
-type ListRunsResponse struct {
-	Runs                 []*Run   `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
-	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+// The numbers from start to stop, included, every step.
+type SweepParameter_Range struct {
+	Start                float64  `protobuf:"fixed64,1,opt,name=start,proto3" json:"start,omitempty"`
+	Stop                 float64  `protobuf:"fixed64,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	Step                 float64  `protobuf:"fixed64,3,opt,name=step,proto3" json:"step,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListRunsResponse) Reset()         { *m = ListRunsResponse{} }
-func (m *ListRunsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListRunsResponse) ProtoMessage()    {}
-func (*ListRunsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{3}
+func (m *SweepParameter_Range) Reset()         { *m = SweepParameter_Range{} }
+func (m *SweepParameter_Range) String() string { return proto.CompactTextString(m) }
+func (*SweepParameter_Range) ProtoMessage()    {}
+func (*SweepParameter_Range) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{2, 0}
 }
 
-func (m *ListRunsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListRunsResponse.Unmarshal(m, b)
+func (m *SweepParameter_Range) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SweepParameter_Range.Unmarshal(m, b)
 }
-func (m *ListRunsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListRunsResponse.Marshal(b, m, deterministic)
+func (m *SweepParameter_Range) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SweepParameter_Range.Marshal(b, m, deterministic)
 }
-func (m *ListRunsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListRunsResponse.Merge(m, src)
+func (m *SweepParameter_Range) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SweepParameter_Range.Merge(m, src)
 }
-func (m *ListRunsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListRunsResponse.Size(m)
+func (m *SweepParameter_Range) XXX_Size() int {
+	return xxx_messageInfo_SweepParameter_Range.Size(m)
 }
-func (m *ListRunsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListRunsResponse.DiscardUnknown(m)
+func (m *SweepParameter_Range) XXX_DiscardUnknown() {
+	xxx_messageInfo_SweepParameter_Range.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListRunsResponse proto.InternalMessageInfo
+var xxx_messageInfo_SweepParameter_Range proto.InternalMessageInfo
 
-func (m *ListRunsResponse) GetRuns() []*Run {
+func (m *SweepParameter_Range) GetStart() float64 {
 	if m != nil {
-		return m.Runs
+		return m.Start
 	}
-	return nil
+	return 0
 }
 
-func (m *ListRunsResponse) GetNextPageToken() string {
+func (m *SweepParameter_Range) GetStop() float64 {
 	if m != nil {
-		return m.NextPageToken
+		return m.Stop
 	}
-	return ""
+	return 0
 }
 
-type Run struct {
-	// Output. Unique run ID. Generated by API server.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Required input field. Name provided by user,
-	// or auto generated if run is created by scheduled job. Not unique.
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Optional input field. Describing the purpose of the run
-	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	// Required input field.
-	// Describing what the pipeline manifest and parameters to use for the run.
-	PipelineSpec *PipelineSpec `protobuf:"bytes,4,opt,name=pipeline_spec,json=pipelineSpec,proto3" json:"pipeline_spec,omitempty"`
-	// Optional input field. Specify which resource this run belongs to.
-	ResourceReferences []*ResourceReference `protobuf:"bytes,5,rep,name=resource_references,json=resourceReferences,proto3" json:"resource_references,omitempty"`
-	// Output. The time that the run created.
-	CreatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	// Output. When this run is scheduled to run. This could be different from
-	// created_at. For example, if a run is from a backfilling job that was
-	// supposed to run 2 month ago, the scheduled_at is 2 month ago,
-	// v.s. created_at is the current time.
-	ScheduledAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
-	// Output. The status of the run.
-	// One of [Pending, Running, Succeeded, Skipped, Failed, Error]
-	Status string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
-	// In case any error happens retrieving a run field, only run ID
-	// and the error message is returned. Client has the flexibility of choosing
-	// how to handle error. This is especially useful during listing call.
-	Error string `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
-	// Output. The metrics of the run. The metrics are reported by ReportMetrics
-	// API.
-	Metrics              []*RunMetric `protobuf:"bytes,9,rep,name=metrics,proto3" json:"metrics,omitempty"`
-	Namespace            string       `protobuf:"bytes,14,opt,name=namespace,proto3" json:"namespace,omitempty"`
+func (m *SweepParameter_Range) GetStep() float64 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+// A trial is a combination of the values of the parameters of a sweep, and
+// its run once created.
+type SweepTrial struct {
+	Index      int32        `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Parameters []*Parameter `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// Empty until the run is created.
+	RunId                string       `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Status               string       `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Metrics              []*RunMetric `protobuf:"bytes,5,rep,name=metrics,proto3" json:"metrics,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
 }
 
-func (m *Run) Reset()         { *m = Run{} }
-func (m *Run) String() string { return proto.CompactTextString(m) }
-func (*Run) ProtoMessage()    {}
-func (*Run) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{4}
+func (m *SweepTrial) Reset()         { *m = SweepTrial{} }
+func (m *SweepTrial) String() string { return proto.CompactTextString(m) }
+func (*SweepTrial) ProtoMessage()    {}
+func (*SweepTrial) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{3}
 }
 
-func (m *Run) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Run.Unmarshal(m, b)
+func (m *SweepTrial) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SweepTrial.Unmarshal(m, b)
 }
-func (m *Run) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Run.Marshal(b, m, deterministic)
+func (m *SweepTrial) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SweepTrial.Marshal(b, m, deterministic)
 }
-func (m *Run) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Run.Merge(m, src)
+func (m *SweepTrial) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SweepTrial.Merge(m, src)
 }
-func (m *Run) XXX_Size() int {
-	return xxx_messageInfo_Run.Size(m)
+func (m *SweepTrial) XXX_Size() int {
+	return xxx_messageInfo_SweepTrial.Size(m)
 }
-func (m *Run) XXX_DiscardUnknown() {
-	xxx_messageInfo_Run.DiscardUnknown(m)
+func (m *SweepTrial) XXX_DiscardUnknown() {
+	xxx_messageInfo_SweepTrial.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Run proto.InternalMessageInfo
+var xxx_messageInfo_SweepTrial proto.InternalMessageInfo
 
-func (m *Run) GetId() string {
+func (m *SweepTrial) GetIndex() int32 {
 	if m != nil {
-		return m.Id
+		return m.Index
 	}
-	return ""
+	return 0
 }
 
-func (m *Run) GetName() string {
+func (m *SweepTrial) GetParameters() []*Parameter {
 	if m != nil {
-		return m.Name
+		return m.Parameters
 	}
-	return ""
+	return nil
 }
 
-func (m *Run) GetDescription() string {
+func (m *SweepTrial) GetRunId() string {
 	if m != nil {
-		return m.Description
+		return m.RunId
 	}
 	return ""
 }
 
-func (m *Run) GetPipelineSpec() *PipelineSpec {
+func (m *SweepTrial) GetStatus() string {
 	if m != nil {
-		return m.PipelineSpec
+		return m.Status
 	}
-	return nil
+	return ""
 }
 
-func (m *Run) GetResourceReferences() []*ResourceReference {
+func (m *SweepTrial) GetMetrics() []*RunMetric {
 	if m != nil {
-		return m.ResourceReferences
+		return m.Metrics
 	}
 	return nil
 }
 
-func (m *Run) GetCreatedAt() *timestamp.Timestamp {
-	if m != nil {
-		return m.CreatedAt
-	}
-	return nil
+type RunSweep struct {
+	// Output. Unique sweep ID. Generated by API server.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Required input field. The runs are named after the sweep, suffixed with
+	// the index of their trial.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Required input field. The pipeline spec, the parameters not swept and the
+	// experiment of the runs.
+	Run *Run `protobuf:"bytes,3,opt,name=run,proto3" json:"run,omitempty"`
+	// Required input field. The parameters swept, whose values override the
+	// ones of the run.
+	Parameters []*SweepParameter `protobuf:"bytes,4,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// Optional input field. How many runs of the sweep may run at once. All of
+	// them if 0.
+	MaxConcurrency int32 `protobuf:"varint,5,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// Output. The time the sweep was created.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Output. The trials of the sweep, in the order of their index.
+	Trials []*SweepTrial `protobuf:"bytes,7,rep,name=trials,proto3" json:"trials,omitempty"`
+	// Output. The number of trials whose run isn't created yet, is running,
+	// succeeded, or failed.
+	PendingRuns   int32 `protobuf:"varint,8,opt,name=pending_runs,json=pendingRuns,proto3" json:"pending_runs,omitempty"`
+	RunningRuns   int32 `protobuf:"varint,9,opt,name=running_runs,json=runningRuns,proto3" json:"running_runs,omitempty"`
+	SucceededRuns int32 `protobuf:"varint,10,opt,name=succeeded_runs,json=succeededRuns,proto3" json:"succeeded_runs,omitempty"`
+	FailedRuns    int32 `protobuf:"varint,11,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
+	// Output. The group tracking the runs of the sweep, e.g. to cancel them.
+	RunGroupId           string   `protobuf:"bytes,12,opt,name=run_group_id,json=runGroupId,proto3" json:"run_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Run) GetScheduledAt() *timestamp.Timestamp {
-	if m != nil {
-		return m.ScheduledAt
-	}
-	return nil
+func (m *RunSweep) Reset()         { *m = RunSweep{} }
+func (m *RunSweep) String() string { return proto.CompactTextString(m) }
+func (*RunSweep) ProtoMessage()    {}
+func (*RunSweep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{4}
 }
 
-func (m *Run) GetStatus() string {
-	if m != nil {
-		return m.Status
-	}
-	return ""
+func (m *RunSweep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunSweep.Unmarshal(m, b)
 }
-
-func (m *Run) GetError() string {
+func (m *RunSweep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunSweep.Marshal(b, m, deterministic)
+}
+func (m *RunSweep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunSweep.Merge(m, src)
+}
+func (m *RunSweep) XXX_Size() int {
+	return xxx_messageInfo_RunSweep.Size(m)
+}
+func (m *RunSweep) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunSweep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunSweep proto.InternalMessageInfo
+
+func (m *RunSweep) GetId() string {
 	if m != nil {
-		return m.Error
+		return m.Id
 	}
 	return ""
 }
 
-func (m *Run) GetMetrics() []*RunMetric {
+func (m *RunSweep) GetName() string {
 	if m != nil {
-		return m.Metrics
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *Run) GetNamespace() string {
+func (m *RunSweep) GetRun() *Run {
 	if m != nil {
-		return m.Namespace
+		return m.Run
 	}
-	return ""
+	return nil
 }
 
-type PipelineRuntime struct {
-	// Output. The runtime JSON manifest of the pipeline, including the status
-	// of pipeline steps and fields need for UI visualization etc.
-	PipelineManifest string `protobuf:"bytes,10,opt,name=pipeline_manifest,json=pipelineManifest,proto3" json:"pipeline_manifest,omitempty"`
-	// Output. The runtime JSON manifest of the argo workflow.
-	// This is deprecated after pipeline_runtime_manifest is in use.
-	WorkflowManifest     string   `protobuf:"bytes,11,opt,name=workflow_manifest,json=workflowManifest,proto3" json:"workflow_manifest,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RunSweep) GetParameters() []*SweepParameter {
+	if m != nil {
+		return m.Parameters
+	}
+	return nil
 }
 
-func (m *PipelineRuntime) Reset()         { *m = PipelineRuntime{} }
-func (m *PipelineRuntime) String() string { return proto.CompactTextString(m) }
-func (*PipelineRuntime) ProtoMessage()    {}
-func (*PipelineRuntime) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{5}
+func (m *RunSweep) GetMaxConcurrency() int32 {
+	if m != nil {
+		return m.MaxConcurrency
+	}
+	return 0
 }
 
-func (m *PipelineRuntime) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PipelineRuntime.Unmarshal(m, b)
-}
-func (m *PipelineRuntime) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PipelineRuntime.Marshal(b, m, deterministic)
+func (m *RunSweep) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
 }
-func (m *PipelineRuntime) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PipelineRuntime.Merge(m, src)
+
+func (m *RunSweep) GetTrials() []*SweepTrial {
+	if m != nil {
+		return m.Trials
+	}
+	return nil
 }
-func (m *PipelineRuntime) XXX_Size() int {
-	return xxx_messageInfo_PipelineRuntime.Size(m)
+
+func (m *RunSweep) GetPendingRuns() int32 {
+	if m != nil {
+		return m.PendingRuns
+	}
+	return 0
 }
-func (m *PipelineRuntime) XXX_DiscardUnknown() {
-	xxx_messageInfo_PipelineRuntime.DiscardUnknown(m)
+
+func (m *RunSweep) GetRunningRuns() int32 {
+	if m != nil {
+		return m.RunningRuns
+	}
+	return 0
 }
 
-var xxx_messageInfo_PipelineRuntime proto.InternalMessageInfo
+func (m *RunSweep) GetSucceededRuns() int32 {
+	if m != nil {
+		return m.SucceededRuns
+	}
+	return 0
+}
 
-func (m *PipelineRuntime) GetPipelineManifest() string {
+func (m *RunSweep) GetFailedRuns() int32 {
 	if m != nil {
-		return m.PipelineManifest
+		return m.FailedRuns
 	}
-	return ""
+	return 0
 }
 
-func (m *PipelineRuntime) GetWorkflowManifest() string {
+func (m *RunSweep) GetRunGroupId() string {
 	if m != nil {
-		return m.WorkflowManifest
+		return m.RunGroupId
 	}
 	return ""
 }
 
-type RunDetail struct {
-	Run                  *Run             `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
-	PipelineRuntime      *PipelineRuntime `protobuf:"bytes,2,opt,name=pipeline_runtime,json=pipelineRuntime,proto3" json:"pipeline_runtime,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+type CreateRunGroupRequest struct {
+	Group                *RunGroup `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *RunDetail) Reset()         { *m = RunDetail{} }
-func (m *RunDetail) String() string { return proto.CompactTextString(m) }
-func (*RunDetail) ProtoMessage()    {}
-func (*RunDetail) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{6}
+func (m *CreateRunGroupRequest) Reset()         { *m = CreateRunGroupRequest{} }
+func (m *CreateRunGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRunGroupRequest) ProtoMessage()    {}
+func (*CreateRunGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{5}
 }
 
-func (m *RunDetail) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RunDetail.Unmarshal(m, b)
+func (m *CreateRunGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRunGroupRequest.Unmarshal(m, b)
 }
-func (m *RunDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RunDetail.Marshal(b, m, deterministic)
+func (m *CreateRunGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRunGroupRequest.Marshal(b, m, deterministic)
 }
-func (m *RunDetail) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RunDetail.Merge(m, src)
+func (m *CreateRunGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRunGroupRequest.Merge(m, src)
 }
-func (m *RunDetail) XXX_Size() int {
-	return xxx_messageInfo_RunDetail.Size(m)
+func (m *CreateRunGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRunGroupRequest.Size(m)
 }
-func (m *RunDetail) XXX_DiscardUnknown() {
-	xxx_messageInfo_RunDetail.DiscardUnknown(m)
+func (m *CreateRunGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRunGroupRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RunDetail proto.InternalMessageInfo
+var xxx_messageInfo_CreateRunGroupRequest proto.InternalMessageInfo
 
-func (m *RunDetail) GetRun() *Run {
+func (m *CreateRunGroupRequest) GetGroup() *RunGroup {
 	if m != nil {
-		return m.Run
+		return m.Group
 	}
 	return nil
 }
 
-func (m *RunDetail) GetPipelineRuntime() *PipelineRuntime {
+type GetRunGroupRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRunGroupRequest) Reset()         { *m = GetRunGroupRequest{} }
+func (m *GetRunGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRunGroupRequest) ProtoMessage()    {}
+func (*GetRunGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{6}
+}
+
+func (m *GetRunGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunGroupRequest.Unmarshal(m, b)
+}
+func (m *GetRunGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRunGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunGroupRequest.Merge(m, src)
+}
+func (m *GetRunGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRunGroupRequest.Size(m)
+}
+func (m *GetRunGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunGroupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunGroupRequest proto.InternalMessageInfo
+
+func (m *GetRunGroupRequest) GetId() string {
 	if m != nil {
-		return m.PipelineRuntime
+		return m.Id
 	}
-	return nil
+	return ""
 }
 
-type RunMetric struct {
-	// Required. The user defined name of the metric. It must between 1 and 63 characters
-	// long and must conform to the following regular expression:
-	// `[a-z]([-a-z0-9]*[a-z0-9])?`.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Required. The runtime node ID which reports the metric. The node ID can be found in
-	// the RunDetail.workflow.Status. Metric with same (node_id, name)
-	// are considerd as duplicate. Only the first reporting will be recorded. Max length is 128.
-	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	// Types that are valid to be assigned to Value:
-	//	*RunMetric_NumberValue
-	Value isRunMetric_Value `protobuf_oneof:"value"`
-	// The display format of metric.
-	Format               RunMetric_Format `protobuf:"varint,4,opt,name=format,proto3,enum=api.RunMetric_Format" json:"format,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+type ListRunGroupsRequest struct {
+	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Can be format of "field_name", "field_name asc" or "field_name des"
+	// Ascending by default.
+	SortBy               string   `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RunMetric) Reset()         { *m = RunMetric{} }
-func (m *RunMetric) String() string { return proto.CompactTextString(m) }
-func (*RunMetric) ProtoMessage()    {}
-func (*RunMetric) Descriptor() ([]byte, []int) {
+func (m *ListRunGroupsRequest) Reset()         { *m = ListRunGroupsRequest{} }
+func (m *ListRunGroupsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRunGroupsRequest) ProtoMessage()    {}
+func (*ListRunGroupsRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_e3419bc3417bf873, []int{7}
 }
 
-func (m *RunMetric) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RunMetric.Unmarshal(m, b)
+func (m *ListRunGroupsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunGroupsRequest.Unmarshal(m, b)
 }
-func (m *RunMetric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RunMetric.Marshal(b, m, deterministic)
+func (m *ListRunGroupsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunGroupsRequest.Marshal(b, m, deterministic)
 }
-func (m *RunMetric) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RunMetric.Merge(m, src)
+func (m *ListRunGroupsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunGroupsRequest.Merge(m, src)
 }
-func (m *RunMetric) XXX_Size() int {
-	return xxx_messageInfo_RunMetric.Size(m)
+func (m *ListRunGroupsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRunGroupsRequest.Size(m)
 }
-func (m *RunMetric) XXX_DiscardUnknown() {
-	xxx_messageInfo_RunMetric.DiscardUnknown(m)
+func (m *ListRunGroupsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunGroupsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RunMetric proto.InternalMessageInfo
+var xxx_messageInfo_ListRunGroupsRequest proto.InternalMessageInfo
 
-func (m *RunMetric) GetName() string {
+func (m *ListRunGroupsRequest) GetPageToken() string {
 	if m != nil {
-		return m.Name
+		return m.PageToken
 	}
 	return ""
 }
 
-func (m *RunMetric) GetNodeId() string {
+func (m *ListRunGroupsRequest) GetPageSize() int32 {
 	if m != nil {
-		return m.NodeId
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListRunGroupsRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
 	}
 	return ""
 }
 
-type isRunMetric_Value interface {
-	isRunMetric_Value()
+type ListRunGroupsResponse struct {
+	Groups               []*RunGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	NextPageToken        string      `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
-type RunMetric_NumberValue struct {
-	NumberValue float64 `protobuf:"fixed64,3,opt,name=number_value,json=numberValue,proto3,oneof"`
+func (m *ListRunGroupsResponse) Reset()         { *m = ListRunGroupsResponse{} }
+func (m *ListRunGroupsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRunGroupsResponse) ProtoMessage()    {}
+func (*ListRunGroupsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{8}
 }
 
-func (*RunMetric_NumberValue) isRunMetric_Value() {}
+func (m *ListRunGroupsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunGroupsResponse.Unmarshal(m, b)
+}
+func (m *ListRunGroupsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunGroupsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRunGroupsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunGroupsResponse.Merge(m, src)
+}
+func (m *ListRunGroupsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRunGroupsResponse.Size(m)
+}
+func (m *ListRunGroupsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunGroupsResponse.DiscardUnknown(m)
+}
 
-func (m *RunMetric) GetValue() isRunMetric_Value {
+var xxx_messageInfo_ListRunGroupsResponse proto.InternalMessageInfo
+
+func (m *ListRunGroupsResponse) GetGroups() []*RunGroup {
 	if m != nil {
-		return m.Value
+		return m.Groups
 	}
 	return nil
 }
 
-func (m *RunMetric) GetNumberValue() float64 {
-	if x, ok := m.GetValue().(*RunMetric_NumberValue); ok {
-		return x.NumberValue
+func (m *ListRunGroupsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
 	}
-	return 0
+	return ""
 }
 
-func (m *RunMetric) GetFormat() RunMetric_Format {
-	if m != nil {
-		return m.Format
-	}
-	return RunMetric_UNSPECIFIED
+type AddRunsToRunGroupRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RunIds               []string `protobuf:"bytes,2,rep,name=run_ids,json=runIds,proto3" json:"run_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// XXX_OneofFuncs is for the internal use of the proto package.
-func (*RunMetric) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
-	return _RunMetric_OneofMarshaler, _RunMetric_OneofUnmarshaler, _RunMetric_OneofSizer, []interface{}{
-		(*RunMetric_NumberValue)(nil),
-	}
+func (m *AddRunsToRunGroupRequest) Reset()         { *m = AddRunsToRunGroupRequest{} }
+func (m *AddRunsToRunGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*AddRunsToRunGroupRequest) ProtoMessage()    {}
+func (*AddRunsToRunGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{9}
 }
 
-func _RunMetric_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
-	m := msg.(*RunMetric)
-	// value
-	switch x := m.Value.(type) {
-	case *RunMetric_NumberValue:
-		b.EncodeVarint(3<<3 | proto.WireFixed64)
-		b.EncodeFixed64(math.Float64bits(x.NumberValue))
-	case nil:
-	default:
-		return fmt.Errorf("RunMetric.Value has unexpected type %T", x)
-	}
-	return nil
+func (m *AddRunsToRunGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddRunsToRunGroupRequest.Unmarshal(m, b)
+}
+func (m *AddRunsToRunGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddRunsToRunGroupRequest.Marshal(b, m, deterministic)
+}
+func (m *AddRunsToRunGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddRunsToRunGroupRequest.Merge(m, src)
+}
+func (m *AddRunsToRunGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_AddRunsToRunGroupRequest.Size(m)
+}
+func (m *AddRunsToRunGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddRunsToRunGroupRequest.DiscardUnknown(m)
 }
 
-func _RunMetric_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
-	m := msg.(*RunMetric)
-	switch tag {
-	case 3: // value.number_value
-		if wire != proto.WireFixed64 {
-			return true, proto.ErrInternalBadWireType
-		}
-		x, err := b.DecodeFixed64()
-		m.Value = &RunMetric_NumberValue{math.Float64frombits(x)}
-		return true, err
-	default:
-		return false, nil
+var xxx_messageInfo_AddRunsToRunGroupRequest proto.InternalMessageInfo
+
+func (m *AddRunsToRunGroupRequest) GetId() string {
+	if m != nil {
+		return m.Id
 	}
+	return ""
 }
 
-func _RunMetric_OneofSizer(msg proto.Message) (n int) {
-	m := msg.(*RunMetric)
-	// value
-	switch x := m.Value.(type) {
-	case *RunMetric_NumberValue:
-		n += 1 // tag and wire
-		n += 8
-	case nil:
-	default:
-		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+func (m *AddRunsToRunGroupRequest) GetRunIds() []string {
+	if m != nil {
+		return m.RunIds
 	}
-	return n
+	return nil
 }
 
-type ReportRunMetricsRequest struct {
-	// Required. The parent run ID of the metric.
-	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
-	// List of metrics to report.
-	Metrics              []*RunMetric `protobuf:"bytes,2,rep,name=metrics,proto3" json:"metrics,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+type CancelRunGroupRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReportRunMetricsRequest) Reset()         { *m = ReportRunMetricsRequest{} }
-func (m *ReportRunMetricsRequest) String() string { return proto.CompactTextString(m) }
-func (*ReportRunMetricsRequest) ProtoMessage()    {}
-func (*ReportRunMetricsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{8}
+func (m *CancelRunGroupRequest) Reset()         { *m = CancelRunGroupRequest{} }
+func (m *CancelRunGroupRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelRunGroupRequest) ProtoMessage()    {}
+func (*CancelRunGroupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{10}
 }
 
-func (m *ReportRunMetricsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReportRunMetricsRequest.Unmarshal(m, b)
+func (m *CancelRunGroupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelRunGroupRequest.Unmarshal(m, b)
 }
-func (m *ReportRunMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReportRunMetricsRequest.Marshal(b, m, deterministic)
+func (m *CancelRunGroupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelRunGroupRequest.Marshal(b, m, deterministic)
 }
-func (m *ReportRunMetricsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReportRunMetricsRequest.Merge(m, src)
+func (m *CancelRunGroupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelRunGroupRequest.Merge(m, src)
 }
-func (m *ReportRunMetricsRequest) XXX_Size() int {
-	return xxx_messageInfo_ReportRunMetricsRequest.Size(m)
+func (m *CancelRunGroupRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelRunGroupRequest.Size(m)
 }
-func (m *ReportRunMetricsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReportRunMetricsRequest.DiscardUnknown(m)
+func (m *CancelRunGroupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelRunGroupRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReportRunMetricsRequest proto.InternalMessageInfo
+var xxx_messageInfo_CancelRunGroupRequest proto.InternalMessageInfo
 
-func (m *ReportRunMetricsRequest) GetRunId() string {
+func (m *CancelRunGroupRequest) GetId() string {
 	if m != nil {
-		return m.RunId
+		return m.Id
 	}
 	return ""
 }
 
-func (m *ReportRunMetricsRequest) GetMetrics() []*RunMetric {
-	if m != nil {
-		return m.Metrics
-	}
-	return nil
-}
-
-type ReportRunMetricsResponse struct {
-	Results              []*ReportRunMetricsResponse_ReportRunMetricResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                          `json:"-"`
-	XXX_unrecognized     []byte                                            `json:"-"`
-	XXX_sizecache        int32                                             `json:"-"`
+// Exactly one of the experiment and the run group is set.
+type TerminateAllRequest struct {
+	ExperimentId         string   `protobuf:"bytes,1,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	RunGroupId           string   `protobuf:"bytes,2,opt,name=run_group_id,json=runGroupId,proto3" json:"run_group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReportRunMetricsResponse) Reset()         { *m = ReportRunMetricsResponse{} }
-func (m *ReportRunMetricsResponse) String() string { return proto.CompactTextString(m) }
-func (*ReportRunMetricsResponse) ProtoMessage()    {}
-func (*ReportRunMetricsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{9}
+func (m *TerminateAllRequest) Reset()         { *m = TerminateAllRequest{} }
+func (m *TerminateAllRequest) String() string { return proto.CompactTextString(m) }
+func (*TerminateAllRequest) ProtoMessage()    {}
+func (*TerminateAllRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{11}
 }
 
-func (m *ReportRunMetricsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReportRunMetricsResponse.Unmarshal(m, b)
+func (m *TerminateAllRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminateAllRequest.Unmarshal(m, b)
 }
-func (m *ReportRunMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReportRunMetricsResponse.Marshal(b, m, deterministic)
+func (m *TerminateAllRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminateAllRequest.Marshal(b, m, deterministic)
 }
-func (m *ReportRunMetricsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReportRunMetricsResponse.Merge(m, src)
+func (m *TerminateAllRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminateAllRequest.Merge(m, src)
 }
-func (m *ReportRunMetricsResponse) XXX_Size() int {
-	return xxx_messageInfo_ReportRunMetricsResponse.Size(m)
+func (m *TerminateAllRequest) XXX_Size() int {
+	return xxx_messageInfo_TerminateAllRequest.Size(m)
 }
-func (m *ReportRunMetricsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReportRunMetricsResponse.DiscardUnknown(m)
+func (m *TerminateAllRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminateAllRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReportRunMetricsResponse proto.InternalMessageInfo
+var xxx_messageInfo_TerminateAllRequest proto.InternalMessageInfo
 
-func (m *ReportRunMetricsResponse) GetResults() []*ReportRunMetricsResponse_ReportRunMetricResult {
+func (m *TerminateAllRequest) GetExperimentId() string {
 	if m != nil {
-		return m.Results
+		return m.ExperimentId
 	}
-	return nil
+	return ""
 }
 
-type ReportRunMetricsResponse_ReportRunMetricResult struct {
-	// Output. The name of the metric.
-	MetricName string `protobuf:"bytes,1,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
-	// Output. The ID of the node which reports the metric.
-	MetricNodeId string `protobuf:"bytes,2,opt,name=metric_node_id,json=metricNodeId,proto3" json:"metric_node_id,omitempty"`
-	// Output. The status of the metric reporting.
-	Status ReportRunMetricsResponse_ReportRunMetricResult_Status `protobuf:"varint,3,opt,name=status,proto3,enum=api.ReportRunMetricsResponse_ReportRunMetricResult_Status" json:"status,omitempty"`
-	// Output. The detailed message of the error of the reporting.
-	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+func (m *TerminateAllRequest) GetRunGroupId() string {
+	if m != nil {
+		return m.RunGroupId
+	}
+	return ""
+}
+
+type GetTerminateAllOperationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) Reset() {
-	*m = ReportRunMetricsResponse_ReportRunMetricResult{}
+func (m *GetTerminateAllOperationRequest) Reset()         { *m = GetTerminateAllOperationRequest{} }
+func (m *GetTerminateAllOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTerminateAllOperationRequest) ProtoMessage()    {}
+func (*GetTerminateAllOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{12}
 }
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) String() string {
-	return proto.CompactTextString(m)
+
+func (m *GetTerminateAllOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTerminateAllOperationRequest.Unmarshal(m, b)
 }
-func (*ReportRunMetricsResponse_ReportRunMetricResult) ProtoMessage() {}
-func (*ReportRunMetricsResponse_ReportRunMetricResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{9, 0}
+func (m *GetTerminateAllOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTerminateAllOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTerminateAllOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTerminateAllOperationRequest.Merge(m, src)
+}
+func (m *GetTerminateAllOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTerminateAllOperationRequest.Size(m)
+}
+func (m *GetTerminateAllOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTerminateAllOperationRequest.DiscardUnknown(m)
 }
 
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Unmarshal(m, b)
+var xxx_messageInfo_GetTerminateAllOperationRequest proto.InternalMessageInfo
+
+func (m *GetTerminateAllOperationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Marshal(b, m, deterministic)
+
+type TerminateAllOperation struct {
+	// Output. Unique operation ID. Generated by API server.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The experiment or the run group whose runs are terminated.
+	ExperimentId string               `protobuf:"bytes,2,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	RunGroupId   string               `protobuf:"bytes,3,opt,name=run_group_id,json=runGroupId,proto3" json:"run_group_id,omitempty"`
+	CreatedAt    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The time every run was processed, unset until then.
+	FinishedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// The results of the runs which weren't finished when the operation was
+	// created, ordered by run ID.
+	Results              []*TerminateResult `protobuf:"bytes,6,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Merge(m, src)
+
+func (m *TerminateAllOperation) Reset()         { *m = TerminateAllOperation{} }
+func (m *TerminateAllOperation) String() string { return proto.CompactTextString(m) }
+func (*TerminateAllOperation) ProtoMessage()    {}
+func (*TerminateAllOperation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{13}
 }
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Size() int {
-	return xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Size(m)
+
+func (m *TerminateAllOperation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminateAllOperation.Unmarshal(m, b)
 }
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.DiscardUnknown(m)
+func (m *TerminateAllOperation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminateAllOperation.Marshal(b, m, deterministic)
+}
+func (m *TerminateAllOperation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminateAllOperation.Merge(m, src)
+}
+func (m *TerminateAllOperation) XXX_Size() int {
+	return xxx_messageInfo_TerminateAllOperation.Size(m)
+}
+func (m *TerminateAllOperation) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminateAllOperation.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult proto.InternalMessageInfo
+var xxx_messageInfo_TerminateAllOperation proto.InternalMessageInfo
 
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetMetricName() string {
+func (m *TerminateAllOperation) GetId() string {
 	if m != nil {
-		return m.MetricName
+		return m.Id
 	}
 	return ""
 }
 
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetMetricNodeId() string {
+func (m *TerminateAllOperation) GetExperimentId() string {
 	if m != nil {
-		return m.MetricNodeId
+		return m.ExperimentId
 	}
 	return ""
 }
 
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetStatus() ReportRunMetricsResponse_ReportRunMetricResult_Status {
+func (m *TerminateAllOperation) GetRunGroupId() string {
+	if m != nil {
+		return m.RunGroupId
+	}
+	return ""
+}
+
+func (m *TerminateAllOperation) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *TerminateAllOperation) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *TerminateAllOperation) GetResults() []*TerminateResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type TerminateResult struct {
+	RunId                string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Status               TerminateResult_Status `protobuf:"varint,2,opt,name=status,proto3,enum=api.TerminateResult_Status" json:"status,omitempty"`
+	Error                string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *TerminateResult) Reset()         { *m = TerminateResult{} }
+func (m *TerminateResult) String() string { return proto.CompactTextString(m) }
+func (*TerminateResult) ProtoMessage()    {}
+func (*TerminateResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{14}
+}
+
+func (m *TerminateResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TerminateResult.Unmarshal(m, b)
+}
+func (m *TerminateResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TerminateResult.Marshal(b, m, deterministic)
+}
+func (m *TerminateResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TerminateResult.Merge(m, src)
+}
+func (m *TerminateResult) XXX_Size() int {
+	return xxx_messageInfo_TerminateResult.Size(m)
+}
+func (m *TerminateResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_TerminateResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TerminateResult proto.InternalMessageInfo
+
+func (m *TerminateResult) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *TerminateResult) GetStatus() TerminateResult_Status {
 	if m != nil {
 		return m.Status
 	}
-	return ReportRunMetricsResponse_ReportRunMetricResult_UNSPECIFIED
+	return TerminateResult_PENDING
 }
 
-func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetMessage() string {
+func (m *TerminateResult) GetError() string {
 	if m != nil {
-		return m.Message
+		return m.Error
 	}
 	return ""
 }
 
-type ReadArtifactRequest struct {
-	// The ID of the run.
-	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
-	// The ID of the running node.
-	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	// The name of the artifact.
-	ArtifactName         string   `protobuf:"bytes,3,opt,name=artifact_name,json=artifactName,proto3" json:"artifact_name,omitempty"`
+type RunGroup struct {
+	// Output. Unique group ID. Generated by API server.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Required input field.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional input field.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Optional input field. The runs of the group.
+	RunIds []string `protobuf:"bytes,4,rep,name=run_ids,json=runIds,proto3" json:"run_ids,omitempty"`
+	// Output. The time the group was created.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Output. The time the group was cancelled, unset if it wasn't.
+	CancelledAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	// Output. The aggregate status of the runs: Running while one of them is,
+	// then Failed if one of them failed, Succeeded otherwise. Cancelled once
+	// the group is cancelled, and empty while the group has no run.
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// Output. The number of runs of the group which are running, succeeded, or
+	// failed.
+	RunningRuns          int32    `protobuf:"varint,8,opt,name=running_runs,json=runningRuns,proto3" json:"running_runs,omitempty"`
+	SucceededRuns        int32    `protobuf:"varint,9,opt,name=succeeded_runs,json=succeededRuns,proto3" json:"succeeded_runs,omitempty"`
+	FailedRuns           int32    `protobuf:"varint,10,opt,name=failed_runs,json=failedRuns,proto3" json:"failed_runs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReadArtifactRequest) Reset()         { *m = ReadArtifactRequest{} }
-func (m *ReadArtifactRequest) String() string { return proto.CompactTextString(m) }
-func (*ReadArtifactRequest) ProtoMessage()    {}
-func (*ReadArtifactRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{10}
+func (m *RunGroup) Reset()         { *m = RunGroup{} }
+func (m *RunGroup) String() string { return proto.CompactTextString(m) }
+func (*RunGroup) ProtoMessage()    {}
+func (*RunGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{15}
 }
 
-func (m *ReadArtifactRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadArtifactRequest.Unmarshal(m, b)
+func (m *RunGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunGroup.Unmarshal(m, b)
 }
-func (m *ReadArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadArtifactRequest.Marshal(b, m, deterministic)
+func (m *RunGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunGroup.Marshal(b, m, deterministic)
 }
-func (m *ReadArtifactRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadArtifactRequest.Merge(m, src)
+func (m *RunGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunGroup.Merge(m, src)
 }
-func (m *ReadArtifactRequest) XXX_Size() int {
-	return xxx_messageInfo_ReadArtifactRequest.Size(m)
+func (m *RunGroup) XXX_Size() int {
+	return xxx_messageInfo_RunGroup.Size(m)
 }
-func (m *ReadArtifactRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadArtifactRequest.DiscardUnknown(m)
+func (m *RunGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunGroup.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadArtifactRequest proto.InternalMessageInfo
+var xxx_messageInfo_RunGroup proto.InternalMessageInfo
 
-func (m *ReadArtifactRequest) GetRunId() string {
+func (m *RunGroup) GetId() string {
 	if m != nil {
-		return m.RunId
+		return m.Id
 	}
 	return ""
 }
 
-func (m *ReadArtifactRequest) GetNodeId() string {
+func (m *RunGroup) GetName() string {
 	if m != nil {
-		return m.NodeId
+		return m.Name
 	}
 	return ""
 }
 
-func (m *ReadArtifactRequest) GetArtifactName() string {
+func (m *RunGroup) GetDescription() string {
 	if m != nil {
-		return m.ArtifactName
+		return m.Description
 	}
 	return ""
 }
 
-type ReadArtifactResponse struct {
-	// The bytes of the artifact content.
-	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+func (m *RunGroup) GetRunIds() []string {
+	if m != nil {
+		return m.RunIds
+	}
+	return nil
+}
+
+func (m *RunGroup) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *RunGroup) GetCancelledAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CancelledAt
+	}
+	return nil
+}
+
+func (m *RunGroup) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *RunGroup) GetRunningRuns() int32 {
+	if m != nil {
+		return m.RunningRuns
+	}
+	return 0
+}
+
+func (m *RunGroup) GetSucceededRuns() int32 {
+	if m != nil {
+		return m.SucceededRuns
+	}
+	return 0
+}
+
+func (m *RunGroup) GetFailedRuns() int32 {
+	if m != nil {
+		return m.FailedRuns
+	}
+	return 0
+}
+
+type CreateRunRequest struct {
+	Run                  *Run     `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReadArtifactResponse) Reset()         { *m = ReadArtifactResponse{} }
-func (m *ReadArtifactResponse) String() string { return proto.CompactTextString(m) }
-func (*ReadArtifactResponse) ProtoMessage()    {}
-func (*ReadArtifactResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e3419bc3417bf873, []int{11}
+func (m *CreateRunRequest) Reset()         { *m = CreateRunRequest{} }
+func (m *CreateRunRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateRunRequest) ProtoMessage()    {}
+func (*CreateRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{16}
 }
 
-func (m *ReadArtifactResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReadArtifactResponse.Unmarshal(m, b)
-}
-func (m *ReadArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReadArtifactResponse.Marshal(b, m, deterministic)
+func (m *CreateRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateRunRequest.Unmarshal(m, b)
 }
-func (m *ReadArtifactResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadArtifactResponse.Merge(m, src)
+func (m *CreateRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateRunRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateRunRequest.Merge(m, src)
+}
+func (m *CreateRunRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateRunRequest.Size(m)
+}
+func (m *CreateRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateRunRequest proto.InternalMessageInfo
+
+func (m *CreateRunRequest) GetRun() *Run {
+	if m != nil {
+		return m.Run
+	}
+	return nil
+}
+
+type GetRunRequest struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRunRequest) Reset()         { *m = GetRunRequest{} }
+func (m *GetRunRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRunRequest) ProtoMessage()    {}
+func (*GetRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{17}
+}
+
+func (m *GetRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunRequest.Unmarshal(m, b)
+}
+func (m *GetRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunRequest.Merge(m, src)
+}
+func (m *GetRunRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRunRequest.Size(m)
+}
+func (m *GetRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunRequest proto.InternalMessageInfo
+
+func (m *GetRunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type UpdateRunRequest struct {
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// Whether to replace the note of the run with note. The note is left unchanged
+	// otherwise.
+	UpdateNote bool   `protobuf:"varint,2,opt,name=update_note,json=updateNote,proto3" json:"update_note,omitempty"`
+	Note       string `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	// The annotations to set. The annotations set to an empty value are removed, and
+	// the ones left out are left unchanged.
+	Annotations          map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *UpdateRunRequest) Reset()         { *m = UpdateRunRequest{} }
+func (m *UpdateRunRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateRunRequest) ProtoMessage()    {}
+func (*UpdateRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{18}
+}
+
+func (m *UpdateRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateRunRequest.Unmarshal(m, b)
+}
+func (m *UpdateRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateRunRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateRunRequest.Merge(m, src)
+}
+func (m *UpdateRunRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateRunRequest.Size(m)
+}
+func (m *UpdateRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateRunRequest proto.InternalMessageInfo
+
+func (m *UpdateRunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *UpdateRunRequest) GetUpdateNote() bool {
+	if m != nil {
+		return m.UpdateNote
+	}
+	return false
+}
+
+func (m *UpdateRunRequest) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *UpdateRunRequest) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+type WatchRunRequest struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchRunRequest) Reset()         { *m = WatchRunRequest{} }
+func (m *WatchRunRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRunRequest) ProtoMessage()    {}
+func (*WatchRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{19}
+}
+
+func (m *WatchRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRunRequest.Unmarshal(m, b)
+}
+func (m *WatchRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRunRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRunRequest.Merge(m, src)
+}
+func (m *WatchRunRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRunRequest.Size(m)
+}
+func (m *WatchRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRunRequest proto.InternalMessageInfo
+
+func (m *WatchRunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type ListRunsRequest struct {
+	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Can be format of "field_name", "field_name asc" or "field_name des"
+	// Ascending by default.
+	SortBy string `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// What resource reference to filter on.
+	// E.g. If listing run for an experiment, the query string would be
+	// resource_reference_key.type=EXPERIMENT&resource_reference_key.id=123
+	ResourceReferenceKey *ResourceKey `protobuf:"bytes,4,opt,name=resource_reference_key,json=resourceReferenceKey,proto3" json:"resource_reference_key,omitempty"`
+	// The fields of the runs to return. The manifests are left out by default, as they
+	// make up most of the size of a run.
+	View ListRunsRequest_View `protobuf:"varint,5,opt,name=view,proto3,enum=api.ListRunsRequest_View" json:"view,omitempty"`
+	// Lists only the runs with an annotation, in the "key" form for any value, or the
+	// "key=value" form.
+	AnnotationFilter string `protobuf:"bytes,6,opt,name=annotation_filter,json=annotationFilter,proto3" json:"annotation_filter,omitempty"`
+	// Lists only the runs starred by the authenticated user.
+	OnlyStarred bool `protobuf:"varint,7,opt,name=only_starred,json=onlyStarred,proto3" json:"only_starred,omitempty"`
+	// Lists only the runs with a matching display name, e.g.
+	// name_filter.name=training&name_filter.match=CASE_INSENSITIVE
+	NameFilter           *NameFilter `protobuf:"bytes,8,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListRunsRequest) Reset()         { *m = ListRunsRequest{} }
+func (m *ListRunsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRunsRequest) ProtoMessage()    {}
+func (*ListRunsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{20}
+}
+
+func (m *ListRunsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunsRequest.Unmarshal(m, b)
+}
+func (m *ListRunsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRunsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunsRequest.Merge(m, src)
+}
+func (m *ListRunsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRunsRequest.Size(m)
+}
+func (m *ListRunsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRunsRequest proto.InternalMessageInfo
+
+func (m *ListRunsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListRunsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListRunsRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+func (m *ListRunsRequest) GetResourceReferenceKey() *ResourceKey {
+	if m != nil {
+		return m.ResourceReferenceKey
+	}
+	return nil
+}
+
+func (m *ListRunsRequest) GetView() ListRunsRequest_View {
+	if m != nil {
+		return m.View
+	}
+	return ListRunsRequest_BASIC
+}
+
+func (m *ListRunsRequest) GetAnnotationFilter() string {
+	if m != nil {
+		return m.AnnotationFilter
+	}
+	return ""
+}
+
+func (m *ListRunsRequest) GetOnlyStarred() bool {
+	if m != nil {
+		return m.OnlyStarred
+	}
+	return false
+}
+
+func (m *ListRunsRequest) GetNameFilter() *NameFilter {
+	if m != nil {
+		return m.NameFilter
+	}
+	return nil
+}
+
+type StarRunRequest struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StarRunRequest) Reset()         { *m = StarRunRequest{} }
+func (m *StarRunRequest) String() string { return proto.CompactTextString(m) }
+func (*StarRunRequest) ProtoMessage()    {}
+func (*StarRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{21}
+}
+
+func (m *StarRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StarRunRequest.Unmarshal(m, b)
+}
+func (m *StarRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StarRunRequest.Marshal(b, m, deterministic)
+}
+func (m *StarRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StarRunRequest.Merge(m, src)
+}
+func (m *StarRunRequest) XXX_Size() int {
+	return xxx_messageInfo_StarRunRequest.Size(m)
+}
+func (m *StarRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StarRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StarRunRequest proto.InternalMessageInfo
+
+func (m *StarRunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type UnstarRunRequest struct {
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnstarRunRequest) Reset()         { *m = UnstarRunRequest{} }
+func (m *UnstarRunRequest) String() string { return proto.CompactTextString(m) }
+func (*UnstarRunRequest) ProtoMessage()    {}
+func (*UnstarRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{22}
+}
+
+func (m *UnstarRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnstarRunRequest.Unmarshal(m, b)
+}
+func (m *UnstarRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnstarRunRequest.Marshal(b, m, deterministic)
+}
+func (m *UnstarRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnstarRunRequest.Merge(m, src)
+}
+func (m *UnstarRunRequest) XXX_Size() int {
+	return xxx_messageInfo_UnstarRunRequest.Size(m)
+}
+func (m *UnstarRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnstarRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnstarRunRequest proto.InternalMessageInfo
+
+func (m *UnstarRunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type ApproveRunRequest struct {
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The ID of the node of the gate to approve. All the waiting gates of the run
+	// are approved if empty.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Recorded in the message of the gates, next to the approving user.
+	Comment              string   `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApproveRunRequest) Reset()         { *m = ApproveRunRequest{} }
+func (m *ApproveRunRequest) String() string { return proto.CompactTextString(m) }
+func (*ApproveRunRequest) ProtoMessage()    {}
+func (*ApproveRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{23}
+}
+
+func (m *ApproveRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApproveRunRequest.Unmarshal(m, b)
+}
+func (m *ApproveRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApproveRunRequest.Marshal(b, m, deterministic)
+}
+func (m *ApproveRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApproveRunRequest.Merge(m, src)
+}
+func (m *ApproveRunRequest) XXX_Size() int {
+	return xxx_messageInfo_ApproveRunRequest.Size(m)
+}
+func (m *ApproveRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApproveRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApproveRunRequest proto.InternalMessageInfo
+
+func (m *ApproveRunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ApproveRunRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ApproveRunRequest) GetComment() string {
+	if m != nil {
+		return m.Comment
+	}
+	return ""
+}
+
+type RejectRunRequest struct {
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The ID of the node of the gate to reject. All the waiting gates of the run
+	// are rejected if empty.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Recorded in the message of the gates, next to the rejecting user.
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RejectRunRequest) Reset()         { *m = RejectRunRequest{} }
+func (m *RejectRunRequest) String() string { return proto.CompactTextString(m) }
+func (*RejectRunRequest) ProtoMessage()    {}
+func (*RejectRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{24}
+}
+
+func (m *RejectRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RejectRunRequest.Unmarshal(m, b)
+}
+func (m *RejectRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RejectRunRequest.Marshal(b, m, deterministic)
+}
+func (m *RejectRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RejectRunRequest.Merge(m, src)
+}
+func (m *RejectRunRequest) XXX_Size() int {
+	return xxx_messageInfo_RejectRunRequest.Size(m)
+}
+func (m *RejectRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RejectRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RejectRunRequest proto.InternalMessageInfo
+
+func (m *RejectRunRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *RejectRunRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *RejectRunRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ListRunsResponse struct {
+	Runs                 []*Run   `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRunsResponse) Reset()         { *m = ListRunsResponse{} }
+func (m *ListRunsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRunsResponse) ProtoMessage()    {}
+func (*ListRunsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{25}
+}
+
+func (m *ListRunsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunsResponse.Unmarshal(m, b)
+}
+func (m *ListRunsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRunsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunsResponse.Merge(m, src)
+}
+func (m *ListRunsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRunsResponse.Size(m)
+}
+func (m *ListRunsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRunsResponse proto.InternalMessageInfo
+
+func (m *ListRunsResponse) GetRuns() []*Run {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+func (m *ListRunsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type Run struct {
+	// Output. Unique run ID. Generated by API server.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Required input field. Name provided by user,
+	// or auto generated if run is created by scheduled job. Not unique.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Optional input field. Describing the purpose of the run
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Required input field.
+	// Describing what the pipeline manifest and parameters to use for the run.
+	PipelineSpec *PipelineSpec `protobuf:"bytes,4,opt,name=pipeline_spec,json=pipelineSpec,proto3" json:"pipeline_spec,omitempty"`
+	// Optional input field. Specify which resource this run belongs to.
+	ResourceReferences []*ResourceReference `protobuf:"bytes,5,rep,name=resource_references,json=resourceReferences,proto3" json:"resource_references,omitempty"`
+	// Output. The time that the run created.
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Output. When this run is scheduled to run. This could be different from
+	// created_at. For example, if a run is from a backfilling job that was
+	// supposed to run 2 month ago, the scheduled_at is 2 month ago,
+	// v.s. created_at is the current time.
+	ScheduledAt *timestamp.Timestamp `protobuf:"bytes,7,opt,name=scheduled_at,json=scheduledAt,proto3" json:"scheduled_at,omitempty"`
+	// Output. The status of the run.
+	// One of [Pending, Running, Succeeded, Skipped, Failed, Error], or Queued
+	// while the run waits for the active runs of its pipeline to be fewer than
+	// the pipeline allows.
+	Status string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// In case any error happens retrieving a run field, only run ID
+	// and the error message is returned. Client has the flexibility of choosing
+	// how to handle error. This is especially useful during listing call.
+	Error string `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	// Output. The metrics of the run. The metrics are reported by ReportMetrics
+	// API.
+	Metrics []*RunMetric `protobuf:"bytes,9,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	// Output. The namespace of the run.
+	Namespace string `protobuf:"bytes,14,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Output. The readiness of the InferenceServices and SeldonDeployments deployed
+	// by the run, tracked after it succeeds. Only returned by GetRun.
+	Deployments []*DeploymentStatus `protobuf:"bytes,13,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	// Output. A free-form note on the run, set by UpdateRun.
+	Note string `protobuf:"bytes,15,opt,name=note,proto3" json:"note,omitempty"`
+	// Output. The annotations of the run, e.g. "quality": "bad data day", set by
+	// UpdateRun.
+	Annotations map[string]string `protobuf:"bytes,16,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Optional input field. The service account the pods of the run run as,
+	// instead of the one of the namespace or of the compiled workflow. It must be
+	// allowed by the server.
+	ServiceAccount string `protobuf:"bytes,17,opt,name=service_account,json=serviceAccount,proto3" json:"service_account,omitempty"`
+	// Optional input field. Executes only a part of the pipeline, e.g. to iterate
+	// on one of its stages.
+	PartialExecution *PartialExecution `protobuf:"bytes,18,opt,name=partial_execution,json=partialExecution,proto3" json:"partial_execution,omitempty"`
+	// Optional input field. The name of the execution target the workflow of the
+	// run is dispatched to. The cluster of the API server by default.
+	ExecutionTarget      string   `protobuf:"bytes,19,opt,name=execution_target,json=executionTarget,proto3" json:"execution_target,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Run) Reset()         { *m = Run{} }
+func (m *Run) String() string { return proto.CompactTextString(m) }
+func (*Run) ProtoMessage()    {}
+func (*Run) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{26}
+}
+
+func (m *Run) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Run.Unmarshal(m, b)
+}
+func (m *Run) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Run.Marshal(b, m, deterministic)
+}
+func (m *Run) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Run.Merge(m, src)
+}
+func (m *Run) XXX_Size() int {
+	return xxx_messageInfo_Run.Size(m)
+}
+func (m *Run) XXX_DiscardUnknown() {
+	xxx_messageInfo_Run.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Run proto.InternalMessageInfo
+
+func (m *Run) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Run) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Run) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Run) GetPipelineSpec() *PipelineSpec {
+	if m != nil {
+		return m.PipelineSpec
+	}
+	return nil
+}
+
+func (m *Run) GetResourceReferences() []*ResourceReference {
+	if m != nil {
+		return m.ResourceReferences
+	}
+	return nil
+}
+
+func (m *Run) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *Run) GetScheduledAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+func (m *Run) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Run) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Run) GetMetrics() []*RunMetric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *Run) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Run) GetDeployments() []*DeploymentStatus {
+	if m != nil {
+		return m.Deployments
+	}
+	return nil
+}
+
+func (m *Run) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *Run) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *Run) GetServiceAccount() string {
+	if m != nil {
+		return m.ServiceAccount
+	}
+	return ""
+}
+
+func (m *Run) GetPartialExecution() *PartialExecution {
+	if m != nil {
+		return m.PartialExecution
+	}
+	return nil
+}
+
+func (m *Run) GetExecutionTarget() string {
+	if m != nil {
+		return m.ExecutionTarget
+	}
+	return ""
+}
+
+type PartialExecution struct {
+	// The template the run starts from instead of the entrypoint of the workflow.
+	Entrypoint string `protobuf:"bytes,1,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+	// The names of the tasks of the DAG templates and of the steps of the steps
+	// templates to execute. The others are removed, except those invoking the
+	// templates of the selected ones, and the dependencies on them are dropped.
+	// Empty, all of them are executed.
+	Steps                []string `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartialExecution) Reset()         { *m = PartialExecution{} }
+func (m *PartialExecution) String() string { return proto.CompactTextString(m) }
+func (*PartialExecution) ProtoMessage()    {}
+func (*PartialExecution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{27}
+}
+
+func (m *PartialExecution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartialExecution.Unmarshal(m, b)
+}
+func (m *PartialExecution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartialExecution.Marshal(b, m, deterministic)
+}
+func (m *PartialExecution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartialExecution.Merge(m, src)
+}
+func (m *PartialExecution) XXX_Size() int {
+	return xxx_messageInfo_PartialExecution.Size(m)
+}
+func (m *PartialExecution) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartialExecution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartialExecution proto.InternalMessageInfo
+
+func (m *PartialExecution) GetEntrypoint() string {
+	if m != nil {
+		return m.Entrypoint
+	}
+	return ""
+}
+
+func (m *PartialExecution) GetSteps() []string {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type DeploymentStatus struct {
+	// The kind of the deployed resource, InferenceService or SeldonDeployment.
+	Kind      string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	State     DeploymentStatus_State `protobuf:"varint,4,opt,name=state,proto3,enum=api.DeploymentStatus_State" json:"state,omitempty"`
+	// Why the resource isn't ready, if known.
+	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// The last time the state or the message changed.
+	UpdatedAt            *timestamp.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeploymentStatus) Reset()         { *m = DeploymentStatus{} }
+func (m *DeploymentStatus) String() string { return proto.CompactTextString(m) }
+func (*DeploymentStatus) ProtoMessage()    {}
+func (*DeploymentStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{28}
+}
+
+func (m *DeploymentStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeploymentStatus.Unmarshal(m, b)
+}
+func (m *DeploymentStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeploymentStatus.Marshal(b, m, deterministic)
+}
+func (m *DeploymentStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeploymentStatus.Merge(m, src)
+}
+func (m *DeploymentStatus) XXX_Size() int {
+	return xxx_messageInfo_DeploymentStatus.Size(m)
+}
+func (m *DeploymentStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeploymentStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeploymentStatus proto.InternalMessageInfo
+
+func (m *DeploymentStatus) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *DeploymentStatus) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *DeploymentStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeploymentStatus) GetState() DeploymentStatus_State {
+	if m != nil {
+		return m.State
+	}
+	return DeploymentStatus_UNSPECIFIED
+}
+
+func (m *DeploymentStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *DeploymentStatus) GetUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type PipelineRuntime struct {
+	// Output. The runtime JSON manifest of the pipeline, including the status
+	// of pipeline steps and fields need for UI visualization etc.
+	PipelineManifest string `protobuf:"bytes,10,opt,name=pipeline_manifest,json=pipelineManifest,proto3" json:"pipeline_manifest,omitempty"`
+	// Output. The runtime JSON manifest of the argo workflow.
+	// This is deprecated after pipeline_runtime_manifest is in use.
+	WorkflowManifest     string   `protobuf:"bytes,11,opt,name=workflow_manifest,json=workflowManifest,proto3" json:"workflow_manifest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PipelineRuntime) Reset()         { *m = PipelineRuntime{} }
+func (m *PipelineRuntime) String() string { return proto.CompactTextString(m) }
+func (*PipelineRuntime) ProtoMessage()    {}
+func (*PipelineRuntime) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{29}
+}
+
+func (m *PipelineRuntime) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PipelineRuntime.Unmarshal(m, b)
+}
+func (m *PipelineRuntime) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PipelineRuntime.Marshal(b, m, deterministic)
+}
+func (m *PipelineRuntime) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PipelineRuntime.Merge(m, src)
+}
+func (m *PipelineRuntime) XXX_Size() int {
+	return xxx_messageInfo_PipelineRuntime.Size(m)
+}
+func (m *PipelineRuntime) XXX_DiscardUnknown() {
+	xxx_messageInfo_PipelineRuntime.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PipelineRuntime proto.InternalMessageInfo
+
+func (m *PipelineRuntime) GetPipelineManifest() string {
+	if m != nil {
+		return m.PipelineManifest
+	}
+	return ""
+}
+
+func (m *PipelineRuntime) GetWorkflowManifest() string {
+	if m != nil {
+		return m.WorkflowManifest
+	}
+	return ""
+}
+
+type RunDetail struct {
+	Run             *Run             `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	PipelineRuntime *PipelineRuntime `protobuf:"bytes,2,opt,name=pipeline_runtime,json=pipelineRuntime,proto3" json:"pipeline_runtime,omitempty"`
+	// The attempts of the steps with a retry strategy, in the order they
+	// started.
+	StepAttempts []*StepAttempts `protobuf:"bytes,3,rep,name=step_attempts,json=stepAttempts,proto3" json:"step_attempts,omitempty"`
+	// The phase of the main DAG of the run. Unlike the status of the run, an
+	// exit handler failing doesn't affect it.
+	MainPhase string `protobuf:"bytes,4,opt,name=main_phase,json=mainPhase,proto3" json:"main_phase,omitempty"`
+	// The exit handler of the run, if it has one and it has started.
+	ExitHandler *ExitHandler `protobuf:"bytes,5,opt,name=exit_handler,json=exitHandler,proto3" json:"exit_handler,omitempty"`
+	// The warnings of the creation of the run, e.g. its pipeline being
+	// deprecated. Only returned by CreateRun.
+	Warnings []string `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	// The approval gates of the run, i.e. its suspend steps, in the order they
+	// started.
+	ApprovalGates        []*ApprovalGate `protobuf:"bytes,7,rep,name=approval_gates,json=approvalGates,proto3" json:"approval_gates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RunDetail) Reset()         { *m = RunDetail{} }
+func (m *RunDetail) String() string { return proto.CompactTextString(m) }
+func (*RunDetail) ProtoMessage()    {}
+func (*RunDetail) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{30}
+}
+
+func (m *RunDetail) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunDetail.Unmarshal(m, b)
+}
+func (m *RunDetail) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunDetail.Marshal(b, m, deterministic)
+}
+func (m *RunDetail) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunDetail.Merge(m, src)
+}
+func (m *RunDetail) XXX_Size() int {
+	return xxx_messageInfo_RunDetail.Size(m)
+}
+func (m *RunDetail) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunDetail.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunDetail proto.InternalMessageInfo
+
+func (m *RunDetail) GetRun() *Run {
+	if m != nil {
+		return m.Run
+	}
+	return nil
+}
+
+func (m *RunDetail) GetPipelineRuntime() *PipelineRuntime {
+	if m != nil {
+		return m.PipelineRuntime
+	}
+	return nil
+}
+
+func (m *RunDetail) GetStepAttempts() []*StepAttempts {
+	if m != nil {
+		return m.StepAttempts
+	}
+	return nil
+}
+
+func (m *RunDetail) GetMainPhase() string {
+	if m != nil {
+		return m.MainPhase
+	}
+	return ""
+}
+
+func (m *RunDetail) GetExitHandler() *ExitHandler {
+	if m != nil {
+		return m.ExitHandler
+	}
+	return nil
+}
+
+func (m *RunDetail) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func (m *RunDetail) GetApprovalGates() []*ApprovalGate {
+	if m != nil {
+		return m.ApprovalGates
+	}
+	return nil
+}
+
+// An approval gate of a run, a suspend step pausing the run until a user
+// approves or rejects it with ApproveRun or RejectRun.
+type ApprovalGate struct {
+	// The ID of the node of the gate.
+	NodeId       string               `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DisplayName  string               `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	TemplateName string               `protobuf:"bytes,3,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	State        ApprovalGate_State   `protobuf:"varint,4,opt,name=state,proto3,enum=api.ApprovalGate_State" json:"state,omitempty"`
+	StartedAt    *timestamp.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	ResolvedAt   *timestamp.Timestamp `protobuf:"bytes,6,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	// Who resolved the gate and why, once resolved.
+	Message              string   `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ApprovalGate) Reset()         { *m = ApprovalGate{} }
+func (m *ApprovalGate) String() string { return proto.CompactTextString(m) }
+func (*ApprovalGate) ProtoMessage()    {}
+func (*ApprovalGate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{31}
+}
+
+func (m *ApprovalGate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ApprovalGate.Unmarshal(m, b)
+}
+func (m *ApprovalGate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ApprovalGate.Marshal(b, m, deterministic)
+}
+func (m *ApprovalGate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ApprovalGate.Merge(m, src)
+}
+func (m *ApprovalGate) XXX_Size() int {
+	return xxx_messageInfo_ApprovalGate.Size(m)
+}
+func (m *ApprovalGate) XXX_DiscardUnknown() {
+	xxx_messageInfo_ApprovalGate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ApprovalGate proto.InternalMessageInfo
+
+func (m *ApprovalGate) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ApprovalGate) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *ApprovalGate) GetTemplateName() string {
+	if m != nil {
+		return m.TemplateName
+	}
+	return ""
+}
+
+func (m *ApprovalGate) GetState() ApprovalGate_State {
+	if m != nil {
+		return m.State
+	}
+	return ApprovalGate_STATE_UNSPECIFIED
+}
+
+func (m *ApprovalGate) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *ApprovalGate) GetResolvedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ResolvedAt
+	}
+	return nil
+}
+
+func (m *ApprovalGate) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// The status of the onExit template of a run, which runs once the main DAG
+// of the run completes, e.g. to clean up.
+type ExitHandler struct {
+	// The ID of the node of the onExit template.
+	NodeId       string               `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	TemplateName string               `protobuf:"bytes,2,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Phase        string               `protobuf:"bytes,3,opt,name=phase,proto3" json:"phase,omitempty"`
+	StartedAt    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt   *timestamp.Timestamp `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Message      string               `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// Whether the exit handler, e.g. the cleanup, completed successfully.
+	Succeeded bool `protobuf:"varint,7,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// The IDs of the nodes run by the exit handler, to tell them apart from the
+	// nodes of the main DAG.
+	NodeIds              []string `protobuf:"bytes,8,rep,name=node_ids,json=nodeIds,proto3" json:"node_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExitHandler) Reset()         { *m = ExitHandler{} }
+func (m *ExitHandler) String() string { return proto.CompactTextString(m) }
+func (*ExitHandler) ProtoMessage()    {}
+func (*ExitHandler) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{32}
+}
+
+func (m *ExitHandler) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExitHandler.Unmarshal(m, b)
+}
+func (m *ExitHandler) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExitHandler.Marshal(b, m, deterministic)
+}
+func (m *ExitHandler) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExitHandler.Merge(m, src)
+}
+func (m *ExitHandler) XXX_Size() int {
+	return xxx_messageInfo_ExitHandler.Size(m)
+}
+func (m *ExitHandler) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExitHandler.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExitHandler proto.InternalMessageInfo
+
+func (m *ExitHandler) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ExitHandler) GetTemplateName() string {
+	if m != nil {
+		return m.TemplateName
+	}
+	return ""
+}
+
+func (m *ExitHandler) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *ExitHandler) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *ExitHandler) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *ExitHandler) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ExitHandler) GetSucceeded() bool {
+	if m != nil {
+		return m.Succeeded
+	}
+	return false
+}
+
+func (m *ExitHandler) GetNodeIds() []string {
+	if m != nil {
+		return m.NodeIds
+	}
+	return nil
+}
+
+// The attempts of a step retried by Argo, parsed from the retry node of the
+// step in the status of the workflow.
+type StepAttempts struct {
+	// The ID of the retry node of the step.
+	NodeId       string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	DisplayName  string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	TemplateName string `protobuf:"bytes,3,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	Phase        string `protobuf:"bytes,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	// The maximum number of retries of the step, from its retry strategy.
+	RetryLimit           int32                   `protobuf:"varint,5,opt,name=retry_limit,json=retryLimit,proto3" json:"retry_limit,omitempty"`
+	Attempts             []*StepAttempts_Attempt `protobuf:"bytes,6,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *StepAttempts) Reset()         { *m = StepAttempts{} }
+func (m *StepAttempts) String() string { return proto.CompactTextString(m) }
+func (*StepAttempts) ProtoMessage()    {}
+func (*StepAttempts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{33}
+}
+
+func (m *StepAttempts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StepAttempts.Unmarshal(m, b)
+}
+func (m *StepAttempts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StepAttempts.Marshal(b, m, deterministic)
+}
+func (m *StepAttempts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StepAttempts.Merge(m, src)
+}
+func (m *StepAttempts) XXX_Size() int {
+	return xxx_messageInfo_StepAttempts.Size(m)
+}
+func (m *StepAttempts) XXX_DiscardUnknown() {
+	xxx_messageInfo_StepAttempts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StepAttempts proto.InternalMessageInfo
+
+func (m *StepAttempts) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *StepAttempts) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *StepAttempts) GetTemplateName() string {
+	if m != nil {
+		return m.TemplateName
+	}
+	return ""
+}
+
+func (m *StepAttempts) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *StepAttempts) GetRetryLimit() int32 {
+	if m != nil {
+		return m.RetryLimit
+	}
+	return 0
+}
+
+func (m *StepAttempts) GetAttempts() []*StepAttempts_Attempt {
+	if m != nil {
+		return m.Attempts
+	}
+	return nil
+}
+
+type StepAttempts_Attempt struct {
+	// The ID of the pod node of the attempt.
+	NodeId     string               `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Phase      string               `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`
+	StartedAt  *timestamp.Timestamp `protobuf:"bytes,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt *timestamp.Timestamp `protobuf:"bytes,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// The duration of the attempt, zero until it completes.
+	DurationSeconds int64 `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// The message of the attempt, e.g. the exit code of its failure.
+	Message              string   `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StepAttempts_Attempt) Reset()         { *m = StepAttempts_Attempt{} }
+func (m *StepAttempts_Attempt) String() string { return proto.CompactTextString(m) }
+func (*StepAttempts_Attempt) ProtoMessage()    {}
+func (*StepAttempts_Attempt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{33, 0}
+}
+
+func (m *StepAttempts_Attempt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StepAttempts_Attempt.Unmarshal(m, b)
+}
+func (m *StepAttempts_Attempt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StepAttempts_Attempt.Marshal(b, m, deterministic)
+}
+func (m *StepAttempts_Attempt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StepAttempts_Attempt.Merge(m, src)
+}
+func (m *StepAttempts_Attempt) XXX_Size() int {
+	return xxx_messageInfo_StepAttempts_Attempt.Size(m)
+}
+func (m *StepAttempts_Attempt) XXX_DiscardUnknown() {
+	xxx_messageInfo_StepAttempts_Attempt.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StepAttempts_Attempt proto.InternalMessageInfo
+
+func (m *StepAttempts_Attempt) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *StepAttempts_Attempt) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *StepAttempts_Attempt) GetStartedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *StepAttempts_Attempt) GetFinishedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *StepAttempts_Attempt) GetDurationSeconds() int64 {
+	if m != nil {
+		return m.DurationSeconds
+	}
+	return 0
+}
+
+func (m *StepAttempts_Attempt) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type RunMetric struct {
+	// Required. The user defined name of the metric. It must between 1 and 63 characters
+	// long and must conform to the following regular expression:
+	// `[a-z]([-a-z0-9]*[a-z0-9])?`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Required. The runtime node ID which reports the metric. The node ID can be found in
+	// the RunDetail.workflow.Status. Metric with same (node_id, name)
+	// are considerd as duplicate. Only the first reporting will be recorded. Max length is 128.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//	*RunMetric_NumberValue
+	Value isRunMetric_Value `protobuf_oneof:"value"`
+	// The display format of metric.
+	Format               RunMetric_Format `protobuf:"varint,4,opt,name=format,proto3,enum=api.RunMetric_Format" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RunMetric) Reset()         { *m = RunMetric{} }
+func (m *RunMetric) String() string { return proto.CompactTextString(m) }
+func (*RunMetric) ProtoMessage()    {}
+func (*RunMetric) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{34}
+}
+
+func (m *RunMetric) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunMetric.Unmarshal(m, b)
+}
+func (m *RunMetric) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunMetric.Marshal(b, m, deterministic)
+}
+func (m *RunMetric) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunMetric.Merge(m, src)
+}
+func (m *RunMetric) XXX_Size() int {
+	return xxx_messageInfo_RunMetric.Size(m)
+}
+func (m *RunMetric) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunMetric.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunMetric proto.InternalMessageInfo
+
+func (m *RunMetric) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RunMetric) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type isRunMetric_Value interface {
+	isRunMetric_Value()
+}
+
+type RunMetric_NumberValue struct {
+	NumberValue float64 `protobuf:"fixed64,3,opt,name=number_value,json=numberValue,proto3,oneof"`
+}
+
+func (*RunMetric_NumberValue) isRunMetric_Value() {}
+
+func (m *RunMetric) GetValue() isRunMetric_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *RunMetric) GetNumberValue() float64 {
+	if x, ok := m.GetValue().(*RunMetric_NumberValue); ok {
+		return x.NumberValue
+	}
+	return 0
+}
+
+func (m *RunMetric) GetFormat() RunMetric_Format {
+	if m != nil {
+		return m.Format
+	}
+	return RunMetric_UNSPECIFIED
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RunMetric) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RunMetric_OneofMarshaler, _RunMetric_OneofUnmarshaler, _RunMetric_OneofSizer, []interface{}{
+		(*RunMetric_NumberValue)(nil),
+	}
+}
+
+func _RunMetric_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RunMetric)
+	// value
+	switch x := m.Value.(type) {
+	case *RunMetric_NumberValue:
+		b.EncodeVarint(3<<3 | proto.WireFixed64)
+		b.EncodeFixed64(math.Float64bits(x.NumberValue))
+	case nil:
+	default:
+		return fmt.Errorf("RunMetric.Value has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RunMetric_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RunMetric)
+	switch tag {
+	case 3: // value.number_value
+		if wire != proto.WireFixed64 {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeFixed64()
+		m.Value = &RunMetric_NumberValue{math.Float64frombits(x)}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RunMetric_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RunMetric)
+	// value
+	switch x := m.Value.(type) {
+	case *RunMetric_NumberValue:
+		n += 1 // tag and wire
+		n += 8
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type ReportRunMetricsRequest struct {
+	// Required. The parent run ID of the metric.
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// List of metrics to report.
+	Metrics              []*RunMetric `protobuf:"bytes,2,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ReportRunMetricsRequest) Reset()         { *m = ReportRunMetricsRequest{} }
+func (m *ReportRunMetricsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportRunMetricsRequest) ProtoMessage()    {}
+func (*ReportRunMetricsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{35}
+}
+
+func (m *ReportRunMetricsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportRunMetricsRequest.Unmarshal(m, b)
+}
+func (m *ReportRunMetricsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportRunMetricsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportRunMetricsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportRunMetricsRequest.Merge(m, src)
+}
+func (m *ReportRunMetricsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportRunMetricsRequest.Size(m)
+}
+func (m *ReportRunMetricsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportRunMetricsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportRunMetricsRequest proto.InternalMessageInfo
+
+func (m *ReportRunMetricsRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ReportRunMetricsRequest) GetMetrics() []*RunMetric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+type ReportRunMetricsResponse struct {
+	Results              []*ReportRunMetricsResponse_ReportRunMetricResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                          `json:"-"`
+	XXX_unrecognized     []byte                                            `json:"-"`
+	XXX_sizecache        int32                                             `json:"-"`
+}
+
+func (m *ReportRunMetricsResponse) Reset()         { *m = ReportRunMetricsResponse{} }
+func (m *ReportRunMetricsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportRunMetricsResponse) ProtoMessage()    {}
+func (*ReportRunMetricsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{36}
+}
+
+func (m *ReportRunMetricsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportRunMetricsResponse.Unmarshal(m, b)
+}
+func (m *ReportRunMetricsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportRunMetricsResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportRunMetricsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportRunMetricsResponse.Merge(m, src)
+}
+func (m *ReportRunMetricsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportRunMetricsResponse.Size(m)
+}
+func (m *ReportRunMetricsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportRunMetricsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportRunMetricsResponse proto.InternalMessageInfo
+
+func (m *ReportRunMetricsResponse) GetResults() []*ReportRunMetricsResponse_ReportRunMetricResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type ReportRunMetricsResponse_ReportRunMetricResult struct {
+	// Output. The name of the metric.
+	MetricName string `protobuf:"bytes,1,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	// Output. The ID of the node which reports the metric.
+	MetricNodeId string `protobuf:"bytes,2,opt,name=metric_node_id,json=metricNodeId,proto3" json:"metric_node_id,omitempty"`
+	// Output. The status of the metric reporting.
+	Status ReportRunMetricsResponse_ReportRunMetricResult_Status `protobuf:"varint,3,opt,name=status,proto3,enum=api.ReportRunMetricsResponse_ReportRunMetricResult_Status" json:"status,omitempty"`
+	// Output. The detailed message of the error of the reporting.
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) Reset() {
+	*m = ReportRunMetricsResponse_ReportRunMetricResult{}
+}
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) String() string {
+	return proto.CompactTextString(m)
+}
+func (*ReportRunMetricsResponse_ReportRunMetricResult) ProtoMessage() {}
+func (*ReportRunMetricsResponse_ReportRunMetricResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{36, 0}
+}
+
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Unmarshal(m, b)
+}
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Marshal(b, m, deterministic)
+}
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Merge(m, src)
+}
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_Size() int {
+	return xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.Size(m)
+}
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportRunMetricsResponse_ReportRunMetricResult proto.InternalMessageInfo
+
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
+}
+
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetMetricNodeId() string {
+	if m != nil {
+		return m.MetricNodeId
+	}
+	return ""
+}
+
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetStatus() ReportRunMetricsResponse_ReportRunMetricResult_Status {
+	if m != nil {
+		return m.Status
+	}
+	return ReportRunMetricsResponse_ReportRunMetricResult_UNSPECIFIED
+}
+
+func (m *ReportRunMetricsResponse_ReportRunMetricResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ReadArtifactRequest struct {
+	// The ID of the run.
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The ID of the running node.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The name of the artifact.
+	ArtifactName         string   `protobuf:"bytes,3,opt,name=artifact_name,json=artifactName,proto3" json:"artifact_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadArtifactRequest) Reset()         { *m = ReadArtifactRequest{} }
+func (m *ReadArtifactRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadArtifactRequest) ProtoMessage()    {}
+func (*ReadArtifactRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{37}
+}
+
+func (m *ReadArtifactRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadArtifactRequest.Unmarshal(m, b)
+}
+func (m *ReadArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadArtifactRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadArtifactRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadArtifactRequest.Merge(m, src)
+}
+func (m *ReadArtifactRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadArtifactRequest.Size(m)
+}
+func (m *ReadArtifactRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadArtifactRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadArtifactRequest proto.InternalMessageInfo
+
+func (m *ReadArtifactRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ReadArtifactRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ReadArtifactRequest) GetArtifactName() string {
+	if m != nil {
+		return m.ArtifactName
+	}
+	return ""
+}
+
+type ReadArtifactResponse struct {
+	// The bytes of the artifact content.
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadArtifactResponse) Reset()         { *m = ReadArtifactResponse{} }
+func (m *ReadArtifactResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadArtifactResponse) ProtoMessage()    {}
+func (*ReadArtifactResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{38}
+}
+
+func (m *ReadArtifactResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadArtifactResponse.Unmarshal(m, b)
+}
+func (m *ReadArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadArtifactResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadArtifactResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadArtifactResponse.Merge(m, src)
 }
 func (m *ReadArtifactResponse) XXX_Size() int {
 	return xxx_messageInfo_ReadArtifactResponse.Size(m)
 }
-func (m *ReadArtifactResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadArtifactResponse.DiscardUnknown(m)
+func (m *ReadArtifactResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadArtifactResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadArtifactResponse proto.InternalMessageInfo
+
+func (m *ReadArtifactResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type GetRunOutputsRequest struct {
+	// The ID of the run.
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRunOutputsRequest) Reset()         { *m = GetRunOutputsRequest{} }
+func (m *GetRunOutputsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRunOutputsRequest) ProtoMessage()    {}
+func (*GetRunOutputsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{39}
+}
+
+func (m *GetRunOutputsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunOutputsRequest.Unmarshal(m, b)
+}
+func (m *GetRunOutputsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunOutputsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRunOutputsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunOutputsRequest.Merge(m, src)
+}
+func (m *GetRunOutputsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRunOutputsRequest.Size(m)
+}
+func (m *GetRunOutputsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunOutputsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunOutputsRequest proto.InternalMessageInfo
+
+func (m *GetRunOutputsRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type RunOutput struct {
+	// The ID of the node of the step which output it.
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The type of the output, as in the UI metadata, i.e. "markdown" or
+	// "web-app".
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// The URI the content was read from, or empty if it was inline.
+	Source string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	// The markdown of a "markdown" output, or the HTML of a "web-app" output.
+	Content string `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// Whether the content was cut at the size limit of the server.
+	Truncated bool `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Why the content couldn't be read, if it couldn't.
+	Error                string   `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunOutput) Reset()         { *m = RunOutput{} }
+func (m *RunOutput) String() string { return proto.CompactTextString(m) }
+func (*RunOutput) ProtoMessage()    {}
+func (*RunOutput) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{40}
+}
+
+func (m *RunOutput) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunOutput.Unmarshal(m, b)
+}
+func (m *RunOutput) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunOutput.Marshal(b, m, deterministic)
+}
+func (m *RunOutput) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunOutput.Merge(m, src)
+}
+func (m *RunOutput) XXX_Size() int {
+	return xxx_messageInfo_RunOutput.Size(m)
+}
+func (m *RunOutput) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunOutput.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunOutput proto.InternalMessageInfo
+
+func (m *RunOutput) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *RunOutput) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *RunOutput) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *RunOutput) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func (m *RunOutput) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *RunOutput) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GetRunOutputsResponse struct {
+	// The outputs in the order the steps started.
+	Outputs              []*RunOutput `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetRunOutputsResponse) Reset()         { *m = GetRunOutputsResponse{} }
+func (m *GetRunOutputsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRunOutputsResponse) ProtoMessage()    {}
+func (*GetRunOutputsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{41}
+}
+
+func (m *GetRunOutputsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunOutputsResponse.Unmarshal(m, b)
+}
+func (m *GetRunOutputsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunOutputsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRunOutputsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunOutputsResponse.Merge(m, src)
+}
+func (m *GetRunOutputsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRunOutputsResponse.Size(m)
+}
+func (m *GetRunOutputsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunOutputsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunOutputsResponse proto.InternalMessageInfo
+
+func (m *GetRunOutputsResponse) GetOutputs() []*RunOutput {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+type GetRunHistoryRequest struct {
+	// The ID of the run.
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The ID of a node of the workflow of the run, to only return its
+	// transitions. If empty, the transitions of the run and of all its nodes are
+	// returned.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Only return the transitions up to this time, so that the last transition
+	// of the run and of each node is its state at that time. If unset, all the
+	// transitions are returned.
+	AsOf                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetRunHistoryRequest) Reset()         { *m = GetRunHistoryRequest{} }
+func (m *GetRunHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRunHistoryRequest) ProtoMessage()    {}
+func (*GetRunHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{42}
+}
+
+func (m *GetRunHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunHistoryRequest.Unmarshal(m, b)
+}
+func (m *GetRunHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunHistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRunHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunHistoryRequest.Merge(m, src)
+}
+func (m *GetRunHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRunHistoryRequest.Size(m)
+}
+func (m *GetRunHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunHistoryRequest proto.InternalMessageInfo
+
+func (m *GetRunHistoryRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *GetRunHistoryRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *GetRunHistoryRequest) GetAsOf() *timestamp.Timestamp {
+	if m != nil {
+		return m.AsOf
+	}
+	return nil
+}
+
+type RunStateTransition struct {
+	// The ID of the node which reached the state, or empty for the run itself.
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The display name of the node, or empty for the run itself.
+	NodeName string `protobuf:"bytes,2,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	// The state reached, i.e. the phase of the workflow or of the node, e.g.
+	// "Running" or "Failed".
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// When the state was reached, as reported by the workflow, or when the
+	// report was processed if the workflow has no time for it. Never before the
+	// previous transition of the node, so that its states stay in order.
+	Time *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// The message reported with the state, e.g. why a step failed.
+	Message              string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunStateTransition) Reset()         { *m = RunStateTransition{} }
+func (m *RunStateTransition) String() string { return proto.CompactTextString(m) }
+func (*RunStateTransition) ProtoMessage()    {}
+func (*RunStateTransition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{43}
+}
+
+func (m *RunStateTransition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunStateTransition.Unmarshal(m, b)
+}
+func (m *RunStateTransition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunStateTransition.Marshal(b, m, deterministic)
+}
+func (m *RunStateTransition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunStateTransition.Merge(m, src)
+}
+func (m *RunStateTransition) XXX_Size() int {
+	return xxx_messageInfo_RunStateTransition.Size(m)
+}
+func (m *RunStateTransition) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunStateTransition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunStateTransition proto.InternalMessageInfo
+
+func (m *RunStateTransition) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *RunStateTransition) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *RunStateTransition) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *RunStateTransition) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *RunStateTransition) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type GetRunHistoryResponse struct {
+	// The transitions in chronological order.
+	Transitions          []*RunStateTransition `protobuf:"bytes,1,rep,name=transitions,proto3" json:"transitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GetRunHistoryResponse) Reset()         { *m = GetRunHistoryResponse{} }
+func (m *GetRunHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRunHistoryResponse) ProtoMessage()    {}
+func (*GetRunHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{44}
+}
+
+func (m *GetRunHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunHistoryResponse.Unmarshal(m, b)
+}
+func (m *GetRunHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunHistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRunHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunHistoryResponse.Merge(m, src)
+}
+func (m *GetRunHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRunHistoryResponse.Size(m)
+}
+func (m *GetRunHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunHistoryResponse proto.InternalMessageInfo
+
+func (m *GetRunHistoryResponse) GetTransitions() []*RunStateTransition {
+	if m != nil {
+		return m.Transitions
+	}
+	return nil
+}
+
+type GetRunManifestRequest struct {
+	// The ID of the run.
+	RunId                string   `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRunManifestRequest) Reset()         { *m = GetRunManifestRequest{} }
+func (m *GetRunManifestRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRunManifestRequest) ProtoMessage()    {}
+func (*GetRunManifestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{45}
+}
+
+func (m *GetRunManifestRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRunManifestRequest.Unmarshal(m, b)
+}
+func (m *GetRunManifestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRunManifestRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRunManifestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRunManifestRequest.Merge(m, src)
+}
+func (m *GetRunManifestRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRunManifestRequest.Size(m)
+}
+func (m *GetRunManifestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRunManifestRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRunManifestRequest proto.InternalMessageInfo
+
+func (m *GetRunManifestRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type RunManifest struct {
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The workflow submitted with the run, i.e. the one of its pipeline or its
+	// own, before its parameters are resolved, in JSON.
+	SubmittedManifest string `protobuf:"bytes,2,opt,name=submitted_manifest,json=submittedManifest,proto3" json:"submitted_manifest,omitempty"`
+	// The workflow as last reported from the cluster, with the resolved
+	// parameters and the status of its nodes, in JSON.
+	RuntimeManifest string `protobuf:"bytes,3,opt,name=runtime_manifest,json=runtimeManifest,proto3" json:"runtime_manifest,omitempty"`
+	// The parameters of the runtime workflow, with the values the run used once
+	// resolved, e.g. with the macros of the runs of a job substituted.
+	ResolvedParameters   []*Parameter `protobuf:"bytes,4,rep,name=resolved_parameters,json=resolvedParameters,proto3" json:"resolved_parameters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RunManifest) Reset()         { *m = RunManifest{} }
+func (m *RunManifest) String() string { return proto.CompactTextString(m) }
+func (*RunManifest) ProtoMessage()    {}
+func (*RunManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{46}
+}
+
+func (m *RunManifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunManifest.Unmarshal(m, b)
+}
+func (m *RunManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunManifest.Marshal(b, m, deterministic)
+}
+func (m *RunManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunManifest.Merge(m, src)
+}
+func (m *RunManifest) XXX_Size() int {
+	return xxx_messageInfo_RunManifest.Size(m)
+}
+func (m *RunManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunManifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunManifest proto.InternalMessageInfo
+
+func (m *RunManifest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *RunManifest) GetSubmittedManifest() string {
+	if m != nil {
+		return m.SubmittedManifest
+	}
+	return ""
+}
+
+func (m *RunManifest) GetRuntimeManifest() string {
+	if m != nil {
+		return m.RuntimeManifest
+	}
+	return ""
+}
+
+func (m *RunManifest) GetResolvedParameters() []*Parameter {
+	if m != nil {
+		return m.ResolvedParameters
+	}
+	return nil
+}
+
+type ReadRunLogsRequest struct {
+	// The ID of the run.
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The ID of the node of the step.
+	NodeId               string   `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadRunLogsRequest) Reset()         { *m = ReadRunLogsRequest{} }
+func (m *ReadRunLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadRunLogsRequest) ProtoMessage()    {}
+func (*ReadRunLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{47}
+}
+
+func (m *ReadRunLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadRunLogsRequest.Unmarshal(m, b)
+}
+func (m *ReadRunLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadRunLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadRunLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRunLogsRequest.Merge(m, src)
+}
+func (m *ReadRunLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadRunLogsRequest.Size(m)
+}
+func (m *ReadRunLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRunLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadRunLogsRequest proto.InternalMessageInfo
+
+func (m *ReadRunLogsRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ReadRunLogsRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type ReadRunLogsResponse struct {
+	// The logs of the main container of the step.
+	Logs []byte `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
+	// Whether the logs were read from the archive rather than from the pod.
+	Archived             bool     `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadRunLogsResponse) Reset()         { *m = ReadRunLogsResponse{} }
+func (m *ReadRunLogsResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadRunLogsResponse) ProtoMessage()    {}
+func (*ReadRunLogsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{48}
+}
+
+func (m *ReadRunLogsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadRunLogsResponse.Unmarshal(m, b)
+}
+func (m *ReadRunLogsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadRunLogsResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadRunLogsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRunLogsResponse.Merge(m, src)
+}
+func (m *ReadRunLogsResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadRunLogsResponse.Size(m)
+}
+func (m *ReadRunLogsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRunLogsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadRunLogsResponse proto.InternalMessageInfo
+
+func (m *ReadRunLogsResponse) GetLogs() []byte {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *ReadRunLogsResponse) GetArchived() bool {
+	if m != nil {
+		return m.Archived
+	}
+	return false
+}
+
+type ReportRunLogsRequest struct {
+	// The ID of the run.
+	RunId string `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// The ID of the node of the step.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// The logs of the main container of the step.
+	Logs                 []byte   `protobuf:"bytes,3,opt,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportRunLogsRequest) Reset()         { *m = ReportRunLogsRequest{} }
+func (m *ReportRunLogsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportRunLogsRequest) ProtoMessage()    {}
+func (*ReportRunLogsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{49}
+}
+
+func (m *ReportRunLogsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportRunLogsRequest.Unmarshal(m, b)
+}
+func (m *ReportRunLogsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportRunLogsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportRunLogsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportRunLogsRequest.Merge(m, src)
+}
+func (m *ReportRunLogsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportRunLogsRequest.Size(m)
+}
+func (m *ReportRunLogsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportRunLogsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportRunLogsRequest proto.InternalMessageInfo
+
+func (m *ReportRunLogsRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *ReportRunLogsRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ReportRunLogsRequest) GetLogs() []byte {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+type EstimateRunRequest struct {
+	Run                  *Run     `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EstimateRunRequest) Reset()         { *m = EstimateRunRequest{} }
+func (m *EstimateRunRequest) String() string { return proto.CompactTextString(m) }
+func (*EstimateRunRequest) ProtoMessage()    {}
+func (*EstimateRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{50}
+}
+
+func (m *EstimateRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateRunRequest.Unmarshal(m, b)
+}
+func (m *EstimateRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateRunRequest.Marshal(b, m, deterministic)
+}
+func (m *EstimateRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateRunRequest.Merge(m, src)
+}
+func (m *EstimateRunRequest) XXX_Size() int {
+	return xxx_messageInfo_EstimateRunRequest.Size(m)
+}
+func (m *EstimateRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateRunRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EstimateRunRequest proto.InternalMessageInfo
+
+func (m *EstimateRunRequest) GetRun() *Run {
+	if m != nil {
+		return m.Run
+	}
+	return nil
+}
+
+type RunEstimate struct {
+	// The resources requested by the steps of the run, summed over the steps.
+	Cpu       float64 `protobuf:"fixed64,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	MemoryGib float64 `protobuf:"fixed64,2,opt,name=memory_gib,json=memoryGib,proto3" json:"memory_gib,omitempty"`
+	Gpu       float64 `protobuf:"fixed64,3,opt,name=gpu,proto3" json:"gpu,omitempty"`
+	// The duration of the run, averaged over the past runs of its pipeline. Zero
+	// if the pipeline has no past run.
+	DurationSeconds int64 `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// The resources requested by the steps of the run over their durations in the
+	// past runs of its pipeline. Zero if the pipeline has no past run.
+	CpuHours       float64 `protobuf:"fixed64,5,opt,name=cpu_hours,json=cpuHours,proto3" json:"cpu_hours,omitempty"`
+	MemoryGibHours float64 `protobuf:"fixed64,6,opt,name=memory_gib_hours,json=memoryGibHours,proto3" json:"memory_gib_hours,omitempty"`
+	GpuHours       float64 `protobuf:"fixed64,7,opt,name=gpu_hours,json=gpuHours,proto3" json:"gpu_hours,omitempty"`
+	// The cost of the resources at the prices configured in the API server.
+	Cost     float64 `protobuf:"fixed64,8,opt,name=cost,proto3" json:"cost,omitempty"`
+	Currency string  `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	// The number of past runs of the pipeline the estimate is based on.
+	PastRuns int32 `protobuf:"varint,10,opt,name=past_runs,json=pastRuns,proto3" json:"past_runs,omitempty"`
+	// Why the estimate may be off, e.g. the steps which request no resources.
+	Warnings             []string `protobuf:"bytes,11,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunEstimate) Reset()         { *m = RunEstimate{} }
+func (m *RunEstimate) String() string { return proto.CompactTextString(m) }
+func (*RunEstimate) ProtoMessage()    {}
+func (*RunEstimate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e3419bc3417bf873, []int{51}
+}
+
+func (m *RunEstimate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunEstimate.Unmarshal(m, b)
+}
+func (m *RunEstimate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunEstimate.Marshal(b, m, deterministic)
+}
+func (m *RunEstimate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunEstimate.Merge(m, src)
+}
+func (m *RunEstimate) XXX_Size() int {
+	return xxx_messageInfo_RunEstimate.Size(m)
+}
+func (m *RunEstimate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunEstimate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunEstimate proto.InternalMessageInfo
+
+func (m *RunEstimate) GetCpu() float64 {
+	if m != nil {
+		return m.Cpu
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetMemoryGib() float64 {
+	if m != nil {
+		return m.MemoryGib
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetGpu() float64 {
+	if m != nil {
+		return m.Gpu
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetDurationSeconds() int64 {
+	if m != nil {
+		return m.DurationSeconds
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetCpuHours() float64 {
+	if m != nil {
+		return m.CpuHours
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetMemoryGibHours() float64 {
+	if m != nil {
+		return m.MemoryGibHours
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetGpuHours() float64 {
+	if m != nil {
+		return m.GpuHours
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetCost() float64 {
+	if m != nil {
+		return m.Cost
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *RunEstimate) GetPastRuns() int32 {
+	if m != nil {
+		return m.PastRuns
+	}
+	return 0
+}
+
+func (m *RunEstimate) GetWarnings() []string {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.TerminateResult_Status", TerminateResult_Status_name, TerminateResult_Status_value)
+	proto.RegisterEnum("api.ListRunsRequest_View", ListRunsRequest_View_name, ListRunsRequest_View_value)
+	proto.RegisterEnum("api.DeploymentStatus_State", DeploymentStatus_State_name, DeploymentStatus_State_value)
+	proto.RegisterEnum("api.ApprovalGate_State", ApprovalGate_State_name, ApprovalGate_State_value)
+	proto.RegisterEnum("api.RunMetric_Format", RunMetric_Format_name, RunMetric_Format_value)
+	proto.RegisterEnum("api.ReportRunMetricsResponse_ReportRunMetricResult_Status", ReportRunMetricsResponse_ReportRunMetricResult_Status_name, ReportRunMetricsResponse_ReportRunMetricResult_Status_value)
+	proto.RegisterType((*CreateRunSweepRequest)(nil), "api.CreateRunSweepRequest")
+	proto.RegisterType((*GetRunSweepRequest)(nil), "api.GetRunSweepRequest")
+	proto.RegisterType((*SweepParameter)(nil), "api.SweepParameter")
+	proto.RegisterType((*SweepParameter_Range)(nil), "api.SweepParameter.Range")
+	proto.RegisterType((*SweepTrial)(nil), "api.SweepTrial")
+	proto.RegisterType((*RunSweep)(nil), "api.RunSweep")
+	proto.RegisterType((*CreateRunGroupRequest)(nil), "api.CreateRunGroupRequest")
+	proto.RegisterType((*GetRunGroupRequest)(nil), "api.GetRunGroupRequest")
+	proto.RegisterType((*ListRunGroupsRequest)(nil), "api.ListRunGroupsRequest")
+	proto.RegisterType((*ListRunGroupsResponse)(nil), "api.ListRunGroupsResponse")
+	proto.RegisterType((*AddRunsToRunGroupRequest)(nil), "api.AddRunsToRunGroupRequest")
+	proto.RegisterType((*CancelRunGroupRequest)(nil), "api.CancelRunGroupRequest")
+	proto.RegisterType((*TerminateAllRequest)(nil), "api.TerminateAllRequest")
+	proto.RegisterType((*GetTerminateAllOperationRequest)(nil), "api.GetTerminateAllOperationRequest")
+	proto.RegisterType((*TerminateAllOperation)(nil), "api.TerminateAllOperation")
+	proto.RegisterType((*TerminateResult)(nil), "api.TerminateResult")
+	proto.RegisterType((*RunGroup)(nil), "api.RunGroup")
+	proto.RegisterType((*CreateRunRequest)(nil), "api.CreateRunRequest")
+	proto.RegisterType((*GetRunRequest)(nil), "api.GetRunRequest")
+	proto.RegisterType((*UpdateRunRequest)(nil), "api.UpdateRunRequest")
+	proto.RegisterMapType((map[string]string)(nil), "api.UpdateRunRequest.AnnotationsEntry")
+	proto.RegisterType((*WatchRunRequest)(nil), "api.WatchRunRequest")
+	proto.RegisterType((*ListRunsRequest)(nil), "api.ListRunsRequest")
+	proto.RegisterType((*StarRunRequest)(nil), "api.StarRunRequest")
+	proto.RegisterType((*UnstarRunRequest)(nil), "api.UnstarRunRequest")
+	proto.RegisterType((*ApproveRunRequest)(nil), "api.ApproveRunRequest")
+	proto.RegisterType((*RejectRunRequest)(nil), "api.RejectRunRequest")
+	proto.RegisterType((*ListRunsResponse)(nil), "api.ListRunsResponse")
+	proto.RegisterType((*Run)(nil), "api.Run")
+	proto.RegisterMapType((map[string]string)(nil), "api.Run.AnnotationsEntry")
+	proto.RegisterType((*PartialExecution)(nil), "api.PartialExecution")
+	proto.RegisterType((*DeploymentStatus)(nil), "api.DeploymentStatus")
+	proto.RegisterType((*PipelineRuntime)(nil), "api.PipelineRuntime")
+	proto.RegisterType((*RunDetail)(nil), "api.RunDetail")
+	proto.RegisterType((*ApprovalGate)(nil), "api.ApprovalGate")
+	proto.RegisterType((*ExitHandler)(nil), "api.ExitHandler")
+	proto.RegisterType((*StepAttempts)(nil), "api.StepAttempts")
+	proto.RegisterType((*StepAttempts_Attempt)(nil), "api.StepAttempts.Attempt")
+	proto.RegisterType((*RunMetric)(nil), "api.RunMetric")
+	proto.RegisterType((*ReportRunMetricsRequest)(nil), "api.ReportRunMetricsRequest")
+	proto.RegisterType((*ReportRunMetricsResponse)(nil), "api.ReportRunMetricsResponse")
+	proto.RegisterType((*ReportRunMetricsResponse_ReportRunMetricResult)(nil), "api.ReportRunMetricsResponse.ReportRunMetricResult")
+	proto.RegisterType((*ReadArtifactRequest)(nil), "api.ReadArtifactRequest")
+	proto.RegisterType((*ReadArtifactResponse)(nil), "api.ReadArtifactResponse")
+	proto.RegisterType((*GetRunOutputsRequest)(nil), "api.GetRunOutputsRequest")
+	proto.RegisterType((*RunOutput)(nil), "api.RunOutput")
+	proto.RegisterType((*GetRunOutputsResponse)(nil), "api.GetRunOutputsResponse")
+	proto.RegisterType((*GetRunHistoryRequest)(nil), "api.GetRunHistoryRequest")
+	proto.RegisterType((*RunStateTransition)(nil), "api.RunStateTransition")
+	proto.RegisterType((*GetRunHistoryResponse)(nil), "api.GetRunHistoryResponse")
+	proto.RegisterType((*GetRunManifestRequest)(nil), "api.GetRunManifestRequest")
+	proto.RegisterType((*RunManifest)(nil), "api.RunManifest")
+	proto.RegisterType((*ReadRunLogsRequest)(nil), "api.ReadRunLogsRequest")
+	proto.RegisterType((*ReadRunLogsResponse)(nil), "api.ReadRunLogsResponse")
+	proto.RegisterType((*ReportRunLogsRequest)(nil), "api.ReportRunLogsRequest")
+	proto.RegisterType((*EstimateRunRequest)(nil), "api.EstimateRunRequest")
+	proto.RegisterType((*RunEstimate)(nil), "api.RunEstimate")
+}
+
+func init() { proto.RegisterFile("run.proto", fileDescriptor_e3419bc3417bf873) }
+
+var fileDescriptor_e3419bc3417bf873 = []byte{
+	// 3770 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0xdb, 0x6e, 0x1b, 0x49,
+	0x76, 0xe6, 0x9d, 0x3c, 0xa4, 0x28, 0xaa, 0x24, 0xd9, 0x34, 0xe5, 0x59, 0xc9, 0x6d, 0x8f, 0x2f,
+	0xb3, 0x2b, 0x6a, 0xc6, 0xce, 0xee, 0xec, 0x68, 0x67, 0xb2, 0xa0, 0x25, 0x5a, 0xe6, 0x8e, 0x2c,
+	0x2b, 0x25, 0x7a, 0x66, 0x33, 0xc9, 0xa2, 0xb7, 0x45, 0x96, 0xa8, 0x5e, 0x93, 0xdd, 0x9d, 0xee,
+	0x6a, 0x4b, 0x9a, 0xc1, 0x22, 0x40, 0x82, 0xcd, 0x07, 0x24, 0x40, 0xf2, 0xe6, 0x0f, 0x48, 0xf2,
+	0x14, 0xe4, 0x71, 0x81, 0xbc, 0x26, 0x79, 0x0d, 0xf2, 0x07, 0x41, 0x90, 0x1f, 0xc8, 0x43, 0x80,
+	0x3c, 0x04, 0x75, 0x65, 0x77, 0xf3, 0x22, 0xd9, 0xce, 0x3e, 0x91, 0x75, 0xea, 0xf4, 0x39, 0xa7,
+	0xea, 0xdc, 0xab, 0x0a, 0x4a, 0x7e, 0xe8, 0x34, 0x3d, 0xdf, 0xa5, 0x2e, 0xca, 0x58, 0x9e, 0xdd,
+	0x28, 0x13, 0xdf, 0x77, 0x7d, 0x01, 0x69, 0x54, 0x4e, 0xec, 0x21, 0x25, 0x6a, 0xb4, 0x36, 0x70,
+	0xdd, 0xc1, 0x90, 0x6c, 0xf1, 0xd1, 0x71, 0x78, 0xb2, 0x45, 0x46, 0x1e, 0xbd, 0x90, 0x93, 0xb7,
+	0xe4, 0xa4, 0xe5, 0xd9, 0x5b, 0x96, 0xe3, 0xb8, 0xd4, 0xa2, 0xb6, 0xeb, 0x04, 0x72, 0x76, 0x3d,
+	0xf9, 0x29, 0xb5, 0x47, 0x24, 0xa0, 0xd6, 0xc8, 0x93, 0x08, 0x8b, 0x9e, 0xe5, 0x5b, 0x23, 0x32,
+	0x66, 0xb6, 0xec, 0xd9, 0x1e, 0x19, 0xda, 0x0e, 0x31, 0x03, 0x8f, 0xf4, 0x24, 0xb0, 0xee, 0x93,
+	0xc0, 0x0d, 0xfd, 0x1e, 0x31, 0x7d, 0x72, 0x42, 0x7c, 0xe2, 0xf4, 0x88, 0x9c, 0xf9, 0x01, 0xff,
+	0xe9, 0x6d, 0x0e, 0x88, 0xb3, 0x19, 0x9c, 0x59, 0x83, 0x01, 0xf1, 0xb7, 0x5c, 0x8f, 0x8b, 0x30,
+	0x29, 0x8e, 0xf1, 0x39, 0xac, 0xee, 0xf8, 0xc4, 0xa2, 0x04, 0x87, 0xce, 0xd1, 0x19, 0x21, 0x1e,
+	0x26, 0x7f, 0x12, 0x92, 0x80, 0xa2, 0x3b, 0x90, 0x0b, 0xd8, 0xb8, 0x9e, 0xda, 0x48, 0x3d, 0x28,
+	0x3f, 0x5a, 0x68, 0x5a, 0x9e, 0xdd, 0xd4, 0x48, 0x62, 0xce, 0xb8, 0x0b, 0x68, 0x8f, 0xd0, 0xe4,
+	0xa7, 0x55, 0x48, 0xdb, 0x7d, 0xfe, 0x5d, 0x09, 0xa7, 0xed, 0xbe, 0xf1, 0x8f, 0x29, 0xa8, 0x72,
+	0x84, 0x43, 0xb5, 0x32, 0x84, 0x20, 0xeb, 0x58, 0x23, 0x22, 0x91, 0xf8, 0x7f, 0x74, 0x1d, 0xf2,
+	0xaf, 0xad, 0x61, 0x48, 0x82, 0x7a, 0x7a, 0x23, 0xf3, 0xa0, 0x84, 0xe5, 0x08, 0x6d, 0x41, 0xce,
+	0xb7, 0x9c, 0x01, 0xa9, 0x67, 0xb8, 0x24, 0x37, 0xb9, 0x24, 0x71, 0x7a, 0x4d, 0xcc, 0x10, 0xb0,
+	0xc0, 0x6b, 0xb4, 0x21, 0xc7, 0xc7, 0x68, 0x05, 0x72, 0x01, 0xb5, 0x7c, 0xca, 0xd9, 0xa4, 0xb0,
+	0x18, 0x30, 0xde, 0x01, 0x75, 0xbd, 0x7a, 0x9a, 0x03, 0xf9, 0x7f, 0x01, 0x23, 0x5e, 0x3d, 0xa3,
+	0x60, 0xc4, 0x33, 0xfe, 0x2e, 0x05, 0xc0, 0xd9, 0x74, 0x7d, 0xdb, 0x1a, 0x32, 0x62, 0xb6, 0xd3,
+	0x27, 0xe7, 0x9c, 0x58, 0x0e, 0x8b, 0x01, 0x6a, 0x02, 0x68, 0x7d, 0x09, 0xc1, 0xcb, 0x8f, 0xaa,
+	0x5c, 0x42, 0x2d, 0x1c, 0x8e, 0x60, 0xa0, 0x55, 0xc8, 0xfb, 0xa1, 0x63, 0xda, 0x7d, 0xce, 0xaa,
+	0x84, 0x73, 0x7e, 0xe8, 0x74, 0xfa, 0x6c, 0xed, 0x01, 0xb5, 0x68, 0x18, 0xd4, 0xb3, 0x1c, 0x2c,
+	0x47, 0xe8, 0x01, 0x14, 0x46, 0x84, 0xfa, 0x76, 0x2f, 0xa8, 0xe7, 0x22, 0xb4, 0x71, 0xe8, 0x3c,
+	0xe7, 0x60, 0xac, 0xa6, 0x8d, 0xdf, 0x66, 0xa0, 0xa8, 0x14, 0x91, 0xd4, 0x80, 0xde, 0xee, 0x74,
+	0x64, 0xbb, 0x1b, 0x90, 0xf1, 0x43, 0x47, 0x6e, 0x6a, 0x51, 0x91, 0xc5, 0x0c, 0x88, 0x1e, 0xc7,
+	0x56, 0x95, 0xe5, 0x9c, 0x97, 0xa7, 0xec, 0x7b, 0x6c, 0x69, 0xf7, 0x61, 0x71, 0x64, 0x9d, 0x9b,
+	0x3d, 0xd7, 0xe9, 0x85, 0x3e, 0xb3, 0xc8, 0x8b, 0x7a, 0x8e, 0x6f, 0x55, 0x75, 0x64, 0x9d, 0xef,
+	0x8c, 0xa1, 0xe8, 0x33, 0x80, 0x1e, 0xb7, 0xb9, 0xbe, 0x69, 0xd1, 0x7a, 0x9e, 0x0b, 0xd0, 0x68,
+	0x0a, 0xbf, 0x68, 0x2a, 0xbf, 0x68, 0x76, 0x95, 0x5f, 0xe0, 0x92, 0xc4, 0x6e, 0x51, 0x74, 0x1f,
+	0xf2, 0x94, 0x69, 0x23, 0xa8, 0x17, 0xb8, 0x50, 0x8b, 0x63, 0xa1, 0xb8, 0x96, 0xb0, 0x9c, 0x46,
+	0xb7, 0xa1, 0xe2, 0x11, 0xa7, 0x6f, 0x3b, 0x03, 0xd3, 0x0f, 0x9d, 0xa0, 0x5e, 0xe4, 0x92, 0x94,
+	0x25, 0x0c, 0x87, 0x0e, 0x47, 0xf1, 0x43, 0xc7, 0xd1, 0x28, 0x25, 0x81, 0x22, 0x61, 0x1c, 0xe5,
+	0x43, 0xa8, 0x06, 0x61, 0xaf, 0x47, 0x48, 0x9f, 0xf4, 0x05, 0x12, 0x70, 0xa4, 0x05, 0x0d, 0xe5,
+	0x68, 0xeb, 0x50, 0x3e, 0xb1, 0xec, 0xa1, 0xc2, 0x29, 0x73, 0x1c, 0x10, 0x20, 0x8e, 0xb0, 0xc1,
+	0x59, 0x99, 0x03, 0xdf, 0x0d, 0x3d, 0xa6, 0xfb, 0x0a, 0xd7, 0x03, 0xf8, 0xa1, 0xb3, 0xc7, 0x40,
+	0x9d, 0x7e, 0xcc, 0x0f, 0x39, 0x2c, 0xe2, 0x87, 0xfc, 0xb3, 0xa4, 0x1f, 0x0a, 0x24, 0x31, 0x37,
+	0xf6, 0xc3, 0xd8, 0xa7, 0x49, 0x3f, 0x7c, 0x05, 0x2b, 0xfb, 0x76, 0xa0, 0xd1, 0x02, 0x85, 0xf7,
+	0x01, 0xd3, 0xf6, 0x80, 0x98, 0xd4, 0x7d, 0x45, 0x1c, 0x89, 0x5f, 0x62, 0x90, 0x2e, 0x03, 0xa0,
+	0x35, 0xe0, 0x03, 0x33, 0xb0, 0xbf, 0x15, 0x16, 0x94, 0xc3, 0x45, 0x06, 0x38, 0xb2, 0xbf, 0x25,
+	0xe8, 0x06, 0x14, 0x02, 0xd7, 0xa7, 0xe6, 0xf1, 0x85, 0x34, 0xe8, 0x3c, 0x1b, 0x3e, 0xb9, 0x30,
+	0x4e, 0x60, 0x35, 0xc1, 0x2c, 0xf0, 0x5c, 0x27, 0x20, 0xe8, 0x43, 0xc8, 0x73, 0xa1, 0x83, 0x7a,
+	0x6a, 0x23, 0x33, 0xb9, 0x22, 0x39, 0x89, 0xee, 0xc1, 0xa2, 0x43, 0xce, 0xa9, 0x19, 0x91, 0x4c,
+	0x58, 0xef, 0x02, 0x03, 0x1f, 0x2a, 0xe9, 0x8c, 0x1d, 0xa8, 0xb7, 0xfa, 0x7c, 0x97, 0xbb, 0xee,
+	0x25, 0x1b, 0xc0, 0x84, 0x15, 0xce, 0xa7, 0x43, 0x0c, 0xf7, 0xbe, 0xc0, 0xb8, 0x0f, 0xab, 0x3b,
+	0x96, 0xd3, 0x23, 0xc3, 0xcb, 0xb6, 0xf0, 0x8f, 0x61, 0xb9, 0x4b, 0xfc, 0x91, 0xed, 0x58, 0x94,
+	0xb4, 0x86, 0xc3, 0xb1, 0x92, 0x16, 0xc8, 0xb9, 0x47, 0x7c, 0x7b, 0x44, 0x1c, 0x6a, 0xea, 0x2f,
+	0x2a, 0x63, 0x60, 0xa7, 0x3f, 0x61, 0x04, 0xe9, 0x09, 0x23, 0xf8, 0x04, 0xd6, 0xf7, 0x08, 0x8d,
+	0x32, 0x78, 0xe1, 0x11, 0x9f, 0xc7, 0xeb, 0x59, 0x02, 0xfd, 0x75, 0x1a, 0x56, 0xa7, 0x7e, 0x30,
+	0xb1, 0xf8, 0x09, 0x19, 0xd3, 0x57, 0x90, 0x31, 0x93, 0x94, 0x31, 0xe1, 0xbc, 0xd9, 0xb7, 0x71,
+	0xde, 0x9f, 0x40, 0xf9, 0xc4, 0x76, 0xec, 0xe0, 0x54, 0x7c, 0x9b, 0xbb, 0xf4, 0x5b, 0x50, 0xe8,
+	0x2d, 0x8a, 0x9a, 0x50, 0xf0, 0x49, 0x10, 0x0e, 0x69, 0x50, 0xcf, 0x73, 0xbb, 0x59, 0xe1, 0x76,
+	0xa3, 0xd7, 0x8e, 0xf9, 0x24, 0x56, 0x48, 0xc6, 0x6f, 0x53, 0xb0, 0x98, 0x98, 0x8c, 0x04, 0xdf,
+	0x54, 0x34, 0xf8, 0x3e, 0xd6, 0xc1, 0x97, 0x6d, 0x49, 0xf5, 0xd1, 0xda, 0x34, 0xca, 0xcd, 0x23,
+	0x8e, 0xa2, 0x23, 0xf3, 0x0a, 0xe4, 0x78, 0x7d, 0xa0, 0xe2, 0x38, 0x1f, 0x18, 0x7b, 0x90, 0x17,
+	0x78, 0xa8, 0x0c, 0x85, 0xc3, 0xf6, 0xc1, 0x6e, 0xe7, 0x60, 0xaf, 0x76, 0x0d, 0x55, 0x01, 0xba,
+	0x6d, 0xfc, 0xbc, 0x73, 0xd0, 0xea, 0xb6, 0x77, 0x6b, 0x29, 0xb4, 0x02, 0xb5, 0xd6, 0x3e, 0x6e,
+	0xb7, 0x76, 0xff, 0xd0, 0x7c, 0xda, 0x39, 0xe8, 0x1c, 0x3d, 0x6b, 0xef, 0xd6, 0xd2, 0x08, 0x20,
+	0xff, 0xb4, 0xd5, 0xd9, 0x6f, 0xef, 0xd6, 0x32, 0xc6, 0x7f, 0xa6, 0x79, 0x38, 0xe7, 0xbb, 0x7e,
+	0xa5, 0x70, 0xbe, 0x01, 0xe5, 0x3e, 0x09, 0x7a, 0xbe, 0xcd, 0x53, 0xbd, 0x94, 0x2a, 0x0a, 0x8a,
+	0x5a, 0x7f, 0x36, 0x6a, 0xfd, 0x09, 0x95, 0xe6, 0xde, 0x46, 0xa5, 0x5f, 0x40, 0xa5, 0xc7, 0x1d,
+	0x67, 0x78, 0xd5, 0x60, 0x5e, 0xd6, 0xf8, 0x2d, 0x1a, 0x49, 0x7b, 0x85, 0x58, 0xda, 0x4b, 0x86,
+	0xe6, 0xe2, 0x55, 0x42, 0x73, 0xe9, 0x0a, 0xa1, 0x19, 0x92, 0xa1, 0xd9, 0x68, 0x42, 0x4d, 0x07,
+	0x5e, 0xe5, 0x64, 0x32, 0x35, 0xa6, 0xa6, 0xa4, 0x46, 0xe3, 0x1e, 0x2c, 0x88, 0x50, 0xab, 0x90,
+	0xa7, 0x1b, 0x95, 0xf1, 0x5f, 0x29, 0xa8, 0xbd, 0xf4, 0xfa, 0x71, 0xc2, 0x33, 0x0c, 0x70, 0x1d,
+	0xca, 0x21, 0x47, 0x35, 0x1d, 0x97, 0x0a, 0xb5, 0x16, 0x31, 0x08, 0xd0, 0x81, 0x4b, 0x09, 0x57,
+	0x38, 0x9b, 0xc9, 0x48, 0x85, 0x33, 0xd8, 0x33, 0x28, 0x47, 0xca, 0x39, 0x99, 0xa4, 0xef, 0x71,
+	0x61, 0x93, 0x7c, 0x9b, 0xad, 0x31, 0x62, 0xdb, 0xa1, 0xfe, 0x05, 0x8e, 0x7e, 0xda, 0xf8, 0x7d,
+	0xa8, 0x25, 0x11, 0x50, 0x0d, 0x32, 0xaf, 0xc8, 0x85, 0x14, 0x93, 0xfd, 0x65, 0x06, 0xcf, 0x0b,
+	0x32, 0x69, 0x75, 0x62, 0xb0, 0x9d, 0xfe, 0x71, 0xca, 0x78, 0x00, 0x8b, 0x5f, 0x5b, 0xb4, 0x77,
+	0x7a, 0xf9, 0xa6, 0xfc, 0x6f, 0x1a, 0x16, 0x65, 0x56, 0xf8, 0x9d, 0x66, 0x1f, 0xf4, 0x14, 0xae,
+	0x4f, 0x16, 0xc8, 0x26, 0x5b, 0x91, 0x88, 0x58, 0x35, 0xa1, 0x54, 0x89, 0xf2, 0x25, 0xb9, 0xc0,
+	0x2b, 0x0a, 0x1f, 0x2b, 0xf4, 0x2f, 0xc9, 0x05, 0xda, 0x84, 0xec, 0x6b, 0x9b, 0x9c, 0x71, 0xa7,
+	0xa8, 0xca, 0xd2, 0x33, 0xb1, 0x80, 0xe6, 0x57, 0x36, 0x39, 0xc3, 0x1c, 0x0d, 0x7d, 0x1f, 0x96,
+	0xc6, 0x1b, 0x6b, 0x8a, 0x96, 0x81, 0xfb, 0x44, 0x09, 0xd7, 0xc6, 0x13, 0x4f, 0x39, 0x9c, 0x19,
+	0xb9, 0xeb, 0x0c, 0x2f, 0x4c, 0x56, 0x95, 0xfa, 0xa4, 0xcf, 0x5d, 0xa0, 0x88, 0xcb, 0x0c, 0x76,
+	0x24, 0x40, 0xe8, 0x63, 0x28, 0x33, 0xe7, 0x56, 0x94, 0x8a, 0x1b, 0x29, 0x5d, 0xf3, 0x1c, 0x58,
+	0x23, 0x22, 0x08, 0x61, 0x70, 0xf4, 0x7f, 0x63, 0x0d, 0xb2, 0x4c, 0x1e, 0x54, 0x82, 0xdc, 0x93,
+	0xd6, 0x51, 0x67, 0xa7, 0x76, 0x0d, 0x15, 0x21, 0xfb, 0xf4, 0xe5, 0xfe, 0x7e, 0x2d, 0x65, 0xdc,
+	0x87, 0x2a, 0xa3, 0x7c, 0xb9, 0x9e, 0x1e, 0x42, 0xed, 0xa5, 0x13, 0x5c, 0x09, 0xf5, 0x17, 0xb0,
+	0xd4, 0xf2, 0x3c, 0xdf, 0x7d, 0x7d, 0x05, 0x3b, 0xbf, 0x01, 0x05, 0xc7, 0xed, 0x93, 0x71, 0xf2,
+	0xc9, 0xb3, 0x61, 0xa7, 0x8f, 0xea, 0x50, 0xe8, 0xb9, 0x23, 0x96, 0x83, 0xa4, 0x1e, 0xd5, 0xd0,
+	0xf8, 0x06, 0x6a, 0x98, 0xfc, 0x8a, 0xf4, 0xe8, 0x7b, 0x50, 0xbf, 0x0e, 0x79, 0x9f, 0x58, 0x81,
+	0x8e, 0x8a, 0x72, 0x64, 0xfc, 0x1c, 0x6a, 0x63, 0x5d, 0xca, 0xea, 0xe4, 0x16, 0x64, 0x79, 0xa0,
+	0x10, 0xb5, 0xc9, 0xd8, 0xf7, 0x39, 0xf4, 0xca, 0x45, 0xc9, 0x9b, 0x3c, 0x64, 0x70, 0xe8, 0xfc,
+	0x3f, 0x05, 0xee, 0x1f, 0xc1, 0x42, 0xac, 0x05, 0x94, 0x36, 0xbc, 0x24, 0xda, 0x0c, 0x39, 0x73,
+	0xe4, 0x91, 0x1e, 0xae, 0x78, 0x91, 0x11, 0xda, 0x83, 0xe5, 0x49, 0x27, 0x50, 0x8d, 0xc4, 0xf5,
+	0x98, 0x07, 0x68, 0xa3, 0xc7, 0x68, 0xc2, 0x0f, 0x82, 0xf7, 0x29, 0xd8, 0xbf, 0x80, 0x4a, 0xd0,
+	0x3b, 0x25, 0xfd, 0x50, 0x26, 0x88, 0xc2, 0xe5, 0x09, 0x42, 0xe3, 0xc7, 0x12, 0x44, 0x31, 0x96,
+	0x20, 0x74, 0xf6, 0xad, 0x44, 0xb2, 0x6f, 0xb4, 0x5b, 0x2a, 0xcd, 0xed, 0x96, 0xd0, 0x2d, 0x28,
+	0xb1, 0xcd, 0x0f, 0x3c, 0xab, 0x47, 0xea, 0x55, 0x11, 0x73, 0x34, 0x00, 0x7d, 0xca, 0x54, 0xe2,
+	0x0d, 0xdd, 0x0b, 0x66, 0x82, 0x41, 0x7d, 0x81, 0xd3, 0x5a, 0xe5, 0xb4, 0x76, 0x35, 0x5c, 0xd6,
+	0x03, 0x51, 0x4c, 0x1d, 0xa7, 0x17, 0x23, 0x71, 0xfa, 0x27, 0xf1, 0x38, 0x5d, 0xe3, 0xc4, 0x6e,
+	0x2a, 0xc1, 0xe6, 0x87, 0x66, 0xd6, 0x53, 0x05, 0xc4, 0x7f, 0x6d, 0xf7, 0x88, 0x69, 0xf5, 0x7a,
+	0x6e, 0xe8, 0xd0, 0xfa, 0x12, 0xa7, 0x5d, 0x95, 0xe0, 0x96, 0x80, 0xa2, 0x27, 0xb0, 0xe4, 0x59,
+	0x3e, 0xb5, 0xad, 0xa1, 0x49, 0xce, 0x49, 0x2f, 0xe4, 0xb6, 0x84, 0x36, 0x52, 0x5a, 0xf0, 0x43,
+	0x31, 0xdb, 0x56, 0x93, 0xb8, 0xe6, 0x25, 0x20, 0xe8, 0x21, 0xd4, 0xf4, 0xb7, 0x26, 0xb5, 0xfc,
+	0x01, 0xa1, 0xf5, 0x65, 0xce, 0x6d, 0x51, 0xc3, 0xbb, 0x1c, 0xfc, 0xde, 0x29, 0xe3, 0x19, 0xd4,
+	0x92, 0x02, 0xa1, 0xef, 0x01, 0x10, 0x46, 0xc8, 0x73, 0x6d, 0x87, 0x4a, 0x32, 0x11, 0x88, 0xe8,
+	0xe6, 0x89, 0xa7, 0x6a, 0x77, 0x31, 0x30, 0xde, 0xa4, 0xa1, 0x96, 0x54, 0x0a, 0xd3, 0xc3, 0x2b,
+	0xdb, 0x51, 0x9e, 0xc7, 0xff, 0xc7, 0x55, 0x9e, 0x4e, 0xaa, 0x5c, 0x79, 0x66, 0x26, 0xe2, 0x99,
+	0x9f, 0x30, 0x86, 0x16, 0x25, 0xf5, 0x6c, 0xa4, 0x2c, 0x4c, 0xf2, 0xe2, 0x75, 0x21, 0xc1, 0x02,
+	0x93, 0x05, 0xb2, 0x11, 0x09, 0x02, 0x6b, 0x40, 0x78, 0xca, 0x28, 0x61, 0x35, 0x64, 0x3e, 0x24,
+	0x12, 0xfa, 0x55, 0x7d, 0x48, 0x62, 0xb7, 0xa8, 0xf1, 0x39, 0xe4, 0x38, 0x13, 0xb4, 0x08, 0xe5,
+	0x97, 0x07, 0x47, 0x87, 0xed, 0x9d, 0xce, 0xd3, 0x4e, 0x7b, 0xb7, 0x76, 0x2d, 0x5a, 0x64, 0xa6,
+	0x58, 0xc8, 0xe7, 0x25, 0x65, 0xa2, 0x92, 0x7c, 0x05, 0x8b, 0x2a, 0x46, 0xe0, 0xd0, 0x61, 0xa7,
+	0x4d, 0x2c, 0x4d, 0xe9, 0x80, 0x32, 0xb2, 0x1c, 0xfb, 0x84, 0x04, 0x94, 0x97, 0x46, 0x25, 0x5c,
+	0x53, 0x13, 0xcf, 0x25, 0x9c, 0x21, 0x9f, 0xb9, 0xfe, 0xab, 0x93, 0xa1, 0x7b, 0x36, 0x46, 0x2e,
+	0x0b, 0x64, 0x35, 0xa1, 0x90, 0x8d, 0x7f, 0x4d, 0x43, 0x09, 0x87, 0xce, 0x2e, 0xa1, 0x96, 0x3d,
+	0x9c, 0x57, 0x47, 0xa1, 0x9f, 0x82, 0x66, 0x65, 0xfa, 0x42, 0x2e, 0xae, 0x15, 0x55, 0xd8, 0x27,
+	0x64, 0xc6, 0x8b, 0x5e, 0x62, 0x11, 0x3f, 0x82, 0x05, 0x66, 0x01, 0xa6, 0x45, 0x29, 0x3b, 0x7d,
+	0x0b, 0xea, 0x99, 0x8d, 0x8c, 0x8e, 0x8a, 0x47, 0x94, 0x78, 0x2d, 0x39, 0x81, 0x2b, 0x41, 0x64,
+	0xc4, 0xea, 0x8d, 0x91, 0x65, 0x3b, 0xa6, 0x77, 0x6a, 0x05, 0x44, 0x1e, 0xb7, 0x94, 0x18, 0xe4,
+	0x90, 0x01, 0xd0, 0x63, 0xa8, 0x90, 0x73, 0x9b, 0x9a, 0xa7, 0x96, 0xd3, 0x1f, 0x12, 0xbf, 0x9e,
+	0x8b, 0xd4, 0x0b, 0xed, 0x73, 0x9b, 0x3e, 0x13, 0x70, 0x5c, 0x26, 0xe3, 0x01, 0x6a, 0x40, 0xf1,
+	0xcc, 0xf2, 0x59, 0x6d, 0x2a, 0xba, 0x93, 0x12, 0xd6, 0x63, 0xf4, 0x63, 0xa8, 0x5a, 0x3c, 0x41,
+	0x5a, 0x43, 0x73, 0x60, 0x51, 0xa2, 0x8e, 0x2e, 0x84, 0xa0, 0x2d, 0x39, 0xb5, 0xc7, 0x8c, 0x68,
+	0xc1, 0x8a, 0x8c, 0x02, 0xe3, 0x7f, 0xd2, 0x50, 0x89, 0xce, 0x47, 0x33, 0x5c, 0x2a, 0x96, 0xe1,
+	0x6e, 0x43, 0xa5, 0x6f, 0x07, 0xde, 0xd0, 0xba, 0x30, 0x23, 0xf9, 0xa5, 0x2c, 0x61, 0xac, 0x54,
+	0x60, 0xed, 0x1f, 0xdb, 0x80, 0x21, 0xaf, 0x32, 0xc7, 0x96, 0x5e, 0x51, 0x40, 0x8e, 0xb4, 0x19,
+	0xb7, 0xf8, 0x1b, 0x13, 0x22, 0xc6, 0xad, 0xfd, 0x33, 0x00, 0x7e, 0xa4, 0x76, 0xe5, 0xc6, 0x41,
+	0x62, 0x8b, 0x5e, 0x90, 0x25, 0x9a, 0xe1, 0xeb, 0xab, 0xfa, 0x03, 0x28, 0xf4, 0x16, 0x8d, 0x7a,
+	0x59, 0x21, 0xe6, 0x65, 0xc6, 0x9e, 0x72, 0x95, 0x55, 0x58, 0x3a, 0xea, 0xb6, 0xba, 0x6d, 0x73,
+	0xc2, 0x61, 0xbe, 0x6e, 0x75, 0xba, 0xc2, 0x61, 0x2a, 0x50, 0x6c, 0x1d, 0x1e, 0xe2, 0x17, 0x5f,
+	0xf1, 0xee, 0xab, 0x02, 0x45, 0xdc, 0xfe, 0x59, 0x7b, 0xa7, 0xcb, 0xbd, 0xe6, 0xef, 0xd3, 0x50,
+	0x8e, 0xa8, 0x7b, 0xf6, 0xd6, 0x4f, 0xec, 0x6b, 0x7a, 0xca, 0xbe, 0xae, 0x40, 0x4e, 0x98, 0x9b,
+	0x6c, 0x16, 0xf9, 0x20, 0xb1, 0x7d, 0xd9, 0xb7, 0xdc, 0xbe, 0x77, 0x6f, 0xa5, 0x23, 0xdb, 0x97,
+	0x8f, 0x07, 0xa9, 0x5b, 0x50, 0xd2, 0xed, 0x93, 0xac, 0x47, 0xc7, 0x00, 0x74, 0x13, 0x8a, 0x72,
+	0x0f, 0x58, 0x3a, 0x66, 0x56, 0x5e, 0x10, 0x9b, 0x10, 0x18, 0xff, 0x9e, 0x81, 0x4a, 0xd4, 0xe7,
+	0x7e, 0xf7, 0xa6, 0xaa, 0xb7, 0x34, 0x1b, 0xdd, 0xd2, 0x75, 0x66, 0x56, 0xd4, 0xbf, 0x30, 0x87,
+	0xf6, 0xc8, 0xa6, 0xf2, 0xfc, 0x11, 0x38, 0x68, 0x9f, 0x41, 0xd0, 0x0f, 0xa1, 0xa8, 0x03, 0x46,
+	0x3e, 0x92, 0x8a, 0xa3, 0xc2, 0x37, 0xe5, 0x1f, 0xac, 0x51, 0x1b, 0xff, 0x9d, 0x82, 0x82, 0x84,
+	0xce, 0x5e, 0x9a, 0x16, 0x29, 0x3d, 0x5b, 0xcb, 0x99, 0xf7, 0xd0, 0x72, 0xf6, 0xad, 0xb4, 0xfc,
+	0x10, 0x6a, 0xfd, 0x50, 0x9c, 0x05, 0x99, 0x01, 0xe9, 0xb9, 0x4e, 0x3f, 0xe0, 0xfb, 0x91, 0xc1,
+	0x8b, 0x0a, 0x7e, 0x24, 0xc0, 0xb3, 0x0d, 0xc2, 0xf8, 0x97, 0x14, 0x94, 0x74, 0xf9, 0x34, 0xf5,
+	0xd4, 0x7e, 0x66, 0xd5, 0x7d, 0x07, 0x2a, 0x4e, 0x38, 0x3a, 0x26, 0xbe, 0x29, 0x6a, 0x00, 0xb6,
+	0xf2, 0xd4, 0xb3, 0x6b, 0xb8, 0x2c, 0xa0, 0x5f, 0x31, 0x20, 0xda, 0x84, 0xfc, 0x89, 0xeb, 0x8f,
+	0xe4, 0xe2, 0xaa, 0xb2, 0x56, 0xd1, 0x1c, 0x9b, 0x4f, 0xf9, 0x24, 0x96, 0x48, 0xc6, 0x23, 0xc8,
+	0x0b, 0xc8, 0x64, 0x2a, 0x2c, 0x40, 0x06, 0xb7, 0xbe, 0xae, 0xa5, 0xd8, 0x59, 0xcb, 0x61, 0x1b,
+	0xef, 0xb4, 0x0f, 0xba, 0xad, 0xbd, 0x76, 0x2d, 0xfd, 0xa4, 0x20, 0x8b, 0x10, 0xe3, 0x1b, 0xb8,
+	0x81, 0x89, 0xe7, 0xfa, 0x54, 0x93, 0x0f, 0x2e, 0xe9, 0x28, 0x22, 0xf5, 0x64, 0x7a, 0xfe, 0xe9,
+	0xfb, 0x9b, 0x0c, 0xd4, 0x27, 0x89, 0xcb, 0x9e, 0xe2, 0xf9, 0xf8, 0xe8, 0x4a, 0xb4, 0x15, 0x8f,
+	0x05, 0x99, 0x19, 0xf8, 0xc9, 0x89, 0xc4, 0xc9, 0x56, 0xe3, 0x1f, 0xd2, 0xb0, 0x3a, 0x15, 0x85,
+	0x59, 0xbf, 0x10, 0xc8, 0x8c, 0xa8, 0x09, 0x04, 0x88, 0x3b, 0xcd, 0x5d, 0xa8, 0x2a, 0x84, 0x98,
+	0xce, 0x2a, 0x12, 0x47, 0x68, 0x0e, 0xeb, 0xa2, 0x3b, 0xc3, 0x95, 0xb2, 0xfd, 0x0e, 0xe2, 0x26,
+	0x8f, 0xcb, 0x22, 0x26, 0x96, 0x8d, 0x9b, 0x58, 0x5f, 0x1f, 0x99, 0x4d, 0xe8, 0x34, 0x0f, 0xe9,
+	0x17, 0x5f, 0x8a, 0xe3, 0xb2, 0xce, 0xc1, 0x57, 0xad, 0xfd, 0xce, 0xae, 0xd9, 0xc2, 0x7b, 0x2f,
+	0x9f, 0xb7, 0x0f, 0xba, 0xb5, 0x34, 0xba, 0x01, 0xcb, 0xbb, 0x2f, 0x0f, 0xf7, 0x3b, 0x3b, 0x2c,
+	0xcc, 0xe3, 0xf6, 0xe1, 0x0b, 0xcc, 0xe3, 0x7a, 0x06, 0x21, 0xa8, 0x76, 0x0e, 0xba, 0x6d, 0x7c,
+	0xd0, 0xda, 0x37, 0xdb, 0x18, 0xbf, 0xc0, 0xb5, 0xac, 0xf1, 0x2b, 0x58, 0xc6, 0xc4, 0xea, 0xb7,
+	0x7c, 0x6a, 0x9f, 0x58, 0x3d, 0xfa, 0xae, 0xad, 0xe4, 0x1d, 0x58, 0xb0, 0x24, 0x89, 0x58, 0x68,
+	0x52, 0x40, 0xb6, 0xcb, 0xc6, 0x47, 0xb0, 0x12, 0xe7, 0x25, 0xed, 0x00, 0x41, 0xb6, 0x6f, 0x51,
+	0x8b, 0xb3, 0xaa, 0x60, 0xfe, 0xdf, 0xd8, 0x84, 0x15, 0x71, 0x9c, 0xf4, 0x22, 0xa4, 0x5e, 0x48,
+	0x2f, 0xb1, 0x48, 0xe3, 0x8d, 0xf0, 0x47, 0x81, 0x3c, 0x3b, 0x12, 0x21, 0xc8, 0xd2, 0x0b, 0x4f,
+	0xf7, 0x99, 0xec, 0x3f, 0x6f, 0xa5, 0x78, 0x63, 0x37, 0x3e, 0x2a, 0x61, 0x23, 0xd1, 0x7b, 0x3b,
+	0x94, 0xf5, 0xde, 0x59, 0xd5, 0x7b, 0xf3, 0x21, 0xcb, 0x06, 0xd4, 0x0f, 0x9d, 0x9e, 0x45, 0x49,
+	0x9f, 0x87, 0x8e, 0x22, 0x1e, 0x03, 0xc6, 0x2d, 0x58, 0x3e, 0x7a, 0x00, 0xda, 0x82, 0xd5, 0xc4,
+	0x7a, 0xe4, 0xe2, 0x1f, 0x40, 0xc1, 0x15, 0xa0, 0x7a, 0x2a, 0xee, 0x4b, 0x02, 0x13, 0xab, 0x69,
+	0xe3, 0x4c, 0x6d, 0xc9, 0x33, 0x3b, 0xa0, 0xae, 0x7f, 0xf1, 0xae, 0xba, 0xda, 0x82, 0x9c, 0x15,
+	0x98, 0xee, 0xc9, 0x15, 0x62, 0x6e, 0xd6, 0x0a, 0x5e, 0x9c, 0x18, 0x7f, 0x9b, 0x02, 0xc4, 0xae,
+	0xd0, 0xa8, 0x45, 0x49, 0xd7, 0xb7, 0x9c, 0xc0, 0x56, 0xe7, 0xa6, 0xd3, 0x77, 0x79, 0x0d, 0x4a,
+	0x7c, 0x22, 0x92, 0xc7, 0x78, 0x82, 0x54, 0xf9, 0x49, 0x94, 0x52, 0x32, 0xe5, 0xf3, 0x01, 0x6a,
+	0x42, 0x96, 0x57, 0xba, 0x97, 0x87, 0x72, 0x8e, 0x37, 0xbb, 0x9f, 0x30, 0x30, 0xac, 0x26, 0x76,
+	0x49, 0x6e, 0xf4, 0x67, 0x50, 0xa6, 0x5a, 0x78, 0xb5, 0xd9, 0x37, 0xf4, 0xf5, 0x6d, 0x7c, 0x71,
+	0x38, 0x8a, 0x6b, 0x34, 0x15, 0x4d, 0x55, 0xcf, 0x5f, 0x62, 0x8d, 0xff, 0x94, 0x82, 0x72, 0x04,
+	0x7b, 0x96, 0x86, 0x36, 0x01, 0x05, 0xe1, 0xf1, 0xc8, 0xa6, 0x2c, 0x07, 0xea, 0x16, 0x42, 0x6c,
+	0xd8, 0x92, 0x9e, 0xd1, 0x54, 0x1e, 0x42, 0x4d, 0x36, 0x04, 0x63, 0x64, 0xb1, 0x89, 0x8b, 0x12,
+	0xae, 0x51, 0x7f, 0x0a, 0xcb, 0xaa, 0x2c, 0x34, 0x27, 0x2e, 0x2c, 0x93, 0xd7, 0xb0, 0x48, 0xa1,
+	0x6a, 0x50, 0x60, 0xec, 0x02, 0x62, 0xae, 0x8a, 0x43, 0x67, 0xdf, 0x1d, 0x04, 0xef, 0x68, 0x69,
+	0x46, 0x1b, 0x96, 0x63, 0x54, 0xc6, 0xfe, 0x3e, 0x74, 0x07, 0x81, 0xf2, 0x77, 0xf6, 0x9f, 0x75,
+	0x0a, 0x96, 0xdf, 0x3b, 0xb5, 0x5f, 0x93, 0xbe, 0x3c, 0xe7, 0xd5, 0x63, 0xe3, 0x1b, 0x58, 0xd1,
+	0xb1, 0xf4, 0x3d, 0xc4, 0xd1, 0x7c, 0x33, 0x63, 0xbe, 0xc6, 0xc7, 0x80, 0xda, 0x01, 0xb5, 0x47,
+	0x57, 0x3f, 0xe8, 0xfe, 0xe7, 0x34, 0x57, 0xae, 0xfa, 0x8a, 0xb5, 0xf7, 0x3d, 0x2f, 0x94, 0x57,
+	0xe9, 0xec, 0x2f, 0xef, 0xa4, 0xc8, 0xc8, 0xf5, 0x2f, 0xcc, 0x81, 0x7d, 0x2c, 0xaf, 0xd3, 0x4b,
+	0x02, 0xb2, 0x67, 0x1f, 0xb3, 0x0f, 0x06, 0x5e, 0x28, 0xaf, 0xd4, 0xd9, 0xdf, 0xa9, 0x25, 0x49,
+	0x76, 0x7a, 0x49, 0xb2, 0x06, 0xa5, 0x9e, 0x17, 0x9a, 0xa7, 0x6e, 0xe8, 0x8b, 0xb2, 0x25, 0x85,
+	0x8b, 0x3d, 0x2f, 0x7c, 0xc6, 0xc6, 0xe8, 0x01, 0xd4, 0xc6, 0x8c, 0x25, 0x4e, 0x9e, 0xe3, 0x54,
+	0x35, 0x7b, 0x81, 0xb9, 0x06, 0xa5, 0x81, 0x26, 0x53, 0x10, 0x64, 0x06, 0x8a, 0x0c, 0x82, 0x6c,
+	0xcf, 0x0d, 0x28, 0x3f, 0x5a, 0x4a, 0x61, 0xfe, 0x9f, 0xe9, 0x47, 0xdf, 0x5e, 0x97, 0x84, 0x4b,
+	0xab, 0xb1, 0x38, 0x8a, 0x0e, 0x68, 0xf4, 0x26, 0xa1, 0xe8, 0x59, 0xe2, 0x00, 0x31, 0xd6, 0x02,
+	0x96, 0xe3, 0x2d, 0xe0, 0xa3, 0x3f, 0xbf, 0x0e, 0xc0, 0x7c, 0x4f, 0x1c, 0xd9, 0xa0, 0x23, 0x28,
+	0xe9, 0x2b, 0x07, 0x24, 0x2a, 0x9e, 0xe4, 0x15, 0x44, 0x43, 0x47, 0x47, 0xd1, 0x4a, 0x1b, 0xeb,
+	0x7f, 0xf6, 0x6f, 0xff, 0xf1, 0x57, 0xe9, 0x9b, 0x06, 0x62, 0xcf, 0x4a, 0x82, 0xad, 0xd7, 0x9f,
+	0x1c, 0x13, 0x6a, 0x7d, 0xb2, 0xc5, 0x44, 0xd9, 0xe6, 0xfd, 0xf4, 0x1f, 0x40, 0x5e, 0xf8, 0x2e,
+	0x42, 0xfc, 0xd3, 0xd8, 0x25, 0xc5, 0x04, 0xb9, 0x3b, 0x9c, 0xdc, 0x07, 0x68, 0x6d, 0x92, 0xdc,
+	0xd6, 0x77, 0xc2, 0xd8, 0x7e, 0x8d, 0x8e, 0xa0, 0xa8, 0xce, 0x47, 0xd1, 0xca, 0xb4, 0xa3, 0xef,
+	0xc6, 0x6a, 0x02, 0x2a, 0x0c, 0xdf, 0x68, 0x70, 0xea, 0x2b, 0x68, 0x8a, 0xb0, 0xe8, 0x37, 0x29,
+	0xa8, 0x69, 0x2b, 0x7f, 0xae, 0x8e, 0xe3, 0x66, 0x54, 0x18, 0x82, 0xcb, 0x07, 0x73, 0xeb, 0x0f,
+	0xe3, 0xf7, 0x38, 0xb7, 0xa6, 0xf1, 0x70, 0xce, 0x5a, 0xb6, 0x7d, 0xfe, 0xb5, 0xfc, 0x74, 0x3b,
+	0xf5, 0x11, 0xfa, 0x9b, 0x14, 0x54, 0xa2, 0x59, 0x1a, 0xd5, 0x25, 0x97, 0x89, 0x22, 0xa1, 0x71,
+	0x73, 0xca, 0x8c, 0xe4, 0x8d, 0x39, 0xef, 0x7d, 0xf4, 0xb3, 0x39, 0xbc, 0xb7, 0x98, 0x5b, 0x06,
+	0x5b, 0xdf, 0x49, 0x67, 0xfd, 0xf5, 0x96, 0x2a, 0x16, 0x82, 0xad, 0xef, 0x62, 0xc5, 0x04, 0x93,
+	0xd2, 0xea, 0xa3, 0x40, 0xdd, 0x30, 0xc9, 0x14, 0x8a, 0x6e, 0x46, 0x14, 0x1a, 0x2f, 0x13, 0x1a,
+	0x8d, 0x69, 0x53, 0x52, 0xb6, 0xef, 0x73, 0xd9, 0x3e, 0x44, 0x77, 0xe6, 0xc9, 0x26, 0x93, 0xee,
+	0x98, 0xa9, 0x4c, 0x27, 0x31, 0xa6, 0xf1, 0x44, 0xdc, 0x68, 0x4c, 0x9b, 0x7a, 0x1b, 0xa6, 0xa7,
+	0x92, 0xc7, 0x10, 0xaa, 0xf1, 0x7c, 0x83, 0xa2, 0xa4, 0x13, 0x49, 0xa8, 0x51, 0xd3, 0xc5, 0xb7,
+	0x9c, 0x30, 0x7e, 0xc0, 0x99, 0xdd, 0x43, 0x77, 0xe7, 0x31, 0x53, 0x39, 0x04, 0xfd, 0x29, 0x94,
+	0x23, 0x51, 0x1a, 0xdd, 0xd0, 0x5a, 0x8d, 0x87, 0xdb, 0x46, 0x7d, 0x72, 0x42, 0x2e, 0xee, 0x0b,
+	0xce, 0xef, 0x53, 0xf4, 0xc3, 0xb7, 0xd1, 0x36, 0x0b, 0xbf, 0x42, 0xb1, 0x7f, 0x91, 0x82, 0x85,
+	0x58, 0x80, 0x47, 0x37, 0xe3, 0x96, 0x1d, 0x95, 0xe2, 0xfa, 0x44, 0x6d, 0xd0, 0x66, 0x6f, 0xcc,
+	0x8c, 0x27, 0x5c, 0x86, 0xcf, 0x8d, 0x4f, 0xdf, 0x41, 0x06, 0xc6, 0x86, 0xd9, 0x7e, 0x17, 0x4a,
+	0xfa, 0x8a, 0x50, 0x06, 0xa0, 0xe4, 0x95, 0x61, 0x43, 0x67, 0x03, 0xe3, 0x1e, 0xe7, 0xb8, 0xf1,
+	0x68, 0x5e, 0xac, 0x60, 0x54, 0x7f, 0x09, 0x05, 0x79, 0xbb, 0x84, 0xe4, 0x5b, 0xa1, 0xd8, 0x05,
+	0xd2, 0xcc, 0x15, 0x3d, 0xe0, 0xf4, 0x0d, 0x63, 0x63, 0x1e, 0x7d, 0xd6, 0x14, 0xa3, 0x13, 0x28,
+	0xe9, 0x6b, 0x29, 0x25, 0xb7, 0x13, 0x5c, 0x8d, 0xcb, 0x47, 0x9c, 0xcb, 0x5d, 0xc3, 0x98, 0xc7,
+	0x25, 0xe4, 0xd4, 0xd0, 0x10, 0x60, 0x7c, 0xa7, 0x85, 0xae, 0x47, 0x4e, 0xc1, 0xc8, 0x15, 0x38,
+	0x35, 0x39, 0xa7, 0x07, 0xc6, 0x3c, 0x17, 0xd8, 0x16, 0x27, 0x7d, 0x84, 0xed, 0x9b, 0x0d, 0x25,
+	0x7d, 0xc5, 0x25, 0x57, 0x95, 0xbc, 0xf2, 0x9a, 0xc9, 0x6b, 0x93, 0xf3, 0xba, 0x3f, 0x7f, 0x55,
+	0x3e, 0xa7, 0xc6, 0x58, 0xfd, 0x02, 0x8a, 0xea, 0xa6, 0x56, 0x46, 0xf4, 0xc4, 0xc5, 0xed, 0x44,
+	0xa2, 0x78, 0xc8, 0x19, 0xdc, 0x41, 0xb7, 0xe7, 0x31, 0x38, 0x63, 0x44, 0x3e, 0x4e, 0xa1, 0x63,
+	0x28, 0x47, 0x8a, 0x0c, 0xe9, 0x61, 0x93, 0x65, 0xc7, 0xd8, 0x93, 0xd5, 0x9c, 0xb6, 0x81, 0x29,
+	0x36, 0xb6, 0x4d, 0x24, 0x92, 0xc8, 0x73, 0xbf, 0x84, 0x6a, 0xfc, 0xc1, 0xa2, 0x8c, 0x19, 0x53,
+	0x5f, 0x31, 0x36, 0xe2, 0xcf, 0x16, 0x55, 0xda, 0x33, 0x56, 0xe2, 0x6c, 0xf8, 0x63, 0xc6, 0x60,
+	0x5b, 0x3c, 0x6a, 0x44, 0x3f, 0x87, 0x72, 0xe4, 0x51, 0xa3, 0x5c, 0xc5, 0xe4, 0x33, 0xc7, 0x24,
+	0xed, 0xdb, 0x9c, 0xf6, 0x1a, 0xba, 0x39, 0x8d, 0xf6, 0xd6, 0x77, 0x2c, 0xa1, 0xf6, 0x22, 0xb2,
+	0x8b, 0x97, 0x1d, 0x09, 0xd9, 0xa3, 0x6f, 0x8f, 0x1a, 0xf1, 0x87, 0x51, 0xca, 0x0d, 0x8d, 0x1b,
+	0x13, 0x5b, 0x24, 0x5e, 0x4c, 0x6d, 0x8b, 0xb7, 0x60, 0xe8, 0x8f, 0x94, 0xf8, 0x82, 0x43, 0x54,
+	0xfc, 0x79, 0xe4, 0xef, 0x72, 0xf2, 0xdf, 0x43, 0xb7, 0x66, 0x90, 0x17, 0x2b, 0x18, 0xc0, 0x42,
+	0xec, 0x55, 0x17, 0x8a, 0x5d, 0x89, 0xc7, 0x9e, 0x95, 0x35, 0x1a, 0xd3, 0xa6, 0x64, 0x24, 0x95,
+	0xe5, 0x0c, 0x9a, 0xb5, 0x18, 0xe4, 0xc3, 0xd2, 0xc4, 0xb3, 0x2e, 0x24, 0x0a, 0x81, 0x59, 0xcf,
+	0xbd, 0x92, 0x2b, 0xda, 0xe2, 0x3c, 0x1e, 0x1a, 0x77, 0xe7, 0xad, 0x68, 0xdb, 0x12, 0xd4, 0x98,
+	0x77, 0x9c, 0x42, 0x35, 0xfe, 0x0a, 0x4c, 0xa9, 0x67, 0xda, 0xd3, 0xb0, 0x24, 0x37, 0x99, 0xf8,
+	0x8c, 0x3b, 0x73, 0xb9, 0x89, 0xc7, 0x2f, 0xe8, 0x15, 0x54, 0xa2, 0x8f, 0xb6, 0x64, 0xed, 0x31,
+	0xe5, 0x65, 0x59, 0xa3, 0x31, 0x31, 0xa3, 0x5f, 0x78, 0x19, 0x1f, 0x72, 0x96, 0xeb, 0x46, 0x23,
+	0xce, 0x92, 0x4a, 0x64, 0xdb, 0x15, 0xcb, 0xfa, 0x4d, 0x0a, 0xea, 0xb3, 0x9e, 0x95, 0xa1, 0xbb,
+	0xca, 0x3c, 0xe6, 0xbd, 0x3a, 0x9b, 0x2b, 0xc5, 0x7d, 0x2e, 0xc5, 0x6d, 0xb4, 0x3e, 0x5b, 0x0a,
+	0xbe, 0xf6, 0x27, 0x87, 0x7f, 0xd9, 0x7a, 0x8e, 0x6f, 0x41, 0xa1, 0x4f, 0x4e, 0x2c, 0x76, 0x5a,
+	0xb5, 0x84, 0x16, 0x61, 0xa1, 0x51, 0x56, 0xa9, 0x82, 0x86, 0xc1, 0x37, 0xeb, 0xf0, 0x01, 0xe4,
+	0x9f, 0x10, 0xcb, 0x27, 0x3e, 0x5a, 0x2e, 0xa6, 0x1b, 0x0b, 0x56, 0x48, 0x4f, 0x5d, 0xdf, 0xfe,
+	0x96, 0xd3, 0xd9, 0x48, 0x1f, 0x57, 0x00, 0x34, 0xc2, 0xb5, 0xe3, 0x3c, 0x8f, 0x86, 0x8f, 0xff,
+	0x6f, 0x00, 0xb4, 0xaf, 0x0c, 0xc1, 0xba, 0x2d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RunServiceClient is the client API for RunService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RunServiceClient interface {
+	CreateRun(ctx context.Context, in *CreateRunRequest, opts ...grpc.CallOption) (*RunDetail, error)
+	GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunDetail, error)
+	ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
+	// ReportRunMetrics reports metrics of a run. Each metric is reported in its
+	// own transaction, so this API accepts partial failures. Metric can be uniquely
+	// identified by (run_id, node_id, name). Duplicate reporting will be
+	// ignored by the API. First reporting wins.
+	ReportRunMetrics(ctx context.Context, in *ReportRunMetricsRequest, opts ...grpc.CallOption) (*ReportRunMetricsResponse, error)
+	ReadArtifact(ctx context.Context, in *ReadArtifactRequest, opts ...grpc.CallOption) (*ReadArtifactResponse, error)
+	// GetRunOutputs returns the summaries the steps of a run output for display,
+	// i.e. the markdown and the web apps of their UI metadata, read from the
+	// object store of the server and cut at its size limit.
+	GetRunOutputs(ctx context.Context, in *GetRunOutputsRequest, opts ...grpc.CallOption) (*GetRunOutputsResponse, error)
+	// GetRunHistory returns the states a run and the steps of its workflow went
+	// through, as recorded from the reports of the persistence agent, to see when
+	// each transition happened and what was reported with it.
+	GetRunHistory(ctx context.Context, in *GetRunHistoryRequest, opts ...grpc.CallOption) (*GetRunHistoryResponse, error)
+	// GetRunManifest returns the workflow submitted with a run next to the
+	// workflow as last reported from the cluster, with the resolved parameters,
+	// to find out what actually ran.
+	GetRunManifest(ctx context.Context, in *GetRunManifestRequest, opts ...grpc.CallOption) (*RunManifest, error)
+	// ReadRunLogs reads the logs of the main container of a step of a run. The logs
+	// archived once the step completed are read from the object store, so that they
+	// survive the deletion of the pod.
+	ReadRunLogs(ctx context.Context, in *ReadRunLogsRequest, opts ...grpc.CallOption) (*ReadRunLogsResponse, error)
+	// ReportRunLogs archives the logs of a completed step of a run. Reported by the
+	// persistence agent before the pod of the step is deleted.
+	ReportRunLogs(ctx context.Context, in *ReportRunLogsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// UpdateRun updates the note and the annotations of a run, e.g. to mark it as a
+	// baseline after the fact. The other fields of a run can't be updated.
+	UpdateRun(ctx context.Context, in *UpdateRunRequest, opts ...grpc.CallOption) (*Run, error)
+	// StarRun adds a run to the favorites of the authenticated user, which can be
+	// listed with only_starred.
+	StarRun(ctx context.Context, in *StarRunRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// UnstarRun removes a run from the favorites of the authenticated user.
+	UnstarRun(ctx context.Context, in *UnstarRunRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// ApproveRun approves the approval gates of a run waiting for a decision, all
+	// of them or the one of node_id. The run resumes once no gate waits anymore.
+	ApproveRun(ctx context.Context, in *ApproveRunRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// RejectRun rejects the approval gates of a run waiting for a decision, all of
+	// them or the one of node_id, which fails the gates and terminates the run.
+	RejectRun(ctx context.Context, in *RejectRunRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// WatchRun streams the run every time its status changes, starting with its
+	// current state. The stream ends once the run reaches a final state.
+	WatchRun(ctx context.Context, in *WatchRunRequest, opts ...grpc.CallOption) (RunService_WatchRunClient, error)
+	// EstimateRun estimates the resources and the cost of a run before it's
+	// created, from the resource requests of its steps and the durations of the
+	// steps of the past runs of its pipeline. Nothing is created.
+	EstimateRun(ctx context.Context, in *EstimateRunRequest, opts ...grpc.CallOption) (*RunEstimate, error)
+	// CreateRunSweep creates one run per combination of the values of the
+	// parameters of a sweep, at most max_concurrency of them running at once.
+	// The runs are created in the background.
+	CreateRunSweep(ctx context.Context, in *CreateRunSweepRequest, opts ...grpc.CallOption) (*RunSweep, error)
+	// GetRunSweep returns a sweep with the status and the metrics of its runs.
+	GetRunSweep(ctx context.Context, in *GetRunSweepRequest, opts ...grpc.CallOption) (*RunSweep, error)
+	// CreateRunGroup creates a group of runs, which are tracked and cancelled as
+	// a unit.
+	CreateRunGroup(ctx context.Context, in *CreateRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error)
+	// GetRunGroup returns a group with the aggregate status of its runs.
+	GetRunGroup(ctx context.Context, in *GetRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error)
+	ListRunGroups(ctx context.Context, in *ListRunGroupsRequest, opts ...grpc.CallOption) (*ListRunGroupsResponse, error)
+	// AddRunsToRunGroup adds runs to a group which isn't cancelled. The runs
+	// already in the group are ignored.
+	AddRunsToRunGroup(ctx context.Context, in *AddRunsToRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error)
+	// CancelRunGroup terminates the runs of a group which are still running.
+	// No run is added to the group afterwards, e.g. by its sweep.
+	CancelRunGroup(ctx context.Context, in *CancelRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error)
+	// TerminateAll terminates the runs of an experiment or of a run group which
+	// aren't finished. The runs are terminated in the background, and the
+	// result of each of them is returned by GetTerminateAllOperation. A run
+	// group is cancelled first, so that no run is added to it afterwards.
+	TerminateAll(ctx context.Context, in *TerminateAllRequest, opts ...grpc.CallOption) (*TerminateAllOperation, error)
+	GetTerminateAllOperation(ctx context.Context, in *GetTerminateAllOperationRequest, opts ...grpc.CallOption) (*TerminateAllOperation, error)
+}
+
+type runServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRunServiceClient(cc *grpc.ClientConn) RunServiceClient {
+	return &runServiceClient{cc}
+}
+
+func (c *runServiceClient) CreateRun(ctx context.Context, in *CreateRunRequest, opts ...grpc.CallOption) (*RunDetail, error) {
+	out := new(RunDetail)
+	err := c.cc.Invoke(ctx, "/api.RunService/CreateRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunDetail, error) {
+	out := new(RunDetail)
+	err := c.cc.Invoke(ctx, "/api.RunService/GetRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
+	out := new(ListRunsResponse)
+	err := c.cc.Invoke(ctx, "/api.RunService/ListRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ReportRunMetrics(ctx context.Context, in *ReportRunMetricsRequest, opts ...grpc.CallOption) (*ReportRunMetricsResponse, error) {
+	out := new(ReportRunMetricsResponse)
+	err := c.cc.Invoke(ctx, "/api.RunService/ReportRunMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ReadArtifact(ctx context.Context, in *ReadArtifactRequest, opts ...grpc.CallOption) (*ReadArtifactResponse, error) {
+	out := new(ReadArtifactResponse)
+	err := c.cc.Invoke(ctx, "/api.RunService/ReadArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetRunOutputs(ctx context.Context, in *GetRunOutputsRequest, opts ...grpc.CallOption) (*GetRunOutputsResponse, error) {
+	out := new(GetRunOutputsResponse)
+	err := c.cc.Invoke(ctx, "/api.RunService/GetRunOutputs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetRunHistory(ctx context.Context, in *GetRunHistoryRequest, opts ...grpc.CallOption) (*GetRunHistoryResponse, error) {
+	out := new(GetRunHistoryResponse)
+	err := c.cc.Invoke(ctx, "/api.RunService/GetRunHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetRunManifest(ctx context.Context, in *GetRunManifestRequest, opts ...grpc.CallOption) (*RunManifest, error) {
+	out := new(RunManifest)
+	err := c.cc.Invoke(ctx, "/api.RunService/GetRunManifest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ReadRunLogs(ctx context.Context, in *ReadRunLogsRequest, opts ...grpc.CallOption) (*ReadRunLogsResponse, error) {
+	out := new(ReadRunLogsResponse)
+	err := c.cc.Invoke(ctx, "/api.RunService/ReadRunLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ReportRunLogs(ctx context.Context, in *ReportRunLogsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.RunService/ReportRunLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) UpdateRun(ctx context.Context, in *UpdateRunRequest, opts ...grpc.CallOption) (*Run, error) {
+	out := new(Run)
+	err := c.cc.Invoke(ctx, "/api.RunService/UpdateRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) StarRun(ctx context.Context, in *StarRunRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.RunService/StarRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) UnstarRun(ctx context.Context, in *UnstarRunRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.RunService/UnstarRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ApproveRun(ctx context.Context, in *ApproveRunRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.RunService/ApproveRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) RejectRun(ctx context.Context, in *RejectRunRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.RunService/RejectRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) WatchRun(ctx context.Context, in *WatchRunRequest, opts ...grpc.CallOption) (RunService_WatchRunClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RunService_serviceDesc.Streams[0], "/api.RunService/WatchRun", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runServiceWatchRunClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RunService_WatchRunClient interface {
+	Recv() (*RunDetail, error)
+	grpc.ClientStream
+}
+
+type runServiceWatchRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *runServiceWatchRunClient) Recv() (*RunDetail, error) {
+	m := new(RunDetail)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runServiceClient) EstimateRun(ctx context.Context, in *EstimateRunRequest, opts ...grpc.CallOption) (*RunEstimate, error) {
+	out := new(RunEstimate)
+	err := c.cc.Invoke(ctx, "/api.RunService/EstimateRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) CreateRunSweep(ctx context.Context, in *CreateRunSweepRequest, opts ...grpc.CallOption) (*RunSweep, error) {
+	out := new(RunSweep)
+	err := c.cc.Invoke(ctx, "/api.RunService/CreateRunSweep", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetRunSweep(ctx context.Context, in *GetRunSweepRequest, opts ...grpc.CallOption) (*RunSweep, error) {
+	out := new(RunSweep)
+	err := c.cc.Invoke(ctx, "/api.RunService/GetRunSweep", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) CreateRunGroup(ctx context.Context, in *CreateRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error) {
+	out := new(RunGroup)
+	err := c.cc.Invoke(ctx, "/api.RunService/CreateRunGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetRunGroup(ctx context.Context, in *GetRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error) {
+	out := new(RunGroup)
+	err := c.cc.Invoke(ctx, "/api.RunService/GetRunGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) ListRunGroups(ctx context.Context, in *ListRunGroupsRequest, opts ...grpc.CallOption) (*ListRunGroupsResponse, error) {
+	out := new(ListRunGroupsResponse)
+	err := c.cc.Invoke(ctx, "/api.RunService/ListRunGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) AddRunsToRunGroup(ctx context.Context, in *AddRunsToRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error) {
+	out := new(RunGroup)
+	err := c.cc.Invoke(ctx, "/api.RunService/AddRunsToRunGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) CancelRunGroup(ctx context.Context, in *CancelRunGroupRequest, opts ...grpc.CallOption) (*RunGroup, error) {
+	out := new(RunGroup)
+	err := c.cc.Invoke(ctx, "/api.RunService/CancelRunGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) TerminateAll(ctx context.Context, in *TerminateAllRequest, opts ...grpc.CallOption) (*TerminateAllOperation, error) {
+	out := new(TerminateAllOperation)
+	err := c.cc.Invoke(ctx, "/api.RunService/TerminateAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runServiceClient) GetTerminateAllOperation(ctx context.Context, in *GetTerminateAllOperationRequest, opts ...grpc.CallOption) (*TerminateAllOperation, error) {
+	out := new(TerminateAllOperation)
+	err := c.cc.Invoke(ctx, "/api.RunService/GetTerminateAllOperation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RunServiceServer is the server API for RunService service.
+type RunServiceServer interface {
+	CreateRun(context.Context, *CreateRunRequest) (*RunDetail, error)
+	GetRun(context.Context, *GetRunRequest) (*RunDetail, error)
+	ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error)
+	// ReportRunMetrics reports metrics of a run. Each metric is reported in its
+	// own transaction, so this API accepts partial failures. Metric can be uniquely
+	// identified by (run_id, node_id, name). Duplicate reporting will be
+	// ignored by the API. First reporting wins.
+	ReportRunMetrics(context.Context, *ReportRunMetricsRequest) (*ReportRunMetricsResponse, error)
+	ReadArtifact(context.Context, *ReadArtifactRequest) (*ReadArtifactResponse, error)
+	// GetRunOutputs returns the summaries the steps of a run output for display,
+	// i.e. the markdown and the web apps of their UI metadata, read from the
+	// object store of the server and cut at its size limit.
+	GetRunOutputs(context.Context, *GetRunOutputsRequest) (*GetRunOutputsResponse, error)
+	// GetRunHistory returns the states a run and the steps of its workflow went
+	// through, as recorded from the reports of the persistence agent, to see when
+	// each transition happened and what was reported with it.
+	GetRunHistory(context.Context, *GetRunHistoryRequest) (*GetRunHistoryResponse, error)
+	// GetRunManifest returns the workflow submitted with a run next to the
+	// workflow as last reported from the cluster, with the resolved parameters,
+	// to find out what actually ran.
+	GetRunManifest(context.Context, *GetRunManifestRequest) (*RunManifest, error)
+	// ReadRunLogs reads the logs of the main container of a step of a run. The logs
+	// archived once the step completed are read from the object store, so that they
+	// survive the deletion of the pod.
+	ReadRunLogs(context.Context, *ReadRunLogsRequest) (*ReadRunLogsResponse, error)
+	// ReportRunLogs archives the logs of a completed step of a run. Reported by the
+	// persistence agent before the pod of the step is deleted.
+	ReportRunLogs(context.Context, *ReportRunLogsRequest) (*empty.Empty, error)
+	// UpdateRun updates the note and the annotations of a run, e.g. to mark it as a
+	// baseline after the fact. The other fields of a run can't be updated.
+	UpdateRun(context.Context, *UpdateRunRequest) (*Run, error)
+	// StarRun adds a run to the favorites of the authenticated user, which can be
+	// listed with only_starred.
+	StarRun(context.Context, *StarRunRequest) (*empty.Empty, error)
+	// UnstarRun removes a run from the favorites of the authenticated user.
+	UnstarRun(context.Context, *UnstarRunRequest) (*empty.Empty, error)
+	// ApproveRun approves the approval gates of a run waiting for a decision, all
+	// of them or the one of node_id. The run resumes once no gate waits anymore.
+	ApproveRun(context.Context, *ApproveRunRequest) (*empty.Empty, error)
+	// RejectRun rejects the approval gates of a run waiting for a decision, all of
+	// them or the one of node_id, which fails the gates and terminates the run.
+	RejectRun(context.Context, *RejectRunRequest) (*empty.Empty, error)
+	// WatchRun streams the run every time its status changes, starting with its
+	// current state. The stream ends once the run reaches a final state.
+	WatchRun(*WatchRunRequest, RunService_WatchRunServer) error
+	// EstimateRun estimates the resources and the cost of a run before it's
+	// created, from the resource requests of its steps and the durations of the
+	// steps of the past runs of its pipeline. Nothing is created.
+	EstimateRun(context.Context, *EstimateRunRequest) (*RunEstimate, error)
+	// CreateRunSweep creates one run per combination of the values of the
+	// parameters of a sweep, at most max_concurrency of them running at once.
+	// The runs are created in the background.
+	CreateRunSweep(context.Context, *CreateRunSweepRequest) (*RunSweep, error)
+	// GetRunSweep returns a sweep with the status and the metrics of its runs.
+	GetRunSweep(context.Context, *GetRunSweepRequest) (*RunSweep, error)
+	// CreateRunGroup creates a group of runs, which are tracked and cancelled as
+	// a unit.
+	CreateRunGroup(context.Context, *CreateRunGroupRequest) (*RunGroup, error)
+	// GetRunGroup returns a group with the aggregate status of its runs.
+	GetRunGroup(context.Context, *GetRunGroupRequest) (*RunGroup, error)
+	ListRunGroups(context.Context, *ListRunGroupsRequest) (*ListRunGroupsResponse, error)
+	// AddRunsToRunGroup adds runs to a group which isn't cancelled. The runs
+	// already in the group are ignored.
+	AddRunsToRunGroup(context.Context, *AddRunsToRunGroupRequest) (*RunGroup, error)
+	// CancelRunGroup terminates the runs of a group which are still running.
+	// No run is added to the group afterwards, e.g. by its sweep.
+	CancelRunGroup(context.Context, *CancelRunGroupRequest) (*RunGroup, error)
+	// TerminateAll terminates the runs of an experiment or of a run group which
+	// aren't finished. The runs are terminated in the background, and the
+	// result of each of them is returned by GetTerminateAllOperation. A run
+	// group is cancelled first, so that no run is added to it afterwards.
+	TerminateAll(context.Context, *TerminateAllRequest) (*TerminateAllOperation, error)
+	GetTerminateAllOperation(context.Context, *GetTerminateAllOperationRequest) (*TerminateAllOperation, error)
+}
+
+func RegisterRunServiceServer(s *grpc.Server, srv RunServiceServer) {
+	s.RegisterService(&_RunService_serviceDesc, srv)
+}
+
+func _RunService_CreateRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).CreateRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/CreateRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).CreateRun(ctx, req.(*CreateRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_GetRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/GetRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetRun(ctx, req.(*GetRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/ListRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).ListRuns(ctx, req.(*ListRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_ReportRunMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRunMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).ReportRunMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/ReportRunMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).ReportRunMetrics(ctx, req.(*ReportRunMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_ReadArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).ReadArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/ReadArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).ReadArtifact(ctx, req.(*ReadArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_GetRunOutputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetRunOutputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/GetRunOutputs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetRunOutputs(ctx, req.(*GetRunOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_GetRunHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetRunHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/GetRunHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetRunHistory(ctx, req.(*GetRunHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_GetRunManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetRunManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/GetRunManifest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetRunManifest(ctx, req.(*GetRunManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_ReadRunLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadRunLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).ReadRunLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/ReadRunLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).ReadRunLogs(ctx, req.(*ReadRunLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_ReportRunLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRunLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).ReportRunLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/ReportRunLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).ReportRunLogs(ctx, req.(*ReportRunLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RunService_UpdateRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).UpdateRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/UpdateRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).UpdateRun(ctx, req.(*UpdateRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-var xxx_messageInfo_ReadArtifactResponse proto.InternalMessageInfo
-
-func (m *ReadArtifactResponse) GetData() []byte {
-	if m != nil {
-		return m.Data
+func _RunService_StarRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StarRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return nil
+	if interceptor == nil {
+		return srv.(RunServiceServer).StarRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/StarRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).StarRun(ctx, req.(*StarRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func init() {
-	proto.RegisterEnum("api.RunMetric_Format", RunMetric_Format_name, RunMetric_Format_value)
-	proto.RegisterEnum("api.ReportRunMetricsResponse_ReportRunMetricResult_Status", ReportRunMetricsResponse_ReportRunMetricResult_Status_name, ReportRunMetricsResponse_ReportRunMetricResult_Status_value)
-	proto.RegisterType((*CreateRunRequest)(nil), "api.CreateRunRequest")
-	proto.RegisterType((*GetRunRequest)(nil), "api.GetRunRequest")
-	proto.RegisterType((*ListRunsRequest)(nil), "api.ListRunsRequest")
-	proto.RegisterType((*ListRunsResponse)(nil), "api.ListRunsResponse")
-	proto.RegisterType((*Run)(nil), "api.Run")
-	proto.RegisterType((*PipelineRuntime)(nil), "api.PipelineRuntime")
-	proto.RegisterType((*RunDetail)(nil), "api.RunDetail")
-	proto.RegisterType((*RunMetric)(nil), "api.RunMetric")
-	proto.RegisterType((*ReportRunMetricsRequest)(nil), "api.ReportRunMetricsRequest")
-	proto.RegisterType((*ReportRunMetricsResponse)(nil), "api.ReportRunMetricsResponse")
-	proto.RegisterType((*ReportRunMetricsResponse_ReportRunMetricResult)(nil), "api.ReportRunMetricsResponse.ReportRunMetricResult")
-	proto.RegisterType((*ReadArtifactRequest)(nil), "api.ReadArtifactRequest")
-	proto.RegisterType((*ReadArtifactResponse)(nil), "api.ReadArtifactResponse")
+func _RunService_UnstarRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnstarRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).UnstarRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/UnstarRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).UnstarRun(ctx, req.(*UnstarRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func init() { proto.RegisterFile("run.proto", fileDescriptor_e3419bc3417bf873) }
-
-var fileDescriptor_e3419bc3417bf873 = []byte{
-	// 1244 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x6e, 0xdb, 0xc6,
-	0x13, 0x0e, 0x25, 0x5b, 0xb2, 0x46, 0xb2, 0xcc, 0xac, 0xed, 0x98, 0x51, 0x6c, 0xd8, 0x60, 0x7e,
-	0x08, 0xf2, 0x4b, 0x1b, 0x09, 0x71, 0x8a, 0x02, 0x35, 0x50, 0x14, 0xb2, 0xad, 0xb8, 0x6a, 0x6c,
-	0x45, 0x5d, 0x29, 0x69, 0x91, 0x0b, 0xb1, 0x26, 0x57, 0x0a, 0x6b, 0x89, 0x64, 0x77, 0x97, 0x49,
-	0x9d, 0x20, 0x97, 0x02, 0x7d, 0x81, 0xf6, 0xd0, 0x5b, 0x5f, 0xa0, 0x40, 0x0f, 0x3d, 0xf7, 0x05,
-	0x7a, 0xee, 0x2b, 0xf4, 0x41, 0x0a, 0xee, 0x2e, 0x19, 0xfd, 0x71, 0x15, 0xa0, 0x27, 0x69, 0xbf,
-	0xf9, 0x66, 0x67, 0x76, 0xe6, 0x9b, 0x5d, 0x42, 0x89, 0xc5, 0x41, 0x3d, 0x62, 0xa1, 0x08, 0x51,
-	0x9e, 0x44, 0x7e, 0xad, 0x4c, 0x19, 0x0b, 0x99, 0x42, 0x6a, 0xb7, 0x86, 0x61, 0x38, 0x1c, 0xd1,
-	0x86, 0x5c, 0x9d, 0xc7, 0x83, 0x06, 0x1d, 0x47, 0xe2, 0x52, 0x1b, 0xb7, 0xb5, 0x91, 0x44, 0x7e,
-	0x83, 0x04, 0x41, 0x28, 0x88, 0xf0, 0xc3, 0x80, 0x6b, 0xeb, 0xee, 0xac, 0xab, 0xf0, 0xc7, 0x94,
-	0x0b, 0x32, 0x8e, 0x34, 0x61, 0x3d, 0xf2, 0x23, 0x3a, 0xf2, 0x03, 0xea, 0xf0, 0x88, 0xba, 0x1a,
-	0xb4, 0x18, 0xe5, 0x61, 0xcc, 0x5c, 0xea, 0x30, 0x3a, 0xa0, 0x8c, 0x06, 0x2e, 0xd5, 0x96, 0x0f,
-	0xe5, 0x8f, 0x7b, 0x7f, 0x48, 0x83, 0xfb, 0xfc, 0x15, 0x19, 0x0e, 0x29, 0x6b, 0x84, 0x91, 0x8c,
-	0x38, 0x1f, 0xdd, 0xae, 0x83, 0x79, 0xc4, 0x28, 0x11, 0x14, 0xc7, 0x01, 0xa6, 0xdf, 0xc6, 0x94,
-	0x0b, 0x54, 0x83, 0x3c, 0x8b, 0x03, 0xcb, 0xd8, 0x33, 0xee, 0x96, 0xf7, 0x57, 0xea, 0x24, 0xf2,
-	0xeb, 0x89, 0x35, 0x01, 0xed, 0x3b, 0xb0, 0x7a, 0x42, 0xc5, 0x04, 0x79, 0x13, 0x0a, 0x2c, 0x0e,
-	0x1c, 0xdf, 0x93, 0xfc, 0x12, 0x5e, 0x66, 0x71, 0xd0, 0xf6, 0xec, 0xdf, 0x0c, 0x58, 0x3b, 0xf5,
-	0x79, 0xc2, 0xe4, 0x29, 0x75, 0x07, 0x20, 0x22, 0x43, 0xea, 0x88, 0xf0, 0x82, 0x06, 0x9a, 0x5e,
-	0x4a, 0x90, 0x7e, 0x02, 0xa0, 0x5b, 0x20, 0x17, 0x0e, 0xf7, 0x5f, 0x53, 0x2b, 0xb7, 0x67, 0xdc,
-	0x5d, 0xc6, 0x2b, 0x09, 0xd0, 0xf3, 0x5f, 0x53, 0xb4, 0x05, 0x45, 0x1e, 0x32, 0xe1, 0x9c, 0x5f,
-	0x5a, 0x79, 0xe9, 0x58, 0x48, 0x96, 0x87, 0x97, 0xe8, 0x11, 0xdc, 0x98, 0x2f, 0x85, 0x73, 0x41,
-	0x2f, 0xad, 0x25, 0x99, 0xbf, 0xa9, 0xf2, 0xd7, 0x94, 0xc7, 0xf4, 0x12, 0x6f, 0xa4, 0x7c, 0x9c,
-	0xd2, 0x1f, 0xd3, 0x4b, 0xfb, 0x6b, 0x30, 0xdf, 0xe5, 0xcb, 0xa3, 0x30, 0xe0, 0x14, 0x6d, 0xc3,
-	0x12, 0x8b, 0x03, 0x6e, 0x19, 0x7b, 0xf9, 0xa9, 0x4a, 0x48, 0x14, 0xdd, 0x81, 0xb5, 0x80, 0x7e,
-	0x27, 0x9c, 0x89, 0x33, 0xe5, 0x64, 0x6a, 0xab, 0x09, 0xdc, 0x4d, 0xcf, 0x65, 0xff, 0x91, 0x87,
-	0x3c, 0x8e, 0x03, 0x54, 0x85, 0x5c, 0x56, 0xa5, 0x9c, 0xef, 0x21, 0x04, 0x4b, 0x01, 0x19, 0x53,
-	0xed, 0x24, 0xff, 0xa3, 0x3d, 0x28, 0x7b, 0x94, 0xbb, 0xcc, 0x97, 0x0d, 0xd3, 0x47, 0x9d, 0x84,
-	0xd0, 0xc7, 0xb0, 0x3a, 0xa5, 0x07, 0x7d, 0xcc, 0xeb, 0x32, 0xb9, 0xae, 0xb6, 0xf4, 0x22, 0xea,
-	0xe2, 0x4a, 0x34, 0xb1, 0x42, 0x27, 0xb0, 0x3e, 0x5f, 0x27, 0x6e, 0x2d, 0xcb, 0xa3, 0xdd, 0x98,
-	0x2a, 0x52, 0x56, 0x17, 0x8c, 0xe6, 0x4a, 0xc5, 0xd1, 0x27, 0x00, 0xae, 0x54, 0x8c, 0xe7, 0x10,
-	0x61, 0x15, 0x64, 0xf4, 0x5a, 0x5d, 0x89, 0xb8, 0x9e, 0x8a, 0xb8, 0xde, 0x4f, 0x45, 0x8c, 0x4b,
-	0x9a, 0xdd, 0x14, 0xe8, 0x53, 0xa8, 0x70, 0xf7, 0x05, 0xf5, 0xe2, 0x91, 0x72, 0x2e, 0xbe, 0xd7,
-	0xb9, 0x9c, 0xf1, 0x9b, 0x02, 0xdd, 0x80, 0x02, 0x17, 0x44, 0xc4, 0xdc, 0x5a, 0xd1, 0x12, 0x90,
-	0x2b, 0xb4, 0x01, 0xcb, 0x72, 0x16, 0xad, 0x8a, 0x52, 0xa0, 0x5c, 0xa0, 0xbb, 0x50, 0x1c, 0x53,
-	0xc1, 0x7c, 0x97, 0x5b, 0x25, 0x79, 0xc8, 0x6a, 0xda, 0xbf, 0x33, 0x09, 0xe3, 0xd4, 0x8c, 0xb6,
-	0xa1, 0x94, 0x14, 0x9f, 0x47, 0xc4, 0xa5, 0x56, 0x55, 0xc9, 0x32, 0x03, 0xec, 0x0b, 0x58, 0x4b,
-	0xcb, 0x8a, 0xe3, 0x20, 0x19, 0x4e, 0xf4, 0x01, 0x5c, 0xcf, 0x7a, 0x30, 0x26, 0x81, 0x3f, 0xa0,
-	0x5c, 0x58, 0x20, 0x1d, 0xcd, 0xd4, 0x70, 0xa6, 0xf1, 0x84, 0xfc, 0x2a, 0x64, 0x17, 0x83, 0x51,
-	0xf8, 0xea, 0x1d, 0xb9, 0xac, 0xc8, 0xa9, 0x21, 0x25, 0xdb, 0x2f, 0xa0, 0x84, 0xe3, 0xe0, 0x98,
-	0x0a, 0xe2, 0x8f, 0x16, 0xcd, 0x21, 0xfa, 0x0c, 0xb2, 0x48, 0x0e, 0x53, 0x69, 0x49, 0x21, 0x95,
-	0xf7, 0x37, 0xa6, 0x94, 0xa0, 0x53, 0xc6, 0x6b, 0xd1, 0x34, 0x60, 0xff, 0x69, 0xc8, 0x50, 0xaa,
-	0x16, 0x99, 0x16, 0x8d, 0x09, 0x2d, 0x6e, 0x41, 0x31, 0x08, 0x3d, 0x9a, 0x8c, 0xb6, 0x92, 0x68,
-	0x21, 0x59, 0xb6, 0x3d, 0x74, 0x1b, 0x2a, 0x41, 0x3c, 0x3e, 0xa7, 0xcc, 0x79, 0x49, 0x46, 0x31,
-	0x95, 0x2a, 0x35, 0x3e, 0xbf, 0x86, 0xcb, 0x0a, 0x7d, 0x96, 0x80, 0xe8, 0x3e, 0x14, 0x06, 0x21,
-	0x1b, 0x13, 0x21, 0x05, 0x5a, 0xdd, 0xdf, 0x9c, 0xae, 0x7e, 0xfd, 0x91, 0x34, 0x62, 0x4d, 0xb2,
-	0xf7, 0xa1, 0xa0, 0x10, 0xb4, 0x06, 0xe5, 0xa7, 0x9d, 0x5e, 0xb7, 0x75, 0xd4, 0x7e, 0xd4, 0x6e,
-	0x1d, 0x9b, 0xd7, 0x50, 0x11, 0xf2, 0xb8, 0xf9, 0x95, 0x69, 0xa0, 0x2a, 0x40, 0xb7, 0x85, 0x8f,
-	0x5a, 0x9d, 0x7e, 0xf3, 0xa4, 0x65, 0xe6, 0x0e, 0x8b, 0xb0, 0x2c, 0x13, 0xb0, 0x9f, 0xc3, 0x16,
-	0xa6, 0x51, 0xc8, 0x44, 0xb6, 0x3d, 0x5f, 0x7c, 0x3d, 0x4d, 0x8a, 0x23, 0xb7, 0x50, 0x1c, 0xf6,
-	0x2f, 0x79, 0xb0, 0xe6, 0x37, 0xd7, 0x17, 0xc4, 0x19, 0x14, 0x19, 0xe5, 0xf1, 0x48, 0xa4, 0x77,
-	0xc4, 0x43, 0x3d, 0x48, 0x57, 0xf3, 0x67, 0x0d, 0x58, 0xfa, 0xe2, 0x74, 0x8f, 0xda, 0xef, 0x39,
-	0xd8, 0xbc, 0x92, 0x82, 0x76, 0xa1, 0xac, 0x12, 0x72, 0x26, 0xda, 0x04, 0x0a, 0xea, 0x24, 0xcd,
-	0xfa, 0x1f, 0x54, 0x53, 0xc2, 0x54, 0xcf, 0x2a, 0x9a, 0xa3, 0x3a, 0x87, 0xb3, 0x09, 0xca, 0xcb,
-	0xa6, 0x1c, 0xfc, 0x87, 0x74, 0xeb, 0x3d, 0xb9, 0x43, 0x36, 0x7d, 0x56, 0x52, 0x4a, 0xce, 0xc9,
-	0x90, 0xca, 0x4e, 0x97, 0x70, 0xba, 0xb4, 0x3d, 0x28, 0x28, 0xee, 0x7c, 0x4f, 0x0b, 0x90, 0x7b,
-	0xf2, 0xd8, 0x34, 0xd0, 0x06, 0x98, 0xed, 0xce, 0xb3, 0xe6, 0x69, 0xfb, 0xd8, 0x69, 0xe2, 0x93,
-	0xa7, 0x67, 0xad, 0x4e, 0xdf, 0xcc, 0xa1, 0x2d, 0x58, 0x3f, 0x7e, 0xda, 0x3d, 0x6d, 0x1f, 0x35,
-	0xfb, 0x2d, 0x07, 0xb7, 0xba, 0x4f, 0x70, 0xbf, 0xdd, 0x39, 0x31, 0xf3, 0x08, 0x41, 0xb5, 0xdd,
-	0xe9, 0xb7, 0x70, 0xa7, 0x79, 0xea, 0xb4, 0x30, 0x7e, 0x82, 0xcd, 0x25, 0xfb, 0x1b, 0x58, 0xc7,
-	0x94, 0x78, 0x4d, 0x26, 0xfc, 0x01, 0x71, 0xc5, 0x7b, 0x1a, 0xbf, 0x40, 0xd4, 0xab, 0x44, 0x6f,
-	0xa1, 0x6a, 0xac, 0xee, 0xde, 0x4a, 0x0a, 0x26, 0x55, 0xb6, 0xef, 0xc1, 0xc6, 0x74, 0x2c, 0xad,
-	0x03, 0x04, 0x4b, 0x1e, 0x11, 0x44, 0x86, 0xaa, 0x60, 0xf9, 0x7f, 0xff, 0xd7, 0x25, 0x00, 0x1c,
-	0x07, 0x3d, 0xca, 0x5e, 0xfa, 0x2e, 0x45, 0x3d, 0x28, 0x65, 0x0f, 0x2d, 0x52, 0xc3, 0x30, 0xfb,
-	0xf0, 0xd6, 0x32, 0x11, 0xaa, 0x0b, 0xc0, 0xde, 0xfd, 0xfe, 0xaf, 0xbf, 0x7f, 0xca, 0xdd, 0xb4,
-	0x51, 0xf2, 0xe9, 0xc0, 0x1b, 0x2f, 0x1f, 0x9c, 0x53, 0x41, 0x1e, 0x34, 0x92, 0xd7, 0xe7, 0x40,
-	0xde, 0x02, 0x5f, 0x42, 0x41, 0xbd, 0xc6, 0x08, 0x49, 0xd7, 0xa9, 0xa7, 0x79, 0x6e, 0xbb, 0xdb,
-	0x72, 0xbb, 0x1d, 0x74, 0x6b, 0x7e, 0xbb, 0xc6, 0x1b, 0x55, 0xac, 0xb7, 0xa8, 0x07, 0x2b, 0xe9,
-	0x3b, 0x88, 0xd4, 0x55, 0x32, 0xf3, 0x8c, 0xd7, 0x36, 0x67, 0x50, 0x55, 0x03, 0xbb, 0x26, 0x77,
-	0xdf, 0x40, 0x57, 0x24, 0x8b, 0x7e, 0x30, 0xc0, 0x9c, 0x55, 0x19, 0xda, 0xfe, 0x17, 0xf1, 0xa9,
-	0x28, 0x3b, 0x0b, 0xa5, 0x69, 0x7f, 0x24, 0xa3, 0xd5, 0xed, 0xff, 0x2f, 0x38, 0xcb, 0x01, 0x93,
-	0xde, 0xda, 0xf5, 0xc0, 0xb8, 0x87, 0x7e, 0x36, 0xa0, 0x32, 0xd9, 0x40, 0x64, 0xe9, 0x28, 0x73,
-	0xfa, 0xa9, 0xdd, 0xbc, 0xc2, 0xa2, 0x63, 0x63, 0x19, 0xfb, 0x14, 0x7d, 0xb1, 0x20, 0x76, 0x23,
-	0x91, 0x15, 0x6f, 0xbc, 0xd1, 0x62, 0x7b, 0xdb, 0x48, 0x75, 0xc4, 0x1b, 0x6f, 0xa6, 0x74, 0x96,
-	0x64, 0x49, 0xbc, 0xc3, 0xee, 0x8f, 0xcd, 0x33, 0xbc, 0x0d, 0x45, 0x8f, 0x0e, 0x48, 0x32, 0xf0,
-	0xd7, 0xd1, 0x1a, 0xac, 0xd6, 0xca, 0x32, 0x09, 0x35, 0x44, 0xcf, 0x77, 0x61, 0x07, 0x0a, 0x87,
-	0x94, 0x30, 0xca, 0xd0, 0xfa, 0x4a, 0xae, 0xb6, 0x4a, 0x62, 0xf1, 0x22, 0x64, 0xfe, 0x6b, 0xf9,
-	0x41, 0xb7, 0x97, 0x3b, 0xaf, 0x00, 0x64, 0x84, 0x6b, 0xe7, 0x05, 0xf9, 0x9c, 0x3e, 0xfc, 0x27,
-	0x00, 0x00, 0xff, 0xff, 0x3c, 0xd9, 0x1a, 0x66, 0xb8, 0x0a, 0x00, 0x00,
+func _RunService_ApproveRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).ApproveRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/ApproveRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).ApproveRun(ctx, req.(*ApproveRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func _RunService_RejectRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RunServiceServer).RejectRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/RejectRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).RejectRun(ctx, req.(*RejectRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func _RunService_WatchRun_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RunServiceServer).WatchRun(m, &runServiceWatchRunServer{stream})
+}
 
-// RunServiceClient is the client API for RunService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RunServiceClient interface {
-	CreateRun(ctx context.Context, in *CreateRunRequest, opts ...grpc.CallOption) (*RunDetail, error)
-	GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunDetail, error)
-	ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
-	// ReportRunMetrics reports metrics of a run. Each metric is reported in its
-	// own transaction, so this API accepts partial failures. Metric can be uniquely
-	// identified by (run_id, node_id, name). Duplicate reporting will be
-	// ignored by the API. First reporting wins.
-	ReportRunMetrics(ctx context.Context, in *ReportRunMetricsRequest, opts ...grpc.CallOption) (*ReportRunMetricsResponse, error)
-	ReadArtifact(ctx context.Context, in *ReadArtifactRequest, opts ...grpc.CallOption) (*ReadArtifactResponse, error)
+type RunService_WatchRunServer interface {
+	Send(*RunDetail) error
+	grpc.ServerStream
 }
 
-type runServiceClient struct {
-	cc *grpc.ClientConn
+type runServiceWatchRunServer struct {
+	grpc.ServerStream
 }
 
-func NewRunServiceClient(cc *grpc.ClientConn) RunServiceClient {
-	return &runServiceClient{cc}
+func (x *runServiceWatchRunServer) Send(m *RunDetail) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *runServiceClient) CreateRun(ctx context.Context, in *CreateRunRequest, opts ...grpc.CallOption) (*RunDetail, error) {
-	out := new(RunDetail)
-	err := c.cc.Invoke(ctx, "/api.RunService/CreateRun", in, out, opts...)
-	if err != nil {
+func _RunService_EstimateRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateRunRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RunServiceServer).EstimateRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/EstimateRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).EstimateRun(ctx, req.(*EstimateRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *runServiceClient) GetRun(ctx context.Context, in *GetRunRequest, opts ...grpc.CallOption) (*RunDetail, error) {
-	out := new(RunDetail)
-	err := c.cc.Invoke(ctx, "/api.RunService/GetRun", in, out, opts...)
-	if err != nil {
+func _RunService_CreateRunSweep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRunSweepRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RunServiceServer).CreateRunSweep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/CreateRunSweep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).CreateRunSweep(ctx, req.(*CreateRunSweepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *runServiceClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
-	out := new(ListRunsResponse)
-	err := c.cc.Invoke(ctx, "/api.RunService/ListRuns", in, out, opts...)
-	if err != nil {
+func _RunService_GetRunSweep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunSweepRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetRunSweep(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/GetRunSweep",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetRunSweep(ctx, req.(*GetRunSweepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *runServiceClient) ReportRunMetrics(ctx context.Context, in *ReportRunMetricsRequest, opts ...grpc.CallOption) (*ReportRunMetricsResponse, error) {
-	out := new(ReportRunMetricsResponse)
-	err := c.cc.Invoke(ctx, "/api.RunService/ReportRunMetrics", in, out, opts...)
-	if err != nil {
+func _RunService_CreateRunGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRunGroupRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RunServiceServer).CreateRunGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/CreateRunGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).CreateRunGroup(ctx, req.(*CreateRunGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *runServiceClient) ReadArtifact(ctx context.Context, in *ReadArtifactRequest, opts ...grpc.CallOption) (*ReadArtifactResponse, error) {
-	out := new(ReadArtifactResponse)
-	err := c.cc.Invoke(ctx, "/api.RunService/ReadArtifact", in, out, opts...)
-	if err != nil {
+func _RunService_GetRunGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunGroupRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// RunServiceServer is the server API for RunService service.
-type RunServiceServer interface {
-	CreateRun(context.Context, *CreateRunRequest) (*RunDetail, error)
-	GetRun(context.Context, *GetRunRequest) (*RunDetail, error)
-	ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error)
-	// ReportRunMetrics reports metrics of a run. Each metric is reported in its
-	// own transaction, so this API accepts partial failures. Metric can be uniquely
-	// identified by (run_id, node_id, name). Duplicate reporting will be
-	// ignored by the API. First reporting wins.
-	ReportRunMetrics(context.Context, *ReportRunMetricsRequest) (*ReportRunMetricsResponse, error)
-	ReadArtifact(context.Context, *ReadArtifactRequest) (*ReadArtifactResponse, error)
-}
-
-func RegisterRunServiceServer(s *grpc.Server, srv RunServiceServer) {
-	s.RegisterService(&_RunService_serviceDesc, srv)
+	if interceptor == nil {
+		return srv.(RunServiceServer).GetRunGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.RunService/GetRunGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RunServiceServer).GetRunGroup(ctx, req.(*GetRunGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _RunService_CreateRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRunRequest)
+func _RunService_ListRunGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunGroupsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RunServiceServer).CreateRun(ctx, in)
+		return srv.(RunServiceServer).ListRunGroups(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.RunService/CreateRun",
+		FullMethod: "/api.RunService/ListRunGroups",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RunServiceServer).CreateRun(ctx, req.(*CreateRunRequest))
+		return srv.(RunServiceServer).ListRunGroups(ctx, req.(*ListRunGroupsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RunService_GetRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRunRequest)
+func _RunService_AddRunsToRunGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRunsToRunGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RunServiceServer).GetRun(ctx, in)
+		return srv.(RunServiceServer).AddRunsToRunGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.RunService/GetRun",
+		FullMethod: "/api.RunService/AddRunsToRunGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RunServiceServer).GetRun(ctx, req.(*GetRunRequest))
+		return srv.(RunServiceServer).AddRunsToRunGroup(ctx, req.(*AddRunsToRunGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RunService_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRunsRequest)
+func _RunService_CancelRunGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRunGroupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RunServiceServer).ListRuns(ctx, in)
+		return srv.(RunServiceServer).CancelRunGroup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.RunService/ListRuns",
+		FullMethod: "/api.RunService/CancelRunGroup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RunServiceServer).ListRuns(ctx, req.(*ListRunsRequest))
+		return srv.(RunServiceServer).CancelRunGroup(ctx, req.(*CancelRunGroupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RunService_ReportRunMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReportRunMetricsRequest)
+func _RunService_TerminateAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TerminateAllRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RunServiceServer).ReportRunMetrics(ctx, in)
+		return srv.(RunServiceServer).TerminateAll(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.RunService/ReportRunMetrics",
+		FullMethod: "/api.RunService/TerminateAll",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RunServiceServer).ReportRunMetrics(ctx, req.(*ReportRunMetricsRequest))
+		return srv.(RunServiceServer).TerminateAll(ctx, req.(*TerminateAllRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RunService_ReadArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReadArtifactRequest)
+func _RunService_GetTerminateAllOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTerminateAllOperationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RunServiceServer).ReadArtifact(ctx, in)
+		return srv.(RunServiceServer).GetTerminateAllOperation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.RunService/ReadArtifact",
+		FullMethod: "/api.RunService/GetTerminateAllOperation",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RunServiceServer).ReadArtifact(ctx, req.(*ReadArtifactRequest))
+		return srv.(RunServiceServer).GetTerminateAllOperation(ctx, req.(*GetTerminateAllOperationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1244,7 +5037,93 @@ var _RunService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReadArtifact",
 			Handler:    _RunService_ReadArtifact_Handler,
 		},
+		{
+			MethodName: "GetRunOutputs",
+			Handler:    _RunService_GetRunOutputs_Handler,
+		},
+		{
+			MethodName: "GetRunHistory",
+			Handler:    _RunService_GetRunHistory_Handler,
+		},
+		{
+			MethodName: "GetRunManifest",
+			Handler:    _RunService_GetRunManifest_Handler,
+		},
+		{
+			MethodName: "ReadRunLogs",
+			Handler:    _RunService_ReadRunLogs_Handler,
+		},
+		{
+			MethodName: "ReportRunLogs",
+			Handler:    _RunService_ReportRunLogs_Handler,
+		},
+		{
+			MethodName: "UpdateRun",
+			Handler:    _RunService_UpdateRun_Handler,
+		},
+		{
+			MethodName: "StarRun",
+			Handler:    _RunService_StarRun_Handler,
+		},
+		{
+			MethodName: "UnstarRun",
+			Handler:    _RunService_UnstarRun_Handler,
+		},
+		{
+			MethodName: "ApproveRun",
+			Handler:    _RunService_ApproveRun_Handler,
+		},
+		{
+			MethodName: "RejectRun",
+			Handler:    _RunService_RejectRun_Handler,
+		},
+		{
+			MethodName: "EstimateRun",
+			Handler:    _RunService_EstimateRun_Handler,
+		},
+		{
+			MethodName: "CreateRunSweep",
+			Handler:    _RunService_CreateRunSweep_Handler,
+		},
+		{
+			MethodName: "GetRunSweep",
+			Handler:    _RunService_GetRunSweep_Handler,
+		},
+		{
+			MethodName: "CreateRunGroup",
+			Handler:    _RunService_CreateRunGroup_Handler,
+		},
+		{
+			MethodName: "GetRunGroup",
+			Handler:    _RunService_GetRunGroup_Handler,
+		},
+		{
+			MethodName: "ListRunGroups",
+			Handler:    _RunService_ListRunGroups_Handler,
+		},
+		{
+			MethodName: "AddRunsToRunGroup",
+			Handler:    _RunService_AddRunsToRunGroup_Handler,
+		},
+		{
+			MethodName: "CancelRunGroup",
+			Handler:    _RunService_CancelRunGroup_Handler,
+		},
+		{
+			MethodName: "TerminateAll",
+			Handler:    _RunService_TerminateAll_Handler,
+		},
+		{
+			MethodName: "GetTerminateAllOperation",
+			Handler:    _RunService_GetTerminateAllOperation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRun",
+			Handler:       _RunService_WatchRun_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "run.proto",
 }
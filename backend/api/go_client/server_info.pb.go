@@ -0,0 +1,442 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: server_info.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Feature_Stage int32
+
+const (
+	Feature_STAGE_UNSPECIFIED Feature_Stage = 0
+	// Disabled by default, and may change or go away.
+	Feature_ALPHA Feature_Stage = 1
+	// Enabled by default, and can still be disabled.
+	Feature_BETA Feature_Stage = 2
+	// Always enabled.
+	Feature_GA Feature_Stage = 3
+)
+
+var Feature_Stage_name = map[int32]string{
+	0: "STAGE_UNSPECIFIED",
+	1: "ALPHA",
+	2: "BETA",
+	3: "GA",
+}
+
+var Feature_Stage_value = map[string]int32{
+	"STAGE_UNSPECIFIED": 0,
+	"ALPHA":             1,
+	"BETA":              2,
+	"GA":                3,
+}
+
+func (x Feature_Stage) String() string {
+	return proto.EnumName(Feature_Stage_name, int32(x))
+}
+
+func (Feature_Stage) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ab092e4c3e802d64, []int{1, 0}
+}
+
+type GetServerInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetServerInfoRequest) Reset()         { *m = GetServerInfoRequest{} }
+func (m *GetServerInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetServerInfoRequest) ProtoMessage()    {}
+func (*GetServerInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab092e4c3e802d64, []int{0}
+}
+
+func (m *GetServerInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetServerInfoRequest.Unmarshal(m, b)
+}
+func (m *GetServerInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetServerInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *GetServerInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetServerInfoRequest.Merge(m, src)
+}
+func (m *GetServerInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_GetServerInfoRequest.Size(m)
+}
+func (m *GetServerInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetServerInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetServerInfoRequest proto.InternalMessageInfo
+
+type Feature struct {
+	// The name of the feature, e.g. Caching.
+	Name    string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled bool          `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Stage   Feature_Stage `protobuf:"varint,3,opt,name=stage,proto3,enum=api.Feature_Stage" json:"stage,omitempty"`
+	// Whether the feature is enabled unless set by the FeatureConfig.Gates
+	// setting of the API server.
+	Default              bool     `protobuf:"varint,4,opt,name=default,proto3" json:"default,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Feature) Reset()         { *m = Feature{} }
+func (m *Feature) String() string { return proto.CompactTextString(m) }
+func (*Feature) ProtoMessage()    {}
+func (*Feature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab092e4c3e802d64, []int{1}
+}
+
+func (m *Feature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Feature.Unmarshal(m, b)
+}
+func (m *Feature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Feature.Marshal(b, m, deterministic)
+}
+func (m *Feature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Feature.Merge(m, src)
+}
+func (m *Feature) XXX_Size() int {
+	return xxx_messageInfo_Feature.Size(m)
+}
+func (m *Feature) XXX_DiscardUnknown() {
+	xxx_messageInfo_Feature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Feature proto.InternalMessageInfo
+
+func (m *Feature) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Feature) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *Feature) GetStage() Feature_Stage {
+	if m != nil {
+		return m.Stage
+	}
+	return Feature_STAGE_UNSPECIFIED
+}
+
+func (m *Feature) GetDefault() bool {
+	if m != nil {
+		return m.Default
+	}
+	return false
+}
+
+type ServerLimits struct {
+	// The maximum size in bytes of the pipeline files uploaded.
+	MaxUploadSize int64 `protobuf:"varint,1,opt,name=max_upload_size,json=maxUploadSize,proto3" json:"max_upload_size,omitempty"`
+	// The maximum size in bytes of the gRPC requests.
+	MaxRequestSize int64 `protobuf:"varint,2,opt,name=max_request_size,json=maxRequestSize,proto3" json:"max_request_size,omitempty"`
+	// The maximum page size of the list calls. The larger page sizes are
+	// reduced to it.
+	MaxPageSize int32 `protobuf:"varint,3,opt,name=max_page_size,json=maxPageSize,proto3" json:"max_page_size,omitempty"`
+	// The maximum length in characters of the names of the resources.
+	MaxNameLength        int32    `protobuf:"varint,4,opt,name=max_name_length,json=maxNameLength,proto3" json:"max_name_length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServerLimits) Reset()         { *m = ServerLimits{} }
+func (m *ServerLimits) String() string { return proto.CompactTextString(m) }
+func (*ServerLimits) ProtoMessage()    {}
+func (*ServerLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab092e4c3e802d64, []int{2}
+}
+
+func (m *ServerLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerLimits.Unmarshal(m, b)
+}
+func (m *ServerLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerLimits.Marshal(b, m, deterministic)
+}
+func (m *ServerLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerLimits.Merge(m, src)
+}
+func (m *ServerLimits) XXX_Size() int {
+	return xxx_messageInfo_ServerLimits.Size(m)
+}
+func (m *ServerLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerLimits proto.InternalMessageInfo
+
+func (m *ServerLimits) GetMaxUploadSize() int64 {
+	if m != nil {
+		return m.MaxUploadSize
+	}
+	return 0
+}
+
+func (m *ServerLimits) GetMaxRequestSize() int64 {
+	if m != nil {
+		return m.MaxRequestSize
+	}
+	return 0
+}
+
+func (m *ServerLimits) GetMaxPageSize() int32 {
+	if m != nil {
+		return m.MaxPageSize
+	}
+	return 0
+}
+
+func (m *ServerLimits) GetMaxNameLength() int32 {
+	if m != nil {
+		return m.MaxNameLength
+	}
+	return 0
+}
+
+type ServerInfo struct {
+	// The features gated while they land, sorted by name. A client detects a
+	// capability by its feature being enabled.
+	Features []*Feature `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	// The release the API server was built for, e.g. 0.1.20, or unknown.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// The commit the API server was built from, or unknown.
+	CommitSha string `protobuf:"bytes,3,opt,name=commit_sha,json=commitSha,proto3" json:"commit_sha,omitempty"`
+	// The versions of the API served, e.g. v1beta1.
+	ApiVersions          []string      `protobuf:"bytes,4,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"`
+	Limits               *ServerLimits `protobuf:"bytes,5,opt,name=limits,proto3" json:"limits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ServerInfo) Reset()         { *m = ServerInfo{} }
+func (m *ServerInfo) String() string { return proto.CompactTextString(m) }
+func (*ServerInfo) ProtoMessage()    {}
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab092e4c3e802d64, []int{3}
+}
+
+func (m *ServerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerInfo.Unmarshal(m, b)
+}
+func (m *ServerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerInfo.Marshal(b, m, deterministic)
+}
+func (m *ServerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerInfo.Merge(m, src)
+}
+func (m *ServerInfo) XXX_Size() int {
+	return xxx_messageInfo_ServerInfo.Size(m)
+}
+func (m *ServerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerInfo proto.InternalMessageInfo
+
+func (m *ServerInfo) GetFeatures() []*Feature {
+	if m != nil {
+		return m.Features
+	}
+	return nil
+}
+
+func (m *ServerInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetCommitSha() string {
+	if m != nil {
+		return m.CommitSha
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetApiVersions() []string {
+	if m != nil {
+		return m.ApiVersions
+	}
+	return nil
+}
+
+func (m *ServerInfo) GetLimits() *ServerLimits {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.Feature_Stage", Feature_Stage_name, Feature_Stage_value)
+	proto.RegisterType((*GetServerInfoRequest)(nil), "api.GetServerInfoRequest")
+	proto.RegisterType((*Feature)(nil), "api.Feature")
+	proto.RegisterType((*ServerLimits)(nil), "api.ServerLimits")
+	proto.RegisterType((*ServerInfo)(nil), "api.ServerInfo")
+}
+
+func init() { proto.RegisterFile("server_info.proto", fileDescriptor_ab092e4c3e802d64) }
+
+var fileDescriptor_ab092e4c3e802d64 = []byte{
+	// 571 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x6b, 0xe7, 0x4f, 0x93, 0x49, 0xd2, 0x26, 0x4b, 0x41, 0x26, 0x6a, 0x85, 0xf1, 0x01,
+	0x19, 0x89, 0x26, 0x6a, 0x38, 0x72, 0x4a, 0x21, 0x2d, 0x95, 0x4a, 0x15, 0x39, 0x2d, 0x48, 0x5c,
+	0xac, 0x49, 0x3b, 0x71, 0x56, 0xb2, 0xbd, 0xc6, 0xbb, 0x2e, 0x51, 0x8f, 0x3c, 0x02, 0xbc, 0x06,
+	0x0f, 0xc0, 0x85, 0xa7, 0xe0, 0x15, 0x78, 0x10, 0xe4, 0xb5, 0xdb, 0x06, 0xc1, 0xc9, 0x3b, 0xb3,
+	0x3f, 0x7f, 0x33, 0xf3, 0x69, 0x16, 0x7a, 0x92, 0xd2, 0x6b, 0x4a, 0x7d, 0x1e, 0x2f, 0xc4, 0x20,
+	0x49, 0x85, 0x12, 0xac, 0x82, 0x09, 0xef, 0xef, 0x06, 0x42, 0x04, 0x21, 0x0d, 0x31, 0xe1, 0x43,
+	0x8c, 0x63, 0xa1, 0x50, 0x71, 0x11, 0xcb, 0x02, 0xe9, 0xbf, 0xd0, 0x9f, 0xcb, 0xfd, 0x80, 0xe2,
+	0x7d, 0xf9, 0x19, 0x83, 0x80, 0xd2, 0xa1, 0x48, 0x34, 0xf1, 0x2f, 0xed, 0x3c, 0x82, 0x9d, 0x63,
+	0x52, 0x33, 0x5d, 0xe8, 0x24, 0x5e, 0x08, 0x8f, 0x3e, 0x65, 0x24, 0x95, 0xf3, 0xc3, 0x80, 0xcd,
+	0x23, 0x42, 0x95, 0xa5, 0xc4, 0x18, 0x54, 0x63, 0x8c, 0xc8, 0x32, 0x6c, 0xc3, 0x6d, 0x7a, 0xfa,
+	0xcc, 0x2c, 0xd8, 0xa4, 0x18, 0xe7, 0x21, 0x5d, 0x59, 0xa6, 0x6d, 0xb8, 0x0d, 0xef, 0x36, 0x64,
+	0x2e, 0xd4, 0xa4, 0xc2, 0x80, 0xac, 0x8a, 0x6d, 0xb8, 0x5b, 0x23, 0x36, 0xc0, 0x84, 0x0f, 0x4a,
+	0xa9, 0xc1, 0x2c, 0xbf, 0xf1, 0x0a, 0x20, 0xd7, 0xb8, 0xa2, 0x05, 0x66, 0xa1, 0xb2, 0xaa, 0x85,
+	0x46, 0x19, 0x3a, 0xaf, 0xa0, 0xa6, 0x49, 0xf6, 0x10, 0x7a, 0xb3, 0xf3, 0xf1, 0xf1, 0xc4, 0xbf,
+	0x38, 0x9b, 0x4d, 0x27, 0xaf, 0x4f, 0x8e, 0x4e, 0x26, 0x6f, 0xba, 0x1b, 0xac, 0x09, 0xb5, 0xf1,
+	0xe9, 0xf4, 0xed, 0xb8, 0x6b, 0xb0, 0x06, 0x54, 0x0f, 0x27, 0xe7, 0xe3, 0xae, 0xc9, 0xea, 0x60,
+	0x1e, 0x8f, 0xbb, 0x15, 0xe7, 0xbb, 0x01, 0xed, 0x62, 0xa0, 0x53, 0x1e, 0x71, 0x25, 0xd9, 0x33,
+	0xd8, 0x8e, 0x70, 0xe5, 0x67, 0x49, 0x28, 0xf0, 0xca, 0x97, 0xfc, 0xa6, 0x18, 0xa5, 0xe2, 0x75,
+	0x22, 0x5c, 0x5d, 0xe8, 0xec, 0x8c, 0xdf, 0x10, 0x73, 0xa1, 0x9b, 0x73, 0x69, 0x61, 0x41, 0x01,
+	0x9a, 0x1a, 0xdc, 0x8a, 0x70, 0x55, 0x3a, 0xa3, 0x49, 0x07, 0xf2, 0x5f, 0xfd, 0x04, 0x03, 0x2a,
+	0xb0, 0x7c, 0xd6, 0x9a, 0xd7, 0x8a, 0x70, 0x35, 0xc5, 0x80, 0x34, 0x53, 0x56, 0xcd, 0xdd, 0xf2,
+	0x43, 0x8a, 0x03, 0xb5, 0xd4, 0x53, 0xd6, 0x74, 0xd5, 0x33, 0x8c, 0xe8, 0x54, 0x27, 0x9d, 0x9f,
+	0x06, 0xc0, 0xbd, 0xff, 0xcc, 0x85, 0xc6, 0xa2, 0x30, 0x4b, 0x5a, 0x86, 0x5d, 0x71, 0x5b, 0xa3,
+	0xf6, 0xba, 0x83, 0xde, 0xdd, 0x6d, 0x6e, 0xdf, 0x35, 0xa5, 0x92, 0x8b, 0x58, 0x77, 0xd9, 0xf4,
+	0x6e, 0x43, 0xb6, 0x07, 0x70, 0x29, 0xa2, 0x88, 0x2b, 0x5f, 0x2e, 0x51, 0xf7, 0xd6, 0xf4, 0x9a,
+	0x45, 0x66, 0xb6, 0x44, 0xf6, 0x14, 0xda, 0x98, 0x70, 0xbf, 0xa4, 0xa5, 0x55, 0xb5, 0x2b, 0x6e,
+	0xd3, 0x6b, 0x61, 0xc2, 0xdf, 0x97, 0x29, 0xf6, 0x1c, 0xea, 0xa1, 0x36, 0xcf, 0xaa, 0xd9, 0x86,
+	0xdb, 0x1a, 0xf5, 0x74, 0x0f, 0xeb, 0xae, 0x7a, 0x25, 0x30, 0x0a, 0xa1, 0x77, 0xdf, 0x7e, 0x7e,
+	0xe2, 0x97, 0xc4, 0x3e, 0x40, 0xe7, 0xaf, 0xb5, 0x62, 0x8f, 0xb5, 0xc0, 0xff, 0x56, 0xad, 0xbf,
+	0xbd, 0xa6, 0x9d, 0xe7, 0x9d, 0xfe, 0x97, 0x5f, 0xbf, 0xbf, 0x99, 0x3b, 0x8c, 0xe5, 0x1b, 0x2e,
+	0x87, 0xd7, 0x07, 0x73, 0x52, 0x78, 0x30, 0xcc, 0x9f, 0xc1, 0xe1, 0xf4, 0xeb, 0xf8, 0x9d, 0xb7,
+	0x7b, 0xb7, 0x38, 0xac, 0xc7, 0xb6, 0xa1, 0xd3, 0x6f, 0x15, 0x12, 0x0a, 0x55, 0x26, 0x3f, 0x3e,
+	0x81, 0x3d, 0xa8, 0x1f, 0x12, 0xa6, 0x94, 0xb2, 0x07, 0x0d, 0xb3, 0xdf, 0xc1, 0x4c, 0x2d, 0x45,
+	0xca, 0x6f, 0xf4, 0xe2, 0xdb, 0xe6, 0xbc, 0x0d, 0x70, 0x07, 0x6c, 0xcc, 0xeb, 0xfa, 0x21, 0xbc,
+	0xfc, 0x33, 0x00, 0x77, 0x71, 0xac, 0x21, 0x6e, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ServerInfoServiceClient is the client API for ServerInfoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ServerInfoServiceClient interface {
+	// Returns the version, the features and the limits of the API server. The
+	// call isn't checked against the authorization policy, so that the clients
+	// can call it before they're set up.
+	GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error)
+}
+
+type serverInfoServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewServerInfoServiceClient(cc *grpc.ClientConn) ServerInfoServiceClient {
+	return &serverInfoServiceClient{cc}
+}
+
+func (c *serverInfoServiceClient) GetServerInfo(ctx context.Context, in *GetServerInfoRequest, opts ...grpc.CallOption) (*ServerInfo, error) {
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, "/api.ServerInfoService/GetServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ServerInfoServiceServer is the server API for ServerInfoService service.
+type ServerInfoServiceServer interface {
+	// Returns the version, the features and the limits of the API server. The
+	// call isn't checked against the authorization policy, so that the clients
+	// can call it before they're set up.
+	GetServerInfo(context.Context, *GetServerInfoRequest) (*ServerInfo, error)
+}
+
+func RegisterServerInfoServiceServer(s *grpc.Server, srv ServerInfoServiceServer) {
+	s.RegisterService(&_ServerInfoService_serviceDesc, srv)
+}
+
+func _ServerInfoService_GetServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerInfoServiceServer).GetServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.ServerInfoService/GetServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerInfoServiceServer).GetServerInfo(ctx, req.(*GetServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ServerInfoService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.ServerInfoService",
+	HandlerType: (*ServerInfoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServerInfo",
+			Handler:    _ServerInfoService_GetServerInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "server_info.proto",
+}
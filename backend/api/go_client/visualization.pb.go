@@ -0,0 +1,303 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: visualization.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Visualization_Type int32
+
+const (
+	Visualization_UNSPECIFIED Visualization_Type = 0
+	// The ROC curve and its AUC, from a CSV of the labels and the scores
+	// predicted for them.
+	Visualization_ROC_CURVE Visualization_Type = 1
+	// The confusion matrix, from a CSV of the labels and the labels predicted
+	// for them.
+	Visualization_CONFUSION_MATRIX Visualization_Type = 2
+	// The first rows of a CSV.
+	Visualization_TABLE Visualization_Type = 3
+)
+
+var Visualization_Type_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "ROC_CURVE",
+	2: "CONFUSION_MATRIX",
+	3: "TABLE",
+}
+
+var Visualization_Type_value = map[string]int32{
+	"UNSPECIFIED":      0,
+	"ROC_CURVE":        1,
+	"CONFUSION_MATRIX": 2,
+	"TABLE":            3,
+}
+
+func (x Visualization_Type) String() string {
+	return proto.EnumName(Visualization_Type_name, int32(x))
+}
+
+func (Visualization_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_637c8cd57f045b32, []int{1, 0}
+}
+
+type CreateVisualizationRequest struct {
+	Visualization        *Visualization `protobuf:"bytes,1,opt,name=visualization,proto3" json:"visualization,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CreateVisualizationRequest) Reset()         { *m = CreateVisualizationRequest{} }
+func (m *CreateVisualizationRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateVisualizationRequest) ProtoMessage()    {}
+func (*CreateVisualizationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_637c8cd57f045b32, []int{0}
+}
+
+func (m *CreateVisualizationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateVisualizationRequest.Unmarshal(m, b)
+}
+func (m *CreateVisualizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateVisualizationRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateVisualizationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateVisualizationRequest.Merge(m, src)
+}
+func (m *CreateVisualizationRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateVisualizationRequest.Size(m)
+}
+func (m *CreateVisualizationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateVisualizationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateVisualizationRequest proto.InternalMessageInfo
+
+func (m *CreateVisualizationRequest) GetVisualization() *Visualization {
+	if m != nil {
+		return m.Visualization
+	}
+	return nil
+}
+
+type Visualization struct {
+	Type Visualization_Type `protobuf:"varint,1,opt,name=type,proto3,enum=api.Visualization_Type" json:"type,omitempty"`
+	// The URI of the artifact visualized, e.g.
+	// minio://mlpipeline/artifacts/predictions.csv. Only the artifacts of the
+	// object store of the pipelines can be visualized.
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// The arguments of the visualization, as a JSON object, e.g.
+	// {"target_column": "label", "score_column": "probability"}.
+	Arguments string `protobuf:"bytes,3,opt,name=arguments,proto3" json:"arguments,omitempty"`
+	// Output. The HTML of the visualization.
+	Html string `protobuf:"bytes,4,opt,name=html,proto3" json:"html,omitempty"`
+	// Output. Why the visualization couldn't be generated, if it couldn't.
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Visualization) Reset()         { *m = Visualization{} }
+func (m *Visualization) String() string { return proto.CompactTextString(m) }
+func (*Visualization) ProtoMessage()    {}
+func (*Visualization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_637c8cd57f045b32, []int{1}
+}
+
+func (m *Visualization) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Visualization.Unmarshal(m, b)
+}
+func (m *Visualization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Visualization.Marshal(b, m, deterministic)
+}
+func (m *Visualization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Visualization.Merge(m, src)
+}
+func (m *Visualization) XXX_Size() int {
+	return xxx_messageInfo_Visualization.Size(m)
+}
+func (m *Visualization) XXX_DiscardUnknown() {
+	xxx_messageInfo_Visualization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Visualization proto.InternalMessageInfo
+
+func (m *Visualization) GetType() Visualization_Type {
+	if m != nil {
+		return m.Type
+	}
+	return Visualization_UNSPECIFIED
+}
+
+func (m *Visualization) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Visualization) GetArguments() string {
+	if m != nil {
+		return m.Arguments
+	}
+	return ""
+}
+
+func (m *Visualization) GetHtml() string {
+	if m != nil {
+		return m.Html
+	}
+	return ""
+}
+
+func (m *Visualization) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("api.Visualization_Type", Visualization_Type_name, Visualization_Type_value)
+	proto.RegisterType((*CreateVisualizationRequest)(nil), "api.CreateVisualizationRequest")
+	proto.RegisterType((*Visualization)(nil), "api.Visualization")
+}
+
+func init() { proto.RegisterFile("visualization.proto", fileDescriptor_637c8cd57f045b32) }
+
+var fileDescriptor_637c8cd57f045b32 = []byte{
+	// 422 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x6b, 0xe7, 0x0f, 0x64, 0x82, 0xa9, 0x99, 0x44, 0x60, 0x59, 0x41, 0x8d, 0x7c, 0xaa,
+	0x04, 0x89, 0xd5, 0xf4, 0x82, 0xb8, 0x25, 0x26, 0x45, 0x96, 0x68, 0x52, 0xd9, 0x49, 0x84, 0xb8,
+	0x54, 0x9b, 0x30, 0xb8, 0x96, 0x5c, 0xaf, 0xd9, 0x5d, 0x07, 0x95, 0x0b, 0x12, 0x8f, 0x00, 0x07,
+	0x1e, 0x8c, 0x57, 0x80, 0xf7, 0x40, 0xdd, 0x56, 0x80, 0xd5, 0xf6, 0x64, 0xcf, 0xcc, 0x6f, 0xbf,
+	0xd9, 0xf9, 0x66, 0xa1, 0xb3, 0x4d, 0x65, 0xc9, 0xb2, 0xf4, 0x33, 0x53, 0x29, 0xcf, 0x87, 0x85,
+	0xe0, 0x8a, 0x63, 0x8d, 0x15, 0xa9, 0xdb, 0x4b, 0x38, 0x4f, 0x32, 0xf2, 0x59, 0x91, 0xfa, 0x2c,
+	0xcf, 0xb9, 0xd2, 0x84, 0xbc, 0x42, 0xdc, 0xe7, 0xfa, 0xb3, 0x19, 0x24, 0x94, 0x0f, 0xe4, 0x27,
+	0x96, 0x24, 0x24, 0x7c, 0x5e, 0x68, 0xe2, 0x26, 0xed, 0xad, 0xc0, 0x0d, 0x04, 0x31, 0x45, 0xab,
+	0xff, 0xbb, 0x45, 0xf4, 0xb1, 0x24, 0xa9, 0xf0, 0x05, 0x58, 0x95, 0x5b, 0x38, 0x46, 0xdf, 0xd8,
+	0x6f, 0x8f, 0x70, 0xc8, 0x8a, 0x74, 0x58, 0x3d, 0x51, 0x05, 0xbd, 0xdf, 0x06, 0x58, 0x15, 0x00,
+	0x9f, 0x41, 0x5d, 0x5d, 0x14, 0xa4, 0x25, 0x1e, 0x8e, 0x9e, 0xdc, 0x94, 0x18, 0x2e, 0x2e, 0x0a,
+	0x8a, 0x34, 0x84, 0x8f, 0xa1, 0x29, 0x79, 0x29, 0x36, 0xe4, 0x98, 0x7d, 0x63, 0xbf, 0x15, 0x5d,
+	0x47, 0xd8, 0x83, 0x16, 0x13, 0x49, 0x79, 0x4e, 0xb9, 0x92, 0x4e, 0x4d, 0x97, 0xfe, 0x25, 0x10,
+	0xa1, 0x7e, 0xa6, 0xce, 0x33, 0xa7, 0xae, 0x0b, 0xfa, 0x1f, 0xbb, 0xd0, 0x20, 0x21, 0xb8, 0x70,
+	0x1a, 0x3a, 0x79, 0x15, 0x78, 0xaf, 0xa1, 0x7e, 0xd9, 0x0d, 0x77, 0xa1, 0xbd, 0x9c, 0xc5, 0x27,
+	0xd3, 0x20, 0x3c, 0x0a, 0xa7, 0xaf, 0xec, 0x1d, 0xb4, 0xa0, 0x15, 0xcd, 0x83, 0xd3, 0x60, 0x19,
+	0xad, 0xa6, 0xb6, 0x81, 0x5d, 0xb0, 0x83, 0xf9, 0xec, 0x68, 0x19, 0x87, 0xf3, 0xd9, 0xe9, 0xf1,
+	0x78, 0x11, 0x85, 0x6f, 0x6d, 0x13, 0x5b, 0xd0, 0x58, 0x8c, 0x27, 0x6f, 0xa6, 0x76, 0x6d, 0xf4,
+	0xc3, 0x80, 0x6e, 0x65, 0x8a, 0x98, 0xc4, 0x36, 0xdd, 0x10, 0x7e, 0x81, 0xce, 0x2d, 0xc6, 0xe2,
+	0x9e, 0x9e, 0xfb, 0x6e, 0xcb, 0xdd, 0x5b, 0xbc, 0xf5, 0x0e, 0xbf, 0xfe, 0xfc, 0xf5, 0xdd, 0x1c,
+	0x78, 0xbd, 0xcb, 0x95, 0x4b, 0x7f, 0x7b, 0xb0, 0x26, 0xc5, 0x0e, 0xfc, 0x8a, 0xe3, 0xf2, 0x65,
+	0x75, 0x03, 0x93, 0x93, 0x6f, 0xe3, 0xe3, 0xa8, 0x07, 0xf7, 0xde, 0xd3, 0x07, 0x56, 0x66, 0x0a,
+	0x1f, 0xe1, 0x2e, 0x58, 0x6e, 0x5b, 0xcb, 0xc7, 0x8a, 0xa9, 0x52, 0xbe, 0xdb, 0x83, 0xa7, 0xd0,
+	0x9c, 0x10, 0x13, 0x24, 0xb0, 0x73, 0xdf, 0x74, 0x2d, 0x56, 0xaa, 0x33, 0x2e, 0xae, 0x15, 0xfa,
+	0xe6, 0xfa, 0x01, 0xc0, 0x5f, 0x60, 0x67, 0xdd, 0xd4, 0x4f, 0xe6, 0xf0, 0xcf, 0x00, 0x91, 0x94,
+	0x7f, 0xee, 0x9a, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// VisualizationServiceClient is the client API for VisualizationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type VisualizationServiceClient interface {
+	CreateVisualization(ctx context.Context, in *CreateVisualizationRequest, opts ...grpc.CallOption) (*Visualization, error)
+}
+
+type visualizationServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewVisualizationServiceClient(cc *grpc.ClientConn) VisualizationServiceClient {
+	return &visualizationServiceClient{cc}
+}
+
+func (c *visualizationServiceClient) CreateVisualization(ctx context.Context, in *CreateVisualizationRequest, opts ...grpc.CallOption) (*Visualization, error) {
+	out := new(Visualization)
+	err := c.cc.Invoke(ctx, "/api.VisualizationService/CreateVisualization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VisualizationServiceServer is the server API for VisualizationService service.
+type VisualizationServiceServer interface {
+	CreateVisualization(context.Context, *CreateVisualizationRequest) (*Visualization, error)
+}
+
+func RegisterVisualizationServiceServer(s *grpc.Server, srv VisualizationServiceServer) {
+	s.RegisterService(&_VisualizationService_serviceDesc, srv)
+}
+
+func _VisualizationService_CreateVisualization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVisualizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VisualizationServiceServer).CreateVisualization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.VisualizationService/CreateVisualization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VisualizationServiceServer).CreateVisualization(ctx, req.(*CreateVisualizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _VisualizationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.VisualizationService",
+	HandlerType: (*VisualizationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateVisualization",
+			Handler:    _VisualizationService_CreateVisualization_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "visualization.proto",
+}
@@ -0,0 +1,632 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: webhook.proto
+
+package api
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/protoc-gen-swagger/options"
+	context "golang.org/x/net/context"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Webhook_Event int32
+
+const (
+	Webhook_UNKNOWN_EVENT Webhook_Event = 0
+	// The run completed successfully.
+	Webhook_RUN_SUCCEEDED Webhook_Event = 1
+	// The run failed or hit an error.
+	Webhook_RUN_FAILED Webhook_Event = 2
+	// An approval gate of the run started waiting for a decision.
+	Webhook_RUN_AWAITING_APPROVAL Webhook_Event = 3
+)
+
+var Webhook_Event_name = map[int32]string{
+	0: "UNKNOWN_EVENT",
+	1: "RUN_SUCCEEDED",
+	2: "RUN_FAILED",
+	3: "RUN_AWAITING_APPROVAL",
+}
+
+var Webhook_Event_value = map[string]int32{
+	"UNKNOWN_EVENT":         0,
+	"RUN_SUCCEEDED":         1,
+	"RUN_FAILED":            2,
+	"RUN_AWAITING_APPROVAL": 3,
+}
+
+func (x Webhook_Event) String() string {
+	return proto.EnumName(Webhook_Event_name, int32(x))
+}
+
+func (Webhook_Event) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{5, 0}
+}
+
+type CreateWebhookRequest struct {
+	Webhook              *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateWebhookRequest) Reset()         { *m = CreateWebhookRequest{} }
+func (m *CreateWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateWebhookRequest) ProtoMessage()    {}
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{0}
+}
+
+func (m *CreateWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateWebhookRequest.Unmarshal(m, b)
+}
+func (m *CreateWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateWebhookRequest.Merge(m, src)
+}
+func (m *CreateWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateWebhookRequest.Size(m)
+}
+func (m *CreateWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateWebhookRequest proto.InternalMessageInfo
+
+func (m *CreateWebhookRequest) GetWebhook() *Webhook {
+	if m != nil {
+		return m.Webhook
+	}
+	return nil
+}
+
+type GetWebhookRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetWebhookRequest) Reset()         { *m = GetWebhookRequest{} }
+func (m *GetWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*GetWebhookRequest) ProtoMessage()    {}
+func (*GetWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{1}
+}
+
+func (m *GetWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetWebhookRequest.Unmarshal(m, b)
+}
+func (m *GetWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *GetWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetWebhookRequest.Merge(m, src)
+}
+func (m *GetWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_GetWebhookRequest.Size(m)
+}
+func (m *GetWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetWebhookRequest proto.InternalMessageInfo
+
+func (m *GetWebhookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type ListWebhooksRequest struct {
+	PageToken string `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Can be format of "field_name", "field_name asc" or "field_name des"
+	// Ascending by default.
+	SortBy               string   `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhooksRequest) Reset()         { *m = ListWebhooksRequest{} }
+func (m *ListWebhooksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksRequest) ProtoMessage()    {}
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{2}
+}
+
+func (m *ListWebhooksRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksRequest.Unmarshal(m, b)
+}
+func (m *ListWebhooksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksRequest.Merge(m, src)
+}
+func (m *ListWebhooksRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksRequest.Size(m)
+}
+func (m *ListWebhooksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksRequest proto.InternalMessageInfo
+
+func (m *ListWebhooksRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListWebhooksRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListWebhooksRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+type ListWebhooksResponse struct {
+	Webhooks             []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	NextPageToken        string     `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListWebhooksResponse) Reset()         { *m = ListWebhooksResponse{} }
+func (m *ListWebhooksResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhooksResponse) ProtoMessage()    {}
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{3}
+}
+
+func (m *ListWebhooksResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhooksResponse.Unmarshal(m, b)
+}
+func (m *ListWebhooksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhooksResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhooksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhooksResponse.Merge(m, src)
+}
+func (m *ListWebhooksResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhooksResponse.Size(m)
+}
+func (m *ListWebhooksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhooksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhooksResponse proto.InternalMessageInfo
+
+func (m *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if m != nil {
+		return m.Webhooks
+	}
+	return nil
+}
+
+func (m *ListWebhooksResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type DeleteWebhookRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteWebhookRequest) Reset()         { *m = DeleteWebhookRequest{} }
+func (m *DeleteWebhookRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteWebhookRequest) ProtoMessage()    {}
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{4}
+}
+
+func (m *DeleteWebhookRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteWebhookRequest.Unmarshal(m, b)
+}
+func (m *DeleteWebhookRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteWebhookRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteWebhookRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteWebhookRequest.Merge(m, src)
+}
+func (m *DeleteWebhookRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteWebhookRequest.Size(m)
+}
+func (m *DeleteWebhookRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteWebhookRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteWebhookRequest proto.InternalMessageInfo
+
+func (m *DeleteWebhookRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Webhook struct {
+	// Output. Unique webhook ID. Generated by API server.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Required input field. Unique webhook name provided by user.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Required input field. The URL the events are posted to.
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// Input only. The key used to sign the payloads. Each request carries the
+	// HMAC-SHA256 of its body in the X-Pipelines-Signature header.
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// Optional input field. Only the runs of this experiment are notified.
+	ExperimentId string `protobuf:"bytes,5,opt,name=experiment_id,json=experimentId,proto3" json:"experiment_id,omitempty"`
+	// Optional input field. Only the runs of this namespace are notified.
+	Namespace string `protobuf:"bytes,6,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Required input field. The run events notified to the webhook.
+	Events []Webhook_Event `protobuf:"varint,7,rep,packed,name=events,proto3,enum=api.Webhook_Event" json:"events,omitempty"`
+	// Output. The time that the webhook created.
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Webhook) Reset()         { *m = Webhook{} }
+func (m *Webhook) String() string { return proto.CompactTextString(m) }
+func (*Webhook) ProtoMessage()    {}
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a0479a603100288, []int{5}
+}
+
+func (m *Webhook) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Webhook.Unmarshal(m, b)
+}
+func (m *Webhook) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Webhook.Marshal(b, m, deterministic)
+}
+func (m *Webhook) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Webhook.Merge(m, src)
+}
+func (m *Webhook) XXX_Size() int {
+	return xxx_messageInfo_Webhook.Size(m)
+}
+func (m *Webhook) XXX_DiscardUnknown() {
+	xxx_messageInfo_Webhook.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Webhook proto.InternalMessageInfo
+
+func (m *Webhook) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Webhook) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Webhook) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *Webhook) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *Webhook) GetExperimentId() string {
+	if m != nil {
+		return m.ExperimentId
+	}
+	return ""
+}
+
+func (m *Webhook) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *Webhook) GetEvents() []Webhook_Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Webhook) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("api.Webhook_Event", Webhook_Event_name, Webhook_Event_value)
+	proto.RegisterType((*CreateWebhookRequest)(nil), "api.CreateWebhookRequest")
+	proto.RegisterType((*GetWebhookRequest)(nil), "api.GetWebhookRequest")
+	proto.RegisterType((*ListWebhooksRequest)(nil), "api.ListWebhooksRequest")
+	proto.RegisterType((*ListWebhooksResponse)(nil), "api.ListWebhooksResponse")
+	proto.RegisterType((*DeleteWebhookRequest)(nil), "api.DeleteWebhookRequest")
+	proto.RegisterType((*Webhook)(nil), "api.Webhook")
+}
+
+func init() { proto.RegisterFile("webhook.proto", fileDescriptor_4a0479a603100288) }
+
+var fileDescriptor_4a0479a603100288 = []byte{
+	// 707 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0x5d, 0x53, 0xd3, 0x40,
+	0x14, 0xa5, 0x29, 0xf4, 0xe3, 0xd2, 0x94, 0xb2, 0x60, 0x0d, 0x01, 0xa4, 0x86, 0x19, 0xec, 0x30,
+	0xd2, 0x0e, 0xf8, 0xa4, 0x0f, 0xce, 0x14, 0x1a, 0x99, 0x8e, 0x58, 0x3a, 0x69, 0xa1, 0x8e, 0x2f,
+	0x99, 0x6d, 0x7b, 0x29, 0x91, 0x36, 0x89, 0xd9, 0x2d, 0x5f, 0x8e, 0x2f, 0xfe, 0x04, 0x7d, 0xf3,
+	0x6f, 0xf9, 0xe8, 0xab, 0x3f, 0xc4, 0xc9, 0x36, 0x01, 0xfa, 0xa1, 0x3e, 0x65, 0xef, 0x3d, 0x67,
+	0xef, 0xbd, 0x67, 0x73, 0x76, 0x41, 0xbe, 0xc2, 0xd6, 0xb9, 0xe3, 0x5c, 0x14, 0x5c, 0xcf, 0xe1,
+	0x0e, 0x89, 0x52, 0xd7, 0x52, 0xd7, 0xba, 0x8e, 0xd3, 0xed, 0x61, 0x91, 0xba, 0x56, 0x91, 0xda,
+	0xb6, 0xc3, 0x29, 0xb7, 0x1c, 0x9b, 0x0d, 0x29, 0xea, 0x6a, 0x80, 0x8a, 0xa8, 0x35, 0x38, 0x2b,
+	0x62, 0xdf, 0xe5, 0x37, 0x01, 0xb8, 0x31, 0x0e, 0x72, 0xab, 0x8f, 0x8c, 0xd3, 0xbe, 0x1b, 0x10,
+	0x9e, 0x8b, 0x4f, 0x7b, 0xa7, 0x8b, 0xf6, 0x0e, 0xbb, 0xa2, 0xdd, 0x2e, 0x7a, 0x45, 0xc7, 0x15,
+	0xf5, 0x27, 0x7b, 0x69, 0xaf, 0x61, 0xf9, 0xc0, 0x43, 0xca, 0xb1, 0x39, 0x9c, 0xd2, 0xc0, 0x4f,
+	0x03, 0x64, 0x9c, 0x6c, 0x41, 0x3c, 0x98, 0x5b, 0x89, 0xe4, 0x22, 0xf9, 0xf9, 0xbd, 0x54, 0x81,
+	0xba, 0x56, 0x21, 0x64, 0x85, 0xa0, 0xb6, 0x09, 0x8b, 0x87, 0xc8, 0xc7, 0x36, 0xa7, 0x41, 0xb2,
+	0x3a, 0x62, 0x5f, 0xd2, 0x90, 0xac, 0x8e, 0xf6, 0x11, 0x96, 0x8e, 0x2c, 0x16, 0xb2, 0x58, 0x48,
+	0x5b, 0x07, 0x70, 0x69, 0x17, 0x4d, 0xee, 0x5c, 0xa0, 0x1d, 0xd0, 0x93, 0x7e, 0xa6, 0xe1, 0x27,
+	0xc8, 0x2a, 0x88, 0xc0, 0x64, 0xd6, 0x2d, 0x2a, 0x52, 0x2e, 0x92, 0x9f, 0x33, 0x12, 0x7e, 0xa2,
+	0x6e, 0xdd, 0x22, 0x79, 0x0c, 0x71, 0xe6, 0x78, 0xdc, 0x6c, 0xdd, 0x28, 0x51, 0xb1, 0x31, 0xe6,
+	0x87, 0xfb, 0x37, 0xda, 0x39, 0x2c, 0x8f, 0xf6, 0x62, 0xae, 0x63, 0x33, 0x24, 0x79, 0x48, 0x04,
+	0x33, 0x33, 0x25, 0x92, 0x8b, 0x4e, 0x28, 0xba, 0x43, 0xc9, 0x16, 0x2c, 0xd8, 0x78, 0xcd, 0xcd,
+	0x07, 0xb3, 0x49, 0xa2, 0x85, 0xec, 0xa7, 0x6b, 0xe1, 0x7c, 0xda, 0x16, 0x2c, 0x97, 0xb1, 0x87,
+	0x1c, 0xff, 0xa3, 0xfe, 0x97, 0x04, 0xf1, 0x80, 0x32, 0x8e, 0x11, 0x02, 0xb3, 0x36, 0xed, 0x63,
+	0xd0, 0x40, 0xac, 0x49, 0x06, 0xa2, 0x03, 0xaf, 0x17, 0xc8, 0xf2, 0x97, 0x24, 0x0b, 0x31, 0x86,
+	0x6d, 0x0f, 0xb9, 0x32, 0x1b, 0x68, 0x15, 0x11, 0xd9, 0x04, 0x19, 0xaf, 0x5d, 0xf4, 0xac, 0x3e,
+	0xda, 0xdc, 0xb4, 0x3a, 0xca, 0x9c, 0x80, 0x53, 0xf7, 0xc9, 0x4a, 0x87, 0xac, 0x41, 0xd2, 0x2f,
+	0xcb, 0x5c, 0xda, 0x46, 0x25, 0x36, 0x3c, 0xe4, 0xbb, 0x04, 0xd9, 0x86, 0x18, 0x5e, 0xa2, 0xcd,
+	0x99, 0x12, 0xcf, 0x45, 0xf3, 0xe9, 0x3d, 0xf2, 0xf0, 0x50, 0x0a, 0xba, 0x0f, 0x19, 0x01, 0x83,
+	0xbc, 0x04, 0x68, 0x0b, 0xaf, 0x74, 0x4c, 0xca, 0x95, 0x84, 0xb0, 0x85, 0x5a, 0x18, 0xfa, 0xb1,
+	0x10, 0xfa, 0xb1, 0xd0, 0x08, 0xfd, 0x68, 0x24, 0x03, 0x76, 0x89, 0x6b, 0xef, 0x61, 0x4e, 0xd4,
+	0x22, 0x8b, 0x20, 0x9f, 0x54, 0xdf, 0x56, 0x8f, 0x9b, 0x55, 0x53, 0x3f, 0xd5, 0xab, 0x8d, 0xcc,
+	0x8c, 0x9f, 0x32, 0x4e, 0xaa, 0x66, 0xfd, 0xe4, 0xe0, 0x40, 0xd7, 0xcb, 0x7a, 0x39, 0x13, 0x21,
+	0x69, 0x00, 0x3f, 0xf5, 0xa6, 0x54, 0x39, 0xd2, 0xcb, 0x19, 0x89, 0xac, 0xc0, 0x23, 0x3f, 0x2e,
+	0x35, 0x4b, 0x95, 0x46, 0xa5, 0x7a, 0x68, 0x96, 0x6a, 0x35, 0xe3, 0xf8, 0xb4, 0x74, 0x94, 0x89,
+	0xee, 0xfd, 0x88, 0x42, 0x3a, 0x18, 0xb7, 0x8e, 0xde, 0xa5, 0xd5, 0x46, 0x42, 0x41, 0x1e, 0xf1,
+	0x34, 0x59, 0x11, 0xa2, 0xa6, 0xf9, 0x5c, 0x1d, 0x31, 0x81, 0xf6, 0xec, 0xeb, 0xcf, 0xdf, 0xdf,
+	0xa5, 0xa7, 0x5a, 0xd6, 0xbf, 0x99, 0xac, 0x78, 0xb9, 0xdb, 0x42, 0x4e, 0x77, 0x8b, 0xa1, 0x35,
+	0x5e, 0x85, 0xb6, 0x27, 0x4d, 0x80, 0x7b, 0xdb, 0x93, 0xac, 0x28, 0x32, 0x71, 0x0f, 0xc6, 0x8a,
+	0x6f, 0x8a, 0xe2, 0xeb, 0x64, 0x75, 0x7a, 0xf1, 0xe2, 0x67, 0xab, 0xf3, 0x85, 0xb4, 0x21, 0xf5,
+	0xd0, 0xbe, 0x44, 0x11, 0x25, 0xa6, 0xdc, 0x1e, 0x75, 0x65, 0x0a, 0x32, 0xf4, 0xba, 0xf6, 0x44,
+	0x74, 0x52, 0xc8, 0x5f, 0x64, 0x90, 0x2e, 0xc8, 0x23, 0xce, 0x0d, 0x0e, 0x68, 0x9a, 0x9b, 0xd5,
+	0xec, 0xc4, 0x0f, 0xd6, 0xfd, 0xd7, 0x28, 0x54, 0xb3, 0xfd, 0x2f, 0x35, 0xfb, 0xb5, 0x6f, 0xa5,
+	0x77, 0xc6, 0x1a, 0xc4, 0x3b, 0x78, 0x46, 0x07, 0x3d, 0x4e, 0x16, 0xc9, 0x02, 0xc8, 0xea, 0xbc,
+	0xe8, 0x56, 0xe7, 0x94, 0x0f, 0xd8, 0x87, 0x0d, 0x58, 0x87, 0xd8, 0x3e, 0x52, 0x0f, 0x3d, 0xb2,
+	0x94, 0x90, 0x54, 0x99, 0x0e, 0xf8, 0xb9, 0xe3, 0x59, 0xb7, 0xe2, 0x99, 0xca, 0x49, 0xad, 0x14,
+	0xc0, 0x1d, 0x61, 0xa6, 0x15, 0x13, 0x63, 0xbc, 0xf8, 0x33, 0x00, 0xcb, 0x8b, 0xdd, 0x08, 0x56,
+	0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type webhookServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWebhookServiceClient(cc *grpc.ClientConn) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/GetWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/api.WebhookService/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+type WebhookServiceServer interface {
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*Webhook, error)
+	GetWebhook(context.Context, *GetWebhookRequest) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*empty.Empty, error)
+}
+
+func RegisterWebhookServiceServer(s *grpc.Server, srv WebhookServiceServer) {
+	s.RegisterService(&_WebhookService_serviceDesc, srv)
+}
+
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/GetWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).GetWebhook(ctx, req.(*GetWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.WebhookService/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WebhookService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhook",
+			Handler:    _WebhookService_GetWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "webhook.proto",
+}
@@ -78,6 +78,11 @@ type UploadPipelineParams struct {
 
 	/*Name*/
 	Name *string
+	/*OnConflict
+	  What to do if a pipeline with the same name exists: fail with 409, the default, create the pipeline under the name suffixed with the first free " (n)", or replace the file of the existing pipeline.
+
+	*/
+	OnConflict *string
 	/*Uploadfile
 	  The pipeline to upload. Maximum size of 32MB is supported.
 
@@ -133,6 +138,17 @@ func (o *UploadPipelineParams) SetName(name *string) {
 	o.Name = name
 }
 
+// WithOnConflict adds the onConflict to the upload pipeline params
+func (o *UploadPipelineParams) WithOnConflict(onConflict *string) *UploadPipelineParams {
+	o.SetOnConflict(onConflict)
+	return o
+}
+
+// SetOnConflict adds the onConflict to the upload pipeline params
+func (o *UploadPipelineParams) SetOnConflict(onConflict *string) {
+	o.OnConflict = onConflict
+}
+
 // WithUploadfile adds the uploadfile to the upload pipeline params
 func (o *UploadPipelineParams) WithUploadfile(uploadfile runtime.NamedReadCloser) *UploadPipelineParams {
 	o.SetUploadfile(uploadfile)
@@ -168,6 +184,22 @@ func (o *UploadPipelineParams) WriteToRequest(r runtime.ClientRequest, reg strfm
 
 	}
 
+	if o.OnConflict != nil {
+
+		// query param on_conflict
+		var qrOnConflict string
+		if o.OnConflict != nil {
+			qrOnConflict = *o.OnConflict
+		}
+		qOnConflict := qrOnConflict
+		if qOnConflict != "" {
+			if err := r.SetQueryParam("on_conflict", qOnConflict); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// form file param uploadfile
 	if err := r.SetFileParam("uploadfile", o.Uploadfile); err != nil {
 		return err
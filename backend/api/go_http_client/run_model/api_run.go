@@ -74,7 +74,9 @@ type APIRun struct {
 	ScheduledAt strfmt.DateTime `json:"scheduled_at,omitempty"`
 
 	// Output. The status of the run.
-	// One of [Pending, Running, Succeeded, Skipped, Failed, Error]
+	// One of [Pending, Running, Succeeded, Skipped, Failed, Error], or Queued
+	// while the run waits for the active runs of its pipeline to be fewer than
+	// the pipeline allows.
 	Status string `json:"status,omitempty"`
 }
 
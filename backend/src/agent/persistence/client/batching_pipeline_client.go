@@ -0,0 +1,145 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+var (
+	pendingReports = metrics.NewGaugeVec(
+		"persistence_agent_pending_reports",
+		"Number of workflows waiting for their batch to be reported to the API server.")
+	reportBatchSize = metrics.NewHistogramVec(
+		"persistence_agent_report_batch_size",
+		"Number of workflows reported to the API server per call.",
+		[]float64{1, 2, 5, 10, 20, 50, 100})
+	reportBatchDurationSeconds = metrics.NewHistogramVec(
+		"persistence_agent_report_batch_duration_seconds",
+		"Duration of the calls reporting a batch of workflows to the API server, in seconds.",
+		metrics.DefBuckets)
+)
+
+func init() {
+	metrics.MustRegister(pendingReports, reportBatchSize, reportBatchDurationSeconds)
+}
+
+type reportRequest struct {
+	workflow *util.Workflow
+	result   chan error
+}
+
+// BatchingPipelineClient is a PipelineClientInterface combining the workflows reported
+// concurrently into batches, so that many workers don't need as many calls to the API server.
+//
+// ReportWorkflow blocks until the batch of its workflow has been reported. Since the work queue
+// of the agent never hands the same workflow to two workers at once, the updates of a workflow
+// are still reported in order. The other calls go straight to the wrapped client.
+type BatchingPipelineClient struct {
+	PipelineClientInterface
+	maxBatchSize int
+	requests     chan *reportRequest
+	stopped      chan struct{}
+
+	// Guards the delay, which can be changed while the batches are collected.
+	mutex    sync.RWMutex
+	maxDelay time.Duration
+}
+
+// NewBatchingPipelineClient creates a client reporting batches of at most maxBatchSize
+// workflows, sent at most maxDelay after the first of them has been reported.
+func NewBatchingPipelineClient(client PipelineClientInterface, maxBatchSize int,
+	maxDelay time.Duration) *BatchingPipelineClient {
+	return &BatchingPipelineClient{
+		PipelineClientInterface: client,
+		maxBatchSize:            maxBatchSize,
+		maxDelay:                maxDelay,
+		requests:                make(chan *reportRequest),
+		stopped:                 make(chan struct{}),
+	}
+}
+
+// SetMaxDelay changes the delay of the batches collected from now on.
+func (c *BatchingPipelineClient) SetMaxDelay(maxDelay time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.maxDelay = maxDelay
+}
+
+func (c *BatchingPipelineClient) getMaxDelay() time.Duration {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.maxDelay
+}
+
+// Run collects and reports the batches until stopCh is closed. Workflows reported after that
+// fail with a transient error.
+func (c *BatchingPipelineClient) Run(stopCh <-chan struct{}) {
+	defer close(c.stopped)
+	for {
+		var batch []*reportRequest
+		select {
+		case request := <-c.requests:
+			batch = append(batch, request)
+		case <-stopCh:
+			return
+		}
+		timer := time.NewTimer(c.getMaxDelay())
+	collect:
+		for len(batch) < c.maxBatchSize {
+			select {
+			case request := <-c.requests:
+				batch = append(batch, request)
+			case <-timer.C:
+				break collect
+			}
+		}
+		timer.Stop()
+		go c.report(batch)
+	}
+}
+
+func (c *BatchingPipelineClient) ReportWorkflow(workflow *util.Workflow) error {
+	request := &reportRequest{workflow: workflow, result: make(chan error, 1)}
+	pendingReports.Add(1)
+	defer pendingReports.Add(-1)
+	select {
+	case c.requests <- request:
+		return <-request.result
+	case <-c.stopped:
+		return util.NewCustomError(errors.New("the batching pipeline client is stopped"),
+			util.CUSTOM_CODE_TRANSIENT, "Error while reporting workflow resource: %v/%v (UID: %v)",
+			workflow.Namespace, workflow.Name, workflow.UID)
+	}
+}
+
+func (c *BatchingPipelineClient) report(batch []*reportRequest) {
+	workflows := make([]*util.Workflow, 0, len(batch))
+	for _, request := range batch {
+		workflows = append(workflows, request.workflow)
+	}
+	start := time.Now()
+	errs := c.PipelineClientInterface.ReportWorkflows(workflows)
+	reportBatchDurationSeconds.Observe(time.Since(start).Seconds())
+	reportBatchSize.Observe(float64(len(batch)))
+	for i, request := range batch {
+		request.result <- errs[i]
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestWorkflow(name string) *util.Workflow {
+	return util.NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "MY_NAMESPACE",
+			Name:      name,
+		},
+	})
+}
+
+func TestBatchingPipelineClient_CombinesConcurrentReports(t *testing.T) {
+	pipelineFake := NewPipelineClientFake()
+	client := NewBatchingPipelineClient(pipelineFake, 3, time.Hour)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go client.Run(stopCh)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = client.ReportWorkflow(newTestWorkflow(fmt.Sprintf("MY_NAME_%v", i)))
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []error{nil, nil, nil}, errs)
+	assert.Equal(t, []int{3}, pipelineFake.GetReportedBatchSizes())
+	for i := 0; i < 3; i++ {
+		assert.NotNil(t, pipelineFake.GetWorkflow("MY_NAMESPACE", fmt.Sprintf("MY_NAME_%v", i)))
+	}
+}
+
+func TestBatchingPipelineClient_ReportsIncompleteBatchAfterDelay(t *testing.T) {
+	pipelineFake := NewPipelineClientFake()
+	client := NewBatchingPipelineClient(pipelineFake, 10, 10*time.Millisecond)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go client.Run(stopCh)
+
+	err := client.ReportWorkflow(newTestWorkflow("MY_NAME"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, pipelineFake.GetReportedBatchSizes())
+	assert.NotNil(t, pipelineFake.GetWorkflow("MY_NAMESPACE", "MY_NAME"))
+}
+
+func TestBatchingPipelineClient_SetMaxDelay(t *testing.T) {
+	pipelineFake := NewPipelineClientFake()
+	client := NewBatchingPipelineClient(pipelineFake, 10, time.Hour)
+	client.SetMaxDelay(10 * time.Millisecond)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go client.Run(stopCh)
+
+	err := client.ReportWorkflow(newTestWorkflow("MY_NAME"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, pipelineFake.GetReportedBatchSizes())
+}
+
+func TestBatchingPipelineClient_ReturnsErrorOfWorkflow(t *testing.T) {
+	pipelineFake := NewPipelineClientFake()
+	pipelineFake.SetError(util.NewCustomErrorf(util.CUSTOM_CODE_PERMANENT, "bad workflow"))
+	client := NewBatchingPipelineClient(pipelineFake, 10, time.Millisecond)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go client.Run(stopCh)
+
+	err := client.ReportWorkflow(newTestWorkflow("MY_NAME"))
+
+	assert.True(t, util.HasCustomCode(err, util.CUSTOM_CODE_PERMANENT))
+}
+
+func TestBatchingPipelineClient_Stopped(t *testing.T) {
+	pipelineFake := NewPipelineClientFake()
+	client := NewBatchingPipelineClient(pipelineFake, 10, time.Millisecond)
+	stopCh := make(chan struct{})
+	close(stopCh)
+	client.Run(stopCh)
+
+	err := client.ReportWorkflow(newTestWorkflow("MY_NAME"))
+
+	assert.True(t, util.HasCustomCode(err, util.CUSTOM_CODE_TRANSIENT))
+	assert.Empty(t, pipelineFake.GetReportedBatchSizes())
+}
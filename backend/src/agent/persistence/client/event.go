@@ -0,0 +1,40 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	workflowscheme "github.com/argoproj/argo/pkg/client/clientset/versioned/scheme"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const eventSourceComponent = "ml-pipeline-persistenceagent"
+
+// NewEventRecorder creates a recorder of Kubernetes events on the workflows, in the
+// namespaces of the workflows.
+func NewEventRecorder(clientSet kubernetes.Interface) record.EventRecorder {
+	// The scheme must know the Workflow type to build the references of the workflows.
+	scheme := runtime.NewScheme()
+	workflowscheme.AddToScheme(scheme)
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(log.Infof)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: eventSourceComponent})
+}
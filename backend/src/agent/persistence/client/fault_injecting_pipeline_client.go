@@ -0,0 +1,77 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// FaultInjectingPipelineClient is a PipelineClientInterface injecting the faults of the
+// operations "report.workflow" and "report.scheduled_workflow". The failed reports are
+// dropped: they never reach the API server, but the agent sees them succeed, as when the
+// API server loses a report. The other calls go straight to the wrapped client.
+type FaultInjectingPipelineClient struct {
+	PipelineClientInterface
+	faults *util.FaultInjector
+}
+
+func NewFaultInjectingPipelineClient(client PipelineClientInterface,
+	faults *util.FaultInjector) *FaultInjectingPipelineClient {
+	return &FaultInjectingPipelineClient{PipelineClientInterface: client, faults: faults}
+}
+
+// drop returns true if the report of the named resource must be dropped.
+func (c *FaultInjectingPipelineClient) drop(operation string, namespace string, name string) bool {
+	if !c.faults.Inject(operation) {
+		return false
+	}
+	log.Warningf("%v: dropped the report of %v/%v", util.InjectedFaultMessage(operation), namespace, name)
+	return true
+}
+
+func (c *FaultInjectingPipelineClient) ReportWorkflow(workflow *util.Workflow) error {
+	if c.drop("report.workflow", workflow.Namespace, workflow.Name) {
+		return nil
+	}
+	return c.PipelineClientInterface.ReportWorkflow(workflow)
+}
+
+// ReportWorkflows drops the reports of some of the workflows of the batch, and reports the
+// others together.
+func (c *FaultInjectingPipelineClient) ReportWorkflows(workflows []*util.Workflow) []error {
+	errs := make([]error, len(workflows))
+	var reported []*util.Workflow
+	var indices []int
+	for i, workflow := range workflows {
+		if !c.drop("report.workflow", workflow.Namespace, workflow.Name) {
+			reported = append(reported, workflow)
+			indices = append(indices, i)
+		}
+	}
+	if len(reported) > 0 {
+		for i, err := range c.PipelineClientInterface.ReportWorkflows(reported) {
+			errs[indices[i]] = err
+		}
+	}
+	return errs
+}
+
+func (c *FaultInjectingPipelineClient) ReportScheduledWorkflow(swf *util.ScheduledWorkflow) error {
+	if c.drop("report.scheduled_workflow", swf.Namespace, swf.Name) {
+		return nil
+	}
+	return c.PipelineClientInterface.ReportScheduledWorkflow(swf)
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultInjectingPipelineClient_DropsReports(t *testing.T) {
+	pipelineFake := NewPipelineClientFake()
+	faults := util.NewFaultInjector([]util.FaultRule{{Operation: "report.workflow", Fail: true, Count: 2}}, 0)
+	client := NewFaultInjectingPipelineClient(pipelineFake, faults)
+
+	assert.Nil(t, client.ReportWorkflow(newTestWorkflow("MY_NAME_1")))
+	errs := client.ReportWorkflows([]*util.Workflow{newTestWorkflow("MY_NAME_2"), newTestWorkflow("MY_NAME_3")})
+	assert.Equal(t, []error{nil, nil}, errs)
+
+	// The first two reports were dropped.
+	assert.Nil(t, pipelineFake.GetWorkflow("MY_NAMESPACE", "MY_NAME_1"))
+	assert.Nil(t, pipelineFake.GetWorkflow("MY_NAMESPACE", "MY_NAME_2"))
+	assert.NotNil(t, pipelineFake.GetWorkflow("MY_NAMESPACE", "MY_NAME_3"))
+	assert.Equal(t, []int{1}, pipelineFake.GetReportedBatchSizes())
+}
+
+func TestFaultInjectingPipelineClient_ReturnsErrorsOfReportedWorkflows(t *testing.T) {
+	pipelineFake := NewPipelineClientFake()
+	pipelineFake.SetError(util.NewInternalServerError(nil, "bad"))
+	faults := util.NewFaultInjector([]util.FaultRule{{Operation: "report.workflow", Fail: true, Count: 1}}, 0)
+	client := NewFaultInjectingPipelineClient(pipelineFake, faults)
+
+	errs := client.ReportWorkflows([]*util.Workflow{newTestWorkflow("MY_NAME_1"), newTestWorkflow("MY_NAME_2")})
+	assert.Nil(t, errs[0])
+	assert.NotNil(t, errs[1])
+}
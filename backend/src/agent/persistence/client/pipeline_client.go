@@ -21,15 +21,20 @@ import (
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 type PipelineClientInterface interface {
 	ReportWorkflow(workflow *util.Workflow) error
+	// ReportWorkflows reports several workflows in a single call and returns the error of each of
+	// them, in order.
+	ReportWorkflows(workflows []*util.Workflow) []error
 	ReportScheduledWorkflow(swf *util.ScheduledWorkflow) error
 	ReadArtifact(request *api.ReadArtifactRequest) (*api.ReadArtifactResponse, error)
 	ReportRunMetrics(request *api.ReportRunMetricsRequest) (*api.ReportRunMetricsResponse, error)
+	ReportRunLogs(request *api.ReportRunLogsRequest) error
 }
 
 type PipelineClient struct {
@@ -51,7 +56,8 @@ func NewPipelineClient(
 	mlPipelineServiceName string,
 	mlPipelineServicePort string,
 	masterurl string,
-	kubeconfig string) (*PipelineClient, error) {
+	kubeconfig string,
+	dialOptions ...grpc.DialOption) (*PipelineClient, error) {
 
 	err := util.WaitForGrpcClientAvailable(namespace, initializeTimeout, basePath, masterurl,
 		kubeconfig)
@@ -61,7 +67,7 @@ func NewPipelineClient(
 	}
 
 	connection, err := util.GetRpcConnection(namespace,
-		mlPipelineServiceName, mlPipelineServicePort, masterurl, kubeconfig)
+		mlPipelineServiceName, mlPipelineServicePort, masterurl, kubeconfig, dialOptions...)
 	if err != nil {
 		return nil, errors.Wrapf(err,
 			"Failed to get RPC connection. Error: %s", err.Error())
@@ -79,12 +85,31 @@ func NewPipelineClient(
 	}, nil
 }
 
+// NewRemotePipelineClient creates the client of the API server at the given gRPC address,
+// e.g. the central API server of the execution target the agent runs in.
+func NewRemotePipelineClient(address string, timeout time.Duration,
+	dialOptions ...grpc.DialOption) (*PipelineClient, error) {
+	connection, err := grpc.Dial(address, append([]grpc.DialOption{grpc.WithInsecure()}, dialOptions...)...)
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"Failed to get RPC connection. Error: %s", err.Error())
+	}
+
+	return &PipelineClient{
+		timeout:             timeout,
+		reportServiceClient: api.NewReportServiceClient(connection),
+		runServiceClient:    api.NewRunServiceClient(connection),
+	}, nil
+}
+
 func (p *PipelineClient) ReportWorkflow(workflow *util.Workflow) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
+	// The API server hashes the values of the secret parameters with its key. The errors
+	// only name the workflow, so that the values aren't logged.
 	_, err := p.reportServiceClient.ReportWorkflow(ctx, &api.ReportWorkflowRequest{
-		Workflow: workflow.ToStringForStore(),
+		Workflow: workflow.ToStringWithSecrets(),
 	})
 
 	if err != nil {
@@ -92,31 +117,78 @@ func (p *PipelineClient) ReportWorkflow(workflow *util.Workflow) error {
 		if statusCode.Code() == codes.InvalidArgument {
 			// Do not retry if there is something wrong with the workflow
 			return util.NewCustomError(err, util.CUSTOM_CODE_PERMANENT,
-				"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
+				"Error while reporting workflow resource (code: %v, message: %v): %v, %v/%v (UID: %v)",
 				statusCode.Code(),
 				statusCode.Message(),
 				err.Error(),
-				workflow.Workflow)
+				workflow.Namespace, workflow.Name, workflow.UID)
 		} else {
 			// Retry otherwise
 			return util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
-				"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
+				"Error while reporting workflow resource (code: %v, message: %v): %v, %v/%v (UID: %v)",
 				statusCode.Code(),
 				statusCode.Message(),
 				err.Error(),
-				workflow.Workflow)
+				workflow.Namespace, workflow.Name, workflow.UID)
 		}
 	}
 	return nil
 }
 
+func (p *PipelineClient) ReportWorkflows(workflows []*util.Workflow) []error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	request := &api.ReportWorkflowsRequest{Workflows: make([]string, 0, len(workflows))}
+	for _, workflow := range workflows {
+		request.Workflows = append(request.Workflows, workflow.ToStringWithSecrets())
+	}
+	response, err := p.reportServiceClient.ReportWorkflows(ctx, request)
+
+	errs := make([]error, len(workflows))
+	if status.Code(err) == codes.Unimplemented {
+		// The API server predates batch reporting.
+		for i, workflow := range workflows {
+			errs[i] = p.ReportWorkflow(workflow)
+		}
+		return errs
+	}
+	if err == nil && len(response.Results) != len(workflows) {
+		err = errors.Errorf("got %v results for %v workflows", len(response.Results), len(workflows))
+	}
+	if err != nil {
+		for i, workflow := range workflows {
+			errs[i] = util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
+				"Error while reporting workflow resource: %v, %v/%v (UID: %v)", err.Error(),
+				workflow.Namespace, workflow.Name, workflow.UID)
+		}
+		return errs
+	}
+	for i, result := range response.Results {
+		switch result.Status {
+		case api.ReportWorkflowsResponse_ReportWorkflowResult_OK:
+		case api.ReportWorkflowsResponse_ReportWorkflowResult_INVALID_ARGUMENT:
+			// Do not retry if there is something wrong with the workflow
+			errs[i] = util.NewCustomErrorf(util.CUSTOM_CODE_PERMANENT,
+				"Error while reporting workflow resource (status: %v, message: %v): %v/%v (UID: %v)",
+				result.Status, result.Message, workflows[i].Namespace, workflows[i].Name, workflows[i].UID)
+		default:
+			// Retry otherwise
+			errs[i] = util.NewCustomErrorf(util.CUSTOM_CODE_TRANSIENT,
+				"Error while reporting workflow resource (status: %v, message: %v): %v/%v (UID: %v)",
+				result.Status, result.Message, workflows[i].Namespace, workflows[i].Name, workflows[i].UID)
+		}
+	}
+	return errs
+}
+
 func (p *PipelineClient) ReportScheduledWorkflow(swf *util.ScheduledWorkflow) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
 	_, err := p.reportServiceClient.ReportScheduledWorkflow(ctx,
 		&api.ReportScheduledWorkflowRequest{
-			ScheduledWorkflow: swf.ToStringForStore(),
+			ScheduledWorkflow: swf.ToStringWithSecrets(),
 		})
 
 	if err != nil {
@@ -124,19 +196,19 @@ func (p *PipelineClient) ReportScheduledWorkflow(swf *util.ScheduledWorkflow) er
 		if statusCode.Code() == codes.InvalidArgument {
 			// Do not retry if there is something wrong with the workflow
 			return util.NewCustomError(err, util.CUSTOM_CODE_PERMANENT,
-				"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
+				"Error while reporting workflow resource (code: %v, message: %v): %v, %v/%v (UID: %v)",
 				statusCode.Code(),
 				statusCode.Message(),
 				err.Error(),
-				swf.ScheduledWorkflow)
+				swf.Namespace, swf.Name, swf.UID)
 		} else {
 			// Retry otherwise
 			return util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
-				"Error while reporting workflow resource (code: %v, message: %v): %v, %+v",
+				"Error while reporting workflow resource (code: %v, message: %v): %v, %v/%v (UID: %v)",
 				statusCode.Code(),
 				statusCode.Message(),
 				err.Error(),
-				swf.ScheduledWorkflow)
+				swf.Namespace, swf.Name, swf.UID)
 		}
 	}
 	return nil
@@ -171,3 +243,18 @@ func (p *PipelineClient) ReportRunMetrics(request *api.ReportRunMetricsRequest)
 	}
 	return response, nil
 }
+
+// ReportRunLogs archives the logs of a step of a run in the object store of the ML pipeline
+// API server.
+func (p *PipelineClient) ReportRunLogs(request *api.ReportRunLogsRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, err := p.runServiceClient.ReportRunLogs(ctx, request)
+	if err != nil {
+		// The logs are reported again on the next resync of the workflow.
+		return util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
+			"Error while reporting the logs of node (%v) of run (%v): %+v", request.NodeId, request.RunId, err)
+	}
+	return nil
+}
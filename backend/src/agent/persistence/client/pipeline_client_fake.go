@@ -28,6 +28,9 @@ type PipelineClientFake struct {
 	reportedMetricsRequest    *api.ReportRunMetricsRequest
 	reportMetricsResponseStub *api.ReportRunMetricsResponse
 	reportMetricsErrorStub    error
+	reportedBatchSizes        []int
+	reportedLogs              map[string]string
+	reportLogsErrorStub       error
 }
 
 func NewPipelineClientFake() *PipelineClientFake {
@@ -37,6 +40,7 @@ func NewPipelineClientFake() *PipelineClientFake {
 		err:                       nil,
 		artifacts:                 make(map[string]*api.ReadArtifactResponse),
 		reportMetricsResponseStub: &api.ReportRunMetricsResponse{},
+		reportedLogs:              make(map[string]string),
 	}
 }
 
@@ -48,6 +52,15 @@ func (p *PipelineClientFake) ReportWorkflow(workflow *util.Workflow) error {
 	return nil
 }
 
+func (p *PipelineClientFake) ReportWorkflows(workflows []*util.Workflow) []error {
+	p.reportedBatchSizes = append(p.reportedBatchSizes, len(workflows))
+	errs := make([]error, len(workflows))
+	for i, workflow := range workflows {
+		errs[i] = p.ReportWorkflow(workflow)
+	}
+	return errs
+}
+
 func (p *PipelineClientFake) ReportScheduledWorkflow(swf *util.ScheduledWorkflow) error {
 	if p.err != nil {
 		return p.err
@@ -65,6 +78,14 @@ func (p *PipelineClientFake) ReportRunMetrics(request *api.ReportRunMetricsReque
 	return p.reportMetricsResponseStub, p.reportMetricsErrorStub
 }
 
+func (p *PipelineClientFake) ReportRunLogs(request *api.ReportRunLogsRequest) error {
+	if p.reportLogsErrorStub != nil {
+		return p.reportLogsErrorStub
+	}
+	p.reportedLogs[getKey(request.RunId, request.NodeId)] = string(request.Logs)
+	return nil
+}
+
 func (p *PipelineClientFake) SetError(err error) {
 	p.err = err
 }
@@ -86,6 +107,22 @@ func (p *PipelineClientFake) StubReportRunMetrics(response *api.ReportRunMetrics
 	p.err = err
 }
 
+// GetReportedBatchSizes returns the number of workflows of each call to ReportWorkflows.
+func (p *PipelineClientFake) GetReportedBatchSizes() []int {
+	return p.reportedBatchSizes
+}
+
 func (p *PipelineClientFake) GetReportedMetricsRequest() *api.ReportRunMetricsRequest {
 	return p.reportedMetricsRequest
 }
+
+// GetReportedLogs returns the logs reported for a step of a run.
+func (p *PipelineClientFake) GetReportedLogs(runID string, nodeID string) (string, bool) {
+	logs, ok := p.reportedLogs[getKey(runID, nodeID)]
+	return logs, ok
+}
+
+// StubReportRunLogs makes the calls to ReportRunLogs fail with an error.
+func (p *PipelineClientFake) StubReportRunLogs(err error) {
+	p.reportLogsErrorStub = err
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+)
+
+// The label Argo sets on the pods of a workflow, to the name of the workflow.
+const workflowPodLabelKey = "workflows.argoproj.io/workflow"
+
+type PodWriterInterface interface {
+	DeleteWorkflowPods(namespace string, workflowName string) (int, error)
+}
+
+// PodWriter deletes the pods of the workflows through the Kubernetes API.
+type PodWriter struct {
+	clientSet kubernetes.Interface
+}
+
+// NewPodWriter creates an instance of the PodWriter.
+func NewPodWriter(clientSet kubernetes.Interface) *PodWriter {
+	return &PodWriter{clientSet: clientSet}
+}
+
+// DeleteWorkflowPods deletes the pods of a workflow, given a namespace and the name of
+// the workflow. It returns the number of pods deleted.
+func (c *PodWriter) DeleteWorkflowPods(namespace string, workflowName string) (int, error) {
+	pods, err := c.clientSet.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: workflowPodLabelKey + "=" + workflowName,
+	})
+	if err != nil {
+		return 0, util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
+			"Error listing the pods of workflow (%v) in namespace (%v): %v", workflowName, namespace, err)
+	}
+	deleted := 0
+	for _, pod := range pods.Items {
+		err := c.clientSet.CoreV1().Pods(namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+		if err != nil && !util.IsNotFound(err) {
+			return deleted, util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
+				"Error deleting pod (%v) of workflow (%v) in namespace (%v): %v", pod.Name, workflowName, namespace, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+type PodLogReaderInterface interface {
+	ReadPodLogs(namespace string, podName string, container string) ([]byte, error)
+}
+
+// PodLogReader reads the logs of the pods of the workflows through the Kubernetes API.
+type PodLogReader struct {
+	clientSet  kubernetes.Interface
+	limitBytes int64
+}
+
+// NewPodLogReader creates an instance of the PodLogReader, reading at most limitBytes of
+// the logs of each container. 0 for no limit.
+func NewPodLogReader(clientSet kubernetes.Interface, limitBytes int64) *PodLogReader {
+	return &PodLogReader{clientSet: clientSet, limitBytes: limitBytes}
+}
+
+// ReadPodLogs returns the logs of a container of a pod. It returns an error with the
+// CUSTOM_CODE_NOT_FOUND code if the pod doesn't exist.
+func (r *PodLogReader) ReadPodLogs(namespace string, podName string, container string) ([]byte, error) {
+	options := &corev1.PodLogOptions{Container: container}
+	if r.limitBytes > 0 {
+		options.LimitBytes = &r.limitBytes
+	}
+	logs, err := r.clientSet.CoreV1().Pods(namespace).GetLogs(podName, options).DoRaw()
+	if err != nil {
+		code := util.CUSTOM_CODE_TRANSIENT
+		if util.IsNotFound(err) {
+			code = util.CUSTOM_CODE_NOT_FOUND
+		}
+		return nil, util.NewCustomError(err, code,
+			"Error reading the logs of pod (%v) in namespace (%v): %v", podName, namespace, err)
+	}
+	return logs, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// PodWriterFake records the workflows whose pods were deleted.
+type PodWriterFake struct {
+	Deleted []string
+	err     error
+}
+
+func NewPodWriterFake() *PodWriterFake {
+	return &PodWriterFake{}
+}
+
+func (p *PodWriterFake) DeleteWorkflowPods(namespace string, workflowName string) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	p.Deleted = append(p.Deleted, getKey(namespace, workflowName))
+	return 1, nil
+}
+
+// SetError makes the calls fail with an error.
+func (p *PodWriterFake) SetError(err error) {
+	p.err = err
+}
+
+// PodLogReaderFake serves the logs of the containers stubbed with StubLogs.
+type PodLogReaderFake struct {
+	logs map[string][]byte
+	err  error
+}
+
+func NewPodLogReaderFake() *PodLogReaderFake {
+	return &PodLogReaderFake{logs: make(map[string][]byte)}
+}
+
+func (p *PodLogReaderFake) ReadPodLogs(namespace string, podName string, container string) ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	logs, ok := p.logs[getKey(getKey(namespace, podName), container)]
+	if !ok {
+		return nil, util.NewCustomError(errors.New("pod not found"), util.CUSTOM_CODE_NOT_FOUND,
+			"Pod (%v) not found in namespace (%v)", podName, namespace)
+	}
+	return logs, nil
+}
+
+// StubLogs sets the logs of a container of a pod.
+func (p *PodLogReaderFake) StubLogs(namespace string, podName string, container string, logs []byte) {
+	p.logs[getKey(getKey(namespace, podName), container)] = logs
+}
+
+// SetError makes the calls fail with an error.
+func (p *PodLogReaderFake) SetError(err error) {
+	p.err = err
+}
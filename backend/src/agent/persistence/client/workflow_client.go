@@ -15,8 +15,13 @@
 package client
 
 import (
+	"fmt"
+
+	workflowclientset "github.com/argoproj/argo/pkg/client/clientset/versioned"
 	"github.com/argoproj/argo/pkg/client/informers/externalversions/workflow/v1alpha1"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/cache"
 )
@@ -63,3 +68,44 @@ func (c *WorkflowClient) Get(namespace string, name string) (
 	}
 	return util.NewWorkflow(workflow), nil
 }
+
+type WorkflowWriterInterface interface {
+	Delete(namespace string, name string) error
+	Label(namespace string, name string, key string, value string) error
+}
+
+// WorkflowWriter deletes and labels the workflows through the Kubernetes API.
+type WorkflowWriter struct {
+	clientSet workflowclientset.Interface
+}
+
+// NewWorkflowWriter creates an instance of the WorkflowWriter.
+func NewWorkflowWriter(clientSet workflowclientset.Interface) *WorkflowWriter {
+	return &WorkflowWriter{clientSet: clientSet}
+}
+
+// Delete deletes a workflow, given a namespace and name. A workflow which doesn't exist
+// is already deleted.
+func (c *WorkflowWriter) Delete(namespace string, name string) error {
+	err := c.clientSet.ArgoprojV1alpha1().Workflows(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !util.IsNotFound(err) {
+		return util.NewCustomError(err, util.CUSTOM_CODE_TRANSIENT,
+			"Error deleting workflow (%v) in namespace (%v): %v", name, namespace, err)
+	}
+	return nil
+}
+
+// Label sets a label of a workflow, given a namespace and name.
+func (c *WorkflowWriter) Label(namespace string, name string, key string, value string) error {
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:%q}}}`, key, value)
+	_, err := c.clientSet.ArgoprojV1alpha1().Workflows(namespace).Patch(name, types.MergePatchType, []byte(patch))
+	if err != nil {
+		code := util.CUSTOM_CODE_TRANSIENT
+		if util.IsNotFound(err) {
+			code = util.CUSTOM_CODE_NOT_FOUND
+		}
+		return util.NewCustomError(err, code,
+			"Error labeling workflow (%v) in namespace (%v): %v", name, namespace, err)
+	}
+	return nil
+}
@@ -49,6 +49,42 @@ func (p *WorkflowClientFake) Put(namespace string, name string, wf *util.Workflo
 	p.workflows[getKey(namespace, name)] = wf
 }
 
+// WorkflowWriterFake records the workflows deleted and labeled.
+type WorkflowWriterFake struct {
+	Deleted []string
+	Labels  map[string]map[string]string
+	err     error
+}
+
+func NewWorkflowWriterFake() *WorkflowWriterFake {
+	return &WorkflowWriterFake{Labels: make(map[string]map[string]string)}
+}
+
+func (p *WorkflowWriterFake) Delete(namespace string, name string) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.Deleted = append(p.Deleted, getKey(namespace, name))
+	return nil
+}
+
+func (p *WorkflowWriterFake) Label(namespace string, name string, key string, value string) error {
+	if p.err != nil {
+		return p.err
+	}
+	workflowKey := getKey(namespace, name)
+	if p.Labels[workflowKey] == nil {
+		p.Labels[workflowKey] = make(map[string]string)
+	}
+	p.Labels[workflowKey][key] = value
+	return nil
+}
+
+// SetError makes the calls fail with an error.
+func (p *WorkflowWriterFake) SetError(err error) {
+	p.err = err
+}
+
 func getKey(namespace string, name string) string {
 	return namespace + "/" + name
 }
@@ -16,21 +16,37 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"time"
 
 	workflowclientSet "github.com/argoproj/argo/pkg/client/clientset/versioned"
 	workflowinformers "github.com/argoproj/argo/pkg/client/informers/externalversions"
 	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/agent/persistence/worker"
+	"github.com/kubeflow/pipelines/backend/src/common/diagnostics"
+	"github.com/kubeflow/pipelines/backend/src/common/features"
+	"github.com/kubeflow/pipelines/backend/src/common/health"
+	"github.com/kubeflow/pipelines/backend/src/common/leaderelection"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/reload"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	swfclientset "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned"
 	swfinformers "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/informers/externalversions"
 	"github.com/kubeflow/pipelines/backend/src/crd/pkg/signals"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
 )
 
+// The features of the agent, set by the featureGates flag.
+var featureGate = features.NewGate()
+
 var (
 	masterURL                   string
 	kubeconfig                  string
@@ -40,6 +56,36 @@ var (
 	mlPipelineAPIServerName     string
 	mlPipelineAPIServerPort     string
 	mlPipelineAPIServerBasePath string
+	mlPipelineAPIServerAddress  string
+	metricsAddress              string
+	healthCheckTimeout          time.Duration
+	diagnosticsAddress          string
+	metadataStoreAddress        string
+	metricsExporter             string
+	statsdAddress               string
+	statsdPrefix                string
+	numWorker                   int
+	reportBatchSize             int
+	reportBatchDelay            time.Duration
+	grpcMaxRecvMsgSize          int
+	grpcMaxSendMsgSize          int
+	grpcCompression             bool
+	crdWaitTimeout              time.Duration
+	leaderElect                 bool
+	leaseName                   string
+	leaseDuration               time.Duration
+	renewDeadline               time.Duration
+	retryPeriod                 time.Duration
+	shardIndex                  int
+	shardCount                  int
+	workflowGCMode              string
+	workflowGCGracePeriod       time.Duration
+	archiveLogs                 bool
+	archiveLogsLimitBytes       int64
+	faultInjection              string
+	faultInjectionSeed          int64
+	configFile                  string
+	configReloadInterval        time.Duration
 )
 
 const (
@@ -51,10 +97,59 @@ const (
 	mlPipelineAPIServerBasePathFlagName = "mlPipelineAPIServerBasePath"
 	mlPipelineAPIServerNameFlagName     = "mlPipelineAPIServerName"
 	mlPipelineAPIServerPortFlagName     = "mlPipelineAPIServerPort"
+	mlPipelineAPIServerAddressFlagName  = "mlPipelineAPIServerAddress"
+	metricsAddressFlagName              = "metricsAddress"
+	healthCheckTimeoutFlagName          = "healthCheckTimeout"
+	diagnosticsAddressFlagName          = "diagnosticsAddress"
+	metadataStoreAddressFlagName        = "metadataStoreAddress"
+	metricsExporterFlagName             = "metricsExporter"
+	statsdAddressFlagName               = "statsdAddress"
+	statsdPrefixFlagName                = "statsdPrefix"
+	numWorkerFlagName                   = "numWorker"
+	reportBatchSizeFlagName             = "reportBatchSize"
+	reportBatchDelayFlagName            = "reportBatchDelay"
+	grpcMaxRecvMsgSizeFlagName          = "grpcMaxRecvMsgSize"
+	grpcMaxSendMsgSizeFlagName          = "grpcMaxSendMsgSize"
+	grpcCompressionFlagName             = "grpcCompression"
+	crdWaitTimeoutFlagName              = "crdWaitTimeout"
+	leaderElectFlagName                 = "leaderElect"
+	leaseNameFlagName                   = "leaseName"
+	leaseDurationFlagName               = "leaseDuration"
+	renewDeadlineFlagName               = "renewDeadline"
+	retryPeriodFlagName                 = "retryPeriod"
+	shardIndexFlagName                  = "shardIndex"
+	shardCountFlagName                  = "shardCount"
+	workflowGCModeFlagName              = "workflowGCMode"
+	workflowGCGracePeriodFlagName       = "workflowGCGracePeriod"
+	archiveLogsFlagName                 = "archiveLogs"
+	archiveLogsLimitBytesFlagName       = "archiveLogsLimitBytes"
+	faultInjectionFlagName              = "faultInjection"
+	faultInjectionSeedFlagName          = "faultInjectionSeed"
+	configFileFlagName                  = "configFile"
+	configReloadIntervalFlagName        = "configReloadInterval"
+	featureGatesFlagName                = "featureGates"
 )
 
 func main() {
 	flag.Parse()
+	if configFile != "" {
+		content, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			log.Fatalf("Error reading the config file: %v", err)
+		}
+		if err := reload.SetFlags(flag.CommandLine, content); err != nil {
+			log.Fatalf("Error applying the config file: %v", err)
+		}
+	}
+
+	for _, state := range featureGate.States() {
+		log.Infof("Feature %s (%s) enabled: %t", state.Feature, state.Stage, state.Enabled)
+	}
+
+	// The diagnostics port also serves the effective flags.
+	config := reload.NewRegistry()
+	config.RegisterFlags(flag.CommandLine)
+	go diagnostics.ListenAndServe(diagnosticsAddress, config)
 
 	// set up signals so we handle the first shutdown signal gracefully
 	stopCh := signals.SetupSignalHandler()
@@ -77,31 +172,168 @@ func main() {
 	swfInformerFactory := swfinformers.NewSharedInformerFactory(swfClient, time.Second*30)
 	workflowInformerFactory := workflowinformers.NewSharedInformerFactory(workflowClient, time.Second*30)
 
-	pipelineClient, err := client.NewPipelineClient(
-		namespace,
-		initializeTimeout,
-		timeout,
-		mlPipelineAPIServerBasePath,
-		mlPipelineAPIServerName,
-		mlPipelineAPIServerPort,
-		masterURL,
-		kubeconfig)
+	grpcOptions := util.GrpcCallOptions(grpcMaxRecvMsgSize, grpcMaxSendMsgSize, grpcCompression)
+	var pipelineClient *client.PipelineClient
+	if mlPipelineAPIServerAddress != "" {
+		// The agent of an execution target reports to the API server of the central cluster.
+		pipelineClient, err = client.NewRemotePipelineClient(mlPipelineAPIServerAddress, timeout, grpcOptions)
+	} else {
+		pipelineClient, err = client.NewPipelineClient(
+			namespace,
+			initializeTimeout,
+			timeout,
+			mlPipelineAPIServerBasePath,
+			mlPipelineAPIServerName,
+			mlPipelineAPIServerPort,
+			masterURL,
+			kubeconfig,
+			grpcOptions)
+	}
 	if err != nil {
 		log.Fatalf("Error creating ML pipeline API Server client: %v", err)
 	}
 
+	// Expose the depth and the latency of the work queues of the agent.
+	workqueue.SetProvider(metrics.NewWorkqueueMetricsProvider(metrics.DefaultRegistry))
+	sink, err := metrics.NewSink(metricsExporter, statsdAddress, statsdPrefix)
+	if err != nil {
+		log.Fatalf("Error creating the metrics exporter: %v", err)
+	}
+	if sink != nil {
+		metrics.AddSink(sink)
+	}
+
+	var metadataStore metadata.MetadataStoreInterface
+	if metadataStoreAddress != "" {
+		metadataStore, err = metadata.DialMetadataStore(metadataStoreAddress, timeout, grpcOptions)
+		if err != nil {
+			log.Fatalf("Error creating the ML Metadata store client: %v", err)
+		}
+	}
+
+	// Combine the workflows reported concurrently by the workers into batches.
+	var reportClient client.PipelineClientInterface = pipelineClient
+	faultRules, err := util.ParseFaultRules(faultInjection)
+	if err != nil {
+		log.Fatalf("Error parsing the fault injection rules: %v", err)
+	}
+	if faults := util.NewFaultInjector(faultRules, faultInjectionSeed); faults != nil {
+		reportClient = client.NewFaultInjectingPipelineClient(reportClient, faults)
+	}
+	if reportBatchSize > 1 {
+		batchingClient := client.NewBatchingPipelineClient(reportClient, reportBatchSize, reportBatchDelay)
+		go batchingClient.Run(stopCh)
+		reportClient = batchingClient
+		config.Register(reportBatchDelayFlagName, reportBatchDelay.String(),
+			reload.Duration(func(delay time.Duration) error {
+				batchingClient.SetMaxDelay(delay)
+				return nil
+			}))
+	}
+
+	shard, err := util.NewShard(shardIndex, shardCount)
+	if err != nil {
+		log.Fatalf("Error configuring the shard: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		log.Fatalf("Error building kubernetes clientset: %s", err.Error())
+	}
+
+	// The completed workflows are deleted or labeled once persisted, if configured.
+	collector, err := worker.NewWorkflowCollector(client.NewWorkflowWriter(workflowClient),
+		client.NewEventRecorder(kubeClient), workflowGCMode, workflowGCGracePeriod, util.NewRealTime())
+	if err != nil {
+		log.Fatalf("Error configuring the workflow garbage collection: %v", err)
+	}
+	if collector != nil {
+		config.Register(workflowGCGracePeriodFlagName, workflowGCGracePeriod.String(),
+			reload.Duration(collector.SetGracePeriod))
+	}
+	if configFile != "" {
+		// The first check only records the content of the config file applied above.
+		watcher := reload.NewFileWatcher(configFile, configReloadInterval, func(content []byte) error {
+			return config.UpdateFlags(flag.CommandLine, content)
+		})
+		if err := watcher.Check(); err != nil {
+			log.Errorf("Error applying the config file: %v", err)
+		}
+		go watcher.Run(stopCh)
+	}
+
+	// The pods of the completed workflows are deleted as set by their pod GC strategy.
+	podCollector := worker.NewPodCollector(client.NewPodWriter(kubeClient), client.NewWorkflowWriter(workflowClient))
+
+	// The logs of the completed workflows are archived before their pods are deleted.
+	var logArchiver *worker.LogArchiver
+	if archiveLogs {
+		logArchiver = worker.NewLogArchiver(client.NewPodLogReader(kubeClient, archiveLogsLimitBytes),
+			reportClient, client.NewWorkflowWriter(workflowClient))
+	}
+
 	controller := NewPersistenceAgent(
 		swfInformerFactory,
 		workflowInformerFactory,
-		pipelineClient,
-		util.NewRealTime())
+		reportClient,
+		metadataStore,
+		collector,
+		podCollector,
+		logArchiver,
+		util.NewRealTime(),
+		shard)
+
+	// Wait for the CRDs instead of crash-looping until they're installed.
+	gate := health.NewStartupGate(time.Second, 15*time.Second, healthCheckTimeout)
+	gate.Add("workflow_crd", crdWaitTimeout,
+		health.APIResourceCheck(workflowClient.Discovery(), "argoproj.io/v1alpha1", "workflows"))
+	gate.Add("scheduledworkflow_crd", crdWaitTimeout,
+		health.APIResourceCheck(workflowClient.Discovery(), "kubeflow.org/v1alpha1", "scheduledworkflows"))
+
+	if metricsAddress != "" {
+		checker := health.NewChecker(healthCheckTimeout)
+		checker.AddReadinessCheck("startup", gate.ReadinessCheck())
+		checker.AddReadinessCheck("kubernetes", health.KubernetesAPICheck(workflowClient.Discovery()))
+		checker.AddReadinessCheck("informers", health.CacheSyncedCheck(controller.HasSynced))
+		go serveMonitoring(metricsAddress, checker)
+	}
+
+	if err = gate.Wait(); err != nil {
+		log.Fatalf("Error waiting for the dependencies of the agent: %v", err)
+	}
 
 	go swfInformerFactory.Start(stopCh)
 	go workflowInformerFactory.Start(stopCh)
 
-	if err = controller.Run(2, stopCh); err != nil {
-		log.Fatalf("Error running controller: %s", err.Error())
+	if !leaderElect {
+		if err = controller.Run(numWorker, stopCh); err != nil {
+			log.Fatalf("Error running controller: %s", err.Error())
+		}
+		return
+	}
+
+	// Only the replica holding the lease of the shard reports the objects, so that the
+	// other replicas take over right away when it's stopped, e.g. during an upgrade.
+	if shard != nil {
+		leaseName = fmt.Sprintf("%s-%d", leaseName, shardIndex)
 	}
+	elector, err := leaderelection.NewReplicaElector(kubeClient.CoreV1().RESTClient(), namespace, leaseName,
+		leaseDuration, renewDeadline, retryPeriod, util.NewRealTime())
+	if err != nil {
+		log.Fatalf("Error creating the leader elector: %v", err)
+	}
+	elector.Register("persistence agent", func(leaderStopCh <-chan struct{}) {
+		if err := controller.Run(numWorker, leaderStopCh); err != nil {
+			log.Fatalf("Error running controller: %s", err.Error())
+		}
+		// The work queues of the agent can't be restarted once shut down.
+		select {
+		case <-stopCh:
+		default:
+			log.Fatalf("Lost lease %v, exiting", leaseName)
+		}
+	})
+	elector.Run(stopCh)
 }
 
 func init() {
@@ -112,7 +344,78 @@ func init() {
 	flag.DurationVar(&timeout, timeoutFlagName, 1*time.Minute, "Duration to wait for calls to complete.")
 	flag.StringVar(&mlPipelineAPIServerName, mlPipelineAPIServerNameFlagName, "ml-pipeline", "Name of the ML pipeline API server.")
 	flag.StringVar(&mlPipelineAPIServerPort, mlPipelineAPIServerPortFlagName, "8887", "Port of the ML pipeline API server.")
+	flag.StringVar(&mlPipelineAPIServerAddress, mlPipelineAPIServerAddressFlagName, "",
+		"The gRPC address of the ML pipeline API server of another cluster, reported to instead of the service of the namespace.")
 	flag.StringVar(&mlPipelineAPIServerBasePath, mlPipelineAPIServerBasePathFlagName,
 		"/api/v1/namespaces/%s/services/ml-pipeline:8888/proxy/apis/v1beta1/%s",
 		"The base path for the ML pipeline API server.")
+	flag.StringVar(&metricsAddress, metricsAddressFlagName, ":8080",
+		"The address serving the Prometheus metrics and the health probes. Empty to disable.")
+	flag.DurationVar(&healthCheckTimeout, healthCheckTimeoutFlagName, 5*time.Second,
+		"Duration to wait for each dependency check of the health probes.")
+	flag.StringVar(&diagnosticsAddress, diagnosticsAddressFlagName, "",
+		"Address of the admin port serving pprof, expvar, goroutine dumps and the effective flags. Disabled if empty.")
+	flag.StringVar(&metadataStoreAddress, metadataStoreAddressFlagName, "",
+		"Address (host:port) of the ML Metadata gRPC server the lineage of the runs is recorded in. Disabled if empty.")
+	flag.StringVar(&metricsExporter, metricsExporterFlagName, metrics.ExporterPrometheus,
+		"Where the metrics are exported to in addition to the Prometheus endpoint: prometheus (nowhere else), statsd or dogstatsd.")
+	flag.StringVar(&statsdAddress, statsdAddressFlagName, "localhost:8125",
+		"Address (host:port) of the StatsD server the metrics are sent to by the statsd and dogstatsd exporters.")
+	flag.StringVar(&statsdPrefix, statsdPrefixFlagName, "kfp.",
+		"Prefix of the names of the metrics sent to the StatsD server.")
+	flag.IntVar(&numWorker, numWorkerFlagName, 10,
+		"Number of workers processing the workflows, and the scheduled workflows. The updates of a workflow are always processed in order.")
+	flag.IntVar(&reportBatchSize, reportBatchSizeFlagName, 20,
+		"Maximum number of workflows reported to the ML pipeline API server per call. 1 to report them one by one.")
+	flag.DurationVar(&reportBatchDelay, reportBatchDelayFlagName, 50*time.Millisecond,
+		"Maximum duration a reported workflow waits for the others of its batch.")
+	flag.IntVar(&grpcMaxRecvMsgSize, grpcMaxRecvMsgSizeFlagName, 32<<20,
+		"Maximum size in bytes of the gRPC messages received from the ML pipeline API server and the ML Metadata server.")
+	flag.IntVar(&grpcMaxSendMsgSize, grpcMaxSendMsgSizeFlagName, 32<<20,
+		"Maximum size in bytes of the gRPC messages sent to the ML pipeline API server and the ML Metadata server.")
+	flag.BoolVar(&grpcCompression, grpcCompressionFlagName, false,
+		"Whether to compress the gRPC requests, and so the responses, with gzip. Requires servers supporting it.")
+	flag.DurationVar(&crdWaitTimeout, crdWaitTimeoutFlagName, 5*time.Minute,
+		"Duration to wait at startup for each of the CRDs of the workflows and the scheduled workflows to be installed. 0 to wait forever.")
+	flag.BoolVar(&leaderElect, leaderElectFlagName, false,
+		"Whether only the replica holding a Kubernetes lease in the namespace runs the agent, so that several replicas can be deployed.")
+	flag.StringVar(&leaseName, leaseNameFlagName, "ml-pipeline-persistenceagent",
+		"The name of the lease of the leader election, suffixed by the shard index if the objects are sharded.")
+	flag.DurationVar(&leaseDuration, leaseDurationFlagName, 15*time.Second,
+		"Duration after which the lease is taken over if its holder didn't renew it.")
+	flag.DurationVar(&renewDeadline, renewDeadlineFlagName, 10*time.Second,
+		"Duration the holder of the lease tries to renew it before it stops the agent. Shorter than the lease duration.")
+	flag.DurationVar(&retryPeriod, retryPeriodFlagName, 2*time.Second,
+		"Duration between the attempts to acquire or renew the lease.")
+	flag.IntVar(&shardIndex, shardIndexFlagName, 0,
+		"Index of the shard of the workflows and scheduled workflows reported by this deployment, from 0 to the shard count minus one.")
+	flag.IntVar(&shardCount, shardCountFlagName, 1,
+		"Number of deployments the objects are split between by the hash of their namespace/name. 1 to report all of them.")
+	flag.StringVar(&workflowGCMode, workflowGCModeFlagName, "",
+		"What happens to the completed workflows once persisted: delete (deleted from the cluster), label (labeled pipelines.kubeflow.org/archived=true), or empty to keep them.")
+	flag.DurationVar(&workflowGCGracePeriod, workflowGCGracePeriodFlagName, 24*time.Hour,
+		"Duration the completed workflows are kept in the cluster after they finished, before they're deleted or labeled.")
+	flag.BoolVar(&archiveLogs, archiveLogsFlagName, false,
+		"Whether to archive the logs of the steps of the completed workflows in the object store of the ML pipeline API server, so that they outlive the pods.")
+	flag.Int64Var(&archiveLogsLimitBytes, archiveLogsLimitBytesFlagName, 16<<20,
+		"Maximum size in bytes of the archived logs of each step. Smaller than the maximum size of the gRPC messages sent. 0 for no limit.")
+	flag.StringVar(&faultInjection, faultInjectionFlagName, "",
+		"For resilience testing only: the rules picking the reports to delay or drop, e.g. \"report.workflow:fail,probability=0.1\". Empty to inject no fault.")
+	flag.Int64Var(&faultInjectionSeed, faultInjectionSeedFlagName, 0,
+		"The seed of the random picks of the fault injection rules.")
+	flag.StringVar(&configFile, configFileFlagName, "",
+		"Path to a JSON file, e.g. mounted from a ConfigMap, setting the flags by name over the command line. The changes of workflowGCGracePeriod and reportBatchDelay are applied without a restart. Disabled if empty.")
+	flag.DurationVar(&configReloadInterval, configReloadIntervalFlagName, 30*time.Second,
+		"How often the config file is checked for changes.")
+	flag.Var(featureGate, featureGatesFlagName,
+		"The features enabled while they land, e.g. Caching=true,V2API=false. Set like the FeatureConfig.Gates of the ML pipeline API server.")
+}
+
+func serveMonitoring(address string, checker *health.Checker) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	checker.RegisterHandlers(mux)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Errorf("Failed to serve monitoring endpoints on %s: %v", address, err)
+	}
 }
@@ -22,6 +22,7 @@ import (
 	workflowinformers "github.com/argoproj/argo/pkg/client/informers/externalversions"
 	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
 	"github.com/kubeflow/pipelines/backend/src/agent/persistence/worker"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	swfregister "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow"
 	swfScheme "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned/scheme"
@@ -46,8 +47,13 @@ type PersistenceAgent struct {
 func NewPersistenceAgent(
 	swfInformerFactory swfinformers.SharedInformerFactory,
 	workflowInformerFactory workflowinformers.SharedInformerFactory,
-	pipelineClient *client.PipelineClient,
-	time util.TimeInterface) *PersistenceAgent {
+	pipelineClient client.PipelineClientInterface,
+	metadataStore metadata.MetadataStoreInterface,
+	collector *worker.WorkflowCollector,
+	podCollector *worker.PodCollector,
+	logArchiver *worker.LogArchiver,
+	time util.TimeInterface,
+	shard *util.Shard) *PersistenceAgent {
 	// obtain references to shared informers
 	swfInformer := swfInformerFactory.Scheduledworkflow().V1alpha1().ScheduledWorkflows()
 	workflowInformer := workflowInformerFactory.Argoproj().V1alpha1().Workflows()
@@ -60,11 +66,18 @@ func NewPersistenceAgent(
 	workflowClient := client.NewWorkflowClient(workflowInformer)
 
 	swfWorker := worker.NewPersistenceWorker(time, swfregister.Kind, swfInformer.Informer(), true,
-		worker.NewScheduledWorkflowSaver(swfClient, pipelineClient))
+		worker.NewScheduledWorkflowSaver(swfClient, pipelineClient), shard)
 
+	// The lineage of the runs is recorded only if a metadata store is configured.
+	var metadataRecorder *worker.MetadataRecorder
+	if metadataStore != nil {
+		metadataRecorder = worker.NewMetadataRecorder(metadataStore)
+	}
 	workflowWorker := worker.NewPersistenceWorker(time, workflowregister.Kind,
 		workflowInformer.Informer(), true,
-		worker.NewWorkflowSaver(workflowClient, pipelineClient))
+		worker.NewWorkflowSaver(workflowClient, pipelineClient, worker.NewRunStatsRecorder(time), metadataRecorder,
+			collector, podCollector, logArchiver),
+		shard)
 
 	agent := &PersistenceAgent{
 		swfClient:      swfClient,
@@ -78,6 +91,11 @@ func NewPersistenceAgent(
 	return agent
 }
 
+// HasSynced returns whether the informer caches of the agent are synced.
+func (p *PersistenceAgent) HasSynced() bool {
+	return p.workflowClient.HasSynced()() && p.swfClient.HasSynced()()
+}
+
 // Run will set up the event handlers for types we are interested in, as well
 // as syncing informer caches and starting workers. It will block until stopCh
 // is closed, at which point it will shutdown the workqueue and wait for
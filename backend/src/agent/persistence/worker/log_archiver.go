@@ -0,0 +1,101 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"sort"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	workflowcommon "github.com/argoproj/argo/workflow/common"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// The label of the workflows whose logs were archived, so that they're not read again
+// on every resync.
+const LogsArchivedLabelKey = "pipelines.kubeflow.org/logsArchived"
+
+var logsArchivedTotal = metrics.NewCounterVec(
+	"persistence_agent_logs_archived_total",
+	"Number of steps of completed workflows whose logs were archived, by result.",
+	"result")
+
+func init() {
+	metrics.MustRegister(logsArchivedTotal)
+}
+
+// LogArchiver archives the logs of the steps of the completed workflows in the object
+// store of the ML pipeline API server, so that they outlive the pods of the workflows.
+type LogArchiver struct {
+	pods           client.PodLogReaderInterface
+	pipelineClient client.PipelineClientInterface
+	workflows      client.WorkflowWriterInterface
+}
+
+func NewLogArchiver(pods client.PodLogReaderInterface, pipelineClient client.PipelineClientInterface,
+	workflows client.WorkflowWriterInterface) *LogArchiver {
+	return &LogArchiver{pods: pods, pipelineClient: pipelineClient, workflows: workflows}
+}
+
+// ArchiveIfCompleted archives the logs of the main container of each step of a completed
+// workflow. It returns whether the logs were archived. The steps whose pods are already
+// gone are skipped.
+func (a *LogArchiver) ArchiveIfCompleted(wf *util.Workflow) (bool, error) {
+	if !wf.IsInFinalState() || wf.Labels[LogsArchivedLabelKey] == "true" {
+		return false, nil
+	}
+	runID := string(wf.UID)
+	var nodeIDs []string
+	for nodeID, node := range wf.Status.Nodes {
+		if node.Type == workflowapi.NodeTypePod {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	sort.Strings(nodeIDs)
+	archived := 0
+	for _, nodeID := range nodeIDs {
+		// The pods of the steps are named after their nodes.
+		logs, err := a.pods.ReadPodLogs(wf.Namespace, nodeID, workflowcommon.MainContainerName)
+		if util.HasCustomCode(err, util.CUSTOM_CODE_NOT_FOUND) {
+			logsArchivedTotal.Inc("pod_not_found")
+			log.Warningf("The pod of node (%v) of Workflow (%v) is gone, its logs are not archived.",
+				nodeID, wf.Name)
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		err = a.pipelineClient.ReportRunLogs(&api.ReportRunLogsRequest{
+			RunId:  runID,
+			NodeId: nodeID,
+			Logs:   logs,
+		})
+		if err != nil {
+			return false, err
+		}
+		logsArchivedTotal.Inc("archived")
+		archived++
+	}
+	if err := a.workflows.Label(wf.Namespace, wf.Name, LogsArchivedLabelKey, "true"); err != nil {
+		return false, err
+	}
+	log.WithFields(log.Fields{
+		"Workflow": wf.Name,
+	}).Infof("Archived the logs of %v steps of Workflow (%v).", archived, wf.Name)
+	return true, nil
+}
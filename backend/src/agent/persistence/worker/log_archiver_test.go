@@ -0,0 +1,94 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newWorkflowWithSteps(phase workflowapi.NodePhase) *util.Workflow {
+	workflow := newFinishedWorkflow(phase, time.Unix(0, 0))
+	workflow.UID = types.UID("RUN_ID")
+	workflow.Status.Nodes = map[string]workflowapi.NodeStatus{
+		"MY_NAME":   {Type: workflowapi.NodeTypeDAG},
+		"MY_NAME-1": {Type: workflowapi.NodeTypePod},
+		"MY_NAME-2": {Type: workflowapi.NodeTypePod},
+	}
+	return workflow
+}
+
+func TestLogArchiver_ArchiveIfCompleted(t *testing.T) {
+	pods := client.NewPodLogReaderFake()
+	pods.StubLogs("MY_NAMESPACE", "MY_NAME-1", "main", []byte("hello"))
+	pipelineClient := client.NewPipelineClientFake()
+	workflows := client.NewWorkflowWriterFake()
+	archiver := NewLogArchiver(pods, pipelineClient, workflows)
+
+	// Not completed.
+	archived, err := archiver.ArchiveIfCompleted(newWorkflowWithSteps(workflowapi.NodeRunning))
+	assert.Nil(t, err)
+	assert.False(t, archived)
+	_, reported := pipelineClient.GetReportedLogs("RUN_ID", "MY_NAME-1")
+	assert.False(t, reported)
+
+	archived, err = archiver.ArchiveIfCompleted(newWorkflowWithSteps(workflowapi.NodeSucceeded))
+	assert.Nil(t, err)
+	assert.True(t, archived)
+	logs, reported := pipelineClient.GetReportedLogs("RUN_ID", "MY_NAME-1")
+	assert.True(t, reported)
+	assert.Equal(t, "hello", logs)
+	// The pod of the second step is gone.
+	_, reported = pipelineClient.GetReportedLogs("RUN_ID", "MY_NAME-2")
+	assert.False(t, reported)
+	assert.Equal(t, "true", workflows.Labels["MY_NAMESPACE/MY_NAME"][LogsArchivedLabelKey])
+}
+
+func TestLogArchiver_AlreadyArchived(t *testing.T) {
+	pods := client.NewPodLogReaderFake()
+	pods.StubLogs("MY_NAMESPACE", "MY_NAME-1", "main", []byte("hello"))
+	pipelineClient := client.NewPipelineClientFake()
+	archiver := NewLogArchiver(pods, pipelineClient, client.NewWorkflowWriterFake())
+	workflow := newWorkflowWithSteps(workflowapi.NodeSucceeded)
+	workflow.SetLabels(LogsArchivedLabelKey, "true")
+
+	archived, err := archiver.ArchiveIfCompleted(workflow)
+	assert.Nil(t, err)
+	assert.False(t, archived)
+	_, reported := pipelineClient.GetReportedLogs("RUN_ID", "MY_NAME-1")
+	assert.False(t, reported)
+}
+
+func TestLogArchiver_ReportError(t *testing.T) {
+	pods := client.NewPodLogReaderFake()
+	pods.StubLogs("MY_NAMESPACE", "MY_NAME-1", "main", []byte("hello"))
+	pipelineClient := client.NewPipelineClientFake()
+	pipelineClient.StubReportRunLogs(fmt.Errorf("Error"))
+	workflows := client.NewWorkflowWriterFake()
+	archiver := NewLogArchiver(pods, pipelineClient, workflows)
+
+	archived, err := archiver.ArchiveIfCompleted(newWorkflowWithSteps(workflowapi.NodeSucceeded))
+	assert.NotNil(t, err)
+	assert.False(t, archived)
+	// The logs are archived again on the next resync.
+	assert.Empty(t, workflows.Labels)
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// MetadataRecorder records the lineage of the completed workflows in an ML Metadata
+// store: the executions of their steps, and the artifacts they consumed and produced.
+type MetadataRecorder struct {
+	store    metadata.MetadataStoreInterface
+	recorded *lru.Cache
+}
+
+// NewMetadataRecorder creates a new instance of MetadataRecorder.
+func NewMetadataRecorder(store metadata.MetadataStoreInterface) *MetadataRecorder {
+	recorded, err := lru.New(recordedRunsCacheSize)
+	if err != nil {
+		log.Fatalf("Failed to create the cache of recorded runs: %v", err)
+	}
+	return &MetadataRecorder{store: store, recorded: recorded}
+}
+
+// RecordIfCompleted records the lineage of a workflow the first time it is seen in a
+// final state.
+func (r *MetadataRecorder) RecordIfCompleted(workflow *util.Workflow) error {
+	if !workflow.IsInFinalState() || r.recorded.Contains(workflow.UID) {
+		return nil
+	}
+	if err := r.store.RecordWorkflow(workflow); err != nil {
+		return err
+	}
+	r.recorded.Add(workflow.UID, true)
+	return nil
+}
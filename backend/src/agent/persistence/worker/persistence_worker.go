@@ -55,6 +55,8 @@ type PersistenceWorker struct {
 	time                 util.TimeInterface
 	enforceRequeueDelays bool
 	saver                Saver
+	// The part of the objects processed by this replica. Nil to process all of them.
+	shard *util.Shard
 }
 
 // NewPersistenceWorker returns a new PersistenceWorker
@@ -63,13 +65,15 @@ func NewPersistenceWorker(
 	name string,
 	eventHandler EventHandler,
 	enforceRequeueDelays bool,
-	saver Saver) *PersistenceWorker {
+	saver Saver,
+	shard *util.Shard) *PersistenceWorker {
 	worker := &PersistenceWorker{
 		workqueue: workqueue.NewNamedRateLimitingQueue(
 			workqueue.NewItemExponentialFailureRateLimiter(DefaultJobBackOff, MaxJobBackOff), name),
 		time:                 time,
 		enforceRequeueDelays: enforceRequeueDelays,
 		saver:                saver,
+		shard:                shard,
 	}
 
 	log.Info("Setting up event handlers")
@@ -112,6 +116,9 @@ func (p *PersistenceWorker) enqueue(obj interface{}) {
 		runtime.HandleError(fmt.Errorf("Equeuing object: error: %v: %+v", err, obj))
 		return
 	}
+	if !p.shard.Owns(key) {
+		return
+	}
 	if p.enforceRequeueDelays {
 		p.workqueue.AddRateLimited(key) // Exponential backoff.
 	} else {
@@ -121,7 +128,7 @@ func (p *PersistenceWorker) enqueue(obj interface{}) {
 
 func (p *PersistenceWorker) enqueueForDelete(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-	if err == nil {
+	if err == nil && p.shard.Owns(key) {
 		p.workqueue.Add(key)
 	}
 }
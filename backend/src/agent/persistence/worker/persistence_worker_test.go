@@ -54,14 +54,15 @@ func TestPersistenceWorker_Success(t *testing.T) {
 	pipelineClient := client.NewPipelineClientFake()
 
 	// Set up peristence worker
-	saver := NewWorkflowSaver(workflowClient, pipelineClient)
+	saver := NewWorkflowSaver(workflowClient, pipelineClient, NewRunStatsRecorder(util.NewFakeTimeForEpoch()), nil, nil, nil, nil)
 	eventHandler := NewFakeEventHandler()
 	worker := NewPersistenceWorker(
 		util.NewFakeTimeForEpoch(),
 		"PERSISTENCE_WORKER",
 		eventHandler,
 		false,
-		saver)
+		saver,
+		nil)
 
 	// Test
 	eventHandler.handler.OnAdd(workflow)
@@ -84,14 +85,15 @@ func TestPersistenceWorker_NotFoundError(t *testing.T) {
 	pipelineClient := client.NewPipelineClientFake()
 
 	// Set up peristence worker
-	saver := NewWorkflowSaver(workflowClient, pipelineClient)
+	saver := NewWorkflowSaver(workflowClient, pipelineClient, NewRunStatsRecorder(util.NewFakeTimeForEpoch()), nil, nil, nil, nil)
 	eventHandler := NewFakeEventHandler()
 	worker := NewPersistenceWorker(
 		util.NewFakeTimeForEpoch(),
 		"PERSISTENCE_WORKER",
 		eventHandler,
 		false,
-		saver)
+		saver,
+		nil)
 
 	// Test
 	eventHandler.handler.OnAdd(workflow)
@@ -115,14 +117,15 @@ func TestPersistenceWorker_GetWorklowError(t *testing.T) {
 	pipelineClient := client.NewPipelineClientFake()
 
 	// Set up peristence worker
-	saver := NewWorkflowSaver(workflowClient, pipelineClient)
+	saver := NewWorkflowSaver(workflowClient, pipelineClient, NewRunStatsRecorder(util.NewFakeTimeForEpoch()), nil, nil, nil, nil)
 	eventHandler := NewFakeEventHandler()
 	worker := NewPersistenceWorker(
 		util.NewFakeTimeForEpoch(),
 		"PERSISTENCE_WORKER",
 		eventHandler,
 		false,
-		saver)
+		saver,
+		nil)
 
 	// Test
 	eventHandler.handler.OnAdd(workflow)
@@ -148,14 +151,15 @@ func TestPersistenceWorker_ReportWorkflowRetryableError(t *testing.T) {
 		"My Retriable Error"))
 
 	// Set up peristence worker
-	saver := NewWorkflowSaver(workflowClient, pipelineClient)
+	saver := NewWorkflowSaver(workflowClient, pipelineClient, NewRunStatsRecorder(util.NewFakeTimeForEpoch()), nil, nil, nil, nil)
 	eventHandler := NewFakeEventHandler()
 	worker := NewPersistenceWorker(
 		util.NewFakeTimeForEpoch(),
 		"PERSISTENCE_WORKER",
 		eventHandler,
 		false,
-		saver)
+		saver,
+		nil)
 
 	// Test
 	eventHandler.handler.OnAdd(workflow)
@@ -181,14 +185,15 @@ func TestPersistenceWorker_ReportWorkflowNonRetryableError(t *testing.T) {
 		"My Permanent Error"))
 
 	// Set up peristence worker
-	saver := NewWorkflowSaver(workflowClient, pipelineClient)
+	saver := NewWorkflowSaver(workflowClient, pipelineClient, NewRunStatsRecorder(util.NewFakeTimeForEpoch()), nil, nil, nil, nil)
 	eventHandler := NewFakeEventHandler()
 	worker := NewPersistenceWorker(
 		util.NewFakeTimeForEpoch(),
 		"PERSISTENCE_WORKER",
 		eventHandler,
 		false,
-		saver)
+		saver,
+		nil)
 
 	// Test
 	eventHandler.handler.OnAdd(workflow)
@@ -196,3 +201,37 @@ func TestPersistenceWorker_ReportWorkflowNonRetryableError(t *testing.T) {
 	assert.Nil(t, pipelineClient.GetWorkflow("MY_NAMESPACE", "MY_NAME"))
 	assert.Equal(t, 0, worker.Len())
 }
+
+func TestPersistenceWorker_Shard(t *testing.T) {
+	workflowClient := client.NewWorkflowClientFake()
+	pipelineClient := client.NewPipelineClientFake()
+	saver := NewWorkflowSaver(workflowClient, pipelineClient, NewRunStatsRecorder(util.NewFakeTimeForEpoch()), nil, nil, nil, nil)
+	shard, err := util.NewShard(0, 2)
+	assert.Nil(t, err)
+	eventHandler := NewFakeEventHandler()
+	worker := NewPersistenceWorker(
+		util.NewFakeTimeForEpoch(),
+		"PERSISTENCE_WORKER",
+		eventHandler,
+		false,
+		saver,
+		shard)
+
+	// Only the workflows of the shard are queued.
+	owned := 0
+	for i := 0; i < 20; i++ {
+		workflow := &workflowapi.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "MY_NAMESPACE",
+				Name:      fmt.Sprintf("MY_NAME_%v", i),
+			},
+		}
+		if shard.Owns("MY_NAMESPACE/" + workflow.Name) {
+			owned++
+		}
+		eventHandler.handler.OnAdd(workflow)
+		eventHandler.handler.OnDelete(workflow)
+	}
+	assert.True(t, owned > 0 && owned < 20)
+	assert.Equal(t, owned, worker.Len())
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// The label of the workflows whose pods were deleted, so that they're not listed again
+// on every resync.
+const PodsCollectedLabelKey = "pipelines.kubeflow.org/podsCollected"
+
+var podsCollectedTotal = metrics.NewCounterVec(
+	"persistence_agent_pods_collected_total",
+	"Number of pods of completed workflows deleted by their pod GC strategy.",
+	"strategy")
+
+func init() {
+	metrics.MustRegister(podsCollectedTotal)
+}
+
+// PodCollector deletes the pods of the completed workflows, as set by the pod GC strategy
+// the API server labels the workflows with. The version of Argo the pipelines run on
+// doesn't support spec.podGC, so the strategy is enforced by the agent once the final
+// state of the workflow is persisted.
+type PodCollector struct {
+	pods      client.PodWriterInterface
+	workflows client.WorkflowWriterInterface
+}
+
+func NewPodCollector(pods client.PodWriterInterface, workflows client.WorkflowWriterInterface) *PodCollector {
+	return &PodCollector{pods: pods, workflows: workflows}
+}
+
+// CollectIfCompleted deletes the pods of a completed workflow, if its pod GC strategy says
+// so. It returns whether the pods were deleted.
+func (c *PodCollector) CollectIfCompleted(wf *util.Workflow) (bool, error) {
+	if !wf.IsInFinalState() || wf.Labels[PodsCollectedLabelKey] == "true" {
+		return false, nil
+	}
+	strategy := wf.Labels[util.LabelKeyWorkflowPodGCStrategy]
+	switch strategy {
+	case util.PodGCStrategyOnWorkflowCompletion:
+	case util.PodGCStrategyOnWorkflowSuccess:
+		// The pods of the failed workflows are kept for debugging.
+		if wf.Condition() != string(workflowapi.NodeSucceeded) {
+			return false, nil
+		}
+	default:
+		return false, nil
+	}
+	deleted, err := c.pods.DeleteWorkflowPods(wf.Namespace, wf.Name)
+	if err != nil {
+		return false, err
+	}
+	if err := c.workflows.Label(wf.Namespace, wf.Name, PodsCollectedLabelKey, "true"); err != nil {
+		return false, err
+	}
+	podsCollectedTotal.Add(float64(deleted), strategy)
+	log.WithFields(log.Fields{
+		"Workflow": wf.Name,
+	}).Infof("Deleted %v pods of Workflow (%v) (%v).", deleted, wf.Name, strategy)
+	return true, nil
+}
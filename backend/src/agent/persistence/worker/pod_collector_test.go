@@ -0,0 +1,95 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPodGCWorkflow(phase workflowapi.NodePhase, strategy string) *util.Workflow {
+	workflow := newFinishedWorkflow(phase, time.Unix(0, 0))
+	if strategy != "" {
+		workflow.SetLabels(util.LabelKeyWorkflowPodGCStrategy, strategy)
+	}
+	return workflow
+}
+
+func TestPodCollector_OnWorkflowCompletion(t *testing.T) {
+	pods := client.NewPodWriterFake()
+	workflows := client.NewWorkflowWriterFake()
+	collector := NewPodCollector(pods, workflows)
+
+	// Not completed.
+	collected, err := collector.CollectIfCompleted(newPodGCWorkflow(workflowapi.NodeRunning, util.PodGCStrategyOnWorkflowCompletion))
+	assert.Nil(t, err)
+	assert.False(t, collected)
+	// No strategy.
+	collected, err = collector.CollectIfCompleted(newPodGCWorkflow(workflowapi.NodeFailed, ""))
+	assert.Nil(t, err)
+	assert.False(t, collected)
+	assert.Empty(t, pods.Deleted)
+
+	collected, err = collector.CollectIfCompleted(newPodGCWorkflow(workflowapi.NodeFailed, util.PodGCStrategyOnWorkflowCompletion))
+	assert.Nil(t, err)
+	assert.True(t, collected)
+	assert.Equal(t, []string{"MY_NAMESPACE/MY_NAME"}, pods.Deleted)
+	assert.Equal(t, "true", workflows.Labels["MY_NAMESPACE/MY_NAME"][PodsCollectedLabelKey])
+}
+
+func TestPodCollector_OnWorkflowSuccess(t *testing.T) {
+	pods := client.NewPodWriterFake()
+	collector := NewPodCollector(pods, client.NewWorkflowWriterFake())
+
+	collected, err := collector.CollectIfCompleted(newPodGCWorkflow(workflowapi.NodeFailed, util.PodGCStrategyOnWorkflowSuccess))
+	assert.Nil(t, err)
+	assert.False(t, collected)
+
+	collected, err = collector.CollectIfCompleted(newPodGCWorkflow(workflowapi.NodeSucceeded, util.PodGCStrategyOnWorkflowSuccess))
+	assert.Nil(t, err)
+	assert.True(t, collected)
+	assert.Equal(t, []string{"MY_NAMESPACE/MY_NAME"}, pods.Deleted)
+}
+
+func TestPodCollector_AlreadyCollected(t *testing.T) {
+	pods := client.NewPodWriterFake()
+	collector := NewPodCollector(pods, client.NewWorkflowWriterFake())
+	workflow := newPodGCWorkflow(workflowapi.NodeSucceeded, util.PodGCStrategyOnWorkflowCompletion)
+	workflow.SetLabels(PodsCollectedLabelKey, "true")
+
+	collected, err := collector.CollectIfCompleted(workflow)
+	assert.Nil(t, err)
+	assert.False(t, collected)
+	assert.Empty(t, pods.Deleted)
+}
+
+func TestPodCollector_Error(t *testing.T) {
+	pods := client.NewPodWriterFake()
+	pods.SetError(fmt.Errorf("Error"))
+	workflows := client.NewWorkflowWriterFake()
+	collector := NewPodCollector(pods, workflows)
+
+	collected, err := collector.CollectIfCompleted(newPodGCWorkflow(workflowapi.NodeSucceeded, util.PodGCStrategyOnWorkflowCompletion))
+	assert.NotNil(t, err)
+	assert.False(t, collected)
+	// The pods are deleted again on the next resync.
+	assert.Empty(t, workflows.Labels)
+}
@@ -0,0 +1,147 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// The number of completed runs remembered to avoid counting a run twice when
+	// the informer resyncs.
+	recordedRunsCacheSize = 10000
+	unknownLabelValue     = "unknown"
+)
+
+var (
+	runDurationSeconds = metrics.NewHistogramVec(
+		"pipeline_run_duration_seconds",
+		"Duration of completed pipeline runs, in seconds.",
+		metrics.DefBuckets,
+		"pipeline_id", "experiment_id", "status")
+	runsCompletedTotal = metrics.NewCounterVec(
+		"pipeline_runs_completed_total",
+		"Number of completed pipeline runs, by final status.",
+		"pipeline_id", "experiment_id", "status")
+	runSchedulingLatencySeconds = metrics.NewHistogramVec(
+		"pipeline_run_scheduling_latency_seconds",
+		"Time between the creation of the workflow of a run and the start of its execution, in seconds.",
+		[]float64{0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600, 1800})
+	reportLagSeconds = metrics.NewHistogramVec(
+		"persistence_agent_report_lag_seconds",
+		"Time between the last status change of a workflow and its persistence in the database, in seconds.",
+		[]float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600})
+)
+
+func init() {
+	metrics.MustRegister(runDurationSeconds, runsCompletedTotal, runSchedulingLatencySeconds, reportLagSeconds)
+}
+
+// RunStatsRecorder records the stats of the runs observed by the persistence agent:
+// the duration and final status of completed runs, their scheduling latency, and
+// the lag between their status changes and their persistence.
+type RunStatsRecorder struct {
+	time util.TimeInterface
+	// Runs that completed or started before this epoch are ignored, so that restarting
+	// the agent does not count the existing runs again.
+	startEpoch        int64
+	recorded          *lru.Cache
+	started           *lru.Cache
+	reportedVersions  *lru.Cache
+	duration          *metrics.HistogramVec
+	completed         *metrics.CounterVec
+	schedulingLatency *metrics.HistogramVec
+	reportLag         *metrics.HistogramVec
+}
+
+// NewRunStatsRecorder creates a new instance of RunStatsRecorder.
+func NewRunStatsRecorder(time util.TimeInterface) *RunStatsRecorder {
+	recorded, err := lru.New(recordedRunsCacheSize)
+	if err != nil {
+		log.Fatalf("Failed to create the cache of recorded runs: %v", err)
+	}
+	started, err := lru.New(recordedRunsCacheSize)
+	if err != nil {
+		log.Fatalf("Failed to create the cache of started runs: %v", err)
+	}
+	reportedVersions, err := lru.New(recordedRunsCacheSize)
+	if err != nil {
+		log.Fatalf("Failed to create the cache of reported runs: %v", err)
+	}
+	return &RunStatsRecorder{
+		time:              time,
+		startEpoch:        time.Now().Unix(),
+		recorded:          recorded,
+		started:           started,
+		reportedVersions:  reportedVersions,
+		duration:          runDurationSeconds,
+		completed:         runsCompletedTotal,
+		schedulingLatency: runSchedulingLatencySeconds,
+		reportLag:         reportLagSeconds,
+	}
+}
+
+// RecordReported records the stats of a workflow that was just persisted.
+func (r *RunStatsRecorder) RecordReported(workflow *util.Workflow) {
+	// Periodic resyncs report workflows that did not change, which is not a lag.
+	version, ok := r.reportedVersions.Get(workflow.UID)
+	isNewVersion := !ok || version != workflow.ResourceVersion
+	r.reportedVersions.Add(workflow.UID, workflow.ResourceVersion)
+	if lastChange := workflow.LastStatusChangeTime(); isNewVersion && !lastChange.IsZero() {
+		r.reportLag.Observe(r.time.Now().Sub(lastChange).Seconds())
+	}
+	r.recordIfStarted(workflow)
+	r.RecordIfCompleted(workflow)
+}
+
+// recordIfStarted records the scheduling latency of a workflow the first time it is
+// seen started.
+func (r *RunStatsRecorder) recordIfStarted(workflow *util.Workflow) {
+	if workflow.Status.StartedAt.IsZero() || workflow.Status.StartedAt.Unix() < r.startEpoch {
+		return
+	}
+	if alreadyRecorded, _ := r.started.ContainsOrAdd(workflow.UID, true); alreadyRecorded {
+		return
+	}
+	r.schedulingLatency.Observe(workflow.Status.StartedAt.Sub(workflow.CreationTimestamp.Time).Seconds())
+}
+
+// RecordIfCompleted records the stats of a workflow the first time it is seen in a
+// final state.
+func (r *RunStatsRecorder) RecordIfCompleted(workflow *util.Workflow) {
+	if !workflow.IsInFinalState() || workflow.Status.FinishedAt.Unix() < r.startEpoch {
+		return
+	}
+	if alreadyRecorded, _ := r.recorded.ContainsOrAdd(workflow.UID, true); alreadyRecorded {
+		return
+	}
+	labels := []string{
+		valueOrUnknown(workflow.PipelineIdOrEmpty()),
+		valueOrUnknown(workflow.ExperimentIdOrEmpty()),
+		workflow.Condition(),
+	}
+	r.completed.Inc(labels...)
+	r.duration.Observe(workflow.DurationInSecOr0(), labels...)
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return unknownLabelValue
+	}
+	return value
+}
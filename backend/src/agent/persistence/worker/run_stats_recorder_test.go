@@ -0,0 +1,119 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newRunStatsRecorderForTest(startEpoch int64) *RunStatsRecorder {
+	recorder := NewRunStatsRecorder(util.NewFakeTime(time.Unix(startEpoch, 0)))
+	recorder.duration = metrics.NewHistogramVec("duration", "", []float64{10, 100}, "pipeline_id", "experiment_id", "status")
+	recorder.completed = metrics.NewCounterVec("completed", "", "pipeline_id", "experiment_id", "status")
+	recorder.schedulingLatency = metrics.NewHistogramVec("scheduling_latency", "", []float64{10, 100})
+	recorder.reportLag = metrics.NewHistogramVec("report_lag", "", []float64{10, 100})
+	return recorder
+}
+
+func newCompletedWorkflow(uid string, phase workflowapi.NodePhase, startedAt int64, finishedAt int64) *util.Workflow {
+	return util.NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			UID: types.UID(uid),
+			Labels: map[string]string{
+				util.LabelKeyWorkflowPipelineId:   "PIPELINE",
+				util.LabelKeyWorkflowExperimentId: "EXPERIMENT",
+			},
+		},
+		Status: workflowapi.WorkflowStatus{
+			Phase:      phase,
+			StartedAt:  metav1.NewTime(time.Unix(startedAt, 0)),
+			FinishedAt: metav1.NewTime(time.Unix(finishedAt, 0)),
+		},
+	})
+}
+
+func TestRunStatsRecorder_RecordIfCompleted(t *testing.T) {
+	recorder := newRunStatsRecorderForTest(100)
+
+	recorder.RecordIfCompleted(newCompletedWorkflow("1", workflowapi.NodeSucceeded, 100, 150))
+	recorder.RecordIfCompleted(newCompletedWorkflow("2", workflowapi.NodeFailed, 100, 105))
+	recorder.RecordIfCompleted(newCompletedWorkflow("3", workflowapi.NodeSucceeded, 100, 110))
+
+	assert.Equal(t, 2.0, recorder.completed.Value("PIPELINE", "EXPERIMENT", "Succeeded"))
+	assert.Equal(t, 1.0, recorder.completed.Value("PIPELINE", "EXPERIMENT", "Failed"))
+	assert.Equal(t, uint64(2), recorder.duration.Count("PIPELINE", "EXPERIMENT", "Succeeded"))
+}
+
+func TestRunStatsRecorder_RecordIfCompleted_OnlyOnce(t *testing.T) {
+	recorder := newRunStatsRecorderForTest(100)
+	workflow := newCompletedWorkflow("1", workflowapi.NodeSucceeded, 100, 150)
+
+	recorder.RecordIfCompleted(workflow)
+	recorder.RecordIfCompleted(workflow)
+
+	assert.Equal(t, 1.0, recorder.completed.Value("PIPELINE", "EXPERIMENT", "Succeeded"))
+}
+
+func TestRunStatsRecorder_RecordIfCompleted_IgnoresRunningAndOldRuns(t *testing.T) {
+	recorder := newRunStatsRecorderForTest(100)
+
+	recorder.RecordIfCompleted(newCompletedWorkflow("1", workflowapi.NodeRunning, 100, 0))
+	recorder.RecordIfCompleted(newCompletedWorkflow("2", workflowapi.NodeSucceeded, 10, 20))
+
+	assert.Equal(t, 0.0, recorder.completed.Value("PIPELINE", "EXPERIMENT", "Running"))
+	assert.Equal(t, 0.0, recorder.completed.Value("PIPELINE", "EXPERIMENT", "Succeeded"))
+}
+
+func TestRunStatsRecorder_RecordIfCompleted_UnknownPipeline(t *testing.T) {
+	recorder := newRunStatsRecorderForTest(100)
+	workflow := newCompletedWorkflow("1", workflowapi.NodeError, 100, 150)
+	workflow.Labels = nil
+
+	recorder.RecordIfCompleted(workflow)
+
+	assert.Equal(t, 1.0, recorder.completed.Value(unknownLabelValue, unknownLabelValue, "Error"))
+}
+
+func TestRunStatsRecorder_RecordReported(t *testing.T) {
+	recorder := newRunStatsRecorderForTest(100)
+	workflow := newCompletedWorkflow("1", workflowapi.NodeRunning, 105, 0)
+	workflow.CreationTimestamp = metav1.NewTime(time.Unix(101, 0))
+	workflow.ResourceVersion = "1"
+
+	recorder.RecordReported(workflow)
+	// A resync of the same version is neither a new report nor a new start.
+	recorder.RecordReported(workflow)
+
+	assert.Equal(t, uint64(1), recorder.reportLag.Count())
+	assert.Equal(t, uint64(1), recorder.schedulingLatency.Count())
+	assert.Equal(t, 0.0, recorder.completed.Value("PIPELINE", "EXPERIMENT", "Running"))
+
+	workflow.Status.Phase = workflowapi.NodeSucceeded
+	workflow.Status.FinishedAt = metav1.NewTime(time.Unix(110, 0))
+	workflow.ResourceVersion = "2"
+	recorder.RecordReported(workflow)
+
+	assert.Equal(t, uint64(2), recorder.reportLag.Count())
+	assert.Equal(t, uint64(1), recorder.schedulingLatency.Count())
+	assert.Equal(t, 1.0, recorder.completed.Value("PIPELINE", "EXPERIMENT", "Succeeded"))
+}
@@ -0,0 +1,139 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// The completed workflows are deleted from the cluster.
+	WorkflowGCModeDelete = "delete"
+	// The completed workflows are labeled, for an external archiver to pick them up.
+	WorkflowGCModeLabel = "label"
+
+	// The label of the workflows archived by the label mode.
+	WorkflowArchivedLabelKey = "pipelines.kubeflow.org/archived"
+)
+
+var workflowsCollectedTotal = metrics.NewCounterVec(
+	"persistence_agent_workflows_collected_total",
+	"Number of completed workflows deleted or labeled as archived after their persistence.",
+	"mode")
+
+func init() {
+	metrics.MustRegister(workflowsCollectedTotal)
+}
+
+// WorkflowCollector collects the completed workflows piling up in the cluster. A workflow
+// is collected once its final state is persisted, so that the run keeps the references
+// to its logs and artifacts in the database, and the grace period after it finished has
+// passed.
+type WorkflowCollector struct {
+	writer   client.WorkflowWriterInterface
+	recorder record.EventRecorder
+	mode     string
+	time     util.TimeInterface
+
+	// Guards the grace period, which can be changed while the workflows are collected.
+	mutex       sync.RWMutex
+	gracePeriod time.Duration
+}
+
+// NewWorkflowCollector creates a collector deleting or labeling the completed workflows
+// depending on the mode. It returns nil if the mode is empty, i.e. the workflows are kept.
+// The labeled workflows get an event recorded by the recorder.
+func NewWorkflowCollector(writer client.WorkflowWriterInterface, recorder record.EventRecorder, mode string,
+	gracePeriod time.Duration, time util.TimeInterface) (*WorkflowCollector, error) {
+	switch mode {
+	case "":
+		return nil, nil
+	case WorkflowGCModeDelete, WorkflowGCModeLabel:
+	default:
+		return nil, util.NewInvalidInputError("Unknown workflow garbage collection mode %q. Supported: %q, %q",
+			mode, WorkflowGCModeDelete, WorkflowGCModeLabel)
+	}
+	if err := validateGracePeriod(gracePeriod); err != nil {
+		return nil, err
+	}
+	return &WorkflowCollector{writer: writer, recorder: recorder, mode: mode, gracePeriod: gracePeriod,
+		time: time}, nil
+}
+
+func validateGracePeriod(gracePeriod time.Duration) error {
+	if gracePeriod < 0 {
+		return util.NewInvalidInputError("The grace period of the workflow garbage collection must be positive, not %v",
+			gracePeriod)
+	}
+	return nil
+}
+
+// SetGracePeriod changes the grace period of the workflows collected from now on.
+func (c *WorkflowCollector) SetGracePeriod(gracePeriod time.Duration) error {
+	if err := validateGracePeriod(gracePeriod); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.gracePeriod = gracePeriod
+	return nil
+}
+
+func (c *WorkflowCollector) getGracePeriod() time.Duration {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.gracePeriod
+}
+
+// CollectIfExpired collects a workflow whose final state was persisted, if its grace
+// period has passed. It returns whether the workflow was collected.
+func (c *WorkflowCollector) CollectIfExpired(wf *util.Workflow) (bool, error) {
+	if !wf.IsInFinalState() || wf.Labels[WorkflowArchivedLabelKey] == "true" {
+		return false, nil
+	}
+	finishedAt := wf.Status.FinishedAt.Time
+	if finishedAt.IsZero() {
+		finishedAt = wf.LastStatusChangeTime()
+	}
+	if c.time.Now().Before(finishedAt.Add(c.getGracePeriod())) {
+		return false, nil
+	}
+	var err error
+	if c.mode == WorkflowGCModeDelete {
+		err = c.writer.Delete(wf.Namespace, wf.Name)
+	} else {
+		err = c.writer.Label(wf.Namespace, wf.Name, WorkflowArchivedLabelKey, "true")
+		if err == nil {
+			c.recorder.Eventf(wf.Get(), corev1.EventTypeNormal, util.EventReasonWorkflowArchived,
+				"Workflow was labeled as archived by the persistence agent")
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	workflowsCollectedTotal.Inc(c.mode)
+	log.WithFields(log.Fields{
+		"Workflow": wf.Name,
+	}).Infof("Collected Workflow (%v) finished at %v (%v).", wf.Name, finishedAt, c.mode)
+	return true, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func newFinishedWorkflow(phase workflowapi.NodePhase, finishedAt time.Time) *util.Workflow {
+	return util.NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "MY_NAMESPACE", Name: "MY_NAME"},
+		Status:     workflowapi.WorkflowStatus{Phase: phase, FinishedAt: metav1.NewTime(finishedAt)},
+	})
+}
+
+func TestWorkflowCollector_Delete(t *testing.T) {
+	writer := client.NewWorkflowWriterFake()
+	now := time.Unix(10000, 0)
+	collector, err := NewWorkflowCollector(writer, record.NewFakeRecorder(10), WorkflowGCModeDelete,
+		time.Hour, util.NewFakeTime(now))
+	assert.Nil(t, err)
+
+	// Not completed.
+	collected, err := collector.CollectIfExpired(newFinishedWorkflow(workflowapi.NodeRunning, time.Time{}))
+	assert.Nil(t, err)
+	assert.False(t, collected)
+	// Within the grace period.
+	collected, err = collector.CollectIfExpired(newFinishedWorkflow(workflowapi.NodeSucceeded, now.Add(-time.Minute)))
+	assert.Nil(t, err)
+	assert.False(t, collected)
+	assert.Empty(t, writer.Deleted)
+
+	collected, err = collector.CollectIfExpired(newFinishedWorkflow(workflowapi.NodeFailed, now.Add(-2*time.Hour)))
+	assert.Nil(t, err)
+	assert.True(t, collected)
+	assert.Equal(t, []string{"MY_NAMESPACE/MY_NAME"}, writer.Deleted)
+}
+
+func TestWorkflowCollector_Label(t *testing.T) {
+	writer := client.NewWorkflowWriterFake()
+	now := time.Unix(10000, 0)
+	recorder := record.NewFakeRecorder(10)
+	collector, err := NewWorkflowCollector(writer, recorder, WorkflowGCModeLabel,
+		time.Hour, util.NewFakeTime(now))
+	assert.Nil(t, err)
+
+	wf := newFinishedWorkflow(workflowapi.NodeSucceeded, now.Add(-2*time.Hour))
+	collected, err := collector.CollectIfExpired(wf)
+	assert.Nil(t, err)
+	assert.True(t, collected)
+	assert.Equal(t, map[string]map[string]string{
+		"MY_NAMESPACE/MY_NAME": {WorkflowArchivedLabelKey: "true"},
+	}, writer.Labels)
+	assert.Equal(t, "Normal WorkflowArchived Workflow was labeled as archived by the persistence agent",
+		<-recorder.Events)
+
+	// An archived workflow isn't labeled again.
+	wf.Labels = map[string]string{WorkflowArchivedLabelKey: "true"}
+	collected, err = collector.CollectIfExpired(wf)
+	assert.Nil(t, err)
+	assert.False(t, collected)
+
+	writer.SetError(fmt.Errorf("conflict"))
+	_, err = collector.CollectIfExpired(newFinishedWorkflow(workflowapi.NodeSucceeded, now.Add(-2*time.Hour)))
+	assert.NotNil(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestWorkflowCollector_SetGracePeriod(t *testing.T) {
+	writer := client.NewWorkflowWriterFake()
+	now := time.Unix(10000, 0)
+	collector, err := NewWorkflowCollector(writer, record.NewFakeRecorder(10), WorkflowGCModeDelete,
+		time.Hour, util.NewFakeTime(now))
+	assert.Nil(t, err)
+
+	assert.NotNil(t, collector.SetGracePeriod(-time.Minute))
+	assert.Nil(t, collector.SetGracePeriod(time.Second))
+	collected, err := collector.CollectIfExpired(newFinishedWorkflow(workflowapi.NodeSucceeded, now.Add(-time.Minute)))
+	assert.Nil(t, err)
+	assert.True(t, collected)
+}
+
+func TestNewWorkflowCollector(t *testing.T) {
+	collector, err := NewWorkflowCollector(client.NewWorkflowWriterFake(), record.NewFakeRecorder(10), "",
+		time.Hour, util.NewFakeTimeForEpoch())
+	assert.Nil(t, err)
+	assert.Nil(t, collector)
+
+	_, err = NewWorkflowCollector(client.NewWorkflowWriterFake(), record.NewFakeRecorder(10), "archive",
+		time.Hour, util.NewFakeTimeForEpoch())
+	assert.NotNil(t, err)
+}
+
+func TestWorkflow_Save_CollectsCompletedWorkflow(t *testing.T) {
+	workflowFake := client.NewWorkflowClientFake()
+	pipelineFake := client.NewPipelineClientFake()
+	writer := client.NewWorkflowWriterFake()
+	collector, err := NewWorkflowCollector(writer, record.NewFakeRecorder(10), WorkflowGCModeDelete,
+		0, util.NewFakeTimeForEpoch())
+	assert.Nil(t, err)
+	workflowFake.Put("MY_NAMESPACE", "MY_NAME", newFinishedWorkflow(workflowapi.NodeSucceeded, time.Unix(0, 0)))
+
+	saver := NewWorkflowSaver(workflowFake, pipelineFake, NewRunStatsRecorder(util.NewFakeTimeForEpoch()), nil,
+		collector, nil, nil)
+	assert.Nil(t, saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20))
+	assert.Equal(t, []string{"MY_NAMESPACE/MY_NAME"}, writer.Deleted)
+}
@@ -23,17 +23,33 @@ import (
 
 // WorkflowSaver provides a function to persist a workflow to a database.
 type WorkflowSaver struct {
-	client          client.WorkflowClientInterface
-	pipelineClient  client.PipelineClientInterface
-	metricsReporter *MetricsReporter
+	client           client.WorkflowClientInterface
+	pipelineClient   client.PipelineClientInterface
+	metricsReporter  *MetricsReporter
+	runStatsRecorder *RunStatsRecorder
+	// Nil if the lineage is not recorded.
+	metadataRecorder *MetadataRecorder
+	// Nil if the completed workflows are kept.
+	collector *WorkflowCollector
+	// Nil if the pods of the completed workflows are kept.
+	podCollector *PodCollector
+	// Nil if the logs of the completed workflows are not archived.
+	logArchiver *LogArchiver
 }
 
 func NewWorkflowSaver(client client.WorkflowClientInterface,
-	pipelineClient client.PipelineClientInterface) *WorkflowSaver {
+	pipelineClient client.PipelineClientInterface, runStatsRecorder *RunStatsRecorder,
+	metadataRecorder *MetadataRecorder, collector *WorkflowCollector, podCollector *PodCollector,
+	logArchiver *LogArchiver) *WorkflowSaver {
 	return &WorkflowSaver{
-		client:          client,
-		pipelineClient:  pipelineClient,
-		metricsReporter: NewMetricsReporter(pipelineClient),
+		client:           client,
+		pipelineClient:   pipelineClient,
+		metricsReporter:  NewMetricsReporter(pipelineClient),
+		runStatsRecorder: runStatsRecorder,
+		metadataRecorder: metadataRecorder,
+		collector:        collector,
+		podCollector:     podCollector,
+		logArchiver:      logArchiver,
 	}
 }
 
@@ -73,5 +89,35 @@ func (s *WorkflowSaver) Save(key string, namespace string, name string, nowEpoch
 	log.WithFields(log.Fields{
 		"Workflow": name,
 	}).Infof("Syncing Workflow (%v): success, processing complete.", name)
-	return s.metricsReporter.ReportMetrics(wf)
+	s.runStatsRecorder.RecordReported(wf)
+	if s.metadataRecorder != nil {
+		if err := s.metadataRecorder.RecordIfCompleted(wf); err != nil {
+			// The lineage is recorded on the next resync of the workflow.
+			log.Errorf("Failed to record the lineage of Workflow (%v): %v", name, err)
+		}
+	}
+	if err := s.metricsReporter.ReportMetrics(wf); err != nil {
+		return err
+	}
+	// The pods are kept until their logs are archived, which is retried on the next resync.
+	logsArchived := true
+	if s.logArchiver != nil {
+		if _, err := s.logArchiver.ArchiveIfCompleted(wf); err != nil {
+			log.Errorf("Failed to archive the logs of Workflow (%v): %v", name, err)
+			logsArchived = false
+		}
+	}
+	if s.podCollector != nil && logsArchived {
+		if _, err := s.podCollector.CollectIfCompleted(wf); err != nil {
+			log.Errorf("Failed to delete the pods of Workflow (%v): %v", name, err)
+		}
+	}
+	// The workflow is collected once everything it holds is persisted. Until its grace
+	// period passes, it's checked again on every resync.
+	if s.collector != nil {
+		if _, err := s.collector.CollectIfExpired(wf); err != nil {
+			log.Errorf("Failed to collect Workflow (%v): %v", name, err)
+		}
+	}
+	return nil
 }
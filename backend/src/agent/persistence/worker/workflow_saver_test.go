@@ -20,6 +20,7 @@ import (
 
 	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	"github.com/kubeflow/pipelines/backend/src/agent/persistence/client"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,7 +42,12 @@ func TestWorkflow_Save_Success(t *testing.T) {
 
 	saver := NewWorkflowSaver(
 		workflowFake,
-		pipelineFake)
+		pipelineFake,
+		NewRunStatsRecorder(util.NewFakeTimeForEpoch()),
+		nil,
+		nil,
+		nil,
+		nil)
 
 	err := saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20)
 
@@ -55,7 +61,12 @@ func TestWorkflow_Save_NotFoundDuringGet(t *testing.T) {
 
 	saver := NewWorkflowSaver(
 		workflowFake,
-		pipelineFake)
+		pipelineFake,
+		NewRunStatsRecorder(util.NewFakeTimeForEpoch()),
+		nil,
+		nil,
+		nil,
+		nil)
 
 	err := saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20)
 
@@ -72,7 +83,12 @@ func TestWorkflow_Save_ErrorDuringGet(t *testing.T) {
 
 	saver := NewWorkflowSaver(
 		workflowFake,
-		pipelineFake)
+		pipelineFake,
+		NewRunStatsRecorder(util.NewFakeTimeForEpoch()),
+		nil,
+		nil,
+		nil,
+		nil)
 
 	err := saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20)
 
@@ -99,7 +115,12 @@ func TestWorkflow_Save_PermanentFailureWhileReporting(t *testing.T) {
 
 	saver := NewWorkflowSaver(
 		workflowFake,
-		pipelineFake)
+		pipelineFake,
+		NewRunStatsRecorder(util.NewFakeTimeForEpoch()),
+		nil,
+		nil,
+		nil,
+		nil)
 
 	err := saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20)
 
@@ -126,7 +147,12 @@ func TestWorkflow_Save_TransientFailureWhileReporting(t *testing.T) {
 
 	saver := NewWorkflowSaver(
 		workflowFake,
-		pipelineFake)
+		pipelineFake,
+		NewRunStatsRecorder(util.NewFakeTimeForEpoch()),
+		nil,
+		nil,
+		nil,
+		nil)
 
 	err := saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20)
 
@@ -134,3 +160,37 @@ func TestWorkflow_Save_TransientFailureWhileReporting(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "transient failure")
 }
+
+func TestWorkflow_Save_RecordsLineageOfCompletedWorkflows(t *testing.T) {
+	workflowFake := client.NewWorkflowClientFake()
+	pipelineFake := client.NewPipelineClientFake()
+	store := metadata.NewFakeMetadataStore()
+
+	workflow := util.NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "MY_NAMESPACE",
+			Name:      "MY_NAME",
+			UID:       "WORKFLOW_1",
+		},
+		Status: workflowapi.WorkflowStatus{Phase: workflowapi.NodeRunning},
+	})
+	workflowFake.Put("MY_NAMESPACE", "MY_NAME", workflow)
+
+	saver := NewWorkflowSaver(
+		workflowFake,
+		pipelineFake,
+		NewRunStatsRecorder(util.NewFakeTimeForEpoch()),
+		NewMetadataRecorder(store),
+		nil,
+		nil,
+		nil)
+
+	assert.Nil(t, saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20))
+	_, err := store.GetRunLineage("WORKFLOW_1")
+	assert.NotNil(t, err)
+
+	workflow.Status.Phase = workflowapi.NodeSucceeded
+	assert.Nil(t, saver.Save("MY_KEY", "MY_NAMESPACE", "MY_NAME", 20))
+	_, err = store.GetRunLineage("WORKFLOW_1")
+	assert.Nil(t, err)
+}
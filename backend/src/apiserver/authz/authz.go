@@ -0,0 +1,217 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The decisions of the authorization hook.
+const (
+	DecisionAllowed = "allowed"
+	DecisionDenied  = "denied"
+	DecisionFailed  = "failed"
+)
+
+var decisionsCounter = metrics.NewCounterVec("authorization_decisions_total",
+	"The calls authorized by the external authorization policy, by decision.", "decision")
+
+func init() {
+	metrics.MustRegister(decisionsCounter)
+}
+
+// Request is the input of the authorization policy: who makes a call, what it does, and to
+// which resource, e.g. the user alice deleting the pipeline 123.
+type Request struct {
+	// The ID of the user making the call, empty if the user isn't authenticated.
+	Subject string `json:"subject"`
+	// The verb of the call, e.g. get, list, create or delete.
+	Verb string `json:"verb"`
+	// The type of the resource, e.g. pipeline, run or model_registry.
+	Resource string `json:"resource"`
+	// The ID of the resource, empty for the calls not acting on a single resource, e.g. list.
+	ResourceId string `json:"resource_id,omitempty"`
+	// The full name of the RPC, e.g. /api.PipelineService/DeletePipeline, or the path of the
+	// HTTP handler, for the rules which need to tell the calls apart.
+	Method string `json:"method"`
+}
+
+// NewRequest returns the authorization request of a call of an RPC, named
+// /package.Service/Method. The verb is the first word of the method and the resource is the
+// service, e.g. delete and pipeline for /api.PipelineService/DeletePipeline. The resource ID
+// is the ID of the request, if it has one.
+func NewRequest(fullMethod string, subject string, request interface{}) *Request {
+	service := fullMethod
+	method := ""
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		service, method = fullMethod[:i], fullMethod[i+1:]
+	}
+	service = strings.TrimSuffix(service[strings.LastIndex(service, ".")+1:], "Service")
+	words := splitCamelCase(method)
+	verb := ""
+	if len(words) > 0 {
+		verb = words[0]
+	}
+	resourceId := ""
+	if withId, ok := request.(interface{ GetId() string }); ok {
+		resourceId = withId.GetId()
+	}
+	return &Request{
+		Subject:    subject,
+		Verb:       verb,
+		Resource:   strings.Join(splitCamelCase(service), "_"),
+		ResourceId: resourceId,
+		Method:     fullMethod,
+	}
+}
+
+// splitCamelCase returns the lower case words of a camel case name, e.g. model and registry
+// for ModelRegistry.
+func splitCamelCase(name string) []string {
+	var words []string
+	start := 0
+	for i, r := range name {
+		if i > start && unicode.IsUpper(r) {
+			words = append(words, strings.ToLower(name[start:i]))
+			start = i
+		}
+	}
+	if start < len(name) {
+		words = append(words, strings.ToLower(name[start:]))
+	}
+	return words
+}
+
+// Decision is the decision of the authorization policy on a request.
+type Decision struct {
+	Allowed bool
+	// Why the request is denied, if the policy tells.
+	Reason string
+}
+
+// Authorizer evaluates the authorization policy, e.g. by querying an OPA server.
+type Authorizer interface {
+	Authorize(ctx context.Context, request *Request) (*Decision, error)
+}
+
+// OpaAuthorizer queries the decision of a policy from an OPA server, with its data API.
+type OpaAuthorizer struct {
+	url    string
+	client *http.Client
+}
+
+// NewOpaAuthorizer returns the authorizer querying the decision at the URL of a rule, e.g.
+// http://opa:8181/v1/data/kubeflow/pipelines/allow. The rule is either a boolean or an
+// object like {"allow": false, "reason": "Only the leads may delete pipelines"}. The request
+// is the input of the rule.
+func NewOpaAuthorizer(url string, timeout time.Duration) *OpaAuthorizer {
+	return &OpaAuthorizer{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (a *OpaAuthorizer) Authorize(ctx context.Context, request *Request) (*Decision, error) {
+	body, err := json.Marshal(map[string]interface{}{"input": request})
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	response, err := a.client.Do(httpRequest.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OPA responded with status %v", response.Status)
+	}
+	var result struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of OPA: %v", err)
+	}
+	// The rule is undefined, e.g. because no rule allows the request and it has no default.
+	if len(result.Result) == 0 {
+		return &Decision{Allowed: false}, nil
+	}
+	var allowed bool
+	if err := json.Unmarshal(result.Result, &allowed); err == nil {
+		return &Decision{Allowed: allowed}, nil
+	}
+	var decision struct {
+		Allow  bool   `json:"allow"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(result.Result, &decision); err != nil {
+		return nil, fmt.Errorf("expected a boolean or an object with allow as the result of OPA, got %s",
+			result.Result)
+	}
+	return &Decision{Allowed: decision.Allow, Reason: decision.Reason}, nil
+}
+
+// Hook checks the calls against the authorization policy, in addition to the built-in access
+// control. The calls fail if the policy can't be evaluated, unless the hook fails open.
+type Hook struct {
+	authorizer Authorizer
+	failOpen   bool
+}
+
+func NewHook(authorizer Authorizer, failOpen bool) *Hook {
+	return &Hook{authorizer: authorizer, failOpen: failOpen}
+}
+
+// Check returns a PermissionDenied error if the policy denies a request, and an Unavailable
+// error if it can't be evaluated and the hook doesn't fail open.
+func (h *Hook) Check(ctx context.Context, request *Request) error {
+	decision, err := h.authorizer.Authorize(ctx, request)
+	if err != nil {
+		decisionsCounter.Inc(DecisionFailed)
+		if h.failOpen {
+			glog.Warningf("Allowing %v: failed to evaluate the authorization policy: %v", request.Method, err)
+			return nil
+		}
+		return util.NewUnavailableError(err, "Failed to evaluate the authorization policy")
+	}
+	if !decision.Allowed {
+		decisionsCounter.Inc(DecisionDenied)
+		subject := request.Subject
+		if subject == "" {
+			subject = "An unauthenticated user"
+		}
+		message := fmt.Sprintf("%v isn't allowed to %v the %v", subject, request.Verb, request.Resource)
+		if request.ResourceId != "" {
+			message += " " + request.ResourceId
+		}
+		if decision.Reason != "" {
+			message += ": " + decision.Reason
+		}
+		return util.NewPermissionDeniedError("%v", message)
+	}
+	decisionsCounter.Inc(DecisionAllowed)
+	return nil
+}
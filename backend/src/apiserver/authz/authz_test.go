@@ -0,0 +1,127 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNewRequest(t *testing.T) {
+	request := NewRequest("/api.PipelineService/DeletePipeline", "alice", &api.DeletePipelineRequest{Id: "123"})
+	assert.Equal(t, &Request{
+		Subject:    "alice",
+		Verb:       "delete",
+		Resource:   "pipeline",
+		ResourceId: "123",
+		Method:     "/api.PipelineService/DeletePipeline",
+	}, request)
+
+	request = NewRequest("/api.ModelRegistryService/ListModelVersions", "", &api.ListModelVersionsRequest{})
+	assert.Equal(t, "list", request.Verb)
+	assert.Equal(t, "model_registry", request.Resource)
+	assert.Empty(t, request.ResourceId)
+}
+
+// newOpaServer returns an OPA server answering with a result, and recording the input.
+func newOpaServer(t *testing.T, result string, input *Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/data/kubeflow/pipelines/allow", r.URL.Path)
+		body := struct {
+			Input *Request `json:"input"`
+		}{Input: input}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Write([]byte(result))
+	}))
+}
+
+func TestOpaAuthorizer(t *testing.T) {
+	request := &Request{Subject: "alice", Verb: "delete", Resource: "pipeline", ResourceId: "123"}
+	tests := []struct {
+		result   string
+		expected *Decision
+	}{
+		{`{"result": true}`, &Decision{Allowed: true}},
+		{`{"result": false}`, &Decision{Allowed: false}},
+		{`{"result": {"allow": false, "reason": "Only the leads may delete pipelines"}}`,
+			&Decision{Allowed: false, Reason: "Only the leads may delete pipelines"}},
+		// The rule is undefined.
+		{`{}`, &Decision{Allowed: false}},
+	}
+	for _, test := range tests {
+		input := &Request{}
+		server := newOpaServer(t, test.result, input)
+		authorizer := NewOpaAuthorizer(server.URL+"/v1/data/kubeflow/pipelines/allow", time.Second)
+		decision, err := authorizer.Authorize(context.Background(), request)
+		server.Close()
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, decision)
+		assert.Equal(t, request, input)
+	}
+}
+
+func TestOpaAuthorizer_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	authorizer := NewOpaAuthorizer(server.URL, time.Second)
+	_, err := authorizer.Authorize(context.Background(), &Request{})
+	assert.NotNil(t, err)
+
+	invalid := newOpaServer(t, `{"result": "yes"}`, &Request{})
+	defer invalid.Close()
+	authorizer = NewOpaAuthorizer(invalid.URL+"/v1/data/kubeflow/pipelines/allow", time.Second)
+	_, err = authorizer.Authorize(context.Background(), &Request{})
+	assert.NotNil(t, err)
+}
+
+type fakeAuthorizer struct {
+	decision *Decision
+	err      error
+}
+
+func (a *fakeAuthorizer) Authorize(ctx context.Context, request *Request) (*Decision, error) {
+	return a.decision, a.err
+}
+
+func TestHook_Check(t *testing.T) {
+	request := &Request{Subject: "alice", Verb: "delete", Resource: "pipeline", ResourceId: "123"}
+	hook := NewHook(&fakeAuthorizer{decision: &Decision{Allowed: true}}, false)
+	assert.Nil(t, hook.Check(context.Background(), request))
+
+	hook = NewHook(&fakeAuthorizer{decision: &Decision{Reason: "Only the leads may delete pipelines"}}, false)
+	err := hook.Check(context.Background(), request)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.Contains(t, err.Error(),
+		"alice isn't allowed to delete the pipeline 123: Only the leads may delete pipelines")
+	assert.Equal(t, float64(1), decisionsCounter.Value(DecisionDenied))
+
+	hook = NewHook(&fakeAuthorizer{err: errors.New("connection refused")}, false)
+	err = hook.Check(context.Background(), request)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unavailable))
+	hook = NewHook(&fakeAuthorizer{err: errors.New("connection refused")}, true)
+	assert.Nil(t, hook.Check(context.Background(), request))
+}
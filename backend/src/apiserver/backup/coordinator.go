@@ -0,0 +1,203 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+// The output of the snapshot command kept in the marker.
+const maxMessageLength = 4096
+
+// SnapshotHook takes the snapshot of the database for a backup, e.g. with mysqldump, and
+// returns a message describing it. It is called while the writes are quiesced.
+type SnapshotHook func(ctx context.Context, backupId string) (string, error)
+
+// NewCommandSnapshotHook runs a shell command to take the snapshot, with the ID of the
+// backup in the BACKUP_ID environment variable. The output of the command is the message
+// of the snapshot.
+func NewCommandSnapshotHook(command string, timeout time.Duration) SnapshotHook {
+	return func(ctx context.Context, backupId string) (string, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+		cmd.Env = append(os.Environ(), "BACKUP_ID="+backupId)
+		var output bytes.Buffer
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+		err := cmd.Run()
+		message := truncate(strings.TrimSpace(output.String()))
+		if err != nil {
+			return message, util.NewInternalServerError(errors.Wrap(err, message), "Failed to run the snapshot command")
+		}
+		return message, nil
+	}
+}
+
+// Manifest lists the files of the object store at the time of a backup.
+type Manifest struct {
+	BackupId       string               `json:"backup_id"`
+	CreatedAtInSec int64                `json:"created_at_in_sec"`
+	Files          []storage.StoredFile `json:"files"`
+}
+
+// Coordinator takes point-in-time backups of the database and the object store, which
+// can be restored together. The writes are quiesced while the database snapshot is taken
+// and the files of the object store are listed, and the manifest of the files is stored
+// along with a marker in the database recording the backup.
+//
+// Listing the files doesn't copy them: the bucket must be versioned, or its files kept,
+// for the manifest to be restorable.
+type Coordinator struct {
+	gate           *WriteGate
+	markerStore    storage.BackupMarkerStoreInterface
+	objectStore    storage.ObjectStoreInterface
+	snapshot       SnapshotHook
+	quiesceTimeout time.Duration
+	time           util.TimeInterface
+	uuid           util.UUIDGeneratorInterface
+
+	// One backup is taken at a time.
+	mu     sync.Mutex
+	taking bool
+}
+
+func NewCoordinator(gate *WriteGate, markerStore storage.BackupMarkerStoreInterface,
+	objectStore storage.ObjectStoreInterface, snapshot SnapshotHook, quiesceTimeout time.Duration,
+	time util.TimeInterface, uuid util.UUIDGeneratorInterface) *Coordinator {
+	return &Coordinator{
+		gate:           gate,
+		markerStore:    markerStore,
+		objectStore:    objectStore,
+		snapshot:       snapshot,
+		quiesceTimeout: quiesceTimeout,
+		time:           time,
+		uuid:           uuid,
+	}
+}
+
+// TakeSnapshot takes a backup and returns its marker. The marker of a failed backup is
+// kept with the reason of the failure, unless the writes couldn't be quiesced.
+func (c *Coordinator) TakeSnapshot(ctx context.Context) (*model.BackupMarker, error) {
+	c.mu.Lock()
+	if c.taking {
+		c.mu.Unlock()
+		return nil, util.NewAlreadyExistError("A backup is already being taken")
+	}
+	c.taking = true
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.taking = false
+		c.mu.Unlock()
+	}()
+
+	id, err := c.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate the backup ID")
+	}
+	marker := &model.BackupMarker{
+		UUID:           id.String(),
+		CreatedAtInSec: c.time.Now().Unix(),
+		Status:         model.BackupMarkerTaking,
+		ManifestPath:   storage.CreateBackupManifestPath(id.String()),
+	}
+
+	start := time.Now()
+	if err := c.gate.Quiesce(c.quiesceTimeout); err != nil {
+		return nil, util.Wrap(err, "Failed to quiesce the writes for the backup")
+	}
+	manifest, err := c.takeSnapshot(ctx, marker)
+	c.gate.Resume()
+	glog.Infof("Writes were paused for %v by backup %v", time.Since(start), marker.UUID)
+	if err != nil {
+		return c.fail(marker, err)
+	}
+
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return c.fail(marker, util.NewInternalServerError(err, "Failed to marshal the backup manifest"))
+	}
+	if err := c.objectStore.AddFile(content, marker.ManifestPath); err != nil {
+		return c.fail(marker, util.Wrap(err, "Failed to store the backup manifest"))
+	}
+	marker.Status = model.BackupMarkerSucceeded
+	marker.FileCount = int64(len(manifest.Files))
+	if err := c.markerStore.UpdateMarker(marker); err != nil {
+		return nil, err
+	}
+	return marker, nil
+}
+
+// takeSnapshot runs while the writes are quiesced. The marker is stored before the
+// database snapshot is taken, so that the snapshot records the backup it belongs to.
+func (c *Coordinator) takeSnapshot(ctx context.Context, marker *model.BackupMarker) (*Manifest, error) {
+	if err := c.markerStore.CreateMarker(marker); err != nil {
+		return nil, err
+	}
+	message, err := c.snapshot(ctx, marker.UUID)
+	marker.Message = message
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to take the database snapshot")
+	}
+	files, err := c.objectStore.ListFiles("")
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to list the files of the object store")
+	}
+	manifest := &Manifest{BackupId: marker.UUID, CreatedAtInSec: marker.CreatedAtInSec, Files: []storage.StoredFile{}}
+	for _, file := range files {
+		// The manifests of the previous backups aren't part of the backup.
+		if strings.HasPrefix(file.Path, storage.BackupFolder+"/") {
+			continue
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+	return manifest, nil
+}
+
+func (c *Coordinator) fail(marker *model.BackupMarker, err error) (*model.BackupMarker, error) {
+	marker.Status = model.BackupMarkerFailed
+	marker.Message = truncate(fmt.Sprintf("%v", err))
+	if updateErr := c.markerStore.UpdateMarker(marker); updateErr != nil {
+		glog.Errorf("Failed to record the failure of backup %v: %+v", marker.UUID, updateErr)
+	}
+	return nil, err
+}
+
+// ListSnapshots returns the markers of the latest backups first.
+func (c *Coordinator) ListSnapshots(limit int) ([]*model.BackupMarker, error) {
+	return c.markerStore.ListMarkers(limit)
+}
+
+func truncate(message string) string {
+	if len(message) > maxMessageLength {
+		return message[:maxMessageLength]
+	}
+	return message
+}
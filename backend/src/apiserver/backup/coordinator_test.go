@@ -0,0 +1,114 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const fakeBackupId = "123e4567-e89b-12d3-a456-426655440000"
+
+func newTestCoordinator(t *testing.T, snapshot SnapshotHook) (*Coordinator, storage.BackupMarkerStoreInterface,
+	storage.ObjectStoreInterface) {
+	db := storage.NewFakeDbOrFatal()
+	markerStore := storage.NewBackupMarkerStore(db)
+	objectStore := storage.NewFakeObjectStore()
+	coordinator := NewCoordinator(NewWriteGate(time.Minute), markerStore, objectStore, snapshot, time.Minute,
+		util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeBackupId, nil))
+	return coordinator, markerStore, objectStore
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	var coordinator *Coordinator
+	var markerStore storage.BackupMarkerStoreInterface
+	snapshot := func(ctx context.Context, backupId string) (string, error) {
+		// The marker is in the database when the snapshot is taken, and the writes are
+		// quiesced.
+		markers, err := markerStore.ListMarkers(1)
+		assert.Nil(t, err)
+		assert.Equal(t, backupId, markers[0].UUID)
+		assert.Equal(t, model.BackupMarkerTaking, markers[0].Status)
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		assert.True(t, util.IsUnavailableError(coordinator.gate.Enter(ctx)))
+		return "dumped", nil
+	}
+	coordinator, markerStore, objectStore := newTestCoordinator(t, snapshot)
+	assert.Nil(t, objectStore.AddFile([]byte("template"), "pipelines/1"))
+	assert.Nil(t, objectStore.AddFile([]byte("old"), storage.CreateBackupManifestPath("old")))
+
+	marker, err := coordinator.TakeSnapshot(context.Background())
+	assert.Nil(t, err)
+	expected := &model.BackupMarker{
+		UUID:           fakeBackupId,
+		CreatedAtInSec: 1,
+		Status:         model.BackupMarkerSucceeded,
+		ManifestPath:   "backups/" + fakeBackupId + "/manifest.json",
+		FileCount:      1,
+		Message:        "dumped",
+	}
+	assert.Equal(t, expected, marker)
+	markers, err := coordinator.ListSnapshots(10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.BackupMarker{expected}, markers)
+
+	content, err := objectStore.GetFile(marker.ManifestPath)
+	assert.Nil(t, err)
+	var manifest Manifest
+	assert.Nil(t, json.Unmarshal(content, &manifest))
+	assert.Equal(t, fakeBackupId, manifest.BackupId)
+	assert.Len(t, manifest.Files, 1)
+	assert.Equal(t, "pipelines/1", manifest.Files[0].Path)
+
+	// The writes resumed.
+	assert.Nil(t, coordinator.gate.Enter(context.Background()))
+	coordinator.gate.Exit()
+}
+
+func TestTakeSnapshot_SnapshotFailed(t *testing.T) {
+	snapshot := func(ctx context.Context, backupId string) (string, error) {
+		return "access denied", errors.New("exit status 2")
+	}
+	coordinator, _, _ := newTestCoordinator(t, snapshot)
+
+	_, err := coordinator.TakeSnapshot(context.Background())
+	assert.NotNil(t, err)
+	markers, err := coordinator.ListSnapshots(10)
+	assert.Nil(t, err)
+	assert.Len(t, markers, 1)
+	assert.Equal(t, model.BackupMarkerFailed, markers[0].Status)
+	assert.Contains(t, markers[0].Message, "Failed to take the database snapshot")
+	assert.Nil(t, coordinator.gate.Enter(context.Background()))
+	coordinator.gate.Exit()
+}
+
+func TestCommandSnapshotHook(t *testing.T) {
+	message, err := NewCommandSnapshotHook("echo dumped $BACKUP_ID", time.Minute)(context.Background(), "id")
+	assert.Nil(t, err)
+	assert.Equal(t, "dumped id", message)
+
+	message, err = NewCommandSnapshotHook("echo denied >&2; exit 1", time.Minute)(context.Background(), "id")
+	assert.NotNil(t, err)
+	assert.Equal(t, "denied", message)
+}
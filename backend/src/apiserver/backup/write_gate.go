@@ -0,0 +1,134 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// WriteGate quiesces the writes of the API server while a backup is taken. The calls
+// writing to the database or the object store enter the gate and exit it when they
+// return. Once the gate is quiesced, the new writes wait for it to resume, up to a
+// maximum delay, and the in-flight writes are drained.
+//
+// A nil gate admits every write.
+type WriteGate struct {
+	maxWait time.Duration
+
+	mu       sync.Mutex
+	inFlight int
+	// Closed when the gate resumes. Nil unless the gate is quiesced.
+	resumed chan struct{}
+	// Closed when the last in-flight write exits. Nil unless writes are being drained.
+	drained chan struct{}
+}
+
+func NewWriteGate(maxWait time.Duration) *WriteGate {
+	return &WriteGate{maxWait: maxWait}
+}
+
+// Enter admits a write, waiting for the gate to resume if it is quiesced. The write
+// fails as unavailable if the gate doesn't resume within the maximum delay or before
+// the context is done. Every admitted write must call Exit.
+func (g *WriteGate) Enter(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	var timeout <-chan time.Time
+	g.mu.Lock()
+	for g.resumed != nil {
+		resumed := g.resumed
+		g.mu.Unlock()
+		if timeout == nil {
+			timer := time.NewTimer(g.maxWait)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return util.NewUnavailableError(ctx.Err(), "The writes are paused while a backup is taken")
+		case <-timeout:
+			return util.NewUnavailableError(fmt.Errorf("waited for %v", g.maxWait),
+				"The writes are paused while a backup is taken")
+		}
+		g.mu.Lock()
+	}
+	g.inFlight++
+	g.mu.Unlock()
+	return nil
+}
+
+// Exit releases a write admitted by Enter.
+func (g *WriteGate) Exit() {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inFlight--
+	if g.inFlight == 0 && g.drained != nil {
+		close(g.drained)
+		g.drained = nil
+	}
+}
+
+// Quiesce stops admitting writes and waits for the in-flight writes to complete. If
+// they don't within the timeout, the gate resumes and an error is returned.
+func (g *WriteGate) Quiesce(timeout time.Duration) error {
+	g.mu.Lock()
+	if g.resumed != nil {
+		g.mu.Unlock()
+		return util.NewInternalServerError(fmt.Errorf("the gate is already quiesced"), "Failed to quiesce the writes")
+	}
+	g.resumed = make(chan struct{})
+	var drained chan struct{}
+	if g.inFlight > 0 {
+		g.drained = make(chan struct{})
+		drained = g.drained
+	}
+	inFlight := g.inFlight
+	g.mu.Unlock()
+
+	if drained == nil {
+		return nil
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+		return nil
+	case <-timer.C:
+		g.Resume()
+		return util.NewUnavailableError(fmt.Errorf("%v writes in flight", inFlight),
+			"The in-flight writes didn't complete within %v", timeout)
+	}
+}
+
+// Resume admits the writes again, including those waiting in Enter.
+func (g *WriteGate) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.resumed != nil {
+		close(g.resumed)
+		g.resumed = nil
+	}
+	g.drained = nil
+}
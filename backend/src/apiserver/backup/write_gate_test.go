@@ -0,0 +1,77 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteGate_QuiesceDrainsInFlightWrites(t *testing.T) {
+	gate := NewWriteGate(time.Minute)
+	assert.Nil(t, gate.Enter(context.Background()))
+
+	quiesced := make(chan error)
+	go func() { quiesced <- gate.Quiesce(time.Minute) }()
+	select {
+	case <-quiesced:
+		t.Fatal("Quiesce returned before the in-flight write exited")
+	case <-time.After(50 * time.Millisecond):
+	}
+	gate.Exit()
+	assert.Nil(t, <-quiesced)
+
+	entered := make(chan error)
+	go func() { entered <- gate.Enter(context.Background()) }()
+	select {
+	case <-entered:
+		t.Fatal("Enter returned while the gate is quiesced")
+	case <-time.After(50 * time.Millisecond):
+	}
+	gate.Resume()
+	assert.Nil(t, <-entered)
+	gate.Exit()
+}
+
+func TestWriteGate_QuiesceTimeout(t *testing.T) {
+	gate := NewWriteGate(time.Minute)
+	assert.Nil(t, gate.Enter(context.Background()))
+
+	err := gate.Quiesce(10 * time.Millisecond)
+	assert.True(t, util.IsUnavailableError(err))
+	// The gate resumed.
+	assert.Nil(t, gate.Enter(context.Background()))
+	gate.Exit()
+	gate.Exit()
+}
+
+func TestWriteGate_EnterTimeout(t *testing.T) {
+	gate := NewWriteGate(10 * time.Millisecond)
+	assert.Nil(t, gate.Quiesce(time.Minute))
+
+	err := gate.Enter(context.Background())
+	assert.True(t, util.IsUnavailableError(err))
+	gate.Resume()
+}
+
+func TestWriteGate_Nil(t *testing.T) {
+	var gate *WriteGate
+	assert.Nil(t, gate.Enter(context.Background()))
+	gate.Exit()
+}
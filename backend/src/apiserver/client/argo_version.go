@@ -0,0 +1,275 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/rest"
+)
+
+var argoVersionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(\.\d+)?(-.*)?$`)
+
+// ArgoVersion is the minor version of the installed Argo, which the schema of the
+// workflows depends on.
+type ArgoVersion struct {
+	Major int
+	Minor int
+}
+
+func (v ArgoVersion) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+// ParseArgoVersion parses a version such as v2.3.0 or 2.3.
+func ParseArgoVersion(version string) (ArgoVersion, error) {
+	match := argoVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return ArgoVersion{}, util.NewInvalidInputError("Invalid Argo version %q", version)
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return ArgoVersion{Major: major, Minor: minor}, nil
+}
+
+// DetectArgoVersion returns the version of the installed Argo, read from the image tag of
+// the deployment of its workflow controller.
+func DetectArgoVersion(restClient rest.Interface, namespace string, deploymentName string) (ArgoVersion, error) {
+	path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/%s", namespace, deploymentName)
+	body, err := restClient.Get().AbsPath(path).Do().Raw()
+	if err != nil {
+		return ArgoVersion{}, util.NewInternalServerError(err, "Failed to get deployment %v/%v", namespace, deploymentName)
+	}
+	var deployment appsv1.Deployment
+	if err := json.Unmarshal(body, &deployment); err != nil {
+		return ArgoVersion{}, util.NewInternalServerError(err, "Failed to parse deployment %v/%v", namespace, deploymentName)
+	}
+	containers := deployment.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return ArgoVersion{}, util.NewInvalidInputError("Deployment %v/%v has no container", namespace, deploymentName)
+	}
+	image := containers[0].Image
+	for _, container := range containers {
+		if strings.Contains(container.Image, "workflow-controller") {
+			image = container.Image
+		}
+	}
+	return parseImageVersion(image)
+}
+
+// parseImageVersion parses the version of an image from its tag, e.g. argoproj/workflow-controller:v2.3.0.
+func parseImageVersion(image string) (ArgoVersion, error) {
+	image = strings.SplitN(image, "@", 2)[0]
+	name := image[strings.LastIndex(image, "/")+1:]
+	index := strings.LastIndex(name, ":")
+	if index < 0 {
+		return ArgoVersion{}, util.NewInvalidInputError(
+			"Image %v has no tag to detect the Argo version from, set it in the configuration", image)
+	}
+	version, err := ParseArgoVersion(name[index+1:])
+	if err != nil {
+		return ArgoVersion{}, util.Wrapf(err, "Failed to detect the Argo version of image %v, set it in the configuration", image)
+	}
+	return version, nil
+}
+
+// The schemas of the workflows of the Argo versions supported, where they differ from the
+// vendored one of v2.2.
+var argoSchemas = []struct {
+	version ArgoVersion
+	// The nodes of the large workflows are gzipped in status.compressedNodes.
+	compressedNodes bool
+	// spec.ttlSecondsAfterFinished is replaced by spec.ttlStrategy.
+	ttlStrategy bool
+	// The priority class of the pods is set by spec.podPriorityClassName, and the other
+	// fields of their spec by the podSpecPatch of the templates.
+	podPriority bool
+}{
+	{version: ArgoVersion{2, 2}},
+	{version: ArgoVersion{2, 3}, podPriority: true},
+	{version: ArgoVersion{2, 4}, compressedNodes: true, podPriority: true},
+	{version: ArgoVersion{2, 5}, compressedNodes: true, ttlStrategy: true, podPriority: true},
+}
+
+// ArgoAdapter translates the workflows between the vendored schema and the schema of the
+// installed Argo version.
+type ArgoAdapter struct {
+	version         ArgoVersion
+	compressedNodes bool
+	ttlStrategy     bool
+	podPriority     bool
+}
+
+// NewArgoAdapter creates the adapter of an Argo version, failing if it isn't supported.
+func NewArgoAdapter(version ArgoVersion) (*ArgoAdapter, error) {
+	var supported []string
+	for _, schema := range argoSchemas {
+		if schema.version == version {
+			return &ArgoAdapter{version: version, compressedNodes: schema.compressedNodes,
+				ttlStrategy: schema.ttlStrategy, podPriority: schema.podPriority}, nil
+		}
+		supported = append(supported, schema.version.String())
+	}
+	return nil, util.NewInvalidInputError("Argo %v isn't supported, the supported versions are %v",
+		version, strings.Join(supported, ", "))
+}
+
+func (a *ArgoAdapter) Version() ArgoVersion {
+	return a.version
+}
+
+// EncodeWorkflow encodes a workflow to the JSON expected by the installed Argo.
+func (a *ArgoAdapter) EncodeWorkflow(workflow *workflowapi.Workflow) ([]byte, error) {
+	object, err := toJSONObject(workflow)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to encode workflow %v", workflow.Name)
+	}
+	if spec, ok := object["spec"].(map[string]interface{}); ok && a.ttlStrategy {
+		if ttl, ok := spec["ttlSecondsAfterFinished"]; ok {
+			spec["ttlStrategy"] = map[string]interface{}{"secondsAfterCompletion": ttl}
+			delete(spec, "ttlSecondsAfterFinished")
+		}
+	}
+	if err := a.encodePodPriority(workflow, object); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(object)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to encode workflow %v", workflow.Name)
+	}
+	return body, nil
+}
+
+// DecodeWorkflow decodes a workflow read from the installed Argo.
+func (a *ArgoAdapter) DecodeWorkflow(body []byte) (*workflowapi.Workflow, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the workflow")
+	}
+	if spec, ok := object["spec"].(map[string]interface{}); ok && a.ttlStrategy {
+		if strategy, ok := spec["ttlStrategy"].(map[string]interface{}); ok {
+			if ttl, ok := strategy["secondsAfterCompletion"]; ok {
+				spec["ttlSecondsAfterFinished"] = ttl
+			}
+			delete(spec, "ttlStrategy")
+		}
+	}
+	if status, ok := object["status"].(map[string]interface{}); ok && a.compressedNodes {
+		if compressed, ok := status["compressedNodes"].(string); ok && compressed != "" {
+			nodes, err := decompressNodes(compressed)
+			if err != nil {
+				return nil, util.Wrap(err, "Failed to decode the compressed nodes of the workflow")
+			}
+			status["nodes"] = nodes
+		}
+		delete(status, "compressedNodes")
+	}
+	decoded, err := json.Marshal(object)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the workflow")
+	}
+	var workflow workflowapi.Workflow
+	if err := json.Unmarshal(decoded, &workflow); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the workflow")
+	}
+	return &workflow, nil
+}
+
+// encodePodPriority sets the priority class and the preemption policy of the pods annotated
+// on a workflow in the spec of its JSON object. The decoded workflows keep them in the
+// annotations, the fields unknown to the vendored schema being dropped.
+func (a *ArgoAdapter) encodePodPriority(workflow *workflowapi.Workflow, object map[string]interface{}) error {
+	priorityClassName := workflow.Annotations[util.AnnotationKeyWorkflowPodPriorityClassName]
+	preemptionPolicy := workflow.Annotations[util.AnnotationKeyWorkflowPodPreemptionPolicy]
+	if priorityClassName == "" && preemptionPolicy == "" {
+		return nil
+	}
+	if !a.podPriority {
+		return util.NewInvalidInputError("Argo %v doesn't support the priority of the pods of workflow %v",
+			a.version, workflow.Name)
+	}
+	spec, ok := object["spec"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if priorityClassName != "" {
+		spec["podPriorityClassName"] = priorityClassName
+	}
+	if preemptionPolicy == "" {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{"preemptionPolicy": preemptionPolicy})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to encode the pod spec patch of workflow %v", workflow.Name)
+	}
+	templates, _ := spec["templates"].([]interface{})
+	for _, template := range templates {
+		template, ok := template.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		_, container := template["container"]
+		_, script := template["script"]
+		if container || script {
+			template["podSpecPatch"] = string(patch)
+		}
+	}
+	return nil
+}
+
+// decompressNodes decodes the nodes gzipped and base64 encoded by Argo.
+func decompressNodes(compressed string) (interface{}, error) {
+	gzipped, err := base64.StdEncoding.DecodeString(compressed)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the base64 of the nodes")
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(gzipped))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decompress the nodes")
+	}
+	defer reader.Close()
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decompress the nodes")
+	}
+	var nodes interface{}
+	if err := json.Unmarshal(body, &nodes); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the nodes")
+	}
+	return nodes, nil
+}
+
+func toJSONObject(value interface{}) (map[string]interface{}, error) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseArgoVersion(t *testing.T) {
+	for input, expected := range map[string]ArgoVersion{
+		"v2.3.0":     {2, 3},
+		"2.4":        {2, 4},
+		"v2.5.0-rc1": {2, 5},
+	} {
+		version, err := ParseArgoVersion(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, expected, version, input)
+	}
+	_, err := ParseArgoVersion("latest")
+	assert.NotNil(t, err)
+}
+
+func TestParseImageVersion(t *testing.T) {
+	version, err := parseImageVersion("gcr.io:443/ml-pipeline/workflow-controller:v2.3.0@sha256:abc")
+	assert.Nil(t, err)
+	assert.Equal(t, ArgoVersion{2, 3}, version)
+
+	_, err = parseImageVersion("gcr.io:443/ml-pipeline/workflow-controller")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "has no tag")
+}
+
+func TestNewArgoAdapter_Unsupported(t *testing.T) {
+	_, err := NewArgoAdapter(ArgoVersion{2, 1})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "v2.2, v2.3, v2.4, v2.5")
+}
+
+func TestArgoAdapter_TTLStrategy(t *testing.T) {
+	ttl := int32(60)
+	workflow := &workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow1"},
+		Spec:       workflowapi.WorkflowSpec{Entrypoint: "main", TTLSecondsAfterFinished: &ttl},
+	}
+
+	adapter, err := NewArgoAdapter(ArgoVersion{2, 5})
+	assert.Nil(t, err)
+	body, err := adapter.EncodeWorkflow(workflow)
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), `"ttlStrategy":{"secondsAfterCompletion":60}`)
+	assert.NotContains(t, string(body), "ttlSecondsAfterFinished")
+	decoded, err := adapter.DecodeWorkflow(body)
+	assert.Nil(t, err)
+	assert.Equal(t, workflow, decoded)
+
+	adapter, err = NewArgoAdapter(ArgoVersion{2, 3})
+	assert.Nil(t, err)
+	body, err = adapter.EncodeWorkflow(workflow)
+	assert.Nil(t, err)
+	assert.Contains(t, string(body), `"ttlSecondsAfterFinished":60`)
+}
+
+func TestArgoAdapter_PodPriority(t *testing.T) {
+	workflow := &workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow1", Annotations: map[string]string{
+			util.AnnotationKeyWorkflowPodPriorityClassName: "production",
+			util.AnnotationKeyWorkflowPodPreemptionPolicy:  util.PreemptionPolicyNever,
+		}},
+		Spec: workflowapi.WorkflowSpec{Entrypoint: "main", Templates: []workflowapi.Template{
+			{Name: "main", DAG: &workflowapi.DAGTemplate{}},
+			{Name: "train", Container: &corev1.Container{Image: "train"}},
+		}},
+	}
+
+	adapter, err := NewArgoAdapter(ArgoVersion{2, 3})
+	assert.Nil(t, err)
+	body, err := adapter.EncodeWorkflow(workflow)
+	assert.Nil(t, err)
+	var object struct {
+		Spec struct {
+			PodPriorityClassName string
+			Templates            []struct {
+				Name         string
+				PodSpecPatch string
+			}
+		}
+	}
+	assert.Nil(t, json.Unmarshal(body, &object))
+	assert.Equal(t, "production", object.Spec.PodPriorityClassName)
+	assert.Equal(t, "", object.Spec.Templates[0].PodSpecPatch)
+	assert.Equal(t, `{"preemptionPolicy":"Never"}`, object.Spec.Templates[1].PodSpecPatch)
+	decoded, err := adapter.DecodeWorkflow(body)
+	assert.Nil(t, err)
+	assert.Equal(t, workflow, decoded)
+
+	adapter, err = NewArgoAdapter(ArgoVersion{2, 2})
+	assert.Nil(t, err)
+	_, err = adapter.EncodeWorkflow(workflow)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Argo v2.2 doesn't support the priority of the pods")
+}
+
+func TestArgoAdapter_CompressedNodes(t *testing.T) {
+	nodes, err := json.Marshal(map[string]workflowapi.NodeStatus{
+		"workflow1": {ID: "workflow1", Phase: workflowapi.NodeSucceeded},
+	})
+	assert.Nil(t, err)
+	var gzipped bytes.Buffer
+	writer := gzip.NewWriter(&gzipped)
+	_, err = writer.Write(nodes)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	body, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "workflow1"},
+		"status": map[string]interface{}{
+			"phase":           "Succeeded",
+			"compressedNodes": base64.StdEncoding.EncodeToString(gzipped.Bytes()),
+		},
+	})
+	assert.Nil(t, err)
+
+	adapter, err := NewArgoAdapter(ArgoVersion{2, 4})
+	assert.Nil(t, err)
+	workflow, err := adapter.DecodeWorkflow(body)
+	assert.Nil(t, err)
+	assert.Equal(t, workflowapi.NodeSucceeded, workflow.Status.Nodes["workflow1"].Phase)
+
+	_, err = adapter.DecodeWorkflow([]byte(`{"status":{"compressedNodes":"not base64"}}`))
+	assert.NotNil(t, err)
+}
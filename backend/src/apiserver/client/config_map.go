@@ -0,0 +1,83 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// ConfigMapClientInterface reads the ConfigMaps of any namespace.
+type ConfigMapClientInterface interface {
+	// GetConfigMapData returns the data of a ConfigMap. It returns a ResourceNotFound error if
+	// the ConfigMap doesn't exist.
+	GetConfigMapData(namespace string, name string) (map[string]string, error)
+}
+
+type ConfigMapClient struct {
+	core typedcorev1.CoreV1Interface
+}
+
+func (c *ConfigMapClient) GetConfigMapData(namespace string, name string) (map[string]string, error) {
+	configMap, err := c.core.ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, util.NewResourceNotFoundError("ConfigMap", namespace+"/"+name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to get ConfigMap %v/%v", namespace, name)
+	}
+	return configMap.Data, nil
+}
+
+func CreateConfigMapClient() (ConfigMapClientInterface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize ConfigMap client.")
+	}
+	kubeClientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize ConfigMap client.")
+	}
+	return &ConfigMapClient{core: kubeClientSet.CoreV1()}, nil
+}
+
+// creates a new client of the ConfigMaps.
+func CreateConfigMapClientOrFatal(initConnectionTimeout time.Duration) ConfigMapClientInterface {
+	var configMapClient ConfigMapClientInterface
+	var err error
+	var operation = func() error {
+		configMapClient, err = CreateConfigMapClient()
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = initConnectionTimeout
+	err = backoff.Retry(operation, b)
+
+	if err != nil {
+		glog.Fatalf("Failed to create ConfigMap client. Error: %v", err)
+	}
+	return configMapClient
+}
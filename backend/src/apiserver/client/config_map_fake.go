@@ -0,0 +1,50 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// FakeConfigMapClient serves the ConfigMaps set with SetConfigMap.
+type FakeConfigMapClient struct {
+	configMaps map[string]map[string]string
+	reads      int
+}
+
+func NewFakeConfigMapClient() *FakeConfigMapClient {
+	return &FakeConfigMapClient{
+		configMaps: make(map[string]map[string]string),
+	}
+}
+
+func (c *FakeConfigMapClient) GetConfigMapData(namespace string, name string) (map[string]string, error) {
+	c.reads++
+	data, ok := c.configMaps[namespace+"/"+name]
+	if !ok {
+		return nil, util.NewResourceNotFoundError("ConfigMap", namespace+"/"+name)
+	}
+	return data, nil
+}
+
+// SetConfigMap creates or replaces a ConfigMap.
+func (c *FakeConfigMapClient) SetConfigMap(namespace string, name string, data map[string]string) {
+	c.configMaps[namespace+"/"+name] = data
+}
+
+// Reads returns the number of calls to GetConfigMapData.
+func (c *FakeConfigMapClient) Reads() int {
+	return c.reads
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+
+	workflowscheme "github.com/argoproj/argo/pkg/client/clientset/versioned/scheme"
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	swfscheme "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned/scheme"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+)
+
+const eventSourceComponent = "ml-pipeline-api-server"
+
+func CreateEventRecorder(namespace string) (record.EventRecorder, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize event recorder.")
+	}
+	kubeClientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize event recorder.")
+	}
+
+	// The scheme must know the Workflow and ScheduledWorkflow types to build the
+	// references of the objects the events are recorded on.
+	scheme := runtime.NewScheme()
+	workflowscheme.AddToScheme(scheme)
+	swfscheme.AddToScheme(scheme)
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(glog.Infof)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientSet.CoreV1().Events(namespace)})
+	return eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: eventSourceComponent}), nil
+}
+
+// creates a new recorder of Kubernetes events on the objects owned by the API server.
+func CreateEventRecorderOrFatal(namespace string, initConnectionTimeout time.Duration) record.EventRecorder {
+	var recorder record.EventRecorder
+	var err error
+	var operation = func() error {
+		recorder, err = CreateEventRecorder(namespace)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = initConnectionTimeout
+	err = backoff.Retry(operation, b)
+
+	if err != nil {
+		glog.Fatalf("Failed to create event recorder. Error: %v", err)
+	}
+	return recorder
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func CreateKubernetesRESTClient() (rest.Interface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize Kubernetes REST client.")
+	}
+	kubeClientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize Kubernetes REST client.")
+	}
+	return kubeClientSet.CoreV1().RESTClient(), nil
+}
+
+// CreateKubernetesDiscoveryClient creates a client listing the resources the Kubernetes API
+// server serves.
+func CreateKubernetesDiscoveryClient() (discovery.DiscoveryInterface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize Kubernetes discovery client.")
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize Kubernetes discovery client.")
+	}
+	return discoveryClient, nil
+}
+
+// creates a new REST client of the Kubernetes API server, reading the resources of any
+// group through absolute paths.
+func CreateKubernetesRESTClientOrFatal(initConnectionTimeout time.Duration) rest.Interface {
+	var restClient rest.Interface
+	var err error
+	var operation = func() error {
+		restClient, err = CreateKubernetesRESTClient()
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = initConnectionTimeout
+	err = backoff.Retry(operation, b)
+
+	if err != nil {
+		glog.Fatalf("Failed to create Kubernetes REST client. Error: %v", err)
+	}
+	return restClient
+}
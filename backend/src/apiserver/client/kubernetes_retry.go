@@ -0,0 +1,194 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	workflowclient "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
+	swfclient "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned/typed/scheduledworkflow/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// isTransientKubernetesError tells whether an idempotent call to the Kubernetes API
+// server may succeed when retried.
+func isTransientKubernetesError(err error) bool {
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	return apierrors.IsConflict(err) || isTransientUpdateError(err)
+}
+
+// isTransientUpdateError tells whether an update may succeed when retried. The
+// conflicts aren't, the update carrying the resource version that conflicted.
+func isTransientUpdateError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) || apierrors.IsServiceUnavailable(err)
+}
+
+// isRejectedCreateError tells whether a create was rejected before the resource could be
+// stored, so that retrying it doesn't create the resource twice under two generated names.
+func isRejectedCreateError(err error) bool {
+	if opError, ok := err.(*net.OpError); ok && opError.Op == "dial" {
+		return true
+	}
+	return apierrors.IsTooManyRequests(err)
+}
+
+// RetryingWorkflowClient retries the calls to the Workflow CRD that fail with a transient
+// error.
+type RetryingWorkflowClient struct {
+	client  workflowclient.WorkflowInterface
+	retrier *util.Retrier
+}
+
+func NewRetryingWorkflowClient(client workflowclient.WorkflowInterface, retryPolicy util.RetryPolicy) *RetryingWorkflowClient {
+	return &RetryingWorkflowClient{client: client, retrier: util.NewRetrier("workflow", retryPolicy)}
+}
+
+func (c *RetryingWorkflowClient) Create(workflow *workflowapi.Workflow) (result *workflowapi.Workflow, err error) {
+	err = c.retrier.Do(context.Background(), isRejectedCreateError, func() error {
+		result, err = c.client.Create(workflow)
+		return err
+	})
+	return result, err
+}
+
+func (c *RetryingWorkflowClient) Update(workflow *workflowapi.Workflow) (result *workflowapi.Workflow, err error) {
+	err = c.retrier.Do(context.Background(), isTransientUpdateError, func() error {
+		result, err = c.client.Update(workflow)
+		return err
+	})
+	return result, err
+}
+
+func (c *RetryingWorkflowClient) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		return c.client.Delete(name, options)
+	})
+}
+
+func (c *RetryingWorkflowClient) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		return c.client.DeleteCollection(options, listOptions)
+	})
+}
+
+func (c *RetryingWorkflowClient) Get(name string, options metav1.GetOptions) (result *workflowapi.Workflow, err error) {
+	err = c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		result, err = c.client.Get(name, options)
+		return err
+	})
+	return result, err
+}
+
+func (c *RetryingWorkflowClient) List(options metav1.ListOptions) (result *workflowapi.WorkflowList, err error) {
+	err = c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		result, err = c.client.List(options)
+		return err
+	})
+	return result, err
+}
+
+// Watch isn't retried, the watchers handling the end of their watches.
+func (c *RetryingWorkflowClient) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(options)
+}
+
+func (c *RetryingWorkflowClient) Patch(name string, pt types.PatchType, data []byte,
+	subresources ...string) (result *workflowapi.Workflow, err error) {
+	err = c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		result, err = c.client.Patch(name, pt, data, subresources...)
+		return err
+	})
+	return result, err
+}
+
+// RetryingScheduledWorkflowClient retries the calls to the ScheduledWorkflow CRD that fail
+// with a transient error.
+type RetryingScheduledWorkflowClient struct {
+	client  swfclient.ScheduledWorkflowInterface
+	retrier *util.Retrier
+}
+
+func NewRetryingScheduledWorkflowClient(client swfclient.ScheduledWorkflowInterface,
+	retryPolicy util.RetryPolicy) *RetryingScheduledWorkflowClient {
+	return &RetryingScheduledWorkflowClient{client: client, retrier: util.NewRetrier("scheduled_workflow", retryPolicy)}
+}
+
+func (c *RetryingScheduledWorkflowClient) Create(swf *swfapi.ScheduledWorkflow) (result *swfapi.ScheduledWorkflow, err error) {
+	err = c.retrier.Do(context.Background(), isRejectedCreateError, func() error {
+		result, err = c.client.Create(swf)
+		return err
+	})
+	return result, err
+}
+
+func (c *RetryingScheduledWorkflowClient) Update(swf *swfapi.ScheduledWorkflow) (result *swfapi.ScheduledWorkflow, err error) {
+	err = c.retrier.Do(context.Background(), isTransientUpdateError, func() error {
+		result, err = c.client.Update(swf)
+		return err
+	})
+	return result, err
+}
+
+func (c *RetryingScheduledWorkflowClient) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		return c.client.Delete(name, options)
+	})
+}
+
+func (c *RetryingScheduledWorkflowClient) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		return c.client.DeleteCollection(options, listOptions)
+	})
+}
+
+func (c *RetryingScheduledWorkflowClient) Get(name string, options metav1.GetOptions) (result *swfapi.ScheduledWorkflow, err error) {
+	err = c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		result, err = c.client.Get(name, options)
+		return err
+	})
+	return result, err
+}
+
+func (c *RetryingScheduledWorkflowClient) List(options metav1.ListOptions) (result *swfapi.ScheduledWorkflowList, err error) {
+	err = c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		result, err = c.client.List(options)
+		return err
+	})
+	return result, err
+}
+
+// Watch isn't retried, the watchers handling the end of their watches.
+func (c *RetryingScheduledWorkflowClient) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	return c.client.Watch(options)
+}
+
+func (c *RetryingScheduledWorkflowClient) Patch(name string, pt types.PatchType, data []byte,
+	subresources ...string) (result *swfapi.ScheduledWorkflow, err error) {
+	err = c.retrier.Do(context.Background(), isTransientKubernetesError, func() error {
+		result, err = c.client.Patch(name, pt, data, subresources...)
+		return err
+	})
+	return result, err
+}
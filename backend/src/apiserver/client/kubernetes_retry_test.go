@@ -0,0 +1,107 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	workflowclient "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// flakyWorkflowClient fails the first calls with err.
+type flakyWorkflowClient struct {
+	workflowclient.WorkflowInterface
+	failures int
+	err      error
+	calls    int
+}
+
+func (c *flakyWorkflowClient) call() error {
+	if c.calls++; c.calls <= c.failures {
+		return c.err
+	}
+	return nil
+}
+
+func (c *flakyWorkflowClient) Create(workflow *workflowapi.Workflow) (*workflowapi.Workflow, error) {
+	return workflow, c.call()
+}
+
+func (c *flakyWorkflowClient) Update(workflow *workflowapi.Workflow) (*workflowapi.Workflow, error) {
+	return workflow, c.call()
+}
+
+func (c *flakyWorkflowClient) Patch(name string, pt types.PatchType, data []byte,
+	subresources ...string) (*workflowapi.Workflow, error) {
+	return &workflowapi.Workflow{}, c.call()
+}
+
+var (
+	testRetryPolicy   = util.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	workflowsResource = schema.GroupResource{Group: "argoproj.io", Resource: "workflows"}
+)
+
+func TestRetryingWorkflowClient_Patch(t *testing.T) {
+	flakyClient := &flakyWorkflowClient{failures: 2, err: apierrors.NewConflict(workflowsResource, "wf", nil)}
+	client := NewRetryingWorkflowClient(flakyClient, testRetryPolicy)
+	_, err := client.Patch("wf", types.MergePatchType, []byte("{}"))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, flakyClient.calls)
+
+	flakyClient = &flakyWorkflowClient{failures: 2, err: apierrors.NewNotFound(workflowsResource, "wf")}
+	client = NewRetryingWorkflowClient(flakyClient, testRetryPolicy)
+	_, err = client.Patch("wf", types.MergePatchType, []byte("{}"))
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.Equal(t, 1, flakyClient.calls)
+}
+
+func TestRetryingWorkflowClient_Update(t *testing.T) {
+	flakyClient := &flakyWorkflowClient{failures: 2, err: apierrors.NewInternalError(net.UnknownNetworkError("tcp"))}
+	client := NewRetryingWorkflowClient(flakyClient, testRetryPolicy)
+	_, err := client.Update(&workflowapi.Workflow{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, flakyClient.calls)
+
+	// The conflicts of the updates aren't retried.
+	flakyClient = &flakyWorkflowClient{failures: 2, err: apierrors.NewConflict(workflowsResource, "wf", nil)}
+	client = NewRetryingWorkflowClient(flakyClient, testRetryPolicy)
+	_, err = client.Update(&workflowapi.Workflow{})
+	assert.True(t, apierrors.IsConflict(err))
+	assert.Equal(t, 1, flakyClient.calls)
+}
+
+func TestRetryingWorkflowClient_Create(t *testing.T) {
+	flakyClient := &flakyWorkflowClient{failures: 2, err: apierrors.NewTooManyRequests("throttled", 1)}
+	client := NewRetryingWorkflowClient(flakyClient, testRetryPolicy)
+	_, err := client.Create(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{GenerateName: "wf-"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, flakyClient.calls)
+
+	// The workflow may have been created by the failed call.
+	flakyClient = &flakyWorkflowClient{failures: 2, err: apierrors.NewServerTimeout(workflowsResource, "create", 1)}
+	client = NewRetryingWorkflowClient(flakyClient, testRetryPolicy)
+	_, err = client.Create(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{GenerateName: "wf-"}})
+	assert.True(t, apierrors.IsServerTimeout(err))
+	assert.Equal(t, 1, flakyClient.calls)
+}
@@ -16,6 +16,7 @@ package client
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -25,22 +26,24 @@ import (
 )
 
 func CreateMinioClient(minioServiceHost string, minioServicePort string,
-	accessKey string, secretKey string) (*minio.Client, error) {
+	accessKey string, secretKey string, transport http.RoundTripper) (*minio.Client, error) {
 	minioClient, err := minio.New(fmt.Sprintf("%s:%s", minioServiceHost, minioServicePort),
 		accessKey, secretKey, false /* Secure connection */)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error while creating minio client: %+v", err)
 	}
+	minioClient.SetCustomTransport(transport)
 	return minioClient, nil
 }
 
 func CreateMinioClientOrFatal(minioServiceHost string, minioServicePort string,
-	accessKey string, secretKey string, initConnectionTimeout time.Duration) *minio.Client {
+	accessKey string, secretKey string, transport http.RoundTripper,
+	initConnectionTimeout time.Duration) *minio.Client {
 	var minioClient *minio.Client
 	var err error
 	var operation = func() error {
 		minioClient, err = CreateMinioClient(minioServiceHost, minioServicePort,
-			accessKey, secretKey)
+			accessKey, secretKey, transport)
 		if err != nil {
 			return err
 		}
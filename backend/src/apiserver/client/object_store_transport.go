@@ -0,0 +1,82 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+)
+
+var objectStoreConnectionsTotal = metrics.NewCounterVec(
+	"object_store_connections_total",
+	"Number of connections used by the requests to the object store, by whether they were reused.",
+	"reused")
+
+func init() {
+	metrics.MustRegister(objectStoreConnectionsTotal)
+}
+
+// ObjectStoreTransportConfig configures the connection pool shared by the requests to the
+// object store.
+type ObjectStoreTransportConfig struct {
+	// The maximum number of idle connections kept open, in total and to each host.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	// How long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+	DialTimeout     time.Duration
+	// The interval of the TCP keep-alive probes of the connections.
+	KeepAlive             time.Duration
+	ResponseHeaderTimeout time.Duration
+}
+
+// NewObjectStoreTransport creates a transport pooling the connections to the object store, and
+// counting how many of them are reused.
+func NewObjectStoreTransport(config ObjectStoreTransportConfig) http.RoundTripper {
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: config.KeepAlive,
+	}
+	return &connectionReuseRecorder{
+		next: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           dialer.DialContext,
+			MaxIdleConns:          config.MaxIdleConns,
+			MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+			IdleConnTimeout:       config.IdleConnTimeout,
+			ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+			TLSHandshakeTimeout:   config.DialTimeout,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
+
+type connectionReuseRecorder struct {
+	next http.RoundTripper
+}
+
+func (r *connectionReuseRecorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			objectStoreConnectionsTotal.Inc(strconv.FormatBool(info.Reused))
+		},
+	}
+	return r.next.RoundTrip(request.WithContext(httptrace.WithClientTrace(request.Context(), trace)))
+}
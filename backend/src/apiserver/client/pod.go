@@ -0,0 +1,95 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// PodClientInterface reads the logs of the pods running the steps of the workflows, and
+// deletes them.
+type PodClientInterface interface {
+	// ReadPodLogs returns the logs of a container of a pod. It returns a ResourceNotFound
+	// error if the pod doesn't exist, e.g. because it was garbage collected.
+	ReadPodLogs(podName string, container string) ([]byte, error)
+	// DeletePod deletes a pod, if it exists.
+	DeletePod(podName string) error
+}
+
+type PodClient struct {
+	pods typedcorev1.PodInterface
+}
+
+func (c *PodClient) ReadPodLogs(podName string, container string) ([]byte, error) {
+	logs, err := c.pods.GetLogs(podName, &corev1.PodLogOptions{Container: container}).DoRaw()
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, util.NewResourceNotFoundError("pod", podName)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to read the logs of pod %v", podName)
+	}
+	return logs, nil
+}
+
+func (c *PodClient) DeletePod(podName string) error {
+	err := c.pods.Delete(podName, &metav1.DeleteOptions{})
+	if err != nil && !util.IsNotFound(err) {
+		return util.NewInternalServerError(err, "Failed to delete pod %v", podName)
+	}
+	return nil
+}
+
+func CreatePodClient(namespace string) (PodClientInterface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize pod client.")
+	}
+	kubeClientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize pod client.")
+	}
+	return &PodClient{pods: kubeClientSet.CoreV1().Pods(namespace)}, nil
+}
+
+// creates a new client of the pods of the workflows.
+func CreatePodClientOrFatal(namespace string, initConnectionTimeout time.Duration) PodClientInterface {
+	var podClient PodClientInterface
+	var err error
+	var operation = func() error {
+		podClient, err = CreatePodClient(namespace)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = initConnectionTimeout
+	err = backoff.Retry(operation, b)
+
+	if err != nil {
+		glog.Fatalf("Failed to create pod client. Error: %v", err)
+	}
+	return podClient
+}
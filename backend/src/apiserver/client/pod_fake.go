@@ -0,0 +1,81 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// FakePodClient serves the logs of the pods stubbed with StubLogs.
+type FakePodClient struct {
+	logs    map[string][]byte
+	reads   int
+	deleted []string
+	err     error
+}
+
+func NewFakePodClient() *FakePodClient {
+	return &FakePodClient{
+		logs: make(map[string][]byte),
+	}
+}
+
+func (c *FakePodClient) ReadPodLogs(podName string, container string) ([]byte, error) {
+	c.reads++
+	if c.err != nil {
+		return nil, c.err
+	}
+	logs, ok := c.logs[podName+"/"+container]
+	if !ok {
+		return nil, util.NewResourceNotFoundError("pod", podName)
+	}
+	return logs, nil
+}
+
+// StubLogs sets the logs of a container of a pod.
+func (c *FakePodClient) StubLogs(podName string, container string, logs []byte) {
+	c.logs[podName+"/"+container] = logs
+}
+
+// DeletePod removes the logs of all the containers of a pod.
+func (c *FakePodClient) DeletePod(podName string) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.deleted = append(c.deleted, podName)
+	for key := range c.logs {
+		if strings.HasPrefix(key, podName+"/") {
+			delete(c.logs, key)
+		}
+	}
+	return nil
+}
+
+// Deleted returns the names of the pods deleted, in order.
+func (c *FakePodClient) Deleted() []string {
+	return c.deleted
+}
+
+// Reads returns the number of calls to ReadPodLogs.
+func (c *FakePodClient) Reads() int {
+	return c.reads
+}
+
+// SetError makes the calls fail with an error.
+func (c *FakePodClient) SetError(err error) {
+	c.err = err
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// CreateRemoteClusterClients creates the clients of the workflows and of the pods of a
+// namespace of a remote cluster, from its kubeconfig. The workflows are translated with the
+// given adapter, so the remote cluster must run the same Argo version as the local one.
+func CreateRemoteClusterClients(kubeconfig []byte, namespace string, adapter *ArgoAdapter) (
+	*VersionedWorkflowClient, PodClientInterface, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, nil, util.NewInvalidInputError("Invalid kubeconfig: %v", err)
+	}
+	kubeClientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, util.NewInternalServerError(err, "Failed to create the client of cluster %v", restConfig.Host)
+	}
+	workflowClient := NewVersionedWorkflowClient(kubeClientSet.CoreV1().RESTClient(), namespace, adapter)
+	return workflowClient, &PodClient{pods: kubeClientSet.CoreV1().Pods(namespace)}, nil
+}
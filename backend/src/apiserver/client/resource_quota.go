@@ -0,0 +1,80 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// ResourceQuotaClientInterface reads the ResourceQuotas of any namespace.
+type ResourceQuotaClientInterface interface {
+	// ListResourceQuotas returns the ResourceQuotas of a namespace, with their usage.
+	ListResourceQuotas(namespace string) ([]corev1.ResourceQuota, error)
+}
+
+type ResourceQuotaClient struct {
+	core typedcorev1.CoreV1Interface
+}
+
+func (c *ResourceQuotaClient) ListResourceQuotas(namespace string) ([]corev1.ResourceQuota, error) {
+	quotas, err := c.core.ResourceQuotas(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the ResourceQuotas of namespace %v", namespace)
+	}
+	return quotas.Items, nil
+}
+
+func CreateResourceQuotaClient() (ResourceQuotaClientInterface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize ResourceQuota client.")
+	}
+	kubeClientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize ResourceQuota client.")
+	}
+	return &ResourceQuotaClient{core: kubeClientSet.CoreV1()}, nil
+}
+
+// creates a new client of the ResourceQuotas.
+func CreateResourceQuotaClientOrFatal(initConnectionTimeout time.Duration) ResourceQuotaClientInterface {
+	var resourceQuotaClient ResourceQuotaClientInterface
+	var err error
+	var operation = func() error {
+		resourceQuotaClient, err = CreateResourceQuotaClient()
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = initConnectionTimeout
+	err = backoff.Retry(operation, b)
+
+	if err != nil {
+		glog.Fatalf("Failed to create ResourceQuota client. Error: %v", err)
+	}
+	return resourceQuotaClient
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FakeResourceQuotaClient serves the ResourceQuotas set with SetResourceQuotas.
+type FakeResourceQuotaClient struct {
+	quotas map[string][]corev1.ResourceQuota
+}
+
+func NewFakeResourceQuotaClient() *FakeResourceQuotaClient {
+	return &FakeResourceQuotaClient{
+		quotas: make(map[string][]corev1.ResourceQuota),
+	}
+}
+
+func (c *FakeResourceQuotaClient) ListResourceQuotas(namespace string) ([]corev1.ResourceQuota, error) {
+	return c.quotas[namespace], nil
+}
+
+// SetResourceQuotas replaces the ResourceQuotas of a namespace.
+func (c *FakeResourceQuotaClient) SetResourceQuotas(namespace string, quotas ...corev1.ResourceQuota) {
+	c.quotas[namespace] = quotas
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// SecretClientInterface reads the Secrets of a namespace.
+type SecretClientInterface interface {
+	// GetSecret returns a Secret. It returns a ResourceNotFound error if the Secret doesn't
+	// exist.
+	GetSecret(name string) (*corev1.Secret, error)
+}
+
+type SecretClient struct {
+	secrets typedcorev1.SecretInterface
+}
+
+func (c *SecretClient) GetSecret(name string) (*corev1.Secret, error) {
+	secret, err := c.secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if util.IsNotFound(err) {
+			return nil, util.NewResourceNotFoundError("Secret", name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to get Secret %v", name)
+	}
+	return secret, nil
+}
+
+func CreateSecretClient(namespace string) (SecretClientInterface, error) {
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize Secret client.")
+	}
+	kubeClientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to initialize Secret client.")
+	}
+	return &SecretClient{secrets: kubeClientSet.CoreV1().Secrets(namespace)}, nil
+}
+
+// creates a new client of the Secrets of a namespace.
+func CreateSecretClientOrFatal(namespace string, initConnectionTimeout time.Duration) SecretClientInterface {
+	var secretClient SecretClientInterface
+	var err error
+	var operation = func() error {
+		secretClient, err = CreateSecretClient(namespace)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = initConnectionTimeout
+	err = backoff.Retry(operation, b)
+
+	if err != nil {
+		glog.Fatalf("Failed to create Secret client. Error: %v", err)
+	}
+	return secretClient
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FakeSecretClient serves the Secrets set with SetSecret.
+type FakeSecretClient struct {
+	secrets map[string]*corev1.Secret
+}
+
+func NewFakeSecretClient() *FakeSecretClient {
+	return &FakeSecretClient{
+		secrets: make(map[string]*corev1.Secret),
+	}
+}
+
+func (c *FakeSecretClient) GetSecret(name string) (*corev1.Secret, error) {
+	secret, ok := c.secrets[name]
+	if !ok {
+		return nil, util.NewResourceNotFoundError("Secret", name)
+	}
+	return secret, nil
+}
+
+// SetSecret creates or replaces a Secret.
+func (c *FakeSecretClient) SetSecret(name string, labels map[string]string, data map[string][]byte) {
+	c.secrets[name] = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}, Data: data}
+}
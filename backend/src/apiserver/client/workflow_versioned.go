@@ -0,0 +1,134 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+)
+
+// VersionedWorkflowClient accesses the workflows through the REST API of Kubernetes,
+// translating them to and from the schema of the installed Argo version with an adapter.
+// The typed client would drop the fields the vendored schema doesn't know of.
+type VersionedWorkflowClient struct {
+	restClient rest.Interface
+	namespace  string
+	adapter    *ArgoAdapter
+}
+
+func NewVersionedWorkflowClient(restClient rest.Interface, namespace string, adapter *ArgoAdapter) *VersionedWorkflowClient {
+	return &VersionedWorkflowClient{restClient: restClient, namespace: namespace, adapter: adapter}
+}
+
+// Adapter returns the adapter translating the workflows to the schema of the Argo version.
+func (c *VersionedWorkflowClient) Adapter() *ArgoAdapter {
+	return c.adapter
+}
+
+func (c *VersionedWorkflowClient) Create(workflow *workflowapi.Workflow) (*workflowapi.Workflow, error) {
+	body, err := c.adapter.EncodeWorkflow(workflow)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(c.restClient.Post().AbsPath(c.path("")).SetHeader("Content-Type", "application/json").Body(body))
+}
+
+func (c *VersionedWorkflowClient) Update(workflow *workflowapi.Workflow) (*workflowapi.Workflow, error) {
+	body, err := c.adapter.EncodeWorkflow(workflow)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(c.restClient.Put().AbsPath(c.path(workflow.Name)).SetHeader("Content-Type", "application/json").Body(body))
+}
+
+func (c *VersionedWorkflowClient) Delete(name string, options *metav1.DeleteOptions) error {
+	body, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	return c.restClient.Delete().AbsPath(c.path(name)).SetHeader("Content-Type", "application/json").Body(body).Do().Error()
+}
+
+func (c *VersionedWorkflowClient) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	body, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	return c.restClient.Delete().AbsPath(c.path("")).VersionedParams(&listOptions, metav1.ParameterCodec).
+		SetHeader("Content-Type", "application/json").Body(body).Do().Error()
+}
+
+func (c *VersionedWorkflowClient) Get(name string, options metav1.GetOptions) (*workflowapi.Workflow, error) {
+	return c.do(c.restClient.Get().AbsPath(c.path(name)).VersionedParams(&options, metav1.ParameterCodec))
+}
+
+func (c *VersionedWorkflowClient) List(options metav1.ListOptions) (*workflowapi.WorkflowList, error) {
+	body, err := c.restClient.Get().AbsPath(c.path("")).VersionedParams(&options, metav1.ParameterCodec).Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		metav1.TypeMeta `json:",inline"`
+		metav1.ListMeta `json:"metadata,omitempty"`
+		Items           []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the workflows of namespace %v", c.namespace)
+	}
+	result := &workflowapi.WorkflowList{TypeMeta: list.TypeMeta, ListMeta: list.ListMeta}
+	for _, item := range list.Items {
+		workflow, err := c.adapter.DecodeWorkflow(item)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, *workflow)
+	}
+	return result, nil
+}
+
+// Watch isn't supported, as the API server doesn't watch the workflows.
+func (c *VersionedWorkflowClient) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	return nil, util.NewInternalServerError(errors.New("not supported"), "Failed to watch the workflows")
+}
+
+func (c *VersionedWorkflowClient) Patch(name string, pt types.PatchType, data []byte,
+	subresources ...string) (*workflowapi.Workflow, error) {
+	return c.do(c.restClient.Patch(pt).AbsPath(append([]string{c.path(name)}, subresources...)...).Body(data))
+}
+
+func (c *VersionedWorkflowClient) path(name string) string {
+	path := fmt.Sprintf("/apis/argoproj.io/v1alpha1/namespaces/%s/workflows", c.namespace)
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
+
+// do sends a request, returning the status errors of Kubernetes as the typed client does.
+func (c *VersionedWorkflowClient) do(request *rest.Request) (*workflowapi.Workflow, error) {
+	body, err := request.Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+	return c.adapter.DecodeWorkflow(body)
+}
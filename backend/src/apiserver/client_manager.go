@@ -15,21 +15,44 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
 	"time"
 
 	workflowclient "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	"github.com/cenkalti/backoff"
 	"github.com/golang/glog"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/authz"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/backup"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/deployment"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/eventexport"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/gitsync"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/policy"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
+	"github.com/kubeflow/pipelines/backend/src/common/client/visualization"
+	"github.com/kubeflow/pipelines/backend/src/common/features"
+	"github.com/kubeflow/pipelines/backend/src/common/health"
+	"github.com/kubeflow/pipelines/backend/src/common/leaderelection"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
+	"github.com/kubeflow/pipelines/backend/src/common/reload"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	scheduledworkflowclient "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned/typed/scheduledworkflow/v1alpha1"
-	"github.com/jinzhu/gorm"
-	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	minio "github.com/minio/minio-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -40,21 +63,225 @@ const (
 	podNamespace          = "POD_NAMESPACE"
 	dbName                = "mlpipeline"
 	initConnectionTimeout = "InitConnectionTimeout"
+	healthCheckTimeout    = "HealthCheckTimeout"
+	webhookWorkers        = "WebhookWorkers"
+	webhookRequestTimeout = "WebhookRequestTimeout"
+	webhookRetryTimeout   = "WebhookRetryTimeout"
+	eventExportBroker     = "EventExportConfig.Broker"
+	eventExportAddress    = "EventExportConfig.Address"
+	eventExportTopic      = "EventExportConfig.Topic"
+	eventExportRetry      = "EventExportConfig.RetryTimeout"
+	metadataStoreAddress  = "MetadataStoreConfig.Address"
+	metadataStoreTimeout  = "MetadataStoreConfig.Timeout"
+	gitSyncRepository     = "GitSyncConfig.Repository"
+	gitSyncBranch         = "GitSyncConfig.Branch"
+	gitSyncPath           = "GitSyncConfig.Path"
+	gitSyncInterval       = "GitSyncConfig.Interval"
+	gitSyncTimeout        = "GitSyncConfig.Timeout"
+	gitSyncWorkDir        = "GitSyncConfig.WorkDir"
+	metricsPushEnabled    = "MetricsPushConfig.Enabled"
+	deploymentWatcher     = "DeploymentWatcherConfig.Enabled"
+	deploymentInterval    = "DeploymentWatcherConfig.Interval"
+	deploymentTimeout     = "DeploymentWatcherConfig.Timeout"
+	templateCacheSize     = "TemplateCacheConfig.Size"
+	templateCacheTTL      = "TemplateCacheConfig.TTL"
+	reportDeduplicate     = "ReportConfig.Deduplicate"
+	reportFullResync      = "ReportConfig.FullResyncInterval"
+	reportMaxAttempts     = "ReportConfig.MaxAttempts"
+	runOutboxInterval     = "RunOutboxConfig.Interval"
+	runOutboxGracePeriod  = "RunOutboxConfig.GracePeriod"
+	runOutboxMaxAttempts  = "RunOutboxConfig.MaxAttempts"
+	runSweepInterval      = "RunSweepConfig.Interval"
+	runQueueInterval      = "RunQueueConfig.Interval"
+	terminateInterval     = "TerminateConfig.Interval"
+	operationInterval     = "OperationConfig.Interval"
+	orphanReconciler      = "OrphanReconcilerConfig.Enabled"
+	orphanInterval        = "OrphanReconcilerConfig.Interval"
+	orphanGracePeriod     = "OrphanReconcilerConfig.GracePeriod"
+	orphanAdoptWorkflows  = "OrphanReconcilerConfig.AdoptWorkflows"
+	orphanDryRun          = "OrphanReconcilerConfig.DryRun"
+	consistencyScheduled  = "ConsistencyCheckerConfig.Enabled"
+	consistencyInterval   = "ConsistencyCheckerConfig.Interval"
+	consistencyRepair     = "ConsistencyCheckerConfig.Repair"
+	storageUsageScheduled = "StorageUsageConfig.Enabled"
+	storageUsageInterval  = "StorageUsageConfig.Interval"
+	leaderElection        = "LeaderElectionConfig.Enabled"
+	leaderElectionLease   = "LeaderElectionConfig.LeaseName"
+	leaderElectionTTL     = "LeaderElectionConfig.LeaseDuration"
+	leaderElectionRenew   = "LeaderElectionConfig.RenewDeadline"
+	leaderElectionRetry   = "LeaderElectionConfig.RetryPeriod"
+
+	dbCreateListIndexes     = "DBConfig.CreateListIndexes"
+	dbDialTimeout           = "DBConfig.DialTimeout"
+	dbBreakerThreshold      = "DBConfig.CircuitBreakerThreshold"
+	dbBreakerOpenTimeout    = "DBConfig.CircuitBreakerOpenTimeout"
+	dbDegradedModeCacheSize = "DBConfig.DegradedModeCacheSize"
+
+	objectStoreAccessKey             = "ObjectStoreConfig.AccessKey"
+	objectStoreSecretAccessKey       = "ObjectStoreConfig.SecretAccessKey"
+	objectStoreMaxIdleConns          = "ObjectStoreConfig.MaxIdleConns"
+	objectStoreMaxIdleConnsPerHost   = "ObjectStoreConfig.MaxIdleConnsPerHost"
+	objectStoreIdleConnTimeout       = "ObjectStoreConfig.IdleConnTimeout"
+	objectStoreDialTimeout           = "ObjectStoreConfig.DialTimeout"
+	objectStoreKeepAlive             = "ObjectStoreConfig.KeepAlive"
+	objectStoreResponseHeaderTimeout = "ObjectStoreConfig.ResponseHeaderTimeout"
+
+	faultInjectionRules = "FaultInjectionConfig.Rules"
+	faultInjectionSeed  = "FaultInjectionConfig.Seed"
+
+	retryMaxRetries     = "RetryConfig.MaxRetries"
+	retryInitialBackoff = "RetryConfig.InitialBackoff"
+	retryMaxBackoff     = "RetryConfig.MaxBackoff"
+	retryJitter         = "RetryConfig.Jitter"
+	retryBudgetRatio    = "RetryConfig.BudgetRatio"
+	retryBudgetBurst    = "RetryConfig.BudgetBurst"
+
+	startupInitialBackoff     = "StartupConfig.InitialBackoff"
+	startupMaxBackoff         = "StartupConfig.MaxBackoff"
+	startupDatabaseTimeout    = "StartupConfig.DatabaseTimeout"
+	startupObjectStoreTimeout = "StartupConfig.ObjectStoreTimeout"
+	startupCRDTimeout         = "StartupConfig.CRDTimeout"
+
+	backupSnapshotCommand = "BackupConfig.SnapshotCommand"
+	backupSnapshotTimeout = "BackupConfig.SnapshotTimeout"
+	backupQuiesceTimeout  = "BackupConfig.QuiesceTimeout"
+	backupMaxWriteDelay   = "BackupConfig.MaxWriteDelay"
+
+	multiUserIdHeader = "MultiUserConfig.UserIdHeader"
+	multiUserIdPrefix = "MultiUserConfig.UserIdPrefix"
+
+	adminUsers = "AdminConfig.Users"
+
+	authorizationOpaUrl   = "AuthorizationConfig.OpaUrl"
+	authorizationTimeout  = "AuthorizationConfig.Timeout"
+	authorizationFailOpen = "AuthorizationConfig.FailOpen"
+
+	urlFetcherProxy           = "URLFetcherConfig.Proxy"
+	urlFetcherDialTimeout     = "URLFetcherConfig.DialTimeout"
+	urlFetcherTimeout         = "URLFetcherConfig.Timeout"
+	urlFetcherMaxRedirects    = "URLFetcherConfig.MaxRedirects"
+	urlFetcherMaxSize         = "URLFetcherConfig.MaxSize"
+	urlFetcherAllowedSchemes  = "URLFetcherConfig.AllowedSchemes"
+	urlFetcherAllowedHosts    = "URLFetcherConfig.AllowedHosts"
+	urlFetcherDeniedHosts     = "URLFetcherConfig.DeniedHosts"
+	urlFetcherBlockedNetworks = "URLFetcherConfig.BlockedNetworks"
+	urlFetcherAllowedNetworks = "URLFetcherConfig.AllowedNetworks"
+
+	corsAllowedOrigins   = "CORSConfig.AllowedOrigins"
+	corsAllowedMethods   = "CORSConfig.AllowedMethods"
+	corsAllowedHeaders   = "CORSConfig.AllowedHeaders"
+	corsAllowCredentials = "CORSConfig.AllowCredentials"
+	corsMaxAge           = "CORSConfig.MaxAge"
+
+	securityHeadersNoSniff                 = "SecurityHeadersConfig.NoSniff"
+	securityHeadersFrameOptions            = "SecurityHeadersConfig.FrameOptions"
+	securityHeadersContentSecurityPolicy   = "SecurityHeadersConfig.ContentSecurityPolicy"
+	securityHeadersReferrerPolicy          = "SecurityHeadersConfig.ReferrerPolicy"
+	securityHeadersStrictTransportSecurity = "SecurityHeadersConfig.StrictTransportSecurity"
+
+	secretParameterKeyFile = "SecretParameterConfig.KeyFile"
+
+	readOnlyForced          = "ReadOnlyConfig.Forced"
+	readOnlyMessage         = "ReadOnlyConfig.Message"
+	readOnlyRefreshInterval = "ReadOnlyConfig.RefreshInterval"
+
+	namespaceConfigEnabled  = "NamespaceConfig.Enabled"
+	namespaceConfigMapName  = "NamespaceConfig.ConfigMapName"
+	namespaceConfigCacheTTL = "NamespaceConfig.CacheTTL"
+
+	executionTargetCacheTTL = "ExecutionTargetConfig.CacheTTL"
+
+	gpuQuotaMode          = "GPUQuotaConfig.Mode"
+	gpuQuotaResourceNames = "GPUQuotaConfig.ResourceNames"
+
+	workflowPodGCStrategy   = "WorkflowConfig.PodGCStrategy"
+	workflowArtifactArchive = "WorkflowConfig.ArtifactArchive"
+	workflowLogArchive      = "WorkflowConfig.LogArchive"
+	workflowPipelineRoot    = "WorkflowConfig.DefaultPipelineRoot"
+	workflowPriorityClass   = "WorkflowConfig.PriorityClassName"
+	workflowPreemption      = "WorkflowConfig.PreemptionPolicy"
+	workflowServiceAccounts = "WorkflowConfig.AllowedServiceAccounts"
+
+	featureGates = "FeatureConfig.Gates"
+
+	// The release and the commit the API server was built from, set by the Dockerfile.
+	buildVersion   = "VERSION"
+	buildCommitSha = "COMMIT_SHA"
+
+	deprecationBlockAfterSunset = "DeprecationConfig.BlockAfterSunset"
+
+	runOutputsMaxSize = "RunOutputsConfig.MaxSize"
+
+	runEstimationPastRuns           = "RunEstimationConfig.PastRuns"
+	runEstimationCPUHourPrice       = "RunEstimationConfig.CPUHourPrice"
+	runEstimationMemoryGiBHourPrice = "RunEstimationConfig.MemoryGiBHourPrice"
+	runEstimationGPUHourPrice       = "RunEstimationConfig.GPUHourPrice"
+	runEstimationCurrency           = "RunEstimationConfig.Currency"
+
+	visualizationAddress       = "VisualizationConfig.Address"
+	visualizationTimeout       = "VisualizationConfig.Timeout"
+	visualizationMaxSourceSize = "VisualizationConfig.MaxSourceSize"
+
+	workflowEngineName         = "WorkflowEngineConfig.Name"
+	workflowEnginePollInterval = "WorkflowEngineConfig.PollInterval"
+
+	argoVersion              = "ArgoConfig.Version"
+	argoControllerDeployment = "ArgoConfig.ControllerDeployment"
+	argoControllerNamespace  = "ArgoConfig.ControllerNamespace"
+
+	policyAllowedRegistries     = "PolicyConfig.AllowedRegistries"
+	policyAllowedRegistriesMode = "PolicyConfig.AllowedRegistriesMode"
+	policyResourceLimitsMode    = "PolicyConfig.ResourceLimitsMode"
+	policyNoHostPathMode        = "PolicyConfig.NoHostPathMode"
+	policyRequiredLabels        = "PolicyConfig.RequiredLabels"
+	policyRequiredLabelsMode    = "PolicyConfig.RequiredLabelsMode"
 )
 
 // Container for all service clients
 type ClientManager struct {
-	db                     *storage.DB
-	experimentStore        storage.ExperimentStoreInterface
-	pipelineStore          storage.PipelineStoreInterface
-	jobStore               storage.JobStoreInterface
-	runStore               storage.RunStoreInterface
-	resourceReferenceStore storage.ResourceReferenceStoreInterface
-	objectStore            storage.ObjectStoreInterface
-	wfClient               workflowclient.WorkflowInterface
-	swfClient              scheduledworkflowclient.ScheduledWorkflowInterface
-	time                   util.TimeInterface
-	uuid                   util.UUIDGeneratorInterface
+	db                      *storage.DB
+	experimentStore         storage.ExperimentStoreInterface
+	pipelineStore           storage.PipelineStoreInterface
+	jobStore                storage.JobStoreInterface
+	runStore                storage.RunStoreInterface
+	runOutboxStore          storage.RunOutboxStoreInterface
+	runSweepStore           storage.RunSweepStoreInterface
+	runGroupStore           storage.RunGroupStoreInterface
+	terminateOperationStore storage.TerminateOperationStoreInterface
+	operationStore          storage.OperationStoreInterface
+	resourceReferenceStore  storage.ResourceReferenceStoreInterface
+	objectStore             storage.ObjectStoreInterface
+	webhookStore            storage.WebhookStoreInterface
+	executionTargetStore    storage.ExecutionTargetStoreInterface
+	executionTargets        *resource.ExecutionTargets
+	artifactLineageStore    storage.ArtifactLineageStoreInterface
+	runHistoryStore         storage.RunHistoryStoreInterface
+	gitSyncStore            storage.GitSyncStoreInterface
+	modelRegistry           storage.ModelRegistryInterface
+	metricsPushTokenStore   storage.MetricsPushTokenStoreInterface
+	favoriteStore           storage.FavoriteStoreInterface
+	deploymentStatusStore   storage.DeploymentStatusStoreInterface
+	backupMarkerStore       storage.BackupMarkerStoreInterface
+	wfClient                workflowclient.WorkflowInterface
+	swfClient               scheduledworkflowclient.ScheduledWorkflowInterface
+	podClient               client.PodClientInterface
+	engine                  engine.Engine
+	eventRecorder           record.EventRecorder
+	webhookNotifier         webhook.NotifierInterface
+	eventPublisher          eventexport.PublisherInterface
+	metadataStore           metadata.MetadataStoreInterface
+	visualizationClient     visualization.VisualizationClientInterface
+	templateCache           *resource.TemplateCache
+	reportDeduplicator      *resource.ReportDeduplicator
+	reportDeadLetterQueue   *resource.ReportDeadLetterQueue
+	readOnlyMode            *resource.ReadOnlyMode
+	workflowDefaults        *api.WorkflowOptions
+	namespaceConfigs        *resource.NamespaceConfigs
+	gpuQuota                *resource.GPUQuota
+	policyLinter            *policy.Linter
+	featureGate             *features.Gate
+	time                    util.TimeInterface
+	uuid                    util.UUIDGeneratorInterface
 }
 
 func (c *ClientManager) ExperimentStore() storage.ExperimentStoreInterface {
@@ -81,14 +308,164 @@ func (c *ClientManager) ObjectStore() storage.ObjectStoreInterface {
 	return c.objectStore
 }
 
-func (c *ClientManager) Workflow() workflowclient.WorkflowInterface {
-	return c.wfClient
+func (c *ClientManager) Engine() engine.Engine {
+	return c.engine
 }
 
 func (c *ClientManager) ScheduledWorkflow() scheduledworkflowclient.ScheduledWorkflowInterface {
 	return c.swfClient
 }
 
+func (c *ClientManager) PodClient() client.PodClientInterface {
+	return c.podClient
+}
+
+func (c *ClientManager) EventRecorder() record.EventRecorder {
+	return c.eventRecorder
+}
+
+func (c *ClientManager) WebhookStore() storage.WebhookStoreInterface {
+	return c.webhookStore
+}
+
+func (c *ClientManager) ExecutionTargetStore() storage.ExecutionTargetStoreInterface {
+	return c.executionTargetStore
+}
+
+func (c *ClientManager) ExecutionTargets() *resource.ExecutionTargets {
+	return c.executionTargets
+}
+
+func (c *ClientManager) ArtifactLineageStore() storage.ArtifactLineageStoreInterface {
+	return c.artifactLineageStore
+}
+
+func (c *ClientManager) RunHistoryStore() storage.RunHistoryStoreInterface {
+	return c.runHistoryStore
+}
+
+func (c *ClientManager) ModelRegistry() storage.ModelRegistryInterface {
+	return c.modelRegistry
+}
+
+func (c *ClientManager) MetricsPushTokenStore() storage.MetricsPushTokenStoreInterface {
+	return c.metricsPushTokenStore
+}
+
+func (c *ClientManager) FavoriteStore() storage.FavoriteStoreInterface {
+	return c.favoriteStore
+}
+
+func (c *ClientManager) DeploymentStatusStore() storage.DeploymentStatusStoreInterface {
+	return c.deploymentStatusStore
+}
+
+func (c *ClientManager) BackupMarkerStore() storage.BackupMarkerStoreInterface {
+	return c.backupMarkerStore
+}
+
+func (c *ClientManager) RunOutboxStore() storage.RunOutboxStoreInterface {
+	return c.runOutboxStore
+}
+
+func (c *ClientManager) RunSweepStore() storage.RunSweepStoreInterface {
+	return c.runSweepStore
+}
+
+func (c *ClientManager) RunGroupStore() storage.RunGroupStoreInterface {
+	return c.runGroupStore
+}
+
+func (c *ClientManager) TerminateOperationStore() storage.TerminateOperationStoreInterface {
+	return c.terminateOperationStore
+}
+
+func (c *ClientManager) OperationStore() storage.OperationStoreInterface {
+	return c.operationStore
+}
+
+func (c *ClientManager) GitSyncStore() storage.GitSyncStoreInterface {
+	return c.gitSyncStore
+}
+
+func (c *ClientManager) WebhookNotifier() webhook.NotifierInterface {
+	return c.webhookNotifier
+}
+
+func (c *ClientManager) EventPublisher() eventexport.PublisherInterface {
+	return c.eventPublisher
+}
+
+func (c *ClientManager) TemplateCache() *resource.TemplateCache {
+	return c.templateCache
+}
+
+func (c *ClientManager) ReportDeduplicator() *resource.ReportDeduplicator {
+	return c.reportDeduplicator
+}
+
+func (c *ClientManager) ReportDeadLetterQueue() *resource.ReportDeadLetterQueue {
+	return c.reportDeadLetterQueue
+}
+
+func (c *ClientManager) WorkflowDefaults() *api.WorkflowOptions {
+	return c.workflowDefaults
+}
+
+func (c *ClientManager) Namespace() string {
+	return getStringConfig(podNamespace)
+}
+
+func (c *ClientManager) NamespaceConfigs() *resource.NamespaceConfigs {
+	return c.namespaceConfigs
+}
+
+func (c *ClientManager) GPUQuota() *resource.GPUQuota {
+	return c.gpuQuota
+}
+
+func (c *ClientManager) AllowedServiceAccounts() []string {
+	return getStringSliceConfig(workflowServiceAccounts)
+}
+
+func (c *ClientManager) BlockSunsetPipelines() bool {
+	return getBoolConfig(deprecationBlockAfterSunset)
+}
+
+func (c *ClientManager) MaxRunOutputSize() int {
+	return getIntConfig(runOutputsMaxSize)
+}
+
+func (c *ClientManager) RunEstimationConfig() resource.RunEstimationConfig {
+	return resource.RunEstimationConfig{
+		PastRuns:           getIntConfig(runEstimationPastRuns),
+		CPUHourPrice:       getFloat64Config(runEstimationCPUHourPrice),
+		MemoryGiBHourPrice: getFloat64Config(runEstimationMemoryGiBHourPrice),
+		GPUHourPrice:       getFloat64Config(runEstimationGPUHourPrice),
+		Currency:           getStringConfig(runEstimationCurrency),
+	}
+}
+
+func (c *ClientManager) PolicyLinter() *policy.Linter {
+	return c.policyLinter
+}
+
+func (c *ClientManager) FeatureGate() *features.Gate {
+	return c.featureGate
+}
+
+func (c *ClientManager) MetadataStore() metadata.MetadataStoreInterface {
+	return c.metadataStore
+}
+
+func (c *ClientManager) VisualizationClient() visualization.VisualizationClientInterface {
+	return c.visualizationClient
+}
+
+func (c *ClientManager) ObjectStoreBucket() string {
+	return getStringConfig("ObjectStoreConfig.BucketName")
+}
+
 func (c *ClientManager) Time() util.TimeInterface {
 	return c.time
 }
@@ -100,34 +477,209 @@ func (c *ClientManager) UUID() util.UUIDGeneratorInterface {
 func (c *ClientManager) init() {
 	glog.Infof("Initializing client manager")
 
+	// Wait for the dependencies deployed alongside the API server instead of crash-looping
+	// until they're up.
+	if err := newStartupGate().Wait(); err != nil {
+		glog.Fatalf("Failed to wait for the dependencies of the API server. Error: %v", err)
+	}
+
 	db := initDBClient(getDurationConfig(initConnectionTimeout))
+	// The faults are only injected to test how the API server recovers from them.
+	faults := initFaultInjector()
+	db.SetFaultInjector(faults)
 
 	// time
 	c.time = util.NewRealTime()
 
+	initSecretParameterKey()
+
 	// UUID generator
 	c.uuid = util.NewUUIDGenerator()
 
 	c.db = db
 	c.experimentStore = storage.NewExperimentStore(db, c.time, c.uuid)
-	c.pipelineStore = storage.NewPipelineStore(db, c.time, c.uuid)
+	// The pipelines last read are served while the database is unavailable.
+	c.pipelineStore = storage.NewDegradedModePipelineStore(
+		storage.NewPipelineStore(db, c.time, c.uuid), getIntConfig(dbDegradedModeCacheSize))
 	c.jobStore = storage.NewJobStore(db, c.time)
 	c.runStore = storage.NewRunStore(db, c.time)
+	c.runOutboxStore = storage.NewRunOutboxStore(db)
+	c.runSweepStore = storage.NewRunSweepStore(db)
+	c.runGroupStore = storage.NewRunGroupStore(db)
+	c.terminateOperationStore = storage.NewTerminateOperationStore(db)
+	c.operationStore = storage.NewOperationStore(db)
 	c.resourceReferenceStore = storage.NewResourceReferenceStore(db)
-	c.objectStore = initMinioClient(getDurationConfig(initConnectionTimeout))
+	c.webhookStore = storage.NewWebhookStore(db, c.time, c.uuid)
+	c.executionTargetStore = storage.NewExecutionTargetStore(db, c.time)
+	c.artifactLineageStore = storage.NewArtifactLineageStore(db)
+	c.runHistoryStore = storage.NewRunHistoryStore(db)
+	c.gitSyncStore = storage.NewGitSyncStore(db)
+	c.modelRegistry = storage.NewModelRegistryStore(db, c.time, c.uuid)
+	c.backupMarkerStore = storage.NewBackupMarkerStore(db)
+	c.favoriteStore = storage.NewFavoriteStore(db, c.time)
+	// The steps of the runs can push their metrics only if a token is issued for them.
+	if getBoolConfig(metricsPushEnabled) {
+		c.metricsPushTokenStore = storage.NewMetricsPushTokenStore(db, c.time)
+	}
+	// The deployments of the runs are tracked only if the watcher checking them runs.
+	if getBoolConfig(deploymentWatcher) {
+		c.deploymentStatusStore = storage.NewDeploymentStatusStore(db)
+	}
+	// The object store and the Kubernetes clients retry the calls failing with a
+	// transient error, each within its own retry budget.
+	retryPolicy := getRetryPolicy()
+	c.objectStore = initMinioClient(getDurationConfig(initConnectionTimeout), retryPolicy, faults)
+	c.templateCache = resource.NewTemplateCache(getIntConfig(templateCacheSize), getDurationConfig(templateCacheTTL), c.time)
+	// The reports of the workflows are applied once per version, if deduplicated.
+	var workflowReportStore storage.WorkflowReportStoreInterface
+	if getBoolConfig(reportDeduplicate) {
+		workflowReportStore = storage.NewWorkflowReportStore(db)
+	}
+	c.reportDeduplicator = resource.NewReportDeduplicator(workflowReportStore, getDurationConfig(reportFullResync), c.time)
+	c.reportDeadLetterQueue = resource.NewReportDeadLetterQueue(storage.NewReportDeadLetterStore(db),
+		getIntConfig(reportMaxAttempts), c.time)
+	// The read-only mode set by the admins is shared by the replicas through the database.
+	c.readOnlyMode = resource.NewReadOnlyMode(storage.NewReadOnlyModeStore(db), getBoolConfig(readOnlyForced),
+		getStringConfig(readOnlyMessage), getDurationConfig(readOnlyRefreshInterval), c.time)
+	if err := c.readOnlyMode.Refresh(); err != nil {
+		glog.Fatalf("Failed to get the read-only mode. Error: %v", err)
+	}
+	c.workflowDefaults = getWorkflowDefaults()
+	c.policyLinter = newPolicyLinter()
+	c.featureGate = newFeatureGate()
+
+	// The workflows are translated to the schema of the installed Argo version.
+	var argoAdapter *client.ArgoAdapter
+	if getStringConfig(workflowEngineName) == engine.Argo {
+		argoClient := newArgoWorkflowClient()
+		argoAdapter = argoClient.Adapter()
+		c.wfClient = client.NewRetryingWorkflowClient(argoClient, retryPolicy)
+	}
+
+	c.swfClient = client.NewRetryingScheduledWorkflowClient(client.CreateScheduledWorkflowClientOrFatal(
+		getStringConfig(podNamespace), getDurationConfig(initConnectionTimeout)), retryPolicy)
 
-	c.wfClient = client.CreateWorkflowClientOrFatal(
+	c.podClient = client.CreatePodClientOrFatal(
 		getStringConfig(podNamespace), getDurationConfig(initConnectionTimeout))
 
-	c.swfClient = client.CreateScheduledWorkflowClientOrFatal(
+	c.engine = newWorkflowEngine(c.wfClient, c.podClient)
+
+	// The kubeconfigs of the execution targets are read from the Secrets of the namespace of
+	// the API server.
+	c.executionTargets = resource.NewExecutionTargets(
+		client.CreateSecretClientOrFatal(getStringConfig(podNamespace), getDurationConfig(initConnectionTimeout)),
+		newTargetClientsFactory(argoAdapter, retryPolicy), getDurationConfig(executionTargetCacheTTL), c.time)
+
+	// The namespace of the runs overrides their configuration with its ConfigMap, if enabled.
+	if getBoolConfig(namespaceConfigEnabled) {
+		c.namespaceConfigs = resource.NewNamespaceConfigs(
+			client.CreateConfigMapClientOrFatal(getDurationConfig(initConnectionTimeout)),
+			getStringConfig(namespaceConfigMapName), getDurationConfig(namespaceConfigCacheTTL), c.time)
+	}
+
+	// The GPU requests of the new runs are checked against the ResourceQuotas, unless off.
+	if mode := getStringConfig(gpuQuotaMode); mode != "off" {
+		gpuQuota, err := resource.NewGPUQuota(
+			client.CreateResourceQuotaClientOrFatal(getDurationConfig(initConnectionTimeout)),
+			getStringSliceConfig(gpuQuotaResourceNames), mode)
+		if err != nil {
+			glog.Fatalf("Invalid %s. Error: %v", gpuQuotaMode, err)
+		}
+		c.gpuQuota = gpuQuota
+	}
+
+	c.eventRecorder = client.CreateEventRecorderOrFatal(
 		getStringConfig(podNamespace), getDurationConfig(initConnectionTimeout))
+
+	webhookNotifier := webhook.NewNotifier(getIntConfig(webhookWorkers),
+		getDurationConfig(webhookRequestTimeout), getDurationConfig(webhookRetryTimeout), newWebhookURLGuard())
+	reloadableConfig.Register(webhookRequestTimeout, getConfigValue(webhookRequestTimeout),
+		reload.Duration(func(timeout time.Duration) error {
+			webhookNotifier.SetRequestTimeout(timeout)
+			return nil
+		}))
+	reloadableConfig.Register(webhookRetryTimeout, getConfigValue(webhookRetryTimeout),
+		reload.Duration(func(timeout time.Duration) error {
+			webhookNotifier.SetRetryTimeout(timeout)
+			return nil
+		}))
+	c.webhookNotifier = webhookNotifier
+
+	c.eventPublisher = initEventPublisher()
+
+	// The lineage of the runs is recorded in ML Metadata only if it's deployed.
+	if address := getStringConfig(metadataStoreAddress); address != "" {
+		metadataStore, err := metadata.DialMetadataStore(address, getDurationConfig(metadataStoreTimeout),
+			util.GrpcCallOptions(*grpcMaxRecvMsgSize, *grpcMaxSendMsgSize, false))
+		if err != nil {
+			glog.Fatalf("Failed to connect to the metadata store. Error: %v", err)
+		}
+		c.metadataStore = metadataStore
+	}
+
+	// The visualizations are generated only if the visualization server is deployed.
+	if address := getStringConfig(visualizationAddress); address != "" {
+		c.visualizationClient = visualization.NewVisualizationClient(address,
+			getDurationConfig(visualizationTimeout), getIntConfig(visualizationMaxSourceSize))
+	}
 	glog.Infof("Client manager initialized successfully")
 }
 
+// ReadOnlyMode returns the mode rejecting the writes during the maintenance of the API server.
+func (c *ClientManager) ReadOnlyMode() *resource.ReadOnlyMode {
+	return c.readOnlyMode
+}
+
+// HealthChecker returns the checker verifying the dependencies of the API server.
+func (c *ClientManager) HealthChecker() *health.Checker {
+	checker := health.NewChecker(getDurationConfig(healthCheckTimeout))
+	checker.AddReadinessCheck("database", health.SQLPingCheck(c.db.DB))
+	checker.AddReadinessCheck("object_store", func(ctx context.Context) error {
+		return c.objectStore.Ping()
+	})
+	checker.AddReadinessCheck("kubernetes", func(ctx context.Context) error {
+		if c.engine.Name() != engine.Argo {
+			_, err := c.swfClient.List(metav1.ListOptions{Limit: 1})
+			return err
+		}
+		_, err := c.wfClient.List(metav1.ListOptions{Limit: 1})
+		return err
+	})
+	return checker
+}
+
 func (c *ClientManager) Close() {
 	c.db.Close()
 }
 
+// newStartupGate creates the gate waiting for the database, the object store and the CRDs
+// of the workflows of the configured engine and the scheduled workflows, in this order.
+func newStartupGate() *health.StartupGate {
+	gate := health.NewStartupGate(getDurationConfig(startupInitialBackoff), getDurationConfig(startupMaxBackoff),
+		getDurationConfig(healthCheckTimeout))
+	mysqlConfig := client.CreateMySQLConfig("root", getStringConfig(mysqlServiceHost),
+		getStringConfig(mysqlServicePort), "")
+	mysqlConfig.Timeout = getDurationConfig(dbDialTimeout)
+	gate.Add("database", getDurationConfig(startupDatabaseTimeout),
+		health.SQLOpenCheck(getStringConfig("DBConfig.DriverName"), mysqlConfig.FormatDSN()))
+	gate.Add("object_store", getDurationConfig(startupObjectStoreTimeout), health.TCPDialCheck(
+		net.JoinHostPort(getStringConfig(minioServiceHost), getStringConfig(minioServicePort))))
+	discoveryClient, err := client.CreateKubernetesDiscoveryClient()
+	if err != nil {
+		glog.Fatalf("Failed to create the Kubernetes discovery client. Error: %v", err)
+	}
+	if getStringConfig(workflowEngineName) == engine.Tekton {
+		gate.Add("pipelinerun_crd", getDurationConfig(startupCRDTimeout),
+			health.APIResourceCheck(discoveryClient, "tekton.dev/v1beta1", "pipelineruns"))
+	} else {
+		gate.Add("workflow_crd", getDurationConfig(startupCRDTimeout),
+			health.APIResourceCheck(discoveryClient, "argoproj.io/v1alpha1", "workflows"))
+	}
+	gate.Add("scheduledworkflow_crd", getDurationConfig(startupCRDTimeout),
+		health.APIResourceCheck(discoveryClient, "kubeflow.org/v1alpha1", "scheduledworkflows"))
+	return gate
+}
+
 func initDBClient(initConnectionTimeout time.Duration) *storage.DB {
 	driverName := getStringConfig("DBConfig.DriverName")
 	var arg string
@@ -144,24 +696,30 @@ func initDBClient(initConnectionTimeout time.Duration) *storage.DB {
 	db, err := gorm.Open(driverName, arg)
 	util.TerminateIfError(err)
 
-	// Create table
-	response := db.AutoMigrate(
-		&model.Experiment{},
-		&model.Job{},
-		&model.Pipeline{},
-		&model.ResourceReference{},
-		&model.RunDetail{},
-		&model.RunMetric{})
-
-	if response.Error != nil {
-		glog.Fatalf("Failed to initialize the databases.")
+	if err := storage.Migrate(db); err != nil {
+		glog.Fatalf("Failed to initialize the databases. Error: %v", err)
 	}
-	response = db.Model(&model.RunMetric{}).
+	response := db.Model(&model.RunMetric{}).
 		AddForeignKey("RunUUID", "run_details(UUID)", "CASCADE" /* onDelete */, "CASCADE" /* update */)
 	if response.Error != nil {
 		glog.Fatalf("Failed to create a foreign key for RunID in run_metrics table. Error: %s", response.Error)
 	}
-	return storage.NewDB(db.DB(), storage.NewMySQLDialect())
+	// Creating an index locks a large table for a while: the indexes can be created
+	// out of band instead.
+	if getBoolConfig(dbCreateListIndexes) {
+		if err := storage.CreateIndexes(db, storage.ListIndexes); err != nil {
+			glog.Warningf("Failed to create the indexes of the list APIs. Error: %v", err)
+		}
+	}
+	for _, index := range storage.MissingIndexes(db, storage.ListIndexes) {
+		glog.Warningf("Index %v on %v(%v) is missing: the lists sorted or filtered by these columns scan the table.",
+			index.Name, index.Table, strings.Join(index.Columns, ", "))
+	}
+	// The queries fail fast while the database is unreachable, instead of waiting for
+	// their connections to time out.
+	breaker := util.NewCircuitBreaker("database",
+		getIntConfig(dbBreakerThreshold), getDurationConfig(dbBreakerOpenTimeout))
+	return storage.NewDB(db.DB(), storage.NewMySQLDialect(), breaker)
 }
 
 // Initialize the connection string for connecting to Mysql database
@@ -172,6 +730,7 @@ func initMysql(driverName string, initConnectionTimeout time.Duration) string {
 		getStringConfig(mysqlServiceHost),
 		getStringConfig(mysqlServicePort),
 		"")
+	mysqlConfig.Timeout = getDurationConfig(dbDialTimeout)
 
 	var db *sql.DB
 	var err error
@@ -196,19 +755,401 @@ func initMysql(driverName string, initConnectionTimeout time.Duration) string {
 	return mysqlConfig.FormatDSN()
 }
 
-func initMinioClient(initConnectionTimeout time.Duration) storage.ObjectStoreInterface {
+func getRetryPolicy() util.RetryPolicy {
+	return util.RetryPolicy{
+		MaxRetries:     getIntConfig(retryMaxRetries),
+		InitialBackoff: getDurationConfig(retryInitialBackoff),
+		MaxBackoff:     getDurationConfig(retryMaxBackoff),
+		Jitter:         getFloat64Config(retryJitter),
+		BudgetRatio:    getFloat64Config(retryBudgetRatio),
+		BudgetBurst:    getIntConfig(retryBudgetBurst),
+	}
+}
+
+func initMinioClient(initConnectionTimeout time.Duration, retryPolicy util.RetryPolicy,
+	faults *util.FaultInjector) storage.ObjectStoreInterface {
 	// Create minio client.
 	minioServiceHost := getStringConfig(minioServiceHost)
 	minioServicePort := getStringConfig(minioServicePort)
-	accessKey := getStringConfig("ObjectStoreConfig.AccessKey")
-	secretKey := getStringConfig("ObjectStoreConfig.SecretAccessKey")
+	accessKey := getStringConfig(objectStoreAccessKey)
+	secretKey := getStringConfig(objectStoreSecretAccessKey)
 	bucketName := getStringConfig("ObjectStoreConfig.BucketName")
 
+	// All the requests to the object store share a pool of connections.
+	transport := client.NewObjectStoreTransport(client.ObjectStoreTransportConfig{
+		MaxIdleConns:          getIntConfig(objectStoreMaxIdleConns),
+		MaxIdleConnsPerHost:   getIntConfig(objectStoreMaxIdleConnsPerHost),
+		IdleConnTimeout:       getDurationConfig(objectStoreIdleConnTimeout),
+		DialTimeout:           getDurationConfig(objectStoreDialTimeout),
+		KeepAlive:             getDurationConfig(objectStoreKeepAlive),
+		ResponseHeaderTimeout: getDurationConfig(objectStoreResponseHeaderTimeout),
+	})
 	minioClient := client.CreateMinioClientOrFatal(minioServiceHost, minioServicePort, accessKey,
-		secretKey, initConnectionTimeout)
+		secretKey, transport, initConnectionTimeout)
 	createMinioBucket(minioClient, bucketName)
 
-	return storage.NewMinioObjectStore(&storage.MinioClient{Client: minioClient}, bucketName)
+	// The credentials are rotated without a restart by swapping the client for one using
+	// the new credentials, and the same pool of connections.
+	swappableClient := storage.NewSwappableMinioClient(&storage.MinioClient{Client: minioClient})
+	rotateCredentials := func(newAccessKey string, newSecretKey string) error {
+		minioClient, err := client.CreateMinioClient(minioServiceHost, minioServicePort, newAccessKey,
+			newSecretKey, transport)
+		if err != nil {
+			return err
+		}
+		swappableClient.Swap(&storage.MinioClient{Client: minioClient})
+		accessKey, secretKey = newAccessKey, newSecretKey
+		return nil
+	}
+	reloadableConfig.Register(objectStoreAccessKey, accessKey, func(value string) error {
+		return rotateCredentials(value, secretKey)
+	})
+	reloadableConfig.Register(objectStoreSecretAccessKey, secretKey, func(value string) error {
+		return rotateCredentials(accessKey, value)
+	})
+
+	var objectStoreClient storage.MinioClientInterface = swappableClient
+	if faults != nil {
+		objectStoreClient = storage.NewFaultInjectingMinioClient(objectStoreClient, faults)
+	}
+	return storage.NewMinioObjectStore(objectStoreClient, bucketName, retryPolicy)
+}
+
+// initSecretParameterKey reads the key the values of the secret parameters are hashed with,
+// and encrypted with, from a file mounted from a Kubernetes Secret. Without a key, the values
+// are stored redacted and the runs with secret parameters can't be created.
+func initSecretParameterKey() {
+	keyFile := getStringConfig(secretParameterKeyFile)
+	if keyFile == "" {
+		glog.Warningf("No key is configured for the secret parameters: their values are stored redacted " +
+			"and the runs with secret parameters can't be created")
+		return
+	}
+	key, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		glog.Fatalf("Failed to read the key of the secret parameters. Error: %v", err)
+	}
+	key = []byte(strings.TrimSpace(string(key)))
+	if len(key) == 0 {
+		glog.Fatalf("The key of the secret parameters in %v is empty", keyFile)
+	}
+	util.SetSecretParameterKey(key)
+}
+
+// initFaultInjector returns the fault injector of the database and the object store, or
+// nil if no fault is injected.
+func initFaultInjector() *util.FaultInjector {
+	rules, err := util.ParseFaultRules(getStringConfig(faultInjectionRules))
+	if err != nil {
+		glog.Fatalf("Failed to parse the fault injection rules. Error: %v", err)
+	}
+	return util.NewFaultInjector(rules, int64(getIntConfig(faultInjectionSeed)))
+}
+
+func initEventPublisher() eventexport.PublisherInterface {
+	publisher, err := eventexport.NewPublisher(eventexport.Config{
+		Broker:       getStringConfig(eventExportBroker),
+		Address:      getStringConfig(eventExportAddress),
+		Topic:        getStringConfig(eventExportTopic),
+		RetryTimeout: getDurationConfig(eventExportRetry),
+	})
+	if err != nil {
+		glog.Fatalf("Failed to create the event publisher. Error: %v", err)
+	}
+	return publisher
+}
+
+// newGitSyncController creates the controller syncing the pipelines from the configured
+// Git repository. It returns nil if no repository is configured.
+func newGitSyncController(resourceManager *resource.ResourceManager, store storage.GitSyncStoreInterface) *gitsync.Controller {
+	url := getStringConfig(gitSyncRepository)
+	if url == "" {
+		return nil
+	}
+	repository := gitsync.NewGitRepository(url, getStringConfig(gitSyncBranch), getStringConfig(gitSyncWorkDir),
+		getDurationConfig(gitSyncTimeout))
+	return gitsync.NewController(repository, resourceManager, store, getStringConfig(gitSyncPath),
+		getDurationConfig(gitSyncInterval))
+}
+
+// newDeploymentWatcher creates the watcher tracking the readiness of the serving
+// resources deployed by the runs. It returns nil if the watcher is disabled.
+func newDeploymentWatcher(store storage.DeploymentStatusStoreInterface, time util.TimeInterface) *deployment.Watcher {
+	if store == nil {
+		return nil
+	}
+	restClient := client.CreateKubernetesRESTClientOrFatal(getDurationConfig(initConnectionTimeout))
+	return deployment.NewWatcher(deployment.NewResourceClient(restClient), store, time,
+		getDurationConfig(deploymentTimeout), getDurationConfig(deploymentInterval))
+}
+
+// newWriteGate creates the gate pausing the writes while a backup is taken. It returns
+// nil if the backups are disabled.
+func newWriteGate() *backup.WriteGate {
+	if getStringConfig(backupSnapshotCommand) == "" {
+		return nil
+	}
+	return backup.NewWriteGate(getDurationConfig(backupMaxWriteDelay))
+}
+
+// newBackupCoordinator creates the coordinator of the point-in-time backups, whose database
+// snapshot is taken by the configured command. It returns nil if the backups are disabled.
+func newBackupCoordinator(clientManager *ClientManager, writeGate *backup.WriteGate) *backup.Coordinator {
+	if writeGate == nil {
+		return nil
+	}
+	snapshot := backup.NewCommandSnapshotHook(getStringConfig(backupSnapshotCommand),
+		getDurationConfig(backupSnapshotTimeout))
+	return backup.NewCoordinator(writeGate, clientManager.BackupMarkerStore(), clientManager.ObjectStore(),
+		snapshot, getDurationConfig(backupQuiesceTimeout), clientManager.Time(), clientManager.UUID())
+}
+
+// getWorkflowDefaults returns the workflow options applied to the runs and the jobs not
+// setting them. The options are configured by the names of their values, empty if the
+// compiled workflows are left as they are. The artifacts are stored under the default
+// pipeline root, if set.
+func getWorkflowDefaults() *api.WorkflowOptions {
+	getEnumConfig := func(configName string, values map[string]int32) int32 {
+		name := getStringConfig(configName)
+		if name == "" {
+			return 0
+		}
+		value, ok := values[name]
+		if !ok {
+			glog.Fatalf("Unknown value %q of %s", name, configName)
+		}
+		return value
+	}
+	defaults := &api.WorkflowOptions{
+		PodGcStrategy: api.WorkflowOptions_PodGCStrategy(
+			getEnumConfig(workflowPodGCStrategy, api.WorkflowOptions_PodGCStrategy_value)),
+		ArtifactArchive: api.WorkflowOptions_ArtifactArchive(
+			getEnumConfig(workflowArtifactArchive, api.WorkflowOptions_ArtifactArchive_value)),
+		LogArchive: api.WorkflowOptions_LogArchive(
+			getEnumConfig(workflowLogArchive, api.WorkflowOptions_LogArchive_value)),
+		PriorityClassName: getStringConfig(workflowPriorityClass),
+		PreemptionPolicy: api.WorkflowOptions_PreemptionPolicy(
+			getEnumConfig(workflowPreemption, api.WorkflowOptions_PreemptionPolicy_value)),
+	}
+	if root := getStringConfig(workflowPipelineRoot); root != "" {
+		repository, err := resource.ParsePipelineRoot(root)
+		if err != nil {
+			glog.Fatalf("Invalid %s. Error: %v", workflowPipelineRoot, err)
+		}
+		defaults.ArtifactRepository = repository
+	}
+	return defaults
+}
+
+// newPolicyLinter creates the linter checking the pipelines against the policies at upload.
+func newPolicyLinter() *policy.Linter {
+	linter, err := policy.NewLinter(policy.Config{
+		AllowedRegistries:     getStringSliceConfig(policyAllowedRegistries),
+		AllowedRegistriesMode: policy.Mode(getStringConfig(policyAllowedRegistriesMode)),
+		ResourceLimitsMode:    policy.Mode(getStringConfig(policyResourceLimitsMode)),
+		NoHostPathMode:        policy.Mode(getStringConfig(policyNoHostPathMode)),
+		RequiredLabels:        getStringSliceConfig(policyRequiredLabels),
+		RequiredLabelsMode:    policy.Mode(getStringConfig(policyRequiredLabelsMode)),
+	})
+	if err != nil {
+		glog.Fatalf("Failed to create the policy linter. Error: %v", err)
+	}
+	return linter
+}
+
+// newFeatureGate returns the gate of the features, set by a list of name=bool pairs, e.g.
+// Caching=true. The features are only changed by a restart.
+func newFeatureGate() *features.Gate {
+	gate := features.NewGate()
+	if err := gate.Set(getStringConfig(featureGates)); err != nil {
+		glog.Fatalf("Invalid %s. Error: %v", featureGates, err)
+	}
+	for _, state := range gate.States() {
+		glog.Infof("Feature %s (%s) enabled: %t", state.Feature, state.Stage, state.Enabled)
+	}
+	return gate
+}
+
+// newAuthorizationHook creates the hook checking the calls against the policy of an OPA
+// server, or returns nil if no OPA server is configured.
+func newAuthorizationHook() *authz.Hook {
+	url := getStringConfig(authorizationOpaUrl)
+	if url == "" {
+		return nil
+	}
+	glog.Infof("Authorizing the calls with the OPA policy at %v", url)
+	return authz.NewHook(authz.NewOpaAuthorizer(url, getDurationConfig(authorizationTimeout)),
+		getBoolConfig(authorizationFailOpen))
+}
+
+// newURLFetcher creates the fetcher downloading the pipelines created from URLs, through the
+// configured proxy or the one of the environment.
+func newURLFetcher() *server.URLFetcher {
+	fetcher, err := server.NewURLFetcher(server.URLFetcherOptions{
+		Proxy:          getStringConfig(urlFetcherProxy),
+		DialTimeout:    getDurationConfig(urlFetcherDialTimeout),
+		Timeout:        getDurationConfig(urlFetcherTimeout),
+		MaxRedirects:   getIntConfig(urlFetcherMaxRedirects),
+		MaxSize:        int64(getIntConfig(urlFetcherMaxSize)),
+		AllowedSchemes: getStringSliceConfig(urlFetcherAllowedSchemes),
+		AllowedHosts:   getStringSliceConfig(urlFetcherAllowedHosts),
+		// The internal addresses, e.g. of the metadata endpoints, are blocked by default.
+		DeniedHosts:     getStringSliceConfig(urlFetcherDeniedHosts),
+		BlockedNetworks: getStringSliceConfig(urlFetcherBlockedNetworks),
+		AllowedNetworks: getStringSliceConfig(urlFetcherAllowedNetworks),
+	})
+	if err != nil {
+		glog.Fatalf("Failed to create the URL fetcher. Error: %v", err)
+	}
+	return fetcher
+}
+
+// newWebhookURLGuard creates the guard of the webhook URLs, denying the same hosts and
+// networks as the URL imports, e.g. the metadata endpoints and the cluster services.
+func newWebhookURLGuard() *util.URLGuard {
+	guard, err := util.NewURLGuard(util.URLGuardOptions{
+		Policy:          "webhook URL",
+		AllowedSchemes:  []string{"http", "https"},
+		DeniedHosts:     getStringSliceConfig(urlFetcherDeniedHosts),
+		BlockedNetworks: getStringSliceConfig(urlFetcherBlockedNetworks),
+		AllowedNetworks: getStringSliceConfig(urlFetcherAllowedNetworks),
+	})
+	if err != nil {
+		glog.Fatalf("Failed to create the webhook URL guard. Error: %v", err)
+	}
+	return guard
+}
+
+// newRunOutboxWorker creates the worker completing the creation of the interrupted runs.
+func newRunOutboxWorker(resourceManager *resource.ResourceManager) *resource.RunOutboxWorker {
+	return resource.NewRunOutboxWorker(resourceManager, getDurationConfig(runOutboxInterval),
+		getDurationConfig(runOutboxGracePeriod), getIntConfig(runOutboxMaxAttempts))
+}
+
+func newRunSweepWorker(resourceManager *resource.ResourceManager) *resource.RunSweepWorker {
+	return resource.NewRunSweepWorker(resourceManager, getDurationConfig(runSweepInterval))
+}
+
+func newRunQueueWorker(resourceManager *resource.ResourceManager) *resource.RunQueueWorker {
+	return resource.NewRunQueueWorker(resourceManager, getDurationConfig(runQueueInterval))
+}
+
+func newTerminateWorker(resourceManager *resource.ResourceManager) *resource.TerminateWorker {
+	return resource.NewTerminateWorker(resourceManager, getDurationConfig(terminateInterval))
+}
+
+func newOperationWorker(resourceManager *resource.ResourceManager) *resource.OperationWorker {
+	return resource.NewOperationWorker(resourceManager, getDurationConfig(operationInterval))
+}
+
+// newOrphanReconciler creates the reconciler of the workflows without a run and the runs
+// whose workflow vanished. It returns nil if the reconciler is disabled.
+func newOrphanReconciler(resourceManager *resource.ResourceManager) *resource.OrphanReconciler {
+	if !getBoolConfig(orphanReconciler) {
+		return nil
+	}
+	return resource.NewOrphanReconciler(resourceManager, getDurationConfig(orphanInterval),
+		getDurationConfig(orphanGracePeriod), getBoolConfig(orphanAdoptWorkflows), getBoolConfig(orphanDryRun))
+}
+
+// newConsistencyChecker creates the checker of the database against the object store. It
+// checks on request, and also every interval if scheduled.
+func newConsistencyChecker(resourceManager *resource.ResourceManager) *resource.ConsistencyChecker {
+	return resource.NewConsistencyChecker(resourceManager, getDurationConfig(consistencyInterval),
+		getBoolConfig(consistencyRepair))
+}
+
+// newStorageUsageCollector creates the collector of the storage usage. It computes the usage
+// on request, and also every interval if scheduled.
+func newStorageUsageCollector(resourceManager *resource.ResourceManager) *resource.StorageUsageCollector {
+	return resource.NewStorageUsageCollector(resourceManager, getDurationConfig(storageUsageInterval))
+}
+
+// newWorkflowEngine creates the engine running the workflows of the runs, Argo unless
+// configured otherwise.
+func newWorkflowEngine(wfClient workflowclient.WorkflowInterface, podClient client.PodClientInterface) engine.Engine {
+	switch name := getStringConfig(workflowEngineName); name {
+	case engine.Argo:
+		return engine.NewArgoEngine(wfClient, podClient)
+	case engine.Tekton:
+		restClient := client.CreateKubernetesRESTClientOrFatal(getDurationConfig(initConnectionTimeout))
+		return engine.NewTektonEngine(restClient, getStringConfig(podNamespace))
+	default:
+		glog.Fatalf("Workflow engine %q is not supported", name)
+		return nil
+	}
+}
+
+// newTargetClientsFactory returns the factory of the clients of the execution targets. The
+// workflows are translated to the schema of the installed Argo version, which the remote
+// clusters must run too. The other engines don't support the execution targets.
+func newTargetClientsFactory(adapter *client.ArgoAdapter, retryPolicy util.RetryPolicy) resource.TargetClientsFactory {
+	return func(target *model.ExecutionTarget, kubeconfig []byte) (*resource.TargetClients, error) {
+		if adapter == nil {
+			return nil, util.NewInvalidInputError("The execution targets aren't supported by the %v workflow engine",
+				getStringConfig(workflowEngineName))
+		}
+		remoteClient, podClient, err := client.CreateRemoteClusterClients(kubeconfig, target.Namespace, adapter)
+		if err != nil {
+			return nil, err
+		}
+		return &resource.TargetClients{
+			Engine:    engine.NewArgoEngine(client.NewRetryingWorkflowClient(remoteClient, retryPolicy), podClient),
+			PodClient: podClient,
+		}, nil
+	}
+}
+
+// newArgoWorkflowClient creates the client of the workflows of the installed Argo version,
+// detected from its workflow controller unless configured.
+func newArgoWorkflowClient() *client.VersionedWorkflowClient {
+	restClient := client.CreateKubernetesRESTClientOrFatal(getDurationConfig(initConnectionTimeout))
+	var version client.ArgoVersion
+	var err error
+	if configured := getStringConfig(argoVersion); configured != "" {
+		version, err = client.ParseArgoVersion(configured)
+	} else {
+		namespace := getStringConfig(argoControllerNamespace)
+		if namespace == "" {
+			namespace = getStringConfig(podNamespace)
+		}
+		version, err = client.DetectArgoVersion(restClient, namespace, getStringConfig(argoControllerDeployment))
+	}
+	if err != nil {
+		glog.Fatalf("Failed to get the Argo version. Error: %v", err)
+	}
+	adapter, err := client.NewArgoAdapter(version)
+	if err != nil {
+		glog.Fatalf("Failed to create the Argo adapter. Error: %v", err)
+	}
+	glog.Infof("Using the workflow schema of Argo %v", version)
+	return client.NewVersionedWorkflowClient(restClient, getStringConfig(podNamespace), adapter)
+}
+
+// newEngineStatusPoller creates the poller reporting the status of the workflows of the
+// engine. It returns nil if the persistence agent reports them, as it does for Argo.
+func newEngineStatusPoller(resourceManager *resource.ResourceManager, workflowEngine engine.Engine) *resource.EngineStatusPoller {
+	if workflowEngine.ReportedByPersistenceAgent() {
+		return nil
+	}
+	return resource.NewEngineStatusPoller(resourceManager, getDurationConfig(workflowEnginePollInterval))
+}
+
+// newLeaderElector creates the elector running the background tasks on a single replica
+// of the API server. It returns nil if leader election is disabled, in which case every
+// replica runs them.
+func newLeaderElector(time util.TimeInterface) *leaderelection.Elector {
+	if !getBoolConfig(leaderElection) {
+		return nil
+	}
+	restClient := client.CreateKubernetesRESTClientOrFatal(getDurationConfig(initConnectionTimeout))
+	elector, err := leaderelection.NewReplicaElector(restClient, getStringConfig(podNamespace),
+		getStringConfig(leaderElectionLease), getDurationConfig(leaderElectionTTL),
+		getDurationConfig(leaderElectionRenew), getDurationConfig(leaderElectionRetry), time)
+	if err != nil {
+		glog.Fatalf("Failed to create the leader elector. Error: %v", err)
+	}
+	return elector
 }
 
 func createMinioBucket(minioClient *minio.Client, bucketName string) {
@@ -34,6 +34,16 @@ const (
 	Creator Relationship = "Creator"
 )
 
+// ListView selects the fields of the runs and jobs returned by the list queries.
+type ListView int
+
+const (
+	// The runs and jobs without the manifests of their pipeline specs.
+	BasicView ListView = iota
+	// The runs and jobs with the manifests of their pipeline specs.
+	FullView
+)
+
 func ToModelResourceType(apiType api.ResourceType) (ResourceType, error) {
 	switch apiType {
 	case api.ResourceType_EXPERIMENT:
@@ -19,7 +19,39 @@ type ReferenceKey struct {
 	ID   string
 }
 
+// Annotation selects the runs with an annotation: with any value if the value is empty.
+type Annotation struct {
+	Name  string
+	Value string
+}
+
+// NameMatch is how a NameFilter matches the names.
+type NameMatch int
+
+const (
+	// The names equal to the name, in the same case.
+	NameMatchExact NameMatch = iota
+	// The names equal to the name, in any case.
+	NameMatchCaseInsensitive
+	// The names starting with the name, in any case.
+	NameMatchPrefix
+)
+
+// NameFilter selects the resources by their names: the display names of the runs and the jobs.
+type NameFilter struct {
+	Name  string
+	Match NameMatch
+}
+
 type FilterContext struct {
 	// Filter by a specific reference key
 	*ReferenceKey
+	// Filter by an annotation. Only the runs are annotated.
+	Annotation *Annotation
+	// Filter by the favorites of a user, if set.
+	StarredBy string
+	// Filter out the deprecated pipelines.
+	ExcludeDeprecated bool
+	// Filter by the names, if set.
+	Name *NameFilter
 }
@@ -14,6 +14,11 @@
 
 package common
 
+// TokenVersion is the version of the page tokens. It's bumped when the tokens of the previous
+// versions would list the wrong rows, e.g. when the order of a list changes, so that they're
+// rejected as stale instead.
+const TokenVersion = 1
+
 // A deserialized token. Assuming the list request is sorted by name, a typical token should be
 // {SortByFieldValue:"foo", KeyFieldValue:"2"}
 // The corresponding list query would be
@@ -23,6 +28,13 @@ type Token struct {
 	SortByFieldValue string
 	// The value of the key field of the next row to be returned.
 	KeyFieldValue string
+	// The order of the list, which the requests of the next pages must keep.
+	SortByFieldName string
+	IsDesc          bool
+	// The version of the token, zero for the tokens issued before they were versioned.
+	Version int
+	// When the token expires, in seconds since the epoch.
+	ExpiresAtInSec int64
 }
 
 type PaginationContext struct {
@@ -31,4 +43,6 @@ type PaginationContext struct {
 	KeyFieldName    string
 	IsDesc          bool
 	Token           *Token
+	// When the token of the next page expires, in seconds since the epoch.
+	TokenExpiresAtInSec int64
 }
@@ -0,0 +1,37 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import "context"
+
+// RequestIdHeader is the header, or the gRPC metadata, carrying the ID of a call. The ID is
+// generated by the API server unless the caller sets it.
+const RequestIdHeader = "x-request-id"
+
+type requestIdKey struct{}
+
+// WithRequestId returns a context for a call identified by a request ID.
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// GetRequestId returns the ID of a call, or "" if the call isn't identified.
+func GetRequestId(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import "context"
+
+type userKey struct{}
+
+// WithUser returns a context for a call made by an authenticated user.
+func WithUser(ctx context.Context, userId string) context.Context {
+	return context.WithValue(ctx, userKey{}, userId)
+}
+
+// GetUser returns the ID of the user who made a call, or "" if the user isn't authenticated.
+func GetUser(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	userId, _ := ctx.Value(userKey{}).(string)
+	return userId
+}
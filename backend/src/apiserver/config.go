@@ -19,9 +19,14 @@ import (
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/reload"
 	"github.com/spf13/viper"
 )
 
+// The effective configuration of the API server. The settings registered with an ApplyFunc
+// are changed without a restart when the configuration file changes.
+var reloadableConfig = reload.NewRegistry()
+
 func initConfig() {
 	// Import environment variable
 	viper.AutomaticEnv()
@@ -34,14 +39,38 @@ func initConfig() {
 		glog.Fatalf("Fatal error config file: %s", err)
 	}
 
+	// The settings of the configuration file are reported until they're registered as
+	// reloadable by the components using them.
+	for _, name := range viper.AllKeys() {
+		reloadableConfig.Register(name, getConfigValue(name), nil)
+	}
+
 	// Watch for configuration change
 	viper.WatchConfig()
 	viper.OnConfigChange(func(e fsnotify.Event) {
 		// Read in config again
 		viper.ReadInConfig()
+		reloadConfig()
 	})
 }
 
+// reloadConfig applies the settings of the configuration file which changed. The
+// environment variables still take precedence over the file.
+func reloadConfig() {
+	values := make(map[string]string)
+	for _, name := range reloadableConfig.Names() {
+		values[name] = getConfigValue(name)
+	}
+	if err := reloadableConfig.Update(values); err != nil {
+		glog.Errorf("Failed to reload the configuration: %v", err)
+	}
+}
+
+// getConfigValue returns the value of a setting as reported in the effective configuration.
+func getConfigValue(configName string) string {
+	return reload.FormatValue(viper.Get(configName))
+}
+
 func getStringConfig(configName string) string {
 	if !viper.IsSet(configName) {
 		glog.Fatalf("Please specify flag %s", configName)
@@ -49,9 +78,42 @@ func getStringConfig(configName string) string {
 	return viper.GetString(configName)
 }
 
+// getOptionalStringConfig returns the value of a setting, or empty if it isn't set.
+func getOptionalStringConfig(configName string) string {
+	return viper.GetString(configName)
+}
+
 func getDurationConfig(configName string) time.Duration {
 	if !viper.IsSet(configName) {
 		glog.Fatalf("Please specify flag %s", configName)
 	}
 	return viper.GetDuration(configName)
 }
+
+func getBoolConfig(configName string) bool {
+	if !viper.IsSet(configName) {
+		glog.Fatalf("Please specify flag %s", configName)
+	}
+	return viper.GetBool(configName)
+}
+
+func getIntConfig(configName string) int {
+	if !viper.IsSet(configName) {
+		glog.Fatalf("Please specify flag %s", configName)
+	}
+	return viper.GetInt(configName)
+}
+
+func getStringSliceConfig(configName string) []string {
+	if !viper.IsSet(configName) {
+		glog.Fatalf("Please specify flag %s", configName)
+	}
+	return viper.GetStringSlice(configName)
+}
+
+func getFloat64Config(configName string) float64 {
+	if !viper.IsSet(configName) {
+		glog.Fatalf("Please specify flag %s", configName)
+	}
+	return viper.GetFloat64(configName)
+}
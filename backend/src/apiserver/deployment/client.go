@@ -0,0 +1,60 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/client-go/rest"
+)
+
+// ResourceClientInterface reads the serving resources the runs deploy, whose types
+// aren't known to the API server.
+type ResourceClientInterface interface {
+	// Get returns the object of a kind in a namespace, decoded from JSON. It returns a
+	// NotFound error if the object doesn't exist.
+	Get(apiVersion string, kind string, namespace string, name string) (map[string]interface{}, error)
+}
+
+// ResourceClient reads the custom resources through the REST API of Kubernetes.
+type ResourceClient struct {
+	restClient rest.Interface
+}
+
+func NewResourceClient(restClient rest.Interface) *ResourceClient {
+	return &ResourceClient{restClient: restClient}
+}
+
+func (c *ResourceClient) Get(apiVersion string, kind string, namespace string, name string) (map[string]interface{}, error) {
+	// The resources of the custom resource definitions are the plural of their kind.
+	path := fmt.Sprintf("/apis/%s/namespaces/%s/%ss/%s", apiVersion, namespace, strings.ToLower(kind), name)
+	var statusCode int
+	body, err := c.restClient.Get().AbsPath(path).Do().StatusCode(&statusCode).Raw()
+	if statusCode == http.StatusNotFound {
+		return nil, util.NewResourceNotFoundError(kind, namespace+"/"+name)
+	}
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get %v %v/%v", kind, namespace, name)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse %v %v/%v", kind, namespace, name)
+	}
+	return object, nil
+}
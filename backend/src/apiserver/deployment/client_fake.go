@@ -0,0 +1,39 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import "github.com/kubeflow/pipelines/backend/src/common/util"
+
+// FakeResourceClient serves the objects it's given, keyed by kind, namespace and name.
+type FakeResourceClient struct {
+	objects map[string]map[string]interface{}
+}
+
+func NewFakeResourceClient() *FakeResourceClient {
+	return &FakeResourceClient{objects: map[string]map[string]interface{}{}}
+}
+
+// Put adds or replaces an object.
+func (c *FakeResourceClient) Put(kind string, namespace string, name string, object map[string]interface{}) {
+	c.objects[kind+"/"+namespace+"/"+name] = object
+}
+
+func (c *FakeResourceClient) Get(apiVersion string, kind string, namespace string, name string) (map[string]interface{}, error) {
+	object, ok := c.objects[kind+"/"+namespace+"/"+name]
+	if !ok {
+		return nil, util.NewResourceNotFoundError(kind, namespace+"/"+name)
+	}
+	return object, nil
+}
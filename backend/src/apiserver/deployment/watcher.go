@@ -0,0 +1,147 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	kindInferenceService = "InferenceService"
+	kindSeldonDeployment = "SeldonDeployment"
+)
+
+// Watcher tracks the readiness of the serving resources deployed by the succeeded runs,
+// so that a run whose endpoint never becomes ready doesn't look successful. A resource
+// that isn't ready within the timeout is marked failed.
+type Watcher struct {
+	client   ResourceClientInterface
+	store    storage.DeploymentStatusStoreInterface
+	time     util.TimeInterface
+	timeout  time.Duration
+	interval time.Duration
+}
+
+func NewWatcher(client ResourceClientInterface, store storage.DeploymentStatusStoreInterface, time util.TimeInterface,
+	timeout time.Duration, interval time.Duration) *Watcher {
+	return &Watcher{
+		client:   client,
+		store:    store,
+		time:     time,
+		timeout:  timeout,
+		interval: interval,
+	}
+}
+
+// Run checks the pending deployments every interval until stopCh is closed.
+func (w *Watcher) Run(stopCh <-chan struct{}) {
+	glog.Infof("Checking the readiness of the deployments of the runs every %v", w.interval)
+	wait.Until(func() {
+		if err := w.Sync(); err != nil {
+			glog.Errorf("Failed to check the deployments of the runs: %+v", err)
+		}
+	}, w.interval, stopCh)
+}
+
+// Sync updates the state of the pending deployments from their resources.
+func (w *Watcher) Sync() error {
+	deployments, err := w.store.ListDeploymentsByState(model.DeploymentPending)
+	if err != nil {
+		return util.Wrap(err, "Failed to list the pending deployments")
+	}
+	var errs []error
+	for _, deployment := range deployments {
+		if err := w.syncDeployment(deployment); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (w *Watcher) syncDeployment(deployment *model.RunDeployment) error {
+	state, message := model.DeploymentPending, ""
+	object, err := w.client.Get(deployment.APIVersion, deployment.Kind, deployment.Namespace, deployment.Name)
+	switch {
+	case err == nil:
+		state, message = deploymentState(deployment.Kind, object)
+	case util.IsUserErrorCodeMatch(err, codes.NotFound):
+		message = fmt.Sprintf("%v %v/%v not found", deployment.Kind, deployment.Namespace, deployment.Name)
+	default:
+		glog.Warningf("Failed to get %v %v/%v of run %v: %v", deployment.Kind, deployment.Namespace,
+			deployment.Name, deployment.RunUUID, err)
+		message = deployment.Message
+	}
+	now := w.time.Now()
+	if timeout := time.Unix(deployment.CreatedAtInSec, 0).Add(w.timeout); state == model.DeploymentPending && !now.Before(timeout) {
+		state = model.DeploymentFailed
+		message = fmt.Sprintf("Not ready after %v: %v", w.timeout, message)
+	}
+	if state == deployment.State && message == deployment.Message {
+		return nil
+	}
+	deployment.State = state
+	deployment.Message = message
+	deployment.UpdatedAtInSec = now.Unix()
+	if err := w.store.UpdateDeployment(deployment); err != nil {
+		return util.Wrapf(err, "Failed to update %v %v/%v of run %v", deployment.Kind, deployment.Namespace,
+			deployment.Name, deployment.RunUUID)
+	}
+	return nil
+}
+
+// deploymentState returns the state of a serving resource read from its status, and why
+// it isn't ready.
+func deploymentState(kind string, object map[string]interface{}) (model.DeploymentState, string) {
+	status, _ := object["status"].(map[string]interface{})
+	switch kind {
+	case kindInferenceService:
+		// The InferenceServices are ready when their Ready condition is true.
+		conditions, _ := status["conditions"].([]interface{})
+		for _, item := range conditions {
+			condition, _ := item.(map[string]interface{})
+			if condition["type"] != "Ready" {
+				continue
+			}
+			if condition["status"] == "True" {
+				return model.DeploymentReady, ""
+			}
+			reason, _ := condition["reason"].(string)
+			message, _ := condition["message"].(string)
+			return model.DeploymentPending, fmt.Sprintf("%v: %v", reason, message)
+		}
+	case kindSeldonDeployment:
+		// The SeldonDeployments report their state: Creating, Available or Failed.
+		if state, ok := status["state"].(string); ok {
+			description, _ := status["description"].(string)
+			switch state {
+			case "Available":
+				return model.DeploymentReady, ""
+			case "Failed":
+				return model.DeploymentFailed, description
+			}
+			return model.DeploymentPending, fmt.Sprintf("%v: %v", state, description)
+		}
+	}
+	return model.DeploymentPending, "No status reported yet"
+}
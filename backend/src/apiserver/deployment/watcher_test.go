@@ -0,0 +1,96 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPendingDeployment(kind string, name string) *model.RunDeployment {
+	return &model.RunDeployment{RunUUID: "run1", Kind: kind, Namespace: "kubeflow", Name: name,
+		APIVersion: "v1alpha1", State: model.DeploymentPending, CreatedAtInSec: 0, UpdatedAtInSec: 0}
+}
+
+func inferenceService(ready string) map[string]interface{} {
+	return map[string]interface{}{"status": map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": ready, "reason": "RevisionMissing", "message": "no revision"},
+		},
+	}}
+}
+
+func TestWatcher_Sync(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	defer db.Close()
+	store := storage.NewDeploymentStatusStore(db)
+	assert.Nil(t, store.CreateDeployments([]*model.RunDeployment{
+		newPendingDeployment(kindInferenceService, "ready"),
+		newPendingDeployment(kindInferenceService, "not-ready"),
+		newPendingDeployment(kindInferenceService, "missing"),
+		newPendingDeployment(kindSeldonDeployment, "failed"),
+	}))
+	client := NewFakeResourceClient()
+	client.Put(kindInferenceService, "kubeflow", "ready", inferenceService("True"))
+	client.Put(kindInferenceService, "kubeflow", "not-ready", inferenceService("False"))
+	client.Put(kindSeldonDeployment, "kubeflow", "failed", map[string]interface{}{
+		"status": map[string]interface{}{"state": "Failed", "description": "image pull error"},
+	})
+	watcher := NewWatcher(client, store, util.NewFakeTime(time.Unix(10, 0)), time.Minute, time.Second)
+
+	assert.Nil(t, watcher.Sync())
+	deployments, err := store.ListRunDeployments("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(deployments))
+	states := map[string]*model.RunDeployment{}
+	for _, deployment := range deployments {
+		states[deployment.Name] = deployment
+	}
+	assert.Equal(t, model.DeploymentReady, states["ready"].State)
+	assert.Equal(t, model.DeploymentPending, states["not-ready"].State)
+	assert.Equal(t, "RevisionMissing: no revision", states["not-ready"].Message)
+	assert.Equal(t, model.DeploymentPending, states["missing"].State)
+	assert.Equal(t, "InferenceService kubeflow/missing not found", states["missing"].Message)
+	assert.Equal(t, model.DeploymentFailed, states["failed"].State)
+	assert.Equal(t, "image pull error", states["failed"].Message)
+}
+
+func TestWatcher_Sync_Timeout(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	defer db.Close()
+	store := storage.NewDeploymentStatusStore(db)
+	assert.Nil(t, store.CreateDeployments([]*model.RunDeployment{newPendingDeployment(kindInferenceService, "mnist")}))
+	client := NewFakeResourceClient()
+	client.Put(kindInferenceService, "kubeflow", "mnist", inferenceService("False"))
+	watcher := NewWatcher(client, store, util.NewFakeTime(time.Unix(120, 0)), time.Minute, time.Second)
+
+	assert.Nil(t, watcher.Sync())
+	deployments, err := store.ListRunDeployments("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, model.DeploymentFailed, deployments[0].State)
+	assert.Equal(t, "Not ready after 1m0s: RevisionMissing: no revision", deployments[0].Message)
+
+	// A failed deployment isn't checked again.
+	client.Put(kindInferenceService, "kubeflow", "mnist", inferenceService("True"))
+	assert.Nil(t, watcher.Sync())
+	deployments, err = store.ListRunDeployments("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, model.DeploymentFailed, deployments[0].State)
+}
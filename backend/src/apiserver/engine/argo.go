@@ -0,0 +1,189 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	workflowclient "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	workflowcommon "github.com/argoproj/argo/workflow/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArgoEngine runs the workflows as Argo workflows, whose status the persistence agent
+// reports.
+type ArgoEngine struct {
+	workflows workflowclient.WorkflowInterface
+	pods      client.PodClientInterface
+}
+
+func NewArgoEngine(workflows workflowclient.WorkflowInterface, pods client.PodClientInterface) *ArgoEngine {
+	return &ArgoEngine{workflows: workflows, pods: pods}
+}
+
+func (e *ArgoEngine) Name() string {
+	return Argo
+}
+
+func (e *ArgoEngine) Create(workflow *util.Workflow) (*util.Workflow, error) {
+	created, err := e.workflows.Create(workflow.Get())
+	if err != nil {
+		return nil, err
+	}
+	return util.NewWorkflow(created), nil
+}
+
+func (e *ArgoEngine) Get(name string) (*util.Workflow, error) {
+	workflow, err := e.workflows.Get(name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return util.NewWorkflow(workflow), nil
+}
+
+func (e *ArgoEngine) List() ([]*util.Workflow, error) {
+	list, err := e.workflows.List(v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	workflows := make([]*util.Workflow, 0, len(list.Items))
+	for i := range list.Items {
+		workflows = append(workflows, util.NewWorkflow(&list.Items[i]))
+	}
+	return workflows, nil
+}
+
+func (e *ArgoEngine) Delete(name string) error {
+	return e.workflows.Delete(name, &v1.DeleteOptions{})
+}
+
+// Terminate sets the deadline of the workflow to now, which makes Argo stop its steps.
+func (e *ArgoEngine) Terminate(name string) error {
+	workflow, err := e.workflows.Get(name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	deadline := int64(0)
+	workflow.Spec.ActiveDeadlineSeconds = &deadline
+	// A queued workflow wouldn't reach its deadline.
+	workflow.Spec.Suspend = nil
+	_, err = e.workflows.Update(workflow)
+	return err
+}
+
+// Resume unsuspends a queued workflow, which makes Argo run its steps.
+func (e *ArgoEngine) Resume(name string) error {
+	workflow, err := e.workflows.Get(name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	workflow.Spec.Suspend = nil
+	delete(workflow.Labels, util.LabelKeyWorkflowQueued)
+	_, err = e.workflows.Update(workflow)
+	return err
+}
+
+// Retry resets the nodes of the failed steps of a workflow and deletes their pods, as
+// `argo retry` does, so that Argo runs them again.
+func (e *ArgoEngine) Retry(name string) error {
+	workflow, err := e.workflows.Get(name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	switch workflow.Status.Phase {
+	case workflowapi.NodeFailed, workflowapi.NodeError:
+	default:
+		return util.NewInvalidInputError("Workflow %v can't be retried in phase %q", name, workflow.Status.Phase)
+	}
+	nodes := make(map[string]workflowapi.NodeStatus)
+	for id, node := range workflow.Status.Nodes {
+		switch {
+		case node.Phase == workflowapi.NodeSucceeded || node.Phase == workflowapi.NodeSkipped:
+			nodes[id] = node
+		case node.Type == workflowapi.NodeTypePod:
+			// The pods are named after their nodes, and the new ones reuse the names.
+			if err := e.pods.DeletePod(id); err != nil {
+				return err
+			}
+		default:
+			node.Phase = workflowapi.NodeRunning
+			node.Message = ""
+			node.FinishedAt = v1.Time{}
+			nodes[id] = node
+		}
+	}
+	workflow.Status.Nodes = nodes
+	workflow.Status.Phase = workflowapi.NodeRunning
+	workflow.Status.Message = ""
+	workflow.Status.FinishedAt = v1.Time{}
+	if workflow.Spec.ActiveDeadlineSeconds != nil && *workflow.Spec.ActiveDeadlineSeconds == 0 {
+		// A terminated workflow would stop again.
+		workflow.Spec.ActiveDeadlineSeconds = nil
+	}
+	delete(workflow.Labels, workflowcommon.LabelKeyCompleted)
+	delete(workflow.Labels, util.LabelKeyWorkflowQueued)
+	if workflow.Labels != nil {
+		workflow.Labels[workflowcommon.LabelKeyPhase] = string(workflowapi.NodeRunning)
+	}
+	_, err = e.workflows.Update(workflow)
+	return err
+}
+
+// ResolveGates completes the suspend nodes of the gates, as `argo resume` does, failing them
+// if they're rejected, and unsuspends the workflow once no gate waits anymore. A rejected
+// workflow is terminated, so that its other branches don't keep running.
+func (e *ArgoEngine) ResolveGates(name string, nodeId string, approved bool, message string) error {
+	workflow, err := e.workflows.Get(name, v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	phase := workflowapi.NodeSucceeded
+	if !approved {
+		phase = workflowapi.NodeFailed
+	}
+	resolved, waiting := 0, 0
+	now := v1.Now()
+	for _, gate := range util.NewWorkflow(workflow).WaitingApprovalGates() {
+		if nodeId != "" && gate.ID != nodeId {
+			waiting++
+			continue
+		}
+		gate.Phase = phase
+		gate.Message = message
+		gate.FinishedAt = now
+		workflow.Status.Nodes[gate.ID] = gate
+		resolved++
+	}
+	if resolved == 0 {
+		if nodeId != "" {
+			return util.NewInvalidInputError("Node %v of workflow %v isn't an approval gate waiting for a decision", nodeId, name)
+		}
+		return util.NewInvalidInputError("Workflow %v has no approval gate waiting for a decision", name)
+	}
+	if !approved {
+		deadline := int64(0)
+		workflow.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	if !approved || waiting == 0 {
+		workflow.Spec.Suspend = nil
+	}
+	_, err = e.workflows.Update(workflow)
+	return err
+}
+
+func (e *ArgoEngine) ReportedByPersistenceAgent() bool {
+	return true
+}
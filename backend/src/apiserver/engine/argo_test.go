@@ -0,0 +1,175 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	workflowcommon "github.com/argoproj/argo/workflow/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createFailedWorkflow(t *testing.T, workflows *storage.FakeWorkflowClient) {
+	_, err := workflows.Create(&workflowapi.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "workflow1",
+			Labels: map[string]string{
+				workflowcommon.LabelKeyCompleted: "true",
+				workflowcommon.LabelKeyPhase:     string(workflowapi.NodeFailed),
+			},
+		},
+		Status: workflowapi.WorkflowStatus{
+			Phase:   workflowapi.NodeFailed,
+			Message: "step2 failed",
+			Nodes: map[string]workflowapi.NodeStatus{
+				"workflow1":       {ID: "workflow1", Type: workflowapi.NodeTypeDAG, Phase: workflowapi.NodeFailed},
+				"workflow1-step1": {ID: "workflow1-step1", Type: workflowapi.NodeTypePod, Phase: workflowapi.NodeSucceeded},
+				"workflow1-step2": {ID: "workflow1-step2", Type: workflowapi.NodeTypePod, Phase: workflowapi.NodeFailed},
+			},
+		},
+	})
+	assert.Nil(t, err)
+}
+
+func TestArgoEngine_Terminate(t *testing.T) {
+	workflows := storage.NewWorkflowClientFake()
+	createFailedWorkflow(t, workflows)
+	engine := NewArgoEngine(workflows, client.NewFakePodClient())
+
+	assert.Nil(t, engine.Terminate("workflow1"))
+	workflow, err := workflows.Get("workflow1", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), *workflow.Spec.ActiveDeadlineSeconds)
+}
+
+func TestArgoEngine_Resume(t *testing.T) {
+	workflows := storage.NewWorkflowClientFake()
+	queued := util.NewWorkflow(&workflowapi.Workflow{ObjectMeta: v1.ObjectMeta{Name: "workflow1"}})
+	queued.Queue()
+	_, err := workflows.Create(queued.Get())
+	assert.Nil(t, err)
+	engine := NewArgoEngine(workflows, client.NewFakePodClient())
+
+	assert.Nil(t, engine.Resume("workflow1"))
+	workflow, err := workflows.Get("workflow1", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, workflow.Spec.Suspend)
+	assert.False(t, util.NewWorkflow(workflow).IsQueued())
+}
+
+func createSuspendedWorkflow(t *testing.T, workflows *storage.FakeWorkflowClient) {
+	suspend := true
+	_, err := workflows.Create(&workflowapi.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: "workflow1"},
+		Spec:       workflowapi.WorkflowSpec{Suspend: &suspend},
+		Status: workflowapi.WorkflowStatus{
+			Phase: workflowapi.NodeRunning,
+			Nodes: map[string]workflowapi.NodeStatus{
+				"workflow1":       {ID: "workflow1", Type: workflowapi.NodeTypeDAG, Phase: workflowapi.NodeRunning},
+				"workflow1-gate1": {ID: "workflow1-gate1", Type: workflowapi.NodeTypeSuspend, Phase: workflowapi.NodeRunning},
+				"workflow1-gate2": {ID: "workflow1-gate2", Type: workflowapi.NodeTypeSuspend, Phase: workflowapi.NodeRunning},
+			},
+		},
+	})
+	assert.Nil(t, err)
+}
+
+func TestArgoEngine_ResolveGates_Approve(t *testing.T) {
+	workflows := storage.NewWorkflowClientFake()
+	createSuspendedWorkflow(t, workflows)
+	engine := NewArgoEngine(workflows, client.NewFakePodClient())
+
+	// The workflow stays suspended while a gate waits.
+	assert.Nil(t, engine.ResolveGates("workflow1", "workflow1-gate1", true, "Approved by alice"))
+	workflow, err := workflows.Get("workflow1", v1.GetOptions{})
+	assert.Nil(t, err)
+	gate := workflow.Status.Nodes["workflow1-gate1"]
+	assert.Equal(t, workflowapi.NodeSucceeded, gate.Phase)
+	assert.Equal(t, "Approved by alice", gate.Message)
+	assert.False(t, gate.FinishedAt.IsZero())
+	assert.Equal(t, workflowapi.NodeRunning, workflow.Status.Nodes["workflow1-gate2"].Phase)
+	assert.True(t, *workflow.Spec.Suspend)
+
+	assert.Nil(t, engine.ResolveGates("workflow1", "", true, "Approved by bob"))
+	workflow, err = workflows.Get("workflow1", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, workflowapi.NodeSucceeded, workflow.Status.Nodes["workflow1-gate2"].Phase)
+	assert.Equal(t, "Approved by alice", workflow.Status.Nodes["workflow1-gate1"].Message)
+	assert.Nil(t, workflow.Spec.Suspend)
+	assert.Nil(t, workflow.Spec.ActiveDeadlineSeconds)
+
+	err = engine.ResolveGates("workflow1", "", true, "Approved by bob")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "has no approval gate waiting for a decision")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestArgoEngine_ResolveGates_Reject(t *testing.T) {
+	workflows := storage.NewWorkflowClientFake()
+	createSuspendedWorkflow(t, workflows)
+	engine := NewArgoEngine(workflows, client.NewFakePodClient())
+
+	err := engine.ResolveGates("workflow1", "workflow1", false, "Rejected")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "isn't an approval gate waiting for a decision")
+
+	assert.Nil(t, engine.ResolveGates("workflow1", "workflow1-gate1", false, "Rejected by alice: bad metrics"))
+	workflow, err := workflows.Get("workflow1", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, workflowapi.NodeFailed, workflow.Status.Nodes["workflow1-gate1"].Phase)
+	assert.Equal(t, "Rejected by alice: bad metrics", workflow.Status.Nodes["workflow1-gate1"].Message)
+	assert.Nil(t, workflow.Spec.Suspend)
+	assert.Equal(t, int64(0), *workflow.Spec.ActiveDeadlineSeconds)
+}
+
+func TestArgoEngine_Retry(t *testing.T) {
+	workflows := storage.NewWorkflowClientFake()
+	createFailedWorkflow(t, workflows)
+	pods := client.NewFakePodClient()
+	engine := NewArgoEngine(workflows, pods)
+	assert.Nil(t, engine.Terminate("workflow1"))
+
+	assert.Nil(t, engine.Retry("workflow1"))
+	workflow, err := workflows.Get("workflow1", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, workflowapi.NodeRunning, workflow.Status.Phase)
+	assert.Empty(t, workflow.Status.Message)
+	assert.Nil(t, workflow.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, map[string]string{workflowcommon.LabelKeyPhase: string(workflowapi.NodeRunning)}, workflow.Labels)
+	assert.Equal(t, []string{"workflow1-step2"}, pods.Deleted())
+	assert.Len(t, workflow.Status.Nodes, 2)
+	assert.Equal(t, workflowapi.NodeRunning, workflow.Status.Nodes["workflow1"].Phase)
+	assert.Equal(t, workflowapi.NodeSucceeded, workflow.Status.Nodes["workflow1-step1"].Phase)
+}
+
+func TestArgoEngine_Retry_NotFailed(t *testing.T) {
+	workflows := storage.NewWorkflowClientFake()
+	_, err := workflows.Create(&workflowapi.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: "workflow1"},
+		Status:     workflowapi.WorkflowStatus{Phase: workflowapi.NodeRunning},
+	})
+	assert.Nil(t, err)
+
+	err = NewArgoEngine(workflows, client.NewFakePodClient()).Retry("workflow1")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "can't be retried")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The names of the engines a deployment runs its workflows on.
+const (
+	Argo   = "argo"
+	Tekton = "tekton"
+)
+
+// Engine runs the workflows of the runs. The workflows are described, and their status
+// parsed, in the format of Argo, which the pipelines are compiled to. The engines other
+// than Argo translate them from and to their own resources.
+type Engine interface {
+	// Name returns the name of the engine, e.g. Argo.
+	Name() string
+	// Create creates a workflow, returning it with its UID set. The errors of the
+	// Kubernetes API, e.g. AlreadyExists, are returned as is.
+	Create(workflow *util.Workflow) (*util.Workflow, error)
+	// Get returns a workflow with its current status.
+	Get(name string) (*util.Workflow, error)
+	// List returns the workflows of the namespace of the engine.
+	List() ([]*util.Workflow, error)
+	// Delete deletes a workflow. The NotFound errors of the Kubernetes API are returned as is.
+	Delete(name string) error
+	// Terminate stops the steps of a running workflow, which then fails.
+	Terminate(name string) error
+	// Resume starts a workflow created queued, i.e. suspended until its pipeline allows
+	// it to run.
+	Resume(name string) error
+	// Retry runs the failed steps of a failed workflow again, keeping the succeeded ones.
+	Retry(name string) error
+	// ResolveGates approves or rejects the approval gates of a workflow waiting for a
+	// decision, all of them or the one of nodeId, recording the message in their nodes.
+	// The workflow resumes once no gate waits anymore, and terminates once one is rejected.
+	ResolveGates(name string, nodeId string, approved bool, message string) error
+	// ReportedByPersistenceAgent returns whether the persistence agent reports the status
+	// of the workflows. Otherwise, the API server polls it.
+	ReportedByPersistenceAgent() bool
+}
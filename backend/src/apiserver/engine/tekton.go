@@ -0,0 +1,156 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+var pipelineRunResource = schema.GroupResource{Group: "tekton.dev", Resource: "pipelineruns"}
+
+// TektonEngine runs the workflows as Tekton PipelineRuns, through the REST API of
+// Kubernetes. The persistence agent only watches the Argo workflows, so the status of
+// the PipelineRuns is polled by the API server.
+type TektonEngine struct {
+	restClient rest.Interface
+	namespace  string
+}
+
+func NewTektonEngine(restClient rest.Interface, namespace string) *TektonEngine {
+	return &TektonEngine{restClient: restClient, namespace: namespace}
+}
+
+func (e *TektonEngine) Name() string {
+	return Tekton
+}
+
+func (e *TektonEngine) Create(workflow *util.Workflow) (*util.Workflow, error) {
+	run, err := toPipelineRun(workflow)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(run)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal PipelineRun %v", run.Name)
+	}
+	request := e.restClient.Post().AbsPath(e.path("")).SetHeader("Content-Type", "application/json").Body(body)
+	return e.do(request, run.Name)
+}
+
+func (e *TektonEngine) Get(name string) (*util.Workflow, error) {
+	return e.do(e.restClient.Get().AbsPath(e.path(name)), name)
+}
+
+func (e *TektonEngine) List() ([]*util.Workflow, error) {
+	body, err := e.restClient.Get().AbsPath(e.path("")).Do().Raw()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the PipelineRuns of namespace %v", e.namespace)
+	}
+	var list PipelineRunList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the PipelineRuns of namespace %v", e.namespace)
+	}
+	workflows := make([]*util.Workflow, 0, len(list.Items))
+	for i := range list.Items {
+		workflow, err := fromPipelineRun(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, workflow)
+	}
+	return workflows, nil
+}
+
+func (e *TektonEngine) Delete(name string) error {
+	_, err := e.send(e.restClient.Delete().AbsPath(e.path(name)), name)
+	return err
+}
+
+// Terminate cancels the PipelineRun, which stops its TaskRuns.
+func (e *TektonEngine) Terminate(name string) error {
+	body := []byte(fmt.Sprintf(`{"spec":{"status":%q}}`, pipelineRunCancelled))
+	request := e.restClient.Patch(types.MergePatchType).AbsPath(e.path(name)).Body(body)
+	_, err := e.do(request, name)
+	return err
+}
+
+// Resume starts a pending PipelineRun.
+func (e *TektonEngine) Resume(name string) error {
+	body := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:null}},"spec":{"status":null}}`, util.LabelKeyWorkflowQueued))
+	request := e.restClient.Patch(types.MergePatchType).AbsPath(e.path(name)).Body(body)
+	_, err := e.do(request, name)
+	return err
+}
+
+// Retry isn't supported, since the PipelineRuns can't run their failed tasks again.
+func (e *TektonEngine) Retry(name string) error {
+	return util.NewInvalidInputError("Workflow %v can't be retried: the %v engine doesn't support it", name, Tekton)
+}
+
+// ResolveGates isn't supported, since the PipelineRuns have no suspend steps.
+func (e *TektonEngine) ResolveGates(name string, nodeId string, approved bool, message string) error {
+	return util.NewInvalidInputError("The approval gates of workflow %v can't be resolved: the %v engine doesn't support them", name, Tekton)
+}
+
+func (e *TektonEngine) ReportedByPersistenceAgent() bool {
+	return false
+}
+
+func (e *TektonEngine) path(name string) string {
+	path := fmt.Sprintf("/apis/%s/namespaces/%s/pipelineruns", tektonAPIVersion, e.namespace)
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
+
+// do sends a request on a PipelineRun and returns the workflow of the PipelineRun of the
+// response.
+func (e *TektonEngine) do(request *rest.Request, name string) (*util.Workflow, error) {
+	body, err := e.send(request, name)
+	if err != nil {
+		return nil, err
+	}
+	var run PipelineRun
+	if err := json.Unmarshal(body, &run); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse PipelineRun %v/%v", e.namespace, name)
+	}
+	return fromPipelineRun(&run)
+}
+
+// send sends a request on a PipelineRun and returns the body of the response. The
+// NotFound and AlreadyExists errors are returned as the Kubernetes API errors the callers
+// check.
+func (e *TektonEngine) send(request *rest.Request, name string) ([]byte, error) {
+	var statusCode int
+	body, err := request.Do().StatusCode(&statusCode).Raw()
+	switch {
+	case statusCode == http.StatusNotFound:
+		return nil, apierrors.NewNotFound(pipelineRunResource, name)
+	case statusCode == http.StatusConflict:
+		return nil, apierrors.NewAlreadyExists(pipelineRunResource, name)
+	case err != nil:
+		return nil, util.NewInternalServerError(err, "Failed to access PipelineRun %v/%v", e.namespace, name)
+	}
+	return body, nil
+}
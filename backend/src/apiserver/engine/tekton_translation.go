@@ -0,0 +1,337 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// The annotation of the PipelineRuns holding the spec of the workflow they were
+	// translated from, so that their status is reported along with it.
+	workflowSpecAnnotationKey = "pipelines.kubeflow.org/workflowSpec"
+	// The name of the step running the container of a template, in the tasks.
+	tektonStepName = "main"
+	// The value of spec.status cancelling a PipelineRun.
+	pipelineRunCancelled = "PipelineRunCancelled"
+	// The value of spec.status creating a PipelineRun which doesn't start until it's unset.
+	pipelineRunPending = "PipelineRunPending"
+)
+
+var argoExpression = regexp.MustCompile(`{{\s*([^}\s]*)\s*}}`)
+
+// The Argo variables with an equivalent in the PipelineRuns. The input parameters of the
+// templates and the parameters of the workflow are both parameters of the tasks and of
+// the pipeline.
+var argoVariables = []struct {
+	prefix      string
+	replacement string
+}{
+	{"inputs.parameters.", "params."},
+	{"workflow.parameters.", "params."},
+	{"workflow.name", "context.pipelineRun.name"},
+	{"workflow.uid", "context.pipelineRun.uid"},
+	{"workflow.namespace", "context.pipelineRun.namespace"},
+}
+
+// toPipelineRun translates a workflow to a PipelineRun embedding its pipeline. The
+// entrypoint of the workflow is either a container or script template, or a DAG of them.
+// The outputs of the steps are not passed to the next ones, and the artifacts are not
+// stored.
+func toPipelineRun(workflow *util.Workflow) (*PipelineRun, error) {
+	spec, err := json.Marshal(workflow.Spec)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the spec of workflow %v", workflow.Name)
+	}
+	annotations := map[string]string{workflowSpecAnnotationKey: string(spec)}
+	for key, value := range workflow.Annotations {
+		annotations[key] = value
+	}
+	run := &PipelineRun{
+		TypeMeta: v1.TypeMeta{APIVersion: tektonAPIVersion, Kind: "PipelineRun"},
+		ObjectMeta: v1.ObjectMeta{
+			Name:         workflow.Name,
+			GenerateName: workflow.GenerateName,
+			Namespace:    workflow.Namespace,
+			Labels:       workflow.Labels,
+			Annotations:  annotations,
+		},
+		Spec: PipelineRunSpec{
+			PipelineSpec:       &PipelineSpec{},
+			ServiceAccountName: workflow.Spec.ServiceAccountName,
+		},
+	}
+	if workflow.Spec.Suspend != nil && *workflow.Spec.Suspend {
+		run.Spec.Status = pipelineRunPending
+	}
+	for _, parameter := range workflow.Spec.Arguments.Parameters {
+		value := ""
+		if parameter.Value != nil {
+			value = *parameter.Value
+		}
+		run.Spec.Params = append(run.Spec.Params, Param{Name: parameter.Name, Value: value})
+		run.Spec.PipelineSpec.Params = append(run.Spec.PipelineSpec.Params,
+			ParamSpec{Name: parameter.Name, Type: "string"})
+	}
+
+	entrypoint := findTemplate(workflow, workflow.Spec.Entrypoint)
+	if entrypoint == nil {
+		return nil, util.NewInvalidInputError("Entrypoint template %q of workflow %v not found",
+			workflow.Spec.Entrypoint, workflow.Name)
+	}
+	if entrypoint.DAG == nil {
+		// The inputs of the entrypoint are the parameters of the workflow.
+		var params []Param
+		for _, parameter := range entrypoint.Inputs.Parameters {
+			params = append(params, Param{Name: parameter.Name, Value: "$(params." + parameter.Name + ")"})
+		}
+		task, err := toPipelineTask(entrypoint.Name, entrypoint, params, nil)
+		if err != nil {
+			return nil, err
+		}
+		run.Spec.PipelineSpec.Tasks = []PipelineTask{*task}
+		return run, nil
+	}
+	for _, dagTask := range entrypoint.DAG.Tasks {
+		template := findTemplate(workflow, dagTask.Template)
+		if template == nil {
+			return nil, util.NewInvalidInputError("Template %q of task %v not found", dagTask.Template, dagTask.Name)
+		}
+		if dagTask.When != "" || len(dagTask.WithItems) > 0 || dagTask.WithParam != "" {
+			return nil, util.NewInvalidInputError(
+				"Task %v is conditional or looped, which the %v engine doesn't support", dagTask.Name, Tekton)
+		}
+		var params []Param
+		for _, parameter := range dagTask.Arguments.Parameters {
+			value := ""
+			if parameter.Value != nil {
+				value, err = translateExpressions(*parameter.Value)
+				if err != nil {
+					return nil, util.Wrapf(err, "Failed to translate the arguments of task %v", dagTask.Name)
+				}
+			}
+			params = append(params, Param{Name: parameter.Name, Value: value})
+		}
+		task, err := toPipelineTask(dagTask.Name, template, params, dagTask.Dependencies)
+		if err != nil {
+			return nil, err
+		}
+		run.Spec.PipelineSpec.Tasks = append(run.Spec.PipelineSpec.Tasks, *task)
+	}
+	return run, nil
+}
+
+func findTemplate(workflow *util.Workflow, name string) *workflowapi.Template {
+	for i := range workflow.Spec.Templates {
+		if workflow.Spec.Templates[i].Name == name {
+			return &workflow.Spec.Templates[i]
+		}
+	}
+	return nil
+}
+
+// toPipelineTask translates a container or script template to a task running it in a
+// single step.
+func toPipelineTask(name string, template *workflowapi.Template, params []Param, runAfter []string) (
+	*PipelineTask, error) {
+	if len(template.Inputs.Artifacts) > 0 {
+		return nil, util.NewInvalidInputError(
+			"Template %v has input artifacts, which the %v engine doesn't support", template.Name, Tekton)
+	}
+	var step Step
+	switch {
+	case template.Container != nil:
+		step.Container = *template.Container.DeepCopy()
+	case template.Script != nil:
+		step.Container = *template.Script.Container.DeepCopy()
+		source, err := translateExpressions(template.Script.Source)
+		if err != nil {
+			return nil, util.Wrapf(err, "Failed to translate the script of template %v", template.Name)
+		}
+		step.Script = source
+	default:
+		return nil, util.NewInvalidInputError(
+			"Template %v isn't a container or a script, which the %v engine requires", template.Name, Tekton)
+	}
+	step.Name = tektonStepName
+	if err := translateContainerExpressions(&step); err != nil {
+		return nil, util.Wrapf(err, "Failed to translate the container of template %v", template.Name)
+	}
+	taskSpec := &TaskSpec{Steps: []Step{step}}
+	for _, parameter := range template.Inputs.Parameters {
+		paramSpec := ParamSpec{Name: parameter.Name, Type: "string"}
+		if parameter.Default != nil {
+			paramSpec.Default = parameter.Default
+		}
+		taskSpec.Params = append(taskSpec.Params, paramSpec)
+	}
+	return &PipelineTask{Name: name, TaskSpec: taskSpec, RunAfter: runAfter, Params: params}, nil
+}
+
+func translateContainerExpressions(step *Step) error {
+	fields := []*string{&step.Image, &step.WorkingDir}
+	for i := range step.Command {
+		fields = append(fields, &step.Command[i])
+	}
+	for i := range step.Args {
+		fields = append(fields, &step.Args[i])
+	}
+	for i := range step.Env {
+		fields = append(fields, &step.Env[i].Value)
+	}
+	for _, field := range fields {
+		translated, err := translateExpressions(*field)
+		if err != nil {
+			return err
+		}
+		*field = translated
+	}
+	return nil
+}
+
+// translateExpressions translates the Argo variables of a string to the variables of
+// the PipelineRuns, e.g. {{inputs.parameters.x}} to $(params.x).
+func translateExpressions(s string) (string, error) {
+	var err error
+	translated := argoExpression.ReplaceAllStringFunc(s, func(expression string) string {
+		variable := argoExpression.FindStringSubmatch(expression)[1]
+		for _, v := range argoVariables {
+			if strings.HasPrefix(variable, v.prefix) {
+				return "$(" + v.replacement + strings.TrimPrefix(variable, v.prefix) + ")"
+			}
+		}
+		if err == nil {
+			err = util.NewInvalidInputError("Variable %v isn't supported by the %v engine", expression, Tekton)
+		}
+		return expression
+	})
+	return translated, err
+}
+
+// fromPipelineRun translates a PipelineRun back to the workflow it was translated from,
+// with the status of the run and of its tasks. The tasks are reported as the nodes of
+// the pods running them.
+func fromPipelineRun(run *PipelineRun) (*util.Workflow, error) {
+	workflow := &workflowapi.Workflow{
+		TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+		ObjectMeta: *run.ObjectMeta.DeepCopy(),
+	}
+	if spec, ok := run.Annotations[workflowSpecAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(spec), &workflow.Spec); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to unmarshal the workflow spec of PipelineRun %v", run.Name)
+		}
+		delete(workflow.Annotations, workflowSpecAnnotationKey)
+	}
+
+	phase, message := tektonPhase(run.Status.Conditions, run.Status.StartTime != nil)
+	workflow.Status = workflowapi.WorkflowStatus{
+		Phase:   phase,
+		Message: message,
+		Nodes:   make(map[string]workflowapi.NodeStatus),
+	}
+	if run.Status.StartTime != nil {
+		workflow.Status.StartedAt = *run.Status.StartTime
+	}
+	if run.Status.CompletionTime != nil {
+		workflow.Status.FinishedAt = *run.Status.CompletionTime
+	}
+	root := workflowapi.NodeStatus{
+		ID:           run.Name,
+		Name:         run.Name,
+		DisplayName:  run.Name,
+		Type:         workflowapi.NodeTypeDAG,
+		TemplateName: workflow.Spec.Entrypoint,
+		Phase:        phase,
+		Message:      message,
+		StartedAt:    workflow.Status.StartedAt,
+		FinishedAt:   workflow.Status.FinishedAt,
+	}
+	for taskRunName, taskRun := range run.Status.TaskRuns {
+		if taskRun == nil || taskRun.Status == nil {
+			continue
+		}
+		id := taskRun.Status.PodName
+		if id == "" {
+			id = taskRunName
+		}
+		taskPhase, taskMessage := tektonPhase(taskRun.Status.Conditions, taskRun.Status.StartTime != nil)
+		node := workflowapi.NodeStatus{
+			ID:           id,
+			Name:         run.Name + "." + taskRun.PipelineTaskName,
+			DisplayName:  taskRun.PipelineTaskName,
+			Type:         workflowapi.NodeTypePod,
+			TemplateName: taskTemplateName(workflow, taskRun.PipelineTaskName),
+			Phase:        taskPhase,
+			Message:      taskMessage,
+			BoundaryID:   root.ID,
+		}
+		if taskRun.Status.StartTime != nil {
+			node.StartedAt = *taskRun.Status.StartTime
+		}
+		if taskRun.Status.CompletionTime != nil {
+			node.FinishedAt = *taskRun.Status.CompletionTime
+		}
+		workflow.Status.Nodes[id] = node
+		root.Children = append(root.Children, id)
+	}
+	sort.Strings(root.Children)
+	workflow.Status.Nodes[root.ID] = root
+	return util.NewWorkflow(workflow), nil
+}
+
+// taskTemplateName returns the name of the template a task of the pipeline runs.
+func taskTemplateName(workflow *workflowapi.Workflow, taskName string) string {
+	for _, template := range workflow.Spec.Templates {
+		if template.Name != workflow.Spec.Entrypoint {
+			continue
+		}
+		if template.DAG == nil {
+			return template.Name
+		}
+		for _, task := range template.DAG.Tasks {
+			if task.Name == taskName {
+				return task.Template
+			}
+		}
+	}
+	return taskName
+}
+
+// tektonPhase returns the phase of a run or a task, given its conditions and whether it
+// started.
+func tektonPhase(conditions []Condition, started bool) (workflowapi.NodePhase, string) {
+	for _, condition := range conditions {
+		if condition.Type != "Succeeded" {
+			continue
+		}
+		switch condition.Status {
+		case "True":
+			return workflowapi.NodeSucceeded, condition.Message
+		case "False":
+			return workflowapi.NodeFailed, condition.Message
+		}
+	}
+	if started {
+		return workflowapi.NodeRunning, ""
+	}
+	return workflowapi.NodePending, ""
+}
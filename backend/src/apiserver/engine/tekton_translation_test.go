@@ -0,0 +1,165 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"testing"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dagWorkflow() *util.Workflow {
+	value := "hello"
+	return util.NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: "workflow1", Labels: map[string]string{"a": "b"}},
+		Spec: workflowapi.WorkflowSpec{
+			Entrypoint: "dag",
+			Arguments:  workflowapi.Arguments{Parameters: []workflowapi.Parameter{{Name: "message", Value: &value}}},
+			Templates: []workflowapi.Template{
+				{
+					Name: "dag",
+					DAG: &workflowapi.DAGTemplate{Tasks: []workflowapi.DAGTask{
+						{
+							Name:     "echo1",
+							Template: "echo",
+							Arguments: workflowapi.Arguments{Parameters: []workflowapi.Parameter{
+								{Name: "text", Value: util.StringPointer("{{workflow.parameters.message}}")}}},
+						},
+						{
+							Name:         "echo2",
+							Template:     "echo",
+							Dependencies: []string{"echo1"},
+							Arguments: workflowapi.Arguments{Parameters: []workflowapi.Parameter{
+								{Name: "text", Value: util.StringPointer("{{workflow.name}}")}}},
+						},
+					}},
+				},
+				{
+					Name:   "echo",
+					Inputs: workflowapi.Inputs{Parameters: []workflowapi.Parameter{{Name: "text"}}},
+					Container: &corev1.Container{
+						Image:   "alpine",
+						Command: []string{"echo", "{{inputs.parameters.text}}"},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestToPipelineRun(t *testing.T) {
+	run, err := toPipelineRun(dagWorkflow())
+	assert.Nil(t, err)
+	assert.Equal(t, "workflow1", run.Name)
+	assert.Equal(t, map[string]string{"a": "b"}, run.Labels)
+	assert.Contains(t, run.Annotations, workflowSpecAnnotationKey)
+	assert.Equal(t, []Param{{Name: "message", Value: "hello"}}, run.Spec.Params)
+	assert.Equal(t, []ParamSpec{{Name: "message", Type: "string"}}, run.Spec.PipelineSpec.Params)
+
+	tasks := run.Spec.PipelineSpec.Tasks
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, "echo1", tasks[0].Name)
+	assert.Equal(t, []Param{{Name: "text", Value: "$(params.message)"}}, tasks[0].Params)
+	assert.Empty(t, tasks[0].RunAfter)
+	assert.Equal(t, "echo2", tasks[1].Name)
+	assert.Equal(t, []Param{{Name: "text", Value: "$(context.pipelineRun.name)"}}, tasks[1].Params)
+	assert.Equal(t, []string{"echo1"}, tasks[1].RunAfter)
+
+	step := tasks[0].TaskSpec.Steps[0]
+	assert.Equal(t, tektonStepName, step.Name)
+	assert.Equal(t, "alpine", step.Image)
+	assert.Equal(t, []string{"echo", "$(params.text)"}, step.Command)
+	assert.Equal(t, []ParamSpec{{Name: "text", Type: "string"}}, tasks[0].TaskSpec.Params)
+}
+
+func TestToPipelineRun_Queued(t *testing.T) {
+	workflow := dagWorkflow()
+	workflow.Queue()
+	run, err := toPipelineRun(workflow)
+	assert.Nil(t, err)
+	assert.Equal(t, pipelineRunPending, run.Spec.Status)
+}
+
+func TestToPipelineRun_UnsupportedVariable(t *testing.T) {
+	workflow := dagWorkflow()
+	workflow.Spec.Templates[1].Container.Args = []string{"{{tasks.echo1.outputs.result}}"}
+
+	_, err := toPipelineRun(workflow)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "isn't supported")
+}
+
+func TestToPipelineRun_ConditionalTask(t *testing.T) {
+	workflow := dagWorkflow()
+	workflow.Spec.Templates[0].DAG.Tasks[1].When = "{{workflow.parameters.message}} == hello"
+
+	_, err := toPipelineRun(workflow)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "conditional or looped")
+}
+
+func TestFromPipelineRun(t *testing.T) {
+	run, err := toPipelineRun(dagWorkflow())
+	assert.Nil(t, err)
+	startTime := v1.NewTime(v1.Now().Rfc3339Copy().Time)
+	run.Status = PipelineRunStatus{
+		Conditions: []Condition{{Type: "Succeeded", Status: "False", Message: "echo2 failed"}},
+		StartTime:  &startTime,
+		TaskRuns: map[string]*PipelineRunTaskRunStatus{
+			"workflow1-echo1": {PipelineTaskName: "echo1", Status: &TaskRunStatus{
+				PodName:    "workflow1-echo1-pod",
+				Conditions: []Condition{{Type: "Succeeded", Status: "True"}},
+				StartTime:  &startTime,
+			}},
+			"workflow1-echo2": {PipelineTaskName: "echo2", Status: &TaskRunStatus{
+				Conditions: []Condition{{Type: "Succeeded", Status: "False", Message: "exit code 1"}},
+			}},
+		},
+	}
+
+	workflow, err := fromPipelineRun(run)
+	assert.Nil(t, err)
+	assert.Equal(t, dagWorkflow().Spec, workflow.Spec)
+	assert.NotContains(t, workflow.Annotations, workflowSpecAnnotationKey)
+	assert.Equal(t, workflowapi.NodeFailed, workflow.Status.Phase)
+	assert.Equal(t, "echo2 failed", workflow.Status.Message)
+	assert.Equal(t, startTime, workflow.Status.StartedAt)
+
+	nodes := workflow.Status.Nodes
+	assert.Len(t, nodes, 3)
+	assert.Equal(t, workflowapi.NodeTypeDAG, nodes["workflow1"].Type)
+	assert.Equal(t, []string{"workflow1-echo1-pod", "workflow1-echo2"}, nodes["workflow1"].Children)
+	assert.Equal(t, workflowapi.NodeSucceeded, nodes["workflow1-echo1-pod"].Phase)
+	assert.Equal(t, "echo", nodes["workflow1-echo1-pod"].TemplateName)
+	assert.Equal(t, "echo1", nodes["workflow1-echo1-pod"].DisplayName)
+	assert.Equal(t, workflowapi.NodeFailed, nodes["workflow1-echo2"].Phase)
+	assert.Equal(t, "exit code 1", nodes["workflow1-echo2"].Message)
+	assert.Equal(t, "workflow1", nodes["workflow1-echo2"].BoundaryID)
+}
+
+func TestTektonPhase(t *testing.T) {
+	phase, _ := tektonPhase(nil, false)
+	assert.Equal(t, workflowapi.NodePending, phase)
+	phase, _ = tektonPhase(nil, true)
+	assert.Equal(t, workflowapi.NodeRunning, phase)
+	phase, _ = tektonPhase([]Condition{{Type: "Succeeded", Status: "Unknown"}}, true)
+	assert.Equal(t, workflowapi.NodeRunning, phase)
+	phase, _ = tektonPhase([]Condition{{Type: "Succeeded", Status: "True"}}, true)
+	assert.Equal(t, workflowapi.NodeSucceeded, phase)
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const tektonAPIVersion = "tekton.dev/v1beta1"
+
+// PipelineRun is a tekton.dev/v1beta1 PipelineRun, whose type isn't vendored. Only the
+// fields the workflows are translated to are declared.
+type PipelineRun struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          PipelineRunSpec   `json:"spec,omitempty"`
+	Status        PipelineRunStatus `json:"status,omitempty"`
+}
+
+type PipelineRunList struct {
+	v1.TypeMeta `json:",inline"`
+	v1.ListMeta `json:"metadata,omitempty"`
+	Items       []PipelineRun `json:"items"`
+}
+
+type PipelineRunSpec struct {
+	PipelineSpec       *PipelineSpec `json:"pipelineSpec,omitempty"`
+	Params             []Param       `json:"params,omitempty"`
+	ServiceAccountName string        `json:"serviceAccountName,omitempty"`
+	// Set to PipelineRunCancelled to cancel the run.
+	Status string `json:"status,omitempty"`
+}
+
+type PipelineSpec struct {
+	Params []ParamSpec    `json:"params,omitempty"`
+	Tasks  []PipelineTask `json:"tasks,omitempty"`
+}
+
+type PipelineTask struct {
+	Name     string    `json:"name"`
+	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+	RunAfter []string  `json:"runAfter,omitempty"`
+	Params   []Param   `json:"params,omitempty"`
+}
+
+type TaskSpec struct {
+	Params []ParamSpec `json:"params,omitempty"`
+	Steps  []Step      `json:"steps,omitempty"`
+}
+
+// Step is a container of a task, optionally running a script.
+type Step struct {
+	corev1.Container `json:",inline"`
+	Script           string `json:"script,omitempty"`
+}
+
+type ParamSpec struct {
+	Name    string  `json:"name"`
+	Type    string  `json:"type,omitempty"`
+	Default *string `json:"default,omitempty"`
+}
+
+type Param struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type PipelineRunStatus struct {
+	Conditions     []Condition                          `json:"conditions,omitempty"`
+	StartTime      *v1.Time                             `json:"startTime,omitempty"`
+	CompletionTime *v1.Time                             `json:"completionTime,omitempty"`
+	TaskRuns       map[string]*PipelineRunTaskRunStatus `json:"taskRuns,omitempty"`
+}
+
+type PipelineRunTaskRunStatus struct {
+	PipelineTaskName string         `json:"pipelineTaskName,omitempty"`
+	Status           *TaskRunStatus `json:"status,omitempty"`
+}
+
+type TaskRunStatus struct {
+	Conditions     []Condition `json:"conditions,omitempty"`
+	PodName        string      `json:"podName,omitempty"`
+	StartTime      *v1.Time    `json:"startTime,omitempty"`
+	CompletionTime *v1.Time    `json:"completionTime,omitempty"`
+}
+
+// Condition is a knative condition. The Succeeded condition tells whether a run completed.
+type Condition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,41 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventexport
+
+// The types of the exported lifecycle events.
+const (
+	EventTypePipelineCreated = "pipeline.created"
+	EventTypeRunCreated      = "run.created"
+	EventTypeRunStarted      = "run.started"
+	EventTypeRunFinished     = "run.finished"
+	EventTypeJobTriggered    = "job.triggered"
+)
+
+// Event is a lifecycle event of a pipeline, run or job, serialized as JSON to the broker.
+type Event struct {
+	Type         string `json:"type"`
+	TimeInSec    int64  `json:"time_in_sec"`
+	PipelineId   string `json:"pipeline_id,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	RunId        string `json:"run_id,omitempty"`
+	RunName      string `json:"run_name,omitempty"`
+	JobId        string `json:"job_id,omitempty"`
+	ExperimentId string `json:"experiment_id,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+	// The state of the run, for the run events.
+	State string `json:"state,omitempty"`
+	// The ID of the call which caused the event, for the run.created events.
+	RequestId string `json:"request_id,omitempty"`
+}
@@ -0,0 +1,176 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+)
+
+const (
+	// The supported brokers.
+	BrokerNone      = ""
+	BrokerKafkaRest = "kafka-rest"
+	BrokerPubSub    = "pubsub"
+	BrokerNats      = "nats"
+
+	// The number of events waiting to be sent before new ones are dropped.
+	queueSize = 10000
+)
+
+// Config selects the broker the events are exported to.
+type Config struct {
+	// One of the Broker* constants. No event is exported if empty.
+	Broker string
+	// The address of the broker: the URL of the Kafka REST proxy, or the host:port
+	// of the NATS server. Unused for Pub/Sub.
+	Address string
+	// The Kafka topic, the NATS subject, or the Pub/Sub topic in the
+	// projects/{project}/topics/{topic} form.
+	Topic string
+	// How long the delivery of an event is retried before it is dropped.
+	RetryTimeout time.Duration
+}
+
+type PublisherInterface interface {
+	// Publish queues the export of an event. It doesn't block.
+	Publish(event *Event)
+	// Drain stops accepting events, and waits until the queued ones are exported or
+	// the context is done.
+	Drain(ctx context.Context) error
+}
+
+// Sink sends serialized events to a broker.
+type Sink interface {
+	Send(payload []byte) error
+}
+
+// Publisher exports the events to a sink from a background worker, retrying the
+// failed deliveries with an exponential backoff. Events are dropped, and logged,
+// when the queue is full or the retries are exhausted, so that an unavailable
+// broker never blocks the API.
+type Publisher struct {
+	sink         Sink
+	queue        chan *Event
+	retryTimeout time.Duration
+	done         chan struct{}
+
+	// Guards the queue from being written once it's closed by Drain.
+	mutex   sync.RWMutex
+	drained bool
+}
+
+// NewPublisher creates the publisher exporting to the broker of the config.
+func NewPublisher(config Config) (PublisherInterface, error) {
+	var sink Sink
+	switch config.Broker {
+	case BrokerNone:
+		return &noopPublisher{}, nil
+	case BrokerKafkaRest:
+		sink = NewKafkaRestSink(config.Address, config.Topic)
+	case BrokerPubSub:
+		pubSubSink, err := NewPubSubSink(config.Topic)
+		if err != nil {
+			return nil, err
+		}
+		sink = pubSubSink
+	case BrokerNats:
+		sink = NewNatsSink(config.Address, config.Topic)
+	default:
+		return nil, fmt.Errorf("unsupported event broker %q", config.Broker)
+	}
+	return newPublisher(sink, config.RetryTimeout), nil
+}
+
+func newPublisher(sink Sink, retryTimeout time.Duration) *Publisher {
+	p := &Publisher{
+		sink:         sink,
+		queue:        make(chan *Event, queueSize),
+		retryTimeout: retryTimeout,
+		done:         make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+func (p *Publisher) Publish(event *Event) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.drained {
+		p.drop(event, fmt.Errorf("the publisher is shut down"))
+		return
+	}
+	select {
+	case p.queue <- event:
+	default:
+		p.drop(event, fmt.Errorf("the export queue is full"))
+	}
+}
+
+func (p *Publisher) Drain(ctx context.Context) error {
+	p.mutex.Lock()
+	if !p.drained {
+		p.drained = true
+		close(p.queue)
+	}
+	p.mutex.Unlock()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d events are still queued: %v", len(p.queue), ctx.Err())
+	}
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+	for event := range p.queue {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			p.drop(event, err)
+			continue
+		}
+		b := backoff.NewExponentialBackOff()
+		b.MaxElapsedTime = p.retryTimeout
+		err = backoff.RetryNotify(
+			func() error { return p.sink.Send(payload) },
+			b,
+			func(err error, wait time.Duration) {
+				glog.Warningf("Failed to export the event %v, retrying in %v: %v", event.Type, wait, err)
+			})
+		if err != nil {
+			p.drop(event, err)
+		}
+	}
+}
+
+func (p *Publisher) drop(event *Event, err error) {
+	payload, _ := json.Marshal(event)
+	glog.Errorf("Dropped the event %s: %v", payload, err)
+}
+
+type noopPublisher struct{}
+
+func (p *noopPublisher) Publish(event *Event) {}
+
+func (p *noopPublisher) Drain(ctx context.Context) error {
+	return nil
+}
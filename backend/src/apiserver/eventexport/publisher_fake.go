@@ -0,0 +1,46 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventexport
+
+import (
+	"context"
+	"sync"
+)
+
+// FakePublisher records the published events instead of exporting them.
+type FakePublisher struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+func NewFakePublisher() *FakePublisher {
+	return &FakePublisher{}
+}
+
+func (p *FakePublisher) Publish(event *Event) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.events = append(p.events, *event)
+}
+
+func (p *FakePublisher) Drain(ctx context.Context) error {
+	return nil
+}
+
+func (p *FakePublisher) Events() []Event {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]Event(nil), p.events...)
+}
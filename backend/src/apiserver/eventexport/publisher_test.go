@@ -0,0 +1,110 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventexport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// flakySink fails the first deliveries, and all the deliveries of the events of the
+// unreachable run.
+type flakySink struct {
+	mutex    sync.Mutex
+	failures int
+	attempts int
+	sent     chan []byte
+}
+
+func (s *flakySink) Send(payload []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures || strings.Contains(string(payload), "unreachable") {
+		return fmt.Errorf("broker unavailable")
+	}
+	s.sent <- payload
+	return nil
+}
+
+func TestPublish_RetriesFailedDeliveries(t *testing.T) {
+	sink := &flakySink{failures: 2, sent: make(chan []byte, 1)}
+	publisher := newPublisher(sink, 10*time.Second)
+
+	publisher.Publish(&Event{Type: EventTypeRunFinished, RunId: "run1", State: "Succeeded"})
+
+	var event Event
+	assert.Nil(t, json.Unmarshal(<-sink.sent, &event))
+	assert.Equal(t, Event{Type: EventTypeRunFinished, RunId: "run1", State: "Succeeded"}, event)
+	assert.Equal(t, 3, sink.attempts)
+}
+
+func TestPublish_DropsEventsAfterRetryTimeout(t *testing.T) {
+	sink := &flakySink{sent: make(chan []byte, 2)}
+	publisher := newPublisher(sink, time.Millisecond)
+
+	publisher.Publish(&Event{Type: EventTypeRunStarted, RunId: "unreachable"})
+	publisher.Publish(&Event{Type: EventTypeRunFinished, RunId: "run1"})
+
+	var event Event
+	assert.Nil(t, json.Unmarshal(<-sink.sent, &event))
+	assert.Equal(t, EventTypeRunFinished, event.Type)
+}
+
+func TestDrain(t *testing.T) {
+	sink := &flakySink{failures: 1, sent: make(chan []byte, 3)}
+	publisher := newPublisher(sink, 10*time.Second)
+	publisher.Publish(&Event{Type: EventTypeRunStarted, RunId: "run1"})
+	publisher.Publish(&Event{Type: EventTypeRunFinished, RunId: "run1"})
+
+	// The queued events are exported before Drain returns, and the later ones dropped.
+	assert.Nil(t, publisher.Drain(context.Background()))
+	assert.Len(t, sink.sent, 2)
+	publisher.Publish(&Event{Type: EventTypeRunStarted, RunId: "run2"})
+	assert.Len(t, sink.sent, 2)
+	assert.Nil(t, publisher.Drain(context.Background()))
+}
+
+func TestDrain_Timeout(t *testing.T) {
+	sink := &flakySink{sent: make(chan []byte)}
+	publisher := newPublisher(sink, 10*time.Second)
+	publisher.Publish(&Event{Type: EventTypeRunStarted, RunId: "unreachable"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := publisher.Drain(ctx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "context deadline exceeded")
+}
+
+func TestNewPublisher(t *testing.T) {
+	publisher, err := NewPublisher(Config{})
+	assert.Nil(t, err)
+	assert.IsType(t, &noopPublisher{}, publisher)
+
+	publisher, err = NewPublisher(Config{Broker: BrokerKafkaRest, Address: "http://kafka", Topic: "events"})
+	assert.Nil(t, err)
+	assert.IsType(t, &Publisher{}, publisher)
+
+	_, err = NewPublisher(Config{Broker: "rabbitmq"})
+	assert.NotNil(t, err)
+}
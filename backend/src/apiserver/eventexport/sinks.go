@@ -0,0 +1,175 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	sinkTimeout    = 10 * time.Second
+	pubSubEndpoint = "https://pubsub.googleapis.com/v1/"
+	pubSubScope    = "https://www.googleapis.com/auth/pubsub"
+)
+
+// KafkaRestSink produces the events to a Kafka topic through a Kafka REST proxy.
+type KafkaRestSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewKafkaRestSink(address string, topic string) *KafkaRestSink {
+	return &KafkaRestSink{
+		url:    strings.TrimSuffix(address, "/") + "/topics/" + topic,
+		client: &http.Client{Timeout: sinkTimeout},
+	}
+}
+
+func (s *KafkaRestSink) Send(payload []byte) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"records": []map[string]json.RawMessage{{"value": payload}},
+	})
+	if err != nil {
+		return err
+	}
+	return post(s.client, s.url, "application/vnd.kafka.json.v2+json", body)
+}
+
+// PubSubSink publishes the events to a GCP Pub/Sub topic, authenticated with the
+// application default credentials.
+type PubSubSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewPubSubSink(topic string) (*PubSubSink, error) {
+	client, err := google.DefaultClient(context.Background(), pubSubScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the credentials to publish to Pub/Sub: %v", err)
+	}
+	client.Timeout = sinkTimeout
+	return &PubSubSink{url: pubSubEndpoint + topic + ":publish", client: client}, nil
+}
+
+func (s *PubSubSink) Send(payload []byte) error {
+	// Byte slices are base64 encoded, as Pub/Sub expects.
+	body, err := json.Marshal(map[string]interface{}{
+		"messages": []map[string][]byte{{"data": payload}},
+	})
+	if err != nil {
+		return err
+	}
+	return post(s.client, s.url, "application/json", body)
+}
+
+func post(client *http.Client, url string, contentType string, body []byte) error {
+	response, err := client.Post(url, contentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("the broker responded with status %v", response.Status)
+	}
+	return nil
+}
+
+// NatsSink publishes the events to a NATS subject. It speaks the plain text NATS
+// protocol and reconnects lazily after a failure.
+type NatsSink struct {
+	address string
+	subject string
+	mutex   sync.Mutex
+	conn    net.Conn
+}
+
+func NewNatsSink(address string, subject string) *NatsSink {
+	return &NatsSink{address: address, subject: subject}
+}
+
+func (s *NatsSink) Send(payload []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+	s.conn.SetWriteDeadline(time.Now().Add(sinkTimeout))
+	_, err := fmt.Fprintf(s.conn, "PUB %s %d\r\n%s\r\n", s.subject, len(payload), payload)
+	if err != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+func (s *NatsSink) connect() error {
+	conn, err := net.DialTimeout("tcp", s.address, sinkTimeout)
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(sinkTimeout))
+	// The server greets the client with its INFO.
+	if info, err := reader.ReadString('\n'); err != nil || !strings.HasPrefix(info, "INFO") {
+		conn.Close()
+		return fmt.Errorf("unexpected NATS greeting %q: %v", info, err)
+	}
+	conn.SetReadDeadline(time.Time{})
+	if _, err := fmt.Fprint(conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"ml-pipeline\"}\r\n"); err != nil {
+		conn.Close()
+		return err
+	}
+	s.conn = conn
+	go s.keepAlive(conn, reader)
+	return nil
+}
+
+// keepAlive answers the pings of the server, which otherwise closes the connection.
+func (s *NatsSink) keepAlive(conn net.Conn, reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			glog.Warningf("Lost the connection to the NATS server %v: %v", s.address, err)
+			s.mutex.Lock()
+			if s.conn == conn {
+				s.conn.Close()
+				s.conn = nil
+			}
+			s.mutex.Unlock()
+			return
+		}
+		if strings.HasPrefix(line, "PING") {
+			s.mutex.Lock()
+			fmt.Fprint(conn, "PONG\r\n")
+			s.mutex.Unlock()
+		} else if strings.HasPrefix(line, "-ERR") {
+			glog.Errorf("The NATS server %v returned an error: %v", s.address, strings.TrimSpace(line))
+		}
+	}
+}
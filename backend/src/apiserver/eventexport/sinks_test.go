@@ -0,0 +1,81 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventexport
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaRestSink(t *testing.T) {
+	var request *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	err := NewKafkaRestSink(server.URL+"/", "events").Send([]byte(`{"type":"run.started"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "/topics/events", request.URL.Path)
+	assert.Equal(t, "application/vnd.kafka.json.v2+json", request.Header.Get("Content-Type"))
+	assert.Equal(t, `{"records":[{"value":{"type":"run.started"}}]}`, string(body))
+}
+
+func TestKafkaRestSink_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewKafkaRestSink(server.URL, "events").Send([]byte(`{}`))
+	assert.NotNil(t, err)
+}
+
+func TestNatsSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("INFO {}\r\n"))
+		reader := bufio.NewReader(conn)
+		var lines []string
+		for i := 0; i < 3; i++ {
+			line, _ := reader.ReadString('\n')
+			lines = append(lines, strings.TrimSpace(line))
+		}
+		received <- lines
+	}()
+
+	err = NewNatsSink(listener.Addr().String(), "events").Send([]byte(`{"type":"run.started"}`))
+	assert.Nil(t, err)
+	lines := <-received
+	assert.True(t, strings.HasPrefix(lines[0], "CONNECT "))
+	assert.Equal(t, []string{"PUB events 22", `{"type":"run.started"}`}, lines[1:])
+}
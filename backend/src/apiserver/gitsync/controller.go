@@ -0,0 +1,202 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsync
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/server"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Controller reconciles the compiled pipelines of a Git repository into the pipeline
+// store, so that the pipelines can be managed through pull requests. Each file matching
+// the path pattern is a pipeline named after its path. A pipeline is replaced when its
+// file changes, and deleted when its file is removed.
+type Controller struct {
+	repository      RepositoryInterface
+	resourceManager *resource.ResourceManager
+	store           storage.GitSyncStoreInterface
+	// The pattern of the paths of the pipeline files, relative to the root of the
+	// repository, e.g. pipelines/*.yaml. The syntax is the one of path.Match.
+	pathPattern string
+	interval    time.Duration
+	time        util.TimeInterface
+}
+
+func NewController(repository RepositoryInterface, resourceManager *resource.ResourceManager,
+	store storage.GitSyncStoreInterface, pathPattern string, interval time.Duration) *Controller {
+	return &Controller{
+		repository:      repository,
+		resourceManager: resourceManager,
+		store:           store,
+		pathPattern:     pathPattern,
+		interval:        interval,
+		time:            resourceManager.GetTime(),
+	}
+}
+
+// Run syncs the repository every interval until stopCh is closed.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	glog.Infof("Syncing the pipelines matching %v every %v", c.pathPattern, c.interval)
+	wait.Until(func() {
+		if err := c.Sync(); err != nil {
+			glog.Errorf("Failed to sync the pipelines from the Git repository: %+v", err)
+		}
+	}, c.interval, stopCh)
+}
+
+// Sync reconciles the pipeline store with the head of the repository. A file that
+// fails to sync doesn't stop the others from syncing, and is retried on the next sync.
+func (c *Controller) Sync() error {
+	commit, dir, err := c.repository.Sync()
+	if err != nil {
+		return util.Wrap(err, "Failed to sync the Git repository")
+	}
+	paths, err := c.listPipelineFiles(dir)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to list the pipeline files of commit %v", commit)
+	}
+	syncedPipelines, err := c.store.ListSyncedPipelines()
+	if err != nil {
+		return util.Wrap(err, "Failed to list the synced pipelines")
+	}
+	synced := map[string]*model.GitSyncedPipeline{}
+	for _, pipeline := range syncedPipelines {
+		synced[pipeline.Path] = pipeline
+	}
+
+	var failed []string
+	for _, filePath := range paths {
+		if err := c.syncPipeline(dir, filePath, commit, synced[filePath]); err != nil {
+			glog.Errorf("Failed to sync pipeline %v at commit %v: %+v", filePath, commit, err)
+			failed = append(failed, filePath)
+		}
+		delete(synced, filePath)
+	}
+	// The remaining pipelines were removed from the repository.
+	for filePath, pipeline := range synced {
+		if err := c.deletePipeline(pipeline); err != nil {
+			glog.Errorf("Failed to delete pipeline %v removed at commit %v: %+v", filePath, commit, err)
+			failed = append(failed, filePath)
+			continue
+		}
+		glog.Infof("Deleted pipeline %v removed at commit %v", filePath, commit)
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return util.NewInternalServerError(fmt.Errorf("failed pipelines: %v", failed),
+			"Failed to sync %v pipelines at commit %v", len(failed), commit)
+	}
+	return nil
+}
+
+// listPipelineFiles returns the sorted paths, relative to dir and slash separated, of
+// the files matching the path pattern.
+func (c *Controller) listPipelineFiles(dir string) ([]string, error) {
+	var paths []string
+	err := filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relativePath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		matched, err := path.Match(c.pathPattern, relativePath)
+		if err != nil {
+			return err
+		}
+		if matched {
+			paths = append(paths, relativePath)
+		}
+		return nil
+	})
+	sort.Strings(paths)
+	return paths, err
+}
+
+// syncPipeline creates the pipeline of a file, or replaces it if the file changed
+// since it was last synced.
+func (c *Controller) syncPipeline(dir string, filePath string, commit string, synced *model.GitSyncedPipeline) error {
+	content, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(filePath)))
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to read the pipeline file")
+	}
+	hash := sha256.Sum256(content)
+	contentHash := hex.EncodeToString(hash[:])
+	if synced != nil && synced.ContentHash == contentHash {
+		return nil
+	}
+	pipelineFile, err := server.ReadPipelineFile(filePath, bytes.NewReader(content), server.MaxFileLength)
+	if err != nil {
+		return util.Wrap(err, "Failed to read the pipeline file")
+	}
+	// Pipeline names are unique, so the previous version is deleted before the new
+	// one is created.
+	if synced != nil {
+		if err := c.deletePipeline(synced); err != nil {
+			return err
+		}
+	}
+	description := fmt.Sprintf("Synced from %v at commit %v.", filePath, commit)
+	pipeline, err := c.resourceManager.CreatePipeline(filePath, description, pipelineFile)
+	if err != nil {
+		return util.Wrap(err, "Failed to create the pipeline")
+	}
+	err = c.store.PutSyncedPipeline(&model.GitSyncedPipeline{
+		Path:          filePath,
+		PipelineUUID:  pipeline.UUID,
+		CommitSHA:     commit,
+		ContentHash:   contentHash,
+		SyncedAtInSec: c.time.Now().Unix(),
+	})
+	if err != nil {
+		return util.Wrap(err, "Failed to record the synced pipeline")
+	}
+	glog.Infof("Synced pipeline %v at commit %v", filePath, commit)
+	return nil
+}
+
+func (c *Controller) deletePipeline(synced *model.GitSyncedPipeline) error {
+	// The pipeline might have been deleted through the API.
+	err := c.resourceManager.DeletePipeline(synced.PipelineUUID)
+	if err != nil && !util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		return util.Wrap(err, "Failed to delete the pipeline")
+	}
+	return c.store.DeleteSyncedPipeline(synced.Path)
+}
@@ -0,0 +1,153 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsync
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func pipelineManifest(param string) string {
+	return fmt.Sprintf(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-
+spec:
+  entrypoint: hello
+  arguments:
+    parameters:
+    - name: %s
+  templates:
+  - name: hello
+    container:
+      image: alpine
+`, param)
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) {
+	filePath := filepath.Join(dir, name)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	assert.Nil(t, ioutil.WriteFile(filePath, []byte(content), 0644))
+}
+
+func initController(t *testing.T) (*resource.FakeClientManager, *resource.ResourceManager, *FakeRepository,
+	*Controller) {
+	dir, err := ioutil.TempDir("", "gitsync")
+	assert.Nil(t, err)
+	// The pipelines need distinct IDs.
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	resourceManager := resource.NewResourceManager(clientManager)
+	repository := NewFakeRepository(dir)
+	controller := NewController(repository, resourceManager, storage.NewGitSyncStore(clientManager.DB()),
+		"pipelines/*.yaml", 0)
+	return clientManager, resourceManager, repository, controller
+}
+
+func listPipelines(t *testing.T, resourceManager *resource.ResourceManager) []model.Pipeline {
+	pipelines, _, err := resourceManager.ListPipelines(&common.FilterContext{}, &common.PaginationContext{
+		PageSize: 10, KeyFieldName: model.GetPipelineTablePrimaryKeyColumn(), SortByFieldName: "Name"})
+	assert.Nil(t, err)
+	return pipelines
+}
+
+func TestSync(t *testing.T) {
+	clientManager, resourceManager, repository, controller := initController(t)
+	defer clientManager.Close()
+	defer os.RemoveAll(repository.Dir)
+	writeFile(t, repository.Dir, "pipelines/train.yaml", pipelineManifest("epochs"))
+	writeFile(t, repository.Dir, "pipelines/serve.yaml", pipelineManifest("replicas"))
+	writeFile(t, repository.Dir, "README.md", "Not a pipeline")
+
+	assert.Nil(t, controller.Sync())
+
+	pipelines := listPipelines(t, resourceManager)
+	assert.Equal(t, 2, len(pipelines))
+	assert.Equal(t, "pipelines/serve.yaml", pipelines[0].Name)
+	assert.Equal(t, "Synced from pipelines/serve.yaml at commit 0000000.", pipelines[0].Description)
+	assert.Equal(t, "pipelines/train.yaml", pipelines[1].Name)
+	assert.Equal(t, `[{"name":"epochs"}]`, pipelines[1].Parameters)
+}
+
+func TestSync_Unchanged(t *testing.T) {
+	clientManager, resourceManager, repository, controller := initController(t)
+	defer clientManager.Close()
+	defer os.RemoveAll(repository.Dir)
+	writeFile(t, repository.Dir, "pipelines/train.yaml", pipelineManifest("epochs"))
+	assert.Nil(t, controller.Sync())
+	pipelines := listPipelines(t, resourceManager)
+
+	repository.Commit = "1111111"
+	assert.Nil(t, controller.Sync())
+
+	assert.Equal(t, pipelines, listPipelines(t, resourceManager))
+}
+
+func TestSync_ReplacesChangedAndDeletesRemovedPipelines(t *testing.T) {
+	clientManager, resourceManager, repository, controller := initController(t)
+	defer clientManager.Close()
+	defer os.RemoveAll(repository.Dir)
+	writeFile(t, repository.Dir, "pipelines/train.yaml", pipelineManifest("epochs"))
+	writeFile(t, repository.Dir, "pipelines/serve.yaml", pipelineManifest("replicas"))
+	assert.Nil(t, controller.Sync())
+
+	repository.Commit = "1111111"
+	writeFile(t, repository.Dir, "pipelines/train.yaml", pipelineManifest("learning_rate"))
+	assert.Nil(t, os.Remove(filepath.Join(repository.Dir, "pipelines/serve.yaml")))
+	assert.Nil(t, controller.Sync())
+
+	pipelines := listPipelines(t, resourceManager)
+	assert.Equal(t, 1, len(pipelines))
+	assert.Equal(t, "pipelines/train.yaml", pipelines[0].Name)
+	assert.Equal(t, "Synced from pipelines/train.yaml at commit 1111111.", pipelines[0].Description)
+	assert.Equal(t, `[{"name":"learning_rate"}]`, pipelines[0].Parameters)
+}
+
+func TestSync_InvalidPipelineDoesNotStopTheOthers(t *testing.T) {
+	clientManager, resourceManager, repository, controller := initController(t)
+	defer clientManager.Close()
+	defer os.RemoveAll(repository.Dir)
+	writeFile(t, repository.Dir, "pipelines/invalid.yaml", "invalid: [")
+	writeFile(t, repository.Dir, "pipelines/train.yaml", pipelineManifest("epochs"))
+
+	err := controller.Sync()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "pipelines/invalid.yaml")
+
+	pipelines := listPipelines(t, resourceManager)
+	assert.Equal(t, 1, len(pipelines))
+	assert.Equal(t, "pipelines/train.yaml", pipelines[0].Name)
+}
+
+func TestSync_RepositoryError(t *testing.T) {
+	clientManager, resourceManager, repository, controller := initController(t)
+	defer clientManager.Close()
+	defer os.RemoveAll(repository.Dir)
+	writeFile(t, repository.Dir, "pipelines/train.yaml", pipelineManifest("epochs"))
+	repository.Err = util.NewInternalServerError(fmt.Errorf("unreachable"), "Failed to run git fetch")
+
+	assert.NotNil(t, controller.Sync())
+	assert.Empty(t, listPipelines(t, resourceManager))
+}
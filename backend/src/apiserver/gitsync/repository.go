@@ -0,0 +1,92 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsync
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+)
+
+type RepositoryInterface interface {
+	// Sync updates the local checkout to the head of the synced branch, and returns
+	// the commit and the directory of the checkout.
+	Sync() (commit string, dir string, err error)
+}
+
+// GitRepository is a shallow checkout of a branch of a Git repository, updated with
+// the git command line.
+type GitRepository struct {
+	url     string
+	branch  string
+	dir     string
+	timeout time.Duration
+}
+
+func (r *GitRepository) Sync() (string, string, error) {
+	if _, err := os.Stat(filepath.Join(r.dir, ".git")); os.IsNotExist(err) {
+		if err := r.git("", "clone", "--depth", "1", "--single-branch", "--branch", r.branch, r.url, r.dir); err != nil {
+			return "", "", err
+		}
+	} else {
+		if err := r.git(r.dir, "fetch", "--depth", "1", "origin", r.branch); err != nil {
+			return "", "", err
+		}
+		if err := r.git(r.dir, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return "", "", err
+		}
+		if err := r.git(r.dir, "clean", "-ffdx"); err != nil {
+			return "", "", err
+		}
+	}
+	commit, err := r.output(r.dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+	return commit, r.dir, nil
+}
+
+func (r *GitRepository) git(dir string, args ...string) error {
+	_, err := r.output(dir, args...)
+	return err
+}
+
+func (r *GitRepository) output(dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", util.NewInternalServerError(errors.Wrap(err, strings.TrimSpace(stderr.String())),
+			"Failed to run git %v", args[0])
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// NewGitRepository creates a checkout of a branch of the repository at url in dir.
+// Each git command times out after timeout.
+func NewGitRepository(url string, branch string, dir string, timeout time.Duration) *GitRepository {
+	return &GitRepository{url: url, branch: branch, dir: dir, timeout: timeout}
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsync
+
+// FakeRepository is a repository whose checkout is a local directory.
+type FakeRepository struct {
+	Commit string
+	Dir    string
+	Err    error
+}
+
+func NewFakeRepository(dir string) *FakeRepository {
+	return &FakeRepository{Commit: "0000000", Dir: dir}
+}
+
+func (r *FakeRepository) Sync() (string, string, error) {
+	if r.Err != nil {
+		return "", "", r.Err
+	}
+	return r.Commit, r.Dir, nil
+}
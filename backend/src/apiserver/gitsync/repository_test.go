@@ -0,0 +1,82 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitsync
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	assert.Nil(t, err, string(output))
+}
+
+func TestGitRepository_Sync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	origin, err := ioutil.TempDir("", "origin")
+	assert.Nil(t, err)
+	defer os.RemoveAll(origin)
+	checkout, err := ioutil.TempDir("", "checkout")
+	assert.Nil(t, err)
+	defer os.RemoveAll(checkout)
+
+	runGit(t, origin, "init")
+	runGit(t, origin, "checkout", "-b", "release")
+	writeFile(t, origin, "pipelines/train.yaml", "v1")
+	runGit(t, origin, "add", "-A")
+	runGit(t, origin, "commit", "-m", "v1")
+
+	repository := NewGitRepository(origin, "release", filepath.Join(checkout, "repo"), time.Minute)
+	firstCommit, dir, err := repository.Sync()
+	assert.Nil(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(dir, "pipelines/train.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", string(content))
+
+	writeFile(t, origin, "pipelines/train.yaml", "v2")
+	runGit(t, origin, "commit", "-am", "v2")
+	secondCommit, dir, err := repository.Sync()
+	assert.Nil(t, err)
+	assert.NotEqual(t, firstCommit, secondCommit)
+	content, err = ioutil.ReadFile(filepath.Join(dir, "pipelines/train.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "v2", string(content))
+}
+
+func TestGitRepository_Sync_UnknownBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	checkout, err := ioutil.TempDir("", "checkout")
+	assert.Nil(t, err)
+	defer os.RemoveAll(checkout)
+
+	repository := NewGitRepository(filepath.Join(checkout, "not-exist"), "master", filepath.Join(checkout, "repo"),
+		time.Minute)
+	_, _, err = repository.Sync()
+	assert.NotNil(t, err)
+}
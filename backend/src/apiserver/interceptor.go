@@ -16,23 +16,282 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/authz"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/backup"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/httpheaders"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-// apiServerInterceptor implements UnaryServerInterceptor that provides the common wrapping logic
+// The prefixes of the names of the RPCs which don't write.
+var readMethodPrefixes = []string{"Get", "List", "Compare", "Read", "Watch", "Estimate"}
+
+const (
+	// The RPCs of the AdminService require the admin role, as do the ones of the
+	// OperationService, whose operations are started by the AdminService.
+	adminMethodPrefix     = "/api.AdminService/"
+	operationMethodPrefix = "/api.OperationService/"
+	// The execution targets are registered and deleted by the admins, since they give access
+	// to the remote clusters.
+	executionTargetMethodPrefix = "/api.ExecutionTargetService/"
+	// The read-only mode is turned off by a call which writes.
+	setReadOnlyModeMethod = adminMethodPrefix + "SetReadOnlyMode"
+	// The info of the server isn't checked against the authorization policy, so that the
+	// clients can detect the capabilities of the server before they're set up.
+	getServerInfoMethod = "/api.ServerInfoService/GetServerInfo"
+	// The role is granted to every caller by this user.
+	anyAdmin = "*"
+)
+
+// The request IDs set by the callers are honored if they match, and replaced otherwise, so
+// that they can be logged and set as annotations as they are.
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// newApiServerInterceptor returns the UnaryServerInterceptor that provides the common wrapping logic
 // to be executed before and after all API handler calls, e.g. Logging, error handling.
 // For more details, see https://github.com/grpc/grpc-go/blob/master/interceptor.go
+// The calls which write are rejected in read-only mode, and pass through the write gate
+// otherwise, so that they're paused while a backup is taken. The calls are made by the user
+// in the userIdHeader metadata, if any, which is set by the ingress authenticating the users
+// in the multi-user deployments. The calls of the AdminService and of the OperationService, and
+// the ones of the ExecutionTargetService which write, are made by the admins only.
+// The calls but GetServerInfo are also checked against the external authorization policy,
+// if any. Each call is identified by a request ID, returned in the RequestIdHeader metadata
+// and in the errors.
+func newApiServerInterceptor(writeGate *backup.WriteGate, readOnlyMode *resource.ReadOnlyMode, admins []string,
+	userIdHeader string, userIdPrefix string, authorizationHook *authz.Hook) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx = withRequestId(ctx)
+		glog.Infof("%v called, request ID %v", info.FullMethod, common.GetRequestId(ctx))
+		ctx = withUser(ctx, userIdHeader, userIdPrefix)
+		if strings.HasPrefix(info.FullMethod, adminMethodPrefix) ||
+			strings.HasPrefix(info.FullMethod, operationMethodPrefix) ||
+			(strings.HasPrefix(info.FullMethod, executionTargetMethodPrefix) && isWriteMethod(info.FullMethod)) {
+			if err := authorizeAdmin(ctx, admins); err != nil {
+				return nil, callFailed(ctx, info.FullMethod, err)
+			}
+		}
+		if authorizationHook != nil && info.FullMethod != getServerInfoMethod {
+			request := authz.NewRequest(info.FullMethod, common.GetUser(ctx), req)
+			if err := authorizationHook.Check(ctx, request); err != nil {
+				return nil, callFailed(ctx, info.FullMethod, err)
+			}
+		}
+		if isWriteMethod(info.FullMethod) {
+			if readOnlyMode.IsReadOnly() && info.FullMethod != setReadOnlyModeMethod {
+				err := readOnlyMode.Error()
+				return nil, callFailed(ctx, info.FullMethod, err)
+			}
+			if err := writeGate.Enter(ctx); err != nil {
+				return nil, callFailed(ctx, info.FullMethod, err)
+			}
+			defer writeGate.Exit()
+		}
+		return apiServerInterceptor(ctx, req, info, handler)
+	}
+}
+
 func apiServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-	glog.Infof("%v called", info.FullMethod)
 	resp, err = handler(ctx, req)
 	if err != nil {
-		util.LogError(util.Wrapf(err, "%s call failed", info.FullMethod))
-		// Convert error to gRPC errors
-		err = util.ToGRPCError(err)
+		err = callFailed(ctx, info.FullMethod, err)
 		return
 	}
 	return
 }
+
+// callFailed logs the error of a call, and converts it to the gRPC error returned, whose
+// message ends with the request ID of the call.
+func callFailed(ctx context.Context, fullMethod string, err error) error {
+	requestId := common.GetRequestId(ctx)
+	util.LogError(util.Wrapf(err, "%s call failed, request ID %s", fullMethod, requestId))
+	// Convert error to gRPC errors
+	grpcStatus := status.Convert(util.ToGRPCError(err)).Proto()
+	grpcStatus.Message = fmt.Sprintf("%s (request ID: %s)", grpcStatus.Message, requestId)
+	return status.ErrorProto(grpcStatus)
+}
+
+// withRequestId adds the request ID of a call to its context, the one in the RequestIdHeader
+// metadata if valid, or a new one, and returns it in the metadata of the response.
+func withRequestId(ctx context.Context) context.Context {
+	requestId := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(common.RequestIdHeader); len(values) > 0 && requestIdPattern.MatchString(values[0]) {
+			requestId = values[0]
+		}
+	}
+	if requestId == "" {
+		requestId = uuid.New().String()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(common.RequestIdHeader, requestId)); err != nil {
+		glog.Warningf("Failed to return the request ID %v: %v", requestId, err)
+	}
+	return common.WithRequestId(ctx, requestId)
+}
+
+// isWriteMethod tells whether an RPC, named /package.Service/Method, writes.
+func isWriteMethod(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// authorizeAdmin checks that the user making a call has the admin role, which is granted to
+// the users listed in admins, or to every caller by "*", e.g. in a deployment whose users
+// aren't authenticated. The admin operations are disabled if no user has the role.
+func authorizeAdmin(ctx context.Context, admins []string) error {
+	if len(admins) == 0 {
+		return util.NewPermissionDeniedError(
+			"The admin operations are disabled. Please grant the admin role to users with AdminConfig.Users")
+	}
+	user := common.GetUser(ctx)
+	for _, admin := range admins {
+		if admin == anyAdmin || (user != "" && admin == user) {
+			return nil
+		}
+	}
+	if user == "" {
+		return util.NewUnauthenticatedError("The admin operations are only allowed to the authenticated admins")
+	}
+	return util.NewPermissionDeniedError("User %v doesn't have the admin role", user)
+}
+
+// withUser adds the user in the userIdHeader metadata of a call to its context, without the
+// prefix the ingress may add, e.g. "accounts.google.com:".
+func withUser(ctx context.Context, userIdHeader string, userIdPrefix string) context.Context {
+	if userIdHeader == "" {
+		return ctx
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	values := md.Get(userIdHeader)
+	if len(values) == 0 || values[0] == "" {
+		return ctx
+	}
+	return common.WithUser(ctx, strings.TrimPrefix(values[0], userIdPrefix))
+}
+
+// newHeaderMatcher returns the matcher of the HTTP proxy forwarding the headers identifying the
+// user and the request as metadata, in addition to the headers forwarded by default.
+func newHeaderMatcher(userIdHeader string) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		if userIdHeader != "" && strings.EqualFold(key, userIdHeader) {
+			return strings.ToLower(userIdHeader), true
+		}
+		if strings.EqualFold(key, common.RequestIdHeader) {
+			return common.RequestIdHeader, true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
+// outgoingHeaderMatcher is the matcher of the HTTP proxy returning the request ID metadata of
+// the responses as the X-Request-Id header, and the other metadata as Grpc-Metadata- headers.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == common.RequestIdHeader {
+		return http.CanonicalHeaderKey(common.RequestIdHeader), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// authorizeHttp checks the requests of an HTTP handler against the external authorization
+// policy, if any, as the verb on the resource. The user is in the header identifying the users.
+func authorizeHttp(authorizationHook *authz.Hook, verb string, resource string, handler http.HandlerFunc) http.HandlerFunc {
+	if authorizationHook == nil {
+		return handler
+	}
+	userIdHeader := getStringConfig(multiUserIdHeader)
+	userIdPrefix := getStringConfig(multiUserIdPrefix)
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := ""
+		if userIdHeader != "" {
+			user = strings.TrimPrefix(r.Header.Get(userIdHeader), userIdPrefix)
+		}
+		request := &authz.Request{Subject: user, Verb: verb, Resource: resource, Method: r.URL.Path}
+		if err := authorizationHook.Check(r.Context(), request); err != nil {
+			util.LogError(util.Wrapf(err, "%s %s failed", r.Method, r.URL.Path))
+			userError := err.(*util.UserError)
+			http.Error(w, userError.ExternalMessage(), runtime.HTTPStatusFromCode(userError.ExternalStatusCode()))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// gateWrites rejects the requests of an HTTP handler which writes in read-only mode, and
+// passes them through the write gate otherwise.
+func gateWrites(writeGate *backup.WriteGate, readOnlyMode *resource.ReadOnlyMode, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if readOnlyMode.IsReadOnly() {
+			err := readOnlyMode.Error()
+			util.LogError(util.Wrapf(err, "%s %s failed", r.Method, r.URL.Path))
+			http.Error(w, err.(*util.UserError).ExternalMessage(), http.StatusPreconditionFailed)
+			return
+		}
+		if err := writeGate.Enter(r.Context()); err != nil {
+			util.LogError(util.Wrapf(err, "%s %s failed", r.Method, r.URL.Path))
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		defer writeGate.Exit()
+		handler(w, r)
+	}
+}
+
+// withHttpHeaders adds the configured CORS and security headers to the responses of the HTTP
+// proxy and of the upload servers. CORS is disabled unless origins are allowed.
+func withHttpHeaders(handler http.Handler) http.Handler {
+	cors := httpheaders.CORSOptions{
+		AllowedOrigins:   getStringSliceConfig(corsAllowedOrigins),
+		AllowedMethods:   getStringSliceConfig(corsAllowedMethods),
+		AllowedHeaders:   getStringSliceConfig(corsAllowedHeaders),
+		AllowCredentials: getBoolConfig(corsAllowCredentials),
+		MaxAge:           getDurationConfig(corsMaxAge),
+	}
+	// The header identifying the users is set by the proxy in front of the server, and
+	// trusted as such. The code of the other origins must never set it.
+	if userIdHeader := getStringConfig(multiUserIdHeader); userIdHeader != "" {
+		allowedHeaders := make([]string, 0, len(cors.AllowedHeaders))
+		for _, header := range cors.AllowedHeaders {
+			if strings.EqualFold(header, userIdHeader) {
+				glog.Warningf("Not allowing the header %v identifying the users in the cross-origin requests", header)
+				continue
+			}
+			allowedHeaders = append(allowedHeaders, header)
+		}
+		cors.AllowedHeaders = allowedHeaders
+	}
+	cors.AllowedHeaders = append(cors.AllowedHeaders, common.RequestIdHeader)
+	if len(cors.AllowedOrigins) > 0 {
+		glog.Infof("Allowing the cross-origin requests from %v", cors.AllowedOrigins)
+	}
+	h, err := httpheaders.NewHandler(handler, cors, httpheaders.SecurityOptions{
+		NoSniff:                 getBoolConfig(securityHeadersNoSniff),
+		FrameOptions:            getStringConfig(securityHeadersFrameOptions),
+		ContentSecurityPolicy:   getStringConfig(securityHeadersContentSecurityPolicy),
+		ReferrerPolicy:          getStringConfig(securityHeadersReferrerPolicy),
+		StrictTransportSecurity: getStringConfig(securityHeadersStrictTransportSecurity),
+	})
+	if err != nil {
+		glog.Fatalf("Invalid %v. Error: %v", corsAllowedOrigins, err)
+	}
+	return h
+}
@@ -16,24 +16,28 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"io"
-	"io/ioutil"
 	"net"
 	"net/http"
 	"time"
 
 	"github.com/golang/glog"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/authz"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/backup"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/server"
+	"github.com/kubeflow/pipelines/backend/src/common/diagnostics"
+	"github.com/kubeflow/pipelines/backend/src/common/health"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/reload"
+	"github.com/kubeflow/pipelines/backend/src/common/shutdown"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/kubeflow/pipelines/backend/src/crd/pkg/signals"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
-	"os"
-	"github.com/pkg/errors"
-	"fmt"
 )
 
 var (
@@ -41,6 +45,23 @@ var (
 	httpPortFlag     = flag.String("httpPortFlag", ":8888", "Http Proxy Port")
 	configPath       = flag.String("config", "", "Path to JSON file containing config")
 	sampleConfigPath = flag.String("sampleconfig", "", "Path to samples")
+	loadSamplesFlag  = flag.Bool("loadSamples", true,
+		"Whether to create the samples listed in the sample config, or update those which changed, at startup.")
+	sampleConfigReloadInterval = flag.Duration("sampleConfigReloadInterval", time.Minute,
+		"How often the sample config is checked for changes, whose samples are then loaded. 0 to load them at startup only.")
+
+	diagnosticsAddress = flag.String("diagnosticsAddress", "",
+		"Address of the admin port serving pprof, expvar, goroutine dumps and the effective config. Disabled if empty.")
+
+	// The gRPC server compresses its responses with gzip when the requests are.
+	grpcMaxRecvMsgSize = flag.Int("grpcMaxRecvMsgSize", 32<<20,
+		"Maximum size in bytes of the gRPC messages the API server receives.")
+	grpcMaxSendMsgSize = flag.Int("grpcMaxSendMsgSize", 32<<20,
+		"Maximum size in bytes of the gRPC messages the API server sends.")
+
+	// Kubernetes kills the pod 30 seconds after SIGTERM by default.
+	drainTimeout = flag.Duration("drainTimeout", 25*time.Second,
+		"How long the in-flight requests and the queued events are drained on SIGTERM.")
 )
 
 type RegisterHttpHandlerFromEndpoint func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
@@ -50,53 +71,159 @@ func main() {
 	glog.Infof("starting API server")
 
 	initConfig()
+	// The diagnostics port also serves the effective configuration.
+	go diagnostics.ListenAndServe(*diagnosticsAddress, reloadableConfig)
 	clientManager := newClientManager()
 	resourceManager := resource.NewResourceManager(&clientManager)
-	err:= loadSamples(resourceManager)
-	if err!=nil{
-		glog.Fatalf("Failed to load samples. Err: %v", err.Error())
+	var sampleWatcher *reload.FileWatcher
+	if *loadSamplesFlag && *sampleConfigPath != "" {
+		sampleWatcher = newSampleWatcher(resourceManager)
+		if err := sampleWatcher.Check(); err != nil {
+			glog.Fatalf("Failed to load samples. Err: %v", err.Error())
+		}
+	}
+	stopCh := signals.SetupSignalHandler()
+
+	// The components are stopped in the order they are registered: the servers first,
+	// so that no new work reaches the workers, and the queues they feed last.
+	coordinator := shutdown.NewCoordinator(*drainTimeout)
+	// The writes are paused while a point-in-time backup is taken, if backups are enabled.
+	writeGate := newWriteGate()
+	snapshotCoordinator := newBackupCoordinator(&clientManager, writeGate)
+	// The writes are rejected while the admins turn the read-only mode on, on all the replicas.
+	readOnlyMode := clientManager.ReadOnlyMode()
+	consistencyChecker := newConsistencyChecker(resourceManager)
+	storageUsageCollector := newStorageUsageCollector(resourceManager)
+	// The calls are checked against the external authorization policy, if any.
+	authorizationHook := newAuthorizationHook()
+	rpcServer := startRpcServer(resourceManager, consistencyChecker, storageUsageCollector, writeGate, readOnlyMode,
+		authorizationHook)
+	httpServer := startHttpProxy(resourceManager, clientManager.HealthChecker(), writeGate, readOnlyMode,
+		snapshotCoordinator, authorizationHook)
+	coordinator.Register("HTTP proxy", httpServer.Shutdown)
+	coordinator.Register("RPC server", shutdown.GrpcServerStep(rpcServer))
+	// The background tasks run on the replica elected leader only, so that they don't
+	// race each other when the API server runs with several replicas.
+	elector := newLeaderElector(clientManager.Time())
+	startTask := func(name string, run func(stopCh <-chan struct{})) {
+		if elector != nil {
+			elector.Register(name, run)
+		} else {
+			coordinator.Register(name, shutdown.StartWorker(run))
+		}
+	}
+	if controller := newGitSyncController(resourceManager, clientManager.GitSyncStore()); controller != nil {
+		startTask("Git sync controller", controller.Run)
 	}
-	go startRpcServer(resourceManager)
-	startHttpProxy(resourceManager)
+	startTask("run outbox worker", newRunOutboxWorker(resourceManager).Run)
+	startTask("run sweep worker", newRunSweepWorker(resourceManager).Run)
+	startTask("run queue worker", newRunQueueWorker(resourceManager).Run)
+	startTask("terminate worker", newTerminateWorker(resourceManager).Run)
+	startTask("operation worker", newOperationWorker(resourceManager).Run)
+	if reconciler := newOrphanReconciler(resourceManager); reconciler != nil {
+		startTask("orphan reconciler", reconciler.Run)
+	}
+	if getBoolConfig(consistencyScheduled) {
+		startTask("consistency checker", consistencyChecker.Run)
+	}
+	if getBoolConfig(storageUsageScheduled) {
+		startTask("storage usage collector", storageUsageCollector.Run)
+	}
+	if poller := newEngineStatusPoller(resourceManager, clientManager.Engine()); poller != nil {
+		startTask("engine status poller", poller.Run)
+	}
+	if watcher := newDeploymentWatcher(clientManager.DeploymentStatusStore(), clientManager.Time()); watcher != nil {
+		startTask("deployment watcher", watcher.Run)
+	}
+	if sampleWatcher != nil && *sampleConfigReloadInterval > 0 {
+		startTask("sample config watcher", sampleWatcher.Run)
+	}
+	coordinator.Register("read-only mode refresher", shutdown.StartWorker(readOnlyMode.Run))
+	if elector != nil {
+		coordinator.Register("leader elector", shutdown.StartWorker(elector.Run))
+	}
+	coordinator.Register("webhook notifier", clientManager.WebhookNotifier().Drain)
+	coordinator.Register("event publisher", clientManager.EventPublisher().Drain)
 
+	<-stopCh
+	glog.Infof("Shutting down API server, draining for up to %v", *drainTimeout)
+	if err := coordinator.Shutdown(); err != nil {
+		glog.Errorf("Failed to shut down API server gracefully: %v", err)
+	}
 	clientManager.Close()
+	glog.Info("API server stopped")
 }
 
-func startRpcServer(resourceManager *resource.ResourceManager) {
+func startRpcServer(resourceManager *resource.ResourceManager, consistencyChecker *resource.ConsistencyChecker,
+	storageUsageCollector *resource.StorageUsageCollector, writeGate *backup.WriteGate,
+	readOnlyMode *resource.ReadOnlyMode, authorizationHook *authz.Hook) *grpc.Server {
 	glog.Info("Starting RPC server")
 	listener, err := net.Listen("tcp", *rpcPortFlag)
 	if err != nil {
 		glog.Fatalf("Failed to start RPC server: %v", err)
 	}
-	s := grpc.NewServer(grpc.UnaryInterceptor(apiServerInterceptor))
-	api.RegisterPipelineServiceServer(s, server.NewPipelineServer(resourceManager))
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(newApiServerInterceptor(writeGate, readOnlyMode, getStringSliceConfig(adminUsers),
+			getStringConfig(multiUserIdHeader), getStringConfig(multiUserIdPrefix), authorizationHook)),
+		grpc.MaxRecvMsgSize(*grpcMaxRecvMsgSize),
+		grpc.MaxSendMsgSize(*grpcMaxSendMsgSize))
+	api.RegisterPipelineServiceServer(s, server.NewPipelineServer(resourceManager, newURLFetcher()))
 	api.RegisterExperimentServiceServer(s, server.NewExperimentServer(resourceManager))
 	api.RegisterRunServiceServer(s, server.NewRunServer(resourceManager))
 	api.RegisterJobServiceServer(s, server.NewJobServer(resourceManager))
 	api.RegisterReportServiceServer(s, server.NewReportServer(resourceManager))
+	api.RegisterWebhookServiceServer(s, server.NewWebhookServer(resourceManager, newWebhookURLGuard()))
+	api.RegisterExecutionTargetServiceServer(s, server.NewExecutionTargetServer(resourceManager))
+	api.RegisterLineageServiceServer(s, server.NewLineageServer(resourceManager))
+	api.RegisterModelRegistryServiceServer(s, server.NewModelRegistryServer(resourceManager))
+	api.RegisterVisualizationServiceServer(s, server.NewVisualizationServer(resourceManager))
+	api.RegisterAdminServiceServer(s, server.NewAdminServer(resourceManager, consistencyChecker,
+		storageUsageCollector, readOnlyMode))
+	api.RegisterOperationServiceServer(s, server.NewOperationServer(resourceManager))
+	api.RegisterServerInfoServiceServer(s, server.NewServerInfoServer(resourceManager, server.ServerInfoConfig{
+		Version:        getOptionalStringConfig(buildVersion),
+		CommitSha:      getOptionalStringConfig(buildCommitSha),
+		MaxRequestSize: *grpcMaxRecvMsgSize,
+	}))
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
-	if err := s.Serve(listener); err != nil {
-		glog.Fatalf("Failed to serve rpc listener: %v", err)
-	}
+	go func() {
+		// Serve returns nil once the server is stopped.
+		if err := s.Serve(listener); err != nil {
+			glog.Fatalf("Failed to serve rpc listener: %v", err)
+		}
+	}()
 	glog.Info("RPC server started")
+	return s
 }
 
-func startHttpProxy(resourceManager *resource.ResourceManager) {
+func startHttpProxy(resourceManager *resource.ResourceManager, healthChecker *health.Checker,
+	writeGate *backup.WriteGate, readOnlyMode *resource.ReadOnlyMode, snapshotCoordinator *backup.Coordinator,
+	authorizationHook *authz.Hook) *http.Server {
 	glog.Info("Starting Http Proxy")
 
+	// The connections of the proxy to the RPC server live as long as the process.
 	ctx := context.Background()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
 
 	// Create gRPC HTTP MUX and register services.
-	mux := runtime.NewServeMux()
+	// The header identifying the user is forwarded to the RPC server along with the default ones.
+	// The request ID of the calls is returned as a header.
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(newHeaderMatcher(getStringConfig(multiUserIdHeader))),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher))
 	registerHttpHandlerFromEndpoint(api.RegisterPipelineServiceHandlerFromEndpoint, "PipelineService", ctx, mux)
 	registerHttpHandlerFromEndpoint(api.RegisterExperimentServiceHandlerFromEndpoint, "ExperimentService", ctx, mux)
 	registerHttpHandlerFromEndpoint(api.RegisterJobServiceHandlerFromEndpoint, "JobService", ctx, mux)
 	registerHttpHandlerFromEndpoint(api.RegisterRunServiceHandlerFromEndpoint, "RunService", ctx, mux)
 	registerHttpHandlerFromEndpoint(api.RegisterReportServiceHandlerFromEndpoint, "ReportService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterWebhookServiceHandlerFromEndpoint, "WebhookService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterExecutionTargetServiceHandlerFromEndpoint, "ExecutionTargetService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterLineageServiceHandlerFromEndpoint, "LineageService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterModelRegistryServiceHandlerFromEndpoint, "ModelRegistryService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterVisualizationServiceHandlerFromEndpoint, "VisualizationService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterAdminServiceHandlerFromEndpoint, "AdminService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterOperationServiceHandlerFromEndpoint, "OperationService", ctx, mux)
+	registerHttpHandlerFromEndpoint(api.RegisterServerInfoServiceHandlerFromEndpoint, "ServerInfoService", ctx, mux)
 
 	// Create a top level mux to include both pipeline upload server and gRPC servers.
 	topMux := http.NewServeMux()
@@ -105,62 +232,63 @@ func startHttpProxy(resourceManager *resource.ResourceManager) {
 	// accept pipeline url for importing.
 	// https://github.com/grpc-ecosystem/grpc-gateway/issues/410
 	pipelineUploadServer := server.NewPipelineUploadServer(resourceManager)
-	topMux.HandleFunc("/apis/v1beta1/pipelines/upload", pipelineUploadServer.UploadPipeline)
+	topMux.HandleFunc("/apis/v1beta1/pipelines/upload", authorizeHttp(authorizationHook, "create", "pipeline",
+		gateWrites(writeGate, readOnlyMode, pipelineUploadServer.UploadPipeline)))
+	// The steps of the runs push their metrics in the Prometheus text format, which isn't
+	// a gRPC message.
+	metricsPushServer := server.NewMetricsPushServer(resourceManager)
+	topMux.HandleFunc(server.MetricsPushPathPrefix, gateWrites(writeGate, readOnlyMode, metricsPushServer.PushMetrics))
+	// The backups are tar.gz archives, exported and imported over HTTP like the pipelines.
+	backupServer := server.NewBackupServer(resourceManager)
+	topMux.HandleFunc(server.BackupExportPath, backupServer.ExportBackup)
+	topMux.HandleFunc(server.BackupImportPath, gateWrites(writeGate, readOnlyMode, backupServer.ImportBackup))
+	if snapshotCoordinator != nil {
+		snapshotServer := server.NewBackupSnapshotServer(snapshotCoordinator)
+		topMux.HandleFunc(server.BackupSnapshotPath, snapshotServer.TakeSnapshot)
+		topMux.HandleFunc(server.BackupSnapshotsPath, snapshotServer.ListSnapshots)
+	}
 	topMux.HandleFunc("/apis/v1beta1/healthz", func(w http.ResponseWriter, r *http.Request) {
-		io.WriteString(w, `{"commit_sha":"`+getStringConfig("COMMIT_SHA")+`"}`)
+		io.WriteString(w, `{"commit_sha":"`+getStringConfig(buildCommitSha)+`"}`)
 	})
 
+	// Liveness and readiness probes checking the dependencies of the API server.
+	healthChecker.RegisterHandlers(topMux)
+	topMux.Handle("/metrics", metrics.Handler())
+
 	topMux.Handle("/apis/", mux)
 
-	http.ListenAndServe(*httpPortFlag, topMux)
+	httpServer := &http.Server{Addr: *httpPortFlag, Handler: withHttpHeaders(topMux)}
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			glog.Fatalf("Failed to serve http proxy: %v", err)
+		}
+	}()
 	glog.Info("Http Proxy started")
+	return httpServer
 }
 
 func registerHttpHandlerFromEndpoint(handler RegisterHttpHandlerFromEndpoint, serviceName string, ctx context.Context, mux *runtime.ServeMux) {
 	endpoint := "localhost" + *rpcPortFlag
-	opts := []grpc.DialOption{grpc.WithInsecure()}
+	// The HTTP proxy receives what the gRPC server sends, and the other way around.
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		util.GrpcCallOptions(*grpcMaxSendMsgSize, *grpcMaxRecvMsgSize, false),
+	}
 
 	if err := handler(ctx, mux, endpoint, opts); err != nil {
 		glog.Fatalf("Failed to register %v handler: %v", serviceName, err)
 	}
 }
 
-// Preload a bunch of pipeline samples
-func loadSamples(resourceManager *resource.ResourceManager) error {
-	configBytes, err := ioutil.ReadFile(*sampleConfigPath)
-	if err != nil {
-		return errors.New(fmt.Sprintf("Failed to read sample configurations file. Err: %v", err.Error()))
-	}
-	type config struct {
-		Name        string
-		Description string
-		File        string
-	}
-	var configs []config
-	if err:= json.Unmarshal(configBytes, &configs);err != nil {
-		return errors.New(fmt.Sprintf("Failed to read sample configurations. Err: %v", err.Error()))
-	}
-	for _, config := range configs {
-		reader, err:= os.Open(config.File)
+// newSampleWatcher returns the watcher loading the samples of the sample config when it
+// changes, i.e. creating the new samples and updating those which changed.
+func newSampleWatcher(resourceManager *resource.ResourceManager) *reload.FileWatcher {
+	loader := server.NewSampleLoader(resourceManager, &http.Client{Timeout: time.Minute})
+	return reload.NewFileWatcher(*sampleConfigPath, *sampleConfigReloadInterval, func(content []byte) error {
+		configs, err := server.ParseSampleConfig(content)
 		if err != nil {
-			return errors.New(fmt.Sprintf("Failed to load sample %s. Error: %v", config.Name, err.Error()))
-		}
-		pipelineFile, err := server.ReadPipelineFile(config.File, reader, server.MaxFileLength)
-		if err!=nil{
-			return errors.New(fmt.Sprintf("Failed to decompress the file %s. Error: %v", config.Name, err.Error()))
-		}
-		_, err = resourceManager.CreatePipeline(config.Name, config.Description, pipelineFile)
-		if err!=nil{
-			// Log the error but not fail. The API Server pod can restart and it could potentially cause name collision.
-			// In the future, we might consider loading samples during deployment, instead of when API server starts.
-			glog.Warningf(fmt.Sprintf("Failed to create pipeline for %s. Error: %v", config.Name, err.Error()))
-			continue
+			return err
 		}
-
-		// Since the default sorting is by create time,
-		// Sleep one second makes sure the samples are showing up in the same order as they are added.
-		time.Sleep(1*time.Second)
-	}
-	glog.Info("All samples are loaded.")
-	return nil
+		return loader.Load(configs)
+	})
 }
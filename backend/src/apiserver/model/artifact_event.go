@@ -0,0 +1,44 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+type ArtifactEventType string
+
+const (
+	// The step consumed the artifact.
+	ArtifactEventInput ArtifactEventType = "Input"
+	// The step produced the artifact.
+	ArtifactEventOutput ArtifactEventType = "Output"
+)
+
+// ArtifactEvent records that a step of a run consumed or produced the artifact stored
+// at ArtifactURI. The events of all the runs form the lineage graph of the artifacts.
+type ArtifactEvent struct {
+	RunUUID      string            `gorm:"column:RunUUID; not null; primary_key"`
+	NodeID       string            `gorm:"column:NodeID; not null; primary_key"`
+	ArtifactName string            `gorm:"column:ArtifactName; not null; primary_key"`
+	Type         ArtifactEventType `gorm:"column:Type; not null; primary_key"`
+	ArtifactURI  string            `gorm:"column:ArtifactURI; not null; index:idx_artifact_uri"`
+	// The display name of the step.
+	StepName       string `gorm:"column:StepName; not null"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+}
+
+// ArtifactLineage is the lineage graph of an artifact, as the events linking the
+// artifacts upstream and downstream of it to the steps that produced and consumed them.
+type ArtifactLineage struct {
+	Upstream   []*ArtifactEvent
+	Downstream []*ArtifactEvent
+}
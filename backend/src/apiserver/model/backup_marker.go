@@ -0,0 +1,39 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+type BackupMarkerStatus string
+
+const (
+	// The writes are quiesced and the database snapshot is being taken.
+	BackupMarkerTaking    BackupMarkerStatus = "TAKING"
+	BackupMarkerSucceeded BackupMarkerStatus = "SUCCEEDED"
+	BackupMarkerFailed    BackupMarkerStatus = "FAILED"
+)
+
+// BackupMarker records a point-in-time backup: the database snapshot taken while the
+// writes were quiesced, and the manifest listing the files of the object store at that
+// time. The marker is stored before the snapshot is taken, so that a restored database
+// holds the ID of the manifest of the files to restore with it.
+type BackupMarker struct {
+	UUID           string             `gorm:"column:UUID; not null; primary_key"`
+	CreatedAtInSec int64              `gorm:"column:CreatedAtInSec; not null"`
+	Status         BackupMarkerStatus `gorm:"column:Status; not null"`
+	// The path of the manifest in the object store.
+	ManifestPath string `gorm:"column:ManifestPath; not null"`
+	FileCount    int64  `gorm:"column:FileCount; not null"`
+	// The output of the snapshot command, or why the backup failed.
+	Message string `gorm:"column:Message; not null; size:65535"`
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// ExecutionTarget is a namespace of a remote cluster the runs may be dispatched to, instead
+// of the cluster of the API server. The kubeconfig of the cluster is read from a Secret of
+// the namespace of the API server, so that it's never stored in the database.
+type ExecutionTarget struct {
+	Name        string `gorm:"column:Name; not null; primary_key"`
+	Description string `gorm:"column:Description; not null"`
+	// The namespace of the remote cluster the workflows are created in.
+	Namespace string `gorm:"column:Namespace; not null"`
+	// The Secret holding the kubeconfig of the remote cluster, and its key.
+	KubeconfigSecretName string `gorm:"column:KubeconfigSecretName; not null"`
+	KubeconfigSecretKey  string `gorm:"column:KubeconfigSecretKey; not null"`
+	CreatedAtInSec       int64  `gorm:"column:CreatedAtInSec; not null"`
+}
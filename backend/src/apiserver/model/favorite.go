@@ -0,0 +1,23 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Favorite is a pipeline or a run starred by a user.
+type Favorite struct {
+	UserID         string `gorm:"column:UserID; not null; primary_key"`
+	ResourceType   string `gorm:"column:ResourceType; not null; primary_key"`
+	ResourceUUID   string `gorm:"column:ResourceUUID; not null; primary_key"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+}
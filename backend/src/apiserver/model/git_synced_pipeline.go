@@ -0,0 +1,28 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// GitSyncedPipeline records the pipeline created from a file of the Git repository
+// synced by the GitOps controller.
+type GitSyncedPipeline struct {
+	// The path of the file in the repository, which is also the name of the pipeline.
+	Path         string `gorm:"column:Path; not null; primary_key"`
+	PipelineUUID string `gorm:"column:PipelineUUID; not null"`
+	// The commit the file was last synced at.
+	CommitSHA string `gorm:"column:CommitSHA; not null"`
+	// The SHA-256 of the content of the file, used to detect its changes.
+	ContentHash   string `gorm:"column:ContentHash; not null"`
+	SyncedAtInSec int64  `gorm:"column:SyncedAtInSec; not null"`
+}
@@ -0,0 +1,27 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "github.com/kubeflow/pipelines/backend/src/apiserver/common"
+
+// MetricsPushToken authenticates the steps of the runs of a run or a job pushing their
+// metrics. Only the hash of the token is stored.
+type MetricsPushToken struct {
+	// The ID of the run or job the token was issued for.
+	ResourceUUID   string              `gorm:"column:ResourceUUID; not null; primary_key"`
+	ResourceType   common.ResourceType `gorm:"column:ResourceType; not null"`
+	TokenHash      string              `gorm:"column:TokenHash; not null"`
+	CreatedAtInSec int64               `gorm:"column:CreatedAtInSec; not null"`
+}
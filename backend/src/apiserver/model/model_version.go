@@ -0,0 +1,40 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// ModelVersion is a version of a model, registered from an output artifact of a
+// succeeded run.
+type ModelVersion struct {
+	UUID      string `gorm:"column:UUID; not null; primary_key"`
+	ModelName string `gorm:"column:ModelName; not null; unique_index:idx_model_name_version"`
+	// The versions of a model are numbered from 1, in the order they are registered.
+	Version      int64  `gorm:"column:Version; not null; unique_index:idx_model_name_version"`
+	ArtifactURI  string `gorm:"column:ArtifactURI; not null"`
+	ArtifactName string `gorm:"column:ArtifactName; not null"`
+	// The run and the node of the step that produced the artifact.
+	RunUUID        string `gorm:"column:RunUUID; not null"`
+	NodeID         string `gorm:"column:NodeID; not null"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+	// The metrics reported by the step that produced the artifact.
+	Metrics []*RunMetric `gorm:"-"`
+}
+
+func (m ModelVersion) GetValueOfPrimaryKey() string {
+	return m.UUID
+}
+
+func GetModelVersionTablePrimaryKeyColumn() string {
+	return "UUID"
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// OperationType is the kind of work done by an operation. The values are the names of the
+// types of the API.
+type OperationType string
+
+const (
+	OperationReindexPipelines     OperationType = "REINDEX_PIPELINES"
+	OperationRecomputeRunStatuses OperationType = "RECOMPUTE_RUN_STATUSES"
+)
+
+type OperationState string
+
+const (
+	OperationRunning   OperationState = "RUNNING"
+	OperationSucceeded OperationState = "SUCCEEDED"
+	OperationFailed    OperationState = "FAILED"
+	OperationCancelled OperationState = "CANCELLED"
+)
+
+// Operation processes many resources in the background, in batches, so that it isn't bound
+// to the deadline of the call which started it.
+type Operation struct {
+	UUID           string         `gorm:"column:UUID; not null; primary_key"`
+	Type           OperationType  `gorm:"column:Type; not null"`
+	State          OperationState `gorm:"column:State; not null"`
+	CreatedAtInSec int64          `gorm:"column:CreatedAtInSec; not null"`
+	// The time the operation left the running state, or 0.
+	FinishedAtInSec int64 `gorm:"column:FinishedAtInSec; not null"`
+	// The number of resources to process, 0 until the first batch, and processed so far.
+	Total     int64 `gorm:"column:Total; not null"`
+	Processed int64 `gorm:"column:Processed; not null"`
+	// The ID of the last resource processed, the resources being processed in the order of
+	// their IDs, so that the operation resumes after it.
+	Cursor string `gorm:"column:Cursor; not null"`
+	// The errors of the resources which failed, as a JSON list.
+	Errors string `gorm:"column:Errors; not null; size:65535"`
+}
+
+func (o Operation) GetValueOfPrimaryKey() string {
+	return o.UUID
+}
+
+func GetOperationTablePrimaryKeyColumn() string {
+	return "UUID"
+}
@@ -36,6 +36,16 @@ type Pipeline struct {
 	/* Set size to 65535 so it will be stored as longtext. https://dev.mysql.com/doc/refman/8.0/en/column-count-limit.html */
 	Parameters string         `gorm:"column:Parameters; not null; size:65535"`
 	Status     PipelineStatus `gorm:"column:Status; not null"`
+	// Whether the pipeline is deprecated, and the pipeline replacing it, if any.
+	Deprecated            bool   `gorm:"column:Deprecated"`
+	ReplacementPipelineId string `gorm:"column:ReplacementPipelineId"`
+	// When the deprecated pipeline is no longer supported, or 0 if it has no sunset.
+	SunsetAtInSec int64 `gorm:"column:SunsetAtInSec"`
+	// The schema of the declared parameters marshalled into JSON, empty if none is declared.
+	ParameterSchema string `gorm:"column:ParameterSchema; size:65535"`
+	// The SHA-256 checksum of the pipeline file, versioning the cached templates of the
+	// pipeline. Empty for the pipelines uploaded before it was stored.
+	TemplateChecksum string `gorm:"column:TemplateChecksum"`
 }
 
 func (p Pipeline) GetValueOfPrimaryKey() string {
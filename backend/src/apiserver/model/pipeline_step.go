@@ -0,0 +1,28 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// PipelineStep is a template of the workflow of a pipeline, indexed when the pipeline is
+// uploaded so that the graph of the pipeline is served without parsing its template.
+type PipelineStep struct {
+	PipelineUUID string `gorm:"column:PipelineUUID; not null; primary_key"`
+	Name         string `gorm:"column:Name; not null; primary_key"`
+	// The position of the template in the workflow.
+	Position int    `gorm:"column:Position; not null"`
+	Type     string `gorm:"column:Type; not null"`
+	Image    string `gorm:"column:Image; not null"`
+	// The JSON list of the names of the templates run by the step.
+	Children string `gorm:"column:Children; not null; size:65535"`
+}
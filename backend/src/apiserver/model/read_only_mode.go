@@ -0,0 +1,27 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// ReadOnlyMode records whether the writes are rejected for maintenance, so that all the
+// replicas of the API server follow the mode set by the admins through any of them.
+type ReadOnlyMode struct {
+	ID       string `gorm:"column:ID; not null; primary_key"`
+	ReadOnly bool   `gorm:"column:ReadOnly; not null"`
+	// The message the writes fail with in read-only mode. A default message is used if empty.
+	Message        string `gorm:"column:Message; not null; size:1000"`
+	UpdatedAtInSec int64  `gorm:"column:UpdatedAtInSec; not null"`
+	// The admin who set the mode last.
+	UpdatedBy string `gorm:"column:UpdatedBy; not null"`
+}
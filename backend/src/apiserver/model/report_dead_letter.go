@@ -0,0 +1,31 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// ReportDeadLetter holds the last report of the workflow of a run which failed to be
+// applied, e.g. because of bad data or a schema mismatch, so that it can be inspected and
+// replayed once the cause is fixed.
+type ReportDeadLetter struct {
+	RunUUID   string `gorm:"column:RunUUID; not null; primary_key"`
+	Namespace string `gorm:"column:Namespace; not null"`
+	Name      string `gorm:"column:Name; not null"`
+	// The workflow reported, as JSON.
+	Workflow string `gorm:"column:Workflow; not null; size:65535"`
+	// The error of the last attempt to apply the report.
+	Error string `gorm:"column:Error; not null; size:65535"`
+	// The number of attempts to apply the report which failed so far.
+	Attempts      int   `gorm:"column:Attempts; not null"`
+	FailedAtInSec int64 `gorm:"column:FailedAtInSec; not null"`
+}
@@ -25,6 +25,14 @@ type Run struct {
 	Conditions         string `gorm:"column:Conditions; not null"`
 	Metrics            []*RunMetric
 	ResourceReferences []*ResourceReference
+	// The serving resources deployed by the run. Only set when a single run is read.
+	Deployments []*RunDeployment `gorm:"-"`
+	// A free-form note on the run. Set size to 65535 so it will be stored as longtext.
+	Note        string            `gorm:"column:Note; size:65535"`
+	Annotations map[string]string `gorm:"-"`
+	// The execution target the workflow of the run is dispatched to. Empty means the cluster
+	// of the API server.
+	ExecutionTarget string `gorm:"column:ExecutionTarget"`
 	PipelineSpec
 }
 
@@ -48,6 +56,22 @@ type RunMetric struct {
 	Payload     string  `gorm:"column:Payload; not null; size:65535"`
 }
 
+// RunAnnotation is an annotation of a run, e.g. "quality": "bad data day".
+type RunAnnotation struct {
+	RunUUID string `gorm:"column:RunUUID; not null; primary_key"`
+	Name    string `gorm:"column:Name; not null; primary_key"`
+	Value   string `gorm:"column:Value; not null"`
+}
+
+// RunStorageSize is the size of the manifests of a run stored in the database, with the
+// namespace and the experiment the storage of the run is accounted to.
+type RunStorageSize struct {
+	RunUUID        string
+	Namespace      string
+	ExperimentUUID string
+	ManifestBytes  int64
+}
+
 func (r Run) GetValueOfPrimaryKey() string {
 	return r.UUID
 }
@@ -0,0 +1,39 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// DeploymentState is the readiness of a serving resource deployed by a run.
+type DeploymentState string
+
+const (
+	DeploymentPending DeploymentState = "Pending"
+	DeploymentReady   DeploymentState = "Ready"
+	DeploymentFailed  DeploymentState = "Failed"
+)
+
+// RunDeployment is the status of an InferenceService or a SeldonDeployment deployed by
+// a run, tracked after the run succeeds.
+type RunDeployment struct {
+	RunUUID    string          `gorm:"column:RunUUID; not null; primary_key"`
+	Kind       string          `gorm:"column:Kind; not null; primary_key"`
+	Namespace  string          `gorm:"column:Namespace; not null; primary_key"`
+	Name       string          `gorm:"column:Name; not null; primary_key"`
+	APIVersion string          `gorm:"column:APIVersion; not null"`
+	State      DeploymentState `gorm:"column:State; not null; index:idx_deployment_state"`
+	// Why the resource isn't ready, if known.
+	Message        string `gorm:"column:Message; not null; size:65535"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+	UpdatedAtInSec int64  `gorm:"column:UpdatedAtInSec; not null"`
+}
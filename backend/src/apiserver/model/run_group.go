@@ -0,0 +1,40 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RunGroup tracks runs as a unit, e.g. the runs of a sweep, so that they're cancelled
+// together.
+type RunGroup struct {
+	UUID           string `gorm:"column:UUID; not null; primary_key"`
+	Name           string `gorm:"column:Name; not null"`
+	Description    string `gorm:"column:Description; not null; size:65535"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+	// The time the group was cancelled, or 0. No run is added to a cancelled group.
+	CancelledAtInSec int64 `gorm:"column:CancelledAtInSec; not null"`
+}
+
+func (g RunGroup) GetValueOfPrimaryKey() string {
+	return g.UUID
+}
+
+func GetRunGroupTablePrimaryKeyColumn() string {
+	return "UUID"
+}
+
+// RunGroupMember is a run of a group.
+type RunGroupMember struct {
+	GroupUUID string `gorm:"column:GroupUUID; not null; primary_key"`
+	RunUUID   string `gorm:"column:RunUUID; not null; primary_key"`
+}
@@ -0,0 +1,37 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RunOutboxEntry records the intent to create a run, before its workflow is created.
+// The entry is deleted once the workflow is created and the run is stored, so that a
+// run whose creation was interrupted is completed by the run outbox worker.
+type RunOutboxEntry struct {
+	UUID string `gorm:"column:UUID; not null; primary_key"`
+	// The workflow to create, as JSON. Its name is set, so that it's created at most once.
+	// The values of its secret parameters are hashed.
+	Workflow string `gorm:"column:Workflow; not null; size:65535"`
+	// The run requested through the API, as JSON. The values of its secret parameters are
+	// masked.
+	Run                  string `gorm:"column:Run; not null; size:65535"`
+	WorkflowSpecManifest string `gorm:"column:WorkflowSpecManifest; not null; size:65535"`
+	// The values of the secret parameters of the workflow and the run, encrypted with the
+	// key of the server, if any.
+	SecretParameters string `gorm:"column:SecretParameters; size:65535"`
+	// The metrics push token set in the environment of the workflow, if any.
+	MetricsPushToken string `gorm:"column:MetricsPushToken"`
+	CreatedAtInSec   int64  `gorm:"column:CreatedAtInSec; not null"`
+	// The number of attempts to create the run started so far.
+	Attempts int `gorm:"column:Attempts; not null"`
+}
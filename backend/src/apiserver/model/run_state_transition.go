@@ -0,0 +1,31 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RunStateTransition records that a run, or a node of its workflow, reached a state, as
+// reported by the persistence agent. The transitions of a run form its history.
+type RunStateTransition struct {
+	RunUUID string `gorm:"column:RunUUID; not null; primary_key"`
+	// The ID of the node, or empty for the run itself.
+	NodeID string `gorm:"column:NodeID; not null; primary_key"`
+	// The position of the transition among the ones of the run or of the node, from 0.
+	Sequence int64 `gorm:"column:Sequence; not null; primary_key; auto_increment:false"`
+	// The display name of the node, or empty for the run itself.
+	NodeName string `gorm:"column:NodeName; not null"`
+	// The phase of the workflow or of the node.
+	State     string `gorm:"column:State; not null"`
+	TimeInSec int64  `gorm:"column:TimeInSec; not null"`
+	Message   string `gorm:"column:Message; not null; size:65535"`
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RunSweep creates one run per combination of the values of its parameters, i.e. per trial,
+// at most MaxConcurrency of them running at once.
+type RunSweep struct {
+	UUID           string `gorm:"column:UUID; not null; primary_key"`
+	Name           string `gorm:"column:Name; not null"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+	// The run requested through the API, as JSON, which the runs of the trials are created from.
+	Run string `gorm:"column:Run; not null; size:65535"`
+	// The number of runs of the sweep which may run at once, or 0 for all of them.
+	MaxConcurrency int `gorm:"column:MaxConcurrency; not null"`
+}
+
+// RunSweepTrial is a combination of the values of the parameters of a sweep.
+type RunSweepTrial struct {
+	SweepUUID string `gorm:"column:SweepUUID; not null; primary_key"`
+	// The index isn't generated, though gorm auto-increments the integer primary keys by default.
+	TrialIndex int `gorm:"column:TrialIndex; not null; primary_key; auto_increment:false"`
+	// The values of the parameters swept, as a JSON list of parameters.
+	Parameters string `gorm:"column:Parameters; not null; size:65535"`
+	// The run of the trial, empty until it's created.
+	RunUUID string `gorm:"column:RunUUID; not null"`
+}
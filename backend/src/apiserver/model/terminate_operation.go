@@ -0,0 +1,45 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// TerminateOperation terminates the runs of an experiment or of a run group which weren't
+// finished when it was created, in the background.
+type TerminateOperation struct {
+	UUID string `gorm:"column:UUID; not null; primary_key"`
+	// Exactly one of the experiment and the run group is set.
+	ExperimentUUID string `gorm:"column:ExperimentUUID; not null"`
+	RunGroupUUID   string `gorm:"column:RunGroupUUID; not null"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+	// The time every run was processed, or 0.
+	FinishedAtInSec int64 `gorm:"column:FinishedAtInSec; not null"`
+}
+
+type TerminateStatus string
+
+const (
+	TerminatePending         TerminateStatus = "Pending"
+	TerminateSucceeded       TerminateStatus = "Terminated"
+	TerminateAlreadyFinished TerminateStatus = "AlreadyFinished"
+	TerminateFailed          TerminateStatus = "Failed"
+)
+
+// TerminateResult is the outcome of the termination of a run by an operation.
+type TerminateResult struct {
+	OperationUUID string          `gorm:"column:OperationUUID; not null; primary_key"`
+	RunUUID       string          `gorm:"column:RunUUID; not null; primary_key"`
+	Status        TerminateStatus `gorm:"column:Status; not null"`
+	// Why the run couldn't be terminated, if it failed.
+	Error string `gorm:"column:Error; not null; size:65535"`
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "strings"
+
+// Webhook is a URL notified when the runs in its scope reach one of the
+// states in its event filter.
+type Webhook struct {
+	UUID string `gorm:"column:UUID; not null; primary_key"`
+	Name string `gorm:"column:Name; not null; unique"`
+	Url  string `gorm:"column:Url; not null"`
+	// The key used to sign the payloads with HMAC-SHA256. Never returned by the API.
+	Secret string `gorm:"column:Secret; not null"`
+	// The experiment whose runs are notified. Empty means every run in Namespace.
+	ExperimentUUID string `gorm:"column:ExperimentUUID; not null"`
+	Namespace      string `gorm:"column:Namespace; not null"`
+	// Comma separated list of the run states that trigger a notification.
+	EventFilters   string `gorm:"column:EventFilters; not null"`
+	CreatedAtInSec int64  `gorm:"column:CreatedAtInSec; not null"`
+}
+
+func (w Webhook) GetValueOfPrimaryKey() string {
+	return w.UUID
+}
+
+func GetWebhookTablePrimaryKeyColumn() string {
+	return "UUID"
+}
+
+// Matches returns whether a run of the given experiment and namespace reaching
+// the given state should be notified to the webhook.
+func (w Webhook) Matches(experimentUUID string, namespace string, state string) bool {
+	if w.ExperimentUUID != "" && w.ExperimentUUID != experimentUUID {
+		return false
+	}
+	if w.Namespace != "" && w.Namespace != namespace {
+		return false
+	}
+	for _, filter := range strings.Split(w.EventFilters, ",") {
+		if filter == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// WorkflowReport records the last version of the workflow of a run reported by the
+// persistence agent, so that the duplicate and out-of-order reports are ignored.
+type WorkflowReport struct {
+	RunUUID string `gorm:"column:RunUUID; not null; primary_key"`
+	// The resourceVersion of the workflow reported.
+	ResourceVersion int64  `gorm:"column:ResourceVersion; not null"`
+	Conditions      string `gorm:"column:Conditions; not null"`
+	ReportedAtInSec int64  `gorm:"column:ReportedAtInSec; not null"`
+}
@@ -0,0 +1,215 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Mode is how the violations of a rule are handled.
+type Mode string
+
+const (
+	// The rule isn't checked.
+	ModeOff Mode = "off"
+	// The violations are reported, but the pipelines are accepted.
+	ModeWarn Mode = "warn"
+	// The pipelines violating the rule are rejected at upload.
+	ModeEnforce Mode = "enforce"
+)
+
+// The names of the rules.
+const (
+	RuleAllowedRegistries = "allowed_registries"
+	RuleResourceLimits    = "resource_limits"
+	RuleNoHostPath        = "no_host_path"
+	RuleRequiredLabels    = "required_labels"
+)
+
+// Config configures the rules the pipelines are checked against, all off by default.
+type Config struct {
+	// The images must be pulled from one of the registries, e.g. gcr.io/my-project.
+	AllowedRegistries     []string
+	AllowedRegistriesMode Mode
+	// The containers must set CPU and memory limits.
+	ResourceLimitsMode Mode
+	// The workflows must not mount hostPath volumes.
+	NoHostPathMode Mode
+	// The pods of the steps must have the labels.
+	RequiredLabels     []string
+	RequiredLabelsMode Mode
+}
+
+// Violation is a violation of a rule by a workflow, or one of its templates.
+type Violation struct {
+	Rule     string
+	Mode     Mode
+	Template string
+	Message  string
+}
+
+func (v Violation) String() string {
+	if v.Template == "" {
+		return fmt.Sprintf("%v: %v", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("%v: template %v: %v", v.Rule, v.Template, v.Message)
+}
+
+// Linter checks the workflows of the pipelines against the rules of the organization.
+type Linter struct {
+	config Config
+}
+
+// NewLinter creates a linter, failing if a mode is unknown.
+func NewLinter(config Config) (*Linter, error) {
+	for _, mode := range []*Mode{&config.AllowedRegistriesMode, &config.ResourceLimitsMode,
+		&config.NoHostPathMode, &config.RequiredLabelsMode} {
+		switch *mode {
+		case "":
+			*mode = ModeOff
+		case ModeOff, ModeWarn, ModeEnforce:
+		default:
+			return nil, util.NewInvalidInputError("Unknown policy mode %q", *mode)
+		}
+	}
+	return &Linter{config: config}, nil
+}
+
+// Lint returns the violations of the rules by a workflow.
+func (l *Linter) Lint(workflow *util.Workflow) []Violation {
+	var violations []Violation
+	report := func(rule string, mode Mode, template string, format string, a ...interface{}) {
+		violations = append(violations, Violation{Rule: rule, Mode: mode, Template: template,
+			Message: fmt.Sprintf(format, a...)})
+	}
+
+	if l.config.NoHostPathMode != ModeOff {
+		for _, volume := range workflow.Spec.Volumes {
+			if volume.HostPath != nil {
+				report(RuleNoHostPath, l.config.NoHostPathMode, "", "volume %v mounts host path %v",
+					volume.Name, volume.HostPath.Path)
+			}
+		}
+	}
+	for _, template := range workflow.Spec.Templates {
+		containers := templateContainers(&template)
+		if len(containers) == 0 {
+			continue
+		}
+		if l.config.AllowedRegistriesMode != ModeOff {
+			for _, container := range containers {
+				if !l.isAllowedImage(container.Image) {
+					report(RuleAllowedRegistries, l.config.AllowedRegistriesMode, template.Name,
+						"image %v isn't pulled from an allowed registry", container.Image)
+				}
+			}
+		}
+		if l.config.ResourceLimitsMode != ModeOff {
+			for _, container := range containers {
+				var missing []string
+				for _, resource := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+					if _, ok := container.Resources.Limits[resource]; !ok {
+						missing = append(missing, string(resource))
+					}
+				}
+				if len(missing) > 0 {
+					report(RuleResourceLimits, l.config.ResourceLimitsMode, template.Name,
+						"container %v has no %v limit", containerName(container), strings.Join(missing, " and "))
+				}
+			}
+		}
+		if l.config.RequiredLabelsMode != ModeOff {
+			var missing []string
+			for _, label := range l.config.RequiredLabels {
+				if _, ok := template.Metadata.Labels[label]; !ok {
+					missing = append(missing, label)
+				}
+			}
+			if len(missing) > 0 {
+				sort.Strings(missing)
+				report(RuleRequiredLabels, l.config.RequiredLabelsMode, template.Name,
+					"the pod has no label %v", strings.Join(missing, ", "))
+			}
+		}
+	}
+	return violations
+}
+
+// isAllowedImage returns whether an image is pulled from an allowed registry.
+func (l *Linter) isAllowedImage(image string) bool {
+	image = util.QualifiedImageName(image)
+	for _, registry := range l.config.AllowedRegistries {
+		registry = strings.TrimSuffix(registry, "/")
+		if image == registry || strings.HasPrefix(image, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// templateContainers returns the containers of the pod of a template, if it runs one.
+func templateContainers(template *workflowapi.Template) []*corev1.Container {
+	var containers []*corev1.Container
+	switch {
+	case template.Container != nil:
+		containers = append(containers, template.Container)
+	case template.Script != nil:
+		containers = append(containers, &template.Script.Container)
+	default:
+		return nil
+	}
+	for i := range template.Sidecars {
+		containers = append(containers, &template.Sidecars[i].Container)
+	}
+	return containers
+}
+
+func containerName(container *corev1.Container) string {
+	if container.Name == "" {
+		return "main"
+	}
+	return container.Name
+}
+
+// Enforced returns the violations of the enforced rules.
+func Enforced(violations []Violation) []Violation {
+	var enforced []Violation
+	for _, violation := range violations {
+		if violation.Mode == ModeEnforce {
+			enforced = append(enforced, violation)
+		}
+	}
+	return enforced
+}
+
+// NewViolationsError returns the error rejecting a pipeline for the violations of enforced
+// rules, or nil if there's none.
+func NewViolationsError(violations []Violation) error {
+	enforced := Enforced(violations)
+	if len(enforced) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(enforced))
+	for _, violation := range enforced {
+		messages = append(messages, violation.String())
+	}
+	return util.NewInvalidInputError("The pipeline violates the policies: %v", strings.Join(messages, "; "))
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func lintedWorkflow() *util.Workflow {
+	return util.NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow1"},
+		Spec: workflowapi.WorkflowSpec{
+			Volumes: []corev1.Volume{
+				{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				{Name: "docker", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+			},
+			Templates: []workflowapi.Template{
+				{Name: "main", Steps: [][]workflowapi.WorkflowStep{{{Name: "s", Template: "train"}}}},
+				{
+					Name:     "train",
+					Metadata: workflowapi.Metadata{Labels: map[string]string{"team": "ml"}},
+					Container: &corev1.Container{
+						Image: "gcr.io/my-project/train:1",
+						Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("1"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						}},
+					},
+				},
+				{
+					Name:     "notify",
+					Script:   &workflowapi.ScriptTemplate{Container: corev1.Container{Image: "python:3.7"}},
+					Sidecars: []workflowapi.Sidecar{{Container: corev1.Container{Name: "proxy", Image: "gcr.io/my-project/proxy"}}},
+				},
+			},
+		},
+	})
+}
+
+func TestLint(t *testing.T) {
+	linter, err := NewLinter(Config{
+		AllowedRegistries:     []string{"gcr.io/my-project/"},
+		AllowedRegistriesMode: ModeEnforce,
+		ResourceLimitsMode:    ModeWarn,
+		NoHostPathMode:        ModeEnforce,
+		RequiredLabels:        []string{"team", "cost-center"},
+		RequiredLabelsMode:    ModeWarn,
+	})
+	assert.Nil(t, err)
+
+	violations := linter.Lint(lintedWorkflow())
+	assert.Equal(t, []Violation{
+		{Rule: RuleNoHostPath, Mode: ModeEnforce, Message: "volume docker mounts host path /var/run/docker.sock"},
+		{Rule: RuleRequiredLabels, Mode: ModeWarn, Template: "train", Message: "the pod has no label cost-center"},
+		{Rule: RuleAllowedRegistries, Mode: ModeEnforce, Template: "notify",
+			Message: "image python:3.7 isn't pulled from an allowed registry"},
+		{Rule: RuleResourceLimits, Mode: ModeWarn, Template: "notify", Message: "container main has no cpu and memory limit"},
+		{Rule: RuleResourceLimits, Mode: ModeWarn, Template: "notify", Message: "container proxy has no cpu and memory limit"},
+		{Rule: RuleRequiredLabels, Mode: ModeWarn, Template: "notify", Message: "the pod has no label cost-center, team"},
+	}, violations)
+
+	err = NewViolationsError(violations)
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "no_host_path: volume docker mounts host path /var/run/docker.sock; "+
+		"allowed_registries: template notify: image python:3.7 isn't pulled from an allowed registry")
+	assert.NotContains(t, err.Error(), RuleResourceLimits)
+}
+
+func TestLint_Off(t *testing.T) {
+	linter, err := NewLinter(Config{AllowedRegistries: []string{"gcr.io/my-project"}})
+	assert.Nil(t, err)
+	violations := linter.Lint(lintedWorkflow())
+	assert.Empty(t, violations)
+	assert.Nil(t, NewViolationsError(violations))
+}
+
+func TestLint_DockerHubImages(t *testing.T) {
+	linter, err := NewLinter(Config{AllowedRegistries: []string{"docker.io/library"}, AllowedRegistriesMode: ModeWarn})
+	assert.Nil(t, err)
+	assert.True(t, linter.isAllowedImage("docker.io/library/python:3.7"))
+	assert.True(t, linter.isAllowedImage("python:3.7"))
+	assert.False(t, linter.isAllowedImage("tensorflow/tensorflow"))
+	assert.False(t, linter.isAllowedImage("docker.io/library-fork/python"))
+	assert.False(t, linter.isAllowedImage("localhost:5000/library/python"))
+
+	linter, err = NewLinter(Config{AllowedRegistries: []string{"docker.io"}, AllowedRegistriesMode: ModeWarn})
+	assert.Nil(t, err)
+	assert.True(t, linter.isAllowedImage("python:3.7"))
+	assert.True(t, linter.isAllowedImage("tensorflow/tensorflow"))
+}
+
+func TestNewLinter_UnknownMode(t *testing.T) {
+	_, err := NewLinter(Config{NoHostPathMode: "block"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `Unknown policy mode "block"`)
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ApproveRun approves the approval gates of a run waiting for a decision, all of them or
+// the one of nodeId. The user and the comment are recorded in the message of the gates.
+func (r *ResourceManager) ApproveRun(runId string, nodeId string, user string, comment string) error {
+	return r.resolveApprovalGates(runId, nodeId, true, approvalMessage("Approved", user, comment))
+}
+
+// RejectRun rejects the approval gates of a run waiting for a decision, all of them or the
+// one of nodeId, which terminates the run. The user and the reason are recorded in the
+// message of the gates.
+func (r *ResourceManager) RejectRun(runId string, nodeId string, user string, reason string) error {
+	return r.resolveApprovalGates(runId, nodeId, false, approvalMessage("Rejected", user, reason))
+}
+
+// approvalMessage returns the message of a resolved gate, e.g. "Approved by alice: LGTM".
+func approvalMessage(decision string, user string, comment string) string {
+	message := decision
+	if user != "" {
+		message += " by " + user
+	}
+	if comment != "" {
+		message += ": " + comment
+	}
+	return message
+}
+
+// resolveApprovalGates resolves the gates of the workflow of a run and stores its status,
+// so that the run shows the decision before the workflow is reported again.
+func (r *ResourceManager) resolveApprovalGates(runId string, nodeId string, approved bool, message string) error {
+	run, err := r.runStore.GetRun(runId)
+	if err != nil {
+		return util.Wrap(err, "Failed to resolve the approval gates of the run")
+	}
+	if util.IsFinalCondition(run.Conditions) {
+		return util.NewInvalidInputError("The approval gates of run %v can't be resolved: the run already finished", runId)
+	}
+	runEngine, err := r.runEngine(&run.Run)
+	if err != nil {
+		return err
+	}
+	if err := runEngine.ResolveGates(run.Name, nodeId, approved, message); err != nil {
+		if apierrors.IsNotFound(err) {
+			return util.NewResourceNotFoundError("Workflow", run.Name)
+		}
+		return util.Wrap(err, "Failed to resolve the approval gates of the run")
+	}
+	workflow, err := runEngine.Get(run.Name)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get the workflow of run %v", runId)
+	}
+	if err := r.runStore.UpdateRun(runId, workflow.Condition(), workflow.ToStringForStore()); err != nil {
+		return util.Wrap(err, "Failed to update the run of the resolved approval gates")
+	}
+	r.runWatcher.Notify(runId)
+	return nil
+}
+
+// notifyApprovalGates notifies the webhooks watching the run of the workflow that approval
+// gates started waiting for a decision since the workflow stored before the report.
+func (r *ResourceManager) notifyApprovalGates(workflow *util.Workflow, experimentId string, previousManifest string) {
+	waiting := workflow.WaitingApprovalGates()
+	if len(waiting) == 0 {
+		return
+	}
+	previouslyWaiting := waitingApprovalGateIds(previousManifest)
+	var gates []string
+	for _, gate := range waiting {
+		if !previouslyWaiting[gate.ID] {
+			gates = append(gates, gate.DisplayName)
+		}
+	}
+	if len(gates) == 0 {
+		return
+	}
+	r.runWatcher.Notify(string(workflow.UID))
+	webhooks, err := r.webhookStore.ListWebhooksForRun(experimentId, workflow.Namespace)
+	if err != nil {
+		glog.Errorf("Failed to list the webhooks of run %v: %v", workflow.UID, err)
+		return
+	}
+	for i := range webhooks {
+		if !webhooks[i].Matches(experimentId, workflow.Namespace, webhook.StateAwaitingApproval) {
+			continue
+		}
+		r.webhookNotifier.Notify(&webhooks[i], &webhook.RunEvent{
+			Event:        webhook.EventTypeForState(webhook.StateAwaitingApproval),
+			RunId:        string(workflow.UID),
+			RunName:      workflow.Name,
+			ExperimentId: experimentId,
+			Namespace:    workflow.Namespace,
+			State:        webhook.StateAwaitingApproval,
+			Gates:        gates,
+		})
+	}
+}
+
+// waitingApprovalGateIds returns the IDs of the approval gates waiting for a decision in a
+// stored workflow, which is empty until the workflow is first reported.
+func waitingApprovalGateIds(manifest string) map[string]bool {
+	ids := make(map[string]bool)
+	if manifest == "" {
+		return ids
+	}
+	var workflow util.Workflow
+	if err := json.Unmarshal([]byte(manifest), &workflow); err != nil {
+		glog.Warningf("Failed to parse the stored workflow to get its approval gates: %v", err)
+		return ids
+	}
+	for _, gate := range workflow.WaitingApprovalGates() {
+		ids[gate.ID] = true
+	}
+	return ids
+}
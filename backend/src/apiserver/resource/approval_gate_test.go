@@ -0,0 +1,132 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// suspendRunWorkflow makes the workflow of a run wait at an approval gate, as Argo does.
+func suspendRunWorkflow(t *testing.T, store *FakeClientManager, run *model.RunDetail) {
+	workflow, err := store.workflowClientFake.Get(run.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	suspend := true
+	workflow.Spec.Suspend = &suspend
+	workflow.Status.Phase = v1alpha1.NodeRunning
+	workflow.Status.Nodes = map[string]v1alpha1.NodeStatus{
+		"gate1": {ID: "gate1", DisplayName: "approve", Type: v1alpha1.NodeTypeSuspend, Phase: v1alpha1.NodeRunning},
+	}
+	_, err = store.workflowClientFake.Update(workflow)
+	assert.Nil(t, err)
+}
+
+func TestApproveRun(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	suspendRunWorkflow(t, store, run)
+
+	assert.Nil(t, manager.ApproveRun(run.UUID, "", "alice", "LGTM"))
+	workflow, err := store.workflowClientFake.Get(run.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.NodeSucceeded, workflow.Status.Nodes["gate1"].Phase)
+	assert.Equal(t, "Approved by alice: LGTM", workflow.Status.Nodes["gate1"].Message)
+	assert.Nil(t, workflow.Spec.Suspend)
+	// The run shows the decision before the workflow is reported.
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Contains(t, runDetail.WorkflowRuntimeManifest, "Approved by alice: LGTM")
+
+	err = manager.ApproveRun(run.UUID, "", "alice", "")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "has no approval gate waiting for a decision")
+}
+
+func TestRejectRun(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	suspendRunWorkflow(t, store, run)
+
+	assert.Nil(t, manager.RejectRun(run.UUID, "gate1", "", "Bad metrics"))
+	workflow, err := store.workflowClientFake.Get(run.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.NodeFailed, workflow.Status.Nodes["gate1"].Phase)
+	assert.Equal(t, "Rejected: Bad metrics", workflow.Status.Nodes["gate1"].Message)
+	assert.Equal(t, int64(0), *workflow.Spec.ActiveDeadlineSeconds)
+}
+
+func TestApproveRun_Finished(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: run.Name, UID: types.UID(run.UUID)},
+		Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.NodeFailed},
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+
+	err := manager.ApproveRun(run.UUID, "", "alice", "")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the run already finished")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestReportWorkflowResource_NotifiesApprovalGates(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	_, err := manager.CreateWebhook(&model.Webhook{
+		Name:         "webhook1",
+		Url:          "https://example.com",
+		Secret:       "secret",
+		EventFilters: webhook.StateAwaitingApproval,
+	})
+	assert.Nil(t, err)
+
+	workflow, err := store.workflowClientFake.Get(run.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	workflow.Labels = map[string]string{util.LabelKeyWorkflowExperimentId: DefaultFakeUUID}
+	workflow.Status.Phase = v1alpha1.NodeRunning
+	workflow.Status.Nodes = map[string]v1alpha1.NodeStatus{
+		"gate1": {ID: "gate1", DisplayName: "approve", Type: v1alpha1.NodeTypeSuspend, Phase: v1alpha1.NodeRunning},
+	}
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+	// A gate still waiting isn't notified again.
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+	workflow.Status.Nodes["gate2"] = v1alpha1.NodeStatus{
+		ID: "gate2", DisplayName: "deploy", Type: v1alpha1.NodeTypeSuspend, Phase: v1alpha1.NodeRunning}
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+
+	event := webhook.RunEvent{
+		Event:        "run.awaiting_approval",
+		RunId:        run.UUID,
+		RunName:      run.Name,
+		ExperimentId: DefaultFakeUUID,
+		State:        "AwaitingApproval",
+	}
+	first, second := event, event
+	first.Gates = []string{"approve"}
+	second.Gates = []string{"deploy"}
+	assert.Equal(t, []webhook.FakeDelivery{
+		{WebhookId: DefaultFakeUUID, Event: first},
+		{WebhookId: DefaultFakeUUID, Event: second},
+	}, store.webhookNotifierFake.Deliveries())
+}
@@ -15,15 +15,33 @@
 package resource
 
 import (
-	workflowclient "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	"github.com/golang/glog"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/eventexport"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/policy"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
+	"github.com/kubeflow/pipelines/backend/src/common/client/visualization"
+	"github.com/kubeflow/pipelines/backend/src/common/features"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	scheduledworkflowclient "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned/typed/scheduledworkflow/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
 	DefaultFakeUUID = "123e4567-e89b-12d3-a456-426655440000"
+
+	fakeTemplateCacheSize  = 10
+	fakeNamespace          = "default"
+	fakeNamespaceConfig    = "pipeline-namespace-config"
+	fakeObjectStoreBucket  = "mlpipeline"
+	fakeEstimationPastRuns = 10
+	fakeReportMaxAttempts  = 3
 )
 
 type FakeClientManager struct {
@@ -32,10 +50,46 @@ type FakeClientManager struct {
 	pipelineStore               storage.PipelineStoreInterface
 	jobStore                    storage.JobStoreInterface
 	runStore                    storage.RunStoreInterface
+	runOutboxStore              storage.RunOutboxStoreInterface
+	runSweepStore               storage.RunSweepStoreInterface
+	runGroupStore               storage.RunGroupStoreInterface
+	terminateOperationStore     storage.TerminateOperationStoreInterface
+	operationStore              storage.OperationStoreInterface
 	resourceReferenceStore      storage.ResourceReferenceStoreInterface
 	objectStore                 storage.ObjectStoreInterface
+	webhookStore                storage.WebhookStoreInterface
+	artifactLineageStore        storage.ArtifactLineageStoreInterface
+	runHistoryStore             storage.RunHistoryStoreInterface
+	modelRegistry               storage.ModelRegistryInterface
+	metricsPushTokenStore       storage.MetricsPushTokenStoreInterface
+	favoriteStore               storage.FavoriteStoreInterface
+	deploymentStatusStore       storage.DeploymentStatusStoreInterface
 	workflowClientFake          *storage.FakeWorkflowClient
 	scheduledWorkflowClientFake *FakeScheduledWorkflowClient
+	podClientFake               *client.FakePodClient
+	configMapClientFake         *client.FakeConfigMapClient
+	executionTargetStore        storage.ExecutionTargetStoreInterface
+	executionTargets            *ExecutionTargets
+	secretClientFake            *client.FakeSecretClient
+	targetWorkflowClientFakes   map[string]*storage.FakeWorkflowClient
+	namespaceConfigs            *NamespaceConfigs
+	resourceQuotaClientFake     *client.FakeResourceQuotaClient
+	gpuQuota                    *GPUQuota
+	allowedServiceAccounts      []string
+	blockSunsetPipelines        bool
+	maxRunOutputSize            int
+	runEstimationConfig         RunEstimationConfig
+	eventRecorderFake           *record.FakeRecorder
+	webhookNotifierFake         *webhook.FakeNotifier
+	eventPublisherFake          *eventexport.FakePublisher
+	metadataStoreFake           *metadata.MetadataStore
+	visualizationClientFake     *visualization.FakeVisualizationClient
+	templateCache               *TemplateCache
+	reportDeduplicator          *ReportDeduplicator
+	reportDeadLetterQueue       *ReportDeadLetterQueue
+	workflowDefaults            *api.WorkflowOptions
+	policyLinter                *policy.Linter
+	featureGate                 *features.Gate
 	time                        util.TimeInterface
 	uuid                        util.UUIDGeneratorInterface
 }
@@ -56,20 +110,62 @@ func NewFakeClientManager(time util.TimeInterface, uuid util.UUIDGeneratorInterf
 	if err != nil {
 		return nil, err
 	}
+	return NewFakeClientManagerWithStores(db, storage.NewFakeObjectStore(), time, uuid)
+}
 
-	return &FakeClientManager{
+// NewFakeClientManagerWithStores returns a fake client manager over the given database and object
+// store, e.g. the ones loaded from the snapshot of a previous release.
+func NewFakeClientManagerWithStores(db *storage.DB, objectStore storage.ObjectStoreInterface,
+	time util.TimeInterface, uuid util.UUIDGeneratorInterface) (*FakeClientManager, error) {
+	policyLinter, err := policy.NewLinter(policy.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	clientManager := &FakeClientManager{
 		db:                          db,
 		experimentStore:             storage.NewExperimentStore(db, time, uuid),
 		pipelineStore:               storage.NewPipelineStore(db, time, uuid),
 		jobStore:                    storage.NewJobStore(db, time),
 		runStore:                    storage.NewRunStore(db, time),
+		runOutboxStore:              storage.NewRunOutboxStore(db),
+		runSweepStore:               storage.NewRunSweepStore(db),
+		runGroupStore:               storage.NewRunGroupStore(db),
+		terminateOperationStore:     storage.NewTerminateOperationStore(db),
+		operationStore:              storage.NewOperationStore(db),
 		workflowClientFake:          storage.NewWorkflowClientFake(),
 		resourceReferenceStore:      storage.NewResourceReferenceStore(db),
-		objectStore:                 storage.NewFakeObjectStore(),
+		objectStore:                 objectStore,
+		webhookStore:                storage.NewWebhookStore(db, time, uuid),
+		artifactLineageStore:        storage.NewArtifactLineageStore(db),
+		runHistoryStore:             storage.NewRunHistoryStore(db),
+		modelRegistry:               storage.NewModelRegistryStore(db, time, uuid),
+		favoriteStore:               storage.NewFavoriteStore(db, time),
+		deploymentStatusStore:       storage.NewDeploymentStatusStore(db),
 		scheduledWorkflowClientFake: NewScheduledWorkflowClientFake(),
+		podClientFake:               client.NewFakePodClient(),
+		configMapClientFake:         client.NewFakeConfigMapClient(),
+		resourceQuotaClientFake:     client.NewFakeResourceQuotaClient(),
+		executionTargetStore:        storage.NewExecutionTargetStore(db, time),
+		secretClientFake:            client.NewFakeSecretClient(),
+		targetWorkflowClientFakes:   make(map[string]*storage.FakeWorkflowClient),
+		eventRecorderFake:           record.NewFakeRecorder(1000),
+		webhookNotifierFake:         webhook.NewFakeNotifier(),
+		eventPublisherFake:          eventexport.NewFakePublisher(),
+		metadataStoreFake:           metadata.NewFakeMetadataStore(),
+		templateCache:               NewTemplateCache(fakeTemplateCacheSize, 0, time),
+		reportDeduplicator:          NewReportDeduplicator(storage.NewWorkflowReportStore(db), 0, time),
+		reportDeadLetterQueue:       NewReportDeadLetterQueue(storage.NewReportDeadLetterStore(db), fakeReportMaxAttempts, time),
+		workflowDefaults:            &api.WorkflowOptions{},
+		runEstimationConfig:         RunEstimationConfig{PastRuns: fakeEstimationPastRuns, Currency: "USD"},
+		policyLinter:                policyLinter,
+		featureGate:                 features.NewGate(),
 		time:                        time,
 		uuid:                        uuid,
-	}, nil
+	}
+	clientManager.executionTargets = NewExecutionTargets(
+		clientManager.secretClientFake, clientManager.createTargetClients, 0, time)
+	return clientManager, nil
 }
 
 func NewFakeClientManagerOrFatal(time util.TimeInterface) *FakeClientManager {
@@ -105,8 +201,8 @@ func (f *FakeClientManager) DB() *storage.DB {
 	return f.db
 }
 
-func (f *FakeClientManager) Workflow() workflowclient.WorkflowInterface {
-	return f.workflowClientFake
+func (f *FakeClientManager) Engine() engine.Engine {
+	return engine.NewArgoEngine(f.workflowClientFake, f.podClientFake)
 }
 
 func (f *FakeClientManager) JobStore() storage.JobStoreInterface {
@@ -125,6 +221,231 @@ func (f *FakeClientManager) ScheduledWorkflow() scheduledworkflowclient.Schedule
 	return f.scheduledWorkflowClientFake
 }
 
+func (f *FakeClientManager) PodClient() client.PodClientInterface {
+	return f.podClientFake
+}
+
+func (f *FakeClientManager) Namespace() string {
+	return fakeNamespace
+}
+
+func (f *FakeClientManager) NamespaceConfigs() *NamespaceConfigs {
+	return f.namespaceConfigs
+}
+
+// SetNamespaceConfig overrides the configuration of the runs and jobs of a namespace created
+// next with the data of its ConfigMap.
+func (f *FakeClientManager) SetNamespaceConfig(namespace string, data map[string]string) {
+	if f.namespaceConfigs == nil {
+		f.namespaceConfigs = NewNamespaceConfigs(f.configMapClientFake, fakeNamespaceConfig, 0, f.time)
+	}
+	f.configMapClientFake.SetConfigMap(namespace, fakeNamespaceConfig, data)
+}
+
+func (f *FakeClientManager) GPUQuota() *GPUQuota {
+	return f.gpuQuota
+}
+
+// SetGPUQuota checks the nvidia.com/gpu requests of the runs created next against the
+// ResourceQuotas of their namespace, in the mode.
+func (f *FakeClientManager) SetGPUQuota(mode string, quotas ...corev1.ResourceQuota) error {
+	gpuQuota, err := NewGPUQuota(f.resourceQuotaClientFake, []string{"nvidia.com/gpu"}, mode)
+	if err != nil {
+		return err
+	}
+	f.gpuQuota = gpuQuota
+	f.resourceQuotaClientFake.SetResourceQuotas(fakeNamespace, quotas...)
+	return nil
+}
+
+func (f *FakeClientManager) ExecutionTargetStore() storage.ExecutionTargetStoreInterface {
+	return f.executionTargetStore
+}
+
+func (f *FakeClientManager) ExecutionTargets() *ExecutionTargets {
+	return f.executionTargets
+}
+
+// SetSecret creates or replaces a Secret of the namespace of the API server, e.g. the one
+// holding the kubeconfig of an execution target.
+func (f *FakeClientManager) SetSecret(name string, labels map[string]string, data map[string][]byte) {
+	f.secretClientFake.SetSecret(name, labels, data)
+}
+
+// ExecutionTargetWorkflowClientFake returns the fake client of the workflows of an execution
+// target, which is nil until the clients of the target are created.
+func (f *FakeClientManager) ExecutionTargetWorkflowClientFake(name string) *storage.FakeWorkflowClient {
+	return f.targetWorkflowClientFakes[name]
+}
+
+// createTargetClients creates clients sharing the fake workflows of each execution target. An
+// empty kubeconfig is invalid.
+func (f *FakeClientManager) createTargetClients(target *model.ExecutionTarget, kubeconfig []byte) (
+	*TargetClients, error) {
+	if len(kubeconfig) == 0 {
+		return nil, util.NewInvalidInputError("Invalid kubeconfig: empty")
+	}
+	workflowClient, ok := f.targetWorkflowClientFakes[target.Name]
+	if !ok {
+		workflowClient = storage.NewWorkflowClientFake()
+		f.targetWorkflowClientFakes[target.Name] = workflowClient
+	}
+	return &TargetClients{Engine: engine.NewArgoEngine(workflowClient, f.podClientFake), PodClient: f.podClientFake}, nil
+}
+
+func (f *FakeClientManager) AllowedServiceAccounts() []string {
+	return f.allowedServiceAccounts
+}
+
+// AllowServiceAccounts lets the runs and jobs created next run as the service accounts.
+func (f *FakeClientManager) AllowServiceAccounts(serviceAccounts ...string) {
+	f.allowedServiceAccounts = append(f.allowedServiceAccounts, serviceAccounts...)
+}
+
+func (f *FakeClientManager) BlockSunsetPipelines() bool {
+	return f.blockSunsetPipelines
+}
+
+// EnableSunsetBlocking refuses the runs and jobs of the deprecated pipelines past their
+// sunset created next.
+func (f *FakeClientManager) EnableSunsetBlocking() {
+	f.blockSunsetPipelines = true
+}
+
+func (f *FakeClientManager) MaxRunOutputSize() int {
+	return f.maxRunOutputSize
+}
+
+// SetMaxRunOutputSize cuts the content of the outputs of the runs read next at the size.
+func (f *FakeClientManager) SetMaxRunOutputSize(maxSize int) {
+	f.maxRunOutputSize = maxSize
+}
+
+func (f *FakeClientManager) RunEstimationConfig() RunEstimationConfig {
+	return f.runEstimationConfig
+}
+
+// SetRunEstimationConfig estimates the runs with the prices and the number of past runs next.
+func (f *FakeClientManager) SetRunEstimationConfig(config RunEstimationConfig) {
+	f.runEstimationConfig = config
+}
+
+func (f *FakeClientManager) EventRecorder() record.EventRecorder {
+	return f.eventRecorderFake
+}
+
+func (f *FakeClientManager) WebhookStore() storage.WebhookStoreInterface {
+	return f.webhookStore
+}
+
+func (f *FakeClientManager) ArtifactLineageStore() storage.ArtifactLineageStoreInterface {
+	return f.artifactLineageStore
+}
+
+func (f *FakeClientManager) RunHistoryStore() storage.RunHistoryStoreInterface {
+	return f.runHistoryStore
+}
+
+func (f *FakeClientManager) ModelRegistry() storage.ModelRegistryInterface {
+	return f.modelRegistry
+}
+
+func (f *FakeClientManager) RunOutboxStore() storage.RunOutboxStoreInterface {
+	return f.runOutboxStore
+}
+
+func (f *FakeClientManager) RunSweepStore() storage.RunSweepStoreInterface {
+	return f.runSweepStore
+}
+
+func (f *FakeClientManager) RunGroupStore() storage.RunGroupStoreInterface {
+	return f.runGroupStore
+}
+
+func (f *FakeClientManager) TerminateOperationStore() storage.TerminateOperationStoreInterface {
+	return f.terminateOperationStore
+}
+
+func (f *FakeClientManager) OperationStore() storage.OperationStoreInterface {
+	return f.operationStore
+}
+
+func (f *FakeClientManager) MetricsPushTokenStore() storage.MetricsPushTokenStoreInterface {
+	return f.metricsPushTokenStore
+}
+
+// EnableMetricsPush issues metrics push tokens for the runs and jobs created next.
+// Otherwise the workflows are created as specified.
+func (f *FakeClientManager) EnableMetricsPush() {
+	f.metricsPushTokenStore = storage.NewMetricsPushTokenStore(f.db, f.time)
+}
+
+func (f *FakeClientManager) FavoriteStore() storage.FavoriteStoreInterface {
+	return f.favoriteStore
+}
+
+func (f *FakeClientManager) DeploymentStatusStore() storage.DeploymentStatusStoreInterface {
+	return f.deploymentStatusStore
+}
+
+func (f *FakeClientManager) WebhookNotifier() webhook.NotifierInterface {
+	return f.webhookNotifierFake
+}
+
+func (f *FakeClientManager) EventPublisher() eventexport.PublisherInterface {
+	return f.eventPublisherFake
+}
+
+func (f *FakeClientManager) TemplateCache() *TemplateCache {
+	return f.templateCache
+}
+
+func (f *FakeClientManager) ReportDeduplicator() *ReportDeduplicator {
+	return f.reportDeduplicator
+}
+
+func (f *FakeClientManager) ReportDeadLetterQueue() *ReportDeadLetterQueue {
+	return f.reportDeadLetterQueue
+}
+
+func (f *FakeClientManager) WorkflowDefaults() *api.WorkflowOptions {
+	return f.workflowDefaults
+}
+
+func (f *FakeClientManager) PolicyLinter() *policy.Linter {
+	return f.policyLinter
+}
+
+// FeatureGate returns the gate of the known features, which the tests set before creating
+// the resource manager.
+func (f *FakeClientManager) FeatureGate() *features.Gate {
+	return f.featureGate
+}
+
+func (f *FakeClientManager) MetadataStore() metadata.MetadataStoreInterface {
+	return f.metadataStoreFake
+}
+
+func (f *FakeClientManager) VisualizationClient() visualization.VisualizationClientInterface {
+	if f.visualizationClientFake == nil {
+		return nil
+	}
+	return f.visualizationClientFake
+}
+
+// EnableVisualizations lets the resource managers created next generate the visualizations with
+// the fake visualization server returned.
+func (f *FakeClientManager) EnableVisualizations() *visualization.FakeVisualizationClient {
+	if f.visualizationClientFake == nil {
+		f.visualizationClientFake = visualization.NewFakeVisualizationClient()
+	}
+	return f.visualizationClientFake
+}
+
+func (f *FakeClientManager) ObjectStoreBucket() string {
+	return fakeObjectStoreBucket
+}
+
 func (f *FakeClientManager) Close() error {
 	return f.db.Close()
 }
@@ -0,0 +1,288 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ConsistencyIssueType is the kind of inconsistency between the database and the object store.
+type ConsistencyIssueType string
+
+const (
+	// A run whose workflow isn't stored, so that its details can't be shown.
+	ConsistencyIssueRunWithoutManifest ConsistencyIssueType = "RUN_WITHOUT_MANIFEST"
+	// A ready pipeline whose package is missing from the object store, so that it can't be run.
+	ConsistencyIssuePipelineWithoutPackage ConsistencyIssueType = "PIPELINE_WITHOUT_PACKAGE"
+	// An object of a pipeline or of a run which doesn't exist anymore.
+	ConsistencyIssueDanglingObject ConsistencyIssueType = "DANGLING_OBJECT"
+)
+
+var consistencyIssuesGauge = metrics.NewGaugeVec("consistency_checker_issues",
+	"The inconsistencies between the database and the object store found by the last check.", "type")
+
+func init() {
+	metrics.MustRegister(consistencyIssuesGauge)
+}
+
+// ConsistencyIssue is an inconsistency found by a check, with the outcome of its repair.
+type ConsistencyIssue struct {
+	Type ConsistencyIssueType `json:"type"`
+	// The ID of the run or of the pipeline, or the path of the dangling object.
+	ResourceId  string `json:"resourceId"`
+	Description string `json:"description"`
+	Repaired    bool   `json:"repaired,omitempty"`
+	RepairError string `json:"repairError,omitempty"`
+}
+
+// ConsistencyReport lists the inconsistencies found by a check.
+type ConsistencyReport struct {
+	CheckedAtInSec int64               `json:"checkedAtInSec"`
+	Repair         bool                `json:"repair"`
+	Issues         []*ConsistencyIssue `json:"issues"`
+}
+
+// ConsistencyChecker audits the database against the object store: the runs without a
+// manifest, the pipelines whose package is missing, and the objects of the pipelines and of
+// the runs which don't exist anymore. With repair, the manifests of the runs are stored from
+// their workflows, the pipelines without a package are deleted since they can't be run, and
+// the dangling objects are deleted. The report of the last check is stored in the object
+// store, so that it is shared by the replicas of the API server.
+type ConsistencyChecker struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+	// Whether the scheduled checks repair the issues they find.
+	repair bool
+	// Serializes the checks, since the repairs of concurrent checks would race.
+	mutex sync.Mutex
+}
+
+func NewConsistencyChecker(resourceManager *ResourceManager, interval time.Duration, repair bool) *ConsistencyChecker {
+	return &ConsistencyChecker{resourceManager: resourceManager, interval: interval, repair: repair}
+}
+
+// Run checks the consistency every interval until stopCh is closed.
+func (c *ConsistencyChecker) Run(stopCh <-chan struct{}) {
+	glog.Infof("Checking the consistency of the database and the object store every %v (repair: %v)", c.interval, c.repair)
+	wait.Until(func() {
+		if _, err := c.Check(c.repair); err != nil {
+			glog.Errorf("Failed to check the consistency of the database and the object store: %+v", err)
+		}
+	}, c.interval, stopCh)
+}
+
+// Check finds the inconsistencies, repairing them if requested, and stores the report. The
+// issues which fail to be repaired are reported with the error of their repair.
+func (c *ConsistencyChecker) Check(repair bool) (*ConsistencyReport, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	check := &consistencyCheck{report: &ConsistencyReport{
+		CheckedAtInSec: c.resourceManager.time.Now().Unix(),
+		Repair:         repair,
+		Issues:         []*ConsistencyIssue{},
+	}}
+	var errs []error
+	for _, checkIssues := range []func(*consistencyCheck) error{c.checkRuns, c.checkObjects} {
+		if err := checkIssues(check); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := utilerrors.NewAggregate(errs); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to check the consistency")
+	}
+	report := check.report
+	counts := map[ConsistencyIssueType]int{}
+	for i, issue := range report.Issues {
+		counts[issue.Type]++
+		if repair {
+			repairIssue(issue, check.repairs[i])
+		}
+	}
+	for _, issueType := range []ConsistencyIssueType{ConsistencyIssueRunWithoutManifest,
+		ConsistencyIssuePipelineWithoutPackage, ConsistencyIssueDanglingObject} {
+		consistencyIssuesGauge.Set(float64(counts[issueType]), string(issueType))
+	}
+	if err := c.resourceManager.objectStore.AddAsYamlFile(report, storage.ConsistencyReportPath); err != nil {
+		return nil, util.Wrap(err, "Failed to store the consistency report")
+	}
+	glog.Infof("Found %v inconsistencies between the database and the object store", len(report.Issues))
+	return report, nil
+}
+
+// LastReport returns the report of the last check, of any replica.
+func (c *ConsistencyChecker) LastReport() (*ConsistencyReport, error) {
+	files, err := c.resourceManager.objectStore.ListFiles(storage.ConsistencyReportPath)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the consistency report")
+	}
+	if len(files) == 0 {
+		return nil, util.NewResourceNotFoundError("ConsistencyReport", "last")
+	}
+	report := &ConsistencyReport{}
+	if err := c.resourceManager.objectStore.GetFromYamlFile(report, storage.ConsistencyReportPath); err != nil {
+		return nil, util.Wrap(err, "Failed to get the consistency report")
+	}
+	return report, nil
+}
+
+// consistencyCheck holds the issues found by a check, with the function repairing each of them.
+type consistencyCheck struct {
+	report  *ConsistencyReport
+	repairs []func() error
+}
+
+func (c *consistencyCheck) add(issue *ConsistencyIssue, repair func() error) {
+	c.report.Issues = append(c.report.Issues, issue)
+	c.repairs = append(c.repairs, repair)
+}
+
+func (c *ConsistencyChecker) checkRuns(check *consistencyCheck) error {
+	runs, err := c.resourceManager.runStore.ListRunsWithoutManifest()
+	if err != nil {
+		return util.Wrap(err, "Failed to list the runs without manifest")
+	}
+	for _, run := range runs {
+		run := run
+		check.add(&ConsistencyIssue{
+			Type:        ConsistencyIssueRunWithoutManifest,
+			ResourceId:  run.UUID,
+			Description: fmt.Sprintf("The workflow of run %v isn't stored", run.Name),
+		}, func() error { return c.storeRunManifest(run) })
+	}
+	return nil
+}
+
+// checkObjects finds the ready pipelines without a package and the dangling objects, listing
+// the object store once.
+func (c *ConsistencyChecker) checkObjects(check *consistencyCheck) error {
+	statuses, err := c.resourceManager.pipelineStore.GetPipelineStatuses()
+	if err != nil {
+		return util.Wrap(err, "Failed to get the pipelines")
+	}
+	files, err := c.resourceManager.objectStore.ListFiles("")
+	if err != nil {
+		return util.Wrap(err, "Failed to list the files of the object store")
+	}
+	packages := make(map[string]bool)
+	runPaths := make(map[string][]string)
+	var runIds []string
+	for _, file := range files {
+		resourceType, id := storage.ParseResourcePath(file.Path)
+		switch resourceType {
+		case common.Pipeline:
+			if file.Path == storage.CreatePipelinePath(id) {
+				packages[id] = true
+			}
+			if _, ok := statuses[id]; !ok {
+				c.addDanglingObject(check, file.Path, "pipeline", id)
+			}
+		case common.Run:
+			if _, ok := runPaths[id]; !ok {
+				runIds = append(runIds, id)
+			}
+			runPaths[id] = append(runPaths[id], file.Path)
+		}
+	}
+	existing, err := c.resourceManager.runStore.GetExistingRunIds(runIds)
+	if err != nil {
+		return util.Wrap(err, "Failed to get the runs of the objects")
+	}
+	for _, id := range runIds {
+		if existing[id] {
+			continue
+		}
+		for _, objectPath := range runPaths[id] {
+			c.addDanglingObject(check, objectPath, "run", id)
+		}
+	}
+	var pipelineIds []string
+	for id, status := range statuses {
+		if status == model.PipelineReady && !packages[id] {
+			pipelineIds = append(pipelineIds, id)
+		}
+	}
+	sort.Strings(pipelineIds)
+	for _, id := range pipelineIds {
+		id := id
+		check.add(&ConsistencyIssue{
+			Type:        ConsistencyIssuePipelineWithoutPackage,
+			ResourceId:  id,
+			Description: fmt.Sprintf("The package of pipeline %v is missing from the object store", id),
+		}, func() error { return c.deletePipelineWithoutPackage(id) })
+	}
+	return nil
+}
+
+func (c *ConsistencyChecker) addDanglingObject(check *consistencyCheck, objectPath string, resourceKind string, id string) {
+	check.add(&ConsistencyIssue{
+		Type:        ConsistencyIssueDanglingObject,
+		ResourceId:  objectPath,
+		Description: fmt.Sprintf("The %v %v of the object doesn't exist", resourceKind, id),
+	}, func() error { return c.resourceManager.objectStore.DeleteFile(objectPath) })
+}
+
+func repairIssue(issue *ConsistencyIssue, repair func() error) {
+	if err := repair(); err != nil {
+		glog.Errorf("Failed to repair %v %v: %+v", issue.Type, issue.ResourceId, err)
+		issue.RepairError = err.Error()
+		return
+	}
+	glog.Infof("Repaired %v %v", issue.Type, issue.ResourceId)
+	issue.Repaired = true
+}
+
+// storeRunManifest stores the manifest of a run from its workflow, if it still exists.
+func (c *ConsistencyChecker) storeRunManifest(run model.Run) error {
+	runEngine, err := c.resourceManager.runEngine(&run)
+	if err != nil {
+		return err
+	}
+	workflow, err := runEngine.Get(run.Name)
+	if err != nil {
+		return util.NewResourceNotFoundError("Workflow", run.Name)
+	}
+	return c.resourceManager.runStore.UpdateRun(run.UUID, workflow.Condition(), workflow.ToStringForStore())
+}
+
+// deletePipelineWithoutPackage deletes a pipeline whose package is missing. Unlike
+// DeletePipeline, its missing objects don't stop the deletion of its row.
+func (c *ConsistencyChecker) deletePipelineWithoutPackage(pipelineId string) error {
+	r := c.resourceManager
+	r.removeCachedTemplates(pipelineId)
+	err := r.objectStore.DeleteFile(storage.CreatePipelineManifestPath(pipelineId))
+	if err != nil {
+		glog.Infof("%v", errors.Wrapf(err, "The compiled workflow of pipeline %v wasn't deleted", pipelineId))
+	}
+	if err := r.pipelineStore.DeletePipeline(pipelineId); err != nil {
+		return err
+	}
+	if err := r.favoriteStore.DeleteFavorites(common.Pipeline, pipelineId); err != nil {
+		glog.Errorf("%v", errors.Wrapf(err, "Failed to delete the favorites of pipeline %v", pipelineId))
+	}
+	return nil
+}
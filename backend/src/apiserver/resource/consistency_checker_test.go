@@ -0,0 +1,152 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestConsistencyChecker_Consistent(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	_, err := manager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	assert.Nil(t, manager.objectStore.AddFile([]byte("logs"), storage.CreateRunLogPath(runDetail.UUID, "node1")))
+	checker := NewConsistencyChecker(manager, time.Hour, false)
+
+	_, err = checker.LastReport()
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	report, err := checker.Check(false)
+	assert.Nil(t, err)
+	assert.Equal(t, &ConsistencyReport{CheckedAtInSec: 4, Issues: []*ConsistencyIssue{}}, report)
+	assert.Equal(t, float64(0), consistencyIssuesGauge.Value(string(ConsistencyIssueDanglingObject)))
+	lastReport, err := checker.LastReport()
+	assert.Nil(t, err)
+	assert.Equal(t, report, lastReport)
+}
+
+func TestConsistencyChecker_RunWithoutManifest(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	assert.Nil(t, store.RunStore().UpdateRun(runDetail.UUID, "", ""))
+	checker := NewConsistencyChecker(manager, time.Hour, false)
+
+	report, err := checker.Check(false)
+	assert.Nil(t, err)
+	assert.Equal(t, []*ConsistencyIssue{{
+		Type:        ConsistencyIssueRunWithoutManifest,
+		ResourceId:  runDetail.UUID,
+		Description: "The workflow of run workflow-name isn't stored",
+	}}, report.Issues)
+	assert.Equal(t, float64(1), consistencyIssuesGauge.Value(string(ConsistencyIssueRunWithoutManifest)))
+
+	report, err = checker.Check(true)
+	assert.Nil(t, err)
+	assert.Len(t, report.Issues, 1)
+	assert.True(t, report.Issues[0].Repaired)
+	run, err := manager.GetRun(runDetail.UUID)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, run.WorkflowRuntimeManifest)
+	report, err = checker.Check(false)
+	assert.Nil(t, err)
+	assert.Empty(t, report.Issues)
+}
+
+func TestConsistencyChecker_RunWithoutManifest_WorkflowDeleted(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	assert.Nil(t, store.RunStore().UpdateRun(runDetail.UUID, "", ""))
+	assert.Nil(t, store.workflowClientFake.Delete(runDetail.Name, nil))
+
+	report, err := NewConsistencyChecker(manager, time.Hour, false).Check(true)
+	assert.Nil(t, err)
+	assert.Len(t, report.Issues, 1)
+	assert.False(t, report.Issues[0].Repaired)
+	assert.Contains(t, report.Issues[0].RepairError, "workflow-name")
+}
+
+func TestConsistencyChecker_PipelineWithoutPackage(t *testing.T) {
+	store, manager, pipeline := initWithPipeline(t)
+	defer store.Close()
+	assert.Nil(t, manager.objectStore.DeleteFile(storage.CreatePipelinePath(pipeline.UUID)))
+	checker := NewConsistencyChecker(manager, time.Hour, false)
+
+	report, err := checker.Check(false)
+	assert.Nil(t, err)
+	assert.Equal(t, []*ConsistencyIssue{{
+		Type:        ConsistencyIssuePipelineWithoutPackage,
+		ResourceId:  pipeline.UUID,
+		Description: "The package of pipeline " + pipeline.UUID + " is missing from the object store",
+	}}, report.Issues)
+
+	report, err = checker.Check(true)
+	assert.Nil(t, err)
+	assert.True(t, report.Issues[0].Repaired)
+	_, err = manager.GetPipeline(pipeline.UUID)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	// The compiled workflow of the pipeline was deleted with it.
+	report, err = checker.Check(false)
+	assert.Nil(t, err)
+	assert.Empty(t, report.Issues)
+}
+
+func TestConsistencyChecker_DanglingObjects(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	objectStore := manager.objectStore
+	assert.Nil(t, objectStore.AddFile([]byte("logs"), storage.CreateRunLogPath(runDetail.UUID, "node1")))
+	assert.Nil(t, objectStore.AddFile([]byte("logs"), storage.CreateRunLogPath("deleted-run", "node1")))
+	assert.Nil(t, objectStore.AddFile([]byte("package"), storage.CreatePipelinePath("deleted-pipeline")))
+	// The objects which don't belong to a resource are left alone.
+	assert.Nil(t, objectStore.AddFile([]byte("manifest"), storage.CreateBackupManifestPath("backup1")))
+	checker := NewConsistencyChecker(manager, time.Hour, false)
+
+	report, err := checker.Check(false)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []*ConsistencyIssue{
+		{
+			Type:        ConsistencyIssueDanglingObject,
+			ResourceId:  "logs/deleted-run/node1.log",
+			Description: "The run deleted-run of the object doesn't exist",
+		},
+		{
+			Type:        ConsistencyIssueDanglingObject,
+			ResourceId:  "pipelines/deleted-pipeline",
+			Description: "The pipeline deleted-pipeline of the object doesn't exist",
+		},
+	}, report.Issues)
+	assert.Equal(t, float64(2), consistencyIssuesGauge.Value(string(ConsistencyIssueDanglingObject)))
+
+	report, err = checker.Check(true)
+	assert.Nil(t, err)
+	assert.Len(t, report.Issues, 2)
+	for _, issue := range report.Issues {
+		assert.True(t, issue.Repaired)
+	}
+	files, err := objectStore.ListFiles("")
+	assert.Nil(t, err)
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	assert.ElementsMatch(t, []string{storage.CreateRunLogPath(runDetail.UUID, "node1"),
+		storage.CreateBackupManifestPath("backup1"), storage.ConsistencyReportPath}, paths)
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// EngineStatusPoller reports the status of the workflows of the engines the persistence
+// agent doesn't watch, e.g. Tekton, as the persistence agent reports the Argo workflows.
+// The reports of the workflows that didn't change are skipped if deduplicated.
+type EngineStatusPoller struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+}
+
+func NewEngineStatusPoller(resourceManager *ResourceManager, interval time.Duration) *EngineStatusPoller {
+	return &EngineStatusPoller{resourceManager: resourceManager, interval: interval}
+}
+
+// Run polls the workflows every interval until stopCh is closed.
+func (p *EngineStatusPoller) Run(stopCh <-chan struct{}) {
+	glog.Infof("Polling the status of the %v workflows every %v", p.resourceManager.engine.Name(), p.interval)
+	wait.Until(func() {
+		if err := p.Poll(); err != nil {
+			glog.Errorf("Failed to poll the status of the workflows: %+v", err)
+		}
+	}, p.interval, stopCh)
+}
+
+// Poll reports the status of the workflows created by the pipelines.
+func (p *EngineStatusPoller) Poll() error {
+	workflows, err := p.resourceManager.engine.List()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to list the workflows")
+	}
+	var errs []error
+	for _, workflow := range workflows {
+		if !isCreatedByPipelines(workflow) {
+			continue
+		}
+		if err := p.resourceManager.ReportWorkflowResource(workflow); err != nil {
+			errs = append(errs, util.Wrap(err, fmt.Sprintf("Failed to report workflow %v", workflow.Name)))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,40 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEngineStatusPoller_ReportsWorkflows(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow, err := store.workflowClientFake.Get(runDetail.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	workflow.Status.Phase = v1alpha1.NodeSucceeded
+	// Not created by the pipelines.
+	_, err = store.workflowClientFake.Create(&v1alpha1.Workflow{ObjectMeta: v1.ObjectMeta{Name: "other"}})
+	assert.Nil(t, err)
+
+	assert.Nil(t, NewEngineStatusPoller(manager, time.Minute).Poll())
+	run, err := manager.GetRun(runDetail.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", run.Conditions)
+}
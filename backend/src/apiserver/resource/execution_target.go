@@ -0,0 +1,175 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The key of the kubeconfig in the Secret of an execution target, unless set.
+const defaultKubeconfigSecretKey = "kubeconfig"
+
+// TargetClients are the clients of the workflows and of the pods of the namespace of an
+// execution target.
+type TargetClients struct {
+	Engine    engine.Engine
+	PodClient client.PodClientInterface
+}
+
+// TargetClientsFactory creates the clients of an execution target from the kubeconfig of
+// its cluster.
+type TargetClientsFactory func(target *model.ExecutionTarget, kubeconfig []byte) (*TargetClients, error)
+
+// ExecutionTargets creates the clients of the execution targets from the kubeconfigs in their
+// Secrets, and caches them for the TTL so that dispatching a run doesn't read the Secret every
+// time, while the kubeconfigs rotated in the Secrets are picked up.
+type ExecutionTargets struct {
+	secretClient client.SecretClientInterface
+	factory      TargetClientsFactory
+	ttl          time.Duration
+	time         util.TimeInterface
+
+	mutex   sync.Mutex
+	clients map[string]*cachedTargetClients
+}
+
+type cachedTargetClients struct {
+	clients   *TargetClients
+	expiresAt time.Time
+}
+
+// NewExecutionTargets creates the clients of the execution targets whose Secrets are read with
+// the given client. The clients are created again after the TTL, or every time if it isn't
+// positive.
+func NewExecutionTargets(secretClient client.SecretClientInterface, factory TargetClientsFactory,
+	ttl time.Duration, time util.TimeInterface) *ExecutionTargets {
+	return &ExecutionTargets{
+		secretClient: secretClient,
+		factory:      factory,
+		ttl:          ttl,
+		time:         time,
+		clients:      make(map[string]*cachedTargetClients),
+	}
+}
+
+// Get returns the clients of an execution target.
+func (t *ExecutionTargets) Get(target *model.ExecutionTarget) (*TargetClients, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if entry, ok := t.clients[target.Name]; ok && t.time.Now().Before(entry.expiresAt) {
+		return entry.clients, nil
+	}
+	secret, err := t.secretClient.GetSecret(target.KubeconfigSecretName)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to read the kubeconfig of execution target %v", target.Name)
+	}
+	// Only the Secrets meant for the execution targets are read, not the other ones of the
+	// namespace, e.g. the credentials of the database.
+	if secret.Labels[util.LabelKeyExecutionTargetKubeconfig] != "true" {
+		return nil, util.NewInvalidInputError("Secret %v of execution target %v must be labeled %v=true",
+			target.KubeconfigSecretName, target.Name, util.LabelKeyExecutionTargetKubeconfig)
+	}
+	kubeconfig, ok := secret.Data[target.KubeconfigSecretKey]
+	if !ok {
+		return nil, util.NewInvalidInputError("Secret %v has no key %v with the kubeconfig of execution target %v",
+			target.KubeconfigSecretName, target.KubeconfigSecretKey, target.Name)
+	}
+	clients, err := t.factory(target, kubeconfig)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to create the clients of execution target %v", target.Name)
+	}
+	if t.ttl > 0 {
+		t.clients[target.Name] = &cachedTargetClients{clients: clients, expiresAt: t.time.Now().Add(t.ttl)}
+	}
+	return clients, nil
+}
+
+// Forget drops the clients of an execution target, e.g. once it's deleted.
+func (t *ExecutionTargets) Forget(name string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.clients, name)
+}
+
+// CreateExecutionTarget registers an execution target once its clients are created, so that a
+// target whose Secret is missing or whose kubeconfig is invalid is rejected.
+func (r *ResourceManager) CreateExecutionTarget(target *model.ExecutionTarget) (*model.ExecutionTarget, error) {
+	newTarget := *target
+	if newTarget.KubeconfigSecretKey == "" {
+		newTarget.KubeconfigSecretKey = defaultKubeconfigSecretKey
+	}
+	r.executionTargets.Forget(newTarget.Name)
+	if _, err := r.executionTargets.Get(&newTarget); err != nil {
+		return nil, util.Wrap(err, "Failed to create the execution target")
+	}
+	return r.executionTargetStore.CreateExecutionTarget(&newTarget)
+}
+
+func (r *ResourceManager) GetExecutionTarget(name string) (*model.ExecutionTarget, error) {
+	return r.executionTargetStore.GetExecutionTarget(name)
+}
+
+func (r *ResourceManager) ListExecutionTargets() ([]*model.ExecutionTarget, error) {
+	return r.executionTargetStore.ListExecutionTargets()
+}
+
+// DeleteExecutionTarget deletes an execution target which has no unfinished run, since the
+// workflows of its runs couldn't be terminated nor their logs read anymore.
+func (r *ResourceManager) DeleteExecutionTarget(name string) error {
+	if _, err := r.executionTargetStore.GetExecutionTarget(name); err != nil {
+		return util.Wrap(err, "Failed to delete the execution target")
+	}
+	count, err := r.runStore.CountUnfinishedRunsOfTarget(name)
+	if err != nil {
+		return util.Wrap(err, "Failed to delete the execution target")
+	}
+	if count > 0 {
+		return util.NewFailedPreconditionError(
+			"Execution target %v has %v unfinished runs. Please wait for them to finish or terminate them", name, count)
+	}
+	if err := r.executionTargetStore.DeleteExecutionTarget(name); err != nil {
+		return err
+	}
+	r.executionTargets.Forget(name)
+	return nil
+}
+
+// targetClients returns the clients of the execution target of a run, which are the ones of
+// the cluster of the API server if the run has no target.
+func (r *ResourceManager) targetClients(targetName string) (*TargetClients, error) {
+	if targetName == "" {
+		return &TargetClients{Engine: r.engine, PodClient: r.podClient}, nil
+	}
+	target, err := r.executionTargetStore.GetExecutionTarget(targetName)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the execution target of the run")
+	}
+	return r.executionTargets.Get(target)
+}
+
+// runEngine returns the engine the workflow of a run is on.
+func (r *ResourceManager) runEngine(run *model.Run) (engine.Engine, error) {
+	clients, err := r.targetClients(run.ExecutionTarget)
+	if err != nil {
+		return nil, err
+	}
+	return clients.Engine, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+var executionTargetSecretLabels = map[string]string{util.LabelKeyExecutionTargetKubeconfig: "true"}
+
+func createFakeExecutionTarget(name string) *model.ExecutionTarget {
+	return &model.ExecutionTarget{Name: name, Namespace: "kubeflow", KubeconfigSecretName: "kubeconfig-" + name}
+}
+
+func initWithExecutionTarget(t *testing.T) (*FakeClientManager, *ResourceManager, *model.Experiment) {
+	store, manager, experiment := initWithExperiment(t)
+	store.SetSecret("kubeconfig-east", executionTargetSecretLabels, map[string][]byte{"kubeconfig": []byte("apiVersion: v1")})
+	_, err := manager.CreateExecutionTarget(createFakeExecutionTarget("east"))
+	assert.Nil(t, err)
+	return store, manager, experiment
+}
+
+func createRunOnTarget(manager *ResourceManager, experimentId string, target string) (*model.RunDetail, error) {
+	return manager.CreateRun(&api.Run{
+		Name:            "run1",
+		PipelineSpec:    &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ExecutionTarget: target,
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experimentId},
+			Relationship: api.Relationship_OWNER,
+		}},
+	}, "")
+}
+
+func TestCreateExecutionTarget(t *testing.T) {
+	store, manager, _ := initWithExecutionTarget(t)
+	defer store.Close()
+
+	target, err := manager.GetExecutionTarget("east")
+	assert.Nil(t, err)
+	expected := createFakeExecutionTarget("east")
+	expected.KubeconfigSecretKey = "kubeconfig"
+	expected.CreatedAtInSec = target.CreatedAtInSec
+	assert.Equal(t, expected, target)
+	targets, err := manager.ListExecutionTargets()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ExecutionTarget{expected}, targets)
+}
+
+func TestCreateExecutionTarget_SecretNotFound(t *testing.T) {
+	store, manager, _ := initWithExperiment(t)
+	defer store.Close()
+
+	_, err := manager.CreateExecutionTarget(createFakeExecutionTarget("east"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = manager.GetExecutionTarget("east")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestCreateExecutionTarget_InvalidKubeconfig(t *testing.T) {
+	store, manager, _ := initWithExperiment(t)
+	defer store.Close()
+	store.SetSecret("kubeconfig-east", executionTargetSecretLabels,
+		map[string][]byte{"config": []byte("apiVersion: v1"), "kubeconfig": {}})
+
+	target := createFakeExecutionTarget("east")
+	target.KubeconfigSecretKey = "other"
+	_, err := manager.CreateExecutionTarget(target)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "has no key other")
+	_, err = manager.CreateExecutionTarget(createFakeExecutionTarget("east"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "Invalid kubeconfig")
+}
+
+func TestCreateExecutionTarget_UnlabeledSecret(t *testing.T) {
+	store, manager, _ := initWithExperiment(t)
+	defer store.Close()
+	store.SetSecret("kubeconfig-east", nil, map[string][]byte{"kubeconfig": []byte("apiVersion: v1")})
+
+	_, err := manager.CreateExecutionTarget(createFakeExecutionTarget("east"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "must be labeled "+util.LabelKeyExecutionTargetKubeconfig)
+}
+
+func TestCreateRun_OnExecutionTarget(t *testing.T) {
+	store, manager, experiment := initWithExecutionTarget(t)
+	defer store.Close()
+
+	run, err := createRunOnTarget(manager, experiment.UUID, "east")
+	assert.Nil(t, err)
+	assert.Equal(t, "east", run.ExecutionTarget)
+	assert.Equal(t, 1, store.ExecutionTargetWorkflowClientFake("east").GetWorkflowCount())
+	assert.Equal(t, 0, store.workflowClientFake.GetWorkflowCount())
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "east", runDetail.ExecutionTarget)
+
+	// The status of the run is read from the workflow on the target.
+	assert.Nil(t, manager.recomputeRunStatus(&runDetail.Run))
+}
+
+func TestCreateRun_ExecutionTargetNotFound(t *testing.T) {
+	store, manager, experiment := initWithExecutionTarget(t)
+	defer store.Close()
+
+	_, err := createRunOnTarget(manager, experiment.UUID, "west")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	assert.Equal(t, 0, store.ExecutionTargetWorkflowClientFake("east").GetWorkflowCount())
+	assert.Equal(t, 0, store.workflowClientFake.GetWorkflowCount())
+}
+
+func TestDeleteExecutionTarget_UnfinishedRuns(t *testing.T) {
+	store, manager, experiment := initWithExecutionTarget(t)
+	defer store.Close()
+	run, err := createRunOnTarget(manager, experiment.UUID, "east")
+	assert.Nil(t, err)
+
+	err = manager.DeleteExecutionTarget("east")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+	assert.Contains(t, err.Error(), "has 1 unfinished runs")
+
+	assert.Nil(t, store.RunStore().UpdateRun(run.UUID, "Succeeded", run.WorkflowRuntimeManifest))
+	assert.Nil(t, manager.DeleteExecutionTarget("east"))
+	_, err = manager.GetExecutionTarget("east")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestDeleteExecutionTarget_NotFound(t *testing.T) {
+	store, manager, _ := initWithExperiment(t)
+	defer store.Close()
+
+	err := manager.DeleteExecutionTarget("east")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestOrphanReconciler_SkipsRunsOnExecutionTargets(t *testing.T) {
+	store, manager, experiment := initWithExecutionTarget(t)
+	defer store.Close()
+	run, err := createRunOnTarget(manager, experiment.UUID, "east")
+	assert.Nil(t, err)
+
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, 0, false, false).Reconcile())
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, run.Conditions, runDetail.Conditions)
+}
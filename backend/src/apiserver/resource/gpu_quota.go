@@ -0,0 +1,173 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The modes of the GPU quota check, applied to the runs whose steps request more GPUs than
+// the quotas of their namespace have left.
+const (
+	GPUQuotaModeReject = "reject"
+	GPUQuotaModeQueue  = "queue"
+)
+
+// GPUQuota checks that the steps of the new runs requesting GPUs fit in the ResourceQuotas of
+// their namespace, instead of leaving their pods pending forever. A run with a step requesting
+// more GPUs than a quota allows is rejected. One with a step requesting more GPUs than a quota
+// has left is rejected or queued until they're available, as the mode sets. The check is soft:
+// the quotas count the pods, not the runs starting at the same time.
+type GPUQuota struct {
+	client        client.ResourceQuotaClientInterface
+	resourceNames []corev1.ResourceName
+	mode          string
+}
+
+// NewGPUQuota creates the check of the GPUs of the resources, e.g. nvidia.com/gpu.
+func NewGPUQuota(client client.ResourceQuotaClientInterface, resourceNames []string, mode string) (*GPUQuota, error) {
+	if mode != GPUQuotaModeReject && mode != GPUQuotaModeQueue {
+		return nil, util.NewInvalidInputError("Unknown GPU quota mode %q, expected %q or %q",
+			mode, GPUQuotaModeReject, GPUQuotaModeQueue)
+	}
+	quota := &GPUQuota{client: client, mode: mode}
+	for _, name := range resourceNames {
+		quota.resourceNames = append(quota.resourceNames, corev1.ResourceName(name))
+	}
+	return quota, nil
+}
+
+// stepGPUs is the number of GPUs of a resource requested by the pod of a step.
+type stepGPUs struct {
+	step         string
+	resourceName corev1.ResourceName
+	count        int64
+}
+
+// maxStepGPUs returns the steps of a workflow requesting the most GPUs of each resource.
+func (q *GPUQuota) maxStepGPUs(workflow *util.Workflow) []stepGPUs {
+	var steps []stepGPUs
+	for _, name := range q.resourceNames {
+		max := stepGPUs{resourceName: name}
+		for _, template := range workflow.Spec.Templates {
+			var containers []corev1.Container
+			if template.Container != nil {
+				containers = append(containers, *template.Container)
+			}
+			if template.Script != nil {
+				containers = append(containers, template.Script.Container)
+			}
+			if len(containers) == 0 {
+				continue
+			}
+			for _, sidecar := range template.Sidecars {
+				containers = append(containers, sidecar.Container)
+			}
+			count := int64(0)
+			for _, container := range containers {
+				// The requests of the extended resources default to their limits.
+				quantity, ok := container.Resources.Requests[name]
+				if !ok {
+					quantity = container.Resources.Limits[name]
+				}
+				count += quantity.Value()
+			}
+			if count > max.count {
+				max.step, max.count = template.Name, count
+			}
+		}
+		if max.count > 0 {
+			steps = append(steps, max)
+		}
+	}
+	return steps
+}
+
+// check returns whether the steps of a workflow fit in the GPUs the quotas of a namespace
+// have left. It returns a ResourceExhausted error if a step requests more GPUs than a quota
+// allows, or than it has left unless the run may be queued.
+func (q *GPUQuota) check(namespace string, workflow *util.Workflow, queue bool) (bool, error) {
+	steps := q.maxStepGPUs(workflow)
+	if len(steps) == 0 {
+		return true, nil
+	}
+	quotas, err := q.client.ListResourceQuotas(namespace)
+	if err != nil {
+		return false, err
+	}
+	fits := true
+	for _, quota := range quotas {
+		for _, step := range steps {
+			// The quotas of the extended resources only limit their requests.
+			key := corev1.ResourceName("requests." + string(step.resourceName))
+			hard, ok := quota.Spec.Hard[key]
+			if !ok {
+				continue
+			}
+			used := quota.Status.Used[key]
+			if step.count > hard.Value() {
+				return false, util.NewResourceExhaustedError(
+					"Step %v requests %v %v, more than the %v allowed by ResourceQuota %v/%v",
+					step.step, step.count, step.resourceName, hard.Value(), namespace, quota.Name)
+			}
+			if left := hard.Value() - used.Value(); step.count > left {
+				if !queue {
+					return false, util.NewResourceExhaustedError(
+						"Step %v requests %v %v, but ResourceQuota %v/%v has %v of %v left",
+						step.step, step.count, step.resourceName, namespace, quota.Name, left, hard.Value())
+				}
+				fits = false
+			}
+		}
+	}
+	return fits, nil
+}
+
+// applyGPUQuota queues the workflow of a new run with a step requesting more GPUs than the
+// quotas of the namespace have left, or rejects the run, as the GPU quota check sets.
+func (r *ResourceManager) applyGPUQuota(workflow *util.Workflow) error {
+	if r.gpuQuota == nil {
+		return nil
+	}
+	fits, err := r.gpuQuota.check(r.namespace, workflow, r.gpuQuota.mode == GPUQuotaModeQueue)
+	if err != nil {
+		return err
+	}
+	if !fits {
+		workflow.Queue()
+	}
+	return nil
+}
+
+// queuedRunFitsGPUQuota returns whether the steps of a queued run fit in the GPUs the quotas
+// of the namespace have left. It returns a ResourceExhausted error if a step requests more
+// GPUs than a quota now allows.
+func (r *ResourceManager) queuedRunFitsGPUQuota(run *model.RunDetail) (bool, error) {
+	// The quotas are the ones of the cluster of the API server.
+	if r.gpuQuota == nil || run.ExecutionTarget != "" {
+		return true, nil
+	}
+	// The steps request the GPUs of the workflow of the pipeline.
+	var workflow util.Workflow
+	if err := json.Unmarshal([]byte(run.WorkflowSpecManifest), &workflow); err != nil {
+		return false, util.NewInternalServerError(err, "Failed to unmarshal the workflow of run %v", run.UUID)
+	}
+	return r.gpuQuota.check(r.namespace, &workflow, true)
+}
@@ -0,0 +1,155 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func gpuResources(count string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{"nvidia.com/gpu": k8sresource.MustParse(count)},
+	}
+}
+
+func gpuResourceQuota(hard string, used string) corev1.ResourceQuota {
+	return corev1.ResourceQuota{
+		ObjectMeta: v1.ObjectMeta{Name: "gpus"},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{"requests.nvidia.com/gpu": k8sresource.MustParse(hard)}},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{"requests.nvidia.com/gpu": k8sresource.MustParse(hard)},
+			Used: corev1.ResourceList{"requests.nvidia.com/gpu": k8sresource.MustParse(used)},
+		},
+	}
+}
+
+// initWithGPUQuota creates a pipeline whose step requests 2 GPUs, checked against a quota.
+func initWithGPUQuota(t *testing.T, mode string, quota corev1.ResourceQuota) (*FakeClientManager, *ResourceManager, string) {
+	store, err := NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	assert.Nil(t, store.SetGPUQuota(mode, quota))
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(&uidWorkflowClient{FakeWorkflowClient: store.workflowClientFake},
+		store.podClientFake)
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+		ObjectMeta: v1.ObjectMeta{Name: "workflow-name"},
+		Spec: v1alpha1.WorkflowSpec{Entrypoint: "train", Templates: []v1alpha1.Template{
+			{Name: "train", Container: &corev1.Container{Image: "train", Resources: gpuResources("2")}},
+		}},
+	})
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(workflow.ToStringForStore()))
+	assert.Nil(t, err)
+	return store, manager, pipeline.UUID
+}
+
+func TestNewGPUQuota_UnknownMode(t *testing.T) {
+	_, err := NewGPUQuota(nil, []string{"nvidia.com/gpu"}, "warn")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestGPUQuota_MaxStepGPUs(t *testing.T) {
+	quota, err := NewGPUQuota(nil, []string{"nvidia.com/gpu", "amd.com/gpu"}, GPUQuotaModeQueue)
+	assert.Nil(t, err)
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{Spec: v1alpha1.WorkflowSpec{Templates: []v1alpha1.Template{
+		{Name: "dag", DAG: &v1alpha1.DAGTemplate{}},
+		{Name: "train", Container: &corev1.Container{Resources: gpuResources("2")}},
+		{Name: "evaluate", Script: &v1alpha1.ScriptTemplate{Container: corev1.Container{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{"nvidia.com/gpu": k8sresource.MustParse("1")}},
+		}}, Sidecars: []v1alpha1.Sidecar{{Container: corev1.Container{Resources: gpuResources("2")}}}},
+	}}})
+
+	assert.Equal(t, []stepGPUs{{step: "evaluate", resourceName: "nvidia.com/gpu", count: 3}}, quota.maxStepGPUs(workflow))
+}
+
+func TestCreateRun_GPUQuotaFits(t *testing.T) {
+	store, manager, pipelineId := initWithGPUQuota(t, GPUQuotaModeQueue, gpuResourceQuota("4", "2"))
+	defer store.Close()
+
+	run, err := createRunOfPipeline(manager, pipelineId)
+	assert.Nil(t, err)
+	assert.NotEqual(t, util.RunConditionQueued, run.Conditions)
+}
+
+func TestCreateRun_GPUQuotaNeverFits(t *testing.T) {
+	for _, mode := range []string{GPUQuotaModeQueue, GPUQuotaModeReject} {
+		store, manager, pipelineId := initWithGPUQuota(t, mode, gpuResourceQuota("1", "0"))
+
+		_, err := createRunOfPipeline(manager, pipelineId)
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.ResourceExhausted), mode)
+		assert.Contains(t, err.Error(), "Step train requests 2 nvidia.com/gpu, more than the 1 allowed")
+		store.Close()
+	}
+}
+
+func TestCreateRun_GPUQuotaQueued(t *testing.T) {
+	store, manager, pipelineId := initWithGPUQuota(t, GPUQuotaModeQueue, gpuResourceQuota("4", "3"))
+	defer store.Close()
+
+	run, err := createRunOfPipeline(manager, pipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, util.RunConditionQueued, run.Conditions)
+}
+
+func TestCreateRun_GPUQuotaRejected(t *testing.T) {
+	store, manager, pipelineId := initWithGPUQuota(t, GPUQuotaModeReject, gpuResourceQuota("4", "3"))
+	defer store.Close()
+
+	_, err := createRunOfPipeline(manager, pipelineId)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.ResourceExhausted))
+	assert.Contains(t, err.Error(), "has 1 of 4 left")
+}
+
+func TestReconcileQueuedRuns_GPUQuota(t *testing.T) {
+	store, manager, pipelineId := initWithGPUQuota(t, GPUQuotaModeQueue, gpuResourceQuota("4", "3"))
+	defer store.Close()
+	queued, err := createRunOfPipeline(manager, pipelineId)
+	assert.Nil(t, err)
+
+	// The GPUs are still in use.
+	assert.Nil(t, manager.ReconcileQueuedRuns())
+	run, err := manager.GetRun(queued.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, util.RunConditionQueued, run.Conditions)
+
+	store.resourceQuotaClientFake.SetResourceQuotas(fakeNamespace, gpuResourceQuota("4", "1"))
+	assert.Nil(t, manager.ReconcileQueuedRuns())
+	run, err = manager.GetRun(queued.UUID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, util.RunConditionQueued, run.Conditions)
+}
+
+func TestReconcileQueuedRuns_GPUQuotaNeverFits(t *testing.T) {
+	store, manager, pipelineId := initWithGPUQuota(t, GPUQuotaModeQueue, gpuResourceQuota("4", "3"))
+	defer store.Close()
+	queued, err := createRunOfPipeline(manager, pipelineId)
+	assert.Nil(t, err)
+
+	// The quota shrank below the GPUs of the step: the run stays queued, without failing.
+	store.resourceQuotaClientFake.SetResourceQuotas(fakeNamespace, gpuResourceQuota("1", "0"))
+	assert.Nil(t, manager.ReconcileQueuedRuns())
+	run, err := manager.GetRun(queued.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, util.RunConditionQueued, run.Conditions)
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// MaintenanceResult is the outcome of a maintenance operation over many resources. The
+// operation carries on past the resources which fail, whose errors are collected.
+type MaintenanceResult struct {
+	Processed int
+	Errors    []string
+}
+
+func (r *MaintenanceResult) fail(err error) {
+	glog.Errorf("%+v", err)
+	r.Errors = append(r.Errors, err.Error())
+}
+
+// maintenanceItem is a resource processed by a maintenance operation.
+type maintenanceItem struct {
+	id      string
+	process func() error
+}
+
+// listMaintenanceItems lists the resources processed by a maintenance operation, in the
+// order of their IDs.
+func (r *ResourceManager) listMaintenanceItems(operationType model.OperationType) ([]maintenanceItem, error) {
+	var items []maintenanceItem
+	switch operationType {
+	case model.OperationReindexPipelines:
+		statuses, err := r.pipelineStore.GetPipelineStatuses()
+		if err != nil {
+			return nil, err
+		}
+		for id, status := range statuses {
+			if status == model.PipelineReady {
+				id := id
+				items = append(items, maintenanceItem{id: id, process: func() error { return r.reindexPipeline(id) }})
+			}
+		}
+	case model.OperationRecomputeRunStatuses:
+		runs, err := r.runStore.ListUnfinishedRuns(r.time.Now().Unix() + 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs {
+			run := run
+			items = append(items, maintenanceItem{id: run.UUID, process: func() error { return r.recomputeRunStatus(&run) }})
+		}
+	default:
+		return nil, util.NewInvalidInputError("Unknown maintenance operation %v", operationType)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].id < items[j].id })
+	return items, nil
+}
+
+func (r *ResourceManager) runMaintenance(operationType model.OperationType) (*MaintenanceResult, error) {
+	items, err := r.listMaintenanceItems(operationType)
+	if err != nil {
+		return nil, err
+	}
+	result := &MaintenanceResult{}
+	for _, item := range items {
+		if err := item.process(); err != nil {
+			result.fail(err)
+			continue
+		}
+		result.Processed++
+	}
+	return result, nil
+}
+
+// ReindexPipelines indexes the steps of the ready pipelines again from their templates, e.g.
+// once the compiler indexes more of them.
+func (r *ResourceManager) ReindexPipelines() (*MaintenanceResult, error) {
+	result, err := r.runMaintenance(model.OperationReindexPipelines)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to reindex the pipelines")
+	}
+	glog.Infof("Reindexed %v pipelines", result.Processed)
+	return result, nil
+}
+
+func (r *ResourceManager) reindexPipeline(id string) error {
+	pipeline, err := r.pipelineStore.GetPipeline(id)
+	if err != nil {
+		return util.Wrapf(err, "Failed to reindex pipeline %v", id)
+	}
+	if _, err := r.indexPipelineSteps(pipeline); err != nil {
+		return util.Wrapf(err, "Failed to reindex pipeline %v", id)
+	}
+	return nil
+}
+
+// RecomputeRunStatuses updates the unfinished runs from their live workflows, as if the
+// workflows were reported again, e.g. after the persistence agent was down. The reports
+// are applied even if they were before, so that the runs which drifted are repaired.
+func (r *ResourceManager) RecomputeRunStatuses() (*MaintenanceResult, error) {
+	result, err := r.runMaintenance(model.OperationRecomputeRunStatuses)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to recompute the run statuses")
+	}
+	glog.Infof("Recomputed the status of %v runs", result.Processed)
+	return result, nil
+}
+
+func (r *ResourceManager) recomputeRunStatus(run *model.Run) error {
+	runEngine, err := r.runEngine(run)
+	if err != nil {
+		return util.Wrapf(err, "Failed to recompute the status of run %v", run.UUID)
+	}
+	workflow, err := runEngine.Get(run.Name)
+	if err != nil || string(workflow.UID) != run.UUID {
+		// The orphan reconciler marks the runs whose workflow vanished as errored.
+		return fmt.Errorf("The workflow %v of run %v wasn't found", run.Name, run.UUID)
+	}
+	if err := r.reportWorkflowResource(workflow); err != nil {
+		return util.Wrapf(err, "Failed to recompute the status of run %v", run.UUID)
+	}
+	if err := r.reportDeduplicator.Record(workflow); err != nil {
+		glog.Warningf("Failed to record the report of workflow %v: %v", workflow.Name, err)
+	}
+	return nil
+}
+
+// FlushCaches invalidates the cached pipeline templates and namespace configurations of this
+// replica. The number of entries flushed is returned as processed.
+func (r *ResourceManager) FlushCaches() *MaintenanceResult {
+	result := &MaintenanceResult{Processed: r.templateCache.Purge()}
+	if r.namespaceConfigs != nil {
+		result.Processed += r.namespaceConfigs.Purge()
+	}
+	glog.Infof("Flushed %v cache entries", result.Processed)
+	return result
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReindexPipelines(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testDAGWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	assert.Nil(t, store.PipelineStore().CreatePipelineSteps(pipeline.UUID, nil))
+
+	result, err := manager.ReindexPipelines()
+	assert.Nil(t, err)
+	assert.Equal(t, &MaintenanceResult{Processed: 1}, result)
+	steps, err := store.PipelineStore().ListPipelineSteps(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Len(t, steps, 2)
+}
+
+func TestReindexPipelines_PackageMissing(t *testing.T) {
+	store, manager, pipeline := initWithPipeline(t)
+	defer store.Close()
+	assert.Nil(t, store.ObjectStore().DeleteFile(storage.CreatePipelinePath(pipeline.UUID)))
+
+	result, err := manager.ReindexPipelines()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.Processed)
+	assert.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "Failed to reindex pipeline "+pipeline.UUID)
+}
+
+func TestRecomputeRunStatuses(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow, err := store.workflowClientFake.Get(runDetail.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	workflow.Status.Phase = v1alpha1.NodeSucceeded
+	_, err = store.workflowClientFake.Update(workflow)
+	assert.Nil(t, err)
+
+	result, err := manager.RecomputeRunStatuses()
+	assert.Nil(t, err)
+	assert.Equal(t, &MaintenanceResult{Processed: 1}, result)
+	run, err := manager.GetRun(runDetail.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", run.Conditions)
+	// The finished runs aren't recomputed again.
+	result, err = manager.RecomputeRunStatuses()
+	assert.Nil(t, err)
+	assert.Equal(t, &MaintenanceResult{}, result)
+}
+
+func TestRecomputeRunStatuses_WorkflowDeleted(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	assert.Nil(t, store.workflowClientFake.Delete(runDetail.Name, nil))
+
+	result, err := manager.RecomputeRunStatuses()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.Processed)
+	assert.Equal(t, []string{"The workflow workflow-name of run workflow1 wasn't found"}, result.Errors)
+}
+
+func TestFlushCaches(t *testing.T) {
+	store, manager, pipeline := initWithPipeline(t)
+	defer store.Close()
+	_, err := manager.GetPipelineTemplate(pipeline.UUID)
+	assert.Nil(t, err)
+
+	assert.Equal(t, &MaintenanceResult{Processed: 1}, manager.FlushCaches())
+	assert.Equal(t, &MaintenanceResult{}, manager.FlushCaches())
+}
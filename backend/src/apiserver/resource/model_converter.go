@@ -16,12 +16,14 @@ package resource
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func ToModelRunMetric(metric *api.RunMetric, runUUID string) *model.RunMetric {
@@ -37,7 +39,7 @@ func ToModelRunMetric(metric *api.RunMetric, runUUID string) *model.RunMetric {
 // The input run might not contain workflowSpecManifest, but instead a pipeline ID.
 // The caller would retrieve workflowSpecManifest and pass in.
 func ToModelRunDetail(run *api.Run, workflow *util.Workflow, workflowSpecManifest string) (*model.RunDetail, error) {
-	params, err := toModelParameters(run.PipelineSpec.Parameters)
+	params, err := toModelParameters(run.PipelineSpec.Parameters, workflow.SecretParameterNames())
 	if err != nil {
 		return nil, util.Wrap(err, "Unable to parse the parameter.")
 	}
@@ -49,12 +51,13 @@ func ToModelRunDetail(run *api.Run, workflow *util.Workflow, workflowSpecManifes
 	return &model.RunDetail{
 		Run: model.Run{
 			UUID:               string(workflow.UID),
-			DisplayName:        run.Name,
+			DisplayName:        util.NormalizeName(run.Name),
 			Name:               workflow.Name,
 			Namespace:          workflow.Namespace,
 			Conditions:         workflow.Condition(),
 			Description:        run.Description,
 			ResourceReferences: resourceReferences,
+			ExecutionTarget:    run.ExecutionTarget,
 			PipelineSpec: model.PipelineSpec{
 				PipelineId:           run.PipelineSpec.GetPipelineId(),
 				WorkflowSpecManifest: workflowSpecManifest,
@@ -68,7 +71,7 @@ func ToModelRunDetail(run *api.Run, workflow *util.Workflow, workflowSpecManifes
 }
 
 func ToModelJob(job *api.Job, swf *util.ScheduledWorkflow, workflowSpecManifest string) (*model.Job, error) {
-	params, err := toModelParameters(job.PipelineSpec.Parameters)
+	params, err := toModelParameters(job.PipelineSpec.Parameters, swf.SecretParameterNames())
 	if err != nil {
 		return nil, util.Wrap(err, "Error parsing the input job.")
 	}
@@ -79,7 +82,7 @@ func ToModelJob(job *api.Job, swf *util.ScheduledWorkflow, workflowSpecManifest
 
 	return &model.Job{
 		UUID:               string(swf.UID),
-		DisplayName:        job.Name,
+		DisplayName:        util.NormalizeName(job.Name),
 		Name:               swf.Name,
 		Namespace:          swf.Namespace,
 		Description:        job.Description,
@@ -126,15 +129,21 @@ func toModelTrigger(trigger *api.Trigger) model.Trigger {
 	return modelTrigger
 }
 
-func toModelParameters(apiParams []*api.Parameter) (string, error) {
+// toModelParameters returns the JSON of the parameters to store, with the values of the
+// secret ones hashed.
+func toModelParameters(apiParams []*api.Parameter, secrets map[string]bool) (string, error) {
 	if apiParams == nil || len(apiParams) == 0 {
 		return "", nil
 	}
 	var params []v1alpha1.Parameter
 	for _, apiParam := range apiParams {
+		value := apiParam.Value
+		if secrets[apiParam.Name] {
+			value = util.HashSecretParameterValue(value)
+		}
 		param := v1alpha1.Parameter{
 			Name:  apiParam.Name,
-			Value: &apiParam.Value,
+			Value: &value,
 		}
 		params = append(params, param)
 	}
@@ -168,3 +177,124 @@ func toModelResourceReferences(
 	}
 	return modelRefs, nil
 }
+
+// ToModelArtifactEvents returns the artifacts consumed and produced by the steps of a
+// workflow, as the events indexed in its artifact lineage.
+func ToModelArtifactEvents(workflow *util.Workflow) []*model.ArtifactEvent {
+	var events []*model.ArtifactEvent
+	addEvents := func(node v1alpha1.NodeStatus, artifacts []v1alpha1.Artifact, eventType model.ArtifactEventType,
+		timestamp metav1.Time) {
+		for _, artifact := range artifacts {
+			uri := util.ArtifactURI(artifact)
+			if uri == "" {
+				continue
+			}
+			event := &model.ArtifactEvent{
+				RunUUID:      string(workflow.UID),
+				NodeID:       node.ID,
+				ArtifactName: artifact.Name,
+				Type:         eventType,
+				ArtifactURI:  uri,
+				StepName:     node.DisplayName,
+			}
+			if !timestamp.IsZero() {
+				event.CreatedAtInSec = timestamp.Unix()
+			}
+			events = append(events, event)
+		}
+	}
+	for _, node := range workflow.Status.Nodes {
+		if node.Type != v1alpha1.NodeTypePod {
+			continue
+		}
+		if node.Inputs != nil {
+			addEvents(node, node.Inputs.Artifacts, model.ArtifactEventInput, node.StartedAt)
+		}
+		if node.Outputs != nil {
+			addEvents(node, node.Outputs.Artifacts, model.ArtifactEventOutput, node.FinishedAt)
+		}
+	}
+	return events
+}
+
+func toModelPipelineSteps(pipelineId string, steps []util.PipelineStep) ([]*model.PipelineStep, error) {
+	modelSteps := make([]*model.PipelineStep, 0, len(steps))
+	for i, step := range steps {
+		children := step.Children
+		if children == nil {
+			children = []string{}
+		}
+		childrenBytes, err := json.Marshal(children)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to marshal the children of step %v", step.Name)
+		}
+		modelSteps = append(modelSteps, &model.PipelineStep{
+			PipelineUUID: pipelineId,
+			Name:         step.Name,
+			Position:     i,
+			Type:         step.Type,
+			Image:        step.Image,
+			Children:     string(childrenBytes),
+		})
+	}
+	return modelSteps, nil
+}
+
+// ToModelRunStateTransitions returns the transitions of the workflow and of its nodes to
+// their current state, for the ones whose state differs from the last one in the history.
+// The transitions are timed with the start or the end of the workflow or the node when
+// the state is its first or its final one, and with nowInSec otherwise. As the report may
+// be processed after the end, a transition is never timed before the previous one of its
+// node, so that the history stays in the order of the states.
+func ToModelRunStateTransitions(workflow *util.Workflow, history []*model.RunStateTransition,
+	nowInSec int64) []*model.RunStateTransition {
+	// The history is in chronological order, so the last transition of a node is its state.
+	last := make(map[string]*model.RunStateTransition)
+	for _, transition := range history {
+		if previous, ok := last[transition.NodeID]; !ok || transition.Sequence > previous.Sequence {
+			last[transition.NodeID] = transition
+		}
+	}
+	var transitions []*model.RunStateTransition
+	add := func(nodeId string, nodeName string, state string, message string, startedAt metav1.Time,
+		finishedAt metav1.Time) {
+		if state == "" {
+			return
+		}
+		transition := &model.RunStateTransition{
+			RunUUID:   string(workflow.UID),
+			NodeID:    nodeId,
+			NodeName:  nodeName,
+			State:     state,
+			TimeInSec: nowInSec,
+			Message:   message,
+		}
+		previous, ok := last[nodeId]
+		if ok {
+			if previous.State == state {
+				return
+			}
+			transition.Sequence = previous.Sequence + 1
+		}
+		if util.IsFinalCondition(state) && !finishedAt.IsZero() {
+			transition.TimeInSec = finishedAt.Unix()
+		} else if !ok && !startedAt.IsZero() {
+			transition.TimeInSec = startedAt.Unix()
+		}
+		if ok && transition.TimeInSec < previous.TimeInSec {
+			transition.TimeInSec = previous.TimeInSec
+		}
+		transitions = append(transitions, transition)
+	}
+	add("", "", workflow.Condition(), workflow.Status.Message, workflow.Status.StartedAt, workflow.Status.FinishedAt)
+	nodeIds := make([]string, 0, len(workflow.Status.Nodes))
+	for nodeId := range workflow.Status.Nodes {
+		nodeIds = append(nodeIds, nodeId)
+	}
+	sort.Strings(nodeIds)
+	for _, nodeId := range nodeIds {
+		node := workflow.Status.Nodes[nodeId]
+		add(nodeId, node.DisplayName, string(node.Phase), node.Message, node.StartedAt, node.FinishedAt)
+	}
+	return transitions
+}
@@ -186,3 +186,54 @@ func TestToModelResourceReferences_UnknownRelationship(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Failed to convert relationship")
 }
+
+func TestToModelRunStateTransitions(t *testing.T) {
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{UID: "run1"},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:   v1alpha1.NodeRunning,
+			Message: "resumed",
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {ID: "node1", DisplayName: "train", Phase: v1alpha1.NodeSucceeded,
+					StartedAt: v1.Unix(10, 0), FinishedAt: v1.Unix(25, 0)},
+				"node2": {ID: "node2", DisplayName: "evaluate", Phase: v1alpha1.NodePending},
+				"node3": {ID: "node3", DisplayName: "export", Phase: v1alpha1.NodeRunning, StartedAt: v1.Unix(12, 0)},
+			},
+		},
+	})
+	history := []*model.RunStateTransition{
+		{RunUUID: "run1", State: "Queued", TimeInSec: 5},
+		{RunUUID: "run1", NodeID: "node1", NodeName: "train", State: "Running", TimeInSec: 10},
+		{RunUUID: "run1", NodeID: "node3", NodeName: "export", State: "Running", TimeInSec: 12},
+	}
+
+	// The run and the nodes which are still in their last recorded state have no transition.
+	assert.Equal(t, []*model.RunStateTransition{
+		{RunUUID: "run1", Sequence: 1, State: "Running", TimeInSec: 100, Message: "resumed"},
+		{RunUUID: "run1", NodeID: "node1", Sequence: 1, NodeName: "train", State: "Succeeded", TimeInSec: 25},
+		{RunUUID: "run1", NodeID: "node2", NodeName: "evaluate", State: "Pending", TimeInSec: 100},
+	}, ToModelRunStateTransitions(workflow, history, 100))
+}
+
+func TestToModelRunStateTransitions_DelayedReport(t *testing.T) {
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{UID: "run1"},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.NodeRunning,
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {ID: "node1", DisplayName: "train", Phase: v1alpha1.NodeSucceeded,
+					StartedAt: v1.Unix(100, 0), FinishedAt: v1.Unix(120, 0)},
+			},
+		},
+	})
+	// The node was seen running only when a report was processed after it had finished.
+	history := []*model.RunStateTransition{
+		{RunUUID: "run1", State: "Running", TimeInSec: 100},
+		{RunUUID: "run1", NodeID: "node1", NodeName: "train", State: "Pending", TimeInSec: 100},
+		{RunUUID: "run1", NodeID: "node1", Sequence: 1, NodeName: "train", State: "Running", TimeInSec: 130},
+	}
+
+	assert.Equal(t, []*model.RunStateTransition{
+		{RunUUID: "run1", NodeID: "node1", Sequence: 2, NodeName: "train", State: "Succeeded", TimeInSec: 130},
+	}, ToModelRunStateTransitions(workflow, history, 140))
+}
@@ -0,0 +1,193 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+)
+
+// The keys of the ConfigMap overriding the configuration of the runs of a namespace.
+const (
+	namespaceServiceAccountKey = "defaultServiceAccount"
+	namespaceArtifactBucketKey = "artifactBucket"
+	namespaceArtifactPrefixKey = "artifactKeyPrefix"
+	namespacePipelineRootKey   = "defaultPipelineRoot"
+	namespaceTTLKey            = "ttlSecondsAfterFinished"
+	namespacePullSecretsKey    = "imagePullSecrets"
+	namespaceMirrorsKey        = "registryMirrors"
+)
+
+// NamespaceConfig overrides the configuration of the workflows of the runs and the jobs of a
+// namespace. The fields left unset keep the configuration of the API server.
+type NamespaceConfig struct {
+	// The service account the pods of the workflows run as.
+	ServiceAccount string
+	// Where the workflows store their artifacts, unless the runs set it.
+	ArtifactRepository *api.ArtifactRepository
+	// How long the workflows are kept once they finish, unless they set it.
+	TTLSecondsAfterFinished *int32
+	// The secrets the pods of the workflows pull their images with, in addition to theirs.
+	ImagePullSecrets []string
+	// The mirrors the images of the workflows are pulled from instead of their registry or
+	// repository, by registry or repository.
+	RegistryMirrors map[string]string
+}
+
+// parseNamespaceConfig parses the data of the ConfigMap of a namespace.
+func parseNamespaceConfig(data map[string]string) (*NamespaceConfig, error) {
+	config := &NamespaceConfig{ServiceAccount: data[namespaceServiceAccountKey]}
+	if bucket, keyPrefix := data[namespaceArtifactBucketKey], data[namespaceArtifactPrefixKey]; bucket != "" || keyPrefix != "" {
+		config.ArtifactRepository = &api.ArtifactRepository{Bucket: bucket, KeyPrefix: keyPrefix}
+	}
+	if root := data[namespacePipelineRootKey]; root != "" {
+		if config.ArtifactRepository != nil {
+			return nil, util.NewInvalidInputError("The %v can't be set with the %v or the %v",
+				namespacePipelineRootKey, namespaceArtifactBucketKey, namespaceArtifactPrefixKey)
+		}
+		repository, err := ParsePipelineRoot(root)
+		if err != nil {
+			return nil, err
+		}
+		config.ArtifactRepository = repository
+	}
+	if value, ok := data[namespaceTTLKey]; ok {
+		ttl, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || ttl < 0 {
+			return nil, util.NewInvalidInputError("The %v %q isn't a non-negative number of seconds", namespaceTTLKey, value)
+		}
+		ttl32 := int32(ttl)
+		config.TTLSecondsAfterFinished = &ttl32
+	}
+	config.ImagePullSecrets = splitList(data[namespacePullSecretsKey])
+	for _, pair := range splitList(data[namespaceMirrorsKey]) {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, util.NewInvalidInputError("The %v %q isn't a list of registry=mirror pairs", namespaceMirrorsKey, data[namespaceMirrorsKey])
+		}
+		if config.RegistryMirrors == nil {
+			config.RegistryMirrors = make(map[string]string)
+		}
+		config.RegistryMirrors[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return config, nil
+}
+
+// splitList returns the non-empty items of a comma-separated list.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// workflowDefaults returns the workflow options applied to the runs of the namespace not
+// setting them.
+func (c *NamespaceConfig) workflowDefaults(defaults *api.WorkflowOptions) *api.WorkflowOptions {
+	if c.ArtifactRepository == nil {
+		return defaults
+	}
+	merged := &api.WorkflowOptions{}
+	if defaults != nil {
+		merged = proto.Clone(defaults).(*api.WorkflowOptions)
+	}
+	merged.ArtifactRepository = c.ArtifactRepository
+	return merged
+}
+
+// apply sets the service account, the TTL, the image pull secrets and the registry mirrors
+// of a workflow of the namespace.
+func (c *NamespaceConfig) apply(workflow *util.Workflow) {
+	if c.ServiceAccount != "" {
+		workflow.SetServiceAccount(c.ServiceAccount)
+	}
+	if c.TTLSecondsAfterFinished != nil {
+		workflow.SetDefaultTTLSecondsAfterFinished(*c.TTLSecondsAfterFinished)
+	}
+	workflow.SetImagePullSecrets(c.ImagePullSecrets)
+	workflow.SetImageRegistryMirrors(c.RegistryMirrors)
+}
+
+// NamespaceConfigs reads the configuration of the namespaces from a ConfigMap of each
+// namespace, and caches it for the TTL so that creating a run doesn't read it every time.
+type NamespaceConfigs struct {
+	client        client.ConfigMapClientInterface
+	configMapName string
+	ttl           time.Duration
+	time          util.TimeInterface
+
+	mutex   sync.Mutex
+	configs map[string]*cachedNamespaceConfig
+}
+
+type cachedNamespaceConfig struct {
+	config    *NamespaceConfig
+	expiresAt time.Time
+}
+
+// NewNamespaceConfigs creates a reader of the ConfigMaps of the given name. The ConfigMaps are
+// read again after the TTL, or every time if it isn't positive.
+func NewNamespaceConfigs(client client.ConfigMapClientInterface, configMapName string, ttl time.Duration,
+	time util.TimeInterface) *NamespaceConfigs {
+	return &NamespaceConfigs{
+		client:        client,
+		configMapName: configMapName,
+		ttl:           ttl,
+		time:          time,
+		configs:       make(map[string]*cachedNamespaceConfig),
+	}
+}
+
+// Get returns the configuration of a namespace, which is empty if the namespace has no
+// ConfigMap.
+func (c *NamespaceConfigs) Get(namespace string) (*NamespaceConfig, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if entry, ok := c.configs[namespace]; ok && c.time.Now().Before(entry.expiresAt) {
+		return entry.config, nil
+	}
+	data, err := c.client.GetConfigMapData(namespace, c.configMapName)
+	if err != nil && !util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		return nil, util.Wrapf(err, "Failed to read the configuration of namespace %v", namespace)
+	}
+	config, err := parseNamespaceConfig(data)
+	if err != nil {
+		return nil, util.Wrapf(err, "Invalid ConfigMap %v/%v", namespace, c.configMapName)
+	}
+	if c.ttl > 0 {
+		c.configs[namespace] = &cachedNamespaceConfig{config: config, expiresAt: c.time.Now().Add(c.ttl)}
+	}
+	return config, nil
+}
+
+// Purge invalidates all the configurations, returning how many were cached.
+func (c *NamespaceConfigs) Purge() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	count := len(c.configs)
+	c.configs = make(map[string]*cachedNamespaceConfig)
+	return count
+}
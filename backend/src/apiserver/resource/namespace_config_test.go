@@ -0,0 +1,122 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNamespaceConfig(t *testing.T) {
+	config, err := parseNamespaceConfig(map[string]string{
+		"defaultServiceAccount":   "team-a-runner",
+		"artifactBucket":          "team-a",
+		"ttlSecondsAfterFinished": "3600",
+	})
+	assert.Nil(t, err)
+	ttl := int32(3600)
+	assert.Equal(t, &NamespaceConfig{
+		ServiceAccount:          "team-a-runner",
+		ArtifactRepository:      &api.ArtifactRepository{Bucket: "team-a"},
+		TTLSecondsAfterFinished: &ttl,
+	}, config)
+
+	config, err = parseNamespaceConfig(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &NamespaceConfig{}, config)
+
+	_, err = parseNamespaceConfig(map[string]string{"ttlSecondsAfterFinished": "1h"})
+	assert.NotNil(t, err)
+
+	config, err = parseNamespaceConfig(map[string]string{"defaultPipelineRoot": "minio://team-a/runs"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ArtifactRepository{Bucket: "team-a", KeyPrefix: "runs"}, config.ArtifactRepository)
+	// The pipeline root and the artifact repository are alternatives.
+	_, err = parseNamespaceConfig(map[string]string{"defaultPipelineRoot": "minio://team-a/runs", "artifactBucket": "team-a"})
+	assert.NotNil(t, err)
+
+	config, err = parseNamespaceConfig(map[string]string{
+		"imagePullSecrets": "registry-a, registry-b,",
+		"registryMirrors":  "docker.io=mirror.corp/dockerhub, gcr.io = mirror.corp/gcr",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"registry-a", "registry-b"}, config.ImagePullSecrets)
+	assert.Equal(t, map[string]string{"docker.io": "mirror.corp/dockerhub", "gcr.io": "mirror.corp/gcr"},
+		config.RegistryMirrors)
+	_, err = parseNamespaceConfig(map[string]string{"registryMirrors": "docker.io"})
+	assert.NotNil(t, err)
+}
+
+func TestNamespaceConfigWorkflowDefaults(t *testing.T) {
+	defaults := &api.WorkflowOptions{
+		PodGcStrategy:      api.WorkflowOptions_ON_WORKFLOW_SUCCESS,
+		ArtifactRepository: &api.ArtifactRepository{Bucket: "mlpipeline"},
+	}
+	config := &NamespaceConfig{ArtifactRepository: &api.ArtifactRepository{Bucket: "team-a", KeyPrefix: "runs"}}
+	assert.Equal(t, &api.WorkflowOptions{
+		PodGcStrategy:      api.WorkflowOptions_ON_WORKFLOW_SUCCESS,
+		ArtifactRepository: &api.ArtifactRepository{Bucket: "team-a", KeyPrefix: "runs"},
+	}, config.workflowDefaults(defaults))
+	// The defaults of the server are left as they are.
+	assert.Equal(t, "mlpipeline", defaults.ArtifactRepository.Bucket)
+	assert.Equal(t, defaults, (&NamespaceConfig{}).workflowDefaults(defaults))
+}
+
+func TestNamespaceConfigs_Get(t *testing.T) {
+	configMaps := client.NewFakeConfigMapClient()
+	configMaps.SetConfigMap("team-a", "config", map[string]string{"defaultServiceAccount": "team-a-runner"})
+	fakeTime := util.NewManualFakeTime(time.Unix(0, 0))
+	configs := NewNamespaceConfigs(configMaps, "config", time.Minute, fakeTime)
+
+	config, err := configs.Get("team-a")
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a-runner", config.ServiceAccount)
+	// A namespace without a ConfigMap keeps the configuration of the server.
+	config, err = configs.Get("team-b")
+	assert.Nil(t, err)
+	assert.Equal(t, &NamespaceConfig{}, config)
+
+	// The configuration is read again once expired.
+	configMaps.SetConfigMap("team-a", "config", map[string]string{"defaultServiceAccount": "runner"})
+	config, _ = configs.Get("team-a")
+	assert.Equal(t, "team-a-runner", config.ServiceAccount)
+	assert.Equal(t, 2, configMaps.Reads())
+	fakeTime.Advance(time.Minute)
+	config, _ = configs.Get("team-a")
+	assert.Equal(t, "runner", config.ServiceAccount)
+	assert.Equal(t, 3, configMaps.Reads())
+
+	configMaps.SetConfigMap("team-c", "config", map[string]string{"ttlSecondsAfterFinished": "-1"})
+	_, err = configs.Get("team-c")
+	assert.NotNil(t, err)
+}
+
+func TestNamespaceConfigs_Purge(t *testing.T) {
+	configMaps := client.NewFakeConfigMapClient()
+	configMaps.SetConfigMap("team-a", "config", map[string]string{"defaultServiceAccount": "team-a-runner"})
+	configs := NewNamespaceConfigs(configMaps, "config", time.Minute, util.NewManualFakeTime(time.Unix(0, 0)))
+
+	configs.Get("team-a")
+	configMaps.SetConfigMap("team-a", "config", map[string]string{"defaultServiceAccount": "runner"})
+	assert.Equal(t, 1, configs.Purge())
+	config, err := configs.Get("team-a")
+	assert.Nil(t, err)
+	assert.Equal(t, "runner", config.ServiceAccount)
+}
@@ -0,0 +1,179 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// The number of resources an operation processes before recording its progress and
+	// checking whether it was cancelled.
+	operationBatchSize = 50
+	// The number of errors kept by an operation, the later ones being only counted as
+	// processed.
+	maxOperationErrors = 100
+)
+
+// StartOperation creates a running operation, processed in the background by the operation
+// worker.
+func (r *ResourceManager) StartOperation(operationType model.OperationType) (*model.Operation, error) {
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate the operation ID")
+	}
+	operation := &model.Operation{
+		UUID:           uuid.String(),
+		Type:           operationType,
+		State:          model.OperationRunning,
+		CreatedAtInSec: r.time.Now().Unix(),
+		Errors:         "[]",
+	}
+	if err := r.operationStore.CreateOperation(operation); err != nil {
+		return nil, util.Wrap(err, "Failed to create the operation")
+	}
+	return operation, nil
+}
+
+func (r *ResourceManager) GetOperation(operationId string) (*model.Operation, error) {
+	operation, err := r.operationStore.GetOperation(operationId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the operation")
+	}
+	return operation, nil
+}
+
+func (r *ResourceManager) ListOperations(context *common.PaginationContext) ([]model.Operation, string, error) {
+	operations, nextPageToken, err := r.operationStore.ListOperations(context)
+	if err != nil {
+		return nil, "", util.Wrap(err, "Failed to list the operations")
+	}
+	return operations, nextPageToken, nil
+}
+
+// CancelOperation stops an operation after the batch it's processing. Cancelling an operation
+// which isn't running does nothing.
+func (r *ResourceManager) CancelOperation(operationId string) (*model.Operation, error) {
+	if _, err := r.operationStore.GetOperation(operationId); err != nil {
+		return nil, util.Wrap(err, "Failed to cancel the operation")
+	}
+	if err := r.operationStore.FinishOperation(operationId, model.OperationCancelled, r.time.Now().Unix()); err != nil {
+		return nil, util.Wrap(err, "Failed to cancel the operation")
+	}
+	return r.GetOperation(operationId)
+}
+
+// ProcessOperations carries on the running operations until they finish or are cancelled.
+// The progress is recorded after each batch, so that the operations resume after the last
+// batch when the server restarts.
+func (r *ResourceManager) ProcessOperations() error {
+	operations, err := r.operationStore.ListRunningOperations()
+	if err != nil {
+		return util.Wrap(err, "Failed to list the running operations")
+	}
+	var failed []string
+	for _, operation := range operations {
+		if err := r.processOperation(operation); err != nil {
+			glog.Errorf("Failed to process operation %v: %+v", operation.UUID, err)
+			failed = append(failed, operation.UUID)
+		}
+	}
+	if len(failed) > 0 {
+		return util.NewInternalServerError(fmt.Errorf("failed operations: %v", failed),
+			"Failed to process %v operations", len(failed))
+	}
+	return nil
+}
+
+func (r *ResourceManager) processOperation(operation *model.Operation) error {
+	var errors []string
+	if err := json.Unmarshal([]byte(operation.Errors), &errors); err != nil {
+		return util.NewInternalServerError(err, "Failed to parse the errors of the operation")
+	}
+	for {
+		items, err := r.listMaintenanceItems(operation.Type)
+		if err != nil && !util.IsUserErrorCodeMatch(err, codes.InvalidArgument) {
+			// The operation is retried at the next interval.
+			return err
+		}
+		if err != nil {
+			// The operation can't be carried on, its type being unknown to this version.
+			glog.Errorf("Failed to list the resources of operation %v: %+v", operation.UUID, err)
+			operation.Errors, _ = marshalOperationErrors(append(errors, err.Error()))
+			if err := r.operationStore.UpdateProgress(operation); err != nil {
+				return err
+			}
+			return r.operationStore.FinishOperation(operation.UUID, model.OperationFailed, r.time.Now().Unix())
+		}
+		// The resources are ordered by ID, those up to the cursor being processed already.
+		remaining := items
+		for len(remaining) > 0 && remaining[0].id <= operation.Cursor {
+			remaining = remaining[1:]
+		}
+		operation.Total = operation.Processed + int64(len(remaining))
+		if len(remaining) == 0 {
+			if err := r.operationStore.UpdateProgress(operation); err != nil {
+				return err
+			}
+			glog.Infof("Operation %v processed %v resources", operation.UUID, operation.Processed)
+			return r.operationStore.FinishOperation(operation.UUID, model.OperationSucceeded, r.time.Now().Unix())
+		}
+		if len(remaining) > operationBatchSize {
+			remaining = remaining[:operationBatchSize]
+		}
+		for _, item := range remaining {
+			if err := item.process(); err != nil {
+				glog.Errorf("%+v", err)
+				if len(errors) < maxOperationErrors {
+					errors = append(errors, err.Error())
+				}
+			}
+			operation.Processed++
+			operation.Cursor = item.id
+		}
+		if operation.Errors, err = marshalOperationErrors(errors); err != nil {
+			return err
+		}
+		if err := r.operationStore.UpdateProgress(operation); err != nil {
+			return err
+		}
+		stored, err := r.operationStore.GetOperation(operation.UUID)
+		if err != nil {
+			return err
+		}
+		if stored.State != model.OperationRunning {
+			glog.Infof("Operation %v was cancelled after %v resources", operation.UUID, operation.Processed)
+			return nil
+		}
+	}
+}
+
+func marshalOperationErrors(errors []string) (string, error) {
+	if errors == nil {
+		errors = []string{}
+	}
+	marshalled, err := json.Marshal(errors)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to marshal the errors of the operation")
+	}
+	return string(marshalled), nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func initWithPipelines(t *testing.T, count int) (*FakeClientManager, *ResourceManager, []*model.Pipeline) {
+	store, err := NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	manager := NewResourceManager(store)
+	var pipelines []*model.Pipeline
+	for _, name := range []string{"p1", "p2", "p3"}[:count] {
+		pipeline, err := manager.CreatePipeline(name, "", []byte(testDAGWorkflow.ToStringForStore()))
+		assert.Nil(t, err)
+		pipelines = append(pipelines, pipeline)
+	}
+	return store, manager, pipelines
+}
+
+func TestProcessOperations(t *testing.T) {
+	store, manager, pipelines := initWithPipelines(t, 2)
+	defer store.Close()
+	assert.Nil(t, store.ObjectStore().DeleteFile(storage.CreatePipelinePath(pipelines[0].UUID)))
+
+	operation, err := manager.StartOperation(model.OperationReindexPipelines)
+	assert.Nil(t, err)
+	assert.Equal(t, model.OperationRunning, operation.State)
+	assert.Nil(t, manager.ProcessOperations())
+
+	operation, err = manager.GetOperation(operation.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.OperationSucceeded, operation.State)
+	assert.NotZero(t, operation.FinishedAtInSec)
+	// The pipeline which failed counts as processed.
+	assert.Equal(t, int64(2), operation.Total)
+	assert.Equal(t, int64(2), operation.Processed)
+	assert.Equal(t, pipelines[1].UUID, operation.Cursor)
+	assert.Contains(t, operation.Errors, "Failed to reindex pipeline "+pipelines[0].UUID)
+	steps, err := store.PipelineStore().ListPipelineSteps(pipelines[1].UUID)
+	assert.Nil(t, err)
+	assert.Len(t, steps, 2)
+}
+
+func TestProcessOperations_Resume(t *testing.T) {
+	store, manager, pipelines := initWithPipelines(t, 3)
+	defer store.Close()
+	operation, err := manager.StartOperation(model.OperationReindexPipelines)
+	assert.Nil(t, err)
+	// The server stopped after processing the first pipeline.
+	operation.Total = 3
+	operation.Processed = 1
+	operation.Cursor = pipelines[0].UUID
+	assert.Nil(t, store.OperationStore().UpdateProgress(operation))
+	assert.Nil(t, store.PipelineStore().CreatePipelineSteps(pipelines[0].UUID, nil))
+
+	assert.Nil(t, manager.ProcessOperations())
+	operation, err = manager.GetOperation(operation.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.OperationSucceeded, operation.State)
+	assert.Equal(t, int64(3), operation.Processed)
+	assert.Equal(t, "[]", operation.Errors)
+	// The pipeline processed before isn't processed again.
+	steps, err := store.PipelineStore().ListPipelineSteps(pipelines[0].UUID)
+	assert.Nil(t, err)
+	assert.Empty(t, steps)
+}
+
+func TestProcessOperations_UnknownType(t *testing.T) {
+	store, manager, _ := initWithPipelines(t, 0)
+	defer store.Close()
+	operation, err := manager.StartOperation("UNKNOWN")
+	assert.Nil(t, err)
+
+	assert.Nil(t, manager.ProcessOperations())
+	operation, err = manager.GetOperation(operation.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.OperationFailed, operation.State)
+	assert.Contains(t, operation.Errors, "Unknown maintenance operation UNKNOWN")
+}
+
+func TestCancelOperation(t *testing.T) {
+	store, manager, pipelines := initWithPipelines(t, 1)
+	defer store.Close()
+	assert.Nil(t, store.PipelineStore().CreatePipelineSteps(pipelines[0].UUID, nil))
+	operation, err := manager.StartOperation(model.OperationReindexPipelines)
+	assert.Nil(t, err)
+
+	operation, err = manager.CancelOperation(operation.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.OperationCancelled, operation.State)
+	assert.NotZero(t, operation.FinishedAtInSec)
+	// The cancelled operation isn't processed.
+	assert.Nil(t, manager.ProcessOperations())
+	operation, err = manager.GetOperation(operation.UUID)
+	assert.Nil(t, err)
+	assert.Zero(t, operation.Processed)
+	steps, err := store.PipelineStore().ListPipelineSteps(pipelines[0].UUID)
+	assert.Nil(t, err)
+	assert.Empty(t, steps)
+
+	_, err = manager.CancelOperation("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
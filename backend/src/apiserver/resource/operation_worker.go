@@ -0,0 +1,42 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// OperationWorker carries on the running operations.
+type OperationWorker struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+}
+
+func NewOperationWorker(resourceManager *ResourceManager, interval time.Duration) *OperationWorker {
+	return &OperationWorker{resourceManager: resourceManager, interval: interval}
+}
+
+// Run processes the running operations every interval until stopCh is closed.
+func (w *OperationWorker) Run(stopCh <-chan struct{}) {
+	glog.Infof("Processing the operations every %v", w.interval)
+	wait.Until(func() {
+		if err := w.resourceManager.ProcessOperations(); err != nil {
+			glog.Errorf("Failed to process the operations: %+v", err)
+		}
+	}, w.interval, stopCh)
+}
@@ -0,0 +1,277 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/glog"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	orphanKindWorkflow = "workflow"
+	orphanKindRun      = "run"
+
+	orphanActionAdoptWorkflow  = "adopt_workflow"
+	orphanActionDeleteWorkflow = "delete_workflow"
+	orphanActionFailRun        = "fail_run"
+)
+
+var (
+	orphansGauge = metrics.NewGaugeVec("orphan_reconciler_orphans",
+		"The orphans found by the last reconciliation: the workflows without a run, and the runs whose workflow vanished.",
+		"kind")
+	orphanActionsCounter = metrics.NewCounterVec("orphan_reconciler_actions_total",
+		"The actions the orphan reconciler took on the orphans.", "action")
+)
+
+func init() {
+	metrics.MustRegister(orphansGauge, orphanActionsCounter)
+}
+
+// OrphanReconciler cross-checks the workflows against the runs. A workflow created by the
+// API server or by a job which has no run is adopted by storing its run, or deleted. A
+// run whose workflow vanished before completing is marked as errored, since it would
+// otherwise never complete. Only the workflows and runs older than the grace period are
+// reconciled, so that the runs being created and the workflows not yet reported by the
+// persistence agent aren't raced. In dry-run mode, the orphans are only logged.
+type OrphanReconciler struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+	gracePeriod     time.Duration
+	// Whether the workflows without a run are adopted rather than deleted.
+	adoptWorkflows bool
+	dryRun         bool
+}
+
+func NewOrphanReconciler(resourceManager *ResourceManager, interval time.Duration, gracePeriod time.Duration,
+	adoptWorkflows bool, dryRun bool) *OrphanReconciler {
+	return &OrphanReconciler{
+		resourceManager: resourceManager,
+		interval:        interval,
+		gracePeriod:     gracePeriod,
+		adoptWorkflows:  adoptWorkflows,
+		dryRun:          dryRun,
+	}
+}
+
+// Run reconciles the orphans every interval until stopCh is closed.
+func (o *OrphanReconciler) Run(stopCh <-chan struct{}) {
+	glog.Infof("Reconciling the orphaned workflows and runs every %v (dry run: %v)", o.interval, o.dryRun)
+	wait.Until(func() {
+		if err := o.Reconcile(); err != nil {
+			glog.Errorf("Failed to reconcile the orphaned workflows and runs: %+v", err)
+		}
+	}, o.interval, stopCh)
+}
+
+func (o *OrphanReconciler) Reconcile() error {
+	createdBefore := o.resourceManager.time.Now().Add(-o.gracePeriod)
+	workflows, err := o.resourceManager.engine.List()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to list the workflows")
+	}
+	var errs []error
+	if err := o.reconcileWorkflows(workflows, createdBefore); err != nil {
+		errs = append(errs, err)
+	}
+	if err := o.reconcileRuns(workflows, createdBefore.Unix()); err != nil {
+		errs = append(errs, err)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// reconcileWorkflows adopts or deletes the workflows without a run.
+func (o *OrphanReconciler) reconcileWorkflows(workflows []*util.Workflow, createdBefore time.Time) error {
+	var candidates []*util.Workflow
+	var runIds []string
+	for _, workflow := range workflows {
+		if isCreatedByPipelines(workflow) && workflow.CreationTimestamp.Time.Before(createdBefore) {
+			candidates = append(candidates, workflow)
+			runIds = append(runIds, string(workflow.UID))
+		}
+	}
+	existing, err := o.resourceManager.runStore.GetExistingRunIds(runIds)
+	if err != nil {
+		return util.Wrap(err, "Failed to get the runs of the workflows")
+	}
+	orphans := 0
+	var errs []error
+	for _, workflow := range candidates {
+		if existing[string(workflow.UID)] {
+			continue
+		}
+		orphans++
+		if err := o.reconcileWorkflow(workflow); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	orphansGauge.Set(float64(orphans), orphanKindWorkflow)
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *OrphanReconciler) reconcileWorkflow(workflow *util.Workflow) error {
+	switch {
+	case o.dryRun:
+		glog.Infof("Found workflow %v without a run (dry run)", workflow.Name)
+	case o.adoptWorkflows:
+		if err := o.adoptWorkflow(workflow); err != nil {
+			return util.Wrap(err, fmt.Sprintf("Failed to adopt workflow %v", workflow.Name))
+		}
+		glog.Infof("Adopted workflow %v without a run", workflow.Name)
+		orphanActionsCounter.Inc(orphanActionAdoptWorkflow)
+	default:
+		err := o.resourceManager.engine.Delete(workflow.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return util.NewInternalServerError(err, "Failed to delete workflow %v", workflow.Name)
+		}
+		glog.Infof("Deleted workflow %v without a run", workflow.Name)
+		orphanActionsCounter.Inc(orphanActionDeleteWorkflow)
+	}
+	return nil
+}
+
+// adoptWorkflow stores the run of a workflow. The run of a one-time workflow is named
+// after the workflow, since the request creating it is lost.
+func (o *OrphanReconciler) adoptWorkflow(workflow *util.Workflow) error {
+	if workflow.ScheduledWorkflowUUIDAsStringOrEmpty() != "" {
+		// The runs of the jobs are stored as their workflows are reported.
+		return o.resourceManager.ReportWorkflowResource(workflow)
+	}
+	runId := string(workflow.UID)
+	var parameters []*api.Parameter
+	for _, parameter := range workflow.Spec.Arguments.Parameters {
+		apiParameter := &api.Parameter{Name: parameter.Name}
+		if parameter.Value != nil {
+			apiParameter.Value = *parameter.Value
+		}
+		parameters = append(parameters, apiParameter)
+	}
+	modelParameters, err := toModelParameters(parameters, workflow.SecretParameterNames())
+	if err != nil {
+		return util.Wrap(err, "Failed to convert the parameters of the workflow")
+	}
+	runDetail := &model.RunDetail{
+		Run: model.Run{
+			UUID:           runId,
+			DisplayName:    workflow.Name,
+			Name:           workflow.Name,
+			Namespace:      workflow.Namespace,
+			CreatedAtInSec: workflow.CreationTimestamp.Unix(),
+			Conditions:     workflow.Condition(),
+			PipelineSpec: model.PipelineSpec{
+				PipelineId:           workflow.PipelineIdOrEmpty(),
+				WorkflowSpecManifest: workflow.GetSpec().ToStringForStore(),
+				Parameters:           modelParameters,
+			},
+		},
+		PipelineRuntime: model.PipelineRuntime{
+			WorkflowRuntimeManifest: workflow.ToStringForStore(),
+		},
+	}
+	if experimentId := workflow.ExperimentIdOrEmpty(); experimentId != "" {
+		runDetail.ResourceReferences = []*model.ResourceReference{{
+			ResourceUUID:  runId,
+			ResourceType:  common.Run,
+			ReferenceUUID: experimentId,
+			ReferenceType: common.Experiment,
+			Relationship:  common.Owner,
+		}}
+	}
+	_, err = o.resourceManager.runStore.CreateRun(runDetail)
+	return err
+}
+
+// reconcileRuns marks the unfinished runs whose workflow vanished as errored. Only the runs on
+// the cluster of the API server are reconciled.
+func (o *OrphanReconciler) reconcileRuns(workflows []*util.Workflow, createdBeforeInSec int64) error {
+	runs, err := o.resourceManager.runStore.ListUnfinishedRuns(createdBeforeInSec)
+	if err != nil {
+		return util.Wrap(err, "Failed to list the unfinished runs")
+	}
+	workflowUIDs := make(map[string]bool)
+	for _, workflow := range workflows {
+		workflowUIDs[string(workflow.UID)] = true
+	}
+	orphans := 0
+	var errs []error
+	for _, run := range runs {
+		// The workflows of the runs dispatched to an execution target aren't listed, since
+		// they're on a remote cluster.
+		if workflowUIDs[run.UUID] || run.ExecutionTarget != "" {
+			continue
+		}
+		orphans++
+		if o.dryRun {
+			glog.Infof("Found run %v whose workflow vanished (dry run)", run.UUID)
+			continue
+		}
+		if err := o.failRun(run); err != nil {
+			errs = append(errs, util.Wrap(err, fmt.Sprintf("Failed to mark run %v as errored", run.UUID)))
+			continue
+		}
+		glog.Infof("Marked run %v whose workflow vanished as errored", run.UUID)
+		orphanActionsCounter.Inc(orphanActionFailRun)
+	}
+	orphansGauge.Set(float64(orphans), orphanKindRun)
+	return utilerrors.NewAggregate(errs)
+}
+
+// failRun marks a run as errored, in its last reported workflow too, and notifies that
+// it completed.
+func (o *OrphanReconciler) failRun(run model.Run) error {
+	// The stored workflow has the values of its secret parameters hashed already.
+	workflow := util.NewWorkflowFromStore(&workflowapi.Workflow{
+		ObjectMeta: v1.ObjectMeta{UID: types.UID(run.UUID), Name: run.Name, Namespace: run.Namespace},
+	})
+	runDetail, err := o.resourceManager.runStore.GetRun(run.UUID)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal([]byte(runDetail.WorkflowRuntimeManifest), workflow); err != nil {
+			return util.NewInternalServerError(err, "Failed to unmarshal the workflow of the run")
+		}
+	case !util.IsUserErrorCodeMatch(err, codes.NotFound):
+		// The run isn't found if its workflow was never reported.
+		return err
+	}
+	workflow.Status.Phase = workflowapi.NodeError
+	workflow.Status.Message = "The workflow of the run was deleted before the run completed"
+	workflow.Status.FinishedAt = v1.NewTime(o.resourceManager.time.Now())
+	if err := o.resourceManager.runStore.UpdateRun(run.UUID, workflow.Condition(), workflow.ToStringForStore()); err != nil {
+		return err
+	}
+	o.resourceManager.notifyRunStateChange(workflow, workflow.ExperimentIdOrEmpty(), run.Conditions)
+	return nil
+}
+
+// isCreatedByPipelines returns whether a workflow was created by the API server, whose
+// runs belong to an experiment, or by a job.
+func isCreatedByPipelines(workflow *util.Workflow) bool {
+	return workflow.ExperimentIdOrEmpty() != "" || workflow.ScheduledWorkflowUUIDAsStringOrEmpty() != ""
+}
@@ -0,0 +1,161 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/eventexport"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createOrphanedWorkflow creates a workflow of an experiment, without a run.
+func createOrphanedWorkflow(t *testing.T, store *FakeClientManager, experimentId string) {
+	_, err := store.workflowClientFake.Create(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "orphan",
+			UID:    "orphan-uid",
+			Labels: map[string]string{util.LabelKeyWorkflowExperimentId: experimentId},
+		},
+		Spec: v1alpha1.WorkflowSpec{Arguments: v1alpha1.Arguments{
+			Parameters: []v1alpha1.Parameter{{Name: "param1", Value: util.StringPointer("world")}}}},
+		Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.NodeRunning},
+	})
+	assert.Nil(t, err)
+}
+
+func TestOrphanReconciler_AdoptsWorkflow(t *testing.T) {
+	store, manager, experiment := initWithExperiment(t)
+	defer store.Close()
+	createOrphanedWorkflow(t, store, experiment.UUID)
+	adopted := orphanActionsCounter.Value(orphanActionAdoptWorkflow)
+
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, 0, true, false).Reconcile())
+	run, err := manager.GetRun("orphan-uid")
+	assert.Nil(t, err)
+	assert.Equal(t, "orphan", run.DisplayName)
+	assert.Equal(t, "Running", run.Conditions)
+	assert.Equal(t, `[{"name":"param1","value":"world"}]`, run.Parameters)
+	assert.Equal(t, []*model.ResourceReference{{
+		ResourceUUID: "orphan-uid", ResourceType: common.Run,
+		ReferenceUUID: experiment.UUID, ReferenceType: common.Experiment,
+		Relationship: common.Owner,
+	}}, run.ResourceReferences)
+	assert.Equal(t, adopted+1, orphanActionsCounter.Value(orphanActionAdoptWorkflow))
+	assert.Equal(t, float64(1), orphansGauge.Value(orphanKindWorkflow))
+
+	// The adopted workflow has a run now.
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, 0, true, false).Reconcile())
+	assert.Equal(t, float64(0), orphansGauge.Value(orphanKindWorkflow))
+}
+
+func TestOrphanReconciler_DeletesWorkflow(t *testing.T) {
+	store, manager, experiment := initWithExperiment(t)
+	defer store.Close()
+	createOrphanedWorkflow(t, store, experiment.UUID)
+	// The workflows created outside of the pipelines are left alone.
+	_, err := store.workflowClientFake.Create(&v1alpha1.Workflow{ObjectMeta: v1.ObjectMeta{Name: "other", UID: "other-uid"}})
+	assert.Nil(t, err)
+
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, 0, false, false).Reconcile())
+	assert.Equal(t, map[string]bool{"other": true}, store.workflowClientFake.GetWorkflowKeys())
+}
+
+func TestOrphanReconciler_FailsRunWhoseWorkflowVanished(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	assert.Nil(t, store.workflowClientFake.Delete(run.Name, &v1.DeleteOptions{}))
+
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, 0, false, false).Reconcile())
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Error", runDetail.Conditions)
+	events := store.eventPublisherFake.Events()
+	assert.Equal(t, eventexport.EventTypeRunFinished, events[len(events)-1].Type)
+	assert.Equal(t, "Error", events[len(events)-1].State)
+
+	// The errored run is in a final state.
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, 0, false, false).Reconcile())
+	assert.Equal(t, float64(0), orphansGauge.Value(orphanKindRun))
+}
+
+func TestOrphanReconciler_DryRun(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	assert.Nil(t, store.workflowClientFake.Delete(run.Name, &v1.DeleteOptions{}))
+	createOrphanedWorkflow(t, store, DefaultFakeUUID)
+
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, 0, false, true).Reconcile())
+	assert.Equal(t, float64(1), orphansGauge.Value(orphanKindWorkflow))
+	assert.Equal(t, float64(1), orphansGauge.Value(orphanKindRun))
+	assert.Equal(t, 1, store.workflowClientFake.GetWorkflowCount())
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", runDetail.Conditions)
+	_, err = manager.GetRun("orphan-uid")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestOrphanReconciler_SkipsRecentRuns(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	assert.Nil(t, store.workflowClientFake.Delete(run.Name, &v1.DeleteOptions{}))
+
+	assert.Nil(t, NewOrphanReconciler(manager, time.Minute, time.Hour, false, false).Reconcile())
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", runDetail.Conditions)
+}
+
+func TestOrphanReconciler_FailsRunOnceGracePeriodIsOver(t *testing.T) {
+	fakeTime := util.NewManualFakeTime(time.Unix(1000, 0))
+	store, err := NewFakeClientManager(fakeTime, util.NewFakeUUIDGeneratorOrFatal(DefaultFakeUUID, nil))
+	assert.Nil(t, err)
+	defer store.Close()
+	manager := NewResourceManager(store)
+	experiment, err := manager.CreateExperiment(&model.Experiment{Name: "e1"})
+	assert.Nil(t, err)
+	run, err := manager.CreateRun(&api.Run{
+		Name:         "run1",
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+			Relationship: api.Relationship_OWNER,
+		}},
+	}, "")
+	assert.Nil(t, err)
+	assert.Nil(t, store.workflowClientFake.Delete(run.Name, &v1.DeleteOptions{}))
+	reconciler := NewOrphanReconciler(manager, time.Minute, time.Hour, false, false)
+
+	fakeTime.Advance(time.Hour - time.Second)
+	assert.Nil(t, reconciler.Reconcile())
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "", runDetail.Conditions)
+
+	fakeTime.Advance(2 * time.Second)
+	assert.Nil(t, reconciler.Reconcile())
+	runDetail, err = manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Error", runDetail.Conditions)
+}
@@ -0,0 +1,170 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	runNameExpression        = "[[RunName]]"
+	runIdExpression          = "[[RunID]]"
+	experimentIdExpression   = "[[ExperimentID]]"
+	experimentNameExpression = "[[ExperimentName]]"
+	pipelineIdExpression     = "[[PipelineID]]"
+	parameterPrefix          = "[[Parameters."
+	scheduledTimeExpression  = "[[ScheduledTime]]"
+	currentTimeExpression    = "[[CurrentTime]]"
+	scheduledTimePrefix      = "[[ScheduledTime."
+	currentTimePrefix        = "[[CurrentTime."
+	defaultTimeFormat        = "20060102150405"
+	macroSuffix              = "]]"
+	// Argo sets the UID of a workflow, i.e. the ID of its run, once it's created.
+	workflowUidVariable = "{{workflow.uid}}"
+)
+
+var macroRegexp = regexp.MustCompile(`\[\[(.*?)\]\]`)
+
+// parameterContext is what the macros of the parameters of a run or a job refer to.
+type parameterContext struct {
+	// The name of the run, or of the job for its runs.
+	runName        string
+	experimentId   string
+	experimentName string
+	pipelineId     string
+	// The time the run is created at. It is zero for a job, whose time macros the
+	// scheduled workflow controller evaluates for each of its runs.
+	now time.Time
+}
+
+// parameterEvaluator substitutes the macros of the parameters of a run or a job, e.g.
+// [[ExperimentName]] or [[Parameters.learning-rate]]. The macros it doesn't know, like the
+// [[Index]] of the runs of a job, are left as they are.
+type parameterEvaluator struct {
+	context parameterContext
+	// The raw values of the parameters, the ones of the run overriding the defaults of the
+	// workflow.
+	values    map[string]string
+	evaluated map[string]string
+	// The parameters being evaluated, to detect the cycles between references.
+	evaluating map[string]bool
+}
+
+// hasParameterMacros returns whether any of the parameters has a macro to evaluate.
+func hasParameterMacros(parameters []*api.Parameter) bool {
+	for _, parameter := range parameters {
+		if macroRegexp.MatchString(parameter.GetValue()) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateParameters substitutes the macros of the parameters of a run or a job in place.
+// The parameters referenced without a value take the default of the workflow.
+func evaluateParameters(parameters []*api.Parameter, workflow *util.Workflow, context parameterContext) error {
+	evaluator := &parameterEvaluator{
+		context:    context,
+		values:     make(map[string]string),
+		evaluated:  make(map[string]string),
+		evaluating: make(map[string]bool),
+	}
+	for _, parameter := range workflow.Spec.Arguments.Parameters {
+		if parameter.Value != nil {
+			evaluator.values[parameter.Name] = *parameter.Value
+		}
+	}
+	for _, parameter := range parameters {
+		evaluator.values[parameter.GetName()] = parameter.GetValue()
+	}
+	for _, parameter := range parameters {
+		value, err := evaluator.evaluate(parameter.GetName())
+		if err != nil {
+			return err
+		}
+		parameter.Value = value
+	}
+	return nil
+}
+
+func (e *parameterEvaluator) evaluate(name string) (string, error) {
+	if value, ok := e.evaluated[name]; ok {
+		return value, nil
+	}
+	value, ok := e.values[name]
+	if !ok {
+		return "", util.NewInvalidInputError("Parameter %v is referenced but has no value", name)
+	}
+	if e.evaluating[name] {
+		return "", util.NewInvalidInputError("Parameter %v references itself", name)
+	}
+	e.evaluating[name] = true
+	defer delete(e.evaluating, name)
+
+	var result strings.Builder
+	last := 0
+	for _, match := range macroRegexp.FindAllStringIndex(value, -1) {
+		substitute, err := e.substitute(value[match[0]:match[1]])
+		if err != nil {
+			return "", util.Wrapf(err, "Failed to evaluate parameter %v", name)
+		}
+		result.WriteString(value[last:match[0]])
+		result.WriteString(substitute)
+		last = match[1]
+	}
+	result.WriteString(value[last:])
+	e.evaluated[name] = result.String()
+	return e.evaluated[name], nil
+}
+
+func (e *parameterEvaluator) substitute(macro string) (string, error) {
+	switch {
+	case macro == runNameExpression:
+		return e.context.runName, nil
+	case macro == runIdExpression:
+		return workflowUidVariable, nil
+	case macro == experimentIdExpression, macro == experimentNameExpression:
+		if e.context.experimentId == "" {
+			return "", util.NewInvalidInputError("%v is used but there is no experiment", macro)
+		}
+		if macro == experimentIdExpression {
+			return e.context.experimentId, nil
+		}
+		return e.context.experimentName, nil
+	case macro == pipelineIdExpression:
+		if e.context.pipelineId == "" {
+			return "", util.NewInvalidInputError("%v is used but the pipeline spec has no pipeline ID", macro)
+		}
+		return e.context.pipelineId, nil
+	case strings.HasPrefix(macro, parameterPrefix):
+		return e.evaluate(strings.TrimSuffix(strings.TrimPrefix(macro, parameterPrefix), macroSuffix))
+	case e.context.now.IsZero():
+		return macro, nil
+	// A run is scheduled when it's created.
+	case macro == scheduledTimeExpression, macro == currentTimeExpression:
+		return e.context.now.UTC().Format(defaultTimeFormat), nil
+	case strings.HasPrefix(macro, scheduledTimePrefix):
+		return e.context.now.UTC().Format(strings.TrimSuffix(strings.TrimPrefix(macro, scheduledTimePrefix), macroSuffix)), nil
+	case strings.HasPrefix(macro, currentTimePrefix):
+		return e.context.now.UTC().Format(strings.TrimSuffix(strings.TrimPrefix(macro, currentTimePrefix), macroSuffix)), nil
+	default:
+		return macro, nil
+	}
+}
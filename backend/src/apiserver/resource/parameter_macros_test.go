@@ -0,0 +1,96 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func macroTestWorkflow() *util.Workflow {
+	defaultValue := "0.1"
+	return util.NewWorkflow(&v1alpha1.Workflow{
+		Spec: v1alpha1.WorkflowSpec{Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{
+			{Name: "output"}, {Name: "tag"}, {Name: "learning-rate", Value: &defaultValue},
+		}}},
+	})
+}
+
+func TestEvaluateParameters(t *testing.T) {
+	parameters := []*api.Parameter{
+		{Name: "output", Value: "gs://bucket/[[ExperimentName]]/[[RunName]]-[[Parameters.tag]]/[[RunID]]"},
+		{Name: "tag", Value: "lr[[Parameters.learning-rate]]-[[ScheduledTime.2006-01-02]]-[[Index]]"},
+	}
+	context := parameterContext{
+		runName:        "run1",
+		experimentId:   "exp1",
+		experimentName: "mnist",
+		now:            time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	err := evaluateParameters(parameters, macroTestWorkflow(), context)
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.Parameter{
+		{Name: "output", Value: "gs://bucket/mnist/run1-lr0.1-2018-10-01-[[Index]]/{{workflow.uid}}"},
+		{Name: "tag", Value: "lr0.1-2018-10-01-[[Index]]"},
+	}, parameters)
+}
+
+func TestEvaluateParameters_JobLeavesTimeMacros(t *testing.T) {
+	parameters := []*api.Parameter{{Name: "output", Value: "[[RunName]]-[[ScheduledTime]]-[[CurrentTime.15:04]]"}}
+	err := evaluateParameters(parameters, macroTestWorkflow(), parameterContext{runName: "job1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "job1-[[ScheduledTime]]-[[CurrentTime.15:04]]", parameters[0].Value)
+}
+
+func TestEvaluateParameters_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []*api.Parameter
+		message    string
+	}{
+		{
+			"cycle",
+			[]*api.Parameter{{Name: "output", Value: "[[Parameters.tag]]"}, {Name: "tag", Value: "[[Parameters.output]]"}},
+			"Parameter output references itself",
+		},
+		{
+			"no value",
+			[]*api.Parameter{{Name: "output", Value: "[[Parameters.tag]]"}},
+			"Parameter tag is referenced but has no value",
+		},
+		{
+			"no experiment",
+			[]*api.Parameter{{Name: "output", Value: "[[ExperimentName]]"}},
+			"[[ExperimentName]] is used but there is no experiment",
+		},
+		{
+			"no pipeline",
+			[]*api.Parameter{{Name: "output", Value: "[[PipelineID]]"}},
+			"[[PipelineID]] is used but the pipeline spec has no pipeline ID",
+		},
+	}
+	for _, test := range tests {
+		err := evaluateParameters(test.parameters, macroTestWorkflow(), parameterContext{now: time.Unix(1, 0)})
+		assert.NotNil(t, err, test.name)
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument), test.name)
+		assert.Contains(t, err.Error(), test.message, test.name)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"strings"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// applyPartialExecution prunes a workflow to the part of it a run executes: from the entrypoint,
+// if overridden, the selected steps, if any, and the steps invoking their templates.
+func applyPartialExecution(workflow *util.Workflow, partial *api.PartialExecution) error {
+	if entrypoint := partial.GetEntrypoint(); entrypoint != "" {
+		if workflow.GetTemplate(entrypoint) == nil {
+			return util.NewInvalidInputError("The entrypoint %v isn't a template of the workflow", entrypoint)
+		}
+		workflow.Spec.Entrypoint = entrypoint
+	}
+	if len(partial.GetSteps()) == 0 {
+		return nil
+	}
+	pruner := &stepPruner{
+		workflow: workflow,
+		selected: make(map[string]bool),
+		found:    make(map[string]bool),
+		contains: make(map[string]bool),
+		visited:  make(map[string]bool),
+	}
+	for _, step := range partial.GetSteps() {
+		pruner.selected[step] = true
+	}
+	for _, template := range workflow.Spec.Templates {
+		pruner.containsSelected(template.Name)
+	}
+	for _, step := range partial.GetSteps() {
+		if !pruner.found[step] {
+			return util.NewInvalidInputError("The step %v isn't in the workflow", step)
+		}
+	}
+	if !pruner.containsSelected(workflow.Spec.Entrypoint) {
+		return util.NewInvalidInputError("None of the selected steps is executed from the entrypoint %v",
+			workflow.Spec.Entrypoint)
+	}
+	for i := range workflow.Spec.Templates {
+		if err := pruner.prune(&workflow.Spec.Templates[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stepPruner removes the steps which are neither selected nor invoking the template of a
+// selected step from the DAG and the steps templates of a workflow.
+type stepPruner struct {
+	workflow *util.Workflow
+	selected map[string]bool
+	// The selected steps found in the templates.
+	found map[string]bool
+	// Whether the templates contain a selected step, directly or not.
+	contains map[string]bool
+	visited  map[string]bool
+}
+
+// containsSelected returns whether a template contains a selected step, or invokes a template
+// which does.
+func (p *stepPruner) containsSelected(templateName string) bool {
+	if p.visited[templateName] {
+		return p.contains[templateName]
+	}
+	// A template invoking itself is assumed not to contain a selected step until it is evaluated.
+	p.visited[templateName] = true
+	template := p.workflow.GetTemplate(templateName)
+	if template == nil {
+		return false
+	}
+	contains := false
+	if template.DAG != nil {
+		for _, task := range template.DAG.Tasks {
+			contains = p.keeps(task.Name, task.Template) || contains
+		}
+	}
+	for _, group := range template.Steps {
+		for _, step := range group {
+			contains = p.keeps(step.Name, step.Template) || contains
+		}
+	}
+	p.contains[templateName] = contains
+	return contains
+}
+
+// keeps returns whether a step is kept, i.e. it is selected or invokes a template containing a
+// selected step.
+func (p *stepPruner) keeps(stepName string, templateName string) bool {
+	if p.selected[stepName] {
+		p.found[stepName] = true
+		return true
+	}
+	return p.containsSelected(templateName)
+}
+
+func (p *stepPruner) prune(template *workflowapi.Template) error {
+	if template.DAG != nil {
+		removed := make(map[string]bool)
+		var tasks []workflowapi.DAGTask
+		for _, task := range template.DAG.Tasks {
+			if p.keeps(task.Name, task.Template) {
+				tasks = append(tasks, task)
+			} else {
+				removed[task.Name] = true
+			}
+		}
+		for i := range tasks {
+			if err := checkRemovedReferences(tasks[i].Name, tasks[i], "tasks", removed); err != nil {
+				return err
+			}
+			var dependencies []string
+			for _, dependency := range tasks[i].Dependencies {
+				if !removed[dependency] {
+					dependencies = append(dependencies, dependency)
+				}
+			}
+			tasks[i].Dependencies = dependencies
+		}
+		template.DAG.Tasks = tasks
+	}
+	if template.Steps != nil {
+		removed := make(map[string]bool)
+		var groups [][]workflowapi.WorkflowStep
+		for _, group := range template.Steps {
+			var steps []workflowapi.WorkflowStep
+			for _, step := range group {
+				if p.keeps(step.Name, step.Template) {
+					steps = append(steps, step)
+				} else {
+					removed[step.Name] = true
+				}
+			}
+			if len(steps) > 0 {
+				groups = append(groups, steps)
+			}
+		}
+		for _, group := range groups {
+			for _, step := range group {
+				if err := checkRemovedReferences(step.Name, step, "steps", removed); err != nil {
+					return err
+				}
+			}
+		}
+		template.Steps = groups
+	}
+	return nil
+}
+
+// checkRemovedReferences returns an error if a step kept uses the outputs of a removed step,
+// which would then never be produced.
+func checkRemovedReferences(stepName string, step interface{}, scope string, removed map[string]bool) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	stepBytes, err := json.Marshal(step)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to marshal the step %v", stepName)
+	}
+	for name := range removed {
+		if strings.Contains(string(stepBytes), scope+"."+name+".") {
+			return util.NewInvalidInputError("The step %v uses the outputs of the step %v, which isn't selected",
+				stepName, name)
+		}
+	}
+	return nil
+}
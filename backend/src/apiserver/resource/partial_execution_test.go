@@ -0,0 +1,119 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testWorkflowWithStages = util.NewWorkflow(&v1alpha1.Workflow{
+	TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+	ObjectMeta: v1.ObjectMeta{Name: "workflow-name"},
+	Spec: v1alpha1.WorkflowSpec{
+		Entrypoint: "main",
+		Templates: []v1alpha1.Template{
+			{Name: "main", DAG: &v1alpha1.DAGTemplate{Tasks: []v1alpha1.DAGTask{
+				{Name: "prepare", Template: "step"},
+				{Name: "train", Template: "step", Dependencies: []string{"prepare"}},
+				{Name: "evaluate", Template: "step", Dependencies: []string{"train"},
+					Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{
+						{Name: "model", Value: util.StringPointer("{{tasks.train.outputs.parameters.model}}")}}}},
+				{Name: "tuning", Template: "tuning", Dependencies: []string{"prepare"}},
+			}}},
+			{Name: "tuning", Steps: [][]v1alpha1.WorkflowStep{
+				{{Name: "search", Template: "step"}},
+				{{Name: "tune-a", Template: "step"}, {Name: "tune-b", Template: "step"}},
+			}},
+			{Name: "step", Container: &corev1.Container{Image: "image"}},
+		},
+	},
+})
+
+func TestApplyPartialExecution_Entrypoint(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithStages.DeepCopy())
+	err := applyPartialExecution(workflow, &api.PartialExecution{Entrypoint: "tuning"})
+	assert.Nil(t, err)
+	assert.Equal(t, "tuning", workflow.Spec.Entrypoint)
+	assert.Equal(t, testWorkflowWithStages.Spec.Templates, workflow.Spec.Templates)
+
+	err = applyPartialExecution(workflow, &api.PartialExecution{Entrypoint: "unknown"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
+
+func TestApplyPartialExecution_Steps(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithStages.DeepCopy())
+	err := applyPartialExecution(workflow, &api.PartialExecution{Steps: []string{"train", "tune-b"}})
+	assert.Nil(t, err)
+	// The dependencies on the removed tasks are dropped, and the tasks invoking a template
+	// containing a selected step are kept.
+	assert.Equal(t, []v1alpha1.DAGTask{
+		{Name: "train", Template: "step"},
+		{Name: "tuning", Template: "tuning"},
+	}, workflow.Spec.Templates[0].DAG.Tasks)
+	assert.Equal(t, [][]v1alpha1.WorkflowStep{{{Name: "tune-b", Template: "step"}}}, workflow.Spec.Templates[1].Steps)
+}
+
+func TestApplyPartialExecution_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		partial *api.PartialExecution
+		message string
+	}{
+		{"unknown step", &api.PartialExecution{Steps: []string{"train", "deploy"}},
+			"The step deploy isn't in the workflow"},
+		{"step not executed", &api.PartialExecution{Entrypoint: "tuning", Steps: []string{"train"}},
+			"None of the selected steps is executed from the entrypoint tuning"},
+		{"outputs of a removed step", &api.PartialExecution{Steps: []string{"evaluate"}},
+			"The step evaluate uses the outputs of the step train, which isn't selected"},
+	}
+	for _, test := range tests {
+		workflow := util.NewWorkflow(testWorkflowWithStages.DeepCopy())
+		err := applyPartialExecution(workflow, test.partial)
+		if assert.NotNil(t, err, test.name) {
+			assert.Equal(t, test.message, err.(*util.UserError).ExternalMessage(), test.name)
+		}
+	}
+}
+
+func TestCreateRun_PartialExecution(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+
+	runDetail, err := manager.CreateRun(&api.Run{
+		Name:             "run1",
+		PipelineSpec:     &api.PipelineSpec{WorkflowManifest: testWorkflowWithStages.ToStringForStore()},
+		PartialExecution: &api.PartialExecution{Steps: []string{"train"}},
+	}, "")
+	assert.Nil(t, err)
+	workflow, err := store.workflowClientFake.Get(runDetail.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, []v1alpha1.DAGTask{{Name: "train", Template: "step"}}, workflow.Spec.Templates[0].DAG.Tasks)
+
+	_, err = manager.CreateRun(&api.Run{
+		Name:             "run2",
+		PipelineSpec:     &api.PipelineSpec{WorkflowManifest: testWorkflowWithStages.ToStringForStore()},
+		PartialExecution: &api.PartialExecution{Steps: []string{"deploy"}},
+	}, "")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+)
+
+// PipelineConflict is what to do when a pipeline is created under the name of an existing one.
+type PipelineConflict int
+
+const (
+	// Fail with AlreadyExists.
+	PipelineConflictFail PipelineConflict = iota
+	// Create the pipeline under the name suffixed with the first free " (n)".
+	PipelineConflictAutoSuffix
+	// Replace the file of the existing pipeline, which keeps its ID.
+	PipelineConflictNewVersion
+)
+
+// The suffixes tried for the name of a pipeline, before failing.
+const maxPipelineNameSuffix = 100
+
+// CreatePipelineOnConflict creates a pipeline like CreatePipelineWithReadme, resolving a
+// conflict with an existing pipeline of the same name as asked.
+func (r *ResourceManager) CreatePipelineOnConflict(name string, description string, pipelineFile []byte,
+	readme []byte, onConflict PipelineConflict) (*model.Pipeline, error) {
+	switch onConflict {
+	case PipelineConflictAutoSuffix:
+		candidate := name
+		for suffix := 1; ; suffix++ {
+			pipeline, err := r.CreatePipelineWithReadme(candidate, description, pipelineFile, readme)
+			if !util.IsUserErrorCodeMatch(err, codes.AlreadyExists) {
+				return pipeline, err
+			}
+			if suffix > maxPipelineNameSuffix {
+				return nil, util.Wrapf(err, "The names %v to %v are all taken", name, candidate)
+			}
+			candidate = fmt.Sprintf("%v (%v)", name, suffix)
+		}
+	case PipelineConflictNewVersion:
+		pipeline, _, err := r.UpsertPipelineWithReadme(name, description, pipelineFile, readme)
+		return pipeline, err
+	default:
+		return r.CreatePipelineWithReadme(name, description, pipelineFile, readme)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+var conflictTemplate = []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow")
+
+func initWithConflictingPipeline(t *testing.T) (*FakeClientManager, *ResourceManager, string) {
+	store, err := NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("pipeline1", "", conflictTemplate)
+	assert.Nil(t, err)
+	return store, manager, pipeline.UUID
+}
+
+func TestCreatePipelineOnConflict_Fail(t *testing.T) {
+	store, manager, _ := initWithConflictingPipeline(t)
+	defer store.Close()
+	_, err := manager.CreatePipelineOnConflict("pipeline1", "", conflictTemplate, nil, PipelineConflictFail)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.AlreadyExists))
+}
+
+func TestCreatePipelineOnConflict_AutoSuffix(t *testing.T) {
+	store, manager, id := initWithConflictingPipeline(t)
+	defer store.Close()
+	first, err := manager.CreatePipelineOnConflict("pipeline1", "", conflictTemplate, nil, PipelineConflictAutoSuffix)
+	assert.Nil(t, err)
+	assert.Equal(t, "pipeline1 (1)", first.Name)
+	assert.NotEqual(t, id, first.UUID)
+	second, err := manager.CreatePipelineOnConflict("pipeline1", "", conflictTemplate, nil, PipelineConflictAutoSuffix)
+	assert.Nil(t, err)
+	assert.Equal(t, "pipeline1 (2)", second.Name)
+
+	// A free name is kept as is.
+	free, err := manager.CreatePipelineOnConflict("pipeline2", "", conflictTemplate, nil, PipelineConflictAutoSuffix)
+	assert.Nil(t, err)
+	assert.Equal(t, "pipeline2", free.Name)
+}
+
+func TestCreatePipelineOnConflict_AutoSuffixExhausted(t *testing.T) {
+	store, manager, _ := initWithConflictingPipeline(t)
+	defer store.Close()
+	for suffix := 1; suffix <= maxPipelineNameSuffix; suffix++ {
+		_, err := manager.CreatePipeline(fmt.Sprintf("pipeline1 (%v)", suffix), "", conflictTemplate)
+		assert.Nil(t, err)
+	}
+	_, err := manager.CreatePipelineOnConflict("pipeline1", "", conflictTemplate, nil, PipelineConflictAutoSuffix)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.AlreadyExists))
+}
+
+func TestCreatePipelineOnConflict_NewVersion(t *testing.T) {
+	store, manager, id := initWithConflictingPipeline(t)
+	defer store.Close()
+	newTemplate := []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow\n" +
+		"spec:\n  arguments:\n    parameters:\n    - name: param1\n")
+	pipeline, err := manager.CreatePipelineOnConflict("pipeline1", "", newTemplate, nil, PipelineConflictNewVersion)
+	assert.Nil(t, err)
+	assert.Equal(t, id, pipeline.UUID)
+	storedTemplate, err := manager.GetPipelineTemplate(id)
+	assert.Nil(t, err)
+	assert.Equal(t, newTemplate, storedTemplate)
+}
@@ -0,0 +1,143 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/robfig/cron"
+)
+
+// The number of the last finished runs of a pipeline its stats aggregate.
+const pipelineStatsRuns = 100
+
+// The number of the next activations of a cron schedule its interval is the shortest gap of.
+const cronActivations = 10
+
+// PipelineStats are the durations and the failure rate of the last finished runs of a pipeline.
+type PipelineStats struct {
+	PipelineID  string
+	Runs        int
+	FailedRuns  int
+	FailureRate float64
+	// The percentiles of the durations of the runs which succeeded, or 0 if none did.
+	P50DurationInSec int64
+	P95DurationInSec int64
+}
+
+// GetPipelineStats aggregates the last finished runs of a pipeline.
+func (r *ResourceManager) GetPipelineStats(pipelineId string) (*PipelineStats, error) {
+	if _, err := r.pipelineStore.GetPipeline(pipelineId); err != nil {
+		return nil, util.Wrap(err, "Failed to get the stats of the pipeline")
+	}
+	runs, err := r.runStore.ListFinishedRuns(pipelineId, pipelineStatsRuns)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the stats of the pipeline")
+	}
+	stats := &PipelineStats{PipelineID: pipelineId, Runs: len(runs)}
+	var durations []float64
+	for _, run := range runs {
+		if run.Conditions != string(workflowapi.NodeSucceeded) {
+			stats.FailedRuns++
+			continue
+		}
+		var workflow workflowapi.Workflow
+		if err := json.Unmarshal([]byte(run.WorkflowRuntimeManifest), &workflow); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to unmarshal the workflow of run %v", run.UUID)
+		}
+		if duration := util.NewWorkflow(&workflow).DurationInSecOr0(); duration > 0 {
+			durations = append(durations, duration)
+		}
+	}
+	if stats.Runs > 0 {
+		stats.FailureRate = float64(stats.FailedRuns) / float64(stats.Runs)
+	}
+	sort.Float64s(durations)
+	stats.P50DurationInSec = percentile(durations, 50)
+	stats.P95DurationInSec = percentile(durations, 95)
+	return stats, nil
+}
+
+// percentile returns the nearest-rank percentile of sorted values, or 0 if there is none.
+func percentile(sorted []float64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return int64(math.Round(sorted[rank-1]))
+}
+
+// CheckJobInterval returns the warnings of a job whose interval is shorter than the typical
+// duration, i.e. the median, of the runs of its pipeline which succeeded, as its runs may then
+// overlap or be delayed by its max concurrency.
+func (r *ResourceManager) CheckJobInterval(apiJob *api.Job) ([]string, error) {
+	pipelineId := apiJob.GetPipelineSpec().GetPipelineId()
+	if pipelineId == "" {
+		return nil, nil
+	}
+	interval, err := jobInterval(apiJob.GetTrigger(), r.time.Now())
+	if err != nil || interval == 0 {
+		return nil, err
+	}
+	stats, err := r.GetPipelineStats(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	typicalDuration := time.Duration(stats.P50DurationInSec) * time.Second
+	if interval >= typicalDuration {
+		return nil, nil
+	}
+	return []string{fmt.Sprintf(
+		"The interval of the job, %v, is shorter than the typical duration of the runs of its pipeline, %v.",
+		interval, typicalDuration)}, nil
+}
+
+// jobInterval returns the interval of a periodic trigger, or the shortest gap between the next
+// activations of a cron trigger, or 0 without trigger.
+func jobInterval(trigger *api.Trigger, now time.Time) (time.Duration, error) {
+	if trigger.GetPeriodicSchedule() != nil {
+		return time.Duration(trigger.GetPeriodicSchedule().IntervalSecond) * time.Second, nil
+	}
+	if trigger.GetCronSchedule() == nil {
+		return 0, nil
+	}
+	schedule, err := cron.Parse(trigger.GetCronSchedule().Cron)
+	if err != nil {
+		return 0, util.NewInvalidInputError("The cron schedule of the job is invalid: %v", err)
+	}
+	var interval time.Duration
+	previous := schedule.Next(now)
+	for i := 0; i < cronActivations; i++ {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(previous); interval == 0 || gap < interval {
+			interval = gap
+		}
+		previous = next
+	}
+	return interval, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func initWithPastRuns(t *testing.T, durations ...time.Duration) (*FakeClientManager, *ResourceManager, string) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testEstimatedWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	for i, duration := range durations {
+		run := newPastRun(string(rune('a'+i)), pipeline.UUID, nil, duration)
+		// The runs with no duration failed.
+		if duration == 0 {
+			run.Conditions = string(v1alpha1.NodeFailed)
+		}
+		_, err = store.RunStore().CreateRun(run)
+		assert.Nil(t, err)
+	}
+	return store, manager, pipeline.UUID
+}
+
+func TestGetPipelineStats(t *testing.T) {
+	store, manager, pipelineId := initWithPastRuns(t,
+		10*time.Minute, 0, 20*time.Minute, 30*time.Minute, 0, 40*time.Minute, 50*time.Minute, 0)
+	defer store.Close()
+
+	stats, err := manager.GetPipelineStats(pipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, &PipelineStats{
+		PipelineID:       pipelineId,
+		Runs:             8,
+		FailedRuns:       3,
+		FailureRate:      0.375,
+		P50DurationInSec: 30 * 60,
+		P95DurationInSec: 50 * 60,
+	}, stats)
+}
+
+func TestGetPipelineStats_NoRun(t *testing.T) {
+	store, manager, pipelineId := initWithPastRuns(t)
+	defer store.Close()
+
+	stats, err := manager.GetPipelineStats(pipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, &PipelineStats{PipelineID: pipelineId}, stats)
+
+	_, err = manager.GetPipelineStats("not-exist")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCheckJobInterval(t *testing.T) {
+	store, manager, pipelineId := initWithPastRuns(t, time.Hour, 2*time.Hour, 3*time.Hour)
+	defer store.Close()
+
+	job := &api.Job{
+		PipelineSpec: &api.PipelineSpec{PipelineId: pipelineId},
+		Trigger: &api.Trigger{Trigger: &api.Trigger_PeriodicSchedule{
+			PeriodicSchedule: &api.PeriodicSchedule{IntervalSecond: 3600}}},
+	}
+	warnings, err := manager.CheckJobInterval(job)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"The interval of the job, 1h0m0s, is shorter than the typical duration of the runs " +
+		"of its pipeline, 2h0m0s."}, warnings)
+
+	// Every 30 minutes.
+	job.Trigger = &api.Trigger{Trigger: &api.Trigger_CronSchedule{
+		CronSchedule: &api.CronSchedule{Cron: "0 0,30 * * * *"}}}
+	warnings, err = manager.CheckJobInterval(job)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"The interval of the job, 30m0s, is shorter than the typical duration of the runs " +
+		"of its pipeline, 2h0m0s."}, warnings)
+
+	// Every day.
+	job.Trigger = &api.Trigger{Trigger: &api.Trigger_CronSchedule{CronSchedule: &api.CronSchedule{Cron: "0 0 0 * * *"}}}
+	warnings, err = manager.CheckJobInterval(job)
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+
+	// The jobs without pipeline have no typical duration.
+	job.PipelineSpec = &api.PipelineSpec{WorkflowManifest: testEstimatedWorkflow.ToStringForStore()}
+	warnings, err = manager.CheckJobInterval(job)
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+}
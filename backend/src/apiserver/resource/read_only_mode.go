@@ -0,0 +1,138 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ReadOnlyMode rejects the calls which write while the API server is under maintenance, the
+// reads being served as usual. It's toggled by the admins at runtime and stored in the database,
+// every replica refreshing its copy periodically, or forced on by the configuration, e.g. for the
+// duration of a schema migration.
+type ReadOnlyMode struct {
+	store           storage.ReadOnlyModeStoreInterface
+	forced          bool
+	defaultMessage  string
+	refreshInterval time.Duration
+	time            util.TimeInterface
+
+	mutex sync.RWMutex
+	mode  model.ReadOnlyMode
+}
+
+func NewReadOnlyMode(store storage.ReadOnlyModeStoreInterface, forced bool, defaultMessage string,
+	refreshInterval time.Duration, time util.TimeInterface) *ReadOnlyMode {
+	return &ReadOnlyMode{
+		store:           store,
+		forced:          forced,
+		defaultMessage:  defaultMessage,
+		refreshInterval: refreshInterval,
+		time:            time,
+	}
+}
+
+// Run refreshes the mode from the database every refresh interval until stopCh is closed.
+func (m *ReadOnlyMode) Run(stopCh <-chan struct{}) {
+	glog.Infof("Refreshing the read-only mode every %v (forced: %v)", m.refreshInterval, m.forced)
+	wait.Until(func() {
+		if err := m.Refresh(); err != nil {
+			glog.Errorf("Failed to refresh the read-only mode: %+v", err)
+		}
+	}, m.refreshInterval, stopCh)
+}
+
+// Refresh reads the mode set by the admins through any replica.
+func (m *ReadOnlyMode) Refresh() error {
+	mode, err := m.store.GetReadOnlyMode()
+	if err != nil {
+		return util.Wrap(err, "Failed to refresh the read-only mode")
+	}
+	m.update(*mode)
+	return nil
+}
+
+// IsReadOnly returns whether the writes are rejected.
+func (m *ReadOnlyMode) IsReadOnly() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.forced || m.mode.ReadOnly
+}
+
+// IsForced returns whether the read-only mode is forced on by the configuration.
+func (m *ReadOnlyMode) IsForced() bool {
+	return m.forced
+}
+
+// Error returns the error the writes fail with in read-only mode.
+func (m *ReadOnlyMode) Error() error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	message := m.defaultMessage
+	if m.mode.ReadOnly && m.mode.Message != "" {
+		message = m.mode.Message
+	}
+	return util.NewFailedPreconditionError("%s", message)
+}
+
+// Get returns the mode stored in the database, refreshing the copy of this replica.
+func (m *ReadOnlyMode) Get() (*model.ReadOnlyMode, error) {
+	if err := m.Refresh(); err != nil {
+		return nil, err
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	mode := m.mode
+	return &mode, nil
+}
+
+// Set turns the read-only mode on or off for all the replicas, on behalf of an admin. The
+// message is the one the writes fail with, the default one being used if empty.
+func (m *ReadOnlyMode) Set(readOnly bool, message string, user string) (*model.ReadOnlyMode, error) {
+	if !readOnly && m.forced {
+		return nil, util.NewFailedPreconditionError(
+			"The read-only mode is forced by the configuration of the API server and can't be turned off")
+	}
+	if !readOnly {
+		message = ""
+	}
+	mode := model.ReadOnlyMode{
+		ReadOnly:       readOnly,
+		Message:        message,
+		UpdatedAtInSec: m.time.Now().Unix(),
+		UpdatedBy:      user,
+	}
+	if err := m.store.SetReadOnlyMode(&mode); err != nil {
+		return nil, util.Wrap(err, "Failed to set the read-only mode")
+	}
+	glog.Infof("Turned the read-only mode %v on behalf of %q", map[bool]string{true: "on", false: "off"}[readOnly], user)
+	return m.Get()
+}
+
+func (m *ReadOnlyMode) update(mode model.ReadOnlyMode) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.mode.ReadOnly != mode.ReadOnly {
+		glog.Infof("The read-only mode is now %v", map[bool]string{true: "on", false: "off"}[mode.ReadOnly])
+	}
+	m.mode = mode
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	defer db.Close()
+	store := storage.NewReadOnlyModeStore(db)
+	mode := NewReadOnlyMode(store, false, "Maintenance", time.Second, util.NewFakeTimeForEpoch())
+	// Another replica.
+	replica := NewReadOnlyMode(store, false, "Maintenance", time.Second, util.NewFakeTimeForEpoch())
+	assert.Nil(t, mode.Refresh())
+	assert.False(t, mode.IsReadOnly())
+
+	stored, err := mode.Set(true, "", "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", stored.UpdatedBy)
+	assert.True(t, mode.IsReadOnly())
+	assert.Equal(t, "Maintenance", mode.Error().(*util.UserError).ExternalMessage())
+	assert.False(t, replica.IsReadOnly())
+	assert.Nil(t, replica.Refresh())
+	assert.True(t, replica.IsReadOnly())
+
+	_, err = replica.Set(true, "Backup in progress", "bob")
+	assert.Nil(t, err)
+	assert.Equal(t, "Backup in progress", replica.Error().(*util.UserError).ExternalMessage())
+
+	_, err = mode.Set(false, "Ignored", "alice")
+	assert.Nil(t, err)
+	assert.False(t, mode.IsReadOnly())
+	stored, err = replica.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, &model.ReadOnlyMode{ID: "default", UpdatedAtInSec: 2, UpdatedBy: "alice"}, stored)
+	assert.False(t, replica.IsReadOnly())
+}
+
+func TestReadOnlyMode_Forced(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	defer db.Close()
+	mode := NewReadOnlyMode(storage.NewReadOnlyModeStore(db), true, "Migration", time.Second,
+		util.NewFakeTimeForEpoch())
+	assert.Nil(t, mode.Refresh())
+	assert.True(t, mode.IsReadOnly())
+	assert.True(t, mode.IsForced())
+	assert.Equal(t, "Migration", mode.Error().(*util.UserError).ExternalMessage())
+
+	_, err := mode.Set(false, "", "alice")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+	_, err = mode.Set(true, "Still migrating", "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, "Still migrating", mode.Error().(*util.UserError).ExternalMessage())
+}
+
+func TestReadOnlyMode_RefreshError(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	mode := NewReadOnlyMode(storage.NewReadOnlyModeStore(db), false, "Maintenance", time.Second,
+		util.NewFakeTimeForEpoch())
+	_, err := mode.Set(true, "", "alice")
+	assert.Nil(t, err)
+	db.Close()
+
+	// The last known mode is kept.
+	assert.NotNil(t, mode.Refresh())
+	assert.True(t, mode.IsReadOnly())
+}
@@ -0,0 +1,173 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"sync"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+)
+
+// The codes of the failures of the reports which don't depend on the workflow reported, e.g.
+// an unavailable database. They're retried by the persistence agent without being counted.
+var transientReportCodes = []codes.Code{
+	codes.Unavailable, codes.FailedPrecondition, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Canceled,
+	codes.Aborted}
+
+// ReportDeadLetterQueue stores the reports of the workflows which can't be applied, e.g.
+// because of bad data or a schema mismatch, so that the persistence agent neither drops
+// them nor retries them forever. The invalid reports, which the agent drops, are stored at
+// once. The others are stored once they failed maxAttempts times in a row on the replica,
+// then acknowledged, so that the agent stops retrying them. The stored reports are
+// inspected and replayed through the AdminService once the cause is fixed.
+type ReportDeadLetterQueue struct {
+	store storage.ReportDeadLetterStoreInterface
+	// Only the invalid reports are stored if it isn't positive.
+	maxAttempts int
+	time        util.TimeInterface
+	mutex       sync.Mutex
+	// The failures in a row of the reports of each run.
+	failures map[string]int
+}
+
+func NewReportDeadLetterQueue(store storage.ReportDeadLetterStoreInterface, maxAttempts int,
+	time util.TimeInterface) *ReportDeadLetterQueue {
+	return &ReportDeadLetterQueue{store: store, maxAttempts: maxAttempts, time: time, failures: make(map[string]int)}
+}
+
+// forget forgets the failures of the reports of a run.
+func (q *ReportDeadLetterQueue) forget(runId string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	delete(q.failures, runId)
+}
+
+// fail records the failure of the report of a workflow, and returns the error the reporter
+// gets: nil once a report which isn't invalid is stored, so that it isn't retried anymore.
+func (q *ReportDeadLetterQueue) fail(workflow *util.Workflow, reportErr error) error {
+	runId := string(workflow.UID)
+	invalid := util.IsUserErrorCodeMatch(reportErr, codes.InvalidArgument)
+	attempts := 1
+	if !invalid {
+		for _, code := range transientReportCodes {
+			if util.IsUserErrorCodeMatch(reportErr, code) {
+				return reportErr
+			}
+		}
+		if q.maxAttempts <= 0 {
+			return reportErr
+		}
+		q.mutex.Lock()
+		q.failures[runId]++
+		attempts = q.failures[runId]
+		q.mutex.Unlock()
+		if attempts < q.maxAttempts {
+			return reportErr
+		}
+	}
+	err := q.store.PutDeadLetter(&model.ReportDeadLetter{
+		RunUUID:       runId,
+		Namespace:     workflow.Namespace,
+		Name:          workflow.Name,
+		Workflow:      workflow.ToStringForStore(),
+		Error:         reportErr.Error(),
+		Attempts:      attempts,
+		FailedAtInSec: q.time.Now().Unix(),
+	})
+	if err != nil {
+		glog.Errorf("Failed to store the dead letter of the report of workflow %v: %+v", workflow.Name, err)
+		return reportErr
+	}
+	q.forget(runId)
+	if invalid {
+		return reportErr
+	}
+	glog.Warningf("The report of workflow %v failed %v times, it's stored as a dead letter: %+v",
+		workflow.Name, attempts, reportErr)
+	return nil
+}
+
+// ApplyWorkflowReport applies the report of a workflow sent by the persistence agent. The
+// report is stored as a dead letter if it's invalid or keeps failing.
+func (r *ResourceManager) ApplyWorkflowReport(workflow *util.Workflow) error {
+	if err := r.ReportWorkflowResource(workflow); err != nil {
+		return r.reportDeadLetters.fail(workflow, err)
+	}
+	r.reportDeadLetters.forget(string(workflow.UID))
+	return nil
+}
+
+func (r *ResourceManager) ListDeadLetterReports() ([]*model.ReportDeadLetter, error) {
+	return r.reportDeadLetters.store.ListDeadLetters()
+}
+
+func (r *ResourceManager) DeleteDeadLetterReport(runId string) error {
+	return r.reportDeadLetters.store.DeleteDeadLetter(runId)
+}
+
+// ReplayDeadLetterReports applies the dead letters of the runs again, or all of them if no
+// run is given. The reports applied are deleted, the others are kept with their new error.
+func (r *ResourceManager) ReplayDeadLetterReports(runIds []string) (*MaintenanceResult, error) {
+	var letters []*model.ReportDeadLetter
+	result := &MaintenanceResult{}
+	if len(runIds) == 0 {
+		var err error
+		if letters, err = r.reportDeadLetters.store.ListDeadLetters(); err != nil {
+			return nil, util.Wrap(err, "Failed to list the dead letters")
+		}
+	}
+	for _, runId := range runIds {
+		letter, err := r.reportDeadLetters.store.GetDeadLetter(runId)
+		if err != nil {
+			result.fail(err)
+			continue
+		}
+		letters = append(letters, letter)
+	}
+	for _, letter := range letters {
+		if err := r.replayDeadLetter(letter); err != nil {
+			result.fail(err)
+			continue
+		}
+		result.Processed++
+	}
+	glog.Infof("Replayed %v dead letters", result.Processed)
+	return result, nil
+}
+
+func (r *ResourceManager) replayDeadLetter(letter *model.ReportDeadLetter) error {
+	var workflow workflowapi.Workflow
+	if err := json.Unmarshal([]byte(letter.Workflow), &workflow); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal the dead letter of run %v", letter.RunUUID)
+	}
+	// The dead letter has the values of the secret parameters hashed already.
+	if err := r.ReportWorkflowResource(util.NewWorkflowFromStore(&workflow)); err != nil {
+		letter.Error = err.Error()
+		letter.Attempts++
+		letter.FailedAtInSec = r.time.Now().Unix()
+		if putErr := r.reportDeadLetters.store.PutDeadLetter(letter); putErr != nil {
+			glog.Errorf("Failed to update the dead letter of run %v: %+v", letter.RunUUID, putErr)
+		}
+		return util.Wrapf(err, "Failed to replay the report of run %v", letter.RunUUID)
+	}
+	r.reportDeadLetters.forget(letter.RunUUID)
+	return r.reportDeadLetters.store.DeleteDeadLetter(letter.RunUUID)
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newWorkflowOfMissingJob returns a workflow whose report fails, since the job which
+// created it doesn't exist.
+func newWorkflowOfMissingJob() *util.Workflow {
+	return util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "workflow1",
+			Namespace: "ns1",
+			UID:       "run1",
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "kubeflow.org/v1alpha1",
+				Kind:       "ScheduledWorkflow",
+				Name:       "job1",
+				UID:        types.UID("missing-job"),
+			}},
+		},
+	})
+}
+
+func TestApplyWorkflowReport_DeadLettersAfterMaxAttempts(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	workflow := newWorkflowOfMissingJob()
+
+	for i := 1; i < fakeReportMaxAttempts; i++ {
+		assert.NotNil(t, manager.ApplyWorkflowReport(workflow))
+	}
+	letters, err := manager.ListDeadLetterReports()
+	assert.Nil(t, err)
+	assert.Empty(t, letters)
+	// The report is acknowledged once it's stored, so that it isn't retried anymore.
+	assert.Nil(t, manager.ApplyWorkflowReport(workflow))
+	letters, err = manager.ListDeadLetterReports()
+	assert.Nil(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, "run1", letters[0].RunUUID)
+	assert.Equal(t, "workflow1", letters[0].Name)
+	assert.Equal(t, fakeReportMaxAttempts, letters[0].Attempts)
+	assert.Contains(t, letters[0].Error, "Failed to retrieve the experiment ID")
+	assert.Equal(t, workflow.ToStringForStore(), letters[0].Workflow)
+}
+
+func TestApplyWorkflowReport_DeadLettersInvalidReports(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	workflow := newWorkflowOfMissingJob()
+
+	err := manager.reportDeadLetters.fail(workflow, util.NewInvalidInputError("bad data"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	letters, err := manager.ListDeadLetterReports()
+	assert.Nil(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, 1, letters[0].Attempts)
+}
+
+func TestApplyWorkflowReport_RetriesTransientErrors(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	workflow := newWorkflowOfMissingJob()
+
+	for i := 0; i <= fakeReportMaxAttempts; i++ {
+		err := manager.reportDeadLetters.fail(workflow, util.NewUnavailableError(nil, "database unavailable"))
+		assert.True(t, util.IsUnavailableError(err))
+	}
+	letters, err := manager.ListDeadLetterReports()
+	assert.Nil(t, err)
+	assert.Empty(t, letters)
+}
+
+func TestReplayDeadLetterReports(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: run.Name, Namespace: run.Namespace, UID: types.UID(run.UUID)},
+		Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.NodeSucceeded},
+	})
+	deadLetters := store.ReportDeadLetterQueue().store
+	assert.Nil(t, deadLetters.PutDeadLetter(&model.ReportDeadLetter{RunUUID: run.UUID, Name: run.Name,
+		Workflow: workflow.ToStringForStore(), Error: "no such column", Attempts: 3}))
+	assert.Nil(t, deadLetters.PutDeadLetter(&model.ReportDeadLetter{RunUUID: "run1", Name: "workflow1",
+		Workflow: newWorkflowOfMissingJob().ToStringForStore(), Error: "job not found", Attempts: 3}))
+
+	result, err := manager.ReplayDeadLetterReports(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Processed)
+	assert.Len(t, result.Errors, 1)
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", runDetail.Conditions)
+	// The report which failed again is kept.
+	letters, err := manager.ListDeadLetterReports()
+	assert.Nil(t, err)
+	assert.Len(t, letters, 1)
+	assert.Equal(t, "run1", letters[0].RunUUID)
+	assert.Equal(t, 4, letters[0].Attempts)
+
+	result, err = manager.ReplayDeadLetterReports([]string{"unknown"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.Processed)
+	assert.Len(t, result.Errors, 1)
+	assert.Nil(t, manager.DeleteDeadLetterReport("run1"))
+	err = manager.DeleteDeadLetterReport("run1")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
@@ -0,0 +1,113 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	reportIgnoredStale     = "stale"
+	reportIgnoredDuplicate = "duplicate"
+)
+
+var reportsIgnoredCounter = metrics.NewCounterVec("workflow_reports_ignored_total",
+	"The reports of workflows ignored because a later version was reported (stale), or the same one (duplicate).",
+	"reason")
+
+func init() {
+	metrics.MustRegister(reportsIgnoredCounter)
+}
+
+// ReportDeduplicator makes the reports of the workflows idempotent, so that the duplicate
+// and out-of-order updates sent by the persistence agent don't make the state of the runs
+// flap. The resourceVersion of the last workflow reported for each run is recorded: an
+// older version is ignored, and so is the same version unless the state of the run drifted
+// or its full resync is due. The full resync applies the reports of the same version again
+// once per interval, repairing the runs which drifted from their workflow otherwise.
+type ReportDeduplicator struct {
+	// Nil if every report is applied.
+	store              storage.WorkflowReportStoreInterface
+	fullResyncInterval time.Duration
+	time               util.TimeInterface
+}
+
+// NewReportDeduplicator creates a deduplicator of the reports recorded in the store. Every
+// report is applied if the store is nil. The reports of the same version are never applied
+// again if the full resync interval isn't positive.
+func NewReportDeduplicator(store storage.WorkflowReportStoreInterface, fullResyncInterval time.Duration,
+	time util.TimeInterface) *ReportDeduplicator {
+	return &ReportDeduplicator{store: store, fullResyncInterval: fullResyncInterval, time: time}
+}
+
+// ShouldApply returns whether the report of a workflow must be applied to its run.
+func (d *ReportDeduplicator) ShouldApply(workflow *util.Workflow) (bool, error) {
+	resourceVersion, ok := parseResourceVersion(workflow)
+	if d.store == nil || !ok {
+		return true, nil
+	}
+	report, err := d.store.GetReport(string(workflow.UID))
+	if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, util.Wrap(err, "Failed to check the last report of the workflow")
+	}
+	switch {
+	case resourceVersion < report.ResourceVersion:
+		reportsIgnoredCounter.Inc(reportIgnoredStale)
+		glog.Infof("Ignored the report of version %v of workflow %v: version %v was reported",
+			resourceVersion, workflow.Name, report.ResourceVersion)
+		return false, nil
+	case resourceVersion > report.ResourceVersion || report.Conditions != workflow.Condition():
+		return true, nil
+	case d.fullResyncInterval > 0 &&
+		!d.time.Now().Before(time.Unix(report.ReportedAtInSec, 0).Add(d.fullResyncInterval)):
+		return true, nil
+	default:
+		reportsIgnoredCounter.Inc(reportIgnoredDuplicate)
+		return false, nil
+	}
+}
+
+// Record records the report of a workflow applied to its run.
+func (d *ReportDeduplicator) Record(workflow *util.Workflow) error {
+	resourceVersion, ok := parseResourceVersion(workflow)
+	if d.store == nil || !ok {
+		return nil
+	}
+	_, err := d.store.RecordReport(&model.WorkflowReport{
+		RunUUID:         string(workflow.UID),
+		ResourceVersion: resourceVersion,
+		Conditions:      workflow.Condition(),
+		ReportedAtInSec: d.time.Now().Unix(),
+	})
+	return err
+}
+
+// parseResourceVersion returns the resourceVersion of a workflow as a number. The versions
+// are only compared if they're numbers, as they are with etcd.
+func parseResourceVersion(workflow *util.Workflow) (int64, bool) {
+	resourceVersion, err := strconv.ParseInt(workflow.ResourceVersion, 10, 64)
+	return resourceVersion, err == nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newReportedWorkflow(resourceVersion string, phase v1alpha1.NodePhase) *util.Workflow {
+	return util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{UID: types.UID("run1"), ResourceVersion: resourceVersion},
+		Status:     v1alpha1.WorkflowStatus{Phase: phase},
+	})
+}
+
+func TestReportDeduplicator(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	defer db.Close()
+	deduplicator := NewReportDeduplicator(storage.NewWorkflowReportStore(db), 0, util.NewFakeTimeForEpoch())
+
+	apply, err := deduplicator.ShouldApply(newReportedWorkflow("5", v1alpha1.NodeRunning))
+	assert.Nil(t, err)
+	assert.True(t, apply)
+	assert.Nil(t, deduplicator.Record(newReportedWorkflow("5", v1alpha1.NodeRunning)))
+
+	// Duplicate.
+	apply, err = deduplicator.ShouldApply(newReportedWorkflow("5", v1alpha1.NodeRunning))
+	assert.Nil(t, err)
+	assert.False(t, apply)
+	// Stale.
+	apply, err = deduplicator.ShouldApply(newReportedWorkflow("4", v1alpha1.NodePending))
+	assert.Nil(t, err)
+	assert.False(t, apply)
+	// Newer.
+	apply, err = deduplicator.ShouldApply(newReportedWorkflow("6", v1alpha1.NodeSucceeded))
+	assert.Nil(t, err)
+	assert.True(t, apply)
+	// The versions which aren't numbers aren't compared.
+	apply, err = deduplicator.ShouldApply(newReportedWorkflow("", v1alpha1.NodePending))
+	assert.Nil(t, err)
+	assert.True(t, apply)
+}
+
+func TestReportDeduplicator_FullResync(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	defer db.Close()
+	reportStore := storage.NewWorkflowReportStore(db)
+	assert.Nil(t, NewReportDeduplicator(reportStore, 0, util.NewFakeTime(time.Unix(100, 0))).
+		Record(newReportedWorkflow("5", v1alpha1.NodeRunning)))
+
+	deduplicator := NewReportDeduplicator(reportStore, time.Minute, util.NewFakeTime(time.Unix(130, 0)))
+	apply, err := deduplicator.ShouldApply(newReportedWorkflow("5", v1alpha1.NodeRunning))
+	assert.Nil(t, err)
+	assert.False(t, apply)
+
+	deduplicator = NewReportDeduplicator(reportStore, time.Minute, util.NewFakeTime(time.Unix(160, 0)))
+	apply, err = deduplicator.ShouldApply(newReportedWorkflow("5", v1alpha1.NodeRunning))
+	assert.Nil(t, err)
+	assert.True(t, apply)
+}
+
+func TestReportDeduplicator_Disabled(t *testing.T) {
+	deduplicator := NewReportDeduplicator(nil, 0, util.NewFakeTimeForEpoch())
+	assert.Nil(t, deduplicator.Record(newReportedWorkflow("5", v1alpha1.NodeRunning)))
+	apply, err := deduplicator.ShouldApply(newReportedWorkflow("5", v1alpha1.NodeRunning))
+	assert.Nil(t, err)
+	assert.True(t, apply)
+}
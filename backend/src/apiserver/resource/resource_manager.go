@@ -15,23 +15,57 @@
 package resource
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
-	workflowclient "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	workflowcommon "github.com/argoproj/argo/workflow/common"
+	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/eventexport"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/policy"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
+	"github.com/kubeflow/pipelines/backend/src/common/client/visualization"
+	"github.com/kubeflow/pipelines/backend/src/common/features"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	scheduledworkflow "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
 	scheduledworkflowclient "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned/typed/scheduledworkflow/v1alpha1"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// The number of steps walked in each direction of the lineage graph of an artifact.
+	defaultArtifactLineageDepth = 10
+	maxArtifactLineageDepth     = 100
+	metricsPushTokenBytes       = 32
+	// The length of the suffix of the generated workflow names, taken from the outbox
+	// entry ID.
+	outboxWorkflowNameSuffixLength = 10
+	runOutboxBatchSize             = 100
 )
 
 type ClientManagerInterface interface {
@@ -41,8 +75,58 @@ type ClientManagerInterface interface {
 	RunStore() storage.RunStoreInterface
 	ResourceReferenceStore() storage.ResourceReferenceStoreInterface
 	ObjectStore() storage.ObjectStoreInterface
-	Workflow() workflowclient.WorkflowInterface
+	// The engine the workflows of the runs run on.
+	Engine() engine.Engine
 	ScheduledWorkflow() scheduledworkflowclient.ScheduledWorkflowInterface
+	PodClient() client.PodClientInterface
+	ExecutionTargetStore() storage.ExecutionTargetStoreInterface
+	// The clients of the remote clusters the runs are dispatched to.
+	ExecutionTargets() *ExecutionTargets
+	WebhookStore() storage.WebhookStoreInterface
+	ArtifactLineageStore() storage.ArtifactLineageStoreInterface
+	RunHistoryStore() storage.RunHistoryStoreInterface
+	ModelRegistry() storage.ModelRegistryInterface
+	// Nil if the steps of the runs can't push their metrics.
+	MetricsPushTokenStore() storage.MetricsPushTokenStoreInterface
+	FavoriteStore() storage.FavoriteStoreInterface
+	RunOutboxStore() storage.RunOutboxStoreInterface
+	RunSweepStore() storage.RunSweepStoreInterface
+	RunGroupStore() storage.RunGroupStoreInterface
+	TerminateOperationStore() storage.TerminateOperationStoreInterface
+	OperationStore() storage.OperationStoreInterface
+	// Nil if the deployments of the runs are not tracked.
+	DeploymentStatusStore() storage.DeploymentStatusStoreInterface
+	EventRecorder() record.EventRecorder
+	WebhookNotifier() webhook.NotifierInterface
+	EventPublisher() eventexport.PublisherInterface
+	// Nil if the lineage of the runs is not recorded.
+	MetadataStore() metadata.MetadataStoreInterface
+	// Nil if the visualization server isn't deployed.
+	VisualizationClient() visualization.VisualizationClientInterface
+	// The bucket of the object store the pipelines and the artifacts of the runs are stored in.
+	ObjectStoreBucket() string
+	TemplateCache() *TemplateCache
+	ReportDeduplicator() *ReportDeduplicator
+	ReportDeadLetterQueue() *ReportDeadLetterQueue
+	// The workflow options applied to the runs and the jobs not setting them.
+	WorkflowDefaults() *api.WorkflowOptions
+	// The namespace the workflows of the runs and the jobs are created in.
+	Namespace() string
+	// Nil if the namespaces don't override the configuration of their runs and jobs.
+	NamespaceConfigs() *NamespaceConfigs
+	GPUQuota() *GPUQuota
+	// The service accounts the runs and the jobs may run as, instead of the default one.
+	AllowedServiceAccounts() []string
+	// Whether the runs and the jobs of the deprecated pipelines past their sunset are refused.
+	BlockSunsetPipelines() bool
+	// The number of bytes the content of the outputs of the runs is cut at, or 0 if it isn't.
+	MaxRunOutputSize() int
+	// The prices and the number of past runs the runs are estimated with.
+	RunEstimationConfig() RunEstimationConfig
+	// The linter checking the pipelines against the policies at upload.
+	PolicyLinter() *policy.Linter
+	// The features enabled while they land.
+	FeatureGate() *features.Gate
 	Time() util.TimeInterface
 	UUID() util.UUIDGeneratorInterface
 }
@@ -52,10 +136,45 @@ type ResourceManager struct {
 	pipelineStore           storage.PipelineStoreInterface
 	jobStore                storage.JobStoreInterface
 	runStore                storage.RunStoreInterface
+	runOutboxStore          storage.RunOutboxStoreInterface
+	runSweepStore           storage.RunSweepStoreInterface
+	runGroupStore           storage.RunGroupStoreInterface
+	terminateOperationStore storage.TerminateOperationStoreInterface
+	operationStore          storage.OperationStoreInterface
 	resourceReferenceStore  storage.ResourceReferenceStoreInterface
 	objectStore             storage.ObjectStoreInterface
-	workflowClient          workflowclient.WorkflowInterface
+	engine                  engine.Engine
 	scheduledWorkflowClient scheduledworkflowclient.ScheduledWorkflowInterface
+	podClient               client.PodClientInterface
+	executionTargetStore    storage.ExecutionTargetStoreInterface
+	executionTargets        *ExecutionTargets
+	webhookStore            storage.WebhookStoreInterface
+	artifactLineageStore    storage.ArtifactLineageStoreInterface
+	runHistoryStore         storage.RunHistoryStoreInterface
+	modelRegistry           storage.ModelRegistryInterface
+	metricsPushTokenStore   storage.MetricsPushTokenStoreInterface
+	favoriteStore           storage.FavoriteStoreInterface
+	deploymentStatusStore   storage.DeploymentStatusStoreInterface
+	eventRecorder           record.EventRecorder
+	webhookNotifier         webhook.NotifierInterface
+	eventPublisher          eventexport.PublisherInterface
+	metadataStore           metadata.MetadataStoreInterface
+	visualizationClient     visualization.VisualizationClientInterface
+	objectStoreBucket       string
+	runWatcher              *RunWatcher
+	templateCache           *TemplateCache
+	reportDeduplicator      *ReportDeduplicator
+	reportDeadLetters       *ReportDeadLetterQueue
+	workflowDefaults        *api.WorkflowOptions
+	namespace               string
+	namespaceConfigs        *NamespaceConfigs
+	gpuQuota                *GPUQuota
+	allowedServiceAccounts  []string
+	blockSunsetPipelines    bool
+	maxRunOutputSize        int
+	runEstimationConfig     RunEstimationConfig
+	policyLinter            *policy.Linter
+	featureGate             *features.Gate
 	time                    util.TimeInterface
 	uuid                    util.UUIDGeneratorInterface
 }
@@ -66,10 +185,45 @@ func NewResourceManager(clientManager ClientManagerInterface) *ResourceManager {
 		pipelineStore:           clientManager.PipelineStore(),
 		jobStore:                clientManager.JobStore(),
 		runStore:                clientManager.RunStore(),
+		runOutboxStore:          clientManager.RunOutboxStore(),
+		runSweepStore:           clientManager.RunSweepStore(),
+		runGroupStore:           clientManager.RunGroupStore(),
+		terminateOperationStore: clientManager.TerminateOperationStore(),
+		operationStore:          clientManager.OperationStore(),
 		resourceReferenceStore:  clientManager.ResourceReferenceStore(),
 		objectStore:             clientManager.ObjectStore(),
-		workflowClient:          clientManager.Workflow(),
+		engine:                  clientManager.Engine(),
 		scheduledWorkflowClient: clientManager.ScheduledWorkflow(),
+		podClient:               clientManager.PodClient(),
+		executionTargetStore:    clientManager.ExecutionTargetStore(),
+		executionTargets:        clientManager.ExecutionTargets(),
+		webhookStore:            clientManager.WebhookStore(),
+		artifactLineageStore:    clientManager.ArtifactLineageStore(),
+		runHistoryStore:         clientManager.RunHistoryStore(),
+		modelRegistry:           clientManager.ModelRegistry(),
+		metricsPushTokenStore:   clientManager.MetricsPushTokenStore(),
+		favoriteStore:           clientManager.FavoriteStore(),
+		deploymentStatusStore:   clientManager.DeploymentStatusStore(),
+		eventRecorder:           clientManager.EventRecorder(),
+		webhookNotifier:         clientManager.WebhookNotifier(),
+		eventPublisher:          clientManager.EventPublisher(),
+		metadataStore:           clientManager.MetadataStore(),
+		visualizationClient:     clientManager.VisualizationClient(),
+		objectStoreBucket:       clientManager.ObjectStoreBucket(),
+		runWatcher:              NewRunWatcher(),
+		templateCache:           clientManager.TemplateCache(),
+		reportDeduplicator:      clientManager.ReportDeduplicator(),
+		reportDeadLetters:       clientManager.ReportDeadLetterQueue(),
+		workflowDefaults:        clientManager.WorkflowDefaults(),
+		namespace:               clientManager.Namespace(),
+		namespaceConfigs:        clientManager.NamespaceConfigs(),
+		gpuQuota:                clientManager.GPUQuota(),
+		allowedServiceAccounts:  clientManager.AllowedServiceAccounts(),
+		blockSunsetPipelines:    clientManager.BlockSunsetPipelines(),
+		maxRunOutputSize:        clientManager.MaxRunOutputSize(),
+		runEstimationConfig:     clientManager.RunEstimationConfig(),
+		policyLinter:            clientManager.PolicyLinter(),
+		featureGate:             clientManager.FeatureGate(),
 		time:                    clientManager.Time(),
 		uuid:                    clientManager.UUID(),
 	}
@@ -79,6 +233,11 @@ func (r *ResourceManager) GetTime() util.TimeInterface {
 	return r.time
 }
 
+// GetFeatureStates returns the state of the features gated while they land.
+func (r *ResourceManager) GetFeatureStates() []features.State {
+	return r.featureGate.States()
+}
+
 func (r *ResourceManager) CreateExperiment(experiment *model.Experiment) (*model.Experiment, error) {
 	return r.experimentStore.CreateExperiment(experiment)
 }
@@ -87,20 +246,126 @@ func (r *ResourceManager) GetExperiment(experimentId string) (*model.Experiment,
 	return r.experimentStore.GetExperiment(experimentId)
 }
 
-func (r *ResourceManager) ListExperiments(context *common.PaginationContext) (
+// ResolveExperimentReference sets the ID of the experiment referred to by its name only,
+// creating the experiment if no experiment has the name.
+func (r *ResourceManager) ResolveExperimentReference(references []*api.ResourceReference) error {
+	for _, reference := range references {
+		if reference.GetKey().GetType() != api.ResourceType_EXPERIMENT ||
+			reference.GetKey().GetId() != "" || reference.GetName() == "" {
+			continue
+		}
+		experiment, err := r.experimentStore.CreateExperimentIfNotExists(&model.Experiment{Name: reference.GetName()})
+		if err != nil {
+			return util.Wrapf(err, "Failed to get or create the experiment %q", reference.GetName())
+		}
+		reference.Key.Id = experiment.UUID
+	}
+	return nil
+}
+
+func (r *ResourceManager) ListExperiments(filterContext *common.FilterContext, context *common.PaginationContext) (
 	experiments []model.Experiment, nextPageToken string, err error) {
-	return r.experimentStore.ListExperiments(context)
+	return r.experimentStore.ListExperiments(filterContext, context)
+}
+
+func (r *ResourceManager) CreateWebhook(webhook *model.Webhook) (*model.Webhook, error) {
+	if webhook.ExperimentUUID != "" {
+		if _, err := r.experimentStore.GetExperiment(webhook.ExperimentUUID); err != nil {
+			return nil, util.Wrap(err, "Failed to create the webhook")
+		}
+	}
+	return r.webhookStore.CreateWebhook(webhook)
 }
 
-func (r *ResourceManager) ListPipelines(context *common.PaginationContext) (
+func (r *ResourceManager) GetWebhook(webhookId string) (*model.Webhook, error) {
+	return r.webhookStore.GetWebhook(webhookId)
+}
+
+func (r *ResourceManager) ListWebhooks(context *common.PaginationContext) (
+	webhooks []model.Webhook, nextPageToken string, err error) {
+	return r.webhookStore.ListWebhooks(context)
+}
+
+func (r *ResourceManager) DeleteWebhook(webhookId string) error {
+	if _, err := r.webhookStore.GetWebhook(webhookId); err != nil {
+		return util.Wrap(err, "Failed to delete the webhook")
+	}
+	return r.webhookStore.DeleteWebhook(webhookId)
+}
+
+func (r *ResourceManager) ListPipelines(filterContext *common.FilterContext, context *common.PaginationContext) (
 	pipelines []model.Pipeline, nextPageToken string, err error) {
-	return r.pipelineStore.ListPipelines(context)
+	return r.pipelineStore.ListPipelines(filterContext, context)
 }
 
 func (r *ResourceManager) GetPipeline(pipelineId string) (*model.Pipeline, error) {
 	return r.pipelineStore.GetPipeline(pipelineId)
 }
 
+// DeprecatePipeline deprecates a pipeline, with the pipeline replacing it and its sunset if
+// set, or 0.
+func (r *ResourceManager) DeprecatePipeline(pipelineId string, replacementPipelineId string,
+	sunsetAtInSec int64) (*model.Pipeline, error) {
+	if _, err := r.pipelineStore.GetPipeline(pipelineId); err != nil {
+		return nil, util.Wrap(err, "Deprecate pipeline failed")
+	}
+	if replacementPipelineId != "" {
+		if replacementPipelineId == pipelineId {
+			return nil, util.NewInvalidInputError("A pipeline can't replace itself")
+		}
+		if _, err := r.pipelineStore.GetPipeline(replacementPipelineId); err != nil {
+			return nil, util.Wrap(err, "Failed to get the replacement pipeline")
+		}
+	}
+	if err := r.pipelineStore.UpdatePipelineDeprecation(pipelineId, true, replacementPipelineId, sunsetAtInSec); err != nil {
+		return nil, util.Wrap(err, "Deprecate pipeline failed")
+	}
+	return r.pipelineStore.GetPipeline(pipelineId)
+}
+
+// UndeprecatePipeline clears the deprecation of a pipeline.
+func (r *ResourceManager) UndeprecatePipeline(pipelineId string) (*model.Pipeline, error) {
+	if _, err := r.pipelineStore.GetPipeline(pipelineId); err != nil {
+		return nil, util.Wrap(err, "Undeprecate pipeline failed")
+	}
+	if err := r.pipelineStore.UpdatePipelineDeprecation(pipelineId, false, "", 0); err != nil {
+		return nil, util.Wrap(err, "Undeprecate pipeline failed")
+	}
+	return r.pipelineStore.GetPipeline(pipelineId)
+}
+
+// CheckPipelineDeprecation returns the warnings of a run or a job of a pipeline spec, i.e.
+// whether its pipeline is deprecated. The runs and jobs of the pipelines past their sunset
+// are refused if the server is configured so.
+func (r *ResourceManager) CheckPipelineDeprecation(spec *api.PipelineSpec) ([]string, error) {
+	if spec.GetPipelineId() == "" {
+		return nil, nil
+	}
+	pipeline, err := r.pipelineStore.GetPipeline(spec.GetPipelineId())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the pipeline")
+	}
+	if !pipeline.Deprecated {
+		return nil, nil
+	}
+	warning := fmt.Sprintf("Pipeline %v is deprecated", pipeline.Name)
+	if pipeline.ReplacementPipelineId != "" {
+		warning += fmt.Sprintf(", use pipeline %v instead", pipeline.ReplacementPipelineId)
+	}
+	if pipeline.SunsetAtInSec != 0 {
+		sunsetAt := time.Unix(pipeline.SunsetAtInSec, 0).UTC().Format(time.RFC3339)
+		if r.time.Now().Unix() >= pipeline.SunsetAtInSec {
+			if r.blockSunsetPipelines {
+				return nil, util.NewInvalidInputError("%v. It is no longer supported since %v.", warning, sunsetAt)
+			}
+			warning += fmt.Sprintf(". It is no longer supported since %v", sunsetAt)
+		} else {
+			warning += fmt.Sprintf(". It is no longer supported after %v", sunsetAt)
+		}
+	}
+	return []string{warning + "."}, nil
+}
+
 func (r *ResourceManager) DeletePipeline(pipelineId string) error {
 	_, err := r.pipelineStore.GetPipeline(pipelineId)
 	if err != nil {
@@ -116,58 +381,258 @@ func (r *ResourceManager) DeletePipeline(pipelineId string) error {
 	// Delete pipeline file and DB entry.
 	// Not fail the request if this step failed. A background run will do the cleanup.
 	// https://github.com/kubeflow/pipelines/issues/388
+	r.removeCachedTemplates(pipelineId)
 	err = r.objectStore.DeleteFile(storage.CreatePipelinePath(fmt.Sprint(pipelineId)))
 	if err != nil {
 		glog.Errorf("%v", errors.Wrapf(err, "Failed to delete pipeline file for pipeline %v", pipelineId))
 		return nil
 	}
+	err = r.objectStore.DeleteFile(storage.CreatePipelineManifestPath(pipelineId))
+	if err != nil {
+		glog.Errorf("%v", errors.Wrapf(err, "Failed to delete the compiled workflow of pipeline %v", pipelineId))
+		return nil
+	}
 	err = r.pipelineStore.DeletePipeline(pipelineId)
 	if err != nil {
 		glog.Errorf("%v", errors.Wrapf(err, "Failed to delete pipeline DB entry for pipeline %v", pipelineId))
+		return nil
+	}
+	if err := r.favoriteStore.DeleteFavorites(common.Pipeline, pipelineId); err != nil {
+		glog.Errorf("%v", errors.Wrapf(err, "Failed to delete the favorites of pipeline %v", pipelineId))
+	}
+	// Most pipelines have no README, which the consistency checks find if it's left behind.
+	if err := r.objectStore.DeleteFile(storage.CreatePipelineReadmePath(pipelineId)); err != nil {
+		glog.Infof("%v", errors.Wrapf(err, "The README of pipeline %v wasn't deleted", pipelineId))
 	}
 	return nil
 }
 
+// StarResource adds a pipeline or a run to the favorites of a user.
+func (r *ResourceManager) StarResource(userId string, resourceType common.ResourceType, resourceId string) error {
+	if err := r.checkFavoriteExists(resourceType, resourceId); err != nil {
+		return util.Wrapf(err, "Failed to star %v", resourceType)
+	}
+	return r.favoriteStore.StarResource(userId, resourceType, resourceId)
+}
+
+// UnstarResource removes a pipeline or a run from the favorites of a user.
+func (r *ResourceManager) UnstarResource(userId string, resourceType common.ResourceType, resourceId string) error {
+	if err := r.checkFavoriteExists(resourceType, resourceId); err != nil {
+		return util.Wrapf(err, "Failed to unstar %v", resourceType)
+	}
+	return r.favoriteStore.UnstarResource(userId, resourceType, resourceId)
+}
+
+func (r *ResourceManager) checkFavoriteExists(resourceType common.ResourceType, resourceId string) error {
+	var err error
+	switch resourceType {
+	case common.Pipeline:
+		_, err = r.pipelineStore.GetPipeline(resourceId)
+	case common.Run:
+		_, err = r.runStore.GetRun(resourceId)
+	default:
+		err = util.NewInvalidInputError("Only pipelines and runs can be starred, not %v", resourceType)
+	}
+	return err
+}
+
 func (r *ResourceManager) CreatePipeline(name string, description string, pipelineFile []byte) (*model.Pipeline, error) {
-	// Extract the parameter from the pipeline
-	params, err := util.GetParameters(pipelineFile)
+	return r.CreatePipelineWithReadme(name, description, pipelineFile, nil)
+}
+
+// CreatePipelineWithReadme creates a pipeline documented by a README, which isn't stored if
+// empty.
+func (r *ResourceManager) CreatePipelineWithReadme(name string, description string, pipelineFile []byte,
+	readme []byte) (*model.Pipeline, error) {
+	// Parse the pipeline once: extract its parameters and its steps, and compile its workflow
+	compiled, err := util.CompilePipeline(pipelineFile)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed")
 	}
+	if err := r.enforcePolicies(name, pipelineFile); err != nil {
+		return nil, util.Wrap(err, "Create pipeline failed")
+	}
 
 	// Create an entry with status of creating the pipeline
-	pipeline := &model.Pipeline{Name: name, Description: description, Parameters: params, Status: model.PipelineCreating}
+	pipeline := &model.Pipeline{Name: name, Description: description, Parameters: compiled.Parameters,
+		ParameterSchema: compiled.ParameterSchema, TemplateChecksum: templateChecksum(pipelineFile),
+		Status: model.PipelineCreating}
 	newPipeline, err := r.pipelineStore.CreatePipeline(pipeline)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed")
 	}
 
-	// Store the pipeline file
+	// Store the pipeline file, its compiled workflow and its steps
 	err = r.objectStore.AddFile(pipelineFile, storage.CreatePipelinePath(fmt.Sprint(newPipeline.UUID)))
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed")
 	}
+	err = r.objectStore.AddFile([]byte(compiled.WorkflowManifest), storage.CreatePipelineManifestPath(newPipeline.UUID))
+	if err != nil {
+		return nil, util.Wrap(err, "Create pipeline failed")
+	}
+	if len(readme) > 0 {
+		err = r.objectStore.AddFile(readme, storage.CreatePipelineReadmePath(newPipeline.UUID))
+		if err != nil {
+			return nil, util.Wrap(err, "Create pipeline failed")
+		}
+	}
+	steps, err := toModelPipelineSteps(newPipeline.UUID, compiled.Steps)
+	if err != nil {
+		return nil, util.Wrap(err, "Create pipeline failed")
+	}
+	err = r.pipelineStore.CreatePipelineSteps(newPipeline.UUID, steps)
+	if err != nil {
+		return nil, util.Wrap(err, "Create pipeline failed")
+	}
 
 	newPipeline.Status = model.PipelineReady
 	err = r.pipelineStore.UpdatePipelineStatus(newPipeline.UUID, newPipeline.Status)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed")
 	}
+	r.eventPublisher.Publish(&eventexport.Event{
+		Type:         eventexport.EventTypePipelineCreated,
+		TimeInSec:    newPipeline.CreatedAtInSec,
+		PipelineId:   newPipeline.UUID,
+		PipelineName: newPipeline.Name,
+	})
 	return newPipeline, nil
 }
 
+// UpsertPipeline creates the pipeline with the name, or replaces its file and description
+// if they changed, e.g. when a sample is loaded again. It returns the pipeline and whether
+// it was created or updated.
+func (r *ResourceManager) UpsertPipeline(name string, description string, pipelineFile []byte) (
+	*model.Pipeline, bool, error) {
+	return r.UpsertPipelineWithReadme(name, description, pipelineFile, nil)
+}
+
+// UpsertPipelineWithReadme upserts a pipeline like UpsertPipeline, replacing its README if
+// the new one isn't empty.
+func (r *ResourceManager) UpsertPipelineWithReadme(name string, description string, pipelineFile []byte,
+	readme []byte) (*model.Pipeline, bool, error) {
+	pipeline, err := r.pipelineStore.GetPipelineByName(name)
+	if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		pipeline, err = r.CreatePipelineWithReadme(name, description, pipelineFile, readme)
+		return pipeline, err == nil, err
+	}
+	if err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	if pipeline.Status == model.PipelineDeleting {
+		return nil, false, util.NewAlreadyExistError("Pipeline %v is being deleted", name)
+	}
+	// The pipeline is kept as is if it's unchanged, unless a crash left it in creation.
+	if pipeline.Status == model.PipelineReady && pipeline.Description == description {
+		storedFile, err := r.objectStore.GetFile(storage.CreatePipelinePath(pipeline.UUID))
+		if err == nil && bytes.Equal(storedFile, pipelineFile) && r.keepsPipelineReadme(pipeline.UUID, readme) {
+			return pipeline, false, nil
+		}
+	}
+
+	compiled, err := util.CompilePipeline(pipelineFile)
+	if err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	if err := r.enforcePolicies(name, pipelineFile); err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	err = r.objectStore.AddFile(pipelineFile, storage.CreatePipelinePath(pipeline.UUID))
+	if err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	err = r.objectStore.AddFile([]byte(compiled.WorkflowManifest), storage.CreatePipelineManifestPath(pipeline.UUID))
+	if err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	if len(readme) > 0 {
+		err = r.objectStore.AddFile(readme, storage.CreatePipelineReadmePath(pipeline.UUID))
+		if err != nil {
+			return nil, false, util.Wrap(err, "Upsert pipeline failed")
+		}
+	}
+	r.removeCachedTemplates(pipeline.UUID)
+	checksum := templateChecksum(pipelineFile)
+	steps, err := toModelPipelineSteps(pipeline.UUID, compiled.Steps)
+	if err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	if err = r.pipelineStore.CreatePipelineSteps(pipeline.UUID, steps); err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	// The other replicas reload their cached templates once the checksum changes.
+	err = r.pipelineStore.UpdatePipelineDefinition(pipeline.UUID, description, compiled.Parameters,
+		compiled.ParameterSchema, checksum)
+	if err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	if err = r.pipelineStore.UpdatePipelineStatus(pipeline.UUID, model.PipelineReady); err != nil {
+		return nil, false, util.Wrap(err, "Upsert pipeline failed")
+	}
+	pipeline.Description = description
+	pipeline.Parameters = compiled.Parameters
+	pipeline.ParameterSchema = compiled.ParameterSchema
+	pipeline.TemplateChecksum = checksum
+	pipeline.Status = model.PipelineReady
+	return pipeline, true, nil
+}
+
+// keepsPipelineReadme tells whether a new README leaves the one of a pipeline as it is: if
+// it's empty or the same.
+func (r *ResourceManager) keepsPipelineReadme(pipelineId string, readme []byte) bool {
+	if len(readme) == 0 {
+		return true
+	}
+	storedReadme, err := r.objectStore.GetFile(storage.CreatePipelineReadmePath(pipelineId))
+	return err == nil && bytes.Equal(storedReadme, readme)
+}
+
+// ValidatePipeline returns the violations of the policies by an uploaded pipeline, or by a
+// pipeline file if the ID is empty.
+func (r *ResourceManager) ValidatePipeline(pipelineId string, pipelineFile []byte) ([]policy.Violation, error) {
+	if pipelineId != "" {
+		template, err := r.GetPipelineTemplate(pipelineId)
+		if err != nil {
+			return nil, util.Wrap(err, "Validate pipeline failed")
+		}
+		pipelineFile = template
+	}
+	workflow, err := util.ValidateWorkflow(pipelineFile)
+	if err != nil {
+		return nil, util.Wrap(err, "Validate pipeline failed")
+	}
+	return r.policyLinter.Lint(util.NewWorkflow(workflow)), nil
+}
+
+// enforcePolicies rejects a pipeline file violating an enforced policy. The violations of
+// the other policies are logged.
+func (r *ResourceManager) enforcePolicies(name string, pipelineFile []byte) error {
+	violations, err := r.ValidatePipeline("", pipelineFile)
+	if err != nil {
+		return err
+	}
+	for _, violation := range violations {
+		if violation.Mode == policy.ModeWarn {
+			glog.Warningf("Pipeline %v violates a policy: %v", name, violation)
+		}
+	}
+	return policy.NewViolationsError(violations)
+}
+
 func (r *ResourceManager) UpdatePipelineStatus(pipelineId string, status model.PipelineStatus) error {
+	r.removeCachedTemplates(pipelineId)
 	return r.pipelineStore.UpdatePipelineStatus(pipelineId, status)
 }
 
 func (r *ResourceManager) GetPipelineTemplate(pipelineId string) ([]byte, error) {
 	// Verify pipeline exist
-	_, err := r.pipelineStore.GetPipeline(pipelineId)
+	pipeline, err := r.pipelineStore.GetPipeline(pipelineId)
 	if err != nil {
 		return nil, util.Wrap(err, "Get pipeline template failed")
 	}
 
-	template, err := r.objectStore.GetFile(storage.CreatePipelinePath(fmt.Sprint(pipelineId)))
+	template, err := r.getTemplate(pipeline)
 	if err != nil {
 		return nil, util.Wrap(err, "Get pipeline template failed")
 	}
@@ -175,7 +640,142 @@ func (r *ResourceManager) GetPipelineTemplate(pipelineId string) ([]byte, error)
 	return template, nil
 }
 
-func (r *ResourceManager) CreateRun(apiRun *api.Run) (*model.RunDetail, error) {
+// GetPipelineReadme returns the sanitized README of a pipeline, uploaded with the pipeline.
+func (r *ResourceManager) GetPipelineReadme(pipelineId string) (string, error) {
+	if _, err := r.pipelineStore.GetPipeline(pipelineId); err != nil {
+		return "", util.Wrap(err, "Get pipeline README failed")
+	}
+	readmePath := storage.CreatePipelineReadmePath(pipelineId)
+	// The object store fails to get a missing file like any other file.
+	files, err := r.objectStore.ListFiles(readmePath)
+	if err != nil {
+		return "", util.Wrap(err, "Get pipeline README failed")
+	}
+	found := false
+	for _, file := range files {
+		found = found || file.Path == readmePath
+	}
+	if !found {
+		return "", util.NewResourceNotFoundError("README of pipeline", pipelineId)
+	}
+	readme, err := r.objectStore.GetFile(readmePath)
+	if err != nil {
+		return "", util.Wrap(err, "Get pipeline README failed")
+	}
+	return util.SanitizeMarkdown(string(readme)), nil
+}
+
+// GetPipelineSteps returns the steps of a pipeline, indexing them first if the pipeline was
+// uploaded before its steps were indexed.
+func (r *ResourceManager) GetPipelineSteps(pipelineId string) ([]*model.PipelineStep, error) {
+	pipeline, err := r.pipelineStore.GetPipeline(pipelineId)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline steps failed")
+	}
+	steps, err := r.pipelineStore.ListPipelineSteps(pipelineId)
+	if err != nil || len(steps) > 0 {
+		return steps, util.Wrap(err, "Get pipeline steps failed")
+	}
+	steps, err = r.indexPipelineSteps(pipeline)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline steps failed")
+	}
+	return steps, nil
+}
+
+// indexPipelineSteps stores the steps of a pipeline compiled from its template, replacing the
+// ones indexed before.
+func (r *ResourceManager) indexPipelineSteps(pipeline *model.Pipeline) ([]*model.PipelineStep, error) {
+	template, err := r.getTemplate(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := util.CompilePipeline(template)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := toModelPipelineSteps(pipeline.UUID, compiled.Steps)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.pipelineStore.CreatePipelineSteps(pipeline.UUID, steps); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// getTemplate returns the template of a pipeline from the template cache, or the object store.
+// The cached template is served only if the checksum of the pipeline row still matches it.
+func (r *ResourceManager) getTemplate(pipeline *model.Pipeline) ([]byte, error) {
+	return r.templateCache.Get(pipeline.UUID, pipeline.TemplateChecksum, func() ([]byte, error) {
+		return r.objectStore.GetFile(storage.CreatePipelinePath(pipeline.UUID))
+	})
+}
+
+// getWorkflowManifest returns the workflow of a pipeline compiled into JSON. The workflows of
+// the pipelines uploaded before they were compiled are compiled on first use.
+func (r *ResourceManager) getWorkflowManifest(pipeline *model.Pipeline) ([]byte, error) {
+	pipelineId := pipeline.UUID
+	manifestPath := storage.CreatePipelineManifestPath(pipelineId)
+	return r.templateCache.Get(manifestPath, pipeline.TemplateChecksum, func() ([]byte, error) {
+		manifest, err := r.objectStore.GetFile(manifestPath)
+		if err == nil && len(manifest) > 0 {
+			return manifest, nil
+		}
+		template, err := r.objectStore.GetFile(storage.CreatePipelinePath(pipelineId))
+		if err != nil {
+			return nil, err
+		}
+		var workflow util.Workflow
+		if err := yaml.Unmarshal(template, &workflow); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to unmarshal the template of pipeline %v: %v",
+				pipelineId, err.Error())
+		}
+		manifest = []byte(workflow.ToStringForStore())
+		if err := r.objectStore.AddFile(manifest, manifestPath); err != nil {
+			glog.Warningf("Failed to store the compiled workflow of pipeline %v: %v", pipelineId, err)
+		}
+		return manifest, nil
+	})
+}
+
+// templateChecksum returns the checksum of a pipeline file, versioning its cached templates.
+func templateChecksum(pipelineFile []byte) string {
+	checksum := sha256.Sum256(pipelineFile)
+	return hex.EncodeToString(checksum[:])
+}
+
+func (r *ResourceManager) removeCachedTemplates(pipelineId string) {
+	r.templateCache.Remove(pipelineId)
+	r.templateCache.Remove(storage.CreatePipelineManifestPath(pipelineId))
+}
+
+// ComparePipelines returns the differences between the templates of two pipelines.
+func (r *ResourceManager) ComparePipelines(baseId string, targetId string) (*util.TemplateDiff, error) {
+	base, err := r.GetPipelineTemplate(baseId)
+	if err != nil {
+		return nil, util.Wrap(err, "Compare pipelines failed")
+	}
+	target, err := r.GetPipelineTemplate(targetId)
+	if err != nil {
+		return nil, util.Wrap(err, "Compare pipelines failed")
+	}
+	return util.DiffTemplates(base, target)
+}
+
+// CreateRun creates the workflow of a run and stores the run. The intent to create the
+// run is stored first, so that a run interrupted after its workflow is created is
+// completed by ReconcileRunOutbox instead of leaving the workflow orphaned. The workflow is
+// annotated with the ID of the call creating the run, if any, and queued if its pipeline
+// has its max number of active runs.
+func (r *ResourceManager) CreateRun(apiRun *api.Run, requestId string) (*model.RunDetail, error) {
+	if err := r.checkServiceAccount(apiRun.GetServiceAccount()); err != nil {
+		return nil, err
+	}
+	// The workflow is dispatched to the execution target of the run, if any
+	if _, err := r.targetClients(apiRun.GetExecutionTarget()); err != nil {
+		return nil, err
+	}
 	// Get workflow from pipeline spec, which might be pipeline ID or an argo workflow
 	workflowSpecManifestBytes, err := r.getWorkflowSpecBytes(apiRun.GetPipelineSpec())
 	if err != nil {
@@ -188,48 +788,504 @@ func (r *ResourceManager) CreateRun(apiRun *api.Run) (*model.RunDetail, error) {
 			"Failed to unmarshal workflow spec manifest. Workflow bytes: %s", string(workflowSpecManifestBytes))
 	}
 
+	if hasParameterMacros(apiRun.GetPipelineSpec().GetParameters()) {
+		context, err := r.newParameterContext(apiRun.GetName(), apiRun.GetPipelineSpec(), apiRun.GetResourceReferences())
+		if err != nil {
+			return nil, err
+		}
+		context.now = r.time.Now()
+		if err := evaluateParameters(apiRun.GetPipelineSpec().GetParameters(), &workflow, context); err != nil {
+			return nil, util.Wrap(err, "Failed to evaluate the parameters.")
+		}
+	}
 	parameters := toParametersMap(apiRun.GetPipelineSpec().GetParameters())
-	// Verify no additional parameter provided
-	if err := workflow.VerifyParameters(parameters); err != nil {
+	// Verify the parameters provided against the ones of the workflow
+	if err := workflow.ValidateParameters(parameters); err != nil {
 		return nil, util.Wrap(err, "Failed to verify parameters.")
 	}
 	// Append provided parameter
 	workflow.OverrideParameters(parameters)
+	if err := applyPartialExecution(&workflow, apiRun.GetPartialExecution()); err != nil {
+		return nil, util.Wrap(err, "Failed to select the steps to execute.")
+	}
+	// Label the workflow with the pipeline and experiment it belongs to
+	for key, value := range toWorkflowLabels(apiRun.GetPipelineSpec(), apiRun.GetResourceReferences()) {
+		workflow.SetLabels(key, value)
+	}
+	options, err := pipelineSpecWorkflowOptions(apiRun.GetPipelineSpec())
+	if err != nil {
+		return nil, err
+	}
+	namespaceConfig, err := r.getNamespaceConfig()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the configuration of the namespace of the run")
+	}
+	workflowOptions := mergeWorkflowOptions(options, namespaceConfig.workflowDefaults(r.workflowDefaults))
+	workflowLabels, err := applyWorkflowOptions(&workflow, workflowOptions)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range workflowLabels {
+		workflow.SetLabels(key, value)
+	}
+	namespaceConfig.apply(&workflow)
+	if serviceAccount := apiRun.GetServiceAccount(); serviceAccount != "" {
+		workflow.SetServiceAccount(serviceAccount)
+	}
+	if requestId != "" {
+		workflow.SetAnnotations(util.AnnotationKeyRequestId, requestId)
+	}
+	// A pipeline with its max number of active runs queues or rejects the run
+	if err := r.applyConcurrency(apiRun.GetPipelineSpec().GetPipelineId(), &workflow); err != nil {
+		return nil, err
+	}
+	// So does a step requesting more GPUs than the quotas of the namespace have left. The
+	// quotas are the ones of the cluster of the API server.
+	if apiRun.GetExecutionTarget() == "" {
+		if err := r.applyGPUQuota(&workflow); err != nil {
+			return nil, err
+		}
+	}
+	metricsPushToken, err := r.issueMetricsPushToken(&workflow)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to issue the metrics push token of the run")
+	}
 
-	// Create argo workflow CRD resource
-	newWorkflow, err := r.workflowClient.Create(workflow.Get())
+	entry, err := r.createRunOutboxEntry(apiRun, &workflow, workflowSpecManifestBytes, metricsPushToken)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to store the intent to create the run")
+	}
+	newWorkflow, err := r.createOutboxWorkflow(entry, &workflow, apiRun.GetExecutionTarget())
+	if err != nil {
+		// The request fails, so the run must not be created later. A workflow whose
+		// creation timed out is left to the orphaned workflow reconciliation.
+		if deleteErr := r.runOutboxStore.DeleteEntry(entry.UUID); deleteErr != nil {
+			glog.Errorf("Failed to delete the outbox entry %v of a run that failed: %+v", entry.UUID, deleteErr)
+		}
+		return nil, err
+	}
+	return r.storeOutboxRun(entry, apiRun, newWorkflow)
+}
+
+// checkServiceAccount returns an error unless a run or a job may run as the service account.
+func (r *ResourceManager) checkServiceAccount(serviceAccount string) error {
+	if serviceAccount == "" {
+		return nil
+	}
+	for _, allowed := range r.allowedServiceAccounts {
+		if serviceAccount == allowed {
+			return nil
+		}
+	}
+	return util.NewInvalidInputError("The service account %q isn't allowed. The allowed service accounts are %v",
+		serviceAccount, r.allowedServiceAccounts)
+}
+
+// getNamespaceConfig returns the configuration overriding the one of the API server for the
+// workflows of the namespace the runs and the jobs are created in.
+func (r *ResourceManager) getNamespaceConfig() (*NamespaceConfig, error) {
+	if r.namespaceConfigs == nil {
+		return &NamespaceConfig{}, nil
+	}
+	return r.namespaceConfigs.Get(r.namespace)
+}
+
+// newParameterContext returns what the macros of the parameters of a run or a job refer to.
+func (r *ResourceManager) newParameterContext(name string, pipelineSpec *api.PipelineSpec,
+	references []*api.ResourceReference) (parameterContext, error) {
+	context := parameterContext{runName: name, pipelineId: pipelineSpec.GetPipelineId()}
+	for _, reference := range references {
+		if reference.GetKey().GetType() == api.ResourceType_EXPERIMENT {
+			context.experimentId = reference.GetKey().GetId()
+		}
+	}
+	if context.experimentId != "" {
+		experiment, err := r.experimentStore.GetExperiment(context.experimentId)
+		if err != nil {
+			return context, util.Wrap(err, "Failed to get the experiment of the parameters")
+		}
+		context.experimentName = experiment.Name
+	}
+	return context, nil
+}
+
+// createRunOutboxEntry stores the intent to create a run before its workflow is created.
+// The name of the workflow is set first, so that a workflow created by an interrupted
+// attempt is found by the next one instead of being created twice.
+func (r *ResourceManager) createRunOutboxEntry(apiRun *api.Run, workflow *util.Workflow,
+	workflowSpecManifest []byte, metricsPushToken string) (*model.RunOutboxEntry, error) {
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate the outbox entry ID")
+	}
+	if workflow.GenerateName != "" {
+		suffix := strings.Replace(uuid.String(), "-", "", -1)[:outboxWorkflowNameSuffixLength]
+		workflow.Name = workflow.GenerateName + suffix
+		workflow.GenerateName = ""
+	}
+	// The entry keeps the values of the secret parameters to create the workflow with
+	// encrypted, and the workflow and the run with the values masked.
+	secretValues := workflow.SecretParameterValues()
+	secretParameters := ""
+	storedRun := apiRun
+	if len(secretValues) > 0 {
+		if !util.HasSecretParameterKey() {
+			return nil, util.NewFailedPreconditionError(
+				"The runs with secret parameters can't be created: no key is configured to encrypt their values")
+		}
+		secretParameters, err = util.EncryptSecretParameterValues(secretValues)
+		if err != nil {
+			return nil, err
+		}
+		storedRun = proto.Clone(apiRun).(*api.Run)
+		for _, param := range storedRun.GetPipelineSpec().GetParameters() {
+			if _, ok := secretValues[param.GetName()]; ok {
+				param.Value = util.MaskedParameterValue
+			}
+		}
+	}
+	run, err := (&jsonpb.Marshaler{}).MarshalToString(storedRun)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the run %v", apiRun.GetName())
+	}
+	entry := &model.RunOutboxEntry{
+		UUID:                 uuid.String(),
+		Workflow:             workflow.ToStringForStore(),
+		Run:                  run,
+		WorkflowSpecManifest: string(workflowSpecManifest),
+		SecretParameters:     secretParameters,
+		MetricsPushToken:     metricsPushToken,
+		CreatedAtInSec:       r.time.Now().Unix(),
+		// The first attempt is made right away.
+		Attempts: 1,
+	}
+	if err := r.runOutboxStore.CreateEntry(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// createOutboxWorkflow creates the workflow of an outbox entry on the given execution target.
+// After the first attempt, an existing workflow of the same name is the one created by a
+// previous attempt.
+func (r *ResourceManager) createOutboxWorkflow(entry *model.RunOutboxEntry, workflow *util.Workflow,
+	target string) (*workflowapi.Workflow, error) {
+	clients, err := r.targetClients(target)
+	if err != nil {
+		return nil, err
+	}
+	newWorkflow, err := clients.Engine.Create(workflow)
+	if err != nil && entry.Attempts > 1 && apierrors.IsAlreadyExists(err) {
+		newWorkflow, err = clients.Engine.Get(workflow.Name)
+	}
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create a workflow for (%s)", workflow.Name)
 	}
+	return newWorkflow.Get(), nil
+}
+
+// storeOutboxRun stores the run of an outbox entry whose workflow was created, then
+// deletes the entry. The steps completed by a previous attempt are skipped.
+func (r *ResourceManager) storeOutboxRun(entry *model.RunOutboxEntry, apiRun *api.Run,
+	newWorkflow *workflowapi.Workflow) (*model.RunDetail, error) {
+	runId := string(newWorkflow.UID)
+	if entry.MetricsPushToken != "" {
+		stored, err := r.metricsPushTokenStore.VerifyToken(runId, entry.MetricsPushToken)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to store the metrics push token of the run")
+		}
+		if !stored {
+			if err := r.metricsPushTokenStore.CreateToken(runId, common.Run, entry.MetricsPushToken); err != nil {
+				return nil, util.Wrap(err, "Failed to store the metrics push token of the run")
+			}
+		}
+	}
+
+	var newRun *model.RunDetail
+	if entry.Attempts > 1 {
+		run, err := r.runStore.GetRun(runId)
+		if err != nil && !util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			return nil, util.Wrap(err, "Failed to get the run of the outbox entry")
+		}
+		newRun = run
+	}
+	if newRun == nil {
+		r.eventRecorder.Eventf(newWorkflow, corev1.EventTypeNormal, util.EventReasonRunCreated,
+			"Run %q was created through the ML pipeline API", apiRun.GetName())
+
+		// Store run metadata into database
+		runDetail, err := ToModelRunDetail(apiRun, util.NewWorkflow(newWorkflow), entry.WorkflowSpecManifest)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to convert run model")
+		}
+		// The run is created at the time it was requested.
+		runDetail.CreatedAtInSec = entry.CreatedAtInSec
+		newRun, err = r.runStore.CreateRun(runDetail)
+		if err != nil {
+			return nil, err
+		}
+		r.eventPublisher.Publish(&eventexport.Event{
+			Type:         eventexport.EventTypeRunCreated,
+			TimeInSec:    newRun.CreatedAtInSec,
+			PipelineId:   newRun.PipelineId,
+			RunId:        newRun.UUID,
+			RunName:      newRun.DisplayName,
+			ExperimentId: util.NewWorkflow(newWorkflow).ExperimentIdOrEmpty(),
+			Namespace:    newRun.Namespace,
+			RequestId:    newWorkflow.Annotations[util.AnnotationKeyRequestId],
+		})
+	}
+	// The run is created: a leftover entry is deleted by the next reconciliation instead.
+	if err := r.runOutboxStore.DeleteEntry(entry.UUID); err != nil {
+		glog.Warningf("Failed to delete the outbox entry %v of run %v: %+v", entry.UUID, runId, err)
+	}
+	return newRun, nil
+}
 
-	// Store run metadata into database
-	runDetail, err := ToModelRunDetail(apiRun, util.NewWorkflow(newWorkflow), string(workflowSpecManifestBytes))
+// ReconcileRunOutbox completes the creation of the runs whose intent was stored before a
+// time, and which weren't created since, e.g. because the API server crashed in between.
+// An entry is dropped after maxAttempts attempts.
+func (r *ResourceManager) ReconcileRunOutbox(createdBeforeInSec int64, maxAttempts int) error {
+	entries, err := r.runOutboxStore.ListEntries(createdBeforeInSec, runOutboxBatchSize)
 	if err != nil {
-		return nil, util.Wrap(err, "Failed to convert run model")
+		return util.Wrap(err, "Failed to list the run outbox entries")
+	}
+	var failed []string
+	for _, entry := range entries {
+		if err := r.reconcileRunOutboxEntry(entry, maxAttempts); err != nil {
+			glog.Errorf("Failed to create the run of outbox entry %v: %+v", entry.UUID, err)
+			failed = append(failed, entry.UUID)
+		}
+	}
+	if len(failed) > 0 {
+		return util.NewInternalServerError(fmt.Errorf("failed entries: %v", failed),
+			"Failed to create the runs of %v outbox entries", len(failed))
 	}
+	return nil
+}
 
-	// Assign the create at time.
-	runDetail.CreatedAtInSec = r.time.Now().Unix()
-	return r.runStore.CreateRun(runDetail)
+func (r *ResourceManager) reconcileRunOutboxEntry(entry *model.RunOutboxEntry, maxAttempts int) error {
+	if entry.Attempts >= maxAttempts {
+		glog.Errorf("Dropping outbox entry %v after %v attempts to create its run", entry.UUID, entry.Attempts)
+		return r.runOutboxStore.DeleteEntry(entry.UUID)
+	}
+	var workflow util.Workflow
+	if err := json.Unmarshal([]byte(entry.Workflow), &workflow); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal the workflow of outbox entry %v", entry.UUID)
+	}
+	var apiRun api.Run
+	if err := jsonpb.UnmarshalString(entry.Run, &apiRun); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal the run of outbox entry %v", entry.UUID)
+	}
+	if entry.SecretParameters != "" {
+		secretValues, err := util.DecryptSecretParameterValues(entry.SecretParameters)
+		if err != nil {
+			return util.Wrapf(err, "Failed to restore the secret parameters of outbox entry %v", entry.UUID)
+		}
+		workflow.OverrideParameters(secretValues)
+		for _, param := range apiRun.GetPipelineSpec().GetParameters() {
+			if value, ok := secretValues[param.GetName()]; ok {
+				param.Value = value
+			}
+		}
+	}
+	// The stored workflow has its metrics push token masked.
+	if entry.MetricsPushToken != "" {
+		setMetricsPushEnv(&workflow, entry.MetricsPushToken)
+	}
+	// The attempt is recorded before it's made, so that an attempt interrupted after
+	// creating the workflow is known to the next one.
+	if err := r.runOutboxStore.IncrementAttempts(entry.UUID); err != nil {
+		return err
+	}
+	entry.Attempts++
+	newWorkflow, err := r.createOutboxWorkflow(entry, &workflow, apiRun.GetExecutionTarget())
+	if err != nil {
+		return err
+	}
+	_, err = r.storeOutboxRun(entry, &apiRun, newWorkflow)
+	return err
+}
+
+// ImportRun stores the metadata, the runtime workflow and the metrics of a run imported
+// from a backup, with a new ID. Its workflow isn't created.
+func (r *ResourceManager) ImportRun(apiRun *api.Run, workflowRuntimeManifest string) (*model.RunDetail, error) {
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate the run ID")
+	}
+	runId := uuid.String()
+	// The values of the secret parameters of the runs exported from the API are masked.
+	params, err := toModelParameters(apiRun.GetPipelineSpec().GetParameters(), nil)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to import the run")
+	}
+	resourceReferences, err := toModelResourceReferences(runId, common.Run, apiRun.GetResourceReferences())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to import the run")
+	}
+	run, err := r.runStore.CreateRun(&model.RunDetail{
+		Run: model.Run{
+			UUID:               runId,
+			DisplayName:        apiRun.GetName(),
+			Name:               apiRun.GetName(),
+			Description:        apiRun.GetDescription(),
+			CreatedAtInSec:     apiRun.GetCreatedAt().GetSeconds(),
+			ScheduledAtInSec:   apiRun.GetScheduledAt().GetSeconds(),
+			Conditions:         apiRun.GetStatus(),
+			ResourceReferences: resourceReferences,
+			PipelineSpec: model.PipelineSpec{
+				PipelineId:           apiRun.GetPipelineSpec().GetPipelineId(),
+				PipelineSpecManifest: apiRun.GetPipelineSpec().GetPipelineManifest(),
+				WorkflowSpecManifest: apiRun.GetPipelineSpec().GetWorkflowManifest(),
+				Parameters:           params,
+			},
+		},
+		WorkflowRuntimeManifest: workflowRuntimeManifest,
+	})
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to import the run")
+	}
+	for _, err := range r.ReportMetrics(apiRun.GetMetrics(), runId) {
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to import the metrics of the run")
+		}
+	}
+	return run, nil
 }
 
 func (r *ResourceManager) GetRun(runId string) (*model.RunDetail, error) {
-	return r.runStore.GetRun(runId)
+	run, err := r.runStore.GetRun(runId)
+	if err != nil || r.deploymentStatusStore == nil {
+		return run, err
+	}
+	if run.Deployments, err = r.deploymentStatusStore.ListRunDeployments(runId); err != nil {
+		return nil, util.Wrap(err, "Failed to get the deployments of the run")
+	}
+	return run, nil
+}
+
+// UpdateRunAnnotations sets the note if it's given and the annotations of a run, and returns the
+// updated run.
+func (r *ResourceManager) UpdateRunAnnotations(runId string, note *string, annotations map[string]string) (
+	*model.RunDetail, error) {
+	if err := r.runStore.UpdateRunAnnotations(runId, note, annotations); err != nil {
+		return nil, util.Wrap(err, "Failed to update the run")
+	}
+	return r.GetRun(runId)
+}
+
+// GetRunLineage returns the lineage recorded in ML Metadata for the steps of a run.
+func (r *ResourceManager) GetRunLineage(runId string) (*metadata.RunLineage, error) {
+	if r.metadataStore == nil {
+		return nil, util.NewInvalidInputError("The lineage of the runs is not recorded: no metadata store is configured.")
+	}
+	if _, err := r.runStore.GetRun(runId); err != nil {
+		return nil, util.Wrap(err, "Failed to get the lineage of the run")
+	}
+	return r.metadataStore.GetRunLineage(runId)
+}
+
+// GetArtifactLineage returns the lineage graph of the artifact stored at uri: upstream,
+// the steps that produced it and the artifacts they consumed, recursively; downstream,
+// the steps that consumed it and the artifacts they produced, recursively. depth limits
+// the number of steps walked in each direction.
+func (r *ResourceManager) GetArtifactLineage(uri string, depth int) (*model.ArtifactLineage, error) {
+	if depth <= 0 {
+		depth = defaultArtifactLineageDepth
+	}
+	if depth > maxArtifactLineageDepth {
+		depth = maxArtifactLineageDepth
+	}
+	upstream, err := r.walkArtifactLineage(uri, depth, model.ArtifactEventOutput, model.ArtifactEventInput)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the upstream lineage of the artifact")
+	}
+	downstream, err := r.walkArtifactLineage(uri, depth, model.ArtifactEventInput, model.ArtifactEventOutput)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the downstream lineage of the artifact")
+	}
+	if len(upstream) == 0 && len(downstream) == 0 {
+		return nil, util.NewResourceNotFoundError("Artifact", uri)
+	}
+	return &model.ArtifactLineage{Upstream: upstream, Downstream: downstream}, nil
+}
+
+// walkArtifactLineage walks the lineage graph breadth first from the artifact stored at
+// uri. It follows the stepEventType events of each artifact to their steps, then the
+// nextEventType events of these steps to the next artifacts.
+func (r *ResourceManager) walkArtifactLineage(uri string, depth int, stepEventType model.ArtifactEventType,
+	nextEventType model.ArtifactEventType) ([]*model.ArtifactEvent, error) {
+	var edges []*model.ArtifactEvent
+	visitedArtifacts := map[string]bool{uri: true}
+	visitedSteps := map[string]bool{}
+	uris := []string{uri}
+	for level := 0; level < depth && len(uris) > 0; level++ {
+		var nextUris []string
+		for _, artifactUri := range uris {
+			stepEvents, err := r.artifactLineageStore.ListArtifactEventsByURI(artifactUri, stepEventType)
+			if err != nil {
+				return nil, err
+			}
+			for _, stepEvent := range stepEvents {
+				edges = append(edges, stepEvent)
+				step := stepEvent.RunUUID + "/" + stepEvent.NodeID
+				if visitedSteps[step] {
+					continue
+				}
+				visitedSteps[step] = true
+				nextEvents, err := r.artifactLineageStore.ListArtifactEventsByStep(
+					stepEvent.RunUUID, stepEvent.NodeID, nextEventType)
+				if err != nil {
+					return nil, err
+				}
+				for _, nextEvent := range nextEvents {
+					edges = append(edges, nextEvent)
+					if !visitedArtifacts[nextEvent.ArtifactURI] {
+						visitedArtifacts[nextEvent.ArtifactURI] = true
+						nextUris = append(nextUris, nextEvent.ArtifactURI)
+					}
+				}
+			}
+		}
+		uris = nextUris
+	}
+	return edges, nil
 }
 
-func (r *ResourceManager) ListRuns(filterContext *common.FilterContext, paginationContext *common.PaginationContext) (runs []model.Run, nextPageToken string, err error) {
-	return r.runStore.ListRuns(filterContext, paginationContext)
+func (r *ResourceManager) GetModelVersion(modelVersionId string) (*model.ModelVersion, error) {
+	return r.modelRegistry.GetModelVersion(modelVersionId)
 }
 
-func (r *ResourceManager) ListJobs(filterContext *common.FilterContext, context *common.PaginationContext) (jobs []model.Job, nextPageToken string, err error) {
-	return r.jobStore.ListJobs(filterContext, context)
+func (r *ResourceManager) ListModelVersions(modelName string, context *common.PaginationContext) (
+	modelVersions []model.ModelVersion, nextPageToken string, err error) {
+	return r.modelRegistry.ListModelVersions(modelName, context)
+}
+
+func (r *ResourceManager) ListRuns(filterContext *common.FilterContext, paginationContext *common.PaginationContext,
+	view common.ListView) (runs []model.Run, nextPageToken string, err error) {
+	return r.runStore.ListRuns(filterContext, paginationContext, view)
+}
+
+func (r *ResourceManager) ListJobs(filterContext *common.FilterContext, context *common.PaginationContext,
+	view common.ListView) (jobs []model.Job, nextPageToken string, err error) {
+	return r.jobStore.ListJobs(filterContext, context, view)
 }
 
 func (r *ResourceManager) GetJob(id string) (*model.Job, error) {
 	return r.jobStore.GetJob(id)
 }
 
-func (r *ResourceManager) CreateJob(apiJob *api.Job) (*model.Job, error) {
+// CreateJob creates the scheduled workflow of a job and stores the job. The scheduled workflow
+// is annotated with the ID of the call creating the job, if any.
+func (r *ResourceManager) CreateJob(apiJob *api.Job, requestId string) (*model.Job, error) {
+	// The scheduled workflow controller creates Argo workflows.
+	if r.engine.Name() != engine.Argo {
+		return nil, util.NewInvalidInputError("Jobs aren't supported by the %v workflow engine", r.engine.Name())
+	}
+	if err := r.checkServiceAccount(apiJob.GetServiceAccount()); err != nil {
+		return nil, err
+	}
 	// Get workflow from pipeline spec, which might be pipeline ID or an argo workflow
 	workflowSpecManifestBytes, err := r.getWorkflowSpecBytes(apiJob.GetPipelineSpec())
 	if err != nil {
@@ -242,8 +1298,18 @@ func (r *ResourceManager) CreateJob(apiJob *api.Job) (*model.Job, error) {
 			"Failed to unmarshal workflow spec manifest. Workflow bytes: %s", string(workflowSpecManifestBytes))
 	}
 
-	// Verify no additional parameter provided
-	err = workflow.VerifyParameters(toParametersMap(apiJob.PipelineSpec.Parameters))
+	// The time macros are left to the scheduled workflow controller, for each run.
+	if hasParameterMacros(apiJob.GetPipelineSpec().GetParameters()) {
+		context, err := r.newParameterContext(apiJob.GetName(), apiJob.GetPipelineSpec(), apiJob.GetResourceReferences())
+		if err != nil {
+			return nil, err
+		}
+		if err := evaluateParameters(apiJob.GetPipelineSpec().GetParameters(), &workflow, context); err != nil {
+			return nil, util.Wrap(err, "Create job failed")
+		}
+	}
+	// Verify the parameters provided against the ones of the workflow
+	err = workflow.ValidateParameters(toParametersMap(apiJob.PipelineSpec.Parameters))
 	if err != nil {
 		return nil, util.Wrap(err, "Create job failed")
 	}
@@ -251,9 +1317,56 @@ func (r *ResourceManager) CreateJob(apiJob *api.Job) (*model.Job, error) {
 	if err != nil {
 		return nil, util.Wrap(err, "Create job failed")
 	}
+	// The workflows of the job are labeled by the scheduled workflow controller.
+	labels := toWorkflowLabels(apiJob.GetPipelineSpec(), apiJob.GetResourceReferences())
+	options, err := pipelineSpecWorkflowOptions(apiJob.GetPipelineSpec())
+	if err != nil {
+		return nil, err
+	}
+	namespaceConfig, err := r.getNamespaceConfig()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the configuration of the namespace of the job")
+	}
+	// The workflows of the job are created by the scheduled workflow controller in the vendored
+	// schema of Argo, which has no priority of the pods. The defaults of the server are left out.
+	if options.GetPriorityClassName() != "" ||
+		options.GetPreemptionPolicy() != api.WorkflowOptions_PREEMPTION_POLICY_UNSPECIFIED {
+		return nil, util.NewInvalidInputError("The priority of the pods isn't supported by the jobs")
+	}
+	workflowOptions := mergeWorkflowOptions(options, namespaceConfig.workflowDefaults(r.workflowDefaults))
+	workflowOptions.PriorityClassName = ""
+	workflowOptions.PreemptionPolicy = api.WorkflowOptions_PREEMPTION_POLICY_UNSPECIFIED
+	workflowLabels, err := applyWorkflowOptions(&workflow, workflowOptions)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range workflowLabels {
+		labels[key] = value
+	}
+	namespaceConfig.apply(&workflow)
+	if serviceAccount := apiJob.GetServiceAccount(); serviceAccount != "" {
+		workflow.SetServiceAccount(serviceAccount)
+	}
+	// All the runs of the job share its token.
+	metricsPushToken, err := r.issueMetricsPushToken(&workflow)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to issue the metrics push token of the job")
+	}
 
+	annotations := make(map[string]string)
+	if requestId != "" {
+		annotations[util.AnnotationKeyRequestId] = requestId
+	}
+	// Copied to the workflows of the job, so that their secret parameters are masked.
+	if declarations, ok := workflow.Annotations[util.AnnotationKeyWorkflowParameters]; ok {
+		annotations[util.AnnotationKeyWorkflowParameters] = declarations
+	}
 	scheduledWorkflow := &scheduledworkflow.ScheduledWorkflow{
-		ObjectMeta: v1.ObjectMeta{GenerateName: swfGeneratedName},
+		ObjectMeta: v1.ObjectMeta{
+			GenerateName: swfGeneratedName,
+			Labels:       labels,
+			Annotations:  annotations,
+		},
 		Spec: scheduledworkflow.ScheduledWorkflowSpec{
 			Enabled:        apiJob.Enabled,
 			MaxConcurrency: &apiJob.MaxConcurrency,
@@ -268,6 +1381,13 @@ func (r *ResourceManager) CreateJob(apiJob *api.Job) (*model.Job, error) {
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create a scheduled workflow for (%s)", scheduledWorkflow.Name)
 	}
+	r.eventRecorder.Eventf(newScheduledWorkflow, corev1.EventTypeNormal, util.EventReasonJobCreated,
+		"Job %q was created through the ML pipeline API", apiJob.GetName())
+	if metricsPushToken != "" {
+		if err := r.metricsPushTokenStore.CreateToken(string(newScheduledWorkflow.UID), common.Job, metricsPushToken); err != nil {
+			return nil, util.Wrap(err, "Failed to store the metrics push token of the job")
+		}
+	}
 	job, err := ToModelJob(apiJob, util.NewScheduledWorkflow(newScheduledWorkflow), string(workflowSpecManifestBytes))
 	if err != nil {
 		return nil, util.Wrap(err, "Create job failed")
@@ -284,7 +1404,7 @@ func (r *ResourceManager) EnableJob(jobID string, enabled bool) error {
 	if err != nil {
 		return util.Wrap(err, "Enable/Disable job failed")
 	}
-	_, err = r.scheduledWorkflowClient.Patch(
+	patchedScheduledWorkflow, err := r.scheduledWorkflowClient.Patch(
 		job.Name,
 		types.MergePatchType,
 		[]byte(fmt.Sprintf(`{"spec":{"enabled":%s}}`, strconv.FormatBool(enabled))))
@@ -293,6 +1413,14 @@ func (r *ResourceManager) EnableJob(jobID string, enabled bool) error {
 			"Failed to enable/disable job CRD. Enabled: %v, jobID: %v",
 			enabled, jobID)
 	}
+	if patchedScheduledWorkflow != nil {
+		reason, action := util.EventReasonJobDisabled, "disabled"
+		if enabled {
+			reason, action = util.EventReasonJobEnabled, "enabled"
+		}
+		r.eventRecorder.Eventf(patchedScheduledWorkflow, corev1.EventTypeNormal, reason,
+			"Job was %s through the ML pipeline API", action)
+	}
 
 	err = r.jobStore.EnableJob(jobID, enabled)
 	if err != nil {
@@ -316,16 +1444,71 @@ func (r *ResourceManager) DeleteJob(jobID string) error {
 	if err != nil {
 		return util.Wrap(err, "Delete job failed")
 	}
+	if r.metricsPushTokenStore != nil {
+		if err := r.metricsPushTokenStore.DeleteToken(jobID); err != nil {
+			return util.Wrap(err, "Delete job failed")
+		}
+	}
 	return nil
 }
 
+// WatchRun returns a channel receiving a value when the state of the run changes, and a
+// function to stop watching.
+func (r *ResourceManager) WatchRun(runId string) (<-chan struct{}, func()) {
+	return r.runWatcher.Watch(runId)
+}
+
 func (r *ResourceManager) ReportWorkflowResource(workflow *util.Workflow) error {
+	// The duplicate and out-of-order reports are ignored.
+	apply, err := r.reportDeduplicator.ShouldApply(workflow)
+	if err != nil || !apply {
+		return err
+	}
+	if err := r.reportWorkflowResource(workflow); err != nil {
+		return err
+	}
+	return r.reportDeduplicator.Record(workflow)
+}
+
+func (r *ResourceManager) reportWorkflowResource(workflow *util.Workflow) error {
 	runId := string(workflow.UID)
+	// The persistence agent reports the same workflow again on every resync. Only the
+	// state transitions are notified.
+	previousCondition := ""
+	previousManifest := ""
+	isNewRun := false
+	if run, err := r.runStore.GetRun(runId); err == nil {
+		previousCondition = run.Conditions
+		previousManifest = run.WorkflowRuntimeManifest
+	} else {
+		isNewRun = util.IsUserErrorCodeMatch(err, codes.NotFound)
+	}
+	// Index the artifacts and record the history before the run is updated, so that a
+	// failure is retried on the next report.
+	if err := r.artifactLineageStore.ReplaceArtifactEvents(runId, ToModelArtifactEvents(workflow)); err != nil {
+		return util.Wrap(err, "Failed to index the artifacts of the run")
+	}
+	if err := r.recordRunHistory(workflow); err != nil {
+		return util.Wrap(err, "Failed to record the history of the run")
+	}
+	if condition := workflow.Condition(); condition == string(workflowapi.NodeSucceeded) && condition != previousCondition {
+		if err := r.registerModels(workflow); err != nil {
+			return util.Wrap(err, "Failed to register the models of the run")
+		}
+		if err := r.trackDeployments(workflow); err != nil {
+			return util.Wrap(err, "Failed to track the deployments of the run")
+		}
+	}
 	jobId := workflow.ScheduledWorkflowUUIDAsStringOrEmpty()
 	if jobId == "" {
 		// If a run doesn't have owner UID, it's a one-time run created by Pipeline API server.
 		// In this case the DB entry should already been created when argo workflow CRD is created.
-		return r.runStore.UpdateRun(runId, workflow.Condition(), workflow.ToStringForStore())
+		err := r.runStore.UpdateRun(runId, workflow.Condition(), workflow.ToStringForStore())
+		if err == nil {
+			r.notifyRunStateChange(workflow, workflow.ExperimentIdOrEmpty(), previousCondition)
+			r.notifyApprovalGates(workflow, workflow.ExperimentIdOrEmpty(), previousManifest)
+		}
+		return err
 	}
 
 	// Get the experiment resource reference for job.
@@ -366,7 +1549,144 @@ func (r *ResourceManager) ReportWorkflowResource(workflow *util.Workflow) error
 			WorkflowRuntimeManifest: workflow.ToStringForStore(),
 		},
 	}
-	return r.runStore.CreateOrUpdateRun(runDetail)
+	err = r.runStore.CreateOrUpdateRun(runDetail)
+	if err != nil {
+		return err
+	}
+	if isNewRun {
+		r.eventPublisher.Publish(&eventexport.Event{
+			Type:         eventexport.EventTypeJobTriggered,
+			TimeInSec:    runDetail.CreatedAtInSec,
+			PipelineId:   workflow.PipelineIdOrEmpty(),
+			RunId:        runId,
+			RunName:      workflow.Name,
+			JobId:        jobId,
+			ExperimentId: experimentRef.ReferenceUUID,
+			Namespace:    workflow.Namespace,
+		})
+	}
+	r.notifyRunStateChange(workflow, experimentRef.ReferenceUUID, previousCondition)
+	r.notifyApprovalGates(workflow, experimentRef.ReferenceUUID, previousManifest)
+	return nil
+}
+
+// registerModels registers the output artifacts declared as models by the workflow in
+// the model registry.
+func (r *ResourceManager) registerModels(workflow *util.Workflow) error {
+	declarations, err := workflow.ModelDeclarations()
+	if err != nil {
+		// Retrying wouldn't fix the annotation.
+		glog.Warningf("Failed to register the models of run %v: %v", workflow.UID, err)
+		return nil
+	}
+	for _, declaration := range declarations {
+		registered := false
+		for _, node := range workflow.Status.Nodes {
+			if node.Type != workflowapi.NodeTypePod || node.TemplateName != declaration.Template || node.Outputs == nil {
+				continue
+			}
+			for _, artifact := range node.Outputs.Artifacts {
+				uri := util.ArtifactURI(artifact)
+				if artifact.Name != declaration.Artifact || uri == "" {
+					continue
+				}
+				_, err := r.modelRegistry.RegisterModelVersion(&model.ModelVersion{
+					ModelName:    declaration.Name,
+					ArtifactURI:  uri,
+					ArtifactName: artifact.Name,
+					RunUUID:      string(workflow.UID),
+					NodeID:       node.ID,
+				})
+				if err != nil {
+					return util.Wrap(err, fmt.Sprintf("Failed to register model %v", declaration.Name))
+				}
+				registered = true
+			}
+		}
+		if !registered {
+			glog.Warningf("Run %v has no artifact %v of template %v to register as model %v", workflow.UID,
+				declaration.Artifact, declaration.Template, declaration.Name)
+		}
+	}
+	return nil
+}
+
+// trackDeployments records the serving resources deployed by the workflow, whose
+// readiness is then tracked by the deployment watcher.
+func (r *ResourceManager) trackDeployments(workflow *util.Workflow) error {
+	if r.deploymentStatusStore == nil {
+		return nil
+	}
+	now := r.time.Now().Unix()
+	var deployments []*model.RunDeployment
+	for _, reference := range workflow.Deployments() {
+		deployments = append(deployments, &model.RunDeployment{
+			RunUUID:        string(workflow.UID),
+			Kind:           reference.Kind,
+			Namespace:      reference.Namespace,
+			Name:           reference.Name,
+			APIVersion:     reference.APIVersion,
+			State:          model.DeploymentPending,
+			CreatedAtInSec: now,
+			UpdatedAtInSec: now,
+		})
+	}
+	return r.deploymentStatusStore.CreateDeployments(deployments)
+}
+
+// notifyRunStateChange exports the start and the end of the run of the workflow, and
+// notifies its end to the webhooks.
+func (r *ResourceManager) notifyRunStateChange(workflow *util.Workflow, experimentId string, previousCondition string) {
+	condition := workflow.Condition()
+	if condition == previousCondition {
+		return
+	}
+	r.runWatcher.Notify(string(workflow.UID))
+	event := &eventexport.Event{
+		PipelineId:   workflow.PipelineIdOrEmpty(),
+		RunId:        string(workflow.UID),
+		RunName:      workflow.Name,
+		JobId:        workflow.ScheduledWorkflowUUIDAsStringOrEmpty(),
+		ExperimentId: experimentId,
+		Namespace:    workflow.Namespace,
+		State:        condition,
+	}
+	switch {
+	case workflow.IsInFinalState():
+		event.Type = eventexport.EventTypeRunFinished
+		event.TimeInSec = workflow.Status.FinishedAt.Unix()
+		r.eventPublisher.Publish(event)
+		r.notifyWebhooks(workflow, experimentId)
+	case condition == string(workflowapi.NodeRunning):
+		event.Type = eventexport.EventTypeRunStarted
+		event.TimeInSec = workflow.Status.StartedAt.Unix()
+		r.eventPublisher.Publish(event)
+	}
+}
+
+// notifyWebhooks notifies the webhooks watching the run of the workflow that it reached
+// its final state. Failing to do so doesn't fail the report of the workflow.
+func (r *ResourceManager) notifyWebhooks(workflow *util.Workflow, experimentId string) {
+	webhooks, err := r.webhookStore.ListWebhooksForRun(experimentId, workflow.Namespace)
+	if err != nil {
+		glog.Errorf("Failed to list the webhooks of run %v: %v", workflow.UID, err)
+		return
+	}
+	state := workflow.Condition()
+	for i := range webhooks {
+		if !webhooks[i].Matches(experimentId, workflow.Namespace, state) {
+			continue
+		}
+		r.webhookNotifier.Notify(&webhooks[i], &webhook.RunEvent{
+			Event:           webhook.EventTypeForState(state),
+			RunId:           string(workflow.UID),
+			RunName:         workflow.Name,
+			ExperimentId:    experimentId,
+			Namespace:       workflow.Namespace,
+			State:           state,
+			FinishedAtInSec: workflow.Status.FinishedAt.Unix(),
+		})
+	}
 }
 
 func (r *ResourceManager) ReportScheduledWorkflowResource(swf *util.ScheduledWorkflow) error {
@@ -393,13 +1713,15 @@ func (r *ResourceManager) checkJobExist(jobID string) (*model.Job, error) {
 
 func (r *ResourceManager) getWorkflowSpecBytes(spec *api.PipelineSpec) ([]byte, error) {
 	if spec.GetPipelineId() != "" {
-		var workflow util.Workflow
-		err := r.objectStore.GetFromYamlFile(&workflow, storage.CreatePipelinePath(spec.GetPipelineId()))
+		pipeline, err := r.pipelineStore.GetPipeline(spec.GetPipelineId())
 		if err != nil {
-			return nil, util.Wrap(err, "Get pipeline YAML failed.")
+			return nil, util.Wrap(err, "Get pipeline workflow failed.")
 		}
-
-		return []byte(workflow.ToStringForStore()), nil
+		manifest, err := r.getWorkflowManifest(pipeline)
+		if err != nil {
+			return nil, util.Wrap(err, "Get pipeline workflow failed.")
+		}
+		return manifest, nil
 	} else if spec.GetWorkflowManifest() != "" {
 		return []byte(spec.GetWorkflowManifest()), nil
 	}
@@ -410,6 +1732,71 @@ func (r *ResourceManager) ReportMetric(metric *api.RunMetric, runUUID string) er
 	return r.runStore.ReportMetric(ToModelRunMetric(metric, runUUID))
 }
 
+// ReportMetrics stores the metrics of a run at once and returns the error of each of them.
+func (r *ResourceManager) ReportMetrics(metrics []*api.RunMetric, runUUID string) []error {
+	modelMetrics := make([]*model.RunMetric, 0, len(metrics))
+	for _, metric := range metrics {
+		modelMetrics = append(modelMetrics, ToModelRunMetric(metric, runUUID))
+	}
+	return r.runStore.ReportMetrics(modelMetrics)
+}
+
+// VerifyMetricsPushToken verifies the token a step of a run pushes its metrics with. The
+// runs of a job are authenticated by the token of the job.
+func (r *ResourceManager) VerifyMetricsPushToken(runId string, token string) error {
+	if r.metricsPushTokenStore == nil {
+		return util.NewInvalidInputError("The steps of the runs can't push their metrics: metrics push is disabled.")
+	}
+	if _, err := r.runStore.GetRun(runId); err != nil {
+		return util.Wrap(err, "Failed to verify the metrics push token")
+	}
+	valid, err := r.metricsPushTokenStore.VerifyToken(runId, token)
+	if err != nil {
+		return util.Wrap(err, "Failed to verify the metrics push token")
+	}
+	if !valid {
+		jobRef, err := r.resourceReferenceStore.GetResourceReference(runId, common.Run, common.Job)
+		if err != nil && !util.IsUserErrorCodeMatch(err, codes.NotFound) {
+			return util.Wrap(err, "Failed to verify the metrics push token")
+		}
+		if err == nil {
+			if valid, err = r.metricsPushTokenStore.VerifyToken(jobRef.ReferenceUUID, token); err != nil {
+				return util.Wrap(err, "Failed to verify the metrics push token")
+			}
+		}
+	}
+	if !valid {
+		return util.NewUnauthenticatedError("Invalid metrics push token for run %v", runId)
+	}
+	return nil
+}
+
+// issueMetricsPushToken issues a metrics push token and sets it in the environment of the
+// containers of a workflow. It returns an empty token if the steps can't push their metrics.
+func (r *ResourceManager) issueMetricsPushToken(workflow *util.Workflow) (string, error) {
+	if r.metricsPushTokenStore == nil {
+		return "", nil
+	}
+	token := make([]byte, metricsPushTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", util.NewInternalServerError(err, "Failed to generate a metrics push token")
+	}
+	encodedToken := hex.EncodeToString(token)
+	setMetricsPushEnv(workflow, encodedToken)
+	return encodedToken, nil
+}
+
+// setMetricsPushEnv sets a metrics push token, with the variables identifying the step, in
+// the environment of the containers of a workflow. The token is masked in the stored
+// workflows.
+func setMetricsPushEnv(workflow *util.Workflow, token string) {
+	workflow.SetContainerEnv([]corev1.EnvVar{
+		{Name: util.EnvKeyRunId, Value: "{{workflow.uid}}"},
+		{Name: util.EnvKeyNodeId, Value: "{{pod.name}}"},
+		{Name: util.EnvKeyMetricsPushToken, Value: token},
+	})
+}
+
 // ReadArtifact parses run's workflow to find artifact file path and reads the content of the file
 // from object store.
 func (r *ResourceManager) ReadArtifact(runID string, nodeID string, artifactName string) ([]byte, error) {
@@ -432,3 +1819,186 @@ func (r *ResourceManager) ReadArtifact(runID string, nodeID string, artifactName
 	}
 	return r.objectStore.GetFile(artifactPath)
 }
+
+// GetRunOutputs returns the markdown and the web apps of the UI metadata of the steps of a
+// run, in the order the steps started. The outputs which aren't inline are read from the
+// bucket of the UI metadata, and their content is cut at the size limit of the server. The
+// outputs which can't be read are returned with the error.
+func (r *ResourceManager) GetRunOutputs(runID string) ([]*RunOutput, error) {
+	run, err := r.runStore.GetRun(runID)
+	if err != nil {
+		return nil, err
+	}
+	var workflow workflowapi.Workflow
+	if err := json.Unmarshal([]byte(run.WorkflowRuntimeManifest), &workflow); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to unmarshal the workflow of run %v", runID)
+	}
+	nodeIDs := make([]string, 0, len(workflow.Status.Nodes))
+	for nodeID := range workflow.Status.Nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		started, otherStarted := workflow.Status.Nodes[nodeIDs[i]].StartedAt, workflow.Status.Nodes[nodeIDs[j]].StartedAt
+		if !started.Equal(&otherStarted) {
+			return started.Before(&otherStarted)
+		}
+		return nodeIDs[i] < nodeIDs[j]
+	})
+	outputs := make([]*RunOutput, 0)
+	for _, nodeID := range nodeIDs {
+		node := workflow.Status.Nodes[nodeID]
+		if node.Outputs == nil {
+			continue
+		}
+		for _, artifact := range node.Outputs.Artifacts {
+			if artifact.Name != util.ArtifactNameUIMetadata || artifact.S3 == nil || artifact.S3.Key == "" {
+				continue
+			}
+			nodeOutputs, err := r.readUIMetadataOutputs(nodeID, artifact.S3)
+			if err != nil {
+				glog.Warningf("Failed to read the UI metadata of node %v of run %v: %v", nodeID, runID, err)
+				continue
+			}
+			outputs = append(outputs, nodeOutputs...)
+		}
+	}
+	return outputs, nil
+}
+
+// readUIMetadataOutputs reads the outputs of the UI metadata of a step, and their content.
+func (r *ResourceManager) readUIMetadataOutputs(nodeID string, uiMetadata *workflowapi.S3Artifact) (
+	[]*RunOutput, error) {
+	archive, err := r.objectStore.GetFile(uiMetadata.Key)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := parseUIMetadataOutputs(nodeID, archive)
+	if err != nil {
+		return nil, err
+	}
+	for _, output := range outputs {
+		if output.Source != "" {
+			key, ok := objectStoreKey(output.Source, uiMetadata.Bucket)
+			if !ok {
+				output.Error = fmt.Sprintf("Only the outputs stored in bucket %v of the object store can be read",
+					uiMetadata.Bucket)
+				continue
+			}
+			content, err := r.objectStore.GetFile(key)
+			if err != nil {
+				glog.Warningf("Failed to read the output %v of node %v: %v", output.Source, nodeID, err)
+				output.Error = fmt.Sprintf("Failed to read %v", output.Source)
+				continue
+			}
+			output.Content = string(content)
+		}
+		output.truncate(r.maxRunOutputSize)
+	}
+	return outputs, nil
+}
+
+// ReadRunLogs returns the logs of the main container of a step of a run, and whether they
+// were served from the archive. The logs of the steps whose pods are gone are only
+// available once archived, e.g. by the persistence agent. The logs read from the pods of
+// the completed steps are archived along the way, so they survive the pods.
+func (r *ResourceManager) ReadRunLogs(runID string, nodeID string) ([]byte, bool, error) {
+	run, node, err := r.getRunPodNode(runID, nodeID)
+	if err != nil {
+		return nil, false, err
+	}
+	logPath := storage.CreateRunLogPath(runID, nodeID)
+	files, err := r.objectStore.ListFiles(logPath)
+	if err != nil {
+		return nil, false, util.Wrap(err, "Failed to look up the archived logs")
+	}
+	for _, file := range files {
+		if file.Path == logPath {
+			logs, err := r.objectStore.GetFile(logPath)
+			if err != nil {
+				return nil, false, util.Wrap(err, "Failed to read the archived logs")
+			}
+			return logs, true, nil
+		}
+	}
+	clients, err := r.targetClients(run.ExecutionTarget)
+	if err != nil {
+		return nil, false, err
+	}
+	logs, err := clients.PodClient.ReadPodLogs(nodeID, workflowcommon.MainContainerName)
+	if err != nil {
+		return nil, false, util.Wrapf(err, "Failed to read the logs of node %v of run %v", nodeID, runID)
+	}
+	if node.Completed() {
+		if err := r.objectStore.AddFile(logs, logPath); err != nil {
+			glog.Warningf("Failed to archive the logs of node %v of run %v: %v", nodeID, runID, err)
+		}
+	}
+	return logs, false, nil
+}
+
+// ReportRunLogs archives the logs of the main container of a step of a run.
+func (r *ResourceManager) ReportRunLogs(runID string, nodeID string, logs []byte) error {
+	if _, _, err := r.getRunPodNode(runID, nodeID); err != nil {
+		return err
+	}
+	err := r.objectStore.AddFile(logs, storage.CreateRunLogPath(runID, nodeID))
+	if err != nil {
+		return util.Wrapf(err, "Failed to archive the logs of node %v of run %v", nodeID, runID)
+	}
+	return nil
+}
+
+// getRunPodNode returns a run with the status of one of its steps running in a pod.
+func (r *ResourceManager) getRunPodNode(runID string, nodeID string) (*model.RunDetail, *workflowapi.NodeStatus, error) {
+	run, err := r.runStore.GetRun(runID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var storageWorkflow workflowapi.Workflow
+	err = json.Unmarshal([]byte(run.WorkflowRuntimeManifest), &storageWorkflow)
+	if err != nil {
+		// This should never happen.
+		return nil, nil, util.NewInternalServerError(
+			err, "failed to unmarshal workflow '%s'", run.WorkflowRuntimeManifest)
+	}
+	node, ok := storageWorkflow.Status.Nodes[nodeID]
+	if !ok || node.Type != workflowapi.NodeTypePod {
+		return nil, nil, util.NewResourceNotFoundError("node", nodeID)
+	}
+	return run, &node, nil
+}
+
+// CreateVisualization generates on the visualization server the visualization of an artifact of
+// the bucket of the pipelines. The visualizations which can't be generated from the artifact and
+// the arguments are returned with the error.
+func (r *ResourceManager) CreateVisualization(v *api.Visualization) (*api.Visualization, error) {
+	if r.visualizationClient == nil {
+		return nil, util.NewInvalidInputError(
+			"The visualizations can't be generated: no visualization server is configured.")
+	}
+	key, ok := objectStoreKey(v.GetSource(), r.objectStoreBucket)
+	if !ok {
+		return nil, util.NewInvalidInputError(
+			"Only the artifacts in bucket %v of the object store can be visualized, got %q.",
+			r.objectStoreBucket, v.GetSource())
+	}
+	content, err := r.objectStore.GetFile(key)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to read the artifact %v", v.GetSource())
+	}
+	response, err := r.visualizationClient.Generate(context.Background(), &visualization.GenerateRequest{
+		Type:      v.GetType(),
+		Arguments: v.GetArguments(),
+		Content:   content,
+	})
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to generate the visualization of %v", v.GetSource())
+	}
+	return &api.Visualization{
+		Type:      v.GetType(),
+		Source:    v.GetSource(),
+		Arguments: v.GetArguments(),
+		Html:      response.Html,
+		Error:     response.Error,
+	}, nil
+}
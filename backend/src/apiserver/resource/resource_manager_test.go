@@ -16,6 +16,7 @@ package resource
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -23,15 +24,22 @@ import (
 	"encoding/json"
 
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/eventexport"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/policy"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -58,6 +66,14 @@ func (m *FakeBadObjectStore) GetFromYamlFile(o interface{}, filePath string) err
 	return util.NewInternalServerError(errors.New("Error"), "bad object store")
 }
 
+func (m *FakeBadObjectStore) Ping() error {
+	return util.NewInternalServerError(errors.New("Error"), "bad object store")
+}
+
+func (m *FakeBadObjectStore) ListFiles(prefix string) ([]storage.StoredFile, error) {
+	return nil, util.NewInternalServerError(errors.New("Error"), "bad object store")
+}
+
 var testWorkflow = util.NewWorkflow(&v1alpha1.Workflow{
 	TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
 	ObjectMeta: v1.ObjectMeta{Name: "workflow-name", UID: "workflow1"},
@@ -96,7 +112,7 @@ func initWithJob(t *testing.T) (*FakeClientManager, *ResourceManager, *model.Job
 			},
 		},
 	}
-	j, err := manager.CreateJob(job)
+	j, err := manager.CreateJob(job, "")
 	assert.Nil(t, err)
 	return store, manager, j
 }
@@ -118,7 +134,7 @@ func initWithOneTimeRun(t *testing.T) (*FakeClientManager, *ResourceManager, *mo
 			},
 		},
 	}
-	runDetail, err := manager.CreateRun(apiRun)
+	runDetail, err := manager.CreateRun(apiRun, "")
 	assert.Nil(t, err)
 	return store, manager, runDetail
 }
@@ -131,13 +147,48 @@ func TestCreatePipeline(t *testing.T) {
 	store, _, pipeline := initWithPipeline(t)
 	defer store.Close()
 	pipelineExpected := &model.Pipeline{
-		UUID:           DefaultFakeUUID,
-		CreatedAtInSec: 1,
-		Name:           "p1",
-		Parameters:     "[{\"name\":\"param1\"}]",
-		Status:         model.PipelineReady,
+		UUID:             DefaultFakeUUID,
+		CreatedAtInSec:   1,
+		Name:             "p1",
+		Parameters:       "[{\"name\":\"param1\"}]",
+		Status:           model.PipelineReady,
+		TemplateChecksum: templateChecksum([]byte(testWorkflow.ToStringForStore())),
 	}
 	assert.Equal(t, pipelineExpected, pipeline)
+	assert.Equal(t, []eventexport.Event{{
+		Type:         eventexport.EventTypePipelineCreated,
+		TimeInSec:    1,
+		PipelineId:   DefaultFakeUUID,
+		PipelineName: "p1",
+	}}, store.eventPublisherFake.Events())
+}
+
+func TestCreatePipeline_PolicyViolation(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	linter, err := policy.NewLinter(policy.Config{AllowedRegistries: []string{"gcr.io/my-project"},
+		AllowedRegistriesMode: policy.ModeEnforce, ResourceLimitsMode: policy.ModeWarn})
+	assert.Nil(t, err)
+	manager.policyLinter = linter
+	workflow := testWorkflow.DeepCopy()
+	workflow.Spec.Templates = []v1alpha1.Template{{Name: "main", Container: &corev1.Container{Image: "python:3.7"}}}
+	pipelineFile := []byte(util.NewWorkflow(workflow).ToStringForStore())
+
+	violations, err := manager.ValidatePipeline("", pipelineFile)
+	assert.Nil(t, err)
+	assert.Len(t, violations, 2)
+	_, err = manager.CreatePipeline("p1", "", pipelineFile)
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "allowed_registries: template main: image python:3.7 isn't pulled from an allowed registry")
+	_, err = store.PipelineStore().GetPipelineByName("p1")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	// Only warned of.
+	workflow.Spec.Templates[0].Container.Image = "gcr.io/my-project/train"
+	_, err = manager.CreatePipeline("p1", "", []byte(util.NewWorkflow(workflow).ToStringForStore()))
+	assert.Nil(t, err)
 }
 
 func TestCreatePipeline_ComplexPipeline(t *testing.T) {
@@ -152,6 +203,34 @@ func TestCreatePipeline_ComplexPipeline(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestCreatePipeline_ParameterSchema(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.Annotations = map[string]string{util.AnnotationKeyWorkflowParameters: `[{"name": "param1", "type": "secret"}]`}
+
+	pipeline, err := manager.CreatePipeline("pipeline1", "", []byte(workflow.ToStringForStore()))
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"param1","type":"secret","required":true}]`, pipeline.ParameterSchema)
+	pipeline, err = manager.GetPipeline(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"param1","type":"secret","required":true}]`, pipeline.ParameterSchema)
+}
+
+func TestCreatePipeline_InvalidParameterSchema(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.Annotations = map[string]string{util.AnnotationKeyWorkflowParameters: `[{"name": "param1", "type": "enum"}]`}
+
+	_, err := manager.CreatePipeline("pipeline1", "", []byte(workflow.ToStringForStore()))
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "parameter param1 of type enum has no allowed value")
+}
+
 func TestCreatePipeline_GetParametersError(t *testing.T) {
 	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	defer store.Close()
@@ -186,6 +265,93 @@ func TestCreatePipeline_CreatePipelineFileError(t *testing.T) {
 	assert.NotNil(t, pipeline)
 }
 
+func TestUpsertPipeline(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	template := []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow")
+
+	// The pipeline is created, then kept as is.
+	created, changed, err := manager.UpsertPipeline("pipeline1", "description", template)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	pipeline, changed, err := manager.UpsertPipeline("pipeline1", "description", template)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, created, pipeline)
+
+	// Its file is replaced once changed.
+	newTemplate := []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow\n" +
+		"spec:\n  arguments:\n    parameters:\n    - name: param1\n")
+	pipeline, changed, err = manager.UpsertPipeline("pipeline1", "new description", newTemplate)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, created.UUID, pipeline.UUID)
+	pipeline, err = manager.GetPipeline(created.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "new description", pipeline.Description)
+	assert.Equal(t, `[{"name":"param1"}]`, pipeline.Parameters)
+	storedTemplate, err := manager.GetPipelineTemplate(created.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, newTemplate, storedTemplate)
+}
+
+func TestUpsertPipeline_OtherReplicaReloadsTemplate(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	// Another replica shares the database and the object store, but not the template cache.
+	replica := NewResourceManager(store)
+	replica.templateCache = NewTemplateCache(fakeTemplateCacheSize, 0, util.NewFakeTimeForEpoch())
+	template := []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow")
+	created, _, err := manager.UpsertPipeline("pipeline1", "description", template)
+	assert.Nil(t, err)
+	_, err = replica.GetPipelineTemplate(created.UUID)
+	assert.Nil(t, err)
+	_, err = replica.getWorkflowManifest(created)
+	assert.Nil(t, err)
+
+	newTemplate := []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow\n" +
+		"spec:\n  arguments:\n    parameters:\n    - name: param1\n")
+	_, _, err = manager.UpsertPipeline("pipeline1", "description", newTemplate)
+	assert.Nil(t, err)
+	storedTemplate, err := replica.GetPipelineTemplate(created.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, newTemplate, storedTemplate)
+	workflowBytes, err := replica.getWorkflowSpecBytes(&api.PipelineSpec{PipelineId: created.UUID})
+	assert.Nil(t, err)
+	assert.Contains(t, string(workflowBytes), "param1")
+}
+
+func TestUpsertPipeline_CompletesCreatingPipeline(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	template := []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow")
+	created, err := manager.CreatePipeline("pipeline1", "", template)
+	assert.Nil(t, err)
+	manager.UpdatePipelineStatus(created.UUID, model.PipelineCreating)
+
+	_, changed, err := manager.UpsertPipeline("pipeline1", "", template)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	_, err = manager.GetPipeline(created.UUID)
+	assert.Nil(t, err)
+}
+
+func TestUpsertPipeline_DeletingPipeline(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	template := []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow")
+	created, err := manager.CreatePipeline("pipeline1", "", template)
+	assert.Nil(t, err)
+	manager.UpdatePipelineStatus(created.UUID, model.PipelineDeleting)
+
+	_, _, err = manager.UpsertPipeline("pipeline1", "", template)
+	assert.Equal(t, codes.AlreadyExists, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestGetPipelineTemplate(t *testing.T) {
 	store, manager, p := initWithPipeline(t)
 	defer store.Close()
@@ -194,6 +360,23 @@ func TestGetPipelineTemplate(t *testing.T) {
 	assert.Equal(t, []byte(testWorkflow.ToStringForStore()), actualTemplate)
 }
 
+func TestGetPipelineTemplate_Cached(t *testing.T) {
+	store, manager, p := initWithPipeline(t)
+	defer store.Close()
+	_, err := manager.GetPipelineTemplate(p.UUID)
+	assert.Nil(t, err)
+
+	// The template is read from the cache until the pipeline is deleted.
+	store.ObjectStore().DeleteFile(storage.CreatePipelinePath(p.UUID))
+	actualTemplate, err := manager.GetPipelineTemplate(p.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte(testWorkflow.ToStringForStore()), actualTemplate)
+
+	assert.Nil(t, manager.DeletePipeline(p.UUID))
+	_, cached := manager.templateCache.templates.Get(p.UUID)
+	assert.False(t, cached)
+}
+
 func TestGetPipelineTemplate_PipelineMetadataNotFound(t *testing.T) {
 	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	defer store.Close()
@@ -215,6 +398,117 @@ func TestGetPipelineTemplate_PipelineFileNotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "object not found")
 }
 
+var testDAGWorkflow = util.NewWorkflow(&v1alpha1.Workflow{
+	TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+	ObjectMeta: v1.ObjectMeta{Name: "workflow-name"},
+	Spec: v1alpha1.WorkflowSpec{
+		Entrypoint: "main",
+		Templates: []v1alpha1.Template{
+			{Name: "main", DAG: &v1alpha1.DAGTemplate{Tasks: []v1alpha1.DAGTask{{Name: "t", Template: "train"}}}},
+			{Name: "train", Container: &corev1.Container{Image: "train:1"}},
+		},
+	},
+})
+
+func TestGetPipelineSteps(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testDAGWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+
+	steps, err := manager.GetPipelineSteps(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.PipelineStep{
+		{PipelineUUID: pipeline.UUID, Name: "main", Position: 0, Type: "dag", Children: `["train"]`},
+		{PipelineUUID: pipeline.UUID, Name: "train", Position: 1, Type: "container", Image: "train:1", Children: "[]"},
+	}, steps)
+	// The compiled workflow is stored next to the template.
+	manifest, err := store.ObjectStore().GetFile(storage.CreatePipelineManifestPath(pipeline.UUID))
+	assert.Nil(t, err)
+	assert.Equal(t, testDAGWorkflow.ToStringForStore(), string(manifest))
+}
+
+func TestGetPipelineSteps_NotIndexed(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	pipeline, err := store.PipelineStore().CreatePipeline(createPipeline("pipeline1"))
+	assert.Nil(t, err)
+	store.ObjectStore().AddFile([]byte(testDAGWorkflow.ToStringForStore()), storage.CreatePipelinePath(pipeline.UUID))
+	manager := NewResourceManager(store)
+
+	// The steps of the pipelines uploaded before the steps were indexed are indexed on first use.
+	steps, err := manager.GetPipelineSteps(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Len(t, steps, 2)
+	indexedSteps, err := store.PipelineStore().ListPipelineSteps(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, steps, indexedSteps)
+}
+
+func TestGetPipelineSteps_PipelineNotFound(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	_, err := manager.GetPipelineSteps("unknown")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCheckPipelineDeprecation(t *testing.T) {
+	clock := util.NewManualFakeTime(time.Unix(1000, 0))
+	store, err := NewFakeClientManager(clock, util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer store.Close()
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	replacement, err := manager.CreatePipeline("p2", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	spec := &api.PipelineSpec{PipelineId: pipeline.UUID}
+
+	warnings, err := manager.CheckPipelineDeprecation(spec)
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+	warnings, err = manager.CheckPipelineDeprecation(&api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()})
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+
+	_, err = manager.DeprecatePipeline(pipeline.UUID, replacement.UUID, 2000)
+	assert.Nil(t, err)
+	warnings, err = manager.CheckPipelineDeprecation(spec)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{fmt.Sprintf(
+		"Pipeline p1 is deprecated, use pipeline %v instead. It is no longer supported after 1970-01-01T00:33:20Z.",
+		replacement.UUID)}, warnings)
+
+	clock.Set(time.Unix(3000, 0))
+	warnings, err = manager.CheckPipelineDeprecation(spec)
+	assert.Nil(t, err)
+	if assert.Len(t, warnings, 1) {
+		assert.Contains(t, warnings[0], "It is no longer supported since 1970-01-01T00:33:20Z.")
+	}
+	store.EnableSunsetBlocking()
+	_, err = NewResourceManager(store).CheckPipelineDeprecation(spec)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+
+	_, err = manager.UndeprecatePipeline(pipeline.UUID)
+	assert.Nil(t, err)
+	warnings, err = manager.CheckPipelineDeprecation(spec)
+	assert.Nil(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestDeprecatePipeline_Errors(t *testing.T) {
+	store, manager, p := initWithPipeline(t)
+	defer store.Close()
+	_, err := manager.DeprecatePipeline(p.UUID, p.UUID, 0)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	_, err = manager.DeprecatePipeline(p.UUID, "unknown", 0)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+	_, err = manager.DeprecatePipeline("unknown", "", 0)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
 func TestCreateRun_ThroughPipelineID(t *testing.T) {
 	store, manager, p := initWithPipeline(t)
 	defer store.Close()
@@ -236,12 +530,16 @@ func TestCreateRun_ThroughPipelineID(t *testing.T) {
 			},
 		},
 	}
-	runDetail, err := manager.CreateRun(apiRun)
+	runDetail, err := manager.CreateRun(apiRun, "")
 	assert.Nil(t, err)
 
 	expectedRuntimeWorkflow := testWorkflow.DeepCopy()
 	expectedRuntimeWorkflow.Spec.Arguments.Parameters = []v1alpha1.Parameter{
 		{Name: "param1", Value: util.StringPointer("world")}}
+	expectedRuntimeWorkflow.Labels = map[string]string{
+		util.LabelKeyWorkflowPipelineId:   p.UUID,
+		util.LabelKeyWorkflowExperimentId: experiment.UUID,
+	}
 	expectedRunDetail := &model.RunDetail{
 		Run: model.Run{
 			UUID:           "workflow1",
@@ -279,6 +577,7 @@ func TestCreateRun_ThroughWorkflowSpec(t *testing.T) {
 	expectedRuntimeWorkflow := testWorkflow.DeepCopy()
 	expectedRuntimeWorkflow.Spec.Arguments.Parameters = []v1alpha1.Parameter{
 		{Name: "param1", Value: util.StringPointer("world")}}
+	expectedRuntimeWorkflow.Labels = map[string]string{util.LabelKeyWorkflowExperimentId: DefaultFakeUUID}
 	expectedRunDetail := &model.RunDetail{
 		Run: model.Run{
 			UUID:           "workflow1",
@@ -309,6 +608,48 @@ func TestCreateRun_ThroughWorkflowSpec(t *testing.T) {
 	runDetail, err := manager.GetRun(runDetail.UUID)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedRunDetail, runDetail, "CreateRun stored invalid data in database")
+	assert.Equal(t, "Normal RunCreated Run \"run1\" was created through the ML pipeline API",
+		<-store.eventRecorderFake.Events)
+}
+
+func TestImportRun(t *testing.T) {
+	store, manager, experiment := initWithExperiment(t)
+	defer store.Close()
+	apiRun := &api.Run{
+		Id:          "exported-run",
+		Name:        "run1",
+		Description: "imported",
+		CreatedAt:   &timestamp.Timestamp{Seconds: 10},
+		ScheduledAt: &timestamp.Timestamp{Seconds: 11},
+		Status:      "Succeeded",
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*api.Parameter{{Name: "param1", Value: "world"}},
+		},
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+			Relationship: api.Relationship_OWNER,
+		}},
+		Metrics: []*api.RunMetric{
+			{Name: "accuracy", NodeId: "node1", Value: &api.RunMetric_NumberValue{NumberValue: 0.9}},
+		},
+	}
+	run, err := manager.ImportRun(apiRun, "runtime workflow")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultFakeUUID, run.UUID)
+
+	run, err = manager.GetRun(DefaultFakeUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "run1", run.DisplayName)
+	assert.Equal(t, "imported", run.Description)
+	assert.Equal(t, int64(10), run.CreatedAtInSec)
+	assert.Equal(t, int64(11), run.ScheduledAtInSec)
+	assert.Equal(t, "Succeeded", run.Conditions)
+	assert.Equal(t, "runtime workflow", run.WorkflowRuntimeManifest)
+	assert.Equal(t, `[{"name":"param1","value":"world"}]`, run.Parameters)
+	assert.Equal(t, experiment.UUID, run.ResourceReferences[0].ReferenceUUID)
+	assert.Len(t, run.Metrics, 1)
+	assert.Equal(t, 0.9, run.Metrics[0].NumberValue)
 }
 
 func TestCreateRun_EmptyPipelineSpec(t *testing.T) {
@@ -323,7 +664,7 @@ func TestCreateRun_EmptyPipelineSpec(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateRun(apiRun)
+	_, err := manager.CreateRun(apiRun, "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Failed to fetch workflow spec")
 }
@@ -341,7 +682,7 @@ func TestCreateRun_InvalidWorkflowSpec(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateRun(apiRun)
+	_, err := manager.CreateRun(apiRun, "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Failed to unmarshal workflow spec manifest")
 }
@@ -359,15 +700,116 @@ func TestCreateRun_OverrideParametersError(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateRun(apiRun)
+	_, err := manager.CreateRun(apiRun, "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Unrecognized input parameter")
 }
+func TestCreateRun_InvalidDeclaredParameter(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.Annotations = map[string]string{util.AnnotationKeyWorkflowParameters: `[{"name": "param1", "type": "integer"}]`}
+	apiRun := &api.Run{
+		Name: "run1",
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: workflow.ToStringForStore(),
+			Parameters: []*api.Parameter{
+				{Name: "param1", Value: "world"},
+			},
+		},
+	}
+	_, err := manager.CreateRun(apiRun, "")
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), `parameter param1 must be of type integer, got "world"`)
+	assert.Equal(t, 0, store.workflowClientFake.GetWorkflowCount())
+}
+
+func TestCreateRun_EvaluatesParameterMacros(t *testing.T) {
+	store, manager, experiment := initWithExperiment(t)
+	defer store.Close()
+	apiRun := &api.Run{
+		Name: "run1",
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters: []*api.Parameter{
+				{Name: "param1", Value: "[[ExperimentName]]/[[RunName]]"},
+			},
+		},
+		ResourceReferences: []*api.ResourceReference{
+			{
+				Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+				Relationship: api.Relationship_OWNER,
+			},
+		},
+	}
+	run, err := manager.CreateRun(apiRun, "")
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"param1","value":"e1/run1"}]`, run.Parameters)
+	assert.Contains(t, run.WorkflowRuntimeManifest, `"value":"e1/run1"`)
+}
+
+func TestCreateRun_SecretParameters(t *testing.T) {
+	util.SetSecretParameterKey([]byte("test-key"))
+	defer util.SetSecretParameterKey(nil)
+	store, manager, experiment := initWithExperiment(t)
+	defer store.Close()
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.Annotations = map[string]string{util.AnnotationKeyWorkflowParameters: `[{"name": "param1", "type": "secret"}]`}
+	apiRun := &api.Run{
+		Name: "run1",
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: workflow.ToStringForStore(),
+			Parameters: []*api.Parameter{
+				{Name: "param1", Value: "hunter2"},
+			},
+		},
+		ResourceReferences: []*api.ResourceReference{
+			{
+				Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+				Relationship: api.Relationship_OWNER,
+			},
+		},
+	}
+	run, err := manager.CreateRun(apiRun, "")
+	assert.Nil(t, err)
+	hashed := util.HashSecretParameterValue("hunter2")
+	assert.Equal(t, `[{"name":"param1","value":"`+hashed+`"}]`, run.Parameters)
+	assert.NotContains(t, run.WorkflowRuntimeManifest, "hunter2")
+	assert.Contains(t, run.WorkflowRuntimeManifest, hashed)
+
+	// Only the workflow gets the real value.
+	createdWorkflow, err := store.workflowClientFake.Get(run.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "hunter2", *createdWorkflow.Spec.Arguments.Parameters[0].Value)
+	run, err = manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.NotContains(t, run.Parameters, "hunter2")
+}
+
+func TestCreateRun_SecretParametersWithoutKey(t *testing.T) {
+	store, manager, _ := initWithExperiment(t)
+	defer store.Close()
+	workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+	workflow.Annotations = map[string]string{util.AnnotationKeyWorkflowParameters: `[{"name": "param1", "type": "secret"}]`}
+	apiRun := &api.Run{
+		Name: "run1",
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: workflow.ToStringForStore(),
+			Parameters:       []*api.Parameter{{Name: "param1", Value: "hunter2"}},
+		},
+	}
+	_, err := manager.CreateRun(apiRun, "")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+	assert.Equal(t, 0, store.workflowClientFake.GetWorkflowCount())
+}
+
 func TestCreateRun_CreateWorkflowError(t *testing.T) {
 	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	defer store.Close()
 	manager := NewResourceManager(store)
-	manager.workflowClient = &storage.FakeBadWorkflowClient{}
+	manager.engine = engine.NewArgoEngine(&storage.FakeBadWorkflowClient{}, store.podClientFake)
 	apiRun := &api.Run{
 		Name: "run1",
 		PipelineSpec: &api.PipelineSpec{
@@ -377,7 +819,7 @@ func TestCreateRun_CreateWorkflowError(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateRun(apiRun)
+	_, err := manager.CreateRun(apiRun, "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Failed to create a workflow")
 }
@@ -396,11 +838,172 @@ func TestCreateRun_StoreRunMetadataError(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateRun(apiRun)
+	_, err := manager.CreateRun(apiRun, "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "database is closed")
 }
 
+func TestCreateRun_DeletesOutboxEntry(t *testing.T) {
+	store, _, _ := initWithOneTimeRun(t)
+	defer store.Close()
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestCreateRun_CreateWorkflowError_DeletesOutboxEntry(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(&storage.FakeBadWorkflowClient{}, store.podClientFake)
+	_, err := manager.CreateRun(&api.Run{
+		Name:         "run1",
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+	}, "")
+	assert.NotNil(t, err)
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+// existingWorkflowClient fails to create the workflows that already exist, as the
+// Kubernetes API does.
+type existingWorkflowClient struct {
+	*storage.FakeWorkflowClient
+}
+
+func (c existingWorkflowClient) Create(workflow *v1alpha1.Workflow) (*v1alpha1.Workflow, error) {
+	if _, err := c.Get(workflow.Name, v1.GetOptions{}); err == nil {
+		return nil, apierrors.NewAlreadyExists(v1alpha1.Resource("workflows"), workflow.Name)
+	}
+	return c.FakeWorkflowClient.Create(workflow)
+}
+
+// createInterruptedRun stores the outbox entry of a run and creates its workflow, as if
+// the API server crashed before storing the run.
+func createInterruptedRun(t *testing.T, manager *ResourceManager, generateName string) *model.RunOutboxEntry {
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{GenerateName: generateName, UID: "workflow1"},
+	})
+	apiRun := &api.Run{Name: "run1", PipelineSpec: &api.PipelineSpec{WorkflowManifest: workflow.ToStringForStore()}}
+	entry, err := manager.createRunOutboxEntry(apiRun, workflow, []byte(workflow.ToStringForStore()), "")
+	assert.Nil(t, err)
+	_, err = manager.createOutboxWorkflow(entry, workflow, "")
+	assert.Nil(t, err)
+	return entry
+}
+
+func TestReconcileRunOutbox(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(existingWorkflowClient{store.workflowClientFake}, store.podClientFake)
+	entry := createInterruptedRun(t, manager, "wf-")
+
+	assert.Nil(t, manager.ReconcileRunOutbox(math.MaxInt64, 5))
+	// The workflow created before is adopted, under the name derived from the entry ID.
+	assert.Equal(t, 1, store.workflowClientFake.GetWorkflowCount())
+	assert.True(t, store.workflowClientFake.GetWorkflowKeys()["wf-123e4567e8"])
+	run, err := manager.GetRun("workflow1")
+	assert.Nil(t, err)
+	assert.Equal(t, "run1", run.DisplayName)
+	assert.Equal(t, "wf-123e4567e8", run.Name)
+	assert.Equal(t, entry.CreatedAtInSec, run.CreatedAtInSec)
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+	assert.Len(t, store.eventPublisherFake.Events(), 1)
+}
+
+func TestReconcileRunOutbox_SecretParameters(t *testing.T) {
+	util.SetSecretParameterKey([]byte("test-key"))
+	defer util.SetSecretParameterKey(nil)
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "wf",
+			UID:         "workflow1",
+			Annotations: map[string]string{util.AnnotationKeyWorkflowParameters: `[{"name": "param1", "type": "secret"}]`},
+		},
+		Spec: v1alpha1.WorkflowSpec{
+			Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{{Name: "param1", Value: util.StringPointer("hunter2")}}},
+		},
+	})
+	apiRun := &api.Run{Name: "run1", PipelineSpec: &api.PipelineSpec{
+		Parameters: []*api.Parameter{{Name: "param1", Value: "hunter2"}},
+	}}
+	_, err := manager.createRunOutboxEntry(apiRun, workflow, []byte(workflow.ToStringForStore()), "")
+	assert.Nil(t, err)
+	// The entry doesn't keep the value in plaintext.
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.NotContains(t, entries[0].Workflow, "hunter2")
+	assert.NotContains(t, entries[0].Run, "hunter2")
+	assert.NotContains(t, entries[0].SecretParameters, "hunter2")
+
+	// The workflow is created with the value decrypted.
+	assert.Nil(t, manager.ReconcileRunOutbox(math.MaxInt64, 5))
+	createdWorkflow, err := store.workflowClientFake.Get("wf", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "hunter2", *createdWorkflow.Spec.Arguments.Parameters[0].Value)
+	run, err := manager.GetRun("workflow1")
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"param1","value":"`+util.HashSecretParameterValue("hunter2")+`"}]`, run.Parameters)
+}
+
+func TestReconcileRunOutbox_RunAlreadyStored(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(existingWorkflowClient{store.workflowClientFake}, store.podClientFake)
+	entry := createInterruptedRun(t, manager, "wf-")
+	// The run was stored, but the entry wasn't deleted.
+	assert.Nil(t, manager.ReconcileRunOutbox(math.MaxInt64, 5))
+	assert.Nil(t, store.RunOutboxStore().CreateEntry(entry))
+
+	assert.Nil(t, manager.ReconcileRunOutbox(math.MaxInt64, 5))
+	_, err := manager.GetRun("workflow1")
+	assert.Nil(t, err)
+	assert.Len(t, store.eventPublisherFake.Events(), 1)
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestReconcileRunOutbox_SkipsRecentEntries(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	entry := createInterruptedRun(t, manager, "wf-")
+
+	assert.Nil(t, manager.ReconcileRunOutbox(entry.CreatedAtInSec, 5))
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestReconcileRunOutbox_DropsEntryAfterMaxAttempts(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(&storage.FakeBadWorkflowClient{}, store.podClientFake)
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{ObjectMeta: v1.ObjectMeta{Name: "wf"}})
+	_, err := manager.createRunOutboxEntry(&api.Run{Name: "run1"}, workflow, []byte(workflow.ToStringForStore()), "")
+	assert.Nil(t, err)
+
+	assert.NotNil(t, manager.ReconcileRunOutbox(math.MaxInt64, 2))
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, entries[0].Attempts)
+	// The entry is dropped once it reached the maximum number of attempts.
+	assert.Nil(t, manager.ReconcileRunOutbox(math.MaxInt64, 2))
+	entries, err = store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+}
+
 func TestCreateJob_ThroughWorkflowSpec(t *testing.T) {
 	store, _, job := initWithJob(t)
 	defer store.Close()
@@ -443,7 +1046,7 @@ func TestCreateJob_ThroughPipelineID(t *testing.T) {
 			},
 		},
 	}
-	newJob, err := manager.CreateJob(job)
+	newJob, err := manager.CreateJob(job, "")
 	expectedJob := &model.Job{
 		UUID:           "123",
 		DisplayName:    "j1",
@@ -476,7 +1079,7 @@ func TestCreateJob_EmptyPipelineSpec(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateJob(job)
+	_, err := manager.CreateJob(job, "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Failed to fetch workflow spec")
 }
@@ -495,7 +1098,7 @@ func TestCreateJob_InvalidWorkflowSpec(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateJob(job)
+	_, err := manager.CreateJob(job, "")
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "Failed to unmarshal workflow spec manifest")
 }
@@ -513,7 +1116,7 @@ func TestCreateJob_ExtraInputParameterError(t *testing.T) {
 			},
 		},
 	}
-	_, err := manager.CreateJob(job)
+	_, err := manager.CreateJob(job, "")
 	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
 	assert.Contains(t, err.Error(), "Unrecognized input parameter: param2")
 }
@@ -527,11 +1130,57 @@ func TestCreateJob_FailedToCreateScheduleWorkflow(t *testing.T) {
 		Enabled:      true,
 		PipelineSpec: &api.PipelineSpec{PipelineId: p.UUID},
 	}
-	_, err := manager.CreateJob(job)
+	_, err := manager.CreateJob(job, "")
 	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
 	assert.Contains(t, err.Error(), "Failed to create a scheduled workflow")
 }
 
+func TestCreateRun_ServiceAccount(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	store.AllowServiceAccounts("team-a-runner")
+	manager := NewResourceManager(store)
+
+	_, err := manager.CreateRun(&api.Run{
+		Name:           "run1",
+		PipelineSpec:   &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ServiceAccount: "cluster-admin",
+	}, "")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+
+	runDetail, err := manager.CreateRun(&api.Run{
+		Name:           "run1",
+		PipelineSpec:   &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ServiceAccount: "team-a-runner",
+	}, "")
+	assert.Nil(t, err)
+	workflow, err := store.workflowClientFake.Get(runDetail.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a-runner", workflow.Spec.ServiceAccountName)
+}
+
+func TestCreateJob_ServiceAccount(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	job := &api.Job{
+		Name:           "j1",
+		Enabled:        true,
+		PipelineSpec:   &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ServiceAccount: "team-a-runner",
+	}
+	_, err := manager.CreateJob(job, "")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+
+	store.AllowServiceAccounts("team-a-runner")
+	manager = NewResourceManager(store)
+	newJob, err := manager.CreateJob(job, "")
+	assert.Nil(t, err)
+	swf, err := store.ScheduledWorkflow().Get(newJob.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "team-a-runner", swf.Spec.Workflow.Spec.ServiceAccountName)
+}
+
 func TestEnableJob(t *testing.T) {
 	store, manager, job := initWithJob(t)
 	defer store.Close()
@@ -561,6 +1210,10 @@ func TestEnableJob(t *testing.T) {
 	}
 	assert.Nil(t, err)
 	assert.Equal(t, expectedJob, job)
+	assert.Equal(t, "Normal JobCreated Job \"j1\" was created through the ML pipeline API",
+		<-store.eventRecorderFake.Events)
+	assert.Equal(t, "Normal JobDisabled Job was disabled through the ML pipeline API",
+		<-store.eventRecorderFake.Events)
 }
 
 func TestEnableJob_JobNotExist(t *testing.T) {
@@ -654,17 +1307,218 @@ func TestReportWorkflowResource_ScheduledWorkflowIDEmpty_Success(t *testing.T) {
 			WorkflowSpecManifest: testWorkflow.ToStringForStore(),
 			Parameters:           "[{\"name\":\"param1\",\"value\":\"world\"}]",
 		},
-		ResourceReferences: []*model.ResourceReference{
-			{
-				ResourceUUID:  "workflow1",
-				ResourceType:  common.Run,
-				ReferenceUUID: DefaultFakeUUID,
-				ReferenceType: common.Experiment,
-				Relationship:  common.Owner,
-			},
+		ResourceReferences: []*model.ResourceReference{
+			{
+				ResourceUUID:  "workflow1",
+				ResourceType:  common.Run,
+				ReferenceUUID: DefaultFakeUUID,
+				ReferenceType: common.Experiment,
+				Relationship:  common.Owner,
+			},
+		},
+	}
+	assert.Equal(t, expectedRun, runDetail.Run)
+}
+
+func TestReportWorkflowResource_IgnoresStaleReports(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	report := func(resourceVersion string, phase v1alpha1.NodePhase) {
+		workflow := util.NewWorkflow(&v1alpha1.Workflow{
+			ObjectMeta: v1.ObjectMeta{UID: types.UID(run.UUID), ResourceVersion: resourceVersion},
+			Status:     v1alpha1.WorkflowStatus{Phase: phase},
+		})
+		assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	}
+	report("5", v1alpha1.NodeSucceeded)
+	// Out of order.
+	report("4", v1alpha1.NodeRunning)
+
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", runDetail.Conditions)
+}
+
+func TestReportWorkflowResource_NotifiesWebhooksOnCompletion(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	_, err := manager.CreateWebhook(&model.Webhook{
+		Name:         "webhook1",
+		Url:          "https://example.com",
+		Secret:       "secret",
+		EventFilters: "Failed,Error",
+	})
+	assert.Nil(t, err)
+
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "workflow-name",
+			UID:    types.UID(run.UUID),
+			Labels: map[string]string{util.LabelKeyWorkflowExperimentId: DefaultFakeUUID},
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.NodeRunning},
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	assert.Empty(t, store.webhookNotifierFake.Deliveries())
+
+	workflow.Status.Phase = v1alpha1.NodeFailed
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	// Reporting the same final state again doesn't notify twice.
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+
+	assert.Equal(t, []webhook.FakeDelivery{{
+		WebhookId: DefaultFakeUUID,
+		Event: webhook.RunEvent{
+			Event:           "run.failed",
+			RunId:           run.UUID,
+			RunName:         "workflow-name",
+			ExperimentId:    DefaultFakeUUID,
+			State:           "Failed",
+			FinishedAtInSec: workflow.Status.FinishedAt.Unix(),
+		},
+	}}, store.webhookNotifierFake.Deliveries())
+}
+
+func TestReportWorkflowResource_WebhookEventFilter(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	manager.CreateWebhook(&model.Webhook{
+		Name:         "webhook1",
+		Url:          "https://example.com",
+		Secret:       "secret",
+		EventFilters: "Failed,Error",
+	})
+
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{UID: types.UID(run.UUID)},
+		Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.NodeSucceeded},
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	assert.Empty(t, store.webhookNotifierFake.Deliveries())
+}
+
+func TestCreateRun_ExportsEvent(t *testing.T) {
+	store, _, run := initWithOneTimeRun(t)
+	defer store.Close()
+
+	assert.Equal(t, []eventexport.Event{{
+		Type:         eventexport.EventTypeRunCreated,
+		TimeInSec:    run.CreatedAtInSec,
+		RunId:        run.UUID,
+		RunName:      "run1",
+		ExperimentId: DefaultFakeUUID,
+	}}, store.eventPublisherFake.Events())
+}
+
+func TestCreateRun_RequestId(t *testing.T) {
+	store, manager, exp := initWithExperiment(t)
+	defer store.Close()
+	runDetail, err := manager.CreateRun(&api.Run{
+		Name:         "run1",
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: exp.UUID},
+			Relationship: api.Relationship_OWNER,
+		}},
+	}, "request-1")
+	assert.Nil(t, err)
+
+	workflow, err := store.workflowClientFake.Get(runDetail.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "request-1", workflow.Annotations[util.AnnotationKeyRequestId])
+	events := store.eventPublisherFake.Events()
+	assert.Len(t, events, 1)
+	assert.Equal(t, "request-1", events[0].RequestId)
+}
+
+func TestCreateJob_RequestId(t *testing.T) {
+	store, manager, _ := initWithExperiment(t)
+	defer store.Close()
+	job, err := manager.CreateJob(&api.Job{
+		Name:         "j1",
+		Enabled:      true,
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+	}, "request-1")
+	assert.Nil(t, err)
+
+	scheduledWorkflow, err := store.scheduledWorkflowClientFake.Get(job.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "request-1", scheduledWorkflow.Annotations[util.AnnotationKeyRequestId])
+}
+
+func TestReportWorkflowResource_ExportsRunEvents(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "workflow-name",
+			UID:    types.UID(run.UUID),
+			Labels: map[string]string{util.LabelKeyWorkflowExperimentId: DefaultFakeUUID},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:     v1alpha1.NodeRunning,
+			StartedAt: v1.NewTime(time.Unix(5, 0)),
 		},
-	}
-	assert.Equal(t, expectedRun, runDetail.Run)
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	// Resyncs of an unchanged workflow are not exported.
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	workflow.Status.Phase = v1alpha1.NodeSucceeded
+	workflow.Status.FinishedAt = v1.NewTime(time.Unix(8, 0))
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+
+	events := store.eventPublisherFake.Events()
+	assert.Equal(t, []eventexport.Event{
+		{
+			Type:         eventexport.EventTypeRunStarted,
+			TimeInSec:    5,
+			RunId:        run.UUID,
+			RunName:      "workflow-name",
+			ExperimentId: DefaultFakeUUID,
+			State:        "Running",
+		},
+		{
+			Type:         eventexport.EventTypeRunFinished,
+			TimeInSec:    8,
+			RunId:        run.UUID,
+			RunName:      "workflow-name",
+			ExperimentId: DefaultFakeUUID,
+			State:        "Succeeded",
+		},
+	}, events[1:])
+}
+
+func TestReportWorkflowResource_ExportsJobTriggeredEvent(t *testing.T) {
+	store, manager, job := initWithJob(t)
+	defer store.Close()
+
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "MY_NAME",
+			Namespace: "MY_NAMESPACE",
+			UID:       "WORKFLOW_1",
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "kubeflow.org/v1alpha1",
+				Kind:       "ScheduledWorkflow",
+				Name:       "SCHEDULE_NAME",
+				UID:        types.UID(job.UUID),
+			}},
+			CreationTimestamp: v1.NewTime(time.Unix(11, 0).UTC()),
+		},
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+
+	assert.Equal(t, []eventexport.Event{{
+		Type:         eventexport.EventTypeJobTriggered,
+		TimeInSec:    11,
+		RunId:        "WORKFLOW_1",
+		RunName:      "MY_NAME",
+		JobId:        job.UUID,
+		ExperimentId: DefaultFakeUUID,
+		Namespace:    "MY_NAMESPACE",
+	}}, store.eventPublisherFake.Events())
 }
 
 func TestReportWorkflowResource_ScheduledWorkflowIDNotEmpty_Success(t *testing.T) {
@@ -735,7 +1589,7 @@ func TestReportWorkflowResource_ScheduledWorkflowIDNotEmpty_ExperimentNotFound(t
 		Enabled:      true,
 		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
 	}
-	newJob, err := manager.CreateJob(job)
+	newJob, err := manager.CreateJob(job, "")
 
 	// report workflow
 	workflow := util.NewWorkflow(&v1alpha1.Workflow{
@@ -828,7 +1682,7 @@ func TestReportScheduledWorkflowResource_Error(t *testing.T) {
 		Enabled:      true,
 		PipelineSpec: &api.PipelineSpec{PipelineId: p.UUID},
 	}
-	newJob, err := manager.CreateJob(job)
+	newJob, err := manager.CreateJob(job, "")
 	assert.Nil(t, err)
 
 	store.Close()
@@ -923,7 +1777,7 @@ func TestReadArtifact_Succeed(t *testing.T) {
 	// 	Name:       "pp1",
 	// 	PipelineId: p.UUID,
 	// 	Enabled:    true,
-	// })
+	// }, "")
 	workflow := util.NewWorkflow(&v1alpha1.Workflow{
 		ObjectMeta: v1.ObjectMeta{
 			Name:              "MY_NAME",
@@ -964,6 +1818,71 @@ func TestReadArtifact_Succeed(t *testing.T) {
 	assert.Equal(t, expectedContent, string(artifactContent))
 }
 
+func TestGetRunOutputs(t *testing.T) {
+	store, manager, job := initWithJob(t)
+	defer store.Close()
+	store.SetMaxRunOutputSize(10)
+	manager = NewResourceManager(store)
+
+	uiMetadata := func(outputs string) []byte {
+		archive, err := util.ArchiveTgz(map[string]string{"mlpipeline-ui-metadata.json": `{"outputs": [` + outputs + `]}`})
+		assert.Nil(t, err)
+		return []byte(archive)
+	}
+	store.ObjectStore().AddFile(uiMetadata(`{"type": "markdown", "storage": "inline", "source": "# Training"}`),
+		"runs/train/ui.tgz")
+	store.ObjectStore().AddFile(uiMetadata(`{"type": "markdown", "source": "minio://mlpipeline/runs/evaluate.md"},
+		{"type": "web-app", "source": "gs://reports/evaluate.html"}`), "runs/evaluate/ui.tgz")
+	store.ObjectStore().AddFile([]byte("# Evaluation of the model"), "runs/evaluate.md")
+	store.ObjectStore().AddFile([]byte("not an archive"), "runs/broken/ui.tgz")
+	uiMetadataNode := func(startedAt int64, key string) v1alpha1.NodeStatus {
+		return v1alpha1.NodeStatus{
+			StartedAt: v1.NewTime(time.Unix(startedAt, 0)),
+			Outputs: &v1alpha1.Outputs{Artifacts: []v1alpha1.Artifact{{
+				Name:             util.ArtifactNameUIMetadata,
+				ArtifactLocation: v1alpha1.ArtifactLocation{S3: &v1alpha1.S3Artifact{Bucket: "mlpipeline", Key: key}},
+			}}},
+		}
+	}
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              "MY_NAME",
+			Namespace:         "MY_NAMESPACE",
+			UID:               "run-1",
+			CreationTimestamp: v1.NewTime(time.Unix(11, 0).UTC()),
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "kubeflow.org/v1alpha1",
+				Kind:       "ScheduledWorkflow",
+				Name:       "SCHEDULE_NAME",
+				UID:        types.UID(job.UUID),
+			}},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"evaluate": uiMetadataNode(20, "runs/evaluate/ui.tgz"),
+				"train":    uiMetadataNode(10, "runs/train/ui.tgz"),
+				"broken":   uiMetadataNode(30, "runs/broken/ui.tgz"),
+				"dag":      {StartedAt: v1.NewTime(time.Unix(5, 0))},
+			},
+		},
+	})
+	err := manager.ReportWorkflowResource(workflow)
+	assert.Nil(t, err)
+
+	outputs, err := manager.GetRunOutputs("run-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []*RunOutput{
+		{NodeID: "train", Type: "markdown", Content: "# Training"},
+		{NodeID: "evaluate", Type: "markdown", Source: "minio://mlpipeline/runs/evaluate.md", Content: "# Evaluati",
+			Truncated: true},
+		{NodeID: "evaluate", Type: "web-app", Source: "gs://reports/evaluate.html",
+			Error: "Only the outputs stored in bucket mlpipeline of the object store can be read"},
+	}, outputs)
+
+	_, err = manager.GetRunOutputs("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
 func TestReadArtifact_WorkflowNoStatus_NotFound(t *testing.T) {
 	store, manager, job := initWithJob(t)
 	defer store.Close()
@@ -997,6 +1916,111 @@ func TestReadArtifact_NoRun_NotFound(t *testing.T) {
 	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
 }
 
+func reportWorkflowWithPodNode(t *testing.T, manager *ResourceManager, jobID string, phase v1alpha1.NodePhase) {
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              "MY_NAME",
+			Namespace:         "MY_NAMESPACE",
+			UID:               "run-1",
+			CreationTimestamp: v1.NewTime(time.Unix(11, 0).UTC()),
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "kubeflow.org/v1alpha1",
+				Kind:       "ScheduledWorkflow",
+				Name:       "SCHEDULE_NAME",
+				UID:        types.UID(jobID),
+			}},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"MY_NAME":   {Type: v1alpha1.NodeTypeDAG, Phase: phase},
+				"MY_NAME-1": {Type: v1alpha1.NodeTypePod, Phase: phase},
+			},
+		},
+	})
+	err := manager.ReportWorkflowResource(workflow)
+	assert.Nil(t, err)
+}
+
+func TestReadRunLogs_CompletedNode_ArchivesLogsOfPod(t *testing.T) {
+	store, manager, job := initWithJob(t)
+	defer store.Close()
+	reportWorkflowWithPodNode(t, manager, job.UUID, v1alpha1.NodeSucceeded)
+	store.podClientFake.StubLogs("MY_NAME-1", "main", []byte("hello"))
+
+	logs, archived, err := manager.ReadRunLogs("run-1", "MY_NAME-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(logs))
+	assert.False(t, archived)
+
+	// The logs are served from the archive once the pod is gone.
+	store.podClientFake.DeletePod("MY_NAME-1")
+	logs, archived, err = manager.ReadRunLogs("run-1", "MY_NAME-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(logs))
+	assert.True(t, archived)
+	assert.Equal(t, 1, store.podClientFake.Reads())
+}
+
+func TestReadRunLogs_RunningNode_DoesNotArchiveLogs(t *testing.T) {
+	store, manager, job := initWithJob(t)
+	defer store.Close()
+	reportWorkflowWithPodNode(t, manager, job.UUID, v1alpha1.NodeRunning)
+	store.podClientFake.StubLogs("MY_NAME-1", "main", []byte("hel"))
+
+	logs, archived, err := manager.ReadRunLogs("run-1", "MY_NAME-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "hel", string(logs))
+	assert.False(t, archived)
+
+	store.podClientFake.StubLogs("MY_NAME-1", "main", []byte("hello"))
+	logs, _, err = manager.ReadRunLogs("run-1", "MY_NAME-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(logs))
+}
+
+func TestReadRunLogs_PodGone_NotFound(t *testing.T) {
+	store, manager, job := initWithJob(t)
+	defer store.Close()
+	reportWorkflowWithPodNode(t, manager, job.UUID, v1alpha1.NodeSucceeded)
+
+	_, _, err := manager.ReadRunLogs("run-1", "MY_NAME-1")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestReadRunLogs_NotPodNode_NotFound(t *testing.T) {
+	store, manager, job := initWithJob(t)
+	defer store.Close()
+	reportWorkflowWithPodNode(t, manager, job.UUID, v1alpha1.NodeSucceeded)
+
+	_, _, err := manager.ReadRunLogs("run-1", "MY_NAME")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	assert.Equal(t, 0, store.podClientFake.Reads())
+}
+
+func TestReportRunLogs(t *testing.T) {
+	store, manager, job := initWithJob(t)
+	defer store.Close()
+	reportWorkflowWithPodNode(t, manager, job.UUID, v1alpha1.NodeSucceeded)
+
+	err := manager.ReportRunLogs("run-1", "MY_NAME-1", []byte("hello"))
+	assert.Nil(t, err)
+
+	logs, archived, err := manager.ReadRunLogs("run-1", "MY_NAME-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(logs))
+	assert.True(t, archived)
+	assert.Equal(t, 0, store.podClientFake.Reads())
+}
+
+func TestReportRunLogs_NoRun_NotFound(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+
+	err := manager.ReportRunLogs("run-1", "node-1", []byte("hello"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
 const (
 	complexPipeline = `
 # Copyright 2018 Google LLC
@@ -1352,3 +2376,319 @@ spec:
         valueFrom:
           path: /output.txt`
 )
+
+func s3Artifact(name string, key string) v1alpha1.Artifact {
+	return v1alpha1.Artifact{Name: name, ArtifactLocation: v1alpha1.ArtifactLocation{
+		S3: &v1alpha1.S3Artifact{S3Bucket: v1alpha1.S3Bucket{Bucket: "mlpipeline"}, Key: key},
+	}}
+}
+
+func TestReportWorkflowResource_IndexesArtifactLineage(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{UID: types.UID(run.UUID)},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.NodeSucceeded,
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {
+					ID:          "node1",
+					DisplayName: "train",
+					Type:        v1alpha1.NodeTypePod,
+					StartedAt:   v1.NewTime(time.Unix(10, 0)),
+					FinishedAt:  v1.NewTime(time.Unix(20, 0)),
+					Inputs:      &v1alpha1.Inputs{Artifacts: []v1alpha1.Artifact{s3Artifact("data", "data.tgz")}},
+					Outputs:     &v1alpha1.Outputs{Artifacts: []v1alpha1.Artifact{s3Artifact("model", "model.tgz")}},
+				},
+			},
+		},
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+
+	lineage, err := manager.GetArtifactLineage("s3://mlpipeline/model.tgz", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{
+		{
+			RunUUID:        run.UUID,
+			NodeID:         "node1",
+			ArtifactName:   "model",
+			Type:           model.ArtifactEventOutput,
+			ArtifactURI:    "s3://mlpipeline/model.tgz",
+			StepName:       "train",
+			CreatedAtInSec: 20,
+		},
+		{
+			RunUUID:        run.UUID,
+			NodeID:         "node1",
+			ArtifactName:   "data",
+			Type:           model.ArtifactEventInput,
+			ArtifactURI:    "s3://mlpipeline/data.tgz",
+			StepName:       "train",
+			CreatedAtInSec: 10,
+		},
+	}, lineage.Upstream)
+	assert.Empty(t, lineage.Downstream)
+}
+
+func TestGetArtifactLineage(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	event := func(runUUID string, eventType model.ArtifactEventType, uri string) *model.ArtifactEvent {
+		return &model.ArtifactEvent{RunUUID: runUUID, NodeID: "node1", ArtifactName: uri, Type: eventType, ArtifactURI: uri}
+	}
+	// run1 preprocesses the raw data, run2 trains a model with it, and run3 evaluates the model.
+	run1 := []*model.ArtifactEvent{event("run1", model.ArtifactEventInput, "raw"), event("run1", model.ArtifactEventOutput, "data")}
+	run2 := []*model.ArtifactEvent{event("run2", model.ArtifactEventInput, "data"), event("run2", model.ArtifactEventOutput, "model")}
+	run3 := []*model.ArtifactEvent{event("run3", model.ArtifactEventInput, "model"), event("run3", model.ArtifactEventOutput, "metrics")}
+	assert.Nil(t, store.ArtifactLineageStore().ReplaceArtifactEvents("run1", run1))
+	assert.Nil(t, store.ArtifactLineageStore().ReplaceArtifactEvents("run2", run2))
+	assert.Nil(t, store.ArtifactLineageStore().ReplaceArtifactEvents("run3", run3))
+
+	lineage, err := manager.GetArtifactLineage("model", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{run2[1], run2[0], run1[1], run1[0]}, lineage.Upstream)
+	assert.Equal(t, []*model.ArtifactEvent{run3[0], run3[1]}, lineage.Downstream)
+
+	lineage, err = manager.GetArtifactLineage("model", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{run2[1], run2[0]}, lineage.Upstream)
+	assert.Equal(t, []*model.ArtifactEvent{run3[0], run3[1]}, lineage.Downstream)
+}
+
+func TestGetArtifactLineage_NotFound(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+
+	_, err := manager.GetArtifactLineage("s3://mlpipeline/not-exist", 0)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func newWorkflowWithModel(runUUID string, phase v1alpha1.NodePhase) *util.Workflow {
+	return util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			UID: types.UID(runUUID),
+			Annotations: map[string]string{
+				util.AnnotationKeyWorkflowModels: `[{"name": "mnist", "template": "train", "artifact": "model"}]`,
+			},
+		},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: phase,
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {
+					ID:           "node1",
+					TemplateName: "train",
+					Type:         v1alpha1.NodeTypePod,
+					Outputs:      &v1alpha1.Outputs{Artifacts: []v1alpha1.Artifact{s3Artifact("model", "model.tgz")}},
+				},
+			},
+		},
+	})
+}
+
+func TestReportWorkflowResource_RegistersModels(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+
+	assert.Nil(t, manager.ReportWorkflowResource(newWorkflowWithModel(run.UUID, v1alpha1.NodeRunning)))
+	modelVersions, _, err := manager.ListModelVersions("mnist", &common.PaginationContext{
+		PageSize: 10, KeyFieldName: "UUID", SortByFieldName: "CreatedAtInSec"})
+	assert.Nil(t, err)
+	assert.Empty(t, modelVersions)
+
+	assert.Nil(t, manager.ReportWorkflowResource(newWorkflowWithModel(run.UUID, v1alpha1.NodeSucceeded)))
+	// A resync of the succeeded workflow doesn't register the model again.
+	assert.Nil(t, manager.ReportWorkflowResource(newWorkflowWithModel(run.UUID, v1alpha1.NodeSucceeded)))
+	modelVersions, _, err = manager.ListModelVersions("mnist", &common.PaginationContext{
+		PageSize: 10, KeyFieldName: "UUID", SortByFieldName: "CreatedAtInSec"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(modelVersions))
+	assert.Equal(t, model.ModelVersion{
+		UUID:           DefaultFakeUUID,
+		ModelName:      "mnist",
+		Version:        1,
+		ArtifactURI:    "s3://mlpipeline/model.tgz",
+		ArtifactName:   "model",
+		RunUUID:        run.UUID,
+		NodeID:         "node1",
+		CreatedAtInSec: modelVersions[0].CreatedAtInSec,
+	}, modelVersions[0])
+}
+
+func TestReportWorkflowResource_InvalidModelDeclarationIsIgnored(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow := newWorkflowWithModel(run.UUID, v1alpha1.NodeSucceeded)
+	workflow.Annotations[util.AnnotationKeyWorkflowModels] = "not json"
+
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", runDetail.Conditions)
+}
+
+func TestReportWorkflowResource_TracksDeployments(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: "workflow-name", Namespace: "kubeflow", UID: types.UID(run.UUID)},
+		Spec: v1alpha1.WorkflowSpec{Templates: []v1alpha1.Template{
+			{Name: "deploy", Resource: &v1alpha1.ResourceTemplate{Action: "apply", Manifest: `
+apiVersion: serving.kubeflow.org/v1alpha1
+kind: InferenceService
+metadata:
+  name: mnist
+`}},
+		}},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.NodeSucceeded,
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {ID: "node1", TemplateName: "deploy", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeSucceeded},
+			},
+		},
+	})
+
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	runDetail, err := manager.GetRun(run.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(runDetail.Deployments))
+	deployment := runDetail.Deployments[0]
+	assert.Equal(t, &model.RunDeployment{
+		RunUUID:        run.UUID,
+		Kind:           "InferenceService",
+		Namespace:      "kubeflow",
+		Name:           "mnist",
+		APIVersion:     "serving.kubeflow.org/v1alpha1",
+		State:          model.DeploymentPending,
+		CreatedAtInSec: deployment.CreatedAtInSec,
+		UpdatedAtInSec: deployment.UpdatedAtInSec,
+	}, deployment)
+}
+
+var testContainerWorkflow = util.NewWorkflow(&v1alpha1.Workflow{
+	TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+	ObjectMeta: v1.ObjectMeta{Name: "workflow-name", UID: "workflow1"},
+	Spec: v1alpha1.WorkflowSpec{
+		Entrypoint: "train",
+		Templates:  []v1alpha1.Template{{Name: "train", Container: &corev1.Container{Image: "train"}}},
+	},
+})
+
+func metricsPushTokenOrEmpty(envs []corev1.EnvVar) string {
+	for _, env := range envs {
+		if env.Name == util.EnvKeyMetricsPushToken {
+			return env.Value
+		}
+	}
+	return ""
+}
+
+func TestCreateRun_SetsMetricsPushEnv(t *testing.T) {
+	store, manager, exp := initWithExperiment(t)
+	defer store.Close()
+	store.EnableMetricsPush()
+	manager = NewResourceManager(store)
+	runDetail, err := manager.CreateRun(&api.Run{
+		Name:         "run1",
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testContainerWorkflow.ToStringForStore()},
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: exp.UUID},
+			Relationship: api.Relationship_OWNER,
+		}},
+	}, "")
+	assert.Nil(t, err)
+
+	workflow, err := store.workflowClientFake.Get(runDetail.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	envs := workflow.Spec.Templates[0].Container.Env
+	assert.Equal(t, corev1.EnvVar{Name: util.EnvKeyRunId, Value: "{{workflow.uid}}"}, envs[0])
+	assert.Equal(t, corev1.EnvVar{Name: util.EnvKeyNodeId, Value: "{{pod.name}}"}, envs[1])
+	token := metricsPushTokenOrEmpty(envs)
+	assert.Len(t, token, 2*metricsPushTokenBytes)
+	// The stored run doesn't have the token.
+	runDetail, err = manager.GetRun(runDetail.UUID)
+	assert.Nil(t, err)
+	assert.NotContains(t, runDetail.WorkflowRuntimeManifest, token)
+	assert.Contains(t, runDetail.WorkflowRuntimeManifest, util.EnvKeyMetricsPushToken)
+
+	assert.Nil(t, manager.VerifyMetricsPushToken(runDetail.UUID, token))
+	err = manager.VerifyMetricsPushToken(runDetail.UUID, "wrong token")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unauthenticated))
+	err = manager.VerifyMetricsPushToken("unknown run", token)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestReconcileRunOutbox_SetsMetricsPushEnv(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	store.EnableMetricsPush()
+	manager := NewResourceManager(store)
+	workflow := util.NewWorkflow(testContainerWorkflow.DeepCopy())
+	workflow.Name = "wf"
+	workflow.UID = "workflow1"
+	token, err := manager.issueMetricsPushToken(workflow)
+	assert.Nil(t, err)
+	apiRun := &api.Run{Name: "run1", PipelineSpec: &api.PipelineSpec{WorkflowManifest: workflow.ToStringForStore()}}
+	_, err = manager.createRunOutboxEntry(apiRun, workflow, []byte(workflow.ToStringForStore()), token)
+	assert.Nil(t, err)
+	entries, err := store.RunOutboxStore().ListEntries(math.MaxInt64, 10)
+	assert.Nil(t, err)
+	assert.NotContains(t, entries[0].Workflow, token)
+
+	// The workflow is created with the token masked in the entry.
+	assert.Nil(t, manager.ReconcileRunOutbox(math.MaxInt64, 5))
+	createdWorkflow, err := store.workflowClientFake.Get("wf", v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, token, metricsPushTokenOrEmpty(createdWorkflow.Spec.Templates[0].Container.Env))
+	assert.Nil(t, manager.VerifyMetricsPushToken("workflow1", token))
+}
+
+func TestVerifyMetricsPushToken_RunOfJob(t *testing.T) {
+	store, manager, exp := initWithExperiment(t)
+	defer store.Close()
+	store.EnableMetricsPush()
+	manager = NewResourceManager(store)
+	job, err := manager.CreateJob(&api.Job{
+		Name:         "j1",
+		Enabled:      true,
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testContainerWorkflow.ToStringForStore()},
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: exp.UUID},
+			Relationship: api.Relationship_OWNER,
+		}},
+	}, "")
+	assert.Nil(t, err)
+	scheduledWorkflow, err := store.scheduledWorkflowClientFake.Get(job.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	token := metricsPushTokenOrEmpty(scheduledWorkflow.Spec.Workflow.Spec.Templates[0].Container.Env)
+	assert.NotEmpty(t, token)
+
+	// The runs of the job are authenticated by its token.
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "MY_NAME",
+			UID:  "WORKFLOW_1",
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "kubeflow.org/v1alpha1",
+				Kind:       "ScheduledWorkflow",
+				Name:       "SCHEDULE_NAME",
+				UID:        types.UID(job.UUID),
+			}},
+		},
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	assert.Nil(t, manager.VerifyMetricsPushToken("WORKFLOW_1", token))
+
+	// Deleting the job revokes its token.
+	assert.Nil(t, manager.DeleteJob(job.UUID))
+	err = manager.VerifyMetricsPushToken("WORKFLOW_1", token)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.Unauthenticated))
+}
+
+func TestVerifyMetricsPushToken_Disabled(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	err := manager.VerifyMetricsPushToken(runDetail.UUID, "token")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+}
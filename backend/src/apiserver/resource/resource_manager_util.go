@@ -109,3 +109,18 @@ func toParametersMap(apiParams []*api.Parameter) map[string]string {
 	}
 	return desiredParamsMap
 }
+
+// toWorkflowLabels returns the labels identifying the pipeline and the experiment a
+// workflow is created from, so components watching workflows can group them.
+func toWorkflowLabels(pipelineSpec *api.PipelineSpec, references []*api.ResourceReference) map[string]string {
+	labels := make(map[string]string)
+	if pipelineSpec.GetPipelineId() != "" {
+		labels[util.LabelKeyWorkflowPipelineId] = pipelineSpec.GetPipelineId()
+	}
+	for _, reference := range references {
+		if reference.GetKey().GetType() == api.ResourceType_EXPERIMENT {
+			labels[util.LabelKeyWorkflowExperimentId] = reference.GetKey().GetId()
+		}
+	}
+	return labels
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const bytesPerGiB = 1 << 30
+
+// The extended resources of the GPUs requested by the steps.
+var gpuResourceNames = []corev1.ResourceName{"nvidia.com/gpu", "amd.com/gpu"}
+
+// RunEstimationConfig configures the estimates of the runs.
+type RunEstimationConfig struct {
+	// The number of past runs of a pipeline the durations of the steps are averaged over.
+	PastRuns int
+	// The prices of the resources per hour, in the currency.
+	CPUHourPrice       float64
+	MemoryGiBHourPrice float64
+	GPUHourPrice       float64
+	Currency           string
+}
+
+// RunEstimate is the estimated use of resources and cost of a run.
+type RunEstimate struct {
+	// The resources requested by the steps of the run, summed over the steps.
+	CPU       float64
+	MemoryGiB float64
+	GPU       float64
+	// The averages over the past runs of the pipeline of the run.
+	DurationInSec  int64
+	CPUHours       float64
+	MemoryGiBHours float64
+	GPUHours       float64
+	Cost           float64
+	Currency       string
+	PastRuns       int
+	Warnings       []string
+}
+
+// stepResources are the resources requested by the pod of a step.
+type stepResources struct {
+	cpu       float64
+	memoryGiB float64
+	gpu       float64
+}
+
+// EstimateRun estimates the resources and the cost of a run, without creating it, from the
+// resources requested by the templates of its workflow and the durations of their steps in the
+// last runs of its pipeline which succeeded.
+func (r *ResourceManager) EstimateRun(apiRun *api.Run) (*RunEstimate, error) {
+	workflowSpecManifestBytes, err := r.getWorkflowSpecBytes(apiRun.GetPipelineSpec())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to fetch workflow spec.")
+	}
+	var workflow util.Workflow
+	if err := json.Unmarshal(workflowSpecManifestBytes, &workflow); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to unmarshal the workflow spec manifest")
+	}
+	estimate := &RunEstimate{Currency: r.runEstimationConfig.Currency}
+	resources := map[string]stepResources{}
+	for _, template := range workflow.Spec.Templates {
+		container := template.Container
+		if template.Script != nil {
+			container = &template.Script.Container
+		}
+		if container == nil {
+			continue
+		}
+		step := toStepResources(container.Resources)
+		if step.cpu == 0 && step.memoryGiB == 0 {
+			estimate.Warnings = append(estimate.Warnings,
+				fmt.Sprintf("The step %v requests no CPU nor memory", template.Name))
+		}
+		resources[template.Name] = step
+		estimate.CPU += step.cpu
+		estimate.MemoryGiB += step.memoryGiB
+		estimate.GPU += step.gpu
+	}
+
+	pipelineId := apiRun.GetPipelineSpec().GetPipelineId()
+	if pipelineId == "" {
+		estimate.Warnings = append(estimate.Warnings,
+			"The run has no pipeline, so the durations of its steps are unknown")
+		return estimate, nil
+	}
+	pastRuns, err := r.runStore.ListSucceededRuns(pipelineId, r.runEstimationConfig.PastRuns)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to list the past runs of the pipeline")
+	}
+	var durationInSec float64
+	for _, pastRun := range pastRuns {
+		var pastWorkflow workflowapi.Workflow
+		if err := json.Unmarshal([]byte(pastRun.WorkflowRuntimeManifest), &pastWorkflow); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to unmarshal the workflow of run %v", pastRun.UUID)
+		}
+		durationInSec += util.NewWorkflow(&pastWorkflow).DurationInSecOr0()
+		for _, node := range pastWorkflow.Status.Nodes {
+			step, ok := resources[node.TemplateName]
+			if node.Type != workflowapi.NodeTypePod || !ok || node.StartedAt.IsZero() || node.FinishedAt.IsZero() {
+				continue
+			}
+			hours := node.FinishedAt.Sub(node.StartedAt.Time).Hours()
+			estimate.CPUHours += step.cpu * hours
+			estimate.MemoryGiBHours += step.memoryGiB * hours
+			estimate.GPUHours += step.gpu * hours
+		}
+	}
+	estimate.PastRuns = len(pastRuns)
+	if estimate.PastRuns == 0 {
+		estimate.Warnings = append(estimate.Warnings,
+			"The pipeline has no past run which succeeded, so the durations of its steps are unknown")
+		return estimate, nil
+	}
+	count := float64(estimate.PastRuns)
+	estimate.DurationInSec = int64(durationInSec / count)
+	estimate.CPUHours /= count
+	estimate.MemoryGiBHours /= count
+	estimate.GPUHours /= count
+	prices := r.runEstimationConfig
+	if prices.CPUHourPrice == 0 && prices.MemoryGiBHourPrice == 0 && prices.GPUHourPrice == 0 {
+		estimate.Warnings = append(estimate.Warnings, "The prices of the resources aren't configured")
+	}
+	estimate.Cost = estimate.CPUHours*prices.CPUHourPrice + estimate.MemoryGiBHours*prices.MemoryGiBHourPrice +
+		estimate.GPUHours*prices.GPUHourPrice
+	return estimate, nil
+}
+
+// toStepResources returns the resources requested by a container, which default to its limits
+// like the ones of the pods.
+func toStepResources(requirements corev1.ResourceRequirements) stepResources {
+	request := func(name corev1.ResourceName) float64 {
+		if quantity, ok := requirements.Requests[name]; ok {
+			return float64(quantity.MilliValue()) / 1000
+		}
+		if quantity, ok := requirements.Limits[name]; ok {
+			return float64(quantity.MilliValue()) / 1000
+		}
+		return 0
+	}
+	step := stepResources{
+		cpu:       request(corev1.ResourceCPU),
+		memoryGiB: request(corev1.ResourceMemory) / bytesPerGiB,
+	}
+	for _, name := range gpuResourceNames {
+		step.gpu += request(name)
+	}
+	return step
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testEstimatedWorkflow = util.NewWorkflow(&v1alpha1.Workflow{
+	TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+	ObjectMeta: v1.ObjectMeta{Name: "workflow-name"},
+	Spec: v1alpha1.WorkflowSpec{
+		Entrypoint: "main",
+		Templates: []v1alpha1.Template{
+			{Name: "main"},
+			{Name: "train", Container: &corev1.Container{Image: "train", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    k8sresource.MustParse("2"),
+					corev1.ResourceMemory: k8sresource.MustParse("4Gi")},
+				Limits: corev1.ResourceList{"nvidia.com/gpu": k8sresource.MustParse("1")},
+			}}},
+			// The requests default to the limits.
+			{Name: "evaluate", Script: &v1alpha1.ScriptTemplate{Container: corev1.Container{Image: "python",
+				Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+					corev1.ResourceCPU: k8sresource.MustParse("500m")}}}}},
+			{Name: "notify", Container: &corev1.Container{Image: "curl"}},
+		},
+	},
+})
+
+func newPastRun(uuid string, pipelineId string, nodes map[string]v1alpha1.NodeStatus, duration time.Duration) *model.RunDetail {
+	workflow := testEstimatedWorkflow.DeepCopy()
+	workflow.Status.StartedAt = v1.NewTime(time.Unix(0, 0))
+	workflow.Status.FinishedAt = v1.NewTime(time.Unix(0, 0).Add(duration))
+	workflow.Status.Nodes = nodes
+	return &model.RunDetail{
+		Run: model.Run{UUID: uuid, Name: uuid, Conditions: string(v1alpha1.NodeSucceeded),
+			PipelineSpec: model.PipelineSpec{PipelineId: pipelineId}},
+		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: util.NewWorkflow(workflow).ToStringForStore()},
+	}
+}
+
+func newPodNode(template string, hours float64) v1alpha1.NodeStatus {
+	return v1alpha1.NodeStatus{
+		Type:         v1alpha1.NodeTypePod,
+		TemplateName: template,
+		StartedAt:    v1.NewTime(time.Unix(0, 0)),
+		FinishedAt:   v1.NewTime(time.Unix(0, 0).Add(time.Duration(hours * float64(time.Hour)))),
+	}
+}
+
+func TestEstimateRun(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	store.SetRunEstimationConfig(RunEstimationConfig{
+		PastRuns: 10, CPUHourPrice: 0.05, MemoryGiBHourPrice: 0.01, GPUHourPrice: 2, Currency: "USD"})
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testEstimatedWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	_, err = store.RunStore().CreateRun(newPastRun("past-1", pipeline.UUID, map[string]v1alpha1.NodeStatus{
+		"main":     {Type: v1alpha1.NodeTypeDAG, TemplateName: "main"},
+		"train":    newPodNode("train", 1),
+		"evaluate": newPodNode("evaluate", 2),
+	}, 3*time.Hour))
+	assert.Nil(t, err)
+	_, err = store.RunStore().CreateRun(newPastRun("past-2", pipeline.UUID, map[string]v1alpha1.NodeStatus{
+		"train":    newPodNode("train", 3),
+		"evaluate": newPodNode("evaluate", 2),
+	}, 5*time.Hour))
+	assert.Nil(t, err)
+
+	estimate, err := manager.EstimateRun(&api.Run{PipelineSpec: &api.PipelineSpec{PipelineId: pipeline.UUID}})
+	assert.Nil(t, err)
+	assert.Equal(t, &RunEstimate{
+		CPU:            2.5,
+		MemoryGiB:      4,
+		GPU:            1,
+		DurationInSec:  4 * 3600,
+		CPUHours:       (2*1 + 0.5*2 + 2*3 + 0.5*2) / 2.0,
+		MemoryGiBHours: (4*1 + 4*3) / 2.0,
+		GPUHours:       (1 + 3) / 2.0,
+		Cost:           5*0.05 + 8*0.01 + 2*2,
+		Currency:       "USD",
+		PastRuns:       2,
+		Warnings:       []string{"The step notify requests no CPU nor memory"},
+	}, estimate)
+}
+
+func TestEstimateRun_WithoutPastRuns(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testEstimatedWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+
+	estimate, err := manager.EstimateRun(&api.Run{PipelineSpec: &api.PipelineSpec{PipelineId: pipeline.UUID}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2.5, estimate.CPU)
+	assert.Zero(t, estimate.CPUHours)
+	assert.Equal(t, 0, estimate.PastRuns)
+	assert.Contains(t, estimate.Warnings,
+		"The pipeline has no past run which succeeded, so the durations of its steps are unknown")
+
+	// The durations of the workflows without pipeline are unknown.
+	estimate, err = manager.EstimateRun(&api.Run{PipelineSpec: &api.PipelineSpec{
+		WorkflowManifest: testEstimatedWorkflow.ToStringForStore()}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, estimate.GPU)
+	assert.Contains(t, estimate.Warnings, "The run has no pipeline, so the durations of its steps are unknown")
+}
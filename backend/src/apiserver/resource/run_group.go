@@ -0,0 +1,155 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// RunGroupDetail is a run group with its runs, of which only the UUID, the name and the
+// conditions are set.
+type RunGroupDetail struct {
+	*model.RunGroup
+	Runs []*model.Run
+}
+
+// CreateRunGroup stores a group with runs, which must exist.
+func (r *ResourceManager) CreateRunGroup(group *model.RunGroup, runIds []string) (*RunGroupDetail, error) {
+	if err := r.checkRunsExist(runIds); err != nil {
+		return nil, util.Wrap(err, "Failed to create the run group")
+	}
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate the run group ID")
+	}
+	newGroup := *group
+	newGroup.UUID = uuid.String()
+	newGroup.CreatedAtInSec = r.time.Now().Unix()
+	newGroup.CancelledAtInSec = 0
+	if err := r.runGroupStore.CreateRunGroup(&newGroup, runIds); err != nil {
+		return nil, util.Wrap(err, "Failed to create the run group")
+	}
+	return r.GetRunGroup(newGroup.UUID)
+}
+
+// GetRunGroup returns a group with its runs.
+func (r *ResourceManager) GetRunGroup(groupId string) (*RunGroupDetail, error) {
+	group, err := r.runGroupStore.GetRunGroup(groupId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the run group")
+	}
+	return r.toRunGroupDetail(group)
+}
+
+func (r *ResourceManager) ListRunGroups(context *common.PaginationContext) ([]*RunGroupDetail, string, error) {
+	groups, nextPageToken, err := r.runGroupStore.ListRunGroups(context)
+	if err != nil {
+		return nil, "", util.Wrap(err, "Failed to list the run groups")
+	}
+	details := make([]*RunGroupDetail, 0, len(groups))
+	for i := range groups {
+		detail, err := r.toRunGroupDetail(&groups[i])
+		if err != nil {
+			return nil, "", util.Wrap(err, "Failed to list the run groups")
+		}
+		details = append(details, detail)
+	}
+	return details, nextPageToken, nil
+}
+
+// AddRunsToRunGroup adds runs, which must exist, to a group which isn't cancelled.
+func (r *ResourceManager) AddRunsToRunGroup(groupId string, runIds []string) (*RunGroupDetail, error) {
+	group, err := r.runGroupStore.GetRunGroup(groupId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to add the runs to the run group")
+	}
+	if group.CancelledAtInSec > 0 {
+		return nil, util.NewFailedPreconditionError("The run group %v is cancelled", groupId)
+	}
+	if err := r.checkRunsExist(runIds); err != nil {
+		return nil, util.Wrap(err, "Failed to add the runs to the run group")
+	}
+	if err := r.runGroupStore.AddRunsToRunGroup(groupId, runIds); err != nil {
+		return nil, util.Wrap(err, "Failed to add the runs to the run group")
+	}
+	return r.GetRunGroup(groupId)
+}
+
+// CancelRunGroup marks a group as cancelled, then terminates its runs which are still running.
+// Cancelling a group again terminates the runs whose termination failed.
+func (r *ResourceManager) CancelRunGroup(groupId string) (*RunGroupDetail, error) {
+	if _, err := r.runGroupStore.GetRunGroup(groupId); err != nil {
+		return nil, util.Wrap(err, "Failed to cancel the run group")
+	}
+	if err := r.runGroupStore.CancelRunGroup(groupId, r.time.Now().Unix()); err != nil {
+		return nil, util.Wrap(err, "Failed to cancel the run group")
+	}
+	runs, err := r.runGroupStore.ListRunGroupRuns(groupId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to cancel the run group")
+	}
+	var failed []string
+	for _, run := range runs {
+		if util.IsFinalCondition(run.Conditions) {
+			continue
+		}
+		runEngine, err := r.runEngine(run)
+		if err != nil {
+			glog.Errorf("Failed to terminate run %v of run group %v: %+v", run.UUID, groupId, err)
+			failed = append(failed, run.UUID)
+			continue
+		}
+		// The runs whose workflow vanished are marked as errored by the orphan reconciler.
+		err = runEngine.Terminate(run.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			glog.Errorf("Failed to terminate run %v of run group %v: %+v", run.UUID, groupId, err)
+			failed = append(failed, run.UUID)
+		} else if err == nil {
+			r.recordRunTerminated(run)
+		}
+	}
+	if len(failed) > 0 {
+		return nil, util.NewInternalServerError(fmt.Errorf("failed runs: %v", failed),
+			"Failed to terminate %v runs of the run group", len(failed))
+	}
+	return r.GetRunGroup(groupId)
+}
+
+func (r *ResourceManager) toRunGroupDetail(group *model.RunGroup) (*RunGroupDetail, error) {
+	runs, err := r.runGroupStore.ListRunGroupRuns(group.UUID)
+	if err != nil {
+		return nil, err
+	}
+	return &RunGroupDetail{RunGroup: group, Runs: runs}, nil
+}
+
+func (r *ResourceManager) checkRunsExist(runIds []string) error {
+	existing, err := r.runStore.GetExistingRunIds(runIds)
+	if err != nil {
+		return err
+	}
+	for _, runId := range runIds {
+		if !existing[runId] {
+			return util.NewResourceNotFoundError("Run", runId)
+		}
+	}
+	return nil
+}
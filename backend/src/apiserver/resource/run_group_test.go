@@ -0,0 +1,125 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func initWithRuns(t *testing.T, count int) (*FakeClientManager, *ResourceManager, []*model.RunDetail) {
+	store, err := NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(&uidWorkflowClient{FakeWorkflowClient: store.workflowClientFake},
+		store.podClientFake)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testSweptWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	var runs []*model.RunDetail
+	for i := 0; i < count; i++ {
+		run, err := manager.CreateRun(&api.Run{
+			Name:         fmt.Sprintf("run%v", i),
+			PipelineSpec: &api.PipelineSpec{PipelineId: pipeline.UUID},
+		}, "")
+		assert.Nil(t, err)
+		runs = append(runs, run)
+	}
+	return store, manager, runs
+}
+
+func TestCreateRunGroup(t *testing.T) {
+	store, manager, runs := initWithRuns(t, 2)
+	defer store.Close()
+
+	group, err := manager.CreateRunGroup(&model.RunGroup{Name: "group", Description: "backfill"},
+		[]string{runs[0].UUID})
+	assert.Nil(t, err)
+	assert.Equal(t, "group", group.Name)
+	assert.Equal(t, "backfill", group.Description)
+	assert.Equal(t, []*model.Run{{UUID: runs[0].UUID, DisplayName: runs[0].DisplayName, Name: runs[0].Name,
+		Namespace: runs[0].Namespace, Conditions: runs[0].Conditions}}, group.Runs)
+
+	group, err = manager.AddRunsToRunGroup(group.UUID, []string{runs[1].UUID})
+	assert.Nil(t, err)
+	assert.Len(t, group.Runs, 2)
+
+	groups, nextPageToken, err := manager.ListRunGroups(&common.PaginationContext{
+		PageSize: 10, KeyFieldName: model.GetRunGroupTablePrimaryKeyColumn(), SortByFieldName: "CreatedAtInSec"})
+	assert.Nil(t, err)
+	assert.Empty(t, nextPageToken)
+	assert.Equal(t, []*RunGroupDetail{group}, groups)
+}
+
+func TestCreateRunGroup_RunNotFound(t *testing.T) {
+	store, manager, _ := initWithRuns(t, 0)
+	defer store.Close()
+
+	_, err := manager.CreateRunGroup(&model.RunGroup{Name: "group"}, []string{"unknown"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestCancelRunGroup(t *testing.T) {
+	store, manager, runs := initWithRuns(t, 2)
+	defer store.Close()
+	assert.Nil(t, store.RunStore().UpdateRun(runs[1].UUID, string(v1alpha1.NodeSucceeded), ""))
+	group, err := manager.CreateRunGroup(&model.RunGroup{Name: "group"}, []string{runs[0].UUID, runs[1].UUID})
+	assert.Nil(t, err)
+
+	group, err = manager.CancelRunGroup(group.UUID)
+	assert.Nil(t, err)
+	assert.NotZero(t, group.CancelledAtInSec)
+	// Only the run still running is terminated.
+	workflow, err := store.workflowClientFake.Get(runs[0].Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), *workflow.Spec.ActiveDeadlineSeconds)
+	events := recordedEvents(store)
+	assert.Contains(t, events, terminatedEvent(runs[0]))
+	assert.NotContains(t, events, terminatedEvent(runs[1]))
+	workflow, err = store.workflowClientFake.Get(runs[1].Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, workflow.Spec.ActiveDeadlineSeconds)
+
+	_, err = manager.AddRunsToRunGroup(group.UUID, []string{runs[0].UUID})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+}
+
+func TestCancelRunGroup_Sweep(t *testing.T) {
+	store, manager, sweep := initWithSweep(t, 2)
+	defer store.Close()
+	assert.Nil(t, manager.ReconcileRunSweeps())
+	group, err := manager.GetRunGroup(sweep.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "sweep", group.Name)
+	assert.Len(t, group.Runs, 2)
+
+	_, err = manager.CancelRunGroup(sweep.UUID)
+	assert.Nil(t, err)
+	// The sweep creates no run once its group is cancelled.
+	assert.Nil(t, store.RunStore().UpdateRun(group.Runs[0].UUID, string(v1alpha1.NodeSucceeded), ""))
+	assert.Nil(t, manager.ReconcileRunSweeps())
+	detail, err := manager.GetRunSweep(sweep.UUID)
+	assert.Nil(t, err)
+	assert.Nil(t, detail.Trials[2].Run)
+}
@@ -0,0 +1,44 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// GetRunHistory returns the state transitions of a run, or only the ones of a node of its
+// workflow if nodeId isn't empty, in chronological order. If asOfInSec isn't 0, only the
+// transitions up to that time are returned.
+func (r *ResourceManager) GetRunHistory(runId string, nodeId string, asOfInSec int64) (
+	[]*model.RunStateTransition, error) {
+	if _, err := r.runStore.GetRun(runId); err != nil {
+		return nil, err
+	}
+	return r.runHistoryStore.ListRunStateTransitions(runId, nodeId, asOfInSec)
+}
+
+// recordRunHistory stores the states the reported workflow and its nodes reached since the
+// last ones recorded. The history itself is compared with, so that the transitions missed
+// by a report failing are recorded on the next one.
+func (r *ResourceManager) recordRunHistory(workflow *util.Workflow) error {
+	runId := string(workflow.UID)
+	history, err := r.runHistoryStore.ListRunStateTransitions(runId, "", 0)
+	if err != nil {
+		return err
+	}
+	transitions := ToModelRunStateTransitions(workflow, history, r.time.Now().Unix())
+	return r.runHistoryStore.AppendRunStateTransitions(transitions)
+}
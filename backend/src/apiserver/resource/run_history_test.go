@@ -0,0 +1,111 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestReportWorkflowResource_RecordsRunHistory(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: run.Name, UID: types.UID(run.UUID)},
+		Status: v1alpha1.WorkflowStatus{
+			Phase:     v1alpha1.NodeRunning,
+			StartedAt: v1.NewTime(time.Unix(10, 0)),
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {ID: "node1", DisplayName: "train", Phase: v1alpha1.NodeRunning,
+					StartedAt: v1.NewTime(time.Unix(10, 0))},
+			},
+		},
+	}
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+	// The same states reported again aren't recorded again.
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+	workflow.Status.Phase = v1alpha1.NodeFailed
+	workflow.Status.FinishedAt = v1.NewTime(time.Unix(30, 0))
+	workflow.Status.Message = "child 'node1' failed"
+	workflow.Status.Nodes["node1"] = v1alpha1.NodeStatus{ID: "node1", DisplayName: "train",
+		Phase: v1alpha1.NodeFailed, Message: "OOMKilled", StartedAt: v1.NewTime(time.Unix(10, 0)),
+		FinishedAt: v1.NewTime(time.Unix(25, 0))}
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+
+	history, err := manager.GetRunHistory(run.UUID, "", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStateTransition{
+		{RunUUID: run.UUID, State: "Running", TimeInSec: 10},
+		{RunUUID: run.UUID, NodeID: "node1", NodeName: "train", State: "Running", TimeInSec: 10},
+		{RunUUID: run.UUID, NodeID: "node1", Sequence: 1, NodeName: "train", State: "Failed", TimeInSec: 25,
+			Message: "OOMKilled"},
+		{RunUUID: run.UUID, Sequence: 1, State: "Failed", TimeInSec: 30, Message: "child 'node1' failed"},
+	}, history)
+
+	// The history as of a time ends with the states at that time.
+	history, err = manager.GetRunHistory(run.UUID, "node1", 20)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStateTransition{
+		{RunUUID: run.UUID, NodeID: "node1", NodeName: "train", State: "Running", TimeInSec: 10},
+	}, history)
+}
+
+func TestReportWorkflowResource_RecordsRunHistoryOfDelayedReport(t *testing.T) {
+	store, manager, run := initWithOneTimeRun(t)
+	defer store.Close()
+	workflow := &v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: run.Name, UID: types.UID(run.UUID)},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.NodeRunning,
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {ID: "node1", DisplayName: "train", Phase: v1alpha1.NodePending},
+			},
+		},
+	}
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+	// The node finished before the first report was processed.
+	workflow.Status.Nodes["node1"] = v1alpha1.NodeStatus{ID: "node1", DisplayName: "train",
+		Phase: v1alpha1.NodeSucceeded, FinishedAt: v1.NewTime(time.Unix(0, 0))}
+	assert.Nil(t, manager.ReportWorkflowResource(util.NewWorkflow(workflow)))
+
+	history, err := manager.GetRunHistory(run.UUID, "node1", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(history))
+	assert.Equal(t, "Pending", history[0].State)
+	assert.Equal(t, "Succeeded", history[1].State)
+	assert.Equal(t, history[0].TimeInSec, history[1].TimeInSec)
+
+	// The state as of the time of the report is the last one.
+	history, err = manager.GetRunHistory(run.UUID, "node1", history[1].TimeInSec)
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", history[len(history)-1].State)
+}
+
+func TestGetRunHistory_RunNotFound(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+
+	_, err := manager.GetRunHistory("run1", "", 0)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
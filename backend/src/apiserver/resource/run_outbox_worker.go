@@ -0,0 +1,57 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RunOutboxWorker completes the creation of the runs interrupted after their intent was
+// stored. Only the entries older than the grace period are reconciled, so that the
+// requests still creating their runs aren't raced.
+type RunOutboxWorker struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+	gracePeriod     time.Duration
+	maxAttempts     int
+}
+
+func NewRunOutboxWorker(resourceManager *ResourceManager, interval time.Duration, gracePeriod time.Duration,
+	maxAttempts int) *RunOutboxWorker {
+	return &RunOutboxWorker{
+		resourceManager: resourceManager,
+		interval:        interval,
+		gracePeriod:     gracePeriod,
+		maxAttempts:     maxAttempts,
+	}
+}
+
+// Run reconciles the run outbox every interval until stopCh is closed.
+func (w *RunOutboxWorker) Run(stopCh <-chan struct{}) {
+	glog.Infof("Reconciling the run outbox every %v", w.interval)
+	wait.Until(func() {
+		if err := w.Reconcile(); err != nil {
+			glog.Errorf("Failed to reconcile the run outbox: %+v", err)
+		}
+	}, w.interval, stopCh)
+}
+
+func (w *RunOutboxWorker) Reconcile() error {
+	createdBefore := w.resourceManager.time.Now().Add(-w.gracePeriod)
+	return w.resourceManager.ReconcileRunOutbox(createdBefore.Unix(), w.maxAttempts)
+}
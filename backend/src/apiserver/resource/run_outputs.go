@@ -0,0 +1,116 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The outputs of the UI metadata returned by GetRunOutputs, whose content is text to display.
+const (
+	runOutputTypeMarkdown   = "markdown"
+	runOutputTypeWebApp     = "web-app"
+	uiMetadataStorageInline = "inline"
+)
+
+// RunOutput is a summary a step of a run outputs for display, e.g. the markdown of its UI
+// metadata.
+type RunOutput struct {
+	NodeID string
+	// "markdown" or "web-app".
+	Type string
+	// The URI the content is read from, or empty if it's inline.
+	Source    string
+	Content   string
+	Truncated bool
+	// Why the content couldn't be read, if it couldn't.
+	Error string
+}
+
+// uiMetadata is the content of the UI metadata artifact of a step, as read by the frontend.
+type uiMetadata struct {
+	Outputs []struct {
+		Type    string `json:"type"`
+		Storage string `json:"storage"`
+		Source  string `json:"source"`
+	} `json:"outputs"`
+}
+
+// parseUIMetadataOutputs returns the markdown and the web apps of the archived UI metadata
+// of a step. The content of the outputs which aren't inline is left to read from their source.
+func parseUIMetadataOutputs(nodeID string, archive []byte) ([]*RunOutput, error) {
+	files, err := util.ExtractTgz(string(archive))
+	if err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "The UI metadata isn't a tar.gz archive")
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var outputs []*RunOutput
+	for _, name := range names {
+		var metadata uiMetadata
+		if err := json.Unmarshal([]byte(files[name]), &metadata); err != nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err,
+				fmt.Sprintf("Failed to parse the UI metadata file %v", name))
+		}
+		for _, output := range metadata.Outputs {
+			if output.Type != runOutputTypeMarkdown && output.Type != runOutputTypeWebApp {
+				continue
+			}
+			runOutput := &RunOutput{NodeID: nodeID, Type: output.Type}
+			if output.Storage == uiMetadataStorageInline {
+				runOutput.Content = output.Source
+			} else {
+				runOutput.Source = output.Source
+			}
+			outputs = append(outputs, runOutput)
+		}
+	}
+	return outputs, nil
+}
+
+// objectStoreKey returns the key of the object at a minio:// or s3:// URI in a bucket, and
+// false if the URI is elsewhere.
+func objectStoreKey(uri string, bucket string) (string, bool) {
+	parsed, err := url.Parse(uri)
+	if err != nil || (parsed.Scheme != "minio" && parsed.Scheme != "s3") || parsed.Host != bucket ||
+		strings.Trim(parsed.Path, "/") == "" {
+		return "", false
+	}
+	return strings.TrimPrefix(parsed.Path, "/"), true
+}
+
+// truncate cuts the content of an output at a number of bytes, on a character boundary. The
+// content isn't cut if the size isn't positive.
+func (o *RunOutput) truncate(maxSize int) {
+	if maxSize <= 0 || len(o.Content) <= maxSize {
+		return
+	}
+	end := maxSize
+	for end > 0 && !utf8.RuneStart(o.Content[end]) {
+		end--
+	}
+	o.Content = o.Content[:end]
+	o.Truncated = true
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUIMetadataOutputs(t *testing.T) {
+	archive, err := util.ArchiveTgz(map[string]string{"mlpipeline-ui-metadata.json": `{"outputs": [
+		{"type": "markdown", "storage": "inline", "source": "# Accuracy"},
+		{"type": "table", "source": "minio://mlpipeline/table.csv"},
+		{"type": "web-app", "source": "minio://mlpipeline/report.html"}
+	]}`})
+	assert.Nil(t, err)
+	outputs, err := parseUIMetadataOutputs("node-1", []byte(archive))
+	assert.Nil(t, err)
+	assert.Equal(t, []*RunOutput{
+		{NodeID: "node-1", Type: "markdown", Content: "# Accuracy"},
+		{NodeID: "node-1", Type: "web-app", Source: "minio://mlpipeline/report.html"},
+	}, outputs)
+
+	_, err = parseUIMetadataOutputs("node-1", []byte("not an archive"))
+	assert.NotNil(t, err)
+	archive, err = util.ArchiveTgz(map[string]string{"mlpipeline-ui-metadata.json": "not JSON"})
+	assert.Nil(t, err)
+	_, err = parseUIMetadataOutputs("node-1", []byte(archive))
+	assert.NotNil(t, err)
+}
+
+func TestObjectStoreKey(t *testing.T) {
+	key, ok := objectStoreKey("minio://mlpipeline/runs/summary.md", "mlpipeline")
+	assert.True(t, ok)
+	assert.Equal(t, "runs/summary.md", key)
+	key, ok = objectStoreKey("s3://mlpipeline/summary.md", "mlpipeline")
+	assert.True(t, ok)
+	assert.Equal(t, "summary.md", key)
+
+	for _, uri := range []string{"gs://mlpipeline/summary.md", "minio://other/summary.md", "minio://mlpipeline/", "summary.md"} {
+		_, ok = objectStoreKey(uri, "mlpipeline")
+		assert.False(t, ok, uri)
+	}
+}
+
+func TestRunOutputTruncate(t *testing.T) {
+	output := &RunOutput{Content: "héllo"}
+	output.truncate(0)
+	assert.Equal(t, &RunOutput{Content: "héllo"}, output)
+	output.truncate(10)
+	assert.Equal(t, &RunOutput{Content: "héllo"}, output)
+	// The é takes 2 bytes, which aren't split.
+	output.truncate(2)
+	assert.Equal(t, &RunOutput{Content: "h", Truncated: true}, output)
+}
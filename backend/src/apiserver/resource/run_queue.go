@@ -0,0 +1,149 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// applyConcurrency queues the workflow of a new run of a pipeline which has its max number
+// of active runs, or rejects the run, as the pipeline declares. The limit is soft: runs
+// created at the same time may all start.
+func (r *ResourceManager) applyConcurrency(pipelineId string, workflow *util.Workflow) error {
+	if pipelineId == "" {
+		return nil
+	}
+	declaration, err := workflow.ConcurrencyDeclaration()
+	if err != nil || declaration == nil {
+		return err
+	}
+	unfinished, err := r.runStore.CountUnfinishedRunsOfPipeline(pipelineId)
+	if err != nil {
+		return util.Wrap(err, "Failed to count the active runs of the pipeline")
+	}
+	if unfinished < declaration.MaxActiveRuns {
+		return nil
+	}
+	if declaration.Policy == util.ConcurrencyPolicyReject {
+		return util.NewResourceExhaustedError("Pipeline %v allows %v active runs at most, and has %v",
+			pipelineId, declaration.MaxActiveRuns, unfinished)
+	}
+	workflow.Queue()
+	return nil
+}
+
+// ReconcileQueuedRuns starts the queued runs of each pipeline, the oldest first, as long as
+// fewer runs of the pipeline than it allows are active.
+func (r *ResourceManager) ReconcileQueuedRuns() error {
+	runs, err := r.runStore.ListQueuedRuns()
+	if err != nil {
+		return util.Wrap(err, "Failed to list the queued runs")
+	}
+	var pipelineIds []string
+	queued := make(map[string][]model.RunDetail)
+	for _, run := range runs {
+		if _, ok := queued[run.PipelineId]; !ok {
+			pipelineIds = append(pipelineIds, run.PipelineId)
+		}
+		queued[run.PipelineId] = append(queued[run.PipelineId], run)
+	}
+	var failed []string
+	for _, pipelineId := range pipelineIds {
+		if err := r.startQueuedRuns(pipelineId, queued[pipelineId]); err != nil {
+			glog.Errorf("Failed to start the queued runs of pipeline %v: %+v", pipelineId, err)
+			failed = append(failed, pipelineId)
+		}
+	}
+	if len(failed) > 0 {
+		return util.NewInternalServerError(fmt.Errorf("failed pipelines: %v", failed),
+			"Failed to start the queued runs of %v pipelines", len(failed))
+	}
+	return nil
+}
+
+// startQueuedRuns starts the queued runs of a pipeline as its max number of active runs and
+// the GPUs left by the quotas allow. The max is the one declared when the oldest queued run
+// was created.
+func (r *ResourceManager) startQueuedRuns(pipelineId string, runs []model.RunDetail) error {
+	var workflow util.Workflow
+	if err := json.Unmarshal([]byte(runs[0].WorkflowSpecManifest), &workflow); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal the workflow of run %v", runs[0].UUID)
+	}
+	declaration, err := workflow.ConcurrencyDeclaration()
+	if err != nil {
+		return err
+	}
+	unfinished, err := r.runStore.CountUnfinishedRunsOfPipeline(pipelineId)
+	if err != nil {
+		return err
+	}
+	active := unfinished - len(runs)
+	for _, run := range runs {
+		if declaration != nil && active >= declaration.MaxActiveRuns {
+			return nil
+		}
+		fits, err := r.queuedRunFitsGPUQuota(&run)
+		if util.IsUserErrorCodeMatch(err, codes.ResourceExhausted) {
+			// The run is left queued for its owner to terminate, without holding the others.
+			glog.Warningf("Queued run %v can never start: %v", run.UUID, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !fits {
+			return nil
+		}
+		started, err := r.startQueuedRun(&run)
+		if err != nil {
+			return err
+		}
+		if started {
+			active++
+		}
+	}
+	return nil
+}
+
+// startQueuedRun resumes the workflow of a queued run and stores its status. A run whose
+// workflow was deleted is left to the reconciliation of the orphaned runs.
+func (r *ResourceManager) startQueuedRun(run *model.RunDetail) (bool, error) {
+	runEngine, err := r.runEngine(&run.Run)
+	if err != nil {
+		return false, err
+	}
+	if err := runEngine.Resume(run.Name); err != nil {
+		if apierrors.IsNotFound(err) {
+			glog.Warningf("The workflow %v of queued run %v wasn't found", run.Name, run.UUID)
+			return false, nil
+		}
+		return false, util.NewInternalServerError(err, "Failed to resume the workflow of run %v", run.UUID)
+	}
+	workflow, err := runEngine.Get(run.Name)
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to get the workflow of run %v", run.UUID)
+	}
+	if err := r.runStore.UpdateRun(run.UUID, workflow.Condition(), workflow.ToStringForStore()); err != nil {
+		return false, util.Wrap(err, "Failed to update the run of the resumed workflow")
+	}
+	return true, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func initWithConcurrency(t *testing.T, concurrency string) (*FakeClientManager, *ResourceManager, string) {
+	store, err := NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(&uidWorkflowClient{FakeWorkflowClient: store.workflowClientFake},
+		store.podClientFake)
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		TypeMeta: v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "workflow-name",
+			Annotations: map[string]string{util.AnnotationKeyWorkflowConcurrency: concurrency},
+		},
+	})
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(workflow.ToStringForStore()))
+	assert.Nil(t, err)
+	return store, manager, pipeline.UUID
+}
+
+func createRunOfPipeline(manager *ResourceManager, pipelineId string) (*model.RunDetail, error) {
+	return manager.CreateRun(&api.Run{Name: "run", PipelineSpec: &api.PipelineSpec{PipelineId: pipelineId}}, "")
+}
+
+func TestCreateRun_Queued(t *testing.T) {
+	store, manager, pipelineId := initWithConcurrency(t, `{"maxActiveRuns": 1}`)
+	defer store.Close()
+
+	run, err := createRunOfPipeline(manager, pipelineId)
+	assert.Nil(t, err)
+	assert.NotEqual(t, util.RunConditionQueued, run.Conditions)
+	run, err = createRunOfPipeline(manager, pipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, util.RunConditionQueued, run.Conditions)
+	workflow, err := store.workflowClientFake.Get(run.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.True(t, *workflow.Spec.Suspend)
+}
+
+func TestCreateRun_Rejected(t *testing.T) {
+	store, manager, pipelineId := initWithConcurrency(t, `{"maxActiveRuns": 1, "policy": "reject"}`)
+	defer store.Close()
+
+	_, err := createRunOfPipeline(manager, pipelineId)
+	assert.Nil(t, err)
+	_, err = createRunOfPipeline(manager, pipelineId)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.ResourceExhausted))
+	assert.Contains(t, err.Error(), "allows 1 active runs at most")
+}
+
+func TestReconcileQueuedRuns(t *testing.T) {
+	store, manager, pipelineId := initWithConcurrency(t, `{"maxActiveRuns": 1}`)
+	defer store.Close()
+	var runs []*model.RunDetail
+	for i := 0; i < 3; i++ {
+		run, err := createRunOfPipeline(manager, pipelineId)
+		assert.Nil(t, err)
+		runs = append(runs, run)
+	}
+
+	// No run finished, so that no run is started.
+	assert.Nil(t, manager.ReconcileQueuedRuns())
+	run, err := manager.GetRun(runs[1].UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, util.RunConditionQueued, run.Conditions)
+
+	assert.Nil(t, store.RunStore().UpdateRun(runs[0].UUID, string(v1alpha1.NodeSucceeded), ""))
+	assert.Nil(t, manager.ReconcileQueuedRuns())
+	run, err = manager.GetRun(runs[1].UUID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, util.RunConditionQueued, run.Conditions)
+	workflow, err := store.workflowClientFake.Get(run.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, workflow.Spec.Suspend)
+	// The oldest queued run started only.
+	run, err = manager.GetRun(runs[2].UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, util.RunConditionQueued, run.Conditions)
+}
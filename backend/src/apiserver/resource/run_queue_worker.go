@@ -0,0 +1,43 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RunQueueWorker starts the queued runs as the max number of active runs of their pipeline
+// allows.
+type RunQueueWorker struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+}
+
+func NewRunQueueWorker(resourceManager *ResourceManager, interval time.Duration) *RunQueueWorker {
+	return &RunQueueWorker{resourceManager: resourceManager, interval: interval}
+}
+
+// Run reconciles the queued runs every interval until stopCh is closed.
+func (w *RunQueueWorker) Run(stopCh <-chan struct{}) {
+	glog.Infof("Reconciling the queued runs every %v", w.interval)
+	wait.Until(func() {
+		if err := w.resourceManager.ReconcileQueuedRuns(); err != nil {
+			glog.Errorf("Failed to reconcile the queued runs: %+v", err)
+		}
+	}, w.interval, stopCh)
+}
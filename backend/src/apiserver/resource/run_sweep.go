@@ -0,0 +1,295 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+)
+
+// The maximum number of trials, i.e. of runs, of a sweep.
+const maxSweepTrials = 100
+
+// RunSweepDetail is a sweep with its trials.
+type RunSweepDetail struct {
+	*model.RunSweep
+	Trials []*RunSweepTrialDetail
+}
+
+// RunSweepTrialDetail is a trial of a sweep with its run, nil until it's created or once
+// it's deleted.
+type RunSweepTrialDetail struct {
+	*model.RunSweepTrial
+	Run *model.RunDetail
+}
+
+// CreateRunSweep stores a sweep with one trial per combination of the values of its
+// parameters. The runs of the trials are created by ReconcileRunSweeps.
+func (r *ResourceManager) CreateRunSweep(apiSweep *api.RunSweep) (*model.RunSweep, error) {
+	combinations, err := sweepCombinations(apiSweep.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+	// The runs would fail to be created in the background otherwise.
+	workflowSpecManifestBytes, err := r.getWorkflowSpecBytes(apiSweep.GetRun().GetPipelineSpec())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to fetch workflow spec.")
+	}
+	var workflow util.Workflow
+	if err := json.Unmarshal(workflowSpecManifestBytes, &workflow); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to unmarshal workflow spec manifest.")
+	}
+	declared := make(map[string]bool)
+	for _, parameter := range workflow.Spec.Arguments.Parameters {
+		declared[parameter.Name] = true
+	}
+	for _, parameter := range apiSweep.GetParameters() {
+		if !declared[parameter.GetName()] {
+			return nil, util.NewInvalidInputError("The parameter %v isn't a parameter of the pipeline",
+				parameter.GetName())
+		}
+	}
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate the sweep ID")
+	}
+	run, err := (&jsonpb.Marshaler{}).MarshalToString(apiSweep.GetRun())
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal the run of the sweep")
+	}
+	sweep := &model.RunSweep{
+		UUID:           uuid.String(),
+		Name:           apiSweep.GetName(),
+		CreatedAtInSec: r.time.Now().Unix(),
+		Run:            run,
+		MaxConcurrency: int(apiSweep.GetMaxConcurrency()),
+	}
+	trials := make([]*model.RunSweepTrial, 0, len(combinations))
+	for index, parameters := range combinations {
+		parametersBytes, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to marshal the parameters of the sweep")
+		}
+		trials = append(trials, &model.RunSweepTrial{
+			SweepUUID: sweep.UUID, TrialIndex: index, Parameters: string(parametersBytes)})
+	}
+	if err := r.runSweepStore.CreateSweep(sweep, trials); err != nil {
+		return nil, util.Wrap(err, "Failed to create the sweep")
+	}
+	return sweep, nil
+}
+
+// GetRunSweep returns a sweep with its trials and their runs.
+func (r *ResourceManager) GetRunSweep(sweepId string) (*RunSweepDetail, error) {
+	sweep, err := r.runSweepStore.GetSweep(sweepId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the sweep")
+	}
+	trials, err := r.runSweepStore.ListTrials(sweepId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the sweep")
+	}
+	detail := &RunSweepDetail{RunSweep: sweep}
+	for _, trial := range trials {
+		run, err := r.getSweepRun(trial)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to get the sweep")
+		}
+		detail.Trials = append(detail.Trials, &RunSweepTrialDetail{RunSweepTrial: trial, Run: run})
+	}
+	return detail, nil
+}
+
+// getSweepRun returns the run of a trial, or nil if it isn't created yet or was deleted.
+func (r *ResourceManager) getSweepRun(trial *model.RunSweepTrial) (*model.RunDetail, error) {
+	if trial.RunUUID == "" {
+		return nil, nil
+	}
+	run, err := r.runStore.GetRun(trial.RunUUID)
+	if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		return nil, nil
+	}
+	return run, err
+}
+
+// ReconcileRunSweeps creates the runs of the trials of the sweeps which aren't created yet, as
+// long as fewer runs than the max concurrency of their sweep are running.
+func (r *ResourceManager) ReconcileRunSweeps() error {
+	sweeps, err := r.runSweepStore.ListPendingSweeps()
+	if err != nil {
+		return util.Wrap(err, "Failed to list the pending sweeps")
+	}
+	var failed []string
+	for _, sweep := range sweeps {
+		if err := r.reconcileRunSweep(sweep); err != nil {
+			glog.Errorf("Failed to create the runs of sweep %v: %+v", sweep.UUID, err)
+			failed = append(failed, sweep.UUID)
+		}
+	}
+	if len(failed) > 0 {
+		return util.NewInternalServerError(fmt.Errorf("failed sweeps: %v", failed),
+			"Failed to create the runs of %v sweeps", len(failed))
+	}
+	return nil
+}
+
+func (r *ResourceManager) reconcileRunSweep(sweep *model.RunSweep) error {
+	trials, err := r.runSweepStore.ListTrials(sweep.UUID)
+	if err != nil {
+		return err
+	}
+	running := 0
+	var pending []*model.RunSweepTrial
+	for _, trial := range trials {
+		if trial.RunUUID == "" {
+			pending = append(pending, trial)
+			continue
+		}
+		run, err := r.getSweepRun(trial)
+		if err != nil {
+			return err
+		}
+		if run != nil && !util.IsFinalCondition(run.Conditions) {
+			running++
+		}
+	}
+	var apiRun api.Run
+	if err := jsonpb.UnmarshalString(sweep.Run, &apiRun); err != nil {
+		return util.NewInternalServerError(err, "Failed to unmarshal the run of sweep %v", sweep.UUID)
+	}
+	for _, trial := range pending {
+		if sweep.MaxConcurrency > 0 && running >= sweep.MaxConcurrency {
+			return nil
+		}
+		var parameters []*api.Parameter
+		if err := json.Unmarshal([]byte(trial.Parameters), &parameters); err != nil {
+			return util.NewInternalServerError(err, "Failed to unmarshal the parameters of trial %v of sweep %v",
+				trial.TrialIndex, sweep.UUID)
+		}
+		trialRun := proto.Clone(&apiRun).(*api.Run)
+		trialRun.Name = fmt.Sprintf("%v-%v", sweep.Name, trial.TrialIndex)
+		if trialRun.PipelineSpec == nil {
+			trialRun.PipelineSpec = &api.PipelineSpec{}
+		}
+		trialRun.PipelineSpec.Parameters = overrideParameters(trialRun.PipelineSpec.Parameters, parameters)
+		run, err := r.CreateRun(trialRun, "")
+		if err != nil {
+			return util.Wrapf(err, "Failed to create the run of trial %v", trial.TrialIndex)
+		}
+		if err := r.runSweepStore.SetTrialRun(sweep.UUID, trial.TrialIndex, run.UUID); err != nil {
+			return err
+		}
+		running++
+	}
+	return nil
+}
+
+// overrideParameters returns the parameters with the values of the overrides, which are
+// appended if they're not set.
+func overrideParameters(parameters []*api.Parameter, overrides []*api.Parameter) []*api.Parameter {
+	result := make([]*api.Parameter, 0, len(parameters)+len(overrides))
+	overridden := make(map[string]bool)
+	for _, parameter := range parameters {
+		for _, override := range overrides {
+			if override.Name == parameter.Name {
+				parameter = override
+				overridden[override.Name] = true
+			}
+		}
+		result = append(result, parameter)
+	}
+	for _, override := range overrides {
+		if !overridden[override.Name] {
+			result = append(result, override)
+		}
+	}
+	return result
+}
+
+// sweepCombinations returns the combinations of the values of the parameters of a sweep, the
+// values of the first parameter varying the slowest.
+func sweepCombinations(parameters []*api.SweepParameter) ([][]*api.Parameter, error) {
+	if len(parameters) == 0 {
+		return nil, util.NewInvalidInputError("The sweep has no parameter")
+	}
+	combinations := [][]*api.Parameter{{}}
+	names := make(map[string]bool)
+	for _, parameter := range parameters {
+		if parameter.GetName() == "" {
+			return nil, util.NewInvalidInputError("A parameter of the sweep has no name")
+		}
+		if names[parameter.GetName()] {
+			return nil, util.NewInvalidInputError("The parameter %v is swept twice", parameter.GetName())
+		}
+		names[parameter.GetName()] = true
+		values, err := sweepValues(parameter)
+		if err != nil {
+			return nil, err
+		}
+		if len(combinations)*len(values) > maxSweepTrials {
+			return nil, util.NewInvalidInputError("The sweep has more than %v combinations of parameters",
+				maxSweepTrials)
+		}
+		next := make([][]*api.Parameter, 0, len(combinations)*len(values))
+		for _, combination := range combinations {
+			for _, value := range values {
+				extended := append(append([]*api.Parameter{}, combination...),
+					&api.Parameter{Name: parameter.GetName(), Value: value})
+				next = append(next, extended)
+			}
+		}
+		combinations = next
+	}
+	return combinations, nil
+}
+
+// sweepValues returns the values of a parameter of a sweep, listed or in a range.
+func sweepValues(parameter *api.SweepParameter) ([]string, error) {
+	valueRange := parameter.GetRange()
+	if (len(parameter.GetValues()) == 0) == (valueRange == nil) {
+		return nil, util.NewInvalidInputError("Exactly one of the values and the range of the parameter %v must be set",
+			parameter.GetName())
+	}
+	if valueRange == nil {
+		return parameter.GetValues(), nil
+	}
+	if valueRange.GetStep() <= 0 || valueRange.GetStop() < valueRange.GetStart() {
+		return nil, util.NewInvalidInputError(
+			"The range of the parameter %v must have a positive step and a stop not below its start",
+			parameter.GetName())
+	}
+	// The values are computed from the start, so that the errors of the steps don't add up.
+	count := math.Floor((valueRange.GetStop()-valueRange.GetStart())/valueRange.GetStep()+1e-9) + 1
+	if count > maxSweepTrials {
+		return nil, util.NewInvalidInputError("The range of the parameter %v has more than %v values",
+			parameter.GetName(), maxSweepTrials)
+	}
+	values := make([]string, 0, int(count))
+	for i := 0; i < int(count); i++ {
+		value := valueRange.GetStart() + float64(i)*valueRange.GetStep()
+		values = append(values, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return values, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/engine"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var testSweptWorkflow = util.NewWorkflow(&v1alpha1.Workflow{
+	TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+	ObjectMeta: v1.ObjectMeta{Name: "workflow-name"},
+	Spec: v1alpha1.WorkflowSpec{Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{
+		{Name: "epochs"}, {Name: "optimizer"}, {Name: "rate"}}}},
+})
+
+// uidWorkflowClient sets distinct names and UIDs on the workflows it creates, as the
+// Kubernetes API does, so that the names and the IDs of their runs are distinct.
+type uidWorkflowClient struct {
+	*storage.FakeWorkflowClient
+	created int
+}
+
+func (c *uidWorkflowClient) Create(workflow *v1alpha1.Workflow) (*v1alpha1.Workflow, error) {
+	c.created++
+	workflow.Name = fmt.Sprintf("workflow%v", c.created)
+	workflow.UID = types.UID(fmt.Sprintf("workflow%v", c.created))
+	return c.FakeWorkflowClient.Create(workflow)
+}
+
+func initWithSweep(t *testing.T, maxConcurrency int32) (*FakeClientManager, *ResourceManager, *model.RunSweep) {
+	store, err := NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	manager := NewResourceManager(store)
+	manager.engine = engine.NewArgoEngine(&uidWorkflowClient{FakeWorkflowClient: store.workflowClientFake},
+		store.podClientFake)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testSweptWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	sweep, err := manager.CreateRunSweep(&api.RunSweep{
+		Name: "sweep",
+		Run: &api.Run{PipelineSpec: &api.PipelineSpec{
+			PipelineId: pipeline.UUID,
+			Parameters: []*api.Parameter{{Name: "epochs", Value: "10"}, {Name: "optimizer", Value: "adam"}},
+		}},
+		Parameters: []*api.SweepParameter{
+			{Name: "optimizer", Values: []string{"adam", "sgd"}},
+			{Name: "rate", Range: &api.SweepParameter_Range{Start: 0.1, Stop: 0.3, Step: 0.1}},
+		},
+		MaxConcurrency: maxConcurrency,
+	})
+	assert.Nil(t, err)
+	return store, manager, sweep
+}
+
+func TestSweepCombinations(t *testing.T) {
+	combinations, err := sweepCombinations([]*api.SweepParameter{
+		{Name: "optimizer", Values: []string{"adam", "sgd"}},
+		{Name: "rate", Range: &api.SweepParameter_Range{Start: 0.1, Stop: 0.3, Step: 0.1}},
+	})
+	assert.Nil(t, err)
+	var values [][]string
+	for _, combination := range combinations {
+		var combinationValues []string
+		for _, parameter := range combination {
+			combinationValues = append(combinationValues, parameter.Name+"="+parameter.Value)
+		}
+		values = append(values, combinationValues)
+	}
+	assert.Equal(t, [][]string{
+		{"optimizer=adam", "rate=0.1"}, {"optimizer=adam", "rate=0.2"}, {"optimizer=adam", "rate=0.30000000000000004"},
+		{"optimizer=sgd", "rate=0.1"}, {"optimizer=sgd", "rate=0.2"}, {"optimizer=sgd", "rate=0.30000000000000004"},
+	}, values)
+}
+
+func TestSweepCombinations_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters []*api.SweepParameter
+		message    string
+	}{
+		{"no parameter", nil, "The sweep has no parameter"},
+		{"no name", []*api.SweepParameter{{Values: []string{"a"}}}, "A parameter of the sweep has no name"},
+		{"swept twice", []*api.SweepParameter{{Name: "a", Values: []string{"1"}}, {Name: "a", Values: []string{"2"}}},
+			"The parameter a is swept twice"},
+		{"values and range", []*api.SweepParameter{{Name: "a", Values: []string{"1"},
+			Range: &api.SweepParameter_Range{Start: 1, Stop: 2, Step: 1}}},
+			"Exactly one of the values and the range of the parameter a must be set"},
+		{"no value", []*api.SweepParameter{{Name: "a"}},
+			"Exactly one of the values and the range of the parameter a must be set"},
+		{"invalid range", []*api.SweepParameter{{Name: "a", Range: &api.SweepParameter_Range{Start: 2, Stop: 1, Step: 1}}},
+			"The range of the parameter a must have a positive step"},
+		{"long range", []*api.SweepParameter{{Name: "a", Range: &api.SweepParameter_Range{Start: 0, Stop: 1000, Step: 1}}},
+			"The range of the parameter a has more than 100 values"},
+		{"too many combinations", []*api.SweepParameter{
+			{Name: "a", Range: &api.SweepParameter_Range{Start: 0, Stop: 10, Step: 1}},
+			{Name: "b", Range: &api.SweepParameter_Range{Start: 0, Stop: 10, Step: 1}}},
+			"The sweep has more than 100 combinations of parameters"},
+	}
+	for _, test := range tests {
+		_, err := sweepCombinations(test.parameters)
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument), test.name)
+		assert.Contains(t, err.(*util.UserError).ExternalMessage(), test.message, test.name)
+	}
+}
+
+func TestCreateRunSweep_UndeclaredParameter(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testSweptWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	_, err = manager.CreateRunSweep(&api.RunSweep{
+		Name:       "sweep",
+		Run:        &api.Run{PipelineSpec: &api.PipelineSpec{PipelineId: pipeline.UUID}},
+		Parameters: []*api.SweepParameter{{Name: "batch-size", Values: []string{"32", "64"}}},
+	})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "The parameter batch-size isn't a parameter of the pipeline")
+}
+
+func TestReconcileRunSweeps(t *testing.T) {
+	store, manager, sweep := initWithSweep(t, 0)
+	defer store.Close()
+
+	detail, err := manager.GetRunSweep(sweep.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(detail.Trials))
+	for _, trial := range detail.Trials {
+		assert.Nil(t, trial.Run)
+	}
+
+	assert.Nil(t, manager.ReconcileRunSweeps())
+	detail, err = manager.GetRunSweep(sweep.UUID)
+	assert.Nil(t, err)
+	for index, trial := range detail.Trials {
+		assert.NotNil(t, trial.Run)
+		assert.Equal(t, fmt.Sprintf("sweep-%v", index), trial.Run.DisplayName)
+	}
+	// The swept parameters override the parameters of the run, the others are kept.
+	assert.Equal(t, `[{"name":"epochs","value":"10"},{"name":"optimizer","value":"sgd"},{"name":"rate","value":"0.2"}]`,
+		detail.Trials[4].Run.Parameters)
+
+	// The sweep is complete, so that it isn't reconciled anymore.
+	pending, err := store.RunSweepStore().ListPendingSweeps()
+	assert.Nil(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestReconcileRunSweeps_MaxConcurrency(t *testing.T) {
+	store, manager, sweep := initWithSweep(t, 2)
+	defer store.Close()
+
+	assert.Nil(t, manager.ReconcileRunSweeps())
+	detail, err := manager.GetRunSweep(sweep.UUID)
+	assert.Nil(t, err)
+	assert.NotNil(t, detail.Trials[0].Run)
+	assert.NotNil(t, detail.Trials[1].Run)
+	assert.Nil(t, detail.Trials[2].Run)
+
+	// No run finished, so that no run is created.
+	assert.Nil(t, manager.ReconcileRunSweeps())
+	detail, err = manager.GetRunSweep(sweep.UUID)
+	assert.Nil(t, err)
+	assert.Nil(t, detail.Trials[2].Run)
+
+	assert.Nil(t, store.RunStore().UpdateRun(detail.Trials[0].Run.UUID, string(v1alpha1.NodeSucceeded), ""))
+	assert.Nil(t, manager.ReconcileRunSweeps())
+	detail, err = manager.GetRunSweep(sweep.UUID)
+	assert.Nil(t, err)
+	assert.NotNil(t, detail.Trials[2].Run)
+	assert.Nil(t, detail.Trials[3].Run)
+}
+
+func TestGetRunSweep_NotFound(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+	_, err := manager.GetRunSweep("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
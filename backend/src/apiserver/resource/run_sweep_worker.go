@@ -0,0 +1,42 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RunSweepWorker creates the runs of the sweeps as their max concurrency allows.
+type RunSweepWorker struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+}
+
+func NewRunSweepWorker(resourceManager *ResourceManager, interval time.Duration) *RunSweepWorker {
+	return &RunSweepWorker{resourceManager: resourceManager, interval: interval}
+}
+
+// Run reconciles the sweeps every interval until stopCh is closed.
+func (w *RunSweepWorker) Run(stopCh <-chan struct{}) {
+	glog.Infof("Reconciling the run sweeps every %v", w.interval)
+	wait.Until(func() {
+		if err := w.resourceManager.ReconcileRunSweeps(); err != nil {
+			glog.Errorf("Failed to reconcile the run sweeps: %+v", err)
+		}
+	}, w.interval, stopCh)
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import "sync"
+
+// RunWatcher notifies the API server requests watching a run that its state changed.
+// It only sees the changes reported to this replica of the API server.
+type RunWatcher struct {
+	mutex    sync.Mutex
+	watchers map[string]map[chan struct{}]bool
+}
+
+func NewRunWatcher() *RunWatcher {
+	return &RunWatcher{watchers: make(map[string]map[chan struct{}]bool)}
+}
+
+// Watch returns a channel receiving a value when the state of the run changes, and a
+// function to stop watching. Consecutive changes may be coalesced into a single value.
+func (w *RunWatcher) Watch(runId string) (<-chan struct{}, func()) {
+	changes := make(chan struct{}, 1)
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.watchers[runId] == nil {
+		w.watchers[runId] = make(map[chan struct{}]bool)
+	}
+	w.watchers[runId][changes] = true
+	return changes, func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		delete(w.watchers[runId], changes)
+		if len(w.watchers[runId]) == 0 {
+			delete(w.watchers, runId)
+		}
+	}
+}
+
+// Notify notifies the watchers of the run that its state changed. It never blocks.
+func (w *RunWatcher) Notify(runId string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for changes := range w.watchers[runId] {
+		select {
+		case changes <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWatcher(t *testing.T) {
+	watcher := NewRunWatcher()
+	changes, stop := watcher.Watch("run1")
+	otherChanges, stopOther := watcher.Watch("run2")
+	defer stopOther()
+
+	watcher.Notify("run1")
+	// Doesn't block while the previous change isn't received.
+	watcher.Notify("run1")
+	assert.Len(t, changes, 1)
+	assert.Len(t, otherChanges, 0)
+
+	<-changes
+	stop()
+	watcher.Notify("run1")
+	assert.Len(t, changes, 0)
+	assert.NotContains(t, watcher.watchers, "run1")
+}
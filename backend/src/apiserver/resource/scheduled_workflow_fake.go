@@ -48,7 +48,7 @@ func (c *FakeScheduledWorkflowClient) Delete(name string, options *v1.DeleteOpti
 }
 
 func (c *FakeScheduledWorkflowClient) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ScheduledWorkflow, err error) {
-	return nil, nil
+	return c.workflows[name], nil
 }
 
 func (c *FakeScheduledWorkflowClient) Get(name string, options v1.GetOptions) (*v1alpha1.ScheduledWorkflow, error) {
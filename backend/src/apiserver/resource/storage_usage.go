@@ -0,0 +1,221 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// StorageUsageCategory is the kind of data a storage usage accounts for.
+type StorageUsageCategory string
+
+const (
+	// The packages of the pipelines and their compiled workflows, in the object store.
+	StorageUsagePipelinePackages StorageUsageCategory = "PIPELINE_PACKAGES"
+	// The pipeline specs and the workflows of the runs, in the database.
+	StorageUsageRunManifests StorageUsageCategory = "RUN_MANIFESTS"
+	// The output artifacts of the steps of the runs, in the object store.
+	StorageUsageArtifacts StorageUsageCategory = "ARTIFACTS"
+	// The archived logs of the steps of the runs, in the object store.
+	StorageUsageLogs StorageUsageCategory = "LOGS"
+)
+
+var storageUsageGauge = metrics.NewGaugeVec("storage_usage_bytes",
+	"The bytes stored per namespace and category, as of the last storage usage computation.", "namespace", "category")
+
+func init() {
+	metrics.MustRegister(storageUsageGauge)
+}
+
+// StorageUsage is the bytes of a category of data stored for a namespace and an experiment.
+// The pipelines are shared, so that their usage has no namespace nor experiment.
+type StorageUsage struct {
+	Namespace    string               `json:"namespace"`
+	ExperimentId string               `json:"experimentId"`
+	Category     StorageUsageCategory `json:"category"`
+	Bytes        int64                `json:"bytes"`
+}
+
+// StorageUsageReport lists the usages computed at a time, by namespace, experiment and category.
+type StorageUsageReport struct {
+	ComputedAtInSec int64           `json:"computedAtInSec"`
+	Usages          []*StorageUsage `json:"usages"`
+}
+
+// StorageUsageCollector accounts the bytes stored per namespace and experiment, e.g. for
+// chargeback or quotas. The manifests of the runs are measured in the database. The objects
+// of the pipelines, the archived logs of the runs and the output artifacts of the runs are
+// measured by listing the object store once; the artifacts are matched to their runs by the
+// artifact lineage, and only the ones stored in the bucket of the API server are accounted.
+// The objects of the runs which don't exist anymore aren't accounted, since they're reported
+// by the consistency checker. The report of the last computation is stored in the object
+// store, so that it is shared by the replicas of the API server.
+type StorageUsageCollector struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+	// Serializes the computations, so that the stored report is the one of the last of them.
+	mutex sync.Mutex
+	// The namespaces and categories of the gauge set by the last computation, so that the
+	// ones without usage anymore are reset.
+	gaugeKeys map[storageUsageKey]bool
+}
+
+func NewStorageUsageCollector(resourceManager *ResourceManager, interval time.Duration) *StorageUsageCollector {
+	return &StorageUsageCollector{resourceManager: resourceManager, interval: interval}
+}
+
+// Run computes the storage usage every interval until stopCh is closed.
+func (c *StorageUsageCollector) Run(stopCh <-chan struct{}) {
+	glog.Infof("Computing the storage usage every %v", c.interval)
+	wait.Until(func() {
+		if _, err := c.Compute(); err != nil {
+			glog.Errorf("Failed to compute the storage usage: %+v", err)
+		}
+	}, c.interval, stopCh)
+}
+
+type storageUsageKey struct {
+	namespace    string
+	experimentId string
+	category     StorageUsageCategory
+}
+
+// Compute measures the storage usage and stores the report.
+func (c *StorageUsageCollector) Compute() (*StorageUsageReport, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	r := c.resourceManager
+	computedAtInSec := r.time.Now().Unix()
+	sizes, err := r.runStore.ListRunStorageSizes()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to compute the storage usage of the runs")
+	}
+	bytes := make(map[storageUsageKey]int64)
+	runs := make(map[string]*model.RunStorageSize)
+	for _, size := range sizes {
+		runs[size.RunUUID] = size
+		bytes[storageUsageKey{size.Namespace, size.ExperimentUUID, StorageUsageRunManifests}] += size.ManifestBytes
+	}
+	events, err := r.artifactLineageStore.ListArtifactEventsByType(model.ArtifactEventOutput)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to compute the storage usage of the artifacts")
+	}
+	// An artifact produced again, e.g. by a retry, is accounted to the run which produced it first.
+	artifactRuns := make(map[string]string)
+	for _, event := range events {
+		key, ok := objectStoreKey(event.ArtifactURI, r.objectStoreBucket)
+		if !ok {
+			continue
+		}
+		if _, found := artifactRuns[key]; !found {
+			artifactRuns[key] = event.RunUUID
+		}
+	}
+	files, err := r.objectStore.ListFiles("")
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to list the files of the object store")
+	}
+	for _, file := range files {
+		resourceType, id := storage.ParseResourcePath(file.Path)
+		switch {
+		case resourceType == common.Pipeline:
+			bytes[storageUsageKey{category: StorageUsagePipelinePackages}] += file.Size
+		case resourceType == common.Run:
+			if run, ok := runs[id]; ok {
+				bytes[storageUsageKey{run.Namespace, run.ExperimentUUID, StorageUsageLogs}] += file.Size
+			}
+		default:
+			if run, ok := runs[artifactRuns[file.Path]]; ok {
+				bytes[storageUsageKey{run.Namespace, run.ExperimentUUID, StorageUsageArtifacts}] += file.Size
+			}
+		}
+	}
+	report := &StorageUsageReport{ComputedAtInSec: computedAtInSec, Usages: []*StorageUsage{}}
+	for key, usageBytes := range bytes {
+		report.Usages = append(report.Usages, &StorageUsage{
+			Namespace:    key.namespace,
+			ExperimentId: key.experimentId,
+			Category:     key.category,
+			Bytes:        usageBytes,
+		})
+	}
+	sortStorageUsages(report.Usages)
+	namespaceBytes := make(map[storageUsageKey]int64)
+	for _, usage := range report.Usages {
+		namespaceBytes[storageUsageKey{namespace: usage.Namespace, category: usage.Category}] += usage.Bytes
+	}
+	for key := range c.gaugeKeys {
+		if _, ok := namespaceBytes[key]; !ok {
+			storageUsageGauge.Set(0, key.namespace, string(key.category))
+		}
+	}
+	c.gaugeKeys = make(map[storageUsageKey]bool)
+	for key, usageBytes := range namespaceBytes {
+		storageUsageGauge.Set(float64(usageBytes), key.namespace, string(key.category))
+		c.gaugeKeys[key] = true
+	}
+	if err := r.objectStore.AddAsYamlFile(report, storage.StorageUsageReportPath); err != nil {
+		return nil, util.Wrap(err, "Failed to store the storage usage report")
+	}
+	glog.Infof("Computed %v storage usages", len(report.Usages))
+	return report, nil
+}
+
+// LastReport returns the report of the last computation, of any replica, with only the
+// usages of a namespace and of an experiment, if set.
+func (c *StorageUsageCollector) LastReport(namespace string, experimentId string) (*StorageUsageReport, error) {
+	files, err := c.resourceManager.objectStore.ListFiles(storage.StorageUsageReportPath)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the storage usage report")
+	}
+	if len(files) == 0 {
+		return nil, util.NewResourceNotFoundError("StorageUsageReport", "last")
+	}
+	report := &StorageUsageReport{}
+	if err := c.resourceManager.objectStore.GetFromYamlFile(report, storage.StorageUsageReportPath); err != nil {
+		return nil, util.Wrap(err, "Failed to get the storage usage report")
+	}
+	usages := []*StorageUsage{}
+	for _, usage := range report.Usages {
+		if (namespace == "" || usage.Namespace == namespace) &&
+			(experimentId == "" || usage.ExperimentId == experimentId) {
+			usages = append(usages, usage)
+		}
+	}
+	report.Usages = usages
+	return report, nil
+}
+
+func sortStorageUsages(usages []*StorageUsage) {
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Namespace != usages[j].Namespace {
+			return usages[i].Namespace < usages[j].Namespace
+		}
+		if usages[i].ExperimentId != usages[j].ExperimentId {
+			return usages[i].ExperimentId < usages[j].ExperimentId
+		}
+		return usages[i].Category < usages[j].Category
+	})
+}
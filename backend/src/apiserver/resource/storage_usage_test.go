@@ -0,0 +1,75 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestStorageUsageCollector_Compute(t *testing.T) {
+	store, manager, runDetail := initWithOneTimeRun(t)
+	defer store.Close()
+	experimentId := runDetail.ResourceReferences[0].ReferenceUUID
+	sizes, err := store.RunStore().ListRunStorageSizes()
+	assert.Nil(t, err)
+	assert.Len(t, sizes, 1)
+	manifestBytes := sizes[0].ManifestBytes
+	assert.Nil(t, manager.objectStore.AddFile([]byte("package"), storage.CreatePipelinePath("p1")))
+	assert.Nil(t, manager.objectStore.AddFile([]byte("logs"), storage.CreateRunLogPath(runDetail.UUID, "node1")))
+	assert.Nil(t, manager.objectStore.AddFile([]byte("model"), "artifacts/model.tgz"))
+	// Neither the artifact of another bucket nor an unknown object are accounted.
+	assert.Nil(t, manager.objectStore.AddFile([]byte("unknown"), "artifacts/unknown.tgz"))
+	assert.Nil(t, store.ArtifactLineageStore().ReplaceArtifactEvents(runDetail.UUID, []*model.ArtifactEvent{
+		{RunUUID: runDetail.UUID, NodeID: "node1", ArtifactName: "model", Type: model.ArtifactEventOutput,
+			ArtifactURI: "s3://mlpipeline/artifacts/model.tgz"},
+		{RunUUID: runDetail.UUID, NodeID: "node1", ArtifactName: "data", Type: model.ArtifactEventOutput,
+			ArtifactURI: "s3://other/artifacts/unknown.tgz"},
+	}))
+	collector := NewStorageUsageCollector(manager, time.Hour)
+
+	_, err = collector.LastReport("", "")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	report, err := collector.Compute()
+	assert.Nil(t, err)
+	expected := &StorageUsageReport{
+		ComputedAtInSec: 3,
+		Usages: []*StorageUsage{
+			{Category: StorageUsagePipelinePackages, Bytes: 7},
+			{ExperimentId: experimentId, Category: StorageUsageArtifacts, Bytes: 5},
+			{ExperimentId: experimentId, Category: StorageUsageLogs, Bytes: 4},
+			{ExperimentId: experimentId, Category: StorageUsageRunManifests, Bytes: manifestBytes},
+		},
+	}
+	assert.Equal(t, expected, report)
+	assert.Equal(t, float64(7), storageUsageGauge.Value("", string(StorageUsagePipelinePackages)))
+	assert.Equal(t, float64(5), storageUsageGauge.Value("", string(StorageUsageArtifacts)))
+
+	lastReport, err := collector.LastReport("", "")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, lastReport)
+	lastReport, err = collector.LastReport("", experimentId)
+	assert.Nil(t, err)
+	assert.Equal(t, expected.Usages[1:], lastReport.Usages)
+	lastReport, err = collector.LastReport("ns1", "")
+	assert.Nil(t, err)
+	assert.Empty(t, lastReport.Usages)
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// TemplateCache is an LRU cache of the pipeline templates, so that the template of a pipeline
+// isn't fetched from the object store for every run and job created from it. The templates
+// expire after the TTL, and are removed when their pipeline is deleted. A template is also
+// reloaded when the version of its pipeline stored in the database changes, so that the
+// replicas not replacing the template don't serve the stale one.
+type TemplateCache struct {
+	// Nil if the templates aren't cached.
+	templates *lru.Cache
+	ttl       time.Duration
+	time      util.TimeInterface
+}
+
+type cachedTemplate struct {
+	template  []byte
+	version   string
+	expiresAt time.Time
+}
+
+// NewTemplateCache creates a cache holding up to size templates. The templates aren't cached if
+// the size isn't positive, and don't expire if the TTL isn't.
+func NewTemplateCache(size int, ttl time.Duration, time util.TimeInterface) *TemplateCache {
+	cache := &TemplateCache{ttl: ttl, time: time}
+	if size > 0 {
+		// New only fails if the size isn't positive.
+		cache.templates, _ = lru.New(size)
+	}
+	return cache
+}
+
+// Get returns the template of a pipeline at a version, loading it if it isn't cached at this
+// version or has expired. The template returned must not be modified.
+func (c *TemplateCache) Get(pipelineId string, version string, load func() ([]byte, error)) ([]byte, error) {
+	if c.templates == nil {
+		return load()
+	}
+	if value, ok := c.templates.Get(pipelineId); ok {
+		entry := value.(*cachedTemplate)
+		if entry.version == version && (c.ttl <= 0 || c.time.Now().Before(entry.expiresAt)) {
+			return entry.template, nil
+		}
+		c.templates.Remove(pipelineId)
+	}
+	template, err := load()
+	if err != nil {
+		return nil, err
+	}
+	entry := &cachedTemplate{template: template, version: version}
+	if c.ttl > 0 {
+		entry.expiresAt = c.time.Now().Add(c.ttl)
+	}
+	c.templates.Add(pipelineId, entry)
+	return template, nil
+}
+
+// Remove invalidates the template of a pipeline.
+func (c *TemplateCache) Remove(pipelineId string) {
+	if c.templates != nil {
+		c.templates.Remove(pipelineId)
+	}
+}
+
+// Purge invalidates all the templates, returning how many were cached.
+func (c *TemplateCache) Purge() int {
+	if c.templates == nil {
+		return 0
+	}
+	count := c.templates.Len()
+	c.templates.Purge()
+	return count
+}
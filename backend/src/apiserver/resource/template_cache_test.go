@@ -0,0 +1,130 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingLoader returns the template loaded, and counts how many times it was loaded.
+type countingLoader struct {
+	template string
+	loads    int
+}
+
+func (l *countingLoader) load() ([]byte, error) {
+	l.loads++
+	return []byte(l.template), nil
+}
+
+func TestTemplateCache_Get(t *testing.T) {
+	cache := NewTemplateCache(10, 0, util.NewFakeTimeForEpoch())
+	loader := &countingLoader{template: "template1"}
+
+	for i := 0; i < 3; i++ {
+		template, err := cache.Get("pipeline1", "v1", loader.load)
+		assert.Nil(t, err)
+		assert.Equal(t, "template1", string(template))
+	}
+	assert.Equal(t, 1, loader.loads)
+}
+
+func TestTemplateCache_Get_Expired(t *testing.T) {
+	// The fake time moves one second forward every time it's read.
+	cache := NewTemplateCache(10, 2*time.Second, util.NewFakeTimeForEpoch())
+	loader := &countingLoader{template: "template1"}
+
+	cache.Get("pipeline1", "v1", loader.load)
+	cache.Get("pipeline1", "v1", loader.load)
+	assert.Equal(t, 1, loader.loads)
+	cache.Get("pipeline1", "v1", loader.load)
+	assert.Equal(t, 2, loader.loads)
+}
+
+func TestTemplateCache_Get_Evicted(t *testing.T) {
+	cache := NewTemplateCache(1, 0, util.NewFakeTimeForEpoch())
+	loader1 := &countingLoader{template: "template1"}
+	loader2 := &countingLoader{template: "template2"}
+
+	cache.Get("pipeline1", "v1", loader1.load)
+	cache.Get("pipeline2", "v1", loader2.load)
+	template, err := cache.Get("pipeline1", "v1", loader1.load)
+	assert.Nil(t, err)
+	assert.Equal(t, "template1", string(template))
+	assert.Equal(t, 2, loader1.loads)
+}
+
+func TestTemplateCache_Get_LoadError(t *testing.T) {
+	cache := NewTemplateCache(10, 0, util.NewFakeTimeForEpoch())
+
+	_, err := cache.Get("pipeline1", "v1", func() ([]byte, error) { return nil, errors.New("not found") })
+	assert.NotNil(t, err)
+	loader := &countingLoader{template: "template1"}
+	template, err := cache.Get("pipeline1", "v1", loader.load)
+	assert.Nil(t, err)
+	assert.Equal(t, "template1", string(template))
+}
+
+func TestTemplateCache_Remove(t *testing.T) {
+	cache := NewTemplateCache(10, 0, util.NewFakeTimeForEpoch())
+	loader := &countingLoader{template: "template1"}
+
+	cache.Get("pipeline1", "v1", loader.load)
+	cache.Remove("pipeline1")
+	cache.Get("pipeline1", "v1", loader.load)
+	assert.Equal(t, 2, loader.loads)
+}
+
+func TestTemplateCache_Purge(t *testing.T) {
+	cache := NewTemplateCache(10, 0, util.NewFakeTimeForEpoch())
+	loader := &countingLoader{template: "template1"}
+
+	cache.Get("pipeline1", "v1", loader.load)
+	cache.Get("pipeline2", "v1", loader.load)
+	assert.Equal(t, 2, cache.Purge())
+	cache.Get("pipeline1", "v1", loader.load)
+	assert.Equal(t, 3, loader.loads)
+	assert.Equal(t, 0, NewTemplateCache(0, 0, util.NewFakeTimeForEpoch()).Purge())
+}
+
+func TestTemplateCache_Disabled(t *testing.T) {
+	cache := NewTemplateCache(0, 0, util.NewFakeTimeForEpoch())
+	loader := &countingLoader{template: "template1"}
+
+	cache.Get("pipeline1", "v1", loader.load)
+	cache.Get("pipeline1", "v1", loader.load)
+	cache.Remove("pipeline1")
+	assert.Equal(t, 2, loader.loads)
+}
+
+func TestTemplateCache_Get_VersionChanged(t *testing.T) {
+	cache := NewTemplateCache(10, 0, util.NewFakeTimeForEpoch())
+	loader1 := &countingLoader{template: "template1"}
+	loader2 := &countingLoader{template: "template2"}
+
+	cache.Get("pipeline1", "v1", loader1.load)
+	template, err := cache.Get("pipeline1", "v2", loader2.load)
+	assert.Nil(t, err)
+	assert.Equal(t, "template2", string(template))
+	template, err = cache.Get("pipeline1", "v2", loader2.load)
+	assert.Nil(t, err)
+	assert.Equal(t, "template2", string(template))
+	assert.Equal(t, 1, loader2.loads)
+}
@@ -0,0 +1,175 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TerminateOperationDetail is a terminate operation with the result of each of its runs.
+type TerminateOperationDetail struct {
+	*model.TerminateOperation
+	Results []*model.TerminateResult
+}
+
+// TerminateAll stores an operation terminating the runs of an experiment or of a run group
+// which aren't finished yet. The runs are terminated in the background by the terminate
+// worker. The run group is cancelled first, so that no run is added to it meanwhile.
+func (r *ResourceManager) TerminateAll(experimentId string, runGroupId string) (*TerminateOperationDetail, error) {
+	var runIds []string
+	if experimentId != "" {
+		if _, err := r.experimentStore.GetExperiment(experimentId); err != nil {
+			return nil, util.Wrap(err, "Failed to terminate the runs of the experiment")
+		}
+		runs, err := r.runStore.ListUnfinishedRunsOfExperiment(experimentId)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to terminate the runs of the experiment")
+		}
+		for _, run := range runs {
+			runIds = append(runIds, run.UUID)
+		}
+	} else {
+		if _, err := r.runGroupStore.GetRunGroup(runGroupId); err != nil {
+			return nil, util.Wrap(err, "Failed to terminate the runs of the run group")
+		}
+		if err := r.runGroupStore.CancelRunGroup(runGroupId, r.time.Now().Unix()); err != nil {
+			return nil, util.Wrap(err, "Failed to terminate the runs of the run group")
+		}
+		runs, err := r.runGroupStore.ListRunGroupRuns(runGroupId)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to terminate the runs of the run group")
+		}
+		for _, run := range runs {
+			if !util.IsFinalCondition(run.Conditions) {
+				runIds = append(runIds, run.UUID)
+			}
+		}
+	}
+	uuid, err := r.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to generate the terminate operation ID")
+	}
+	operation := &model.TerminateOperation{
+		UUID:           uuid.String(),
+		ExperimentUUID: experimentId,
+		RunGroupUUID:   runGroupId,
+		CreatedAtInSec: r.time.Now().Unix(),
+	}
+	// An operation without runs has nothing left to do.
+	if len(runIds) == 0 {
+		operation.FinishedAtInSec = operation.CreatedAtInSec
+	}
+	if err := r.terminateOperationStore.CreateOperation(operation, runIds); err != nil {
+		return nil, util.Wrap(err, "Failed to create the terminate operation")
+	}
+	return r.GetTerminateOperation(operation.UUID)
+}
+
+// GetTerminateOperation returns an operation with the result of each of its runs.
+func (r *ResourceManager) GetTerminateOperation(operationId string) (*TerminateOperationDetail, error) {
+	operation, err := r.terminateOperationStore.GetOperation(operationId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the terminate operation")
+	}
+	results, err := r.terminateOperationStore.ListResults(operationId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the terminate operation")
+	}
+	return &TerminateOperationDetail{TerminateOperation: operation, Results: results}, nil
+}
+
+// ProcessTerminateOperations terminates the runs of the pending operations, recording the
+// result of each of them, then marks the operations as finished.
+func (r *ResourceManager) ProcessTerminateOperations() error {
+	operations, err := r.terminateOperationStore.ListPendingOperations()
+	if err != nil {
+		return util.Wrap(err, "Failed to list the pending terminate operations")
+	}
+	var failed []string
+	for _, operation := range operations {
+		if err := r.processTerminateOperation(operation); err != nil {
+			glog.Errorf("Failed to process terminate operation %v: %+v", operation.UUID, err)
+			failed = append(failed, operation.UUID)
+		}
+	}
+	if len(failed) > 0 {
+		return util.NewInternalServerError(fmt.Errorf("failed operations: %v", failed),
+			"Failed to process %v terminate operations", len(failed))
+	}
+	return nil
+}
+
+func (r *ResourceManager) processTerminateOperation(operation *model.TerminateOperation) error {
+	results, err := r.terminateOperationStore.ListResults(operation.UUID)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.Status != model.TerminatePending {
+			continue
+		}
+		result.Status, result.Error = r.terminateRun(result.RunUUID)
+		if err := r.terminateOperationStore.SetResult(result); err != nil {
+			return err
+		}
+	}
+	return r.terminateOperationStore.FinishOperation(operation.UUID, r.time.Now().Unix())
+}
+
+// terminateRun terminates a run unless it already finished, and returns the status to record
+// with the reason of the failure, if any.
+func (r *ResourceManager) terminateRun(runId string) (model.TerminateStatus, string) {
+	run, err := r.runStore.GetRun(runId)
+	if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		return model.TerminateFailed, fmt.Sprintf("The run %v doesn't exist anymore", runId)
+	}
+	if err != nil {
+		return model.TerminateFailed, err.Error()
+	}
+	if util.IsFinalCondition(run.Conditions) {
+		return model.TerminateAlreadyFinished, ""
+	}
+	runEngine, err := r.runEngine(&run.Run)
+	if err != nil {
+		return model.TerminateFailed, err.Error()
+	}
+	if err := runEngine.Terminate(run.Name); err != nil {
+		if apierrors.IsNotFound(err) {
+			return model.TerminateFailed, fmt.Sprintf("The workflow of the run %v doesn't exist anymore", runId)
+		}
+		return model.TerminateFailed, err.Error()
+	}
+	r.recordRunTerminated(&run.Run)
+	return model.TerminateSucceeded, ""
+}
+
+// recordRunTerminated records an event on the workflow of a run terminated through the API.
+// The workflow is referenced by the name, namespace and UID stored with the run.
+func (r *ResourceManager) recordRunTerminated(run *model.Run) {
+	workflow := &workflowapi.Workflow{ObjectMeta: v1.ObjectMeta{
+		Name: run.Name, Namespace: run.Namespace, UID: types.UID(run.UUID)}}
+	r.eventRecorder.Eventf(workflow, corev1.EventTypeNormal, util.EventReasonRunTerminated,
+		"Run %q was terminated through the ML pipeline API", run.DisplayName)
+}
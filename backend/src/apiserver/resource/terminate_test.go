@@ -0,0 +1,145 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recordedEvents returns the events recorded so far by the fake recorder.
+func recordedEvents(store *FakeClientManager) []string {
+	var events []string
+	for {
+		select {
+		case event := <-store.eventRecorderFake.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func terminatedEvent(run *model.RunDetail) string {
+	return fmt.Sprintf("Normal RunTerminated Run %q was terminated through the ML pipeline API", run.DisplayName)
+}
+
+func TestTerminateAll_Experiment(t *testing.T) {
+	store, manager, runs := initWithRuns(t, 1)
+	defer store.Close()
+	experiment, err := manager.CreateExperiment(&model.Experiment{Name: "e1"})
+	assert.Nil(t, err)
+	var experimentRuns []*model.RunDetail
+	for _, name := range []string{"run1", "run2", "run3"} {
+		run, err := manager.CreateRun(&api.Run{
+			Name:         name,
+			PipelineSpec: &api.PipelineSpec{PipelineId: runs[0].PipelineId},
+			ResourceReferences: []*api.ResourceReference{{
+				Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+				Relationship: api.Relationship_OWNER,
+			}},
+		}, "")
+		assert.Nil(t, err)
+		experimentRuns = append(experimentRuns, run)
+	}
+	assert.Nil(t, store.RunStore().UpdateRun(experimentRuns[0].UUID, string(v1alpha1.NodeSucceeded), ""))
+
+	operation, err := manager.TerminateAll(experiment.UUID, "")
+	assert.Nil(t, err)
+	assert.Equal(t, experiment.UUID, operation.ExperimentUUID)
+	assert.Zero(t, operation.FinishedAtInSec)
+	// Only the runs of the experiment which aren't finished are terminated.
+	assert.Equal(t, []*model.TerminateResult{
+		{OperationUUID: operation.UUID, RunUUID: experimentRuns[1].UUID, Status: model.TerminatePending},
+		{OperationUUID: operation.UUID, RunUUID: experimentRuns[2].UUID, Status: model.TerminatePending},
+	}, operation.Results)
+
+	// The run which finished meanwhile isn't terminated.
+	assert.Nil(t, store.RunStore().UpdateRun(experimentRuns[2].UUID, string(v1alpha1.NodeFailed),
+		experimentRuns[2].WorkflowRuntimeManifest))
+	assert.Nil(t, manager.ProcessTerminateOperations())
+	operation, err = manager.GetTerminateOperation(operation.UUID)
+	assert.Nil(t, err)
+	assert.NotZero(t, operation.FinishedAtInSec)
+	assert.Equal(t, model.TerminateSucceeded, operation.Results[0].Status)
+	assert.Equal(t, model.TerminateAlreadyFinished, operation.Results[1].Status)
+	workflow, err := store.workflowClientFake.Get(experimentRuns[1].Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), *workflow.Spec.ActiveDeadlineSeconds)
+	events := recordedEvents(store)
+	assert.Contains(t, events, terminatedEvent(experimentRuns[1]))
+	assert.NotContains(t, events, terminatedEvent(experimentRuns[2]))
+	workflow, err = store.workflowClientFake.Get(runs[0].Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Nil(t, workflow.Spec.ActiveDeadlineSeconds)
+}
+
+func TestTerminateAll_RunGroup(t *testing.T) {
+	store, manager, runs := initWithRuns(t, 2)
+	defer store.Close()
+	group, err := manager.CreateRunGroup(&model.RunGroup{Name: "group"}, []string{runs[0].UUID, runs[1].UUID})
+	assert.Nil(t, err)
+	// The workflow of the first run vanished.
+	assert.Nil(t, store.workflowClientFake.Delete(runs[0].Name, nil))
+
+	operation, err := manager.TerminateAll("", group.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, group.UUID, operation.RunGroupUUID)
+	assert.Len(t, operation.Results, 2)
+	// The group is cancelled, so that no run is added to it.
+	_, err = manager.AddRunsToRunGroup(group.UUID, []string{runs[0].UUID})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+
+	assert.Nil(t, manager.ProcessTerminateOperations())
+	operation, err = manager.GetTerminateOperation(operation.UUID)
+	assert.Nil(t, err)
+	assert.NotZero(t, operation.FinishedAtInSec)
+	assert.Equal(t, model.TerminateFailed, operation.Results[0].Status)
+	assert.NotEmpty(t, operation.Results[0].Error)
+	assert.Equal(t, model.TerminateSucceeded, operation.Results[1].Status)
+}
+
+func TestTerminateAll_NoRun(t *testing.T) {
+	store, manager, _ := initWithRuns(t, 0)
+	defer store.Close()
+	experiment, err := manager.CreateExperiment(&model.Experiment{Name: "e1"})
+	assert.Nil(t, err)
+
+	// An operation without runs is finished right away.
+	operation, err := manager.TerminateAll(experiment.UUID, "")
+	assert.Nil(t, err)
+	assert.NotZero(t, operation.FinishedAtInSec)
+	assert.Empty(t, operation.Results)
+}
+
+func TestTerminateAll_NotFound(t *testing.T) {
+	store, manager, _ := initWithRuns(t, 0)
+	defer store.Close()
+
+	_, err := manager.TerminateAll("unknown", "")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = manager.TerminateAll("", "unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	_, err = manager.GetTerminateOperation("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
@@ -0,0 +1,42 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// TerminateWorker terminates the runs of the terminate operations.
+type TerminateWorker struct {
+	resourceManager *ResourceManager
+	interval        time.Duration
+}
+
+func NewTerminateWorker(resourceManager *ResourceManager, interval time.Duration) *TerminateWorker {
+	return &TerminateWorker{resourceManager: resourceManager, interval: interval}
+}
+
+// Run processes the pending terminate operations every interval until stopCh is closed.
+func (w *TerminateWorker) Run(stopCh <-chan struct{}) {
+	glog.Infof("Processing the terminate operations every %v", w.interval)
+	wait.Until(func() {
+		if err := w.resourceManager.ProcessTerminateOperations(); err != nil {
+			glog.Errorf("Failed to process the terminate operations: %+v", err)
+		}
+	}, w.interval, stopCh)
+}
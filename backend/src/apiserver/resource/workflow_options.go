@@ -0,0 +1,272 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The schemes of the pipeline roots, both in the S3 compatible object store of the compiled
+// workflows.
+var pipelineRootSchemes = map[string]bool{"minio": true, "s3": true}
+
+// ParsePipelineRoot returns the artifact repository of a pipeline root, e.g.
+// minio://mlpipeline/team-a for the keys prefixed by team-a in the bucket mlpipeline.
+func ParsePipelineRoot(root string) (*api.ArtifactRepository, error) {
+	rootURL, err := url.Parse(root)
+	if err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, fmt.Sprintf("Invalid pipeline root %q", root))
+	}
+	if !pipelineRootSchemes[rootURL.Scheme] || rootURL.Host == "" || rootURL.RawQuery != "" {
+		return nil, util.NewInvalidInputError(
+			"Invalid pipeline root %q. It must be minio://<bucket>/<path> or s3://<bucket>/<path>", root)
+	}
+	return &api.ArtifactRepository{
+		Bucket:    rootURL.Host,
+		KeyPrefix: strings.Trim(rootURL.Path, "/"),
+	}, nil
+}
+
+// pipelineSpecWorkflowOptions returns the workflow options of a pipeline spec, with the
+// artifact repository of its pipeline root if set.
+func pipelineSpecWorkflowOptions(spec *api.PipelineSpec) (*api.WorkflowOptions, error) {
+	if spec.GetPipelineRoot() == "" {
+		return spec.GetWorkflowOptions(), nil
+	}
+	if spec.GetWorkflowOptions().GetArtifactRepository() != nil {
+		return nil, util.NewInvalidInputError("The pipeline root and the artifact repository can't both be set")
+	}
+	repository, err := ParsePipelineRoot(spec.GetPipelineRoot())
+	if err != nil {
+		return nil, err
+	}
+	options := &api.WorkflowOptions{}
+	if spec.GetWorkflowOptions() != nil {
+		options = proto.Clone(spec.GetWorkflowOptions()).(*api.WorkflowOptions)
+	}
+	options.ArtifactRepository = repository
+	return options, nil
+}
+
+// mergeWorkflowOptions returns the workflow options of a run or a job, the options not
+// set taking the defaults of the server.
+func mergeWorkflowOptions(options *api.WorkflowOptions, defaults *api.WorkflowOptions) *api.WorkflowOptions {
+	merged := &api.WorkflowOptions{
+		PodGcStrategy:   options.GetPodGcStrategy(),
+		ArtifactArchive: options.GetArtifactArchive(),
+		LogArchive:      options.GetLogArchive(),
+		// The retry strategy of the steps is set as a whole.
+		DefaultRetryStrategy: options.GetDefaultRetryStrategy(),
+		Parallelism:          options.GetParallelism(),
+		// The bucket and the prefix of the artifacts are set together.
+		ArtifactRepository: options.GetArtifactRepository(),
+		// The scheduling constraints are set as a whole.
+		PodScheduling:     options.GetPodScheduling(),
+		Env:               options.GetEnv(),
+		PriorityClassName: options.GetPriorityClassName(),
+		PreemptionPolicy:  options.GetPreemptionPolicy(),
+	}
+	if merged.PodGcStrategy == api.WorkflowOptions_POD_GC_STRATEGY_UNSPECIFIED {
+		merged.PodGcStrategy = defaults.GetPodGcStrategy()
+	}
+	if merged.ArtifactArchive == api.WorkflowOptions_ARTIFACT_ARCHIVE_UNSPECIFIED {
+		merged.ArtifactArchive = defaults.GetArtifactArchive()
+	}
+	if merged.LogArchive == api.WorkflowOptions_LOG_ARCHIVE_UNSPECIFIED {
+		merged.LogArchive = defaults.GetLogArchive()
+	}
+	if merged.DefaultRetryStrategy == nil {
+		merged.DefaultRetryStrategy = defaults.GetDefaultRetryStrategy()
+	}
+	if merged.Parallelism == 0 {
+		merged.Parallelism = defaults.GetParallelism()
+	}
+	if merged.ArtifactRepository == nil {
+		merged.ArtifactRepository = defaults.GetArtifactRepository()
+	}
+	if merged.PodScheduling == nil {
+		merged.PodScheduling = defaults.GetPodScheduling()
+	}
+	if len(merged.Env) == 0 {
+		merged.Env = defaults.GetEnv()
+	}
+	if merged.PriorityClassName == "" {
+		merged.PriorityClassName = defaults.GetPriorityClassName()
+	}
+	if merged.PreemptionPolicy == api.WorkflowOptions_PREEMPTION_POLICY_UNSPECIFIED {
+		merged.PreemptionPolicy = defaults.GetPreemptionPolicy()
+	}
+	return merged
+}
+
+// applyWorkflowOptions sets where and how a workflow archives its artifacts and logs,
+// retries its steps, where and how many of its pods run at once, their priority and their environment. The options left unspecified
+// keep the settings of the compiled workflow. It returns the labels of the workflow carrying
+// its pod GC strategy, which the persistence agent enforces.
+func applyWorkflowOptions(workflow *util.Workflow, options *api.WorkflowOptions) (map[string]string, error) {
+	switch options.GetArtifactArchive() {
+	case api.WorkflowOptions_TAR:
+		workflow.SetArtifactArchive(true)
+	case api.WorkflowOptions_NO_ARCHIVE:
+		workflow.SetArtifactArchive(false)
+	}
+	if repository := options.GetArtifactRepository(); repository != nil {
+		workflow.SetArtifactRepository(repository.GetBucket(), repository.GetKeyPrefix())
+	}
+	switch options.GetLogArchive() {
+	case api.WorkflowOptions_ARCHIVE_LOGS:
+		workflow.SetArchiveLogs(true)
+	case api.WorkflowOptions_NO_LOG_ARCHIVE:
+		workflow.SetArchiveLogs(false)
+	}
+	if retryStrategy := options.GetDefaultRetryStrategy(); retryStrategy != nil {
+		workflow.SetDefaultRetryStrategy(retryStrategy.GetLimit())
+	}
+	if parallelism := options.GetParallelism(); parallelism > 0 {
+		workflow.SetParallelism(parallelism)
+	}
+	if scheduling := options.GetPodScheduling(); scheduling != nil {
+		tolerations, affinity, err := toPodScheduling(scheduling)
+		if err != nil {
+			return nil, err
+		}
+		workflow.SetPodScheduling(scheduling.GetNodeSelector(), tolerations, affinity)
+	}
+	preemptionPolicy := ""
+	switch options.GetPreemptionPolicy() {
+	case api.WorkflowOptions_PREEMPT_LOWER_PRIORITY:
+		preemptionPolicy = util.PreemptionPolicyLowerPriority
+	case api.WorkflowOptions_PREEMPT_NEVER:
+		preemptionPolicy = util.PreemptionPolicyNever
+	}
+	workflow.SetPodPriority(options.GetPriorityClassName(), preemptionPolicy)
+	if env := options.GetEnv(); len(env) > 0 {
+		envVars, err := toEnvVars(env)
+		if err != nil {
+			return nil, err
+		}
+		workflow.SetContainerEnv(envVars)
+	}
+	labels := make(map[string]string)
+	switch options.GetPodGcStrategy() {
+	case api.WorkflowOptions_ON_WORKFLOW_COMPLETION:
+		labels[util.LabelKeyWorkflowPodGCStrategy] = util.PodGCStrategyOnWorkflowCompletion
+	case api.WorkflowOptions_ON_WORKFLOW_SUCCESS:
+		labels[util.LabelKeyWorkflowPodGCStrategy] = util.PodGCStrategyOnWorkflowSuccess
+	}
+	return labels, nil
+}
+
+// toPodScheduling converts the tolerations and the affinity of the pods of a workflow to the
+// ones of Kubernetes.
+func toPodScheduling(scheduling *api.PodScheduling) ([]corev1.Toleration, *corev1.Affinity, error) {
+	var tolerations []corev1.Toleration
+	for _, toleration := range scheduling.GetTolerations() {
+		operator := corev1.TolerationOperator(toleration.GetOperator())
+		switch operator {
+		case "", corev1.TolerationOpEqual:
+		case corev1.TolerationOpExists:
+			if toleration.GetValue() != "" {
+				return nil, nil, util.NewInvalidInputError("The toleration of %q must not have a value with the Exists operator",
+					toleration.GetKey())
+			}
+		default:
+			return nil, nil, util.NewInvalidInputError("Unknown toleration operator %q", operator)
+		}
+		effect := corev1.TaintEffect(toleration.GetEffect())
+		switch effect {
+		case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return nil, nil, util.NewInvalidInputError("Unknown toleration effect %q", effect)
+		}
+		converted := corev1.Toleration{
+			Key:      toleration.GetKey(),
+			Operator: operator,
+			Value:    toleration.GetValue(),
+			Effect:   effect,
+		}
+		if seconds := toleration.GetTolerationSeconds(); seconds != 0 {
+			if effect != corev1.TaintEffectNoExecute {
+				return nil, nil, util.NewInvalidInputError("The toleration of %q can only have seconds with the NoExecute effect",
+					toleration.GetKey())
+			}
+			converted.TolerationSeconds = &seconds
+		}
+		tolerations = append(tolerations, converted)
+	}
+	if scheduling.GetAffinity() == "" {
+		return tolerations, nil, nil
+	}
+	affinity := &corev1.Affinity{}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(scheduling.GetAffinity())))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(affinity); err != nil {
+		return nil, nil, util.NewInvalidInputErrorWithDetails(err, "Invalid affinity of the pods")
+	}
+	return tolerations, affinity, nil
+}
+
+// toEnvVars converts the environment variables of the containers of a workflow to the ones of
+// Kubernetes.
+func toEnvVars(env []*api.EnvVar) ([]corev1.EnvVar, error) {
+	var envVars []corev1.EnvVar
+	for _, variable := range env {
+		name := variable.GetName()
+		if name == "" || strings.Contains(name, "=") {
+			return nil, util.NewInvalidInputError("Invalid environment variable name %q", name)
+		}
+		envVar := corev1.EnvVar{Name: name, Value: variable.GetValue()}
+		sources := 0
+		if variable.GetValue() != "" {
+			sources++
+		}
+		for _, key := range []*api.KeySelector{variable.GetConfigMapKey(), variable.GetSecretKey()} {
+			if key == nil {
+				continue
+			}
+			sources++
+			if key.GetName() == "" || key.GetKey() == "" {
+				return nil, util.NewInvalidInputError("The environment variable %q must refer to both a name and a key", name)
+			}
+		}
+		if sources != 1 {
+			return nil, util.NewInvalidInputError(
+				"The environment variable %q must have exactly one of a value, a ConfigMap key and a secret key", name)
+		}
+		if key := variable.GetConfigMapKey(); key != nil {
+			envVar.ValueFrom = &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: key.GetName()},
+				Key:                  key.GetKey(),
+			}}
+		}
+		if key := variable.GetSecretKey(); key != nil {
+			envVar.ValueFrom = &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: key.GetName()},
+				Key:                  key.GetKey(),
+			}}
+		}
+		envVars = append(envVars, envVar)
+	}
+	return envVars, nil
+}
@@ -0,0 +1,396 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testWorkflowWithArtifacts = util.NewWorkflow(&v1alpha1.Workflow{
+	TypeMeta:   v1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+	ObjectMeta: v1.ObjectMeta{Name: "workflow-name"},
+	Spec: v1alpha1.WorkflowSpec{Templates: []v1alpha1.Template{{
+		Name:      "main",
+		Container: &corev1.Container{Image: "image"},
+		Outputs:   v1alpha1.Outputs{Artifacts: []v1alpha1.Artifact{{Name: "model"}}},
+	}}},
+})
+
+func TestMergeWorkflowOptions(t *testing.T) {
+	defaults := &api.WorkflowOptions{
+		PodGcStrategy:        api.WorkflowOptions_ON_WORKFLOW_SUCCESS,
+		ArtifactArchive:      api.WorkflowOptions_NO_ARCHIVE,
+		DefaultRetryStrategy: &api.RetryStrategy{Limit: 2},
+		Parallelism:          10,
+		PriorityClassName:    "experiments",
+		PreemptionPolicy:     api.WorkflowOptions_PREEMPT_NEVER,
+	}
+	options := mergeWorkflowOptions(&api.WorkflowOptions{PodGcStrategy: api.WorkflowOptions_KEEP_PODS, Parallelism: 4,
+		PriorityClassName: "production"}, defaults)
+	assert.Equal(t, &api.WorkflowOptions{
+		PodGcStrategy:        api.WorkflowOptions_KEEP_PODS,
+		ArtifactArchive:      api.WorkflowOptions_NO_ARCHIVE,
+		DefaultRetryStrategy: &api.RetryStrategy{Limit: 2},
+		Parallelism:          4,
+		PriorityClassName:    "production",
+		PreemptionPolicy:     api.WorkflowOptions_PREEMPT_NEVER,
+	}, options)
+	// No retries at all, whatever the defaults.
+	options = mergeWorkflowOptions(&api.WorkflowOptions{DefaultRetryStrategy: &api.RetryStrategy{}}, defaults)
+	assert.Equal(t, &api.RetryStrategy{}, options.DefaultRetryStrategy)
+	// The bucket and the prefix aren't merged separately.
+	defaults.ArtifactRepository = &api.ArtifactRepository{Bucket: "mlpipeline", KeyPrefix: "default"}
+	options = mergeWorkflowOptions(&api.WorkflowOptions{ArtifactRepository: &api.ArtifactRepository{Bucket: "team-a"}}, defaults)
+	assert.Equal(t, &api.ArtifactRepository{Bucket: "team-a"}, options.ArtifactRepository)
+
+	assert.Equal(t, defaults, mergeWorkflowOptions(nil, defaults))
+	assert.Equal(t, &api.WorkflowOptions{}, mergeWorkflowOptions(nil, nil))
+}
+
+func TestParsePipelineRoot(t *testing.T) {
+	repository, err := ParsePipelineRoot("minio://mlpipeline/teams/a/")
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ArtifactRepository{Bucket: "mlpipeline", KeyPrefix: "teams/a"}, repository)
+
+	repository, err = ParsePipelineRoot("s3://team-a")
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ArtifactRepository{Bucket: "team-a"}, repository)
+
+	for _, root := range []string{"gs://team-a/runs", "team-a/runs", "minio:///runs", "s3://team-a/runs?versioned"} {
+		_, err = ParsePipelineRoot(root)
+		assert.NotNil(t, err, root)
+	}
+}
+
+func TestPipelineSpecWorkflowOptions(t *testing.T) {
+	options, err := pipelineSpecWorkflowOptions(&api.PipelineSpec{
+		WorkflowOptions: &api.WorkflowOptions{Parallelism: 2},
+		PipelineRoot:    "minio://team-a/runs",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.WorkflowOptions{
+		Parallelism:        2,
+		ArtifactRepository: &api.ArtifactRepository{Bucket: "team-a", KeyPrefix: "runs"},
+	}, options)
+
+	_, err = pipelineSpecWorkflowOptions(&api.PipelineSpec{
+		WorkflowOptions: &api.WorkflowOptions{ArtifactRepository: &api.ArtifactRepository{Bucket: "team-b"}},
+		PipelineRoot:    "minio://team-a/runs",
+	})
+	assert.NotNil(t, err)
+}
+
+func TestApplyWorkflowOptions(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	labels, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{
+		PodGcStrategy:        api.WorkflowOptions_ON_WORKFLOW_COMPLETION,
+		ArtifactArchive:      api.WorkflowOptions_NO_ARCHIVE,
+		LogArchive:           api.WorkflowOptions_ARCHIVE_LOGS,
+		DefaultRetryStrategy: &api.RetryStrategy{Limit: 3},
+		Parallelism:          5,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{util.LabelKeyWorkflowPodGCStrategy: util.PodGCStrategyOnWorkflowCompletion}, labels)
+	template := workflow.Spec.Templates[0]
+	assert.NotNil(t, template.Outputs.Artifacts[0].Archive.None)
+	assert.True(t, *template.ArchiveLocation.ArchiveLogs)
+	assert.Equal(t, int32(3), *template.RetryStrategy.Limit)
+	assert.Equal(t, int64(5), *workflow.Spec.Parallelism)
+}
+
+func TestApplyWorkflowOptions_ArtifactRepository(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	workflow.Spec.Templates[0].Outputs.Artifacts[0].S3 = &v1alpha1.S3Artifact{
+		S3Bucket: v1alpha1.S3Bucket{Bucket: "mlpipeline"},
+		Key:      "runs/{{workflow.uid}}/{{pod.name}}/model.tgz",
+	}
+	_, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{
+		ArtifactRepository: &api.ArtifactRepository{Bucket: "team-a", KeyPrefix: "experiments"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &v1alpha1.S3Artifact{
+		S3Bucket: v1alpha1.S3Bucket{Bucket: "team-a"},
+		Key:      "experiments/runs/{{workflow.uid}}/{{pod.name}}/model.tgz",
+	}, workflow.Spec.Templates[0].Outputs.Artifacts[0].S3)
+}
+
+func TestApplyWorkflowOptions_PodScheduling(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	_, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{PodScheduling: &api.PodScheduling{
+		NodeSelector: map[string]string{"cloud.google.com/gke-preemptible": "true"},
+		Tolerations: []*api.Toleration{
+			{Key: "preemptible", Operator: "Exists", Effect: "NoExecute", TolerationSeconds: 60},
+		},
+		Affinity: `{"nodeAffinity": {"preferredDuringSchedulingIgnoredDuringExecution": [{"weight": 1,
+			"preference": {"matchExpressions": [{"key": "pool", "operator": "In", "values": ["gpu"]}]}}]}}`,
+	}})
+	assert.Nil(t, err)
+
+	seconds := int64(60)
+	template := workflow.Spec.Templates[0]
+	assert.Equal(t, map[string]string{"cloud.google.com/gke-preemptible": "true"}, template.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{{Key: "preemptible", Operator: corev1.TolerationOpExists,
+		Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &seconds}}, template.Tolerations)
+	assert.Equal(t, []string{"gpu"},
+		template.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].Preference.MatchExpressions[0].Values)
+}
+
+func TestApplyWorkflowOptions_PodPriority(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	_, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{
+		PriorityClassName: "production",
+		PreemptionPolicy:  api.WorkflowOptions_PREEMPT_LOWER_PRIORITY,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "production", workflow.Annotations[util.AnnotationKeyWorkflowPodPriorityClassName])
+	assert.Equal(t, util.PreemptionPolicyLowerPriority, workflow.Annotations[util.AnnotationKeyWorkflowPodPreemptionPolicy])
+
+	workflow = util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	_, err = applyWorkflowOptions(workflow, &api.WorkflowOptions{})
+	assert.Nil(t, err)
+	assert.NotContains(t, workflow.Annotations, util.AnnotationKeyWorkflowPodPriorityClassName)
+	assert.NotContains(t, workflow.Annotations, util.AnnotationKeyWorkflowPodPreemptionPolicy)
+}
+
+func TestApplyWorkflowOptions_InvalidPodScheduling(t *testing.T) {
+	for _, scheduling := range []*api.PodScheduling{
+		{Tolerations: []*api.Toleration{{Key: "gpu", Operator: "Matches"}}},
+		{Tolerations: []*api.Toleration{{Key: "gpu", Operator: "Exists", Value: "true"}}},
+		{Tolerations: []*api.Toleration{{Key: "gpu", Effect: "NoSchedule", TolerationSeconds: 60}}},
+		{Tolerations: []*api.Toleration{{Key: "gpu", Effect: "Evict"}}},
+		{Affinity: `{"nodeAffinity": {"required": {}}}`},
+	} {
+		workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+		_, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{PodScheduling: scheduling})
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument), "%v", scheduling)
+	}
+}
+
+func TestApplyWorkflowOptions_Env(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	workflow.Spec.Templates[0].Container.Env = []corev1.EnvVar{
+		{Name: "STAGE", Value: "dev"},
+		{Name: "LOG_LEVEL", Value: "info"},
+	}
+	_, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{Env: []*api.EnvVar{
+		{Name: "STAGE", Value: "prod"},
+		{Name: "DB_HOST", ConfigMapKey: &api.KeySelector{Name: "prod-config", Key: "db-host"}},
+		{Name: "DB_PASSWORD", SecretKey: &api.KeySelector{Name: "prod-db", Key: "password"}},
+	}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "STAGE", Value: "prod"},
+		{Name: "DB_HOST", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "prod-config"}, Key: "db-host"}}},
+		{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "prod-db"}, Key: "password"}}},
+	}, workflow.Spec.Templates[0].Container.Env)
+}
+
+func TestApplyWorkflowOptions_InvalidEnv(t *testing.T) {
+	for _, env := range []*api.EnvVar{
+		{Value: "prod"},
+		{Name: "A=B", Value: "prod"},
+		{Name: "STAGE"},
+		{Name: "STAGE", Value: "prod", SecretKey: &api.KeySelector{Name: "prod", Key: "stage"}},
+		{Name: "STAGE", ConfigMapKey: &api.KeySelector{Name: "prod"}},
+	} {
+		workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+		_, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{Env: []*api.EnvVar{env}})
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument), "%v", env)
+	}
+}
+
+func TestApplyWorkflowOptions_Unspecified(t *testing.T) {
+	workflow := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	labels, err := applyWorkflowOptions(workflow, &api.WorkflowOptions{PodGcStrategy: api.WorkflowOptions_KEEP_PODS})
+	assert.Nil(t, err)
+
+	assert.Empty(t, labels)
+	assert.Equal(t, testWorkflowWithArtifacts.Spec, workflow.Spec)
+}
+
+func TestCreateRun_AppliesWorkflowDefaults(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	store.workflowDefaults = &api.WorkflowOptions{
+		PodGcStrategy: api.WorkflowOptions_ON_WORKFLOW_SUCCESS,
+		LogArchive:    api.WorkflowOptions_NO_LOG_ARCHIVE,
+	}
+	manager := NewResourceManager(store)
+
+	runDetail, err := manager.CreateRun(&api.Run{
+		Name: "run1",
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflowWithArtifacts.ToStringForStore(),
+			WorkflowOptions:  &api.WorkflowOptions{PodGcStrategy: api.WorkflowOptions_ON_WORKFLOW_COMPLETION},
+		},
+	}, "")
+	assert.Nil(t, err)
+
+	var workflow util.Workflow
+	assert.Nil(t, json.Unmarshal([]byte(runDetail.WorkflowRuntimeManifest), &workflow))
+	assert.Equal(t, util.PodGCStrategyOnWorkflowCompletion, workflow.Labels[util.LabelKeyWorkflowPodGCStrategy])
+	assert.False(t, *workflow.Spec.Templates[0].ArchiveLocation.ArchiveLogs)
+	assert.Nil(t, workflow.Spec.Templates[0].Outputs.Artifacts[0].Archive)
+}
+
+func TestCreateJob_AppliesWorkflowOptions(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	manager := NewResourceManager(store)
+
+	job, err := manager.CreateJob(&api.Job{
+		Name:    "j1",
+		Enabled: true,
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflowWithArtifacts.ToStringForStore(),
+			WorkflowOptions: &api.WorkflowOptions{
+				PodGcStrategy:   api.WorkflowOptions_ON_WORKFLOW_SUCCESS,
+				ArtifactArchive: api.WorkflowOptions_TAR,
+			},
+		},
+	}, "")
+	assert.Nil(t, err)
+
+	swf, err := store.ScheduledWorkflow().Get(job.Name, v1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, util.PodGCStrategyOnWorkflowSuccess, swf.Labels[util.LabelKeyWorkflowPodGCStrategy])
+	assert.NotNil(t, swf.Spec.Workflow.Spec.Templates[0].Outputs.Artifacts[0].Archive.Tar)
+}
+
+func TestCreateRun_PodPriorityDefaults(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	store.workflowDefaults = &api.WorkflowOptions{
+		PriorityClassName: "experiments",
+		PreemptionPolicy:  api.WorkflowOptions_PREEMPT_NEVER,
+	}
+	manager := NewResourceManager(store)
+
+	runDetail, err := manager.CreateRun(&api.Run{
+		Name: "run1",
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflowWithArtifacts.ToStringForStore(),
+			WorkflowOptions:  &api.WorkflowOptions{PriorityClassName: "production"},
+		},
+	}, "")
+	assert.Nil(t, err)
+
+	var workflow util.Workflow
+	assert.Nil(t, json.Unmarshal([]byte(runDetail.WorkflowRuntimeManifest), &workflow))
+	assert.Equal(t, "production", workflow.Annotations[util.AnnotationKeyWorkflowPodPriorityClassName])
+	assert.Equal(t, util.PreemptionPolicyNever, workflow.Annotations[util.AnnotationKeyWorkflowPodPreemptionPolicy])
+}
+
+func TestCreateJob_PodPriority(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	store.workflowDefaults = &api.WorkflowOptions{PriorityClassName: "experiments"}
+	manager := NewResourceManager(store)
+
+	_, err := manager.CreateJob(&api.Job{
+		Name:    "j1",
+		Enabled: true,
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflowWithArtifacts.ToStringForStore(),
+			WorkflowOptions:  &api.WorkflowOptions{PriorityClassName: "production"},
+		},
+	}, "")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+
+	// The defaults of the server aren't applied to the jobs.
+	_, err = manager.CreateJob(&api.Job{
+		Name:         "j2",
+		Enabled:      true,
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflowWithArtifacts.ToStringForStore()},
+	}, "")
+	assert.Nil(t, err)
+}
+
+func TestCreateRun_AppliesNamespaceConfig(t *testing.T) {
+	store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer store.Close()
+	store.workflowDefaults = &api.WorkflowOptions{
+		ArtifactRepository: &api.ArtifactRepository{Bucket: "mlpipeline"},
+	}
+	store.SetNamespaceConfig(store.Namespace(), map[string]string{
+		"defaultServiceAccount":   "team-a-runner",
+		"artifactBucket":          "team-a",
+		"ttlSecondsAfterFinished": "3600",
+	})
+	manager := NewResourceManager(store)
+	workflowSpec := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	workflowSpec.Spec.Templates[0].Outputs.Artifacts[0].S3 = &v1alpha1.S3Artifact{
+		S3Bucket: v1alpha1.S3Bucket{Bucket: "mlpipeline"},
+		Key:      "model.tgz",
+	}
+
+	runDetail, err := manager.CreateRun(&api.Run{
+		Name:         "run1",
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: workflowSpec.ToStringForStore()},
+	}, "")
+	assert.Nil(t, err)
+
+	var workflow util.Workflow
+	assert.Nil(t, json.Unmarshal([]byte(runDetail.WorkflowRuntimeManifest), &workflow))
+	assert.Equal(t, "team-a-runner", workflow.Spec.ServiceAccountName)
+	assert.Equal(t, int32(3600), *workflow.Spec.TTLSecondsAfterFinished)
+	assert.Equal(t, "team-a", workflow.Spec.Templates[0].Outputs.Artifacts[0].S3.Bucket)
+}
+
+func TestCreateRun_PipelineRoot(t *testing.T) {
+	workflowSpec := util.NewWorkflow(testWorkflowWithArtifacts.DeepCopy())
+	workflowSpec.Spec.Templates[0].Outputs.Artifacts[0].S3 = &v1alpha1.S3Artifact{
+		S3Bucket: v1alpha1.S3Bucket{Bucket: "mlpipeline"},
+		Key:      "model.tgz",
+	}
+	// The pipeline root of the run wins over the one of its namespace.
+	for pipelineRoot, expected := range map[string]*v1alpha1.S3Artifact{
+		"minio://team-b/run": {S3Bucket: v1alpha1.S3Bucket{Bucket: "team-b"}, Key: "run/model.tgz"},
+		"":                   {S3Bucket: v1alpha1.S3Bucket{Bucket: "team-a"}, Key: "namespace/model.tgz"},
+	} {
+		store := NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+		store.SetNamespaceConfig(store.Namespace(), map[string]string{"defaultPipelineRoot": "minio://team-a/namespace"})
+		manager := NewResourceManager(store)
+
+		runDetail, err := manager.CreateRun(&api.Run{
+			Name: "run1",
+			PipelineSpec: &api.PipelineSpec{
+				WorkflowManifest: workflowSpec.ToStringForStore(),
+				PipelineRoot:     pipelineRoot,
+			},
+		}, "")
+		if assert.Nil(t, err) {
+			var workflow util.Workflow
+			assert.Nil(t, json.Unmarshal([]byte(runDetail.WorkflowRuntimeManifest), &workflow))
+			assert.Equal(t, expected, workflow.Spec.Templates[0].Outputs.Artifacts[0].S3)
+		}
+		store.Close()
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type AdminServer struct {
+	resourceManager       *resource.ResourceManager
+	consistencyChecker    *resource.ConsistencyChecker
+	storageUsageCollector *resource.StorageUsageCollector
+	readOnlyMode          *resource.ReadOnlyMode
+}
+
+func (s *AdminServer) CheckConsistency(ctx context.Context, request *api.CheckConsistencyRequest) (
+	*api.ConsistencyReport, error) {
+	report, err := s.consistencyChecker.Check(request.Repair)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to check the consistency.")
+	}
+	return ToApiConsistencyReport(report), nil
+}
+
+func (s *AdminServer) GetConsistencyReport(ctx context.Context, request *api.GetConsistencyReportRequest) (
+	*api.ConsistencyReport, error) {
+	report, err := s.consistencyChecker.LastReport()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the consistency report.")
+	}
+	return ToApiConsistencyReport(report), nil
+}
+
+func (s *AdminServer) ReindexPipelines(ctx context.Context, request *api.ReindexPipelinesRequest) (
+	*api.MaintenanceResult, error) {
+	if request.Async {
+		operation, err := s.resourceManager.StartOperation(model.OperationReindexPipelines)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to reindex the pipelines.")
+		}
+		return &api.MaintenanceResult{OperationId: operation.UUID}, nil
+	}
+	result, err := s.resourceManager.ReindexPipelines()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to reindex the pipelines.")
+	}
+	return ToApiMaintenanceResult(result), nil
+}
+
+func (s *AdminServer) RecomputeRunStatuses(ctx context.Context, request *api.RecomputeRunStatusesRequest) (
+	*api.MaintenanceResult, error) {
+	if request.Async {
+		operation, err := s.resourceManager.StartOperation(model.OperationRecomputeRunStatuses)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to recompute the run statuses.")
+		}
+		return &api.MaintenanceResult{OperationId: operation.UUID}, nil
+	}
+	result, err := s.resourceManager.RecomputeRunStatuses()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to recompute the run statuses.")
+	}
+	return ToApiMaintenanceResult(result), nil
+}
+
+func (s *AdminServer) FlushCaches(ctx context.Context, request *api.FlushCachesRequest) (
+	*api.MaintenanceResult, error) {
+	return ToApiMaintenanceResult(s.resourceManager.FlushCaches()), nil
+}
+
+func (s *AdminServer) ComputeStorageUsage(ctx context.Context, request *api.ComputeStorageUsageRequest) (
+	*api.StorageUsageReport, error) {
+	report, err := s.storageUsageCollector.Compute()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to compute the storage usage.")
+	}
+	return ToApiStorageUsageReport(report), nil
+}
+
+func (s *AdminServer) GetStorageUsage(ctx context.Context, request *api.GetStorageUsageRequest) (
+	*api.StorageUsageReport, error) {
+	report, err := s.storageUsageCollector.LastReport(request.Namespace, request.ExperimentId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the storage usage.")
+	}
+	return ToApiStorageUsageReport(report), nil
+}
+
+func (s *AdminServer) GetReadOnlyMode(ctx context.Context, request *api.GetReadOnlyModeRequest) (
+	*api.ReadOnlyMode, error) {
+	mode, err := s.readOnlyMode.Get()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the read-only mode.")
+	}
+	return ToApiReadOnlyMode(mode, s.readOnlyMode.IsForced()), nil
+}
+
+func (s *AdminServer) SetReadOnlyMode(ctx context.Context, request *api.SetReadOnlyModeRequest) (
+	*api.ReadOnlyMode, error) {
+	mode, err := s.readOnlyMode.Set(request.ReadOnly, request.Message, common.GetUser(ctx))
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to set the read-only mode.")
+	}
+	return ToApiReadOnlyMode(mode, s.readOnlyMode.IsForced()), nil
+}
+
+func (s *AdminServer) ListDeadLetterReports(ctx context.Context, request *api.ListDeadLetterReportsRequest) (
+	*api.ListDeadLetterReportsResponse, error) {
+	letters, err := s.resourceManager.ListDeadLetterReports()
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to list the dead letter reports.")
+	}
+	return &api.ListDeadLetterReportsResponse{
+		Reports: ToApiDeadLetterReports(letters, request.IncludeWorkflows),
+	}, nil
+}
+
+func (s *AdminServer) ReplayDeadLetterReports(ctx context.Context, request *api.ReplayDeadLetterReportsRequest) (
+	*api.MaintenanceResult, error) {
+	result, err := s.resourceManager.ReplayDeadLetterReports(request.RunIds)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to replay the dead letter reports.")
+	}
+	return ToApiMaintenanceResult(result), nil
+}
+
+func (s *AdminServer) DeleteDeadLetterReport(ctx context.Context, request *api.DeleteDeadLetterReportRequest) (
+	*empty.Empty, error) {
+	if err := s.resourceManager.DeleteDeadLetterReport(request.RunId); err != nil {
+		return nil, util.Wrap(err, "Failed to delete the dead letter report.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func NewAdminServer(resourceManager *resource.ResourceManager, consistencyChecker *resource.ConsistencyChecker,
+	storageUsageCollector *resource.StorageUsageCollector, readOnlyMode *resource.ReadOnlyMode) *AdminServer {
+	return &AdminServer{
+		resourceManager:       resourceManager,
+		consistencyChecker:    consistencyChecker,
+		storageUsageCollector: storageUsageCollector,
+		readOnlyMode:          readOnlyMode,
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newAdminServerForTest() (*resource.FakeClientManager, *AdminServer) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+	checker := resource.NewConsistencyChecker(resourceManager, time.Hour, false)
+	readOnlyMode := resource.NewReadOnlyMode(storage.NewReadOnlyModeStore(clientManager.DB()), false, "Maintenance",
+		time.Second, clientManager.Time())
+	collector := resource.NewStorageUsageCollector(resourceManager, time.Hour)
+	return clientManager, NewAdminServer(resourceManager, checker, collector, readOnlyMode)
+}
+
+func TestCheckConsistency(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+	assert.Nil(t, clientManager.ObjectStore().AddFile([]byte("package"), storage.CreatePipelinePath("deleted")))
+
+	report, err := server.CheckConsistency(nil, &api.CheckConsistencyRequest{Repair: true})
+	assert.Nil(t, err)
+	expected := &api.ConsistencyReport{
+		CheckedAt: &timestamp.Timestamp{Seconds: 1},
+		Repair:    true,
+		Issues: []*api.ConsistencyIssue{{
+			Type:        api.ConsistencyIssue_DANGLING_OBJECT,
+			ResourceId:  "pipelines/deleted",
+			Description: "The pipeline deleted of the object doesn't exist",
+			Repaired:    true,
+		}},
+	}
+	assert.Equal(t, expected, report)
+
+	report, err = server.GetConsistencyReport(nil, &api.GetConsistencyReportRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, report)
+}
+
+func TestGetConsistencyReport_NotChecked(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+
+	_, err := server.GetConsistencyReport(nil, &api.GetConsistencyReportRequest{})
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestComputeStorageUsage(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+	assert.Nil(t, clientManager.ObjectStore().AddFile([]byte("package"), storage.CreatePipelinePath("p1")))
+
+	_, err := server.GetStorageUsage(nil, &api.GetStorageUsageRequest{})
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	report, err := server.ComputeStorageUsage(nil, &api.ComputeStorageUsageRequest{})
+	assert.Nil(t, err)
+	expected := &api.StorageUsageReport{
+		ComputedAt: &timestamp.Timestamp{Seconds: 1},
+		Usages:     []*api.StorageUsage{{Category: api.StorageUsage_PIPELINE_PACKAGES, Bytes: 7}},
+		TotalBytes: 7,
+	}
+	assert.Equal(t, expected, report)
+
+	report, err = server.GetStorageUsage(nil, &api.GetStorageUsageRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, report)
+	report, err = server.GetStorageUsage(nil, &api.GetStorageUsageRequest{Namespace: "ns1"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.StorageUsageReport{
+		ComputedAt: &timestamp.Timestamp{Seconds: 1},
+		Usages:     []*api.StorageUsage{},
+	}, report)
+}
+
+func TestReindexPipelines(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+	_, err := server.resourceManager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+
+	result, err := server.ReindexPipelines(nil, &api.ReindexPipelinesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.MaintenanceResult{Processed: 1}, result)
+}
+
+func TestReindexPipelines_Async(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+
+	result, err := server.ReindexPipelines(nil, &api.ReindexPipelinesRequest{Async: true})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.MaintenanceResult{OperationId: resource.DefaultFakeUUID}, result)
+	operation, err := clientManager.OperationStore().GetOperation(resource.DefaultFakeUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.OperationReindexPipelines, operation.Type)
+	assert.Equal(t, model.OperationRunning, operation.State)
+}
+
+func TestRecomputeRunStatuses(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+
+	result, err := server.RecomputeRunStatuses(nil, &api.RecomputeRunStatusesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.MaintenanceResult{}, result)
+}
+
+func TestFlushCaches(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+	pipeline, err := server.resourceManager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	_, err = server.resourceManager.GetPipelineTemplate(pipeline.UUID)
+	assert.Nil(t, err)
+
+	result, err := server.FlushCaches(nil, &api.FlushCachesRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.MaintenanceResult{Processed: 1}, result)
+}
+
+func TestSetReadOnlyMode(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+
+	mode, err := server.GetReadOnlyMode(nil, &api.GetReadOnlyModeRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ReadOnlyMode{}, mode)
+
+	mode, err = server.SetReadOnlyMode(common.WithUser(context.Background(), "alice"),
+		&api.SetReadOnlyModeRequest{ReadOnly: true, Message: "Backup in progress"})
+	assert.Nil(t, err)
+	expected := &api.ReadOnlyMode{
+		ReadOnly:  true,
+		Message:   "Backup in progress",
+		UpdatedAt: &timestamp.Timestamp{Seconds: 1},
+		UpdatedBy: "alice",
+	}
+	assert.Equal(t, expected, mode)
+	assert.True(t, server.readOnlyMode.IsReadOnly())
+	mode, err = server.GetReadOnlyMode(nil, &api.GetReadOnlyModeRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, mode)
+
+	mode, err = server.SetReadOnlyMode(nil, &api.SetReadOnlyModeRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ReadOnlyMode{UpdatedAt: &timestamp.Timestamp{Seconds: 2}}, mode)
+	assert.False(t, server.readOnlyMode.IsReadOnly())
+}
+
+func TestSetReadOnlyMode_Forced(t *testing.T) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	readOnlyMode := resource.NewReadOnlyMode(storage.NewReadOnlyModeStore(clientManager.DB()), true, "Migration",
+		time.Second, clientManager.Time())
+	server := NewAdminServer(resourceManager, resource.NewConsistencyChecker(resourceManager, time.Hour, false),
+		resource.NewStorageUsageCollector(resourceManager, time.Hour), readOnlyMode)
+
+	mode, err := server.GetReadOnlyMode(nil, &api.GetReadOnlyModeRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ReadOnlyMode{ReadOnly: true, Forced: true}, mode)
+	_, err = server.SetReadOnlyMode(nil, &api.SetReadOnlyModeRequest{})
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+}
+
+func TestDeadLetterReports(t *testing.T) {
+	clientManager, server := newAdminServerForTest()
+	defer clientManager.Close()
+	err := storage.NewReportDeadLetterStore(clientManager.DB()).PutDeadLetter(&model.ReportDeadLetter{
+		RunUUID: "run1", Namespace: "ns1", Name: "workflow1", Workflow: "{}", Error: "bad data", Attempts: 1,
+		FailedAtInSec: 1})
+	assert.Nil(t, err)
+
+	response, err := server.ListDeadLetterReports(nil, &api.ListDeadLetterReportsRequest{})
+	assert.Nil(t, err)
+	expected := &api.DeadLetterReport{
+		RunId:        "run1",
+		Namespace:    "ns1",
+		WorkflowName: "workflow1",
+		Error:        "bad data",
+		Attempts:     1,
+		FailedAt:     &timestamp.Timestamp{Seconds: 1},
+	}
+	assert.Equal(t, []*api.DeadLetterReport{expected}, response.Reports)
+	response, err = server.ListDeadLetterReports(nil, &api.ListDeadLetterReportsRequest{IncludeWorkflows: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", response.Reports[0].Workflow)
+
+	result, err := server.ReplayDeadLetterReports(nil, &api.ReplayDeadLetterReportsRequest{RunIds: []string{"run1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), result.Processed)
+	assert.Len(t, result.Errors, 1)
+
+	_, err = server.DeleteDeadLetterReport(nil, &api.DeleteDeadLetterReportRequest{RunId: "run1"})
+	assert.Nil(t, err)
+	response, err = server.ListDeadLetterReports(nil, &api.ListDeadLetterReportsRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Reports)
+	_, err = server.DeleteDeadLetterReport(nil, &api.DeleteDeadLetterReportRequest{RunId: "run1"})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
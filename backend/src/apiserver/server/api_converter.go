@@ -16,13 +16,22 @@ package server
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/policy"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/webhook"
+	"github.com/kubeflow/pipelines/backend/src/common/features"
+	"github.com/kubeflow/pipelines/backend/src/common/metadata"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	mlmd "github.com/kubeflow/pipelines/third_party/ml-metadata/go/ml_metadata"
 )
 
 func ToApiExperiment(experiment *model.Experiment) *api.Experiment {
@@ -56,13 +65,27 @@ func ToApiPipeline(pipeline *model.Pipeline) *api.Pipeline {
 			Error: err.Error(),
 		}
 	}
-	return &api.Pipeline{
-		Id:          pipeline.UUID,
-		CreatedAt:   &timestamp.Timestamp{Seconds: pipeline.CreatedAtInSec},
-		Name:        pipeline.Name,
-		Description: pipeline.Description,
-		Parameters:  params,
+	schema, err := toApiParameterSchema(pipeline.ParameterSchema)
+	if err != nil {
+		return &api.Pipeline{
+			Id:    pipeline.UUID,
+			Error: err.Error(),
+		}
+	}
+	apiPipeline := &api.Pipeline{
+		Id:                    pipeline.UUID,
+		CreatedAt:             &timestamp.Timestamp{Seconds: pipeline.CreatedAtInSec},
+		Name:                  pipeline.Name,
+		Description:           pipeline.Description,
+		Parameters:            params,
+		Deprecated:            pipeline.Deprecated,
+		ReplacementPipelineId: pipeline.ReplacementPipelineId,
+		ParameterSchema:       schema,
 	}
+	if pipeline.SunsetAtInSec != 0 {
+		apiPipeline.SunsetAt = &timestamp.Timestamp{Seconds: pipeline.SunsetAtInSec}
+	}
+	return apiPipeline
 }
 
 func ToApiPipelines(pipelines []model.Pipeline) []*api.Pipeline {
@@ -73,6 +96,73 @@ func ToApiPipelines(pipelines []model.Pipeline) []*api.Pipeline {
 	return apiPipelines
 }
 
+func ToApiPipelineDiff(diff *util.TemplateDiff) *api.PipelineDiff {
+	apiDiff := &api.PipelineDiff{}
+	for _, parameter := range diff.Parameters {
+		apiDiff.Parameters = append(apiDiff.Parameters, &api.PipelineDiff_Parameter{
+			Name:        parameter.Name,
+			Change:      api.PipelineDiff_Change(api.PipelineDiff_Change_value[string(parameter.Change)]),
+			BaseValue:   parameter.BaseValue,
+			TargetValue: parameter.TargetValue,
+		})
+	}
+	for _, step := range diff.Steps {
+		apiDiff.Steps = append(apiDiff.Steps, &api.PipelineDiff_Step{
+			Name:          step.Name,
+			Change:        api.PipelineDiff_Change(api.PipelineDiff_Change_value[string(step.Change)]),
+			BaseImage:     step.BaseImage,
+			TargetImage:   step.TargetImage,
+			ChangedFields: step.ChangedFields,
+		})
+	}
+	return apiDiff
+}
+
+func ToApiPolicyViolations(violations []policy.Violation) []*api.PolicyViolation {
+	apiViolations := make([]*api.PolicyViolation, 0, len(violations))
+	for _, violation := range violations {
+		mode := api.PolicyViolation_WARN
+		if violation.Mode == policy.ModeEnforce {
+			mode = api.PolicyViolation_ENFORCE
+		}
+		apiViolations = append(apiViolations, &api.PolicyViolation{
+			Rule:     violation.Rule,
+			Mode:     mode,
+			Template: violation.Template,
+			Message:  violation.Message,
+		})
+	}
+	return apiViolations
+}
+
+func ToApiPipelineSteps(steps []*model.PipelineStep) ([]*api.PipelineStep, error) {
+	apiSteps := make([]*api.PipelineStep, 0)
+	for _, step := range steps {
+		var children []string
+		if err := json.Unmarshal([]byte(step.Children), &children); err != nil {
+			return nil, util.NewInternalServerError(err, "Pipeline step with wrong format is stored")
+		}
+		apiSteps = append(apiSteps, &api.PipelineStep{
+			Name:     step.Name,
+			Type:     step.Type,
+			Image:    step.Image,
+			Children: children,
+		})
+	}
+	return apiSteps, nil
+}
+
+func ToApiPipelineStats(stats *resource.PipelineStats) *api.PipelineStats {
+	return &api.PipelineStats{
+		PipelineId:         stats.PipelineID,
+		Runs:               int32(stats.Runs),
+		FailedRuns:         int32(stats.FailedRuns),
+		FailureRate:        stats.FailureRate,
+		P50DurationSeconds: stats.P50DurationInSec,
+		P95DurationSeconds: stats.P95DurationInSec,
+	}
+}
+
 func toApiParameters(paramsString string) ([]*api.Parameter, error) {
 	if paramsString == "" {
 		return nil, nil
@@ -86,7 +176,7 @@ func toApiParameters(paramsString string) ([]*api.Parameter, error) {
 	for _, param := range params {
 		var value string
 		if param.Value != nil {
-			value = *param.Value
+			value = maskParameterValue(*param.Value)
 		}
 		apiParam := api.Parameter{
 			Name:  param.Name,
@@ -97,6 +187,35 @@ func toApiParameters(paramsString string) ([]*api.Parameter, error) {
 	return apiParams, nil
 }
 
+// maskParameterValue masks the stored value of a secret parameter, i.e. its hash.
+func maskParameterValue(value string) string {
+	if util.IsHashedSecretParameterValue(value) {
+		return util.MaskedParameterValue
+	}
+	return value
+}
+
+// toApiParameterSchema converts the schema of the declared parameters stored with a pipeline.
+func toApiParameterSchema(schemaString string) ([]*api.ParameterSchema, error) {
+	if schemaString == "" {
+		return nil, nil
+	}
+	var schema []util.ParameterSchema
+	if err := json.Unmarshal([]byte(schemaString), &schema); err != nil {
+		return nil, util.NewInternalServerError(err, "Parameter schema with wrong format is stored")
+	}
+	apiSchema := make([]*api.ParameterSchema, 0, len(schema))
+	for _, param := range schema {
+		apiSchema = append(apiSchema, &api.ParameterSchema{
+			Name:          param.Name,
+			Type:          api.ParameterSchema_Type(api.ParameterSchema_Type_value[strings.ToUpper(param.Type)]),
+			AllowedValues: param.Enum,
+			Required:      param.Required,
+		})
+	}
+	return apiSchema, nil
+}
+
 func toApiRun(run *model.Run) *api.Run {
 	params, err := toApiParameters(run.Parameters)
 	if err != nil {
@@ -126,9 +245,28 @@ func toApiRun(run *model.Run) *api.Run {
 			Parameters:       params,
 		},
 		ResourceReferences: toApiResourceReferences(run.ResourceReferences),
+		Deployments:        toApiDeploymentStatuses(run.Deployments),
+		Note:               run.Note,
+		Annotations:        run.Annotations,
+		ExecutionTarget:    run.ExecutionTarget,
 	}
 }
 
+func toApiDeploymentStatuses(deployments []*model.RunDeployment) []*api.DeploymentStatus {
+	var apiDeployments []*api.DeploymentStatus
+	for _, deployment := range deployments {
+		apiDeployments = append(apiDeployments, &api.DeploymentStatus{
+			Kind:      deployment.Kind,
+			Namespace: deployment.Namespace,
+			Name:      deployment.Name,
+			State:     api.DeploymentStatus_State(api.DeploymentStatus_State_value[strings.ToUpper(string(deployment.State))]),
+			Message:   deployment.Message,
+			UpdatedAt: &timestamp.Timestamp{Seconds: deployment.UpdatedAtInSec},
+		})
+	}
+	return apiDeployments
+}
+
 func ToApiRuns(runs []model.Run) []*api.Run {
 	apiRuns := make([]*api.Run, 0)
 	for _, run := range runs {
@@ -138,13 +276,133 @@ func ToApiRuns(runs []model.Run) []*api.Run {
 }
 
 func ToApiRunDetail(run *model.RunDetail) *api.RunDetail {
-	return &api.RunDetail{
+	apiRunDetail := &api.RunDetail{
 		Run: toApiRun(&run.Run),
 		PipelineRuntime: &api.PipelineRuntime{
 			WorkflowManifest: run.WorkflowRuntimeManifest,
 			PipelineManifest: run.PipelineRuntimeManifest,
 		},
 	}
+	// The status of the nodes is only known once the workflow has been reported.
+	if run.WorkflowRuntimeManifest == "" {
+		return apiRunDetail
+	}
+	var workflow util.Workflow
+	if err := json.Unmarshal([]byte(run.WorkflowRuntimeManifest), &workflow); err != nil {
+		glog.Warningf("Failed to parse the workflow of run %v to get the status of its nodes: %v", run.UUID, err)
+		return apiRunDetail
+	}
+	apiRunDetail.StepAttempts = toApiStepAttempts(&workflow)
+	apiRunDetail.MainPhase = workflow.MainPhase()
+	apiRunDetail.ExitHandler = toApiExitHandler(workflow.ExitHandler())
+	apiRunDetail.ApprovalGates = toApiApprovalGates(workflow.ApprovalGates())
+	return apiRunDetail
+}
+
+// ToApiRunManifest returns the workflow submitted with a run and its runtime workflow, with
+// the parameters resolved in the latter.
+func ToApiRunManifest(run *model.RunDetail) (*api.RunManifest, error) {
+	var workflow util.Workflow
+	if err := json.Unmarshal([]byte(run.WorkflowRuntimeManifest), &workflow); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the workflow of run %v", run.UUID)
+	}
+	apiManifest := &api.RunManifest{
+		RunId:             run.UUID,
+		SubmittedManifest: run.WorkflowSpecManifest,
+		RuntimeManifest:   run.WorkflowRuntimeManifest,
+	}
+	for _, param := range workflow.Spec.Arguments.Parameters {
+		apiParam := &api.Parameter{Name: param.Name}
+		if param.Value != nil {
+			apiParam.Value = maskParameterValue(*param.Value)
+		}
+		apiManifest.ResolvedParameters = append(apiManifest.ResolvedParameters, apiParam)
+	}
+	return apiManifest, nil
+}
+
+// toApiStepAttempts returns the attempts of the retried steps of a run, from the status of
+// its workflow.
+func toApiStepAttempts(workflow *util.Workflow) []*api.StepAttempts {
+	var apiSteps []*api.StepAttempts
+	for _, step := range workflow.StepAttempts() {
+		apiStep := &api.StepAttempts{
+			NodeId:       step.Node.ID,
+			DisplayName:  step.Node.DisplayName,
+			TemplateName: step.Node.TemplateName,
+			Phase:        string(step.Node.Phase),
+			RetryLimit:   step.RetryLimit,
+		}
+		for _, attempt := range step.Attempts {
+			apiAttempt := &api.StepAttempts_Attempt{
+				NodeId:  attempt.ID,
+				Phase:   string(attempt.Phase),
+				Message: attempt.Message,
+			}
+			if !attempt.StartedAt.IsZero() {
+				apiAttempt.StartedAt = &timestamp.Timestamp{Seconds: attempt.StartedAt.Unix()}
+			}
+			if !attempt.FinishedAt.IsZero() {
+				apiAttempt.FinishedAt = &timestamp.Timestamp{Seconds: attempt.FinishedAt.Unix()}
+				if !attempt.StartedAt.IsZero() {
+					apiAttempt.DurationSeconds = attempt.FinishedAt.Unix() - attempt.StartedAt.Unix()
+				}
+			}
+			apiStep.Attempts = append(apiStep.Attempts, apiAttempt)
+		}
+		apiSteps = append(apiSteps, apiStep)
+	}
+	return apiSteps
+}
+
+func toApiExitHandler(handler *util.ExitHandler) *api.ExitHandler {
+	if handler == nil {
+		return nil
+	}
+	apiHandler := &api.ExitHandler{
+		NodeId:       handler.Node.ID,
+		TemplateName: handler.Node.TemplateName,
+		Phase:        string(handler.Node.Phase),
+		Message:      handler.Node.Message,
+		Succeeded:    handler.Succeeded(),
+		NodeIds:      handler.NodeIDs,
+	}
+	if !handler.Node.StartedAt.IsZero() {
+		apiHandler.StartedAt = &timestamp.Timestamp{Seconds: handler.Node.StartedAt.Unix()}
+	}
+	if !handler.Node.FinishedAt.IsZero() {
+		apiHandler.FinishedAt = &timestamp.Timestamp{Seconds: handler.Node.FinishedAt.Unix()}
+	}
+	return apiHandler
+}
+
+func toApiApprovalGates(gates []v1alpha1.NodeStatus) []*api.ApprovalGate {
+	var apiGates []*api.ApprovalGate
+	for _, gate := range gates {
+		apiGate := &api.ApprovalGate{
+			NodeId:       gate.ID,
+			DisplayName:  gate.DisplayName,
+			TemplateName: gate.TemplateName,
+			Message:      gate.Message,
+		}
+		switch gate.Phase {
+		case v1alpha1.NodeRunning:
+			apiGate.State = api.ApprovalGate_WAITING
+		case v1alpha1.NodeSucceeded:
+			apiGate.State = api.ApprovalGate_APPROVED
+		case v1alpha1.NodeFailed, v1alpha1.NodeError:
+			// Rejected, or stopped with the run, e.g. at its deadline.
+			apiGate.State = api.ApprovalGate_REJECTED
+		}
+		if !gate.StartedAt.IsZero() {
+			apiGate.StartedAt = &timestamp.Timestamp{Seconds: gate.StartedAt.Unix()}
+		}
+		if !gate.FinishedAt.IsZero() {
+			apiGate.ResolvedAt = &timestamp.Timestamp{Seconds: gate.FinishedAt.Unix()}
+		}
+		apiGates = append(apiGates, apiGate)
+	}
+	return apiGates
 }
 
 func ToApiJob(job *model.Job) *api.Job {
@@ -260,3 +518,450 @@ func toApiTrigger(trigger model.Trigger) *api.Trigger {
 	}
 	return &api.Trigger{}
 }
+
+// The run states notified for each webhook event.
+var runStatesByWebhookEvent = map[api.Webhook_Event][]string{
+	api.Webhook_RUN_SUCCEEDED: {string(v1alpha1.NodeSucceeded)},
+	api.Webhook_RUN_FAILED:    {string(v1alpha1.NodeFailed), string(v1alpha1.NodeError)},
+	// The gates of a run waiting for approval are notified while it runs.
+	api.Webhook_RUN_AWAITING_APPROVAL: {webhook.StateAwaitingApproval},
+}
+
+func ToApiWebhook(webhook *model.Webhook) *api.Webhook {
+	var events []api.Webhook_Event
+	for _, event := range []api.Webhook_Event{api.Webhook_RUN_SUCCEEDED, api.Webhook_RUN_FAILED, api.Webhook_RUN_AWAITING_APPROVAL} {
+		if webhook.Matches(webhook.ExperimentUUID, webhook.Namespace, runStatesByWebhookEvent[event][0]) {
+			events = append(events, event)
+		}
+	}
+	// The secret is never returned.
+	return &api.Webhook{
+		Id:           webhook.UUID,
+		Name:         webhook.Name,
+		Url:          webhook.Url,
+		ExperimentId: webhook.ExperimentUUID,
+		Namespace:    webhook.Namespace,
+		Events:       events,
+		CreatedAt:    &timestamp.Timestamp{Seconds: webhook.CreatedAtInSec},
+	}
+}
+
+func ToApiWebhooks(webhooks []model.Webhook) []*api.Webhook {
+	apiWebhooks := make([]*api.Webhook, 0)
+	for _, webhook := range webhooks {
+		apiWebhooks = append(apiWebhooks, ToApiWebhook(&webhook))
+	}
+	return apiWebhooks
+}
+
+func ToModelWebhook(webhook *api.Webhook) *model.Webhook {
+	var states []string
+	for _, event := range webhook.Events {
+		states = append(states, runStatesByWebhookEvent[event]...)
+	}
+	return &model.Webhook{
+		Name:           webhook.Name,
+		Url:            webhook.Url,
+		Secret:         webhook.Secret,
+		ExperimentUUID: webhook.ExperimentId,
+		Namespace:      webhook.Namespace,
+		EventFilters:   strings.Join(states, ","),
+	}
+}
+
+func ToApiExecutionTarget(target *model.ExecutionTarget) *api.ExecutionTarget {
+	return &api.ExecutionTarget{
+		Name:                 target.Name,
+		Description:          target.Description,
+		Namespace:            target.Namespace,
+		KubeconfigSecretName: target.KubeconfigSecretName,
+		KubeconfigSecretKey:  target.KubeconfigSecretKey,
+		CreatedAt:            &timestamp.Timestamp{Seconds: target.CreatedAtInSec},
+	}
+}
+
+func ToApiExecutionTargets(targets []*model.ExecutionTarget) []*api.ExecutionTarget {
+	apiTargets := make([]*api.ExecutionTarget, 0)
+	for _, target := range targets {
+		apiTargets = append(apiTargets, ToApiExecutionTarget(target))
+	}
+	return apiTargets
+}
+
+func ToModelExecutionTarget(target *api.ExecutionTarget) *model.ExecutionTarget {
+	return &model.ExecutionTarget{
+		Name:                 target.Name,
+		Description:          target.Description,
+		Namespace:            target.Namespace,
+		KubeconfigSecretName: target.KubeconfigSecretName,
+		KubeconfigSecretKey:  target.KubeconfigSecretKey,
+	}
+}
+
+func ToApiRunLineage(lineage *metadata.RunLineage) *api.RunLineage {
+	apiLineage := &api.RunLineage{
+		Executions: make([]*api.LineageExecution, 0),
+		Artifacts:  make([]*api.LineageArtifact, 0),
+		Events:     make([]*api.LineageEvent, 0),
+	}
+	for _, execution := range lineage.Executions {
+		properties := execution.GetProperties()
+		apiLineage.Executions = append(apiLineage.Executions, &api.LineageExecution{
+			Id:           execution.GetId(),
+			NodeId:       properties[metadata.PropertyNodeId].GetStringValue(),
+			DisplayName:  properties[metadata.PropertyDisplayName].GetStringValue(),
+			TemplateName: properties[metadata.PropertyTemplateName].GetStringValue(),
+			State:        execution.GetLastKnownState().String(),
+			StartedAt:    &timestamp.Timestamp{Seconds: properties[metadata.PropertyStartedAtInSec].GetIntValue()},
+			FinishedAt:   &timestamp.Timestamp{Seconds: properties[metadata.PropertyFinishedAtInSec].GetIntValue()},
+		})
+	}
+	for _, artifact := range lineage.Artifacts {
+		apiLineage.Artifacts = append(apiLineage.Artifacts, &api.LineageArtifact{
+			Id:   artifact.GetId(),
+			Name: artifact.GetProperties()[metadata.PropertyName].GetStringValue(),
+			Uri:  artifact.GetUri(),
+		})
+	}
+	for _, event := range lineage.Events {
+		apiEvent := &api.LineageEvent{
+			ExecutionId: event.GetExecutionId(),
+			ArtifactId:  event.GetArtifactId(),
+		}
+		switch event.GetType() {
+		case mlmd.Event_INPUT:
+			apiEvent.Type = api.LineageEvent_INPUT
+		case mlmd.Event_OUTPUT:
+			apiEvent.Type = api.LineageEvent_OUTPUT
+		}
+		if steps := event.GetPath().GetSteps(); len(steps) > 0 {
+			apiEvent.Path = steps[0].GetKey()
+		}
+		apiLineage.Events = append(apiLineage.Events, apiEvent)
+	}
+	return apiLineage
+}
+
+func ToApiArtifactLineage(lineage *model.ArtifactLineage) *api.ArtifactLineage {
+	return &api.ArtifactLineage{
+		Upstream:   toApiArtifactLineageEdges(lineage.Upstream),
+		Downstream: toApiArtifactLineageEdges(lineage.Downstream),
+	}
+}
+
+func toApiArtifactLineageEdges(events []*model.ArtifactEvent) []*api.ArtifactLineageEdge {
+	edges := make([]*api.ArtifactLineageEdge, 0)
+	for _, event := range events {
+		edge := &api.ArtifactLineageEdge{
+			ArtifactUri:  event.ArtifactURI,
+			ArtifactName: event.ArtifactName,
+			RunId:        event.RunUUID,
+			NodeId:       event.NodeID,
+			StepName:     event.StepName,
+		}
+		switch event.Type {
+		case model.ArtifactEventInput:
+			edge.Type = api.LineageEvent_INPUT
+		case model.ArtifactEventOutput:
+			edge.Type = api.LineageEvent_OUTPUT
+		}
+		edges = append(edges, edge)
+	}
+	return edges
+}
+
+func ToApiModelVersion(modelVersion *model.ModelVersion) *api.ModelVersion {
+	var metrics []*api.RunMetric
+	for _, metric := range modelVersion.Metrics {
+		metrics = append(metrics, ToApiRunMetric(metric))
+	}
+	return &api.ModelVersion{
+		Id:           modelVersion.UUID,
+		ModelName:    modelVersion.ModelName,
+		Version:      modelVersion.Version,
+		ArtifactUri:  modelVersion.ArtifactURI,
+		ArtifactName: modelVersion.ArtifactName,
+		RunId:        modelVersion.RunUUID,
+		NodeId:       modelVersion.NodeID,
+		Metrics:      metrics,
+		CreatedAt:    &timestamp.Timestamp{Seconds: modelVersion.CreatedAtInSec},
+	}
+}
+
+func ToApiModelVersions(modelVersions []model.ModelVersion) []*api.ModelVersion {
+	apiModelVersions := make([]*api.ModelVersion, 0)
+	for _, modelVersion := range modelVersions {
+		apiModelVersions = append(apiModelVersions, ToApiModelVersion(&modelVersion))
+	}
+	return apiModelVersions
+}
+
+func ToApiRunEstimate(estimate *resource.RunEstimate) *api.RunEstimate {
+	return &api.RunEstimate{
+		Cpu:             estimate.CPU,
+		MemoryGib:       estimate.MemoryGiB,
+		Gpu:             estimate.GPU,
+		DurationSeconds: estimate.DurationInSec,
+		CpuHours:        estimate.CPUHours,
+		MemoryGibHours:  estimate.MemoryGiBHours,
+		GpuHours:        estimate.GPUHours,
+		Cost:            estimate.Cost,
+		Currency:        estimate.Currency,
+		PastRuns:        int32(estimate.PastRuns),
+		Warnings:        estimate.Warnings,
+	}
+}
+
+func ToApiRunSweep(detail *resource.RunSweepDetail) (*api.RunSweep, error) {
+	var run api.Run
+	if err := jsonpb.UnmarshalString(detail.Run, &run); err != nil {
+		return nil, util.NewInternalServerError(err, "Run sweep with wrong format is stored")
+	}
+	apiSweep := &api.RunSweep{
+		Id:             detail.UUID,
+		Name:           detail.Name,
+		Run:            &run,
+		MaxConcurrency: int32(detail.MaxConcurrency),
+		CreatedAt:      &timestamp.Timestamp{Seconds: detail.CreatedAtInSec},
+		// The run group of a sweep has the ID of the sweep.
+		RunGroupId: detail.UUID,
+	}
+	for _, trial := range detail.Trials {
+		var parameters []*api.Parameter
+		if err := json.Unmarshal([]byte(trial.Parameters), &parameters); err != nil {
+			return nil, util.NewInternalServerError(err, "Run sweep trial with wrong format is stored")
+		}
+		apiTrial := &api.SweepTrial{
+			Index:      int32(trial.TrialIndex),
+			Parameters: parameters,
+			RunId:      trial.RunUUID,
+		}
+		switch {
+		case trial.RunUUID == "":
+			apiSweep.PendingRuns++
+		case trial.Run == nil:
+			// The run was deleted.
+		case trial.Run.Conditions == string(v1alpha1.NodeSucceeded):
+			apiSweep.SucceededRuns++
+		case util.IsFinalCondition(trial.Run.Conditions):
+			apiSweep.FailedRuns++
+		default:
+			apiSweep.RunningRuns++
+		}
+		if trial.Run != nil {
+			apiTrial.Status = trial.Run.Conditions
+			for _, metric := range trial.Run.Metrics {
+				apiTrial.Metrics = append(apiTrial.Metrics, ToApiRunMetric(metric))
+			}
+		}
+		apiSweep.Trials = append(apiSweep.Trials, apiTrial)
+	}
+	return apiSweep, nil
+}
+
+func ToApiRunGroup(detail *resource.RunGroupDetail) *api.RunGroup {
+	apiGroup := &api.RunGroup{
+		Id:          detail.UUID,
+		Name:        detail.Name,
+		Description: detail.Description,
+		CreatedAt:   &timestamp.Timestamp{Seconds: detail.CreatedAtInSec},
+	}
+	for _, run := range detail.Runs {
+		apiGroup.RunIds = append(apiGroup.RunIds, run.UUID)
+		switch {
+		case run.Conditions == string(v1alpha1.NodeSucceeded):
+			apiGroup.SucceededRuns++
+		case util.IsFinalCondition(run.Conditions):
+			apiGroup.FailedRuns++
+		default:
+			apiGroup.RunningRuns++
+		}
+	}
+	switch {
+	case detail.CancelledAtInSec > 0:
+		apiGroup.CancelledAt = &timestamp.Timestamp{Seconds: detail.CancelledAtInSec}
+		apiGroup.Status = "Cancelled"
+	case apiGroup.RunningRuns > 0:
+		apiGroup.Status = "Running"
+	case apiGroup.FailedRuns > 0:
+		apiGroup.Status = "Failed"
+	case apiGroup.SucceededRuns > 0:
+		apiGroup.Status = "Succeeded"
+	}
+	return apiGroup
+}
+
+func ToApiTerminateAllOperation(detail *resource.TerminateOperationDetail) *api.TerminateAllOperation {
+	apiOperation := &api.TerminateAllOperation{
+		Id:           detail.UUID,
+		ExperimentId: detail.ExperimentUUID,
+		RunGroupId:   detail.RunGroupUUID,
+		CreatedAt:    &timestamp.Timestamp{Seconds: detail.CreatedAtInSec},
+	}
+	if detail.FinishedAtInSec > 0 {
+		apiOperation.FinishedAt = &timestamp.Timestamp{Seconds: detail.FinishedAtInSec}
+	}
+	for _, result := range detail.Results {
+		apiOperation.Results = append(apiOperation.Results, &api.TerminateResult{
+			RunId:  result.RunUUID,
+			Status: toApiTerminateStatus(result.Status),
+			Error:  result.Error,
+		})
+	}
+	return apiOperation
+}
+
+func toApiTerminateStatus(status model.TerminateStatus) api.TerminateResult_Status {
+	switch status {
+	case model.TerminateSucceeded:
+		return api.TerminateResult_TERMINATED
+	case model.TerminateAlreadyFinished:
+		return api.TerminateResult_ALREADY_FINISHED
+	case model.TerminateFailed:
+		return api.TerminateResult_FAILED
+	default:
+		return api.TerminateResult_PENDING
+	}
+}
+
+func ToApiRunOutputs(outputs []*resource.RunOutput) []*api.RunOutput {
+	apiOutputs := make([]*api.RunOutput, 0, len(outputs))
+	for _, output := range outputs {
+		apiOutputs = append(apiOutputs, &api.RunOutput{
+			NodeId:    output.NodeID,
+			Type:      output.Type,
+			Source:    output.Source,
+			Content:   output.Content,
+			Truncated: output.Truncated,
+			Error:     output.Error,
+		})
+	}
+	return apiOutputs
+}
+
+func ToApiRunStateTransitions(transitions []*model.RunStateTransition) []*api.RunStateTransition {
+	apiTransitions := make([]*api.RunStateTransition, 0, len(transitions))
+	for _, transition := range transitions {
+		apiTransitions = append(apiTransitions, &api.RunStateTransition{
+			NodeId:   transition.NodeID,
+			NodeName: transition.NodeName,
+			State:    transition.State,
+			Time:     &timestamp.Timestamp{Seconds: transition.TimeInSec},
+			Message:  transition.Message,
+		})
+	}
+	return apiTransitions
+}
+
+func ToApiConsistencyReport(report *resource.ConsistencyReport) *api.ConsistencyReport {
+	apiIssues := make([]*api.ConsistencyIssue, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		apiIssues = append(apiIssues, &api.ConsistencyIssue{
+			Type:        api.ConsistencyIssue_Type(api.ConsistencyIssue_Type_value[string(issue.Type)]),
+			ResourceId:  issue.ResourceId,
+			Description: issue.Description,
+			Repaired:    issue.Repaired,
+			RepairError: issue.RepairError,
+		})
+	}
+	return &api.ConsistencyReport{
+		CheckedAt: &timestamp.Timestamp{Seconds: report.CheckedAtInSec},
+		Repair:    report.Repair,
+		Issues:    apiIssues,
+	}
+}
+
+func ToApiStorageUsageReport(report *resource.StorageUsageReport) *api.StorageUsageReport {
+	apiUsages := make([]*api.StorageUsage, 0, len(report.Usages))
+	var totalBytes int64
+	for _, usage := range report.Usages {
+		apiUsages = append(apiUsages, &api.StorageUsage{
+			Namespace:    usage.Namespace,
+			ExperimentId: usage.ExperimentId,
+			Category:     api.StorageUsage_Category(api.StorageUsage_Category_value[string(usage.Category)]),
+			Bytes:        usage.Bytes,
+		})
+		totalBytes += usage.Bytes
+	}
+	return &api.StorageUsageReport{
+		ComputedAt: &timestamp.Timestamp{Seconds: report.ComputedAtInSec},
+		Usages:     apiUsages,
+		TotalBytes: totalBytes,
+	}
+}
+
+func ToApiDeadLetterReports(letters []*model.ReportDeadLetter, includeWorkflows bool) []*api.DeadLetterReport {
+	apiReports := make([]*api.DeadLetterReport, 0, len(letters))
+	for _, letter := range letters {
+		apiReport := &api.DeadLetterReport{
+			RunId:        letter.RunUUID,
+			Namespace:    letter.Namespace,
+			WorkflowName: letter.Name,
+			Error:        letter.Error,
+			Attempts:     int32(letter.Attempts),
+			FailedAt:     &timestamp.Timestamp{Seconds: letter.FailedAtInSec},
+		}
+		if includeWorkflows {
+			apiReport.Workflow = letter.Workflow
+		}
+		apiReports = append(apiReports, apiReport)
+	}
+	return apiReports
+}
+
+func ToApiMaintenanceResult(result *resource.MaintenanceResult) *api.MaintenanceResult {
+	return &api.MaintenanceResult{Processed: int32(result.Processed), Errors: result.Errors}
+}
+
+func ToApiOperation(operation *model.Operation) (*api.Operation, error) {
+	var errors []string
+	if err := json.Unmarshal([]byte(operation.Errors), &errors); err != nil {
+		return nil, util.NewInternalServerError(err, "Operation with wrong format is stored")
+	}
+	apiOperation := &api.Operation{
+		Id:        operation.UUID,
+		Type:      api.Operation_Type(api.Operation_Type_value[string(operation.Type)]),
+		State:     api.Operation_State(api.Operation_State_value[string(operation.State)]),
+		CreatedAt: &timestamp.Timestamp{Seconds: operation.CreatedAtInSec},
+		Total:     int32(operation.Total),
+		Processed: int32(operation.Processed),
+		Errors:    errors,
+	}
+	if operation.FinishedAtInSec > 0 {
+		apiOperation.FinishedAt = &timestamp.Timestamp{Seconds: operation.FinishedAtInSec}
+	}
+	return apiOperation, nil
+}
+
+var apiFeatureStages = map[features.Stage]api.Feature_Stage{
+	features.Alpha: api.Feature_ALPHA,
+	features.Beta:  api.Feature_BETA,
+	features.GA:    api.Feature_GA,
+}
+
+func ToApiFeatures(states []features.State) []*api.Feature {
+	apiFeatures := make([]*api.Feature, 0, len(states))
+	for _, state := range states {
+		apiFeatures = append(apiFeatures, &api.Feature{
+			Name:    string(state.Feature),
+			Enabled: state.Enabled,
+			Stage:   apiFeatureStages[state.Stage],
+			Default: state.Default,
+		})
+	}
+	return apiFeatures
+}
+
+func ToApiReadOnlyMode(mode *model.ReadOnlyMode, forced bool) *api.ReadOnlyMode {
+	apiMode := &api.ReadOnlyMode{
+		ReadOnly:  mode.ReadOnly || forced,
+		Message:   mode.Message,
+		Forced:    forced,
+		UpdatedBy: mode.UpdatedBy,
+	}
+	// The mode was never set if it has no update time.
+	if mode.UpdatedAtInSec != 0 {
+		apiMode.UpdatedAt = &timestamp.Timestamp{Seconds: mode.UpdatedAtInSec}
+	}
+	return apiMode
+}
@@ -21,6 +21,7 @@ import (
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -40,6 +41,25 @@ func TestToApiPipeline(t *testing.T) {
 	assert.Equal(t, expectedApiPipeline, apiPipeline)
 }
 
+func TestToApiPipeline_ParameterSchema(t *testing.T) {
+	modelPipeline := &model.Pipeline{
+		UUID:           "pipeline1",
+		CreatedAtInSec: 1,
+		Parameters:     `[{"name":"optimizer","value":"sgd"},{"name":"token"}]`,
+		ParameterSchema: `[{"name":"optimizer","type":"enum","enum":["sgd","adam"]},` +
+			`{"name":"token","type":"secret","required":true}]`,
+	}
+	apiPipeline := ToApiPipeline(modelPipeline)
+	assert.Equal(t, []*api.ParameterSchema{
+		{Name: "optimizer", Type: api.ParameterSchema_ENUM, AllowedValues: []string{"sgd", "adam"}},
+		{Name: "token", Type: api.ParameterSchema_SECRET, Required: true},
+	}, apiPipeline.ParameterSchema)
+
+	modelPipeline.ParameterSchema = "[invalid schema"
+	apiPipeline = ToApiPipeline(modelPipeline)
+	assert.Contains(t, apiPipeline.Error, "Parameter schema with wrong format is stored")
+}
+
 func TestToApiPipeline_ErrorParsingField(t *testing.T) {
 	modelPipeline := &model.Pipeline{
 		UUID:           "pipeline1",
@@ -97,6 +117,114 @@ func TestToApiRunDetail(t *testing.T) {
 	assert.Equal(t, expectedApiRun, apiRun)
 }
 
+func TestToApiRunDetail_SecretParameters(t *testing.T) {
+	modelRun := &model.RunDetail{
+		Run: model.Run{
+			UUID: "run123",
+			PipelineSpec: model.PipelineSpec{
+				Parameters: `[{"name":"token","value":"` + util.HashSecretParameterValue("hunter2") + `"},{"name":"user","value":"alice"}]`,
+			},
+		},
+	}
+	apiRun := ToApiRunDetail(modelRun)
+	assert.Equal(t, []*api.Parameter{
+		{Name: "token", Value: util.MaskedParameterValue},
+		{Name: "user", Value: "alice"},
+	}, apiRun.Run.PipelineSpec.Parameters)
+}
+
+func TestToApiRunDetail_StepAttempts(t *testing.T) {
+	manifest := `{"spec": {"templates": [{"name": "train", "container": {}, "retryStrategy": {"limit": 2}}]},
+		"status": {"nodes": {
+			"wf-1": {"id": "wf-1", "displayName": "train", "type": "Retry", "templateName": "train", "phase": "Succeeded",
+				"children": ["wf-2", "wf-3"]},
+			"wf-2": {"id": "wf-2", "type": "Pod", "phase": "Failed", "message": "failed with exit code 1",
+				"startedAt": "2018-01-01T00:00:00Z", "finishedAt": "2018-01-01T00:00:30Z"},
+			"wf-3": {"id": "wf-3", "type": "Pod", "phase": "Succeeded",
+				"startedAt": "2018-01-01T00:01:00Z", "finishedAt": "2018-01-01T00:01:10Z"}}}}`
+
+	runDetail := ToApiRunDetail(&model.RunDetail{Run: model.Run{UUID: "run1"}, WorkflowRuntimeManifest: manifest})
+	assert.Equal(t, []*api.StepAttempts{{
+		NodeId:       "wf-1",
+		DisplayName:  "train",
+		TemplateName: "train",
+		Phase:        "Succeeded",
+		RetryLimit:   2,
+		Attempts: []*api.StepAttempts_Attempt{
+			{NodeId: "wf-2", Phase: "Failed", Message: "failed with exit code 1",
+				StartedAt: &timestamp.Timestamp{Seconds: 1514764800}, FinishedAt: &timestamp.Timestamp{Seconds: 1514764830},
+				DurationSeconds: 30},
+			{NodeId: "wf-3", Phase: "Succeeded",
+				StartedAt: &timestamp.Timestamp{Seconds: 1514764860}, FinishedAt: &timestamp.Timestamp{Seconds: 1514764870},
+				DurationSeconds: 10},
+		},
+	}}, runDetail.StepAttempts)
+	assert.Nil(t, ToApiRunDetail(&model.RunDetail{}).StepAttempts)
+	assert.Nil(t, ToApiRunDetail(&model.RunDetail{WorkflowRuntimeManifest: "not a workflow"}).StepAttempts)
+}
+
+func TestToApiRunDetail_ExitHandler(t *testing.T) {
+	manifest := `{"metadata": {"name": "wf"}, "spec": {"onExit": "cleanup"},
+		"status": {"phase": "Failed", "nodes": {
+			"wf": {"id": "wf", "name": "wf", "type": "DAG", "phase": "Succeeded"},
+			"wf-1": {"id": "wf-1", "name": "wf.onExit", "type": "Steps", "templateName": "cleanup",
+				"phase": "Failed", "message": "child 'wf-2' failed", "children": ["wf-3"],
+				"startedAt": "2018-01-01T00:00:00Z", "finishedAt": "2018-01-01T00:00:30Z"},
+			"wf-3": {"id": "wf-3", "name": "wf.onExit[0]", "type": "StepGroup", "phase": "Failed", "children": ["wf-2"]},
+			"wf-2": {"id": "wf-2", "name": "wf.onExit[0].delete", "type": "Pod", "phase": "Failed"}}}}`
+
+	runDetail := ToApiRunDetail(&model.RunDetail{Run: model.Run{UUID: "run1"}, WorkflowRuntimeManifest: manifest})
+	assert.Equal(t, "Succeeded", runDetail.MainPhase)
+	assert.Equal(t, &api.ExitHandler{
+		NodeId:       "wf-1",
+		TemplateName: "cleanup",
+		Phase:        "Failed",
+		StartedAt:    &timestamp.Timestamp{Seconds: 1514764800},
+		FinishedAt:   &timestamp.Timestamp{Seconds: 1514764830},
+		Message:      "child 'wf-2' failed",
+		Succeeded:    false,
+		NodeIds:      []string{"wf-2", "wf-3"},
+	}, runDetail.ExitHandler)
+}
+
+func TestToApiRunDetail_ApprovalGates(t *testing.T) {
+	manifest := `{"metadata": {"name": "wf"}, "status": {"phase": "Running", "nodes": {
+			"wf": {"id": "wf", "name": "wf", "type": "DAG", "phase": "Running"},
+			"wf-1": {"id": "wf-1", "displayName": "approve", "type": "Suspend", "templateName": "approval",
+				"phase": "Succeeded", "message": "Approved by alice",
+				"startedAt": "2018-01-01T00:00:00Z", "finishedAt": "2018-01-01T00:00:30Z"},
+			"wf-2": {"id": "wf-2", "displayName": "deploy", "type": "Suspend", "templateName": "approval",
+				"phase": "Running", "startedAt": "2018-01-01T00:01:00Z"}}}}`
+
+	runDetail := ToApiRunDetail(&model.RunDetail{Run: model.Run{UUID: "run1"}, WorkflowRuntimeManifest: manifest})
+	assert.Equal(t, []*api.ApprovalGate{
+		{
+			NodeId:       "wf-1",
+			DisplayName:  "approve",
+			TemplateName: "approval",
+			State:        api.ApprovalGate_APPROVED,
+			StartedAt:    &timestamp.Timestamp{Seconds: 1514764800},
+			ResolvedAt:   &timestamp.Timestamp{Seconds: 1514764830},
+			Message:      "Approved by alice",
+		},
+		{
+			NodeId:       "wf-2",
+			DisplayName:  "deploy",
+			TemplateName: "approval",
+			State:        api.ApprovalGate_WAITING,
+			StartedAt:    &timestamp.Timestamp{Seconds: 1514764860},
+		},
+	}, runDetail.ApprovalGates)
+}
+
+func TestToModelWebhook_AwaitingApproval(t *testing.T) {
+	webhook := ToModelWebhook(&api.Webhook{
+		Events: []api.Webhook_Event{api.Webhook_RUN_FAILED, api.Webhook_RUN_AWAITING_APPROVAL}})
+	assert.Equal(t, "Failed,Error,AwaitingApproval", webhook.EventFilters)
+	assert.Equal(t, []api.Webhook_Event{api.Webhook_RUN_FAILED, api.Webhook_RUN_AWAITING_APPROVAL},
+		ToApiWebhook(webhook).Events)
+}
+
 func TestToApiRuns(t *testing.T) {
 	metric1 := &model.RunMetric{
 		Name:        "metric-1",
@@ -436,6 +564,28 @@ func TestToApiRunMetric(t *testing.T) {
 	assert.Equal(t, expectedAPIRunMetric, actualAPIRunMetric)
 }
 
+func TestToApiDeploymentStatuses(t *testing.T) {
+	deployments := []*model.RunDeployment{{
+		RunUUID:        "run1",
+		Kind:           "InferenceService",
+		Namespace:      "kubeflow",
+		Name:           "mnist",
+		State:          model.DeploymentFailed,
+		Message:        "Not ready after 15m0s",
+		UpdatedAtInSec: 2,
+	}}
+
+	expectedDeployments := []*api.DeploymentStatus{{
+		Kind:      "InferenceService",
+		Namespace: "kubeflow",
+		Name:      "mnist",
+		State:     api.DeploymentStatus_FAILED,
+		Message:   "Not ready after 15m0s",
+		UpdatedAt: &timestamp.Timestamp{Seconds: 2},
+	}}
+	assert.Equal(t, expectedDeployments, toApiDeploymentStatuses(deployments))
+}
+
 func TestToApiRunMetric_UnknownFormat(t *testing.T) {
 	// This can happen if we accidentally remove an existing format value from proto.
 	modelRunMetric := &model.RunMetric{
@@ -459,6 +609,68 @@ func TestToApiRunMetric_UnknownFormat(t *testing.T) {
 	assert.Equal(t, expectedAPIRunMetric, actualAPIRunMetric)
 }
 
+func TestToApiRunGroup(t *testing.T) {
+	group := &model.RunGroup{UUID: "group1", Name: "group", CreatedAtInSec: 1}
+	tests := []struct {
+		conditions []string
+		cancelled  int64
+		status     string
+	}{
+		{nil, 0, ""},
+		{[]string{"Succeeded", "Running"}, 0, "Running"},
+		{[]string{"Succeeded", "Failed"}, 0, "Failed"},
+		{[]string{"Succeeded", "Succeeded"}, 0, "Succeeded"},
+		{[]string{"Succeeded", "Running"}, 2, "Cancelled"},
+	}
+	for _, test := range tests {
+		cancelledGroup := *group
+		cancelledGroup.CancelledAtInSec = test.cancelled
+		detail := &resource.RunGroupDetail{RunGroup: &cancelledGroup}
+		for i, conditions := range test.conditions {
+			detail.Runs = append(detail.Runs, &model.Run{UUID: string('a' + rune(i)), Conditions: conditions})
+		}
+		assert.Equal(t, test.status, ToApiRunGroup(detail).Status, "%v", test.conditions)
+	}
+
+	detail := &resource.RunGroupDetail{RunGroup: group, Runs: []*model.Run{
+		{UUID: "run1", Conditions: "Succeeded"}, {UUID: "run2", Conditions: "Error"}, {UUID: "run3", Conditions: ""}}}
+	assert.Equal(t, &api.RunGroup{
+		Id:            "group1",
+		Name:          "group",
+		RunIds:        []string{"run1", "run2", "run3"},
+		CreatedAt:     &timestamp.Timestamp{Seconds: 1},
+		Status:        "Running",
+		RunningRuns:   1,
+		SucceededRuns: 1,
+		FailedRuns:    1,
+	}, ToApiRunGroup(detail))
+}
+
+func TestToApiTerminateAllOperation(t *testing.T) {
+	detail := &resource.TerminateOperationDetail{
+		TerminateOperation: &model.TerminateOperation{
+			UUID: "operation1", RunGroupUUID: "group1", CreatedAtInSec: 1, FinishedAtInSec: 2},
+		Results: []*model.TerminateResult{
+			{OperationUUID: "operation1", RunUUID: "run1", Status: model.TerminateSucceeded},
+			{OperationUUID: "operation1", RunUUID: "run2", Status: model.TerminateAlreadyFinished},
+			{OperationUUID: "operation1", RunUUID: "run3", Status: model.TerminateFailed, Error: "boom"},
+			{OperationUUID: "operation1", RunUUID: "run4", Status: model.TerminatePending},
+		},
+	}
+	assert.Equal(t, &api.TerminateAllOperation{
+		Id:         "operation1",
+		RunGroupId: "group1",
+		CreatedAt:  &timestamp.Timestamp{Seconds: 1},
+		FinishedAt: &timestamp.Timestamp{Seconds: 2},
+		Results: []*api.TerminateResult{
+			{RunId: "run1", Status: api.TerminateResult_TERMINATED},
+			{RunId: "run2", Status: api.TerminateResult_ALREADY_FINISHED},
+			{RunId: "run3", Status: api.TerminateResult_FAILED, Error: "boom"},
+			{RunId: "run4", Status: api.TerminateResult_PENDING},
+		},
+	}, ToApiTerminateAllOperation(detail))
+}
+
 func TestToApiResourceReferences(t *testing.T) {
 	resourceReferences := []*model.ResourceReference{
 		{ResourceUUID: "run1", ResourceType: common.Run, ReferenceUUID: "experiment1",
@@ -474,3 +686,26 @@ func TestToApiResourceReferences(t *testing.T) {
 	}
 	assert.Equal(t, expectedApiResourceReferences, toApiResourceReferences(resourceReferences))
 }
+
+func TestToApiPipelineDiff(t *testing.T) {
+	diff := &util.TemplateDiff{
+		Parameters: []util.ParameterDiff{
+			{Name: "epochs", Change: util.TemplateChangeModified, BaseValue: "10", TargetValue: "20"},
+		},
+		Steps: []util.StepDiff{
+			{Name: "deploy", Change: util.TemplateChangeRemoved, BaseImage: "deployer:1"},
+			{Name: "train", Change: util.TemplateChangeModified, BaseImage: "trainer:1", TargetImage: "trainer:2",
+				ChangedFields: []string{"image", "args"}},
+		},
+	}
+	assert.Equal(t, &api.PipelineDiff{
+		Parameters: []*api.PipelineDiff_Parameter{
+			{Name: "epochs", Change: api.PipelineDiff_MODIFIED, BaseValue: "10", TargetValue: "20"},
+		},
+		Steps: []*api.PipelineDiff_Step{
+			{Name: "deploy", Change: api.PipelineDiff_REMOVED, BaseImage: "deployer:1"},
+			{Name: "train", Change: api.PipelineDiff_MODIFIED, BaseImage: "trainer:1", TargetImage: "trainer:2",
+				ChangedFields: []string{"image", "args"}},
+		},
+	}, ToApiPipelineDiff(diff))
+}
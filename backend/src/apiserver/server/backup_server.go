@@ -0,0 +1,478 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	BackupExportPath = "/apis/v1beta1/backup:export"
+	BackupImportPath = "/apis/v1beta1/backup:import"
+
+	// The version of the archive format. Archives of other versions aren't imported.
+	backupVersion = 1
+	// The maximum size of an imported archive.
+	maxBackupBytes = 256 << 20
+
+	IncludeRunsQueryStringKey = "include_runs"
+	DisableJobsQueryStringKey = "disable_jobs"
+
+	backupManifestFile    = "manifest.json"
+	backupPipelinesFile   = "pipelines.json"
+	backupExperimentsFile = "experiments.json"
+	backupJobsFile        = "jobs.json"
+	backupRunsFile        = "runs.json"
+)
+
+type BackupServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+// ExportBackup is the HTTP endpoint returning the pipelines, the experiments, the jobs
+// and, if include_runs is true, the runs as a tar.gz archive which ImportBackup can
+// restore in another deployment:
+//
+//	GET /apis/v1beta1/backup:export?include_runs=true
+//
+// The layout of the archive is documented in backup.proto.
+func (s *BackupServer) ExportBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorToResponse(w, http.StatusMethodNotAllowed,
+			util.NewInvalidInputError("Backups must be exported with GET, not %v", r.Method))
+		return
+	}
+	includeRuns, err := parseBoolQueryString(r, IncludeRunsQueryStringKey)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	archive, err := s.exportBackup(includeRuns)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusInternalServerError, util.Wrap(err, "Failed to export the backup"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", `attachment; filename="pipelines-backup.tar.gz"`)
+	w.Write([]byte(archive))
+}
+
+func (s *BackupServer) exportBackup(includeRuns bool) (string, error) {
+	files := make(map[string]string)
+	addFile := func(name string, message proto.Message) error {
+		content, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(message)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to marshal %v", name)
+		}
+		files[name] = content
+		return nil
+	}
+
+	pipelines, err := s.listAllPipelines()
+	if err != nil {
+		return "", err
+	}
+	for _, pipeline := range pipelines {
+		template, err := s.resourceManager.GetPipelineTemplate(pipeline.UUID)
+		if err != nil {
+			return "", util.Wrap(err, fmt.Sprintf("Failed to get the template of pipeline %v", pipeline.UUID))
+		}
+		files[backupPipelineTemplateFile(pipeline.UUID)] = string(template)
+	}
+	if err := addFile(backupPipelinesFile, &api.ListPipelinesResponse{Pipelines: ToApiPipelines(pipelines)}); err != nil {
+		return "", err
+	}
+
+	experiments, err := s.listAllExperiments()
+	if err != nil {
+		return "", err
+	}
+	if err := addFile(backupExperimentsFile,
+		&api.ListExperimentsResponse{Experiments: ToApiExperiments(experiments)}); err != nil {
+		return "", err
+	}
+
+	jobs, err := s.listAllJobs()
+	if err != nil {
+		return "", err
+	}
+	if err := addFile(backupJobsFile, &api.ListJobsResponse{Jobs: ToApiJobs(jobs)}); err != nil {
+		return "", err
+	}
+
+	if includeRuns {
+		runs, err := s.listAllRuns()
+		if err != nil {
+			return "", err
+		}
+		for _, run := range runs {
+			runDetail, err := s.resourceManager.GetRun(run.UUID)
+			if err != nil {
+				// The runs whose workflow was never reported have no runtime workflow.
+				glog.Warningf("Failed to get the runtime workflow of run %v: %v", run.UUID, err)
+				continue
+			}
+			files[backupRunWorkflowFile(run.UUID)] = runDetail.WorkflowRuntimeManifest
+		}
+		if err := addFile(backupRunsFile, &api.ListRunsResponse{Runs: ToApiRuns(runs)}); err != nil {
+			return "", err
+		}
+	}
+
+	manifest := &api.BackupManifest{
+		Version:     backupVersion,
+		ExportedAt:  &timestamp.Timestamp{Seconds: s.resourceManager.GetTime().Now().Unix()},
+		IncludeRuns: includeRuns,
+	}
+	if err := addFile(backupManifestFile, manifest); err != nil {
+		return "", err
+	}
+	archive, err := util.ArchiveTgz(files)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to archive the backup")
+	}
+	return archive, nil
+}
+
+func (s *BackupServer) listAllPipelines() ([]model.Pipeline, error) {
+	var result []model.Pipeline
+	pageToken := ""
+	for {
+		paginationContext, err := ValidatePagination(pageToken, maxPageSize,
+			model.GetPipelineTablePrimaryKeyColumn(), "", pipelineModelFieldsBySortableAPIFields)
+		if err != nil {
+			return nil, err
+		}
+		pipelines, nextPageToken, err := s.resourceManager.ListPipelines(&common.FilterContext{}, paginationContext)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to list the pipelines")
+		}
+		result = append(result, pipelines...)
+		if nextPageToken == "" {
+			return result, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func (s *BackupServer) listAllExperiments() ([]model.Experiment, error) {
+	var result []model.Experiment
+	pageToken := ""
+	for {
+		paginationContext, err := ValidatePagination(pageToken, maxPageSize,
+			model.GetExperimentTablePrimaryKeyColumn(), "", experimentModelFieldsBySortableAPIFields)
+		if err != nil {
+			return nil, err
+		}
+		experiments, nextPageToken, err := s.resourceManager.ListExperiments(&common.FilterContext{}, paginationContext)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to list the experiments")
+		}
+		result = append(result, experiments...)
+		if nextPageToken == "" {
+			return result, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func (s *BackupServer) listAllJobs() ([]model.Job, error) {
+	var result []model.Job
+	pageToken := ""
+	for {
+		paginationContext, err := ValidatePagination(pageToken, maxPageSize,
+			model.GetJobTablePrimaryKeyColumn(), "", jobModelFieldsBySortableAPIFields)
+		if err != nil {
+			return nil, err
+		}
+		jobs, nextPageToken, err := s.resourceManager.ListJobs(&common.FilterContext{}, paginationContext, common.FullView)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to list the jobs")
+		}
+		result = append(result, jobs...)
+		if nextPageToken == "" {
+			return result, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+func (s *BackupServer) listAllRuns() ([]model.Run, error) {
+	var result []model.Run
+	pageToken := ""
+	for {
+		paginationContext, err := ValidatePagination(pageToken, maxPageSize,
+			model.GetRunTablePrimaryKeyColumn(), "", runModelFieldsBySortableAPIFields)
+		if err != nil {
+			return nil, err
+		}
+		runs, nextPageToken, err := s.resourceManager.ListRuns(&common.FilterContext{}, paginationContext, common.FullView)
+		if err != nil {
+			return nil, util.Wrap(err, "Failed to list the runs")
+		}
+		result = append(result, runs...)
+		if nextPageToken == "" {
+			return result, nil
+		}
+		pageToken = nextPageToken
+	}
+}
+
+// ImportBackup is the HTTP multipart endpoint restoring an archive exported by
+// ExportBackup:
+//
+//	POST /apis/v1beta1/backup:import?disable_jobs=true
+//
+// The resources get new IDs, and the references between them are remapped to these IDs.
+// A resource failing to be imported doesn't stop the import, its error is returned
+// along with the mapping from the exported IDs to the new ones.
+func (s *BackupServer) ImportBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeErrorToResponse(w, http.StatusMethodNotAllowed,
+			util.NewInvalidInputError("Backups must be imported with POST, not %v", r.Method))
+		return
+	}
+	disableJobs, err := parseBoolQueryString(r, DisableJobsQueryStringKey)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	file, _, err := r.FormFile(FormFileKey)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Failed to read the backup form file"))
+		return
+	}
+	defer file.Close()
+	archive, err := ioutil.ReadAll(http.MaxBytesReader(w, file, maxBackupBytes))
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest,
+			util.NewInvalidInputError("Failed to read the backup, it can't be larger than %v bytes: %v",
+				maxBackupBytes, err))
+		return
+	}
+	files, err := util.ExtractTgz(string(archive))
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest,
+			util.NewInvalidInputError("The backup isn't a valid tar.gz archive: %v", err))
+		return
+	}
+	response, err := s.importBackup(files, disableJobs)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	content, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(response)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusInternalServerError,
+			util.NewInternalServerError(err, "Failed to marshal the result of the import"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(content))
+}
+
+func (s *BackupServer) importBackup(files map[string]string, disableJobs bool) (*api.ImportBackupResponse, error) {
+	manifest := &api.BackupManifest{}
+	if err := readBackupFile(files, backupManifestFile, manifest, true); err != nil {
+		return nil, err
+	}
+	if manifest.Version != backupVersion {
+		return nil, util.NewInvalidInputError("Unsupported backup version %v. Expected %v",
+			manifest.Version, backupVersion)
+	}
+	pipelines := &api.ListPipelinesResponse{}
+	experiments := &api.ListExperimentsResponse{}
+	jobs := &api.ListJobsResponse{}
+	runs := &api.ListRunsResponse{}
+	for name, message := range map[string]proto.Message{
+		backupPipelinesFile:   pipelines,
+		backupExperimentsFile: experiments,
+		backupJobsFile:        jobs,
+	} {
+		if err := readBackupFile(files, name, message, true); err != nil {
+			return nil, err
+		}
+	}
+	if err := readBackupFile(files, backupRunsFile, runs, manifest.IncludeRuns); err != nil {
+		return nil, err
+	}
+
+	response := &api.ImportBackupResponse{
+		PipelineIds:   make(map[string]string),
+		ExperimentIds: make(map[string]string),
+		JobIds:        make(map[string]string),
+		RunIds:        make(map[string]string),
+	}
+	addError := func(err error, format string, a ...interface{}) {
+		response.Errors = append(response.Errors, fmt.Sprintf("%v: %v", fmt.Sprintf(format, a...), err.Error()))
+	}
+
+	for _, pipeline := range pipelines.Pipelines {
+		template, ok := files[backupPipelineTemplateFile(pipeline.Id)]
+		if !ok {
+			addError(util.NewInvalidInputError("Missing %v", backupPipelineTemplateFile(pipeline.Id)),
+				"Failed to import pipeline %v", pipeline.Id)
+			continue
+		}
+		newPipeline, err := s.resourceManager.CreatePipeline(pipeline.Name, pipeline.Description, []byte(template))
+		if err != nil {
+			addError(err, "Failed to import pipeline %v", pipeline.Id)
+			continue
+		}
+		response.PipelineIds[pipeline.Id] = newPipeline.UUID
+	}
+
+	for _, experiment := range experiments.Experiments {
+		newExperiment, err := s.resourceManager.CreateExperiment(&model.Experiment{
+			Name:        experiment.Name,
+			Description: experiment.Description,
+		})
+		if err != nil {
+			addError(err, "Failed to import experiment %v", experiment.Id)
+			continue
+		}
+		response.ExperimentIds[experiment.Id] = newExperiment.UUID
+	}
+
+	for _, job := range jobs.Jobs {
+		exportedId := job.Id
+		job.Id = ""
+		job.PipelineSpec = remapPipelineSpec(job.PipelineSpec, response.PipelineIds)
+		job.ResourceReferences = remapResourceReferences(job.ResourceReferences, response)
+		if disableJobs {
+			job.Enabled = false
+		}
+		newJob, err := s.resourceManager.CreateJob(job, "")
+		if err != nil {
+			addError(err, "Failed to import job %v", exportedId)
+			continue
+		}
+		response.JobIds[exportedId] = newJob.UUID
+	}
+
+	for _, run := range runs.Runs {
+		exportedId := run.Id
+		run.PipelineSpec = remapPipelineSpec(run.PipelineSpec, response.PipelineIds)
+		run.ResourceReferences = remapResourceReferences(run.ResourceReferences, response)
+		newRun, err := s.resourceManager.ImportRun(run, files[backupRunWorkflowFile(exportedId)])
+		if err != nil {
+			addError(err, "Failed to import run %v", exportedId)
+			continue
+		}
+		response.RunIds[exportedId] = newRun.UUID
+	}
+	return response, nil
+}
+
+// remapPipelineSpec points a pipeline spec to the imported pipeline. If the pipeline
+// wasn't imported, the spec falls back to its workflow manifest.
+func remapPipelineSpec(spec *api.PipelineSpec, pipelineIds map[string]string) *api.PipelineSpec {
+	if spec == nil || spec.PipelineId == "" {
+		return spec
+	}
+	spec.PipelineId = pipelineIds[spec.PipelineId]
+	return spec
+}
+
+// remapResourceReferences points the references to the imported experiments and jobs,
+// dropping the ones to resources which weren't imported.
+func remapResourceReferences(references []*api.ResourceReference,
+	response *api.ImportBackupResponse) []*api.ResourceReference {
+	var result []*api.ResourceReference
+	for _, reference := range references {
+		var ids map[string]string
+		switch reference.GetKey().GetType() {
+		case api.ResourceType_EXPERIMENT:
+			ids = response.ExperimentIds
+		case api.ResourceType_JOB:
+			ids = response.JobIds
+		default:
+			continue
+		}
+		newId, ok := ids[reference.Key.Id]
+		if !ok {
+			continue
+		}
+		result = append(result, &api.ResourceReference{
+			Key:          &api.ResourceKey{Type: reference.Key.Type, Id: newId},
+			Relationship: reference.Relationship,
+		})
+	}
+	return result
+}
+
+func readBackupFile(files map[string]string, name string, message proto.Message, required bool) error {
+	content, ok := files[name]
+	if !ok {
+		if required {
+			return util.NewInvalidInputError("The backup is missing %v", name)
+		}
+		return nil
+	}
+	if err := (&jsonpb.Unmarshaler{AllowUnknownFields: true}).Unmarshal(strings.NewReader(content), message); err != nil {
+		return util.NewInvalidInputError("Failed to parse %v of the backup: %v", name, err)
+	}
+	return nil
+}
+
+func backupPipelineTemplateFile(pipelineId string) string {
+	return fmt.Sprintf("pipeline-%v.yaml", pipelineId)
+}
+
+func backupRunWorkflowFile(runId string) string {
+	return fmt.Sprintf("run-%v.json", runId)
+}
+
+func parseBoolQueryString(r *http.Request, key string) (bool, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return false, nil
+	}
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, util.NewInvalidInputError("Invalid value '%v' of %v, expected a boolean", value, key)
+	}
+	return result, nil
+}
+
+func (s *BackupServer) writeErrorToResponse(w http.ResponseWriter, code int, err error) {
+	glog.Errorf("Failed to export or import the backup. Error: %+v", err)
+	w.WriteHeader(code)
+	errorResponse := api.Error{ErrorMessage: err.Error(), ErrorDetails: fmt.Sprintf("%+v", err)}
+	errBytes, err := json.Marshal(errorResponse)
+	if err != nil {
+		w.Write([]byte("Error exporting or importing the backup"))
+	}
+	w.Write(errBytes)
+}
+
+func NewBackupServer(resourceManager *resource.ResourceManager) *BackupServer {
+	return &BackupServer{resourceManager: resourceManager}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBackupTestResourceManager(t *testing.T) (*resource.FakeClientManager, *resource.ResourceManager) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	return clientManager, resource.NewResourceManager(clientManager)
+}
+
+func exportBackupForTest(t *testing.T, server *BackupServer, query string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", BackupExportPath+query, nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(server.ExportBackup).ServeHTTP(rr, req)
+	return rr
+}
+
+func importBackupForTest(t *testing.T, server *BackupServer, query string, archive []byte) *httptest.ResponseRecorder {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	part, _ := w.CreateFormFile(FormFileKey, "backup.tar.gz")
+	io.Copy(part, bytes.NewReader(archive))
+	w.Close()
+	req, _ := http.NewRequest("POST", BackupImportPath+query, bytes.NewReader(b.Bytes()))
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(server.ImportBackup).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestExportImportBackup(t *testing.T) {
+	sourceClients, sourceManager := newBackupTestResourceManager(t)
+	defer sourceClients.Close()
+	pipeline, err := sourceManager.CreatePipeline("pipeline", "description", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	experiment, err := sourceManager.CreateExperiment(&model.Experiment{Name: "experiment"})
+	assert.Nil(t, err)
+	experimentReference := []*api.ResourceReference{{
+		Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+		Relationship: api.Relationship_OWNER,
+	}}
+	job, err := sourceManager.CreateJob(&api.Job{
+		Name:           "job",
+		Enabled:        true,
+		MaxConcurrency: 1,
+		Trigger: &api.Trigger{Trigger: &api.Trigger_CronSchedule{CronSchedule: &api.CronSchedule{
+			StartTime: &timestamp.Timestamp{Seconds: 1}, Cron: "1 * * * *"}}},
+		PipelineSpec:       &api.PipelineSpec{PipelineId: pipeline.UUID},
+		ResourceReferences: experimentReference,
+	}, "")
+	assert.Nil(t, err)
+	run, err := sourceManager.CreateRun(&api.Run{
+		Name:               "run",
+		PipelineSpec:       &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ResourceReferences: experimentReference,
+	}, "")
+	assert.Nil(t, err)
+	metric := &api.RunMetric{Name: "accuracy", NodeId: "node1", Value: &api.RunMetric_NumberValue{NumberValue: 0.9}}
+	assert.Nil(t, sourceManager.ReportMetric(metric, run.UUID))
+
+	rr := exportBackupForTest(t, NewBackupServer(sourceManager), "?include_runs=true")
+	assert.Equal(t, 200, rr.Code, rr.Body.String())
+	assert.Equal(t, "application/gzip", rr.Header().Get("Content-Type"))
+	archive := rr.Body.Bytes()
+
+	targetClients, targetManager := newBackupTestResourceManager(t)
+	defer targetClients.Close()
+	rr = importBackupForTest(t, NewBackupServer(targetManager), "?disable_jobs=true", archive)
+	assert.Equal(t, 200, rr.Code, rr.Body.String())
+	response := &api.ImportBackupResponse{}
+	assert.Nil(t, jsonpb.Unmarshal(rr.Body, response))
+	assert.Empty(t, response.Errors)
+
+	newPipelineId := response.PipelineIds[pipeline.UUID]
+	newPipeline, err := targetManager.GetPipeline(newPipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, "pipeline", newPipeline.Name)
+	assert.Equal(t, "description", newPipeline.Description)
+	template, err := targetManager.GetPipelineTemplate(newPipelineId)
+	assert.Nil(t, err)
+	assert.Equal(t, testWorkflow.ToStringForStore(), string(template))
+
+	newExperimentId := response.ExperimentIds[experiment.UUID]
+	newExperiment, err := targetManager.GetExperiment(newExperimentId)
+	assert.Nil(t, err)
+	assert.Equal(t, "experiment", newExperiment.Name)
+
+	newJob, err := targetManager.GetJob(response.JobIds[job.UUID])
+	assert.Nil(t, err)
+	assert.Equal(t, "job", newJob.DisplayName)
+	assert.Equal(t, newPipelineId, newJob.PipelineId)
+	assert.False(t, newJob.Enabled)
+	assert.Equal(t, newExperimentId, newJob.ResourceReferences[0].ReferenceUUID)
+
+	newRun, err := targetManager.GetRun(response.RunIds[run.UUID])
+	assert.Nil(t, err)
+	assert.Equal(t, "run", newRun.DisplayName)
+	assert.Equal(t, run.WorkflowRuntimeManifest, newRun.WorkflowRuntimeManifest)
+	assert.Equal(t, newExperimentId, newRun.ResourceReferences[0].ReferenceUUID)
+	assert.Len(t, newRun.Metrics, 1)
+	assert.Equal(t, "accuracy", newRun.Metrics[0].Name)
+	assert.Equal(t, 0.9, newRun.Metrics[0].NumberValue)
+}
+
+func TestExportBackup_ExcludesRunsByDefault(t *testing.T) {
+	clients, manager, _ := initWithOneTimeRun(t)
+	defer clients.Close()
+	rr := exportBackupForTest(t, NewBackupServer(manager), "")
+	assert.Equal(t, 200, rr.Code, rr.Body.String())
+	files, err := util.ExtractTgz(rr.Body.String())
+	assert.Nil(t, err)
+	assert.Contains(t, files, backupManifestFile)
+	assert.Contains(t, files, backupExperimentsFile)
+	assert.NotContains(t, files, backupRunsFile)
+}
+
+func TestExportBackup_InvalidIncludeRuns(t *testing.T) {
+	clients, manager := newBackupTestResourceManager(t)
+	defer clients.Close()
+	rr := exportBackupForTest(t, NewBackupServer(manager), "?include_runs=maybe")
+	assert.Equal(t, 400, rr.Code)
+	assert.Contains(t, rr.Body.String(), "include_runs")
+}
+
+func TestImportBackup_UnsupportedVersion(t *testing.T) {
+	clients, manager := newBackupTestResourceManager(t)
+	defer clients.Close()
+	archive, err := util.ArchiveTgz(map[string]string{backupManifestFile: `{"version": 2}`})
+	assert.Nil(t, err)
+	rr := importBackupForTest(t, NewBackupServer(manager), "", []byte(archive))
+	assert.Equal(t, 400, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Unsupported backup version 2")
+}
+
+func TestImportBackup_NotAnArchive(t *testing.T) {
+	clients, manager := newBackupTestResourceManager(t)
+	defer clients.Close()
+	rr := importBackupForTest(t, NewBackupServer(manager), "", []byte("not an archive"))
+	assert.Equal(t, 400, rr.Code)
+	assert.Contains(t, rr.Body.String(), "isn't a valid tar.gz archive")
+}
+
+func TestImportBackup_ReportsFailedResources(t *testing.T) {
+	clients, manager := newBackupTestResourceManager(t)
+	defer clients.Close()
+	archive, err := util.ArchiveTgz(map[string]string{
+		backupManifestFile:    `{"version": 1}`,
+		backupPipelinesFile:   `{"pipelines": [{"id": "p1", "name": "pipeline"}]}`,
+		backupExperimentsFile: `{"experiments": [{"id": "e1", "name": "experiment"}]}`,
+		backupJobsFile:        `{"jobs": [{"id": "j1", "name": "job", "pipeline_spec": {"pipeline_id": "p1"}}]}`,
+	})
+	assert.Nil(t, err)
+	rr := importBackupForTest(t, NewBackupServer(manager), "", []byte(archive))
+	assert.Equal(t, 200, rr.Code, rr.Body.String())
+	response := &api.ImportBackupResponse{}
+	assert.Nil(t, jsonpb.Unmarshal(strings.NewReader(rr.Body.String()), response))
+	assert.Len(t, response.ExperimentIds, 1)
+	assert.Empty(t, response.PipelineIds)
+	assert.Empty(t, response.JobIds)
+	assert.Len(t, response.Errors, 2)
+	assert.Contains(t, response.Errors[0], "Failed to import pipeline p1")
+	assert.Contains(t, response.Errors[1], "Failed to import job j1")
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/glog"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/backup"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	BackupSnapshotPath  = "/apis/v1beta1/backup:snapshot"
+	BackupSnapshotsPath = "/apis/v1beta1/backup:snapshots"
+
+	LimitQueryStringKey  = "limit"
+	defaultSnapshotLimit = 20
+)
+
+// BackupSnapshot is the JSON form of the marker of a point-in-time backup.
+type BackupSnapshot struct {
+	Id             string `json:"id"`
+	CreatedAtInSec int64  `json:"created_at_in_sec"`
+	Status         string `json:"status"`
+	ManifestPath   string `json:"manifest_path"`
+	FileCount      int64  `json:"file_count"`
+	Message        string `json:"message,omitempty"`
+}
+
+type ListBackupSnapshotsResponse struct {
+	Snapshots []*BackupSnapshot `json:"snapshots"`
+}
+
+type BackupSnapshotServer struct {
+	coordinator *backup.Coordinator
+}
+
+// TakeSnapshot is the admin HTTP endpoint taking a point-in-time backup of the database
+// and the object store, and returning its marker:
+//
+//	POST /apis/v1beta1/backup:snapshot
+//
+// The writes are rejected as unavailable if they wait for the snapshot for too long.
+func (s *BackupSnapshotServer) TakeSnapshot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.writeErrorToResponse(w, http.StatusMethodNotAllowed,
+			util.NewInvalidInputError("Snapshots must be taken with POST, not %v", r.Method))
+		return
+	}
+	marker, err := s.coordinator.TakeSnapshot(r.Context())
+	if err != nil {
+		s.writeErrorToResponse(w, httpStatusFromError(err), util.Wrap(err, "Failed to take the snapshot"))
+		return
+	}
+	s.writeResponse(w, toBackupSnapshot(marker))
+}
+
+// ListSnapshots is the admin HTTP endpoint listing the markers of the latest backups:
+//
+//	GET /apis/v1beta1/backup:snapshots?limit=20
+func (s *BackupSnapshotServer) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.writeErrorToResponse(w, http.StatusMethodNotAllowed,
+			util.NewInvalidInputError("Snapshots must be listed with GET, not %v", r.Method))
+		return
+	}
+	limit := defaultSnapshotLimit
+	if value := r.URL.Query().Get(LimitQueryStringKey); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			s.writeErrorToResponse(w, http.StatusBadRequest,
+				util.NewInvalidInputError("Invalid limit %v. It must be a positive integer", value))
+			return
+		}
+	}
+	markers, err := s.coordinator.ListSnapshots(limit)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusInternalServerError, util.Wrap(err, "Failed to list the snapshots"))
+		return
+	}
+	response := &ListBackupSnapshotsResponse{Snapshots: []*BackupSnapshot{}}
+	for _, marker := range markers {
+		response.Snapshots = append(response.Snapshots, toBackupSnapshot(marker))
+	}
+	s.writeResponse(w, response)
+}
+
+func toBackupSnapshot(marker *model.BackupMarker) *BackupSnapshot {
+	return &BackupSnapshot{
+		Id:             marker.UUID,
+		CreatedAtInSec: marker.CreatedAtInSec,
+		Status:         string(marker.Status),
+		ManifestPath:   marker.ManifestPath,
+		FileCount:      marker.FileCount,
+		Message:        marker.Message,
+	}
+}
+
+func (s *BackupSnapshotServer) writeResponse(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		glog.Errorf("Failed to write the snapshots. Error: %v", err)
+	}
+}
+
+func (s *BackupSnapshotServer) writeErrorToResponse(w http.ResponseWriter, code int, err error) {
+	glog.Errorf("Failed to take or list the snapshots. Error: %+v", err)
+	w.WriteHeader(code)
+	errorResponse := api.Error{ErrorMessage: err.Error(), ErrorDetails: fmt.Sprintf("%+v", err)}
+	errBytes, err := json.Marshal(errorResponse)
+	if err != nil {
+		w.Write([]byte("Error taking or listing the snapshots"))
+	}
+	w.Write(errBytes)
+}
+
+func NewBackupSnapshotServer(coordinator *backup.Coordinator) *BackupSnapshotServer {
+	return &BackupSnapshotServer{coordinator: coordinator}
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/backup"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupSnapshotServer(t *testing.T) {
+	db := storage.NewFakeDbOrFatal()
+	defer db.Close()
+	snapshot := func(ctx context.Context, backupId string) (string, error) {
+		return "dumped", nil
+	}
+	coordinator := backup.NewCoordinator(backup.NewWriteGate(time.Minute), storage.NewBackupMarkerStore(db),
+		storage.NewFakeObjectStore(), snapshot, time.Minute, util.NewFakeTimeForEpoch(),
+		util.NewFakeUUIDGeneratorOrFatal(resource.DefaultFakeUUID, nil))
+	server := NewBackupSnapshotServer(coordinator)
+
+	req, _ := http.NewRequest("POST", BackupSnapshotPath, nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(server.TakeSnapshot).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	expected := &BackupSnapshot{
+		Id:             resource.DefaultFakeUUID,
+		CreatedAtInSec: 1,
+		Status:         "SUCCEEDED",
+		ManifestPath:   "backups/" + resource.DefaultFakeUUID + "/manifest.json",
+		Message:        "dumped",
+	}
+	var snapshotResponse BackupSnapshot
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &snapshotResponse))
+	assert.Equal(t, expected, &snapshotResponse)
+
+	req, _ = http.NewRequest("GET", BackupSnapshotsPath+"?limit=5", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(server.ListSnapshots).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var listResponse ListBackupSnapshotsResponse
+	assert.Nil(t, json.Unmarshal(rr.Body.Bytes(), &listResponse))
+	assert.Equal(t, []*BackupSnapshot{expected}, listResponse.Snapshots)
+
+	req, _ = http.NewRequest("GET", BackupSnapshotsPath+"?limit=-1", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(server.ListSnapshots).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	req, _ = http.NewRequest("GET", BackupSnapshotPath, nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(server.TakeSnapshot).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
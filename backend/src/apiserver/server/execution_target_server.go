@@ -0,0 +1,88 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type ExecutionTargetServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+func (s *ExecutionTargetServer) CreateExecutionTarget(ctx context.Context, request *api.CreateExecutionTargetRequest) (
+	*api.ExecutionTarget, error) {
+	err := ValidateCreateExecutionTargetRequest(request)
+	if err != nil {
+		return nil, util.Wrap(err, "Validate execution target request failed.")
+	}
+	newTarget, err := s.resourceManager.CreateExecutionTarget(ToModelExecutionTarget(request.ExecutionTarget))
+	if err != nil {
+		return nil, util.Wrap(err, "Create execution target failed.")
+	}
+	return ToApiExecutionTarget(newTarget), nil
+}
+
+func (s *ExecutionTargetServer) GetExecutionTarget(ctx context.Context, request *api.GetExecutionTargetRequest) (
+	*api.ExecutionTarget, error) {
+	target, err := s.resourceManager.GetExecutionTarget(request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Get execution target failed.")
+	}
+	return ToApiExecutionTarget(target), nil
+}
+
+func (s *ExecutionTargetServer) ListExecutionTargets(ctx context.Context, request *api.ListExecutionTargetsRequest) (
+	*api.ListExecutionTargetsResponse, error) {
+	targets, err := s.resourceManager.ListExecutionTargets()
+	if err != nil {
+		return nil, util.Wrap(err, "List execution targets failed.")
+	}
+	return &api.ListExecutionTargetsResponse{ExecutionTargets: ToApiExecutionTargets(targets)}, nil
+}
+
+func (s *ExecutionTargetServer) DeleteExecutionTarget(ctx context.Context, request *api.DeleteExecutionTargetRequest) (
+	*empty.Empty, error) {
+	err := s.resourceManager.DeleteExecutionTarget(request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Delete execution target failed.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func ValidateCreateExecutionTargetRequest(request *api.CreateExecutionTargetRequest) error {
+	target := request.ExecutionTarget
+	if target == nil || target.Name == "" {
+		return util.NewInvalidInputError("Execution target name is empty. Please specify a valid name.")
+	}
+	if target.Namespace == "" {
+		return util.NewInvalidInputError(
+			"Execution target namespace is empty. Please specify the namespace the workflows are created in.")
+	}
+	if target.KubeconfigSecretName == "" {
+		return util.NewInvalidInputError(
+			"Execution target Secret is empty. Please specify the Secret holding the kubeconfig of the cluster.")
+	}
+	return nil
+}
+
+func NewExecutionTargetServer(resourceManager *resource.ResourceManager) *ExecutionTargetServer {
+	return &ExecutionTargetServer{resourceManager: resourceManager}
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newExecutionTargetServerForTest() (*resource.FakeClientManager, *ExecutionTargetServer) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	clientManager.SetSecret("kubeconfig-east", map[string]string{util.LabelKeyExecutionTargetKubeconfig: "true"},
+		map[string][]byte{"kubeconfig": []byte("apiVersion: v1")})
+	resourceManager := resource.NewResourceManager(clientManager)
+	return clientManager, &ExecutionTargetServer{resourceManager: resourceManager}
+}
+
+func validExecutionTarget() *api.ExecutionTarget {
+	return &api.ExecutionTarget{
+		Name:                 "east",
+		Namespace:            "kubeflow",
+		KubeconfigSecretName: "kubeconfig-east",
+	}
+}
+
+func TestCreateExecutionTarget(t *testing.T) {
+	clientManager, server := newExecutionTargetServerForTest()
+	defer clientManager.Close()
+
+	result, err := server.CreateExecutionTarget(nil,
+		&api.CreateExecutionTargetRequest{ExecutionTarget: validExecutionTarget()})
+	assert.Nil(t, err)
+	expectedTarget := &api.ExecutionTarget{
+		Name:                 "east",
+		Namespace:            "kubeflow",
+		KubeconfigSecretName: "kubeconfig-east",
+		KubeconfigSecretKey:  "kubeconfig",
+		CreatedAt:            &timestamp.Timestamp{Seconds: 1},
+	}
+	assert.Equal(t, expectedTarget, result)
+
+	result, err = server.GetExecutionTarget(nil, &api.GetExecutionTargetRequest{Name: "east"})
+	assert.Nil(t, err)
+	assert.Equal(t, expectedTarget, result)
+	list, err := server.ListExecutionTargets(nil, &api.ListExecutionTargetsRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.ExecutionTarget{expectedTarget}, list.ExecutionTargets)
+}
+
+func TestCreateExecutionTarget_SecretNotFound(t *testing.T) {
+	clientManager, server := newExecutionTargetServerForTest()
+	defer clientManager.Close()
+	target := validExecutionTarget()
+	target.KubeconfigSecretName = "not-exist"
+
+	_, err := server.CreateExecutionTarget(nil, &api.CreateExecutionTargetRequest{ExecutionTarget: target})
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestValidateCreateExecutionTargetRequest(t *testing.T) {
+	assert.Nil(t, ValidateCreateExecutionTargetRequest(
+		&api.CreateExecutionTargetRequest{ExecutionTarget: validExecutionTarget()}))
+
+	noName := validExecutionTarget()
+	noName.Name = ""
+	noNamespace := validExecutionTarget()
+	noNamespace.Namespace = ""
+	noSecret := validExecutionTarget()
+	noSecret.KubeconfigSecretName = ""
+
+	for _, target := range []*api.ExecutionTarget{nil, noName, noNamespace, noSecret} {
+		err := ValidateCreateExecutionTargetRequest(&api.CreateExecutionTargetRequest{ExecutionTarget: target})
+		assert.NotNil(t, err)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	}
+}
+
+func TestDeleteExecutionTarget(t *testing.T) {
+	clientManager, server := newExecutionTargetServerForTest()
+	defer clientManager.Close()
+	server.CreateExecutionTarget(nil, &api.CreateExecutionTargetRequest{ExecutionTarget: validExecutionTarget()})
+
+	_, err := server.DeleteExecutionTarget(nil, &api.DeleteExecutionTargetRequest{Name: "east"})
+	assert.Nil(t, err)
+	_, err = server.GetExecutionTarget(nil, &api.GetExecutionTargetRequest{Name: "east"})
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
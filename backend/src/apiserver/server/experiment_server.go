@@ -4,6 +4,7 @@ import (
 	"context"
 
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
@@ -43,7 +44,12 @@ func (s *ExperimentServer) ListExperiment(ctx context.Context, request *api.List
 	if err != nil {
 		return nil, util.Wrap(err, "List experiments failed.")
 	}
-	experiments, nextPageToken, err := s.resourceManager.ListExperiments(paginationContext)
+	nameFilter, err := ValidateNameFilter(request.NameFilter)
+	if err != nil {
+		return nil, util.Wrap(err, "List experiments failed.")
+	}
+	experiments, nextPageToken, err := s.resourceManager.ListExperiments(
+		&common.FilterContext{Name: nameFilter}, paginationContext)
 	if err != nil {
 		return nil, util.Wrap(err, "List experiments failed.")
 	}
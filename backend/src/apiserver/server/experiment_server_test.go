@@ -83,6 +83,32 @@ func TestListExperiment(t *testing.T) {
 	assert.Equal(t, expectedExperiment, result.Experiments)
 }
 
+func TestListExperiment_Pagination(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	server := NewExperimentServer(resource.NewResourceManager(clientManager))
+	for _, name := range []string{"ex1", "ex2", "ex3"} {
+		_, err := server.CreateExperiment(nil, &api.CreateExperimentRequest{Experiment: &api.Experiment{Name: name}})
+		assert.Nil(t, err)
+	}
+
+	result, err := server.ListExperiment(nil, &api.ListExperimentsRequest{PageSize: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.Experiment{
+		{Id: "00000000-0000-0000-0000-000000000001", Name: "ex1"},
+		{Id: "00000000-0000-0000-0000-000000000002", Name: "ex2"},
+	}, result.Experiments)
+	assert.NotEmpty(t, result.NextPageToken)
+
+	result, err = server.ListExperiment(nil, &api.ListExperimentsRequest{PageSize: 2, PageToken: result.NextPageToken})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.Experiment{
+		{Id: "00000000-0000-0000-0000-000000000003", Name: "ex3"},
+	}, result.Experiments)
+	assert.Empty(t, result.NextPageToken)
+}
+
 func TestListExperiment_Failed(t *testing.T) {
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	resourceManager := resource.NewResourceManager(clientManager)
@@ -19,6 +19,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
@@ -34,11 +35,21 @@ func (s *JobServer) CreateJob(ctx context.Context, request *api.CreateJobRequest
 	if err != nil {
 		return nil, err
 	}
-	newJob, err := s.resourceManager.CreateJob(request.Job)
+	warnings, err := s.resourceManager.CheckPipelineDeprecation(request.Job.GetPipelineSpec())
 	if err != nil {
 		return nil, err
 	}
-	return ToApiJob(newJob), nil
+	intervalWarnings, err := s.resourceManager.CheckJobInterval(request.Job)
+	if err != nil {
+		return nil, err
+	}
+	newJob, err := s.resourceManager.CreateJob(request.Job, common.GetRequestId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	apiJob := ToApiJob(newJob)
+	apiJob.Warnings = append(warnings, intervalWarnings...)
+	return apiJob, nil
 }
 
 func (s *JobServer) GetJob(ctx context.Context, request *api.GetJobRequest) (*api.Job, error) {
@@ -60,7 +71,14 @@ func (s *JobServer) ListJobs(ctx context.Context, request *api.ListJobsRequest)
 	if err != nil {
 		return nil, util.Wrap(err, "Validating filter failed.")
 	}
-	jobs, nextPageToken, err := s.resourceManager.ListJobs(filterContext, paginationContext)
+	if filterContext.Name, err = ValidateNameFilter(request.NameFilter); err != nil {
+		return nil, util.Wrap(err, "Validating filter failed.")
+	}
+	view := common.BasicView
+	if request.View == api.ListJobsRequest_FULL {
+		view = common.FullView
+	}
+	jobs, nextPageToken, err := s.resourceManager.ListJobs(filterContext, paginationContext, view)
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to list jobs.")
 	}
@@ -85,6 +103,9 @@ func (s *JobServer) DeleteJob(ctx context.Context, request *api.DeleteJobRequest
 
 func (s *JobServer) validateCreateJobRequest(request *api.CreateJobRequest) error {
 	job := request.Job
+	if _, err := util.ValidateName(job.Name, util.MaxNameLength); err != nil {
+		return util.Wrap(err, "Invalid job name.")
+	}
 	// Job must be created under an experiment.
 	if err := ValidateExperimentResourceReference(s.resourceManager, job.ResourceReferences); err != nil {
 		return util.Wrap(err, "The job must have a valid experiment resource reference.")
@@ -1,13 +1,18 @@
 package server
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestValidateApiJob(t *testing.T) {
@@ -169,3 +174,58 @@ func TestValidateApiJob_NegativeIntervalSecond(t *testing.T) {
 	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
 	assert.Contains(t, err.Error(), "The max concurrency of the job is out of range")
 }
+
+func TestCreateJob_IntervalShorterThanRuns(t *testing.T) {
+	clients, manager, experiment := initWithExperiment(t)
+	defer clients.Close()
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	pastWorkflow := testWorkflow.DeepCopy()
+	pastWorkflow.Status.StartedAt = metav1.NewTime(time.Unix(0, 0))
+	pastWorkflow.Status.FinishedAt = metav1.NewTime(time.Unix(0, 0).Add(time.Hour))
+	_, err = clients.RunStore().CreateRun(&model.RunDetail{
+		Run: model.Run{UUID: "past", Name: "past", Conditions: "Succeeded",
+			PipelineSpec: model.PipelineSpec{PipelineId: pipeline.UUID}},
+		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: util.NewWorkflow(pastWorkflow).ToStringForStore()},
+	})
+	assert.Nil(t, err)
+
+	server := NewJobServer(manager)
+	job, err := server.CreateJob(context.Background(), &api.CreateJobRequest{Job: &api.Job{
+		Name:           "name1",
+		Enabled:        true,
+		MaxConcurrency: 1,
+		Trigger: &api.Trigger{Trigger: &api.Trigger_PeriodicSchedule{
+			PeriodicSchedule: &api.PeriodicSchedule{IntervalSecond: 600}}},
+		PipelineSpec: &api.PipelineSpec{PipelineId: pipeline.UUID},
+		ResourceReferences: []*api.ResourceReference{
+			{Key: &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID}, Relationship: api.Relationship_OWNER},
+		},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"The interval of the job, 10m0s, is shorter than the typical duration of the runs " +
+		"of its pipeline, 1h0m0s."}, job.Warnings)
+}
+
+func TestCreateJob_RequestId(t *testing.T) {
+	clients, manager, experiment := initWithExperiment(t)
+	defer clients.Close()
+	server := NewJobServer(manager)
+	ctx := common.WithRequestId(context.Background(), "request-1")
+	job, err := server.CreateJob(ctx, &api.CreateJobRequest{Job: &api.Job{
+		Name:           "name1",
+		Enabled:        true,
+		MaxConcurrency: 1,
+		PipelineSpec:   &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ResourceReferences: []*api.ResourceReference{
+			{Key: &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID}, Relationship: api.Relationship_OWNER},
+		},
+	}})
+	assert.Nil(t, err)
+
+	storedJob, err := manager.GetJob(job.Id)
+	assert.Nil(t, err)
+	scheduledWorkflow, err := clients.ScheduledWorkflow().Get(storedJob.Name, metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "request-1", scheduledWorkflow.Annotations[util.AnnotationKeyRequestId])
+}
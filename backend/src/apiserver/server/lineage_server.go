@@ -0,0 +1,58 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type LineageServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+func (s *LineageServer) GetRunLineage(ctx context.Context, request *api.GetRunLineageRequest) (
+	*api.RunLineage, error) {
+	if request.RunId == "" {
+		return nil, util.NewInvalidInputError("Run ID is empty. Please specify a valid ID.")
+	}
+	lineage, err := s.resourceManager.GetRunLineage(request.RunId)
+	if err != nil {
+		return nil, util.Wrap(err, "Get run lineage failed.")
+	}
+	return ToApiRunLineage(lineage), nil
+}
+
+func (s *LineageServer) GetArtifactLineage(ctx context.Context, request *api.GetArtifactLineageRequest) (
+	*api.ArtifactLineage, error) {
+	if request.Uri == "" {
+		return nil, util.NewInvalidInputError("Artifact URI is empty. Please specify a valid URI.")
+	}
+	if request.Depth < 0 {
+		return nil, util.NewInvalidInputError("Depth must be a positive number, got %v.", request.Depth)
+	}
+	lineage, err := s.resourceManager.GetArtifactLineage(request.Uri, int(request.Depth))
+	if err != nil {
+		return nil, util.Wrap(err, "Get artifact lineage failed.")
+	}
+	return ToApiArtifactLineage(lineage), nil
+}
+
+func NewLineageServer(resourceManager *resource.ResourceManager) *LineageServer {
+	return &LineageServer{resourceManager: resourceManager}
+}
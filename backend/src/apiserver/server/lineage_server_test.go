@@ -0,0 +1,126 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetRunLineage(t *testing.T) {
+	clientManager, resourceManager, run := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{UID: types.UID(run.UUID)},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.NodeSucceeded,
+			Nodes: map[string]v1alpha1.NodeStatus{
+				"node1": {
+					ID:           "node1",
+					DisplayName:  "train",
+					TemplateName: "train-template",
+					Type:         v1alpha1.NodeTypePod,
+					Phase:        v1alpha1.NodeSucceeded,
+					StartedAt:    metav1.NewTime(time.Unix(10, 0)),
+					FinishedAt:   metav1.NewTime(time.Unix(20, 0)),
+					Outputs: &v1alpha1.Outputs{Artifacts: []v1alpha1.Artifact{{
+						Name: "model",
+						ArtifactLocation: v1alpha1.ArtifactLocation{
+							S3: &v1alpha1.S3Artifact{S3Bucket: v1alpha1.S3Bucket{Bucket: "mlpipeline"}, Key: "model.tgz"},
+						},
+					}}},
+				},
+			},
+		},
+	})
+	assert.Nil(t, clientManager.MetadataStore().RecordWorkflow(workflow))
+
+	server := NewLineageServer(resourceManager)
+	lineage, err := server.GetRunLineage(nil, &api.GetRunLineageRequest{RunId: run.UUID})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(lineage.Executions))
+	execution := lineage.Executions[0]
+	assert.Equal(t, &api.LineageExecution{
+		Id:           execution.Id,
+		NodeId:       "node1",
+		DisplayName:  "train",
+		TemplateName: "train-template",
+		State:        "COMPLETE",
+		StartedAt:    &timestamp.Timestamp{Seconds: 10},
+		FinishedAt:   &timestamp.Timestamp{Seconds: 20},
+	}, execution)
+	assert.Equal(t, 1, len(lineage.Artifacts))
+	artifact := lineage.Artifacts[0]
+	assert.Equal(t, &api.LineageArtifact{Id: artifact.Id, Name: "model", Uri: "s3://mlpipeline/model.tgz"}, artifact)
+	assert.Equal(t, []*api.LineageEvent{{
+		ExecutionId: execution.Id,
+		ArtifactId:  artifact.Id,
+		Type:        api.LineageEvent_OUTPUT,
+		Path:        "model",
+	}}, lineage.Events)
+}
+
+func TestGetRunLineage_RunNotFound(t *testing.T) {
+	clientManager, resourceManager, _ := initWithExperiment(t)
+	defer clientManager.Close()
+
+	server := NewLineageServer(resourceManager)
+	_, err := server.GetRunLineage(nil, &api.GetRunLineageRequest{RunId: "not-exist"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestGetRunLineage_EmptyRunId(t *testing.T) {
+	clientManager, resourceManager, _ := initWithExperiment(t)
+	defer clientManager.Close()
+
+	server := NewLineageServer(resourceManager)
+	_, err := server.GetRunLineage(nil, &api.GetRunLineageRequest{})
+	AssertUserError(t, err, codes.InvalidArgument)
+}
+
+func TestGetArtifactLineage(t *testing.T) {
+	clientManager, resourceManager, _ := initWithExperiment(t)
+	defer clientManager.Close()
+	assert.Nil(t, clientManager.ArtifactLineageStore().ReplaceArtifactEvents("run1", []*model.ArtifactEvent{{
+		RunUUID:      "run1",
+		NodeID:       "node1",
+		ArtifactName: "model",
+		Type:         model.ArtifactEventOutput,
+		ArtifactURI:  "s3://mlpipeline/model.tgz",
+		StepName:     "train",
+	}}))
+
+	server := NewLineageServer(resourceManager)
+	lineage, err := server.GetArtifactLineage(nil, &api.GetArtifactLineageRequest{Uri: "s3://mlpipeline/model.tgz"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ArtifactLineage{
+		Upstream: []*api.ArtifactLineageEdge{{
+			ArtifactUri:  "s3://mlpipeline/model.tgz",
+			ArtifactName: "model",
+			RunId:        "run1",
+			NodeId:       "node1",
+			StepName:     "train",
+			Type:         api.LineageEvent_OUTPUT,
+		}},
+		Downstream: []*api.ArtifactLineageEdge{},
+	}, lineage)
+}
+
+func TestGetArtifactLineage_InvalidRequest(t *testing.T) {
+	clientManager, resourceManager, _ := initWithExperiment(t)
+	defer clientManager.Close()
+
+	server := NewLineageServer(resourceManager)
+	_, err := server.GetArtifactLineage(nil, &api.GetArtifactLineageRequest{})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = server.GetArtifactLineage(nil, &api.GetArtifactLineageRequest{Uri: "s3://mlpipeline/model.tgz", Depth: -1})
+	AssertUserError(t, err, codes.InvalidArgument)
+}
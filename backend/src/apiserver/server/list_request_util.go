@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"strconv"
 	"strings"
+	"time"
 
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
@@ -28,8 +29,13 @@ import (
 const (
 	defaultPageSize = 20
 	maxPageSize     = 200
+	// How long the token of the next page of a list is valid.
+	pageTokenTTL = 24 * time.Hour
 )
 
+// The clock checking the expiry of the page tokens.
+var pageTokenTime = util.NewRealTime()
+
 var experimentModelFieldsBySortableAPIFields = map[string]string{
 	// Sort by CreatedAtInSec by default
 	"":           "CreatedAtInSec",
@@ -46,6 +52,38 @@ var pipelineModelFieldsBySortableAPIFields = map[string]string{
 	"created_at": "CreatedAtInSec",
 }
 
+var webhookModelFieldsBySortableAPIFields = map[string]string{
+	// Sort by CreatedAtInSec by default
+	"":           "CreatedAtInSec",
+	"id":         "UUID",
+	"name":       "Name",
+	"created_at": "CreatedAtInSec",
+}
+
+var runGroupModelFieldsBySortableAPIFields = map[string]string{
+	// Sort by CreatedAtInSec by default
+	"":           "CreatedAtInSec",
+	"id":         "UUID",
+	"name":       "Name",
+	"created_at": "CreatedAtInSec",
+}
+
+var operationModelFieldsBySortableAPIFields = map[string]string{
+	// Sort by CreatedAtInSec by default
+	"":           "CreatedAtInSec",
+	"id":         "UUID",
+	"created_at": "CreatedAtInSec",
+}
+
+var modelVersionModelFieldsBySortableAPIFields = map[string]string{
+	// Sort by CreatedAtInSec by default
+	"":           "CreatedAtInSec",
+	"id":         "UUID",
+	"model_name": "ModelName",
+	"version":    "Version",
+	"created_at": "CreatedAtInSec",
+}
+
 var jobModelFieldsBySortableAPIFields = map[string]string{
 	// Sort by CreatedAtInSec by default
 	"":           "CreatedAtInSec",
@@ -62,6 +100,29 @@ var runModelFieldsBySortableAPIFields = map[string]string{
 	"created_at": "CreatedAtInSec",
 }
 
+// ValidateNameFilter converts the name filter of a list request, returning nil if there's none.
+func ValidateNameFilter(filter *api.NameFilter) (*common.NameFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+	name := util.NormalizeName(filter.Name)
+	if name == "" {
+		return nil, util.NewInvalidInputError("The name of the name filter is empty.")
+	}
+	var match common.NameMatch
+	switch filter.Match {
+	case api.NameFilter_EXACT:
+		match = common.NameMatchExact
+	case api.NameFilter_CASE_INSENSITIVE:
+		match = common.NameMatchCaseInsensitive
+	case api.NameFilter_PREFIX:
+		match = common.NameMatchPrefix
+	default:
+		return nil, util.NewInvalidInputError("Unknown match %v of the name filter.", filter.Match)
+	}
+	return &common.NameFilter{Name: name, Match: match}, nil
+}
+
 func ValidateFilter(referenceKey *api.ResourceKey) (*common.FilterContext, error) {
 	filterContext := &common.FilterContext{}
 	if referenceKey != nil {
@@ -98,12 +159,39 @@ func ValidatePagination(pageToken string, pageSize int, keyFieldName string, que
 	if err != nil {
 		return nil, util.Wrap(err, "Invalid page token.")
 	}
+	now := pageTokenTime.Now()
+	if err := validatePageToken(token, sortByFieldName, isDesc, now); err != nil {
+		return nil, util.Wrap(err, "Invalid page token.")
+	}
 	return &common.PaginationContext{
-		PageSize:        pageSize,
-		SortByFieldName: sortByFieldName,
-		KeyFieldName:    keyFieldName,
-		IsDesc:          isDesc,
-		Token:           token}, nil
+		PageSize:            pageSize,
+		SortByFieldName:     sortByFieldName,
+		KeyFieldName:        keyFieldName,
+		IsDesc:              isDesc,
+		Token:               token,
+		TokenExpiresAtInSec: now.Add(pageTokenTTL).Unix()}, nil
+}
+
+// validatePageToken returns a FailedPrecondition error if a page token is stale: expired, or
+// of another version, e.g. issued by a previous release. The list must then be listed again
+// from its first page. The token must be of a list sorted the same.
+func validatePageToken(token *common.Token, sortByFieldName string, isDesc bool, now time.Time) error {
+	if token == nil {
+		return nil
+	}
+	if token.Version != common.TokenVersion {
+		return util.NewFailedPreconditionError(
+			"The page token is of version %v instead of %v. Please list again from the first page.",
+			token.Version, common.TokenVersion)
+	}
+	if now.Unix() > token.ExpiresAtInSec {
+		return util.NewFailedPreconditionError("The page token expired at %v. Please list again from the first page.",
+			time.Unix(token.ExpiresAtInSec, 0).UTC().Format(time.RFC3339))
+	}
+	if token.SortByFieldName != sortByFieldName || token.IsDesc != isDesc {
+		return util.NewInvalidInputError("The page token is of a list sorted differently.")
+	}
+	return nil
 }
 
 func parseSortByQueryString(queryString string, modelFieldByApiFieldMapping map[string]string) (string, bool, error) {
@@ -18,6 +18,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
@@ -33,13 +34,34 @@ var fakeModelFieldsBySortableAPIFields = map[string]string{
 	"description": "Description",
 }
 
-func getFakeModelToken() string {
-	token := common.Token{
+// The page tokens of the tests are validated at this time.
+var fakePageTokenNow = time.Unix(1000, 0)
+
+// useFakePageTokenTime checks the expiry of the page tokens at fakePageTokenNow, until the
+// returned function restores the clock.
+func useFakePageTokenTime() func() {
+	realTime := pageTokenTime
+	pageTokenTime = util.NewManualFakeTime(fakePageTokenNow)
+	return func() { pageTokenTime = realTime }
+}
+
+func getFakeModelTokenValue() *common.Token {
+	return &common.Token{
 		SortByFieldValue: "bar",
 		KeyFieldValue:    "foo",
+		SortByFieldName:  "Name",
+		Version:          common.TokenVersion,
+		ExpiresAtInSec:   fakePageTokenNow.Add(time.Hour).Unix(),
 	}
-	expectedJson, _ := json.Marshal(token)
-	return base64.StdEncoding.EncodeToString(expectedJson)
+}
+
+func encodeFakeToken(token *common.Token) string {
+	tokenJson, _ := json.Marshal(token)
+	return base64.StdEncoding.EncodeToString(tokenJson)
+}
+
+func getFakeModelToken() string {
+	return encodeFakeToken(getFakeModelTokenValue())
 }
 
 func TestValidateFilter(t *testing.T) {
@@ -56,16 +78,38 @@ func TestValidateFilter_ToModelResourceTypeFailed(t *testing.T) {
 	assert.Contains(t, err.Error(), "Unrecognized resource reference type")
 }
 
+func TestValidateNameFilter(t *testing.T) {
+	filter, err := ValidateNameFilter(&api.NameFilter{Name: "xgboost", Match: api.NameFilter_PREFIX})
+	assert.Nil(t, err)
+	assert.Equal(t, &common.NameFilter{Name: "xgboost", Match: common.NameMatchPrefix}, filter)
+
+	filter, err = ValidateNameFilter(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, filter)
+}
+
+func TestValidateNameFilter_Invalid(t *testing.T) {
+	_, err := ValidateNameFilter(&api.NameFilter{Match: api.NameFilter_PREFIX})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "The name of the name filter is empty")
+
+	_, err = ValidateNameFilter(&api.NameFilter{Name: "xgboost", Match: 10})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "Unknown match")
+}
+
 func TestValidatePagination(t *testing.T) {
+	defer useFakePageTokenTime()()
 	token := getFakeModelToken()
 	context, err := ValidatePagination(token, 3, "Name",
 		"", fakeModelFieldsBySortableAPIFields)
 	assert.Nil(t, err)
 	expected := &common.PaginationContext{
-		PageSize:        3,
-		SortByFieldName: "Name",
-		KeyFieldName:    "Name",
-		Token:           &common.Token{SortByFieldValue: "bar", KeyFieldValue: "foo"}}
+		PageSize:            3,
+		SortByFieldName:     "Name",
+		KeyFieldName:        "Name",
+		Token:               getFakeModelTokenValue(),
+		TokenExpiresAtInSec: fakePageTokenNow.Add(pageTokenTTL).Unix()}
 	assert.Equal(t, expected, context)
 }
 
@@ -77,31 +121,81 @@ func TestValidatePagination_NegativePageSizeError(t *testing.T) {
 }
 
 func TestValidatePagination_DefaultPageSize(t *testing.T) {
+	defer useFakePageTokenTime()()
 	token := getFakeModelToken()
 	context, err := ValidatePagination(token, 0, "Name",
 		"", fakeModelFieldsBySortableAPIFields)
 	expected := &common.PaginationContext{
-		PageSize:        defaultPageSize,
-		SortByFieldName: "Name",
-		KeyFieldName:    "Name",
-		Token:           &common.Token{SortByFieldValue: "bar", KeyFieldValue: "foo"}}
+		PageSize:            defaultPageSize,
+		SortByFieldName:     "Name",
+		KeyFieldName:        "Name",
+		Token:               getFakeModelTokenValue(),
+		TokenExpiresAtInSec: fakePageTokenNow.Add(pageTokenTTL).Unix()}
 	assert.Nil(t, err)
 	assert.Equal(t, expected, context)
 }
 
 func TestValidatePagination_DefaultSorting(t *testing.T) {
+	defer useFakePageTokenTime()()
 	token := getFakeModelToken()
 	context, err := ValidatePagination(token, 0, "Name",
 		"", fakeModelFieldsBySortableAPIFields)
 	expected := &common.PaginationContext{
-		PageSize:        defaultPageSize,
-		SortByFieldName: "Name",
-		KeyFieldName:    "Name",
-		Token:           &common.Token{SortByFieldValue: "bar", KeyFieldValue: "foo"}}
+		PageSize:            defaultPageSize,
+		SortByFieldName:     "Name",
+		KeyFieldName:        "Name",
+		Token:               getFakeModelTokenValue(),
+		TokenExpiresAtInSec: fakePageTokenNow.Add(pageTokenTTL).Unix()}
 	assert.Nil(t, err)
 	assert.Equal(t, expected, context)
 }
 
+// The tokens issued by the releases of the same token version stay valid until they expire.
+func TestValidatePagination_TokenOfSameVersion(t *testing.T) {
+	defer useFakePageTokenTime()()
+	token := "eyJTb3J0QnlGaWVsZFZhbHVlIjoiYmFyIiwiS2V5RmllbGRWYWx1ZSI6ImZvbyIsIlNvcnRCeUZpZWxkTmFtZSI6Ik5hbWUiLCJJ" +
+		"c0Rlc2MiOmZhbHNlLCJWZXJzaW9uIjoxLCJFeHBpcmVzQXRJblNlYyI6NDYwMH0="
+	context, err := ValidatePagination(token, 0, "Name", "", fakeModelFieldsBySortableAPIFields)
+	assert.Nil(t, err)
+	assert.Equal(t, getFakeModelTokenValue(), context.Token)
+}
+
+// The tokens issued before the tokens were versioned list the rows in another order.
+func TestValidatePagination_UnversionedToken(t *testing.T) {
+	defer useFakePageTokenTime()()
+	token := base64.StdEncoding.EncodeToString([]byte(`{"SortByFieldValue":"bar","KeyFieldValue":"foo"}`))
+	_, err := ValidatePagination(token, 0, "Name", "", fakeModelFieldsBySortableAPIFields)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+	assert.Contains(t, err.Error(), "The page token is of version 0")
+}
+
+func TestValidatePagination_TokenOfOtherVersion(t *testing.T) {
+	defer useFakePageTokenTime()()
+	token := getFakeModelTokenValue()
+	token.Version = common.TokenVersion + 1
+	_, err := ValidatePagination(encodeFakeToken(token), 0, "Name", "", fakeModelFieldsBySortableAPIFields)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+}
+
+func TestValidatePagination_ExpiredToken(t *testing.T) {
+	defer useFakePageTokenTime()()
+	token := getFakeModelTokenValue()
+	token.ExpiresAtInSec = fakePageTokenNow.Add(-time.Second).Unix()
+	_, err := ValidatePagination(encodeFakeToken(token), 0, "Name", "", fakeModelFieldsBySortableAPIFields)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+	assert.Contains(t, err.Error(), "The page token expired at 1970-01-01T00:16:39Z")
+}
+
+func TestValidatePagination_TokenOfOtherSorting(t *testing.T) {
+	defer useFakePageTokenTime()()
+	token := getFakeModelToken()
+	for _, sortBy := range []string{"author", "name desc"} {
+		_, err := ValidatePagination(token, 0, "Name", sortBy, fakeModelFieldsBySortableAPIFields)
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument), sortBy)
+		assert.Contains(t, err.Error(), "sorted differently", sortBy)
+	}
+}
+
 func TestValidatePagination_InvalidToken(t *testing.T) {
 	_, err := ValidatePagination("invalid token", 0, "",
 		"", fakeModelFieldsBySortableAPIFields)
@@ -0,0 +1,169 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	MetricsPushPathPrefix = "/apis/v1beta1/metrics/push/runs/"
+	// The maximum size of the metrics pushed at once.
+	maxMetricsPushBytes         = 1 << 20
+	metricsFormatQueryStringKey = "format"
+	bearerTokenPrefix           = "Bearer "
+)
+
+type MetricsPushServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+// PushMetrics is the HTTP endpoint where the steps of a run push their metrics, in the
+// Prometheus text exposition format without labels:
+//
+//	POST /apis/v1beta1/metrics/push/runs/{run_id}/nodes/{node_id}?format=RAW
+//	Authorization: Bearer <the value of KFP_METRICS_PUSH_TOKEN>
+//
+//	# Comments are ignored.
+//	accuracy 0.92
+//
+// The metrics are stored as if they were reported through ReportRunMetrics, and the
+// result of each of them is returned.
+func (s *MetricsPushServer) PushMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		s.writeErrorToResponse(w, http.StatusMethodNotAllowed,
+			util.NewInvalidInputError("Metrics must be pushed with POST or PUT, not %v", r.Method))
+		return
+	}
+	runId, nodeId, err := parseMetricsPushPath(r.URL.Path)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusNotFound, err)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), bearerTokenPrefix) {
+		s.writeErrorToResponse(w, http.StatusUnauthorized,
+			util.NewUnauthenticatedError("Metrics must be pushed with a bearer token"))
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), bearerTokenPrefix)
+	if err := s.resourceManager.VerifyMetricsPushToken(runId, token); err != nil {
+		s.writeErrorToResponse(w, httpStatusFromError(err), err)
+		return
+	}
+	format := api.RunMetric_RAW
+	if formatName := r.URL.Query().Get(metricsFormatQueryStringKey); formatName != "" {
+		value, ok := api.RunMetric_Format_value[strings.ToUpper(formatName)]
+		if !ok {
+			s.writeErrorToResponse(w, http.StatusBadRequest, util.NewInvalidInputError("Unknown metric format %v", formatName))
+			return
+		}
+		format = api.RunMetric_Format(value)
+	}
+	metrics, err := ParsePushedMetrics(http.MaxBytesReader(w, r.Body, maxMetricsPushBytes), nodeId, format)
+	if err != nil {
+		s.writeErrorToResponse(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response := reportRunMetrics(s.resourceManager, runId, metrics)
+	marshaler := jsonpb.Marshaler{OrigName: true}
+	if err := marshaler.Marshal(w, response); err != nil {
+		glog.Errorf("Failed to write the results of the pushed metrics. Error: %v", err)
+	}
+}
+
+func (s *MetricsPushServer) writeErrorToResponse(w http.ResponseWriter, code int, err error) {
+	glog.Errorf("Failed to push metrics. Error: %+v", err)
+	w.WriteHeader(code)
+	errorResponse := api.Error{ErrorMessage: err.Error(), ErrorDetails: fmt.Sprintf("%+v", err)}
+	if userError, ok := err.(*util.UserError); ok {
+		errorResponse.ErrorMessage = userError.ExternalMessage()
+	}
+	marshaler := jsonpb.Marshaler{OrigName: true}
+	if err := marshaler.Marshal(w, &errorResponse); err != nil {
+		w.Write([]byte("Error pushing metrics"))
+	}
+}
+
+// parseMetricsPushPath extracts the run and node IDs from the path of a pushed metrics
+// request.
+func parseMetricsPushPath(path string) (runId string, nodeId string, err error) {
+	segments := strings.Split(strings.TrimPrefix(path, MetricsPushPathPrefix), "/")
+	if !strings.HasPrefix(path, MetricsPushPathPrefix) || len(segments) != 3 || segments[1] != "nodes" ||
+		segments[0] == "" || segments[2] == "" {
+		return "", "", util.NewInvalidInputError(
+			"Metrics must be pushed to %v{run_id}/nodes/{node_id}, not %v", MetricsPushPathPrefix, path)
+	}
+	return segments[0], segments[2], nil
+}
+
+// ParsePushedMetrics parses metrics in the Prometheus text exposition format. The labels
+// are not supported, and the timestamps are ignored.
+func ParsePushedMetrics(reader io.Reader, nodeId string, format api.RunMetric_Format) ([]*api.RunMetric, error) {
+	var metrics []*api.RunMetric
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if strings.Contains(fields[0], "{") {
+			return nil, util.NewInvalidInputError("Line %v: metric labels are not supported", lineNumber)
+		}
+		if len(fields) != 2 && len(fields) != 3 {
+			return nil, util.NewInvalidInputError("Line %v: expected a metric name and value, got %q", lineNumber, line)
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, util.NewInvalidInputError("Line %v: invalid value %q of metric %v", lineNumber, fields[1], fields[0])
+		}
+		metrics = append(metrics, &api.RunMetric{
+			Name:   fields[0],
+			NodeId: nodeId,
+			Value:  &api.RunMetric_NumberValue{NumberValue: value},
+			Format: format,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to read the pushed metrics")
+	}
+	return metrics, nil
+}
+
+// httpStatusFromError returns the HTTP status of the code of a user error.
+func httpStatusFromError(err error) int {
+	if userError, ok := err.(*util.UserError); ok {
+		return runtime.HTTPStatusFromCode(userError.ExternalStatusCode())
+	}
+	return http.StatusInternalServerError
+}
+
+func NewMetricsPushServer(resourceManager *resource.ResourceManager) *MetricsPushServer {
+	return &MetricsPushServer{resourceManager: resourceManager}
+}
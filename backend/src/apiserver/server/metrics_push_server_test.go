@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func initWithMetricsPushToken(t *testing.T) (*resource.FakeClientManager, *MetricsPushServer, string) {
+	clientManager, _, runDetail := initWithOneTimeRun(t)
+	clientManager.EnableMetricsPush()
+	assert.Nil(t, clientManager.MetricsPushTokenStore().CreateToken(runDetail.UUID, common.Run, "token"))
+	return clientManager, NewMetricsPushServer(resource.NewResourceManager(clientManager)), runDetail.UUID
+}
+
+func pushMetrics(server *MetricsPushServer, path string, token string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(server.PushMetrics).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestPushMetrics(t *testing.T) {
+	clientManager, server, runId := initWithMetricsPushToken(t)
+	defer clientManager.Close()
+
+	rr := pushMetrics(server, MetricsPushPathPrefix+runId+"/nodes/node1?format=percentage", "token",
+		"# TYPE accuracy gauge\naccuracy 0.92\n\nloss_value 0.1 1550000000\n")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `{"metric_name":"accuracy","metric_node_id":"node1","status":"OK"}`)
+	assert.Contains(t, rr.Body.String(), `"metric_name":"loss_value","metric_node_id":"node1","status":"INVALID_ARGUMENT"`)
+
+	run, err := clientManager.RunStore().GetRun(runId)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(run.Metrics))
+	assert.Equal(t, "accuracy", run.Metrics[0].Name)
+	assert.Equal(t, 0.92, run.Metrics[0].NumberValue)
+	assert.Equal(t, api.RunMetric_PERCENTAGE.String(), run.Metrics[0].Format)
+}
+
+func TestPushMetrics_InvalidRequest(t *testing.T) {
+	clientManager, server, runId := initWithMetricsPushToken(t)
+	defer clientManager.Close()
+
+	path := MetricsPushPathPrefix + runId + "/nodes/node1"
+	assert.Equal(t, http.StatusUnauthorized, pushMetrics(server, path, "wrong token", "accuracy 0.92").Code)
+	assert.Equal(t, http.StatusNotFound, pushMetrics(server, MetricsPushPathPrefix+"unknown/nodes/node1", "token", "").Code)
+	assert.Equal(t, http.StatusNotFound, pushMetrics(server, MetricsPushPathPrefix+runId, "token", "").Code)
+	assert.Equal(t, http.StatusBadRequest, pushMetrics(server, path, "token", `accuracy{label="a"} 0.92`).Code)
+	assert.Equal(t, http.StatusBadRequest, pushMetrics(server, path, "token", "accuracy high").Code)
+	assert.Equal(t, http.StatusBadRequest, pushMetrics(server, path+"?format=unknown", "token", "accuracy 0.92").Code)
+
+	req, _ := http.NewRequest("GET", path, nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(server.PushMetrics).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type ModelRegistryServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+func (s *ModelRegistryServer) GetModelVersion(ctx context.Context, request *api.GetModelVersionRequest) (
+	*api.ModelVersion, error) {
+	modelVersion, err := s.resourceManager.GetModelVersion(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Get model version failed.")
+	}
+	return ToApiModelVersion(modelVersion), nil
+}
+
+func (s *ModelRegistryServer) ListModelVersions(ctx context.Context, request *api.ListModelVersionsRequest) (
+	*api.ListModelVersionsResponse, error) {
+	paginationContext, err := ValidatePagination(
+		request.PageToken, int(request.PageSize), model.GetModelVersionTablePrimaryKeyColumn(),
+		request.SortBy, modelVersionModelFieldsBySortableAPIFields)
+	if err != nil {
+		return nil, util.Wrap(err, "List model versions failed.")
+	}
+	modelVersions, nextPageToken, err := s.resourceManager.ListModelVersions(request.ModelName, paginationContext)
+	if err != nil {
+		return nil, util.Wrap(err, "List model versions failed.")
+	}
+	return &api.ListModelVersionsResponse{
+			ModelVersions: ToApiModelVersions(modelVersions),
+			NextPageToken: nextPageToken},
+		nil
+}
+
+func NewModelRegistryServer(resourceManager *resource.ResourceManager) *ModelRegistryServer {
+	return &ModelRegistryServer{resourceManager: resourceManager}
+}
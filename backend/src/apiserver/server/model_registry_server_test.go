@@ -0,0 +1,85 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newModelRegistryServerForTest(t *testing.T) (*resource.FakeClientManager, *ModelRegistryServer) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	assert.Nil(t, clientManager.RunStore().ReportMetric(&model.RunMetric{
+		RunUUID: "run1", NodeID: "node1", Name: "accuracy", NumberValue: 0.9, Format: "PERCENTAGE"}))
+	_, err := clientManager.ModelRegistry().RegisterModelVersion(&model.ModelVersion{
+		ModelName:    "mnist",
+		ArtifactURI:  "s3://mlpipeline/model.tgz",
+		ArtifactName: "model",
+		RunUUID:      "run1",
+		NodeID:       "node1",
+	})
+	assert.Nil(t, err)
+	return clientManager, NewModelRegistryServer(resource.NewResourceManager(clientManager))
+}
+
+func expectedModelVersion() *api.ModelVersion {
+	return &api.ModelVersion{
+		Id:           resource.DefaultFakeUUID,
+		ModelName:    "mnist",
+		Version:      1,
+		ArtifactUri:  "s3://mlpipeline/model.tgz",
+		ArtifactName: "model",
+		RunId:        "run1",
+		NodeId:       "node1",
+		Metrics: []*api.RunMetric{{
+			Name:   "accuracy",
+			NodeId: "node1",
+			Value:  &api.RunMetric_NumberValue{NumberValue: 0.9},
+			Format: api.RunMetric_PERCENTAGE,
+		}},
+		CreatedAt: &timestamp.Timestamp{Seconds: 1},
+	}
+}
+
+func TestGetModelVersion(t *testing.T) {
+	clientManager, server := newModelRegistryServerForTest(t)
+	defer clientManager.Close()
+
+	modelVersion, err := server.GetModelVersion(nil, &api.GetModelVersionRequest{Id: resource.DefaultFakeUUID})
+	assert.Nil(t, err)
+	assert.Equal(t, expectedModelVersion(), modelVersion)
+}
+
+func TestGetModelVersion_NotFound(t *testing.T) {
+	clientManager, server := newModelRegistryServerForTest(t)
+	defer clientManager.Close()
+
+	_, err := server.GetModelVersion(nil, &api.GetModelVersionRequest{Id: "not-exist"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestListModelVersions(t *testing.T) {
+	clientManager, server := newModelRegistryServerForTest(t)
+	defer clientManager.Close()
+
+	response, err := server.ListModelVersions(nil, &api.ListModelVersionsRequest{ModelName: "mnist", PageSize: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ListModelVersionsResponse{ModelVersions: []*api.ModelVersion{expectedModelVersion()}}, response)
+
+	response, err = server.ListModelVersions(nil, &api.ListModelVersionsRequest{ModelName: "resnet", PageSize: 10})
+	assert.Nil(t, err)
+	assert.Empty(t, response.ModelVersions)
+}
+
+func TestListModelVersions_InvalidSortBy(t *testing.T) {
+	clientManager, server := newModelRegistryServerForTest(t)
+	defer clientManager.Close()
+
+	_, err := server.ListModelVersions(nil, &api.ListModelVersionsRequest{SortBy: "unknown"})
+	AssertUserError(t, err, codes.InvalidArgument)
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type OperationServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+func (s *OperationServer) GetOperation(ctx context.Context, request *api.GetOperationRequest) (*api.Operation, error) {
+	operation, err := s.resourceManager.GetOperation(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Get operation failed.")
+	}
+	return ToApiOperation(operation)
+}
+
+func (s *OperationServer) ListOperations(ctx context.Context, request *api.ListOperationsRequest) (
+	*api.ListOperationsResponse, error) {
+	paginationContext, err := ValidatePagination(
+		request.PageToken, int(request.PageSize), model.GetOperationTablePrimaryKeyColumn(),
+		request.SortBy, operationModelFieldsBySortableAPIFields)
+	if err != nil {
+		return nil, util.Wrap(err, "List operations failed.")
+	}
+	operations, nextPageToken, err := s.resourceManager.ListOperations(paginationContext)
+	if err != nil {
+		return nil, util.Wrap(err, "List operations failed.")
+	}
+	apiOperations := make([]*api.Operation, 0, len(operations))
+	for i := range operations {
+		apiOperation, err := ToApiOperation(&operations[i])
+		if err != nil {
+			return nil, util.Wrap(err, "List operations failed.")
+		}
+		apiOperations = append(apiOperations, apiOperation)
+	}
+	return &api.ListOperationsResponse{Operations: apiOperations, NextPageToken: nextPageToken}, nil
+}
+
+func (s *OperationServer) CancelOperation(ctx context.Context, request *api.CancelOperationRequest) (
+	*api.Operation, error) {
+	operation, err := s.resourceManager.CancelOperation(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to cancel the operation.")
+	}
+	return ToApiOperation(operation)
+}
+
+func NewOperationServer(resourceManager *resource.ResourceManager) *OperationServer {
+	return &OperationServer{resourceManager: resourceManager}
+}
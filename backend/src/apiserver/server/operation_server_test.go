@@ -0,0 +1,91 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newOperationServerForTest() (*resource.FakeClientManager, *OperationServer) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+	return clientManager, NewOperationServer(resourceManager)
+}
+
+func TestGetOperation(t *testing.T) {
+	clientManager, server := newOperationServerForTest()
+	defer clientManager.Close()
+	_, err := server.resourceManager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	_, err = server.resourceManager.StartOperation(model.OperationReindexPipelines)
+	assert.Nil(t, err)
+	assert.Nil(t, server.resourceManager.ProcessOperations())
+
+	operation, err := server.GetOperation(nil, &api.GetOperationRequest{Id: resource.DefaultFakeUUID})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.Operation{
+		Id:         resource.DefaultFakeUUID,
+		Type:       api.Operation_REINDEX_PIPELINES,
+		State:      api.Operation_SUCCEEDED,
+		CreatedAt:  &timestamp.Timestamp{Seconds: 2},
+		FinishedAt: &timestamp.Timestamp{Seconds: 3},
+		Total:      1,
+		Processed:  1,
+		Errors:     []string{},
+	}, operation)
+
+	_, err = server.GetOperation(nil, &api.GetOperationRequest{Id: "unknown"})
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestListOperations(t *testing.T) {
+	clientManager, server := newOperationServerForTest()
+	defer clientManager.Close()
+	_, err := server.resourceManager.StartOperation(model.OperationRecomputeRunStatuses)
+	assert.Nil(t, err)
+
+	response, err := server.ListOperations(nil, &api.ListOperationsRequest{PageSize: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ListOperationsResponse{Operations: []*api.Operation{{
+		Id:        resource.DefaultFakeUUID,
+		Type:      api.Operation_RECOMPUTE_RUN_STATUSES,
+		State:     api.Operation_RUNNING,
+		CreatedAt: &timestamp.Timestamp{Seconds: 1},
+		Errors:    []string{},
+	}}}, response)
+
+	_, err = server.ListOperations(nil, &api.ListOperationsRequest{SortBy: "type"})
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCancelOperation(t *testing.T) {
+	clientManager, server := newOperationServerForTest()
+	defer clientManager.Close()
+	_, err := server.resourceManager.StartOperation(model.OperationRecomputeRunStatuses)
+	assert.Nil(t, err)
+
+	operation, err := server.CancelOperation(nil, &api.CancelOperationRequest{Id: resource.DefaultFakeUUID})
+	assert.Nil(t, err)
+	assert.Equal(t, api.Operation_CANCELLED, operation.State)
+	assert.Equal(t, &timestamp.Timestamp{Seconds: 2}, operation.FinishedAt)
+}
@@ -15,21 +15,25 @@
 package server
 
 import (
+	"bytes"
 	"context"
-	"net/http"
 	"net/url"
 	"path"
+	"strings"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/policy"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 )
 
 type PipelineServer struct {
 	resourceManager *resource.ResourceManager
-	httpClient      *http.Client
+	urlFetcher      *URLFetcher
 }
 
 func (s *PipelineServer) CreatePipeline(ctx context.Context, request *api.CreatePipelineRequest) (*api.Pipeline, error) {
@@ -37,13 +41,13 @@ func (s *PipelineServer) CreatePipeline(ctx context.Context, request *api.Create
 		return nil, err
 	}
 
-	resp, err := s.httpClient.Get(request.Url.PipelineUrl)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return nil, util.NewInternalServerError(err, "Failed to download the pipeline from %v "+
+	content, err := s.urlFetcher.Fetch(ctx, request.Url.PipelineUrl)
+	if err != nil {
+		return nil, util.Wrapf(err, "Failed to download the pipeline from %v. "+
 			"Please double check the URL is valid and can be accessed by the pipeline system.", request.Url.PipelineUrl)
 	}
 	pipelineFileName := path.Base(request.Url.PipelineUrl)
-	pipelineFile, err := ReadPipelineFile(pipelineFileName, resp.Body, MaxFileLength)
+	pipelinePackage, err := ReadPipelinePackage(pipelineFileName, bytes.NewReader(content), MaxFileLength)
 	if err != nil {
 		return nil, util.Wrap(err, "The URL is valid but pipeline system failed to read the file.")
 	}
@@ -52,8 +56,13 @@ func (s *PipelineServer) CreatePipeline(ctx context.Context, request *api.Create
 	if err != nil {
 		return nil, util.Wrap(err, "Invalid pipeline name.")
 	}
+	onConflict, err := toPipelineConflict(request.OnConflict)
+	if err != nil {
+		return nil, err
+	}
 
-	pipeline, err := s.resourceManager.CreatePipeline(pipelineName, "", pipelineFile)
+	pipeline, err := s.resourceManager.CreatePipelineOnConflict(pipelineName, "", pipelinePackage.Template,
+		pipelinePackage.Readme, onConflict)
 	if err != nil {
 		return nil, util.Wrap(err, "Create pipeline failed.")
 	}
@@ -76,7 +85,16 @@ func (s *PipelineServer) ListPipelines(ctx context.Context, request *api.ListPip
 	if err != nil {
 		return nil, util.Wrap(err, "List pipelines failed.")
 	}
-	pipelines, nextPageToken, err := s.resourceManager.ListPipelines(paginationContext)
+	filterContext := &common.FilterContext{ExcludeDeprecated: request.ExcludeDeprecated}
+	if filterContext.Name, err = ValidateNameFilter(request.NameFilter); err != nil {
+		return nil, util.Wrap(err, "List pipelines failed.")
+	}
+	if request.OnlyStarred {
+		if filterContext.StarredBy, err = getAuthenticatedUser(ctx); err != nil {
+			return nil, util.Wrap(err, "List pipelines failed.")
+		}
+	}
+	pipelines, nextPageToken, err := s.resourceManager.ListPipelines(filterContext, paginationContext)
 	if err != nil {
 		return nil, util.Wrap(err, "List pipelines failed.")
 	}
@@ -93,6 +111,52 @@ func (s *PipelineServer) DeletePipeline(ctx context.Context, request *api.Delete
 	return &empty.Empty{}, nil
 }
 
+func (s *PipelineServer) StarPipeline(ctx context.Context, request *api.StarPipelineRequest) (*empty.Empty, error) {
+	userId, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, util.Wrap(err, "Star pipeline failed.")
+	}
+	if err := s.resourceManager.StarResource(userId, common.Pipeline, request.Id); err != nil {
+		return nil, util.Wrap(err, "Star pipeline failed.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *PipelineServer) UnstarPipeline(ctx context.Context, request *api.UnstarPipelineRequest) (*empty.Empty, error) {
+	userId, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, util.Wrap(err, "Unstar pipeline failed.")
+	}
+	if err := s.resourceManager.UnstarResource(userId, common.Pipeline, request.Id); err != nil {
+		return nil, util.Wrap(err, "Unstar pipeline failed.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *PipelineServer) DeprecatePipeline(ctx context.Context, request *api.DeprecatePipelineRequest) (*api.Pipeline, error) {
+	var sunsetAtInSec int64
+	if request.SunsetAt != nil {
+		sunsetAt, err := ptypes.Timestamp(request.SunsetAt)
+		if err != nil || sunsetAt.Unix() <= 0 {
+			return nil, util.NewInvalidInputError("Invalid sunset date %v.", request.SunsetAt)
+		}
+		sunsetAtInSec = sunsetAt.Unix()
+	}
+	pipeline, err := s.resourceManager.DeprecatePipeline(request.Id, request.ReplacementPipelineId, sunsetAtInSec)
+	if err != nil {
+		return nil, util.Wrap(err, "Deprecate pipeline failed.")
+	}
+	return ToApiPipeline(pipeline), nil
+}
+
+func (s *PipelineServer) UndeprecatePipeline(ctx context.Context, request *api.UndeprecatePipelineRequest) (*api.Pipeline, error) {
+	pipeline, err := s.resourceManager.UndeprecatePipeline(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Undeprecate pipeline failed.")
+	}
+	return ToApiPipeline(pipeline), nil
+}
+
 func (s *PipelineServer) GetTemplate(ctx context.Context, request *api.GetTemplateRequest) (*api.GetTemplateResponse, error) {
 	template, err := s.resourceManager.GetPipelineTemplate(request.Id)
 	if err != nil {
@@ -102,6 +166,59 @@ func (s *PipelineServer) GetTemplate(ctx context.Context, request *api.GetTempla
 	return &api.GetTemplateResponse{Template: string(template)}, nil
 }
 
+func (s *PipelineServer) ComparePipelines(ctx context.Context, request *api.ComparePipelinesRequest) (*api.PipelineDiff, error) {
+	if request.BaseId == "" || request.TargetId == "" {
+		return nil, util.NewInvalidInputError("Both the base and the target pipelines are required.")
+	}
+	diff, err := s.resourceManager.ComparePipelines(request.BaseId, request.TargetId)
+	if err != nil {
+		return nil, util.Wrap(err, "Compare pipelines failed.")
+	}
+	return ToApiPipelineDiff(diff), nil
+}
+
+func (s *PipelineServer) GetPipelineSteps(ctx context.Context, request *api.GetPipelineStepsRequest) (*api.GetPipelineStepsResponse, error) {
+	steps, err := s.resourceManager.GetPipelineSteps(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline steps failed.")
+	}
+	apiSteps, err := ToApiPipelineSteps(steps)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline steps failed.")
+	}
+	return &api.GetPipelineStepsResponse{Steps: apiSteps}, nil
+}
+
+func (s *PipelineServer) GetPipelineReadme(ctx context.Context, request *api.GetPipelineReadmeRequest) (*api.GetPipelineReadmeResponse, error) {
+	readme, err := s.resourceManager.GetPipelineReadme(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline README failed.")
+	}
+	return &api.GetPipelineReadmeResponse{Readme: readme}, nil
+}
+
+func (s *PipelineServer) ValidatePipeline(ctx context.Context, request *api.ValidatePipelineRequest) (*api.ValidatePipelineResponse, error) {
+	if (request.PipelineId == "") == (request.Template == "") {
+		return nil, util.NewInvalidInputError("Exactly one of the pipeline ID and the template must be set.")
+	}
+	violations, err := s.resourceManager.ValidatePipeline(request.PipelineId, []byte(request.Template))
+	if err != nil {
+		return nil, util.Wrap(err, "Validate pipeline failed.")
+	}
+	return &api.ValidatePipelineResponse{
+		Violations: ToApiPolicyViolations(violations),
+		Valid:      len(policy.Enforced(violations)) == 0,
+	}, nil
+}
+
+func (s *PipelineServer) GetPipelineStats(ctx context.Context, request *api.GetPipelineStatsRequest) (*api.PipelineStats, error) {
+	stats, err := s.resourceManager.GetPipelineStats(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Get pipeline stats failed.")
+	}
+	return ToApiPipelineStats(stats), nil
+}
+
 func ValidateCreatePipelineRequest(request *api.CreatePipelineRequest) error {
 	if request.Url == nil || request.Url.PipelineUrl == "" {
 		return util.NewInvalidInputError("Pipeline URL is empty. Please specify a valid URL.")
@@ -113,6 +230,32 @@ func ValidateCreatePipelineRequest(request *api.CreatePipelineRequest) error {
 	return nil
 }
 
-func NewPipelineServer(resourceManager *resource.ResourceManager) *PipelineServer {
-	return &PipelineServer{resourceManager: resourceManager, httpClient: http.DefaultClient}
+func toPipelineConflict(onConflict api.CreatePipelineRequest_OnConflict) (resource.PipelineConflict, error) {
+	switch onConflict {
+	case api.CreatePipelineRequest_FAIL:
+		return resource.PipelineConflictFail, nil
+	case api.CreatePipelineRequest_AUTO_SUFFIX:
+		return resource.PipelineConflictAutoSuffix, nil
+	case api.CreatePipelineRequest_NEW_VERSION:
+		return resource.PipelineConflictNewVersion, nil
+	}
+	return resource.PipelineConflictFail, util.NewInvalidInputError("Unknown on_conflict %v.", onConflict)
+}
+
+// parsePipelineConflict parses the on_conflict query parameter of the upload, either the name
+// of the value, e.g. AUTO_SUFFIX, or its lower case form with dashes, e.g. auto-suffix.
+func parsePipelineConflict(queryString string) (resource.PipelineConflict, error) {
+	if queryString == "" {
+		return resource.PipelineConflictFail, nil
+	}
+	value, ok := api.CreatePipelineRequest_OnConflict_value[strings.ToUpper(strings.Replace(queryString, "-", "_", -1))]
+	if !ok {
+		return resource.PipelineConflictFail, util.NewInvalidInputError(
+			"Unknown on_conflict %q. Please specify fail, auto-suffix or new-version.", queryString)
+	}
+	return toPipelineConflict(api.CreatePipelineRequest_OnConflict(value))
+}
+
+func NewPipelineServer(resourceManager *resource.ResourceManager, urlFetcher *URLFetcher) *PipelineServer {
+	return &PipelineServer{resourceManager: resourceManager, urlFetcher: urlFetcher}
 }
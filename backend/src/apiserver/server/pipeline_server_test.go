@@ -9,11 +9,16 @@ import (
 	"os"
 	"testing"
 
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestCreatePipeline_YAML(t *testing.T) {
@@ -24,7 +29,7 @@ func TestCreatePipeline_YAML(t *testing.T) {
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	resourceManager := resource.NewResourceManager(clientManager)
 
-	pipelineServer := PipelineServer{resourceManager: resourceManager, httpClient: httpServer.Client()}
+	pipelineServer := PipelineServer{resourceManager: resourceManager, urlFetcher: newURLFetcherForTest()}
 	pipeline, err := pipelineServer.CreatePipeline(context.Background(), &api.CreatePipelineRequest{
 		Url: &api.Url{PipelineUrl: httpServer.URL + "/arguments-parameters.yaml"}, Name: "argument-parameters"})
 
@@ -48,7 +53,7 @@ func TestCreatePipeline_Tarball(t *testing.T) {
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	resourceManager := resource.NewResourceManager(clientManager)
 
-	pipelineServer := PipelineServer{resourceManager: resourceManager, httpClient: httpServer.Client()}
+	pipelineServer := PipelineServer{resourceManager: resourceManager, urlFetcher: newURLFetcherForTest()}
 	pipeline, err := pipelineServer.CreatePipeline(context.Background(), &api.CreatePipelineRequest{
 		Url: &api.Url{PipelineUrl: httpServer.URL + "/arguments_tarball/arguments.tar.gz"}, Name: "argument-parameters"})
 
@@ -72,7 +77,7 @@ func TestCreatePipeline_InvalidYAML(t *testing.T) {
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	resourceManager := resource.NewResourceManager(clientManager)
 
-	pipelineServer := PipelineServer{resourceManager: resourceManager, httpClient: httpServer.Client()}
+	pipelineServer := PipelineServer{resourceManager: resourceManager, urlFetcher: newURLFetcherForTest()}
 	_, err := pipelineServer.CreatePipeline(context.Background(), &api.CreatePipelineRequest{
 		Url: &api.Url{PipelineUrl: httpServer.URL + "/invalid-workflow.yaml"}, Name: "argument-parameters"})
 
@@ -89,7 +94,7 @@ func TestCreatePipeline_InvalidURL(t *testing.T) {
 	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
 	resourceManager := resource.NewResourceManager(clientManager)
 
-	pipelineServer := PipelineServer{resourceManager: resourceManager, httpClient: httpServer.Client()}
+	pipelineServer := PipelineServer{resourceManager: resourceManager, urlFetcher: newURLFetcherForTest()}
 	_, err := pipelineServer.CreatePipeline(context.Background(), &api.CreatePipelineRequest{
 		Url: &api.Url{PipelineUrl: httpServer.URL + "/invalid-workflow.yaml"}, Name: "argument-parameters"})
 
@@ -116,3 +121,223 @@ func getBadMockServer() *httptest.Server {
 	}))
 	return httpServer
 }
+
+func TestComparePipelines(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	base, err := resourceManager.CreatePipeline("base", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	targetWorkflow := testWorkflow.DeepCopy()
+	targetWorkflow.Spec.Templates = []v1alpha1.Template{{Name: "train", Container: &corev1.Container{Image: "train:1"}}}
+	targetWorkflow.Spec.Arguments.Parameters = []v1alpha1.Parameter{{Name: "param1", Value: util.StringPointer("hello")}}
+	target, err := resourceManager.CreatePipeline("target", "", []byte(util.NewWorkflow(targetWorkflow).ToStringForStore()))
+	assert.Nil(t, err)
+
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+	diff, err := pipelineServer.ComparePipelines(context.Background(),
+		&api.ComparePipelinesRequest{BaseId: base.UUID, TargetId: target.UUID})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.PipelineDiff{
+		Parameters: []*api.PipelineDiff_Parameter{
+			{Name: "param1", Change: api.PipelineDiff_MODIFIED, TargetValue: "hello"},
+		},
+		Steps: []*api.PipelineDiff_Step{
+			{Name: "train", Change: api.PipelineDiff_ADDED, TargetImage: "train:1"},
+		},
+	}, diff)
+}
+
+func TestComparePipelines_Errors(t *testing.T) {
+	store, manager, pipeline := initWithPipeline(t)
+	defer store.Close()
+	pipelineServer := NewPipelineServer(manager, newURLFetcherForTest())
+
+	_, err := pipelineServer.ComparePipelines(context.Background(), &api.ComparePipelinesRequest{BaseId: pipeline.UUID})
+	AssertUserError(t, err, codes.InvalidArgument)
+
+	_, err = pipelineServer.ComparePipelines(context.Background(),
+		&api.ComparePipelinesRequest{BaseId: pipeline.UUID, TargetId: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestGetPipelineSteps(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	workflow := testWorkflow.DeepCopy()
+	workflow.Spec.Templates = []v1alpha1.Template{
+		{Name: "main", Steps: [][]v1alpha1.WorkflowStep{{{Name: "s", Template: "train"}}}},
+		{Name: "train", Container: &corev1.Container{Image: "train:1"}},
+	}
+	pipeline, err := resourceManager.CreatePipeline("p1", "", []byte(util.NewWorkflow(workflow).ToStringForStore()))
+	assert.Nil(t, err)
+
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+	response, err := pipelineServer.GetPipelineSteps(context.Background(), &api.GetPipelineStepsRequest{Id: pipeline.UUID})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.GetPipelineStepsResponse{Steps: []*api.PipelineStep{
+		{Name: "main", Type: "steps", Children: []string{"train"}},
+		{Name: "train", Type: "container", Image: "train:1", Children: []string{}},
+	}}, response)
+
+	_, err = pipelineServer.GetPipelineSteps(context.Background(), &api.GetPipelineStepsRequest{Id: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestGetPipelineReadme(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	template := []byte(testWorkflow.ToStringForStore())
+	pipeline, err := resourceManager.CreatePipelineWithReadme("p1", "", template,
+		[]byte("# Usage\n<script>alert(1)</script>[docs](javascript:alert(1))"))
+	assert.Nil(t, err)
+	undocumented, err := resourceManager.CreatePipeline("p2", "", template)
+	assert.Nil(t, err)
+
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+	response, err := pipelineServer.GetPipelineReadme(context.Background(), &api.GetPipelineReadmeRequest{Id: pipeline.UUID})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.GetPipelineReadmeResponse{Readme: "# Usage\n[docs](#)"}, response)
+
+	_, err = pipelineServer.GetPipelineReadme(context.Background(), &api.GetPipelineReadmeRequest{Id: undocumented.UUID})
+	AssertUserError(t, err, codes.NotFound)
+
+	assert.Nil(t, resourceManager.DeletePipeline(pipeline.UUID))
+	_, err = clientManager.ObjectStore().GetFile(storage.CreatePipelineReadmePath(pipeline.UUID))
+	assert.NotNil(t, err)
+}
+
+func TestGetPipelineStats(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	pipeline, err := resourceManager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+	stats, err := pipelineServer.GetPipelineStats(context.Background(), &api.GetPipelineStatsRequest{Id: pipeline.UUID})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.PipelineStats{PipelineId: pipeline.UUID}, stats)
+
+	_, err = pipelineServer.GetPipelineStats(context.Background(), &api.GetPipelineStatsRequest{Id: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestValidatePipeline(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	template := testWorkflow.ToStringForStore()
+	pipeline, err := resourceManager.CreatePipeline("p1", "", []byte(template))
+	assert.Nil(t, err)
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+
+	response, err := pipelineServer.ValidatePipeline(context.Background(), &api.ValidatePipelineRequest{Template: template})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ValidatePipelineResponse{Violations: []*api.PolicyViolation{}, Valid: true}, response)
+	response, err = pipelineServer.ValidatePipeline(context.Background(), &api.ValidatePipelineRequest{PipelineId: pipeline.UUID})
+	assert.Nil(t, err)
+	assert.True(t, response.Valid)
+
+	_, err = pipelineServer.ValidatePipeline(context.Background(),
+		&api.ValidatePipelineRequest{PipelineId: pipeline.UUID, Template: template})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = pipelineServer.ValidatePipeline(context.Background(), &api.ValidatePipelineRequest{PipelineId: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestStarPipeline(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	pipeline, err := resourceManager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	_, err = resourceManager.CreatePipeline("p2", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+	alice := common.WithUser(context.Background(), "alice")
+
+	_, err = pipelineServer.StarPipeline(alice, &api.StarPipelineRequest{Id: pipeline.UUID})
+	assert.Nil(t, err)
+	response, err := pipelineServer.ListPipelines(alice, &api.ListPipelinesRequest{OnlyStarred: true})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Pipelines, 1) {
+		assert.Equal(t, pipeline.UUID, response.Pipelines[0].Id)
+	}
+	response, err = pipelineServer.ListPipelines(common.WithUser(context.Background(), "bob"),
+		&api.ListPipelinesRequest{OnlyStarred: true})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Pipelines)
+
+	_, err = pipelineServer.UnstarPipeline(alice, &api.UnstarPipelineRequest{Id: pipeline.UUID})
+	assert.Nil(t, err)
+	response, err = pipelineServer.ListPipelines(alice, &api.ListPipelinesRequest{OnlyStarred: true})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Pipelines)
+}
+
+func TestStarPipeline_Errors(t *testing.T) {
+	clientManager, resourceManager, pipeline := initWithPipeline(t)
+	defer clientManager.Close()
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+
+	_, err := pipelineServer.StarPipeline(context.Background(), &api.StarPipelineRequest{Id: pipeline.UUID})
+	AssertUserError(t, err, codes.Unauthenticated)
+	_, err = pipelineServer.ListPipelines(context.Background(), &api.ListPipelinesRequest{OnlyStarred: true})
+	AssertUserError(t, err, codes.Unauthenticated)
+	_, err = pipelineServer.StarPipeline(common.WithUser(context.Background(), "alice"),
+		&api.StarPipelineRequest{Id: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestDeprecatePipeline(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	pipeline, err := resourceManager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	replacement, err := resourceManager.CreatePipeline("p2", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	pipelineServer := NewPipelineServer(resourceManager, newURLFetcherForTest())
+
+	apiPipeline, err := pipelineServer.DeprecatePipeline(context.Background(), &api.DeprecatePipelineRequest{
+		Id:                    pipeline.UUID,
+		ReplacementPipelineId: replacement.UUID,
+		SunsetAt:              &timestamp.Timestamp{Seconds: 100},
+	})
+	assert.Nil(t, err)
+	assert.True(t, apiPipeline.Deprecated)
+	assert.Equal(t, replacement.UUID, apiPipeline.ReplacementPipelineId)
+	assert.Equal(t, &timestamp.Timestamp{Seconds: 100}, apiPipeline.SunsetAt)
+
+	response, err := pipelineServer.ListPipelines(context.Background(), &api.ListPipelinesRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, response.Pipelines, 2)
+	response, err = pipelineServer.ListPipelines(context.Background(), &api.ListPipelinesRequest{ExcludeDeprecated: true})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Pipelines, 1) {
+		assert.Equal(t, replacement.UUID, response.Pipelines[0].Id)
+	}
+
+	apiPipeline, err = pipelineServer.UndeprecatePipeline(context.Background(),
+		&api.UndeprecatePipelineRequest{Id: pipeline.UUID})
+	assert.Nil(t, err)
+	assert.False(t, apiPipeline.Deprecated)
+	assert.Empty(t, apiPipeline.ReplacementPipelineId)
+	assert.Nil(t, apiPipeline.SunsetAt)
+
+	_, err = pipelineServer.DeprecatePipeline(context.Background(), &api.DeprecatePipelineRequest{
+		Id:       pipeline.UUID,
+		SunsetAt: &timestamp.Timestamp{Seconds: -1},
+	})
+	AssertUserError(t, err, codes.InvalidArgument)
+}
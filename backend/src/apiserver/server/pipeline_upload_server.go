@@ -28,8 +28,9 @@ import (
 
 // These are valid conditions of a ScheduledWorkflow.
 const (
-	FormFileKey        = "uploadfile"
-	NameQueryStringKey = "name"
+	FormFileKey              = "uploadfile"
+	NameQueryStringKey       = "name"
+	OnConflictQueryStringKey = "on_conflict"
 )
 
 type PipelineUploadServer struct {
@@ -51,7 +52,7 @@ func (s *PipelineUploadServer) UploadPipeline(w http.ResponseWriter, r *http.Req
 	}
 	defer file.Close()
 
-	pipelineFile, err := ReadPipelineFile(header.Filename, file, MaxFileLength)
+	pipelinePackage, err := ReadPipelinePackage(header.Filename, file, MaxFileLength)
 	if err != nil {
 		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Error read pipeline file."))
 		return
@@ -63,9 +64,16 @@ func (s *PipelineUploadServer) UploadPipeline(w http.ResponseWriter, r *http.Req
 		s.writeErrorToResponse(w, http.StatusBadRequest, util.Wrap(err, "Invalid pipeline name."))
 		return
 	}
-	newPipeline, err := s.resourceManager.CreatePipeline(pipelineName, "", pipelineFile)
+	onConflict, err := parsePipelineConflict(r.URL.Query().Get(OnConflictQueryStringKey))
 	if err != nil {
-		s.writeErrorToResponse(w, http.StatusInternalServerError, util.Wrap(err, "Error creating pipeline"))
+		s.writeErrorToResponse(w, http.StatusBadRequest, err)
+		return
+	}
+	newPipeline, err := s.resourceManager.CreatePipelineOnConflict(pipelineName, "", pipelinePackage.Template,
+		pipelinePackage.Readme, onConflict)
+	if err != nil {
+		// A conflict fails with 409, so that the uploaders don't have to parse the message.
+		s.writeErrorToResponse(w, httpStatusFromError(err), util.Wrap(err, "Error creating pipeline"))
 		return
 	}
 	apiPipeline := ToApiPipeline(newPipeline)
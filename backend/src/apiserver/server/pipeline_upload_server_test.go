@@ -16,6 +16,7 @@ package server
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"mime/multipart"
 	"net/http"
@@ -62,12 +63,13 @@ func TestUploadPipeline_YAML(t *testing.T) {
 	// Verify metadata in db
 	pkgsExpect := []model.Pipeline{
 		{
-			UUID:           resource.DefaultFakeUUID,
-			CreatedAtInSec: 1,
-			Name:           "hello-world.yaml",
-			Parameters:     "[]",
-			Status:         model.PipelineReady}}
-	pkg, str, err := clientManager.PipelineStore().ListPipelines(&common.PaginationContext{
+			UUID:             resource.DefaultFakeUUID,
+			CreatedAtInSec:   1,
+			Name:             "hello-world.yaml",
+			Parameters:       "[]",
+			Status:           model.PipelineReady,
+			TemplateChecksum: fmt.Sprintf("%x", sha256.Sum256(template))}}
+	pkg, str, err := clientManager.PipelineStore().ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
 		SortByFieldName: model.GetPipelineTablePrimaryKeyColumn(),
@@ -106,12 +108,13 @@ func TestUploadPipeline_Tarball(t *testing.T) {
 	// Verify metadata in db
 	pkgsExpect := []model.Pipeline{
 		{
-			UUID:           resource.DefaultFakeUUID,
-			CreatedAtInSec: 1,
-			Name:           "arguments.tar.gz",
-			Parameters:     "[{\"name\":\"param1\",\"value\":\"hello\"},{\"name\":\"param2\"}]",
-			Status:         model.PipelineReady}}
-	pkg, str, err := clientManager.PipelineStore().ListPipelines(&common.PaginationContext{
+			UUID:             resource.DefaultFakeUUID,
+			CreatedAtInSec:   1,
+			Name:             "arguments.tar.gz",
+			Parameters:       "[{\"name\":\"param1\",\"value\":\"hello\"},{\"name\":\"param2\"}]",
+			Status:           model.PipelineReady,
+			TemplateChecksum: fmt.Sprintf("%x", sha256.Sum256(template))}}
+	pkg, str, err := clientManager.PipelineStore().ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
 		SortByFieldName: model.GetPipelineTablePrimaryKeyColumn(),
@@ -167,12 +170,13 @@ func TestUploadPipeline_SpecifyFileName(t *testing.T) {
 	// Verify metadata in db
 	pkgsExpect := []model.Pipeline{
 		{
-			UUID:           resource.DefaultFakeUUID,
-			CreatedAtInSec: 1,
-			Name:           "foo bar",
-			Parameters:     "[]",
-			Status:         model.PipelineReady}}
-	pkg, str, err := clientManager.PipelineStore().ListPipelines(&common.PaginationContext{
+			UUID:             resource.DefaultFakeUUID,
+			CreatedAtInSec:   1,
+			Name:             "foo bar",
+			Parameters:       "[]",
+			Status:           model.PipelineReady,
+			TemplateChecksum: fmt.Sprintf("%x", sha256.Sum256(template))}}
+	pkg, str, err := clientManager.PipelineStore().ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
 		SortByFieldName: model.GetPipelineTablePrimaryKeyColumn(),
@@ -203,3 +207,44 @@ func TestUploadPipeline_FileNameTooLong(t *testing.T) {
 	assert.Equal(t, 400, rr.Code)
 	assert.Contains(t, string(rr.Body.Bytes()), "Pipeline name too long")
 }
+
+func uploadPipelineFile(server *PipelineUploadServer, query string) *httptest.ResponseRecorder {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	part, _ := w.CreateFormFile("uploadfile", "hello-world.yaml")
+	io.Copy(part, bytes.NewBufferString("apiVersion: argoproj.io/v1alpha1\nkind: Workflow"))
+	w.Close()
+	req, _ := http.NewRequest("POST", "/apis/v1beta1/pipelines/upload?"+query, bytes.NewReader(b.Bytes()))
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(server.UploadPipeline).ServeHTTP(rr, req)
+	return rr
+}
+
+func TestUploadPipeline_OnConflict(t *testing.T) {
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	server := &PipelineUploadServer{resourceManager: resource.NewResourceManager(clientManager)}
+	rr := uploadPipelineFile(server, "")
+	assert.Equal(t, 200, rr.Code)
+
+	// The conflict fails by default, with a status telling it apart from other errors.
+	rr = uploadPipelineFile(server, "")
+	assert.Equal(t, 409, rr.Code)
+	rr = uploadPipelineFile(server, "on_conflict=fail")
+	assert.Equal(t, 409, rr.Code)
+
+	rr = uploadPipelineFile(server, "on_conflict=auto-suffix")
+	assert.Equal(t, 200, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"name":"hello-world.yaml (1)"`)
+
+	rr = uploadPipelineFile(server, "on_conflict=NEW_VERSION")
+	assert.Equal(t, 200, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"name":"hello-world.yaml"`)
+
+	rr = uploadPipelineFile(server, "on_conflict=overwrite")
+	assert.Equal(t, 400, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Unknown on_conflict")
+}
@@ -19,11 +19,13 @@ import (
 	"encoding/json"
 
 	workflow "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/glog"
 	"github.com/golang/protobuf/ptypes/empty"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	scheduledworkflow "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
+	"google.golang.org/grpc/codes"
 )
 
 type ReportServer struct {
@@ -36,13 +38,52 @@ func (s *ReportServer) ReportWorkflow(ctx context.Context,
 	if err != nil {
 		return nil, util.Wrap(err, "Report workflow failed.")
 	}
-	err = s.resourceManager.ReportWorkflowResource(workflow)
+	err = s.resourceManager.ApplyWorkflowReport(workflow)
 	if err != nil {
 		return nil, util.Wrap(err, "Report workflow failed.")
 	}
 	return &empty.Empty{}, nil
 }
 
+// ReportWorkflows reports each workflow of the request independently, so that an invalid
+// workflow doesn't keep the others of its batch from being stored.
+func (s *ReportServer) ReportWorkflows(ctx context.Context,
+	request *api.ReportWorkflowsRequest) (*api.ReportWorkflowsResponse, error) {
+	response := &api.ReportWorkflowsResponse{
+		Results: make([]*api.ReportWorkflowsResponse_ReportWorkflowResult, 0, len(request.Workflows)),
+	}
+	for _, workflowString := range request.Workflows {
+		workflow, err := ValidateReportWorkflowRequest(&api.ReportWorkflowRequest{Workflow: workflowString})
+		if err == nil {
+			err = s.resourceManager.ApplyWorkflowReport(workflow)
+		}
+		response.Results = append(response.Results, newReportWorkflowResult(err))
+	}
+	return response, nil
+}
+
+func newReportWorkflowResult(err error) *api.ReportWorkflowsResponse_ReportWorkflowResult {
+	if err == nil {
+		return &api.ReportWorkflowsResponse_ReportWorkflowResult{
+			Status: api.ReportWorkflowsResponse_ReportWorkflowResult_OK,
+		}
+	}
+	result := &api.ReportWorkflowsResponse_ReportWorkflowResult{
+		Status:  api.ReportWorkflowsResponse_ReportWorkflowResult_INTERNAL_ERROR,
+		Message: err.Error(),
+	}
+	if userError, ok := err.(*util.UserError); ok {
+		if userError.ExternalStatusCode() == codes.InvalidArgument {
+			result.Status = api.ReportWorkflowsResponse_ReportWorkflowResult_INVALID_ARGUMENT
+		}
+		result.Message = userError.ExternalMessage()
+	}
+	if result.Status == api.ReportWorkflowsResponse_ReportWorkflowResult_INTERNAL_ERROR {
+		glog.Errorf("Internal error '%v' when reporting a workflow", err)
+	}
+	return result
+}
+
 func (s *ReportServer) ReportScheduledWorkflow(ctx context.Context,
 	request *api.ReportScheduledWorkflowRequest) (*empty.Empty, error) {
 	scheduledWorkflow, err := ValidateReportScheduledWorkflowRequest(request)
@@ -60,17 +101,21 @@ func ValidateReportWorkflowRequest(request *api.ReportWorkflowRequest) (*util.Wo
 	var workflow1 workflow.Workflow
 	err := json.Unmarshal([]byte(request.Workflow), &workflow1)
 	if err != nil {
-		return nil, util.NewInvalidInputError("Could not unmarshal workflow: %v: %v", err, request.Workflow)
+		// The workflow isn't echoed, since it has the values of its secret parameters.
+		return nil, util.NewInvalidInputError("Could not unmarshal workflow: %v", err)
 	}
 	workflow := util.NewWorkflow(&workflow1)
 	if workflow.Name == "" {
-		return nil, util.NewInvalidInputError("The workflow must have a name: %+v", workflow.Workflow)
+		return nil, util.NewInvalidInputError("The workflow must have a name (namespace: %v, UID: %v)",
+			workflow.Namespace, workflow.UID)
 	}
 	if workflow.Namespace == "" {
-		return nil, util.NewInvalidInputError("The workflow must have a namespace: %+v", workflow.Workflow)
+		return nil, util.NewInvalidInputError("The workflow must have a namespace (name: %v, UID: %v)",
+			workflow.Name, workflow.UID)
 	}
 	if workflow.UID == "" {
-		return nil, util.NewInvalidInputError("The workflow must have a UID: %+v", workflow.Workflow)
+		return nil, util.NewInvalidInputError("The workflow must have a UID (namespace: %v, name: %v)",
+			workflow.Namespace, workflow.Name)
 	}
 	return workflow, nil
 }
@@ -79,18 +124,20 @@ func ValidateReportScheduledWorkflowRequest(request *api.ReportScheduledWorkflow
 	var scheduledWorkflow scheduledworkflow.ScheduledWorkflow
 	err := json.Unmarshal([]byte(request.ScheduledWorkflow), &scheduledWorkflow)
 	if err != nil {
-		return nil, util.NewInvalidInputError("Could not unmarshal scheduled workflow: %v: %v",
-			err, request.ScheduledWorkflow)
+		return nil, util.NewInvalidInputError("Could not unmarshal scheduled workflow: %v", err)
 	}
 	swf := util.NewScheduledWorkflow(&scheduledWorkflow)
 	if swf.Name == "" {
-		return nil, util.NewInvalidInputError("The resource must have a name: %+v", swf.ScheduledWorkflow)
+		return nil, util.NewInvalidInputError("The resource must have a name (namespace: %v, UID: %v)",
+			swf.Namespace, swf.UID)
 	}
 	if swf.Namespace == "" {
-		return nil, util.NewInvalidInputError("The resource must have a namespace: %+v", swf.ScheduledWorkflow)
+		return nil, util.NewInvalidInputError("The resource must have a namespace (name: %v, UID: %v)",
+			swf.Name, swf.UID)
 	}
 	if swf.UID == "" {
-		return nil, util.NewInvalidInputError("The resource must have a UID: %+v", swf.UID)
+		return nil, util.NewInvalidInputError("The resource must have a UID (namespace: %v, name: %v)",
+			swf.Namespace, swf.Name)
 	}
 	return swf, nil
 }
@@ -61,6 +61,37 @@ func TestReportWorkflow_ValidationFailed(t *testing.T) {
 	assert.Contains(t, err.Error(), "must have a name")
 }
 
+func TestReportWorkflows(t *testing.T) {
+	clientManager, resourceManager, run := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	reportServer := NewReportServer(resourceManager)
+
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "run1",
+			Namespace: "default",
+			UID:       types.UID(run.UUID),
+		},
+	})
+	invalidWorkflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			UID:       types.UID(run.UUID),
+		},
+	})
+
+	response, err := reportServer.ReportWorkflows(nil, &api.ReportWorkflowsRequest{
+		Workflows: []string{invalidWorkflow.ToStringForStore(), workflow.ToStringForStore()},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, api.ReportWorkflowsResponse_ReportWorkflowResult_INVALID_ARGUMENT, response.Results[0].Status)
+	assert.Contains(t, response.Results[0].Message, "must have a name")
+	assert.Equal(t, &api.ReportWorkflowsResponse_ReportWorkflowResult{
+		Status: api.ReportWorkflowsResponse_ReportWorkflowResult_OK,
+	}, response.Results[1])
+}
+
 func TestValidateReportWorkflowRequest(t *testing.T) {
 	// Name
 	workflow := &workflowapi.Workflow{
@@ -19,6 +19,7 @@ import (
 
 	"github.com/golang/glog"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"google.golang.org/grpc/codes"
 )
@@ -49,6 +50,33 @@ func ValidateRunMetric(metric *api.RunMetric) error {
 	return nil
 }
 
+// reportRunMetrics stores the valid metrics of a run at once and returns the result of each
+// metric, in order.
+func reportRunMetrics(resourceManager *resource.ResourceManager, runId string,
+	metrics []*api.RunMetric) *api.ReportRunMetricsResponse {
+	errs := make([]error, len(metrics))
+	var valid []*api.RunMetric
+	var validIndexes []int
+	for i, metric := range metrics {
+		if errs[i] = ValidateRunMetric(metric); errs[i] == nil {
+			valid = append(valid, metric)
+			validIndexes = append(validIndexes, i)
+		}
+	}
+	for i, err := range resourceManager.ReportMetrics(valid, runId) {
+		errs[validIndexes[i]] = err
+	}
+	response := &api.ReportRunMetricsResponse{
+		Results: []*api.ReportRunMetricsResponse_ReportRunMetricResult{},
+	}
+	for i, metric := range metrics {
+		response.Results = append(
+			response.Results,
+			NewReportRunMetricResult(metric.GetName(), metric.GetNodeId(), errs[i]))
+	}
+	return response
+}
+
 // NewReportRunMetricResult turns error into a ReportRunMetricResult.
 func NewReportRunMetricResult(
 	metricName string, nodeID string, err error) *api.ReportRunMetricsResponse_ReportRunMetricResult {
@@ -16,13 +16,27 @@ package server
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/golang/protobuf/ptypes/empty"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 )
 
+// The interval at which WatchRun re-reads the run, in case its state changes are reported
+// to another replica of the API server.
+var watchRunResyncInterval = 10 * time.Second
+
+const (
+	// The column sizes of the note and the annotations of runs.
+	maxRunNoteLength       = 65535
+	maxRunAnnotationLength = 255
+)
+
 type RunServer struct {
 	resourceManager *resource.ResourceManager
 }
@@ -32,11 +46,17 @@ func (s *RunServer) CreateRun(ctx context.Context, request *api.CreateRunRequest
 	if err != nil {
 		return nil, util.Wrap(err, "Validate create run request failed.")
 	}
-	run, err := s.resourceManager.CreateRun(request.Run)
+	warnings, err := s.resourceManager.CheckPipelineDeprecation(request.Run.GetPipelineSpec())
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to create a new run.")
 	}
-	return ToApiRunDetail(run), nil
+	run, err := s.resourceManager.CreateRun(request.Run, common.GetRequestId(ctx))
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create a new run.")
+	}
+	runDetail := ToApiRunDetail(run)
+	runDetail.Warnings = warnings
+	return runDetail, nil
 }
 
 func (s *RunServer) GetRun(ctx context.Context, request *api.GetRunRequest) (*api.RunDetail, error) {
@@ -47,6 +67,18 @@ func (s *RunServer) GetRun(ctx context.Context, request *api.GetRunRequest) (*ap
 	return ToApiRunDetail(run), nil
 }
 
+func (s *RunServer) GetRunManifest(ctx context.Context, request *api.GetRunManifestRequest) (*api.RunManifest, error) {
+	run, err := s.resourceManager.GetRun(request.RunId)
+	if err != nil {
+		return nil, util.Wrap(err, "Get run manifest failed.")
+	}
+	manifest, err := ToApiRunManifest(run)
+	if err != nil {
+		return nil, util.Wrap(err, "Get run manifest failed.")
+	}
+	return manifest, nil
+}
+
 func (s *RunServer) ListRuns(ctx context.Context, request *api.ListRunsRequest) (*api.ListRunsResponse, error) {
 	paginationContext, err := ValidatePagination(
 		request.PageToken, int(request.PageSize), model.GetRunTablePrimaryKeyColumn(),
@@ -58,32 +90,111 @@ func (s *RunServer) ListRuns(ctx context.Context, request *api.ListRunsRequest)
 	if err != nil {
 		return nil, util.Wrap(err, "Validating filter failed.")
 	}
-	runs, nextPageToken, err := s.resourceManager.ListRuns(filterContext, paginationContext)
+	if filterContext.Name, err = ValidateNameFilter(request.NameFilter); err != nil {
+		return nil, util.Wrap(err, "Validating filter failed.")
+	}
+	if request.AnnotationFilter != "" {
+		if filterContext.Annotation, err = parseAnnotationFilter(request.AnnotationFilter); err != nil {
+			return nil, util.Wrap(err, "Validating filter failed.")
+		}
+	}
+	if request.OnlyStarred {
+		if filterContext.StarredBy, err = getAuthenticatedUser(ctx); err != nil {
+			return nil, util.Wrap(err, "Validating filter failed.")
+		}
+	}
+	view := common.BasicView
+	if request.View == api.ListRunsRequest_FULL {
+		view = common.FullView
+	}
+	runs, nextPageToken, err := s.resourceManager.ListRuns(filterContext, paginationContext, view)
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to list runs.")
 	}
 	return &api.ListRunsResponse{Runs: ToApiRuns(runs), NextPageToken: nextPageToken}, nil
 }
 
+// UpdateRun sets the note and the annotations of a run. The annotations not in the request are left
+// unchanged, and the ones set to an empty value are removed.
+func (s *RunServer) UpdateRun(ctx context.Context, request *api.UpdateRunRequest) (*api.Run, error) {
+	if err := validateUpdateRunRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate update run request failed.")
+	}
+	var note *string
+	if request.GetUpdateNote() {
+		note = &request.Note
+	}
+	run, err := s.resourceManager.UpdateRunAnnotations(request.GetRunId(), note, request.GetAnnotations())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to update the run.")
+	}
+	return ToApiRunDetail(run).Run, nil
+}
+
+func (s *RunServer) StarRun(ctx context.Context, request *api.StarRunRequest) (*empty.Empty, error) {
+	userId, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to star the run.")
+	}
+	if err := s.resourceManager.StarResource(userId, common.Run, request.GetRunId()); err != nil {
+		return nil, util.Wrap(err, "Failed to star the run.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *RunServer) UnstarRun(ctx context.Context, request *api.UnstarRunRequest) (*empty.Empty, error) {
+	userId, err := getAuthenticatedUser(ctx)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to unstar the run.")
+	}
+	if err := s.resourceManager.UnstarResource(userId, common.Run, request.GetRunId()); err != nil {
+		return nil, util.Wrap(err, "Failed to unstar the run.")
+	}
+	return &empty.Empty{}, nil
+}
+
+// ApproveRun approves the approval gates of a run as the user who made the call, if any.
+func (s *RunServer) ApproveRun(ctx context.Context, request *api.ApproveRunRequest) (*empty.Empty, error) {
+	err := s.resourceManager.ApproveRun(request.GetRunId(), request.GetNodeId(), common.GetUser(ctx), request.GetComment())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to approve the run.")
+	}
+	return &empty.Empty{}, nil
+}
+
+// RejectRun rejects the approval gates of a run as the user who made the call, if any.
+func (s *RunServer) RejectRun(ctx context.Context, request *api.RejectRunRequest) (*empty.Empty, error) {
+	err := s.resourceManager.RejectRun(request.GetRunId(), request.GetNodeId(), common.GetUser(ctx), request.GetReason())
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to reject the run.")
+	}
+	return &empty.Empty{}, nil
+}
+
 func (s *RunServer) ReportRunMetrics(ctx context.Context, request *api.ReportRunMetricsRequest) (*api.ReportRunMetricsResponse, error) {
 	// Makes sure run exists
 	_, err := s.resourceManager.GetRun(request.GetRunId())
 	if err != nil {
 		return nil, err
 	}
-	response := &api.ReportRunMetricsResponse{
-		Results: []*api.ReportRunMetricsResponse_ReportRunMetricResult{},
+	return reportRunMetrics(s.resourceManager, request.GetRunId(), request.GetMetrics()), nil
+}
+
+func (s *RunServer) GetRunOutputs(ctx context.Context, request *api.GetRunOutputsRequest) (*api.GetRunOutputsResponse, error) {
+	outputs, err := s.resourceManager.GetRunOutputs(request.GetRunId())
+	if err != nil {
+		return nil, util.Wrapf(err, "failed to get the outputs of run '%v'.", request.GetRunId())
 	}
-	for _, metric := range request.GetMetrics() {
-		err := ValidateRunMetric(metric)
-		if err == nil {
-			err = s.resourceManager.ReportMetric(metric, request.GetRunId())
-		}
-		response.Results = append(
-			response.Results,
-			NewReportRunMetricResult(metric.GetName(), metric.GetNodeId(), err))
+	return &api.GetRunOutputsResponse{Outputs: ToApiRunOutputs(outputs)}, nil
+}
+
+func (s *RunServer) GetRunHistory(ctx context.Context, request *api.GetRunHistoryRequest) (*api.GetRunHistoryResponse, error) {
+	transitions, err := s.resourceManager.GetRunHistory(
+		request.GetRunId(), request.GetNodeId(), request.GetAsOf().GetSeconds())
+	if err != nil {
+		return nil, util.Wrapf(err, "failed to get the history of run '%v'.", request.GetRunId())
 	}
-	return response, nil
+	return &api.GetRunHistoryResponse{Transitions: ToApiRunStateTransitions(transitions)}, nil
 }
 
 func (s *RunServer) ReadArtifact(ctx context.Context, request *api.ReadArtifactRequest) (*api.ReadArtifactResponse, error) {
@@ -97,22 +208,265 @@ func (s *RunServer) ReadArtifact(ctx context.Context, request *api.ReadArtifactR
 	}, nil
 }
 
+func (s *RunServer) ReadRunLogs(ctx context.Context, request *api.ReadRunLogsRequest) (*api.ReadRunLogsResponse, error) {
+	logs, archived, err := s.resourceManager.ReadRunLogs(request.GetRunId(), request.GetNodeId())
+	if err != nil {
+		return nil, util.Wrapf(err, "failed to read run logs '%+v'.", request)
+	}
+	return &api.ReadRunLogsResponse{
+		Logs:     logs,
+		Archived: archived,
+	}, nil
+}
+
+func (s *RunServer) ReportRunLogs(ctx context.Context, request *api.ReportRunLogsRequest) (*empty.Empty, error) {
+	if request.GetRunId() == "" || request.GetNodeId() == "" {
+		return nil, util.NewInvalidInputError("The run ID and the node ID of the logs are required.")
+	}
+	err := s.resourceManager.ReportRunLogs(request.GetRunId(), request.GetNodeId(), request.GetLogs())
+	if err != nil {
+		return nil, util.Wrapf(err, "failed to report run logs of node '%v' of run '%v'.",
+			request.GetNodeId(), request.GetRunId())
+	}
+	return &empty.Empty{}, nil
+}
+
+// WatchRun sends the run every time its status changes, starting with its current state,
+// until it reaches a final state.
+func (s *RunServer) WatchRun(request *api.WatchRunRequest, stream api.RunService_WatchRunServer) error {
+	changes, stop := s.resourceManager.WatchRun(request.RunId)
+	defer stop()
+	resync := time.NewTicker(watchRunResyncInterval)
+	defer resync.Stop()
+	sent := false
+	lastCondition := ""
+	for {
+		run, err := s.resourceManager.GetRun(request.RunId)
+		if err != nil {
+			return err
+		}
+		if !sent || run.Conditions != lastCondition {
+			if err := stream.Send(ToApiRunDetail(run)); err != nil {
+				return util.NewInternalServerError(err, "Failed to send the run %v", request.RunId)
+			}
+			sent = true
+			lastCondition = run.Conditions
+		}
+		if util.IsFinalCondition(run.Conditions) {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-changes:
+		case <-resync.C:
+		}
+	}
+}
+
+func (s *RunServer) EstimateRun(ctx context.Context, request *api.EstimateRunRequest) (*api.RunEstimate, error) {
+	if request.GetRun() == nil {
+		return nil, util.NewInvalidInputError("The run is required.")
+	}
+	if err := ValidatePipelineSpec(s.resourceManager, request.Run.PipelineSpec); err != nil {
+		return nil, util.Wrap(err, "The pipeline spec is invalid.")
+	}
+	estimate, err := s.resourceManager.EstimateRun(request.Run)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to estimate the run.")
+	}
+	return ToApiRunEstimate(estimate), nil
+}
+
+func (s *RunServer) CreateRunSweep(ctx context.Context, request *api.CreateRunSweepRequest) (*api.RunSweep, error) {
+	if err := s.validateCreateRunSweepRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate create run sweep request failed.")
+	}
+	sweep, err := s.resourceManager.CreateRunSweep(request.Sweep)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create a new run sweep.")
+	}
+	detail, err := s.resourceManager.GetRunSweep(sweep.UUID)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create a new run sweep.")
+	}
+	return ToApiRunSweep(detail)
+}
+
+func (s *RunServer) GetRunSweep(ctx context.Context, request *api.GetRunSweepRequest) (*api.RunSweep, error) {
+	detail, err := s.resourceManager.GetRunSweep(request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return ToApiRunSweep(detail)
+}
+
+func (s *RunServer) CreateRunGroup(ctx context.Context, request *api.CreateRunGroupRequest) (*api.RunGroup, error) {
+	group := request.GetGroup()
+	if group.GetName() == "" {
+		return nil, util.NewInvalidInputError("The run group name is empty. Please specify a valid name.")
+	}
+	detail, err := s.resourceManager.CreateRunGroup(
+		&model.RunGroup{Name: group.Name, Description: group.Description}, group.RunIds)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create a new run group.")
+	}
+	return ToApiRunGroup(detail), nil
+}
+
+func (s *RunServer) GetRunGroup(ctx context.Context, request *api.GetRunGroupRequest) (*api.RunGroup, error) {
+	detail, err := s.resourceManager.GetRunGroup(request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return ToApiRunGroup(detail), nil
+}
+
+func (s *RunServer) ListRunGroups(ctx context.Context, request *api.ListRunGroupsRequest) (
+	*api.ListRunGroupsResponse, error) {
+	paginationContext, err := ValidatePagination(
+		request.PageToken, int(request.PageSize), model.GetRunGroupTablePrimaryKeyColumn(),
+		request.SortBy, runGroupModelFieldsBySortableAPIFields)
+	if err != nil {
+		return nil, util.Wrap(err, "List run groups failed.")
+	}
+	details, nextPageToken, err := s.resourceManager.ListRunGroups(paginationContext)
+	if err != nil {
+		return nil, util.Wrap(err, "List run groups failed.")
+	}
+	groups := make([]*api.RunGroup, 0, len(details))
+	for _, detail := range details {
+		groups = append(groups, ToApiRunGroup(detail))
+	}
+	return &api.ListRunGroupsResponse{Groups: groups, NextPageToken: nextPageToken}, nil
+}
+
+func (s *RunServer) AddRunsToRunGroup(ctx context.Context, request *api.AddRunsToRunGroupRequest) (
+	*api.RunGroup, error) {
+	if len(request.RunIds) == 0 {
+		return nil, util.NewInvalidInputError("No run to add. Please specify the IDs of the runs.")
+	}
+	detail, err := s.resourceManager.AddRunsToRunGroup(request.Id, request.RunIds)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to add the runs to the run group.")
+	}
+	return ToApiRunGroup(detail), nil
+}
+
+func (s *RunServer) CancelRunGroup(ctx context.Context, request *api.CancelRunGroupRequest) (*api.RunGroup, error) {
+	detail, err := s.resourceManager.CancelRunGroup(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to cancel the run group.")
+	}
+	return ToApiRunGroup(detail), nil
+}
+
+func (s *RunServer) TerminateAll(ctx context.Context, request *api.TerminateAllRequest) (
+	*api.TerminateAllOperation, error) {
+	if (request.ExperimentId == "") == (request.RunGroupId == "") {
+		return nil, util.NewInvalidInputError(
+			"Exactly one of the experiment ID and the run group ID must be specified. Received %q and %q.",
+			request.ExperimentId, request.RunGroupId)
+	}
+	detail, err := s.resourceManager.TerminateAll(request.ExperimentId, request.RunGroupId)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to terminate the runs.")
+	}
+	return ToApiTerminateAllOperation(detail), nil
+}
+
+func (s *RunServer) GetTerminateAllOperation(ctx context.Context, request *api.GetTerminateAllOperationRequest) (
+	*api.TerminateAllOperation, error) {
+	detail, err := s.resourceManager.GetTerminateOperation(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to get the terminate operation.")
+	}
+	return ToApiTerminateAllOperation(detail), nil
+}
+
+func (s *RunServer) validateCreateRunSweepRequest(request *api.CreateRunSweepRequest) error {
+	sweep := request.GetSweep()
+	if sweep.GetName() == "" {
+		return util.NewInvalidInputError("The sweep name is empty. Please specify a valid name.")
+	}
+	if sweep.GetRun() == nil {
+		return util.NewInvalidInputError("The run of the sweep is required.")
+	}
+	if sweep.GetMaxConcurrency() < 0 {
+		return util.NewInvalidInputError("The max concurrency of the sweep is negative. Received %v.",
+			sweep.GetMaxConcurrency())
+	}
+	// The runs must be created under an experiment.
+	if err := ValidateExperimentResourceReference(s.resourceManager, sweep.Run.ResourceReferences); err != nil {
+		return util.Wrap(err, "The run of the sweep must have a valid experiment resource reference.")
+	}
+	if err := ValidatePipelineSpec(s.resourceManager, sweep.Run.PipelineSpec); err != nil {
+		return util.Wrap(err, "The pipeline spec is invalid.")
+	}
+	return nil
+}
+
 func (s *RunServer) validateCreateRunRequest(request *api.CreateRunRequest) error {
 	run := request.Run
 	if run.Name == "" {
 		return util.NewInvalidInputError("The run name is empty. Please specify a valid name.")
 	}
-	// Run must be created under an experiment.
+	if _, err := util.ValidateName(run.Name, util.MaxNameLength); err != nil {
+		return util.Wrap(err, "Invalid run name.")
+	}
+	if err := ValidatePipelineSpec(s.resourceManager, run.PipelineSpec); err != nil {
+		return util.Wrap(err, "The pipeline spec is invalid.")
+	}
+	// Run must be created under an experiment. The experiment referred to by its name is
+	// created last, once the rest of the request is known to be valid.
+	if err := s.resourceManager.ResolveExperimentReference(run.ResourceReferences); err != nil {
+		return util.Wrap(err, "The run must have a valid experiment resource reference.")
+	}
 	if err := ValidateExperimentResourceReference(s.resourceManager, run.ResourceReferences); err != nil {
 		return util.Wrap(err, "The run must have a valid experiment resource reference.")
 	}
+	return nil
+}
 
-	if err := ValidatePipelineSpec(s.resourceManager, run.PipelineSpec); err != nil {
-		return util.Wrap(err, "The pipeline spec is invalid.")
+func validateUpdateRunRequest(request *api.UpdateRunRequest) error {
+	if request.GetRunId() == "" {
+		return util.NewInvalidInputError("The run ID is required.")
+	}
+	if len(request.GetNote()) > maxRunNoteLength {
+		return util.NewInvalidInputError("The note is longer than %v characters.", maxRunNoteLength)
+	}
+	for name, value := range request.GetAnnotations() {
+		if err := validateAnnotation(name, value); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func validateAnnotation(name string, value string) error {
+	if name == "" {
+		return util.NewInvalidInputError("The annotation name is empty. Please specify a valid name.")
+	}
+	if len(name) > maxRunAnnotationLength || len(value) > maxRunAnnotationLength {
+		return util.NewInvalidInputError("The annotation %q is longer than %v characters.", name,
+			maxRunAnnotationLength)
+	}
+	return nil
+}
+
+// parseAnnotationFilter parses a filter on the annotations of runs, either "name" matching the runs
+// with the annotation, or "name=value" matching the runs where the annotation has this value.
+func parseAnnotationFilter(filter string) (*common.Annotation, error) {
+	annotation := &common.Annotation{Name: filter}
+	if i := strings.Index(filter, "="); i >= 0 {
+		annotation.Name, annotation.Value = filter[:i], filter[i+1:]
+	}
+	if err := validateAnnotation(annotation.Name, annotation.Value); err != nil {
+		return nil, util.Wrapf(err, "Invalid annotation filter %q.", filter)
+	}
+	return annotation, nil
+}
+
 func NewRunServer(resourceManager *resource.ResourceManager) *RunServer {
 	return &RunServer{resourceManager: resourceManager}
 }
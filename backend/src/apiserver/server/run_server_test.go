@@ -7,9 +7,15 @@ import (
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestCreateRun(t *testing.T) {
@@ -30,6 +36,7 @@ func TestCreateRun(t *testing.T) {
 	expectedRuntimeWorkflow := testWorkflow.DeepCopy()
 	expectedRuntimeWorkflow.Spec.Arguments.Parameters = []v1alpha1.Parameter{
 		{Name: "param1", Value: util.StringPointer("world")}}
+	expectedRuntimeWorkflow.Labels = map[string]string{util.LabelKeyWorkflowExperimentId: experiment.UUID}
 	expectedRunDetail := api.RunDetail{
 		Run: &api.Run{
 			Id:          "workflow1",
@@ -54,6 +61,143 @@ func TestCreateRun(t *testing.T) {
 	assert.Equal(t, expectedRunDetail, *runDetail)
 }
 
+func TestCreateRun_ExperimentName(t *testing.T) {
+	clients := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clients.Close()
+	manager := resource.NewResourceManager(clients)
+	server := NewRunServer(manager)
+	newRun := func(experimentName string, workflowUID types.UID) *api.Run {
+		workflow := util.NewWorkflow(testWorkflow.DeepCopy())
+		workflow.UID = workflowUID
+		return &api.Run{
+			Name: "123",
+			ResourceReferences: []*api.ResourceReference{{
+				Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT},
+				Name:         experimentName,
+				Relationship: api.Relationship_OWNER,
+			}},
+			PipelineSpec: &api.PipelineSpec{
+				WorkflowManifest: workflow.ToStringForStore(),
+				Parameters:       []*api.Parameter{{Name: "param1", Value: "world"}},
+			},
+		}
+	}
+
+	// The experiment is created if missing.
+	runDetail, err := server.CreateRun(nil, &api.CreateRunRequest{Run: newRun("e1", "workflow1")})
+	assert.Nil(t, err)
+	experiment, err := clients.ExperimentStore().GetExperimentByName("e1")
+	assert.Nil(t, err)
+	assert.Equal(t, experiment.UUID, runDetail.Run.ResourceReferences[0].Key.Id)
+
+	// Then used by the next runs.
+	runDetail, err = server.CreateRun(nil, &api.CreateRunRequest{Run: newRun("e1", "workflow2")})
+	assert.Nil(t, err)
+	assert.Equal(t, experiment.UUID, runDetail.Run.ResourceReferences[0].Key.Id)
+	experiments, _, err := manager.ListExperiments(&common.FilterContext{}, &common.PaginationContext{
+		PageSize: 10, KeyFieldName: model.GetExperimentTablePrimaryKeyColumn(),
+		SortByFieldName: model.GetExperimentTablePrimaryKeyColumn()})
+	assert.Nil(t, err)
+	assert.Len(t, experiments, 1)
+}
+
+func TestCreateRun_ExperimentName_InvalidRun(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager)
+	run := &api.Run{
+		Name: "123",
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT},
+			Name:         "new experiment",
+			Relationship: api.Relationship_OWNER,
+		}},
+	}
+	_, err := server.CreateRun(nil, &api.CreateRunRequest{Run: run})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "The pipeline spec is invalid.")
+
+	// The experiment isn't created for a run which fails validation.
+	_, err = clients.ExperimentStore().GetExperimentByName("new experiment")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
+
+func TestGetRunManifest(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager)
+	run := &api.Run{
+		Name:               "123",
+		ResourceReferences: validReference,
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*api.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	runDetail, err := server.CreateRun(nil, &api.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+
+	manifest, err := server.GetRunManifest(nil, &api.GetRunManifestRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.RunManifest{
+		RunId:              "workflow1",
+		SubmittedManifest:  testWorkflow.ToStringForStore(),
+		RuntimeManifest:    runDetail.PipelineRuntime.WorkflowManifest,
+		ResolvedParameters: []*api.Parameter{{Name: "param1", Value: "world"}},
+	}, manifest)
+
+	_, err = server.GetRunManifest(nil, &api.GetRunManifestRequest{RunId: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestCreateRun_DeprecatedPipeline(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	pipeline, err := manager.CreatePipeline("p1", "", []byte(testWorkflow.ToStringForStore()))
+	assert.Nil(t, err)
+	_, err = manager.DeprecatePipeline(pipeline.UUID, "", 0)
+	assert.Nil(t, err)
+	server := NewRunServer(manager)
+	run := &api.Run{
+		Name:               "123",
+		ResourceReferences: validReference,
+		PipelineSpec: &api.PipelineSpec{
+			PipelineId: pipeline.UUID,
+			Parameters: []*api.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	runDetail, err := server.CreateRun(nil, &api.CreateRunRequest{Run: run})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Pipeline p1 is deprecated."}, runDetail.Warnings)
+
+	// The warnings are only returned at creation.
+	runDetail, err = server.GetRun(nil, &api.GetRunRequest{RunId: runDetail.Run.Id})
+	assert.Nil(t, err)
+	assert.Empty(t, runDetail.Warnings)
+}
+
+func TestListRuns_ManifestsOnlyInFullView(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager)
+	_, err := server.CreateRun(nil, &api.CreateRunRequest{Run: &api.Run{
+		Name:               "123",
+		ResourceReferences: validReference,
+		PipelineSpec:       &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+	}})
+	assert.Nil(t, err)
+
+	response, err := server.ListRuns(nil, &api.ListRunsRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, response.Runs, 1)
+	assert.Empty(t, response.Runs[0].PipelineSpec.WorkflowManifest)
+
+	response, err = server.ListRuns(nil, &api.ListRunsRequest{View: api.ListRunsRequest_FULL})
+	assert.Nil(t, err)
+	assert.Len(t, response.Runs, 1)
+	assert.Equal(t, testWorkflow.ToStringForStore(), response.Runs[0].PipelineSpec.WorkflowManifest)
+}
+
 func TestValidateCreateRunRequest(t *testing.T) {
 	clients, manager, _ := initWithExperiment(t)
 	defer clients.Close()
@@ -86,6 +230,23 @@ func TestValidateCreateRunRequest_EmptyName(t *testing.T) {
 	assert.Contains(t, err.Error(), "The run name is empty")
 }
 
+func TestValidateCreateRunRequest_InvalidName(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager)
+	run := &api.Run{
+		Name:               "run\n1",
+		ResourceReferences: validReference,
+		PipelineSpec: &api.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			Parameters:       []*api.Parameter{{Name: "param1", Value: "world"}},
+		},
+	}
+	err := server.validateCreateRunRequest(&api.CreateRunRequest{Run: run})
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "Invalid run name")
+}
+
 func TestValidateCreateRunRequest_EmptyPipelineSpec(t *testing.T) {
 	clients, manager, _ := initWithExperiment(t)
 	defer clients.Close()
@@ -123,6 +284,62 @@ func TestValidateCreateRunRequest_TooMuchParameters(t *testing.T) {
 	assert.Contains(t, err.Error(), "The input parameter length exceed maximum size")
 }
 
+func TestReportRunLogs_MissingNodeID(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+
+	_, err := runServer.ReportRunLogs(context.Background(), &api.ReportRunLogsRequest{
+		RunId: runDetails.UUID,
+		Logs:  []byte("hello"),
+	})
+	AssertUserError(t, err, codes.InvalidArgument)
+}
+
+func TestReadRunLogs_RunNotFound(t *testing.T) {
+	clientManager, resourceManager, _ := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+
+	_, err := runServer.ReadRunLogs(context.Background(), &api.ReadRunLogsRequest{
+		RunId:  "1",
+		NodeId: "node-1",
+	})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestGetRunOutputs_RunNotFound(t *testing.T) {
+	clientManager, resourceManager, _ := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+
+	_, err := runServer.GetRunOutputs(context.Background(), &api.GetRunOutputsRequest{RunId: "1"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestGetRunHistory(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+	err := clientManager.RunHistoryStore().AppendRunStateTransitions([]*model.RunStateTransition{
+		{RunUUID: runDetails.UUID, State: "Running", TimeInSec: 10},
+		{RunUUID: runDetails.UUID, NodeID: "node1", NodeName: "train", State: "Failed", TimeInSec: 20, Message: "OOMKilled"},
+		{RunUUID: runDetails.UUID, Sequence: 1, State: "Failed", TimeInSec: 30},
+	})
+	assert.Nil(t, err)
+
+	response, err := runServer.GetRunHistory(context.Background(), &api.GetRunHistoryRequest{
+		RunId: runDetails.UUID, AsOf: &timestamp.Timestamp{Seconds: 20}})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.RunStateTransition{
+		{State: "Running", Time: &timestamp.Timestamp{Seconds: 10}},
+		{NodeId: "node1", NodeName: "train", State: "Failed", Time: &timestamp.Timestamp{Seconds: 20}, Message: "OOMKilled"},
+	}, response.Transitions)
+
+	_, err = runServer.GetRunHistory(context.Background(), &api.GetRunHistoryRequest{RunId: "1"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
 func TestReportRunMetrics_RunNotFound(t *testing.T) {
 	httpServer := getMockServer(t)
 	// Close the server when test finishes
@@ -232,3 +449,337 @@ func TestReportRunMetrics_PartialFailures(t *testing.T) {
 	}
 	assert.Equal(t, expectedResponse, response)
 }
+
+type fakeWatchRunServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	runs chan *api.RunDetail
+}
+
+func (s *fakeWatchRunServer) Send(runDetail *api.RunDetail) error {
+	s.runs <- runDetail
+	return nil
+}
+
+func (s *fakeWatchRunServer) Context() context.Context {
+	return s.ctx
+}
+
+func TestWatchRun(t *testing.T) {
+	clients, manager, runDetail := initWithOneTimeRun(t)
+	defer clients.Close()
+	server := NewRunServer(manager)
+	stream := &fakeWatchRunServer{ctx: context.Background(), runs: make(chan *api.RunDetail, 10)}
+	done := make(chan error)
+	go func() {
+		done <- server.WatchRun(&api.WatchRunRequest{RunId: runDetail.UUID}, stream)
+	}()
+	assert.Equal(t, "", (<-stream.runs).Run.Status)
+
+	workflow := util.NewWorkflow(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow-name", UID: types.UID(runDetail.UUID)},
+		Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.NodeSucceeded},
+	})
+	assert.Nil(t, manager.ReportWorkflowResource(workflow))
+	assert.Equal(t, "Succeeded", (<-stream.runs).Run.Status)
+	assert.Nil(t, <-done)
+}
+
+func TestWatchRun_StopsWhenCanceled(t *testing.T) {
+	clients, manager, runDetail := initWithOneTimeRun(t)
+	defer clients.Close()
+	server := NewRunServer(manager)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeWatchRunServer{ctx: ctx, runs: make(chan *api.RunDetail, 10)}
+	done := make(chan error)
+	go func() {
+		done <- server.WatchRun(&api.WatchRunRequest{RunId: runDetail.UUID}, stream)
+	}()
+	<-stream.runs
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestWatchRun_NotFound(t *testing.T) {
+	clients, manager, _ := initWithExperiment(t)
+	defer clients.Close()
+	server := NewRunServer(manager)
+	stream := &fakeWatchRunServer{ctx: context.Background(), runs: make(chan *api.RunDetail, 10)}
+	err := server.WatchRun(&api.WatchRunRequest{RunId: "unknown"}, stream)
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestUpdateRun(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+
+	run, err := runServer.UpdateRun(context.Background(), &api.UpdateRunRequest{
+		RunId:       runDetails.UUID,
+		UpdateNote:  true,
+		Note:        "rerun of the nightly training",
+		Annotations: map[string]string{"quality": "bad data day"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "rerun of the nightly training", run.Note)
+	assert.Equal(t, map[string]string{"quality": "bad data day"}, run.Annotations)
+
+	response, err := runServer.ListRuns(context.Background(), &api.ListRunsRequest{AnnotationFilter: "quality=bad data day"})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Runs, 1) {
+		assert.Equal(t, runDetails.UUID, response.Runs[0].Id)
+		assert.Equal(t, "rerun of the nightly training", response.Runs[0].Note)
+	}
+	response, err = runServer.ListRuns(context.Background(), &api.ListRunsRequest{AnnotationFilter: "quality=good"})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Runs)
+}
+
+func TestUpdateRun_InvalidAnnotation(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+
+	_, err := runServer.UpdateRun(context.Background(), &api.UpdateRunRequest{
+		RunId:       runDetails.UUID,
+		Annotations: map[string]string{"": "bad data day"},
+	})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = runServer.ListRuns(context.Background(), &api.ListRunsRequest{AnnotationFilter: "=bad"})
+	AssertUserError(t, err, codes.InvalidArgument)
+}
+
+func TestUpdateRun_RunNotFound(t *testing.T) {
+	clientManager, resourceManager, _ := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+
+	_, err := runServer.UpdateRun(context.Background(), &api.UpdateRunRequest{
+		RunId:       "not-exist",
+		Annotations: map[string]string{"quality": "bad data day"},
+	})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestApproveRun_NoWaitingGate(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+	alice := common.WithUser(context.Background(), "alice")
+
+	_, err := runServer.ApproveRun(alice, &api.ApproveRunRequest{RunId: runDetails.UUID, Comment: "LGTM"})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = runServer.RejectRun(alice, &api.RejectRunRequest{RunId: runDetails.UUID, NodeId: "node1"})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = runServer.ApproveRun(alice, &api.ApproveRunRequest{RunId: "not-exist"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestStarRun(t *testing.T) {
+	clientManager, resourceManager, runDetails := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	runServer := RunServer{resourceManager: resourceManager}
+	alice := common.WithUser(context.Background(), "alice")
+
+	_, err := runServer.StarRun(alice, &api.StarRunRequest{RunId: runDetails.UUID})
+	assert.Nil(t, err)
+	response, err := runServer.ListRuns(alice, &api.ListRunsRequest{OnlyStarred: true})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Runs, 1) {
+		assert.Equal(t, runDetails.UUID, response.Runs[0].Id)
+	}
+
+	_, err = runServer.UnstarRun(alice, &api.UnstarRunRequest{RunId: runDetails.UUID})
+	assert.Nil(t, err)
+	response, err = runServer.ListRuns(alice, &api.ListRunsRequest{OnlyStarred: true})
+	assert.Nil(t, err)
+	assert.Empty(t, response.Runs)
+
+	_, err = runServer.StarRun(context.Background(), &api.StarRunRequest{RunId: runDetails.UUID})
+	AssertUserError(t, err, codes.Unauthenticated)
+	_, err = runServer.StarRun(alice, &api.StarRunRequest{RunId: "not-exist"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestEstimateRun(t *testing.T) {
+	clientManager, resourceManager, _ := initWithExperiment(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+
+	estimate, err := server.EstimateRun(context.Background(), &api.EstimateRunRequest{Run: &api.Run{
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, "USD", estimate.Currency)
+	assert.Zero(t, estimate.PastRuns)
+	assert.Contains(t, estimate.Warnings, "The run has no pipeline, so the durations of its steps are unknown")
+}
+
+func TestEstimateRun_InvalidRequest(t *testing.T) {
+	clientManager, resourceManager, _ := initWithExperiment(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+
+	_, err := server.EstimateRun(context.Background(), &api.EstimateRunRequest{})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = server.EstimateRun(context.Background(), &api.EstimateRunRequest{Run: &api.Run{}})
+	AssertUserError(t, err, codes.InvalidArgument)
+}
+
+func TestCreateRunSweep(t *testing.T) {
+	clientManager, resourceManager, experiment := initWithExperiment(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+
+	sweep, err := server.CreateRunSweep(context.Background(), &api.CreateRunSweepRequest{Sweep: &api.RunSweep{
+		Name: "sweep",
+		Run: &api.Run{
+			PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+			ResourceReferences: []*api.ResourceReference{{
+				Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+				Relationship: api.Relationship_OWNER,
+			}},
+		},
+		Parameters:     []*api.SweepParameter{{Name: "param1", Values: []string{"hello", "world"}}},
+		MaxConcurrency: 1,
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), sweep.PendingRuns)
+	assert.Equal(t, sweep.Id, sweep.RunGroupId)
+	assert.Equal(t, []*api.SweepTrial{
+		{Index: 0, Parameters: []*api.Parameter{{Name: "param1", Value: "hello"}}},
+		{Index: 1, Parameters: []*api.Parameter{{Name: "param1", Value: "world"}}},
+	}, sweep.Trials)
+
+	// Only one run is created, as the max concurrency of the sweep is 1.
+	assert.Nil(t, resourceManager.ReconcileRunSweeps())
+	sweep, err = server.GetRunSweep(context.Background(), &api.GetRunSweepRequest{Id: sweep.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), sweep.PendingRuns)
+	assert.Equal(t, int32(1), sweep.RunningRuns)
+	assert.NotEmpty(t, sweep.Trials[0].RunId)
+	assert.Empty(t, sweep.Trials[1].RunId)
+}
+
+func TestCreateRunSweep_InvalidRequest(t *testing.T) {
+	clientManager, resourceManager, experiment := initWithExperiment(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+	run := &api.Run{
+		PipelineSpec: &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore()},
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.UUID},
+			Relationship: api.Relationship_OWNER,
+		}},
+	}
+	parameters := []*api.SweepParameter{{Name: "param1", Values: []string{"hello"}}}
+
+	tests := []*api.RunSweep{
+		{Run: run, Parameters: parameters},
+		{Name: "sweep", Parameters: parameters},
+		{Name: "sweep", Run: run, Parameters: parameters, MaxConcurrency: -1},
+		{Name: "sweep", Run: &api.Run{PipelineSpec: run.PipelineSpec}, Parameters: parameters},
+		{Name: "sweep", Run: run},
+	}
+	for _, sweep := range tests {
+		_, err := server.CreateRunSweep(context.Background(), &api.CreateRunSweepRequest{Sweep: sweep})
+		AssertUserError(t, err, codes.InvalidArgument)
+	}
+}
+
+func TestGetRunSweep_NotFound(t *testing.T) {
+	clientManager, resourceManager, _ := initWithExperiment(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+
+	_, err := server.GetRunSweep(context.Background(), &api.GetRunSweepRequest{Id: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestRunGroup(t *testing.T) {
+	clientManager, resourceManager, runDetail := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+
+	group, err := server.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{
+		Group: &api.RunGroup{Name: "group", Description: "backfill", RunIds: []string{runDetail.UUID}}})
+	assert.Nil(t, err)
+	expected := &api.RunGroup{
+		Id:          group.Id,
+		Name:        "group",
+		Description: "backfill",
+		RunIds:      []string{runDetail.UUID},
+		CreatedAt:   &timestamp.Timestamp{Seconds: 3},
+		Status:      "Running",
+		RunningRuns: 1,
+	}
+	assert.Equal(t, expected, group)
+	group, err = server.GetRunGroup(context.Background(), &api.GetRunGroupRequest{Id: group.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, group)
+	groups, err := server.ListRunGroups(context.Background(), &api.ListRunGroupsRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ListRunGroupsResponse{Groups: []*api.RunGroup{expected}}, groups)
+
+	group, err = server.CancelRunGroup(context.Background(), &api.CancelRunGroupRequest{Id: group.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "Cancelled", group.Status)
+	assert.NotNil(t, group.CancelledAt)
+}
+
+func TestRunGroup_InvalidRequest(t *testing.T) {
+	clientManager, resourceManager, _ := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+
+	_, err := server.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{Group: &api.RunGroup{}})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = server.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{
+		Group: &api.RunGroup{Name: "group", RunIds: []string{"unknown"}}})
+	AssertUserError(t, err, codes.NotFound)
+	group, err := server.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{
+		Group: &api.RunGroup{Name: "group"}})
+	assert.Nil(t, err)
+	_, err = server.AddRunsToRunGroup(context.Background(), &api.AddRunsToRunGroupRequest{Id: group.Id})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = server.CancelRunGroup(context.Background(), &api.CancelRunGroupRequest{Id: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
+
+func TestTerminateAll(t *testing.T) {
+	clientManager, resourceManager, runDetail := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+	experimentId := runDetail.ResourceReferences[0].ReferenceUUID
+
+	operation, err := server.TerminateAll(context.Background(), &api.TerminateAllRequest{ExperimentId: experimentId})
+	assert.Nil(t, err)
+	assert.Equal(t, experimentId, operation.ExperimentId)
+	assert.Nil(t, operation.FinishedAt)
+	assert.Equal(t, []*api.TerminateResult{
+		{RunId: runDetail.UUID, Status: api.TerminateResult_PENDING}}, operation.Results)
+
+	assert.Nil(t, resourceManager.ProcessTerminateOperations())
+	operation, err = server.GetTerminateAllOperation(context.Background(),
+		&api.GetTerminateAllOperationRequest{Id: operation.Id})
+	assert.Nil(t, err)
+	assert.NotNil(t, operation.FinishedAt)
+	assert.Equal(t, []*api.TerminateResult{
+		{RunId: runDetail.UUID, Status: api.TerminateResult_TERMINATED}}, operation.Results)
+}
+
+func TestTerminateAll_InvalidRequest(t *testing.T) {
+	clientManager, resourceManager, _ := initWithOneTimeRun(t)
+	defer clientManager.Close()
+	server := NewRunServer(resourceManager)
+
+	_, err := server.TerminateAll(context.Background(), &api.TerminateAllRequest{})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = server.TerminateAll(context.Background(), &api.TerminateAllRequest{
+		ExperimentId: "experiment1", RunGroupId: "group1"})
+	AssertUserError(t, err, codes.InvalidArgument)
+	_, err = server.TerminateAll(context.Background(), &api.TerminateAllRequest{RunGroupId: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+	_, err = server.GetTerminateAllOperation(context.Background(), &api.GetTerminateAllOperationRequest{Id: "unknown"})
+	AssertUserError(t, err, codes.NotFound)
+}
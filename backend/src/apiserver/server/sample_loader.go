@@ -0,0 +1,121 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// SampleConfig is a sample pipeline loaded at startup, from a local file or a URL.
+type SampleConfig struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	File        string `json:"file"`
+	URL         string `json:"url"`
+}
+
+// ReadSampleConfig reads the list of samples from a JSON file, e.g. mounted from a
+// ConfigMap.
+func ReadSampleConfig(configPath string) ([]SampleConfig, error) {
+	configBytes, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read sample configurations file %v", configPath)
+	}
+	return ParseSampleConfig(configBytes)
+}
+
+// ParseSampleConfig parses and validates the content of a sample configurations file.
+func ParseSampleConfig(configBytes []byte) ([]SampleConfig, error) {
+	var configs []SampleConfig
+	if err := json.Unmarshal(configBytes, &configs); err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to parse sample configurations")
+	}
+	for _, config := range configs {
+		if config.Name == "" || (config.File == "") == (config.URL == "") {
+			return nil, util.NewInvalidInputError(
+				"Invalid sample %q. A sample has a name, and either a file or a URL.", config.Name)
+		}
+	}
+	return configs, nil
+}
+
+// SampleLoader creates the sample pipelines, or updates those whose file or description
+// changed, so that the API server can be restarted without the samples colliding.
+type SampleLoader struct {
+	resourceManager *resource.ResourceManager
+	httpClient      *http.Client
+	// How long to wait after creating a sample, so that the samples, listed by creation time
+	// by default, show up in the order they are configured.
+	orderDelay time.Duration
+}
+
+func NewSampleLoader(resourceManager *resource.ResourceManager, httpClient *http.Client) *SampleLoader {
+	return &SampleLoader{resourceManager: resourceManager, httpClient: httpClient, orderDelay: time.Second}
+}
+
+// Load loads the samples in order. It fails if a sample can't be read, and skips the
+// samples which can't be stored, e.g. because another replica is storing them.
+func (l *SampleLoader) Load(configs []SampleConfig) error {
+	for _, config := range configs {
+		pipelineFile, err := l.readSample(config)
+		if err != nil {
+			return util.Wrapf(err, "Failed to load sample %s", config.Name)
+		}
+		_, changed, err := l.resourceManager.UpsertPipeline(config.Name, config.Description, pipelineFile)
+		if err != nil {
+			glog.Warningf("Failed to create pipeline for sample %s. Error: %v", config.Name, err)
+			continue
+		}
+		if !changed {
+			glog.Infof("Sample %s is up to date", config.Name)
+			continue
+		}
+		glog.Infof("Loaded sample %s", config.Name)
+		time.Sleep(l.orderDelay)
+	}
+	glog.Info("All samples are loaded.")
+	return nil
+}
+
+func (l *SampleLoader) readSample(config SampleConfig) ([]byte, error) {
+	if config.File != "" {
+		reader, err := os.Open(config.File)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to open the sample file %v", config.File)
+		}
+		defer reader.Close()
+		return ReadPipelineFile(config.File, reader, MaxFileLength)
+	}
+	resp, err := l.httpClient.Get(config.URL)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to download the sample from %v", config.URL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.NewInternalServerError(fmt.Errorf("unexpected status %v", resp.Status),
+			"Failed to download the sample from %v", config.URL)
+	}
+	return ReadPipelineFile(path.Base(config.URL), resp.Body, MaxFileLength)
+}
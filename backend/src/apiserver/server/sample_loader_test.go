@@ -0,0 +1,79 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func writeSampleConfig(t *testing.T, content string) string {
+	dir, err := ioutil.TempDir("", "samples")
+	assert.Nil(t, err)
+	configPath := filepath.Join(dir, "sample_config.json")
+	assert.Nil(t, ioutil.WriteFile(configPath, []byte(content), 0644))
+	return configPath
+}
+
+func TestReadSampleConfig(t *testing.T) {
+	configPath := writeSampleConfig(t, `[
+		{"name": "sample1", "description": "first", "file": "test/arguments-parameters.yaml"},
+		{"name": "sample2", "url": "http://samples/arguments.tar.gz"}]`)
+	defer os.RemoveAll(filepath.Dir(configPath))
+
+	configs, err := ReadSampleConfig(configPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []SampleConfig{
+		{Name: "sample1", Description: "first", File: "test/arguments-parameters.yaml"},
+		{Name: "sample2", URL: "http://samples/arguments.tar.gz"},
+	}, configs)
+}
+
+func TestReadSampleConfig_InvalidSample(t *testing.T) {
+	configPath := writeSampleConfig(t, `[{"name": "sample1"}]`)
+	defer os.RemoveAll(filepath.Dir(configPath))
+
+	_, err := ReadSampleConfig(configPath)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	assert.Contains(t, err.Error(), "either a file or a URL")
+}
+
+func TestSampleLoader_Load(t *testing.T) {
+	httpServer := getMockServer(t)
+	defer httpServer.Close()
+	clientManager, err := resource.NewFakeClientManager(util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	assert.Nil(t, err)
+	defer clientManager.Close()
+	resourceManager := resource.NewResourceManager(clientManager)
+	loader := &SampleLoader{resourceManager: resourceManager, httpClient: httpServer.Client()}
+	configs := []SampleConfig{
+		{Name: "sample1", File: "test/arguments-parameters.yaml"},
+		{Name: "sample2", URL: httpServer.URL + "/arguments_tarball/arguments.tar.gz"},
+	}
+
+	// Loading the samples again doesn't duplicate them.
+	assert.Nil(t, loader.Load(configs))
+	assert.Nil(t, loader.Load(configs))
+	pipelines, _, err := resourceManager.ListPipelines(&common.FilterContext{}, &common.PaginationContext{
+		PageSize: 10, KeyFieldName: "UUID", SortByFieldName: "CreatedAtInSec"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(pipelines))
+	assert.Equal(t, "sample1", pipelines[0].Name)
+	assert.Equal(t, "sample2", pipelines[1].Name)
+}
+
+func TestSampleLoader_Load_MissingFile(t *testing.T) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clientManager.Close()
+	loader := NewSampleLoader(resource.NewResourceManager(clientManager), nil)
+
+	err := loader.Load([]SampleConfig{{Name: "sample1", File: "test/missing.yaml"}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to load sample sample1")
+}
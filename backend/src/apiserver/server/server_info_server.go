@@ -0,0 +1,67 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The versions of the API served.
+var apiVersions = []string{"v1beta1"}
+
+// ServerInfoConfig is how the API server was built, and its limits set by flags.
+type ServerInfoConfig struct {
+	// The release and the commit the API server was built from, unknown if empty.
+	Version   string
+	CommitSha string
+	// The maximum size in bytes of the gRPC requests.
+	MaxRequestSize int
+}
+
+type ServerInfoServer struct {
+	resourceManager *resource.ResourceManager
+	config          ServerInfoConfig
+}
+
+func (s *ServerInfoServer) GetServerInfo(ctx context.Context, request *api.GetServerInfoRequest) (
+	*api.ServerInfo, error) {
+	return &api.ServerInfo{
+		Features:    ToApiFeatures(s.resourceManager.GetFeatureStates()),
+		Version:     valueOrUnknown(s.config.Version),
+		CommitSha:   valueOrUnknown(s.config.CommitSha),
+		ApiVersions: apiVersions,
+		Limits: &api.ServerLimits{
+			MaxUploadSize:  MaxFileLength,
+			MaxRequestSize: int64(s.config.MaxRequestSize),
+			MaxPageSize:    maxPageSize,
+			MaxNameLength:  util.MaxNameLength,
+		},
+	}, nil
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+func NewServerInfoServer(resourceManager *resource.ResourceManager, config ServerInfoConfig) *ServerInfoServer {
+	return &ServerInfoServer{resourceManager: resourceManager, config: config}
+}
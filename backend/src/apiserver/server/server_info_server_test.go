@@ -0,0 +1,74 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/features"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServerInfo(t *testing.T) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clientManager.Close()
+	assert.Nil(t, clientManager.FeatureGate().Set("Caching=true"))
+	server := NewServerInfoServer(resource.NewResourceManager(clientManager),
+		ServerInfoConfig{Version: "0.1.20", CommitSha: "abc123", MaxRequestSize: 32 << 20})
+
+	info, err := server.GetServerInfo(nil, &api.GetServerInfoRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.ServerInfo{
+		Features: []*api.Feature{
+			{Name: "Caching", Enabled: true, Stage: api.Feature_ALPHA},
+			{Name: "MultiUser", Enabled: false, Stage: api.Feature_ALPHA},
+			{Name: "V2API", Enabled: false, Stage: api.Feature_ALPHA},
+		},
+		Version:     "0.1.20",
+		CommitSha:   "abc123",
+		ApiVersions: []string{"v1beta1"},
+		Limits: &api.ServerLimits{
+			MaxUploadSize:  32 << 20,
+			MaxRequestSize: 32 << 20,
+			MaxPageSize:    200,
+			MaxNameLength:  128,
+		},
+	}, info)
+}
+
+func TestGetServerInfo_UnknownVersion(t *testing.T) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clientManager.Close()
+	server := NewServerInfoServer(resource.NewResourceManager(clientManager), ServerInfoConfig{})
+
+	info, err := server.GetServerInfo(nil, &api.GetServerInfoRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "unknown", info.Version)
+	assert.Equal(t, "unknown", info.CommitSha)
+}
+
+func TestToApiFeatures(t *testing.T) {
+	states := []features.State{
+		{Feature: "Beta", Spec: features.Spec{Default: true, Stage: features.Beta}, Enabled: false},
+		{Feature: "GA", Spec: features.Spec{Default: true, Stage: features.GA}, Enabled: true},
+	}
+	assert.Equal(t, []*api.Feature{
+		{Name: "Beta", Enabled: false, Stage: api.Feature_BETA, Default: true},
+		{Name: "GA", Enabled: true, Stage: api.Feature_GA, Default: true},
+	}, ToApiFeatures(states))
+}
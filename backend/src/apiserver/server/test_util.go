@@ -67,7 +67,7 @@ func initWithOneTimeRun(t *testing.T) (*resource.FakeClientManager, *resource.Re
 			},
 		},
 	}
-	runDetail, err := manager.CreateRun(apiRun)
+	runDetail, err := manager.CreateRun(apiRun, "")
 	assert.Nil(t, err)
 	return clientManager, manager, runDetail
 }
@@ -0,0 +1,122 @@
+-- The database of a previous release, dumped for the fake database of the tests. The tables
+-- predate the columns the later releases added: the runs have no ScheduledAtInSec, and neither
+-- the runs nor the jobs have a PipelineSpecManifest. None of the other tables exist yet.
+
+CREATE TABLE "experiments" (
+  "UUID" varchar(255) NOT NULL,
+  "Name" varchar(255) NOT NULL,
+  "Description" varchar(255) NOT NULL,
+  "CreatedAtInSec" bigint NOT NULL,
+  PRIMARY KEY ("UUID")
+);
+CREATE UNIQUE INDEX uix_experiments_name ON "experiments"("Name");
+
+CREATE TABLE "pipelines" (
+  "UUID" varchar(255) NOT NULL,
+  "CreatedAtInSec" bigint NOT NULL,
+  "Name" varchar(255) NOT NULL,
+  "Description" varchar(255) NOT NULL,
+  "Parameters" varchar(65535) NOT NULL,
+  "Status" varchar(255) NOT NULL,
+  PRIMARY KEY ("UUID")
+);
+CREATE UNIQUE INDEX uix_pipelines_name ON "pipelines"("Name");
+
+CREATE TABLE "jobs" (
+  "UUID" varchar(255) NOT NULL,
+  "DisplayName" varchar(255) NOT NULL,
+  "Name" varchar(255) NOT NULL,
+  "Namespace" varchar(255) NOT NULL,
+  "Description" varchar(255) NOT NULL,
+  "MaxConcurrency" bigint NOT NULL,
+  "CreatedAtInSec" bigint NOT NULL,
+  "UpdatedAtInSec" bigint NOT NULL,
+  "Enabled" bool NOT NULL,
+  "CronScheduleStartTimeInSec" bigint,
+  "CronScheduleEndTimeInSec" bigint,
+  "Schedule" varchar(255),
+  "PeriodicScheduleStartTimeInSec" bigint,
+  "PeriodicScheduleEndTimeInSec" bigint,
+  "IntervalSecond" bigint,
+  "PipelineId" varchar(255) NOT NULL,
+  "WorkflowSpecManifest" varchar(65535) NOT NULL,
+  "Parameters" varchar(65535),
+  "Conditions" varchar(255) NOT NULL,
+  PRIMARY KEY ("UUID")
+);
+
+CREATE TABLE "run_details" (
+  "UUID" varchar(255) NOT NULL,
+  "DisplayName" varchar(255) NOT NULL,
+  "Name" varchar(255) NOT NULL,
+  "Namespace" varchar(255) NOT NULL,
+  "Description" varchar(255) NOT NULL,
+  "CreatedAtInSec" bigint NOT NULL,
+  "Conditions" varchar(255) NOT NULL,
+  "PipelineId" varchar(255) NOT NULL,
+  "WorkflowSpecManifest" varchar(65535) NOT NULL,
+  "Parameters" varchar(65535),
+  "PipelineRuntimeManifest" varchar(65535) NOT NULL,
+  "WorkflowRuntimeManifest" varchar(65535) NOT NULL,
+  PRIMARY KEY ("UUID")
+);
+
+CREATE TABLE "resource_references" (
+  "ResourceUUID" varchar(255) NOT NULL,
+  "ResourceType" varchar(255) NOT NULL,
+  "ReferenceUUID" varchar(255) NOT NULL,
+  "ReferenceType" varchar(255) NOT NULL,
+  "Relationship" varchar(255) NOT NULL,
+  "Payload" varchar(255) NOT NULL,
+  PRIMARY KEY ("ResourceUUID","ResourceType","ReferenceType")
+);
+
+CREATE TABLE "run_metrics" (
+  "RunUUID" varchar(255) NOT NULL,
+  "NodeID" varchar(255) NOT NULL,
+  "Name" varchar(255) NOT NULL,
+  "NumberValue" real,
+  "Format" varchar(255),
+  "Payload" varchar(65535) NOT NULL,
+  PRIMARY KEY ("RunUUID","NodeID","Name")
+);
+
+INSERT INTO "experiments" VALUES
+  ('00000000-0000-0000-0000-0000000000e1', 'Default', 'All runs created without specifying an experiment will be grouped here.', 1),
+  ('00000000-0000-0000-0000-0000000000e2', 'training', 'Nightly training of the model', 2);
+
+INSERT INTO "pipelines" VALUES
+  ('00000000-0000-0000-0000-0000000000a1', 1, 'hello-world', 'Prints a message', '[{"name":"message","value":"hello"}]', 'READY'),
+  ('00000000-0000-0000-0000-0000000000a2', 2, 'sequential', 'Downloads the data, then trains the model', '', 'READY');
+
+INSERT INTO "jobs" VALUES
+  ('00000000-0000-0000-0000-0000000000b1', 'nightly', 'nightlyr9xk2', 'kubeflow', 'Says hello every night', 1, 3, 4, 1,
+   NULL, NULL, '0 0 0 * * *', NULL, NULL, NULL, '00000000-0000-0000-0000-0000000000a1',
+   '{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow","metadata":{"generateName":"hello-world-"},"spec":{"templates":[{"name":"hello-world","container":{"image":"docker/whalesay:latest"}}],"entrypoint":"hello-world","arguments":{"parameters":[{"name":"message","value":"hello"}]}}}',
+   '[{"name":"message","value":"good night"}]', 'Enabled');
+
+INSERT INTO "run_details" VALUES
+  ('00000000-0000-0000-0000-0000000000c1', 'first run', 'hello-world-x7k2p', 'kubeflow', 'Says hello once', 5, 'Succeeded',
+   '00000000-0000-0000-0000-0000000000a1',
+   '{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow","metadata":{"generateName":"hello-world-"},"spec":{"templates":[{"name":"hello-world","container":{"image":"docker/whalesay:latest"}}],"entrypoint":"hello-world","arguments":{"parameters":[{"name":"message","value":"hello"}]}}}',
+   '[{"name":"message","value":"hello"}]', '',
+   '{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow","metadata":{"name":"hello-world-x7k2p","namespace":"kubeflow","uid":"00000000-0000-0000-0000-0000000000c1"},"spec":{"templates":[{"name":"hello-world","container":{"image":"docker/whalesay:latest"}}],"entrypoint":"hello-world","arguments":{"parameters":[{"name":"message","value":"hello"}]}},"status":{"phase":"Succeeded","nodes":{"hello-world-x7k2p":{"id":"hello-world-x7k2p","name":"hello-world-x7k2p","displayName":"hello-world-x7k2p","type":"Pod","templateName":"hello-world","phase":"Succeeded"}}}}'),
+  ('00000000-0000-0000-0000-0000000000c2', 'nightly-1', 'nightlyr9xk2-1-3', 'kubeflow', '', 6, 'Running',
+   '00000000-0000-0000-0000-0000000000a1',
+   '{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow","metadata":{"generateName":"hello-world-"},"spec":{"templates":[{"name":"hello-world","container":{"image":"docker/whalesay:latest"}}],"entrypoint":"hello-world","arguments":{"parameters":[{"name":"message","value":"good night"}]}}}',
+   '[{"name":"message","value":"good night"}]', '',
+   '{"apiVersion":"argoproj.io/v1alpha1","kind":"Workflow","metadata":{"name":"nightlyr9xk2-1-3","namespace":"kubeflow","uid":"00000000-0000-0000-0000-0000000000c2"},"spec":{"templates":[{"name":"hello-world","container":{"image":"docker/whalesay:latest"}}],"entrypoint":"hello-world","arguments":{"parameters":[{"name":"message","value":"good night"}]}},"status":{"phase":"Running"}}');
+
+INSERT INTO "resource_references" VALUES
+  ('00000000-0000-0000-0000-0000000000b1', 'Job', '00000000-0000-0000-0000-0000000000e2', 'Experiment', 'Owner',
+   '{"ResourceUUID":"00000000-0000-0000-0000-0000000000b1","ResourceType":"Job","ReferenceUUID":"00000000-0000-0000-0000-0000000000e2","ReferenceType":"Experiment","Relationship":"Owner","Payload":""}'),
+  ('00000000-0000-0000-0000-0000000000c1', 'Run', '00000000-0000-0000-0000-0000000000e1', 'Experiment', 'Owner',
+   '{"ResourceUUID":"00000000-0000-0000-0000-0000000000c1","ResourceType":"Run","ReferenceUUID":"00000000-0000-0000-0000-0000000000e1","ReferenceType":"Experiment","Relationship":"Owner","Payload":""}'),
+  ('00000000-0000-0000-0000-0000000000c2', 'Run', '00000000-0000-0000-0000-0000000000e2', 'Experiment', 'Owner',
+   '{"ResourceUUID":"00000000-0000-0000-0000-0000000000c2","ResourceType":"Run","ReferenceUUID":"00000000-0000-0000-0000-0000000000e2","ReferenceType":"Experiment","Relationship":"Owner","Payload":""}'),
+  ('00000000-0000-0000-0000-0000000000c2', 'Run', '00000000-0000-0000-0000-0000000000b1', 'Job', 'Creator',
+   '{"ResourceUUID":"00000000-0000-0000-0000-0000000000c2","ResourceType":"Run","ReferenceUUID":"00000000-0000-0000-0000-0000000000b1","ReferenceType":"Job","Relationship":"Creator","Payload":""}');
+
+INSERT INTO "run_metrics" VALUES
+  ('00000000-0000-0000-0000-0000000000c1', 'hello-world-x7k2p', 'accuracy', 0.92, 'PERCENTAGE',
+   '{"RunUUID":"00000000-0000-0000-0000-0000000000c1","NodeID":"hello-world-x7k2p","Name":"accuracy","NumberValue":0.92,"Format":"PERCENTAGE","Payload":""}');
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// The snapshot of a previous release the tests upgrade from.
+const previousReleaseSnapshot = "testdata/upgrade/previous_release"
+
+// E.g. go test ./backend/src/apiserver/server -run TestUpgrade -upgrade_snapshot=/tmp/snapshot verifies the
+// upgrade of another snapshot, whose db.sql is a SQLite dump of the database, and whose objects/ directory
+// holds the files of the object store.
+var upgradeSnapshot = flag.String("upgrade_snapshot", previousReleaseSnapshot,
+	"The directory of the snapshot of a previous release to upgrade from.")
+
+// loadUpgradeSnapshot loads the database and the object store of a snapshot, then migrates the
+// database as the API server does on start up.
+func loadUpgradeSnapshot(t *testing.T, dir string) (*resource.FakeClientManager, *resource.ResourceManager) {
+	db, err := storage.NewFakeDbFromSnapshot(filepath.Join(dir, "db.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	objectStore, err := storage.NewFakeObjectStoreFromDir(filepath.Join(dir, "objects"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientManager, err := resource.NewFakeClientManagerWithStores(db, objectStore,
+		util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(resource.DefaultFakeUUID, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return clientManager, resource.NewResourceManager(clientManager)
+}
+
+func countRows(t *testing.T, db *storage.DB, query string) int {
+	var count int
+	if err := db.QueryRow(query).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+// The list APIs return each stored resource, one page at a time, the same as the get APIs do.
+func TestUpgrade_ListAndGetAllResources(t *testing.T) {
+	clientManager, manager := loadUpgradeSnapshot(t, *upgradeSnapshot)
+	defer clientManager.Close()
+	ctx := context.Background()
+
+	pipelineServer := NewPipelineServer(manager, newURLFetcherForTest())
+	var pipelines []*api.Pipeline
+	for token := ""; ; {
+		response, err := pipelineServer.ListPipelines(ctx, &api.ListPipelinesRequest{PageToken: token, PageSize: 1})
+		if !assert.Nil(t, err) {
+			return
+		}
+		pipelines = append(pipelines, response.Pipelines...)
+		if token = response.NextPageToken; token == "" {
+			break
+		}
+	}
+	assert.Len(t, pipelines, countRows(t, clientManager.DB(), "SELECT COUNT(*) FROM pipelines WHERE Status = 'READY'"))
+	for _, pipeline := range pipelines {
+		assert.Empty(t, pipeline.Error, "pipeline %v", pipeline.Id)
+		got, err := pipelineServer.GetPipeline(ctx, &api.GetPipelineRequest{Id: pipeline.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, pipeline, got)
+		template, err := pipelineServer.GetTemplate(ctx, &api.GetTemplateRequest{Id: pipeline.Id})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, template.GetTemplate(), "template of pipeline %v", pipeline.Id)
+		steps, err := pipelineServer.GetPipelineSteps(ctx, &api.GetPipelineStepsRequest{Id: pipeline.Id})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, steps.GetSteps(), "steps of pipeline %v", pipeline.Id)
+	}
+
+	experimentServer := NewExperimentServer(manager)
+	var experiments []*api.Experiment
+	for token := ""; ; {
+		response, err := experimentServer.ListExperiment(ctx, &api.ListExperimentsRequest{PageToken: token, PageSize: 1})
+		if !assert.Nil(t, err) {
+			return
+		}
+		experiments = append(experiments, response.Experiments...)
+		if token = response.NextPageToken; token == "" {
+			break
+		}
+	}
+	assert.Len(t, experiments, countRows(t, clientManager.DB(), "SELECT COUNT(*) FROM experiments"))
+	for _, experiment := range experiments {
+		got, err := experimentServer.GetExperiment(ctx, &api.GetExperimentRequest{Id: experiment.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, experiment, got)
+	}
+
+	jobServer := NewJobServer(manager)
+	var jobs []*api.Job
+	for token := ""; ; {
+		response, err := jobServer.ListJobs(ctx,
+			&api.ListJobsRequest{PageToken: token, PageSize: 1, View: api.ListJobsRequest_FULL})
+		if !assert.Nil(t, err) {
+			return
+		}
+		jobs = append(jobs, response.Jobs...)
+		if token = response.NextPageToken; token == "" {
+			break
+		}
+	}
+	assert.Len(t, jobs, countRows(t, clientManager.DB(), "SELECT COUNT(*) FROM jobs"))
+	for _, job := range jobs {
+		assert.Empty(t, job.Error, "job %v", job.Id)
+		got, err := jobServer.GetJob(ctx, &api.GetJobRequest{Id: job.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, job, got)
+	}
+
+	runServer := NewRunServer(manager)
+	var runs []*api.Run
+	for token := ""; ; {
+		response, err := runServer.ListRuns(ctx,
+			&api.ListRunsRequest{PageToken: token, PageSize: 1, View: api.ListRunsRequest_FULL})
+		if !assert.Nil(t, err) {
+			return
+		}
+		runs = append(runs, response.Runs...)
+		if token = response.NextPageToken; token == "" {
+			break
+		}
+	}
+	assert.Len(t, runs, countRows(t, clientManager.DB(), "SELECT COUNT(*) FROM run_details"))
+	for _, run := range runs {
+		assert.Empty(t, run.Error, "run %v", run.Id)
+		got, err := runServer.GetRun(ctx, &api.GetRunRequest{RunId: run.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, run, got.GetRun())
+		assert.NotEmpty(t, got.GetPipelineRuntime().GetWorkflowManifest(), "workflow of run %v", run.Id)
+	}
+}
+
+func TestUpgrade_PreviousRelease(t *testing.T) {
+	clientManager, manager := loadUpgradeSnapshot(t, previousReleaseSnapshot)
+	defer clientManager.Close()
+	ctx := context.Background()
+
+	pipeline, err := NewPipelineServer(manager, newURLFetcherForTest()).GetPipeline(ctx,
+		&api.GetPipelineRequest{Id: "00000000-0000-0000-0000-0000000000a1"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.Pipeline{
+		Id:          "00000000-0000-0000-0000-0000000000a1",
+		CreatedAt:   &timestamp.Timestamp{Seconds: 1},
+		Name:        "hello-world",
+		Description: "Prints a message",
+		Parameters:  []*api.Parameter{{Name: "message", Value: "hello"}},
+	}, pipeline)
+
+	// The steps of the pipelines uploaded before the steps were indexed are indexed on demand.
+	steps, err := NewPipelineServer(manager, newURLFetcherForTest()).GetPipelineSteps(ctx,
+		&api.GetPipelineStepsRequest{Id: "00000000-0000-0000-0000-0000000000a2"})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.PipelineStep{
+		{Name: "sequential", Type: "dag", Children: []string{"download", "train"}},
+		{Name: "download", Type: "container", Image: "gcr.io/ml-pipeline/download:0.1.0", Children: []string{}},
+		{Name: "train", Type: "container", Image: "gcr.io/ml-pipeline/train:0.1.0", Children: []string{}},
+	}, steps.Steps)
+
+	job, err := NewJobServer(manager).GetJob(ctx, &api.GetJobRequest{Id: "00000000-0000-0000-0000-0000000000b1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "nightly", job.Name)
+	assert.Equal(t, "0 0 0 * * *", job.GetTrigger().GetCronSchedule().GetCron())
+	assert.Equal(t, []*api.Parameter{{Name: "message", Value: "good night"}}, job.PipelineSpec.Parameters)
+	assert.Empty(t, job.PipelineSpec.PipelineManifest)
+
+	run, err := NewRunServer(manager).GetRun(ctx, &api.GetRunRequest{RunId: "00000000-0000-0000-0000-0000000000c1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "first run", run.Run.Name)
+	assert.Equal(t, "Succeeded", run.Run.Status)
+	// The runs stored before the runs had a scheduled time have none.
+	assert.Equal(t, &timestamp.Timestamp{}, run.Run.ScheduledAt)
+	assert.Equal(t, []*api.Parameter{{Name: "message", Value: "hello"}}, run.Run.PipelineSpec.Parameters)
+	assert.Equal(t, []*api.RunMetric{{
+		Name:   "accuracy",
+		NodeId: "hello-world-x7k2p",
+		Value:  &api.RunMetric_NumberValue{NumberValue: 0.92},
+		Format: api.RunMetric_PERCENTAGE,
+	}}, run.Run.Metrics)
+	assert.Equal(t, "Succeeded", run.MainPhase)
+
+	response, err := NewRunServer(manager).ListRuns(ctx, &api.ListRunsRequest{
+		ResourceReferenceKey: &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: "00000000-0000-0000-0000-0000000000e2"}})
+	assert.Nil(t, err)
+	if !assert.Len(t, response.Runs, 1) {
+		return
+	}
+	assert.Equal(t, "00000000-0000-0000-0000-0000000000c2", response.Runs[0].Id)
+	assert.Equal(t, []*api.ResourceReference{
+		{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: "00000000-0000-0000-0000-0000000000e2"},
+			Relationship: api.Relationship_OWNER,
+		},
+		{
+			Key:          &api.ResourceKey{Type: api.ResourceType_JOB, Id: "00000000-0000-0000-0000-0000000000b1"},
+			Relationship: api.Relationship_CREATOR,
+		},
+	}, response.Runs[0].ResourceReferences)
+}
+
+func TestUpgrade_CreateRunOfPreviousPipeline(t *testing.T) {
+	clientManager, manager := loadUpgradeSnapshot(t, previousReleaseSnapshot)
+	defer clientManager.Close()
+	ctx := context.Background()
+
+	_, err := NewRunServer(manager).CreateRun(ctx, &api.CreateRunRequest{Run: &api.Run{
+		Name: "after upgrade",
+		PipelineSpec: &api.PipelineSpec{
+			PipelineId: "00000000-0000-0000-0000-0000000000a1",
+			Parameters: []*api.Parameter{{Name: "message", Value: "upgraded"}},
+		},
+		ResourceReferences: []*api.ResourceReference{{
+			Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: "00000000-0000-0000-0000-0000000000e1"},
+			Relationship: api.Relationship_OWNER,
+		}},
+	}})
+	assert.Nil(t, err)
+
+	response, err := NewRunServer(manager).ListRuns(ctx, &api.ListRunsRequest{
+		ResourceReferenceKey: &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: "00000000-0000-0000-0000-0000000000e1"}})
+	assert.Nil(t, err)
+	var names []string
+	for _, run := range response.Runs {
+		names = append(names, run.Name)
+	}
+	assert.ElementsMatch(t, []string{"first run", "after upgrade"}, names)
+}
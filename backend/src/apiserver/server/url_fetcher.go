@@ -0,0 +1,149 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// URLFetcherOptions configure the downloads of the pipelines from their URLs.
+type URLFetcherOptions struct {
+	// The proxy of the downloads, e.g. http://proxy.example.com:3128. The proxies of the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used if empty.
+	Proxy string
+	// The timeout of the connections, and of the whole downloads.
+	DialTimeout time.Duration
+	Timeout     time.Duration
+	// The redirects followed at most.
+	MaxRedirects int
+	// The size of the files downloaded at most, in bytes.
+	MaxSize int64
+	// The schemes of the URLs allowed, e.g. https.
+	AllowedSchemes []string
+	// The hosts of the URLs allowed, e.g. github.com, or *.example.com for all its subdomains.
+	// All the hosts are allowed if empty. With blocked networks, the hosts which only the
+	// proxy can resolve, e.g. the ones of an internal DNS, must be listed to be allowed.
+	AllowedHosts []string
+	// The hosts of the URLs denied, even if allowed, e.g. metadata.google.internal.
+	DeniedHosts []string
+	// The networks the URLs can't resolve to, in CIDR notation, e.g. 169.254.0.0/16 for the
+	// metadata endpoints of the clouds, except for the allowed ones, e.g. of an internal mirror.
+	BlockedNetworks []string
+	AllowedNetworks []string
+}
+
+// URLFetcher downloads the pipelines from the URLs allowed by the URL import policy,
+// following the redirects to the URLs allowed only.
+type URLFetcher struct {
+	client  *http.Client
+	options URLFetcherOptions
+	guard   *util.URLGuard
+}
+
+func NewURLFetcher(options URLFetcherOptions) (*URLFetcher, error) {
+	proxy := http.ProxyFromEnvironment
+	if options.Proxy != "" {
+		proxyURL, err := url.Parse(options.Proxy)
+		if err != nil {
+			return nil, util.NewInvalidInputError("Invalid proxy %v: %v", options.Proxy, err)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	guard, err := util.NewURLGuard(util.URLGuardOptions{
+		Policy:          "URL import",
+		AllowedSchemes:  options.AllowedSchemes,
+		AllowedHosts:    options.AllowedHosts,
+		DeniedHosts:     options.DeniedHosts,
+		BlockedNetworks: options.BlockedNetworks,
+		AllowedNetworks: options.AllowedNetworks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	fetcher := &URLFetcher{options: options, guard: guard}
+	fetcher.client = &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 guard.Proxy(proxy),
+			DialContext:           guard.Dial(options.DialTimeout),
+			TLSHandshakeTimeout:   options.DialTimeout,
+			ResponseHeaderTimeout: options.Timeout,
+		},
+		Timeout:       options.Timeout,
+		CheckRedirect: fetcher.checkRedirect,
+	}
+	return fetcher, nil
+}
+
+// Fetch downloads the file of a URL.
+func (f *URLFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, util.NewInvalidInputError("Invalid URL %v: %v", rawURL, err)
+	}
+	if err := f.guard.CheckURL(ctx, u); err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, util.NewInvalidInputError("Invalid URL %v: %v", rawURL, err)
+	}
+	resp, err := f.client.Do(request.WithContext(ctx))
+	if err != nil {
+		// The redirects and the connections which aren't allowed are rejected as the URLs.
+		var userErr *util.UserError
+		if errors.As(err, &userErr) {
+			return nil, userErr
+		}
+		return nil, util.NewInternalServerError(err, "Failed to download %v", rawURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.NewInternalServerError(fmt.Errorf("unexpected status %v", resp.Status),
+			"Failed to download %v", rawURL)
+	}
+	if resp.ContentLength > f.options.MaxSize {
+		return nil, f.tooLargeError(rawURL)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(resp.Body, f.options.MaxSize+1))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to download %v", rawURL)
+	}
+	if int64(len(content)) > f.options.MaxSize {
+		return nil, f.tooLargeError(rawURL)
+	}
+	return content, nil
+}
+
+func (f *URLFetcher) checkRedirect(request *http.Request, via []*http.Request) error {
+	if len(via) > f.options.MaxRedirects {
+		return util.NewInvalidInputError("Failed to download %v: more than %v redirects",
+			via[0].URL, f.options.MaxRedirects)
+	}
+	return f.guard.CheckURL(request.Context(), request.URL)
+}
+
+func (f *URLFetcher) tooLargeError(rawURL string) error {
+	return util.NewInvalidInputError("The file of %v is larger than the max size of %v bytes",
+		rawURL, f.options.MaxSize)
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newURLFetcherForTest() *URLFetcher {
+	fetcher, _ := NewURLFetcher(URLFetcherOptions{
+		DialTimeout:    time.Second,
+		Timeout:        time.Minute,
+		MaxRedirects:   2,
+		MaxSize:        MaxFileLength,
+		AllowedSchemes: []string{"http", "https"},
+	})
+	return fetcher
+}
+
+func newRedirectingServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("content"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/file", http.StatusFound)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/external", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://example.com/file", http.StatusFound)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestURLFetcher_Fetch(t *testing.T) {
+	httpServer := newRedirectingServer()
+	defer httpServer.Close()
+
+	content, err := newURLFetcherForTest().Fetch(context.Background(), httpServer.URL+"/redirect")
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+
+	_, err = newURLFetcherForTest().Fetch(context.Background(), httpServer.URL+"/loop")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "more than 2 redirects")
+}
+
+func TestURLFetcher_MaxSize(t *testing.T) {
+	httpServer := newRedirectingServer()
+	defer httpServer.Close()
+	fetcher, err := NewURLFetcher(URLFetcherOptions{MaxSize: 3, AllowedSchemes: []string{"http"}})
+	assert.Nil(t, err)
+
+	_, err = fetcher.Fetch(context.Background(), httpServer.URL+"/file")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "larger than the max size of 3 bytes")
+}
+
+func TestURLFetcher_AllowedSchemesAndHosts(t *testing.T) {
+	httpServer := newRedirectingServer()
+	defer httpServer.Close()
+	fetcher, err := NewURLFetcher(URLFetcherOptions{
+		MaxRedirects:   2,
+		MaxSize:        MaxFileLength,
+		AllowedSchemes: []string{"HTTP"},
+		AllowedHosts:   []string{"127.0.0.1", "*.github.com"},
+	})
+	assert.Nil(t, err)
+
+	_, err = fetcher.Fetch(context.Background(), httpServer.URL+"/file")
+	assert.Nil(t, err)
+	_, err = fetcher.Fetch(context.Background(), "ftp://127.0.0.1/file")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "The scheme of ftp://127.0.0.1/file isn't allowed")
+	_, err = fetcher.Fetch(context.Background(), "http://github.com.example.com/file")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	// The redirects are checked too.
+	_, err = fetcher.Fetch(context.Background(), httpServer.URL+"/external")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "The host of http://example.com/file isn't allowed")
+}
+
+func TestURLFetcher_Proxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The proxy receives the absolute URL.
+		w.Write([]byte(r.URL.String()))
+	}))
+	defer proxy.Close()
+	fetcher, err := NewURLFetcher(URLFetcherOptions{
+		Proxy:          proxy.URL,
+		MaxSize:        MaxFileLength,
+		AllowedSchemes: []string{"http"},
+	})
+	assert.Nil(t, err)
+
+	content, err := fetcher.Fetch(context.Background(), "http://pipelines.example.com/pipeline.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "http://pipelines.example.com/pipeline.yaml", string(content))
+
+	_, err = NewURLFetcher(URLFetcherOptions{Proxy: "://invalid"})
+	assert.NotNil(t, err)
+}
+
+func TestURLFetcher_BlockedNetworks(t *testing.T) {
+	httpServer := newRedirectingServer()
+	defer httpServer.Close()
+	fetcher, err := NewURLFetcher(URLFetcherOptions{
+		MaxSize:         MaxFileLength,
+		AllowedSchemes:  []string{"http"},
+		DeniedHosts:     []string{"metadata.google.internal"},
+		BlockedNetworks: []string{"127.0.0.0/8", "169.254.0.0/16"},
+	})
+	assert.Nil(t, err)
+
+	_, err = fetcher.Fetch(context.Background(), httpServer.URL+"/file")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.Contains(t, err.Error(), "is denied by the URL import policy")
+	_, err = fetcher.Fetch(context.Background(), "http://169.254.169.254/computeMetadata/v1/")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.Contains(t, err.Error(), "resolves to the blocked address 169.254.169.254")
+	_, err = fetcher.Fetch(context.Background(), "http://metadata.google.internal/computeMetadata/v1/")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.Contains(t, err.Error(), "the host metadata.google.internal is denied")
+
+	// The addresses are checked again when connecting, in case the host resolves differently.
+	_, err = fetcher.guard.Dial(time.Second)(context.Background(), "tcp", httpServer.Listener.Addr().String())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Connecting to the blocked address 127.0.0.1 is denied")
+}
+
+func TestURLFetcher_AllowedNetworks(t *testing.T) {
+	httpServer := newRedirectingServer()
+	defer httpServer.Close()
+	fetcher, err := NewURLFetcher(URLFetcherOptions{
+		MaxSize:         MaxFileLength,
+		AllowedSchemes:  []string{"http"},
+		BlockedNetworks: []string{"127.0.0.0/8"},
+		AllowedNetworks: []string{"127.0.0.1/32"},
+	})
+	assert.Nil(t, err)
+
+	content, err := fetcher.Fetch(context.Background(), httpServer.URL+"/file")
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+
+	_, err = NewURLFetcher(URLFetcherOptions{BlockedNetworks: []string{"127.0.0.1"}})
+	assert.NotNil(t, err)
+}
+
+func TestURLFetcher_BlockedNetworksExemptProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("content"))
+	}))
+	defer proxy.Close()
+	fetcher, err := NewURLFetcher(URLFetcherOptions{
+		Proxy:           proxy.URL,
+		MaxSize:         MaxFileLength,
+		AllowedSchemes:  []string{"http"},
+		BlockedNetworks: []string{"127.0.0.0/8"},
+	})
+	assert.Nil(t, err)
+
+	// A host which can't be resolved before it's sent to the proxy isn't trusted.
+	_, err = fetcher.Fetch(context.Background(), "http://pipelines.invalid/pipeline.yaml")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.Contains(t, err.Error(), "the host pipelines.invalid can't be resolved to check its address")
+
+	fetcher, err = NewURLFetcher(URLFetcherOptions{
+		Proxy:           proxy.URL,
+		MaxSize:         MaxFileLength,
+		AllowedSchemes:  []string{"http"},
+		AllowedHosts:    []string{"pipelines.invalid"},
+		BlockedNetworks: []string{"127.0.0.0/8"},
+	})
+	assert.Nil(t, err)
+	content, err := fetcher.Fetch(context.Background(), "http://pipelines.invalid/pipeline.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "content", string(content))
+}
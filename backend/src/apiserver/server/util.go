@@ -5,23 +5,40 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/url"
+	"path"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // These are valid conditions of a ScheduledWorkflow.
 const (
 	MaxFileNameLength = 100
 	MaxFileLength     = 32 << 20 // 32Mb
+	MaxReadmeLength   = 1 << 20  // 1Mb
+	// The name of the file documenting the pipeline in its tarball, wherever it is.
+	ReadmeFileName = "README.md"
 )
 
+// PipelinePackage is the content of a pipeline file: the pipeline template and, for a
+// tarball, the README documenting the pipeline if any.
+type PipelinePackage struct {
+	Template []byte
+	Readme   []byte
+}
+
 // This method extract the common logic of naming the pipeline.
 // API caller can either explicitly name the pipeline through query string ?name=foobar
 // or API server can use the file name by default.
@@ -33,7 +50,7 @@ func GetPipelineName(queryString string, fileName string) (string, error) {
 	if pipelineName == "" {
 		pipelineName = fileName
 	}
-	if len(pipelineName) > MaxFileNameLength {
+	if utf8.RuneCountInString(pipelineName) > MaxFileNameLength {
 		return "", util.NewInvalidInputError("Pipeline name too long. Support maximum length of %v", MaxFileNameLength)
 	}
 	return pipelineName, nil
@@ -62,26 +79,73 @@ func isYamlFile(fileName string) bool {
 }
 
 func DecompressPipelineTarball(compressedFile []byte) ([]byte, error) {
+	pipelinePackage, err := DecompressPipelinePackage(compressedFile)
+	if err != nil {
+		return nil, err
+	}
+	return pipelinePackage.Template, nil
+}
+
+// DecompressPipelinePackage extracts the first YAML file of a tarball, which is the pipeline
+// template, and its README if any.
+func DecompressPipelinePackage(compressedFile []byte) (*PipelinePackage, error) {
 	gzipReader, err := gzip.NewReader(bytes.NewReader(compressedFile))
 	if err != nil {
 		return nil, util.NewInvalidInputErrorWithDetails(err, "Error extracting pipeline from the tarball file. Not a valid tarball file.")
 	}
 	tarReader := tar.NewReader(gzipReader)
-	header, err := tarReader.Next()
-	if err != nil || header == nil {
-		return nil, util.NewInvalidInputErrorWithDetails(err, "Error extracting pipeline from the tarball file. Not a valid tarball file.")
+	pipelinePackage := &PipelinePackage{}
+	var firstFileName string
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil || header == nil {
+			return nil, util.NewInvalidInputErrorWithDetails(err, "Error extracting pipeline from the tarball file. Not a valid tarball file.")
+		}
+		if header.FileInfo().IsDir() {
+			continue
+		}
+		if firstFileName == "" {
+			firstFileName = header.Name
+		}
+		switch {
+		case isYamlFile(header.Name) && pipelinePackage.Template == nil:
+			pipelinePackage.Template, err = ioutil.ReadAll(tarReader)
+			if err != nil {
+				return nil, util.NewInvalidInputErrorWithDetails(err, "Error reading pipeline YAML from the tarball file.")
+			}
+		case path.Base(header.Name) == ReadmeFileName && pipelinePackage.Readme == nil:
+			if header.Size > MaxReadmeLength {
+				return nil, util.NewInvalidInputError("%v too large. Maximum supported size: %v", header.Name, MaxReadmeLength)
+			}
+			pipelinePackage.Readme, err = ioutil.ReadAll(tarReader)
+			if err != nil {
+				return nil, util.NewInvalidInputErrorWithDetails(err,
+					fmt.Sprintf("Error reading %v from the tarball file.", header.Name))
+			}
+		}
 	}
-	if !isYamlFile(header.Name) {
-		return nil, util.NewInvalidInputError("Error extracting pipeline from the tarball file. Expecting a YAML file inside the tarball. Got: %v", header.Name)
+	if firstFileName == "" {
+		return nil, util.NewInvalidInputError("Error extracting pipeline from the tarball file. Not a valid tarball file.")
 	}
-	decompressedFile, err := ioutil.ReadAll(tarReader)
-	if err != nil {
-		return nil, util.NewInvalidInputErrorWithDetails(err, "Error reading pipeline YAML from the tarball file.")
+	if pipelinePackage.Template == nil {
+		return nil, util.NewInvalidInputError("Error extracting pipeline from the tarball file. Expecting a YAML file inside the tarball. Got: %v", firstFileName)
 	}
-	return decompressedFile, err
+	return pipelinePackage, nil
 }
 
 func ReadPipelineFile(fileName string, fileReader io.Reader, maxFileLength int) ([]byte, error) {
+	pipelinePackage, err := ReadPipelinePackage(fileName, fileReader, maxFileLength)
+	if err != nil {
+		return nil, err
+	}
+	return pipelinePackage.Template, nil
+}
+
+// ReadPipelinePackage reads a pipeline file, either YAML or a tarball.
+func ReadPipelinePackage(fileName string, fileReader io.Reader, maxFileLength int) (*PipelinePackage, error) {
 	if !isSupportedPipelineFormat(fileName) {
 		return nil, util.NewInvalidInputError("Unexpected pipeline file format. Support .tar.gz or YAML.")
 	}
@@ -94,15 +158,15 @@ func ReadPipelineFile(fileName string, fileReader io.Reader, maxFileLength int)
 
 	// Return if file is YAML
 	if isYamlFile(fileName) {
-		return pipelineFileBytes, nil
+		return &PipelinePackage{Template: pipelineFileBytes}, nil
 	}
 
 	// Decompress if file is tarball
-	decompressedFile, err := DecompressPipelineTarball(pipelineFileBytes)
+	pipelinePackage, err := DecompressPipelinePackage(pipelineFileBytes)
 	if err != nil {
 		return nil, util.Wrap(err, "Error decompress the pipeline file")
 	}
-	return decompressedFile, nil
+	return pipelinePackage, nil
 }
 
 func printParameters(params []*api.Parameter) string {
@@ -159,6 +223,12 @@ func ValidatePipelineSpec(resourceManager *resource.ResourceManager, spec *api.P
 				"Invalid argo workflow format. Workflow: "+spec.GetWorkflowManifest())
 		}
 	}
+	if err := validateWorkflowOptions(spec.GetWorkflowOptions()); err != nil {
+		return err
+	}
+	if err := validatePipelineRoot(spec); err != nil {
+		return err
+	}
 	paramsBytes, err := json.Marshal(spec.Parameters)
 	if err != nil {
 		return util.NewInternalServerError(err,
@@ -170,3 +240,70 @@ func ValidatePipelineSpec(resourceManager *resource.ResourceManager, spec *api.P
 	}
 	return nil
 }
+
+// The names of the S3 buckets.
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+func validateWorkflowOptions(options *api.WorkflowOptions) error {
+	if _, ok := api.WorkflowOptions_PodGCStrategy_name[int32(options.GetPodGcStrategy())]; !ok {
+		return util.NewInvalidInputError("Unknown pod GC strategy %v.", options.GetPodGcStrategy())
+	}
+	if _, ok := api.WorkflowOptions_ArtifactArchive_name[int32(options.GetArtifactArchive())]; !ok {
+		return util.NewInvalidInputError("Unknown artifact archive strategy %v.", options.GetArtifactArchive())
+	}
+	if _, ok := api.WorkflowOptions_LogArchive_name[int32(options.GetLogArchive())]; !ok {
+		return util.NewInvalidInputError("Unknown log archive strategy %v.", options.GetLogArchive())
+	}
+	if options.GetDefaultRetryStrategy().GetLimit() < 0 {
+		return util.NewInvalidInputError("The retry limit must not be negative.")
+	}
+	if options.GetParallelism() < 0 {
+		return util.NewInvalidInputError("The parallelism must not be negative.")
+	}
+	if name := options.GetPriorityClassName(); name != "" && len(validation.IsDNS1123Subdomain(name)) > 0 {
+		return util.NewInvalidInputError("Invalid priority class name %q.", name)
+	}
+	if _, ok := api.WorkflowOptions_PreemptionPolicy_name[int32(options.GetPreemptionPolicy())]; !ok {
+		return util.NewInvalidInputError("Unknown preemption policy %v.", options.GetPreemptionPolicy())
+	}
+	return validateArtifactRepository(options.GetArtifactRepository())
+}
+
+func validateArtifactRepository(repository *api.ArtifactRepository) error {
+	if bucket := repository.GetBucket(); bucket != "" && !bucketNameRegexp.MatchString(bucket) {
+		return util.NewInvalidInputError("Invalid artifact bucket name %q.", bucket)
+	}
+	keyPrefix := repository.GetKeyPrefix()
+	for _, segment := range strings.Split(keyPrefix, "/") {
+		if segment == ".." {
+			return util.NewInvalidInputError("The artifact key prefix %q must not contain '..'.", keyPrefix)
+		}
+	}
+	return nil
+}
+
+// validatePipelineRoot validates the pipeline root of a pipeline spec, which sets the
+// artifact repository of its workflow options.
+func validatePipelineRoot(spec *api.PipelineSpec) error {
+	if spec.GetPipelineRoot() == "" {
+		return nil
+	}
+	if spec.GetWorkflowOptions().GetArtifactRepository() != nil {
+		return util.NewInvalidInputError("Please either specify a pipeline root or an artifact repository, not both.")
+	}
+	repository, err := resource.ParsePipelineRoot(spec.GetPipelineRoot())
+	if err != nil {
+		return err
+	}
+	return validateArtifactRepository(repository)
+}
+
+// getAuthenticatedUser returns the user who made a call. The favorites are kept per user, so
+// they can't be used by the unauthenticated calls.
+func getAuthenticatedUser(ctx context.Context) (string, error) {
+	userId := common.GetUser(ctx)
+	if userId == "" {
+		return "", util.NewUnauthenticatedError("The favorites are only kept for the authenticated users")
+	}
+	return userId, nil
+}
@@ -84,6 +84,31 @@ func TestDecompressPipelineTarball_EmptyTarball(t *testing.T) {
 	assert.Contains(t, err.Error(), "Not a valid tarball file")
 }
 
+func TestDecompressPipelinePackage_Readme(t *testing.T) {
+	tarball, err := util.ArchiveTgz(map[string]string{
+		"docs/README.md": "# Usage",
+		"pipeline.yaml":  "apiVersion: argoproj.io/v1alpha1\nkind: Workflow",
+	})
+	assert.Nil(t, err)
+	pipelinePackage, err := DecompressPipelinePackage([]byte(tarball))
+	assert.Nil(t, err)
+	assert.Equal(t, &PipelinePackage{
+		Template: []byte("apiVersion: argoproj.io/v1alpha1\nkind: Workflow"),
+		Readme:   []byte("# Usage"),
+	}, pipelinePackage)
+}
+
+func TestDecompressPipelinePackage_ReadmeTooLarge(t *testing.T) {
+	tarball, err := util.ArchiveTgz(map[string]string{
+		"README.md":     strings.Repeat("a", MaxReadmeLength+1),
+		"pipeline.yaml": "apiVersion: argoproj.io/v1alpha1\nkind: Workflow",
+	})
+	assert.Nil(t, err)
+	_, err = DecompressPipelinePackage([]byte(tarball))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "README.md too large")
+}
+
 func TestReadPipelineFile_YAML(t *testing.T) {
 	file, _ := os.Open("test/arguments-parameters.yaml")
 	fileBytes, err := ReadPipelineFile("arguments-parameters.yaml", file, MaxFileLength)
@@ -239,6 +264,83 @@ func TestValidatePipelineSpec_InvalidWorkflowManifest(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid argo workflow format")
 }
 
+func TestValidatePipelineSpec_UnknownWorkflowOptions(t *testing.T) {
+	clients, manager, _ := initWithPipeline(t)
+	defer clients.Close()
+	spec := &api.PipelineSpec{
+		WorkflowManifest: testWorkflow.ToStringForStore(),
+		WorkflowOptions:  &api.WorkflowOptions{PodGcStrategy: 42},
+	}
+	err := ValidatePipelineSpec(manager, spec)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Unknown pod GC strategy")
+}
+
+func TestValidatePipelineSpec_InvalidPodPriority(t *testing.T) {
+	clients, manager, _ := initWithPipeline(t)
+	defer clients.Close()
+	for _, test := range []struct {
+		options *api.WorkflowOptions
+		message string
+	}{
+		{&api.WorkflowOptions{PriorityClassName: "Production_Pipelines"}, "Invalid priority class name"},
+		{&api.WorkflowOptions{PreemptionPolicy: 42}, "Unknown preemption policy"},
+	} {
+		spec := &api.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			WorkflowOptions:  test.options,
+		}
+		err := ValidatePipelineSpec(manager, spec)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), test.message)
+	}
+}
+
+func TestValidatePipelineSpec_InvalidArtifactRepository(t *testing.T) {
+	clients, manager, _ := initWithPipeline(t)
+	defer clients.Close()
+	for repository, message := range map[*api.ArtifactRepository]string{
+		{Bucket: "Team_A"}:                      "Invalid artifact bucket name",
+		{Bucket: "team-a", KeyPrefix: "a/../b"}: "must not contain '..'",
+	} {
+		spec := &api.PipelineSpec{
+			WorkflowManifest: testWorkflow.ToStringForStore(),
+			WorkflowOptions:  &api.WorkflowOptions{ArtifactRepository: repository},
+		}
+		err := ValidatePipelineSpec(manager, spec)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), message)
+	}
+}
+
+func TestValidatePipelineSpec_InvalidPipelineRoot(t *testing.T) {
+	clients, manager, _ := initWithPipeline(t)
+	defer clients.Close()
+	for root, message := range map[string]string{
+		"gs://team-a/runs":        "Invalid pipeline root",
+		"minio:///runs":           "Invalid pipeline root",
+		"minio://Team_A/runs":     "Invalid artifact bucket name",
+		"s3://team-a/runs/../etc": "must not contain '..'",
+	} {
+		spec := &api.PipelineSpec{WorkflowManifest: testWorkflow.ToStringForStore(), PipelineRoot: root}
+		err := ValidatePipelineSpec(manager, spec)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), message)
+	}
+
+	spec := &api.PipelineSpec{
+		WorkflowManifest: testWorkflow.ToStringForStore(),
+		WorkflowOptions:  &api.WorkflowOptions{ArtifactRepository: &api.ArtifactRepository{Bucket: "team-a"}},
+		PipelineRoot:     "minio://team-a/runs",
+	}
+	err := ValidatePipelineSpec(manager, spec)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "not both")
+
+	spec.WorkflowOptions = nil
+	assert.Nil(t, ValidatePipelineSpec(manager, spec))
+}
+
 func TestValidatePipelineSpec_ParameterTooLong(t *testing.T) {
 	clients, manager, pipeline := initWithPipeline(t)
 	defer clients.Close()
@@ -0,0 +1,65 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type VisualizationServer struct {
+	resourceManager *resource.ResourceManager
+}
+
+func (s *VisualizationServer) CreateVisualization(ctx context.Context, request *api.CreateVisualizationRequest) (
+	*api.Visualization, error) {
+	if err := validateCreateVisualizationRequest(request); err != nil {
+		return nil, util.Wrap(err, "Validate create visualization request failed.")
+	}
+	visualization, err := s.resourceManager.CreateVisualization(request.Visualization)
+	if err != nil {
+		return nil, util.Wrap(err, "Failed to create the visualization.")
+	}
+	return visualization, nil
+}
+
+func validateCreateVisualizationRequest(request *api.CreateVisualizationRequest) error {
+	visualization := request.GetVisualization()
+	if visualization == nil {
+		return util.NewInvalidInputError("The visualization is required.")
+	}
+	if _, ok := api.Visualization_Type_name[int32(visualization.Type)]; !ok ||
+		visualization.Type == api.Visualization_UNSPECIFIED {
+		return util.NewInvalidInputError("The visualization type is invalid. Please specify a valid type.")
+	}
+	if visualization.Source == "" {
+		return util.NewInvalidInputError("The source of the visualization is empty. Please specify a valid URI.")
+	}
+	if visualization.Arguments != "" {
+		var arguments map[string]interface{}
+		if err := json.Unmarshal([]byte(visualization.Arguments), &arguments); err != nil {
+			return util.NewInvalidInputError("The arguments of the visualization must be a JSON object: %v", err)
+		}
+	}
+	return nil
+}
+
+func NewVisualizationServer(resourceManager *resource.ResourceManager) *VisualizationServer {
+	return &VisualizationServer{resourceManager: resourceManager}
+}
@@ -0,0 +1,145 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/client/visualization"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newVisualizationServerForTest(t *testing.T) (*resource.FakeClientManager,
+	*visualization.FakeVisualizationClient, *VisualizationServer) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	visualizationClient := clientManager.EnableVisualizations()
+	assert.Nil(t, clientManager.ObjectStore().AddFile([]byte("target,score\n1,0.9\n0,0.1\n"),
+		"artifacts/predictions.csv"))
+	return clientManager, visualizationClient, NewVisualizationServer(resource.NewResourceManager(clientManager))
+}
+
+func TestCreateVisualization(t *testing.T) {
+	clientManager, visualizationClient, server := newVisualizationServerForTest(t)
+	defer clientManager.Close()
+
+	result, err := server.CreateVisualization(nil, &api.CreateVisualizationRequest{
+		Visualization: &api.Visualization{
+			Type:      api.Visualization_ROC_CURVE,
+			Source:    "minio://mlpipeline/artifacts/predictions.csv",
+			Arguments: `{"positive_label": "1"}`,
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.Visualization{
+		Type:      api.Visualization_ROC_CURVE,
+		Source:    "minio://mlpipeline/artifacts/predictions.csv",
+		Arguments: `{"positive_label": "1"}`,
+		Html:      "<p>visualization</p>",
+	}, result)
+	assert.Equal(t, []*visualization.GenerateRequest{{
+		Type:      api.Visualization_ROC_CURVE,
+		Arguments: `{"positive_label": "1"}`,
+		Content:   []byte("target,score\n1,0.9\n0,0.1\n"),
+	}}, visualizationClient.Requests())
+}
+
+func TestCreateVisualization_GenerationError(t *testing.T) {
+	clientManager, visualizationClient, server := newVisualizationServerForTest(t)
+	defer clientManager.Close()
+	visualizationClient.SetResponse(&visualization.GenerateResponse{Error: `The CSV source has no column "label".`}, nil)
+
+	result, err := server.CreateVisualization(nil, &api.CreateVisualizationRequest{
+		Visualization: &api.Visualization{
+			Type:   api.Visualization_TABLE,
+			Source: "s3://mlpipeline/artifacts/predictions.csv",
+		},
+	})
+	assert.Nil(t, err)
+	assert.Empty(t, result.Html)
+	assert.Equal(t, `The CSV source has no column "label".`, result.Error)
+}
+
+func TestCreateVisualization_ServerUnavailable(t *testing.T) {
+	clientManager, visualizationClient, server := newVisualizationServerForTest(t)
+	defer clientManager.Close()
+	visualizationClient.SetResponse(nil, util.NewUnavailableError(nil, "Failed to call the visualization server"))
+
+	_, err := server.CreateVisualization(nil, &api.CreateVisualizationRequest{
+		Visualization: &api.Visualization{
+			Type:   api.Visualization_TABLE,
+			Source: "minio://mlpipeline/artifacts/predictions.csv",
+		},
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCreateVisualization_NotConfigured(t *testing.T) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	defer clientManager.Close()
+	server := NewVisualizationServer(resource.NewResourceManager(clientManager))
+
+	_, err := server.CreateVisualization(nil, &api.CreateVisualizationRequest{
+		Visualization: &api.Visualization{
+			Type:   api.Visualization_TABLE,
+			Source: "minio://mlpipeline/artifacts/predictions.csv",
+		},
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no visualization server is configured")
+}
+
+func TestCreateVisualization_SourceOutsideBucket(t *testing.T) {
+	clientManager, visualizationClient, server := newVisualizationServerForTest(t)
+	defer clientManager.Close()
+
+	for _, source := range []string{
+		"minio://other/artifacts/predictions.csv", "gs://mlpipeline/predictions.csv", "https://example.com/a.csv"} {
+		_, err := server.CreateVisualization(nil, &api.CreateVisualizationRequest{
+			Visualization: &api.Visualization{Type: api.Visualization_TABLE, Source: source},
+		})
+		assert.NotNil(t, err, source)
+		assert.Contains(t, err.Error(), "Only the artifacts in bucket mlpipeline of the object store can be visualized")
+	}
+	assert.Empty(t, visualizationClient.Requests())
+}
+
+func TestCreateVisualization_InvalidRequest(t *testing.T) {
+	clientManager, visualizationClient, server := newVisualizationServerForTest(t)
+	defer clientManager.Close()
+
+	tests := []struct {
+		visualization *api.Visualization
+		message       string
+	}{
+		{nil, "The visualization is required."},
+		{&api.Visualization{Source: "minio://mlpipeline/a.csv"}, "The visualization type is invalid."},
+		{&api.Visualization{Type: 10, Source: "minio://mlpipeline/a.csv"}, "The visualization type is invalid."},
+		{&api.Visualization{Type: api.Visualization_TABLE}, "The source of the visualization is empty."},
+		{&api.Visualization{Type: api.Visualization_TABLE, Source: "minio://mlpipeline/a.csv", Arguments: "[1]"},
+			"The arguments of the visualization must be a JSON object"},
+	}
+	for _, test := range tests {
+		_, err := server.CreateVisualization(nil, &api.CreateVisualizationRequest{Visualization: test.visualization})
+		assert.NotNil(t, err, test.message)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode(), test.message)
+		assert.Contains(t, err.Error(), test.message)
+	}
+	assert.Empty(t, visualizationClient.Requests())
+}
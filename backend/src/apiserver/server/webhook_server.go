@@ -0,0 +1,112 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type WebhookServer struct {
+	resourceManager *resource.ResourceManager
+	// Denies the URLs of the webhooks reaching internal addresses. The URLs are checked again
+	// when the events are delivered.
+	urlGuard *util.URLGuard
+}
+
+func (s *WebhookServer) CreateWebhook(ctx context.Context, request *api.CreateWebhookRequest) (
+	*api.Webhook, error) {
+	err := ValidateCreateWebhookRequest(request)
+	if err != nil {
+		return nil, util.Wrap(err, "Validate webhook request failed.")
+	}
+	parsedUrl, _ := url.ParseRequestURI(request.Webhook.Url)
+	if err := s.urlGuard.CheckURL(ctx, parsedUrl); err != nil {
+		return nil, util.Wrap(err, "Validate webhook request failed.")
+	}
+	newWebhook, err := s.resourceManager.CreateWebhook(ToModelWebhook(request.Webhook))
+	if err != nil {
+		return nil, util.Wrap(err, "Create webhook failed.")
+	}
+	return ToApiWebhook(newWebhook), nil
+}
+
+func (s *WebhookServer) GetWebhook(ctx context.Context, request *api.GetWebhookRequest) (
+	*api.Webhook, error) {
+	webhook, err := s.resourceManager.GetWebhook(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Get webhook failed.")
+	}
+	return ToApiWebhook(webhook), nil
+}
+
+func (s *WebhookServer) ListWebhooks(ctx context.Context, request *api.ListWebhooksRequest) (
+	*api.ListWebhooksResponse, error) {
+	paginationContext, err := ValidatePagination(
+		request.PageToken, int(request.PageSize), model.GetWebhookTablePrimaryKeyColumn(),
+		request.SortBy, webhookModelFieldsBySortableAPIFields)
+	if err != nil {
+		return nil, util.Wrap(err, "List webhooks failed.")
+	}
+	webhooks, nextPageToken, err := s.resourceManager.ListWebhooks(paginationContext)
+	if err != nil {
+		return nil, util.Wrap(err, "List webhooks failed.")
+	}
+	return &api.ListWebhooksResponse{
+			Webhooks:      ToApiWebhooks(webhooks),
+			NextPageToken: nextPageToken},
+		nil
+}
+
+func (s *WebhookServer) DeleteWebhook(ctx context.Context, request *api.DeleteWebhookRequest) (*empty.Empty, error) {
+	err := s.resourceManager.DeleteWebhook(request.Id)
+	if err != nil {
+		return nil, util.Wrap(err, "Delete webhook failed.")
+	}
+	return &empty.Empty{}, nil
+}
+
+func ValidateCreateWebhookRequest(request *api.CreateWebhookRequest) error {
+	webhook := request.Webhook
+	if webhook == nil || webhook.Name == "" {
+		return util.NewInvalidInputError("Webhook name is empty. Please specify a valid webhook name.")
+	}
+	parsedUrl, err := url.ParseRequestURI(webhook.Url)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") {
+		return util.NewInvalidInputError("Invalid webhook URL %v. Please specify a valid http or https URL.", webhook.Url)
+	}
+	if webhook.Secret == "" {
+		return util.NewInvalidInputError("Webhook secret is empty. Please specify a secret to sign the payloads with.")
+	}
+	if len(webhook.Events) == 0 {
+		return util.NewInvalidInputError("Webhook has no event. Please specify the run events to notify.")
+	}
+	for _, event := range webhook.Events {
+		if _, ok := runStatesByWebhookEvent[event]; !ok {
+			return util.NewInvalidInputError("Unknown webhook event %v.", event)
+		}
+	}
+	return nil
+}
+
+func NewWebhookServer(resourceManager *resource.ResourceManager, urlGuard *util.URLGuard) *WebhookServer {
+	return &WebhookServer{resourceManager: resourceManager, urlGuard: urlGuard}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/resource"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newWebhookServerForTest() (*resource.FakeClientManager, *WebhookServer) {
+	clientManager := resource.NewFakeClientManagerOrFatal(util.NewFakeTimeForEpoch())
+	resourceManager := resource.NewResourceManager(clientManager)
+	urlGuard, _ := util.NewURLGuard(util.URLGuardOptions{Policy: "webhook URL",
+		AllowedSchemes: []string{"http", "https"}})
+	return clientManager, NewWebhookServer(resourceManager, urlGuard)
+}
+
+func validWebhook() *api.Webhook {
+	return &api.Webhook{
+		Name:   "webhook1",
+		Url:    "https://example.com/hook",
+		Secret: "secret",
+		Events: []api.Webhook_Event{api.Webhook_RUN_FAILED},
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	clientManager, server := newWebhookServerForTest()
+	defer clientManager.Close()
+
+	result, err := server.CreateWebhook(nil, &api.CreateWebhookRequest{Webhook: validWebhook()})
+	assert.Nil(t, err)
+	expectedWebhook := &api.Webhook{
+		Id:        resource.DefaultFakeUUID,
+		Name:      "webhook1",
+		Url:       "https://example.com/hook",
+		Events:    []api.Webhook_Event{api.Webhook_RUN_FAILED},
+		CreatedAt: &timestamp.Timestamp{Seconds: 1},
+	}
+	assert.Equal(t, expectedWebhook, result)
+
+	webhook, err := clientManager.WebhookStore().GetWebhook(resource.DefaultFakeUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Failed,Error", webhook.EventFilters)
+	assert.Equal(t, "secret", webhook.Secret)
+}
+
+func TestCreateWebhook_ExperimentNotFound(t *testing.T) {
+	clientManager, server := newWebhookServerForTest()
+	defer clientManager.Close()
+	webhook := validWebhook()
+	webhook.ExperimentId = "not-exist"
+
+	_, err := server.CreateWebhook(nil, &api.CreateWebhookRequest{Webhook: webhook})
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCreateWebhook_DeniedURL(t *testing.T) {
+	clientManager, server := newWebhookServerForTest()
+	defer clientManager.Close()
+	server.urlGuard, _ = util.NewURLGuard(util.URLGuardOptions{
+		Policy:          "webhook URL",
+		AllowedSchemes:  []string{"http", "https"},
+		DeniedHosts:     []string{"metadata.google.internal"},
+		BlockedNetworks: []string{"127.0.0.0/8", "169.254.0.0/16"},
+	})
+
+	for _, url := range []string{
+		"http://169.254.169.254/computeMetadata/v1/",
+		"http://127.0.0.1:8888/apis/v1beta1/runs",
+		"https://metadata.google.internal/hook",
+	} {
+		webhook := validWebhook()
+		webhook.Url = url
+		_, err := server.CreateWebhook(context.Background(), &api.CreateWebhookRequest{Webhook: webhook})
+		assert.True(t, util.IsUserErrorCodeMatch(err, codes.PermissionDenied), url)
+		assert.Contains(t, err.Error(), "is denied by the webhook URL policy", url)
+	}
+}
+
+func TestValidateCreateWebhookRequest(t *testing.T) {
+	assert.Nil(t, ValidateCreateWebhookRequest(&api.CreateWebhookRequest{Webhook: validWebhook()}))
+
+	noName := validWebhook()
+	noName.Name = ""
+	badUrl := validWebhook()
+	badUrl.Url = "ftp://example.com"
+	noSecret := validWebhook()
+	noSecret.Secret = ""
+	noEvent := validWebhook()
+	noEvent.Events = nil
+	unknownEvent := validWebhook()
+	unknownEvent.Events = []api.Webhook_Event{api.Webhook_UNKNOWN_EVENT}
+
+	for _, webhook := range []*api.Webhook{noName, badUrl, noSecret, noEvent, unknownEvent} {
+		err := ValidateCreateWebhookRequest(&api.CreateWebhookRequest{Webhook: webhook})
+		assert.NotNil(t, err)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+	}
+}
+
+func TestListWebhooks(t *testing.T) {
+	clientManager, server := newWebhookServerForTest()
+	defer clientManager.Close()
+	server.CreateWebhook(nil, &api.CreateWebhookRequest{Webhook: validWebhook()})
+
+	result, err := server.ListWebhooks(nil, &api.ListWebhooksRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, result.Webhooks, 1)
+	assert.Equal(t, "webhook1", result.Webhooks[0].Name)
+	assert.Empty(t, result.Webhooks[0].Secret)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	clientManager, server := newWebhookServerForTest()
+	defer clientManager.Close()
+	server.CreateWebhook(nil, &api.CreateWebhookRequest{Webhook: validWebhook()})
+
+	_, err := server.DeleteWebhook(nil, &api.DeleteWebhookRequest{Id: resource.DefaultFakeUUID})
+	assert.Nil(t, err)
+	_, err = server.GetWebhook(nil, &api.GetWebhookRequest{Id: resource.DefaultFakeUUID})
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
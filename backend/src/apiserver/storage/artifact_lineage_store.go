@@ -0,0 +1,134 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var artifactEventColumns = []string{"RunUUID", "NodeID", "ArtifactName", "Type", "ArtifactURI", "StepName", "CreatedAtInSec"}
+
+type ArtifactLineageStoreInterface interface {
+	// ReplaceArtifactEvents replaces the artifact events of a run with the given ones.
+	ReplaceArtifactEvents(runUUID string, events []*model.ArtifactEvent) error
+	// ListArtifactEventsByURI returns the events of the given type of the artifact stored at the URI.
+	ListArtifactEventsByURI(uri string, eventType model.ArtifactEventType) ([]*model.ArtifactEvent, error)
+	// ListArtifactEventsByStep returns the events of the given type of a step of a run.
+	ListArtifactEventsByStep(runUUID string, nodeID string, eventType model.ArtifactEventType) ([]*model.ArtifactEvent, error)
+	// ListArtifactEventsByType returns the events of the given type of all the runs.
+	ListArtifactEventsByType(eventType model.ArtifactEventType) ([]*model.ArtifactEvent, error)
+}
+
+type ArtifactLineageStore struct {
+	db *DB
+}
+
+func (s *ArtifactLineageStore) ReplaceArtifactEvents(runUUID string, events []*model.ArtifactEvent) error {
+	deleteSql, deleteArgs, err := sq.Delete("artifact_events").Where(sq.Eq{"RunUUID": runUUID}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete the artifact events of run %v", runUUID)
+	}
+	// Use a transaction so that the lineage of the run is never partially indexed.
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to index the artifacts of run %v", runUUID)
+	}
+	if _, err = tx.Exec(deleteSql, deleteArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to delete the artifact events of run %v", runUUID)
+	}
+	if len(events) > 0 {
+		insertBuilder := sq.Insert("artifact_events").Columns(artifactEventColumns...)
+		for _, event := range events {
+			insertBuilder = insertBuilder.Values(event.RunUUID, event.NodeID, event.ArtifactName, string(event.Type),
+				event.ArtifactURI, event.StepName, event.CreatedAtInSec)
+		}
+		insertSql, insertArgs, err := insertBuilder.ToSql()
+		if err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to create query to store the artifact events of run %v", runUUID)
+		}
+		if _, err = tx.Exec(insertSql, insertArgs...); err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to store the artifact events of run %v", runUUID)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to index the artifacts of run %v", runUUID)
+	}
+	return nil
+}
+
+func (s *ArtifactLineageStore) ListArtifactEventsByURI(uri string, eventType model.ArtifactEventType) (
+	[]*model.ArtifactEvent, error) {
+	return s.list(sq.Eq{"ArtifactURI": uri, "Type": string(eventType)})
+}
+
+func (s *ArtifactLineageStore) ListArtifactEventsByStep(runUUID string, nodeID string,
+	eventType model.ArtifactEventType) ([]*model.ArtifactEvent, error) {
+	return s.list(sq.Eq{"RunUUID": runUUID, "NodeID": nodeID, "Type": string(eventType)})
+}
+
+func (s *ArtifactLineageStore) ListArtifactEventsByType(eventType model.ArtifactEventType) (
+	[]*model.ArtifactEvent, error) {
+	return s.list(sq.Eq{"Type": string(eventType)})
+}
+
+func (s *ArtifactLineageStore) list(filter sq.Eq) ([]*model.ArtifactEvent, error) {
+	sql, args, err := sq.
+		Select(artifactEventColumns...).
+		From("artifact_events").
+		Where(filter).
+		OrderBy("CreatedAtInSec", "RunUUID", "NodeID", "ArtifactName").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list artifact events: %v", err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list artifact events: %v", err.Error())
+	}
+	defer rows.Close()
+	events, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse artifact events: %v", err.Error())
+	}
+	return events, nil
+}
+
+func (s *ArtifactLineageStore) scanRows(rows *sql.Rows) ([]*model.ArtifactEvent, error) {
+	var events []*model.ArtifactEvent
+	for rows.Next() {
+		var event model.ArtifactEvent
+		var eventType string
+		if err := rows.Scan(&event.RunUUID, &event.NodeID, &event.ArtifactName, &eventType,
+			&event.ArtifactURI, &event.StepName, &event.CreatedAtInSec); err != nil {
+			return events, err
+		}
+		event.Type = model.ArtifactEventType(eventType)
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+// factory function for artifact lineage store
+func NewArtifactLineageStore(db *DB) *ArtifactLineageStore {
+	return &ArtifactLineageStore{db: db}
+}
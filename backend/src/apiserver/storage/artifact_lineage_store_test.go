@@ -0,0 +1,94 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func artifactEvent(runUUID string, nodeID string, eventType model.ArtifactEventType, uri string) *model.ArtifactEvent {
+	return &model.ArtifactEvent{
+		RunUUID:        runUUID,
+		NodeID:         nodeID,
+		ArtifactName:   "data",
+		Type:           eventType,
+		ArtifactURI:    uri,
+		StepName:       nodeID,
+		CreatedAtInSec: 1,
+	}
+}
+
+func TestReplaceArtifactEvents(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewArtifactLineageStore(db)
+
+	producer := artifactEvent("run1", "node1", model.ArtifactEventOutput, "s3://bucket/data")
+	consumer := artifactEvent("run2", "node1", model.ArtifactEventInput, "s3://bucket/data")
+	assert.Nil(t, store.ReplaceArtifactEvents("run1", []*model.ArtifactEvent{producer}))
+	assert.Nil(t, store.ReplaceArtifactEvents("run2", []*model.ArtifactEvent{consumer}))
+
+	events, err := store.ListArtifactEventsByURI("s3://bucket/data", model.ArtifactEventOutput)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{producer}, events)
+	events, err = store.ListArtifactEventsByURI("s3://bucket/data", model.ArtifactEventInput)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{consumer}, events)
+
+	// Indexing a run again replaces its previous events.
+	newProducer := artifactEvent("run1", "node2", model.ArtifactEventOutput, "s3://bucket/data")
+	assert.Nil(t, store.ReplaceArtifactEvents("run1", []*model.ArtifactEvent{newProducer}))
+	events, err = store.ListArtifactEventsByURI("s3://bucket/data", model.ArtifactEventOutput)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{newProducer}, events)
+}
+
+func TestListArtifactEventsByStep(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewArtifactLineageStore(db)
+
+	input := artifactEvent("run1", "node1", model.ArtifactEventInput, "s3://bucket/in")
+	output := artifactEvent("run1", "node1", model.ArtifactEventOutput, "s3://bucket/out")
+	otherStep := artifactEvent("run1", "node2", model.ArtifactEventInput, "s3://bucket/out")
+	assert.Nil(t, store.ReplaceArtifactEvents("run1", []*model.ArtifactEvent{input, output, otherStep}))
+
+	events, err := store.ListArtifactEventsByStep("run1", "node1", model.ArtifactEventInput)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{input}, events)
+
+	events, err = store.ListArtifactEventsByStep("run1", "node3", model.ArtifactEventInput)
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+}
+
+func TestListArtifactEventsByType(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewArtifactLineageStore(db)
+
+	input := artifactEvent("run2", "node1", model.ArtifactEventInput, "s3://bucket/out1")
+	output1 := artifactEvent("run1", "node1", model.ArtifactEventOutput, "s3://bucket/out1")
+	output2 := artifactEvent("run2", "node1", model.ArtifactEventOutput, "s3://bucket/out2")
+	assert.Nil(t, store.ReplaceArtifactEvents("run1", []*model.ArtifactEvent{output1}))
+	assert.Nil(t, store.ReplaceArtifactEvents("run2", []*model.ArtifactEvent{input, output2}))
+
+	events, err := store.ListArtifactEventsByType(model.ArtifactEventOutput)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ArtifactEvent{output1, output2}, events)
+}
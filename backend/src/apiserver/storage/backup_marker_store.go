@@ -0,0 +1,105 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var backupMarkerColumns = []string{"UUID", "CreatedAtInSec", "Status", "ManifestPath", "FileCount", "Message"}
+
+type BackupMarkerStoreInterface interface {
+	CreateMarker(*model.BackupMarker) error
+	// UpdateMarker stores the outcome of a backup: its status, file count and message.
+	UpdateMarker(*model.BackupMarker) error
+	// ListMarkers returns the latest markers first, up to a limit.
+	ListMarkers(limit int) ([]*model.BackupMarker, error)
+}
+
+type BackupMarkerStore struct {
+	db *DB
+}
+
+func (s *BackupMarkerStore) CreateMarker(marker *model.BackupMarker) error {
+	sql, args, err := sq.
+		Insert("backup_markers").
+		SetMap(sq.Eq{
+			"UUID":           marker.UUID,
+			"CreatedAtInSec": marker.CreatedAtInSec,
+			"Status":         marker.Status,
+			"ManifestPath":   marker.ManifestPath,
+			"FileCount":      marker.FileCount,
+			"Message":        marker.Message}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the backup marker %v", marker.UUID)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the backup marker %v", marker.UUID)
+	}
+	return nil
+}
+
+func (s *BackupMarkerStore) UpdateMarker(marker *model.BackupMarker) error {
+	sql, args, err := sq.
+		Update("backup_markers").
+		SetMap(sq.Eq{
+			"Status":    marker.Status,
+			"FileCount": marker.FileCount,
+			"Message":   marker.Message}).
+		Where(sq.Eq{"UUID": marker.UUID}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the backup marker %v", marker.UUID)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the backup marker %v", marker.UUID)
+	}
+	return nil
+}
+
+func (s *BackupMarkerStore) ListMarkers(limit int) ([]*model.BackupMarker, error) {
+	sql, args, err := sq.
+		Select(backupMarkerColumns...).
+		From("backup_markers").
+		OrderBy("CreatedAtInSec DESC", "UUID").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the backup markers")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the backup markers")
+	}
+	defer rows.Close()
+	var markers []*model.BackupMarker
+	for rows.Next() {
+		var marker model.BackupMarker
+		if err := rows.Scan(&marker.UUID, &marker.CreatedAtInSec, &marker.Status, &marker.ManifestPath,
+			&marker.FileCount, &marker.Message); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to read the backup markers")
+		}
+		markers = append(markers, &marker)
+	}
+	return markers, nil
+}
+
+// factory function for backup marker store
+func NewBackupMarkerStore(db *DB) *BackupMarkerStore {
+	return &BackupMarkerStore{db: db}
+}
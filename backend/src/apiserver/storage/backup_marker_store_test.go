@@ -0,0 +1,45 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupMarkerStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewBackupMarkerStore(db)
+
+	first := &model.BackupMarker{UUID: fakeID, CreatedAtInSec: 1, Status: model.BackupMarkerTaking,
+		ManifestPath: CreateBackupManifestPath(fakeID)}
+	second := &model.BackupMarker{UUID: fakeIDTwo, CreatedAtInSec: 2, Status: model.BackupMarkerTaking,
+		ManifestPath: CreateBackupManifestPath(fakeIDTwo)}
+	assert.Nil(t, store.CreateMarker(first))
+	assert.Nil(t, store.CreateMarker(second))
+
+	first.Status, first.FileCount, first.Message = model.BackupMarkerSucceeded, 3, "dumped"
+	assert.Nil(t, store.UpdateMarker(first))
+
+	markers, err := store.ListMarkers(10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.BackupMarker{second, first}, markers)
+	markers, err = store.ListMarkers(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.BackupMarker{second}, markers)
+}
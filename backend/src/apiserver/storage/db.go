@@ -17,25 +17,97 @@ package storage
 import (
 	"bytes"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"net"
 	"strings"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/VividCortex/mysqlerr"
 	"github.com/go-sql-driver/mysql"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
 	sqlite3 "github.com/mattn/go-sqlite3"
 )
 
 // DB a struct wrapping plain sql library with SQL dialect, to solve any feature
 // difference between MySQL, which is used in production, and Sqlite, which is used
 // for unit testing.
+// The queries fail fast with UNAVAILABLE while the circuit breaker is open.
 type DB struct {
 	*sql.DB
 	SQLDialect
+	breaker *util.CircuitBreaker
+	faults  *util.FaultInjector
 }
 
 // NewDB creates a DB
-func NewDB(db *sql.DB, dialect SQLDialect) *DB {
-	return &DB{db, dialect}
+func NewDB(db *sql.DB, dialect SQLDialect, breaker *util.CircuitBreaker) *DB {
+	return &DB{DB: db, SQLDialect: dialect, breaker: breaker}
+}
+
+// SetFaultInjector injects the faults of the operations "db.query", "db.exec" and
+// "db.begin". The failed calls fail as if the connection to the database was lost.
+func (d *DB) SetFaultInjector(faults *util.FaultInjector) {
+	d.faults = faults
+}
+
+func (d *DB) injectFault(operation string) error {
+	if d.faults.Inject(operation) {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (d *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if err := d.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	var rows *sql.Rows
+	err := d.injectFault("db.query")
+	if err == nil {
+		rows, err = d.DB.Query(query, args...)
+	}
+	d.breaker.Record(isUnavailableError(err))
+	return rows, err
+}
+
+func (d *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if err := d.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	var result sql.Result
+	err := d.injectFault("db.exec")
+	if err == nil {
+		result, err = d.DB.Exec(query, args...)
+	}
+	d.breaker.Record(isUnavailableError(err))
+	return result, err
+}
+
+func (d *DB) Begin() (*sql.Tx, error) {
+	if err := d.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	var tx *sql.Tx
+	err := d.injectFault("db.begin")
+	if err == nil {
+		tx, err = d.DB.Begin()
+	}
+	d.breaker.Record(isUnavailableError(err))
+	return tx, err
+}
+
+// isUnavailableError tells whether a query failed because the database couldn't be reached.
+func isUnavailableError(err error) bool {
+	if err == driver.ErrBadConn || err == mysql.ErrInvalidConn {
+		return true
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	sqlError, ok := err.(*mysql.MySQLError)
+	return ok && (sqlError.Number == mysqlerr.ER_CON_COUNT_ERROR || sqlError.Number == mysqlerr.ER_SERVER_SHUTDOWN)
 }
 
 // SQLDialect abstracts common sql queries which vary in different dialect.
@@ -52,6 +124,14 @@ type SQLDialect interface {
 
 	// Check whether the error is a SQL duplicate entry error or not
 	IsDuplicateError(err error) bool
+
+	// InsertIgnore returns the option of INSERT statements skipping the rows conflicting with
+	// the existing ones instead of failing.
+	InsertIgnore() string
+
+	// MatchName builds the condition of a name column matching a filter, in a way the index of
+	// the column serves.
+	MatchName(column string, filter *common.NameFilter) sq.Sqlizer
 }
 
 // MySQLDialect implements SQLDialect with mysql dialect implementation.
@@ -81,6 +161,24 @@ func (d MySQLDialect) IsDuplicateError(err error) bool {
 	return ok && sqlError.Number == mysqlerr.ER_DUP_ENTRY
 }
 
+func (d MySQLDialect) InsertIgnore() string {
+	return "IGNORE"
+}
+
+// MatchName relies on the columns having the default collation of MySQL, which is case
+// insensitive. The exact match compares the bytes only of the rows the index selected.
+func (d MySQLDialect) MatchName(column string, filter *common.NameFilter) sq.Sqlizer {
+	switch filter.Match {
+	case common.NameMatchCaseInsensitive:
+		return sq.Eq{column: filter.Name}
+	case common.NameMatchPrefix:
+		// The backslash is the default escape character of LIKE.
+		return sq.Expr(column+" LIKE ?", escapeLike(filter.Name)+"%")
+	default:
+		return sq.And{sq.Eq{column: filter.Name}, sq.Expr("BINARY "+column+" = ?", filter.Name)}
+	}
+}
+
 // SQLiteDialect implements SQLDialect with sqlite dialect implementation.
 type SQLiteDialect struct{}
 
@@ -108,6 +206,31 @@ func (d SQLiteDialect) IsDuplicateError(err error) bool {
 	return ok && sqlError.Code == sqlite3.ErrConstraint
 }
 
+func (d SQLiteDialect) InsertIgnore() string {
+	return "OR IGNORE"
+}
+
+// MatchName relies on the columns having the default collation of SQLite, which is case
+// sensitive. The indexes don't serve the case insensitive matches, which only the tests run.
+func (d SQLiteDialect) MatchName(column string, filter *common.NameFilter) sq.Sqlizer {
+	switch filter.Match {
+	case common.NameMatchCaseInsensitive:
+		return sq.Expr(column+" = ? COLLATE NOCASE", filter.Name)
+	case common.NameMatchPrefix:
+		// LIKE is case insensitive, and has no escape character by default.
+		return sq.Expr(column+` LIKE ? ESCAPE '\'`, escapeLike(filter.Name)+"%")
+	default:
+		return sq.Eq{column: filter.Name}
+	}
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, with the backslash.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func NewMySQLDialect() MySQLDialect {
 	return MySQLDialect{}
 }
@@ -18,27 +18,33 @@ import (
 	"fmt"
 
 	"github.com/golang/glog"
-	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/jinzhu/gorm"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 func NewFakeDb() (*DB, error) {
+	db, err := newFakeGormDb()
+	if err != nil {
+		return nil, err
+	}
+	// The circuit breaker of the fake never opens.
+	return NewDB(db.DB(), NewSQLiteDialect(), util.NewCircuitBreaker("database", 0, 0)), nil
+}
+
+func newFakeGormDb() (*gorm.DB, error) {
 	// Initialize GORM
 	db, err := gorm.Open("sqlite3", ":memory:")
 	if err != nil {
 		return nil, fmt.Errorf("Could not create the GORM database: %v", err)
 	}
-	// Create tables
-	db.AutoMigrate(
-		&model.Experiment{},
-		&model.Job{},
-		&model.Pipeline{},
-		&model.ResourceReference{},
-		&model.RunDetail{},
-		&model.RunMetric{})
-
-	return NewDB(db.DB(), NewSQLiteDialect()), nil
+	if err := Migrate(db); err != nil {
+		return nil, err
+	}
+	if err := CreateIndexes(db, ListIndexes); err != nil {
+		return nil, err
+	}
+	return db, nil
 }
 
 func NewFakeDbOrFatal() *DB {
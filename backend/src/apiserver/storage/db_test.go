@@ -15,9 +15,16 @@
 package storage
 
 import (
+	"database/sql/driver"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 )
 
 func TestMySQLDialect_GroupConcat_WithSeparator(t *testing.T) {
@@ -91,3 +98,82 @@ func TestSQLiteDialect_Concat_WithoutSeparator(t *testing.T) {
 	expectedQuery := `col1||col2`
 	assert.Equal(t, expectedQuery, actualQuery)
 }
+
+func TestMySQLDialect_MatchName(t *testing.T) {
+	mysqlDialect := NewMySQLDialect()
+	tests := []struct {
+		match        common.NameMatch
+		expectedSql  string
+		expectedArgs []interface{}
+	}{
+		{common.NameMatchExact, "(Name = ? AND BINARY Name = ?)", []interface{}{"my_Pipe%", "my_Pipe%"}},
+		{common.NameMatchCaseInsensitive, "Name = ?", []interface{}{"my_Pipe%"}},
+		{common.NameMatchPrefix, "Name LIKE ?", []interface{}{`my\_Pipe\%%`}},
+	}
+	for _, test := range tests {
+		sql, args, err := mysqlDialect.MatchName("Name", &common.NameFilter{Name: "my_Pipe%", Match: test.match}).ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, test.expectedSql, sql)
+		assert.Equal(t, test.expectedArgs, args)
+	}
+}
+
+func TestSQLiteDialect_MatchName(t *testing.T) {
+	sqliteDialect := NewSQLiteDialect()
+	tests := []struct {
+		match        common.NameMatch
+		expectedSql  string
+		expectedArgs []interface{}
+	}{
+		{common.NameMatchExact, "Name = ?", []interface{}{`my\Pipe`}},
+		{common.NameMatchCaseInsensitive, "Name = ? COLLATE NOCASE", []interface{}{`my\Pipe`}},
+		{common.NameMatchPrefix, `Name LIKE ? ESCAPE '\'`, []interface{}{`my\\Pipe%`}},
+	}
+	for _, test := range tests {
+		sql, args, err := sqliteDialect.MatchName("Name", &common.NameFilter{Name: `my\Pipe`, Match: test.match}).ToSql()
+		assert.Nil(t, err)
+		assert.Equal(t, test.expectedSql, sql)
+		assert.Equal(t, test.expectedArgs, args)
+	}
+}
+
+func TestDB_CircuitBreaker(t *testing.T) {
+	fakeDB := NewFakeDbOrFatal()
+	defer fakeDB.Close()
+	breaker := util.NewCircuitBreaker("database", 1, time.Hour)
+	db := NewDB(fakeDB.DB, fakeDB.SQLDialect, breaker)
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
+	_, err := pipelineStore.CreatePipeline(createPipeline("pipeline1"))
+	assert.Nil(t, err)
+
+	// The queries fail fast as UNAVAILABLE once the circuit is open.
+	breaker.Record(true)
+	_, err = pipelineStore.GetPipeline(fakeUUID)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	err = pipelineStore.DeletePipeline(fakeUUID)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestIsUnavailableError(t *testing.T) {
+	assert.True(t, isUnavailableError(driver.ErrBadConn))
+	assert.True(t, isUnavailableError(mysql.ErrInvalidConn))
+	assert.True(t, isUnavailableError(&mysql.MySQLError{Number: 1040, Message: "Too many connections"}))
+	assert.False(t, isUnavailableError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}))
+	assert.False(t, isUnavailableError(fmt.Errorf("sql: no rows in result set")))
+	assert.False(t, isUnavailableError(nil))
+}
+
+func TestDB_InjectedFaults(t *testing.T) {
+	db, err := NewFakeDb()
+	assert.Nil(t, err)
+	defer db.Close()
+	db.SetFaultInjector(util.NewFaultInjector([]util.FaultRule{{Operation: "db.exec", Fail: true, Count: 1}}, 0))
+
+	_, err = db.Exec("SELECT 1")
+	assert.Equal(t, driver.ErrBadConn, err)
+	_, err = db.Exec("SELECT 1")
+	assert.Nil(t, err)
+	rows, err := db.Query("SELECT 1")
+	assert.Nil(t, err)
+	rows.Close()
+}
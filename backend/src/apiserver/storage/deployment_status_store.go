@@ -0,0 +1,163 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var runDeploymentColumns = []string{"RunUUID", "Kind", "Namespace", "Name", "APIVersion", "State", "Message",
+	"CreatedAtInSec", "UpdatedAtInSec"}
+
+type DeploymentStatusStoreInterface interface {
+	// CreateDeployments stores the deployments that are not already stored.
+	CreateDeployments(deployments []*model.RunDeployment) error
+	ListDeploymentsByState(state model.DeploymentState) ([]*model.RunDeployment, error)
+	ListRunDeployments(runUUID string) ([]*model.RunDeployment, error)
+	// UpdateDeployment updates the state and the message of a deployment.
+	UpdateDeployment(deployment *model.RunDeployment) error
+}
+
+type DeploymentStatusStore struct {
+	db *DB
+}
+
+func (s *DeploymentStatusStore) CreateDeployments(deployments []*model.RunDeployment) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to store deployments")
+	}
+	for _, deployment := range deployments {
+		key := sq.Eq{
+			"RunUUID":   deployment.RunUUID,
+			"Kind":      deployment.Kind,
+			"Namespace": deployment.Namespace,
+			"Name":      deployment.Name}
+		selectSql, selectArgs, err := sq.Select("count(*)").From("run_deployments").Where(key).ToSql()
+		if err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to create query to check deployment %v", deployment.Name)
+		}
+		var count int
+		if err := tx.QueryRow(selectSql, selectArgs...).Scan(&count); err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to check deployment %v", deployment.Name)
+		}
+		if count > 0 {
+			continue
+		}
+		insertSql, insertArgs, err := sq.
+			Insert("run_deployments").
+			SetMap(sq.Eq{
+				"RunUUID":        deployment.RunUUID,
+				"Kind":           deployment.Kind,
+				"Namespace":      deployment.Namespace,
+				"Name":           deployment.Name,
+				"APIVersion":     deployment.APIVersion,
+				"State":          deployment.State,
+				"Message":        deployment.Message,
+				"CreatedAtInSec": deployment.CreatedAtInSec,
+				"UpdatedAtInSec": deployment.UpdatedAtInSec}).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to create query to store deployment %v", deployment.Name)
+		}
+		if _, err = tx.Exec(insertSql, insertArgs...); err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to store deployment %v", deployment.Name)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store deployments")
+	}
+	return nil
+}
+
+func (s *DeploymentStatusStore) ListDeploymentsByState(state model.DeploymentState) ([]*model.RunDeployment, error) {
+	return s.listDeployments(sq.Eq{"State": state})
+}
+
+func (s *DeploymentStatusStore) ListRunDeployments(runUUID string) ([]*model.RunDeployment, error) {
+	return s.listDeployments(sq.Eq{"RunUUID": runUUID})
+}
+
+func (s *DeploymentStatusStore) listDeployments(filter sq.Eq) ([]*model.RunDeployment, error) {
+	sql, args, err := sq.
+		Select(runDeploymentColumns...).
+		From("run_deployments").
+		Where(filter).
+		OrderBy("RunUUID", "Kind", "Namespace", "Name").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list deployments: %v", err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list deployments: %v", err.Error())
+	}
+	defer rows.Close()
+	deployments, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse deployments: %v", err.Error())
+	}
+	return deployments, nil
+}
+
+func (s *DeploymentStatusStore) UpdateDeployment(deployment *model.RunDeployment) error {
+	sql, args, err := sq.
+		Update("run_deployments").
+		SetMap(sq.Eq{
+			"State":          deployment.State,
+			"Message":        deployment.Message,
+			"UpdatedAtInSec": deployment.UpdatedAtInSec}).
+		Where(sq.Eq{
+			"RunUUID":   deployment.RunUUID,
+			"Kind":      deployment.Kind,
+			"Namespace": deployment.Namespace,
+			"Name":      deployment.Name}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update deployment %v", deployment.Name)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update deployment %v", deployment.Name)
+	}
+	return nil
+}
+
+func (s *DeploymentStatusStore) scanRows(rows *sql.Rows) ([]*model.RunDeployment, error) {
+	var deployments []*model.RunDeployment
+	for rows.Next() {
+		var deployment model.RunDeployment
+		if err := rows.Scan(&deployment.RunUUID, &deployment.Kind, &deployment.Namespace, &deployment.Name,
+			&deployment.APIVersion, &deployment.State, &deployment.Message, &deployment.CreatedAtInSec,
+			&deployment.UpdatedAtInSec); err != nil {
+			return deployments, err
+		}
+		deployments = append(deployments, &deployment)
+	}
+	return deployments, nil
+}
+
+// factory function for deployment status store
+func NewDeploymentStatusStore(db *DB) *DeploymentStatusStore {
+	return &DeploymentStatusStore{db: db}
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentStatusStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewDeploymentStatusStore(db)
+
+	mnist := &model.RunDeployment{RunUUID: fakeID, Kind: "InferenceService", Namespace: "kubeflow", Name: "mnist",
+		APIVersion: "serving.kubeflow.org/v1alpha1", State: model.DeploymentPending, CreatedAtInSec: 1, UpdatedAtInSec: 1}
+	seldon := &model.RunDeployment{RunUUID: fakeID, Kind: "SeldonDeployment", Namespace: "kubeflow", Name: "seldon",
+		APIVersion: "machinelearning.seldon.io/v1alpha2", State: model.DeploymentPending, CreatedAtInSec: 1, UpdatedAtInSec: 1}
+	other := &model.RunDeployment{RunUUID: fakeIDTwo, Kind: "InferenceService", Namespace: "kubeflow", Name: "mnist",
+		APIVersion: "serving.kubeflow.org/v1alpha1", State: model.DeploymentPending, CreatedAtInSec: 1, UpdatedAtInSec: 1}
+	assert.Nil(t, store.CreateDeployments([]*model.RunDeployment{mnist, seldon}))
+	assert.Nil(t, store.CreateDeployments([]*model.RunDeployment{other}))
+
+	mnist.State = model.DeploymentReady
+	mnist.UpdatedAtInSec = 2
+	assert.Nil(t, store.UpdateDeployment(mnist))
+	// Creating a stored deployment again doesn't reset its state.
+	assert.Nil(t, store.CreateDeployments([]*model.RunDeployment{
+		{RunUUID: fakeID, Kind: "InferenceService", Namespace: "kubeflow", Name: "mnist", State: model.DeploymentPending},
+	}))
+
+	deployments, err := store.ListRunDeployments(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunDeployment{mnist, seldon}, deployments)
+	deployments, err = store.ListDeploymentsByState(model.DeploymentPending)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunDeployment{seldon, other}, deployments)
+}
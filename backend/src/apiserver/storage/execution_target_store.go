@@ -0,0 +1,134 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var executionTargetColumns = []string{
+	"Name", "Description", "Namespace", "KubeconfigSecretName", "KubeconfigSecretKey", "CreatedAtInSec"}
+
+type ExecutionTargetStoreInterface interface {
+	// ListExecutionTargets lists the execution targets, ordered by name.
+	ListExecutionTargets() ([]*model.ExecutionTarget, error)
+	GetExecutionTarget(name string) (*model.ExecutionTarget, error)
+	CreateExecutionTarget(*model.ExecutionTarget) (*model.ExecutionTarget, error)
+	DeleteExecutionTarget(name string) error
+}
+
+type ExecutionTargetStore struct {
+	db   *DB
+	time util.TimeInterface
+}
+
+func (s *ExecutionTargetStore) ListExecutionTargets() ([]*model.ExecutionTarget, error) {
+	sql, args, err := sq.Select(executionTargetColumns...).From("execution_targets").OrderBy("Name").ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the execution targets: %v", err.Error())
+	}
+	targets, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the execution targets: %v", err.Error())
+	}
+	return targets, nil
+}
+
+func (s *ExecutionTargetStore) GetExecutionTarget(name string) (*model.ExecutionTarget, error) {
+	sql, args, err := sq.
+		Select(executionTargetColumns...).
+		From("execution_targets").
+		Where(sq.Eq{"Name": name}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get execution target: %v", err.Error())
+	}
+	targets, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get execution target: %v", err.Error())
+	}
+	if len(targets) == 0 {
+		return nil, util.NewResourceNotFoundError("ExecutionTarget", name)
+	}
+	return targets[0], nil
+}
+
+func (s *ExecutionTargetStore) CreateExecutionTarget(target *model.ExecutionTarget) (*model.ExecutionTarget, error) {
+	newTarget := *target
+	newTarget.CreatedAtInSec = s.time.Now().Unix()
+	sql, args, err := sq.
+		Insert("execution_targets").
+		SetMap(sq.Eq{
+			"Name":                 newTarget.Name,
+			"Description":          newTarget.Description,
+			"Namespace":            newTarget.Namespace,
+			"KubeconfigSecretName": newTarget.KubeconfigSecretName,
+			"KubeconfigSecretKey":  newTarget.KubeconfigSecretKey,
+			"CreatedAtInSec":       newTarget.CreatedAtInSec}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to insert execution target: %v", err.Error())
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		if s.db.IsDuplicateError(err) {
+			return nil, util.NewAlreadyExistError("Execution target %v already exists", target.Name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to add execution target to table: %v", err.Error())
+	}
+	return &newTarget, nil
+}
+
+func (s *ExecutionTargetStore) DeleteExecutionTarget(name string) error {
+	sql, args, err := sq.Delete("execution_targets").Where(sq.Eq{"Name": name}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete execution target: %v", err.Error())
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete execution target: %v", err.Error())
+	}
+	return nil
+}
+
+func (s *ExecutionTargetStore) query(sql string, args []interface{}) ([]*model.ExecutionTarget, error) {
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanRows(rows)
+}
+
+func (s *ExecutionTargetStore) scanRows(rows *sql.Rows) ([]*model.ExecutionTarget, error) {
+	var targets []*model.ExecutionTarget
+	for rows.Next() {
+		var target model.ExecutionTarget
+		if err := rows.Scan(&target.Name, &target.Description, &target.Namespace, &target.KubeconfigSecretName,
+			&target.KubeconfigSecretKey, &target.CreatedAtInSec); err != nil {
+			return targets, err
+		}
+		targets = append(targets, &target)
+	}
+	return targets, nil
+}
+
+// factory function for execution target store
+func NewExecutionTargetStore(db *DB, time util.TimeInterface) *ExecutionTargetStore {
+	return &ExecutionTargetStore{db: db, time: time}
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func createExecutionTarget(name string) *model.ExecutionTarget {
+	return &model.ExecutionTarget{
+		Name:                 name,
+		Description:          "The cluster " + name,
+		Namespace:            "kubeflow",
+		KubeconfigSecretName: "kubeconfig-" + name,
+		KubeconfigSecretKey:  "config",
+	}
+}
+
+func TestCreateExecutionTarget(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	targetStore := NewExecutionTargetStore(db, util.NewFakeTimeForEpoch())
+
+	target, err := targetStore.CreateExecutionTarget(createExecutionTarget("east"))
+	assert.Nil(t, err)
+	expected := createExecutionTarget("east")
+	expected.CreatedAtInSec = 1
+	assert.Equal(t, expected, target)
+
+	target, err = targetStore.GetExecutionTarget("east")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, target)
+}
+
+func TestCreateExecutionTarget_DuplicateName(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	targetStore := NewExecutionTargetStore(db, util.NewFakeTimeForEpoch())
+	targetStore.CreateExecutionTarget(createExecutionTarget("east"))
+
+	_, err := targetStore.CreateExecutionTarget(createExecutionTarget("east"))
+	assert.Equal(t, codes.AlreadyExists, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetExecutionTarget_NotFound(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	targetStore := NewExecutionTargetStore(db, util.NewFakeTimeForEpoch())
+
+	_, err := targetStore.GetExecutionTarget("east")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestListExecutionTargets(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	targetStore := NewExecutionTargetStore(db, util.NewFakeTimeForEpoch())
+	targetStore.CreateExecutionTarget(createExecutionTarget("west"))
+	targetStore.CreateExecutionTarget(createExecutionTarget("east"))
+
+	targets, err := targetStore.ListExecutionTargets()
+	assert.Nil(t, err)
+	assert.Len(t, targets, 2)
+	assert.Equal(t, "east", targets[0].Name)
+	assert.Equal(t, "west", targets[1].Name)
+}
+
+func TestDeleteExecutionTarget(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	targetStore := NewExecutionTargetStore(db, util.NewFakeTimeForEpoch())
+	targetStore.CreateExecutionTarget(createExecutionTarget("east"))
+
+	assert.Nil(t, targetStore.DeleteExecutionTarget("east"))
+	_, err := targetStore.GetExecutionTarget("east")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
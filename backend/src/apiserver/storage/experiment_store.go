@@ -11,10 +11,20 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 )
 
+// ExperimentStoreInterface stores the experiments. The implementations are tested against
+// its contract by storagetest.TestExperimentStore.
 type ExperimentStoreInterface interface {
-	ListExperiments(*common.PaginationContext) ([]model.Experiment, string, error)
+	ListExperiments(*common.FilterContext, *common.PaginationContext) ([]model.Experiment, string, error)
+	// Get the experiment, or fail with NOT_FOUND.
 	GetExperiment(uuid string) (*model.Experiment, error)
+	// Get the experiment with the name, or fail with NOT_FOUND.
+	GetExperimentByName(name string) (*model.Experiment, error)
+	// Create an experiment with a new ID and its name normalized. Fails with INVALID_ARGUMENT if
+	// an experiment has the name.
 	CreateExperiment(*model.Experiment) (*model.Experiment, error)
+	// Get the experiment with the name of the given one, creating it if none has the name. The
+	// concurrent calls with the same name return the same experiment.
+	CreateExperimentIfNotExists(*model.Experiment) (*model.Experiment, error)
 }
 
 type ExperimentStore struct {
@@ -23,16 +33,24 @@ type ExperimentStore struct {
 	uuid util.UUIDGeneratorInterface
 }
 
-func (s *ExperimentStore) ListExperiments(context *common.PaginationContext) ([]model.Experiment, string, error) {
-	models, pageToken, err := listModel(context, s.queryExperimentTable)
+func (s *ExperimentStore) ListExperiments(filterContext *common.FilterContext, context *common.PaginationContext) (
+	[]model.Experiment, string, error) {
+	queryExperimentTable := func(request *common.PaginationContext) ([]model.ListableDataModel, error) {
+		return s.queryExperimentTable(filterContext, request)
+	}
+	models, pageToken, err := listModel(context, queryExperimentTable)
 	if err != nil {
 		return nil, "", util.Wrap(err, "List experiments failed.")
 	}
 	return s.toExperiments(models), pageToken, err
 }
 
-func (s *ExperimentStore) queryExperimentTable(context *common.PaginationContext) ([]model.ListableDataModel, error) {
+func (s *ExperimentStore) queryExperimentTable(filterContext *common.FilterContext, context *common.PaginationContext) (
+	[]model.ListableDataModel, error) {
 	sqlBuilder := sq.Select("*").From("experiments")
+	if filterContext.Name != nil {
+		sqlBuilder = sqlBuilder.Where(s.db.MatchName("Name", filterContext.Name))
+	}
 	sql, args, err := toPaginationQuery(sqlBuilder, context).Limit(uint64(context.PageSize)).ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to list experiments: %v",
@@ -78,6 +96,31 @@ func (s *ExperimentStore) GetExperiment(uuid string) (*model.Experiment, error)
 	return &experiments[0], nil
 }
 
+func (s *ExperimentStore) GetExperimentByName(name string) (*model.Experiment, error) {
+	sql, args, err := sq.
+		Select("*").
+		From("experiments").
+		Where(sq.Eq{"Name": util.NormalizeName(name)}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get experiment: %v", err.Error())
+	}
+	r, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get experiment: %v", err.Error())
+	}
+	defer r.Close()
+	experiments, err := s.scanRows(r)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get experiment: %v", err.Error())
+	}
+	if len(experiments) == 0 {
+		return nil, util.NewResourceNotFoundError("Experiment", name)
+	}
+	return &experiments[0], nil
+}
+
 func (s *ExperimentStore) scanRows(rows *sql.Rows) ([]model.Experiment, error) {
 	var experiments []model.Experiment
 	for rows.Next() {
@@ -98,23 +141,11 @@ func (s *ExperimentStore) scanRows(rows *sql.Rows) ([]model.Experiment, error) {
 }
 
 func (s *ExperimentStore) CreateExperiment(experiment *model.Experiment) (*model.Experiment, error) {
-	newExperiment := *experiment
-	now := s.time.Now().Unix()
-	newExperiment.CreatedAtInSec = now
-	id, err := s.uuid.NewRandom()
+	newExperiment, err := s.newExperiment(experiment)
 	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to create an experiment id.")
+		return nil, err
 	}
-	newExperiment.UUID = id.String()
-	sql, args, err := sq.
-		Insert("experiments").
-		SetMap(
-			sq.Eq{
-				"UUID":           newExperiment.UUID,
-				"CreatedAtInSec": newExperiment.CreatedAtInSec,
-				"Name":           newExperiment.Name,
-				"Description":    newExperiment.Description}).
-		ToSql()
+	sql, args, err := insertExperiment(newExperiment).ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to insert experiment to experiment table: %v",
 			err.Error())
@@ -123,14 +154,64 @@ func (s *ExperimentStore) CreateExperiment(experiment *model.Experiment) (*model
 	if err != nil {
 		if s.db.IsDuplicateError(err) {
 			return nil, util.NewInvalidInputError(
-				"Failed to create a new experiment. The name %v already exist. Please specify a new name.", experiment.Name)
+				"Failed to create a new experiment. The name %v already exist. Please specify a new name.", newExperiment.Name)
 		}
 		return nil, util.NewInternalServerError(err, "Failed to add experiment to experiment table: %v",
 			err.Error())
 	}
+	return newExperiment, nil
+}
+
+// CreateExperimentIfNotExists returns the experiment named like the given one, which it creates
+// if there's none. The insertion skips the row conflicting with an existing experiment, so that
+// the concurrent calls with the same name all return the one experiment created.
+func (s *ExperimentStore) CreateExperimentIfNotExists(experiment *model.Experiment) (*model.Experiment, error) {
+	newExperiment, err := s.newExperiment(experiment)
+	if err != nil {
+		return nil, err
+	}
+	sql, args, err := insertExperiment(newExperiment).Options(s.db.InsertIgnore()).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to insert experiment to experiment table: %v",
+			err.Error())
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to add experiment to experiment table: %v",
+			err.Error())
+	}
+	return s.GetExperimentByName(newExperiment.Name)
+}
+
+// newExperiment returns the experiment to insert, with its name validated and its ID and
+// creation time set.
+func (s *ExperimentStore) newExperiment(experiment *model.Experiment) (*model.Experiment, error) {
+	newExperiment := *experiment
+	name, err := util.ValidateName(experiment.Name, util.MaxNameLength)
+	if err != nil {
+		return nil, util.Wrap(err, "Invalid experiment name.")
+	}
+	newExperiment.Name = name
+	now := s.time.Now().Unix()
+	newExperiment.CreatedAtInSec = now
+	id, err := s.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create an experiment id.")
+	}
+	newExperiment.UUID = id.String()
 	return &newExperiment, nil
 }
 
+func insertExperiment(experiment *model.Experiment) sq.InsertBuilder {
+	return sq.
+		Insert("experiments").
+		SetMap(
+			sq.Eq{
+				"UUID":           experiment.UUID,
+				"CreatedAtInSec": experiment.CreatedAtInSec,
+				"Name":           experiment.Name,
+				"Description":    experiment.Description})
+}
+
 func (s *ExperimentStore) toListableModels(experiments []model.Experiment) []model.ListableDataModel {
 	models := make([]model.ListableDataModel, len(experiments))
 	for i := range models {
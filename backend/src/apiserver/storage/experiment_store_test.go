@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"fmt"
+	"strings"
 
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
@@ -48,7 +49,7 @@ func TestListExperiments_Pagination(t *testing.T) {
 		Description:    "My name is experiment2",
 	}
 	experimentsExpected := []model.Experiment{expectedExperiment1, expectedExperiment4}
-	experiments, nextPageToken, err := experimentStore.ListExperiments(&common.PaginationContext{
+	experiments, nextPageToken, err := experimentStore.ListExperiments(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetExperimentTablePrimaryKeyColumn(),
 		SortByFieldName: "Name",
@@ -72,7 +73,7 @@ func TestListExperiments_Pagination(t *testing.T) {
 	}
 	experimentsExpected2 := []model.Experiment{expectedExperiment2, expectedExperiment3}
 
-	experiments, nextPageToken, err = experimentStore.ListExperiments(
+	experiments, nextPageToken, err = experimentStore.ListExperiments(&common.FilterContext{},
 		&common.PaginationContext{
 			Token: &common.Token{
 				SortByFieldValue: "experiment3",
@@ -113,7 +114,7 @@ func TestListExperiments_Pagination_Descend(t *testing.T) {
 		Description:    "My name is experiment4",
 	}
 	experimentsExpected := []model.Experiment{expectedExperiment3, expectedExperiment2}
-	experiments, nextPageToken, err := experimentStore.ListExperiments(&common.PaginationContext{
+	experiments, nextPageToken, err := experimentStore.ListExperiments(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetExperimentTablePrimaryKeyColumn(),
 		SortByFieldName: "Name",
@@ -136,7 +137,7 @@ func TestListExperiments_Pagination_Descend(t *testing.T) {
 		Description:    "My name is experiment2",
 	}
 	experimentsExpected2 := []model.Experiment{expectedExperiment4, expectedExperiment1}
-	experiments, nextPageToken, err = experimentStore.ListExperiments(
+	experiments, nextPageToken, err = experimentStore.ListExperiments(&common.FilterContext{},
 		&common.PaginationContext{
 			Token: &common.Token{
 				SortByFieldValue: "experiment2",
@@ -165,7 +166,7 @@ func TestListExperiments_Pagination_LessThanPageSize(t *testing.T) {
 	}
 	experimentsExpected := []model.Experiment{expectedExperiment1}
 
-	experiments, nextPageToken, err := experimentStore.ListExperiments(&common.PaginationContext{
+	experiments, nextPageToken, err := experimentStore.ListExperiments(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetExperimentTablePrimaryKeyColumn(),
 		SortByFieldName: model.GetExperimentTablePrimaryKeyColumn(),
@@ -181,7 +182,7 @@ func TestListExperimentsError(t *testing.T) {
 	defer db.Close()
 	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
 	db.Close()
-	_, _, err := experimentStore.ListExperiments(&common.PaginationContext{
+	_, _, err := experimentStore.ListExperiments(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:     2,
 		KeyFieldName: model.GetExperimentTablePrimaryKeyColumn(),
 		IsDesc:       true,
@@ -257,6 +258,78 @@ func TestCreateExperiment_DuplicatedKey(t *testing.T) {
 	assert.Contains(t, err.Error(), "The name experiment1 already exist")
 }
 
+func TestCreateExperimentIfNotExists(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experimentExpected := model.Experiment{
+		UUID:           fakeID,
+		CreatedAtInSec: 1,
+		Name:           "experiment1",
+		Description:    "My name is experiment1",
+	}
+
+	experiment, err := experimentStore.CreateExperimentIfNotExists(createExperiment("experiment1"))
+	assert.Nil(t, err)
+	assert.Equal(t, experimentExpected, *experiment, "Got unexpected experiment.")
+
+	// The existing experiment is returned.
+	experimentStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	experiment, err = experimentStore.CreateExperimentIfNotExists(createExperiment(" experiment1"))
+	assert.Nil(t, err)
+	assert.Equal(t, experimentExpected, *experiment, "Got unexpected experiment.")
+	experiments, _, err := experimentStore.ListExperiments(&common.FilterContext{}, &common.PaginationContext{
+		PageSize:        10,
+		KeyFieldName:    model.GetExperimentTablePrimaryKeyColumn(),
+		SortByFieldName: model.GetExperimentTablePrimaryKeyColumn(),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, experiments, 1)
+}
+
+func TestCreateExperimentIfNotExists_InvalidName(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+
+	_, err := experimentStore.CreateExperimentIfNotExists(createExperiment(" "))
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetExperimentByName_NotFoundError(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+
+	_, err := experimentStore.GetExperimentByName("experiment1")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestCreateExperiment_NormalizedName(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	experiment, err := experimentStore.CreateExperiment(createExperiment(" cafe\u0301 "))
+	assert.Nil(t, err)
+	assert.Equal(t, "caf\u00e9", experiment.Name)
+
+	// The same name, typed composed, is a duplicate.
+	experimentStore = NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil))
+	_, err = experimentStore.CreateExperiment(createExperiment("caf\u00e9"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "The name caf\u00e9 already exist")
+}
+
+func TestCreateExperiment_InvalidName(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	experimentStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	_, err := experimentStore.CreateExperiment(createExperiment(strings.Repeat("a", util.MaxNameLength+1)))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "Invalid experiment name")
+}
+
 func TestCreateExperiment_InternalServerError(t *testing.T) {
 	experiment := &model.Experiment{Name: "Experiment123"}
 	db := NewFakeDbOrFatal()
@@ -0,0 +1,124 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type FavoriteStoreInterface interface {
+	// StarResource adds a pipeline or a run to the favorites of a user. Starring a resource
+	// twice is the same as starring it once.
+	StarResource(userId string, resourceType common.ResourceType, resourceUUID string) error
+	// UnstarResource removes a pipeline or a run from the favorites of a user, if it's there.
+	UnstarResource(userId string, resourceType common.ResourceType, resourceUUID string) error
+	// DeleteFavorites removes a deleted pipeline or run from the favorites of all the users.
+	DeleteFavorites(resourceType common.ResourceType, resourceUUID string) error
+}
+
+type FavoriteStore struct {
+	db   *DB
+	time util.TimeInterface
+}
+
+func (s *FavoriteStore) StarResource(userId string, resourceType common.ResourceType, resourceUUID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to star %v %v", resourceType, resourceUUID)
+	}
+	// The favorite is replaced if it exists, so that starring is idempotent on both MySQL and SQLite.
+	sql, args, err := s.deleteFavorite(userId, resourceType, resourceUUID).ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to create query to star %v %v", resourceType, resourceUUID)
+	}
+	if _, err := tx.Exec(sql, args...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to star %v %v", resourceType, resourceUUID)
+	}
+	sql, args, err = sq.
+		Insert("favorites").
+		SetMap(sq.Eq{
+			"UserID":         userId,
+			"ResourceType":   resourceType,
+			"ResourceUUID":   resourceUUID,
+			"CreatedAtInSec": s.time.Now().Unix()}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to create query to star %v %v", resourceType, resourceUUID)
+	}
+	if _, err := tx.Exec(sql, args...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to star %v %v", resourceType, resourceUUID)
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to commit the star of %v %v", resourceType, resourceUUID)
+	}
+	return nil
+}
+
+func (s *FavoriteStore) UnstarResource(userId string, resourceType common.ResourceType, resourceUUID string) error {
+	sql, args, err := s.deleteFavorite(userId, resourceType, resourceUUID).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to unstar %v %v", resourceType, resourceUUID)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to unstar %v %v", resourceType, resourceUUID)
+	}
+	return nil
+}
+
+func (s *FavoriteStore) DeleteFavorites(resourceType common.ResourceType, resourceUUID string) error {
+	sql, args, err := sq.
+		Delete("favorites").
+		Where(sq.Eq{"ResourceType": resourceType, "ResourceUUID": resourceUUID}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete the favorites of %v %v",
+			resourceType, resourceUUID)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete the favorites of %v %v", resourceType, resourceUUID)
+	}
+	return nil
+}
+
+func (s *FavoriteStore) deleteFavorite(userId string, resourceType common.ResourceType, resourceUUID string) sq.DeleteBuilder {
+	return sq.
+		Delete("favorites").
+		Where(sq.Eq{"UserID": userId, "ResourceType": resourceType, "ResourceUUID": resourceUUID})
+}
+
+// filterStarred filters a query listing pipelines or runs down to the ones starred by a user.
+func filterStarred(selectBuilder sq.SelectBuilder, userId string, resourceType common.ResourceType) (
+	sq.SelectBuilder, error) {
+	sql, args, err := sq.
+		Select("ResourceUUID").
+		From("favorites").
+		Where(sq.Eq{"UserID": userId, "ResourceType": resourceType}).
+		ToSql()
+	if err != nil {
+		return selectBuilder, util.NewInternalServerError(err, "Failed to append starred filter: %v", err.Error())
+	}
+	return selectBuilder.Where("UUID IN ("+sql+")", args...), nil
+}
+
+// factory function for favorite store
+func NewFavoriteStore(db *DB, time util.TimeInterface) *FavoriteStore {
+	return &FavoriteStore{db: db, time: time}
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFavoriteStore_ListStarredPipelines(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewSequentialFakeUUIDGenerator())
+	var pipelineIds []string
+	for _, name := range []string{"pipeline1", "pipeline2", "pipeline3"} {
+		pipeline, err := pipelineStore.CreatePipeline(createPipeline(name))
+		assert.Nil(t, err)
+		pipelineIds = append(pipelineIds, pipeline.UUID)
+	}
+	store := NewFavoriteStore(db, util.NewFakeTimeForEpoch())
+	assert.Nil(t, store.StarResource("alice", common.Pipeline, pipelineIds[0]))
+	assert.Nil(t, store.StarResource("alice", common.Pipeline, pipelineIds[2]))
+	// Starring twice is the same as starring once.
+	assert.Nil(t, store.StarResource("alice", common.Pipeline, pipelineIds[2]))
+	assert.Nil(t, store.StarResource("bob", common.Pipeline, pipelineIds[1]))
+
+	listStarred := func(userId string) []string {
+		pipelines, _, err := pipelineStore.ListPipelines(&common.FilterContext{StarredBy: userId}, &common.PaginationContext{
+			PageSize:        10,
+			KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
+			SortByFieldName: model.GetPipelineTablePrimaryKeyColumn(),
+		})
+		assert.Nil(t, err)
+		var names []string
+		for _, pipeline := range pipelines {
+			names = append(names, pipeline.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"pipeline1", "pipeline3"}, listStarred("alice"))
+	assert.Equal(t, []string{"pipeline2"}, listStarred("bob"))
+
+	assert.Nil(t, store.UnstarResource("alice", common.Pipeline, pipelineIds[0]))
+	// Unstarring a resource which isn't starred does nothing.
+	assert.Nil(t, store.UnstarResource("alice", common.Pipeline, pipelineIds[1]))
+	assert.Equal(t, []string{"pipeline3"}, listStarred("alice"))
+
+	assert.Nil(t, store.DeleteFavorites(common.Pipeline, pipelineIds[1]))
+	assert.Empty(t, listStarred("bob"))
+}
+
+func TestFavoriteStore_ListStarredRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	store := NewFavoriteStore(db, util.NewFakeTimeForEpoch())
+	assert.Nil(t, store.StarResource("alice", common.Run, "2"))
+	// The favorites of the pipelines aren't mixed with the ones of the runs.
+	assert.Nil(t, store.StarResource("alice", common.Pipeline, "3"))
+
+	runs, _, err := runStore.ListRuns(&common.FilterContext{StarredBy: "alice"}, &common.PaginationContext{
+		PageSize:        10,
+		KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
+		SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
+	}, common.BasicView)
+	assert.Nil(t, err)
+	if assert.Len(t, runs, 1) {
+		assert.Equal(t, "2", runs[0].UUID)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var gitSyncedPipelineColumns = []string{"Path", "PipelineUUID", "CommitSHA", "ContentHash", "SyncedAtInSec"}
+
+type GitSyncStoreInterface interface {
+	ListSyncedPipelines() ([]*model.GitSyncedPipeline, error)
+	// PutSyncedPipeline creates or replaces the record of the pipeline synced from a path.
+	PutSyncedPipeline(*model.GitSyncedPipeline) error
+	DeleteSyncedPipeline(path string) error
+}
+
+type GitSyncStore struct {
+	db *DB
+}
+
+func (s *GitSyncStore) ListSyncedPipelines() ([]*model.GitSyncedPipeline, error) {
+	sql, args, err := sq.Select(gitSyncedPipelineColumns...).From("git_synced_pipelines").OrderBy("Path").ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list synced pipelines: %v", err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list synced pipelines: %v", err.Error())
+	}
+	defer rows.Close()
+	pipelines, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse synced pipelines: %v", err.Error())
+	}
+	return pipelines, nil
+}
+
+func (s *GitSyncStore) PutSyncedPipeline(pipeline *model.GitSyncedPipeline) error {
+	deleteSql, deleteArgs, err := sq.Delete("git_synced_pipelines").Where(sq.Eq{"Path": pipeline.Path}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to replace synced pipeline %v", pipeline.Path)
+	}
+	insertSql, insertArgs, err := sq.
+		Insert("git_synced_pipelines").
+		SetMap(sq.Eq{
+			"Path":          pipeline.Path,
+			"PipelineUUID":  pipeline.PipelineUUID,
+			"CommitSHA":     pipeline.CommitSHA,
+			"ContentHash":   pipeline.ContentHash,
+			"SyncedAtInSec": pipeline.SyncedAtInSec}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store synced pipeline %v", pipeline.Path)
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to store synced pipeline %v", pipeline.Path)
+	}
+	if _, err = tx.Exec(deleteSql, deleteArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to replace synced pipeline %v", pipeline.Path)
+	}
+	if _, err = tx.Exec(insertSql, insertArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store synced pipeline %v", pipeline.Path)
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store synced pipeline %v", pipeline.Path)
+	}
+	return nil
+}
+
+func (s *GitSyncStore) DeleteSyncedPipeline(path string) error {
+	sql, args, err := sq.Delete("git_synced_pipelines").Where(sq.Eq{"Path": path}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete synced pipeline: %v", err.Error())
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete synced pipeline: %v", err.Error())
+	}
+	return nil
+}
+
+func (s *GitSyncStore) scanRows(rows *sql.Rows) ([]*model.GitSyncedPipeline, error) {
+	var pipelines []*model.GitSyncedPipeline
+	for rows.Next() {
+		var pipeline model.GitSyncedPipeline
+		if err := rows.Scan(&pipeline.Path, &pipeline.PipelineUUID, &pipeline.CommitSHA, &pipeline.ContentHash,
+			&pipeline.SyncedAtInSec); err != nil {
+			return pipelines, err
+		}
+		pipelines = append(pipelines, &pipeline)
+	}
+	return pipelines, nil
+}
+
+// factory function for git sync store
+func NewGitSyncStore(db *DB) *GitSyncStore {
+	return &GitSyncStore{db: db}
+}
@@ -0,0 +1,44 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitSyncStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewGitSyncStore(db)
+
+	train := &model.GitSyncedPipeline{Path: "pipelines/train.yaml", PipelineUUID: fakeID, CommitSHA: "c1", ContentHash: "h1"}
+	serve := &model.GitSyncedPipeline{Path: "pipelines/serve.yaml", PipelineUUID: fakeIDTwo, CommitSHA: "c1", ContentHash: "h2"}
+	assert.Nil(t, store.PutSyncedPipeline(train))
+	assert.Nil(t, store.PutSyncedPipeline(serve))
+	pipelines, err := store.ListSyncedPipelines()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.GitSyncedPipeline{serve, train}, pipelines)
+
+	// Putting a path again replaces its record.
+	newTrain := &model.GitSyncedPipeline{Path: "pipelines/train.yaml", PipelineUUID: fakeIDThree, CommitSHA: "c2", ContentHash: "h3"}
+	assert.Nil(t, store.PutSyncedPipeline(newTrain))
+	assert.Nil(t, store.DeleteSyncedPipeline("pipelines/serve.yaml"))
+	pipelines, err = store.ListSyncedPipelines()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.GitSyncedPipeline{newTrain}, pipelines)
+}
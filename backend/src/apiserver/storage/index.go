@@ -0,0 +1,72 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// Index is a secondary index of a table.
+type Index struct {
+	Table   string
+	Name    string
+	Columns []string
+}
+
+// ListIndexes are the composite indexes matching the filters and the sort orders of the list
+// APIs. A page of a list is ordered by the sort field, then by the primary key.
+var ListIndexes = []Index{
+	{Table: "run_details", Name: "idx_run_details_created_at", Columns: []string{"CreatedAtInSec", "UUID"}},
+	{Table: "run_details", Name: "idx_run_details_display_name", Columns: []string{"DisplayName", "UUID"}},
+	{Table: "jobs", Name: "idx_jobs_created_at", Columns: []string{"CreatedAtInSec", "UUID"}},
+	{Table: "jobs", Name: "idx_jobs_display_name", Columns: []string{"DisplayName", "UUID"}},
+	{Table: "jobs", Name: "idx_jobs_pipeline_id", Columns: []string{"PipelineId", "UUID"}},
+	{Table: "experiments", Name: "idx_experiments_created_at", Columns: []string{"CreatedAtInSec", "UUID"}},
+	{Table: "experiments", Name: "idx_experiments_name", Columns: []string{"Name", "UUID"}},
+	{Table: "pipelines", Name: "idx_pipelines_created_at", Columns: []string{"CreatedAtInSec", "UUID"}},
+	{Table: "pipelines", Name: "idx_pipelines_name", Columns: []string{"Name", "UUID"}},
+	{Table: "webhooks", Name: "idx_webhooks_created_at", Columns: []string{"CreatedAtInSec", "UUID"}},
+	{Table: "webhooks", Name: "idx_webhooks_name", Columns: []string{"Name", "UUID"}},
+	{Table: "model_versions", Name: "idx_model_versions_created_at", Columns: []string{"CreatedAtInSec", "UUID"}},
+	{Table: "model_versions", Name: "idx_model_versions_model_name", Columns: []string{"ModelName", "UUID"}},
+	{Table: "model_versions", Name: "idx_model_versions_version", Columns: []string{"Version", "UUID"}},
+	// The runs are filtered by their annotations.
+	{Table: "run_annotations", Name: "idx_run_annotations_name", Columns: []string{"Name", "Value", "RunUUID"}},
+	// The runs and the jobs of an experiment are listed through their references to it.
+	{Table: "resource_references", Name: "idx_resource_references_reference",
+		Columns: []string{"ReferenceUUID", "ReferenceType", "ResourceType", "ResourceUUID"}},
+}
+
+// CreateIndexes creates the indexes which don't exist yet.
+func CreateIndexes(db *gorm.DB, indexes []Index) error {
+	for _, index := range indexes {
+		if err := db.Table(index.Table).AddIndex(index.Name, index.Columns...).Error; err != nil {
+			return util.NewInternalServerError(err, "Failed to create index %v of table %v", index.Name, index.Table)
+		}
+	}
+	return nil
+}
+
+// MissingIndexes returns the indexes which don't exist.
+func MissingIndexes(db *gorm.DB, indexes []Index) []Index {
+	var missing []Index
+	for _, index := range indexes {
+		if !db.Dialect().HasIndex(index.Table, index.Name) {
+			missing = append(missing, index)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIndexes(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+	db.AutoMigrate(&model.RunDetail{}, &model.ResourceReference{})
+	indexes := []Index{
+		{Table: "run_details", Name: "idx_run_details_created_at", Columns: []string{"CreatedAtInSec", "UUID"}},
+		{Table: "resource_references", Name: "idx_resource_references_reference",
+			Columns: []string{"ReferenceUUID", "ReferenceType", "ResourceType", "ResourceUUID"}},
+	}
+	assert.Equal(t, indexes, MissingIndexes(db, indexes))
+
+	assert.Nil(t, CreateIndexes(db, indexes))
+	assert.Empty(t, MissingIndexes(db, indexes))
+	// Existing indexes are skipped.
+	assert.Nil(t, CreateIndexes(db, indexes))
+}
+
+func TestCreateIndexes_ListIndexes(t *testing.T) {
+	db, err := newFakeGormDb()
+	assert.Nil(t, err)
+	defer db.Close()
+
+	assert.Empty(t, MissingIndexes(db, ListIndexes))
+}
+
+func TestCreateIndexes_UnknownTable(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	err = CreateIndexes(db, []Index{{Table: "unknown", Name: "idx_unknown", Columns: []string{"UUID"}}})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "idx_unknown")
+}
@@ -24,12 +24,19 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 )
 
+// JobStoreInterface stores the jobs and their resource references. The implementations are
+// tested against its contract by storagetest.TestJobStore.
 type JobStoreInterface interface {
-	ListJobs(filterContext *common.FilterContext, paginationContext *common.PaginationContext) ([]model.Job, string, error)
+	// List the jobs. The manifests of their pipeline specs are only loaded with the full view.
+	ListJobs(filterContext *common.FilterContext, paginationContext *common.PaginationContext,
+		view common.ListView) ([]model.Job, string, error)
+	// Get the job, or fail with NOT_FOUND.
 	GetJob(id string) (*model.Job, error)
 	CreateJob(*model.Job) (*model.Job, error)
 	DeleteJob(id string) error
 	EnableJob(id string, enabled bool) error
+	// Update the job from the status of its scheduled workflow, or fail with INVALID_ARGUMENT
+	// if the job doesn't exist.
 	UpdateJob(swf *util.ScheduledWorkflow) error
 }
 
@@ -39,10 +46,10 @@ type JobStore struct {
 	time                   util.TimeInterface
 }
 
-func (s *JobStore) ListJobs(
-	filterContext *common.FilterContext, paginationContext *common.PaginationContext) ([]model.Job, string, error) {
+func (s *JobStore) ListJobs(filterContext *common.FilterContext, paginationContext *common.PaginationContext,
+	view common.ListView) ([]model.Job, string, error) {
 	queryJobTable := func(request *common.PaginationContext) ([]model.ListableDataModel, error) {
-		return s.queryJobTable(filterContext, request)
+		return s.queryJobTable(filterContext, request, view)
 	}
 	models, pageToken, err := listModel(paginationContext, queryJobTable)
 	if err != nil {
@@ -51,9 +58,9 @@ func (s *JobStore) ListJobs(
 	return s.toJobMetadatas(models), pageToken, err
 }
 
-func (s *JobStore) queryJobTable(
-	filterContext *common.FilterContext, paginationContext *common.PaginationContext) ([]model.ListableDataModel, error) {
-	sqlBuilder := s.selectJob()
+func (s *JobStore) queryJobTable(filterContext *common.FilterContext, paginationContext *common.PaginationContext,
+	view common.ListView) ([]model.ListableDataModel, error) {
+	sqlBuilder := s.selectJobsForList(view)
 
 	// Add filter condition
 	sqlBuilder, err := s.toFilteredQuery(sqlBuilder, filterContext)
@@ -67,13 +74,7 @@ func (s *JobStore) queryJobTable(
 		return nil, util.NewInternalServerError(err, "Failed to create query to list jobs: %v",
 			err.Error())
 	}
-	rows, err := s.db.Query(sql, args...)
-	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to list jobs: %v",
-			err.Error())
-	}
-	defer rows.Close()
-	jobs, err := s.scanRows(rows)
+	jobs, err := s.queryJobs(sql, args)
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to list jobs: %v",
 			err.Error())
@@ -82,6 +83,9 @@ func (s *JobStore) queryJobTable(
 }
 
 func (s *JobStore) toFilteredQuery(selectBuilder sq.SelectBuilder, filterContext *common.FilterContext) (sq.SelectBuilder, error) {
+	if filterContext.Name != nil {
+		selectBuilder = selectBuilder.Where(s.db.MatchName("DisplayName", filterContext.Name))
+	}
 	sql, args, err := selectBuilder.ToSql()
 	if err != nil {
 		return selectBuilder, util.NewInternalServerError(err, "Failed to append filter condition to list job: %v",
@@ -99,7 +103,7 @@ func (s *JobStore) toFilteredQuery(selectBuilder sq.SelectBuilder, filterContext
 }
 
 func (s *JobStore) GetJob(id string) (*model.Job, error) {
-	sql, args, err := s.selectJob().
+	sql, args, err := s.selectJobsForList(common.FullView).
 		Where(sq.Eq{"uuid": id}).
 		Limit(1).
 		ToSql()
@@ -107,13 +111,7 @@ func (s *JobStore) GetJob(id string) (*model.Job, error) {
 		return nil, util.NewInternalServerError(err, "Failed to create query to get job: %v",
 			err.Error())
 	}
-	row, err := s.db.Query(sql, args...)
-	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to get job: %v",
-			err.Error())
-	}
-	defer row.Close()
-	jobs, err := s.scanRows(row)
+	jobs, err := s.queryJobs(sql, args)
 	if err != nil || len(jobs) > 1 {
 		return nil, util.NewInternalServerError(err, "Failed to get job: %v", err.Error())
 	}
@@ -123,24 +121,59 @@ func (s *JobStore) GetJob(id string) (*model.Job, error) {
 	return &jobs[0], nil
 }
 
-func (s *JobStore) selectJob() sq.SelectBuilder {
-	resourceRefConcatQuery := s.db.Concat([]string{`"["`, s.db.GroupConcat("r.Payload", ","), `"]"`}, "")
+// selectJobsForList selects the columns of jobs a list returns. The manifests of the pipeline
+// specs are only returned with the full view, and are otherwise selected as empty strings. The
+// columns are selected by name, since the ones added by a migration come last in the table.
+func (s *JobStore) selectJobsForList(view common.ListView) sq.SelectBuilder {
+	pipelineSpecManifest, workflowSpecManifest := "PipelineSpecManifest", "WorkflowSpecManifest"
+	if view != common.FullView {
+		pipelineSpecManifest, workflowSpecManifest = "'' AS PipelineSpecManifest", "'' AS WorkflowSpecManifest"
+	}
 	return sq.
-		Select("jobs.*", resourceRefConcatQuery+" AS refs").
-		From("jobs").
-		// Append all the resource references for the run as a json column
-		LeftJoin("resource_references AS r ON jobs.UUID=r.ResourceUUID").
-		Where(sq.Eq{"r.ResourceType": common.Job}).GroupBy("jobs.UUID")
+		Select("UUID", "DisplayName", "Name", "Namespace", "Description", "MaxConcurrency", "CreatedAtInSec",
+			"UpdatedAtInSec", "Enabled", "CronScheduleStartTimeInSec", "CronScheduleEndTimeInSec", "Schedule",
+			"PeriodicScheduleStartTimeInSec", "PeriodicScheduleEndTimeInSec", "IntervalSecond",
+			"PipelineId", pipelineSpecManifest, workflowSpecManifest, "Parameters", "Conditions").
+		From("jobs")
+}
+
+// queryJobs runs a query selecting from jobs, then loads the resource references of the selected
+// jobs with a single query for the whole page.
+func (s *JobStore) queryJobs(sql string, args []interface{}) ([]model.Job, error) {
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.scanRows(rows)
+	// Release the connection before loading the resource references.
+	rows.Close()
+	if err != nil || len(jobs) == 0 {
+		return jobs, err
+	}
+	jobIds := make([]string, len(jobs))
+	for i := range jobs {
+		jobIds[i] = jobs[i].UUID
+	}
+	resourceReferences, err := s.resourceReferenceStore.ListResourceReferences(jobIds, common.Job)
+	if err != nil {
+		return nil, err
+	}
+	for i := range jobs {
+		jobs[i].ResourceReferences = resourceReferences[jobs[i].UUID]
+	}
+	return jobs, nil
 }
 
 func (s *JobStore) scanRows(r *sql.Rows) ([]model.Job, error) {
 	var jobs []model.Job
 	for r.Next() {
 		var uuid, displayName, name, namespace, pipelineId, conditions,
-			description, parameters, pipelineSpecManifest, workflowSpecManifest string
+			description, workflowSpecManifest string
+		// The columns are NULL in the rows of a previous release which didn't have them.
+		var parameters, pipelineSpecManifest sql.NullString
 		var cronScheduleStartTimeInSec, cronScheduleEndTimeInSec,
 			periodicScheduleStartTimeInSec, periodicScheduleEndTimeInSec, intervalSecond sql.NullInt64
-		var cron, resourceReferencesInString sql.NullString
+		var cron sql.NullString
 		var enabled bool
 		var createdAtInSec, updatedAtInSec, maxConcurrency int64
 		err := r.Scan(
@@ -148,21 +181,19 @@ func (s *JobStore) scanRows(r *sql.Rows) ([]model.Job, error) {
 			&maxConcurrency, &createdAtInSec, &updatedAtInSec, &enabled,
 			&cronScheduleStartTimeInSec, &cronScheduleEndTimeInSec, &cron,
 			&periodicScheduleStartTimeInSec, &periodicScheduleEndTimeInSec, &intervalSecond,
-			&pipelineId, &pipelineSpecManifest, &workflowSpecManifest, &parameters, &conditions, &resourceReferencesInString)
+			&pipelineId, &pipelineSpecManifest, &workflowSpecManifest, &parameters, &conditions)
 		if err != nil {
 			return nil, err
 		}
-		resourceReferences, err := parseResourceReferences(resourceReferencesInString)
 		jobs = append(jobs, model.Job{
-			UUID:               uuid,
-			DisplayName:        displayName,
-			Name:               name,
-			Namespace:          namespace,
-			Description:        description,
-			Enabled:            enabled,
-			Conditions:         conditions,
-			MaxConcurrency:     maxConcurrency,
-			ResourceReferences: resourceReferences,
+			UUID:           uuid,
+			DisplayName:    displayName,
+			Name:           name,
+			Namespace:      namespace,
+			Description:    description,
+			Enabled:        enabled,
+			Conditions:     conditions,
+			MaxConcurrency: maxConcurrency,
 			Trigger: model.Trigger{
 				CronSchedule: model.CronSchedule{
 					CronScheduleStartTimeInSec: NullInt64ToPointer(cronScheduleStartTimeInSec),
@@ -177,9 +208,9 @@ func (s *JobStore) scanRows(r *sql.Rows) ([]model.Job, error) {
 			},
 			PipelineSpec: model.PipelineSpec{
 				PipelineId:           pipelineId,
-				PipelineSpecManifest: pipelineSpecManifest,
+				PipelineSpecManifest: pipelineSpecManifest.String,
 				WorkflowSpecManifest: workflowSpecManifest,
-				Parameters:           parameters,
+				Parameters:           parameters.String,
 			},
 			CreatedAtInSec: createdAtInSec,
 			UpdatedAtInSec: updatedAtInSec,
@@ -15,6 +15,7 @@
 package storage
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -138,7 +139,7 @@ func TestListJobs_Pagination(t *testing.T) {
 			KeyFieldName:    "Name",
 			SortByFieldName: "Name",
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.NotEmpty(t, nextPageToken)
 	assert.Equal(t, jobsExpected, jobs)
@@ -181,7 +182,7 @@ func TestListJobs_Pagination(t *testing.T) {
 			KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
 			SortByFieldName: "Name",
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, "", newToken)
 	assert.Equal(t, jobsExpected2, jobs)
@@ -226,7 +227,7 @@ func TestListJobs_Pagination_Descent(t *testing.T) {
 			KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
 			SortByFieldName: "Name",
 			IsDesc:          true,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.NotEmpty(t, nextPageToken)
 	assert.Equal(t, jobsExpected, jobs)
@@ -269,7 +270,7 @@ func TestListJobs_Pagination_Descent(t *testing.T) {
 			KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
 			SortByFieldName: "Name",
 			IsDesc:          true,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, "", newToken)
 	assert.Equal(t, jobsExpected2, jobs)
@@ -341,7 +342,7 @@ func TestListJobs_Pagination_LessThanPageSize(t *testing.T) {
 			KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
 			SortByFieldName: "Name",
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, "", nextPageToken)
 	assert.Equal(t, jobsExpected, jobs)
@@ -386,12 +387,59 @@ func TestListJobs_FilterByReferenceKey(t *testing.T) {
 			KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
 			SortByFieldName: "Name",
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, "", nextPageToken)
 	assert.Equal(t, jobsExpected, jobs)
 }
 
+func TestListJobs_ManifestsOnlyInFullView(t *testing.T) {
+	db, jobStore := initializeDbAndStore()
+	defer db.Close()
+	_, err := jobStore.CreateJob(&model.Job{
+		UUID:    "3",
+		Name:    "pp3",
+		Enabled: true,
+		PipelineSpec: model.PipelineSpec{
+			PipelineSpecManifest: "pipeline spec",
+			WorkflowSpecManifest: "workflow spec",
+		},
+		CreatedAtInSec: 3,
+		UpdatedAtInSec: 3,
+		ResourceReferences: []*model.ResourceReference{
+			{
+				ResourceUUID: "3", ResourceType: common.Job,
+				ReferenceUUID: defaultFakeExpId, ReferenceType: common.Experiment,
+				Relationship: common.Owner,
+			},
+		},
+	})
+	assert.Nil(t, err)
+	paginationContext := &common.PaginationContext{
+		PageSize:        1,
+		KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
+		SortByFieldName: "CreatedAtInSec",
+		IsDesc:          true,
+	}
+
+	jobs, _, err := jobStore.ListJobs(&common.FilterContext{}, paginationContext, common.BasicView)
+	assert.Nil(t, err)
+	assert.Equal(t, "3", jobs[0].UUID)
+	assert.Equal(t, model.PipelineSpec{}, jobs[0].PipelineSpec)
+	assert.Len(t, jobs[0].ResourceReferences, 1)
+
+	jobs, _, err = jobStore.ListJobs(&common.FilterContext{}, paginationContext, common.FullView)
+	assert.Nil(t, err)
+	assert.Equal(t, model.PipelineSpec{
+		PipelineSpecManifest: "pipeline spec",
+		WorkflowSpecManifest: "workflow spec",
+	}, jobs[0].PipelineSpec)
+
+	job, err := jobStore.GetJob("3")
+	assert.Nil(t, err)
+	assert.Equal(t, "workflow spec", job.WorkflowSpecManifest)
+}
+
 func TestListJobsError(t *testing.T) {
 	db, jobStore := initializeDbAndStore()
 	defer db.Close()
@@ -404,7 +452,7 @@ func TestListJobsError(t *testing.T) {
 			KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
 			SortByFieldName: model.GetJobTablePrimaryKeyColumn(),
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode(),
 		"Expected to list job to return error")
 }
@@ -912,3 +960,59 @@ func TestDeleteJob(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func initializeJobStoreForBenchmark(b *testing.B) (*DB, *JobStore) {
+	db := NewFakeDbOrFatal()
+	expStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakeExpId, nil))
+	expStore.CreateExperiment(&model.Experiment{Name: "exp1"})
+	jobStore := NewJobStore(db, util.NewFakeTimeForEpoch())
+	for i := 0; i < benchmarkRowCount; i++ {
+		uuid := fmt.Sprintf("job-%05d", i)
+		_, err := jobStore.CreateJob(&model.Job{
+			UUID:    uuid,
+			Name:    uuid,
+			Enabled: true,
+			Trigger: model.Trigger{
+				PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60)},
+			},
+			CreatedAtInSec: int64(i),
+			UpdatedAtInSec: int64(i),
+			ResourceReferences: []*model.ResourceReference{
+				{
+					ResourceUUID: uuid, ResourceType: common.Job,
+					ReferenceUUID: defaultFakeExpId, ReferenceType: common.Experiment,
+					Relationship: common.Owner,
+				},
+			},
+		})
+		if err != nil {
+			b.Fatalf("Failed to create job %v: %v", uuid, err)
+		}
+	}
+	return db, jobStore
+}
+
+func BenchmarkListJobs(b *testing.B) {
+	db, jobStore := initializeJobStoreForBenchmark(b)
+	defer db.Close()
+
+	filterContexts := map[string]*common.FilterContext{
+		"All":          {},
+		"ByExperiment": {ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpId}},
+	}
+	for name, filterContext := range filterContexts {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				jobs, _, err := jobStore.ListJobs(filterContext, &common.PaginationContext{
+					PageSize:        100,
+					KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
+					SortByFieldName: "CreatedAtInSec",
+					IsDesc:          true,
+				}, common.BasicView)
+				if err != nil || len(jobs) != 100 {
+					b.Fatalf("Failed to list jobs. Got %v jobs and error: %v", len(jobs), err)
+				}
+			}
+		})
+	}
+}
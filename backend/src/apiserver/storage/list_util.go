@@ -43,7 +43,7 @@ func listModel(context *common.PaginationContext, queryTable QueryListableModelT
 	if len(results) < newContext.PageSize {
 		return results, "", nil
 	}
-	tokenString, err := toNextPageToken(context.SortByFieldName, results[context.PageSize])
+	tokenString, err := toNextPageToken(context, results[context.PageSize])
 	if err != nil {
 		return nil, "", util.Wrap(err, "Failed to create page token")
 	}
@@ -51,10 +51,14 @@ func listModel(context *common.PaginationContext, queryTable QueryListableModelT
 }
 
 // Generate page token given the first model to be listed in the next page.
-func toNextPageToken(sortByFieldName string, model model.ListableDataModel) (string, error) {
+func toNextPageToken(context *common.PaginationContext, model model.ListableDataModel) (string, error) {
 	newToken := common.Token{
-		SortByFieldValue: fmt.Sprint(reflect.ValueOf(model).FieldByName(sortByFieldName)),
+		SortByFieldValue: fmt.Sprint(reflect.ValueOf(model).FieldByName(context.SortByFieldName)),
 		KeyFieldValue:    model.GetValueOfPrimaryKey(),
+		SortByFieldName:  context.SortByFieldName,
+		IsDesc:           context.IsDesc,
+		Version:          common.TokenVersion,
+		ExpiresAtInSec:   context.TokenExpiresAtInSec,
 	}
 
 	tokenBytes, err := json.Marshal(newToken)
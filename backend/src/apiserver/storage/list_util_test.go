@@ -55,7 +55,7 @@ func TestList(t *testing.T) {
 	models, token, err := listModel(request, fooListInternal)
 	assert.Nil(t, err)
 	assert.Equal(t, []model.ListableDataModel{FakeListableModel{Name: "a_name", Author: "a_author"}}, models)
-	expectedToken, err := toNextPageToken("name", FakeListableModel{Name: "b_name", Author: "b_author"})
+	expectedToken, err := toNextPageToken(request, FakeListableModel{Name: "b_name", Author: "b_author"})
 	assert.Nil(t, err)
 	assert.Equal(t, expectedToken, token)
 }
@@ -78,11 +78,16 @@ func TestList_ListInternalError(t *testing.T) {
 
 func TestToNextPageToken(t *testing.T) {
 	model := FakeListableModel{Name: "foo", Author: "bar"}
-	token, err := toNextPageToken("Author", model)
+	token, err := toNextPageToken(
+		&common.PaginationContext{SortByFieldName: "Author", IsDesc: true, TokenExpiresAtInSec: 86400}, model)
 	assert.Nil(t, err)
 	expectedJson, _ := json.Marshal(common.Token{
 		SortByFieldValue: "bar",
 		KeyFieldValue:    "foo",
+		SortByFieldName:  "Author",
+		IsDesc:           true,
+		Version:          common.TokenVersion,
+		ExpiresAtInSec:   86400,
 	})
 	assert.Equal(t, base64.StdEncoding.EncodeToString(expectedJson), token)
 }
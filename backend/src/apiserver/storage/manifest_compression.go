@@ -0,0 +1,93 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The prefix of the manifests stored gzipped, then base64 encoded so that they still fit the
+// text columns. The manifests stored before they were compressed are read as they are.
+const compressedManifestPrefix = "gzip:"
+
+// compressManifest returns the value a manifest is stored as. The manifest is compressed,
+// unless it's too small for the compression to save space. The manifests which could be
+// mistaken for compressed ones are always compressed.
+func compressManifest(manifest string) (string, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(manifest)); err != nil {
+		return "", util.NewInternalServerError(err, "Failed to compress the manifest")
+	}
+	if err := writer.Close(); err != nil {
+		return "", util.NewInternalServerError(err, "Failed to compress the manifest")
+	}
+	compressed := compressedManifestPrefix + base64.StdEncoding.EncodeToString(buffer.Bytes())
+	if len(compressed) >= len(manifest) && !strings.HasPrefix(manifest, compressedManifestPrefix) {
+		return manifest, nil
+	}
+	return compressed, nil
+}
+
+// decompressManifest returns the manifest stored as the value, compressed or not.
+func decompressManifest(value string) (string, error) {
+	if !strings.HasPrefix(value, compressedManifestPrefix) {
+		return value, nil
+	}
+	compressed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, compressedManifestPrefix))
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to decode the compressed manifest")
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to decompress the manifest")
+	}
+	defer reader.Close()
+	manifest, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return "", util.NewInternalServerError(err, "Failed to decompress the manifest")
+	}
+	return string(manifest), nil
+}
+
+// compressManifests replaces each of the manifests with the value it's stored as.
+func compressManifests(manifests ...*string) error {
+	for _, manifest := range manifests {
+		value, err := compressManifest(*manifest)
+		if err != nil {
+			return err
+		}
+		*manifest = value
+	}
+	return nil
+}
+
+// decompressManifests replaces each of the stored values with the manifest it holds.
+func decompressManifests(values ...*string) error {
+	for _, value := range values {
+		manifest, err := decompressManifest(*value)
+		if err != nil {
+			return err
+		}
+		*value = manifest
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressManifest(t *testing.T) {
+	manifest := strings.Repeat(`{"name": "step", "image": "gcr.io/ml-pipeline/step"}`, 100)
+
+	stored, err := compressManifest(manifest)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(stored, compressedManifestPrefix))
+	assert.True(t, len(stored) < len(manifest)/10)
+
+	decompressed, err := decompressManifest(stored)
+	assert.Nil(t, err)
+	assert.Equal(t, manifest, decompressed)
+}
+
+func TestCompressManifest_SmallManifest(t *testing.T) {
+	stored, err := compressManifest("workflow1")
+	assert.Nil(t, err)
+	assert.Equal(t, "workflow1", stored)
+
+	stored, err = compressManifest("")
+	assert.Nil(t, err)
+	assert.Equal(t, "", stored)
+}
+
+func TestCompressManifest_ManifestWithPrefix(t *testing.T) {
+	stored, err := compressManifest("gzip: true")
+	assert.Nil(t, err)
+	assert.NotEqual(t, "gzip: true", stored)
+
+	decompressed, err := decompressManifest(stored)
+	assert.Nil(t, err)
+	assert.Equal(t, "gzip: true", decompressed)
+}
+
+func TestDecompressManifest_Uncompressed(t *testing.T) {
+	manifest, err := decompressManifest(`{"kind": "Workflow"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"kind": "Workflow"}`, manifest)
+}
+
+func TestDecompressManifest_Corrupted(t *testing.T) {
+	_, err := decompressManifest(compressedManifestPrefix + "not base64!")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type MetricsPushTokenStoreInterface interface {
+	// CreateToken stores the hash of the token issued for a run or a job.
+	CreateToken(resourceUUID string, resourceType common.ResourceType, token string) error
+	// VerifyToken returns whether a token is the one issued for a run or a job.
+	VerifyToken(resourceUUID string, token string) (bool, error)
+	DeleteToken(resourceUUID string) error
+}
+
+type MetricsPushTokenStore struct {
+	db   *DB
+	time util.TimeInterface
+}
+
+func (s *MetricsPushTokenStore) CreateToken(resourceUUID string, resourceType common.ResourceType, token string) error {
+	sql, args, err := sq.
+		Insert("metrics_push_tokens").
+		SetMap(sq.Eq{
+			"ResourceUUID":   resourceUUID,
+			"ResourceType":   resourceType,
+			"TokenHash":      hashMetricsPushToken(token),
+			"CreatedAtInSec": s.time.Now().Unix()}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the metrics push token of %v", resourceUUID)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the metrics push token of %v", resourceUUID)
+	}
+	return nil
+}
+
+func (s *MetricsPushTokenStore) VerifyToken(resourceUUID string, token string) (bool, error) {
+	sql, args, err := sq.Select("TokenHash").From("metrics_push_tokens").Where(sq.Eq{"ResourceUUID": resourceUUID}).ToSql()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to create query to get the metrics push token of %v", resourceUUID)
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to get the metrics push token of %v", resourceUUID)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return false, nil
+	}
+	var tokenHash string
+	if err := rows.Scan(&tokenHash); err != nil {
+		return false, util.NewInternalServerError(err, "Failed to parse the metrics push token of %v", resourceUUID)
+	}
+	return subtle.ConstantTimeCompare([]byte(tokenHash), []byte(hashMetricsPushToken(token))) == 1, nil
+}
+
+func (s *MetricsPushTokenStore) DeleteToken(resourceUUID string) error {
+	sql, args, err := sq.Delete("metrics_push_tokens").Where(sq.Eq{"ResourceUUID": resourceUUID}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete the metrics push token of %v", resourceUUID)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete the metrics push token of %v", resourceUUID)
+	}
+	return nil
+}
+
+func hashMetricsPushToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// factory function for metrics push token store
+func NewMetricsPushTokenStore(db *DB, time util.TimeInterface) *MetricsPushTokenStore {
+	return &MetricsPushTokenStore{db: db, time: time}
+}
@@ -0,0 +1,47 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsPushTokenStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewMetricsPushTokenStore(db, util.NewFakeTimeForEpoch())
+
+	assert.Nil(t, store.CreateToken(fakeID, common.Run, "token1"))
+	assert.Nil(t, store.CreateToken(fakeIDTwo, common.Job, "token2"))
+
+	valid, err := store.VerifyToken(fakeID, "token1")
+	assert.Nil(t, err)
+	assert.True(t, valid)
+	valid, err = store.VerifyToken(fakeID, "token2")
+	assert.Nil(t, err)
+	assert.False(t, valid)
+	valid, err = store.VerifyToken(fakeIDThree, "token1")
+	assert.Nil(t, err)
+	assert.False(t, valid)
+
+	assert.Nil(t, store.DeleteToken(fakeID))
+	valid, err = store.VerifyToken(fakeID, "token1")
+	assert.Nil(t, err)
+	assert.False(t, valid)
+}
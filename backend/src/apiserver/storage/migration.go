@@ -0,0 +1,64 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// Models are the models stored in the database, one table each.
+var Models = []interface{}{
+	&model.ArtifactEvent{},
+	&model.BackupMarker{},
+	&model.ExecutionTarget{},
+	&model.Experiment{},
+	&model.Favorite{},
+	&model.GitSyncedPipeline{},
+	&model.Job{},
+	&model.MetricsPushToken{},
+	&model.ModelVersion{},
+	&model.Operation{},
+	&model.Pipeline{},
+	&model.PipelineStep{},
+	&model.ReadOnlyMode{},
+	&model.ReportDeadLetter{},
+	&model.ResourceReference{},
+	&model.RunAnnotation{},
+	&model.RunDeployment{},
+	&model.RunDetail{},
+	&model.RunGroup{},
+	&model.RunGroupMember{},
+	&model.RunMetric{},
+	&model.RunOutboxEntry{},
+	&model.RunSweep{},
+	&model.RunStateTransition{},
+	&model.RunSweepTrial{},
+	&model.TerminateOperation{},
+	&model.TerminateResult{},
+	&model.Webhook{},
+	&model.WorkflowReport{},
+}
+
+// Migrate creates the tables, and the columns and indexes of the models, which don't exist yet.
+// The tables of a previous release are upgraded in place: a migration never drops or changes a
+// column, so the rows stored before the upgrade have NULL in the columns added since.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(Models...).Error; err != nil {
+		return util.NewInternalServerError(err, "Failed to migrate the database")
+	}
+	return nil
+}
@@ -16,7 +16,10 @@ package storage
 
 import (
 	"io"
+	"net/http"
+	"sync"
 
+	"github.com/kubeflow/pipelines/backend/src/common/util"
 	minio "github.com/minio/minio-go"
 )
 
@@ -25,6 +28,8 @@ type MinioClientInterface interface {
 	PutObject(bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error)
 	GetObject(bucketName, objectName string, opts minio.GetObjectOptions) (io.Reader, error)
 	DeleteObject(bucketName, objectName string) error
+	BucketExists(bucketName string) (bool, error)
+	ListObjects(bucketName, objectPrefix string, recursive bool, doneCh <-chan struct{}) <-chan minio.ObjectInfo
 }
 
 type MinioClient struct {
@@ -42,3 +47,120 @@ func (c *MinioClient) GetObject(bucketName, objectName string, opts minio.GetObj
 func (c *MinioClient) DeleteObject(bucketName, objectName string) error {
 	return c.Client.RemoveObject(bucketName, objectName)
 }
+
+func (c *MinioClient) BucketExists(bucketName string) (bool, error) {
+	return c.Client.BucketExists(bucketName)
+}
+
+func (c *MinioClient) ListObjects(bucketName, objectPrefix string, recursive bool,
+	doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	return c.Client.ListObjects(bucketName, objectPrefix, recursive, doneCh)
+}
+
+// FaultInjectingMinioClient injects the faults of the operations "objectstore.put",
+// "objectstore.get", "objectstore.delete", "objectstore.bucket_exists" and
+// "objectstore.list" in the calls to a MinioClientInterface. The failed calls fail as if
+// the object store was unavailable, so that they're retried.
+type FaultInjectingMinioClient struct {
+	MinioClientInterface
+	faults *util.FaultInjector
+}
+
+func NewFaultInjectingMinioClient(client MinioClientInterface, faults *util.FaultInjector) *FaultInjectingMinioClient {
+	return &FaultInjectingMinioClient{MinioClientInterface: client, faults: faults}
+}
+
+func (c *FaultInjectingMinioClient) injectFault(operation string) error {
+	if c.faults.Inject(operation) {
+		return minio.ErrorResponse{
+			Code:       "ServiceUnavailable",
+			Message:    util.InjectedFaultMessage(operation),
+			StatusCode: http.StatusServiceUnavailable,
+		}
+	}
+	return nil
+}
+
+func (c *FaultInjectingMinioClient) PutObject(bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error) {
+	if err := c.injectFault("objectstore.put"); err != nil {
+		return 0, err
+	}
+	return c.MinioClientInterface.PutObject(bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *FaultInjectingMinioClient) GetObject(bucketName, objectName string, opts minio.GetObjectOptions) (io.Reader, error) {
+	if err := c.injectFault("objectstore.get"); err != nil {
+		return nil, err
+	}
+	return c.MinioClientInterface.GetObject(bucketName, objectName, opts)
+}
+
+func (c *FaultInjectingMinioClient) DeleteObject(bucketName, objectName string) error {
+	if err := c.injectFault("objectstore.delete"); err != nil {
+		return err
+	}
+	return c.MinioClientInterface.DeleteObject(bucketName, objectName)
+}
+
+func (c *FaultInjectingMinioClient) BucketExists(bucketName string) (bool, error) {
+	if err := c.injectFault("objectstore.bucket_exists"); err != nil {
+		return false, err
+	}
+	return c.MinioClientInterface.BucketExists(bucketName)
+}
+
+func (c *FaultInjectingMinioClient) ListObjects(bucketName, objectPrefix string, recursive bool,
+	doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	if err := c.injectFault("objectstore.list"); err != nil {
+		objects := make(chan minio.ObjectInfo, 1)
+		objects <- minio.ObjectInfo{Err: err}
+		close(objects)
+		return objects
+	}
+	return c.MinioClientInterface.ListObjects(bucketName, objectPrefix, recursive, doneCh)
+}
+
+// SwappableMinioClient forwards the calls to a MinioClientInterface which can be replaced
+// while the calls are served, e.g. by a client with the new credentials of the object store.
+type SwappableMinioClient struct {
+	mutex  sync.RWMutex
+	client MinioClientInterface
+}
+
+func NewSwappableMinioClient(client MinioClientInterface) *SwappableMinioClient {
+	return &SwappableMinioClient{client: client}
+}
+
+// Swap replaces the client the calls starting from now on are forwarded to.
+func (c *SwappableMinioClient) Swap(client MinioClientInterface) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.client = client
+}
+
+func (c *SwappableMinioClient) current() MinioClientInterface {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.client
+}
+
+func (c *SwappableMinioClient) PutObject(bucketName, objectName string, reader io.Reader, objectSize int64, opts minio.PutObjectOptions) (n int64, err error) {
+	return c.current().PutObject(bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *SwappableMinioClient) GetObject(bucketName, objectName string, opts minio.GetObjectOptions) (io.Reader, error) {
+	return c.current().GetObject(bucketName, objectName, opts)
+}
+
+func (c *SwappableMinioClient) DeleteObject(bucketName, objectName string) error {
+	return c.current().DeleteObject(bucketName, objectName)
+}
+
+func (c *SwappableMinioClient) BucketExists(bucketName string) (bool, error) {
+	return c.current().BucketExists(bucketName)
+}
+
+func (c *SwappableMinioClient) ListObjects(bucketName, objectPrefix string, recursive bool,
+	doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	return c.current().ListObjects(bucketName, objectPrefix, recursive, doneCh)
+}
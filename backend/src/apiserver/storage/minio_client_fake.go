@@ -16,7 +16,11 @@ package storage
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/hex"
 	"io"
+	"sort"
+	"strings"
 
 	"github.com/minio/minio-go"
 	"github.com/pkg/errors"
@@ -56,6 +60,28 @@ func (c *FakeMinioClient) DeleteObject(bucketName, objectName string) error {
 	return nil
 }
 
+func (c *FakeMinioClient) BucketExists(bucketName string) (bool, error) {
+	return true, nil
+}
+
+func (c *FakeMinioClient) ListObjects(bucketName, objectPrefix string, recursive bool,
+	doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	var names []string
+	for name := range c.minioClient {
+		if strings.HasPrefix(name, objectPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	objects := make(chan minio.ObjectInfo, len(names))
+	for _, name := range names {
+		hash := md5.Sum(c.minioClient[name])
+		objects <- minio.ObjectInfo{Key: name, Size: int64(len(c.minioClient[name])), ETag: hex.EncodeToString(hash[:])}
+	}
+	close(objects)
+	return objects
+}
+
 func (c *FakeMinioClient) GetObjectCount() int {
 	return len(c.minioClient)
 }
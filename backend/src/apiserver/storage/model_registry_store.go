@@ -0,0 +1,240 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var modelVersionColumns = []string{"UUID", "ModelName", "Version", "ArtifactURI", "ArtifactName", "RunUUID", "NodeID",
+	"CreatedAtInSec"}
+
+// ModelRegistryInterface is the registry the models produced by the runs are registered
+// in. ModelRegistryStore is the default one, storing them in the database.
+type ModelRegistryInterface interface {
+	// RegisterModelVersion registers an artifact as the next version of a model.
+	// Registering the same artifact of the same step again returns the existing version.
+	RegisterModelVersion(*model.ModelVersion) (*model.ModelVersion, error)
+	GetModelVersion(uuid string) (*model.ModelVersion, error)
+	// ListModelVersions lists the versions of a model, or of all the models if modelName is empty.
+	ListModelVersions(modelName string, context *common.PaginationContext) ([]model.ModelVersion, string, error)
+}
+
+type ModelRegistryStore struct {
+	db   *DB
+	time util.TimeInterface
+	uuid util.UUIDGeneratorInterface
+}
+
+func (s *ModelRegistryStore) RegisterModelVersion(modelVersion *model.ModelVersion) (*model.ModelVersion, error) {
+	existingSql, existingArgs, err := sq.
+		Select(modelVersionColumns...).
+		From("model_versions").
+		Where(sq.Eq{
+			"ModelName":    modelVersion.ModelName,
+			"RunUUID":      modelVersion.RunUUID,
+			"NodeID":       modelVersion.NodeID,
+			"ArtifactName": modelVersion.ArtifactName}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get model version: %v", err.Error())
+	}
+	existing, err := s.query(existingSql, existingArgs)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get model version: %v", err.Error())
+	}
+	if len(existing) > 0 {
+		if existing[0].Metrics, err = s.queryMetrics(&existing[0]); err != nil {
+			return nil, err
+		}
+		return &existing[0], nil
+	}
+
+	newModelVersion := *modelVersion
+	id, err := s.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a model version id.")
+	}
+	newModelVersion.UUID = id.String()
+	newModelVersion.CreatedAtInSec = s.time.Now().Unix()
+	lastVersionSql, lastVersionArgs, err := sq.
+		Select("COALESCE(MAX(Version), 0)").
+		From("model_versions").
+		Where(sq.Eq{"ModelName": modelVersion.ModelName}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the last version of model %v",
+			modelVersion.ModelName)
+	}
+	// Use a transaction so that the version is numbered after the last one. Concurrent
+	// registrations of the same model fail on the unique index of the versions.
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a new transaction to register model %v",
+			modelVersion.ModelName)
+	}
+	if err = tx.QueryRow(lastVersionSql, lastVersionArgs...).Scan(&newModelVersion.Version); err != nil {
+		tx.Rollback()
+		return nil, util.NewInternalServerError(err, "Failed to get the last version of model %v", modelVersion.ModelName)
+	}
+	newModelVersion.Version++
+	insertSql, insertArgs, err := sq.
+		Insert("model_versions").
+		SetMap(sq.Eq{
+			"UUID":           newModelVersion.UUID,
+			"ModelName":      newModelVersion.ModelName,
+			"Version":        newModelVersion.Version,
+			"ArtifactURI":    newModelVersion.ArtifactURI,
+			"ArtifactName":   newModelVersion.ArtifactName,
+			"RunUUID":        newModelVersion.RunUUID,
+			"NodeID":         newModelVersion.NodeID,
+			"CreatedAtInSec": newModelVersion.CreatedAtInSec}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return nil, util.NewInternalServerError(err, "Failed to create query to register model %v", modelVersion.ModelName)
+	}
+	if _, err = tx.Exec(insertSql, insertArgs...); err != nil {
+		tx.Rollback()
+		return nil, util.NewInternalServerError(err, "Failed to register model %v", modelVersion.ModelName)
+	}
+	if err = tx.Commit(); err != nil {
+		tx.Rollback()
+		return nil, util.NewInternalServerError(err, "Failed to register model %v", modelVersion.ModelName)
+	}
+	if newModelVersion.Metrics, err = s.queryMetrics(&newModelVersion); err != nil {
+		return nil, err
+	}
+	return &newModelVersion, nil
+}
+
+func (s *ModelRegistryStore) GetModelVersion(uuid string) (*model.ModelVersion, error) {
+	sql, args, err := sq.
+		Select(modelVersionColumns...).
+		From("model_versions").
+		Where(sq.Eq{"UUID": uuid}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get model version: %v", err.Error())
+	}
+	modelVersions, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get model version: %v", err.Error())
+	}
+	if len(modelVersions) == 0 {
+		return nil, util.NewResourceNotFoundError("ModelVersion", uuid)
+	}
+	if modelVersions[0].Metrics, err = s.queryMetrics(&modelVersions[0]); err != nil {
+		return nil, err
+	}
+	return &modelVersions[0], nil
+}
+
+func (s *ModelRegistryStore) ListModelVersions(modelName string, context *common.PaginationContext) (
+	[]model.ModelVersion, string, error) {
+	queryModelVersionTable := func(context *common.PaginationContext) ([]model.ListableDataModel, error) {
+		sqlBuilder := sq.Select(modelVersionColumns...).From("model_versions")
+		if modelName != "" {
+			sqlBuilder = sqlBuilder.Where(sq.Eq{"ModelName": modelName})
+		}
+		sql, args, err := toPaginationQuery(sqlBuilder, context).Limit(uint64(context.PageSize)).ToSql()
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to create query to list model versions: %v", err.Error())
+		}
+		modelVersions, err := s.query(sql, args)
+		if err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to list model versions: %v", err.Error())
+		}
+		models := make([]model.ListableDataModel, len(modelVersions))
+		for i := range models {
+			models[i] = modelVersions[i]
+		}
+		return models, nil
+	}
+	models, pageToken, err := listModel(context, queryModelVersionTable)
+	if err != nil {
+		return nil, "", util.Wrap(err, "List model versions failed.")
+	}
+	modelVersions := make([]model.ModelVersion, len(models))
+	for i := range models {
+		modelVersions[i] = models[i].(model.ModelVersion)
+		if modelVersions[i].Metrics, err = s.queryMetrics(&modelVersions[i]); err != nil {
+			return nil, "", err
+		}
+	}
+	return modelVersions, pageToken, nil
+}
+
+// queryMetrics returns the metrics reported by the step that produced a model version.
+func (s *ModelRegistryStore) queryMetrics(modelVersion *model.ModelVersion) ([]*model.RunMetric, error) {
+	sql, args, err := sq.
+		Select("Name", "NumberValue", "Format").
+		From("run_metrics").
+		Where(sq.Eq{"RunUUID": modelVersion.RunUUID, "NodeID": modelVersion.NodeID}).
+		OrderBy("Name").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the metrics of a model version: %v",
+			err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the metrics of a model version: %v", err.Error())
+	}
+	defer rows.Close()
+	var metrics []*model.RunMetric
+	for rows.Next() {
+		metric := &model.RunMetric{RunUUID: modelVersion.RunUUID, NodeID: modelVersion.NodeID}
+		if err := rows.Scan(&metric.Name, &metric.NumberValue, &metric.Format); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse the metrics of a model version: %v", err.Error())
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, nil
+}
+
+func (s *ModelRegistryStore) query(sql string, args []interface{}) ([]model.ModelVersion, error) {
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanRows(rows)
+}
+
+func (s *ModelRegistryStore) scanRows(rows *sql.Rows) ([]model.ModelVersion, error) {
+	var modelVersions []model.ModelVersion
+	for rows.Next() {
+		var modelVersion model.ModelVersion
+		if err := rows.Scan(&modelVersion.UUID, &modelVersion.ModelName, &modelVersion.Version,
+			&modelVersion.ArtifactURI, &modelVersion.ArtifactName, &modelVersion.RunUUID, &modelVersion.NodeID,
+			&modelVersion.CreatedAtInSec); err != nil {
+			return modelVersions, err
+		}
+		modelVersions = append(modelVersions, modelVersion)
+	}
+	return modelVersions, nil
+}
+
+// factory function for model registry store
+func NewModelRegistryStore(db *DB, time util.TimeInterface, uuid util.UUIDGeneratorInterface) *ModelRegistryStore {
+	return &ModelRegistryStore{db: db, time: time, uuid: uuid}
+}
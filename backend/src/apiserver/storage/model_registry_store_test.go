@@ -0,0 +1,104 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func newModelVersion(modelName string, runUUID string) *model.ModelVersion {
+	return &model.ModelVersion{
+		ModelName:    modelName,
+		ArtifactURI:  "s3://mlpipeline/" + runUUID + "/model.tgz",
+		ArtifactName: "model",
+		RunUUID:      runUUID,
+		NodeID:       "node1",
+	}
+}
+
+func TestRegisterModelVersion(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	runStore := NewRunStore(db, util.NewFakeTimeForEpoch())
+	assert.Nil(t, runStore.ReportMetric(&model.RunMetric{RunUUID: "run1", NodeID: "node1", Name: "accuracy",
+		NumberValue: 0.9, Format: "PERCENTAGE"}))
+	store := NewModelRegistryStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+
+	modelVersion, err := store.RegisterModelVersion(newModelVersion("mnist", "run1"))
+	assert.Nil(t, err)
+	expected := newModelVersion("mnist", "run1")
+	expected.UUID = fakeID
+	expected.Version = 1
+	expected.CreatedAtInSec = 1
+	expected.Metrics = []*model.RunMetric{
+		{RunUUID: "run1", NodeID: "node1", Name: "accuracy", NumberValue: 0.9, Format: "PERCENTAGE"},
+	}
+	assert.Equal(t, expected, modelVersion)
+
+	modelVersion, err = store.GetModelVersion(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, modelVersion)
+
+	// Registering the same artifact again returns the existing version.
+	modelVersion, err = store.RegisterModelVersion(newModelVersion("mnist", "run1"))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, modelVersion)
+}
+
+func TestRegisterModelVersion_NumbersVersionsByModel(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewModelRegistryStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+
+	_, err := store.RegisterModelVersion(newModelVersion("mnist", "run1"))
+	assert.Nil(t, err)
+	store.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	_, err = store.RegisterModelVersion(newModelVersion("mnist", "run2"))
+	assert.Nil(t, err)
+	store.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDThree, nil)
+	_, err = store.RegisterModelVersion(newModelVersion("resnet", "run2"))
+	assert.Nil(t, err)
+
+	modelVersions, nextPageToken, err := store.ListModelVersions("mnist", &common.PaginationContext{
+		PageSize: 10, KeyFieldName: "UUID", SortByFieldName: "Version", IsDesc: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "", nextPageToken)
+	assert.Equal(t, 2, len(modelVersions))
+	assert.Equal(t, int64(2), modelVersions[0].Version)
+	assert.Equal(t, "run2", modelVersions[0].RunUUID)
+	assert.Equal(t, int64(1), modelVersions[1].Version)
+
+	modelVersions, _, err = store.ListModelVersions("", &common.PaginationContext{
+		PageSize: 10, KeyFieldName: "UUID", SortByFieldName: "CreatedAtInSec"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(modelVersions))
+	assert.Equal(t, "resnet", modelVersions[2].ModelName)
+	assert.Equal(t, int64(1), modelVersions[2].Version)
+}
+
+func TestGetModelVersion_NotFound(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewModelRegistryStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+
+	_, err := store.GetModelVersion(fakeID)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
@@ -16,6 +16,11 @@ package storage
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
 
 	"github.com/ghodss/yaml"
 	"github.com/kubeflow/pipelines/backend/src/common/util"
@@ -33,18 +38,34 @@ type ObjectStoreInterface interface {
 	GetFile(filePath string) ([]byte, error)
 	AddAsYamlFile(o interface{}, filePath string) error
 	GetFromYamlFile(o interface{}, filePath string) error
+	// Ping verifies that the object store is reachable and its bucket exists.
+	Ping() error
+	// List the files whose path starts with the prefix, in the order of their paths.
+	ListFiles(prefix string) ([]StoredFile, error)
 }
 
-// Managing pipeline using Minio
+// StoredFile describes a file of the object store.
+type StoredFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	ETag string `json:"etag"`
+}
+
+// Managing pipeline using Minio. The calls failing with a transient error are retried,
+// all of them being idempotent.
 type MinioObjectStore struct {
 	minioClient MinioClientInterface
 	bucketName  string
+	retrier     *util.Retrier
 }
 
 func (m *MinioObjectStore) AddFile(file []byte, filePath string) error {
-	_, err := m.minioClient.PutObject(
-		m.bucketName, filePath, bytes.NewReader(file),
-		multipartDefaultSize, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	err := m.retrier.Do(context.Background(), isTransientObjectStoreError, func() error {
+		_, err := m.minioClient.PutObject(
+			m.bucketName, filePath, bytes.NewReader(file),
+			multipartDefaultSize, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+		return err
+	})
 	if err != nil {
 		return util.NewInternalServerError(err, "Failed to store %v", filePath)
 	}
@@ -52,7 +73,9 @@ func (m *MinioObjectStore) AddFile(file []byte, filePath string) error {
 }
 
 func (m *MinioObjectStore) DeleteFile(filePath string) error {
-	err := m.minioClient.DeleteObject(m.bucketName, filePath)
+	err := m.retrier.Do(context.Background(), isTransientObjectStoreError, func() error {
+		return m.minioClient.DeleteObject(m.bucketName, filePath)
+	})
 	if err != nil {
 		return util.NewInternalServerError(err, "Failed to delete %v", filePath)
 	}
@@ -60,16 +83,43 @@ func (m *MinioObjectStore) DeleteFile(filePath string) error {
 }
 
 func (m *MinioObjectStore) GetFile(filePath string) ([]byte, error) {
-	reader, err := m.minioClient.GetObject(m.bucketName, filePath, minio.GetObjectOptions{})
+	buf := new(bytes.Buffer)
+	err := m.retrier.Do(context.Background(), isTransientObjectStoreError, func() error {
+		// The object is requested on the first read, which returns the errors.
+		reader, err := m.minioClient.GetObject(m.bucketName, filePath, minio.GetObjectOptions{})
+		if err != nil {
+			return err
+		}
+		buf.Reset()
+		_, err = buf.ReadFrom(reader)
+		return err
+	})
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to get %v", filePath)
 	}
-
-	buf := new(bytes.Buffer)
-	buf.ReadFrom(reader)
 	return buf.Bytes(), nil
 }
 
+func (m *MinioObjectStore) ListFiles(prefix string) ([]StoredFile, error) {
+	var files []StoredFile
+	err := m.retrier.Do(context.Background(), isTransientObjectStoreError, func() error {
+		doneCh := make(chan struct{})
+		defer close(doneCh)
+		files = nil
+		for object := range m.minioClient.ListObjects(m.bucketName, prefix, true, doneCh) {
+			if object.Err != nil {
+				return object.Err
+			}
+			files = append(files, StoredFile{Path: object.Key, Size: object.Size, ETag: object.ETag})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the files under %v", prefix)
+	}
+	return files, nil
+}
+
 func (m *MinioObjectStore) AddAsYamlFile(o interface{}, filePath string) error {
 	bytes, err := yaml.Marshal(o)
 	if err != nil {
@@ -98,6 +148,36 @@ func buildPath(folder, file string) string {
 	return folder + "/" + file
 }
 
-func NewMinioObjectStore(minioClient MinioClientInterface, bucketName string) *MinioObjectStore {
-	return &MinioObjectStore{minioClient: minioClient, bucketName: bucketName}
+// isTransientObjectStoreError tells whether a call to the object store may succeed
+// when retried.
+func isTransientObjectStoreError(err error) bool {
+	if _, ok := err.(net.Error); ok || err == io.ErrUnexpectedEOF {
+		return true
+	}
+	response := minio.ToErrorResponse(err)
+	switch response.Code {
+	case "InternalError", "RequestTimeout", "ServiceUnavailable", "SlowDown":
+		return true
+	}
+	return response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+}
+
+func (m *MinioObjectStore) Ping() error {
+	exists, err := m.minioClient.BucketExists(m.bucketName)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to reach the object store")
+	}
+	if !exists {
+		return util.NewInternalServerError(fmt.Errorf("bucket %v does not exist", m.bucketName),
+			"Failed to find the object store bucket")
+	}
+	return nil
+}
+
+func NewMinioObjectStore(minioClient MinioClientInterface, bucketName string, retryPolicy util.RetryPolicy) *MinioObjectStore {
+	return &MinioObjectStore{
+		minioClient: minioClient,
+		bucketName:  bucketName,
+		retrier:     util.NewRetrier("object_store", retryPolicy),
+	}
 }
@@ -14,7 +14,9 @@
 
 package storage
 
+import "github.com/kubeflow/pipelines/backend/src/common/util"
+
 // Return the object store with faked minio client.
 func NewFakeObjectStore() ObjectStoreInterface {
-	return NewMinioObjectStore(NewFakeMinioClient(), "")
+	return NewMinioObjectStore(NewFakeMinioClient(), "", util.RetryPolicy{})
 }
@@ -17,7 +17,9 @@ package storage
 import (
 	"bytes"
 	"io"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 	minio "github.com/minio/minio-go"
@@ -44,22 +46,34 @@ func (c *FakeBadMinioClient) DeleteObject(bucketName, objectName string) error {
 	return errors.New("some error")
 }
 
+func (c *FakeBadMinioClient) BucketExists(bucketName string) (bool, error) {
+	return false, errors.New("some error")
+}
+
+func (c *FakeBadMinioClient) ListObjects(bucketName, objectPrefix string, recursive bool,
+	doneCh <-chan struct{}) <-chan minio.ObjectInfo {
+	objects := make(chan minio.ObjectInfo, 1)
+	objects <- minio.ObjectInfo{Err: errors.New("some error")}
+	close(objects)
+	return objects
+}
+
 func TestAddFile(t *testing.T) {
 	minioClient := NewFakeMinioClient()
-	manager := &MinioObjectStore{minioClient: minioClient}
+	manager := NewMinioObjectStore(minioClient, "", util.RetryPolicy{})
 	error := manager.AddFile([]byte("abc"), CreatePipelinePath("1"))
 	assert.Nil(t, error)
 	assert.Equal(t, 1, minioClient.GetObjectCount())
 }
 
 func TestAddFileError(t *testing.T) {
-	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}}
+	manager := NewMinioObjectStore(&FakeBadMinioClient{}, "", util.RetryPolicy{})
 	error := manager.AddFile([]byte("abc"), CreatePipelinePath("1"))
 	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
 }
 
 func TestGetFile(t *testing.T) {
-	manager := &MinioObjectStore{minioClient: NewFakeMinioClient()}
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "", util.RetryPolicy{})
 	manager.AddFile([]byte("abc"), CreatePipelinePath("1"))
 	file, error := manager.GetFile(CreatePipelinePath("1"))
 	assert.Nil(t, error)
@@ -67,14 +81,14 @@ func TestGetFile(t *testing.T) {
 }
 
 func TestGetFileError(t *testing.T) {
-	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}}
+	manager := NewMinioObjectStore(&FakeBadMinioClient{}, "", util.RetryPolicy{})
 	_, error := manager.GetFile(CreatePipelinePath("1"))
 	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
 }
 
 func TestDeleteFile(t *testing.T) {
 	minioClient := NewFakeMinioClient()
-	manager := &MinioObjectStore{minioClient: minioClient}
+	manager := NewMinioObjectStore(minioClient, "", util.RetryPolicy{})
 	manager.AddFile([]byte("abc"), CreatePipelinePath("1"))
 	error := manager.DeleteFile(CreatePipelinePath("1"))
 	assert.Nil(t, error)
@@ -82,14 +96,14 @@ func TestDeleteFile(t *testing.T) {
 }
 
 func TestDeleteFileError(t *testing.T) {
-	manager := &MinioObjectStore{minioClient: &FakeBadMinioClient{}}
+	manager := NewMinioObjectStore(&FakeBadMinioClient{}, "", util.RetryPolicy{})
 	error := manager.DeleteFile(CreatePipelinePath("1"))
 	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
 }
 
 func TestAddAsYamlFile(t *testing.T) {
 	minioClient := NewFakeMinioClient()
-	manager := &MinioObjectStore{minioClient: minioClient}
+	manager := NewMinioObjectStore(minioClient, "", util.RetryPolicy{})
 	error := manager.AddAsYamlFile(Foo{ID: 1}, CreatePipelinePath("1"))
 	assert.Nil(t, error)
 	assert.Equal(t, 1, minioClient.GetObjectCount())
@@ -97,7 +111,7 @@ func TestAddAsYamlFile(t *testing.T) {
 
 func TestGetFromYamlFile(t *testing.T) {
 	minioClient := NewFakeMinioClient()
-	manager := &MinioObjectStore{minioClient: minioClient}
+	manager := NewMinioObjectStore(minioClient, "", util.RetryPolicy{})
 	manager.minioClient.PutObject(
 		"", CreatePipelinePath("1"),
 		bytes.NewReader([]byte("id: 1")), -1,
@@ -111,7 +125,7 @@ func TestGetFromYamlFile(t *testing.T) {
 
 func TestGetFromYamlFile_UnmarshalError(t *testing.T) {
 	minioClient := NewFakeMinioClient()
-	manager := &MinioObjectStore{minioClient: minioClient}
+	manager := NewMinioObjectStore(minioClient, "", util.RetryPolicy{})
 	manager.minioClient.PutObject(
 		"", CreatePipelinePath("1"),
 		bytes.NewReader([]byte("invalid")), -1,
@@ -121,3 +135,126 @@ func TestGetFromYamlFile_UnmarshalError(t *testing.T) {
 	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
 	assert.Contains(t, error.Error(), "Failed to unmarshal")
 }
+
+func TestListFiles(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "", util.RetryPolicy{})
+	manager.AddFile([]byte("abc"), CreatePipelinePath("2"))
+	manager.AddFile([]byte("de"), CreatePipelinePath("1"))
+	manager.AddFile([]byte("f"), CreatePipelineManifestPath("1"))
+
+	files, err := manager.ListFiles(CreatePipelinePath(""))
+	assert.Nil(t, err)
+	assert.Equal(t, []StoredFile{
+		{Path: "pipelines/1", Size: 2, ETag: "5f02f0889301fd7be1ac972c11bf3e7d"},
+		{Path: "pipelines/2", Size: 3, ETag: "900150983cd24fb0d6963f7d28e17f72"},
+	}, files)
+}
+
+func TestListFilesError(t *testing.T) {
+	manager := NewMinioObjectStore(&FakeBadMinioClient{}, "", util.RetryPolicy{})
+	_, err := manager.ListFiles("")
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestPing(t *testing.T) {
+	manager := NewMinioObjectStore(NewFakeMinioClient(), "", util.RetryPolicy{})
+	assert.Nil(t, manager.Ping())
+}
+
+func TestPingError(t *testing.T) {
+	manager := NewMinioObjectStore(&FakeBadMinioClient{}, "", util.RetryPolicy{})
+	error := manager.Ping()
+	assert.Equal(t, codes.Internal, error.(*util.UserError).ExternalStatusCode())
+}
+
+// flakyMinioClient fails the first calls with err, including the reads of the objects.
+type flakyMinioClient struct {
+	*FakeMinioClient
+	failures int
+	err      error
+	calls    int
+}
+
+type failingReader struct{ err error }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+func (c *flakyMinioClient) PutObject(bucketName, objectName string, reader io.Reader,
+	objectSize int64, opts minio.PutObjectOptions) (n int64, err error) {
+	if c.calls++; c.calls <= c.failures {
+		return 0, c.err
+	}
+	return c.FakeMinioClient.PutObject(bucketName, objectName, reader, objectSize, opts)
+}
+
+func (c *flakyMinioClient) GetObject(bucketName, objectName string,
+	opts minio.GetObjectOptions) (io.Reader, error) {
+	if c.calls++; c.calls <= c.failures {
+		return &failingReader{err: c.err}, nil
+	}
+	return c.FakeMinioClient.GetObject(bucketName, objectName, opts)
+}
+
+var testRetryPolicy = util.RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func TestObjectStore_RetriesTransientErrors(t *testing.T) {
+	minioClient := &flakyMinioClient{
+		FakeMinioClient: NewFakeMinioClient(),
+		failures:        2,
+		err:             minio.ErrorResponse{StatusCode: http.StatusServiceUnavailable, Code: "ServiceUnavailable"},
+	}
+	manager := NewMinioObjectStore(minioClient, "", testRetryPolicy)
+	assert.Nil(t, manager.AddFile([]byte("abc"), CreatePipelinePath("1")))
+	assert.Equal(t, 3, minioClient.calls)
+
+	minioClient.calls = 0
+	minioClient.err = io.ErrUnexpectedEOF
+	file, err := manager.GetFile(CreatePipelinePath("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("abc"), file)
+	assert.Equal(t, 3, minioClient.calls)
+}
+
+func TestObjectStore_DoesNotRetryPermanentErrors(t *testing.T) {
+	minioClient := &flakyMinioClient{
+		FakeMinioClient: NewFakeMinioClient(),
+		failures:        2,
+		err:             minio.ErrorResponse{StatusCode: http.StatusNotFound, Code: "NoSuchKey"},
+	}
+	manager := NewMinioObjectStore(minioClient, "", testRetryPolicy)
+	_, err := manager.GetFile(CreatePipelinePath("1"))
+	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
+	assert.Equal(t, 1, minioClient.calls)
+}
+
+func TestObjectStore_RetriesInjectedFaults(t *testing.T) {
+	faults := util.NewFaultInjector([]util.FaultRule{{Operation: "objectstore.*", Fail: true, Count: 2}}, 0)
+	manager := NewMinioObjectStore(NewFaultInjectingMinioClient(NewFakeMinioClient(), faults), "", testRetryPolicy)
+	assert.Nil(t, manager.AddFile([]byte("abc"), CreatePipelinePath("1")))
+	file, err := manager.GetFile(CreatePipelinePath("1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("abc"), file)
+
+	faults = util.NewFaultInjector([]util.FaultRule{{Operation: "objectstore.get", Fail: true}}, 0)
+	manager = NewMinioObjectStore(NewFaultInjectingMinioClient(NewFakeMinioClient(), faults), "", testRetryPolicy)
+	_, err = manager.GetFile(CreatePipelinePath("1"))
+	assert.Contains(t, err.Error(), "Fault injected in objectstore.get")
+}
+
+func TestObjectStore_SwappedClient(t *testing.T) {
+	oldClient := NewFakeMinioClient()
+	client := NewSwappableMinioClient(oldClient)
+	manager := NewMinioObjectStore(client, "", testRetryPolicy)
+	assert.Nil(t, manager.AddFile([]byte("abc"), CreatePipelinePath("1")))
+
+	newClient := NewFakeMinioClient()
+	client.Swap(newClient)
+	assert.Nil(t, manager.AddFile([]byte("def"), CreatePipelinePath("2")))
+	assert.Equal(t, 1, oldClient.GetObjectCount())
+	assert.Equal(t, 1, newClient.GetObjectCount())
+	file, err := manager.GetFile(CreatePipelinePath("2"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("def"), file)
+}
@@ -14,13 +14,79 @@
 
 package storage
 
-import "path"
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+)
 
 const (
-	pipelineFolder = "pipelines"
+	pipelineFolder         = "pipelines"
+	pipelineManifestFolder = "pipeline_manifests"
+	pipelineReadmeFolder   = "pipeline_readmes"
+	runLogFolder           = "logs"
+	// BackupFolder holds the manifests of the files of the backups.
+	BackupFolder = "backups"
+	// ConsistencyReportPath is the object store path to the report of the last consistency check.
+	ConsistencyReportPath = "consistency/report.json"
+	// StorageUsageReportPath is the object store path to the report of the last storage usage
+	// computation.
+	StorageUsageReportPath = "usage/report.json"
 )
 
 // CreatePipelinePath creates object store path to a pipeline spec.
 func CreatePipelinePath(pipelineID string) string {
-	return path.Join(pipelineFolder, pipelineID)
+	return path.Join(pipelineFolder, encodeKeySegment(pipelineID))
+}
+
+// CreatePipelineManifestPath creates object store path to the workflow of a pipeline spec,
+// compiled into JSON.
+func CreatePipelineManifestPath(pipelineID string) string {
+	return path.Join(pipelineManifestFolder, encodeKeySegment(pipelineID))
+}
+
+// CreatePipelineReadmePath creates object store path to the README uploaded with a pipeline.
+func CreatePipelineReadmePath(pipelineID string) string {
+	return path.Join(pipelineReadmeFolder, encodeKeySegment(pipelineID))
+}
+
+// CreateBackupManifestPath creates object store path to the list of the files of the object
+// store backed up with the database snapshot of a backup.
+func CreateBackupManifestPath(backupID string) string {
+	return path.Join(BackupFolder, encodeKeySegment(backupID), "manifest.json")
+}
+
+// CreateRunLogPath creates object store path to the archived logs of a step of a run.
+func CreateRunLogPath(runID string, nodeID string) string {
+	return path.Join(runLogFolder, encodeKeySegment(runID), encodeKeySegment(nodeID)+".log")
+}
+
+// ParseResourcePath returns the type, i.e. Pipeline or Run, and the ID of the resource an object
+// store path belongs to. The type is empty if the path doesn't belong to a resource.
+func ParseResourcePath(objectPath string) (common.ResourceType, string) {
+	parts := strings.SplitN(objectPath, "/", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		return "", ""
+	}
+	id, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return "", ""
+	}
+	switch {
+	case (parts[0] == pipelineFolder || parts[0] == pipelineManifestFolder || parts[0] == pipelineReadmeFolder) &&
+		len(parts) == 2:
+		return common.Pipeline, id
+	case parts[0] == runLogFolder && len(parts) == 3:
+		return common.Run, id
+	}
+	return "", ""
+}
+
+// encodeKeySegment escapes a segment of an object store key, so that it stays a single segment
+// of ASCII characters whatever it holds, e.g. slashes, spaces or non-ASCII characters. The
+// generated IDs are kept as they are.
+func encodeKeySegment(segment string) string {
+	return url.PathEscape(segment)
 }
@@ -0,0 +1,48 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRunLogPath(t *testing.T) {
+	assert.Equal(t, "logs/123e4567/run-abc-1234.log", CreateRunLogPath("123e4567", "run-abc-1234"))
+	// The segments stay single segments of ASCII characters.
+	assert.Equal(t, "logs/123e4567/..%2F..%2Fpipelines%2Fp1.log", CreateRunLogPath("123e4567", "../../pipelines/p1"))
+	assert.Equal(t, "logs/123e4567/%E8%AE%AD%E7%BB%83%20step.log", CreateRunLogPath("123e4567", "训练 step"))
+}
+
+func TestParseResourcePath(t *testing.T) {
+	tests := []struct {
+		path         string
+		expectedType common.ResourceType
+		expectedId   string
+	}{
+		{CreatePipelinePath("123e4567"), common.Pipeline, "123e4567"},
+		{CreatePipelineManifestPath("p/1"), common.Pipeline, "p/1"},
+		{CreateRunLogPath("训练", "step"), common.Run, "训练"},
+		{CreateBackupManifestPath("b1"), "", ""},
+		{"logs/%ZZ/step.log", "", ""},
+	}
+	for _, test := range tests {
+		resourceType, id := ParseResourcePath(test.path)
+		assert.Equal(t, test.expectedType, resourceType, test.path)
+		assert.Equal(t, test.expectedId, id, test.path)
+	}
+}
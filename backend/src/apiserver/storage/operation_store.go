@@ -0,0 +1,195 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var operationColumns = []string{"UUID", "Type", "State", "CreatedAtInSec", "FinishedAtInSec", "Total", "Processed",
+	"Cursor", "Errors"}
+
+type OperationStoreInterface interface {
+	CreateOperation(operation *model.Operation) error
+	GetOperation(uuid string) (*model.Operation, error)
+	ListOperations(*common.PaginationContext) ([]model.Operation, string, error)
+	// ListRunningOperations lists the operations which are running, the oldest first.
+	ListRunningOperations() ([]*model.Operation, error)
+	// UpdateProgress records the total, the processed count, the cursor and the errors of an
+	// operation, unless it isn't running anymore.
+	UpdateProgress(operation *model.Operation) error
+	// FinishOperation moves an operation out of the running state, unless it already was.
+	FinishOperation(uuid string, state model.OperationState, finishedAtInSec int64) error
+}
+
+type OperationStore struct {
+	db *DB
+}
+
+func (s *OperationStore) CreateOperation(operation *model.Operation) error {
+	sql, args, err := sq.
+		Insert("operations").
+		SetMap(sq.Eq{
+			"UUID":            operation.UUID,
+			"Type":            operation.Type,
+			"State":           operation.State,
+			"CreatedAtInSec":  operation.CreatedAtInSec,
+			"FinishedAtInSec": operation.FinishedAtInSec,
+			"Total":           operation.Total,
+			"Processed":       operation.Processed,
+			"Cursor":          operation.Cursor,
+			"Errors":          operation.Errors}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the operation %v", operation.UUID)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the operation %v", operation.UUID)
+	}
+	return nil
+}
+
+func (s *OperationStore) GetOperation(uuid string) (*model.Operation, error) {
+	sql, args, err := sq.Select(operationColumns...).From("operations").Where(sq.Eq{"UUID": uuid}).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the operation %v", uuid)
+	}
+	operations, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the operation %v", uuid)
+	}
+	if len(operations) == 0 {
+		return nil, util.NewResourceNotFoundError("Operation", uuid)
+	}
+	return &operations[0], nil
+}
+
+func (s *OperationStore) ListOperations(context *common.PaginationContext) ([]model.Operation, string, error) {
+	models, pageToken, err := listModel(context, s.queryOperationTable)
+	if err != nil {
+		return nil, "", util.Wrap(err, "List operations failed.")
+	}
+	operations := make([]model.Operation, len(models))
+	for i := range models {
+		operations[i] = models[i].(model.Operation)
+	}
+	return operations, pageToken, nil
+}
+
+func (s *OperationStore) queryOperationTable(context *common.PaginationContext) ([]model.ListableDataModel, error) {
+	sqlBuilder := sq.Select(operationColumns...).From("operations")
+	sql, args, err := toPaginationQuery(sqlBuilder, context).Limit(uint64(context.PageSize)).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list operations: %v", err.Error())
+	}
+	operations, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list operations: %v", err.Error())
+	}
+	models := make([]model.ListableDataModel, len(operations))
+	for i := range operations {
+		models[i] = operations[i]
+	}
+	return models, nil
+}
+
+func (s *OperationStore) ListRunningOperations() ([]*model.Operation, error) {
+	sql, args, err := sq.
+		Select(operationColumns...).
+		From("operations").
+		Where(sq.Eq{"State": model.OperationRunning}).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the running operations")
+	}
+	operations, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the running operations")
+	}
+	result := make([]*model.Operation, len(operations))
+	for i := range operations {
+		result[i] = &operations[i]
+	}
+	return result, nil
+}
+
+func (s *OperationStore) UpdateProgress(operation *model.Operation) error {
+	sql, args, err := sq.
+		Update("operations").
+		SetMap(sq.Eq{
+			"Total":     operation.Total,
+			"Processed": operation.Processed,
+			"Cursor":    operation.Cursor,
+			"Errors":    operation.Errors}).
+		Where(sq.Eq{"UUID": operation.UUID, "State": model.OperationRunning}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the progress of the operation %v",
+			operation.UUID)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the progress of the operation %v", operation.UUID)
+	}
+	return nil
+}
+
+func (s *OperationStore) FinishOperation(uuid string, state model.OperationState, finishedAtInSec int64) error {
+	sql, args, err := sq.
+		Update("operations").
+		SetMap(sq.Eq{"State": state, "FinishedAtInSec": finishedAtInSec}).
+		Where(sq.Eq{"UUID": uuid, "State": model.OperationRunning}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to finish the operation %v", uuid)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to finish the operation %v", uuid)
+	}
+	return nil
+}
+
+func (s *OperationStore) query(sql string, args []interface{}) ([]model.Operation, error) {
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanRows(rows)
+}
+
+func (s *OperationStore) scanRows(rows *sql.Rows) ([]model.Operation, error) {
+	var operations []model.Operation
+	for rows.Next() {
+		var operation model.Operation
+		if err := rows.Scan(&operation.UUID, &operation.Type, &operation.State, &operation.CreatedAtInSec,
+			&operation.FinishedAtInSec, &operation.Total, &operation.Processed, &operation.Cursor,
+			&operation.Errors); err != nil {
+			return operations, err
+		}
+		operations = append(operations, operation)
+	}
+	return operations, nil
+}
+
+// factory function for operation store
+func NewOperationStore(db *DB) *OperationStore {
+	return &OperationStore{db: db}
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestOperationStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewOperationStore(db)
+
+	operation := &model.Operation{
+		UUID:           fakeID,
+		Type:           model.OperationReindexPipelines,
+		State:          model.OperationRunning,
+		CreatedAtInSec: 1,
+		Errors:         "[]",
+	}
+	assert.Nil(t, store.CreateOperation(operation))
+	stored, err := store.GetOperation(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, operation, stored)
+	_, err = store.GetOperation("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	operation.Total = 3
+	operation.Processed = 2
+	operation.Cursor = "p2"
+	operation.Errors = `["p1: failed"]`
+	assert.Nil(t, store.UpdateProgress(operation))
+	running, err := store.ListRunningOperations()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.Operation{operation}, running)
+
+	assert.Nil(t, store.FinishOperation(fakeID, model.OperationCancelled, 5))
+	// A finished operation keeps its state and its progress.
+	assert.Nil(t, store.FinishOperation(fakeID, model.OperationSucceeded, 6))
+	assert.Nil(t, store.UpdateProgress(&model.Operation{UUID: fakeID, Total: 3, Processed: 3, Errors: "[]"}))
+	stored, err = store.GetOperation(fakeID)
+	assert.Nil(t, err)
+	operation.State = model.OperationCancelled
+	operation.FinishedAtInSec = 5
+	assert.Equal(t, operation, stored)
+	running, err = store.ListRunningOperations()
+	assert.Nil(t, err)
+	assert.Empty(t, running)
+}
+
+func TestListOperations(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewOperationStore(db)
+	first := model.Operation{UUID: fakeID, Type: model.OperationReindexPipelines, State: model.OperationRunning,
+		CreatedAtInSec: 1, Errors: "[]"}
+	second := model.Operation{UUID: fakeIDTwo, Type: model.OperationRecomputeRunStatuses,
+		State: model.OperationRunning, CreatedAtInSec: 2, Errors: "[]"}
+	assert.Nil(t, store.CreateOperation(&first))
+	assert.Nil(t, store.CreateOperation(&second))
+
+	context := &common.PaginationContext{
+		PageSize:        1,
+		KeyFieldName:    model.GetOperationTablePrimaryKeyColumn(),
+		SortByFieldName: "CreatedAtInSec",
+	}
+	operations, nextPageToken, err := store.ListOperations(context)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, nextPageToken)
+	assert.Equal(t, []model.Operation{first}, operations)
+}
@@ -24,31 +24,69 @@ import (
 	"github.com/kubeflow/pipelines/backend/src/common/util"
 )
 
+// PipelineStoreInterface stores the pipelines and their steps. The implementations are tested
+// against its contract by storagetest.TestPipelineStore.
 type PipelineStoreInterface interface {
-	ListPipelines(context *common.PaginationContext) ([]model.Pipeline, string, error)
+	ListPipelines(filterContext *common.FilterContext, context *common.PaginationContext) ([]model.Pipeline, string, error)
 	GetPipeline(pipelineId string) (*model.Pipeline, error)
 	GetPipelineWithStatus(id string, status model.PipelineStatus) (*model.Pipeline, error)
 	DeletePipeline(pipelineId string) error
 	CreatePipeline(*model.Pipeline) (*model.Pipeline, error)
 	UpdatePipelineStatus(string, model.PipelineStatus) error
+	// Get the pipeline with the name, whatever its status.
+	GetPipelineByName(name string) (*model.Pipeline, error)
+	// Replace the description, the parameters, the parameter schema and the template checksum of a
+	// pipeline, whose file changed.
+	UpdatePipelineDefinition(pipelineId string, description string, parameters string, parameterSchema string,
+		templateChecksum string) error
+	// Set the deprecation of a pipeline, with the pipeline replacing it and its sunset if deprecated.
+	UpdatePipelineDeprecation(pipelineId string, deprecated bool, replacementPipelineId string, sunsetAtInSec int64) error
+	// Replace the indexed steps of a pipeline.
+	CreatePipelineSteps(pipelineId string, steps []*model.PipelineStep) error
+	// List the indexed steps of a pipeline, in the order of its template.
+	ListPipelineSteps(pipelineId string) ([]*model.PipelineStep, error)
+	// Get the status of all the pipelines, by pipeline ID.
+	GetPipelineStatuses() (map[string]model.PipelineStatus, error)
 }
 
+// The columns of the pipelines table, in the order scanned by scanRows.
+var pipelineColumns = []string{"UUID", "CreatedAtInSec", "Name", "Description", "Parameters", "Status",
+	"Deprecated", "ReplacementPipelineId", "SunsetAtInSec", "ParameterSchema", "TemplateChecksum"}
+
 type PipelineStore struct {
 	db   *DB
 	time util.TimeInterface
 	uuid util.UUIDGeneratorInterface
 }
 
-func (s *PipelineStore) ListPipelines(context *common.PaginationContext) ([]model.Pipeline, string, error) {
-	models, pageToken, err := listModel(context, s.queryPipelineTable)
+func (s *PipelineStore) ListPipelines(filterContext *common.FilterContext, context *common.PaginationContext) (
+	[]model.Pipeline, string, error) {
+	queryPipelineTable := func(request *common.PaginationContext) ([]model.ListableDataModel, error) {
+		return s.queryPipelineTable(filterContext, request)
+	}
+	models, pageToken, err := listModel(context, queryPipelineTable)
 	if err != nil {
 		return nil, "", util.Wrap(err, "List pipeline failed.")
 	}
 	return s.toPipelines(models), pageToken, err
 }
 
-func (s *PipelineStore) queryPipelineTable(context *common.PaginationContext) ([]model.ListableDataModel, error) {
-	sqlBuilder := sq.Select("*").From("pipelines").Where(sq.Eq{"Status": model.PipelineReady})
+func (s *PipelineStore) queryPipelineTable(filterContext *common.FilterContext, context *common.PaginationContext) (
+	[]model.ListableDataModel, error) {
+	sqlBuilder := sq.Select(pipelineColumns...).From("pipelines").Where(sq.Eq{"Status": model.PipelineReady})
+	if filterContext.ExcludeDeprecated {
+		// The pipelines stored before deprecation was added have no value.
+		sqlBuilder = sqlBuilder.Where(sq.Or{sq.Eq{"Deprecated": nil}, sq.Eq{"Deprecated": false}})
+	}
+	if filterContext.Name != nil {
+		sqlBuilder = sqlBuilder.Where(s.db.MatchName("Name", filterContext.Name))
+	}
+	if filterContext.StarredBy != "" {
+		var err error
+		if sqlBuilder, err = filterStarred(sqlBuilder, filterContext.StarredBy, common.Pipeline); err != nil {
+			return nil, err
+		}
+	}
 	sql, args, err := toPaginationQuery(sqlBuilder, context).Limit(uint64(context.PageSize)).ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to list pipelines: %v",
@@ -72,7 +110,12 @@ func (s *PipelineStore) scanRows(rows *sql.Rows) ([]model.Pipeline, error) {
 		var uuid, name, parameters, description string
 		var createdAtInSec int64
 		var status model.PipelineStatus
-		if err := rows.Scan(&uuid, &createdAtInSec, &name, &description, &parameters, &status); err != nil {
+		var deprecated sql.NullBool
+		var replacementPipelineId sql.NullString
+		var sunsetAtInSec sql.NullInt64
+		var parameterSchema, templateChecksum sql.NullString
+		if err := rows.Scan(&uuid, &createdAtInSec, &name, &description, &parameters, &status,
+			&deprecated, &replacementPipelineId, &sunsetAtInSec, &parameterSchema, &templateChecksum); err != nil {
 			return pipelines, err
 		}
 		pipelines = append(pipelines, model.Pipeline{
@@ -81,7 +124,13 @@ func (s *PipelineStore) scanRows(rows *sql.Rows) ([]model.Pipeline, error) {
 			Name:           name,
 			Description:    description,
 			Parameters:     parameters,
-			Status:         status})
+			Status:         status,
+
+			Deprecated:            deprecated.Bool,
+			ReplacementPipelineId: replacementPipelineId.String,
+			SunsetAtInSec:         sunsetAtInSec.Int64,
+			ParameterSchema:       parameterSchema.String,
+			TemplateChecksum:      templateChecksum.String})
 	}
 	return pipelines, nil
 }
@@ -92,7 +141,7 @@ func (s *PipelineStore) GetPipeline(id string) (*model.Pipeline, error) {
 
 func (s *PipelineStore) GetPipelineWithStatus(id string, status model.PipelineStatus) (*model.Pipeline, error) {
 	sql, args, err := sq.
-		Select("*").
+		Select(pipelineColumns...).
 		From("pipelines").
 		Where(sq.Eq{"uuid": id}).
 		Where(sq.Eq{"status": status}).
@@ -116,21 +165,150 @@ func (s *PipelineStore) GetPipelineWithStatus(id string, status model.PipelineSt
 	return &pipelines[0], nil
 }
 
+func (s *PipelineStore) GetPipelineByName(name string) (*model.Pipeline, error) {
+	sql, args, err := sq.
+		Select(pipelineColumns...).
+		From("pipelines").
+		Where(sq.Eq{"Name": util.NormalizeName(name)}).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get pipeline: %v", err.Error())
+	}
+	r, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get pipeline: %v", err.Error())
+	}
+	defer r.Close()
+	pipelines, err := s.scanRows(r)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get pipeline: %v", err.Error())
+	}
+	if len(pipelines) == 0 {
+		return nil, util.NewResourceNotFoundError("Pipeline", name)
+	}
+	return &pipelines[0], nil
+}
+
+func (s *PipelineStore) GetPipelineStatuses() (map[string]model.PipelineStatus, error) {
+	sql, args, err := sq.Select("UUID", "Status").From("pipelines").ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the pipeline statuses")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the pipeline statuses")
+	}
+	defer rows.Close()
+	statuses := make(map[string]model.PipelineStatus)
+	for rows.Next() {
+		var id string
+		var status model.PipelineStatus
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse the pipeline statuses")
+		}
+		statuses[id] = status
+	}
+	return statuses, nil
+}
+
 func (s *PipelineStore) DeletePipeline(id string) error {
 	sql, args, err := sq.Delete("pipelines").Where(sq.Eq{"UUID": id}).ToSql()
 	if err != nil {
 		return util.NewInternalServerError(err, "Failed to create query to delete pipeline: %v", err.Error())
 	}
+	stepsSql, stepsArgs, err := sq.Delete("pipeline_steps").Where(sq.Eq{"PipelineUUID": id}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete the steps of pipeline: %v", err.Error())
+	}
 
-	_, err = s.db.Exec(sql, args...)
+	tx, err := s.db.Begin()
 	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to delete pipeline.")
+	}
+	if _, err = tx.Exec(stepsSql, stepsArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to delete the steps of pipeline: %v", err.Error())
+	}
+	if _, err = tx.Exec(sql, args...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to delete pipeline: %v", err.Error())
+	}
+	if err = tx.Commit(); err != nil {
 		return util.NewInternalServerError(err, "Failed to delete pipeline: %v", err.Error())
 	}
 	return nil
 }
 
+func (s *PipelineStore) CreatePipelineSteps(pipelineId string, steps []*model.PipelineStep) error {
+	deleteSql, deleteArgs, err := sq.Delete("pipeline_steps").Where(sq.Eq{"PipelineUUID": pipelineId}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete the steps of pipeline: %v", err.Error())
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to store the steps of pipeline.")
+	}
+	if _, err = tx.Exec(deleteSql, deleteArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to delete the steps of pipeline: %v", err.Error())
+	}
+	if len(steps) > 0 {
+		insertBuilder := sq.
+			Insert("pipeline_steps").
+			Columns("PipelineUUID", "Name", "Position", "Type", "Image", "Children")
+		for _, step := range steps {
+			insertBuilder = insertBuilder.Values(
+				pipelineId, step.Name, step.Position, step.Type, step.Image, step.Children)
+		}
+		insertSql, insertArgs, err := insertBuilder.ToSql()
+		if err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to create query to store the steps of pipeline: %v", err.Error())
+		}
+		if _, err = tx.Exec(insertSql, insertArgs...); err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to store the steps of pipeline: %v", err.Error())
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the steps of pipeline: %v", err.Error())
+	}
+	return nil
+}
+
+func (s *PipelineStore) ListPipelineSteps(pipelineId string) ([]*model.PipelineStep, error) {
+	sql, args, err := sq.
+		Select("PipelineUUID", "Name", "Position", "Type", "Image", "Children").
+		From("pipeline_steps").
+		Where(sq.Eq{"PipelineUUID": pipelineId}).
+		OrderBy("Position").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the steps of pipeline: %v", err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the steps of pipeline: %v", err.Error())
+	}
+	defer rows.Close()
+	var steps []*model.PipelineStep
+	for rows.Next() {
+		var step model.PipelineStep
+		if err := rows.Scan(&step.PipelineUUID, &step.Name, &step.Position, &step.Type, &step.Image, &step.Children); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to list the steps of pipeline: %v", err.Error())
+		}
+		steps = append(steps, &step)
+	}
+	return steps, nil
+}
+
 func (s *PipelineStore) CreatePipeline(p *model.Pipeline) (*model.Pipeline, error) {
 	newPipeline := *p
+	name, err := util.ValidateName(p.Name, util.MaxNameLength)
+	if err != nil {
+		return nil, util.Wrap(err, "Invalid pipeline name.")
+	}
+	newPipeline.Name = name
 	now := s.time.Now().Unix()
 	newPipeline.CreatedAtInSec = now
 	id, err := s.uuid.NewRandom()
@@ -147,7 +325,13 @@ func (s *PipelineStore) CreatePipeline(p *model.Pipeline) (*model.Pipeline, erro
 				"Name":           newPipeline.Name,
 				"Description":    newPipeline.Description,
 				"Parameters":     newPipeline.Parameters,
-				"Status":         string(newPipeline.Status)}).
+				"Status":         string(newPipeline.Status),
+
+				"Deprecated":            newPipeline.Deprecated,
+				"ReplacementPipelineId": newPipeline.ReplacementPipelineId,
+				"SunsetAtInSec":         newPipeline.SunsetAtInSec,
+				"ParameterSchema":       newPipeline.ParameterSchema,
+				"TemplateChecksum":      newPipeline.TemplateChecksum}).
 		ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to insert pipeline to pipeline table: %v",
@@ -156,8 +340,8 @@ func (s *PipelineStore) CreatePipeline(p *model.Pipeline) (*model.Pipeline, erro
 	_, err = s.db.Exec(sql, args...)
 	if err != nil {
 		if s.db.IsDuplicateError(err) {
-			return nil, util.NewInvalidInputError(
-				"Failed to create a new pipeline. The name %v already exist. Please specify a new name.", p.Name)
+			return nil, util.NewAlreadyExistError(
+				"Failed to create a new pipeline. The name %v already exist. Please specify a new name.", newPipeline.Name)
 		}
 		return nil, util.NewInternalServerError(err, "Failed to add pipeline to pipeline table: %v",
 			err.Error())
@@ -181,6 +365,44 @@ func (s *PipelineStore) UpdatePipelineStatus(id string, status model.PipelineSta
 	return nil
 }
 
+func (s *PipelineStore) UpdatePipelineDefinition(id string, description string, parameters string,
+	parameterSchema string, templateChecksum string) error {
+	sql, args, err := sq.
+		Update("pipelines").
+		SetMap(sq.Eq{"Description": description, "Parameters": parameters, "ParameterSchema": parameterSchema,
+			"TemplateChecksum": templateChecksum}).
+		Where(sq.Eq{"UUID": id}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the pipeline definition: %s", err.Error())
+	}
+	_, err = s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update the pipeline definition: %s", err.Error())
+	}
+	return nil
+}
+
+func (s *PipelineStore) UpdatePipelineDeprecation(id string, deprecated bool, replacementPipelineId string,
+	sunsetAtInSec int64) error {
+	sql, args, err := sq.
+		Update("pipelines").
+		SetMap(sq.Eq{
+			"Deprecated":            deprecated,
+			"ReplacementPipelineId": replacementPipelineId,
+			"SunsetAtInSec":         sunsetAtInSec}).
+		Where(sq.Eq{"UUID": id}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the pipeline deprecation: %s", err.Error())
+	}
+	_, err = s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to update the pipeline deprecation: %s", err.Error())
+	}
+	return nil
+}
+
 func (s *PipelineStore) toListablePipelines(pipelines []model.Pipeline) []model.ListableDataModel {
 	models := make([]model.ListableDataModel, len(pipelines))
 	for i := range models {
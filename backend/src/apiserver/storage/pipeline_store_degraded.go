@@ -0,0 +1,161 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// DegradedModePipelineStore serves the pipeline metadata last read from the database while
+// the database is unavailable, so that the pipelines can still be browsed. The writes fail
+// as UNAVAILABLE.
+type DegradedModePipelineStore struct {
+	PipelineStoreInterface
+	// The pipelines and their steps by pipeline ID, and the pages of pipelines by list request.
+	pipelines *lru.Cache
+	steps     *lru.Cache
+	lists     *lru.Cache
+}
+
+type pipelinePage struct {
+	pipelines []model.Pipeline
+	pageToken string
+}
+
+// NewDegradedModePipelineStore wraps a pipeline store, caching up to size entries of each
+// kind. The store is returned as is if the size isn't positive.
+func NewDegradedModePipelineStore(store PipelineStoreInterface, size int) PipelineStoreInterface {
+	if size <= 0 {
+		return store
+	}
+	// New only fails if the size isn't positive.
+	pipelines, _ := lru.New(size)
+	steps, _ := lru.New(size)
+	lists, _ := lru.New(size)
+	return &DegradedModePipelineStore{PipelineStoreInterface: store, pipelines: pipelines, steps: steps, lists: lists}
+}
+
+func (s *DegradedModePipelineStore) ListPipelines(filterContext *common.FilterContext, context *common.PaginationContext) (
+	[]model.Pipeline, string, error) {
+	key := fmt.Sprintf("%v/%v/%v/%v/%v/%v", filterContext.StarredBy, filterContext.ExcludeDeprecated,
+		context.PageSize, context.SortByFieldName, context.KeyFieldName, context.IsDesc)
+	if context.Token != nil {
+		key += fmt.Sprintf("/%+v", *context.Token)
+	}
+	if filterContext.Name != nil {
+		key += fmt.Sprintf("/%+v", *filterContext.Name)
+	}
+	pipelines, pageToken, err := s.PipelineStoreInterface.ListPipelines(filterContext, context)
+	if err == nil {
+		s.lists.Add(key, &pipelinePage{pipelines: pipelines, pageToken: pageToken})
+		return pipelines, pageToken, nil
+	}
+	if value, ok := s.lists.Get(key); ok && util.IsUnavailableError(err) {
+		glog.Warningf("Serving cached pipelines, the database being unavailable: %v", err)
+		page := value.(*pipelinePage)
+		return append([]model.Pipeline(nil), page.pipelines...), page.pageToken, nil
+	}
+	return nil, "", err
+}
+
+func (s *DegradedModePipelineStore) GetPipeline(pipelineId string) (*model.Pipeline, error) {
+	pipeline, err := s.PipelineStoreInterface.GetPipeline(pipelineId)
+	if err == nil {
+		s.pipelines.Add(pipelineId, *pipeline)
+		return pipeline, nil
+	}
+	if value, ok := s.pipelines.Get(pipelineId); ok && util.IsUnavailableError(err) {
+		glog.Warningf("Serving cached pipeline %v, the database being unavailable: %v", pipelineId, err)
+		cachedPipeline := value.(model.Pipeline)
+		return &cachedPipeline, nil
+	}
+	return nil, err
+}
+
+func (s *DegradedModePipelineStore) ListPipelineSteps(pipelineId string) ([]*model.PipelineStep, error) {
+	steps, err := s.PipelineStoreInterface.ListPipelineSteps(pipelineId)
+	if err == nil {
+		s.steps.Add(pipelineId, steps)
+		return steps, nil
+	}
+	if value, ok := s.steps.Get(pipelineId); ok && util.IsUnavailableError(err) {
+		glog.Warningf("Serving the cached steps of pipeline %v, the database being unavailable: %v", pipelineId, err)
+		return value.([]*model.PipelineStep), nil
+	}
+	return nil, err
+}
+
+func (s *DegradedModePipelineStore) CreatePipeline(pipeline *model.Pipeline) (*model.Pipeline, error) {
+	newPipeline, err := s.PipelineStoreInterface.CreatePipeline(pipeline)
+	if err == nil {
+		s.lists.Purge()
+	}
+	return newPipeline, err
+}
+
+func (s *DegradedModePipelineStore) DeletePipeline(pipelineId string) error {
+	err := s.PipelineStoreInterface.DeletePipeline(pipelineId)
+	if err == nil {
+		s.remove(pipelineId)
+	}
+	return err
+}
+
+func (s *DegradedModePipelineStore) UpdatePipelineStatus(pipelineId string, status model.PipelineStatus) error {
+	err := s.PipelineStoreInterface.UpdatePipelineStatus(pipelineId, status)
+	if err == nil {
+		s.remove(pipelineId)
+	}
+	return err
+}
+
+func (s *DegradedModePipelineStore) UpdatePipelineDefinition(pipelineId string, description string,
+	parameters string, parameterSchema string, templateChecksum string) error {
+	err := s.PipelineStoreInterface.UpdatePipelineDefinition(pipelineId, description, parameters, parameterSchema,
+		templateChecksum)
+	if err == nil {
+		s.remove(pipelineId)
+	}
+	return err
+}
+
+func (s *DegradedModePipelineStore) UpdatePipelineDeprecation(pipelineId string, deprecated bool,
+	replacementPipelineId string, sunsetAtInSec int64) error {
+	err := s.PipelineStoreInterface.UpdatePipelineDeprecation(pipelineId, deprecated, replacementPipelineId, sunsetAtInSec)
+	if err == nil {
+		s.remove(pipelineId)
+	}
+	return err
+}
+
+func (s *DegradedModePipelineStore) CreatePipelineSteps(pipelineId string, steps []*model.PipelineStep) error {
+	err := s.PipelineStoreInterface.CreatePipelineSteps(pipelineId, steps)
+	if err == nil {
+		s.steps.Remove(pipelineId)
+	}
+	return err
+}
+
+func (s *DegradedModePipelineStore) remove(pipelineId string) {
+	s.pipelines.Remove(pipelineId)
+	s.steps.Remove(pipelineId)
+	s.lists.Purge()
+}
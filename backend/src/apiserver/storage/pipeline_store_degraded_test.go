@@ -0,0 +1,93 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// unavailablePipelineStore fails the reads as UNAVAILABLE once the database is down.
+type unavailablePipelineStore struct {
+	PipelineStoreInterface
+	down bool
+}
+
+func (s *unavailablePipelineStore) err() error {
+	return util.NewInternalServerError(util.NewUnavailableError(fmt.Errorf("connection refused"),
+		"The database is unavailable"), "Failed to query the pipelines")
+}
+
+func (s *unavailablePipelineStore) ListPipelines(filterContext *common.FilterContext, context *common.PaginationContext) (
+	[]model.Pipeline, string, error) {
+	if s.down {
+		return nil, "", s.err()
+	}
+	return s.PipelineStoreInterface.ListPipelines(filterContext, context)
+}
+
+func (s *unavailablePipelineStore) GetPipeline(pipelineId string) (*model.Pipeline, error) {
+	if s.down {
+		return nil, s.err()
+	}
+	return s.PipelineStoreInterface.GetPipeline(pipelineId)
+}
+
+func TestDegradedModePipelineStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := &unavailablePipelineStore{
+		PipelineStoreInterface: NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil)),
+	}
+	pipelineStore := NewDegradedModePipelineStore(store, 10)
+	_, err := pipelineStore.CreatePipeline(createPipeline("pipeline1"))
+	assert.Nil(t, err)
+	pipeline, err := pipelineStore.GetPipeline(fakeUUID)
+	assert.Nil(t, err)
+	listContext := &common.PaginationContext{PageSize: 10, SortByFieldName: "Name", KeyFieldName: "UUID"}
+	pipelines, _, err := pipelineStore.ListPipelines(&common.FilterContext{}, listContext)
+	assert.Nil(t, err)
+	assert.Len(t, pipelines, 1)
+
+	// The pipelines last read are served while the database is unavailable.
+	store.down = true
+	cachedPipeline, err := pipelineStore.GetPipeline(fakeUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, pipeline, cachedPipeline)
+	cachedPipelines, _, err := pipelineStore.ListPipelines(&common.FilterContext{}, listContext)
+	assert.Nil(t, err)
+	assert.Equal(t, pipelines, cachedPipelines)
+
+	// The pipelines that weren't read aren't.
+	_, err = pipelineStore.GetPipeline(fakeUUIDTwo)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	_, _, err = pipelineStore.ListPipelines(&common.FilterContext{}, &common.PaginationContext{PageSize: 5, SortByFieldName: "Name", KeyFieldName: "UUID"})
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+
+	// Nor the deleted pipelines.
+	store.down = false
+	assert.Nil(t, pipelineStore.DeletePipeline(fakeUUID))
+	store.down = true
+	_, err = pipelineStore.GetPipeline(fakeUUID)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+	_, _, err = pipelineStore.ListPipelines(&common.FilterContext{}, listContext)
+	assert.Equal(t, codes.Unavailable, err.(*util.UserError).ExternalStatusCode())
+}
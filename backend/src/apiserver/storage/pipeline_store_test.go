@@ -15,6 +15,7 @@
 package storage
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
@@ -58,7 +59,7 @@ func TestListPipelines_FilterOutNotReady(t *testing.T) {
 		Status:         model.PipelineReady}
 	pipelinesExpected := []model.Pipeline{expectedPipeline1, expectedPipeline2}
 
-	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.PaginationContext{
+	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        10,
 		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
 		SortByFieldName: model.GetPipelineTablePrimaryKeyColumn(),
@@ -69,6 +70,22 @@ func TestListPipelines_FilterOutNotReady(t *testing.T) {
 	assert.Equal(t, pipelinesExpected, pipelines)
 }
 
+func TestGetPipelineStatuses(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
+	pipelineStore.CreatePipeline(createPipeline("pipeline1"))
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeUUIDTwo, nil)
+	pipelineStore.CreatePipeline(&model.Pipeline{Name: "pipeline2", Status: model.PipelineCreating})
+
+	statuses, err := pipelineStore.GetPipelineStatuses()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]model.PipelineStatus{
+		fakeUUID:    model.PipelineReady,
+		fakeUUIDTwo: model.PipelineCreating,
+	}, statuses)
+}
+
 func TestListPipelines_Pagination(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -93,7 +110,7 @@ func TestListPipelines_Pagination(t *testing.T) {
 		Parameters:     `[{"Name": "param1"}]`,
 		Status:         model.PipelineReady}
 	pipelinesExpected := []model.Pipeline{expectedPipeline1, expectedPipeline4}
-	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.PaginationContext{
+	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
 		SortByFieldName: "Name",
@@ -117,7 +134,7 @@ func TestListPipelines_Pagination(t *testing.T) {
 		Status:         model.PipelineReady}
 	pipelinesExpected2 := []model.Pipeline{expectedPipeline2, expectedPipeline3}
 
-	pipelines, nextPageToken, err = pipelineStore.ListPipelines(
+	pipelines, nextPageToken, err = pipelineStore.ListPipelines(&common.FilterContext{},
 		&common.PaginationContext{
 			Token: &common.Token{
 				SortByFieldValue: "pipeline3",
@@ -158,7 +175,7 @@ func TestListPipelines_Pagination_Descend(t *testing.T) {
 		Parameters:     `[{"Name": "param1"}]`,
 		Status:         model.PipelineReady}
 	pipelinesExpected := []model.Pipeline{expectedPipeline3, expectedPipeline2}
-	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.PaginationContext{
+	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
 		SortByFieldName: "Name",
@@ -181,7 +198,7 @@ func TestListPipelines_Pagination_Descend(t *testing.T) {
 		Parameters:     `[{"Name": "param1"}]`,
 		Status:         model.PipelineReady}
 	pipelinesExpected2 := []model.Pipeline{expectedPipeline4, expectedPipeline1}
-	pipelines, nextPageToken, err = pipelineStore.ListPipelines(
+	pipelines, nextPageToken, err = pipelineStore.ListPipelines(&common.FilterContext{},
 		&common.PaginationContext{
 			Token: &common.Token{
 				SortByFieldValue: "pipeline2",
@@ -210,7 +227,7 @@ func TestListPipelines_Pagination_LessThanPageSize(t *testing.T) {
 		Status:         model.PipelineReady}
 	pipelinesExpected := []model.Pipeline{expectedPipeline1}
 
-	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.PaginationContext{
+	pipelines, nextPageToken, err := pipelineStore.ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:        2,
 		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
 		SortByFieldName: model.GetPipelineTablePrimaryKeyColumn(),
@@ -221,12 +238,49 @@ func TestListPipelines_Pagination_LessThanPageSize(t *testing.T) {
 	assert.Equal(t, pipelinesExpected, pipelines)
 }
 
+func TestListPipelines_NameFilter(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), nil)
+	for i, name := range []string{"xgboost-train", "XGBoost-eval", "xgboost_eval", "tfx"} {
+		pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fmt.Sprintf("123e4567-e89b-12d3-a456-42665544000%v", i), nil)
+		pipelineStore.CreatePipeline(createPipeline(name))
+	}
+	tests := []struct {
+		filter        common.NameFilter
+		expectedNames []string
+	}{
+		{common.NameFilter{Name: "xgboost-train", Match: common.NameMatchExact}, []string{"xgboost-train"}},
+		{common.NameFilter{Name: "XGBOOST-TRAIN", Match: common.NameMatchExact}, nil},
+		{common.NameFilter{Name: "XGBOOST-TRAIN", Match: common.NameMatchCaseInsensitive}, []string{"xgboost-train"}},
+		{common.NameFilter{Name: "xgb", Match: common.NameMatchPrefix},
+			[]string{"XGBoost-eval", "xgboost-train", "xgboost_eval"}},
+		// The wildcards of LIKE are matched literally.
+		{common.NameFilter{Name: "XGBoost_", Match: common.NameMatchPrefix}, []string{"xgboost_eval"}},
+		{common.NameFilter{Name: "%", Match: common.NameMatchPrefix}, nil},
+	}
+	for _, test := range tests {
+		filter := test.filter
+		pipelines, _, err := pipelineStore.ListPipelines(&common.FilterContext{Name: &filter}, &common.PaginationContext{
+			PageSize:        10,
+			KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
+			SortByFieldName: "Name",
+		})
+		assert.Nil(t, err)
+		var names []string
+		for _, pipeline := range pipelines {
+			names = append(names, pipeline.Name)
+		}
+		assert.Equal(t, test.expectedNames, names, "%+v", filter)
+	}
+}
+
 func TestListPipelinesError(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
 	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
 	db.Close()
-	_, _, err := pipelineStore.ListPipelines(&common.PaginationContext{
+	_, _, err := pipelineStore.ListPipelines(&common.FilterContext{}, &common.PaginationContext{
 		PageSize:     2,
 		KeyFieldName: model.GetPipelineTablePrimaryKeyColumn(),
 		IsDesc:       true,
@@ -300,6 +354,23 @@ func TestCreatePipeline(t *testing.T) {
 	assert.Equal(t, pipelineExpected, *pipeline, "Got unexpected pipeline.")
 }
 
+func TestCreatePipeline_NormalizedName(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
+	pipeline, err := pipelineStore.CreatePipeline(createPipeline("训练 cafe\u0301\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, "训练 caf\u00e9", pipeline.Name)
+
+	pipeline, err = pipelineStore.GetPipelineByName("训练 cafe\u0301")
+	assert.Nil(t, err)
+	assert.Equal(t, fakeUUID, pipeline.UUID)
+
+	_, err = pipelineStore.CreatePipeline(createPipeline("rocket \U0001F680"))
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "Invalid pipeline name")
+}
+
 func TestCreatePipeline_DuplicateKey(t *testing.T) {
 	db := NewFakeDbOrFatal()
 	defer db.Close()
@@ -373,3 +444,122 @@ func TestUpdatePipelineStatusError(t *testing.T) {
 	err := pipelineStore.UpdatePipelineStatus(fakeUUID, model.PipelineDeleting)
 	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode())
 }
+
+func TestGetPipelineByName(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
+	pipelineStore.CreatePipeline(&model.Pipeline{
+		Name:       "pipeline1",
+		Parameters: `[{"Name": "param1"}]`,
+		Status:     model.PipelineCreating,
+	})
+
+	// The pipelines are found whatever their status.
+	pipeline, err := pipelineStore.GetPipelineByName("pipeline1")
+	assert.Nil(t, err)
+	assert.Equal(t, fakeUUID, pipeline.UUID)
+	assert.Equal(t, model.PipelineCreating, pipeline.Status)
+
+	_, err = pipelineStore.GetPipelineByName("pipeline2")
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestUpdatePipelineDefinition(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
+	pipelineStore.CreatePipeline(createPipeline("pipeline1"))
+
+	err := pipelineStore.UpdatePipelineDefinition(fakeUUID, "new description", `[{"Name": "param2"}]`,
+		`[{"name":"param2","type":"integer"}]`, "checksum2")
+	assert.Nil(t, err)
+	pipeline, err := pipelineStore.GetPipeline(fakeUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.Pipeline{
+		UUID:             fakeUUID,
+		CreatedAtInSec:   1,
+		Name:             "pipeline1",
+		Description:      "new description",
+		Parameters:       `[{"Name": "param2"}]`,
+		Status:           model.PipelineReady,
+		ParameterSchema:  `[{"name":"param2","type":"integer"}]`,
+		TemplateChecksum: "checksum2",
+	}, *pipeline)
+}
+
+func TestUpdatePipelineDeprecation(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
+	pipelineStore.CreatePipeline(createPipeline("pipeline1"))
+	pipelineStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeUUIDTwo, nil)
+	pipelineStore.CreatePipeline(createPipeline("pipeline2"))
+
+	err := pipelineStore.UpdatePipelineDeprecation(fakeUUID, true, fakeUUIDTwo, 100)
+	assert.Nil(t, err)
+	pipeline, err := pipelineStore.GetPipeline(fakeUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.Pipeline{
+		UUID:                  fakeUUID,
+		CreatedAtInSec:        1,
+		Name:                  "pipeline1",
+		Parameters:            `[{"Name": "param1"}]`,
+		Status:                model.PipelineReady,
+		Deprecated:            true,
+		ReplacementPipelineId: fakeUUIDTwo,
+		SunsetAtInSec:         100,
+	}, *pipeline)
+
+	listPipelineNames := func(filterContext *common.FilterContext) []string {
+		pipelines, _, err := pipelineStore.ListPipelines(filterContext, &common.PaginationContext{
+			PageSize:        10,
+			KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
+			SortByFieldName: model.GetPipelineTablePrimaryKeyColumn(),
+		})
+		assert.Nil(t, err)
+		var names []string
+		for _, pipeline := range pipelines {
+			names = append(names, pipeline.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"pipeline1", "pipeline2"}, listPipelineNames(&common.FilterContext{}))
+	assert.Equal(t, []string{"pipeline2"}, listPipelineNames(&common.FilterContext{ExcludeDeprecated: true}))
+
+	err = pipelineStore.UpdatePipelineDeprecation(fakeUUID, false, "", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pipeline1", "pipeline2"}, listPipelineNames(&common.FilterContext{ExcludeDeprecated: true}))
+}
+
+func TestCreatePipelineSteps(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	pipelineStore := NewPipelineStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeUUID, nil))
+	_, err := pipelineStore.CreatePipeline(createPipeline("pipeline1"))
+	assert.Nil(t, err)
+
+	err = pipelineStore.CreatePipelineSteps(fakeUUID, []*model.PipelineStep{
+		{Name: "old", Type: "container", Image: "old:1", Children: "[]"},
+	})
+	assert.Nil(t, err)
+	// The steps replace the ones of a previous indexing.
+	stepsExpected := []*model.PipelineStep{
+		{PipelineUUID: fakeUUID, Name: "main", Position: 0, Type: "dag", Children: `["train"]`},
+		{PipelineUUID: fakeUUID, Name: "train", Position: 1, Type: "container", Image: "train:1", Children: "[]"},
+	}
+	err = pipelineStore.CreatePipelineSteps(fakeUUID, []*model.PipelineStep{
+		{Name: "main", Type: "dag", Children: `["train"]`},
+		{Name: "train", Position: 1, Type: "container", Image: "train:1", Children: "[]"},
+	})
+	assert.Nil(t, err)
+	steps, err := pipelineStore.ListPipelineSteps(fakeUUID)
+	assert.Nil(t, err)
+	assert.Equal(t, stepsExpected, steps)
+
+	// The steps are deleted with the pipeline.
+	assert.Nil(t, pipelineStore.DeletePipeline(fakeUUID))
+	steps, err = pipelineStore.ListPipelineSteps(fakeUUID)
+	assert.Nil(t, err)
+	assert.Empty(t, steps)
+}
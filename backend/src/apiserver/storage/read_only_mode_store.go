@@ -0,0 +1,100 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The ID of the row of the read-only mode, the only one of its table.
+const readOnlyModeId = "default"
+
+type ReadOnlyModeStoreInterface interface {
+	// GetReadOnlyMode returns the stored mode, which is off if it was never set.
+	GetReadOnlyMode() (*model.ReadOnlyMode, error)
+	SetReadOnlyMode(mode *model.ReadOnlyMode) error
+}
+
+type ReadOnlyModeStore struct {
+	db *DB
+}
+
+func (s *ReadOnlyModeStore) GetReadOnlyMode() (*model.ReadOnlyMode, error) {
+	sql, args, err := sq.
+		Select("ID", "ReadOnly", "Message", "UpdatedAtInSec", "UpdatedBy").
+		From("read_only_modes").
+		Where(sq.Eq{"ID": readOnlyModeId}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the read-only mode")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the read-only mode")
+	}
+	defer rows.Close()
+	mode := &model.ReadOnlyMode{ID: readOnlyModeId}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to read the read-only mode")
+		}
+		return mode, nil
+	}
+	if err := rows.Scan(&mode.ID, &mode.ReadOnly, &mode.Message, &mode.UpdatedAtInSec, &mode.UpdatedBy); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read the read-only mode")
+	}
+	return mode, nil
+}
+
+func (s *ReadOnlyModeStore) SetReadOnlyMode(mode *model.ReadOnlyMode) error {
+	deleteSql, deleteArgs, err := sq.Delete("read_only_modes").Where(sq.Eq{"ID": readOnlyModeId}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to set the read-only mode")
+	}
+	insertSql, insertArgs, err := sq.
+		Insert("read_only_modes").
+		SetMap(sq.Eq{
+			"ID":             readOnlyModeId,
+			"ReadOnly":       mode.ReadOnly,
+			"Message":        mode.Message,
+			"UpdatedAtInSec": mode.UpdatedAtInSec,
+			"UpdatedBy":      mode.UpdatedBy}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to set the read-only mode")
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to set the read-only mode")
+	}
+	if _, err := tx.Exec(deleteSql, deleteArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to set the read-only mode")
+	}
+	if _, err := tx.Exec(insertSql, insertArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to set the read-only mode")
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to set the read-only mode")
+	}
+	return nil
+}
+
+func NewReadOnlyModeStore(db *DB) *ReadOnlyModeStore {
+	return &ReadOnlyModeStore{db: db}
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyModeStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewReadOnlyModeStore(db)
+
+	mode, err := store.GetReadOnlyMode()
+	assert.Nil(t, err)
+	assert.Equal(t, &model.ReadOnlyMode{ID: readOnlyModeId}, mode)
+
+	err = store.SetReadOnlyMode(&model.ReadOnlyMode{ReadOnly: true, Message: "Backup", UpdatedAtInSec: 1, UpdatedBy: "admin"})
+	assert.Nil(t, err)
+	mode, err = store.GetReadOnlyMode()
+	assert.Nil(t, err)
+	assert.Equal(t, &model.ReadOnlyMode{
+		ID: readOnlyModeId, ReadOnly: true, Message: "Backup", UpdatedAtInSec: 1, UpdatedBy: "admin"}, mode)
+
+	err = store.SetReadOnlyMode(&model.ReadOnlyMode{ReadOnly: false, UpdatedAtInSec: 2, UpdatedBy: "other-admin"})
+	assert.Nil(t, err)
+	mode, err = store.GetReadOnlyMode()
+	assert.Nil(t, err)
+	assert.Equal(t, &model.ReadOnlyMode{
+		ID: readOnlyModeId, ReadOnly: false, UpdatedAtInSec: 2, UpdatedBy: "other-admin"}, mode)
+}
+
+func TestReadOnlyModeStore_DBError(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	store := NewReadOnlyModeStore(db)
+	db.Close()
+
+	_, err := store.GetReadOnlyMode()
+	assert.NotNil(t, err)
+	err = store.SetReadOnlyMode(&model.ReadOnlyMode{ReadOnly: true})
+	assert.NotNil(t, err)
+}
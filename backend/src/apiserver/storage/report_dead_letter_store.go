@@ -0,0 +1,155 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var reportDeadLetterColumns = []string{
+	"RunUUID", "Namespace", "Name", "Workflow", "Error", "Attempts", "FailedAtInSec"}
+
+type ReportDeadLetterStoreInterface interface {
+	// PutDeadLetter stores the dead letter of a run, replacing the previous one, if any.
+	PutDeadLetter(letter *model.ReportDeadLetter) error
+	GetDeadLetter(runUUID string) (*model.ReportDeadLetter, error)
+	// ListDeadLetters returns the dead letters, the oldest failure first.
+	ListDeadLetters() ([]*model.ReportDeadLetter, error)
+	DeleteDeadLetter(runUUID string) error
+}
+
+type ReportDeadLetterStore struct {
+	db *DB
+}
+
+func (s *ReportDeadLetterStore) PutDeadLetter(letter *model.ReportDeadLetter) error {
+	workflow := letter.Workflow
+	if err := compressManifests(&workflow); err != nil {
+		return util.Wrap(err, "Failed to store the dead letter of a report")
+	}
+	deleteSql, deleteArgs, err := sq.Delete("report_dead_letters").Where(sq.Eq{"RunUUID": letter.RunUUID}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the dead letter of run %v",
+			letter.RunUUID)
+	}
+	insertSql, insertArgs, err := sq.
+		Insert("report_dead_letters").
+		SetMap(sq.Eq{
+			"RunUUID":       letter.RunUUID,
+			"Namespace":     letter.Namespace,
+			"Name":          letter.Name,
+			"Workflow":      workflow,
+			"Error":         letter.Error,
+			"Attempts":      letter.Attempts,
+			"FailedAtInSec": letter.FailedAtInSec}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the dead letter of run %v",
+			letter.RunUUID)
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to store the dead letter")
+	}
+	if _, err := tx.Exec(deleteSql, deleteArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store the dead letter of run %v", letter.RunUUID)
+	}
+	if _, err := tx.Exec(insertSql, insertArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store the dead letter of run %v", letter.RunUUID)
+	}
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store the dead letter of run %v", letter.RunUUID)
+	}
+	return nil
+}
+
+func (s *ReportDeadLetterStore) GetDeadLetter(runUUID string) (*model.ReportDeadLetter, error) {
+	letters, err := s.queryDeadLetters(sq.Eq{"RunUUID": runUUID})
+	if err != nil {
+		return nil, err
+	}
+	if len(letters) == 0 {
+		return nil, util.NewResourceNotFoundError("Dead letter", runUUID)
+	}
+	return letters[0], nil
+}
+
+func (s *ReportDeadLetterStore) ListDeadLetters() ([]*model.ReportDeadLetter, error) {
+	return s.queryDeadLetters(nil)
+}
+
+func (s *ReportDeadLetterStore) queryDeadLetters(where sq.Sqlizer) ([]*model.ReportDeadLetter, error) {
+	query := sq.Select(reportDeadLetterColumns...).From("report_dead_letters")
+	if where != nil {
+		query = query.Where(where)
+	}
+	sql, args, err := query.OrderBy("FailedAtInSec", "RunUUID").ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the dead letters")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the dead letters")
+	}
+	defer rows.Close()
+	letters, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the dead letters")
+	}
+	return letters, nil
+}
+
+func (s *ReportDeadLetterStore) DeleteDeadLetter(runUUID string) error {
+	sql, args, err := sq.Delete("report_dead_letters").Where(sq.Eq{"RunUUID": runUUID}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete the dead letter of run %v", runUUID)
+	}
+	result, err := s.db.Exec(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to delete the dead letter of run %v", runUUID)
+	}
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return util.NewResourceNotFoundError("Dead letter", runUUID)
+	}
+	return nil
+}
+
+func (s *ReportDeadLetterStore) scanRows(rows *sql.Rows) ([]*model.ReportDeadLetter, error) {
+	var letters []*model.ReportDeadLetter
+	for rows.Next() {
+		var letter model.ReportDeadLetter
+		if err := rows.Scan(&letter.RunUUID, &letter.Namespace, &letter.Name, &letter.Workflow, &letter.Error,
+			&letter.Attempts, &letter.FailedAtInSec); err != nil {
+			return letters, err
+		}
+		if err := decompressManifests(&letter.Workflow); err != nil {
+			return letters, err
+		}
+		letters = append(letters, &letter)
+	}
+	return letters, nil
+}
+
+// factory function for report dead letter store
+func NewReportDeadLetterStore(db *DB) *ReportDeadLetterStore {
+	return &ReportDeadLetterStore{db: db}
+}
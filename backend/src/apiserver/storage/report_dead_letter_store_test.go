@@ -0,0 +1,58 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestReportDeadLetterStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewReportDeadLetterStore(db)
+
+	_, err := store.GetDeadLetter(fakeID)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	assert.Nil(t, store.PutDeadLetter(&model.ReportDeadLetter{RunUUID: fakeID, Namespace: "ns", Name: "workflow1",
+		Workflow: `{"metadata":{"name":"workflow1"}}`, Error: "bad data", Attempts: 1, FailedAtInSec: 2}))
+	assert.Nil(t, store.PutDeadLetter(&model.ReportDeadLetter{RunUUID: "run2", Namespace: "ns", Name: "workflow2",
+		Workflow: `{"metadata":{"name":"workflow2"}}`, Error: "bad data", Attempts: 1, FailedAtInSec: 1}))
+	// The next failure replaces the dead letter.
+	letter := &model.ReportDeadLetter{RunUUID: fakeID, Namespace: "ns", Name: "workflow1",
+		Workflow: `{"metadata":{"name":"workflow1"}}`, Error: "no such column", Attempts: 2, FailedAtInSec: 3}
+	assert.Nil(t, store.PutDeadLetter(letter))
+
+	stored, err := store.GetDeadLetter(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, letter, stored)
+	letters, err := store.ListDeadLetters()
+	assert.Nil(t, err)
+	assert.Len(t, letters, 2)
+	assert.Equal(t, "run2", letters[0].RunUUID)
+	assert.Equal(t, fakeID, letters[1].RunUUID)
+
+	assert.Nil(t, store.DeleteDeadLetter("run2"))
+	err = store.DeleteDeadLetter("run2")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	letters, err = store.ListDeadLetters()
+	assert.Nil(t, err)
+	assert.Len(t, letters, 1)
+}
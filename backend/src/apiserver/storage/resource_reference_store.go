@@ -3,8 +3,6 @@ package storage
 import (
 	"database/sql"
 
-	"fmt"
-
 	sq "github.com/Masterminds/squirrel"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
@@ -26,13 +24,13 @@ type ResourceReferenceStore struct {
 // This is always in company with creating a parent resource so a transaction is needed as input.
 func (s *ResourceReferenceStore) CreateResourceReferences(tx *sql.Tx, refs []*model.ResourceReference) error {
 	if len(refs) > 0 {
+		if err := s.checkReferencesExist(tx, refs); err != nil {
+			return err
+		}
 		resourceRefSqlBuilder := sq.
 			Insert("resource_references").
 			Columns("ResourceUUID", "ResourceType", "ReferenceUUID", "ReferenceType", "Relationship", "Payload")
 		for _, ref := range refs {
-			if !s.checkReferenceExist(tx, ref.ReferenceUUID, ref.ReferenceType) {
-				return util.NewResourceNotFoundError(string(ref.ReferenceType), ref.ReferenceUUID)
-			}
 			payload, err := json.Marshal(ref)
 			if err != nil {
 				return util.NewInternalServerError(err, "Failed to stream resource reference model to a json payload")
@@ -52,26 +50,49 @@ func (s *ResourceReferenceStore) CreateResourceReferences(tx *sql.Tx, refs []*mo
 	return nil
 }
 
-func (s *ResourceReferenceStore) checkReferenceExist(tx *sql.Tx, referenceId string, referenceType common.ResourceType) bool {
-	var selectBuilder sq.SelectBuilder
-	switch referenceType {
-	case common.Job:
-		selectBuilder = sq.Select("1").From("jobs").Where(sq.Eq{"uuid": referenceId})
-	case common.Experiment:
-		selectBuilder = sq.Select("1").From("experiments").Where(sq.Eq{"uuid": referenceId})
-	default:
-		return false
+// checkReferencesExist checks that the resources the references point to exist, with a single
+// query per type of resource.
+func (s *ResourceReferenceStore) checkReferencesExist(tx *sql.Tx, refs []*model.ResourceReference) error {
+	referenceIds := make(map[common.ResourceType][]string)
+	for _, ref := range refs {
+		referenceIds[ref.ReferenceType] = append(referenceIds[ref.ReferenceType], ref.ReferenceUUID)
 	}
-	query, args, err := selectBuilder.ToSql()
-	if err != nil {
-		return false
+	existing := make(map[common.ResourceType]map[string]bool)
+	for referenceType, ids := range referenceIds {
+		var table string
+		switch referenceType {
+		case common.Job:
+			table = "jobs"
+		case common.Experiment:
+			table = "experiments"
+		default:
+			return util.NewResourceNotFoundError(string(referenceType), ids[0])
+		}
+		query, args, err := sq.Select("UUID").From(table).Where(sq.Eq{"UUID": ids}).ToSql()
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to create query to check the referenced resources.")
+		}
+		rows, err := tx.Query(query, args...)
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to check the referenced resources.")
+		}
+		existing[referenceType] = make(map[string]bool)
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return util.NewInternalServerError(err, "Failed to check the referenced resources.")
+			}
+			existing[referenceType][id] = true
+		}
+		rows.Close()
 	}
-	var exists bool
-	err = tx.QueryRow(fmt.Sprintf("SELECT exists (%s)", query), args...).Scan(&exists)
-	if err != nil && err != sql.ErrNoRows {
-		return false
+	for _, ref := range refs {
+		if !existing[ref.ReferenceType][ref.ReferenceUUID] {
+			return util.NewResourceNotFoundError(string(ref.ReferenceType), ref.ReferenceUUID)
+		}
 	}
-	return exists
+	return nil
 }
 
 // Delete all resource references for a specific resource.
@@ -122,6 +143,42 @@ func (s *ResourceReferenceStore) GetResourceReference(resourceId string, resourc
 	return &reference[0], nil
 }
 
+// ListResourceReferences returns the resource references of a page of resources, keyed by
+// the resource ID. The references of the whole page are loaded with a single query.
+func (s *ResourceReferenceStore) ListResourceReferences(resourceIds []string,
+	resourceType common.ResourceType) (map[string][]*model.ResourceReference, error) {
+	refsByResource := map[string][]*model.ResourceReference{}
+	if len(resourceIds) == 0 {
+		return refsByResource, nil
+	}
+	sql, args, err := sq.Select("ResourceUUID", "Payload").
+		From("resource_references").
+		Where(sq.Eq{"ResourceUUID": resourceIds, "ResourceType": resourceType}).
+		OrderBy("ResourceUUID", "ReferenceType").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list resource references: %v",
+			err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list resource references: %v", err.Error())
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var resourceUUID, payload string
+		if err := rows.Scan(&resourceUUID, &payload); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan resource reference: %v", err.Error())
+		}
+		var ref model.ResourceReference
+		if err := json.Unmarshal([]byte(payload), &ref); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse resource reference '%s'", payload)
+		}
+		refsByResource[resourceUUID] = append(refsByResource[resourceUUID], &ref)
+	}
+	return refsByResource, nil
+}
+
 func (s *ResourceReferenceStore) scanRows(r *sql.Rows) ([]model.ResourceReference, error) {
 	var references []model.ResourceReference
 	for r.Next() {
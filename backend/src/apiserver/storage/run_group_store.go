@@ -0,0 +1,267 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var runGroupColumns = []string{"UUID", "Name", "Description", "CreatedAtInSec", "CancelledAtInSec"}
+
+type RunGroupStoreInterface interface {
+	// CreateRunGroup stores a group with its runs.
+	CreateRunGroup(group *model.RunGroup, runUUIDs []string) error
+	GetRunGroup(uuid string) (*model.RunGroup, error)
+	ListRunGroups(*common.PaginationContext) ([]model.RunGroup, string, error)
+	// ListRunGroupRuns returns the UUID, the name and the conditions of the runs of a group,
+	// ordered by UUID.
+	ListRunGroupRuns(groupUUID string) ([]*model.Run, error)
+	// AddRunsToRunGroup adds runs to a group, ignoring the ones already in it.
+	AddRunsToRunGroup(groupUUID string, runUUIDs []string) error
+	// CancelRunGroup records the time a group was cancelled, unless it already was.
+	CancelRunGroup(uuid string, cancelledAtInSec int64) error
+}
+
+type RunGroupStore struct {
+	db *DB
+}
+
+func (s *RunGroupStore) CreateRunGroup(group *model.RunGroup, runUUIDs []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to store the run group %v",
+			group.UUID)
+	}
+	if err := insertRunGroup(tx, group); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := insertRunGroupMembers(tx, group.UUID, runUUIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the run group %v", group.UUID)
+	}
+	return nil
+}
+
+func (s *RunGroupStore) GetRunGroup(uuid string) (*model.RunGroup, error) {
+	sql, args, err := sq.Select(runGroupColumns...).From("run_groups").Where(sq.Eq{"UUID": uuid}).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the run group %v", uuid)
+	}
+	groups, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the run group %v", uuid)
+	}
+	if len(groups) == 0 {
+		return nil, util.NewResourceNotFoundError("Run group", uuid)
+	}
+	return &groups[0], nil
+}
+
+func (s *RunGroupStore) ListRunGroups(context *common.PaginationContext) ([]model.RunGroup, string, error) {
+	models, pageToken, err := listModel(context, s.queryRunGroupTable)
+	if err != nil {
+		return nil, "", util.Wrap(err, "List run groups failed.")
+	}
+	groups := make([]model.RunGroup, len(models))
+	for i := range models {
+		groups[i] = models[i].(model.RunGroup)
+	}
+	return groups, pageToken, nil
+}
+
+func (s *RunGroupStore) queryRunGroupTable(context *common.PaginationContext) ([]model.ListableDataModel, error) {
+	sqlBuilder := sq.Select(runGroupColumns...).From("run_groups")
+	sql, args, err := toPaginationQuery(sqlBuilder, context).Limit(uint64(context.PageSize)).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list run groups: %v", err.Error())
+	}
+	groups, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list run groups: %v", err.Error())
+	}
+	models := make([]model.ListableDataModel, len(groups))
+	for i := range groups {
+		models[i] = groups[i]
+	}
+	return models, nil
+}
+
+func (s *RunGroupStore) ListRunGroupRuns(groupUUID string) ([]*model.Run, error) {
+	sql, args, err := sq.
+		Select("run_details.UUID", "run_details.DisplayName", "run_details.Name", "run_details.Namespace",
+			"run_details.Conditions",
+			// The column is NULL in the rows of a previous release which didn't have it.
+			"COALESCE(run_details.ExecutionTarget, '')").
+		From("run_group_members").
+		Join("run_details ON run_group_members.RunUUID = run_details.UUID").
+		Where(sq.Eq{"run_group_members.GroupUUID": groupUUID}).
+		OrderBy("run_details.UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the runs of the run group %v",
+			groupUUID)
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the runs of the run group %v", groupUUID)
+	}
+	defer rows.Close()
+	var runs []*model.Run
+	for rows.Next() {
+		var run model.Run
+		if err := rows.Scan(&run.UUID, &run.DisplayName, &run.Name, &run.Namespace, &run.Conditions,
+			&run.ExecutionTarget); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse the runs of the run group %v", groupUUID)
+		}
+		runs = append(runs, &run)
+	}
+	return runs, nil
+}
+
+func (s *RunGroupStore) AddRunsToRunGroup(groupUUID string, runUUIDs []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to update the run group %v",
+			groupUUID)
+	}
+	if err := insertRunGroupMembers(tx, groupUUID, runUUIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the run group %v", groupUUID)
+	}
+	return nil
+}
+
+func (s *RunGroupStore) CancelRunGroup(uuid string, cancelledAtInSec int64) error {
+	sql, args, err := sq.
+		Update("run_groups").
+		Set("CancelledAtInSec", cancelledAtInSec).
+		Where(sq.Eq{"UUID": uuid, "CancelledAtInSec": 0}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to cancel the run group %v", uuid)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to cancel the run group %v", uuid)
+	}
+	return nil
+}
+
+func (s *RunGroupStore) query(sql string, args []interface{}) ([]model.RunGroup, error) {
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanRows(rows)
+}
+
+func (s *RunGroupStore) scanRows(rows *sql.Rows) ([]model.RunGroup, error) {
+	var groups []model.RunGroup
+	for rows.Next() {
+		var group model.RunGroup
+		if err := rows.Scan(&group.UUID, &group.Name, &group.Description, &group.CreatedAtInSec,
+			&group.CancelledAtInSec); err != nil {
+			return groups, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// insertRunGroup inserts a group in a transaction, e.g. along with the sweep whose runs it
+// tracks.
+func insertRunGroup(tx *sql.Tx, group *model.RunGroup) error {
+	sql, args, err := sq.
+		Insert("run_groups").
+		SetMap(sq.Eq{
+			"UUID":             group.UUID,
+			"Name":             group.Name,
+			"Description":      group.Description,
+			"CreatedAtInSec":   group.CreatedAtInSec,
+			"CancelledAtInSec": group.CancelledAtInSec}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the run group %v", group.UUID)
+	}
+	if _, err := tx.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the run group %v", group.UUID)
+	}
+	return nil
+}
+
+// insertRunGroupMembers adds runs to a group in a transaction, ignoring the ones already in it.
+func insertRunGroupMembers(tx *sql.Tx, groupUUID string, runUUIDs []string) error {
+	if len(runUUIDs) == 0 {
+		return nil
+	}
+	existingSql, existingArgs, err := sq.
+		Select("RunUUID").
+		From("run_group_members").
+		Where(sq.Eq{"GroupUUID": groupUUID, "RunUUID": runUUIDs}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to get the runs of the run group %v",
+			groupUUID)
+	}
+	rows, err := tx.Query(existingSql, existingArgs...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get the runs of the run group %v", groupUUID)
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var runUUID string
+		if err := rows.Scan(&runUUID); err != nil {
+			rows.Close()
+			return util.NewInternalServerError(err, "Failed to parse the runs of the run group %v", groupUUID)
+		}
+		existing[runUUID] = true
+	}
+	rows.Close()
+	for _, runUUID := range runUUIDs {
+		if existing[runUUID] {
+			continue
+		}
+		existing[runUUID] = true
+		sql, args, err := sq.
+			Insert("run_group_members").
+			SetMap(sq.Eq{"GroupUUID": groupUUID, "RunUUID": runUUID}).
+			ToSql()
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to create query to add the run %v to the run group %v",
+				runUUID, groupUUID)
+		}
+		if _, err := tx.Exec(sql, args...); err != nil {
+			return util.NewInternalServerError(err, "Failed to add the run %v to the run group %v", runUUID, groupUUID)
+		}
+	}
+	return nil
+}
+
+// factory function for run group store
+func NewRunGroupStore(db *DB) *RunGroupStore {
+	return &RunGroupStore{db: db}
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRunGroupStore(t *testing.T) {
+	db, _ := initializeRunStore()
+	defer db.Close()
+	store := NewRunGroupStore(db)
+
+	group := &model.RunGroup{UUID: fakeID, Name: "group1", Description: "backfill", CreatedAtInSec: 1}
+	assert.Nil(t, store.CreateRunGroup(group, []string{"2"}))
+	stored, err := store.GetRunGroup(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, group, stored)
+	_, err = store.GetRunGroup("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	// The runs already in the group are ignored.
+	assert.Nil(t, store.AddRunsToRunGroup(fakeID, []string{"1", "2", "1"}))
+	runs, err := store.ListRunGroupRuns(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.Run{
+		{UUID: "1", Name: "run1", Namespace: "n1", Conditions: "running"},
+		{UUID: "2", Name: "run2", Namespace: "n2", Conditions: "done"},
+	}, runs)
+
+	assert.Nil(t, store.CancelRunGroup(fakeID, 5))
+	// The time of the first cancellation is kept.
+	assert.Nil(t, store.CancelRunGroup(fakeID, 6))
+	stored, err = store.GetRunGroup(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), stored.CancelledAtInSec)
+}
+
+func TestListRunGroups(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewRunGroupStore(db)
+	assert.Nil(t, store.CreateRunGroup(&model.RunGroup{UUID: fakeID, Name: "group1", CreatedAtInSec: 1}, nil))
+	assert.Nil(t, store.CreateRunGroup(&model.RunGroup{UUID: fakeIDTwo, Name: "group2", CreatedAtInSec: 2}, nil))
+
+	context := &common.PaginationContext{
+		PageSize:        1,
+		KeyFieldName:    model.GetRunGroupTablePrimaryKeyColumn(),
+		SortByFieldName: "CreatedAtInSec",
+	}
+	groups, nextPageToken, err := store.ListRunGroups(context)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, nextPageToken)
+	assert.Equal(t, []model.RunGroup{{UUID: fakeID, Name: "group1", CreatedAtInSec: 1}}, groups)
+}
@@ -0,0 +1,107 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var runStateTransitionColumns = []string{"RunUUID", "NodeID", "Sequence", "NodeName", "State", "TimeInSec", "Message"}
+
+type RunHistoryStoreInterface interface {
+	// AppendRunStateTransitions stores the transitions, skipping the ones of which the run,
+	// the node and the sequence are already stored, so that a report processed twice is
+	// recorded once.
+	AppendRunStateTransitions(transitions []*model.RunStateTransition) error
+	// ListRunStateTransitions returns the transitions of a run, or only the ones of a node of
+	// its workflow if nodeID isn't empty, in chronological order. If asOfInSec isn't 0, only
+	// the transitions up to that time are returned.
+	ListRunStateTransitions(runUUID string, nodeID string, asOfInSec int64) ([]*model.RunStateTransition, error)
+}
+
+type RunHistoryStore struct {
+	db *DB
+}
+
+func (s *RunHistoryStore) AppendRunStateTransitions(transitions []*model.RunStateTransition) error {
+	if len(transitions) == 0 {
+		return nil
+	}
+	insertBuilder := sq.Insert("run_state_transitions").Options(s.db.InsertIgnore()).Columns(runStateTransitionColumns...)
+	for _, transition := range transitions {
+		insertBuilder = insertBuilder.Values(transition.RunUUID, transition.NodeID, transition.Sequence,
+			transition.NodeName, transition.State, transition.TimeInSec, transition.Message)
+	}
+	sql, args, err := insertBuilder.ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the state transitions of run %v",
+			transitions[0].RunUUID)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the state transitions of run %v", transitions[0].RunUUID)
+	}
+	return nil
+}
+
+func (s *RunHistoryStore) ListRunStateTransitions(runUUID string, nodeID string, asOfInSec int64) (
+	[]*model.RunStateTransition, error) {
+	selectBuilder := sq.
+		Select(runStateTransitionColumns...).
+		From("run_state_transitions").
+		Where(sq.Eq{"RunUUID": runUUID})
+	if nodeID != "" {
+		selectBuilder = selectBuilder.Where(sq.Eq{"NodeID": nodeID})
+	}
+	if asOfInSec != 0 {
+		selectBuilder = selectBuilder.Where(sq.LtOrEq{"TimeInSec": asOfInSec})
+	}
+	// The sequence orders the transitions of a node reported within the same second.
+	sql, args, err := selectBuilder.OrderBy("TimeInSec", "Sequence", "NodeID").ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the state transitions of run %v", runUUID)
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the state transitions of run %v", runUUID)
+	}
+	defer rows.Close()
+	transitions, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the state transitions of run %v", runUUID)
+	}
+	return transitions, nil
+}
+
+func (s *RunHistoryStore) scanRows(rows *sql.Rows) ([]*model.RunStateTransition, error) {
+	var transitions []*model.RunStateTransition
+	for rows.Next() {
+		var transition model.RunStateTransition
+		if err := rows.Scan(&transition.RunUUID, &transition.NodeID, &transition.Sequence, &transition.NodeName,
+			&transition.State, &transition.TimeInSec, &transition.Message); err != nil {
+			return transitions, err
+		}
+		transitions = append(transitions, &transition)
+	}
+	return transitions, nil
+}
+
+// factory function for run history store
+func NewRunHistoryStore(db *DB) *RunHistoryStore {
+	return &RunHistoryStore{db: db}
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendRunStateTransitions(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewRunHistoryStore(db)
+
+	running := &model.RunStateTransition{RunUUID: "run1", State: "Running", TimeInSec: 10}
+	nodeRunning := &model.RunStateTransition{RunUUID: "run1", NodeID: "node1", NodeName: "train", State: "Running", TimeInSec: 10}
+	nodeFailed := &model.RunStateTransition{RunUUID: "run1", NodeID: "node1", Sequence: 1, NodeName: "train",
+		State: "Failed", TimeInSec: 10, Message: "OOMKilled"}
+	failed := &model.RunStateTransition{RunUUID: "run1", Sequence: 1, State: "Failed", TimeInSec: 12}
+	otherRun := &model.RunStateTransition{RunUUID: "run2", State: "Running", TimeInSec: 11}
+	assert.Nil(t, store.AppendRunStateTransitions([]*model.RunStateTransition{running, nodeRunning, otherRun}))
+	assert.Nil(t, store.AppendRunStateTransitions([]*model.RunStateTransition{nodeFailed, failed}))
+	// The transitions already stored are skipped.
+	assert.Nil(t, store.AppendRunStateTransitions([]*model.RunStateTransition{
+		{RunUUID: "run1", Sequence: 1, State: "Succeeded", TimeInSec: 13}}))
+	assert.Nil(t, store.AppendRunStateTransitions(nil))
+
+	// The transitions of the same second are in the order of the sequence.
+	transitions, err := store.ListRunStateTransitions("run1", "", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStateTransition{running, nodeRunning, nodeFailed, failed}, transitions)
+}
+
+func TestListRunStateTransitions_Filters(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewRunHistoryStore(db)
+
+	running := &model.RunStateTransition{RunUUID: "run1", State: "Running", TimeInSec: 10}
+	nodeRunning := &model.RunStateTransition{RunUUID: "run1", NodeID: "node1", State: "Running", TimeInSec: 11}
+	nodeSucceeded := &model.RunStateTransition{RunUUID: "run1", NodeID: "node1", Sequence: 1, State: "Succeeded", TimeInSec: 20}
+	assert.Nil(t, store.AppendRunStateTransitions([]*model.RunStateTransition{running, nodeRunning, nodeSucceeded}))
+
+	transitions, err := store.ListRunStateTransitions("run1", "node1", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStateTransition{nodeRunning, nodeSucceeded}, transitions)
+
+	transitions, err = store.ListRunStateTransitions("run1", "", 11)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStateTransition{running, nodeRunning}, transitions)
+
+	transitions, err = store.ListRunStateTransitions("run2", "", 0)
+	assert.Nil(t, err)
+	assert.Empty(t, transitions)
+}
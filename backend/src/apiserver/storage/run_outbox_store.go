@@ -0,0 +1,139 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var runOutboxEntryColumns = []string{
+	"UUID", "Workflow", "Run", "WorkflowSpecManifest", "SecretParameters", "MetricsPushToken", "CreatedAtInSec",
+	"Attempts"}
+
+type RunOutboxStoreInterface interface {
+	CreateEntry(*model.RunOutboxEntry) error
+	// ListEntries returns the oldest entries created before a time, up to a limit.
+	ListEntries(createdBeforeInSec int64, limit int) ([]*model.RunOutboxEntry, error)
+	// IncrementAttempts records that an attempt to create the run of an entry started.
+	IncrementAttempts(uuid string) error
+	DeleteEntry(uuid string) error
+}
+
+type RunOutboxStore struct {
+	db *DB
+}
+
+func (s *RunOutboxStore) CreateEntry(entry *model.RunOutboxEntry) error {
+	workflow, workflowSpecManifest := entry.Workflow, entry.WorkflowSpecManifest
+	if err := compressManifests(&workflow, &workflowSpecManifest); err != nil {
+		return util.Wrap(err, "Failed to store the outbox entry of a run")
+	}
+	sql, args, err := sq.
+		Insert("run_outbox_entries").
+		SetMap(sq.Eq{
+			"UUID":                 entry.UUID,
+			"Workflow":             workflow,
+			"Run":                  entry.Run,
+			"WorkflowSpecManifest": workflowSpecManifest,
+			"SecretParameters":     entry.SecretParameters,
+			"MetricsPushToken":     entry.MetricsPushToken,
+			"CreatedAtInSec":       entry.CreatedAtInSec,
+			"Attempts":             entry.Attempts}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to store the outbox entry %v", entry.UUID)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the outbox entry %v", entry.UUID)
+	}
+	return nil
+}
+
+func (s *RunOutboxStore) ListEntries(createdBeforeInSec int64, limit int) ([]*model.RunOutboxEntry, error) {
+	sql, args, err := sq.
+		Select(runOutboxEntryColumns...).
+		From("run_outbox_entries").
+		Where(sq.Lt{"CreatedAtInSec": createdBeforeInSec}).
+		OrderBy("CreatedAtInSec", "UUID").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the run outbox entries")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the run outbox entries")
+	}
+	defer rows.Close()
+	entries, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the run outbox entries")
+	}
+	return entries, nil
+}
+
+func (s *RunOutboxStore) IncrementAttempts(uuid string) error {
+	sql, args, err := sq.
+		Update("run_outbox_entries").
+		Set("Attempts", sq.Expr("Attempts + 1")).
+		Where(sq.Eq{"UUID": uuid}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the outbox entry %v", uuid)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the outbox entry %v", uuid)
+	}
+	return nil
+}
+
+func (s *RunOutboxStore) DeleteEntry(uuid string) error {
+	sql, args, err := sq.Delete("run_outbox_entries").Where(sq.Eq{"UUID": uuid}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete the outbox entry %v", uuid)
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete the outbox entry %v", uuid)
+	}
+	return nil
+}
+
+func (s *RunOutboxStore) scanRows(rows *sql.Rows) ([]*model.RunOutboxEntry, error) {
+	var entries []*model.RunOutboxEntry
+	for rows.Next() {
+		var entry model.RunOutboxEntry
+		var secretParameters, metricsPushToken sql.NullString
+		if err := rows.Scan(&entry.UUID, &entry.Workflow, &entry.Run, &entry.WorkflowSpecManifest,
+			&secretParameters, &metricsPushToken, &entry.CreatedAtInSec, &entry.Attempts); err != nil {
+			return entries, err
+		}
+		if err := decompressManifests(&entry.Workflow, &entry.WorkflowSpecManifest); err != nil {
+			return entries, err
+		}
+		entry.SecretParameters = secretParameters.String
+		entry.MetricsPushToken = metricsPushToken.String
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+// factory function for run outbox store
+func NewRunOutboxStore(db *DB) *RunOutboxStore {
+	return &RunOutboxStore{db: db}
+}
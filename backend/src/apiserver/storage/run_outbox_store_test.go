@@ -0,0 +1,55 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunOutboxStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewRunOutboxStore(db)
+
+	first := &model.RunOutboxEntry{
+		UUID: fakeID, Workflow: `{"metadata":{"name":"wf1"}}`, Run: `{"name":"run1"}`,
+		WorkflowSpecManifest: "spec1", MetricsPushToken: "token", CreatedAtInSec: 1, Attempts: 1}
+	second := &model.RunOutboxEntry{
+		UUID: fakeIDTwo, Workflow: `{"metadata":{"name":"wf2"}}`, Run: `{"name":"run2"}`,
+		WorkflowSpecManifest: "spec2", CreatedAtInSec: 2, Attempts: 1}
+	assert.Nil(t, store.CreateEntry(second))
+	assert.Nil(t, store.CreateEntry(first))
+
+	entries, err := store.ListEntries(3, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunOutboxEntry{first, second}, entries)
+	// The entries created since are not listed.
+	entries, err = store.ListEntries(2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunOutboxEntry{first}, entries)
+	entries, err = store.ListEntries(3, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunOutboxEntry{first}, entries)
+
+	assert.Nil(t, store.IncrementAttempts(fakeID))
+	assert.Nil(t, store.DeleteEntry(fakeIDTwo))
+	entries, err = store.ListEntries(3, 10)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, 2, entries[0].Attempts)
+}
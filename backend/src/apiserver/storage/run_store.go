@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	sq "github.com/Masterminds/squirrel"
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	"github.com/golang/glog"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
 	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
@@ -26,22 +27,75 @@ import (
 	"k8s.io/apimachinery/pkg/util/json"
 )
 
+// RunStoreInterface stores the runs, their resource references and their metrics. The
+// implementations are tested against its contract by storagetest.TestRunStore.
 type RunStoreInterface interface {
+	// Get the run, or fail with NOT_FOUND.
 	GetRun(runId string) (*model.RunDetail, error)
 
-	ListRuns(filterContext *common.FilterContext, pagination *common.PaginationContext) ([]model.Run, string, error)
+	// ListRuns lists the runs. The manifests of their pipeline specs are only loaded with the
+	// full view.
+	ListRuns(filterContext *common.FilterContext, pagination *common.PaginationContext,
+		view common.ListView) ([]model.Run, string, error)
 
 	// Create a run entry in the database
 	CreateRun(run *model.RunDetail) (*model.RunDetail, error)
 
-	// Update run table. Only condition and runtime manifest is allowed to be updated.
+	// Update run table. Only condition and runtime manifest is allowed to be updated. Fails
+	// with INVALID_ARGUMENT if the run doesn't exist.
 	UpdateRun(id string, condition string, workflowRuntimeManifest string) (err error)
 
+	// UpdateRunAnnotations replaces the note of a run if note is set, and sets its annotations.
+	// The annotations set to an empty value are removed.
+	UpdateRunAnnotations(runId string, note *string, annotations map[string]string) error
+
 	// Update the run table or create one if the run doesn't exist
 	CreateOrUpdateRun(run *model.RunDetail) error
 
+	// ListUnfinishedRuns lists the runs created before a time which aren't in a final state.
+	// Their manifests are not loaded.
+	ListUnfinishedRuns(createdBeforeInSec int64) ([]model.Run, error)
+
+	// ListUnfinishedRunsOfExperiment lists the runs of an experiment which aren't in a final
+	// state. Their manifests are not loaded.
+	ListUnfinishedRunsOfExperiment(experimentId string) ([]model.Run, error)
+
+	// CountUnfinishedRunsOfPipeline counts the runs of a pipeline which aren't in a final
+	// state, queued ones included.
+	CountUnfinishedRunsOfPipeline(pipelineId string) (int, error)
+
+	// CountUnfinishedRunsOfTarget counts the runs dispatched to an execution target which
+	// aren't in a final state.
+	CountUnfinishedRunsOfTarget(target string) (int, error)
+
+	// ListQueuedRuns lists the queued runs, the oldest first. Their spec manifests are
+	// loaded, but not their runtime ones.
+	ListQueuedRuns() ([]model.RunDetail, error)
+
+	// ListRunStorageSizes returns the size of the manifests of every run, with its namespace
+	// and its experiment.
+	ListRunStorageSizes() ([]*model.RunStorageSize, error)
+
+	// GetExistingRunIds returns which of the runs exist.
+	GetExistingRunIds(runIds []string) (map[string]bool, error)
+
+	// ListRunsWithoutManifest lists the runs whose workflow isn't stored, in the order of their
+	// creation. Their manifests are not loaded.
+	ListRunsWithoutManifest() ([]model.Run, error)
+
+	// ListSucceededRuns lists the last runs of a pipeline which succeeded, the most recent first,
+	// with their runtime manifests.
+	ListSucceededRuns(pipelineId string, limit int) ([]model.RunDetail, error)
+
+	// ListFinishedRuns lists the last runs of a pipeline which succeeded or failed, the most
+	// recent first, with their runtime manifests.
+	ListFinishedRuns(pipelineId string, limit int) ([]model.RunDetail, error)
+
 	// Store a new metric entry to run_metrics table.
 	ReportMetric(metric *model.RunMetric) (err error)
+
+	// Store new metric entries to run_metrics table and return the error of each of them.
+	ReportMetrics(metrics []*model.RunMetric) []error
 }
 
 type RunStore struct {
@@ -51,10 +105,10 @@ type RunStore struct {
 }
 
 // ListRuns list the run metadata for a job from DB
-func (s *RunStore) ListRuns(
-	filterContext *common.FilterContext, paginationContext *common.PaginationContext) ([]model.Run, string, error) {
+func (s *RunStore) ListRuns(filterContext *common.FilterContext, paginationContext *common.PaginationContext,
+	view common.ListView) ([]model.Run, string, error) {
 	queryRunTable := func(request *common.PaginationContext) ([]model.ListableDataModel, error) {
-		return s.queryRunTable(filterContext, request)
+		return s.queryRunTable(filterContext, request, view)
 	}
 	models, pageToken, err := listModel(paginationContext, queryRunTable)
 	if err != nil {
@@ -63,9 +117,9 @@ func (s *RunStore) ListRuns(
 	return s.toRunMetadatas(models), pageToken, err
 }
 
-func (s *RunStore) queryRunTable(
-	filterContext *common.FilterContext, paginationContext *common.PaginationContext) ([]model.ListableDataModel, error) {
-	sqlBuilder := s.selectRunDetails()
+func (s *RunStore) queryRunTable(filterContext *common.FilterContext, paginationContext *common.PaginationContext,
+	view common.ListView) ([]model.ListableDataModel, error) {
+	sqlBuilder := s.selectRunsForList(view)
 
 	// Add filter condition
 	sqlBuilder, err := s.toFilteredQuery(sqlBuilder, filterContext)
@@ -79,12 +133,7 @@ func (s *RunStore) queryRunTable(
 		return nil, util.NewInternalServerError(err, "Failed to create query to list jobs: %v",
 			err.Error())
 	}
-	r, err := s.db.Query(sql, args...)
-	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to list runs: %v", err.Error())
-	}
-	defer r.Close()
-	runs, err := s.scanRows(r)
+	runs, err := s.queryRuns(sql, args)
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to list runs: %v", err.Error())
 	}
@@ -93,6 +142,27 @@ func (s *RunStore) queryRunTable(
 }
 
 func (s *RunStore) toFilteredQuery(selectBuilder sq.SelectBuilder, filterContext *common.FilterContext) (sq.SelectBuilder, error) {
+	if filterContext.Name != nil {
+		selectBuilder = selectBuilder.Where(s.db.MatchName("DisplayName", filterContext.Name))
+	}
+	if annotation := filterContext.Annotation; annotation != nil {
+		annotated := sq.Select("RunUUID").From("run_annotations").Where(sq.Eq{"Name": annotation.Name})
+		if annotation.Value != "" {
+			annotated = annotated.Where(sq.Eq{"Value": annotation.Value})
+		}
+		annotatedSql, annotatedArgs, err := annotated.ToSql()
+		if err != nil {
+			return selectBuilder, util.NewInternalServerError(err, "Failed to append annotation filter to list run: %v",
+				err.Error())
+		}
+		selectBuilder = selectBuilder.Where(fmt.Sprintf("UUID IN (%s)", annotatedSql), annotatedArgs...)
+	}
+	if filterContext.StarredBy != "" {
+		var err error
+		if selectBuilder, err = filterStarred(selectBuilder, filterContext.StarredBy, common.Run); err != nil {
+			return selectBuilder, err
+		}
+	}
 	sql, args, err := selectBuilder.ToSql()
 	if err != nil {
 		return selectBuilder, util.NewInternalServerError(err, "Failed to append filter condition to list run: %v",
@@ -119,13 +189,7 @@ func (s *RunStore) GetRun(runId string) (*model.RunDetail, error) {
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to get run: %v", err.Error())
 	}
-	r, err := s.db.Query(sql, args...)
-	if err != nil {
-		return nil, util.NewInternalServerError(err, "Failed to get run: %v", err.Error())
-	}
-	defer r.Close()
-	runs, err := s.scanRows(r)
-
+	runs, err := s.queryRuns(sql, args)
 	if err != nil || len(runs) > 1 {
 		return nil, util.NewInternalServerError(err, "Failed to get run: %v", err.Error())
 	}
@@ -139,68 +203,171 @@ func (s *RunStore) GetRun(runId string) (*model.RunDetail, error) {
 	return &runs[0], nil
 }
 
+// selectRunDetails selects the columns of run_details in the order the rows are scanned. The
+// columns are selected by name, since the ones added by a migration come last in the table.
 func (s *RunStore) selectRunDetails() sq.SelectBuilder {
-	metricConcatQuery := s.db.Concat([]string{`"["`, s.db.GroupConcat("m.Payload", ","), `"]"`}, "")
-	subQ := sq.
-		Select("rd.*", metricConcatQuery+" AS metrics").
-		From("run_details AS rd").
-		LeftJoin("run_metrics AS m ON rd.UUID=m.RunUUID").
-		GroupBy("rd.UUID")
+	return sq.
+		Select("UUID", "DisplayName", "Name", "Namespace", "Description", "CreatedAtInSec", "ScheduledAtInSec",
+			"Conditions", "PipelineId", "PipelineSpecManifest", "WorkflowSpecManifest", "Parameters",
+			"PipelineRuntimeManifest", "WorkflowRuntimeManifest", "Note", "ExecutionTarget").
+		From("run_details")
+}
 
-	resourceRefConcatQuery := s.db.Concat([]string{`"["`, s.db.GroupConcat("r.Payload", ","), `"]"`}, "")
+// selectRunsForList selects the columns of run_details a list returns. The runtime manifests are
+// only returned by GetRun, and the spec manifests only with the full view. The manifests left out
+// are selected as empty strings, so that the rows scan the same as the ones of GetRun.
+func (s *RunStore) selectRunsForList(view common.ListView) sq.SelectBuilder {
+	pipelineSpecManifest, workflowSpecManifest := "PipelineSpecManifest", "WorkflowSpecManifest"
+	if view != common.FullView {
+		pipelineSpecManifest, workflowSpecManifest = "'' AS PipelineSpecManifest", "'' AS WorkflowSpecManifest"
+	}
 	return sq.
-		Select("subq.*", resourceRefConcatQuery+" AS refs").
-		FromSelect(subQ, "subq").
-		// Append all the resource references for the run as a json column
-		LeftJoin("resource_references AS r ON subq.UUID=r.ResourceUUID").
-		Where(sq.Eq{"r.ResourceType": common.Run}).
-		GroupBy("subq.UUID")
+		Select("UUID", "DisplayName", "Name", "Namespace", "Description", "CreatedAtInSec", "ScheduledAtInSec",
+			"Conditions", "PipelineId", pipelineSpecManifest, workflowSpecManifest, "Parameters",
+			"'' AS PipelineRuntimeManifest", "'' AS WorkflowRuntimeManifest", "Note", "ExecutionTarget").
+		From("run_details")
+}
+
+// queryRuns runs a query selecting from run_details, then loads the metrics, the resource
+// references and the annotations of the selected runs. The related rows are loaded for the whole page at once, rather
+// than aggregated over the whole run_details table before paging.
+func (s *RunStore) queryRuns(sql string, args []interface{}) ([]model.RunDetail, error) {
+	r, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.scanRows(r)
+	// Release the connection before loading the related rows.
+	r.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return runs, nil
+	}
+	runIds := make([]string, len(runs))
+	for i := range runs {
+		runIds[i] = runs[i].UUID
+	}
+	metrics, err := s.listMetrics(runIds)
+	if err != nil {
+		return nil, err
+	}
+	resourceReferences, err := s.resourceReferenceStore.ListResourceReferences(runIds, common.Run)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := s.listAnnotations(runIds)
+	if err != nil {
+		return nil, err
+	}
+	for i := range runs {
+		runs[i].Metrics = metrics[runs[i].UUID]
+		runs[i].ResourceReferences = resourceReferences[runs[i].UUID]
+		runs[i].Annotations = annotations[runs[i].UUID]
+	}
+	return runs, nil
+}
+
+// listMetrics returns the metrics of the given runs, keyed by the run ID.
+func (s *RunStore) listMetrics(runIds []string) (map[string][]*model.RunMetric, error) {
+	sql, args, err := sq.Select("RunUUID", "Payload").
+		From("run_metrics").
+		Where(sq.Eq{"RunUUID": runIds}).
+		OrderBy("RunUUID", "NodeID", "Name").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list metrics: %v", err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list metrics: %v", err.Error())
+	}
+	defer rows.Close()
+	metrics := map[string][]*model.RunMetric{}
+	for rows.Next() {
+		var runUUID, payload string
+		if err := rows.Scan(&runUUID, &payload); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan metric: %v", err.Error())
+		}
+		var metric model.RunMetric
+		if err := json.Unmarshal([]byte(payload), &metric); err != nil {
+			// Skip the error to allow user to get runs even when metrics data
+			// are invalid.
+			glog.Errorf("Failed to parse metric (%v) of run %v from DB: %v", payload, runUUID, err)
+			continue
+		}
+		metrics[runUUID] = append(metrics[runUUID], &metric)
+	}
+	return metrics, nil
+}
+
+// listAnnotations returns the annotations of the given runs, keyed by the run ID.
+func (s *RunStore) listAnnotations(runIds []string) (map[string]map[string]string, error) {
+	sql, args, err := sq.Select("RunUUID", "Name", "Value").
+		From("run_annotations").
+		Where(sq.Eq{"RunUUID": runIds}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list annotations: %v", err.Error())
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list annotations: %v", err.Error())
+	}
+	defer rows.Close()
+	annotations := map[string]map[string]string{}
+	for rows.Next() {
+		var runUUID, name, value string
+		if err := rows.Scan(&runUUID, &name, &value); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to scan annotation: %v", err.Error())
+		}
+		if annotations[runUUID] == nil {
+			annotations[runUUID] = map[string]string{}
+		}
+		annotations[runUUID][name] = value
+	}
+	return annotations, nil
 }
 
 func (s *RunStore) scanRows(rows *sql.Rows) ([]model.RunDetail, error) {
 	var runs []model.RunDetail
 	for rows.Next() {
-		var uuid, displayName, name, namespace, description, pipelineId, pipelineSpecManifest, workflowSpecManifest,
-			parameters, conditions, pipelineRuntimeManifest, workflowRuntimeManifest string
-		var createdAtInSec, scheduledAtInSec int64
-		var metricsInString, resourceReferencesInString sql.NullString
+		var uuid, displayName, name, namespace, description, pipelineId, workflowSpecManifest,
+			conditions, pipelineRuntimeManifest, workflowRuntimeManifest string
+		var createdAtInSec int64
+		// The columns are NULL in the rows of a previous release which didn't have them.
+		var pipelineSpecManifest, parameters, note, executionTarget sql.NullString
+		var scheduledAtInSec sql.NullInt64
 		err := rows.Scan(
 			&uuid, &displayName, &name, &namespace, &description, &createdAtInSec, &scheduledAtInSec,
 			&conditions, &pipelineId, &pipelineSpecManifest, &workflowSpecManifest, &parameters,
-			&pipelineRuntimeManifest, &workflowRuntimeManifest,
-			&metricsInString, &resourceReferencesInString)
+			&pipelineRuntimeManifest, &workflowRuntimeManifest, &note, &executionTarget)
 		if err != nil {
 			glog.Errorf("Failed to scan row: %v", err)
 			return runs, nil
 		}
-		metrics, err := parseMetrics(metricsInString)
-		if err != nil {
-			glog.Errorf("Failed to parse metrics (%v) from DB: %v", metricsInString, err)
-			// Skip the error to allow user to get runs even when metrics data
-			// are invalid.
-			metrics = []*model.RunMetric{}
-		}
-		resourceReferences, err := parseResourceReferences(resourceReferencesInString)
+		err = decompressManifests(
+			&pipelineSpecManifest.String, &workflowSpecManifest, &pipelineRuntimeManifest, &workflowRuntimeManifest)
 		if err != nil {
-			// throw internal exception if failed to parse the resource reference.
-			return nil, util.NewInternalServerError(err, "Failed to parse resource reference.")
+			return nil, util.Wrap(err, fmt.Sprintf("Failed to read the manifests of run %v", uuid))
 		}
 		runs = append(runs, model.RunDetail{Run: model.Run{
-			UUID:               uuid,
-			DisplayName:        displayName,
-			Name:               name,
-			Namespace:          namespace,
-			Description:        description,
-			CreatedAtInSec:     createdAtInSec,
-			ScheduledAtInSec:   scheduledAtInSec,
-			Conditions:         conditions,
-			Metrics:            metrics,
-			ResourceReferences: resourceReferences,
+			UUID:             uuid,
+			DisplayName:      displayName,
+			Name:             name,
+			Namespace:        namespace,
+			Description:      description,
+			CreatedAtInSec:   createdAtInSec,
+			ScheduledAtInSec: scheduledAtInSec.Int64,
+			Conditions:       conditions,
+			Note:             note.String,
+			ExecutionTarget:  executionTarget.String,
 			PipelineSpec: model.PipelineSpec{
 				PipelineId:           pipelineId,
 				PipelineSpecManifest: pipelineRuntimeManifest,
 				WorkflowSpecManifest: workflowSpecManifest,
-				Parameters:           parameters,
+				Parameters:           parameters.String,
 			},
 		},
 			PipelineRuntime: model.PipelineRuntime{
@@ -210,29 +377,14 @@ func (s *RunStore) scanRows(rows *sql.Rows) ([]model.RunDetail, error) {
 	return runs, nil
 }
 
-func parseMetrics(metricsInString sql.NullString) ([]*model.RunMetric, error) {
-	if !metricsInString.Valid {
-		return nil, nil
-	}
-	var metrics []*model.RunMetric
-	if err := json.Unmarshal([]byte(metricsInString.String), &metrics); err != nil {
-		return nil, fmt.Errorf("failed unmarshal metrics '%s'. error: %v", metricsInString.String, err)
-	}
-	return metrics, nil
-}
-
-func parseResourceReferences(resourceRefString sql.NullString) ([]*model.ResourceReference, error) {
-	if !resourceRefString.Valid {
-		return nil, nil
-	}
-	var refs []*model.ResourceReference
-	if err := json.Unmarshal([]byte(resourceRefString.String), &refs); err != nil {
-		return nil, fmt.Errorf("failed unmarshal resource references '%s'. error: %v", resourceRefString.String, err)
-	}
-	return refs, nil
-}
-
 func (s *RunStore) CreateRun(r *model.RunDetail) (*model.RunDetail, error) {
+	workflowRuntimeManifest, pipelineRuntimeManifest, pipelineSpecManifest, workflowSpecManifest :=
+		r.WorkflowRuntimeManifest, r.PipelineRuntimeManifest, r.PipelineSpecManifest, r.WorkflowSpecManifest
+	err := compressManifests(
+		&workflowRuntimeManifest, &pipelineRuntimeManifest, &pipelineSpecManifest, &workflowSpecManifest)
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("Failed to store run %v", r.Name))
+	}
 	runSql, runArgs, err := sq.
 		Insert("run_details").
 		SetMap(sq.Eq{
@@ -244,12 +396,13 @@ func (s *RunStore) CreateRun(r *model.RunDetail) (*model.RunDetail, error) {
 			"CreatedAtInSec":          r.CreatedAtInSec,
 			"ScheduledAtInSec":        r.ScheduledAtInSec,
 			"Conditions":              r.Conditions,
-			"WorkflowRuntimeManifest": r.WorkflowRuntimeManifest,
-			"PipelineRuntimeManifest": r.PipelineRuntimeManifest,
+			"WorkflowRuntimeManifest": workflowRuntimeManifest,
+			"PipelineRuntimeManifest": pipelineRuntimeManifest,
 			"PipelineId":              r.PipelineId,
-			"PipelineSpecManifest":    r.PipelineSpecManifest,
-			"WorkflowSpecManifest":    r.WorkflowSpecManifest,
+			"PipelineSpecManifest":    pipelineSpecManifest,
+			"WorkflowSpecManifest":    workflowSpecManifest,
 			"Parameters":              r.Parameters,
+			"ExecutionTarget":         r.ExecutionTarget,
 		}).ToSql()
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create query to store run to run table: '%v/%v",
@@ -281,11 +434,15 @@ func (s *RunStore) CreateRun(r *model.RunDetail) (*model.RunDetail, error) {
 }
 
 func (s *RunStore) UpdateRun(runID string, condition string, workflowRuntimeManifest string) (err error) {
+	storedWorkflowRuntimeManifest, err := compressManifest(workflowRuntimeManifest)
+	if err != nil {
+		return util.Wrap(err, fmt.Sprintf("Failed to update run %s", runID))
+	}
 	sql, args, err := sq.
 		Update("run_details").
 		SetMap(sq.Eq{
 			"Conditions":              condition,
-			"WorkflowRuntimeManifest": workflowRuntimeManifest}).
+			"WorkflowRuntimeManifest": storedWorkflowRuntimeManifest}).
 		Where(sq.Eq{"UUID": runID}).
 		ToSql()
 	if err != nil {
@@ -303,6 +460,306 @@ func (s *RunStore) UpdateRun(runID string, condition string, workflowRuntimeMani
 	return nil
 }
 
+func (s *RunStore) UpdateRunAnnotations(runId string, note *string, annotations map[string]string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to update run %v", runId)
+	}
+	if err := s.updateRunAnnotations(tx, runId, note, annotations); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to commit the update of run %v", runId)
+	}
+	return nil
+}
+
+func (s *RunStore) updateRunAnnotations(tx *sql.Tx, runId string, note *string, annotations map[string]string) error {
+	sql, args, err := sq.Select("UUID").From("run_details").Where(sq.Eq{"UUID": runId}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to get run %v", runId)
+	}
+	rows, err := tx.Query(sql, args...)
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get run %v", runId)
+	}
+	exists := rows.Next()
+	rows.Close()
+	if !exists {
+		return util.NewResourceNotFoundError("Run", runId)
+	}
+	if note != nil {
+		sql, args, err := sq.Update("run_details").Set("Note", *note).Where(sq.Eq{"UUID": runId}).ToSql()
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to create query to update run %v", runId)
+		}
+		if _, err := tx.Exec(sql, args...); err != nil {
+			return util.NewInternalServerError(err, "Failed to update the note of run %v", runId)
+		}
+	}
+	for name, value := range annotations {
+		sql, args, err := sq.Delete("run_annotations").Where(sq.Eq{"RunUUID": runId, "Name": name}).ToSql()
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to create query to remove annotation %v of run %v",
+				name, runId)
+		}
+		if _, err := tx.Exec(sql, args...); err != nil {
+			return util.NewInternalServerError(err, "Failed to remove annotation %v of run %v", name, runId)
+		}
+		if value == "" {
+			continue
+		}
+		sql, args, err = sq.Insert("run_annotations").
+			SetMap(sq.Eq{"RunUUID": runId, "Name": name, "Value": value}).
+			ToSql()
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to create query to set annotation %v of run %v",
+				name, runId)
+		}
+		if _, err := tx.Exec(sql, args...); err != nil {
+			return util.NewInternalServerError(err, "Failed to set annotation %v of run %v", name, runId)
+		}
+	}
+	return nil
+}
+
+// finalConditions are the conditions of the runs which finished.
+func finalConditions() []string {
+	var conditions []string
+	for _, phase := range []workflowapi.NodePhase{workflowapi.NodeSucceeded, workflowapi.NodeFailed, workflowapi.NodeError} {
+		conditions = append(conditions, string(phase))
+	}
+	return conditions
+}
+
+func (s *RunStore) ListUnfinishedRuns(createdBeforeInSec int64) ([]model.Run, error) {
+	sql, args, err := s.selectRunsForList(common.BasicView).
+		Where(sq.And{
+			sq.NotEq{"Conditions": finalConditions()},
+			sq.Lt{"CreatedAtInSec": createdBeforeInSec}}).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the unfinished runs")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the unfinished runs")
+	}
+	defer rows.Close()
+	runDetails, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the unfinished runs")
+	}
+	runs := make([]model.Run, 0, len(runDetails))
+	for _, runDetail := range runDetails {
+		runs = append(runs, runDetail.Run)
+	}
+	return runs, nil
+}
+
+func (s *RunStore) ListUnfinishedRunsOfExperiment(experimentId string) ([]model.Run, error) {
+	referenced, referencedArgs, err := sq.
+		Select("ResourceUUID").
+		From("resource_references").
+		Where(sq.Eq{
+			"ReferenceUUID": experimentId,
+			"ReferenceType": common.Experiment,
+			"ResourceType":  common.Run}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the unfinished runs of experiment %v",
+			experimentId)
+	}
+	sql, args, err := s.selectRunsForList(common.BasicView).
+		Where(sq.NotEq{"Conditions": finalConditions()}).
+		Where(fmt.Sprintf("UUID IN (%s)", referenced), referencedArgs...).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the unfinished runs of experiment %v",
+			experimentId)
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the unfinished runs of experiment %v", experimentId)
+	}
+	defer rows.Close()
+	runDetails, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the unfinished runs of experiment %v", experimentId)
+	}
+	runs := make([]model.Run, 0, len(runDetails))
+	for _, runDetail := range runDetails {
+		runs = append(runs, runDetail.Run)
+	}
+	return runs, nil
+}
+
+func (s *RunStore) CountUnfinishedRunsOfPipeline(pipelineId string) (int, error) {
+	sql, args, err := sq.
+		Select("count(*)").
+		From("run_details").
+		Where(sq.And{
+			sq.Eq{"PipelineId": pipelineId},
+			sq.NotEq{"Conditions": finalConditions()}}).
+		ToSql()
+	if err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to create query to count the unfinished runs of pipeline %v",
+			pipelineId)
+	}
+	var count int
+	if err := s.db.QueryRow(sql, args...).Scan(&count); err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to count the unfinished runs of pipeline %v", pipelineId)
+	}
+	return count, nil
+}
+
+func (s *RunStore) CountUnfinishedRunsOfTarget(target string) (int, error) {
+	sql, args, err := sq.
+		Select("count(*)").
+		From("run_details").
+		Where(sq.And{
+			sq.Eq{"ExecutionTarget": target},
+			sq.NotEq{"Conditions": finalConditions()}}).
+		ToSql()
+	if err != nil {
+		return 0, util.NewInternalServerError(err,
+			"Failed to create query to count the unfinished runs of execution target %v", target)
+	}
+	var count int
+	if err := s.db.QueryRow(sql, args...).Scan(&count); err != nil {
+		return 0, util.NewInternalServerError(err, "Failed to count the unfinished runs of execution target %v", target)
+	}
+	return count, nil
+}
+
+func (s *RunStore) ListQueuedRuns() ([]model.RunDetail, error) {
+	sql, args, err := s.selectRunsForList(common.FullView).
+		Where(sq.Eq{"Conditions": util.RunConditionQueued}).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the queued runs")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the queued runs")
+	}
+	defer rows.Close()
+	runs, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the queued runs")
+	}
+	return runs, nil
+}
+
+func (s *RunStore) ListRunStorageSizes() ([]*model.RunStorageSize, error) {
+	// LENGTH counts the bytes of the manifests with MySQL, and their characters with SQLite.
+	query, args, err := sq.
+		Select("rd.UUID", "rd.Namespace", "COALESCE(rf.ReferenceUUID, '')",
+			"LENGTH(rd.PipelineSpecManifest) + LENGTH(rd.WorkflowSpecManifest) + "+
+				"LENGTH(rd.PipelineRuntimeManifest) + LENGTH(rd.WorkflowRuntimeManifest)").
+		From("run_details AS rd").
+		LeftJoin("resource_references AS rf ON rf.ResourceUUID = rd.UUID AND rf.ResourceType = ? AND rf.ReferenceType = ?",
+			common.Run, common.Experiment).
+		OrderBy("rd.UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the storage sizes of the runs")
+	}
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the storage sizes of the runs")
+	}
+	defer rows.Close()
+	var sizes []*model.RunStorageSize
+	for rows.Next() {
+		var size model.RunStorageSize
+		var manifestBytes sql.NullInt64
+		if err := rows.Scan(&size.RunUUID, &size.Namespace, &size.ExperimentUUID, &manifestBytes); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse the storage sizes of the runs")
+		}
+		size.ManifestBytes = manifestBytes.Int64
+		sizes = append(sizes, &size)
+	}
+	return sizes, nil
+}
+
+func (s *RunStore) GetExistingRunIds(runIds []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(runIds) == 0 {
+		return existing, nil
+	}
+	sql, args, err := sq.Select("UUID").From("run_details").Where(sq.Eq{"UUID": runIds}).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the existing runs")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the existing runs")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var runId string
+		if err := rows.Scan(&runId); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse the existing runs")
+		}
+		existing[runId] = true
+	}
+	return existing, nil
+}
+
+func (s *RunStore) ListRunsWithoutManifest() ([]model.Run, error) {
+	sql, args, err := s.selectRunsForList(common.BasicView).
+		Where(sq.Eq{"WorkflowRuntimeManifest": ""}).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the runs without manifest")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the runs without manifest")
+	}
+	defer rows.Close()
+	runDetails, err := s.scanRows(rows)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse the runs without manifest")
+	}
+	runs := make([]model.Run, 0, len(runDetails))
+	for _, runDetail := range runDetails {
+		runs = append(runs, runDetail.Run)
+	}
+	return runs, nil
+}
+
+func (s *RunStore) ListSucceededRuns(pipelineId string, limit int) ([]model.RunDetail, error) {
+	return s.listRunsOfPipeline(pipelineId, []string{string(workflowapi.NodeSucceeded)}, limit)
+}
+
+func (s *RunStore) ListFinishedRuns(pipelineId string, limit int) ([]model.RunDetail, error) {
+	return s.listRunsOfPipeline(pipelineId, finalConditions(), limit)
+}
+
+func (s *RunStore) listRunsOfPipeline(pipelineId string, conditions []string, limit int) ([]model.RunDetail, error) {
+	sql, args, err := s.selectRunDetails().
+		Where(sq.Eq{"PipelineId": pipelineId, "Conditions": conditions}).
+		OrderBy("CreatedAtInSec DESC", "UUID").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the runs of pipeline %v",
+			pipelineId)
+	}
+	runs, err := s.queryRuns(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the runs of pipeline %v", pipelineId)
+	}
+	return runs, nil
+}
+
 func (s *RunStore) CreateOrUpdateRun(runDetail *model.RunDetail) error {
 	_, createError := s.CreateRun(runDetail)
 	if createError == nil {
@@ -321,33 +778,109 @@ func (s *RunStore) CreateOrUpdateRun(runDetail *model.RunDetail) error {
 // ReportMetric inserts a new metric to run_metrics table. Conflicting metrics
 // are ignored.
 func (s *RunStore) ReportMetric(metric *model.RunMetric) (err error) {
-	payloadBytes, err := json.Marshal(metric)
+	return s.ReportMetrics([]*model.RunMetric{metric})[0]
+}
+
+type runMetricKey struct {
+	runUUID string
+	nodeID  string
+	name    string
+}
+
+// ReportMetrics inserts the metrics to run_metrics table with a single statement and returns
+// the error of each of them, in order. The metrics reported before, or earlier in the same
+// call, are not inserted and fail with an AlreadyExists error.
+func (s *RunStore) ReportMetrics(metrics []*model.RunMetric) []error {
+	errs := make([]error, len(metrics))
+	if len(metrics) == 0 {
+		return errs
+	}
+	reported, err := s.listReportedMetrics(metrics)
 	if err != nil {
-		return util.NewInternalServerError(err,
-			"failed to marshal metric to json: %+v", metric)
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
 	}
-	sql, args, err := sq.
+	insertBuilder := sq.
 		Insert("run_metrics").
-		SetMap(sq.Eq{
-			"RunUUID":     metric.RunUUID,
-			"NodeID":      metric.NodeID,
-			"Name":        metric.Name,
-			"NumberValue": metric.NumberValue,
-			"Format":      metric.Format,
-			"Payload":     string(payloadBytes)}).ToSql()
+		Options(s.db.InsertIgnore()).
+		Columns("RunUUID", "NodeID", "Name", "NumberValue", "Format", "Payload")
+	var inserted []int
+	for i, metric := range metrics {
+		key := runMetricKey{runUUID: metric.RunUUID, nodeID: metric.NodeID, name: metric.Name}
+		if reported[key] {
+			errs[i] = util.NewAlreadyExistError(
+				"same metric has been reported before: %s/%s", metric.NodeID, metric.Name)
+			continue
+		}
+		payloadBytes, err := json.Marshal(metric)
+		if err != nil {
+			errs[i] = util.NewInternalServerError(err,
+				"failed to marshal metric to json: %+v", metric)
+			continue
+		}
+		reported[key] = true
+		inserted = append(inserted, i)
+		insertBuilder = insertBuilder.Values(
+			metric.RunUUID, metric.NodeID, metric.Name, metric.NumberValue, metric.Format, string(payloadBytes))
+	}
+	if len(inserted) == 0 {
+		return errs
+	}
+	// A metric reported concurrently between the check above and the insert is ignored.
+	sql, args, err := insertBuilder.ToSql()
 	if err != nil {
-		return util.NewInternalServerError(err,
-			"failed to create query for inserting metric: %+v", metric)
+		err = util.NewInternalServerError(err, "failed to create query for inserting %v metrics", len(inserted))
+	} else if _, err = s.db.Exec(sql, args...); err != nil {
+		err = util.NewInternalServerError(err, "failed to insert %v metrics", len(inserted))
 	}
-	_, err = s.db.Exec(sql, args...)
 	if err != nil {
-		if s.db.IsDuplicateError(err) {
-			return util.NewAlreadyExistError(
-				"same metric has been reported before: %s/%s", metric.NodeID, metric.Name)
+		for _, i := range inserted {
+			errs[i] = err
 		}
-		return util.NewInternalServerError(err, "failed to insert metric: %v", metric)
 	}
-	return nil
+	return errs
+}
+
+// listReportedMetrics returns which of the metrics have been stored already.
+func (s *RunStore) listReportedMetrics(metrics []*model.RunMetric) (map[runMetricKey]bool, error) {
+	runIds := make(map[string]bool)
+	nodeIds := make(map[string]bool)
+	for _, metric := range metrics {
+		runIds[metric.RunUUID] = true
+		nodeIds[metric.NodeID] = true
+	}
+	sql, args, err := sq.
+		Select("RunUUID", "NodeID", "Name").
+		From("run_metrics").
+		Where(sq.Eq{"RunUUID": stringSetToSlice(runIds), "NodeID": stringSetToSlice(nodeIds)}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "failed to create query for listing the reported metrics")
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "failed to list the reported metrics")
+	}
+	defer rows.Close()
+	reported := make(map[runMetricKey]bool)
+	for rows.Next() {
+		var key runMetricKey
+		if err := rows.Scan(&key.runUUID, &key.nodeID, &key.name); err != nil {
+			return nil, util.NewInternalServerError(err, "failed to list the reported metrics")
+		}
+		reported[key] = true
+	}
+	return reported, nil
+}
+
+func stringSetToSlice(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	return values
 }
 
 func (s *RunStore) toListableModels(runs []model.RunDetail) []model.ListableDataModel {
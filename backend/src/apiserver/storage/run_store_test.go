@@ -15,6 +15,8 @@
 package storage
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	sq "github.com/Masterminds/squirrel"
@@ -142,7 +144,7 @@ func TestListRuns_Pagination(t *testing.T) {
 			KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
 			SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedFirstPageRuns, runs, "Unexpected Run listed.")
 	assert.NotEmpty(t, nextPageToken)
@@ -158,7 +160,7 @@ func TestListRuns_Pagination(t *testing.T) {
 			KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
 			SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedSecondPageRuns, runs, "Unexpected Run listed.")
 	assert.Empty(t, nextPageToken)
@@ -207,7 +209,7 @@ func TestListRuns_Pagination_Descend(t *testing.T) {
 			KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
 			SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
 			IsDesc:          true,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedFirstPageRuns, runs, "Unexpected Run listed.")
 	assert.NotEmpty(t, nextPageToken)
@@ -223,7 +225,7 @@ func TestListRuns_Pagination_Descend(t *testing.T) {
 			KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
 			SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
 			IsDesc:          true,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedSecondPageRuns, runs, "Unexpected Run listed.")
 	assert.Empty(t, nextPageToken)
@@ -271,7 +273,7 @@ func TestListRuns_Pagination_LessThanPageSize(t *testing.T) {
 			KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
 			SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedRuns, runs, "Unexpected Run listed.")
 	assert.Empty(t, nextPageToken)
@@ -289,7 +291,7 @@ func TestListRunsError(t *testing.T) {
 			KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
 			SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
 			IsDesc:          false,
-		})
+		}, common.BasicView)
 	assert.Equal(t, codes.Internal, err.(*util.UserError).ExternalStatusCode(),
 		"Expected to throw an internal error")
 }
@@ -322,6 +324,41 @@ func TestGetRun(t *testing.T) {
 	assert.Equal(t, expectedRun, runDetail)
 }
 
+func TestCreateRun_CompressesManifests(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	workflowRuntimeManifest := strings.Repeat(`{"name": "step", "phase": "Succeeded"}`, 100)
+	_, err := runStore.CreateRun(&model.RunDetail{
+		Run: model.Run{
+			UUID: "4",
+			Name: "run4",
+			ResourceReferences: []*model.ResourceReference{
+				{
+					ResourceUUID: "4", ResourceType: common.Run,
+					ReferenceUUID: defaultFakeExpId, ReferenceType: common.Experiment,
+					Relationship: common.Creator,
+				},
+			},
+		},
+		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: workflowRuntimeManifest},
+	})
+	assert.Nil(t, err)
+
+	var stored string
+	err = db.QueryRow(`SELECT WorkflowRuntimeManifest FROM run_details WHERE UUID = '4'`).Scan(&stored)
+	assert.Nil(t, err)
+	assert.True(t, len(stored) < len(workflowRuntimeManifest))
+	runDetail, err := runStore.GetRun("4")
+	assert.Nil(t, err)
+	assert.Equal(t, workflowRuntimeManifest, runDetail.WorkflowRuntimeManifest)
+
+	updatedManifest := workflowRuntimeManifest + strings.Repeat(`{"name": "step2"}`, 100)
+	assert.Nil(t, runStore.UpdateRun("4", "Succeeded", updatedManifest))
+	runDetail, err = runStore.GetRun("4")
+	assert.Nil(t, err)
+	assert.Equal(t, updatedManifest, runDetail.WorkflowRuntimeManifest)
+}
+
 func TestGetRun_NotFoundError(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -491,6 +528,104 @@ func TestUpdateRun_RunNotExist(t *testing.T) {
 	assert.Contains(t, err.Error(), "Row not found")
 }
 
+func TestUpdateRunAnnotations(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	note := "flaky"
+	err := runStore.UpdateRunAnnotations("1", &note, map[string]string{"quality": "bad", "owner": "alice"})
+	assert.Nil(t, err)
+	// The annotations not in the update are left unchanged, and the empty ones are removed.
+	err = runStore.UpdateRunAnnotations("1", nil, map[string]string{"quality": "good", "owner": ""})
+	assert.Nil(t, err)
+
+	run, err := runStore.GetRun("1")
+	assert.Nil(t, err)
+	assert.Equal(t, "flaky", run.Note)
+	assert.Equal(t, map[string]string{"quality": "good"}, run.Annotations)
+	run, err = runStore.GetRun("2")
+	assert.Nil(t, err)
+	assert.Empty(t, run.Note)
+	assert.Empty(t, run.Annotations)
+}
+
+func TestUpdateRunAnnotations_RunNotExist(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	err := runStore.UpdateRunAnnotations("not-exist", nil, map[string]string{"quality": "bad"})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestListRuns_FilterByAnnotation(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	assert.Nil(t, runStore.UpdateRunAnnotations("1", nil, map[string]string{"quality": "bad"}))
+	assert.Nil(t, runStore.UpdateRunAnnotations("3", nil, map[string]string{"quality": "good"}))
+
+	runIds := func(annotation *common.Annotation) []string {
+		runs, _, err := runStore.ListRuns(
+			&common.FilterContext{Annotation: annotation},
+			&common.PaginationContext{
+				PageSize:        10,
+				KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
+				SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
+			},
+			common.BasicView)
+		assert.Nil(t, err)
+		var ids []string
+		for _, run := range runs {
+			ids = append(ids, run.UUID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"1", "3"}, runIds(&common.Annotation{Name: "quality"}))
+	assert.Equal(t, []string{"3"}, runIds(&common.Annotation{Name: "quality", Value: "good"}))
+	assert.Empty(t, runIds(&common.Annotation{Name: "owner"}))
+}
+
+func TestListRuns_FilterByName(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	for id, displayName := range map[string]string{"4": "Training nightly", "5": "training weekly"} {
+		_, err := runStore.CreateRun(&model.RunDetail{Run: model.Run{
+			UUID: id, Name: "run" + id, DisplayName: displayName, Namespace: "n1", CreatedAtInSec: 4,
+			ResourceReferences: []*model.ResourceReference{{
+				ResourceUUID: id, ResourceType: common.Run,
+				ReferenceUUID: defaultFakeExpIdTwo, ReferenceType: common.Experiment, Relationship: common.Creator,
+			}},
+		}})
+		assert.Nil(t, err)
+	}
+
+	runIds := func(filterContext *common.FilterContext) []string {
+		runs, _, err := runStore.ListRuns(filterContext,
+			&common.PaginationContext{
+				PageSize:        10,
+				KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
+				SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
+			},
+			common.BasicView)
+		assert.Nil(t, err)
+		var ids []string
+		for _, run := range runs {
+			ids = append(ids, run.UUID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"5"}, runIds(&common.FilterContext{
+		Name: &common.NameFilter{Name: "training weekly", Match: common.NameMatchExact}}))
+	assert.Equal(t, []string{"4"}, runIds(&common.FilterContext{
+		Name: &common.NameFilter{Name: "training NIGHTLY", Match: common.NameMatchCaseInsensitive}}))
+	assert.Equal(t, []string{"4", "5"}, runIds(&common.FilterContext{
+		Name:         &common.NameFilter{Name: "training", Match: common.NameMatchPrefix},
+		ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpIdTwo}}))
+	assert.Empty(t, runIds(&common.FilterContext{
+		Name:         &common.NameFilter{Name: "training", Match: common.NameMatchPrefix},
+		ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpId}}))
+}
+
 func TestReportMetric_Success(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -534,6 +669,32 @@ func TestReportMetric_DupReports_Fail(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestReportMetrics(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	reported := &model.RunMetric{RunUUID: "1", NodeID: "node1", Name: "accuracy", NumberValue: 0.77, Format: "PERCENTAGE"}
+	assert.Nil(t, runStore.ReportMetric(reported))
+
+	recall := &model.RunMetric{RunUUID: "1", NodeID: "node1", Name: "recall", NumberValue: 0.5, Format: "RAW"}
+	precision := &model.RunMetric{RunUUID: "1", NodeID: "node2", Name: "precision", NumberValue: 0.6, Format: "RAW"}
+	errs := runStore.ReportMetrics([]*model.RunMetric{
+		{RunUUID: "1", NodeID: "node1", Name: "accuracy", NumberValue: 0.88, Format: "PERCENTAGE"},
+		recall,
+		precision,
+		{RunUUID: "1", NodeID: "node1", Name: "recall", NumberValue: 0.9, Format: "RAW"},
+	})
+
+	assert.Len(t, errs, 4)
+	assert.True(t, util.IsUserErrorCodeMatch(errs[0], codes.AlreadyExists))
+	assert.Nil(t, errs[1])
+	assert.Nil(t, errs[2])
+	assert.True(t, util.IsUserErrorCodeMatch(errs[3], codes.AlreadyExists))
+	runDetail, err := runStore.GetRun("1")
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunMetric{reported, recall, precision}, runDetail.Run.Metrics)
+}
+
 func TestGetRun_InvalidMetricPayload_Ignore(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -553,6 +714,179 @@ func TestGetRun_InvalidMetricPayload_Ignore(t *testing.T) {
 	assert.Empty(t, runDetail.Run.Metrics)
 }
 
+func TestListUnfinishedRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	assert.Nil(t, runStore.UpdateRun("2", "Succeeded", "workflow2"))
+
+	runs, err := runStore.ListUnfinishedRuns(3)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 1)
+	assert.Equal(t, "1", runs[0].UUID)
+	assert.Equal(t, "running", runs[0].Conditions)
+	runs, err = runStore.ListUnfinishedRuns(4)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 2)
+}
+
+func TestListUnfinishedRunsOfExperiment(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	assert.Nil(t, runStore.UpdateRun("2", "Succeeded", "workflow2"))
+
+	runs, err := runStore.ListUnfinishedRunsOfExperiment(defaultFakeExpId)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 1)
+	assert.Equal(t, "1", runs[0].UUID)
+	runs, err = runStore.ListUnfinishedRunsOfExperiment(defaultFakeExpIdTwo)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 1)
+	assert.Equal(t, "3", runs[0].UUID)
+	runs, err = runStore.ListUnfinishedRunsOfExperiment("unknown")
+	assert.Nil(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestCountUnfinishedRunsOfPipeline(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	_, err := db.Exec(`UPDATE run_details SET PipelineId = 'p1'`)
+	assert.Nil(t, err)
+	assert.Nil(t, runStore.UpdateRun("2", util.RunConditionQueued, "workflow2"))
+	assert.Nil(t, runStore.UpdateRun("3", "Succeeded", "workflow3"))
+
+	count, err := runStore.CountUnfinishedRunsOfPipeline("p1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	count, err = runStore.CountUnfinishedRunsOfPipeline("p2")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestCountUnfinishedRunsOfTarget(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	_, err := db.Exec(`UPDATE run_details SET ExecutionTarget = 'remote' WHERE UUID IN ('2', '3')`)
+	assert.Nil(t, err)
+	assert.Nil(t, runStore.UpdateRun("3", "Succeeded", "workflow3"))
+
+	count, err := runStore.CountUnfinishedRunsOfTarget("remote")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+	run, err := runStore.GetRun("2")
+	assert.Nil(t, err)
+	assert.Equal(t, "remote", run.ExecutionTarget)
+	count, err = runStore.CountUnfinishedRunsOfTarget("other")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestListQueuedRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	assert.Nil(t, runStore.UpdateRun("3", util.RunConditionQueued, "workflow3"))
+	assert.Nil(t, runStore.UpdateRun("2", util.RunConditionQueued, "workflow2"))
+
+	runs, err := runStore.ListQueuedRuns()
+	assert.Nil(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "2", runs[0].UUID)
+	assert.Equal(t, "3", runs[1].UUID)
+}
+
+func TestListRunStorageSizes(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+	_, err := db.Exec(`DELETE FROM resource_references WHERE ResourceUUID = '3'`)
+	assert.Nil(t, err)
+
+	sizes, err := runStore.ListRunStorageSizes()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunStorageSize{
+		{RunUUID: "1", Namespace: "n1", ExperimentUUID: defaultFakeExpId, ManifestBytes: 9},
+		{RunUUID: "2", Namespace: "n2", ExperimentUUID: defaultFakeExpId, ManifestBytes: 9},
+		// The runs without experiment are accounted to their namespace only.
+		{RunUUID: "3", Namespace: "n3", ManifestBytes: 9},
+	}, sizes)
+}
+
+func TestGetExistingRunIds(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	existing, err := runStore.GetExistingRunIds([]string{"1", "3", "4"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"1": true, "3": true}, existing)
+	existing, err = runStore.GetExistingRunIds(nil)
+	assert.Nil(t, err)
+	assert.Empty(t, existing)
+}
+
+func TestListRunsWithoutManifest(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	runs, err := runStore.ListRunsWithoutManifest()
+	assert.Nil(t, err)
+	assert.Empty(t, runs)
+
+	_, err = db.Exec(`UPDATE run_details SET WorkflowRuntimeManifest = '' WHERE UUID IN ('3', '2')`)
+	assert.Nil(t, err)
+	runs, err = runStore.ListRunsWithoutManifest()
+	assert.Nil(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "2", runs[0].UUID)
+	assert.Equal(t, "3", runs[1].UUID)
+}
+
+func TestListSucceededRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	runs, err := runStore.ListSucceededRuns("pipeline-1", 10)
+	assert.Nil(t, err)
+	assert.Empty(t, runs)
+
+	_, err = db.Exec(`UPDATE run_details SET PipelineId = 'pipeline-1', Conditions = 'Succeeded'`)
+	assert.Nil(t, err)
+	_, err = db.Exec(`UPDATE run_details SET Conditions = 'Failed' WHERE UUID = '2'`)
+	assert.Nil(t, err)
+	runs, err = runStore.ListSucceededRuns("pipeline-1", 10)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "3", runs[0].UUID)
+	assert.Equal(t, "1", runs[1].UUID)
+	assert.NotEmpty(t, runs[0].WorkflowRuntimeManifest)
+
+	runs, err = runStore.ListSucceededRuns("pipeline-1", 1)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 1)
+	runs, err = runStore.ListSucceededRuns("pipeline-2", 10)
+	assert.Nil(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestListFinishedRuns(t *testing.T) {
+	db, runStore := initializeRunStore()
+	defer db.Close()
+
+	_, err := db.Exec(`UPDATE run_details SET PipelineId = 'pipeline-1', Conditions = 'Succeeded'`)
+	assert.Nil(t, err)
+	_, err = db.Exec(`UPDATE run_details SET Conditions = 'Failed' WHERE UUID = '2'`)
+	assert.Nil(t, err)
+	_, err = db.Exec(`UPDATE run_details SET Conditions = 'Running' WHERE UUID = '3'`)
+	assert.Nil(t, err)
+	runs, err := runStore.ListFinishedRuns("pipeline-1", 10)
+	assert.Nil(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, "2", runs[0].UUID)
+	assert.Equal(t, "1", runs[1].UUID)
+
+	runs, err = runStore.ListFinishedRuns("pipeline-2", 10)
+	assert.Nil(t, err)
+	assert.Empty(t, runs)
+}
+
 func TestListRuns_WithMetrics(t *testing.T) {
 	db, runStore := initializeRunStore()
 	defer db.Close()
@@ -620,7 +954,72 @@ func TestListRuns_WithMetrics(t *testing.T) {
 		KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
 		SortByFieldName: model.GetRunTablePrimaryKeyColumn(),
 		IsDesc:          false,
-	})
+	}, common.BasicView)
 	assert.Nil(t, err)
 	assert.Equal(t, expectedRuns, runs, "Unexpected Run listed.")
 }
+
+// The number of rows the list benchmarks are run against.
+const benchmarkRowCount = 10000
+
+func initializeRunStoreForBenchmark(b *testing.B) (*DB, *RunStore) {
+	db := NewFakeDbOrFatal()
+	expStore := NewExperimentStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(defaultFakeExpId, nil))
+	expStore.CreateExperiment(&model.Experiment{Name: "exp1"})
+	runStore := NewRunStore(db, util.NewFakeTimeForEpoch())
+	for i := 0; i < benchmarkRowCount; i++ {
+		uuid := fmt.Sprintf("run-%05d", i)
+		_, err := runStore.CreateRun(&model.RunDetail{
+			Run: model.Run{
+				UUID:           uuid,
+				Name:           uuid,
+				CreatedAtInSec: int64(i),
+				Conditions:     "Succeeded",
+				ResourceReferences: []*model.ResourceReference{
+					{
+						ResourceUUID: uuid, ResourceType: common.Run,
+						ReferenceUUID: defaultFakeExpId, ReferenceType: common.Experiment,
+						Relationship: common.Creator,
+					},
+				},
+			},
+			PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "workflow"},
+		})
+		if err != nil {
+			b.Fatalf("Failed to create run %v: %v", uuid, err)
+		}
+		for _, name := range []string{"accuracy", "logloss"} {
+			err := runStore.ReportMetric(&model.RunMetric{
+				RunUUID: uuid, NodeID: "node1", Name: name, NumberValue: 0.5, Format: "RAW"})
+			if err != nil {
+				b.Fatalf("Failed to report metric of run %v: %v", uuid, err)
+			}
+		}
+	}
+	return db, runStore
+}
+
+func BenchmarkListRuns(b *testing.B) {
+	db, runStore := initializeRunStoreForBenchmark(b)
+	defer db.Close()
+
+	filterContexts := map[string]*common.FilterContext{
+		"All":          {},
+		"ByExperiment": {ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: defaultFakeExpId}},
+	}
+	for name, filterContext := range filterContexts {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				runs, _, err := runStore.ListRuns(filterContext, &common.PaginationContext{
+					PageSize:        100,
+					KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
+					SortByFieldName: "CreatedAtInSec",
+					IsDesc:          true,
+				}, common.BasicView)
+				if err != nil || len(runs) != 100 {
+					b.Fatalf("Failed to list runs. Got %v runs and error: %v", len(runs), err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var runSweepColumns = []string{"UUID", "Name", "CreatedAtInSec", "Run", "MaxConcurrency"}
+
+var runSweepTrialColumns = []string{"SweepUUID", "TrialIndex", "Parameters", "RunUUID"}
+
+type RunSweepStoreInterface interface {
+	// CreateSweep stores a sweep with its trials, and the run group tracking its runs, which
+	// has the ID and the name of the sweep.
+	CreateSweep(sweep *model.RunSweep, trials []*model.RunSweepTrial) error
+	GetSweep(uuid string) (*model.RunSweep, error)
+	// ListTrials lists the trials of a sweep in the order of their index.
+	ListTrials(sweepUUID string) ([]*model.RunSweepTrial, error)
+	// ListPendingSweeps lists the sweeps which have trials whose run isn't created yet, and
+	// whose run group isn't cancelled, the oldest first.
+	ListPendingSweeps() ([]*model.RunSweep, error)
+	// SetTrialRun records the run created for a trial, and adds it to the run group of the
+	// sweep.
+	SetTrialRun(sweepUUID string, index int, runUUID string) error
+}
+
+type RunSweepStore struct {
+	db *DB
+}
+
+func (s *RunSweepStore) CreateSweep(sweep *model.RunSweep, trials []*model.RunSweepTrial) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to store the sweep %v", sweep.UUID)
+	}
+	sweepSql, sweepArgs, err := sq.
+		Insert("run_sweeps").
+		SetMap(sq.Eq{
+			"UUID":           sweep.UUID,
+			"Name":           sweep.Name,
+			"CreatedAtInSec": sweep.CreatedAtInSec,
+			"Run":            sweep.Run,
+			"MaxConcurrency": sweep.MaxConcurrency}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to create query to store the sweep %v", sweep.UUID)
+	}
+	if _, err := tx.Exec(sweepSql, sweepArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store the sweep %v", sweep.UUID)
+	}
+	group := &model.RunGroup{UUID: sweep.UUID, Name: sweep.Name, CreatedAtInSec: sweep.CreatedAtInSec}
+	if err := insertRunGroup(tx, group); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, trial := range trials {
+		trialSql, trialArgs, err := sq.
+			Insert("run_sweep_trials").
+			SetMap(sq.Eq{
+				"SweepUUID":  sweep.UUID,
+				"TrialIndex": trial.TrialIndex,
+				"Parameters": trial.Parameters,
+				"RunUUID":    trial.RunUUID}).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to create query to store the trials of the sweep %v",
+				sweep.UUID)
+		}
+		if _, err := tx.Exec(trialSql, trialArgs...); err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to store the trials of the sweep %v", sweep.UUID)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the sweep %v", sweep.UUID)
+	}
+	return nil
+}
+
+func (s *RunSweepStore) GetSweep(uuid string) (*model.RunSweep, error) {
+	sql, args, err := sq.Select(runSweepColumns...).From("run_sweeps").Where(sq.Eq{"UUID": uuid}).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the sweep %v", uuid)
+	}
+	sweeps, err := s.querySweeps(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the sweep %v", uuid)
+	}
+	if len(sweeps) == 0 {
+		return nil, util.NewResourceNotFoundError("Sweep", uuid)
+	}
+	return sweeps[0], nil
+}
+
+func (s *RunSweepStore) ListTrials(sweepUUID string) ([]*model.RunSweepTrial, error) {
+	sql, args, err := sq.
+		Select(runSweepTrialColumns...).
+		From("run_sweep_trials").
+		Where(sq.Eq{"SweepUUID": sweepUUID}).
+		OrderBy("TrialIndex").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the trials of the sweep %v",
+			sweepUUID)
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the trials of the sweep %v", sweepUUID)
+	}
+	defer rows.Close()
+	var trials []*model.RunSweepTrial
+	for rows.Next() {
+		var trial model.RunSweepTrial
+		if err := rows.Scan(&trial.SweepUUID, &trial.TrialIndex, &trial.Parameters, &trial.RunUUID); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse the trials of the sweep %v", sweepUUID)
+		}
+		trials = append(trials, &trial)
+	}
+	return trials, nil
+}
+
+func (s *RunSweepStore) ListPendingSweeps() ([]*model.RunSweep, error) {
+	pendingSql, pendingArgs, err := sq.
+		Select("SweepUUID").
+		From("run_sweep_trials").
+		Where(sq.Eq{"RunUUID": ""}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the pending sweeps")
+	}
+	sql, args, err := sq.
+		Select(runSweepColumns...).
+		From("run_sweeps").
+		Where("UUID IN ("+pendingSql+")", pendingArgs...).
+		Where("UUID NOT IN (SELECT UUID FROM run_groups WHERE CancelledAtInSec > 0)").
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the pending sweeps")
+	}
+	sweeps, err := s.querySweeps(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the pending sweeps")
+	}
+	return sweeps, nil
+}
+
+func (s *RunSweepStore) SetTrialRun(sweepUUID string, index int, runUUID string) error {
+	sql, args, err := sq.
+		Update("run_sweep_trials").
+		Set("RunUUID", runUUID).
+		Where(sq.Eq{"SweepUUID": sweepUUID, "TrialIndex": index}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the trial %v of the sweep %v",
+			index, sweepUUID)
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to update the trial %v of the sweep %v",
+			index, sweepUUID)
+	}
+	if _, err := tx.Exec(sql, args...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to update the trial %v of the sweep %v", index, sweepUUID)
+	}
+	if err := insertRunGroupMembers(tx, sweepUUID, []string{runUUID}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the trial %v of the sweep %v", index, sweepUUID)
+	}
+	return nil
+}
+
+func (s *RunSweepStore) querySweeps(query string, args []interface{}) ([]*model.RunSweep, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanSweepRows(rows)
+}
+
+func (s *RunSweepStore) scanSweepRows(rows *sql.Rows) ([]*model.RunSweep, error) {
+	var sweeps []*model.RunSweep
+	for rows.Next() {
+		var sweep model.RunSweep
+		if err := rows.Scan(&sweep.UUID, &sweep.Name, &sweep.CreatedAtInSec, &sweep.Run,
+			&sweep.MaxConcurrency); err != nil {
+			return sweeps, err
+		}
+		sweeps = append(sweeps, &sweep)
+	}
+	return sweeps, nil
+}
+
+// factory function for run sweep store
+func NewRunSweepStore(db *DB) *RunSweepStore {
+	return &RunSweepStore{db: db}
+}
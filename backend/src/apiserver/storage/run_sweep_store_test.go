@@ -0,0 +1,80 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRunSweepStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewRunSweepStore(db)
+
+	first := &model.RunSweep{UUID: fakeID, Name: "sweep1", CreatedAtInSec: 1, Run: `{"name":"run"}`, MaxConcurrency: 2}
+	second := &model.RunSweep{UUID: fakeIDTwo, Name: "sweep2", CreatedAtInSec: 2, Run: `{"name":"run"}`}
+	firstTrials := []*model.RunSweepTrial{
+		{SweepUUID: fakeID, TrialIndex: 0, Parameters: `[{"name":"lr","value":"0.1"}]`},
+		{SweepUUID: fakeID, TrialIndex: 1, Parameters: `[{"name":"lr","value":"0.2"}]`},
+	}
+	assert.Nil(t, store.CreateSweep(second, []*model.RunSweepTrial{{SweepUUID: fakeIDTwo, TrialIndex: 0}}))
+	assert.Nil(t, store.CreateSweep(first, firstTrials))
+
+	sweep, err := store.GetSweep(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, first, sweep)
+	_, err = store.GetSweep("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	trials, err := store.ListTrials(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, firstTrials, trials)
+
+	sweeps, err := store.ListPendingSweeps()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunSweep{first, second}, sweeps)
+	// The sweeps whose runs are all created aren't pending anymore.
+	assert.Nil(t, store.SetTrialRun(fakeIDTwo, 0, "run1"))
+	sweeps, err = store.ListPendingSweeps()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RunSweep{first}, sweeps)
+	trials, err = store.ListTrials(fakeIDTwo)
+	assert.Nil(t, err)
+	assert.Equal(t, "run1", trials[0].RunUUID)
+}
+
+func TestRunSweepStore_RunGroup(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewRunSweepStore(db)
+	groupStore := NewRunGroupStore(db)
+
+	sweep := &model.RunSweep{UUID: fakeID, Name: "sweep1", CreatedAtInSec: 1, Run: `{"name":"run"}`}
+	assert.Nil(t, store.CreateSweep(sweep, []*model.RunSweepTrial{
+		{SweepUUID: fakeID, TrialIndex: 0}, {SweepUUID: fakeID, TrialIndex: 1}}))
+	group, err := groupStore.GetRunGroup(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, &model.RunGroup{UUID: fakeID, Name: "sweep1", CreatedAtInSec: 1}, group)
+
+	// The sweeps whose group is cancelled aren't pending anymore.
+	assert.Nil(t, groupStore.CancelRunGroup(fakeID, 2))
+	sweeps, err := store.ListPendingSweeps()
+	assert.Nil(t, err)
+	assert.Empty(t, sweeps)
+}
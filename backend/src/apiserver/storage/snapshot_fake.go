@@ -0,0 +1,73 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jinzhu/gorm"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// NewFakeDbFromSnapshot returns a fake DB loaded from a SQL dump of the database of a previous
+// release, then migrated the same way the API server migrates its database on start up.
+func NewFakeDbFromSnapshot(sqlFile string) (*DB, error) {
+	dump, err := ioutil.ReadFile(sqlFile)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read the database snapshot %v: %v", sqlFile, err)
+	}
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("Could not create the GORM database: %v", err)
+	}
+	// The statements of the dump are run at once.
+	if _, err := db.DB().Exec(string(dump)); err != nil {
+		return nil, fmt.Errorf("Could not load the database snapshot %v: %v", sqlFile, err)
+	}
+	if err := Migrate(db); err != nil {
+		return nil, err
+	}
+	if err := CreateIndexes(db, ListIndexes); err != nil {
+		return nil, err
+	}
+	return NewDB(db.DB(), NewSQLiteDialect(), util.NewCircuitBreaker("database", 0, 0)), nil
+}
+
+// NewFakeObjectStoreFromDir returns a fake object store holding the files under a directory, each
+// stored at its path relative to the directory.
+func NewFakeObjectStoreFromDir(dir string) (ObjectStoreInterface, error) {
+	objectStore := NewFakeObjectStore()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		file, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return objectStore.AddFile(file, filepath.ToSlash(relativePath))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Could not load the object store snapshot %v: %v", dir, err)
+	}
+	return objectStore, nil
+}
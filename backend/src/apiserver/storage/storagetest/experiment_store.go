@@ -0,0 +1,129 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// TestExperimentStore runs the conformance tests of the experiment store.
+func TestExperimentStore(t *testing.T, newStores NewStores) {
+	runConformanceTests(t, newStores, []conformanceTest{
+		{"CreateExperiment", testCreateExperiment},
+		{"CreateExperiment_DuplicateName", testCreateExperimentDuplicateName},
+		{"CreateExperiment_InvalidName", testCreateExperimentInvalidName},
+		{"CreateExperimentIfNotExists", testCreateExperimentIfNotExists},
+		{"GetExperiment_NotFound", testGetExperimentNotFound},
+		{"ListExperiments", testListExperiments},
+	})
+}
+
+func testCreateExperiment(t *testing.T, stores *Stores) {
+	experiment, err := stores.Experiments.CreateExperiment(&model.Experiment{Name: " e1 ", Description: "first"})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, experiment.UUID)
+	assert.NotZero(t, experiment.CreatedAtInSec)
+	// The names are stored normalized.
+	assert.Equal(t, "e1", experiment.Name)
+	assert.Equal(t, "first", experiment.Description)
+
+	fetched, err := stores.Experiments.GetExperiment(experiment.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, experiment, fetched)
+	fetched, err = stores.Experiments.GetExperimentByName("e1")
+	assert.Nil(t, err)
+	assert.Equal(t, experiment, fetched)
+}
+
+func testCreateExperimentDuplicateName(t *testing.T, stores *Stores) {
+	_, err := stores.Experiments.CreateExperiment(&model.Experiment{Name: "e1"})
+	assert.Nil(t, err)
+
+	_, err = stores.Experiments.CreateExperiment(&model.Experiment{Name: "e1"})
+	assertErrorCode(t, err, codes.InvalidArgument)
+}
+
+func testCreateExperimentInvalidName(t *testing.T, stores *Stores) {
+	_, err := stores.Experiments.CreateExperiment(&model.Experiment{Name: " "})
+	assertErrorCode(t, err, codes.InvalidArgument)
+	_, err = stores.Experiments.CreateExperimentIfNotExists(&model.Experiment{Name: " "})
+	assertErrorCode(t, err, codes.InvalidArgument)
+}
+
+func testCreateExperimentIfNotExists(t *testing.T, stores *Stores) {
+	experiment, err := stores.Experiments.CreateExperimentIfNotExists(&model.Experiment{Name: "e1"})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, experiment.UUID)
+	assert.Equal(t, "e1", experiment.Name)
+
+	// The existing experiment is returned, whatever the other fields.
+	existing, err := stores.Experiments.CreateExperimentIfNotExists(&model.Experiment{Name: "e1", Description: "other"})
+	assert.Nil(t, err)
+	assert.Equal(t, experiment, existing)
+	experiments, _, err := stores.Experiments.ListExperiments(&common.FilterContext{}, experimentsByName(10))
+	assert.Nil(t, err)
+	assert.Len(t, experiments, 1)
+}
+
+func testGetExperimentNotFound(t *testing.T, stores *Stores) {
+	_, err := stores.Experiments.GetExperiment("missing")
+	assertErrorCode(t, err, codes.NotFound)
+	_, err = stores.Experiments.GetExperimentByName("missing")
+	assertErrorCode(t, err, codes.NotFound)
+}
+
+func testListExperiments(t *testing.T, stores *Stores) {
+	for _, name := range []string{"e2", "e3", "e1"} {
+		_, err := stores.Experiments.CreateExperiment(&model.Experiment{Name: name})
+		assert.Nil(t, err)
+	}
+
+	context := experimentsByName(2)
+	experiments, pageToken, err := stores.Experiments.ListExperiments(&common.FilterContext{}, context)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"e1", "e2"}, experimentNames(experiments))
+	assert.NotEmpty(t, pageToken)
+	experiments, pageToken, err = stores.Experiments.ListExperiments(&common.FilterContext{},
+		nextPage(t, context, pageToken))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"e3"}, experimentNames(experiments))
+	assert.Empty(t, pageToken)
+
+	context.IsDesc = true
+	experiments, _, err = stores.Experiments.ListExperiments(&common.FilterContext{}, context)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"e3", "e2"}, experimentNames(experiments))
+}
+
+func experimentsByName(pageSize int) *common.PaginationContext {
+	return &common.PaginationContext{
+		PageSize:        pageSize,
+		SortByFieldName: "Name",
+		KeyFieldName:    model.GetExperimentTablePrimaryKeyColumn(),
+	}
+}
+
+func experimentNames(experiments []model.Experiment) []string {
+	var names []string
+	for _, experiment := range experiments {
+		names = append(names, experiment.Name)
+	}
+	return names
+}
@@ -0,0 +1,201 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TestJobStore runs the conformance tests of the job store.
+func TestJobStore(t *testing.T, newStores NewStores) {
+	runConformanceTests(t, newStores, []conformanceTest{
+		{"CreateJob", testCreateJob},
+		{"GetJob_NotFound", testGetJobNotFound},
+		{"EnableJob", testEnableJob},
+		{"UpdateJob", testUpdateJob},
+		{"UpdateJob_NotFound", testUpdateJobNotFound},
+		{"DeleteJob", testDeleteJob},
+		{"ListJobs", testListJobs},
+	})
+}
+
+// newJob returns a job owned by an experiment, created at createdAtInSec.
+func newJob(id string, experimentId string, createdAtInSec int64) *model.Job {
+	return &model.Job{
+		UUID:           id,
+		DisplayName:    "job " + id,
+		Name:           "job-" + id,
+		Namespace:      "kubeflow",
+		MaxConcurrency: 1,
+		Enabled:        true,
+		Conditions:     "Enabled",
+		CreatedAtInSec: createdAtInSec,
+		UpdatedAtInSec: createdAtInSec,
+		Trigger: model.Trigger{
+			PeriodicSchedule: model.PeriodicSchedule{IntervalSecond: util.Int64Pointer(60)},
+		},
+		PipelineSpec: model.PipelineSpec{
+			WorkflowSpecManifest: "workflow",
+			Parameters:           `[{"name":"param1","value":"world"}]`,
+		},
+		ResourceReferences: []*model.ResourceReference{{
+			ResourceUUID: id, ResourceType: common.Job,
+			ReferenceUUID: experimentId, ReferenceType: common.Experiment,
+			Relationship: common.Owner,
+		}},
+	}
+}
+
+func createExperiment(t *testing.T, stores *Stores, name string) string {
+	experiment, err := stores.Experiments.CreateExperiment(&model.Experiment{Name: name})
+	assert.Nil(t, err)
+	return experiment.UUID
+}
+
+func testCreateJob(t *testing.T, stores *Stores) {
+	job := newJob("job1", createExperiment(t, stores, "e1"), 1)
+	created, err := stores.Jobs.CreateJob(job)
+	assert.Nil(t, err)
+	assert.Equal(t, job, created)
+
+	fetched, err := stores.Jobs.GetJob("job1")
+	assert.Nil(t, err)
+	assert.Equal(t, job, fetched)
+}
+
+func testGetJobNotFound(t *testing.T, stores *Stores) {
+	_, err := stores.Jobs.GetJob("missing")
+	assertErrorCode(t, err, codes.NotFound)
+}
+
+func testEnableJob(t *testing.T, stores *Stores) {
+	_, err := stores.Jobs.CreateJob(newJob("job1", createExperiment(t, stores, "e1"), 1))
+	assert.Nil(t, err)
+
+	assert.Nil(t, stores.Jobs.EnableJob("job1", false))
+	job, err := stores.Jobs.GetJob("job1")
+	assert.Nil(t, err)
+	assert.False(t, job.Enabled)
+	assert.Nil(t, stores.Jobs.EnableJob("job1", true))
+	job, err = stores.Jobs.GetJob("job1")
+	assert.Nil(t, err)
+	assert.True(t, job.Enabled)
+}
+
+func testUpdateJob(t *testing.T, stores *Stores) {
+	_, err := stores.Jobs.CreateJob(newJob("job1", createExperiment(t, stores, "e1"), 1))
+	assert.Nil(t, err)
+
+	swf := util.NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-1-renamed", Namespace: "kubeflow", UID: types.UID("job1")},
+		Spec: swfapi.ScheduledWorkflowSpec{
+			Enabled:        false,
+			MaxConcurrency: util.Int64Pointer(2),
+			Trigger:        swfapi.Trigger{CronSchedule: &swfapi.CronSchedule{Cron: "0 0 * * *"}},
+			Workflow: &swfapi.WorkflowResource{
+				Parameters: []swfapi.Parameter{{Name: "param1", Value: "again"}},
+			},
+		},
+	})
+	assert.Nil(t, stores.Jobs.UpdateJob(swf))
+	job, err := stores.Jobs.GetJob("job1")
+	assert.Nil(t, err)
+	assert.Equal(t, "job-1-renamed", job.Name)
+	assert.False(t, job.Enabled)
+	assert.Equal(t, int64(2), job.MaxConcurrency)
+	assert.Equal(t, util.StringPointer("0 0 * * *"), job.Cron)
+	assert.Equal(t, `[{"name":"param1","value":"again"}]`, job.Parameters)
+	// The display name and the spec of the pipeline are kept.
+	assert.Equal(t, "job job1", job.DisplayName)
+	assert.Equal(t, "workflow", job.WorkflowSpecManifest)
+}
+
+func testUpdateJobNotFound(t *testing.T, stores *Stores) {
+	swf := util.NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "missing", UID: types.UID("missing")},
+	})
+	assertErrorCode(t, stores.Jobs.UpdateJob(swf), codes.InvalidArgument)
+}
+
+func testDeleteJob(t *testing.T, stores *Stores) {
+	_, err := stores.Jobs.CreateJob(newJob("job1", createExperiment(t, stores, "e1"), 1))
+	assert.Nil(t, err)
+
+	assert.Nil(t, stores.Jobs.DeleteJob("job1"))
+	_, err = stores.Jobs.GetJob("job1")
+	assertErrorCode(t, err, codes.NotFound)
+	jobs, _, err := stores.Jobs.ListJobs(&common.FilterContext{}, jobsByCreationTime(10), common.BasicView)
+	assert.Nil(t, err)
+	assert.Empty(t, jobs)
+}
+
+func testListJobs(t *testing.T, stores *Stores) {
+	experimentId := createExperiment(t, stores, "e1")
+	otherExperimentId := createExperiment(t, stores, "e2")
+	for i, job := range []*model.Job{
+		newJob("job1", experimentId, 1),
+		newJob("job2", otherExperimentId, 2),
+		newJob("job3", experimentId, 3),
+		newJob("job4", experimentId, 4),
+	} {
+		_, err := stores.Jobs.CreateJob(job)
+		assert.Nil(t, err, "Failed to create the job %v", i)
+	}
+	filterContext := &common.FilterContext{
+		ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: experimentId}}
+
+	context := jobsByCreationTime(2)
+	jobs, pageToken, err := stores.Jobs.ListJobs(filterContext, context, common.BasicView)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"job1", "job3"}, jobIds(jobs))
+	assert.NotEmpty(t, pageToken)
+	// The basic view doesn't load the manifests.
+	assert.Empty(t, jobs[0].WorkflowSpecManifest)
+	assert.Equal(t, `[{"name":"param1","value":"world"}]`, jobs[0].Parameters)
+	jobs, pageToken, err = stores.Jobs.ListJobs(filterContext, nextPage(t, context, pageToken), common.FullView)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"job4"}, jobIds(jobs))
+	assert.Empty(t, pageToken)
+	assert.Equal(t, "workflow", jobs[0].WorkflowSpecManifest)
+
+	jobs, _, err = stores.Jobs.ListJobs(&common.FilterContext{}, jobsByCreationTime(10), common.BasicView)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"job1", "job2", "job3", "job4"}, jobIds(jobs))
+}
+
+func jobsByCreationTime(pageSize int) *common.PaginationContext {
+	return &common.PaginationContext{
+		PageSize:        pageSize,
+		SortByFieldName: "CreatedAtInSec",
+		KeyFieldName:    model.GetJobTablePrimaryKeyColumn(),
+	}
+}
+
+func jobIds(jobs []model.Job) []string {
+	var ids []string
+	for _, job := range jobs {
+		ids = append(ids, job.UUID)
+	}
+	return ids
+}
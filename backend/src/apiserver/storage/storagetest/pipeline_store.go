@@ -0,0 +1,218 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// TestPipelineStore runs the conformance tests of the pipeline store.
+func TestPipelineStore(t *testing.T, newStores NewStores) {
+	runConformanceTests(t, newStores, []conformanceTest{
+		{"CreatePipeline", testCreatePipeline},
+		{"CreatePipeline_DuplicateName", testCreatePipelineDuplicateName},
+		{"GetPipeline_NotReady", testGetPipelineNotReady},
+		{"GetPipeline_NotFound", testGetPipelineNotFound},
+		{"DeletePipeline", testDeletePipeline},
+		{"UpdatePipelineDefinition", testUpdatePipelineDefinition},
+		{"UpdatePipelineDeprecation", testUpdatePipelineDeprecation},
+		{"CreatePipelineSteps", testCreatePipelineSteps},
+		{"ListPipelines", testListPipelines},
+	})
+}
+
+func testCreatePipeline(t *testing.T, stores *Stores) {
+	pipeline, err := stores.Pipelines.CreatePipeline(&model.Pipeline{
+		Name:            " p1 ",
+		Description:     "first",
+		Parameters:      `[{"name":"param1"}]`,
+		ParameterSchema: `[{"name":"param1","type":"string"}]`,
+		Status:          model.PipelineReady,
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, pipeline.UUID)
+	assert.NotZero(t, pipeline.CreatedAtInSec)
+	// The names are stored normalized.
+	assert.Equal(t, "p1", pipeline.Name)
+
+	fetched, err := stores.Pipelines.GetPipeline(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, pipeline, fetched)
+	fetched, err = stores.Pipelines.GetPipelineByName("p1")
+	assert.Nil(t, err)
+	assert.Equal(t, pipeline, fetched)
+}
+
+func testCreatePipelineDuplicateName(t *testing.T, stores *Stores) {
+	_, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Status: model.PipelineReady})
+	assert.Nil(t, err)
+
+	_, err = stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Status: model.PipelineCreating})
+	assertErrorCode(t, err, codes.AlreadyExists)
+}
+
+// The pipelines being created or deleted are only returned when asked for with their status.
+func testGetPipelineNotReady(t *testing.T, stores *Stores) {
+	pipeline, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Status: model.PipelineCreating})
+	assert.Nil(t, err)
+
+	_, err = stores.Pipelines.GetPipeline(pipeline.UUID)
+	assertErrorCode(t, err, codes.NotFound)
+	fetched, err := stores.Pipelines.GetPipelineWithStatus(pipeline.UUID, model.PipelineCreating)
+	assert.Nil(t, err)
+	assert.Equal(t, pipeline, fetched)
+	// Whatever its status.
+	fetched, err = stores.Pipelines.GetPipelineByName("p1")
+	assert.Nil(t, err)
+	assert.Equal(t, pipeline, fetched)
+	statuses, err := stores.Pipelines.GetPipelineStatuses()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]model.PipelineStatus{pipeline.UUID: model.PipelineCreating}, statuses)
+
+	assert.Nil(t, stores.Pipelines.UpdatePipelineStatus(pipeline.UUID, model.PipelineReady))
+	fetched, err = stores.Pipelines.GetPipeline(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.PipelineReady, fetched.Status)
+}
+
+func testGetPipelineNotFound(t *testing.T, stores *Stores) {
+	_, err := stores.Pipelines.GetPipeline("missing")
+	assertErrorCode(t, err, codes.NotFound)
+	_, err = stores.Pipelines.GetPipelineByName("missing")
+	assertErrorCode(t, err, codes.NotFound)
+}
+
+func testDeletePipeline(t *testing.T, stores *Stores) {
+	pipeline, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Status: model.PipelineReady})
+	assert.Nil(t, err)
+	assert.Nil(t, stores.Pipelines.CreatePipelineSteps(pipeline.UUID, []*model.PipelineStep{
+		{PipelineUUID: pipeline.UUID, Name: "train", Position: 0, Type: "Container", Children: "[]"}}))
+
+	assert.Nil(t, stores.Pipelines.DeletePipeline(pipeline.UUID))
+	_, err = stores.Pipelines.GetPipeline(pipeline.UUID)
+	assertErrorCode(t, err, codes.NotFound)
+	// Its steps are deleted with it.
+	steps, err := stores.Pipelines.ListPipelineSteps(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Empty(t, steps)
+	// And its name can be reused.
+	_, err = stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Status: model.PipelineReady})
+	assert.Nil(t, err)
+}
+
+func testUpdatePipelineDefinition(t *testing.T, stores *Stores) {
+	pipeline, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Description: "first",
+		Parameters: `[{"name":"param1"}]`, Status: model.PipelineReady})
+	assert.Nil(t, err)
+
+	assert.Nil(t, stores.Pipelines.UpdatePipelineDefinition(pipeline.UUID, "second", `[{"name":"param2"}]`,
+		`[{"name":"param2","type":"integer"}]`, "checksum2"))
+	fetched, err := stores.Pipelines.GetPipeline(pipeline.UUID)
+	assert.Nil(t, err)
+	expected := *pipeline
+	expected.Description = "second"
+	expected.Parameters = `[{"name":"param2"}]`
+	expected.ParameterSchema = `[{"name":"param2","type":"integer"}]`
+	expected.TemplateChecksum = "checksum2"
+	assert.Equal(t, &expected, fetched)
+}
+
+func testUpdatePipelineDeprecation(t *testing.T, stores *Stores) {
+	pipeline, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Status: model.PipelineReady})
+	assert.Nil(t, err)
+	replacement, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p2", Status: model.PipelineReady})
+	assert.Nil(t, err)
+
+	assert.Nil(t, stores.Pipelines.UpdatePipelineDeprecation(pipeline.UUID, true, replacement.UUID, 100))
+	fetched, err := stores.Pipelines.GetPipeline(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.True(t, fetched.Deprecated)
+	assert.Equal(t, replacement.UUID, fetched.ReplacementPipelineId)
+	assert.Equal(t, int64(100), fetched.SunsetAtInSec)
+	pipelines, _, err := stores.Pipelines.ListPipelines(&common.FilterContext{ExcludeDeprecated: true},
+		pipelinesByName(10))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"p2"}, pipelineNames(pipelines))
+
+	assert.Nil(t, stores.Pipelines.UpdatePipelineDeprecation(pipeline.UUID, false, "", 0))
+	fetched, err = stores.Pipelines.GetPipeline(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.False(t, fetched.Deprecated)
+	assert.Empty(t, fetched.ReplacementPipelineId)
+	assert.Zero(t, fetched.SunsetAtInSec)
+}
+
+func testCreatePipelineSteps(t *testing.T, stores *Stores) {
+	pipeline, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p1", Status: model.PipelineReady})
+	assert.Nil(t, err)
+	train := &model.PipelineStep{PipelineUUID: pipeline.UUID, Name: "train", Position: 1, Type: "Container",
+		Image: "python:3.7", Children: "[]"}
+	main := &model.PipelineStep{PipelineUUID: pipeline.UUID, Name: "main", Position: 0, Type: "Steps",
+		Children: `["train"]`}
+
+	assert.Nil(t, stores.Pipelines.CreatePipelineSteps(pipeline.UUID, []*model.PipelineStep{train, main}))
+	steps, err := stores.Pipelines.ListPipelineSteps(pipeline.UUID)
+	assert.Nil(t, err)
+	// In the order of the template.
+	assert.Equal(t, []*model.PipelineStep{main, train}, steps)
+
+	// The steps are replaced.
+	assert.Nil(t, stores.Pipelines.CreatePipelineSteps(pipeline.UUID, []*model.PipelineStep{train}))
+	steps, err = stores.Pipelines.ListPipelineSteps(pipeline.UUID)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.PipelineStep{train}, steps)
+}
+
+func testListPipelines(t *testing.T, stores *Stores) {
+	for _, name := range []string{"p2", "p3", "p1"} {
+		_, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: name, Status: model.PipelineReady})
+		assert.Nil(t, err)
+	}
+	// Only the ready pipelines are listed.
+	_, err := stores.Pipelines.CreatePipeline(&model.Pipeline{Name: "p0", Status: model.PipelineCreating})
+	assert.Nil(t, err)
+
+	context := pipelinesByName(2)
+	pipelines, pageToken, err := stores.Pipelines.ListPipelines(&common.FilterContext{}, context)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"p1", "p2"}, pipelineNames(pipelines))
+	assert.NotEmpty(t, pageToken)
+	pipelines, pageToken, err = stores.Pipelines.ListPipelines(&common.FilterContext{},
+		nextPage(t, context, pageToken))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"p3"}, pipelineNames(pipelines))
+	assert.Empty(t, pageToken)
+}
+
+func pipelinesByName(pageSize int) *common.PaginationContext {
+	return &common.PaginationContext{
+		PageSize:        pageSize,
+		SortByFieldName: "Name",
+		KeyFieldName:    model.GetPipelineTablePrimaryKeyColumn(),
+	}
+}
+
+func pipelineNames(pipelines []model.Pipeline) []string {
+	var names []string
+	for _, pipeline := range pipelines {
+		names = append(names, pipeline.Name)
+	}
+	return names
+}
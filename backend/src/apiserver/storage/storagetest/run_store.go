@@ -0,0 +1,243 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// TestRunStore runs the conformance tests of the run store.
+func TestRunStore(t *testing.T, newStores NewStores) {
+	runConformanceTests(t, newStores, []conformanceTest{
+		{"CreateRun", testCreateRun},
+		{"GetRun_NotFound", testGetRunNotFound},
+		{"UpdateRun", testUpdateRun},
+		{"UpdateRun_NotFound", testUpdateRunNotFound},
+		{"CreateOrUpdateRun", testCreateOrUpdateRun},
+		{"UpdateRunAnnotations", testUpdateRunAnnotations},
+		{"ListRuns", testListRuns},
+		{"ListUnfinishedRunsOfExperiment", testListUnfinishedRunsOfExperiment},
+		{"GetExistingRunIds", testGetExistingRunIds},
+		{"ReportMetric", testReportMetric},
+	})
+}
+
+// newRun returns a run owned by an experiment, created at createdAtInSec.
+func newRun(id string, experimentId string, createdAtInSec int64, conditions string) *model.RunDetail {
+	return &model.RunDetail{
+		Run: model.Run{
+			UUID:             id,
+			DisplayName:      "run " + id,
+			Name:             "run-" + id,
+			Namespace:        "kubeflow",
+			CreatedAtInSec:   createdAtInSec,
+			ScheduledAtInSec: createdAtInSec,
+			Conditions:       conditions,
+			PipelineSpec: model.PipelineSpec{
+				WorkflowSpecManifest: "workflow",
+				Parameters:           `[{"name":"param1","value":"world"}]`,
+			},
+			ResourceReferences: []*model.ResourceReference{{
+				ResourceUUID: id, ResourceType: common.Run,
+				ReferenceUUID: experimentId, ReferenceType: common.Experiment,
+				Relationship: common.Owner,
+			}},
+		},
+		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "runtime workflow"},
+	}
+}
+
+func testCreateRun(t *testing.T, stores *Stores) {
+	run := newRun("run1", createExperiment(t, stores, "e1"), 1, "Running")
+	created, err := stores.Runs.CreateRun(run)
+	assert.Nil(t, err)
+	assert.Equal(t, run, created)
+
+	fetched, err := stores.Runs.GetRun("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, run, fetched)
+}
+
+func testGetRunNotFound(t *testing.T, stores *Stores) {
+	_, err := stores.Runs.GetRun("missing")
+	assertErrorCode(t, err, codes.NotFound)
+}
+
+func testUpdateRun(t *testing.T, stores *Stores) {
+	run := newRun("run1", createExperiment(t, stores, "e1"), 1, "Running")
+	_, err := stores.Runs.CreateRun(run)
+	assert.Nil(t, err)
+
+	assert.Nil(t, stores.Runs.UpdateRun("run1", "Succeeded", "finished workflow"))
+	fetched, err := stores.Runs.GetRun("run1")
+	assert.Nil(t, err)
+	run.Conditions = "Succeeded"
+	run.WorkflowRuntimeManifest = "finished workflow"
+	assert.Equal(t, run, fetched)
+}
+
+func testUpdateRunNotFound(t *testing.T, stores *Stores) {
+	assertErrorCode(t, stores.Runs.UpdateRun("missing", "Succeeded", "finished workflow"), codes.InvalidArgument)
+}
+
+func testCreateOrUpdateRun(t *testing.T, stores *Stores) {
+	run := newRun("run1", createExperiment(t, stores, "e1"), 1, "Running")
+
+	// The missing run is created.
+	assert.Nil(t, stores.Runs.CreateOrUpdateRun(run))
+	fetched, err := stores.Runs.GetRun("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, run, fetched)
+
+	// Then only its condition and runtime manifest are updated.
+	assert.Nil(t, stores.Runs.CreateOrUpdateRun(&model.RunDetail{
+		Run:             model.Run{UUID: "run1", Name: "other", ScheduledAtInSec: 2, Conditions: "Succeeded"},
+		PipelineRuntime: model.PipelineRuntime{WorkflowRuntimeManifest: "finished workflow"},
+	}))
+	fetched, err = stores.Runs.GetRun("run1")
+	assert.Nil(t, err)
+	run.Conditions = "Succeeded"
+	run.WorkflowRuntimeManifest = "finished workflow"
+	assert.Equal(t, run, fetched)
+}
+
+func testUpdateRunAnnotations(t *testing.T, stores *Stores) {
+	_, err := stores.Runs.CreateRun(newRun("run1", createExperiment(t, stores, "e1"), 1, "Running"))
+	assert.Nil(t, err)
+
+	note := "flaky"
+	assert.Nil(t, stores.Runs.UpdateRunAnnotations("run1", &note, map[string]string{"quality": "bad", "owner": "alice"}))
+	// The note is kept if not set, the other annotations are kept and the empty ones are removed.
+	assert.Nil(t, stores.Runs.UpdateRunAnnotations("run1", nil, map[string]string{"quality": "good", "owner": ""}))
+	run, err := stores.Runs.GetRun("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, "flaky", run.Note)
+	assert.Equal(t, map[string]string{"quality": "good"}, run.Annotations)
+
+	err = stores.Runs.UpdateRunAnnotations("missing", &note, nil)
+	assertErrorCode(t, err, codes.NotFound)
+}
+
+func testListRuns(t *testing.T, stores *Stores) {
+	experimentId := createExperiment(t, stores, "e1")
+	otherExperimentId := createExperiment(t, stores, "e2")
+	for _, run := range []*model.RunDetail{
+		newRun("run1", experimentId, 1, "Succeeded"),
+		newRun("run2", otherExperimentId, 2, "Succeeded"),
+		newRun("run3", experimentId, 3, "Running"),
+		newRun("run4", experimentId, 4, "Running"),
+	} {
+		_, err := stores.Runs.CreateRun(run)
+		assert.Nil(t, err)
+	}
+	filterContext := &common.FilterContext{
+		ReferenceKey: &common.ReferenceKey{Type: common.Experiment, ID: experimentId}}
+
+	context := runsByCreationTime(2)
+	runs, pageToken, err := stores.Runs.ListRuns(filterContext, context, common.BasicView)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"run1", "run3"}, runIds(runs))
+	assert.NotEmpty(t, pageToken)
+	// The basic view doesn't load the manifests.
+	assert.Empty(t, runs[0].WorkflowSpecManifest)
+	assert.Equal(t, "Succeeded", runs[0].Conditions)
+	runs, pageToken, err = stores.Runs.ListRuns(filterContext, nextPage(t, context, pageToken), common.FullView)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"run4"}, runIds(runs))
+	assert.Empty(t, pageToken)
+	assert.Equal(t, "workflow", runs[0].WorkflowSpecManifest)
+
+	context.IsDesc = true
+	runs, _, err = stores.Runs.ListRuns(&common.FilterContext{}, context, common.BasicView)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"run4", "run3"}, runIds(runs))
+}
+
+func testListUnfinishedRunsOfExperiment(t *testing.T, stores *Stores) {
+	experimentId := createExperiment(t, stores, "e1")
+	otherExperimentId := createExperiment(t, stores, "e2")
+	for _, run := range []*model.RunDetail{
+		newRun("run1", experimentId, 1, "Succeeded"),
+		newRun("run2", experimentId, 2, "Running"),
+		newRun("run3", otherExperimentId, 3, "Running"),
+		newRun("run4", experimentId, 4, "Failed"),
+		newRun("run5", experimentId, 5, "Pending"),
+	} {
+		_, err := stores.Runs.CreateRun(run)
+		assert.Nil(t, err)
+	}
+
+	runs, err := stores.Runs.ListUnfinishedRunsOfExperiment(experimentId)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"run2", "run5"}, runIds(runs))
+}
+
+func testGetExistingRunIds(t *testing.T, stores *Stores) {
+	_, err := stores.Runs.CreateRun(newRun("run1", createExperiment(t, stores, "e1"), 1, "Running"))
+	assert.Nil(t, err)
+
+	existing, err := stores.Runs.GetExistingRunIds([]string{"run1", "missing"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"run1": true}, existing)
+	existing, err = stores.Runs.GetExistingRunIds(nil)
+	assert.Nil(t, err)
+	assert.Empty(t, existing)
+}
+
+func testReportMetric(t *testing.T, stores *Stores) {
+	_, err := stores.Runs.CreateRun(newRun("run1", createExperiment(t, stores, "e1"), 1, "Running"))
+	assert.Nil(t, err)
+	metric := &model.RunMetric{RunUUID: "run1", NodeID: "node1", Name: "accuracy", NumberValue: 0.9,
+		Format: "PERCENTAGE"}
+
+	assert.Nil(t, stores.Runs.ReportMetric(metric))
+	assertErrorCode(t, stores.Runs.ReportMetric(metric), codes.AlreadyExists)
+	errs := stores.Runs.ReportMetrics([]*model.RunMetric{
+		{RunUUID: "run1", NodeID: "node1", Name: "loss", NumberValue: 0.1},
+		{RunUUID: "run1", NodeID: "node1", Name: "loss", NumberValue: 0.2},
+	})
+	assert.Nil(t, errs[0])
+	assertErrorCode(t, errs[1], codes.AlreadyExists)
+
+	run, err := stores.Runs.GetRun("run1")
+	assert.Nil(t, err)
+	if assert.Len(t, run.Metrics, 2) {
+		assert.Equal(t, "accuracy", run.Metrics[0].Name)
+		assert.Equal(t, 0.9, run.Metrics[0].NumberValue)
+		assert.Equal(t, "loss", run.Metrics[1].Name)
+		assert.Equal(t, 0.1, run.Metrics[1].NumberValue)
+	}
+}
+
+func runsByCreationTime(pageSize int) *common.PaginationContext {
+	return &common.PaginationContext{
+		PageSize:        pageSize,
+		SortByFieldName: "CreatedAtInSec",
+		KeyFieldName:    model.GetRunTablePrimaryKeyColumn(),
+	}
+}
+
+func runIds(runs []model.Run) []string {
+	var ids []string
+	for _, run := range runs {
+		ids = append(ids, run.UUID)
+	}
+	return ids
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+func newSQLStores(t *testing.T) (*Stores, func()) {
+	db := storage.NewFakeDbOrFatal()
+	time := util.NewFakeTimeForEpoch()
+	uuid := util.NewUUIDGenerator()
+	return &Stores{
+		Experiments: storage.NewExperimentStore(db, time, uuid),
+		Pipelines:   storage.NewPipelineStore(db, time, uuid),
+		Jobs:        storage.NewJobStore(db, time),
+		Runs:        storage.NewRunStore(db, time),
+	}, func() { db.Close() }
+}
+
+func TestSQLStores(t *testing.T) {
+	TestStores(t, newSQLStores)
+}
+
+func TestDegradedModePipelineStore(t *testing.T) {
+	TestPipelineStore(t, func(t *testing.T) (*Stores, func()) {
+		stores, release := newSQLStores(t)
+		stores.Pipelines = storage.NewDegradedModePipelineStore(stores.Pipelines, 10)
+		return stores, release
+	})
+}
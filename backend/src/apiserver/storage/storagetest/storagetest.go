@@ -0,0 +1,87 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storagetest tests that the implementations of the store interfaces honor the
+// contract the resource manager relies on. A store backed by another database runs the suite
+// from its own tests, with a NewStores function returning its stores.
+package storagetest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+// Stores are the stores under test. They share a database which has no row at first, and
+// generate different IDs for the resources they create.
+type Stores struct {
+	Experiments storage.ExperimentStoreInterface
+	Pipelines   storage.PipelineStoreInterface
+	Jobs        storage.JobStoreInterface
+	Runs        storage.RunStoreInterface
+}
+
+// NewStores returns new stores under test, and the function releasing their database. Every
+// test case gets its own stores.
+type NewStores func(t *testing.T) (*Stores, func())
+
+// conformanceTest is a test case of the contract of a store.
+type conformanceTest struct {
+	name string
+	test func(t *testing.T, stores *Stores)
+}
+
+// TestStores runs the conformance tests of all the stores.
+func TestStores(t *testing.T, newStores NewStores) {
+	t.Run("ExperimentStore", func(t *testing.T) { TestExperimentStore(t, newStores) })
+	t.Run("PipelineStore", func(t *testing.T) { TestPipelineStore(t, newStores) })
+	t.Run("JobStore", func(t *testing.T) { TestJobStore(t, newStores) })
+	t.Run("RunStore", func(t *testing.T) { TestRunStore(t, newStores) })
+}
+
+func runConformanceTests(t *testing.T, newStores NewStores, tests []conformanceTest) {
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			stores, release := newStores(t)
+			defer release()
+			test.test(t, stores)
+		})
+	}
+}
+
+// nextPage returns the pagination context of the page following the one listed with context.
+// The page tokens are the base64 encoding of the JSON of a common.Token, which the API server
+// decodes.
+func nextPage(t *testing.T, context *common.PaginationContext, pageToken string) *common.PaginationContext {
+	tokenBytes, err := base64.StdEncoding.DecodeString(pageToken)
+	assert.Nil(t, err)
+	var token common.Token
+	assert.Nil(t, json.Unmarshal(tokenBytes, &token))
+	next := *context
+	next.Token = &token
+	return &next
+}
+
+func assertErrorCode(t *testing.T, err error, code codes.Code) {
+	if assert.NotNil(t, err) {
+		assert.True(t, util.IsUserErrorCodeMatch(err, code), "Expected the code %v, got the error: %v", code, err)
+	}
+}
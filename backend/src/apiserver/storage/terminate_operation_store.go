@@ -0,0 +1,219 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var terminateOperationColumns = []string{"UUID", "ExperimentUUID", "RunGroupUUID", "CreatedAtInSec", "FinishedAtInSec"}
+
+var terminateResultColumns = []string{"OperationUUID", "RunUUID", "Status", "Error"}
+
+type TerminateOperationStoreInterface interface {
+	// CreateOperation stores an operation with a pending result for each of its runs.
+	CreateOperation(operation *model.TerminateOperation, runUUIDs []string) error
+	GetOperation(uuid string) (*model.TerminateOperation, error)
+	// ListResults lists the results of an operation in the order of the UUID of their run.
+	ListResults(operationUUID string) ([]*model.TerminateResult, error)
+	// ListPendingOperations lists the operations which aren't finished, the oldest first.
+	ListPendingOperations() ([]*model.TerminateOperation, error)
+	// SetResult records the outcome of the termination of a run.
+	SetResult(result *model.TerminateResult) error
+	// FinishOperation records the time every run of an operation was processed.
+	FinishOperation(uuid string, finishedAtInSec int64) error
+}
+
+type TerminateOperationStore struct {
+	db *DB
+}
+
+func (s *TerminateOperationStore) CreateOperation(operation *model.TerminateOperation, runUUIDs []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create a new transaction to store the terminate operation %v",
+			operation.UUID)
+	}
+	operationSql, operationArgs, err := sq.
+		Insert("terminate_operations").
+		SetMap(sq.Eq{
+			"UUID":            operation.UUID,
+			"ExperimentUUID":  operation.ExperimentUUID,
+			"RunGroupUUID":    operation.RunGroupUUID,
+			"CreatedAtInSec":  operation.CreatedAtInSec,
+			"FinishedAtInSec": operation.FinishedAtInSec}).
+		ToSql()
+	if err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to create query to store the terminate operation %v",
+			operation.UUID)
+	}
+	if _, err := tx.Exec(operationSql, operationArgs...); err != nil {
+		tx.Rollback()
+		return util.NewInternalServerError(err, "Failed to store the terminate operation %v", operation.UUID)
+	}
+	for _, runUUID := range runUUIDs {
+		resultSql, resultArgs, err := sq.
+			Insert("terminate_results").
+			SetMap(sq.Eq{
+				"OperationUUID": operation.UUID,
+				"RunUUID":       runUUID,
+				"Status":        model.TerminatePending,
+				"Error":         ""}).
+			ToSql()
+		if err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to create query to store the results of the terminate operation %v",
+				operation.UUID)
+		}
+		if _, err := tx.Exec(resultSql, resultArgs...); err != nil {
+			tx.Rollback()
+			return util.NewInternalServerError(err, "Failed to store the results of the terminate operation %v",
+				operation.UUID)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return util.NewInternalServerError(err, "Failed to store the terminate operation %v", operation.UUID)
+	}
+	return nil
+}
+
+func (s *TerminateOperationStore) GetOperation(uuid string) (*model.TerminateOperation, error) {
+	sql, args, err := sq.
+		Select(terminateOperationColumns...).
+		From("terminate_operations").
+		Where(sq.Eq{"UUID": uuid}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the terminate operation %v", uuid)
+	}
+	operations, err := s.queryOperations(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the terminate operation %v", uuid)
+	}
+	if len(operations) == 0 {
+		return nil, util.NewResourceNotFoundError("Terminate operation", uuid)
+	}
+	return operations[0], nil
+}
+
+func (s *TerminateOperationStore) ListResults(operationUUID string) ([]*model.TerminateResult, error) {
+	sql, args, err := sq.
+		Select(terminateResultColumns...).
+		From("terminate_results").
+		Where(sq.Eq{"OperationUUID": operationUUID}).
+		OrderBy("RunUUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the results of the terminate operation %v",
+			operationUUID)
+	}
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the results of the terminate operation %v",
+			operationUUID)
+	}
+	defer rows.Close()
+	var results []*model.TerminateResult
+	for rows.Next() {
+		var result model.TerminateResult
+		if err := rows.Scan(&result.OperationUUID, &result.RunUUID, &result.Status, &result.Error); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to parse the results of the terminate operation %v",
+				operationUUID)
+		}
+		results = append(results, &result)
+	}
+	return results, nil
+}
+
+func (s *TerminateOperationStore) ListPendingOperations() ([]*model.TerminateOperation, error) {
+	sql, args, err := sq.
+		Select(terminateOperationColumns...).
+		From("terminate_operations").
+		Where(sq.Eq{"FinishedAtInSec": 0}).
+		OrderBy("CreatedAtInSec", "UUID").
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the pending terminate operations")
+	}
+	operations, err := s.queryOperations(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the pending terminate operations")
+	}
+	return operations, nil
+}
+
+func (s *TerminateOperationStore) SetResult(result *model.TerminateResult) error {
+	sql, args, err := sq.
+		Update("terminate_results").
+		SetMap(sq.Eq{"Status": result.Status, "Error": result.Error}).
+		Where(sq.Eq{"OperationUUID": result.OperationUUID, "RunUUID": result.RunUUID}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to update the result of the run %v of the terminate operation %v",
+			result.RunUUID, result.OperationUUID)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to update the result of the run %v of the terminate operation %v",
+			result.RunUUID, result.OperationUUID)
+	}
+	return nil
+}
+
+func (s *TerminateOperationStore) FinishOperation(uuid string, finishedAtInSec int64) error {
+	sql, args, err := sq.
+		Update("terminate_operations").
+		Set("FinishedAtInSec", finishedAtInSec).
+		Where(sq.Eq{"UUID": uuid}).
+		ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to finish the terminate operation %v", uuid)
+	}
+	if _, err := s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to finish the terminate operation %v", uuid)
+	}
+	return nil
+}
+
+func (s *TerminateOperationStore) queryOperations(query string, args []interface{}) ([]*model.TerminateOperation, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanOperationRows(rows)
+}
+
+func (s *TerminateOperationStore) scanOperationRows(rows *sql.Rows) ([]*model.TerminateOperation, error) {
+	var operations []*model.TerminateOperation
+	for rows.Next() {
+		var operation model.TerminateOperation
+		if err := rows.Scan(&operation.UUID, &operation.ExperimentUUID, &operation.RunGroupUUID,
+			&operation.CreatedAtInSec, &operation.FinishedAtInSec); err != nil {
+			return operations, err
+		}
+		operations = append(operations, &operation)
+	}
+	return operations, nil
+}
+
+// factory function for terminate operation store
+func NewTerminateOperationStore(db *DB) *TerminateOperationStore {
+	return &TerminateOperationStore{db: db}
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestTerminateOperationStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewTerminateOperationStore(db)
+
+	first := &model.TerminateOperation{UUID: fakeID, ExperimentUUID: "experiment1", CreatedAtInSec: 1}
+	second := &model.TerminateOperation{UUID: fakeIDTwo, RunGroupUUID: "group1", CreatedAtInSec: 2}
+	assert.Nil(t, store.CreateOperation(second, nil))
+	assert.Nil(t, store.CreateOperation(first, []string{"run2", "run1"}))
+
+	operation, err := store.GetOperation(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, first, operation)
+	_, err = store.GetOperation("unknown")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+	results, err := store.ListResults(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TerminateResult{
+		{OperationUUID: fakeID, RunUUID: "run1", Status: model.TerminatePending},
+		{OperationUUID: fakeID, RunUUID: "run2", Status: model.TerminatePending},
+	}, results)
+
+	assert.Nil(t, store.SetResult(&model.TerminateResult{
+		OperationUUID: fakeID, RunUUID: "run2", Status: model.TerminateFailed, Error: "boom"}))
+	results, err = store.ListResults(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, model.TerminatePending, results[0].Status)
+	assert.Equal(t, model.TerminateFailed, results[1].Status)
+	assert.Equal(t, "boom", results[1].Error)
+
+	operations, err := store.ListPendingOperations()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TerminateOperation{first, second}, operations)
+	// The finished operations aren't pending anymore.
+	assert.Nil(t, store.FinishOperation(fakeIDTwo, 3))
+	operations, err = store.ListPendingOperations()
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TerminateOperation{first}, operations)
+	operation, err = store.GetOperation(fakeIDTwo)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), operation.FinishedAtInSec)
+}
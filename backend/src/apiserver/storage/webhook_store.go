@@ -0,0 +1,186 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var webhookColumns = []string{"UUID", "Name", "Url", "Secret", "ExperimentUUID", "Namespace", "EventFilters", "CreatedAtInSec"}
+
+type WebhookStoreInterface interface {
+	ListWebhooks(*common.PaginationContext) ([]model.Webhook, string, error)
+	// ListWebhooksForRun returns the webhooks scoped to the given experiment or namespace.
+	ListWebhooksForRun(experimentUUID string, namespace string) ([]model.Webhook, error)
+	GetWebhook(uuid string) (*model.Webhook, error)
+	CreateWebhook(*model.Webhook) (*model.Webhook, error)
+	DeleteWebhook(uuid string) error
+}
+
+type WebhookStore struct {
+	db   *DB
+	time util.TimeInterface
+	uuid util.UUIDGeneratorInterface
+}
+
+func (s *WebhookStore) ListWebhooks(context *common.PaginationContext) ([]model.Webhook, string, error) {
+	models, pageToken, err := listModel(context, s.queryWebhookTable)
+	if err != nil {
+		return nil, "", util.Wrap(err, "List webhooks failed.")
+	}
+	return s.toWebhooks(models), pageToken, err
+}
+
+func (s *WebhookStore) queryWebhookTable(context *common.PaginationContext) ([]model.ListableDataModel, error) {
+	sqlBuilder := sq.Select(webhookColumns...).From("webhooks")
+	sql, args, err := toPaginationQuery(sqlBuilder, context).Limit(uint64(context.PageSize)).ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list webhooks: %v", err.Error())
+	}
+	webhooks, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list webhooks: %v", err.Error())
+	}
+	return s.toListableModels(webhooks), nil
+}
+
+func (s *WebhookStore) ListWebhooksForRun(experimentUUID string, namespace string) ([]model.Webhook, error) {
+	sql, args, err := sq.
+		Select(webhookColumns...).
+		From("webhooks").
+		Where(sq.Or{sq.Eq{"ExperimentUUID": experimentUUID}, sq.Eq{"ExperimentUUID": ""}}).
+		Where(sq.Or{sq.Eq{"Namespace": namespace}, sq.Eq{"Namespace": ""}}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to list the webhooks of a run: %v", err.Error())
+	}
+	webhooks, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to list the webhooks of a run: %v", err.Error())
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookStore) GetWebhook(uuid string) (*model.Webhook, error) {
+	sql, args, err := sq.
+		Select(webhookColumns...).
+		From("webhooks").
+		Where(sq.Eq{"UUID": uuid}).
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get webhook: %v", err.Error())
+	}
+	webhooks, err := s.query(sql, args)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get webhook: %v", err.Error())
+	}
+	if len(webhooks) == 0 {
+		return nil, util.NewResourceNotFoundError("Webhook", uuid)
+	}
+	return &webhooks[0], nil
+}
+
+func (s *WebhookStore) CreateWebhook(webhook *model.Webhook) (*model.Webhook, error) {
+	newWebhook := *webhook
+	newWebhook.CreatedAtInSec = s.time.Now().Unix()
+	id, err := s.uuid.NewRandom()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a webhook id.")
+	}
+	newWebhook.UUID = id.String()
+	sql, args, err := sq.
+		Insert("webhooks").
+		SetMap(sq.Eq{
+			"UUID":           newWebhook.UUID,
+			"Name":           newWebhook.Name,
+			"Url":            newWebhook.Url,
+			"Secret":         newWebhook.Secret,
+			"ExperimentUUID": newWebhook.ExperimentUUID,
+			"Namespace":      newWebhook.Namespace,
+			"EventFilters":   newWebhook.EventFilters,
+			"CreatedAtInSec": newWebhook.CreatedAtInSec}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to insert webhook to webhook table: %v", err.Error())
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		if s.db.IsDuplicateError(err) {
+			return nil, util.NewInvalidInputError(
+				"Failed to create a new webhook. The name %v already exist. Please specify a new name.", webhook.Name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to add webhook to webhook table: %v", err.Error())
+	}
+	return &newWebhook, nil
+}
+
+func (s *WebhookStore) DeleteWebhook(uuid string) error {
+	sql, args, err := sq.Delete("webhooks").Where(sq.Eq{"UUID": uuid}).ToSql()
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to create query to delete webhook: %v", err.Error())
+	}
+	if _, err = s.db.Exec(sql, args...); err != nil {
+		return util.NewInternalServerError(err, "Failed to delete webhook: %v", err.Error())
+	}
+	return nil
+}
+
+func (s *WebhookStore) query(sql string, args []interface{}) ([]model.Webhook, error) {
+	rows, err := s.db.Query(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return s.scanRows(rows)
+}
+
+func (s *WebhookStore) scanRows(rows *sql.Rows) ([]model.Webhook, error) {
+	var webhooks []model.Webhook
+	for rows.Next() {
+		var webhook model.Webhook
+		if err := rows.Scan(&webhook.UUID, &webhook.Name, &webhook.Url, &webhook.Secret,
+			&webhook.ExperimentUUID, &webhook.Namespace, &webhook.EventFilters, &webhook.CreatedAtInSec); err != nil {
+			return webhooks, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, nil
+}
+
+func (s *WebhookStore) toListableModels(webhooks []model.Webhook) []model.ListableDataModel {
+	models := make([]model.ListableDataModel, len(webhooks))
+	for i := range models {
+		models[i] = webhooks[i]
+	}
+	return models
+}
+
+func (s *WebhookStore) toWebhooks(models []model.ListableDataModel) []model.Webhook {
+	webhooks := make([]model.Webhook, len(models))
+	for i := range models {
+		webhooks[i] = models[i].(model.Webhook)
+	}
+	return webhooks
+}
+
+// factory function for webhook store
+func NewWebhookStore(db *DB, time util.TimeInterface, uuid util.UUIDGeneratorInterface) *WebhookStore {
+	return &WebhookStore{db: db, time: time, uuid: uuid}
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/common"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func createWebhook(name string, experimentUUID string) *model.Webhook {
+	return &model.Webhook{
+		Name:           name,
+		Url:            "https://example.com/" + name,
+		Secret:         "secret",
+		ExperimentUUID: experimentUUID,
+		EventFilters:   "Succeeded,Failed",
+	}
+}
+
+func TestCreateWebhook(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	webhookStore := NewWebhookStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+
+	webhook, err := webhookStore.CreateWebhook(createWebhook("webhook1", ""))
+	assert.Nil(t, err)
+	expected := createWebhook("webhook1", "")
+	expected.UUID = fakeID
+	expected.CreatedAtInSec = 1
+	assert.Equal(t, expected, webhook)
+
+	webhook, err = webhookStore.GetWebhook(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, webhook)
+}
+
+func TestCreateWebhook_DuplicateName(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	webhookStore := NewWebhookStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	webhookStore.CreateWebhook(createWebhook("webhook1", ""))
+	webhookStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+
+	_, err := webhookStore.CreateWebhook(createWebhook("webhook1", ""))
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGetWebhook_NotFound(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	webhookStore := NewWebhookStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+
+	_, err := webhookStore.GetWebhook(fakeID)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestListWebhooks(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	webhookStore := NewWebhookStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	webhookStore.CreateWebhook(createWebhook("webhook1", ""))
+	webhookStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	webhookStore.CreateWebhook(createWebhook("webhook2", ""))
+
+	webhooks, nextPageToken, err := webhookStore.ListWebhooks(&common.PaginationContext{
+		PageSize:        1,
+		KeyFieldName:    model.GetWebhookTablePrimaryKeyColumn(),
+		SortByFieldName: "CreatedAtInSec",
+	})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, nextPageToken)
+	assert.Len(t, webhooks, 1)
+	assert.Equal(t, "webhook1", webhooks[0].Name)
+}
+
+func TestListWebhooksForRun(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	webhookStore := NewWebhookStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	webhookStore.CreateWebhook(createWebhook("all runs", ""))
+	webhookStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDTwo, nil)
+	webhookStore.CreateWebhook(createWebhook("experiment1", "experiment1"))
+	webhookStore.uuid = util.NewFakeUUIDGeneratorOrFatal(fakeIDThree, nil)
+	webhookStore.CreateWebhook(createWebhook("experiment2", "experiment2"))
+
+	webhooks, err := webhookStore.ListWebhooksForRun("experiment1", "kubeflow")
+	assert.Nil(t, err)
+	var names []string
+	for _, webhook := range webhooks {
+		names = append(names, webhook.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"all runs", "experiment1"}, names)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	webhookStore := NewWebhookStore(db, util.NewFakeTimeForEpoch(), util.NewFakeUUIDGeneratorOrFatal(fakeID, nil))
+	webhookStore.CreateWebhook(createWebhook("webhook1", ""))
+
+	assert.Nil(t, webhookStore.DeleteWebhook(fakeID))
+	_, err := webhookStore.GetWebhook(fakeID)
+	assert.Equal(t, codes.NotFound, err.(*util.UserError).ExternalStatusCode())
+}
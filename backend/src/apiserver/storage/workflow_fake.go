@@ -15,12 +15,15 @@
 package storage
 
 import (
+	"sort"
 	"strconv"
 
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/watch"
 )
@@ -68,8 +71,22 @@ func (c *FakeWorkflowClient) Get(name string, options v1.GetOptions) (*v1alpha1.
 }
 
 func (c *FakeWorkflowClient) List(opts v1.ListOptions) (*v1alpha1.WorkflowList, error) {
-	glog.Error("This fake method is not yet implemented.")
-	return nil, nil
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(c.workflows))
+	for name := range c.workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	list := &v1alpha1.WorkflowList{}
+	for _, name := range names {
+		if workflow := c.workflows[name]; selector.Matches(labels.Set(workflow.Labels)) {
+			list.Items = append(list.Items, *workflow)
+		}
+	}
+	return list, nil
 }
 
 func (c *FakeWorkflowClient) Watch(opts v1.ListOptions) (watch.Interface, error) {
@@ -78,12 +95,18 @@ func (c *FakeWorkflowClient) Watch(opts v1.ListOptions) (watch.Interface, error)
 }
 
 func (c *FakeWorkflowClient) Update(workflow *v1alpha1.Workflow) (*v1alpha1.Workflow, error) {
-	glog.Error("This fake method is not yet implemented.")
-	return nil, nil
+	if _, ok := c.workflows[workflow.Name]; !ok {
+		return nil, apierrors.NewNotFound(v1alpha1.Resource("workflows"), workflow.Name)
+	}
+	c.workflows[workflow.Name] = workflow
+	return workflow, nil
 }
 
 func (c *FakeWorkflowClient) Delete(name string, options *v1.DeleteOptions) error {
-	glog.Error("This fake method is not yet implemented.")
+	if _, ok := c.workflows[name]; !ok {
+		return apierrors.NewNotFound(v1alpha1.Resource("workflows"), name)
+	}
+	delete(c.workflows, name)
 	return nil
 }
 
@@ -0,0 +1,122 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+type WorkflowReportStoreInterface interface {
+	GetReport(runUUID string) (*model.WorkflowReport, error)
+	// RecordReport stores the report of a run, unless a later version of its workflow was
+	// reported. It returns whether the report was stored.
+	RecordReport(report *model.WorkflowReport) (bool, error)
+}
+
+type WorkflowReportStore struct {
+	db *DB
+}
+
+func (s *WorkflowReportStore) GetReport(runUUID string) (*model.WorkflowReport, error) {
+	report, err := s.getReport(s.db.Query, runUUID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, util.NewResourceNotFoundError("Workflow report", runUUID)
+	}
+	return report, nil
+}
+
+// getReport returns the report of a run, or nil if there is none, through the query
+// function of the database or of a transaction.
+func (s *WorkflowReportStore) getReport(query func(string, ...interface{}) (*sql.Rows, error), runUUID string) (
+	*model.WorkflowReport, error) {
+	selectSql, args, err := sq.
+		Select("RunUUID", "ResourceVersion", "Conditions", "ReportedAtInSec").
+		From("workflow_reports").
+		Where(sq.Eq{"RunUUID": runUUID}).
+		ToSql()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create query to get the workflow report of run %v", runUUID)
+	}
+	rows, err := query(selectSql, args...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the workflow report of run %v", runUUID)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, util.NewInternalServerError(err, "Failed to read the workflow report of run %v", runUUID)
+		}
+		return nil, nil
+	}
+	var report model.WorkflowReport
+	if err := rows.Scan(&report.RunUUID, &report.ResourceVersion, &report.Conditions, &report.ReportedAtInSec); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to read the workflow report of run %v", runUUID)
+	}
+	return &report, nil
+}
+
+func (s *WorkflowReportStore) RecordReport(report *model.WorkflowReport) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, util.NewInternalServerError(err, "Failed to create a new transaction to record the workflow report")
+	}
+	stored, err := s.getReport(tx.Query, report.RunUUID)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if stored != nil && stored.ResourceVersion > report.ResourceVersion {
+		tx.Rollback()
+		return false, nil
+	}
+	values := sq.Eq{
+		"ResourceVersion": report.ResourceVersion,
+		"Conditions":      report.Conditions,
+		"ReportedAtInSec": report.ReportedAtInSec}
+	var query string
+	var args []interface{}
+	if stored == nil {
+		values["RunUUID"] = report.RunUUID
+		query, args, err = sq.Insert("workflow_reports").SetMap(values).ToSql()
+	} else {
+		query, args, err = sq.Update("workflow_reports").SetMap(values).Where(sq.Eq{"RunUUID": report.RunUUID}).ToSql()
+	}
+	if err != nil {
+		tx.Rollback()
+		return false, util.NewInternalServerError(err, "Failed to create query to record the workflow report of run %v",
+			report.RunUUID)
+	}
+	if _, err := tx.Exec(query, args...); err != nil {
+		tx.Rollback()
+		return false, util.NewInternalServerError(err, "Failed to record the workflow report of run %v", report.RunUUID)
+	}
+	if err := tx.Commit(); err != nil {
+		tx.Rollback()
+		return false, util.NewInternalServerError(err, "Failed to record the workflow report of run %v", report.RunUUID)
+	}
+	return true, nil
+}
+
+// factory function for workflow report store
+func NewWorkflowReportStore(db *DB) *WorkflowReportStore {
+	return &WorkflowReportStore{db: db}
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWorkflowReportStore(t *testing.T) {
+	db := NewFakeDbOrFatal()
+	defer db.Close()
+	store := NewWorkflowReportStore(db)
+
+	_, err := store.GetReport(fakeID)
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+
+	stored, err := store.RecordReport(&model.WorkflowReport{RunUUID: fakeID, ResourceVersion: 5, Conditions: "Running",
+		ReportedAtInSec: 1})
+	assert.Nil(t, err)
+	assert.True(t, stored)
+	// Stale.
+	stored, err = store.RecordReport(&model.WorkflowReport{RunUUID: fakeID, ResourceVersion: 4, Conditions: "Pending",
+		ReportedAtInSec: 2})
+	assert.Nil(t, err)
+	assert.False(t, stored)
+	stored, err = store.RecordReport(&model.WorkflowReport{RunUUID: fakeID, ResourceVersion: 7, Conditions: "Succeeded",
+		ReportedAtInSec: 3})
+	assert.Nil(t, err)
+	assert.True(t, stored)
+
+	report, err := store.GetReport(fakeID)
+	assert.Nil(t, err)
+	assert.Equal(t, &model.WorkflowReport{RunUUID: fakeID, ResourceVersion: 7, Conditions: "Succeeded",
+		ReportedAtInSec: 3}, report)
+}
@@ -0,0 +1,271 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	// The header carrying the HMAC-SHA256 of the payload, keyed by the secret of the webhook.
+	SignatureHeader = "X-Pipelines-Signature"
+	// The header carrying the type of the event, e.g. run.succeeded.
+	EventHeader = "X-Pipelines-Event"
+
+	// The state notified when approval gates of a run start waiting for a decision. Unlike
+	// the other states, it isn't a condition of the run, which keeps running meanwhile.
+	StateAwaitingApproval = "AwaitingApproval"
+
+	// The number of deliveries waiting to be sent before new ones are dropped.
+	queueSize = 1000
+)
+
+// RunEvent is the JSON payload posted to the webhooks when a run changes state.
+type RunEvent struct {
+	Event           string `json:"event"`
+	RunId           string `json:"run_id"`
+	RunName         string `json:"run_name"`
+	ExperimentId    string `json:"experiment_id,omitempty"`
+	Namespace       string `json:"namespace,omitempty"`
+	State           string `json:"state"`
+	FinishedAtInSec int64  `json:"finished_at_in_sec,omitempty"`
+	// The display names of the approval gates which started waiting for a decision.
+	Gates []string `json:"gates,omitempty"`
+}
+
+// EventTypeForState returns the type of the event sent when a run reaches the given state.
+func EventTypeForState(state string) string {
+	switch state {
+	case "Succeeded":
+		return "run.succeeded"
+	case "Failed", "Error":
+		return "run.failed"
+	case StateAwaitingApproval:
+		return "run.awaiting_approval"
+	default:
+		return "run." + state
+	}
+}
+
+type NotifierInterface interface {
+	// Notify queues the delivery of an event to a webhook. It doesn't block.
+	Notify(webhook *model.Webhook, event *RunEvent)
+	// Drain stops accepting events, and waits until the queued ones are delivered or
+	// the context is done.
+	Drain(ctx context.Context) error
+}
+
+type delivery struct {
+	webhook *model.Webhook
+	event   *RunEvent
+}
+
+// Notifier posts the events to the webhooks from a pool of workers. Failed deliveries
+// are retried with an exponential backoff, and logged as dead letters once the retries
+// are exhausted. The URLs of the webhooks, and the addresses they connect to, are checked
+// by the guard when the events are delivered, in case they changed since the webhooks
+// were registered.
+type Notifier struct {
+	queue     chan delivery
+	workers   sync.WaitGroup
+	guard     *util.URLGuard
+	transport *http.Transport
+
+	// Guards the timeouts, which can be changed while the events are delivered.
+	timeoutMutex   sync.RWMutex
+	client         *http.Client
+	maxElapsedTime time.Duration
+
+	// Guards the queue from being written once it's closed by Drain.
+	mutex   sync.RWMutex
+	drained bool
+}
+
+// NewNotifier creates a notifier and starts its workers.
+func NewNotifier(workers int, requestTimeout time.Duration, maxElapsedTime time.Duration,
+	guard *util.URLGuard) *Notifier {
+	n := &Notifier{
+		queue: make(chan delivery, queueSize),
+		guard: guard,
+		transport: &http.Transport{
+			Proxy:       guard.Proxy(http.ProxyFromEnvironment),
+			DialContext: guard.Dial(requestTimeout),
+		},
+		maxElapsedTime: maxElapsedTime,
+	}
+	n.client = n.newClient(requestTimeout)
+	n.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go n.run()
+	}
+	return n
+}
+
+// SetRequestTimeout changes the timeout of the requests posting the events from now on.
+func (n *Notifier) SetRequestTimeout(requestTimeout time.Duration) {
+	n.timeoutMutex.Lock()
+	defer n.timeoutMutex.Unlock()
+	n.client = n.newClient(requestTimeout)
+}
+
+// newClient creates the client posting the events, following the redirects to the URLs
+// allowed only.
+func (n *Notifier) newClient(requestTimeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: n.transport,
+		Timeout:   requestTimeout,
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			return n.guard.CheckURL(request.Context(), request.URL)
+		},
+	}
+}
+
+// SetRetryTimeout changes how long the deliveries starting from now on are retried.
+func (n *Notifier) SetRetryTimeout(maxElapsedTime time.Duration) {
+	n.timeoutMutex.Lock()
+	defer n.timeoutMutex.Unlock()
+	n.maxElapsedTime = maxElapsedTime
+}
+
+func (n *Notifier) timeouts() (*http.Client, time.Duration) {
+	n.timeoutMutex.RLock()
+	defer n.timeoutMutex.RUnlock()
+	return n.client, n.maxElapsedTime
+}
+
+func (n *Notifier) Notify(webhook *model.Webhook, event *RunEvent) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	if n.drained {
+		n.deadLetter(delivery{webhook: webhook, event: event}, fmt.Errorf("the notifier is shut down"))
+		return
+	}
+	select {
+	case n.queue <- delivery{webhook: webhook, event: event}:
+	default:
+		n.deadLetter(delivery{webhook: webhook, event: event}, fmt.Errorf("the delivery queue is full"))
+	}
+}
+
+func (n *Notifier) Drain(ctx context.Context) error {
+	n.mutex.Lock()
+	if !n.drained {
+		n.drained = true
+		close(n.queue)
+	}
+	n.mutex.Unlock()
+	done := make(chan struct{})
+	go func() {
+		n.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d deliveries are still queued: %v", len(n.queue), ctx.Err())
+	}
+}
+
+func (n *Notifier) run() {
+	defer n.workers.Done()
+	for d := range n.queue {
+		if err := n.deliverWithRetries(d); err != nil {
+			n.deadLetter(d, err)
+		}
+	}
+}
+
+func (n *Notifier) deliverWithRetries(d delivery) error {
+	payload, err := json.Marshal(d.event)
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	client, maxElapsedTime := n.timeouts()
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = maxElapsedTime
+	return backoff.RetryNotify(
+		func() error { return n.deliver(client, d.webhook, d.event.Event, payload) },
+		b,
+		func(err error, wait time.Duration) {
+			glog.Warningf("Failed to deliver the event %v of run %v to webhook %v, retrying in %v: %v",
+				d.event.Event, d.event.RunId, d.webhook.UUID, wait, err)
+		})
+}
+
+func (n *Notifier) deliver(client *http.Client, webhook *model.Webhook, eventType string, payload []byte) error {
+	u, err := url.Parse(webhook.Url)
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	if err := n.guard.CheckURL(context.Background(), u); err != nil {
+		return backoff.Permanent(err)
+	}
+	request, err := http.NewRequest(http.MethodPost, webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return backoff.Permanent(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, eventType)
+	request.Header.Set(SignatureHeader, "sha256="+Sign(webhook.Secret, payload))
+	response, err := client.Do(request)
+	if err != nil {
+		// The redirects and the connections denied by the guard won't be allowed by retrying.
+		var userErr *util.UserError
+		if errors.As(err, &userErr) {
+			return backoff.Permanent(userErr)
+		}
+		return err
+	}
+	defer response.Body.Close()
+	switch {
+	case response.StatusCode >= 200 && response.StatusCode < 300:
+		return nil
+	case response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("the webhook responded with status %v", response.Status)
+	default:
+		// The other client errors won't go away by retrying.
+		return backoff.Permanent(fmt.Errorf("the webhook responded with status %v", response.Status))
+	}
+}
+
+func (n *Notifier) deadLetter(d delivery, err error) {
+	payload, _ := json.Marshal(d.event)
+	glog.Errorf("Dead letter: failed to deliver event to webhook %v (%v): %v. Payload: %s",
+		d.webhook.UUID, d.webhook.Url, err, payload)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload, keyed by secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
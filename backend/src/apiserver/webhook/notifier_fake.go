@@ -0,0 +1,54 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+)
+
+// FakeDelivery is an event notified to the FakeNotifier.
+type FakeDelivery struct {
+	WebhookId string
+	Event     RunEvent
+}
+
+// FakeNotifier records the notified events instead of sending them.
+type FakeNotifier struct {
+	mutex      sync.Mutex
+	deliveries []FakeDelivery
+}
+
+func NewFakeNotifier() *FakeNotifier {
+	return &FakeNotifier{}
+}
+
+func (n *FakeNotifier) Notify(webhook *model.Webhook, event *RunEvent) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.deliveries = append(n.deliveries, FakeDelivery{WebhookId: webhook.UUID, Event: *event})
+}
+
+func (n *FakeNotifier) Drain(ctx context.Context) error {
+	return nil
+}
+
+func (n *FakeNotifier) Deliveries() []FakeDelivery {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return append([]FakeDelivery(nil), n.deliveries...)
+}
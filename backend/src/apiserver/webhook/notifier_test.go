@@ -0,0 +1,195 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/apiserver/model"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+var testEvent = &RunEvent{
+	Event:   "run.succeeded",
+	RunId:   "run1",
+	RunName: "run-name",
+	State:   "Succeeded",
+}
+
+func newURLGuardForTest() *util.URLGuard {
+	guard, _ := util.NewURLGuard(util.URLGuardOptions{Policy: "webhook URL", AllowedSchemes: []string{"http"}})
+	return guard
+}
+
+func TestDeliver_SignsPayload(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		received <- r
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(1, time.Second, time.Second, newURLGuardForTest())
+	notifier.Notify(&model.Webhook{UUID: "webhook1", Url: server.URL, Secret: "secret"}, testEvent)
+
+	request := <-received
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, "run.succeeded", request.Header.Get(EventHeader))
+	assert.Equal(t, "sha256="+Sign("secret", body), request.Header.Get(SignatureHeader))
+	var event RunEvent
+	assert.Nil(t, json.Unmarshal(body, &event))
+	assert.Equal(t, *testEvent, event)
+}
+
+func TestDrain(t *testing.T) {
+	var deliveries int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&deliveries, 1)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(2, time.Second, time.Second, newURLGuardForTest())
+	webhook := &model.Webhook{UUID: "webhook1", Url: server.URL, Secret: "secret"}
+	for i := 0; i < 3; i++ {
+		notifier.Notify(webhook, testEvent)
+	}
+
+	// The queued events are delivered before Drain returns, and the later ones dead lettered.
+	assert.Nil(t, notifier.Drain(context.Background()))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&deliveries))
+	notifier.Notify(webhook, testEvent)
+	assert.Nil(t, notifier.Drain(context.Background()))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&deliveries))
+}
+
+func TestDeliver_RetriesServerErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(0, time.Second, 10*time.Second, newURLGuardForTest())
+	err := notifier.deliverWithRetries(delivery{
+		webhook: &model.Webhook{UUID: "webhook1", Url: server.URL, Secret: "secret"},
+		event:   testEvent,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestDeliver_DoesNotRetryClientErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(0, time.Second, 10*time.Second, newURLGuardForTest())
+	err := notifier.deliverWithRetries(delivery{
+		webhook: &model.Webhook{UUID: "webhook1", Url: server.URL, Secret: "secret"},
+		event:   testEvent,
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "404")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestDeliver_SetTimeouts(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(0, time.Second, time.Hour, newURLGuardForTest())
+	// The deliveries are given up right after the first attempt.
+	notifier.SetRetryTimeout(time.Nanosecond)
+	notifier.SetRequestTimeout(time.Second)
+	err := notifier.deliverWithRetries(delivery{
+		webhook: &model.Webhook{UUID: "webhook1", Url: server.URL, Secret: "secret"},
+		event:   testEvent,
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestDeliver_DeniedURL(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+	}))
+	defer server.Close()
+	redirecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://metadata.google.internal/hook", http.StatusTemporaryRedirect)
+	}))
+	defer redirecting.Close()
+	guard, err := util.NewURLGuard(util.URLGuardOptions{
+		Policy:          "webhook URL",
+		AllowedSchemes:  []string{"http"},
+		DeniedHosts:     []string{"metadata.google.internal"},
+		BlockedNetworks: []string{"127.0.0.0/8"},
+	})
+	assert.Nil(t, err)
+
+	// The URL registered before the guard denied its address isn't posted to, nor retried.
+	notifier := NewNotifier(0, time.Second, time.Hour, guard)
+	err = notifier.deliverWithRetries(delivery{
+		webhook: &model.Webhook{UUID: "webhook1", Url: server.URL, Secret: "secret"},
+		event:   testEvent,
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "is denied by the webhook URL policy")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&attempts))
+
+	// Neither are the redirects to the URLs denied, nor the blocked addresses when connecting.
+	guard, err = util.NewURLGuard(util.URLGuardOptions{
+		Policy:          "webhook URL",
+		AllowedSchemes:  []string{"http"},
+		DeniedHosts:     []string{"metadata.google.internal"},
+		BlockedNetworks: []string{"127.0.0.0/8"},
+		AllowedNetworks: []string{"127.0.0.1/32"},
+	})
+	assert.Nil(t, err)
+	notifier = NewNotifier(0, time.Second, time.Hour, guard)
+	err = notifier.deliverWithRetries(delivery{
+		webhook: &model.Webhook{UUID: "webhook1", Url: redirecting.URL, Secret: "secret"},
+		event:   testEvent,
+	})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the host metadata.google.internal is denied")
+	_, err = notifier.transport.DialContext(context.Background(), "tcp", "127.0.0.2:80")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Connecting to the blocked address 127.0.0.2 is denied")
+}
+
+func TestEventTypeForState(t *testing.T) {
+	assert.Equal(t, "run.succeeded", EventTypeForState("Succeeded"))
+	assert.Equal(t, "run.failed", EventTypeForState("Failed"))
+	assert.Equal(t, "run.failed", EventTypeForState("Error"))
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/spf13/cobra"
+)
+
+func NewAdminCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "admin",
+		Short: "Administer the API server, e.g. check the consistency of its database and object store",
+	}
+}
+
+func NewAdminCheckConsistencyCmd(root *RootCommand) *cobra.Command {
+	var (
+		repair       bool
+		failOnIssues bool
+	)
+	var command = &cobra.Command{
+		Use:   "check-consistency",
+		Short: "Check the consistency of the database and the object store, printing the issues found",
+		Long: "Check the consistency of the database and the object store: the runs without manifest, the " +
+			"pipelines whose package is missing, and the objects of the deleted pipelines and runs. With " +
+			"--fail-on-issues, the command fails if issues are left unrepaired, e.g. to alert from a CronJob.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := root.Client().Admin.CheckConsistency(context.Background(),
+				&api.CheckConsistencyRequest{Repair: repair})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			if err := PrintMessage(root.Writer(), root.OutputFormat(), report); err != nil {
+				return err
+			}
+			unrepaired := 0
+			for _, issue := range report.Issues {
+				if !issue.Repaired {
+					unrepaired++
+				}
+			}
+			if failOnIssues && unrepaired > 0 {
+				return fmt.Errorf("Found %v unrepaired inconsistencies", unrepaired)
+			}
+			return nil
+		},
+	}
+	command.Flags().BoolVar(&repair, "repair", false,
+		"Repair the issues: store the manifests of the runs from their workflows, delete the pipelines "+
+			"without package and delete the dangling objects")
+	command.Flags().BoolVar(&failOnIssues, "fail-on-issues", false, "Fail if issues are left unrepaired")
+	return command
+}
+
+func NewAdminConsistencyReportCmd(root *RootCommand) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "consistency-report",
+		Short: "Display the report of the last consistency check, scheduled or not",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := root.Client().Admin.GetConsistencyReport(context.Background(),
+				&api.GetConsistencyReportRequest{})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), report)
+		},
+	}
+	return command
+}
+
+func NewAdminStorageUsageCmd(root *RootCommand) *cobra.Command {
+	var (
+		compute      bool
+		namespace    string
+		experimentId string
+	)
+	var command = &cobra.Command{
+		Use:   "storage-usage",
+		Short: "Display the bytes stored per namespace, experiment and category as of the last computation",
+		Long: "Display the bytes stored per namespace, experiment and category as of the last computation, " +
+			"scheduled or not: the packages of the pipelines, the manifests of the runs, and their artifacts " +
+			"and logs. With --compute, the storage usage is computed again first.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if compute {
+				if _, err := root.Client().Admin.ComputeStorageUsage(context.Background(),
+					&api.ComputeStorageUsageRequest{}); err != nil {
+					return errorForCLI(err)
+				}
+			}
+			report, err := root.Client().Admin.GetStorageUsage(context.Background(),
+				&api.GetStorageUsageRequest{Namespace: namespace, ExperimentId: experimentId})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), report)
+		},
+	}
+	command.Flags().BoolVar(&compute, "compute", false, "Compute the storage usage before displaying it")
+	command.Flags().StringVar(&namespace, "namespace", "", "Display only the usages of this namespace")
+	command.Flags().StringVar(&experimentId, "experiment-id", "", "Display only the usages of this experiment")
+	return command
+}
+
+// newAdminMaintenanceCmd creates the command of a maintenance operation, which fails if the
+// operation failed for some resources.
+func newAdminMaintenanceCmd(root *RootCommand, use string, short string,
+	operation func(ctx context.Context, client api.AdminServiceClient) (*api.MaintenanceResult, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := operation(context.Background(), root.Client().Admin)
+			if err != nil {
+				return errorForCLI(err)
+			}
+			if err := PrintMessage(root.Writer(), root.OutputFormat(), result); err != nil {
+				return err
+			}
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("Failed for %v resources", len(result.Errors))
+			}
+			return nil
+		},
+	}
+}
+
+// addAsyncFlag adds the flag of the maintenance operations which can be processed in the
+// background, not to be bound to the deadline of the call.
+func addAsyncFlag(command *cobra.Command, async *bool) {
+	command.Flags().BoolVar(async, "async", false,
+		"Print at once the ID of an operation processing the resources in the background, to follow "+
+			"with 'admin operation wait'")
+}
+
+func NewAdminReindexPipelinesCmd(root *RootCommand) *cobra.Command {
+	var async bool
+	command := newAdminMaintenanceCmd(root, "reindex-pipelines",
+		"Index the steps of the pipelines again from their templates",
+		func(ctx context.Context, client api.AdminServiceClient) (*api.MaintenanceResult, error) {
+			return client.ReindexPipelines(ctx, &api.ReindexPipelinesRequest{Async: async})
+		})
+	addAsyncFlag(command, &async)
+	return command
+}
+
+func NewAdminRecomputeRunStatusesCmd(root *RootCommand) *cobra.Command {
+	var async bool
+	command := newAdminMaintenanceCmd(root, "recompute-run-statuses",
+		"Update the status of the unfinished runs from their live workflows",
+		func(ctx context.Context, client api.AdminServiceClient) (*api.MaintenanceResult, error) {
+			return client.RecomputeRunStatuses(ctx, &api.RecomputeRunStatusesRequest{Async: async})
+		})
+	addAsyncFlag(command, &async)
+	return command
+}
+
+func NewAdminFlushCachesCmd(root *RootCommand) *cobra.Command {
+	return newAdminMaintenanceCmd(root, "flush-caches",
+		"Flush the caches of the pipeline templates and of the namespace configurations",
+		func(ctx context.Context, client api.AdminServiceClient) (*api.MaintenanceResult, error) {
+			return client.FlushCaches(ctx, &api.FlushCachesRequest{})
+		})
+}
+
+func NewAdminReadOnlyCmd(root *RootCommand) *cobra.Command {
+	var message string
+	var command = &cobra.Command{
+		Use:   "read-only on|off|status",
+		Short: "Turn the read-only mode on or off, the calls which write failing while it's on, or print it",
+		Long: "Turn the read-only mode on or off for all the replicas of the API server, e.g. for the " +
+			"duration of a schema migration or a backup, or print it with status. The calls which write " +
+			"fail with the --message while it's on, the reads being served as usual.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || (args[0] != "on" && args[0] != "off" && args[0] != "status") {
+				return fmt.Errorf("Expected on, off or status as the only argument")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				mode *api.ReadOnlyMode
+				err  error
+			)
+			if args[0] == "status" {
+				mode, err = root.Client().Admin.GetReadOnlyMode(context.Background(), &api.GetReadOnlyModeRequest{})
+			} else {
+				mode, err = root.Client().Admin.SetReadOnlyMode(context.Background(),
+					&api.SetReadOnlyModeRequest{ReadOnly: args[0] == "on", Message: message})
+			}
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), mode)
+		},
+	}
+	command.Flags().StringVar(&message, "message", "",
+		"The message the calls which write fail with, the default one of the API server if empty")
+	return command
+}
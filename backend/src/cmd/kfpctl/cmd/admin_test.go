@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminCheckConsistency(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	factory.Client().Admin.(*kfpfake.AdminClient).SetConsistencyIssues(&api.ConsistencyIssue{
+		Type: api.ConsistencyIssue_DANGLING_OBJECT, ResourceId: "pipelines/deleted"})
+	rootCmd.Command().SetArgs([]string{"admin", "check-consistency", "--repair", "--fail-on-issues"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Contains(t, factory.Result(), "resource_id: pipelines/deleted")
+	assert.Contains(t, factory.Result(), "repaired: true")
+
+	rootCmd.Command().SetArgs([]string{"admin", "consistency-report"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+}
+
+func TestAdminCheckConsistencyFailOnIssues(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	factory.Client().Admin.(*kfpfake.AdminClient).SetConsistencyIssues(&api.ConsistencyIssue{
+		Type: api.ConsistencyIssue_RUN_WITHOUT_MANIFEST, ResourceId: "run-1"})
+	rootCmd.Command().SetArgs([]string{"admin", "check-consistency", "--fail-on-issues"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Found 1 unrepaired inconsistencies")
+}
+
+func TestAdminConsistencyReportNotChecked(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"admin", "consistency-report"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+}
+
+func TestAdminStorageUsage(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	factory.Client().Admin.(*kfpfake.AdminClient).SetStorageUsages(
+		&api.StorageUsage{Namespace: "ns1", Category: api.StorageUsage_ARTIFACTS, Bytes: 10},
+		&api.StorageUsage{Namespace: "ns2", Category: api.StorageUsage_LOGS, Bytes: 5})
+	rootCmd.Command().SetArgs([]string{"admin", "storage-usage"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+
+	rootCmd.Command().SetArgs([]string{"admin", "storage-usage", "--compute", "--namespace", "ns1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Contains(t, factory.Result(), "namespace: ns1")
+	assert.NotContains(t, factory.Result(), "namespace: ns2")
+	assert.Contains(t, factory.Result(), "total_bytes: \"10\"")
+}
+
+func TestAdminReindexPipelines(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"admin", "reindex-pipelines"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", strings.TrimSpace(factory.Result()))
+}
+
+func TestAdminReadOnly(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"admin", "read-only", "on", "--message", "Backup in progress"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Contains(t, factory.Result(), "read_only: true")
+	assert.Contains(t, factory.Result(), "message: Backup in progress")
+	assert.True(t, factory.Client().Admin.(*kfpfake.AdminClient).IsReadOnly())
+
+	rootCmd.Command().SetArgs([]string{"admin", "read-only", "maybe"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+}
+
+func TestAdminReadOnlyStatus(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"admin", "read-only", "status"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", strings.TrimSpace(factory.Result()))
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+func NewBackupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup",
+		Short: "Export and import the pipelines, experiments, jobs and runs, e.g. to migrate them to another cluster",
+	}
+}
+
+func NewBackupExportCmd(root *RootCommand) *cobra.Command {
+	var (
+		file        string
+		includeRuns bool
+	)
+	var command = &cobra.Command{
+		Use:   "export",
+		Short: "Export the pipelines, experiments and jobs to a .tar.gz file",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archive, err := os.Create(file)
+			if err != nil {
+				return err
+			}
+			if err := root.BackupClient().Export(includeRuns, archive); err != nil {
+				archive.Close()
+				os.Remove(file)
+				return err
+			}
+			if err := archive.Close(); err != nil {
+				return err
+			}
+			fmt.Fprintf(root.Writer(), "Exported the backup to %v\n", file)
+			return nil
+		},
+	}
+	command.Flags().StringVarP(&file, "file", "f", "", "The .tar.gz file to write the backup to")
+	command.Flags().BoolVar(&includeRuns, "include-runs", false,
+		"Also export the runs. Only their status and metrics can be imported, not their workflows")
+	command.MarkFlagRequired("file")
+	return command
+}
+
+func NewBackupImportCmd(root *RootCommand) *cobra.Command {
+	var disableJobs bool
+	var command = &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import a backup, printing the new IDs of the imported resources by their exported IDs",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "backup file")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archive, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer archive.Close()
+			result, err := root.BackupClient().Import(filepath.Base(args[0]), archive, disableJobs)
+			if err != nil {
+				return err
+			}
+			if err := PrintMessage(root.Writer(), root.OutputFormat(), result); err != nil {
+				return err
+			}
+			if len(result.Errors) > 0 {
+				return fmt.Errorf("%v resources couldn't be imported", len(result.Errors))
+			}
+			return nil
+		},
+	}
+	command.Flags().BoolVar(&disableJobs, "disable-jobs", false,
+		"Disable the imported jobs, e.g. while both clusters are running")
+	return command
+}
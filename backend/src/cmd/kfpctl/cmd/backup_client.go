@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/golang/protobuf/jsonpb"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+)
+
+const (
+	backupExportPath    = "/apis/v1beta1/backup:export"
+	backupImportPath    = "/apis/v1beta1/backup:import"
+	backupImportFileKey = "uploadfile"
+)
+
+// BackupClientInterface exports and imports backups. Like the pipeline upload, they
+// aren't part of the gRPC API, they go through the HTTP API of the API server.
+type BackupClientInterface interface {
+	Export(includeRuns bool, archive io.Writer) error
+	Import(fileName string, archive io.Reader, disableJobs bool) (*api.ImportBackupResponse, error)
+}
+
+type BackupClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+func NewBackupClient(httpEndpoint string, useTLS bool, token string, httpClient *http.Client) *BackupClient {
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	return &BackupClient{
+		baseURL:    fmt.Sprintf("%v://%v", scheme, httpEndpoint),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// Export writes the tar.gz archive of the pipelines, the experiments, the jobs and, if
+// includeRuns is true, the runs.
+func (c *BackupClient) Export(includeRuns bool, archive io.Writer) error {
+	request, err := http.NewRequest(http.MethodGet,
+		fmt.Sprintf("%v%v?include_runs=%v", c.baseURL, backupExportPath, includeRuns), nil)
+	if err != nil {
+		return err
+	}
+	response, err := c.do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("Failed to export the backup: %v", readHTTPError(response))
+	}
+	if _, err := io.Copy(archive, response.Body); err != nil {
+		return fmt.Errorf("Failed to download the backup: %v", err)
+	}
+	return nil
+}
+
+// Import restores an archive written by Export. If disableJobs is true, the imported
+// jobs are disabled.
+func (c *BackupClient) Import(fileName string, archive io.Reader, disableJobs bool) (
+	*api.ImportBackupResponse, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(backupImportFileKey, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, archive); err != nil {
+		return nil, fmt.Errorf("Failed to read the backup %v: %v", fileName, err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(http.MethodPost,
+		fmt.Sprintf("%v%v?disable_jobs=%v", c.baseURL, backupImportPath, disableJobs), body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	response, err := c.do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to import the backup: %v", readHTTPError(response))
+	}
+	result := &api.ImportBackupResponse{}
+	if err := jsonpb.Unmarshal(response.Body, result); err != nil {
+		return nil, fmt.Errorf("Failed to read the result of the import: %v", err)
+	}
+	return result, nil
+}
+
+func (c *BackupClient) do(request *http.Request) (*http.Response, error) {
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to reach the API server at %v: %v", c.baseURL, err)
+	}
+	return response, nil
+}
+
+// readHTTPError returns the message of the api.Error returned by the HTTP API, or the
+// body of the response if it isn't one.
+func readHTTPError(response *http.Response) string {
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Sprintf("Failed to read the response: %v (HTTP status: %v)", err, response.StatusCode)
+	}
+	var apiError api.Error
+	if err := json.Unmarshal(body, &apiError); err != nil || apiError.ErrorMessage == "" {
+		apiError.ErrorMessage = string(body)
+	}
+	return fmt.Sprintf("%v (HTTP status: %v)", apiError.ErrorMessage, response.StatusCode)
+}
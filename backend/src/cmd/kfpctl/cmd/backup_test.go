@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfpctl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "backup.tar.gz")
+
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"backup", "export", "-f", file, "--include-runs"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, "Exported the backup to "+file, strings.TrimSpace(factory.Result()))
+	content, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, "backup include_runs=true", string(content))
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"backup", "import", file, "--disable-jobs"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+experiment_ids:
+  experiment-1: experiment-2
+pipeline_ids:
+  pipeline-1: pipeline-2
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestBackupImport_FailedResources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfpctl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "backup.tar.gz")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("backup include_runs=false"), 0644))
+
+	rootCmd, factory := GetFakeRootCommand()
+	factory.backup.importErrors = []string{"Failed to import job job-1: Invalid cron"}
+	rootCmd.Command().SetArgs([]string{"backup", "import", file})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "1 resources couldn't be imported")
+	assert.Contains(t, factory.Result(), "Failed to import job job-1: Invalid cron")
+}
+
+func TestBackupExport_MissingFile(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"backup", "export"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `required flag(s) "file" not set`)
+}
+
+func TestBackupClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case backupExportPath:
+			assert.Equal(t, "true", r.URL.Query().Get("include_runs"))
+			w.Write([]byte("archive"))
+		case backupImportPath:
+			assert.Equal(t, "false", r.URL.Query().Get("disable_jobs"))
+			file, _, err := r.FormFile(backupImportFileKey)
+			assert.Nil(t, err)
+			content, _ := ioutil.ReadAll(file)
+			if string(content) != "archive" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error_message": "The backup isn't a valid tar.gz archive"}`))
+				return
+			}
+			w.Write([]byte(`{"pipeline_ids": {"p1": "p2"}}`))
+		}
+	}))
+	defer server.Close()
+	client := NewBackupClient(strings.TrimPrefix(server.URL, "http://"), false, "token", server.Client())
+
+	archive := &bytes.Buffer{}
+	assert.Nil(t, client.Export(true, archive))
+	assert.Equal(t, "archive", archive.String())
+
+	result, err := client.Import("backup.tar.gz", archive, false)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"p1": "p2"}, result.PipelineIds)
+
+	_, err = client.Import("backup.tar.gz", strings.NewReader("invalid"), false)
+	assert.NotNil(t, err)
+	assert.Equal(t,
+		"Failed to import the backup: The backup isn't a valid tar.gz archive (HTTP status: 400)", err.Error())
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+)
+
+type ClientFactoryInterface interface {
+	CreateClient(endpoint string, options ...kfp.Option) (*kfp.Client, error)
+	CreatePipelineUploader(httpEndpoint string, useTLS bool, token string, httpClient *http.Client,
+		client *kfp.Client) PipelineUploaderInterface
+	CreateBackupClient(httpEndpoint string, useTLS bool, token string, httpClient *http.Client) BackupClientInterface
+	Writer() io.Writer
+	Result() string
+}
+
+type ClientFactory struct {
+	buffer *bytes.Buffer
+	writer io.Writer
+}
+
+func NewClientFactory() *ClientFactory {
+	return &ClientFactory{writer: os.Stdout}
+}
+
+func (f *ClientFactory) CreateClient(endpoint string, options ...kfp.Option) (*kfp.Client, error) {
+	return kfp.NewClient(endpoint, options...)
+}
+
+func (f *ClientFactory) CreatePipelineUploader(httpEndpoint string, useTLS bool, token string,
+	httpClient *http.Client, client *kfp.Client) PipelineUploaderInterface {
+	return NewPipelineUploader(httpEndpoint, useTLS, token, httpClient, client)
+}
+
+func (f *ClientFactory) CreateBackupClient(httpEndpoint string, useTLS bool, token string,
+	httpClient *http.Client) BackupClientInterface {
+	return NewBackupClient(httpEndpoint, useTLS, token, httpClient)
+}
+
+func (f *ClientFactory) Writer() io.Writer {
+	return f.writer
+}
+
+func (f *ClientFactory) Result() string {
+	if f.buffer == nil {
+		return "The writer is set to 'os.Stdout'. The result is not recorded."
+	}
+	return f.buffer.String()
+}
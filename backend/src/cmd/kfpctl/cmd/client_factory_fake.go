@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+)
+
+type ClientFactoryFake struct {
+	buffer *bytes.Buffer
+	client *kfp.Client
+	backup *BackupClientFake
+}
+
+func NewClientFactoryFake() *ClientFactoryFake {
+	return &ClientFactoryFake{buffer: new(bytes.Buffer), client: kfpfake.NewClient(), backup: &BackupClientFake{}}
+}
+
+func (f *ClientFactoryFake) CreateClient(endpoint string, options ...kfp.Option) (*kfp.Client, error) {
+	return f.client, nil
+}
+
+func (f *ClientFactoryFake) CreatePipelineUploader(httpEndpoint string, useTLS bool, token string,
+	httpClient *http.Client, client *kfp.Client) PipelineUploaderInterface {
+	return &PipelineUploaderFake{pipelines: client.Pipelines.(*kfpfake.PipelineClient)}
+}
+
+func (f *ClientFactoryFake) CreateBackupClient(httpEndpoint string, useTLS bool, token string,
+	httpClient *http.Client) BackupClientInterface {
+	return f.backup
+}
+
+// Client returns the in-memory client used by the commands.
+func (f *ClientFactoryFake) Client() *kfp.Client {
+	return f.client
+}
+
+func (f *ClientFactoryFake) Writer() io.Writer {
+	return f.buffer
+}
+
+func (f *ClientFactoryFake) Result() string {
+	return f.buffer.String()
+}
+
+// PipelineUploaderFake adds the uploaded pipelines to a fake pipeline client.
+type PipelineUploaderFake struct {
+	pipelines *kfpfake.PipelineClient
+}
+
+func (u *PipelineUploaderFake) Upload(name string, onConflict string, fileName string, file io.Reader) (
+	*api.Pipeline, error) {
+	if name == "" {
+		name = fileName
+	}
+	return u.pipelines.Put(&api.Pipeline{Name: name}), nil
+}
+
+// BackupClientFake exports a fixed archive, and imports the archives it exported.
+type BackupClientFake struct {
+	// The errors returned by the next import.
+	importErrors []string
+}
+
+func (c *BackupClientFake) Export(includeRuns bool, archive io.Writer) error {
+	_, err := fmt.Fprintf(archive, "backup include_runs=%v", includeRuns)
+	return err
+}
+
+func (c *BackupClientFake) Import(fileName string, archive io.Reader, disableJobs bool) (
+	*api.ImportBackupResponse, error) {
+	content, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(string(content), "backup ") {
+		return nil, fmt.Errorf("Failed to import the backup: %v isn't a backup (HTTP status: 400)", fileName)
+	}
+	result := &api.ImportBackupResponse{
+		PipelineIds:   map[string]string{"pipeline-1": "pipeline-2"},
+		ExperimentIds: map[string]string{"experiment-1": "experiment-2"},
+		Errors:        c.importErrors,
+	}
+	if !disableJobs {
+		result.JobIds = map[string]string{"job-1": "job-2"}
+	}
+	return result, nil
+}
+
+func GetFakeRootCommand() (*RootCommand, *ClientFactoryFake) {
+	factory := NewClientFactoryFake()
+	rootCmd := NewRootCmd(factory)
+	rootCmd = CreateSubCommands(rootCmd)
+	return rootCmd, factory
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func NewExperimentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "experiment",
+		Short: "Manage experiments",
+	}
+}
+
+func NewExperimentCreateCmd(root *RootCommand) *cobra.Command {
+	var name, description string
+	var command = &cobra.Command{
+		Use:   "create",
+		Short: "Create an experiment",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			experiment, err := root.Client().Experiments.CreateExperiment(context.Background(),
+				&api.CreateExperimentRequest{Experiment: &api.Experiment{Name: name, Description: description}})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), experiment)
+		},
+	}
+	command.Flags().StringVar(&name, "name", "", "The name of the experiment")
+	command.Flags().StringVar(&description, "description", "", "The description of the experiment")
+	command.MarkFlagRequired("name")
+	return command
+}
+
+func NewExperimentListCmd(root *RootCommand) *cobra.Command {
+	var flags listFlags
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List experiments",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			experiments := root.Client().ListExperiments(context.Background(), &api.ListExperimentsRequest{
+				PageSize: flags.pageSize(),
+				SortBy:   flags.sortBy,
+			})
+			result := &api.ListExperimentsResponse{}
+			for len(result.Experiments) < flags.maxItems {
+				experiment, err := experiments.Next()
+				if err == kfp.Done {
+					break
+				}
+				if err != nil {
+					return errorForCLI(err)
+				}
+				if !flags.matches(experiment.Name) {
+					continue
+				}
+				result.Experiments = append(result.Experiments, experiment)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), result)
+		},
+	}
+	addListFlags(command, &flags)
+	return command
+}
+
+func NewExperimentGetCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Display an experiment",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "experiment")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			experiment, err := root.Client().Experiments.GetExperiment(context.Background(),
+				&api.GetExperimentRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), experiment)
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExperimentCreateAndList(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"experiment", "create", "--name", "exp1", "--description", "first"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"experiment", "list"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+experiments:
+- description: first
+  id: experiment-1
+  name: exp1
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestExperimentGetNotFound(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"experiment", "get", "experiment-1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Equal(t, "Experiment experiment-1 not found", err.Error())
+}
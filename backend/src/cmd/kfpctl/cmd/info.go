@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/spf13/cobra"
+)
+
+func NewInfoCmd(root *RootCommand) *cobra.Command {
+	var command = &cobra.Command{
+		Use:   "info",
+		Short: "Print the version, the features and the limits of the API server",
+		Long: "Print the version of the API server, the versions of the API it serves, the features " +
+			"enabled while they land, and its limits, e.g. the maximum size of the pipelines uploaded.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := root.Client().ServerInfo.GetServerInfo(context.Background(), &api.GetServerInfoRequest{})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), info)
+		},
+	}
+	return command
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInfo(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	factory.Client().ServerInfo.(*kfpfake.ServerInfoClient).SetServerInfo(&api.ServerInfo{
+		Features:    []*api.Feature{{Name: "Caching", Enabled: true, Stage: api.Feature_ALPHA}},
+		Version:     "0.1.20",
+		ApiVersions: []string{"v1beta1"},
+		Limits:      &api.ServerLimits{MaxPageSize: 200},
+	})
+	rootCmd.Command().SetArgs([]string{"info"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Contains(t, factory.Result(), "version: 0.1.20")
+	assert.Contains(t, factory.Result(), "name: Caching")
+	assert.Contains(t, factory.Result(), "max_page_size: 200")
+
+	rootCmd.Command().SetArgs([]string{"info", "extra"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+}
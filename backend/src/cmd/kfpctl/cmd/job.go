@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	crontab "github.com/robfig/cron"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func NewJobCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "job",
+		Short: "Manage recurring runs",
+	}
+}
+
+func NewJobCreateCmd(root *RootCommand) *cobra.Command {
+	var (
+		name           string
+		description    string
+		pipelineId     string
+		experimentId   string
+		parameters     []string
+		cron           string
+		period         time.Duration
+		startTime      string
+		endTime        string
+		maxConcurrency int64
+		disable        bool
+	)
+	var command = &cobra.Command{
+		Use:   "create",
+		Short: "Create a job running a pipeline on a schedule",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			if (cron == "") == (period == 0) {
+				return fmt.Errorf("Expected exactly one of the flags 'cron' and 'period'")
+			}
+			if cron != "" {
+				if _, err := crontab.Parse(cron); err != nil {
+					return fmt.Errorf("Value '%s' (flag 'cron') is not a valid cron schedule: %v", cron, err)
+				}
+			}
+			if maxConcurrency < 1 {
+				return fmt.Errorf("Flag 'max-concurrency' must be at least 1")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiParameters, err := ParseParameters(parameters)
+			if err != nil {
+				return err
+			}
+			start, err := parseTimestamp(startTime, "start-time")
+			if err != nil {
+				return err
+			}
+			end, err := parseTimestamp(endTime, "end-time")
+			if err != nil {
+				return err
+			}
+			trigger := &api.Trigger{}
+			if cron != "" {
+				trigger.Trigger = &api.Trigger_CronSchedule{CronSchedule: &api.CronSchedule{
+					Cron: cron, StartTime: start, EndTime: end}}
+			} else {
+				trigger.Trigger = &api.Trigger_PeriodicSchedule{PeriodicSchedule: &api.PeriodicSchedule{
+					IntervalSecond: int64(period.Seconds()), StartTime: start, EndTime: end}}
+			}
+			job, err := root.Client().Jobs.CreateJob(context.Background(), &api.CreateJobRequest{Job: &api.Job{
+				Name:               name,
+				Description:        description,
+				PipelineSpec:       &api.PipelineSpec{PipelineId: pipelineId, Parameters: apiParameters},
+				ResourceReferences: experimentReference(experimentId),
+				MaxConcurrency:     maxConcurrency,
+				Trigger:            trigger,
+				Enabled:            !disable,
+			}})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), job)
+		},
+	}
+	command.Flags().StringVar(&name, "name", "", "The name of the job")
+	command.Flags().StringVar(&description, "description", "", "The description of the job")
+	command.Flags().StringVar(&pipelineId, "pipeline-id", "", "The ID of the pipeline to run")
+	command.Flags().StringVar(&experimentId, "experiment-id", "", "The ID of the experiment of the runs")
+	command.Flags().StringArrayVarP(&parameters, "parameter", "p", []string{},
+		"A parameter of the runs, in the NAME=VALUE format. Can be repeated")
+	command.Flags().StringVar(&cron, "cron", "",
+		"The cron schedule of the runs (https://godoc.org/github.com/robfig/cron)")
+	command.Flags().DurationVar(&period, "period", 0, "The interval between the runs, e.g. 1h")
+	command.Flags().StringVar(&startTime, "start-time", "", "The RFC 3339 time of the first run")
+	command.Flags().StringVar(&endTime, "end-time", "", "The RFC 3339 time after which no run is started")
+	command.Flags().Int64Var(&maxConcurrency, "max-concurrency", 1, "The maximum number of concurrent runs")
+	command.Flags().BoolVar(&disable, "disable", false, "Create the job disabled")
+	command.MarkFlagRequired("name")
+	command.MarkFlagRequired("pipeline-id")
+	return command
+}
+
+func parseTimestamp(value string, flagName string) (*timestamp.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("Value '%s' (flag '%s') is not an RFC 3339 time: %v", value, flagName, err)
+	}
+	return &timestamp.Timestamp{Seconds: parsed.Unix()}, nil
+}
+
+func NewJobListCmd(root *RootCommand) *cobra.Command {
+	var (
+		flags        listFlags
+		experimentId string
+	)
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List jobs",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := &api.ListJobsRequest{
+				PageSize: flags.pageSize(),
+				SortBy:   flags.sortBy,
+			}
+			if experimentId != "" {
+				request.ResourceReferenceKey = &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experimentId}
+			}
+			jobs := root.Client().ListJobs(context.Background(), request)
+			result := &api.ListJobsResponse{}
+			for len(result.Jobs) < flags.maxItems {
+				job, err := jobs.Next()
+				if err == kfp.Done {
+					break
+				}
+				if err != nil {
+					return errorForCLI(err)
+				}
+				if !flags.matches(job.Name) {
+					continue
+				}
+				result.Jobs = append(result.Jobs, job)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), result)
+		},
+	}
+	addListFlags(command, &flags)
+	command.Flags().StringVar(&experimentId, "experiment-id", "", "List only the jobs of this experiment")
+	return command
+}
+
+func NewJobGetCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Display a job",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "job")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			job, err := root.Client().Jobs.GetJob(context.Background(), &api.GetJobRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), job)
+		},
+	}
+}
+
+func NewJobEnableCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable ID",
+		Short: "Enable a job",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "job")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := root.Client().Jobs.EnableJob(context.Background(), &api.EnableJobRequest{Id: args[0]})
+			return errorForCLI(err)
+		},
+	}
+}
+
+func NewJobDisableCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable ID",
+		Short: "Disable a job",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "job")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := root.Client().Jobs.DisableJob(context.Background(), &api.DisableJobRequest{Id: args[0]})
+			return errorForCLI(err)
+		},
+	}
+}
+
+func NewJobDeleteCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a job",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "job")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := root.Client().Jobs.DeleteJob(context.Background(), &api.DeleteJobRequest{Id: args[0]})
+			return errorForCLI(err)
+		},
+	}
+}
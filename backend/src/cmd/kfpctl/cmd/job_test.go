@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobCreateAndDisable(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"job", "create", "--name", "nightly", "--pipeline-id", "pipeline1",
+		"--cron", "0 0 0 * * *", "--start-time", "2019-01-01T00:00:00Z"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+enabled: true
+id: job-1
+max_concurrency: "1"
+name: nightly
+pipeline_spec:
+  pipeline_id: pipeline1
+trigger:
+  cron_schedule:
+    cron: 0 0 0 * * *
+    start_time: "2019-01-01T00:00:00Z"
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+
+	rootCmd.Command().SetArgs([]string{"job", "disable", "job-1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+	rootCmd.Command().SetArgs([]string{"job", "get", "job-1", "-o", "json"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.NotContains(t, factory.Result(), "enabled")
+}
+
+func TestJobCreateInvalidSchedule(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"job", "create", "--name", "nightly", "--pipeline-id", "pipeline1",
+		"--cron", "0 0 0 * * *", "--period", "1h"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected exactly one of the flags 'cron' and 'period'")
+
+	rootCmd, _ = GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"job", "create", "--name", "nightly", "--pipeline-id", "pipeline1",
+		"--cron", "every day"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "is not a valid cron schedule")
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/spf13/cobra"
+)
+
+func NewAdminOperationCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "operation",
+		Short: "Manage the operations processing resources in the background, e.g. with 'reindex-pipelines --async'",
+	}
+}
+
+func NewAdminOperationListCmd(root *RootCommand) *cobra.Command {
+	var flags listFlags
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List operations",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operations := root.Client().ListOperations(context.Background(), &api.ListOperationsRequest{
+				PageSize: flags.pageSize(),
+				SortBy:   flags.sortBy,
+			})
+			result := &api.ListOperationsResponse{}
+			for len(result.Operations) < flags.maxItems {
+				operation, err := operations.Next()
+				if err == kfp.Done {
+					break
+				}
+				if err != nil {
+					return errorForCLI(err)
+				}
+				result.Operations = append(result.Operations, operation)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), result)
+		},
+	}
+	// The operations have no name to filter by.
+	command.Flags().StringVar(&flags.sortBy, "sort-by", "",
+		"The field to sort by, followed by ' desc' for a descending order. E.g. 'created_at desc'")
+	command.Flags().IntVarP(&flags.maxItems, "max-items", "m", math.MaxInt32,
+		"Maximum number of items to list")
+	return command
+}
+
+func NewAdminOperationGetCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Display an operation with its progress",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "operation")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operation, err := root.Client().Operations.GetOperation(context.Background(),
+				&api.GetOperationRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), operation)
+		},
+	}
+}
+
+func NewAdminOperationCancelCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel ID",
+		Short: "Cancel a running operation, the resources already processed being left as they are",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "operation")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operation, err := root.Client().Operations.CancelOperation(context.Background(),
+				&api.CancelOperationRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), operation)
+		},
+	}
+}
+
+func NewAdminOperationWaitCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "wait ID",
+		Short: "Wait for an operation to finish, failing if it didn't succeed for every resource",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "operation")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operation, err := root.Client().WaitForOperation(context.Background(), args[0])
+			if err != nil {
+				return errorForCLI(err)
+			}
+			if err := PrintMessage(root.Writer(), root.OutputFormat(), operation); err != nil {
+				return err
+			}
+			if operation.State != api.Operation_SUCCEEDED {
+				return fmt.Errorf("The operation %v is %v", operation.Id, operation.State)
+			}
+			if len(operation.Errors) > 0 {
+				return fmt.Errorf("Failed for %v resources", len(operation.Errors))
+			}
+			return nil
+		},
+	}
+}
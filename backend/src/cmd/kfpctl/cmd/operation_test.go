@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminOperation(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"admin", "reindex-pipelines", "--async"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, "operation_id: operation-1", strings.TrimSpace(factory.Result()))
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"admin", "operation", "wait", "operation-1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Contains(t, factory.Result(), "state: SUCCEEDED")
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"admin", "operation", "list"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Contains(t, factory.Result(), "type: REINDEX_PIPELINES")
+}
+
+func TestAdminOperationCancel(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	operationId := factory.Client().Operations.(*kfpfake.OperationClient).AddOperation(
+		&api.Operation{Type: api.Operation_RECOMPUTE_RUN_STATUSES, State: api.Operation_RUNNING, Total: 10})
+
+	rootCmd.Command().SetArgs([]string{"admin", "operation", "cancel", operationId})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Contains(t, factory.Result(), "state: CANCELLED")
+
+	rootCmd.Command().SetArgs([]string{"admin", "operation", "wait", operationId})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "The operation operation-1 is CANCELLED")
+}
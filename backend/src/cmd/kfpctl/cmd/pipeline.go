@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func NewPipelineCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pipeline",
+		Short: "Manage pipelines",
+	}
+}
+
+// The help of the --on-conflict flag of the commands creating pipelines.
+const onConflictUsage = "What to do if a pipeline with the same name exists: fail, auto-suffix to create it " +
+	"under the name suffixed with the first free \" (n)\", or new-version to replace the file of the existing one"
+
+// parseOnConflict parses the --on-conflict flag: fail, auto-suffix or new-version.
+func parseOnConflict(onConflict string) (api.CreatePipelineRequest_OnConflict, error) {
+	value, ok := api.CreatePipelineRequest_OnConflict_value[strings.ToUpper(strings.Replace(onConflict, "-", "_", -1))]
+	if !ok {
+		return api.CreatePipelineRequest_FAIL, fmt.Errorf(
+			"Unknown --on-conflict %q. Expected fail, auto-suffix or new-version", onConflict)
+	}
+	return api.CreatePipelineRequest_OnConflict(value), nil
+}
+
+func NewPipelineUploadCmd(root *RootCommand) *cobra.Command {
+	var name, onConflict string
+	var command = &cobra.Command{
+		Use:   "upload FILE",
+		Short: "Upload a pipeline file (.yaml, .zip or .tar.gz)",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "pipeline file")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := parseOnConflict(onConflict); err != nil {
+				return err
+			}
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			pipeline, err := root.PipelineUploader().Upload(name, onConflict, filepath.Base(args[0]), file)
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), pipeline)
+		},
+	}
+	command.Flags().StringVar(&name, "name", "", "The name of the pipeline. Defaults to the file name")
+	command.Flags().StringVar(&onConflict, "on-conflict", "fail", onConflictUsage)
+	return command
+}
+
+func NewPipelineCreateCmd(root *RootCommand) *cobra.Command {
+	var name, url, onConflict string
+	var command = &cobra.Command{
+		Use:   "create",
+		Short: "Create a pipeline from a URL",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conflict, err := parseOnConflict(onConflict)
+			if err != nil {
+				return err
+			}
+			pipeline, err := root.Client().Pipelines.CreatePipeline(context.Background(), &api.CreatePipelineRequest{
+				Url:        &api.Url{PipelineUrl: url},
+				Name:       name,
+				OnConflict: conflict,
+			})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), pipeline)
+		},
+	}
+	command.Flags().StringVar(&url, "url", "", "The URL of the pipeline file")
+	command.Flags().StringVar(&name, "name", "", "The name of the pipeline. Defaults to the file name")
+	command.Flags().StringVar(&onConflict, "on-conflict", "fail", onConflictUsage)
+	command.MarkFlagRequired("url")
+	return command
+}
+
+func NewPipelineListCmd(root *RootCommand) *cobra.Command {
+	var flags listFlags
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List pipelines",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pipelines := root.Client().ListPipelines(context.Background(), &api.ListPipelinesRequest{
+				PageSize: flags.pageSize(),
+				SortBy:   flags.sortBy,
+			})
+			result := &api.ListPipelinesResponse{}
+			for len(result.Pipelines) < flags.maxItems {
+				pipeline, err := pipelines.Next()
+				if err == kfp.Done {
+					break
+				}
+				if err != nil {
+					return errorForCLI(err)
+				}
+				if !flags.matches(pipeline.Name) {
+					continue
+				}
+				result.Pipelines = append(result.Pipelines, pipeline)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), result)
+		},
+	}
+	addListFlags(command, &flags)
+	return command
+}
+
+func NewPipelineGetCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Display a pipeline",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "pipeline")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pipeline, err := root.Client().Pipelines.GetPipeline(context.Background(),
+				&api.GetPipelineRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), pipeline)
+		},
+	}
+}
+
+func NewPipelineStatsCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats ID",
+		Short: "Display the durations and the failure rate of the last runs of a pipeline",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "pipeline")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stats, err := root.Client().Pipelines.GetPipelineStats(context.Background(),
+				&api.GetPipelineStatsRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), stats)
+		},
+	}
+}
+
+func NewPipelineReadmeCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "readme ID",
+		Short: "Display the README uploaded with a pipeline, in Markdown",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "pipeline")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			response, err := root.Client().Pipelines.GetPipelineReadme(context.Background(),
+				&api.GetPipelineReadmeRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			fmt.Fprintln(root.Writer(), response.Readme)
+			return nil
+		},
+	}
+}
+
+func NewPipelineDeleteCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a pipeline",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "pipeline")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := root.Client().Pipelines.DeletePipeline(context.Background(),
+				&api.DeletePipelineRequest{Id: args[0]})
+			return errorForCLI(err)
+		},
+	}
+}
+
+func NewPipelineDiffCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff BASE_ID TARGET_ID",
+		Short: "Display the parameters and the steps which changed between two pipelines",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("Expected the IDs of the base and the target pipelines as the only arguments")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			diff, err := root.Client().Pipelines.ComparePipelines(context.Background(),
+				&api.ComparePipelinesRequest{BaseId: args[0], TargetId: args[1]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), diff)
+		},
+	}
+}
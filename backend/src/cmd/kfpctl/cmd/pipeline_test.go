@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineUpload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfpctl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "hello-world.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("kind: Workflow"), 0644))
+
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"pipeline", "upload", file})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	expected := `
+id: pipeline-1
+name: hello-world.yaml
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestPipelineCreate_OnConflict(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"pipeline", "create", "--url", "gs://bucket/pipeline.yaml",
+		"--on-conflict", "auto-suffix"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	rootCmd.Command().SetArgs([]string{"pipeline", "create", "--url", "gs://bucket/pipeline.yaml",
+		"--on-conflict", "overwrite"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Unknown --on-conflict")
+}
+
+func TestPipelineListGetDelete(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	for _, name := range []string{"mnist", "xgboost", "mnist-gpu"} {
+		rootCmd.Command().SetArgs([]string{"pipeline", "create", "--url", "gs://bucket/pipeline.yaml", "--name", name})
+		_, err := rootCmd.Command().ExecuteC()
+		assert.Nil(t, err)
+	}
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"pipeline", "list", "--name", "mnist", "-o", "json"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+{
+  "pipelines": [
+    {
+      "id": "pipeline-1",
+      "name": "mnist"
+    },
+    {
+      "id": "pipeline-3",
+      "name": "mnist-gpu"
+    }
+  ]
+}
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+
+	rootCmd.Command().SetArgs([]string{"pipeline", "delete", "pipeline-1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	rootCmd.Command().SetArgs([]string{"pipeline", "get", "pipeline-1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Pipeline pipeline-1 not found")
+}
+
+func TestPipelineGetInvalidArgumentCount(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"pipeline", "get"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected the ID of the pipeline")
+}
+
+func TestPipelineStats(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	pipelines := factory.Client().Pipelines.(*kfpfake.PipelineClient)
+	pipeline := pipelines.Put(&api.Pipeline{Name: "p"})
+	pipelines.SetStats(&api.PipelineStats{PipelineId: pipeline.Id, Runs: 4, FailedRuns: 1, FailureRate: 0.25,
+		P50DurationSeconds: 600, P95DurationSeconds: 900})
+
+	rootCmd.Command().SetArgs([]string{"pipeline", "stats", pipeline.Id})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+failed_runs: 1
+failure_rate: 0.25
+p50_duration_seconds: "600"
+p95_duration_seconds: "900"
+pipeline_id: pipeline-1
+runs: 4
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestPipelineReadme(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	pipelines := factory.Client().Pipelines.(*kfpfake.PipelineClient)
+	pipeline := pipelines.Put(&api.Pipeline{Name: "p"})
+
+	rootCmd.Command().SetArgs([]string{"pipeline", "readme", pipeline.Id})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+
+	pipelines.SetReadme(pipeline.Id, "# Usage")
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, "# Usage\n", factory.Result())
+}
+
+func TestPipelineDiff(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	pipelines := factory.Client().Pipelines.(*kfpfake.PipelineClient)
+	base := pipelines.Put(&api.Pipeline{Name: "base"})
+	pipelines.SetTemplate(base.Id, `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+spec:
+  arguments:
+    parameters:
+    - name: epochs
+      value: "10"
+  templates:
+  - name: train
+    container:
+      image: trainer:1
+`)
+	target := pipelines.Put(&api.Pipeline{Name: "target"})
+	pipelines.SetTemplate(target.Id, `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+spec:
+  arguments:
+    parameters:
+    - name: epochs
+      value: "20"
+  templates:
+  - name: train
+    container:
+      image: trainer:2
+      args: [--fast]
+`)
+
+	rootCmd.Command().SetArgs([]string{"pipeline", "diff", base.Id, target.Id})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+parameters:
+- base_value: "10"
+  change: MODIFIED
+  name: epochs
+  target_value: "20"
+steps:
+- base_image: trainer:1
+  change: MODIFIED
+  changed_fields:
+  - image
+  - args
+  name: train
+  target_image: trainer:2
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestPipelineDiffRequiresTwoPipelines(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"pipeline", "diff", "pipeline-1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected the IDs of the base and the target pipelines")
+}
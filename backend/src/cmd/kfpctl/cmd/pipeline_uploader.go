@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"golang.org/x/net/context"
+)
+
+const (
+	pipelineUploadPath    = "/apis/v1beta1/pipelines/upload"
+	pipelineUploadFileKey = "uploadfile"
+)
+
+// PipelineUploaderInterface uploads pipeline files. The upload isn't part of the gRPC
+// API, it goes through the HTTP API of the API server.
+type PipelineUploaderInterface interface {
+	Upload(name string, onConflict string, fileName string, file io.Reader) (*api.Pipeline, error)
+}
+
+type PipelineUploader struct {
+	uploadURL  string
+	token      string
+	httpClient *http.Client
+	client     *kfp.Client
+}
+
+func NewPipelineUploader(httpEndpoint string, useTLS bool, token string, httpClient *http.Client,
+	client *kfp.Client) *PipelineUploader {
+	scheme := "http"
+	if useTLS {
+		scheme = "https"
+	}
+	return &PipelineUploader{
+		uploadURL:  fmt.Sprintf("%v://%v%v", scheme, httpEndpoint, pipelineUploadPath),
+		token:      token,
+		httpClient: httpClient,
+		client:     client,
+	}
+}
+
+// Upload uploads a pipeline file, named after the file if name is empty, and
+// returns the created pipeline. onConflict is what to do if a pipeline with the same name
+// exists: fail, auto-suffix or new-version.
+func (u *PipelineUploader) Upload(name string, onConflict string, fileName string, file io.Reader) (
+	*api.Pipeline, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(pipelineUploadFileKey, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("Failed to read the pipeline file %v: %v", fileName, err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if name != "" {
+		query.Set("name", name)
+	}
+	if onConflict != "" {
+		query.Set("on_conflict", onConflict)
+	}
+	uploadURL := u.uploadURL
+	if len(query) > 0 {
+		uploadURL += "?" + query.Encode()
+	}
+	request, err := http.NewRequest(http.MethodPost, uploadURL, body)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	if u.token != "" {
+		request.Header.Set("Authorization", "Bearer "+u.token)
+	}
+	response, err := u.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to upload the pipeline to %v: %v", u.uploadURL, err)
+	}
+	defer response.Body.Close()
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the response of the upload: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		var apiError api.Error
+		if err := json.Unmarshal(responseBody, &apiError); err != nil || apiError.ErrorMessage == "" {
+			apiError.ErrorMessage = string(responseBody)
+		}
+		return nil, fmt.Errorf("Failed to upload the pipeline: %v (HTTP status: %v)",
+			apiError.ErrorMessage, response.StatusCode)
+	}
+
+	// The upload returns the pipeline with its creation time formatted differently,
+	// so only its ID is read.
+	var uploaded struct {
+		Id string `json:"id"`
+	}
+	if err := json.Unmarshal(responseBody, &uploaded); err != nil {
+		return nil, fmt.Errorf("Failed to read the uploaded pipeline: %v", err)
+	}
+	return u.client.Pipelines.GetPipeline(context.Background(), &api.GetPipelineRequest{Id: uploaded.Id})
+}
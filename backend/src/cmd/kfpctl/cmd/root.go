@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	defaultEndpoint     = "localhost:8887"
+	defaultHTTPEndpoint = "localhost:8888"
+	defaultPageSize     = int32(100)
+	// The defaults of the endpoints with --port-forward.
+	defaultPortForwardEndpoint     = "ml-pipeline.kubeflow:8887"
+	defaultPortForwardHTTPEndpoint = "ml-pipeline.kubeflow:8888"
+	// The environment variables overriding the defaults of the connection flags.
+	envEndpoint     = "KFP_ENDPOINT"
+	envHTTPEndpoint = "KFP_HTTP_ENDPOINT"
+	envToken        = "KFP_TOKEN"
+)
+
+type RootCommand struct {
+	command      *cobra.Command
+	outputFormat string
+	endpoint     string
+	httpEndpoint string
+	useTLS       bool
+	token        string
+	timeout      time.Duration
+	portForward  bool
+	kubeconfig   string
+	runTemplates string
+	client       *kfp.Client
+	uploader     PipelineUploaderInterface
+	backup       BackupClientInterface
+	writer       io.Writer
+}
+
+func NewRootCmd(factory ClientFactoryInterface) *RootCommand {
+	root := &RootCommand{}
+	root.writer = factory.Writer()
+	command := &cobra.Command{
+		Use:   "kfpctl",
+		Short: "kfpctl manages the pipelines, experiments, runs and jobs of the ML pipeline API server.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			options := []kfp.Option{kfp.WithTimeout(root.timeout)}
+			if !root.useTLS {
+				options = append(options, kfp.WithInsecure())
+			}
+			if root.token != "" {
+				options = append(options, kfp.WithBearerToken(root.token))
+			}
+			httpClient := http.DefaultClient
+			if root.portForward {
+				if root.useTLS {
+					return fmt.Errorf("The flags 'tls' and 'port-forward' can't be used together")
+				}
+				dialer, err := root.newPortForwardDialer()
+				if err != nil {
+					return fmt.Errorf("Could not connect to the cluster: %v", err)
+				}
+				options = append(options, kfp.WithPortForward(dialer))
+				httpClient = &http.Client{Transport: &http.Transport{DialContext: dialer.DialContext}}
+				if !cmd.Flags().Changed("endpoint") && os.Getenv(envEndpoint) == "" {
+					root.endpoint = defaultPortForwardEndpoint
+				}
+				if !cmd.Flags().Changed("http-endpoint") && os.Getenv(envHTTPEndpoint) == "" {
+					root.httpEndpoint = defaultPortForwardHTTPEndpoint
+				}
+			}
+			client, err := factory.CreateClient(root.endpoint, options...)
+			if err != nil {
+				return fmt.Errorf("Could not connect to the API server: %v", err)
+			}
+			root.client = client
+			root.uploader = factory.CreatePipelineUploader(root.httpEndpoint, root.useTLS, root.token,
+				httpClient, client)
+			root.backup = factory.CreateBackupClient(root.httpEndpoint, root.useTLS, root.token, httpClient)
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return root.client.Close()
+		},
+	}
+	command.SetOutput(factory.Writer())
+	command.SilenceErrors = true
+	command.SilenceUsage = true
+	command.PersistentFlags().StringVarP(&root.outputFormat, "output", "o", string(OutputFormatYaml),
+		"Output format. One of: json|yaml")
+	command.PersistentFlags().StringVar(&root.endpoint, "endpoint", envOrDefault(envEndpoint, defaultEndpoint),
+		"The host:port of the gRPC API of the API server. Defaults to $"+envEndpoint+" if set")
+	command.PersistentFlags().StringVar(&root.httpEndpoint, "http-endpoint",
+		envOrDefault(envHTTPEndpoint, defaultHTTPEndpoint),
+		"The host:port of the HTTP API of the API server, used to upload pipelines and backups. Defaults to $"+
+			envHTTPEndpoint+" if set")
+	command.PersistentFlags().BoolVar(&root.useTLS, "tls", false,
+		"Connect to the API server with TLS")
+	command.PersistentFlags().StringVar(&root.token, "token", os.Getenv(envToken),
+		"The bearer token authenticating the requests. Defaults to $"+envToken)
+	command.PersistentFlags().DurationVar(&root.timeout, "timeout", 30*time.Second,
+		"The timeout of each request, including its retries")
+	command.PersistentFlags().BoolVar(&root.portForward, "port-forward", false,
+		"Connect to the API server from outside the cluster, through the Kubernetes API server. "+
+			"The endpoints are then <service>.<namespace>:<port>, by default "+defaultPortForwardEndpoint+
+			" and "+defaultPortForwardHTTPEndpoint)
+	command.PersistentFlags().StringVar(&root.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig used by --port-forward. Defaults to $KUBECONFIG or ~/.kube/config")
+	command.PersistentFlags().StringVar(&root.runTemplates, "run-templates",
+		envOrDefault(envRunTemplates, defaultRunTemplatesPath()),
+		"The file storing the run templates. Defaults to $"+envRunTemplates+" if set")
+	root.command = command
+	return root
+}
+
+func (r *RootCommand) newPortForwardDialer() (*kfp.PortForwardDialer, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = r.kubeconfig
+	return kfp.NewPortForwardDialer(clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{}))
+}
+
+func envOrDefault(name string, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Execute runs the command selected by the arguments of the process.
+func (r *RootCommand) Execute() {
+	if err := r.command.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func (r *RootCommand) Command() *cobra.Command {
+	return r.command
+}
+
+func (r *RootCommand) OutputFormat() string {
+	return r.outputFormat
+}
+
+func (r *RootCommand) Client() *kfp.Client {
+	return r.client
+}
+
+func (r *RootCommand) PipelineUploader() PipelineUploaderInterface {
+	return r.uploader
+}
+
+func (r *RootCommand) BackupClient() BackupClientInterface {
+	return r.backup
+}
+
+func (r *RootCommand) RunTemplates() *RunTemplateStore {
+	return NewRunTemplateStore(r.runTemplates)
+}
+
+func (r *RootCommand) Writer() io.Writer {
+	return r.writer
+}
+
+func (r *RootCommand) AddCommand(commands ...*cobra.Command) {
+	r.command.AddCommand(commands...)
+}
+
+func CreateSubCommands(rootCmd *RootCommand) *RootCommand {
+	pipelineCmd := NewPipelineCmd()
+	pipelineCmd.AddCommand(
+		NewPipelineUploadCmd(rootCmd),
+		NewPipelineCreateCmd(rootCmd),
+		NewPipelineListCmd(rootCmd),
+		NewPipelineGetCmd(rootCmd),
+		NewPipelineStatsCmd(rootCmd),
+		NewPipelineReadmeCmd(rootCmd),
+		NewPipelineDeleteCmd(rootCmd),
+		NewPipelineDiffCmd(rootCmd))
+
+	experimentCmd := NewExperimentCmd()
+	experimentCmd.AddCommand(
+		NewExperimentCreateCmd(rootCmd),
+		NewExperimentListCmd(rootCmd),
+		NewExperimentGetCmd(rootCmd))
+
+	runCmd := NewRunCmd()
+	runCmd.AddCommand(
+		NewRunSubmitCmd(rootCmd),
+		NewRunEstimateCmd(rootCmd),
+		NewRunListCmd(rootCmd),
+		NewRunGetCmd(rootCmd),
+		NewRunManifestCmd(rootCmd),
+		NewRunHistoryCmd(rootCmd),
+		NewRunWatchCmd(rootCmd),
+		NewRunApproveCmd(rootCmd),
+		NewRunRejectCmd(rootCmd),
+		NewRunTerminateAllCmd(rootCmd),
+		NewRunTerminateStatusCmd(rootCmd))
+	runTemplateCmd := NewRunTemplateCmd()
+	runTemplateCmd.AddCommand(
+		NewRunTemplateSaveCmd(rootCmd),
+		NewRunTemplateListCmd(rootCmd),
+		NewRunTemplateGetCmd(rootCmd),
+		NewRunTemplateDeleteCmd(rootCmd))
+	runCmd.AddCommand(runTemplateCmd)
+	runSweepCmd := NewRunSweepCmd()
+	runSweepCmd.AddCommand(
+		NewRunSweepCreateCmd(rootCmd),
+		NewRunSweepGetCmd(rootCmd))
+	runCmd.AddCommand(runSweepCmd)
+	runGroupCmd := NewRunGroupCmd()
+	runGroupCmd.AddCommand(
+		NewRunGroupCreateCmd(rootCmd),
+		NewRunGroupListCmd(rootCmd),
+		NewRunGroupGetCmd(rootCmd),
+		NewRunGroupAddCmd(rootCmd),
+		NewRunGroupCancelCmd(rootCmd))
+	runCmd.AddCommand(runGroupCmd)
+
+	jobCmd := NewJobCmd()
+	jobCmd.AddCommand(
+		NewJobCreateCmd(rootCmd),
+		NewJobListCmd(rootCmd),
+		NewJobGetCmd(rootCmd),
+		NewJobEnableCmd(rootCmd),
+		NewJobDisableCmd(rootCmd),
+		NewJobDeleteCmd(rootCmd))
+
+	backupCmd := NewBackupCmd()
+	backupCmd.AddCommand(
+		NewBackupExportCmd(rootCmd),
+		NewBackupImportCmd(rootCmd))
+
+	adminCmd := NewAdminCmd()
+	adminCmd.AddCommand(
+		NewAdminCheckConsistencyCmd(rootCmd),
+		NewAdminConsistencyReportCmd(rootCmd),
+		NewAdminStorageUsageCmd(rootCmd),
+		NewAdminReindexPipelinesCmd(rootCmd),
+		NewAdminRecomputeRunStatusesCmd(rootCmd),
+		NewAdminFlushCachesCmd(rootCmd),
+		NewAdminReadOnlyCmd(rootCmd))
+	adminOperationCmd := NewAdminOperationCmd()
+	adminOperationCmd.AddCommand(
+		NewAdminOperationListCmd(rootCmd),
+		NewAdminOperationGetCmd(rootCmd),
+		NewAdminOperationCancelCmd(rootCmd),
+		NewAdminOperationWaitCmd(rootCmd))
+	adminCmd.AddCommand(adminOperationCmd)
+
+	rootCmd.AddCommand(pipelineCmd, experimentCmd, runCmd, jobCmd, backupCmd, adminCmd, NewVisualizeCmd(rootCmd),
+		NewInfoCmd(rootCmd))
+	return rootCmd
+}
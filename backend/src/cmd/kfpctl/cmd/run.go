@@ -0,0 +1,454 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func NewRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run",
+		Short: "Manage runs",
+	}
+}
+
+func NewRunSubmitCmd(root *RootCommand) *cobra.Command {
+	var (
+		name           string
+		description    string
+		pipelineId     string
+		pipelineFile   string
+		experimentId   string
+		experimentName string
+		parameters     []string
+		templateName   string
+		entrypoint     string
+		steps          []string
+		watch          bool
+	)
+	var command = &cobra.Command{
+		Use:   "submit",
+		Short: "Submit a run of a pipeline",
+		Long: "Submit a run of a pipeline. With --template, the flags which aren't set default to the " +
+			"values saved in the run template, and the parameters are merged with the saved ones.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			template := &RunTemplate{}
+			if templateName != "" {
+				var err error
+				if template, err = root.RunTemplates().Get(templateName); err != nil {
+					return err
+				}
+			}
+			run := mergeRunTemplate(template, &RunTemplate{
+				Name:         name,
+				Description:  description,
+				PipelineId:   pipelineId,
+				PipelineFile: pipelineFile,
+				ExperimentId: experimentId,
+			})
+			if (run.PipelineId == "") == (run.PipelineFile == "") {
+				return fmt.Errorf("Expected exactly one of the flags 'pipeline-id' and 'pipeline-file'")
+			}
+			if run.Name == "" {
+				return fmt.Errorf("Expected the flag 'name', or a run template named after the runs")
+			}
+			if experimentId != "" && experimentName != "" {
+				return fmt.Errorf("Expected at most one of the flags 'experiment-id' and 'experiment-name'")
+			}
+			references := experimentReference(run.ExperimentId)
+			if experimentName != "" {
+				references = experimentNameReference(experimentName)
+			}
+			pipelineSpec, err := newPipelineSpec(template, run, parameters)
+			if err != nil {
+				return err
+			}
+			var partialExecution *api.PartialExecution
+			if entrypoint != "" || len(steps) > 0 {
+				partialExecution = &api.PartialExecution{Entrypoint: entrypoint, Steps: steps}
+			}
+			runDetail, err := root.Client().Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{
+				Name:               run.Name,
+				Description:        run.Description,
+				PipelineSpec:       pipelineSpec,
+				ResourceReferences: references,
+				PartialExecution:   partialExecution,
+			}})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			if watch {
+				return watchRun(root, runDetail.Run.Id)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), runDetail.Run)
+		},
+	}
+	command.Flags().StringVar(&name, "name", "", "The name of the run. Defaults to the name of the run template")
+	command.Flags().StringVar(&description, "description", "", "The description of the run")
+	command.Flags().StringVar(&pipelineId, "pipeline-id", "", "The ID of the pipeline to run")
+	command.Flags().StringVar(&pipelineFile, "pipeline-file", "",
+		"The Argo workflow to run, if the pipeline isn't uploaded")
+	command.Flags().StringVar(&experimentId, "experiment-id", "", "The ID of the experiment of the run")
+	command.Flags().StringVar(&experimentName, "experiment-name", "",
+		"The name of the experiment of the run, created if missing, instead of its ID")
+	command.Flags().StringArrayVarP(&parameters, "parameter", "p", []string{},
+		"A parameter of the run, in the NAME=VALUE format. Can be repeated")
+	command.Flags().StringVarP(&templateName, "template", "t", "",
+		"The run template providing the flags which aren't set (see 'run template save')")
+	command.Flags().StringVar(&entrypoint, "entrypoint", "",
+		"The template of the workflow to start the run from, instead of its entrypoint")
+	command.Flags().StringArrayVar(&steps, "step", []string{},
+		"A step of the workflow to execute, the others being skipped. Can be repeated")
+	command.Flags().BoolVarP(&watch, "watch", "w", false, "Wait until the run finishes")
+	return command
+}
+
+func NewRunEstimateCmd(root *RootCommand) *cobra.Command {
+	var (
+		pipelineId   string
+		pipelineFile string
+		parameters   []string
+		templateName string
+	)
+	var command = &cobra.Command{
+		Use:   "estimate",
+		Short: "Estimate the resources and the cost of a run of a pipeline, without submitting it",
+		Long: "Estimate the resources and the cost of a run of a pipeline, without submitting it, from the " +
+			"resources its steps request and their durations in the past runs of the pipeline. The flags " +
+			"are the ones of 'run submit'.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			template := &RunTemplate{}
+			if templateName != "" {
+				var err error
+				if template, err = root.RunTemplates().Get(templateName); err != nil {
+					return err
+				}
+			}
+			run := mergeRunTemplate(template, &RunTemplate{PipelineId: pipelineId, PipelineFile: pipelineFile})
+			if (run.PipelineId == "") == (run.PipelineFile == "") {
+				return fmt.Errorf("Expected exactly one of the flags 'pipeline-id' and 'pipeline-file'")
+			}
+			pipelineSpec, err := newPipelineSpec(template, run, parameters)
+			if err != nil {
+				return err
+			}
+			estimate, err := root.Client().Runs.EstimateRun(context.Background(), &api.EstimateRunRequest{
+				Run: &api.Run{Name: run.Name, PipelineSpec: pipelineSpec}})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), estimate)
+		},
+	}
+	command.Flags().StringVar(&pipelineId, "pipeline-id", "", "The ID of the pipeline to run")
+	command.Flags().StringVar(&pipelineFile, "pipeline-file", "",
+		"The Argo workflow to run, if the pipeline isn't uploaded")
+	command.Flags().StringArrayVarP(&parameters, "parameter", "p", []string{},
+		"A parameter of the run, in the NAME=VALUE format. Can be repeated")
+	command.Flags().StringVarP(&templateName, "template", "t", "",
+		"The run template providing the flags which aren't set (see 'run template save')")
+	return command
+}
+
+// newPipelineSpec returns the pipeline spec of a run of the pipeline, uploaded or read from its
+// file, with the parameters merged with the ones of the run template.
+func newPipelineSpec(template *RunTemplate, run *RunTemplate, parameters []string) (*api.PipelineSpec, error) {
+	apiParameters, err := template.apiParameters(parameters)
+	if err != nil {
+		return nil, err
+	}
+	pipelineSpec := &api.PipelineSpec{PipelineId: run.PipelineId, Parameters: apiParameters}
+	if run.PipelineFile != "" {
+		workflowManifest, err := ioutil.ReadFile(run.PipelineFile)
+		if err != nil {
+			return nil, err
+		}
+		pipelineSpec.WorkflowManifest = string(workflowManifest)
+	}
+	return pipelineSpec, nil
+}
+
+func NewRunListCmd(root *RootCommand) *cobra.Command {
+	var (
+		flags        listFlags
+		experimentId string
+	)
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List runs",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := &api.ListRunsRequest{
+				PageSize: flags.pageSize(),
+				SortBy:   flags.sortBy,
+			}
+			if experimentId != "" {
+				request.ResourceReferenceKey = &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experimentId}
+			}
+			runs := root.Client().ListRuns(context.Background(), request)
+			result := &api.ListRunsResponse{}
+			for len(result.Runs) < flags.maxItems {
+				run, err := runs.Next()
+				if err == kfp.Done {
+					break
+				}
+				if err != nil {
+					return errorForCLI(err)
+				}
+				if !flags.matches(run.Name) {
+					continue
+				}
+				result.Runs = append(result.Runs, run)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), result)
+		},
+	}
+	addListFlags(command, &flags)
+	command.Flags().StringVar(&experimentId, "experiment-id", "", "List only the runs of this experiment")
+	return command
+}
+
+func NewRunGetCmd(root *RootCommand) *cobra.Command {
+	var showWorkflow bool
+	var command = &cobra.Command{
+		Use:   "get ID",
+		Short: "Display a run",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runDetail, err := root.Client().Runs.GetRun(context.Background(), &api.GetRunRequest{RunId: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			if !showWorkflow {
+				runDetail.PipelineRuntime = nil
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), runDetail)
+		},
+	}
+	command.Flags().BoolVar(&showWorkflow, "show-workflow", false, "Display the Argo workflow of the run")
+	return command
+}
+
+func NewRunManifestCmd(root *RootCommand) *cobra.Command {
+	var submitted bool
+	var command = &cobra.Command{
+		Use:   "manifest ID",
+		Short: "Display the Argo workflow of a run as last reported, with the resolved parameters",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, err := root.Client().Runs.GetRunManifest(context.Background(),
+				&api.GetRunManifestRequest{RunId: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			workflow := manifest.RuntimeManifest
+			if submitted {
+				workflow = manifest.SubmittedManifest
+			}
+			if workflow == "" {
+				return fmt.Errorf("Run %v has no such manifest", args[0])
+			}
+			content, err := yaml.JSONToYAML([]byte(workflow))
+			if err != nil {
+				return fmt.Errorf("Failed to convert the manifest of run %v to YAML: %v", args[0], err)
+			}
+			fmt.Fprint(root.Writer(), string(content))
+			return nil
+		},
+	}
+	command.Flags().BoolVar(&submitted, "submitted", false,
+		"Display the workflow submitted with the run instead, before its parameters were resolved")
+	return command
+}
+
+func NewRunHistoryCmd(root *RootCommand) *cobra.Command {
+	var nodeId, asOf string
+	var command = &cobra.Command{
+		Use:   "history ID",
+		Short: "Display the states a run and its steps went through, in chronological order",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			asOfTime, err := parseTimestamp(asOf, "as-of")
+			if err != nil {
+				return err
+			}
+			history, err := root.Client().Runs.GetRunHistory(context.Background(), &api.GetRunHistoryRequest{
+				RunId: args[0], NodeId: nodeId, AsOf: asOfTime})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), history)
+		},
+	}
+	command.Flags().StringVar(&nodeId, "node-id", "",
+		"The ID of the node to display the states of. The states of the run and of all its nodes are displayed if empty")
+	command.Flags().StringVar(&asOf, "as-of", "",
+		"Only display the states reached up to this time, in RFC 3339 format, e.g. 2019-01-02T15:04:05Z")
+	return command
+}
+
+func NewRunWatchCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch ID",
+		Short: "Wait until a run finishes, printing its status changes",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return watchRun(root, args[0])
+		},
+	}
+}
+
+func NewRunApproveCmd(root *RootCommand) *cobra.Command {
+	var nodeId, comment string
+	var command = &cobra.Command{
+		Use:   "approve ID",
+		Short: "Approve the approval gates of a run waiting for a decision, which resumes the run",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := root.Client().Runs.ApproveRun(context.Background(), &api.ApproveRunRequest{
+				RunId: args[0], NodeId: nodeId, Comment: comment})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			fmt.Fprintf(root.Writer(), "Approved run %v\n", args[0])
+			return nil
+		},
+	}
+	command.Flags().StringVar(&nodeId, "node-id", "",
+		"The ID of the node of the gate to approve. All the waiting gates are approved if empty")
+	command.Flags().StringVar(&comment, "comment", "", "The comment recorded with the approval")
+	return command
+}
+
+func NewRunRejectCmd(root *RootCommand) *cobra.Command {
+	var nodeId, reason string
+	var command = &cobra.Command{
+		Use:   "reject ID",
+		Short: "Reject the approval gates of a run waiting for a decision, which terminates the run",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := root.Client().Runs.RejectRun(context.Background(), &api.RejectRunRequest{
+				RunId: args[0], NodeId: nodeId, Reason: reason})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			fmt.Fprintf(root.Writer(), "Rejected run %v\n", args[0])
+			return nil
+		},
+	}
+	command.Flags().StringVar(&nodeId, "node-id", "",
+		"The ID of the node of the gate to reject. All the waiting gates are rejected if empty")
+	command.Flags().StringVar(&reason, "reason", "", "The reason recorded with the rejection")
+	return command
+}
+
+func NewRunTerminateAllCmd(root *RootCommand) *cobra.Command {
+	var experimentId, runGroupId string
+	var command = &cobra.Command{
+		Use:   "terminate-all",
+		Short: "Terminate the unfinished runs of an experiment or of a run group",
+		Long: "Terminate the unfinished runs of an experiment or of a run group. The runs are terminated in " +
+			"the background: use 'run terminate-status' to display the result of each of them",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			if (experimentId == "") == (runGroupId == "") {
+				return fmt.Errorf("Expected exactly one of the flags 'experiment-id' and 'group-id'")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operation, err := root.Client().Runs.TerminateAll(context.Background(), &api.TerminateAllRequest{
+				ExperimentId: experimentId, RunGroupId: runGroupId})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), operation)
+		},
+	}
+	command.Flags().StringVar(&experimentId, "experiment-id", "", "The ID of the experiment whose runs to terminate")
+	command.Flags().StringVar(&runGroupId, "group-id", "", "The ID of the run group whose runs to terminate")
+	return command
+}
+
+func NewRunTerminateStatusCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "terminate-status ID",
+		Short: "Display the result of the termination of each run of a terminate-all operation",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "terminate operation")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			operation, err := root.Client().Runs.GetTerminateAllOperation(context.Background(),
+				&api.GetTerminateAllOperationRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), operation)
+		},
+	}
+}
+
+// watchRun waits until a run finishes, printing its status changes, and prints it. It
+// fails if the run didn't succeed.
+func watchRun(root *RootCommand, runId string) error {
+	runDetail, err := root.Client().WaitForRunCompletion(context.Background(), runId,
+		func(runDetail *api.RunDetail) {
+			fmt.Fprintf(root.Writer(), "Run %v: %v\n", runId, statusOrPending(runDetail.Run.Status))
+		})
+	if err != nil {
+		return errorForCLI(err)
+	}
+	run := runDetail.Run
+	if err := PrintMessage(root.Writer(), root.OutputFormat(), run); err != nil {
+		return err
+	}
+	if run.Status != "Succeeded" {
+		return fmt.Errorf("Run %v finished with status %v", runId, run.Status)
+	}
+	return nil
+}
+
+func statusOrPending(status string) string {
+	if status == "" {
+		return "Pending"
+	}
+	return status
+}
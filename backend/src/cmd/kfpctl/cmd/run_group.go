@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/spf13/cobra"
+)
+
+func NewRunGroupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "group",
+		Short: "Manage the run groups, which track and cancel runs as a unit",
+	}
+}
+
+func NewRunGroupCreateCmd(root *RootCommand) *cobra.Command {
+	var (
+		name        string
+		description string
+		runIds      []string
+	)
+	var command = &cobra.Command{
+		Use:   "create",
+		Short: "Create a run group",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			group, err := root.Client().Runs.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{
+				Group: &api.RunGroup{Name: name, Description: description, RunIds: runIds}})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), group)
+		},
+	}
+	command.Flags().StringVar(&name, "name", "", "The name of the group")
+	command.Flags().StringVar(&description, "description", "", "The description of the group")
+	command.Flags().StringArrayVar(&runIds, "run", []string{}, "The ID of a run of the group. Can be repeated")
+	return command
+}
+
+func NewRunGroupListCmd(root *RootCommand) *cobra.Command {
+	var flags listFlags
+	var command = &cobra.Command{
+		Use:   "list",
+		Short: "List run groups",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := validateNoArgument(args); err != nil {
+				return err
+			}
+			return flags.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groups := root.Client().ListRunGroups(context.Background(), &api.ListRunGroupsRequest{
+				PageSize: flags.pageSize(),
+				SortBy:   flags.sortBy,
+			})
+			result := &api.ListRunGroupsResponse{}
+			for len(result.Groups) < flags.maxItems {
+				group, err := groups.Next()
+				if err == kfp.Done {
+					break
+				}
+				if err != nil {
+					return errorForCLI(err)
+				}
+				if !flags.matches(group.Name) {
+					continue
+				}
+				result.Groups = append(result.Groups, group)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), result)
+		},
+	}
+	addListFlags(command, &flags)
+	return command
+}
+
+func NewRunGroupGetCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Display a run group with the aggregate status of its runs",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run group")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			group, err := root.Client().Runs.GetRunGroup(context.Background(), &api.GetRunGroupRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), group)
+		},
+	}
+}
+
+func NewRunGroupAddCmd(root *RootCommand) *cobra.Command {
+	var runIds []string
+	var command = &cobra.Command{
+		Use:   "add ID",
+		Short: "Add runs to a run group",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run group")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(runIds) == 0 {
+				return fmt.Errorf("Expected at least one 'run' flag")
+			}
+			group, err := root.Client().Runs.AddRunsToRunGroup(context.Background(), &api.AddRunsToRunGroupRequest{
+				Id: args[0], RunIds: runIds})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), group)
+		},
+	}
+	command.Flags().StringArrayVar(&runIds, "run", []string{}, "The ID of a run to add. Can be repeated")
+	return command
+}
+
+func NewRunGroupCancelCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel ID",
+		Short: "Cancel a run group, terminating its runs which are still running",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run group")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			group, err := root.Client().Runs.CancelRunGroup(context.Background(), &api.CancelRunGroupRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), group)
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunGroup(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run2", "--pipeline-id", "pipeline1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "group", "create", "--name", "backfill", "--run", "run-1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+id: group-3
+name: backfill
+run_ids:
+- run-1
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "group", "add", "group-3", "--run", "run-2"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "group", "cancel", "group-3"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "group", "list"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected = `
+groups:
+- id: group-3
+  name: backfill
+  run_ids:
+  - run-1
+  - run-2
+  status: Cancelled
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestRunTerminateAll(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	for _, name := range []string{"run1", "run2"} {
+		rootCmd.Command().SetArgs([]string{"run", "submit", "--name", name, "--pipeline-id", "pipeline1"})
+		_, err := rootCmd.Command().ExecuteC()
+		assert.Nil(t, err)
+	}
+	rootCmd.Command().SetArgs([]string{"run", "group", "create", "--name", "backfill", "--run", "run-1",
+		"--run", "run-2"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "terminate-all", "--group-id", "group-3"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+id: termination-4
+results:
+- run_id: run-1
+  status: TERMINATED
+- run_id: run-2
+  status: TERMINATED
+run_group_id: group-3
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "terminate-status", "termination-4"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestRunTerminateAllRequiresOneScope(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "terminate-all"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected exactly one of the flags 'experiment-id' and 'group-id'")
+}
+
+func TestRunGroupAddWithoutRun(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "group", "add", "group-1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected at least one 'run' flag")
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/spf13/cobra"
+)
+
+func NewRunSweepCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sweep",
+		Short: "Manage the sweeps, which run a pipeline once per combination of the values of its parameters",
+	}
+}
+
+func NewRunSweepCreateCmd(root *RootCommand) *cobra.Command {
+	var (
+		name           string
+		pipelineId     string
+		pipelineFile   string
+		experimentId   string
+		parameters     []string
+		values         []string
+		ranges         []string
+		maxConcurrency int32
+	)
+	var command = &cobra.Command{
+		Use:   "create",
+		Short: "Create a sweep, which submits one run per combination of the values of the parameters swept",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (pipelineId == "") == (pipelineFile == "") {
+				return fmt.Errorf("Expected exactly one of the flags 'pipeline-id' and 'pipeline-file'")
+			}
+			sweepParameters, err := parseSweepParameters(values, ranges)
+			if err != nil {
+				return err
+			}
+			run := &RunTemplate{PipelineId: pipelineId, PipelineFile: pipelineFile}
+			pipelineSpec, err := newPipelineSpec(&RunTemplate{}, run, parameters)
+			if err != nil {
+				return err
+			}
+			sweep, err := root.Client().Runs.CreateRunSweep(context.Background(), &api.CreateRunSweepRequest{
+				Sweep: &api.RunSweep{
+					Name: name,
+					Run: &api.Run{
+						PipelineSpec:       pipelineSpec,
+						ResourceReferences: experimentReference(experimentId),
+					},
+					Parameters:     sweepParameters,
+					MaxConcurrency: maxConcurrency,
+				}})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), sweep)
+		},
+	}
+	command.Flags().StringVar(&name, "name", "", "The name of the sweep, which names its runs")
+	command.Flags().StringVar(&pipelineId, "pipeline-id", "", "The ID of the pipeline to run")
+	command.Flags().StringVar(&pipelineFile, "pipeline-file", "",
+		"The Argo workflow to run, if the pipeline isn't uploaded")
+	command.Flags().StringVar(&experimentId, "experiment-id", "", "The ID of the experiment of the runs")
+	command.Flags().StringArrayVarP(&parameters, "parameter", "p", []string{},
+		"A parameter of the runs which isn't swept, in the NAME=VALUE format. Can be repeated")
+	command.Flags().StringArrayVar(&values, "values", []string{},
+		"A parameter swept over a list of values, in the NAME=VALUE,VALUE... format. Can be repeated")
+	command.Flags().StringArrayVar(&ranges, "range", []string{},
+		"A parameter swept over a range of numbers, in the NAME=START:STOP:STEP format, the stop included. "+
+			"Can be repeated")
+	command.Flags().Int32Var(&maxConcurrency, "max-concurrency", 0,
+		"How many runs of the sweep may run at once. All of them if 0")
+	return command
+}
+
+func NewRunSweepGetCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Display a sweep with the status and the metrics of its runs",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "sweep")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sweep, err := root.Client().Runs.GetRunSweep(context.Background(), &api.GetRunSweepRequest{Id: args[0]})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			return PrintMessage(root.Writer(), root.OutputFormat(), sweep)
+		},
+	}
+}
+
+// parseSweepParameters parses the parameters swept over lists of values and over ranges, in the
+// order of the flags.
+func parseSweepParameters(values []string, ranges []string) ([]*api.SweepParameter, error) {
+	var parameters []*api.SweepParameter
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Values format is not valid. Expected: 'NAME=VALUE,VALUE...'. Got: '%s'", value)
+		}
+		parameters = append(parameters, &api.SweepParameter{Name: parts[0], Values: strings.Split(parts[1], ",")})
+	}
+	for _, valueRange := range ranges {
+		parts := strings.SplitN(valueRange, "=", 2)
+		var bounds []float64
+		if len(parts) == 2 && parts[0] != "" {
+			for _, bound := range strings.Split(parts[1], ":") {
+				number, err := strconv.ParseFloat(bound, 64)
+				if err != nil {
+					break
+				}
+				bounds = append(bounds, number)
+			}
+		}
+		if len(bounds) != 3 {
+			return nil, fmt.Errorf("Range format is not valid. Expected: 'NAME=START:STOP:STEP'. Got: '%s'", valueRange)
+		}
+		parameters = append(parameters, &api.SweepParameter{
+			Name:  parts[0],
+			Range: &api.SweepParameter_Range{Start: bounds[0], Stop: bounds[1], Step: bounds[2]},
+		})
+	}
+	return parameters, nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunSweepCreate(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "sweep", "create", "--name", "sweep1", "--pipeline-id", "pipeline1",
+		"-p", "epochs=10", "--values", "optimizer=adam,sgd", "--range", "rate=0.1:0.3:0.1", "--max-concurrency", "2"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	expected := `
+id: sweep-1
+max_concurrency: 2
+name: sweep1
+parameters:
+- name: optimizer
+  values:
+  - adam
+  - sgd
+- name: rate
+  range:
+    start: 0.1
+    step: 0.1
+    stop: 0.3
+run:
+  pipeline_spec:
+    parameters:
+    - name: epochs
+      value: "10"
+    pipeline_id: pipeline1
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "sweep", "get", "sweep-1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestRunSweepCreateInvalidRange(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "sweep", "create", "--name", "sweep1", "--pipeline-id", "pipeline1",
+		"--range", "rate=0.1:0.3"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected: 'NAME=START:STOP:STEP'")
+}
+
+func TestRunSweepCreateInvalidValues(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "sweep", "create", "--name", "sweep1", "--pipeline-id", "pipeline1",
+		"--values", "optimizer"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected: 'NAME=VALUE,VALUE...'")
+}
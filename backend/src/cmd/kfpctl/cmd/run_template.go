@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/homedir"
+)
+
+// The environment variable overriding the default file of the run templates.
+const envRunTemplates = "KFPCTL_RUN_TEMPLATES"
+
+// RunTemplate is a named set of flags of 'run submit', saved to submit similar runs
+// without repeating them.
+type RunTemplate struct {
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	PipelineId   string            `json:"pipeline_id,omitempty"`
+	PipelineFile string            `json:"pipeline_file,omitempty"`
+	ExperimentId string            `json:"experiment_id,omitempty"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+}
+
+// RunTemplateStore stores the run templates in a local YAML file.
+type RunTemplateStore struct {
+	path string
+}
+
+type runTemplateFile struct {
+	Templates []*RunTemplate `json:"templates"`
+}
+
+func NewRunTemplateStore(path string) *RunTemplateStore {
+	return &RunTemplateStore{path: path}
+}
+
+func defaultRunTemplatesPath() string {
+	return filepath.Join(homedir.HomeDir(), ".kfpctl", "run-templates.yaml")
+}
+
+// List returns the templates sorted by name. There is none if the file doesn't exist.
+func (s *RunTemplateStore) List() ([]*RunTemplate, error) {
+	content, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read the run templates: %v", err)
+	}
+	var file runTemplateFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("Failed to parse the run templates in %v: %v", s.path, err)
+	}
+	sort.Slice(file.Templates, func(i, j int) bool { return file.Templates[i].Name < file.Templates[j].Name })
+	return file.Templates, nil
+}
+
+func (s *RunTemplateStore) Get(name string) (*RunTemplate, error) {
+	templates, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range templates {
+		if template.Name == name {
+			return template, nil
+		}
+	}
+	return nil, fmt.Errorf("Run template %v not found", name)
+}
+
+// Save adds a template, replacing the template with the same name.
+func (s *RunTemplateStore) Save(template *RunTemplate) error {
+	templates, err := s.List()
+	if err != nil {
+		return err
+	}
+	var result []*RunTemplate
+	for _, existing := range templates {
+		if existing.Name != template.Name {
+			result = append(result, existing)
+		}
+	}
+	return s.write(append(result, template))
+}
+
+func (s *RunTemplateStore) Delete(name string) error {
+	templates, err := s.List()
+	if err != nil {
+		return err
+	}
+	var result []*RunTemplate
+	for _, existing := range templates {
+		if existing.Name != name {
+			result = append(result, existing)
+		}
+	}
+	if len(result) == len(templates) {
+		return fmt.Errorf("Run template %v not found", name)
+	}
+	return s.write(result)
+}
+
+func (s *RunTemplateStore) write(templates []*RunTemplate) error {
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	content, err := yaml.Marshal(&runTemplateFile{Templates: templates})
+	if err != nil {
+		return fmt.Errorf("Failed to save the run templates: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("Failed to save the run templates: %v", err)
+	}
+	if err := ioutil.WriteFile(s.path, content, 0644); err != nil {
+		return fmt.Errorf("Failed to save the run templates: %v", err)
+	}
+	return nil
+}
+
+// mergeRunTemplate returns the flags of a run, defaulting to the values of the template
+// for the flags which aren't set. The pipeline flags are defaulted together.
+func mergeRunTemplate(template *RunTemplate, flags *RunTemplate) *RunTemplate {
+	merged := *flags
+	if merged.PipelineId == "" && merged.PipelineFile == "" {
+		merged.PipelineId, merged.PipelineFile = template.PipelineId, template.PipelineFile
+	}
+	if merged.Name == "" {
+		merged.Name = template.Name
+	}
+	if merged.Description == "" {
+		merged.Description = template.Description
+	}
+	if merged.ExperimentId == "" {
+		merged.ExperimentId = template.ExperimentId
+	}
+	return &merged
+}
+
+// apiParameters returns the parameters of the template overridden by the parameter
+// flags, sorted by name.
+func (t *RunTemplate) apiParameters(overrides []string) ([]*api.Parameter, error) {
+	overridden, err := ParseParameters(overrides)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	for name, value := range t.Parameters {
+		values[name] = value
+	}
+	for _, param := range overridden {
+		values[param.Name] = param.Value
+	}
+	var parameters []*api.Parameter
+	for name, value := range values {
+		parameters = append(parameters, &api.Parameter{Name: name, Value: value})
+	}
+	sort.Slice(parameters, func(i, j int) bool { return parameters[i].Name < parameters[j].Name })
+	return parameters, nil
+}
+
+func NewRunTemplateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "template",
+		Short: "Manage the run templates, the saved flags of 'run submit --template'",
+	}
+}
+
+func NewRunTemplateSaveCmd(root *RootCommand) *cobra.Command {
+	var (
+		template   RunTemplate
+		parameters []string
+	)
+	var command = &cobra.Command{
+		Use:   "save NAME",
+		Short: "Save a run template, replacing the template with the same name",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if _, err := validateIdArgument(args, "run template"); err != nil {
+				return err
+			}
+			if template.PipelineId != "" && template.PipelineFile != "" {
+				return fmt.Errorf("Expected at most one of the flags 'pipeline-id' and 'pipeline-file'")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			apiParameters, err := ParseParameters(parameters)
+			if err != nil {
+				return err
+			}
+			saved := template
+			saved.Name = args[0]
+			for _, param := range apiParameters {
+				if saved.Parameters == nil {
+					saved.Parameters = make(map[string]string)
+				}
+				saved.Parameters[param.Name] = param.Value
+			}
+			if saved.PipelineFile != "" {
+				// The template can be submitted from another directory.
+				if saved.PipelineFile, err = filepath.Abs(saved.PipelineFile); err != nil {
+					return err
+				}
+			}
+			if err := root.RunTemplates().Save(&saved); err != nil {
+				return err
+			}
+			return printObject(root.Writer(), root.OutputFormat(), &saved)
+		},
+	}
+	command.Flags().StringVar(&template.Description, "description", "", "The description of the runs")
+	command.Flags().StringVar(&template.PipelineId, "pipeline-id", "", "The ID of the pipeline to run")
+	command.Flags().StringVar(&template.PipelineFile, "pipeline-file", "",
+		"The Argo workflow to run, if the pipeline isn't uploaded")
+	command.Flags().StringVar(&template.ExperimentId, "experiment-id", "", "The ID of the experiment of the runs")
+	command.Flags().StringArrayVarP(&parameters, "parameter", "p", []string{},
+		"A parameter of the runs, in the NAME=VALUE format. Can be repeated")
+	return command
+}
+
+func NewRunTemplateListCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the run templates",
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateNoArgument(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templates, err := root.RunTemplates().List()
+			if err != nil {
+				return err
+			}
+			return printObject(root.Writer(), root.OutputFormat(), &runTemplateFile{Templates: templates})
+		},
+	}
+}
+
+func NewRunTemplateGetCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get NAME",
+		Short: "Display a run template",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run template")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			template, err := root.RunTemplates().Get(args[0])
+			if err != nil {
+				return err
+			}
+			return printObject(root.Writer(), root.OutputFormat(), template)
+		},
+	}
+}
+
+func NewRunTemplateDeleteCmd(root *RootCommand) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete NAME",
+		Short: "Delete a run template",
+		Args: func(cmd *cobra.Command, args []string) error {
+			_, err := validateIdArgument(args, "run template")
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return root.RunTemplates().Delete(args[0])
+		},
+	}
+}
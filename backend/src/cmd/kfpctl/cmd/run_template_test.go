@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRunTemplateTestCommand(t *testing.T) (*RootCommand, *ClientFactoryFake, string) {
+	dir, err := ioutil.TempDir("", "kfpctl")
+	assert.Nil(t, err)
+	rootCmd, factory := newRunTemplateTestCommandInDir(t, dir)
+	return rootCmd, factory, dir
+}
+
+// newRunTemplateTestCommandInDir returns a new command sharing the run templates of dir.
+// The flags of a command keep their values between executions.
+func newRunTemplateTestCommandInDir(t *testing.T, dir string) (*RootCommand, *ClientFactoryFake) {
+	rootCmd, factory := GetFakeRootCommand()
+	assert.Nil(t, rootCmd.Command().PersistentFlags().Set("run-templates",
+		filepath.Join(dir, "templates", "run-templates.yaml")))
+	return rootCmd, factory
+}
+
+func TestRunTemplateSaveListDelete(t *testing.T) {
+	rootCmd, factory, dir := newRunTemplateTestCommand(t)
+	defer os.RemoveAll(dir)
+	rootCmd.Command().SetArgs([]string{"run", "template", "save", "train", "--pipeline-id", "pipeline1",
+		"--experiment-id", "experiment1", "-p", "learning-rate=0.1", "-p", "epochs=10"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	rootCmd, factory = newRunTemplateTestCommandInDir(t, dir)
+	rootCmd.Command().SetArgs([]string{"run", "template", "save", "evaluate", "--pipeline-id", "pipeline2"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "template", "list"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+templates:
+- name: evaluate
+  pipeline_id: pipeline2
+- experiment_id: experiment1
+  name: train
+  parameters:
+    epochs: "10"
+    learning-rate: "0.1"
+  pipeline_id: pipeline1
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+
+	rootCmd.Command().SetArgs([]string{"run", "template", "delete", "train"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	rootCmd.Command().SetArgs([]string{"run", "template", "get", "train"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Run template train not found")
+}
+
+func TestRunSubmitWithTemplate(t *testing.T) {
+	rootCmd, factory, dir := newRunTemplateTestCommand(t)
+	defer os.RemoveAll(dir)
+	rootCmd.Command().SetArgs([]string{"run", "template", "save", "train", "--pipeline-id", "pipeline1",
+		"--experiment-id", "experiment1", "-p", "learning-rate=0.1", "-p", "epochs=10"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--template", "train", "-p", "epochs=20", "-p", "seed=1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+id: run-1
+name: train
+pipeline_spec:
+  parameters:
+  - name: epochs
+    value: "20"
+  - name: learning-rate
+    value: "0.1"
+  - name: seed
+    value: "1"
+  pipeline_id: pipeline1
+resource_references:
+- key:
+    id: experiment1
+    type: EXPERIMENT
+  relationship: OWNER
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestRunSubmitWithMissingTemplate(t *testing.T) {
+	rootCmd, _, dir := newRunTemplateTestCommand(t)
+	defer os.RemoveAll(dir)
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--template", "train"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Run template train not found")
+}
+
+func TestRunTemplateSaveRequiresAtMostOnePipeline(t *testing.T) {
+	rootCmd, _, dir := newRunTemplateTestCommand(t)
+	defer os.RemoveAll(dir)
+	rootCmd.Command().SetArgs([]string{"run", "template", "save", "train", "--pipeline-id", "pipeline1",
+		"--pipeline-file", "pipeline.yaml"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected at most one of the flags 'pipeline-id' and 'pipeline-file'")
+}
+
+func TestMergeRunTemplate(t *testing.T) {
+	template := &RunTemplate{Name: "train", PipelineFile: "/pipeline.yaml", ExperimentId: "experiment1"}
+	merged := mergeRunTemplate(template, &RunTemplate{Name: "run1", PipelineId: "pipeline1"})
+	assert.Equal(t, &RunTemplate{Name: "run1", PipelineId: "pipeline1", ExperimentId: "experiment1"}, merged)
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestRunSubmit(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1",
+		"--experiment-id", "experiment1", "-p", "learning-rate=0.1", "-p", "epochs=10"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	expected := `
+id: run-1
+name: run1
+pipeline_spec:
+  parameters:
+  - name: epochs
+    value: "10"
+  - name: learning-rate
+    value: "0.1"
+  pipeline_id: pipeline1
+resource_references:
+- key:
+    id: experiment1
+    type: EXPERIMENT
+  relationship: OWNER
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestRunSubmitExperimentName(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1",
+		"--experiment-name", "nightly"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	expected := `
+id: run-1
+name: run1
+pipeline_spec:
+  pipeline_id: pipeline1
+resource_references:
+- key:
+    type: EXPERIMENT
+  name: nightly
+  relationship: OWNER
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+
+	rootCmd, _ = GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1",
+		"--experiment-id", "experiment1", "--experiment-name", "nightly"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected at most one of the flags 'experiment-id' and 'experiment-name'")
+}
+
+func TestRunSubmitPartialExecution(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1",
+		"--step", "train", "--step", "evaluate"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	expected := `
+id: run-1
+name: run1
+partial_execution:
+  steps:
+  - train
+  - evaluate
+pipeline_spec:
+  pipeline_id: pipeline1
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestRunEstimate(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	factory.Client().Runs.(*kfpfake.RunClient).SetEstimate(&api.RunEstimate{
+		Cpu: 2, DurationSeconds: 3600, CpuHours: 2, Cost: 0.1, Currency: "USD", PastRuns: 3})
+	rootCmd.Command().SetArgs([]string{"run", "estimate", "--pipeline-id", "pipeline1", "-p", "epochs=10"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	expected := `
+cost: 0.1
+cpu: 2
+cpu_hours: 2
+currency: USD
+duration_seconds: "3600"
+past_runs: 3
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+
+}
+
+func TestRunEstimateWithoutPipeline(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "estimate"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected exactly one of the flags")
+}
+
+func TestRunSubmitInvalidParameter(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1",
+		"-p", "epochs"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected: 'NAME=VALUE'")
+}
+
+func TestRunSubmitRequiresOnePipeline(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Expected exactly one of the flags 'pipeline-id' and 'pipeline-file'")
+}
+
+func TestRunWatch(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	factory.Client().Runs.(*kfpfake.RunClient).SetStatus("run-1", "Failed")
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "watch", "run-1"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Run run-1 finished with status Failed")
+	assert.True(t, strings.HasPrefix(factory.Result(), "Run run-1: Failed\n"))
+}
+
+func TestRunApproveAndReject(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	runs := factory.Client().Runs.(*kfpfake.RunClient)
+	runs.SetApprovalGates("run-1",
+		&api.ApprovalGate{NodeId: "gate1", State: api.ApprovalGate_WAITING},
+		&api.ApprovalGate{NodeId: "gate2", State: api.ApprovalGate_WAITING})
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "approve", "run-1", "--node-id", "gate1", "--comment", "LGTM"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, "Approved run run-1\n", factory.Result())
+
+	rootCmd.Command().SetArgs([]string{"run", "reject", "run-1", "--reason", "Bad metrics"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	runDetail, err := runs.GetRun(context.Background(), &api.GetRunRequest{RunId: "run-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Failed", runDetail.Run.Status)
+	assert.Equal(t, []*api.ApprovalGate{
+		{NodeId: "gate1", State: api.ApprovalGate_APPROVED, Message: "Approved: LGTM"},
+		{NodeId: "gate2", State: api.ApprovalGate_REJECTED, Message: "Rejected: Bad metrics"},
+	}, runDetail.ApprovalGates)
+
+	// No gate waits anymore.
+	rootCmd.Command().SetArgs([]string{"run", "approve", "run-1", "--node-id", ""})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "no approval gate waiting for a decision")
+}
+
+func TestRunManifest(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	_, err := factory.Client().Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{
+		Name: "run1", PipelineSpec: &api.PipelineSpec{WorkflowManifest: `{"kind":"Workflow","spec":{}}`}}})
+	assert.Nil(t, err)
+
+	rootCmd.Command().SetArgs([]string{"run", "manifest", "run-1", "--submitted"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	assert.Equal(t, "kind: Workflow\nspec: {}\n", factory.Result())
+
+	// The fake runs no workflow.
+	rootCmd.Command().SetArgs([]string{"run", "manifest", "run-1", "--submitted=false"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Run run-1 has no such manifest")
+}
+
+func TestRunHistory(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"run", "submit", "--name", "run1", "--pipeline-id", "pipeline1"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	runs := factory.Client().Runs.(*kfpfake.RunClient)
+	runs.SetHistory("run-1",
+		&api.RunStateTransition{State: "Running", Time: &timestamp.Timestamp{Seconds: 1546441445}},
+		&api.RunStateTransition{NodeId: "node-1", NodeName: "train", State: "Failed",
+			Time: &timestamp.Timestamp{Seconds: 1546441505}, Message: "OOMKilled"},
+		&api.RunStateTransition{State: "Failed", Time: &timestamp.Timestamp{Seconds: 1546441510}})
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "history", "run-1", "--as-of", "2019-01-02T15:05:05Z"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+transitions:
+- state: Running
+  time: "2019-01-02T15:04:05Z"
+- message: OOMKilled
+  node_id: node-1
+  node_name: train
+  state: Failed
+  time: "2019-01-02T15:05:05Z"
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+
+	rootCmd.Command().SetArgs([]string{"run", "history", "run-1", "--as-of", "yesterday"})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "is not an RFC 3339 time")
+}
+
+func TestRunList(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	for _, name := range []string{"run1", "run2", "run3"} {
+		rootCmd.Command().SetArgs([]string{"run", "submit", "--name", name, "--pipeline-id", "pipeline1"})
+		_, err := rootCmd.Command().ExecuteC()
+		assert.Nil(t, err)
+	}
+	factory.buffer.Reset()
+
+	rootCmd.Command().SetArgs([]string{"run", "list", "--max-items", "2"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+	expected := `
+runs:
+- id: run-1
+  name: run1
+  pipeline_spec:
+    pipeline_id: pipeline1
+- id: run-2
+  name: run2
+  pipeline_spec:
+    pipeline_id: pipeline1
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
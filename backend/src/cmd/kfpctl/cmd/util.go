@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/spf13/cobra"
+)
+
+type OutputFormat string
+
+const (
+	OutputFormatYaml OutputFormat = "yaml"
+	OutputFormatJson OutputFormat = "json"
+)
+
+// PrintMessage prints a message with the field names of the REST API.
+func PrintMessage(writer io.Writer, outputFormat string, message proto.Message) error {
+	var buffer bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true, Indent: "  "}
+	if err := marshaler.Marshal(&buffer, message); err != nil {
+		return fmt.Errorf("Failed to print the result: %v", err)
+	}
+	return printJson(writer, outputFormat, buffer.Bytes())
+}
+
+// printObject prints an object which isn't a message of the API, with the field names of
+// its JSON tags.
+func printObject(writer io.Writer, outputFormat string, object interface{}) error {
+	content, err := json.MarshalIndent(object, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to print the result: %v", err)
+	}
+	return printJson(writer, outputFormat, content)
+}
+
+func printJson(writer io.Writer, outputFormat string, content []byte) error {
+	switch OutputFormat(outputFormat) {
+	case OutputFormatJson:
+		fmt.Fprintln(writer, string(content))
+	case OutputFormatYaml:
+		result, err := yaml.JSONToYAML(content)
+		if err != nil {
+			return fmt.Errorf("Failed to print the result: %v", err)
+		}
+		fmt.Fprint(writer, string(result))
+	default:
+		return fmt.Errorf("Unknown output format '%v'. Expected: json|yaml", outputFormat)
+	}
+	return nil
+}
+
+// ParseParameters parses the NAME=VALUE parameter flags, sorted by name.
+func ParseParameters(params []string) ([]*api.Parameter, error) {
+	var parameters []*api.Parameter
+	for _, param := range params {
+		parts := strings.SplitN(param, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("Parameter format is not valid. Expected: 'NAME=VALUE'. Got: '%s'", param)
+		}
+		parameters = append(parameters, &api.Parameter{Name: parts[0], Value: parts[1]})
+	}
+	sort.Slice(parameters, func(i, j int) bool { return parameters[i].Name < parameters[j].Name })
+	return parameters, nil
+}
+
+func experimentReference(experimentId string) []*api.ResourceReference {
+	if experimentId == "" {
+		return nil
+	}
+	return []*api.ResourceReference{{
+		Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experimentId},
+		Relationship: api.Relationship_OWNER,
+	}}
+}
+
+// experimentNameReference returns the reference to the experiment of the name, which the API
+// server creates if missing.
+func experimentNameReference(experimentName string) []*api.ResourceReference {
+	return []*api.ResourceReference{{
+		Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT},
+		Name:         experimentName,
+		Relationship: api.Relationship_OWNER,
+	}}
+}
+
+// errorForCLI returns the message of the errors of the API server without their gRPC
+// status.
+func errorForCLI(err error) error {
+	if apiError, ok := err.(*kfp.Error); ok {
+		return fmt.Errorf("%v", apiError.Message)
+	}
+	return err
+}
+
+// listFlags are the flags of the list commands.
+type listFlags struct {
+	name     string
+	sortBy   string
+	maxItems int
+}
+
+func addListFlags(command *cobra.Command, flags *listFlags) {
+	command.Flags().StringVar(&flags.name, "name", "",
+		"List only the resources whose name contains this value")
+	command.Flags().StringVar(&flags.sortBy, "sort-by", "",
+		"The field to sort by, followed by ' desc' for a descending order. E.g. 'created_at desc'")
+	command.Flags().IntVarP(&flags.maxItems, "max-items", "m", math.MaxInt32,
+		"Maximum number of items to list")
+}
+
+func (f *listFlags) validate() error {
+	if f.maxItems < 0 {
+		return fmt.Errorf("The flag 'max-items' cannot be negative")
+	}
+	return nil
+}
+
+// matches returns true if a resource with this name is listed. The API server can't
+// filter by name, so the resources are filtered after being listed.
+func (f *listFlags) matches(name string) bool {
+	return strings.Contains(name, f.name)
+}
+
+func (f *listFlags) pageSize() int32 {
+	if f.maxItems < int(defaultPageSize) {
+		return int32(f.maxItems)
+	}
+	return defaultPageSize
+}
+
+// validateIdArgument returns the only argument of the commands taking a resource ID.
+func validateIdArgument(args []string, resourceName string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("Expected the ID of the %v as the only argument", resourceName)
+	}
+	return args[0], nil
+}
+
+func validateNoArgument(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("Expected no argument, got %v", len(args))
+	}
+	return nil
+}
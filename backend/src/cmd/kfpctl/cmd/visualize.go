@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/spf13/cobra"
+)
+
+// The visualization types, named as in the API in lower case.
+var visualizationTypes = map[string]api.Visualization_Type{
+	"roc_curve":        api.Visualization_ROC_CURVE,
+	"confusion_matrix": api.Visualization_CONFUSION_MATRIX,
+	"table":            api.Visualization_TABLE,
+}
+
+func NewVisualizeCmd(root *RootCommand) *cobra.Command {
+	var (
+		visualizationType string
+		arguments         string
+		htmlFile          string
+	)
+	var command = &cobra.Command{
+		Use:   "visualize URI",
+		Short: "Generate the visualization of an artifact of a run",
+		Long: "Generate the visualization of an artifact of a run, e.g. the ROC curve of the predictions " +
+			"at minio://mlpipeline/artifacts/predictions.csv. With --html-file, the HTML of the visualization " +
+			"is written to the file instead of being displayed.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Expected the URI of the artifact as the only argument")
+			}
+			if _, ok := visualizationTypes[visualizationType]; !ok {
+				return fmt.Errorf("Invalid visualization type '%v'. Expected one of: roc_curve|confusion_matrix|table",
+					visualizationType)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			visualization, err := root.Client().Visualizations.CreateVisualization(context.Background(),
+				&api.CreateVisualizationRequest{Visualization: &api.Visualization{
+					Type:      visualizationTypes[visualizationType],
+					Source:    args[0],
+					Arguments: arguments,
+				}})
+			if err != nil {
+				return errorForCLI(err)
+			}
+			if visualization.Error != "" {
+				return fmt.Errorf("Could not visualize %v: %v", args[0], visualization.Error)
+			}
+			if htmlFile == "" {
+				return PrintMessage(root.Writer(), root.OutputFormat(), visualization)
+			}
+			if err := ioutil.WriteFile(htmlFile, []byte(visualization.Html), 0644); err != nil {
+				return err
+			}
+			fmt.Fprintf(root.Writer(), "Visualization written to %v\n", htmlFile)
+			return nil
+		},
+	}
+	command.Flags().StringVar(&visualizationType, "type", "table",
+		"The type of the visualization. One of: roc_curve|confusion_matrix|table")
+	command.Flags().StringVar(&arguments, "arguments", "",
+		`The arguments of the visualization as a JSON object, e.g. '{"target_column": "label"}'`)
+	command.Flags().StringVar(&htmlFile, "html-file", "", "The file to write the HTML of the visualization to")
+	return command
+}
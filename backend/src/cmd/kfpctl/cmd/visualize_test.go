@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisualize(t *testing.T) {
+	rootCmd, factory := GetFakeRootCommand()
+	factory.Client().Visualizations.(*kfpfake.VisualizationClient).SetVisualization(
+		api.Visualization_ROC_CURVE, "minio://mlpipeline/predictions.csv", "<svg></svg>")
+	rootCmd.Command().SetArgs([]string{"visualize", "minio://mlpipeline/predictions.csv", "--type", "roc_curve",
+		"--arguments", `{"target_column": "label"}`})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	expected := `
+arguments: '{"target_column": "label"}'
+html: <svg></svg>
+source: minio://mlpipeline/predictions.csv
+type: ROC_CURVE
+`
+	assert.Equal(t, strings.TrimSpace(expected), strings.TrimSpace(factory.Result()))
+}
+
+func TestVisualizeToHtmlFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kfpctl")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	htmlFile := filepath.Join(dir, "table.html")
+	rootCmd, factory := GetFakeRootCommand()
+	factory.Client().Visualizations.(*kfpfake.VisualizationClient).SetVisualization(
+		api.Visualization_TABLE, "minio://mlpipeline/predictions.csv", "<table></table>")
+	rootCmd.Command().SetArgs([]string{"visualize", "minio://mlpipeline/predictions.csv", "--html-file", htmlFile})
+	_, err = rootCmd.Command().ExecuteC()
+	assert.Nil(t, err)
+
+	html, err := ioutil.ReadFile(htmlFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "<table></table>", string(html))
+	assert.Equal(t, "Visualization written to "+htmlFile+"\n", factory.Result())
+}
+
+func TestVisualizeInvalidType(t *testing.T) {
+	rootCmd, _ := GetFakeRootCommand()
+	rootCmd.Command().SetArgs([]string{"visualize", "minio://mlpipeline/predictions.csv", "--type", "pie"})
+	_, err := rootCmd.Command().ExecuteC()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Invalid visualization type 'pie'")
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"github.com/kubeflow/pipelines/backend/src/cmd/kfpctl/cmd"
+)
+
+func main() {
+	rootCmd := cmd.NewRootCmd(cmd.NewClientFactory())
+	rootCmd = cmd.CreateSubCommands(rootCmd)
+	rootCmd.Execute()
+}
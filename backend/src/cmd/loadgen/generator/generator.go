@@ -0,0 +1,219 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generator generates load on the API server, to measure the latency and the
+// error rate of its RPCs: it imports pipelines, then submits runs of synthetic workflows
+// from concurrent workers, which also get and list the runs, and deletes the pipelines.
+// The API can't delete runs and experiments, so these are left behind, named with the
+// prefix of the load test.
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"golang.org/x/net/context"
+)
+
+// DefaultPipelineURL is the pipeline imported by default.
+const DefaultPipelineURL = "https://storage.googleapis.com/ml-pipeline-dataset/sequential.yaml"
+
+// The size of the pages of runs listed by the workers.
+const listRunsPageSize = 10
+
+// Config is the load generated.
+type Config struct {
+	// The number of pipelines imported from PipelineURL.
+	Pipelines   int
+	PipelineURL string
+	// The number of runs submitted, unless Duration is set.
+	Runs int
+	// The number of workers submitting runs and importing pipelines concurrently.
+	Concurrency int
+	// If set, the workers submit runs for this long, to soak the API server, instead of
+	// submitting Runs runs.
+	Duration time.Duration
+	// The synthetic workflows of the runs have Steps steps one after the other, running
+	// Image for StepDuration.
+	Steps        int
+	StepDuration time.Duration
+	Image        string
+	// The prefix of the names of the resources, a random one if empty.
+	NamePrefix string
+	// Whether to keep the imported pipelines.
+	KeepPipelines bool
+}
+
+// Generator generates the load of a Config.
+type Generator struct {
+	client   *kfp.Client
+	config   Config
+	stats    *Stats
+	workflow string
+}
+
+// NewGenerator creates a Generator calling the API server through client.
+func NewGenerator(client *kfp.Client, config Config) (*Generator, error) {
+	if config.Pipelines < 0 || config.Runs < 0 || config.Duration < 0 {
+		return nil, fmt.Errorf("The number of pipelines, the number of runs and the duration can't be negative")
+	}
+	if config.Concurrency < 1 {
+		return nil, fmt.Errorf("The concurrency must be at least 1, got %v", config.Concurrency)
+	}
+	if config.Steps < 1 {
+		return nil, fmt.Errorf("The synthetic workflows must have at least 1 step, got %v", config.Steps)
+	}
+	if config.Pipelines > 0 && config.PipelineURL == "" {
+		config.PipelineURL = DefaultPipelineURL
+	}
+	if config.NamePrefix == "" {
+		uuid, err := util.NewUUIDGenerator().NewRandom()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to generate the name prefix: %v", err)
+		}
+		config.NamePrefix = "loadgen-" + strings.Replace(uuid.String(), "-", "", -1)[:8]
+	}
+	return &Generator{
+		client:   client,
+		config:   config,
+		stats:    NewStats(),
+		workflow: syntheticWorkflow(config.Image, config.Steps, int(config.StepDuration.Seconds())),
+	}, nil
+}
+
+// Run generates the load, until it's done or ctx is canceled, and reports the calls to
+// the API server. The calls failing don't stop the load, they're reported. It only fails
+// if the experiment of the runs can't be created.
+func (g *Generator) Run(ctx context.Context) (*Report, error) {
+	var experiment *api.Experiment
+	err := g.stats.Record("CreateExperiment", func() (err error) {
+		experiment, err = g.client.Experiments.CreateExperiment(ctx, &api.CreateExperimentRequest{
+			Experiment: &api.Experiment{Name: g.config.NamePrefix, Description: "Runs of a load test."}})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create the experiment of the runs: %v", err)
+	}
+
+	pipelineIds := g.importPipelines(ctx)
+	g.submitRuns(ctx, experiment.Id)
+	if !g.config.KeepPipelines {
+		g.forEach(ctx, len(pipelineIds), func(i int) {
+			g.stats.Record("DeletePipeline", func() error {
+				_, err := g.client.Pipelines.DeletePipeline(ctx, &api.DeletePipelineRequest{Id: pipelineIds[i]})
+				return err
+			})
+		})
+	}
+	return g.stats.Report(), nil
+}
+
+// importPipelines imports and gets the pipelines, and returns the IDs of the ones
+// imported.
+func (g *Generator) importPipelines(ctx context.Context) []string {
+	var mutex sync.Mutex
+	var ids []string
+	g.forEach(ctx, g.config.Pipelines, func(i int) {
+		var pipeline *api.Pipeline
+		err := g.stats.Record("CreatePipeline", func() (err error) {
+			pipeline, err = g.client.Pipelines.CreatePipeline(ctx, &api.CreatePipelineRequest{
+				Url:  &api.Url{PipelineUrl: g.config.PipelineURL},
+				Name: fmt.Sprintf("%v-%v", g.config.NamePrefix, i),
+			})
+			return err
+		})
+		if err != nil {
+			return
+		}
+		mutex.Lock()
+		ids = append(ids, pipeline.Id)
+		mutex.Unlock()
+		g.stats.Record("GetPipeline", func() error {
+			_, err := g.client.Pipelines.GetPipeline(ctx, &api.GetPipelineRequest{Id: pipeline.Id})
+			return err
+		})
+	})
+	return ids
+}
+
+// submitRuns submits the runs of the synthetic workflow in the experiment. Every worker
+// gets each run it submits, then lists the runs of the experiment.
+func (g *Generator) submitRuns(ctx context.Context, experimentId string) {
+	count := g.config.Runs
+	if g.config.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.config.Duration)
+		defer cancel()
+		count = -1
+	}
+	experimentKey := &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experimentId}
+	g.forEach(ctx, count, func(i int) {
+		var runDetail *api.RunDetail
+		err := g.stats.Record("CreateRun", func() (err error) {
+			runDetail, err = g.client.Runs.CreateRun(ctx, &api.CreateRunRequest{Run: &api.Run{
+				Name:         fmt.Sprintf("%v-%v", g.config.NamePrefix, i),
+				PipelineSpec: &api.PipelineSpec{WorkflowManifest: g.workflow},
+				ResourceReferences: []*api.ResourceReference{
+					{Key: experimentKey, Relationship: api.Relationship_OWNER},
+				},
+			}})
+			return err
+		})
+		if ctx.Err() != nil {
+			// The calls interrupted by the end of the soak test aren't failures.
+			return
+		}
+		if err == nil {
+			g.stats.Record("GetRun", func() error {
+				_, err := g.client.Runs.GetRun(ctx, &api.GetRunRequest{RunId: runDetail.Run.Id})
+				return err
+			})
+		}
+		g.stats.Record("ListRuns", func() error {
+			_, err := g.client.Runs.ListRuns(ctx, &api.ListRunsRequest{
+				PageSize: listRunsPageSize, SortBy: "created_at desc", ResourceReferenceKey: experimentKey})
+			return err
+		})
+	})
+}
+
+// forEach calls f with 0 to count-1 from Concurrency workers, or with increasing numbers
+// until ctx is done if count is negative. It returns once the calls have returned.
+func (g *Generator) forEach(ctx context.Context, count int, f func(i int)) {
+	var mutex sync.Mutex
+	next := 0
+	var wg sync.WaitGroup
+	for w := 0; w < g.config.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				mutex.Lock()
+				i := next
+				next++
+				mutex.Unlock()
+				if count >= 0 && i >= count {
+					return
+				}
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
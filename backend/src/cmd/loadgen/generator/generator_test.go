@@ -0,0 +1,130 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testConfig = Config{Pipelines: 3, Runs: 7, Concurrency: 2, Steps: 2, Image: "alpine:3.8", NamePrefix: "loadgen-1"}
+
+func callsByMethod(report *Report) map[string]int {
+	calls := make(map[string]int)
+	for _, method := range report.Methods {
+		calls[method.Method] = method.Calls
+	}
+	return calls
+}
+
+func TestRun(t *testing.T) {
+	client := kfpfake.NewClient()
+	g, err := NewGenerator(client, testConfig)
+	assert.Nil(t, err)
+
+	report, err := g.Run(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{
+		"CreateExperiment": 1,
+		"CreatePipeline":   3,
+		"GetPipeline":      3,
+		"DeletePipeline":   3,
+		"CreateRun":        7,
+		"GetRun":           7,
+		"ListRuns":         7,
+	}, callsByMethod(report))
+	assert.Equal(t, 0.0, report.ErrorRate())
+
+	// The pipelines were deleted, the runs were kept.
+	pipelines, err := client.Pipelines.ListPipelines(context.Background(), &api.ListPipelinesRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, pipelines.Pipelines)
+	runs, err := client.Runs.ListRuns(context.Background(), &api.ListRunsRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, runs.Runs, 7)
+	assert.Equal(t, "loadgen-1-0", runs.Runs[0].Name)
+	assert.NotEmpty(t, runs.Runs[0].PipelineSpec.WorkflowManifest)
+}
+
+func TestRun_ReportsErrors(t *testing.T) {
+	client := kfpfake.NewClient()
+	client.Runs.(*kfpfake.RunClient).SetError("CreateRun", status.Error(codes.ResourceExhausted, "full"))
+	g, err := NewGenerator(client, testConfig)
+	assert.Nil(t, err)
+
+	report, err := g.Run(context.Background())
+	assert.Nil(t, err)
+	calls := callsByMethod(report)
+	assert.Equal(t, 7, calls["CreateRun"])
+	assert.Equal(t, 0, calls["GetRun"])
+	assert.Equal(t, 7, calls["ListRuns"])
+	for _, method := range report.Methods {
+		if method.Method == "CreateRun" {
+			assert.Equal(t, map[string]int{"ResourceExhausted": 7}, method.Errors)
+		}
+	}
+}
+
+func TestRun_ExperimentNotCreated(t *testing.T) {
+	client := kfpfake.NewClient()
+	client.Experiments.(*kfpfake.ExperimentClient).SetError("CreateExperiment", status.Error(codes.Internal, "bad"))
+	g, err := NewGenerator(client, testConfig)
+	assert.Nil(t, err)
+
+	_, err = g.Run(context.Background())
+	assert.Contains(t, err.Error(), "Failed to create the experiment of the runs")
+}
+
+func TestRun_Soak(t *testing.T) {
+	config := testConfig
+	config.Pipelines = 0
+	config.Duration = 20 * time.Millisecond
+	g, err := NewGenerator(kfpfake.NewClient(), config)
+	assert.Nil(t, err)
+
+	report, err := g.Run(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, callsByMethod(report)["CreateRun"] > 0)
+	assert.Equal(t, 0.0, report.ErrorRate())
+}
+
+func TestNewGenerator_InvalidConfig(t *testing.T) {
+	config := testConfig
+	config.Concurrency = 0
+	_, err := NewGenerator(kfpfake.NewClient(), config)
+	assert.NotNil(t, err)
+
+	config = testConfig
+	config.Runs = -1
+	_, err = NewGenerator(kfpfake.NewClient(), config)
+	assert.NotNil(t, err)
+}
+
+func TestSyntheticWorkflow(t *testing.T) {
+	workflow, err := util.ValidateWorkflow([]byte(syntheticWorkflow("alpine:3.8", 3, 5)))
+	assert.Nil(t, err)
+	dag := workflow.Spec.Templates[0].DAG
+	assert.Len(t, dag.Tasks, 3)
+	assert.Equal(t, []string{"step-2"}, dag.Tasks[2].Dependencies)
+	assert.Equal(t, []string{"sleep", "5"}, workflow.Spec.Templates[1].Container.Command)
+}
@@ -0,0 +1,175 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+)
+
+// Stats records the latency and the outcome of the calls to the API server, by RPC. It
+// is safe for concurrent use.
+type Stats struct {
+	mutex   sync.Mutex
+	start   time.Time
+	methods map[string]*methodStats
+}
+
+type methodStats struct {
+	latencies []time.Duration
+	// The number of failed calls by gRPC code.
+	errors map[string]int
+}
+
+// NewStats creates a Stats recording from now on.
+func NewStats() *Stats {
+	return &Stats{start: time.Now(), methods: make(map[string]*methodStats)}
+}
+
+// Record times call, a call to the RPC method, and returns its error.
+func (s *Stats) Record(method string, call func() error) error {
+	start := time.Now()
+	err := call()
+	latency := time.Since(start)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats, ok := s.methods[method]
+	if !ok {
+		stats = &methodStats{errors: make(map[string]int)}
+		s.methods[method] = stats
+	}
+	stats.latencies = append(stats.latencies, latency)
+	if err != nil {
+		stats.errors[kfp.Code(err).String()]++
+	}
+	return err
+}
+
+// Report summarizes the calls recorded so far.
+func (s *Stats) Report() *Report {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	report := &Report{Duration: time.Since(s.start)}
+	for method, stats := range s.methods {
+		latencies := append([]time.Duration(nil), stats.latencies...)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		methodReport := MethodReport{
+			Method: method,
+			Calls:  len(latencies),
+			P50:    percentile(latencies, 50),
+			P90:    percentile(latencies, 90),
+			P99:    percentile(latencies, 99),
+			Max:    latencies[len(latencies)-1],
+			Errors: make(map[string]int),
+		}
+		for code, count := range stats.errors {
+			methodReport.Errors[code] = count
+			methodReport.ErrorCount += count
+		}
+		report.Methods = append(report.Methods, methodReport)
+	}
+	sort.Slice(report.Methods, func(i, j int) bool { return report.Methods[i].Method < report.Methods[j].Method })
+	return report
+}
+
+// percentile returns the p-th percentile of the sorted latencies, using the nearest
+// rank.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Report is the summary of the calls to the API server during a load test.
+type Report struct {
+	Duration time.Duration
+	// The RPCs called, sorted by name.
+	Methods []MethodReport
+}
+
+// MethodReport summarizes the calls to an RPC.
+type MethodReport struct {
+	Method     string
+	Calls      int
+	ErrorCount int
+	// The number of failed calls by gRPC code, e.g. "Unavailable".
+	Errors map[string]int
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// ErrorRate returns the fraction of the calls that failed.
+func (r MethodReport) ErrorRate() float64 {
+	if r.Calls == 0 {
+		return 0
+	}
+	return float64(r.ErrorCount) / float64(r.Calls)
+}
+
+// ErrorRate returns the fraction of all the calls that failed.
+func (r *Report) ErrorRate() float64 {
+	calls, errors := 0, 0
+	for _, method := range r.Methods {
+		calls += method.Calls
+		errors += method.ErrorCount
+	}
+	if calls == 0 {
+		return 0
+	}
+	return float64(errors) / float64(calls)
+}
+
+// Write writes the report as a table, with a row per RPC.
+func (r *Report) Write(w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "RPC\tCALLS\tCALLS/S\tERRORS\tERROR RATE\tP50\tP90\tP99\tMAX")
+	for _, method := range r.Methods {
+		fmt.Fprintf(table, "%v\t%v\t%.1f\t%v\t%.2f%%\t%v\t%v\t%v\t%v\n",
+			method.Method, method.Calls, float64(method.Calls)/r.Duration.Seconds(), method.ErrorCount,
+			100*method.ErrorRate(), roundLatency(method.P50), roundLatency(method.P90),
+			roundLatency(method.P99), roundLatency(method.Max))
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	for _, method := range r.Methods {
+		codes := make([]string, 0, len(method.Errors))
+		for code := range method.Errors {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			fmt.Fprintf(w, "%v failed with %v %v times\n", method.Method, code, method.Errors[code])
+		}
+	}
+	_, err := fmt.Fprintf(w, "Total: %.2f%% errors in %v\n", 100*r.ErrorRate(), r.Duration.Round(time.Millisecond))
+	return err
+}
+
+func roundLatency(latency time.Duration) time.Duration {
+	return latency.Round(100 * time.Microsecond)
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(latencies, 100))
+	assert.Equal(t, time.Millisecond, percentile(latencies[:1], 50))
+}
+
+func TestStats(t *testing.T) {
+	stats := NewStats()
+	assert.Nil(t, stats.Record("GetRun", func() error { return nil }))
+	assert.Nil(t, stats.Record("CreateRun", func() error { return nil }))
+	err := stats.Record("CreateRun", func() error { return status.Error(codes.Unavailable, "down") })
+	assert.NotNil(t, err)
+	stats.Record("CreateRun", func() error { return errors.New("not a gRPC error") })
+
+	report := stats.Report()
+	assert.Len(t, report.Methods, 2)
+	createRun := report.Methods[0]
+	assert.Equal(t, "CreateRun", createRun.Method)
+	assert.Equal(t, 3, createRun.Calls)
+	assert.Equal(t, 2, createRun.ErrorCount)
+	assert.Equal(t, map[string]int{"Unavailable": 1, "Unknown": 1}, createRun.Errors)
+	assert.InDelta(t, 2.0/3, createRun.ErrorRate(), 1e-9)
+	assert.Equal(t, "GetRun", report.Methods[1].Method)
+	assert.Equal(t, 0, report.Methods[1].ErrorCount)
+	assert.InDelta(t, 0.5, report.ErrorRate(), 1e-9)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, report.Write(&buffer))
+	assert.Contains(t, buffer.String(), "CreateRun failed with Unavailable 1 times\n")
+	assert.Contains(t, buffer.String(), "Total: 50.00% errors in ")
+}
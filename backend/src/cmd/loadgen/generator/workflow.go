@@ -0,0 +1,55 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// syntheticWorkflow returns the manifest of a workflow running steps containers of image
+// one after the other, each sleeping for stepSeconds.
+func syntheticWorkflow(image string, steps int, stepSeconds int) string {
+	var tasks []workflowapi.DAGTask
+	for i := 1; i <= steps; i++ {
+		task := workflowapi.DAGTask{Name: fmt.Sprintf("step-%v", i), Template: "sleep"}
+		if i > 1 {
+			task.Dependencies = []string{fmt.Sprintf("step-%v", i-1)}
+		}
+		tasks = append(tasks, task)
+	}
+	workflow := &workflowapi.Workflow{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow"},
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "loadgen-"},
+		Spec: workflowapi.WorkflowSpec{
+			Entrypoint: "main",
+			Templates: []workflowapi.Template{
+				{Name: "main", DAG: &workflowapi.DAGTemplate{Tasks: tasks}},
+				{
+					Name: "sleep",
+					Container: &corev1.Container{
+						Image:   image,
+						Command: []string{"sleep", fmt.Sprint(stepSeconds)},
+					},
+				},
+			},
+		},
+	}
+	return util.NewWorkflow(workflow).ToStringForStore()
+}
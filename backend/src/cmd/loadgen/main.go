@@ -0,0 +1,116 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command loadgen generates load on the API server and reports the latency percentiles
+// and the error rate of its RPCs, e.g. to submit 1000 runs from 20 workers:
+//
+//	loadgen --endpoint=localhost:8887 --pipelines=10 --runs=1000 --concurrency=20
+//
+// or to soak it with runs for an hour:
+//
+//	loadgen --endpoint=localhost:8887 --duration=1h
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/cmd/loadgen/generator"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"golang.org/x/net/context"
+)
+
+var (
+	endpoint      = flag.String("endpoint", "localhost:8887", "The host:port of the gRPC API of the API server.")
+	useTLS        = flag.Bool("tls", false, "Whether to connect to the endpoint with TLS.")
+	token         = flag.String("token", os.Getenv("KFP_TOKEN"), "The bearer token authenticating the calls. Defaults to $KFP_TOKEN.")
+	timeout       = flag.Duration("timeout", 30*time.Second, "The timeout of every call to the API server.")
+	pipelines     = flag.Int("pipelines", 10, "The number of pipelines to import.")
+	pipelineURL   = flag.String("pipeline-url", generator.DefaultPipelineURL, "The URL of the pipeline to import.")
+	runs          = flag.Int("runs", 100, "The number of runs to submit. Ignored if --duration is set.")
+	concurrency   = flag.Int("concurrency", 10, "The number of concurrent workers.")
+	duration      = flag.Duration("duration", 0, "If set, submit runs for this long instead of submitting --runs runs.")
+	steps         = flag.Int("steps", 1, "The number of steps of the synthetic workflows of the runs.")
+	stepDuration  = flag.Duration("step-duration", 0, "How long every step of the synthetic workflows sleeps.")
+	image         = flag.String("image", "alpine:3.8", "The image of the steps of the synthetic workflows.")
+	namePrefix    = flag.String("name-prefix", "", "The prefix of the names of the created resources. Defaults to a random one.")
+	keepPipelines = flag.Bool("keep-pipelines", false, "Keep the imported pipelines instead of deleting them.")
+	maxErrorRate  = flag.Float64("max-error-rate", 1, "Exit with a non-zero status if more than this fraction of the calls fail.")
+)
+
+func main() {
+	flag.Parse()
+	options := []kfp.Option{
+		kfp.WithTimeout(*timeout),
+		// The calls are measured as the callers of the API server see them without
+		// retries.
+		kfp.WithRetryPolicy(kfp.RetryPolicy{}),
+	}
+	if !*useTLS {
+		options = append(options, kfp.WithInsecure())
+	}
+	if *token != "" {
+		options = append(options, kfp.WithBearerToken(*token))
+	}
+	client, err := kfp.NewClient(*endpoint, options...)
+	if err != nil {
+		exitf("Failed to connect to %v: %v", *endpoint, err)
+	}
+	defer client.Close()
+
+	g, err := generator.NewGenerator(client, generator.Config{
+		Pipelines:     *pipelines,
+		PipelineURL:   *pipelineURL,
+		Runs:          *runs,
+		Concurrency:   *concurrency,
+		Duration:      *duration,
+		Steps:         *steps,
+		StepDuration:  *stepDuration,
+		Image:         *image,
+		NamePrefix:    *namePrefix,
+		KeepPipelines: *keepPipelines,
+	})
+	if err != nil {
+		exitf("Invalid load: %v", err)
+	}
+
+	// Interrupting the load test still reports the calls made so far.
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	go func() {
+		<-interrupts
+		cancel()
+	}()
+
+	report, err := g.Run(ctx)
+	if err != nil {
+		exitf("%v", err)
+	}
+	if err := report.Write(os.Stdout); err != nil {
+		exitf("Failed to write the report: %v", err)
+	}
+	if report.ErrorRate() > *maxErrorRate {
+		client.Close()
+		exitf("The error rate %.2f%% is above %.2f%%", 100*report.ErrorRate(), 100**maxErrorRate)
+	}
+}
+
+func exitf(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kfp is the Go client of the gRPC API of the ML pipeline API server.
+//
+//	client, err := kfp.NewClient("ml-pipeline.kubeflow:8887", kfp.WithInsecure())
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//	runs := client.ListRuns(ctx, &api.ListRunsRequest{SortBy: "created_at desc"})
+//	for {
+//		run, err := runs.Next()
+//		if err == kfp.Done {
+//			break
+//		}
+//		...
+//	}
+//
+// The calls that fail because the API server is unavailable are retried with an
+// exponential backoff, and the errors returned by the API server are *Error.
+//
+// From outside the cluster, the client connects through the Kubernetes API server with
+// the credentials of a kubeconfig:
+//
+//	dialer, err := kfp.NewPortForwardDialer(clientConfig)
+//	...
+//	client, err := kfp.NewClient("ml-pipeline.kubeflow:8887", kfp.WithPortForward(dialer))
+//
+// The tests of the code using the client can run without an API server, by replaying
+// the calls recorded once against a deployment:
+//
+//	if *record {
+//		recorder := kfp.NewRecorder()
+//		client, err = kfp.NewClient(endpoint, kfp.WithInsecure(), kfp.WithRecorder(recorder))
+//		defer recorder.Save("testdata/list_runs.json")
+//	} else {
+//		recording, err := kfp.LoadRecording("testdata/list_runs.json")
+//		...
+//		client, err = kfp.NewReplayClient(recording)
+//	}
+package kfp
+
+import (
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// Client is a connection to the API server. The service clients are safe for
+// concurrent use.
+type Client struct {
+	conn *grpc.ClientConn
+
+	Pipelines     api.PipelineServiceClient
+	Experiments   api.ExperimentServiceClient
+	Runs          api.RunServiceClient
+	Jobs          api.JobServiceClient
+	Lineage       api.LineageServiceClient
+	ModelRegistry api.ModelRegistryServiceClient
+	Webhooks      api.WebhookServiceClient
+	// Visualizations generates the visualizations of the artifacts of the runs.
+	Visualizations api.VisualizationServiceClient
+	// Admin checks the consistency of the database and the object store.
+	Admin api.AdminServiceClient
+	// Operations polls the operations started by the Admin client.
+	Operations api.OperationServiceClient
+	// ExecutionTargets registers the remote clusters the runs can be dispatched to.
+	ExecutionTargets api.ExecutionTargetServiceClient
+	// ServerInfo tells which features of the API server are enabled.
+	ServerInfo api.ServerInfoServiceClient
+}
+
+// NewClient connects to the gRPC API of the API server at endpoint, in the
+// host:port form. The connection is established lazily, on the first call.
+func NewClient(endpoint string, opts ...Option) (*Client, error) {
+	conn, err := grpc.Dial(endpoint, DialOptions(opts...)...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to connect to the API server at %v", endpoint)
+	}
+	return NewClientFromConn(conn), nil
+}
+
+// NewClientFromConn creates a Client using an existing connection. The calls
+// aren't retried and their errors aren't converted unless the connection was
+// dialed with the options returned by DialOptions.
+func NewClientFromConn(conn *grpc.ClientConn) *Client {
+	return &Client{
+		conn:             conn,
+		Pipelines:        api.NewPipelineServiceClient(conn),
+		Experiments:      api.NewExperimentServiceClient(conn),
+		Runs:             api.NewRunServiceClient(conn),
+		Jobs:             api.NewJobServiceClient(conn),
+		Lineage:          api.NewLineageServiceClient(conn),
+		ModelRegistry:    api.NewModelRegistryServiceClient(conn),
+		Webhooks:         api.NewWebhookServiceClient(conn),
+		Visualizations:   api.NewVisualizationServiceClient(conn),
+		Admin:            api.NewAdminServiceClient(conn),
+		Operations:       api.NewOperationServiceClient(conn),
+		ExecutionTargets: api.NewExecutionTargetServiceClient(conn),
+		ServerInfo:       api.NewServerInfoServiceClient(conn),
+	}
+}
+
+// Conn returns the underlying connection.
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close closes the connection to the API server.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		// The fake clients have no connection.
+		return nil
+	}
+	return c.conn.Close()
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeRunServer serves runs named "0" to "4" in pages of two, after failing the
+// first unavailableCalls calls.
+type fakeRunServer struct {
+	api.RunServiceServer
+	unavailableCalls int
+	calls            int
+	authorization    []string
+}
+
+func (s *fakeRunServer) ListRuns(ctx context.Context, request *api.ListRunsRequest) (*api.ListRunsResponse, error) {
+	s.calls++
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		s.authorization = md["authorization"]
+	}
+	if s.calls <= s.unavailableCalls {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	start := 0
+	if request.PageToken != "" {
+		start, _ = strconv.Atoi(request.PageToken)
+	}
+	response := &api.ListRunsResponse{}
+	for i := start; i < 5 && i < start+2; i++ {
+		response.Runs = append(response.Runs, &api.Run{Name: strconv.Itoa(i)})
+	}
+	if start+2 < 5 {
+		response.NextPageToken = strconv.Itoa(start + 2)
+	}
+	return response, nil
+}
+
+func (s *fakeRunServer) GetRun(ctx context.Context, request *api.GetRunRequest) (*api.RunDetail, error) {
+	s.calls++
+	return nil, util.ToGRPCError(util.NewResourceNotFoundError("Run", request.RunId))
+}
+
+func startFakeRunServer(t *testing.T, runServer *fakeRunServer) (string, func()) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	api.RegisterRunServiceServer(server, runServer)
+	go server.Serve(listener)
+	return listener.Addr().String(), server.Stop
+}
+
+var fastRetries = RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+func TestListRuns(t *testing.T) {
+	runServer := &fakeRunServer{}
+	endpoint, stop := startFakeRunServer(t, runServer)
+	defer stop()
+	client, err := NewClient(endpoint, WithInsecure(), WithBearerToken("token"))
+	assert.Nil(t, err)
+	defer client.Close()
+
+	var names []string
+	runs := client.ListRuns(context.Background(), &api.ListRunsRequest{PageSize: 2})
+	for {
+		run, err := runs.Next()
+		if err == Done {
+			break
+		}
+		assert.Nil(t, err)
+		names = append(names, run.Name)
+	}
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, names)
+	assert.Equal(t, 3, runServer.calls)
+	assert.Equal(t, []string{"Bearer token"}, runServer.authorization)
+}
+
+func TestListRuns_RetriesUnavailable(t *testing.T) {
+	runServer := &fakeRunServer{unavailableCalls: 2}
+	endpoint, stop := startFakeRunServer(t, runServer)
+	defer stop()
+	client, err := NewClient(endpoint, WithInsecure(), WithRetryPolicy(fastRetries))
+	assert.Nil(t, err)
+	defer client.Close()
+
+	run, err := client.ListRuns(context.Background(), nil).Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "0", run.Name)
+	assert.Equal(t, 3, runServer.calls)
+}
+
+func TestListRuns_UnavailableAfterRetries(t *testing.T) {
+	runServer := &fakeRunServer{unavailableCalls: 10}
+	endpoint, stop := startFakeRunServer(t, runServer)
+	defer stop()
+	client, err := NewClient(endpoint, WithInsecure(), WithRetryPolicy(fastRetries))
+	assert.Nil(t, err)
+	defer client.Close()
+
+	runs := client.ListRuns(context.Background(), nil)
+	_, err = runs.Next()
+	assert.True(t, IsUnavailable(err))
+	assert.Equal(t, 4, runServer.calls)
+	// The iteration stops at the first error.
+	_, err = runs.Next()
+	assert.True(t, IsUnavailable(err))
+	assert.Equal(t, 4, runServer.calls)
+}
+
+func TestListRuns_MessageOptions(t *testing.T) {
+	runServer := &fakeRunServer{}
+	endpoint, stop := startFakeRunServer(t, runServer)
+	defer stop()
+
+	// The server decompresses the gzip calls, and compresses its responses.
+	client, err := NewClient(endpoint, WithInsecure(), WithMessageOptions(1024, 1024, true))
+	assert.Nil(t, err)
+	defer client.Close()
+	run, err := client.ListRuns(context.Background(), nil).Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "0", run.Name)
+
+	client, err = NewClient(endpoint, WithInsecure(), WithMessageOptions(1, 1024, true))
+	assert.Nil(t, err)
+	defer client.Close()
+	_, err = client.ListRuns(context.Background(), nil).Next()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestTypedErrors(t *testing.T) {
+	runServer := &fakeRunServer{}
+	endpoint, stop := startFakeRunServer(t, runServer)
+	defer stop()
+	client, err := NewClient(endpoint, WithInsecure(), WithRetryPolicy(fastRetries))
+	assert.Nil(t, err)
+	defer client.Close()
+
+	_, err = client.Runs.GetRun(context.Background(), &api.GetRunRequest{RunId: "run1"})
+	assert.True(t, IsNotFound(err))
+	apiError, ok := err.(*Error)
+	assert.True(t, ok)
+	assert.Contains(t, apiError.Message, "run1")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	// The errors other than UNAVAILABLE aren't retried.
+	assert.Equal(t, 1, runServer.calls)
+}
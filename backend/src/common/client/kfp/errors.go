@@ -0,0 +1,113 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"fmt"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is an error returned by the API server.
+type Error struct {
+	Code codes.Code
+	// The message meant for the users.
+	Message string
+	// The internal error of the API server, if reported.
+	Details string
+
+	status *status.Status
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v (code: %v)", e.Message, e.Code)
+}
+
+// GRPCStatus returns the status of the call, so that status.FromError and
+// status.Code keep working on the errors of the client.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// toError converts the errors of the gRPC calls to *Error. The other errors are
+// returned unchanged.
+func toError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	stat, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	apiError := &Error{Code: stat.Code(), Message: stat.Message(), status: stat}
+	for _, detail := range stat.Details() {
+		if errorDetail, ok := detail.(*api.Error); ok {
+			apiError.Message = errorDetail.ErrorMessage
+			apiError.Details = errorDetail.ErrorDetails
+		}
+	}
+	return apiError
+}
+
+// ConvertError converts the error of a gRPC call to *Error as the client does, e.g. in
+// the fakes of the service clients. The other errors are returned unchanged.
+func ConvertError(err error) error {
+	return toError(err)
+}
+
+// Code returns the gRPC code of the error, codes.OK if it's nil and
+// codes.Unknown if it wasn't returned by a call.
+func Code(err error) codes.Code {
+	if apiError, ok := err.(*Error); ok {
+		return apiError.Code
+	}
+	return status.Code(err)
+}
+
+// IsNotFound returns true if the requested resource doesn't exist.
+func IsNotFound(err error) bool {
+	return Code(err) == codes.NotFound
+}
+
+// IsAlreadyExists returns true if the resource to create already exists.
+func IsAlreadyExists(err error) bool {
+	return Code(err) == codes.AlreadyExists
+}
+
+// IsInvalidArgument returns true if the request was rejected as invalid.
+func IsInvalidArgument(err error) bool {
+	return Code(err) == codes.InvalidArgument
+}
+
+// IsUnauthenticated returns true if the credentials were missing or invalid.
+func IsUnauthenticated(err error) bool {
+	return Code(err) == codes.Unauthenticated
+}
+
+// IsPermissionDenied returns true if the caller isn't allowed to make the request.
+func IsPermissionDenied(err error) bool {
+	return Code(err) == codes.PermissionDenied
+}
+
+// IsUnavailable returns true if the API server couldn't be reached, after all the
+// retries.
+func IsUnavailable(err error) bool {
+	return Code(err) == codes.Unavailable
+}
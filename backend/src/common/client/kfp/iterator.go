@@ -0,0 +1,303 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+)
+
+// Done is returned by the iterators once all the resources were returned.
+var Done = errors.New("no more items in iterator")
+
+// pager fetches the pages of a list call until the API server returns no page
+// token.
+type pager struct {
+	ctx     context.Context
+	token   string
+	started bool
+	err     error
+}
+
+// next fetches the next page with fetchPage, which returns the number of
+// resources and the next page token. It returns false once all the pages were
+// fetched or a call failed.
+func (p *pager) next(fetchPage func(ctx context.Context, token string) (int, string, error)) bool {
+	for p.err == nil && (!p.started || p.token != "") {
+		count, token, err := fetchPage(p.ctx, p.token)
+		p.started = true
+		p.token = token
+		p.err = err
+		if err == nil && count > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// done returns the error ending the iteration.
+func (p *pager) done() error {
+	if p.err != nil {
+		return p.err
+	}
+	return Done
+}
+
+// PipelineIterator iterates over the pipelines returned by ListPipelines.
+type PipelineIterator struct {
+	pager
+	client  api.PipelineServiceClient
+	request *api.ListPipelinesRequest
+	items   []*api.Pipeline
+}
+
+// ListPipelines returns an iterator over the pipelines matching request, whose
+// page token is ignored.
+func (c *Client) ListPipelines(ctx context.Context, request *api.ListPipelinesRequest) *PipelineIterator {
+	if request == nil {
+		request = &api.ListPipelinesRequest{}
+	}
+	return &PipelineIterator{
+		pager:   pager{ctx: ctx},
+		client:  c.Pipelines,
+		request: proto.Clone(request).(*api.ListPipelinesRequest),
+	}
+}
+
+// Next returns the next pipeline, or Done once they were all returned.
+func (it *PipelineIterator) Next() (*api.Pipeline, error) {
+	if len(it.items) == 0 && !it.next(it.fetchPage) {
+		return nil, it.done()
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *PipelineIterator) fetchPage(ctx context.Context, token string) (int, string, error) {
+	it.request.PageToken = token
+	response, err := it.client.ListPipelines(ctx, it.request)
+	if err != nil {
+		return 0, "", err
+	}
+	it.items = response.Pipelines
+	return len(it.items), response.NextPageToken, nil
+}
+
+// ExperimentIterator iterates over the experiments returned by ListExperiment.
+type ExperimentIterator struct {
+	pager
+	client  api.ExperimentServiceClient
+	request *api.ListExperimentsRequest
+	items   []*api.Experiment
+}
+
+// ListExperiments returns an iterator over the experiments matching request, whose
+// page token is ignored.
+func (c *Client) ListExperiments(ctx context.Context, request *api.ListExperimentsRequest) *ExperimentIterator {
+	if request == nil {
+		request = &api.ListExperimentsRequest{}
+	}
+	return &ExperimentIterator{
+		pager:   pager{ctx: ctx},
+		client:  c.Experiments,
+		request: proto.Clone(request).(*api.ListExperimentsRequest),
+	}
+}
+
+// Next returns the next experiment, or Done once they were all returned.
+func (it *ExperimentIterator) Next() (*api.Experiment, error) {
+	if len(it.items) == 0 && !it.next(it.fetchPage) {
+		return nil, it.done()
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *ExperimentIterator) fetchPage(ctx context.Context, token string) (int, string, error) {
+	it.request.PageToken = token
+	response, err := it.client.ListExperiment(ctx, it.request)
+	if err != nil {
+		return 0, "", err
+	}
+	it.items = response.Experiments
+	return len(it.items), response.NextPageToken, nil
+}
+
+// RunIterator iterates over the runs returned by ListRuns.
+type RunIterator struct {
+	pager
+	client  api.RunServiceClient
+	request *api.ListRunsRequest
+	items   []*api.Run
+}
+
+// ListRuns returns an iterator over the runs matching request, whose page token is
+// ignored.
+func (c *Client) ListRuns(ctx context.Context, request *api.ListRunsRequest) *RunIterator {
+	if request == nil {
+		request = &api.ListRunsRequest{}
+	}
+	return &RunIterator{
+		pager:   pager{ctx: ctx},
+		client:  c.Runs,
+		request: proto.Clone(request).(*api.ListRunsRequest),
+	}
+}
+
+// Next returns the next run, or Done once they were all returned.
+func (it *RunIterator) Next() (*api.Run, error) {
+	if len(it.items) == 0 && !it.next(it.fetchPage) {
+		return nil, it.done()
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *RunIterator) fetchPage(ctx context.Context, token string) (int, string, error) {
+	it.request.PageToken = token
+	response, err := it.client.ListRuns(ctx, it.request)
+	if err != nil {
+		return 0, "", err
+	}
+	it.items = response.Runs
+	return len(it.items), response.NextPageToken, nil
+}
+
+// JobIterator iterates over the jobs returned by ListJobs.
+type JobIterator struct {
+	pager
+	client  api.JobServiceClient
+	request *api.ListJobsRequest
+	items   []*api.Job
+}
+
+// ListJobs returns an iterator over the jobs matching request, whose page token is
+// ignored.
+func (c *Client) ListJobs(ctx context.Context, request *api.ListJobsRequest) *JobIterator {
+	if request == nil {
+		request = &api.ListJobsRequest{}
+	}
+	return &JobIterator{
+		pager:   pager{ctx: ctx},
+		client:  c.Jobs,
+		request: proto.Clone(request).(*api.ListJobsRequest),
+	}
+}
+
+// Next returns the next job, or Done once they were all returned.
+func (it *JobIterator) Next() (*api.Job, error) {
+	if len(it.items) == 0 && !it.next(it.fetchPage) {
+		return nil, it.done()
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *JobIterator) fetchPage(ctx context.Context, token string) (int, string, error) {
+	it.request.PageToken = token
+	response, err := it.client.ListJobs(ctx, it.request)
+	if err != nil {
+		return 0, "", err
+	}
+	it.items = response.Jobs
+	return len(it.items), response.NextPageToken, nil
+}
+
+// RunGroupIterator iterates over the run groups returned by ListRunGroups.
+type RunGroupIterator struct {
+	pager
+	client  api.RunServiceClient
+	request *api.ListRunGroupsRequest
+	items   []*api.RunGroup
+}
+
+// ListRunGroups returns an iterator over the run groups, whose page token is ignored.
+func (c *Client) ListRunGroups(ctx context.Context, request *api.ListRunGroupsRequest) *RunGroupIterator {
+	if request == nil {
+		request = &api.ListRunGroupsRequest{}
+	}
+	return &RunGroupIterator{
+		pager:   pager{ctx: ctx},
+		client:  c.Runs,
+		request: proto.Clone(request).(*api.ListRunGroupsRequest),
+	}
+}
+
+// Next returns the next run group, or Done once they were all returned.
+func (it *RunGroupIterator) Next() (*api.RunGroup, error) {
+	if len(it.items) == 0 && !it.next(it.fetchPage) {
+		return nil, it.done()
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *RunGroupIterator) fetchPage(ctx context.Context, token string) (int, string, error) {
+	it.request.PageToken = token
+	response, err := it.client.ListRunGroups(ctx, it.request)
+	if err != nil {
+		return 0, "", err
+	}
+	it.items = response.Groups
+	return len(it.items), response.NextPageToken, nil
+}
+
+// OperationIterator iterates over the operations returned by ListOperations.
+type OperationIterator struct {
+	pager
+	client  api.OperationServiceClient
+	request *api.ListOperationsRequest
+	items   []*api.Operation
+}
+
+// ListOperations returns an iterator over the operations, whose page token is ignored.
+func (c *Client) ListOperations(ctx context.Context, request *api.ListOperationsRequest) *OperationIterator {
+	if request == nil {
+		request = &api.ListOperationsRequest{}
+	}
+	return &OperationIterator{
+		pager:   pager{ctx: ctx},
+		client:  c.Operations,
+		request: proto.Clone(request).(*api.ListOperationsRequest),
+	}
+}
+
+// Next returns the next operation, or Done once they were all returned.
+func (it *OperationIterator) Next() (*api.Operation, error) {
+	if len(it.items) == 0 && !it.next(it.fetchPage) {
+		return nil, it.done()
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+func (it *OperationIterator) fetchPage(ctx context.Context, token string) (int, string, error) {
+	it.request.PageToken = token
+	response, err := it.client.ListOperations(ctx, it.request)
+	if err != nil {
+		return 0, "", err
+	}
+	it.items = response.Operations
+	return len(it.items), response.NextPageToken, nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// AdminClient is an in-memory AdminServiceClient whose consistency checks find the issues
+// set with SetConsistencyIssues. The issues are repaired by the checks requesting it. The
+// storage usage computations find the usages set with SetStorageUsages. The other
+// maintenance operations have no resource to process, the asynchronous ones returning an
+// operation which succeeded. The dead letter reports set with SetDeadLetterReports are all
+// applied when replayed.
+type AdminClient struct {
+	errorInjector
+	store *store
+}
+
+// SetConsistencyIssues sets the inconsistencies found by the next checks.
+func (c *AdminClient) SetConsistencyIssues(issues ...*api.ConsistencyIssue) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.consistencyIssues = issues
+}
+
+func (c *AdminClient) CheckConsistency(ctx context.Context, in *api.CheckConsistencyRequest,
+	opts ...grpc.CallOption) (*api.ConsistencyReport, error) {
+	if err := c.injectedError("CheckConsistency"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	report := &api.ConsistencyReport{CheckedAt: ptypes.TimestampNow(), Repair: in.GetRepair()}
+	for _, issue := range c.store.consistencyIssues {
+		issue = proto.Clone(issue).(*api.ConsistencyIssue)
+		issue.Repaired = in.GetRepair()
+		report.Issues = append(report.Issues, issue)
+	}
+	if in.GetRepair() {
+		c.store.consistencyIssues = nil
+	}
+	c.store.consistencyReport = report
+	return proto.Clone(report).(*api.ConsistencyReport), nil
+}
+
+func (c *AdminClient) GetConsistencyReport(ctx context.Context, in *api.GetConsistencyReportRequest,
+	opts ...grpc.CallOption) (*api.ConsistencyReport, error) {
+	if err := c.injectedError("GetConsistencyReport"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if c.store.consistencyReport == nil {
+		return nil, notFoundError("ConsistencyReport", "last")
+	}
+	return proto.Clone(c.store.consistencyReport).(*api.ConsistencyReport), nil
+}
+
+// SetStorageUsages sets the usages found by the next storage usage computations.
+func (c *AdminClient) SetStorageUsages(usages ...*api.StorageUsage) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.storageUsages = usages
+}
+
+func (c *AdminClient) ComputeStorageUsage(ctx context.Context, in *api.ComputeStorageUsageRequest,
+	opts ...grpc.CallOption) (*api.StorageUsageReport, error) {
+	if err := c.injectedError("ComputeStorageUsage"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	report := &api.StorageUsageReport{ComputedAt: ptypes.TimestampNow()}
+	for _, usage := range c.store.storageUsages {
+		report.Usages = append(report.Usages, proto.Clone(usage).(*api.StorageUsage))
+		report.TotalBytes += usage.Bytes
+	}
+	c.store.storageUsageReport = report
+	return proto.Clone(report).(*api.StorageUsageReport), nil
+}
+
+func (c *AdminClient) GetStorageUsage(ctx context.Context, in *api.GetStorageUsageRequest,
+	opts ...grpc.CallOption) (*api.StorageUsageReport, error) {
+	if err := c.injectedError("GetStorageUsage"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if c.store.storageUsageReport == nil {
+		return nil, notFoundError("StorageUsageReport", "last")
+	}
+	report := &api.StorageUsageReport{ComputedAt: c.store.storageUsageReport.ComputedAt}
+	for _, usage := range c.store.storageUsageReport.Usages {
+		if (in.GetNamespace() == "" || usage.Namespace == in.GetNamespace()) &&
+			(in.GetExperimentId() == "" || usage.ExperimentId == in.GetExperimentId()) {
+			report.Usages = append(report.Usages, usage)
+			report.TotalBytes += usage.Bytes
+		}
+	}
+	return proto.Clone(report).(*api.StorageUsageReport), nil
+}
+
+func (c *AdminClient) ReindexPipelines(ctx context.Context, in *api.ReindexPipelinesRequest,
+	opts ...grpc.CallOption) (*api.MaintenanceResult, error) {
+	if err := c.injectedError("ReindexPipelines"); err != nil {
+		return nil, err
+	}
+	if in.GetAsync() {
+		operationId := c.store.create("operation", finishedOperation(api.Operation_REINDEX_PIPELINES))
+		return &api.MaintenanceResult{OperationId: operationId}, nil
+	}
+	return &api.MaintenanceResult{}, nil
+}
+
+func (c *AdminClient) RecomputeRunStatuses(ctx context.Context, in *api.RecomputeRunStatusesRequest,
+	opts ...grpc.CallOption) (*api.MaintenanceResult, error) {
+	if err := c.injectedError("RecomputeRunStatuses"); err != nil {
+		return nil, err
+	}
+	if in.GetAsync() {
+		operationId := c.store.create("operation", finishedOperation(api.Operation_RECOMPUTE_RUN_STATUSES))
+		return &api.MaintenanceResult{OperationId: operationId}, nil
+	}
+	return &api.MaintenanceResult{}, nil
+}
+
+func (c *AdminClient) FlushCaches(ctx context.Context, in *api.FlushCachesRequest,
+	opts ...grpc.CallOption) (*api.MaintenanceResult, error) {
+	if err := c.injectedError("FlushCaches"); err != nil {
+		return nil, err
+	}
+	return &api.MaintenanceResult{}, nil
+}
+
+func (c *AdminClient) GetReadOnlyMode(ctx context.Context, in *api.GetReadOnlyModeRequest,
+	opts ...grpc.CallOption) (*api.ReadOnlyMode, error) {
+	if err := c.injectedError("GetReadOnlyMode"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if c.store.readOnlyMode == nil {
+		return &api.ReadOnlyMode{}, nil
+	}
+	return proto.Clone(c.store.readOnlyMode).(*api.ReadOnlyMode), nil
+}
+
+func (c *AdminClient) SetReadOnlyMode(ctx context.Context, in *api.SetReadOnlyModeRequest,
+	opts ...grpc.CallOption) (*api.ReadOnlyMode, error) {
+	if err := c.injectedError("SetReadOnlyMode"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	mode := &api.ReadOnlyMode{ReadOnly: in.GetReadOnly(), UpdatedAt: ptypes.TimestampNow()}
+	if in.GetReadOnly() {
+		mode.Message = in.GetMessage()
+	}
+	c.store.readOnlyMode = mode
+	return proto.Clone(mode).(*api.ReadOnlyMode), nil
+}
+
+// IsReadOnly returns whether the read-only mode was turned on.
+func (c *AdminClient) IsReadOnly() bool {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	return c.store.readOnlyMode.GetReadOnly()
+}
+
+// SetDeadLetterReports sets the reports kept as dead letters.
+func (c *AdminClient) SetDeadLetterReports(reports ...*api.DeadLetterReport) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.deadLetterReports = reports
+}
+
+func (c *AdminClient) ListDeadLetterReports(ctx context.Context, in *api.ListDeadLetterReportsRequest,
+	opts ...grpc.CallOption) (*api.ListDeadLetterReportsResponse, error) {
+	if err := c.injectedError("ListDeadLetterReports"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	response := &api.ListDeadLetterReportsResponse{}
+	for _, report := range c.store.deadLetterReports {
+		report = proto.Clone(report).(*api.DeadLetterReport)
+		if !in.GetIncludeWorkflows() {
+			report.Workflow = ""
+		}
+		response.Reports = append(response.Reports, report)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) ReplayDeadLetterReports(ctx context.Context, in *api.ReplayDeadLetterReportsRequest,
+	opts ...grpc.CallOption) (*api.MaintenanceResult, error) {
+	if err := c.injectedError("ReplayDeadLetterReports"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if len(in.GetRunIds()) == 0 {
+		result := &api.MaintenanceResult{Processed: int32(len(c.store.deadLetterReports))}
+		c.store.deadLetterReports = nil
+		return result, nil
+	}
+	result := &api.MaintenanceResult{}
+	for _, runId := range in.GetRunIds() {
+		if c.store.deleteDeadLetterReport(runId) {
+			result.Processed++
+		} else {
+			result.Errors = append(result.Errors, fmt.Sprintf("Dead letter %v not found.", runId))
+		}
+	}
+	return result, nil
+}
+
+func (c *AdminClient) DeleteDeadLetterReport(ctx context.Context, in *api.DeleteDeadLetterReportRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("DeleteDeadLetterReport"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if !c.store.deleteDeadLetterReport(in.GetRunId()) {
+		return nil, notFoundError("DeadLetterReport", in.GetRunId())
+	}
+	return &empty.Empty{}, nil
+}
+
+// deleteDeadLetterReport deletes the dead letter report of a run, and returns whether it
+// existed. The store must be locked.
+func (s *store) deleteDeadLetterReport(runId string) bool {
+	for i, report := range s.deadLetterReports {
+		if report.RunId == runId {
+			s.deadLetterReports = append(s.deadLetterReports[:i], s.deadLetterReports[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kfpfake provides in-memory fakes of the service clients of the API server, to
+// unit test the code using a kfp.Client without a cluster:
+//
+//	client := kfpfake.NewClient()
+//	runs := client.Runs.(*kfpfake.RunClient)
+//	runs.SetError("GetRun", status.Error(codes.Unavailable, "down"))
+//
+// The IDs of the created resources are deterministic, "<type>-<number>" in the order of
+// creation across the clients of a Client. The resources are listed by ID, and the
+// sorting and filtering of the requests are ignored, except for the runs filtered by
+// annotation and the resources filtered by favorites. All the calls are made by the
+// same user, who stars the resources.
+package kfpfake
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewClient creates a Client whose service clients share their resources in memory.
+func NewClient() *kfp.Client {
+	store := newStore()
+	return &kfp.Client{
+		Pipelines:        &PipelineClient{store: store},
+		Experiments:      &ExperimentClient{store: store},
+		Runs:             &RunClient{store: store},
+		Jobs:             &JobClient{store: store},
+		Lineage:          &LineageClient{store: store},
+		ModelRegistry:    &ModelRegistryClient{store: store},
+		Webhooks:         &WebhookClient{store: store},
+		Visualizations:   &VisualizationClient{store: store},
+		Admin:            &AdminClient{store: store},
+		Operations:       &OperationClient{store: store},
+		ExecutionTargets: &ExecutionTargetClient{store: store},
+		ServerInfo:       &ServerInfoClient{store: store},
+	}
+}
+
+// errorInjector makes the calls of a fake fail with injected errors.
+type errorInjector struct {
+	mutex  sync.Mutex
+	errors map[string]error
+}
+
+// SetError makes the calls of method, e.g. "GetRun", fail with err until it's reset with
+// a nil error. The gRPC status errors are converted to *kfp.Error, as the client does.
+func (i *errorInjector) SetError(method string, err error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if i.errors == nil {
+		i.errors = make(map[string]error)
+	}
+	if err == nil {
+		delete(i.errors, method)
+		return
+	}
+	i.errors[method] = kfp.ConvertError(err)
+}
+
+func (i *errorInjector) injectedError(method string) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return i.errors[method]
+}
+
+// store keeps the resources of the fake clients by ID.
+type store struct {
+	mutex     sync.Mutex
+	lastId    int
+	resources map[string]proto.Message
+	// The templates, the READMEs and the stats of the pipelines, by pipeline ID.
+	templates     map[string]string
+	readmes       map[string]string
+	pipelineStats map[string]*api.PipelineStats
+	// The artifacts and the logs of the runs, by run ID, node ID and artifact name.
+	artifacts map[string][]byte
+	logs      map[string][]byte
+	// The outputs of the runs, by run ID.
+	outputs map[string][]*api.RunOutput
+	// The approval gates of the runs, by run ID.
+	approvalGates map[string][]*api.ApprovalGate
+	// The state transitions of the runs, by run ID.
+	histories map[string][]*api.RunStateTransition
+	// The estimate returned for the runs, empty if nil.
+	estimate *api.RunEstimate
+	// The lineage of the runs by run ID, and of the artifacts by URI.
+	runLineages      map[string]proto.Message
+	artifactLineages map[string]proto.Message
+	// The HTML of the visualizations, by type and source.
+	visualizations map[string]string
+	// The inconsistencies found by the checks, and the report of the last check.
+	consistencyIssues []*api.ConsistencyIssue
+	consistencyReport *api.ConsistencyReport
+	// The usages found by the storage usage computations, and the report of the last one.
+	storageUsages      []*api.StorageUsage
+	storageUsageReport *api.StorageUsageReport
+	// The read-only mode, off if nil.
+	readOnlyMode *api.ReadOnlyMode
+	// The reports kept as dead letters, the oldest failure first.
+	deadLetterReports []*api.DeadLetterReport
+	// The execution targets, by name.
+	executionTargets map[string]*api.ExecutionTarget
+	// The info of the server, with no feature if nil.
+	serverInfo *api.ServerInfo
+	// The IDs of the starred resources.
+	starred map[string]bool
+}
+
+func newStore() *store {
+	return &store{
+		resources:        make(map[string]proto.Message),
+		templates:        make(map[string]string),
+		readmes:          make(map[string]string),
+		pipelineStats:    make(map[string]*api.PipelineStats),
+		artifacts:        make(map[string][]byte),
+		logs:             make(map[string][]byte),
+		outputs:          make(map[string][]*api.RunOutput),
+		approvalGates:    make(map[string][]*api.ApprovalGate),
+		histories:        make(map[string][]*api.RunStateTransition),
+		runLineages:      make(map[string]proto.Message),
+		artifactLineages: make(map[string]proto.Message),
+		visualizations:   make(map[string]string),
+		executionTargets: make(map[string]*api.ExecutionTarget),
+		starred:          make(map[string]bool),
+	}
+}
+
+func notFoundError(resourceType string, id string) error {
+	return kfp.ConvertError(status.Errorf(codes.NotFound, "%v %v not found", resourceType, id))
+}
+
+func (s *store) create(resourceType string, resource proto.Message) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lastId++
+	id := fmt.Sprintf("%v-%v", resourceType, s.lastId)
+	s.resources[id] = resource
+	return id
+}
+
+func (s *store) get(resourceType string, id string) (proto.Message, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	resource, ok := s.resources[id]
+	if !ok {
+		return nil, notFoundError(resourceType, id)
+	}
+	return proto.Clone(resource), nil
+}
+
+func (s *store) delete(resourceType string, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.resources[id]; !ok {
+		return notFoundError(resourceType, id)
+	}
+	delete(s.resources, id)
+	delete(s.starred, id)
+	return nil
+}
+
+// list returns a page of the resources of the same Go type as resourceType kept by keep,
+// if not nil, ordered by ID, and the next page token.
+func (s *store) list(resourceType proto.Message, pageSize int32, pageToken string,
+	keep func(resource proto.Message) bool) ([]proto.Message, string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var ids []string
+	for id, resource := range s.resources {
+		if fmt.Sprintf("%T", resource) == fmt.Sprintf("%T", resourceType) && (keep == nil || keep(resource)) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return idNumber(ids[i]) < idNumber(ids[j])
+	})
+	start := 0
+	if pageToken != "" {
+		var err error
+		if start, err = strconv.Atoi(pageToken); err != nil {
+			return nil, "", kfp.ConvertError(status.Errorf(codes.InvalidArgument, "Invalid page token %v", pageToken))
+		}
+	}
+	end := len(ids)
+	nextPageToken := ""
+	if pageSize > 0 && start+int(pageSize) < len(ids) {
+		end = start + int(pageSize)
+		nextPageToken = strconv.Itoa(end)
+	}
+	var resources []proto.Message
+	for i := start; i < end && i < len(ids); i++ {
+		resources = append(resources, proto.Clone(s.resources[ids[i]]))
+	}
+	return resources, nextPageToken, nil
+}
+
+// idNumber returns the number of an ID, so that "run-10" is listed after "run-9".
+func idNumber(id string) int {
+	for i := len(id) - 1; i >= 0; i-- {
+		if id[i] == '-' {
+			number, _ := strconv.Atoi(id[i+1:])
+			return number
+		}
+	}
+	return 0
+}
+
+// star stars or unstars a resource.
+func (s *store) star(resourceType string, id string, starred bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.resources[id]; !ok {
+		return notFoundError(resourceType, id)
+	}
+	if starred {
+		s.starred[id] = true
+	} else {
+		delete(s.starred, id)
+	}
+	return nil
+}
+
+func (s *store) update(id string, update func(resource proto.Message)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if resource, ok := s.resources[id]; ok {
+		update(resource)
+	}
+}
+
+var (
+	_ api.PipelineServiceClient        = &PipelineClient{}
+	_ api.ExperimentServiceClient      = &ExperimentClient{}
+	_ api.RunServiceClient             = &RunClient{}
+	_ api.JobServiceClient             = &JobClient{}
+	_ api.LineageServiceClient         = &LineageClient{}
+	_ api.ModelRegistryServiceClient   = &ModelRegistryClient{}
+	_ api.WebhookServiceClient         = &WebhookClient{}
+	_ api.ReportServiceClient          = &ReportClient{}
+	_ api.VisualizationServiceClient   = &VisualizationClient{}
+	_ api.AdminServiceClient           = &AdminClient{}
+	_ api.OperationServiceClient       = &OperationClient{}
+	_ api.ExecutionTargetServiceClient = &ExecutionTargetClient{}
+)
@@ -0,0 +1,516 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNewClient_DeterministicIds(t *testing.T) {
+	client := NewClient()
+	experiment, err := client.Experiments.CreateExperiment(context.Background(),
+		&api.CreateExperimentRequest{Experiment: &api.Experiment{Name: "exp"}})
+	assert.Nil(t, err)
+	run, err := client.Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "run"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "experiment-1", experiment.Id)
+	assert.Equal(t, "run-2", run.Run.Id)
+
+	got, err := client.Runs.GetRun(context.Background(), &api.GetRunRequest{RunId: "run-2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "run", got.Run.Name)
+}
+
+func TestSetError(t *testing.T) {
+	client := NewClient()
+	runs := client.Runs.(*RunClient)
+	runs.SetError("ListRuns", status.Error(codes.Unavailable, "down"))
+
+	_, err := client.Runs.ListRuns(context.Background(), &api.ListRunsRequest{})
+	assert.Equal(t, codes.Unavailable, kfp.Code(err))
+	assert.IsType(t, &kfp.Error{}, err)
+
+	// The other methods aren't affected.
+	_, err = client.Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "run"}})
+	assert.Nil(t, err)
+
+	runs.SetError("ListRuns", nil)
+	response, err := client.Runs.ListRuns(context.Background(), &api.ListRunsRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, response.Runs, 1)
+}
+
+func TestListRuns_Pagination(t *testing.T) {
+	client := NewClient()
+	for i := 0; i < 11; i++ {
+		_, err := client.Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "run"}})
+		assert.Nil(t, err)
+	}
+
+	var ids []string
+	pageToken := ""
+	for {
+		response, err := client.Runs.ListRuns(context.Background(),
+			&api.ListRunsRequest{PageSize: 5, PageToken: pageToken})
+		assert.Nil(t, err)
+		for _, run := range response.Runs {
+			ids = append(ids, run.Id)
+		}
+		if pageToken = response.NextPageToken; pageToken == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"run-1", "run-2", "run-3", "run-4", "run-5", "run-6", "run-7", "run-8",
+		"run-9", "run-10", "run-11"}, ids)
+}
+
+func TestGetPipeline_NotFound(t *testing.T) {
+	client := NewClient()
+	_, err := client.Pipelines.GetPipeline(context.Background(), &api.GetPipelineRequest{Id: "pipeline-1"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestReportRunMetrics(t *testing.T) {
+	client := NewClient()
+	run, err := client.Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "run"}})
+	assert.Nil(t, err)
+
+	metric := &api.RunMetric{Name: "accuracy", NodeId: "node-1", Value: &api.RunMetric_NumberValue{NumberValue: 0.9}}
+	response, err := client.Runs.ReportRunMetrics(context.Background(),
+		&api.ReportRunMetricsRequest{RunId: run.Run.Id, Metrics: []*api.RunMetric{metric, metric}})
+	assert.Nil(t, err)
+	assert.Equal(t, api.ReportRunMetricsResponse_ReportRunMetricResult_OK, response.Results[0].Status)
+	assert.Equal(t, api.ReportRunMetricsResponse_ReportRunMetricResult_DUPLICATE_REPORTING, response.Results[1].Status)
+
+	got, err := client.Runs.GetRun(context.Background(), &api.GetRunRequest{RunId: run.Run.Id})
+	assert.Nil(t, err)
+	assert.Len(t, got.Run.Metrics, 1)
+}
+
+func TestUpdateRun_FiltersByAnnotation(t *testing.T) {
+	client := NewClient()
+	for _, name := range []string{"run-a", "run-b"} {
+		_, err := client.Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: name}})
+		assert.Nil(t, err)
+	}
+	run, err := client.Runs.UpdateRun(context.Background(), &api.UpdateRunRequest{
+		RunId: "run-2", UpdateNote: true, Note: "flaky", Annotations: map[string]string{"quality": "bad"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "flaky", run.Note)
+
+	response, err := client.Runs.ListRuns(context.Background(), &api.ListRunsRequest{AnnotationFilter: "quality=bad"})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Runs, 1) {
+		assert.Equal(t, "run-b", response.Runs[0].Name)
+	}
+}
+
+func TestStarPipeline(t *testing.T) {
+	client := NewClient()
+	for _, name := range []string{"p1", "p2"} {
+		_, err := client.Pipelines.CreatePipeline(context.Background(), &api.CreatePipelineRequest{Name: name})
+		assert.Nil(t, err)
+	}
+	_, err := client.Pipelines.StarPipeline(context.Background(), &api.StarPipelineRequest{Id: "pipeline-2"})
+	assert.Nil(t, err)
+
+	response, err := client.Pipelines.ListPipelines(context.Background(), &api.ListPipelinesRequest{OnlyStarred: true})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Pipelines, 1) {
+		assert.Equal(t, "pipeline-2", response.Pipelines[0].Id)
+	}
+	_, err = client.Pipelines.StarPipeline(context.Background(), &api.StarPipelineRequest{Id: "pipeline-3"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestDeprecatePipeline(t *testing.T) {
+	client := NewClient()
+	for _, name := range []string{"p1", "p2"} {
+		_, err := client.Pipelines.CreatePipeline(context.Background(), &api.CreatePipelineRequest{Name: name})
+		assert.Nil(t, err)
+	}
+	pipeline, err := client.Pipelines.DeprecatePipeline(context.Background(),
+		&api.DeprecatePipelineRequest{Id: "pipeline-1", ReplacementPipelineId: "pipeline-2"})
+	assert.Nil(t, err)
+	assert.True(t, pipeline.Deprecated)
+	assert.Equal(t, "pipeline-2", pipeline.ReplacementPipelineId)
+
+	response, err := client.Pipelines.ListPipelines(context.Background(), &api.ListPipelinesRequest{ExcludeDeprecated: true})
+	assert.Nil(t, err)
+	if assert.Len(t, response.Pipelines, 1) {
+		assert.Equal(t, "pipeline-2", response.Pipelines[0].Id)
+	}
+	pipeline, err = client.Pipelines.UndeprecatePipeline(context.Background(), &api.UndeprecatePipelineRequest{Id: "pipeline-1"})
+	assert.Nil(t, err)
+	assert.False(t, pipeline.Deprecated)
+	_, err = client.Pipelines.DeprecatePipeline(context.Background(), &api.DeprecatePipelineRequest{Id: "pipeline-3"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestReadArtifact(t *testing.T) {
+	client := NewClient()
+	runs := client.Runs.(*RunClient)
+	runs.SetArtifact("run-1", "node-1", "mlpipeline-metrics", []byte("data"))
+
+	response, err := runs.ReadArtifact(context.Background(),
+		&api.ReadArtifactRequest{RunId: "run-1", NodeId: "node-1", ArtifactName: "mlpipeline-metrics"})
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("data"), response.Data)
+
+	_, err = runs.ReadArtifact(context.Background(),
+		&api.ReadArtifactRequest{RunId: "run-1", NodeId: "node-2", ArtifactName: "mlpipeline-metrics"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestGetRunOutputs(t *testing.T) {
+	client := NewClient()
+	runs := client.Runs.(*RunClient)
+	run, err := runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "r1"}})
+	assert.Nil(t, err)
+	runs.SetOutputs(run.Run.Id, &api.RunOutput{NodeId: "node-1", Type: "markdown", Content: "# Summary"})
+
+	response, err := runs.GetRunOutputs(context.Background(), &api.GetRunOutputsRequest{RunId: run.Run.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.RunOutput{{NodeId: "node-1", Type: "markdown", Content: "# Summary"}}, response.Outputs)
+
+	_, err = runs.GetRunOutputs(context.Background(), &api.GetRunOutputsRequest{RunId: "run-2"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestEstimateRun(t *testing.T) {
+	runs := NewClient().Runs.(*RunClient)
+	estimate, err := runs.EstimateRun(context.Background(), &api.EstimateRunRequest{Run: &api.Run{}})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.RunEstimate{}, estimate)
+	runs.SetEstimate(&api.RunEstimate{Cpu: 2, Currency: "USD"})
+	estimate, err = runs.EstimateRun(context.Background(), &api.EstimateRunRequest{Run: &api.Run{}})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.RunEstimate{Cpu: 2, Currency: "USD"}, estimate)
+}
+
+func TestGetPipelineStats(t *testing.T) {
+	pipelines := NewClient().Pipelines.(*PipelineClient)
+	_, err := pipelines.GetPipelineStats(context.Background(), &api.GetPipelineStatsRequest{Id: "pipeline-1"})
+	assert.True(t, kfp.IsNotFound(err))
+
+	pipeline := pipelines.Put(&api.Pipeline{Name: "p"})
+	stats, err := pipelines.GetPipelineStats(context.Background(), &api.GetPipelineStatsRequest{Id: pipeline.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.PipelineStats{PipelineId: pipeline.Id}, stats)
+	pipelines.SetStats(&api.PipelineStats{PipelineId: pipeline.Id, Runs: 4, FailedRuns: 1, FailureRate: 0.25})
+	stats, err = pipelines.GetPipelineStats(context.Background(), &api.GetPipelineStatsRequest{Id: pipeline.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.PipelineStats{PipelineId: pipeline.Id, Runs: 4, FailedRuns: 1, FailureRate: 0.25}, stats)
+}
+
+func TestGetPipelineReadme(t *testing.T) {
+	pipelines := NewClient().Pipelines.(*PipelineClient)
+	pipeline := pipelines.Put(&api.Pipeline{Name: "p"})
+	_, err := pipelines.GetPipelineReadme(context.Background(), &api.GetPipelineReadmeRequest{Id: pipeline.Id})
+	assert.True(t, kfp.IsNotFound(err))
+
+	pipelines.SetReadme(pipeline.Id, "# Usage")
+	readme, err := pipelines.GetPipelineReadme(context.Background(), &api.GetPipelineReadmeRequest{Id: pipeline.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.GetPipelineReadmeResponse{Readme: "# Usage"}, readme)
+}
+
+func TestExecutionTargetClient(t *testing.T) {
+	targets := NewClient().ExecutionTargets.(*ExecutionTargetClient)
+	for _, name := range []string{"west", "east"} {
+		_, err := targets.CreateExecutionTarget(context.Background(),
+			&api.CreateExecutionTargetRequest{ExecutionTarget: &api.ExecutionTarget{Name: name}})
+		assert.Nil(t, err)
+	}
+	_, err := targets.CreateExecutionTarget(context.Background(),
+		&api.CreateExecutionTargetRequest{ExecutionTarget: &api.ExecutionTarget{Name: "east"}})
+	assert.True(t, kfp.IsAlreadyExists(err))
+
+	list, err := targets.ListExecutionTargets(context.Background(), &api.ListExecutionTargetsRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.ExecutionTarget{{Name: "east"}, {Name: "west"}}, list.ExecutionTargets)
+	_, err = targets.DeleteExecutionTarget(context.Background(), &api.DeleteExecutionTargetRequest{Name: "east"})
+	assert.Nil(t, err)
+	_, err = targets.GetExecutionTarget(context.Background(), &api.GetExecutionTargetRequest{Name: "east"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestRunSweep(t *testing.T) {
+	runs := NewClient().Runs
+	sweep, err := runs.CreateRunSweep(context.Background(), &api.CreateRunSweepRequest{
+		Sweep: &api.RunSweep{Name: "sweep", MaxConcurrency: 2}})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.RunSweep{Id: "sweep-1", Name: "sweep", MaxConcurrency: 2}, sweep)
+	sweep, err = runs.GetRunSweep(context.Background(), &api.GetRunSweepRequest{Id: "sweep-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.RunSweep{Id: "sweep-1", Name: "sweep", MaxConcurrency: 2}, sweep)
+	_, err = runs.GetRunSweep(context.Background(), &api.GetRunSweepRequest{Id: "sweep-2"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestRunGroup(t *testing.T) {
+	runs := NewClient().Runs
+	_, err := runs.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{
+		Group: &api.RunGroup{Name: "group", RunIds: []string{"run-1"}}})
+	assert.True(t, kfp.IsNotFound(err))
+
+	run, err := runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "run"}})
+	assert.Nil(t, err)
+	group, err := runs.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{
+		Group: &api.RunGroup{Name: "group"}})
+	assert.Nil(t, err)
+	group, err = runs.AddRunsToRunGroup(context.Background(), &api.AddRunsToRunGroupRequest{
+		Id: group.Id, RunIds: []string{run.Run.Id, run.Run.Id}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{run.Run.Id}, group.RunIds)
+
+	group, err = runs.CancelRunGroup(context.Background(), &api.CancelRunGroupRequest{Id: group.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "Cancelled", group.Status)
+	_, err = runs.AddRunsToRunGroup(context.Background(), &api.AddRunsToRunGroupRequest{
+		Id: group.Id, RunIds: []string{run.Run.Id}})
+	assert.Equal(t, codes.FailedPrecondition, kfp.Code(err))
+}
+
+func TestTerminateAll(t *testing.T) {
+	client := NewClient()
+	runs := client.Runs.(*RunClient)
+	experiment, err := client.Experiments.CreateExperiment(context.Background(),
+		&api.CreateExperimentRequest{Experiment: &api.Experiment{Name: "exp"}})
+	assert.Nil(t, err)
+	references := []*api.ResourceReference{{
+		Key:          &api.ResourceKey{Type: api.ResourceType_EXPERIMENT, Id: experiment.Id},
+		Relationship: api.Relationship_OWNER,
+	}}
+	var runIds []string
+	for _, run := range []*api.Run{
+		{Name: "run1", ResourceReferences: references},
+		{Name: "run2", ResourceReferences: references},
+		{Name: "run3"},
+	} {
+		runDetail, err := runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: run})
+		assert.Nil(t, err)
+		runIds = append(runIds, runDetail.Run.Id)
+	}
+	runs.SetStatus(runIds[1], "Succeeded")
+
+	operation, err := runs.TerminateAll(context.Background(), &api.TerminateAllRequest{ExperimentId: experiment.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.TerminateResult{{RunId: runIds[0], Status: api.TerminateResult_TERMINATED}},
+		operation.Results)
+	fetched, err := runs.GetTerminateAllOperation(context.Background(),
+		&api.GetTerminateAllOperationRequest{Id: operation.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, operation, fetched)
+	run, err := runs.GetRun(context.Background(), &api.GetRunRequest{RunId: runIds[0]})
+	assert.Nil(t, err)
+	assert.Equal(t, "Failed", run.Run.Status)
+	run, err = runs.GetRun(context.Background(), &api.GetRunRequest{RunId: runIds[2]})
+	assert.Nil(t, err)
+	assert.Empty(t, run.Run.Status)
+
+	group, err := runs.CreateRunGroup(context.Background(), &api.CreateRunGroupRequest{
+		Group: &api.RunGroup{Name: "group", RunIds: runIds}})
+	assert.Nil(t, err)
+	operation, err = runs.TerminateAll(context.Background(), &api.TerminateAllRequest{RunGroupId: group.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, []*api.TerminateResult{{RunId: runIds[2], Status: api.TerminateResult_TERMINATED}},
+		operation.Results)
+	group, err = runs.GetRunGroup(context.Background(), &api.GetRunGroupRequest{Id: group.Id})
+	assert.Nil(t, err)
+	assert.Equal(t, "Cancelled", group.Status)
+
+	_, err = runs.TerminateAll(context.Background(), &api.TerminateAllRequest{})
+	assert.Equal(t, codes.InvalidArgument, kfp.Code(err))
+	_, err = runs.TerminateAll(context.Background(), &api.TerminateAllRequest{ExperimentId: "experiment-10"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestListModelVersions_FiltersByModel(t *testing.T) {
+	client := NewClient()
+	registry := client.ModelRegistry.(*ModelRegistryClient)
+	registry.Put(&api.ModelVersion{ModelName: "a"})
+	registry.Put(&api.ModelVersion{ModelName: "b"})
+	registry.Put(&api.ModelVersion{ModelName: "a"})
+
+	response, err := registry.ListModelVersions(context.Background(), &api.ListModelVersionsRequest{ModelName: "a"})
+	assert.Nil(t, err)
+	assert.Len(t, response.ModelVersions, 2)
+	assert.Equal(t, "model-version-1", response.ModelVersions[0].Id)
+	assert.Equal(t, "model-version-3", response.ModelVersions[1].Id)
+}
+
+func TestCreateVisualization(t *testing.T) {
+	client := NewClient()
+	visualizations := client.Visualizations.(*VisualizationClient)
+	visualizations.SetVisualization(api.Visualization_TABLE, "minio://mlpipeline/a.csv", "<table></table>")
+
+	visualization, err := visualizations.CreateVisualization(context.Background(), &api.CreateVisualizationRequest{
+		Visualization: &api.Visualization{Type: api.Visualization_TABLE, Source: "minio://mlpipeline/a.csv"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "<table></table>", visualization.Html)
+	_, err = visualizations.CreateVisualization(context.Background(), &api.CreateVisualizationRequest{
+		Visualization: &api.Visualization{Type: api.Visualization_ROC_CURVE, Source: "minio://mlpipeline/a.csv"}})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestAdminClient(t *testing.T) {
+	client := NewClient()
+	admin := client.Admin.(*AdminClient)
+	_, err := admin.GetConsistencyReport(context.Background(), &api.GetConsistencyReportRequest{})
+	assert.True(t, kfp.IsNotFound(err))
+	admin.SetConsistencyIssues(&api.ConsistencyIssue{
+		Type: api.ConsistencyIssue_DANGLING_OBJECT, ResourceId: "pipelines/deleted"})
+
+	report, err := admin.CheckConsistency(context.Background(), &api.CheckConsistencyRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, report.Issues, 1)
+	assert.False(t, report.Issues[0].Repaired)
+	report, err = admin.CheckConsistency(context.Background(), &api.CheckConsistencyRequest{Repair: true})
+	assert.Nil(t, err)
+	assert.True(t, report.Issues[0].Repaired)
+	last, err := admin.GetConsistencyReport(context.Background(), &api.GetConsistencyReportRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, report, last)
+	// The repaired issues aren't found anymore.
+	report, err = admin.CheckConsistency(context.Background(), &api.CheckConsistencyRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, report.Issues)
+}
+
+func TestAdminClient_StorageUsage(t *testing.T) {
+	admin := NewClient().Admin.(*AdminClient)
+	_, err := admin.GetStorageUsage(context.Background(), &api.GetStorageUsageRequest{})
+	assert.True(t, kfp.IsNotFound(err))
+	admin.SetStorageUsages(
+		&api.StorageUsage{Namespace: "ns1", Category: api.StorageUsage_ARTIFACTS, Bytes: 10},
+		&api.StorageUsage{Namespace: "ns2", Category: api.StorageUsage_LOGS, Bytes: 5})
+
+	report, err := admin.ComputeStorageUsage(context.Background(), &api.ComputeStorageUsageRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, report.Usages, 2)
+	assert.Equal(t, int64(15), report.TotalBytes)
+	last, err := admin.GetStorageUsage(context.Background(), &api.GetStorageUsageRequest{Namespace: "ns2"})
+	assert.Nil(t, err)
+	assert.Len(t, last.Usages, 1)
+	assert.Equal(t, int64(5), last.TotalBytes)
+}
+
+func TestAdminClient_ReadOnlyMode(t *testing.T) {
+	admin := NewClient().Admin.(*AdminClient)
+	mode, err := admin.GetReadOnlyMode(context.Background(), &api.GetReadOnlyModeRequest{})
+	assert.Nil(t, err)
+	assert.False(t, mode.ReadOnly)
+	mode, err = admin.SetReadOnlyMode(context.Background(),
+		&api.SetReadOnlyModeRequest{ReadOnly: true, Message: "Backup in progress"})
+	assert.Nil(t, err)
+	assert.True(t, mode.ReadOnly)
+	assert.True(t, admin.IsReadOnly())
+	mode, err = admin.GetReadOnlyMode(context.Background(), &api.GetReadOnlyModeRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Backup in progress", mode.Message)
+	admin.SetError("FlushCaches", status.Error(codes.PermissionDenied, "not an admin"))
+	_, err = admin.FlushCaches(context.Background(), &api.FlushCachesRequest{})
+	assert.True(t, kfp.IsPermissionDenied(err))
+}
+
+func TestAdminClient_DeadLetterReports(t *testing.T) {
+	admin := NewClient().Admin.(*AdminClient)
+	admin.SetDeadLetterReports(
+		&api.DeadLetterReport{RunId: "run1", Workflow: "{}", Error: "bad data"},
+		&api.DeadLetterReport{RunId: "run2", Workflow: "{}", Error: "bad data"},
+		&api.DeadLetterReport{RunId: "run3", Workflow: "{}", Error: "bad data"})
+
+	response, err := admin.ListDeadLetterReports(context.Background(), &api.ListDeadLetterReportsRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, response.Reports, 3)
+	assert.Empty(t, response.Reports[0].Workflow)
+	result, err := admin.ReplayDeadLetterReports(context.Background(),
+		&api.ReplayDeadLetterReportsRequest{RunIds: []string{"run1", "unknown"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), result.Processed)
+	assert.Len(t, result.Errors, 1)
+	_, err = admin.DeleteDeadLetterReport(context.Background(), &api.DeleteDeadLetterReportRequest{RunId: "run2"})
+	assert.Nil(t, err)
+	_, err = admin.DeleteDeadLetterReport(context.Background(), &api.DeleteDeadLetterReportRequest{RunId: "run2"})
+	assert.True(t, kfp.IsNotFound(err))
+	response, err = admin.ListDeadLetterReports(context.Background(),
+		&api.ListDeadLetterReportsRequest{IncludeWorkflows: true})
+	assert.Nil(t, err)
+	assert.Len(t, response.Reports, 1)
+	assert.Equal(t, "{}", response.Reports[0].Workflow)
+}
+
+func TestOperationClient(t *testing.T) {
+	client := NewClient()
+	result, err := client.Admin.ReindexPipelines(context.Background(), &api.ReindexPipelinesRequest{Async: true})
+	assert.Nil(t, err)
+	operation, err := client.Operations.GetOperation(context.Background(),
+		&api.GetOperationRequest{Id: result.OperationId})
+	assert.Nil(t, err)
+	assert.Equal(t, api.Operation_REINDEX_PIPELINES, operation.Type)
+	assert.Equal(t, api.Operation_SUCCEEDED, operation.State)
+
+	operations := client.Operations.(*OperationClient)
+	runningId := operations.AddOperation(&api.Operation{State: api.Operation_RUNNING})
+	operation, err = operations.CancelOperation(context.Background(), &api.CancelOperationRequest{Id: runningId})
+	assert.Nil(t, err)
+	assert.Equal(t, api.Operation_CANCELLED, operation.State)
+	assert.NotNil(t, operation.FinishedAt)
+	// The operations which finished aren't cancelled.
+	operation, err = operations.CancelOperation(context.Background(),
+		&api.CancelOperationRequest{Id: result.OperationId})
+	assert.Nil(t, err)
+	assert.Equal(t, api.Operation_SUCCEEDED, operation.State)
+	response, err := operations.ListOperations(context.Background(), &api.ListOperationsRequest{})
+	assert.Nil(t, err)
+	assert.Len(t, response.Operations, 2)
+	_, err = operations.GetOperation(context.Background(), &api.GetOperationRequest{Id: "unknown"})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestReportClient(t *testing.T) {
+	reports := NewReportClient()
+	_, err := reports.ReportWorkflow(context.Background(), &api.ReportWorkflowRequest{Workflow: "wf-1"})
+	assert.Nil(t, err)
+	reports.SetError("ReportScheduledWorkflow", status.Error(codes.Internal, "bad"))
+	_, err = reports.ReportScheduledWorkflow(context.Background(),
+		&api.ReportScheduledWorkflowRequest{ScheduledWorkflow: "swf-1"})
+	assert.Equal(t, codes.Internal, kfp.Code(err))
+
+	assert.Equal(t, []string{"wf-1"}, reports.Workflows())
+	assert.Empty(t, reports.ScheduledWorkflows())
+}
+
+func TestServerInfoClient(t *testing.T) {
+	serverInfo := NewClient().ServerInfo.(*ServerInfoClient)
+	info, err := serverInfo.GetServerInfo(context.Background(), &api.GetServerInfoRequest{})
+	assert.Nil(t, err)
+	assert.Empty(t, info.Features)
+	serverInfo.SetServerInfo(&api.ServerInfo{Features: []*api.Feature{
+		{Name: "Caching", Enabled: true, Stage: api.Feature_ALPHA},
+	}})
+	info, err = serverInfo.GetServerInfo(context.Background(), &api.GetServerInfoRequest{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Caching", info.Features[0].Name)
+	assert.True(t, info.Features[0].Enabled)
+}
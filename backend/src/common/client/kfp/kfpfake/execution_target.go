@@ -0,0 +1,96 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExecutionTargetClient is an in-memory ExecutionTargetServiceClient. The targets are kept by
+// name, and their Secrets aren't read.
+type ExecutionTargetClient struct {
+	errorInjector
+	store *store
+}
+
+func (c *ExecutionTargetClient) CreateExecutionTarget(ctx context.Context, in *api.CreateExecutionTargetRequest,
+	opts ...grpc.CallOption) (*api.ExecutionTarget, error) {
+	if err := c.injectedError("CreateExecutionTarget"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	name := in.ExecutionTarget.GetName()
+	if _, ok := c.store.executionTargets[name]; ok {
+		return nil, kfp.ConvertError(status.Errorf(codes.AlreadyExists, "Execution target %v already exists", name))
+	}
+	target := proto.Clone(in.ExecutionTarget).(*api.ExecutionTarget)
+	c.store.executionTargets[name] = target
+	return proto.Clone(target).(*api.ExecutionTarget), nil
+}
+
+func (c *ExecutionTargetClient) GetExecutionTarget(ctx context.Context, in *api.GetExecutionTargetRequest,
+	opts ...grpc.CallOption) (*api.ExecutionTarget, error) {
+	if err := c.injectedError("GetExecutionTarget"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	target, ok := c.store.executionTargets[in.Name]
+	if !ok {
+		return nil, notFoundError("ExecutionTarget", in.Name)
+	}
+	return proto.Clone(target).(*api.ExecutionTarget), nil
+}
+
+func (c *ExecutionTargetClient) ListExecutionTargets(ctx context.Context, in *api.ListExecutionTargetsRequest,
+	opts ...grpc.CallOption) (*api.ListExecutionTargetsResponse, error) {
+	if err := c.injectedError("ListExecutionTargets"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	response := &api.ListExecutionTargetsResponse{}
+	for _, target := range c.store.executionTargets {
+		response.ExecutionTargets = append(response.ExecutionTargets, proto.Clone(target).(*api.ExecutionTarget))
+	}
+	sort.Slice(response.ExecutionTargets, func(i, j int) bool {
+		return response.ExecutionTargets[i].Name < response.ExecutionTargets[j].Name
+	})
+	return response, nil
+}
+
+func (c *ExecutionTargetClient) DeleteExecutionTarget(ctx context.Context, in *api.DeleteExecutionTargetRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("DeleteExecutionTarget"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if _, ok := c.store.executionTargets[in.Name]; !ok {
+		return nil, notFoundError("ExecutionTarget", in.Name)
+	}
+	delete(c.store.executionTargets, in.Name)
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ExperimentClient is an in-memory ExperimentServiceClient.
+type ExperimentClient struct {
+	errorInjector
+	store *store
+}
+
+func (c *ExperimentClient) CreateExperiment(ctx context.Context, in *api.CreateExperimentRequest,
+	opts ...grpc.CallOption) (*api.Experiment, error) {
+	if err := c.injectedError("CreateExperiment"); err != nil {
+		return nil, err
+	}
+	experiment := proto.Clone(in.Experiment).(*api.Experiment)
+	experiment.Id = c.store.create("experiment", experiment)
+	return proto.Clone(experiment).(*api.Experiment), nil
+}
+
+func (c *ExperimentClient) GetExperiment(ctx context.Context, in *api.GetExperimentRequest,
+	opts ...grpc.CallOption) (*api.Experiment, error) {
+	if err := c.injectedError("GetExperiment"); err != nil {
+		return nil, err
+	}
+	experiment, err := c.store.get("Experiment", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return experiment.(*api.Experiment), nil
+}
+
+func (c *ExperimentClient) ListExperiment(ctx context.Context, in *api.ListExperimentsRequest,
+	opts ...grpc.CallOption) (*api.ListExperimentsResponse, error) {
+	if err := c.injectedError("ListExperiment"); err != nil {
+		return nil, err
+	}
+	resources, nextPageToken, err := c.store.list(&api.Experiment{}, in.PageSize, in.PageToken, nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListExperimentsResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.Experiments = append(response.Experiments, resource.(*api.Experiment))
+	}
+	return response, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// JobClient is an in-memory JobServiceClient. The jobs create no runs.
+type JobClient struct {
+	errorInjector
+	store *store
+}
+
+func (c *JobClient) CreateJob(ctx context.Context, in *api.CreateJobRequest,
+	opts ...grpc.CallOption) (*api.Job, error) {
+	if err := c.injectedError("CreateJob"); err != nil {
+		return nil, err
+	}
+	job := proto.Clone(in.Job).(*api.Job)
+	job.Id = c.store.create("job", job)
+	return proto.Clone(job).(*api.Job), nil
+}
+
+func (c *JobClient) GetJob(ctx context.Context, in *api.GetJobRequest,
+	opts ...grpc.CallOption) (*api.Job, error) {
+	if err := c.injectedError("GetJob"); err != nil {
+		return nil, err
+	}
+	job, err := c.store.get("Job", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return job.(*api.Job), nil
+}
+
+func (c *JobClient) ListJobs(ctx context.Context, in *api.ListJobsRequest,
+	opts ...grpc.CallOption) (*api.ListJobsResponse, error) {
+	if err := c.injectedError("ListJobs"); err != nil {
+		return nil, err
+	}
+	resources, nextPageToken, err := c.store.list(&api.Job{}, in.PageSize, in.PageToken, nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListJobsResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.Jobs = append(response.Jobs, resource.(*api.Job))
+	}
+	return response, nil
+}
+
+func (c *JobClient) EnableJob(ctx context.Context, in *api.EnableJobRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("EnableJob"); err != nil {
+		return nil, err
+	}
+	return c.setEnabled(in.Id, true)
+}
+
+func (c *JobClient) DisableJob(ctx context.Context, in *api.DisableJobRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("DisableJob"); err != nil {
+		return nil, err
+	}
+	return c.setEnabled(in.Id, false)
+}
+
+func (c *JobClient) setEnabled(id string, enabled bool) (*empty.Empty, error) {
+	if _, err := c.store.get("Job", id); err != nil {
+		return nil, err
+	}
+	c.store.update(id, func(resource proto.Message) {
+		resource.(*api.Job).Enabled = enabled
+	})
+	return &empty.Empty{}, nil
+}
+
+func (c *JobClient) DeleteJob(ctx context.Context, in *api.DeleteJobRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("DeleteJob"); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, c.store.delete("Job", in.Id)
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// LineageClient is an in-memory LineageServiceClient serving the lineage set with
+// SetRunLineage and SetArtifactLineage.
+type LineageClient struct {
+	errorInjector
+	store *store
+}
+
+// SetRunLineage sets the lineage of a run.
+func (c *LineageClient) SetRunLineage(runId string, lineage *api.RunLineage) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.runLineages[runId] = proto.Clone(lineage)
+}
+
+// SetArtifactLineage sets the lineage of the artifact at uri, whatever the depth requested.
+func (c *LineageClient) SetArtifactLineage(uri string, lineage *api.ArtifactLineage) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.artifactLineages[uri] = proto.Clone(lineage)
+}
+
+func (c *LineageClient) GetRunLineage(ctx context.Context, in *api.GetRunLineageRequest,
+	opts ...grpc.CallOption) (*api.RunLineage, error) {
+	if err := c.injectedError("GetRunLineage"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	lineage, ok := c.store.runLineages[in.RunId]
+	if !ok {
+		return nil, notFoundError("Run", in.RunId)
+	}
+	return proto.Clone(lineage).(*api.RunLineage), nil
+}
+
+func (c *LineageClient) GetArtifactLineage(ctx context.Context, in *api.GetArtifactLineageRequest,
+	opts ...grpc.CallOption) (*api.ArtifactLineage, error) {
+	if err := c.injectedError("GetArtifactLineage"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	lineage, ok := c.store.artifactLineages[in.Uri]
+	if !ok {
+		return nil, notFoundError("Artifact", in.Uri)
+	}
+	return proto.Clone(lineage).(*api.ArtifactLineage), nil
+}
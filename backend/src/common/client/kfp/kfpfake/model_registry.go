@@ -0,0 +1,69 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ModelRegistryClient is an in-memory ModelRegistryServiceClient serving the model
+// versions added with Put.
+type ModelRegistryClient struct {
+	errorInjector
+	store *store
+}
+
+// Put adds a model version, as the API server does when a run completes.
+func (c *ModelRegistryClient) Put(modelVersion *api.ModelVersion) *api.ModelVersion {
+	modelVersion = proto.Clone(modelVersion).(*api.ModelVersion)
+	modelVersion.Id = c.store.create("model-version", modelVersion)
+	return proto.Clone(modelVersion).(*api.ModelVersion)
+}
+
+func (c *ModelRegistryClient) GetModelVersion(ctx context.Context, in *api.GetModelVersionRequest,
+	opts ...grpc.CallOption) (*api.ModelVersion, error) {
+	if err := c.injectedError("GetModelVersion"); err != nil {
+		return nil, err
+	}
+	modelVersion, err := c.store.get("ModelVersion", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return modelVersion.(*api.ModelVersion), nil
+}
+
+// ListModelVersions lists the versions of the model of the request, or of all the models
+// if it has none.
+func (c *ModelRegistryClient) ListModelVersions(ctx context.Context, in *api.ListModelVersionsRequest,
+	opts ...grpc.CallOption) (*api.ListModelVersionsResponse, error) {
+	if err := c.injectedError("ListModelVersions"); err != nil {
+		return nil, err
+	}
+	resources, nextPageToken, err := c.store.list(&api.ModelVersion{}, in.PageSize, in.PageToken,
+		func(resource proto.Message) bool {
+			return in.ModelName == "" || resource.(*api.ModelVersion).ModelName == in.ModelName
+		})
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListModelVersionsResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.ModelVersions = append(response.ModelVersions, resource.(*api.ModelVersion))
+	}
+	return response, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// OperationClient is an in-memory OperationServiceClient. The operations started by the
+// AdminClient succeed at once, having no resource to process, and the others are added
+// with AddOperation.
+type OperationClient struct {
+	errorInjector
+	store *store
+}
+
+// AddOperation adds an operation, e.g. a running one, and returns its ID.
+func (c *OperationClient) AddOperation(operation *api.Operation) string {
+	operation = proto.Clone(operation).(*api.Operation)
+	operation.Id = c.store.create("operation", operation)
+	return operation.Id
+}
+
+func (c *OperationClient) GetOperation(ctx context.Context, in *api.GetOperationRequest,
+	opts ...grpc.CallOption) (*api.Operation, error) {
+	if err := c.injectedError("GetOperation"); err != nil {
+		return nil, err
+	}
+	operation, err := c.store.get("Operation", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return operation.(*api.Operation), nil
+}
+
+func (c *OperationClient) ListOperations(ctx context.Context, in *api.ListOperationsRequest,
+	opts ...grpc.CallOption) (*api.ListOperationsResponse, error) {
+	if err := c.injectedError("ListOperations"); err != nil {
+		return nil, err
+	}
+	resources, nextPageToken, err := c.store.list(&api.Operation{}, in.PageSize, in.PageToken, nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListOperationsResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.Operations = append(response.Operations, resource.(*api.Operation))
+	}
+	return response, nil
+}
+
+func (c *OperationClient) CancelOperation(ctx context.Context, in *api.CancelOperationRequest,
+	opts ...grpc.CallOption) (*api.Operation, error) {
+	if err := c.injectedError("CancelOperation"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Operation", in.Id); err != nil {
+		return nil, err
+	}
+	c.store.update(in.Id, func(resource proto.Message) {
+		operation := resource.(*api.Operation)
+		if operation.State == api.Operation_RUNNING {
+			operation.State = api.Operation_CANCELLED
+			operation.FinishedAt = ptypes.TimestampNow()
+		}
+	})
+	return c.GetOperation(ctx, &api.GetOperationRequest{Id: in.Id})
+}
+
+// finishedOperation returns an operation of a type which had no resource to process.
+func finishedOperation(operationType api.Operation_Type) *api.Operation {
+	now := ptypes.TimestampNow()
+	return &api.Operation{Type: operationType, State: api.Operation_SUCCEEDED, CreatedAt: now, FinishedAt: now}
+}
@@ -0,0 +1,294 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// PipelineClient is an in-memory PipelineServiceClient. The pipelines are created without
+// parameters nor template, until SetTemplate is called.
+type PipelineClient struct {
+	errorInjector
+	store *store
+}
+
+func (c *PipelineClient) CreatePipeline(ctx context.Context, in *api.CreatePipelineRequest,
+	opts ...grpc.CallOption) (*api.Pipeline, error) {
+	if err := c.injectedError("CreatePipeline"); err != nil {
+		return nil, err
+	}
+	return c.Put(&api.Pipeline{Name: in.Name}), nil
+}
+
+// Put adds a pipeline, e.g. one uploaded through the HTTP API.
+func (c *PipelineClient) Put(pipeline *api.Pipeline) *api.Pipeline {
+	pipeline = proto.Clone(pipeline).(*api.Pipeline)
+	pipeline.Id = c.store.create("pipeline", pipeline)
+	return proto.Clone(pipeline).(*api.Pipeline)
+}
+
+func (c *PipelineClient) GetPipeline(ctx context.Context, in *api.GetPipelineRequest,
+	opts ...grpc.CallOption) (*api.Pipeline, error) {
+	if err := c.injectedError("GetPipeline"); err != nil {
+		return nil, err
+	}
+	pipeline, err := c.store.get("Pipeline", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.(*api.Pipeline), nil
+}
+
+func (c *PipelineClient) ListPipelines(ctx context.Context, in *api.ListPipelinesRequest,
+	opts ...grpc.CallOption) (*api.ListPipelinesResponse, error) {
+	if err := c.injectedError("ListPipelines"); err != nil {
+		return nil, err
+	}
+	keep := func(resource proto.Message) bool {
+		pipeline := resource.(*api.Pipeline)
+		return (!in.OnlyStarred || c.store.starred[pipeline.Id]) && !(in.ExcludeDeprecated && pipeline.Deprecated)
+	}
+	resources, nextPageToken, err := c.store.list(&api.Pipeline{}, in.PageSize, in.PageToken, keep)
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListPipelinesResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.Pipelines = append(response.Pipelines, resource.(*api.Pipeline))
+	}
+	return response, nil
+}
+
+func (c *PipelineClient) StarPipeline(ctx context.Context, in *api.StarPipelineRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("StarPipeline"); err != nil {
+		return nil, err
+	}
+	if err := c.store.star("Pipeline", in.Id, true); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (c *PipelineClient) UnstarPipeline(ctx context.Context, in *api.UnstarPipelineRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("UnstarPipeline"); err != nil {
+		return nil, err
+	}
+	if err := c.store.star("Pipeline", in.Id, false); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (c *PipelineClient) DeprecatePipeline(ctx context.Context, in *api.DeprecatePipelineRequest,
+	opts ...grpc.CallOption) (*api.Pipeline, error) {
+	if err := c.injectedError("DeprecatePipeline"); err != nil {
+		return nil, err
+	}
+	return c.setDeprecation(in.Id, true, in.ReplacementPipelineId, in.SunsetAt)
+}
+
+func (c *PipelineClient) UndeprecatePipeline(ctx context.Context, in *api.UndeprecatePipelineRequest,
+	opts ...grpc.CallOption) (*api.Pipeline, error) {
+	if err := c.injectedError("UndeprecatePipeline"); err != nil {
+		return nil, err
+	}
+	return c.setDeprecation(in.Id, false, "", nil)
+}
+
+func (c *PipelineClient) setDeprecation(pipelineId string, deprecated bool, replacementPipelineId string,
+	sunsetAt *timestamp.Timestamp) (*api.Pipeline, error) {
+	if _, err := c.store.get("Pipeline", pipelineId); err != nil {
+		return nil, err
+	}
+	c.store.update(pipelineId, func(resource proto.Message) {
+		pipeline := resource.(*api.Pipeline)
+		pipeline.Deprecated = deprecated
+		pipeline.ReplacementPipelineId = replacementPipelineId
+		pipeline.SunsetAt = sunsetAt
+	})
+	pipeline, err := c.store.get("Pipeline", pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.(*api.Pipeline), nil
+}
+
+func (c *PipelineClient) DeletePipeline(ctx context.Context, in *api.DeletePipelineRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("DeletePipeline"); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, c.store.delete("Pipeline", in.Id)
+}
+
+// SetTemplate sets the template of a pipeline. Its parameters aren't updated.
+func (c *PipelineClient) SetTemplate(pipelineId string, template string) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.templates[pipelineId] = template
+}
+
+// SetReadme sets the README of a pipeline, returned as is.
+func (c *PipelineClient) SetReadme(pipelineId string, readme string) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.readmes[pipelineId] = readme
+}
+
+// GetPipelineReadme fails if the README of the pipeline wasn't set.
+func (c *PipelineClient) GetPipelineReadme(ctx context.Context, in *api.GetPipelineReadmeRequest,
+	opts ...grpc.CallOption) (*api.GetPipelineReadmeResponse, error) {
+	if err := c.injectedError("GetPipelineReadme"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Pipeline", in.Id); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	readme, ok := c.store.readmes[in.Id]
+	if !ok {
+		return nil, notFoundError("README of pipeline", in.Id)
+	}
+	return &api.GetPipelineReadmeResponse{Readme: readme}, nil
+}
+
+func (c *PipelineClient) GetTemplate(ctx context.Context, in *api.GetTemplateRequest,
+	opts ...grpc.CallOption) (*api.GetTemplateResponse, error) {
+	if err := c.injectedError("GetTemplate"); err != nil {
+		return nil, err
+	}
+	return c.getTemplate(in.Id)
+}
+
+func (c *PipelineClient) getTemplate(pipelineId string) (*api.GetTemplateResponse, error) {
+	if _, err := c.store.get("Pipeline", pipelineId); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	return &api.GetTemplateResponse{Template: c.store.templates[pipelineId]}, nil
+}
+
+func (c *PipelineClient) ComparePipelines(ctx context.Context, in *api.ComparePipelinesRequest,
+	opts ...grpc.CallOption) (*api.PipelineDiff, error) {
+	if err := c.injectedError("ComparePipelines"); err != nil {
+		return nil, err
+	}
+	base, err := c.getTemplate(in.BaseId)
+	if err != nil {
+		return nil, err
+	}
+	target, err := c.getTemplate(in.TargetId)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := util.DiffTemplates([]byte(base.Template), []byte(target.Template))
+	if err != nil {
+		return nil, kfp.ConvertError(util.ToGRPCError(err))
+	}
+	apiDiff := &api.PipelineDiff{}
+	for _, parameter := range diff.Parameters {
+		apiDiff.Parameters = append(apiDiff.Parameters, &api.PipelineDiff_Parameter{
+			Name:        parameter.Name,
+			Change:      api.PipelineDiff_Change(api.PipelineDiff_Change_value[string(parameter.Change)]),
+			BaseValue:   parameter.BaseValue,
+			TargetValue: parameter.TargetValue,
+		})
+	}
+	for _, step := range diff.Steps {
+		apiDiff.Steps = append(apiDiff.Steps, &api.PipelineDiff_Step{
+			Name:          step.Name,
+			Change:        api.PipelineDiff_Change(api.PipelineDiff_Change_value[string(step.Change)]),
+			BaseImage:     step.BaseImage,
+			TargetImage:   step.TargetImage,
+			ChangedFields: step.ChangedFields,
+		})
+	}
+	return apiDiff, nil
+}
+
+func (c *PipelineClient) GetPipelineSteps(ctx context.Context, in *api.GetPipelineStepsRequest,
+	opts ...grpc.CallOption) (*api.GetPipelineStepsResponse, error) {
+	if err := c.injectedError("GetPipelineSteps"); err != nil {
+		return nil, err
+	}
+	template, err := c.getTemplate(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := util.CompilePipeline([]byte(template.Template))
+	if err != nil {
+		return nil, kfp.ConvertError(util.ToGRPCError(err))
+	}
+	response := &api.GetPipelineStepsResponse{}
+	for _, step := range compiled.Steps {
+		response.Steps = append(response.Steps, &api.PipelineStep{
+			Name:     step.Name,
+			Type:     step.Type,
+			Image:    step.Image,
+			Children: step.Children,
+		})
+	}
+	return response, nil
+}
+
+// ValidatePipeline finds no violation, since the fake has no policies. It fails if the
+// pipeline to validate doesn't exist.
+func (c *PipelineClient) ValidatePipeline(ctx context.Context, in *api.ValidatePipelineRequest,
+	opts ...grpc.CallOption) (*api.ValidatePipelineResponse, error) {
+	if err := c.injectedError("ValidatePipeline"); err != nil {
+		return nil, err
+	}
+	if in.PipelineId != "" {
+		if _, err := c.store.get("Pipeline", in.PipelineId); err != nil {
+			return nil, err
+		}
+	}
+	return &api.ValidatePipelineResponse{Valid: true}, nil
+}
+
+// SetStats sets the stats of a pipeline, which has no run otherwise.
+func (c *PipelineClient) SetStats(stats *api.PipelineStats) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.pipelineStats[stats.PipelineId] = stats
+}
+
+func (c *PipelineClient) GetPipelineStats(ctx context.Context, in *api.GetPipelineStatsRequest,
+	opts ...grpc.CallOption) (*api.PipelineStats, error) {
+	if err := c.injectedError("GetPipelineStats"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Pipeline", in.Id); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if stats, ok := c.store.pipelineStats[in.Id]; ok {
+		return proto.Clone(stats).(*api.PipelineStats), nil
+	}
+	return &api.PipelineStats{PipelineId: in.Id}, nil
+}
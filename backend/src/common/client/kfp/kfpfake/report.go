@@ -0,0 +1,94 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ReportClient is an in-memory ReportServiceClient, recording the reported workflows and
+// scheduled workflows. It isn't part of a Client, since only the persistence agent
+// reports.
+type ReportClient struct {
+	errorInjector
+
+	mutex              sync.Mutex
+	workflows          []string
+	scheduledWorkflows []string
+}
+
+// NewReportClient creates a ReportClient with nothing reported.
+func NewReportClient() *ReportClient {
+	return &ReportClient{}
+}
+
+func (c *ReportClient) ReportWorkflow(ctx context.Context, in *api.ReportWorkflowRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("ReportWorkflow"); err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.workflows = append(c.workflows, in.Workflow)
+	return &empty.Empty{}, nil
+}
+
+func (c *ReportClient) ReportWorkflows(ctx context.Context, in *api.ReportWorkflowsRequest,
+	opts ...grpc.CallOption) (*api.ReportWorkflowsResponse, error) {
+	if err := c.injectedError("ReportWorkflows"); err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &api.ReportWorkflowsResponse{}
+	for _, workflow := range in.Workflows {
+		c.workflows = append(c.workflows, workflow)
+		response.Results = append(response.Results, &api.ReportWorkflowsResponse_ReportWorkflowResult{
+			Status: api.ReportWorkflowsResponse_ReportWorkflowResult_OK,
+		})
+	}
+	return response, nil
+}
+
+func (c *ReportClient) ReportScheduledWorkflow(ctx context.Context, in *api.ReportScheduledWorkflowRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("ReportScheduledWorkflow"); err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.scheduledWorkflows = append(c.scheduledWorkflows, in.ScheduledWorkflow)
+	return &empty.Empty{}, nil
+}
+
+// Workflows returns the reported workflows, in the order they were reported.
+func (c *ReportClient) Workflows() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]string(nil), c.workflows...)
+}
+
+// ScheduledWorkflows returns the reported scheduled workflows, in the order they were
+// reported.
+func (c *ReportClient) ScheduledWorkflows() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]string(nil), c.scheduledWorkflows...)
+}
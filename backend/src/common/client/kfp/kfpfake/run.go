@@ -0,0 +1,587 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RunClient is an in-memory RunServiceClient. The runs stay in the status they are
+// created with until SetStatus is called, and have no artifacts nor outputs until SetArtifact
+// and SetOutputs are, nor approval gates until SetApprovalGates is, nor history until
+// SetHistory is. Rejecting a gate sets the status of its run to Failed. The runs are estimated with the estimate set with SetEstimate. The sweeps
+// are stored as they are created, without trials nor runs. The run groups keep their runs,
+// without aggregating their status, and cancelling them leaves the runs as they are.
+// TerminateAll terminates the runs right away, setting their status to Failed, and returns
+// a finished operation.
+type RunClient struct {
+	errorInjector
+	store *store
+}
+
+func (c *RunClient) CreateRun(ctx context.Context, in *api.CreateRunRequest,
+	opts ...grpc.CallOption) (*api.RunDetail, error) {
+	if err := c.injectedError("CreateRun"); err != nil {
+		return nil, err
+	}
+	run := proto.Clone(in.Run).(*api.Run)
+	run.Id = c.store.create("run", run)
+	return &api.RunDetail{Run: proto.Clone(run).(*api.Run), PipelineRuntime: &api.PipelineRuntime{}}, nil
+}
+
+func (c *RunClient) GetRun(ctx context.Context, in *api.GetRunRequest,
+	opts ...grpc.CallOption) (*api.RunDetail, error) {
+	if err := c.injectedError("GetRun"); err != nil {
+		return nil, err
+	}
+	run, err := c.store.get("Run", in.RunId)
+	if err != nil {
+		return nil, err
+	}
+	detail := &api.RunDetail{Run: run.(*api.Run), PipelineRuntime: &api.PipelineRuntime{}}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	for _, gate := range c.store.approvalGates[in.RunId] {
+		detail.ApprovalGates = append(detail.ApprovalGates, proto.Clone(gate).(*api.ApprovalGate))
+	}
+	return detail, nil
+}
+
+// GetRunManifest returns the workflow manifest and the parameters of the run as submitted. The
+// runtime manifest is empty, since the fake runs no workflow.
+func (c *RunClient) GetRunManifest(ctx context.Context, in *api.GetRunManifestRequest,
+	opts ...grpc.CallOption) (*api.RunManifest, error) {
+	if err := c.injectedError("GetRunManifest"); err != nil {
+		return nil, err
+	}
+	resource, err := c.store.get("Run", in.RunId)
+	if err != nil {
+		return nil, err
+	}
+	run := resource.(*api.Run)
+	return &api.RunManifest{
+		RunId:              run.Id,
+		SubmittedManifest:  run.GetPipelineSpec().GetWorkflowManifest(),
+		ResolvedParameters: run.GetPipelineSpec().GetParameters(),
+	}, nil
+}
+
+func (c *RunClient) ListRuns(ctx context.Context, in *api.ListRunsRequest,
+	opts ...grpc.CallOption) (*api.ListRunsResponse, error) {
+	if err := c.injectedError("ListRuns"); err != nil {
+		return nil, err
+	}
+	name, value, hasValue := in.AnnotationFilter, "", false
+	if i := strings.Index(name, "="); i >= 0 {
+		name, value, hasValue = name[:i], name[i+1:], true
+	}
+	keep := func(resource proto.Message) bool {
+		run := resource.(*api.Run)
+		if in.OnlyStarred && !c.store.starred[run.Id] {
+			return false
+		}
+		if name == "" {
+			return true
+		}
+		annotation, ok := run.Annotations[name]
+		return ok && (!hasValue || annotation == value)
+	}
+	resources, nextPageToken, err := c.store.list(&api.Run{}, in.PageSize, in.PageToken, keep)
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListRunsResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.Runs = append(response.Runs, resource.(*api.Run))
+	}
+	return response, nil
+}
+
+// UpdateRun sets the note and the annotations of the run. As with the API server, the
+// annotations set to an empty value are removed.
+func (c *RunClient) UpdateRun(ctx context.Context, in *api.UpdateRunRequest,
+	opts ...grpc.CallOption) (*api.Run, error) {
+	if err := c.injectedError("UpdateRun"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Run", in.RunId); err != nil {
+		return nil, err
+	}
+	c.store.update(in.RunId, func(resource proto.Message) {
+		run := resource.(*api.Run)
+		if in.UpdateNote {
+			run.Note = in.Note
+		}
+		for name, value := range in.Annotations {
+			if value == "" {
+				delete(run.Annotations, name)
+				continue
+			}
+			if run.Annotations == nil {
+				run.Annotations = map[string]string{}
+			}
+			run.Annotations[name] = value
+		}
+	})
+	run, err := c.store.get("Run", in.RunId)
+	if err != nil {
+		return nil, err
+	}
+	return run.(*api.Run), nil
+}
+
+func (c *RunClient) StarRun(ctx context.Context, in *api.StarRunRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("StarRun"); err != nil {
+		return nil, err
+	}
+	if err := c.store.star("Run", in.RunId, true); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (c *RunClient) UnstarRun(ctx context.Context, in *api.UnstarRunRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("UnstarRun"); err != nil {
+		return nil, err
+	}
+	if err := c.store.star("Run", in.RunId, false); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// ReportRunMetrics adds the metrics to the run. As with the API server, a metric already
+// reported by a node is a duplicate.
+func (c *RunClient) ReportRunMetrics(ctx context.Context, in *api.ReportRunMetricsRequest,
+	opts ...grpc.CallOption) (*api.ReportRunMetricsResponse, error) {
+	if err := c.injectedError("ReportRunMetrics"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Run", in.RunId); err != nil {
+		return nil, err
+	}
+	response := &api.ReportRunMetricsResponse{}
+	c.store.update(in.RunId, func(resource proto.Message) {
+		run := resource.(*api.Run)
+		for _, metric := range in.Metrics {
+			result := &api.ReportRunMetricsResponse_ReportRunMetricResult{
+				MetricName:   metric.Name,
+				MetricNodeId: metric.NodeId,
+				Status:       api.ReportRunMetricsResponse_ReportRunMetricResult_OK,
+			}
+			for _, reported := range run.Metrics {
+				if reported.Name == metric.Name && reported.NodeId == metric.NodeId {
+					result.Status = api.ReportRunMetricsResponse_ReportRunMetricResult_DUPLICATE_REPORTING
+				}
+			}
+			if result.Status == api.ReportRunMetricsResponse_ReportRunMetricResult_OK {
+				run.Metrics = append(run.Metrics, proto.Clone(metric).(*api.RunMetric))
+			}
+			response.Results = append(response.Results, result)
+		}
+	})
+	return response, nil
+}
+
+// SetArtifact sets the content of an output artifact of a node of a run.
+func (c *RunClient) SetArtifact(runId string, nodeId string, artifactName string, data []byte) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.artifacts[strings.Join([]string{runId, nodeId, artifactName}, "/")] = data
+}
+
+func (c *RunClient) ReadArtifact(ctx context.Context, in *api.ReadArtifactRequest,
+	opts ...grpc.CallOption) (*api.ReadArtifactResponse, error) {
+	if err := c.injectedError("ReadArtifact"); err != nil {
+		return nil, err
+	}
+	path := strings.Join([]string{in.RunId, in.NodeId, in.ArtifactName}, "/")
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	data, ok := c.store.artifacts[path]
+	if !ok {
+		return nil, notFoundError("Artifact", path)
+	}
+	return &api.ReadArtifactResponse{Data: data}, nil
+}
+
+// SetOutputs sets the outputs of a run.
+func (c *RunClient) SetOutputs(runId string, outputs ...*api.RunOutput) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.outputs[runId] = outputs
+}
+
+func (c *RunClient) GetRunOutputs(ctx context.Context, in *api.GetRunOutputsRequest,
+	opts ...grpc.CallOption) (*api.GetRunOutputsResponse, error) {
+	if err := c.injectedError("GetRunOutputs"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Run", in.RunId); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	response := &api.GetRunOutputsResponse{}
+	for _, output := range c.store.outputs[in.RunId] {
+		response.Outputs = append(response.Outputs, proto.Clone(output).(*api.RunOutput))
+	}
+	return response, nil
+}
+
+// SetHistory sets the state transitions of a run, in chronological order.
+func (c *RunClient) SetHistory(runId string, transitions ...*api.RunStateTransition) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.histories[runId] = transitions
+}
+
+func (c *RunClient) GetRunHistory(ctx context.Context, in *api.GetRunHistoryRequest,
+	opts ...grpc.CallOption) (*api.GetRunHistoryResponse, error) {
+	if err := c.injectedError("GetRunHistory"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Run", in.RunId); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	response := &api.GetRunHistoryResponse{}
+	for _, transition := range c.store.histories[in.RunId] {
+		if in.NodeId != "" && transition.NodeId != in.NodeId {
+			continue
+		}
+		if asOf := in.GetAsOf().GetSeconds(); asOf != 0 && transition.GetTime().GetSeconds() > asOf {
+			continue
+		}
+		response.Transitions = append(response.Transitions, proto.Clone(transition).(*api.RunStateTransition))
+	}
+	return response, nil
+}
+
+// ReadRunLogs returns the logs reported for the node, which are archived.
+func (c *RunClient) ReadRunLogs(ctx context.Context, in *api.ReadRunLogsRequest,
+	opts ...grpc.CallOption) (*api.ReadRunLogsResponse, error) {
+	if err := c.injectedError("ReadRunLogs"); err != nil {
+		return nil, err
+	}
+	path := in.RunId + "/" + in.NodeId
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	logs, ok := c.store.logs[path]
+	if !ok {
+		return nil, notFoundError("Logs", path)
+	}
+	return &api.ReadRunLogsResponse{Logs: logs, Archived: true}, nil
+}
+
+func (c *RunClient) ReportRunLogs(ctx context.Context, in *api.ReportRunLogsRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("ReportRunLogs"); err != nil {
+		return nil, err
+	}
+	if _, err := c.store.get("Run", in.RunId); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.logs[in.RunId+"/"+in.NodeId] = in.Logs
+	return &empty.Empty{}, nil
+}
+
+// WatchRun fails as unimplemented, as with the API servers that don't support watching
+// runs, so that the runs are polled.
+func (c *RunClient) WatchRun(ctx context.Context, in *api.WatchRunRequest,
+	opts ...grpc.CallOption) (api.RunService_WatchRunClient, error) {
+	if err := c.injectedError("WatchRun"); err != nil {
+		return nil, err
+	}
+	return nil, kfp.ConvertError(status.Error(codes.Unimplemented, "WatchRun is not implemented"))
+}
+
+// SetEstimate sets the estimate returned for the runs.
+func (c *RunClient) SetEstimate(estimate *api.RunEstimate) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.estimate = estimate
+}
+
+func (c *RunClient) EstimateRun(ctx context.Context, in *api.EstimateRunRequest,
+	opts ...grpc.CallOption) (*api.RunEstimate, error) {
+	if err := c.injectedError("EstimateRun"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if c.store.estimate == nil {
+		return &api.RunEstimate{}, nil
+	}
+	return proto.Clone(c.store.estimate).(*api.RunEstimate), nil
+}
+
+// SetApprovalGates sets the approval gates of a run, as the persistence agent reports them.
+func (c *RunClient) SetApprovalGates(runId string, gates ...*api.ApprovalGate) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.approvalGates[runId] = gates
+}
+
+// ApproveRun approves the waiting gates of the run, all of them or the one of the node ID.
+// The message of the gates only has the comment, since the fake has no users.
+func (c *RunClient) ApproveRun(ctx context.Context, in *api.ApproveRunRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("ApproveRun"); err != nil {
+		return nil, err
+	}
+	if err := c.resolveApprovalGates(in.RunId, in.NodeId, api.ApprovalGate_APPROVED, "Approved", in.Comment); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// RejectRun rejects the waiting gates of the run, all of them or the one of the node ID,
+// and sets the status of the run to Failed.
+func (c *RunClient) RejectRun(ctx context.Context, in *api.RejectRunRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("RejectRun"); err != nil {
+		return nil, err
+	}
+	if err := c.resolveApprovalGates(in.RunId, in.NodeId, api.ApprovalGate_REJECTED, "Rejected", in.Reason); err != nil {
+		return nil, err
+	}
+	c.SetStatus(in.RunId, "Failed")
+	return &empty.Empty{}, nil
+}
+
+func (c *RunClient) resolveApprovalGates(runId string, nodeId string, state api.ApprovalGate_State,
+	decision string, comment string) error {
+	if _, err := c.store.get("Run", runId); err != nil {
+		return err
+	}
+	message := decision
+	if comment != "" {
+		message += ": " + comment
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	resolved := false
+	for _, gate := range c.store.approvalGates[runId] {
+		if gate.State != api.ApprovalGate_WAITING || (nodeId != "" && gate.NodeId != nodeId) {
+			continue
+		}
+		gate.State = state
+		gate.Message = message
+		resolved = true
+	}
+	if !resolved {
+		return kfp.ConvertError(status.Errorf(codes.InvalidArgument,
+			"Run %v has no approval gate waiting for a decision", runId))
+	}
+	return nil
+}
+
+// SetStatus changes the status of a run, as the persistence agent does.
+func (c *RunClient) SetStatus(runId string, status string) {
+	c.store.update(runId, func(resource proto.Message) {
+		resource.(*api.Run).Status = status
+	})
+}
+
+func (c *RunClient) CreateRunSweep(ctx context.Context, in *api.CreateRunSweepRequest,
+	opts ...grpc.CallOption) (*api.RunSweep, error) {
+	if err := c.injectedError("CreateRunSweep"); err != nil {
+		return nil, err
+	}
+	sweep := proto.Clone(in.Sweep).(*api.RunSweep)
+	sweep.Id = c.store.create("sweep", sweep)
+	return proto.Clone(sweep).(*api.RunSweep), nil
+}
+
+func (c *RunClient) GetRunSweep(ctx context.Context, in *api.GetRunSweepRequest,
+	opts ...grpc.CallOption) (*api.RunSweep, error) {
+	if err := c.injectedError("GetRunSweep"); err != nil {
+		return nil, err
+	}
+	sweep, err := c.store.get("Sweep", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return sweep.(*api.RunSweep), nil
+}
+
+func (c *RunClient) CreateRunGroup(ctx context.Context, in *api.CreateRunGroupRequest,
+	opts ...grpc.CallOption) (*api.RunGroup, error) {
+	if err := c.injectedError("CreateRunGroup"); err != nil {
+		return nil, err
+	}
+	for _, runId := range in.Group.RunIds {
+		if _, err := c.store.get("Run", runId); err != nil {
+			return nil, err
+		}
+	}
+	group := proto.Clone(in.Group).(*api.RunGroup)
+	group.Id = c.store.create("group", group)
+	return proto.Clone(group).(*api.RunGroup), nil
+}
+
+func (c *RunClient) GetRunGroup(ctx context.Context, in *api.GetRunGroupRequest,
+	opts ...grpc.CallOption) (*api.RunGroup, error) {
+	if err := c.injectedError("GetRunGroup"); err != nil {
+		return nil, err
+	}
+	group, err := c.store.get("Run group", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return group.(*api.RunGroup), nil
+}
+
+func (c *RunClient) ListRunGroups(ctx context.Context, in *api.ListRunGroupsRequest,
+	opts ...grpc.CallOption) (*api.ListRunGroupsResponse, error) {
+	if err := c.injectedError("ListRunGroups"); err != nil {
+		return nil, err
+	}
+	resources, nextPageToken, err := c.store.list(&api.RunGroup{}, in.PageSize, in.PageToken, nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListRunGroupsResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.Groups = append(response.Groups, resource.(*api.RunGroup))
+	}
+	return response, nil
+}
+
+func (c *RunClient) AddRunsToRunGroup(ctx context.Context, in *api.AddRunsToRunGroupRequest,
+	opts ...grpc.CallOption) (*api.RunGroup, error) {
+	if err := c.injectedError("AddRunsToRunGroup"); err != nil {
+		return nil, err
+	}
+	group, err := c.GetRunGroup(ctx, &api.GetRunGroupRequest{Id: in.Id})
+	if err != nil {
+		return nil, err
+	}
+	if group.Status == "Cancelled" {
+		return nil, kfp.ConvertError(status.Errorf(codes.FailedPrecondition, "Run group %v is cancelled", in.Id))
+	}
+	for _, runId := range in.RunIds {
+		if _, err := c.store.get("Run", runId); err != nil {
+			return nil, err
+		}
+	}
+	c.store.update(in.Id, func(resource proto.Message) {
+		group := resource.(*api.RunGroup)
+		for _, runId := range in.RunIds {
+			if !containsString(group.RunIds, runId) {
+				group.RunIds = append(group.RunIds, runId)
+			}
+		}
+	})
+	return c.GetRunGroup(ctx, &api.GetRunGroupRequest{Id: in.Id})
+}
+
+func (c *RunClient) CancelRunGroup(ctx context.Context, in *api.CancelRunGroupRequest,
+	opts ...grpc.CallOption) (*api.RunGroup, error) {
+	if err := c.injectedError("CancelRunGroup"); err != nil {
+		return nil, err
+	}
+	if _, err := c.GetRunGroup(ctx, &api.GetRunGroupRequest{Id: in.Id}); err != nil {
+		return nil, err
+	}
+	c.store.update(in.Id, func(resource proto.Message) {
+		resource.(*api.RunGroup).Status = "Cancelled"
+	})
+	return c.GetRunGroup(ctx, &api.GetRunGroupRequest{Id: in.Id})
+}
+
+func (c *RunClient) TerminateAll(ctx context.Context, in *api.TerminateAllRequest,
+	opts ...grpc.CallOption) (*api.TerminateAllOperation, error) {
+	if err := c.injectedError("TerminateAll"); err != nil {
+		return nil, err
+	}
+	if (in.ExperimentId == "") == (in.RunGroupId == "") {
+		return nil, kfp.ConvertError(status.Errorf(codes.InvalidArgument,
+			"Exactly one of the experiment ID and the run group ID must be specified"))
+	}
+	inScope := func(run *api.Run) bool {
+		for _, reference := range run.ResourceReferences {
+			if reference.Key.GetType() == api.ResourceType_EXPERIMENT && reference.Key.GetId() == in.ExperimentId {
+				return true
+			}
+		}
+		return false
+	}
+	if in.ExperimentId != "" {
+		if _, err := c.store.get("Experiment", in.ExperimentId); err != nil {
+			return nil, err
+		}
+	} else {
+		group, err := c.CancelRunGroup(ctx, &api.CancelRunGroupRequest{Id: in.RunGroupId})
+		if err != nil {
+			return nil, err
+		}
+		inScope = func(run *api.Run) bool {
+			return containsString(group.RunIds, run.Id)
+		}
+	}
+	resources, _, err := c.store.list(&api.Run{}, 0, "", func(resource proto.Message) bool {
+		run := resource.(*api.Run)
+		return inScope(run) && !kfp.IsRunFinished(run)
+	})
+	if err != nil {
+		return nil, err
+	}
+	operation := &api.TerminateAllOperation{ExperimentId: in.ExperimentId, RunGroupId: in.RunGroupId}
+	for _, resource := range resources {
+		runId := resource.(*api.Run).Id
+		c.SetStatus(runId, "Failed")
+		operation.Results = append(operation.Results,
+			&api.TerminateResult{RunId: runId, Status: api.TerminateResult_TERMINATED})
+	}
+	operation.Id = c.store.create("termination", operation)
+	return proto.Clone(operation).(*api.TerminateAllOperation), nil
+}
+
+func (c *RunClient) GetTerminateAllOperation(ctx context.Context, in *api.GetTerminateAllOperationRequest,
+	opts ...grpc.CallOption) (*api.TerminateAllOperation, error) {
+	if err := c.injectedError("GetTerminateAllOperation"); err != nil {
+		return nil, err
+	}
+	operation, err := c.store.get("Terminate operation", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return operation.(*api.TerminateAllOperation), nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
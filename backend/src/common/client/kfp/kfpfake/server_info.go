@@ -0,0 +1,49 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// ServerInfoClient is an in-memory ServerInfoServiceClient describing a server with no
+// feature, unless set with SetServerInfo.
+type ServerInfoClient struct {
+	errorInjector
+	store *store
+}
+
+// SetServerInfo sets the info returned by GetServerInfo, e.g. with the features enabled.
+func (c *ServerInfoClient) SetServerInfo(info *api.ServerInfo) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.serverInfo = proto.Clone(info).(*api.ServerInfo)
+}
+
+func (c *ServerInfoClient) GetServerInfo(ctx context.Context, in *api.GetServerInfoRequest,
+	opts ...grpc.CallOption) (*api.ServerInfo, error) {
+	if err := c.injectedError("GetServerInfo"); err != nil {
+		return nil, err
+	}
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	if c.store.serverInfo == nil {
+		return &api.ServerInfo{}, nil
+	}
+	return proto.Clone(c.store.serverInfo).(*api.ServerInfo), nil
+}
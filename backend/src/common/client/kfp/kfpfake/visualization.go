@@ -0,0 +1,63 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"fmt"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// VisualizationClient is an in-memory VisualizationServiceClient serving the HTML set with
+// SetVisualization.
+type VisualizationClient struct {
+	errorInjector
+	store *store
+}
+
+func visualizationKey(visualizationType api.Visualization_Type, source string) string {
+	return fmt.Sprintf("%v/%v", visualizationType, source)
+}
+
+// SetVisualization sets the HTML of the visualization of a type of the artifact at source,
+// whatever the arguments.
+func (c *VisualizationClient) SetVisualization(visualizationType api.Visualization_Type, source string,
+	html string) {
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	c.store.visualizations[visualizationKey(visualizationType, source)] = html
+}
+
+func (c *VisualizationClient) CreateVisualization(ctx context.Context, in *api.CreateVisualizationRequest,
+	opts ...grpc.CallOption) (*api.Visualization, error) {
+	if err := c.injectedError("CreateVisualization"); err != nil {
+		return nil, err
+	}
+	visualization := in.GetVisualization()
+	c.store.mutex.Lock()
+	defer c.store.mutex.Unlock()
+	html, ok := c.store.visualizations[visualizationKey(visualization.GetType(), visualization.GetSource())]
+	if !ok {
+		return nil, notFoundError("Artifact", visualization.GetSource())
+	}
+	return &api.Visualization{
+		Type:      visualization.GetType(),
+		Source:    visualization.GetSource(),
+		Arguments: visualization.GetArguments(),
+		Html:      html,
+	}, nil
+}
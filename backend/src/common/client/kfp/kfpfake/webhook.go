@@ -0,0 +1,75 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfpfake
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// WebhookClient is an in-memory WebhookServiceClient. The webhooks are never called.
+type WebhookClient struct {
+	errorInjector
+	store *store
+}
+
+func (c *WebhookClient) CreateWebhook(ctx context.Context, in *api.CreateWebhookRequest,
+	opts ...grpc.CallOption) (*api.Webhook, error) {
+	if err := c.injectedError("CreateWebhook"); err != nil {
+		return nil, err
+	}
+	webhook := proto.Clone(in.Webhook).(*api.Webhook)
+	webhook.Id = c.store.create("webhook", webhook)
+	return proto.Clone(webhook).(*api.Webhook), nil
+}
+
+func (c *WebhookClient) GetWebhook(ctx context.Context, in *api.GetWebhookRequest,
+	opts ...grpc.CallOption) (*api.Webhook, error) {
+	if err := c.injectedError("GetWebhook"); err != nil {
+		return nil, err
+	}
+	webhook, err := c.store.get("Webhook", in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return webhook.(*api.Webhook), nil
+}
+
+func (c *WebhookClient) ListWebhooks(ctx context.Context, in *api.ListWebhooksRequest,
+	opts ...grpc.CallOption) (*api.ListWebhooksResponse, error) {
+	if err := c.injectedError("ListWebhooks"); err != nil {
+		return nil, err
+	}
+	resources, nextPageToken, err := c.store.list(&api.Webhook{}, in.PageSize, in.PageToken, nil)
+	if err != nil {
+		return nil, err
+	}
+	response := &api.ListWebhooksResponse{NextPageToken: nextPageToken}
+	for _, resource := range resources {
+		response.Webhooks = append(response.Webhooks, resource.(*api.Webhook))
+	}
+	return response, nil
+}
+
+func (c *WebhookClient) DeleteWebhook(ctx context.Context, in *api.DeleteWebhookRequest,
+	opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.injectedError("DeleteWebhook"); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, c.store.delete("Webhook", in.Id)
+}
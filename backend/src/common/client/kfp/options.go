@@ -0,0 +1,160 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// RetryPolicy is the exponential backoff of the calls that fail because the API
+// server is unavailable.
+type RetryPolicy struct {
+	// The number of retries after the first attempt. Zero disables the retries.
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy retries for about 10 seconds.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 5, InitialBackoff: 500 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	insecure    bool
+	tlsConfig   *tls.Config
+	tokenSource oauth2.TokenSource
+	retryPolicy RetryPolicy
+	timeout     time.Duration
+	dialOptions []grpc.DialOption
+	recorder    *Recorder
+}
+
+func defaultOptions() *options {
+	return &options{retryPolicy: DefaultRetryPolicy}
+}
+
+// WithInsecure disables the transport security, e.g. to connect to the API server
+// from inside the cluster.
+func WithInsecure() Option {
+	return func(o *options) {
+		o.insecure = true
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the connection. By default, the
+// certificate of the API server is verified against the roots of the host.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+// WithBearerToken authenticates the calls with a static bearer token.
+func WithBearerToken(token string) Option {
+	return WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+}
+
+// WithTokenSource authenticates the calls with the OAuth2 tokens of source, e.g.
+// the identity tokens of an Identity-Aware Proxy.
+func WithTokenSource(source oauth2.TokenSource) Option {
+	return func(o *options) {
+		o.tokenSource = source
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithTimeout sets the deadline of the calls whose context has none, including
+// their retries.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithMessageOptions sets the maximum size of the messages the client receives and sends, e.g.
+// to get pipelines larger than the default 4MB, and whether it compresses its calls, and so the
+// responses of the API server, with gzip.
+func WithMessageOptions(maxRecvMsgSize int, maxSendMsgSize int, compress bool) Option {
+	return WithDialOptions(util.GrpcCallOptions(maxRecvMsgSize, maxSendMsgSize, compress))
+}
+
+// WithDialOptions adds options to the gRPC connection.
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
+
+// DialOptions returns the gRPC options of a connection configured with opts, for
+// the callers that dial the connection themselves.
+func DialOptions(opts ...Option) []grpc.DialOption {
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options.grpcDialOptions()
+}
+
+func (o *options) grpcDialOptions() []grpc.DialOption {
+	var dialOptions []grpc.DialOption
+	if o.insecure {
+		dialOptions = append(dialOptions, grpc.WithInsecure())
+	} else {
+		tlsConfig := o.tlsConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	if o.tokenSource != nil {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(
+			&tokenCredentials{source: o.tokenSource, requireTransportSecurity: !o.insecure}))
+	}
+	dialOptions = append(dialOptions, grpc.WithUnaryInterceptor(newUnaryInterceptor(o.retryPolicy, o.timeout, o.recorder)))
+	return append(dialOptions, o.dialOptions...)
+}
+
+// tokenCredentials sends the tokens of an OAuth2 token source as bearer tokens.
+type tokenCredentials struct {
+	source                   oauth2.TokenSource
+	requireTransportSecurity bool
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := c.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": token.Type() + " " + token.AccessToken}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTransportSecurity
+}
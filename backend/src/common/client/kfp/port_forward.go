@@ -0,0 +1,262 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const portForwardProtocol = "portforward.k8s.io"
+
+// PortForwardDialer connects to the ports of Kubernetes services from outside the
+// cluster, as `kubectl port-forward` does. Each connection is forwarded to a running
+// pod of the service through the Kubernetes API server, with the credentials of a
+// kubeconfig, so no local port is opened.
+type PortForwardDialer struct {
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	namespace string
+}
+
+// NewPortForwardDialer creates a dialer authenticated with the credentials of the
+// current context of clientConfig.
+func NewPortForwardDialer(clientConfig clientcmd.ClientConfig) (*PortForwardDialer, error) {
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load the kubeconfig")
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get the namespace of the kubeconfig")
+	}
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the Kubernetes client")
+	}
+	return &PortForwardDialer{config: config, clientSet: clientSet, namespace: namespace}, nil
+}
+
+// WithPortForward connects to the API server through dialer, e.g. with the endpoint
+// "ml-pipeline.kubeflow:8887". The connection is tunneled through the Kubernetes API
+// server, so it doesn't use TLS itself.
+func WithPortForward(dialer *PortForwardDialer) Option {
+	return func(o *options) {
+		o.insecure = true
+		o.dialOptions = append(o.dialOptions, grpc.WithDialer(
+			func(address string, timeout time.Duration) (net.Conn, error) {
+				return dialer.Dial(address)
+			}))
+	}
+}
+
+// DialContext is Dial with the signature of net.Dialer.DialContext, e.g. to use the
+// dialer in an http.Transport. network must be "tcp".
+func (d *PortForwardDialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	if network != "tcp" {
+		return nil, fmt.Errorf("Unsupported network %q", network)
+	}
+	return d.Dial(address)
+}
+
+// Dial connects to address, in the format "<service>.<namespace>:<port>", or
+// "<service>:<port>" for a service in the namespace of the kubeconfig.
+func (d *PortForwardDialer) Dial(address string) (net.Conn, error) {
+	namespace, serviceName, port, err := parseServiceAddress(address, d.namespace)
+	if err != nil {
+		return nil, err
+	}
+	service, err := d.clientSet.CoreV1().Services(namespace).Get(serviceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get the service %v/%v", namespace, serviceName)
+	}
+	pods, err := d.clientSet.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String()})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list the pods of the service %v/%v", namespace, serviceName)
+	}
+	podName, podPort, err := selectPodPort(service, pods.Items, port)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(d.config)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the port forward transport")
+	}
+	url := d.clientSet.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward").URL()
+	connection, _, err := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url).
+		Dial(portForwardProtocol)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to forward a port of the pod %v/%v", namespace, podName)
+	}
+	conn, err := newPortForwardConn(connection, podPort, address)
+	if err != nil {
+		connection.Close()
+		return nil, errors.Wrapf(err, "Failed to forward the port %v of the pod %v/%v", podPort, namespace, podName)
+	}
+	return conn, nil
+}
+
+func parseServiceAddress(address string, defaultNamespace string) (
+	namespace string, service string, port int, err error) {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", "", 0, errors.Wrapf(err, "Invalid service address %q", address)
+	}
+	port, err = strconv.Atoi(portString)
+	if err != nil {
+		return "", "", 0, errors.Wrapf(err, "Invalid port in the service address %q", address)
+	}
+	service, namespace = host, defaultNamespace
+	if parts := strings.SplitN(host, ".", 2); len(parts) == 2 {
+		service, namespace = parts[0], parts[1]
+	}
+	if service == "" || namespace == "" {
+		return "", "", 0, fmt.Errorf("Invalid service address %q", address)
+	}
+	return namespace, service, port, nil
+}
+
+// selectPodPort returns a running pod of the service and its port receiving the
+// traffic of the service port.
+func selectPodPort(service *v1.Service, pods []v1.Pod, port int) (string, int, error) {
+	var servicePort *v1.ServicePort
+	for i := range service.Spec.Ports {
+		if int(service.Spec.Ports[i].Port) == port {
+			servicePort = &service.Spec.Ports[i]
+		}
+	}
+	if servicePort == nil {
+		return "", 0, fmt.Errorf("The service %v/%v has no port %v", service.Namespace, service.Name, port)
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		switch {
+		case servicePort.TargetPort.Type == intstr.Int && servicePort.TargetPort.IntVal != 0:
+			return pod.Name, int(servicePort.TargetPort.IntVal), nil
+		case servicePort.TargetPort.Type == intstr.String && servicePort.TargetPort.StrVal != "":
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == servicePort.TargetPort.StrVal {
+						return pod.Name, int(containerPort.ContainerPort), nil
+					}
+				}
+			}
+		default:
+			return pod.Name, port, nil
+		}
+	}
+	return "", 0, fmt.Errorf("The service %v/%v has no running pod serving the port %v",
+		service.Namespace, service.Name, port)
+}
+
+// portForwardConn is a connection forwarded to a port of a pod. Like `kubectl
+// port-forward`, it opens an error stream and a data stream in the SPDY connection to
+// the Kubernetes API server.
+type portForwardConn struct {
+	httpstream.Stream
+	connection httpstream.Connection
+	address    string
+}
+
+func newPortForwardConn(connection httpstream.Connection, port int, address string) (*portForwardConn, error) {
+	headers := http.Header{}
+	headers.Set(v1.StreamType, v1.StreamTypeError)
+	headers.Set(v1.PortHeader, strconv.Itoa(port))
+	headers.Set(v1.PortForwardRequestIDHeader, "0")
+	errorStream, err := connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing is written to the error stream.
+	errorStream.Close()
+	headers.Set(v1.StreamType, v1.StreamTypeData)
+	dataStream, err := connection.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		message, err := ioutil.ReadAll(errorStream)
+		switch {
+		case err != nil:
+			glog.Warningf("Failed to read the errors of the port forward to %v: %v", address, err)
+		case len(message) > 0:
+			glog.Warningf("The port forward to %v failed: %s", address, message)
+			connection.Close()
+		}
+	}()
+	return &portForwardConn{Stream: dataStream, connection: connection, address: address}, nil
+}
+
+func (c *portForwardConn) Close() error {
+	c.Stream.Reset()
+	return c.connection.Close()
+}
+
+func (c *portForwardConn) LocalAddr() net.Addr {
+	return portForwardAddr("localhost")
+}
+
+func (c *portForwardConn) RemoteAddr() net.Addr {
+	return portForwardAddr(c.address)
+}
+
+// The deadlines aren't supported by the SPDY streams. The calls are bounded by the
+// deadlines of their contexts instead.
+func (c *portForwardConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *portForwardConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *portForwardConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+type portForwardAddr string
+
+func (a portForwardAddr) Network() string {
+	return "portforward"
+}
+
+func (a portForwardAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestParseServiceAddress(t *testing.T) {
+	namespace, service, port, err := parseServiceAddress("ml-pipeline.kubeflow:8887", "default")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"kubeflow", "ml-pipeline", 8887}, []interface{}{namespace, service, port})
+
+	namespace, service, port, err = parseServiceAddress("ml-pipeline:8888", "default")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"default", "ml-pipeline", 8888}, []interface{}{namespace, service, port})
+
+	for _, address := range []string{"ml-pipeline", "ml-pipeline:grpc", ".kubeflow:8887", "ml-pipeline.:8887"} {
+		_, _, _, err = parseServiceAddress(address, "default")
+		assert.NotNil(t, err, address)
+	}
+}
+
+func newTestService(targetPort intstr.IntOrString) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "ml-pipeline", Namespace: "kubeflow"},
+		Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+			{Name: "http", Port: 8888},
+			{Name: "grpc", Port: 8887, TargetPort: targetPort},
+		}},
+	}
+}
+
+func newTestPod(name string, phase v1.PodPhase) v1.Pod {
+	return v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Ports: []v1.ContainerPort{{Name: "grpc-port", ContainerPort: 9887}}},
+		}},
+		Status: v1.PodStatus{Phase: phase},
+	}
+}
+
+func TestSelectPodPort(t *testing.T) {
+	pods := []v1.Pod{newTestPod("pending", v1.PodPending), newTestPod("running", v1.PodRunning)}
+
+	pod, port, err := selectPodPort(newTestService(intstr.FromInt(7887)), pods, 8887)
+	assert.Nil(t, err)
+	assert.Equal(t, "running", pod)
+	assert.Equal(t, 7887, port)
+
+	pod, port, err = selectPodPort(newTestService(intstr.FromString("grpc-port")), pods, 8887)
+	assert.Nil(t, err)
+	assert.Equal(t, "running", pod)
+	assert.Equal(t, 9887, port)
+
+	// The target port defaults to the service port.
+	pod, port, err = selectPodPort(newTestService(intstr.IntOrString{}), pods, 8888)
+	assert.Nil(t, err)
+	assert.Equal(t, "running", pod)
+	assert.Equal(t, 8888, port)
+}
+
+func TestSelectPodPort_Errors(t *testing.T) {
+	service := newTestService(intstr.FromInt(8887))
+	_, _, err := selectPodPort(service, []v1.Pod{newTestPod("running", v1.PodRunning)}, 1234)
+	assert.Contains(t, err.Error(), "The service kubeflow/ml-pipeline has no port 1234")
+
+	_, _, err = selectPodPort(service, []v1.Pod{newTestPod("pending", v1.PodPending)}, 8887)
+	assert.Contains(t, err.Error(), "has no running pod serving the port 8887")
+
+	_, _, err = selectPodPort(newTestService(intstr.FromString("unknown")),
+		[]v1.Pod{newTestPod("running", v1.PodRunning)}, 8887)
+	assert.Contains(t, err.Error(), "has no running pod serving the port 8887")
+}
@@ -0,0 +1,221 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Interaction is a call recorded by a Recorder: its request, and either its response or the
+// status of its error, as the JSON form of the messages.
+type Interaction struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Status   json.RawMessage `json:"status,omitempty"`
+}
+
+// Recording is the calls recorded by a Recorder, in the order they were made.
+type Recording struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// LoadRecording reads a recording saved by Recording.Save.
+func LoadRecording(path string) (*Recording, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read the recording %v", path)
+	}
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse the recording %v", path)
+	}
+	return &recording, nil
+}
+
+// Save writes the recording to a file, e.g. the golden file of a test.
+func (r *Recording) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal the recording")
+	}
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.Wrapf(err, "Failed to write the recording %v", path)
+	}
+	return nil
+}
+
+var marshaler = &jsonpb.Marshaler{OrigName: true}
+
+func marshalMessage(message interface{}) (json.RawMessage, error) {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("%T isn't a protocol buffer message", message)
+	}
+	var buffer bytes.Buffer
+	if err := marshaler.Marshal(&buffer, protoMessage); err != nil {
+		return nil, errors.Wrapf(err, "Failed to marshal %T", message)
+	}
+	return buffer.Bytes(), nil
+}
+
+func unmarshalMessage(data json.RawMessage, message interface{}) error {
+	protoMessage, ok := message.(proto.Message)
+	if !ok {
+		return errors.Errorf("%T isn't a protocol buffer message", message)
+	}
+	return jsonpb.Unmarshal(bytes.NewReader(data), protoMessage)
+}
+
+// Recorder records the unary calls of the clients created with WithRecorder, after their
+// retries. The streaming calls aren't recorded.
+type Recorder struct {
+	mu        sync.Mutex
+	recording Recording
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// WithRecorder records the calls of the client with recorder.
+func WithRecorder(recorder *Recorder) Option {
+	return func(o *options) {
+		o.recorder = recorder
+	}
+}
+
+// Recording returns the calls recorded so far.
+func (r *Recorder) Recording() *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Recording{Interactions: append([]*Interaction(nil), r.recording.Interactions...)}
+}
+
+// Save writes the calls recorded so far to a file.
+func (r *Recorder) Save(path string) error {
+	return r.Recording().Save(path)
+}
+
+func (r *Recorder) record(method string, req, reply interface{}, err error) {
+	interaction := &Interaction{Method: method}
+	request, marshalErr := marshalMessage(req)
+	if marshalErr == nil {
+		interaction.Request = request
+		if err != nil {
+			interaction.Status, marshalErr = marshalMessage(status.Convert(err).Proto())
+		} else {
+			interaction.Response, marshalErr = marshalMessage(reply)
+		}
+	}
+	if marshalErr != nil {
+		// The call is still recorded, so that its replay fails with the reason.
+		interaction.Status, _ = marshalMessage(status.New(codes.Internal, marshalErr.Error()).Proto())
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recording.Interactions = append(r.recording.Interactions, interaction)
+}
+
+// replayer answers each call with the first recorded interaction of the same method and request
+// it hasn't replayed yet, so that the calls polling a resource get the recorded responses in
+// order.
+type replayer struct {
+	mu           sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+}
+
+func (r *replayer) intercept(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return toError(r.replay(method, req, reply))
+}
+
+func (r *replayer) replay(method string, req, reply interface{}) error {
+	request, err := marshalMessage(req)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || interaction.Method != method || !r.sameRequest(interaction.Request, req) {
+			continue
+		}
+		r.replayed[i] = true
+		if interaction.Status != nil {
+			var stat spb.Status
+			if err := jsonpb.Unmarshal(bytes.NewReader(interaction.Status), &stat); err != nil {
+				return status.Errorf(codes.Internal, "Failed to parse the recorded status of %v: %v", method, err)
+			}
+			return status.ErrorProto(&stat)
+		}
+		if err := unmarshalMessage(interaction.Response, reply); err != nil {
+			return status.Errorf(codes.Internal, "Failed to parse the recorded response of %v: %v", method, err)
+		}
+		return nil
+	}
+	return status.Errorf(codes.Unimplemented, "No recorded response to %v with request %s", method, request)
+}
+
+// sameRequest compares the messages rather than their JSON form, whose formatting may differ.
+func (r *replayer) sameRequest(recorded json.RawMessage, req interface{}) bool {
+	recordedRequest := proto.Clone(req.(proto.Message))
+	recordedRequest.Reset()
+	if err := unmarshalMessage(recorded, recordedRequest); err != nil {
+		return false
+	}
+	return proto.Equal(recordedRequest, req.(proto.Message))
+}
+
+func (r *replayer) interceptStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, toError(status.Errorf(codes.Unimplemented, "The streaming call %v can't be replayed", method))
+}
+
+var errReplayOnly = errors.New("The client replays a recording: it doesn't connect to an API server")
+
+// NewReplayClient creates a Client answering the calls with the responses of a recording,
+// without an API server, e.g. in offline tests. A call which wasn't recorded fails with
+// UNIMPLEMENTED.
+func NewReplayClient(recording *Recording) (*Client, error) {
+	replayer := &replayer{
+		interactions: recording.Interactions,
+		replayed:     make([]bool, len(recording.Interactions)),
+	}
+	conn, err := grpc.Dial("replay",
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) { return nil, errReplayOnly }),
+		grpc.WithUnaryInterceptor(replayer.intercept),
+		grpc.WithStreamInterceptor(replayer.interceptStream))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the replay client")
+	}
+	return NewClientFromConn(conn), nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+func listRunNames(t *testing.T, client *Client) []string {
+	var names []string
+	runs := client.ListRuns(context.Background(), &api.ListRunsRequest{PageSize: 2})
+	for {
+		run, err := runs.Next()
+		if err == Done {
+			return names
+		}
+		if !assert.Nil(t, err) {
+			return names
+		}
+		names = append(names, run.Name)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	endpoint, stop := startFakeRunServer(t, &fakeRunServer{unavailableCalls: 1})
+	defer stop()
+	recorder := NewRecorder()
+	client, err := NewClient(endpoint, WithInsecure(), WithRetryPolicy(fastRetries), WithRecorder(recorder))
+	assert.Nil(t, err)
+	defer client.Close()
+	ctx := context.Background()
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, listRunNames(t, client))
+	_, getErr := client.Runs.GetRun(ctx, &api.GetRunRequest{RunId: "missing"})
+	assert.True(t, IsNotFound(getErr))
+
+	dir, err := ioutil.TempDir("", "recording")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "recording.json")
+	assert.Nil(t, recorder.Save(path))
+	recording, err := LoadRecording(path)
+	assert.Nil(t, err)
+	// The unavailable call was retried: only its outcome is recorded.
+	assert.Len(t, recording.Interactions, 4)
+
+	replayClient, err := NewReplayClient(recording)
+	assert.Nil(t, err)
+	defer replayClient.Close()
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, listRunNames(t, replayClient))
+	_, replayedErr := replayClient.Runs.GetRun(ctx, &api.GetRunRequest{RunId: "missing"})
+	assert.Equal(t, getErr, replayedErr)
+
+	// Each interaction is replayed once.
+	_, err = replayClient.Runs.GetRun(ctx, &api.GetRunRequest{RunId: "missing"})
+	assert.Equal(t, codes.Unimplemented, Code(err))
+	_, err = replayClient.Runs.GetRun(ctx, &api.GetRunRequest{RunId: "other"})
+	assert.Equal(t, codes.Unimplemented, Code(err))
+}
+
+func TestReplay_InOrder(t *testing.T) {
+	recording := &Recording{Interactions: []*Interaction{
+		{Method: "/api.RunService/GetRun", Request: []byte(`{"run_id":"run1"}`),
+			Response: []byte(`{"run":{"id":"run1","status":"Running"}}`)},
+		{Method: "/api.RunService/GetRun", Request: []byte(`{"run_id": "run1"}`),
+			Response: []byte(`{"run":{"id":"run1","status":"Succeeded"}}`)},
+	}}
+	client, err := NewReplayClient(recording)
+	assert.Nil(t, err)
+	defer client.Close()
+
+	run, err := client.Runs.GetRun(context.Background(), &api.GetRunRequest{RunId: "run1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Running", run.Run.Status)
+	run, err = client.Runs.GetRun(context.Background(), &api.GetRunRequest{RunId: "run1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Succeeded", run.Run.Status)
+}
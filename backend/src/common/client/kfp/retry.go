@@ -0,0 +1,64 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newUnaryInterceptor returns the interceptor retrying the calls that fail with
+// UNAVAILABLE and converting their errors to *Error. UNAVAILABLE is returned when
+// the request didn't reach the API server, e.g. during its restarts. The calls are
+// recorded with recorder, if any, once retried.
+func newUnaryInterceptor(policy RetryPolicy, timeout time.Duration, recorder *Recorder) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		operation := func() error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err != nil && status.Code(err) != codes.Unavailable {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		err := backoff.Retry(operation, newBackOff(ctx, policy))
+		if recorder != nil {
+			recorder.record(method, req, reply, err)
+		}
+		return toError(err)
+	}
+}
+
+func newBackOff(ctx context.Context, policy RetryPolicy) backoff.BackOff {
+	if policy.MaxRetries <= 0 {
+		return &backoff.StopBackOff{}
+	}
+	exponential := backoff.NewExponentialBackOff()
+	exponential.InitialInterval = policy.InitialBackoff
+	exponential.MaxInterval = policy.MaxBackoff
+	// The retries are bounded by their number and the deadline of the call.
+	exponential.MaxElapsedTime = 0
+	return backoff.WithContext(backoff.WithMaxRetries(exponential, uint64(policy.MaxRetries)), ctx)
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"io"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+// The intervals between the polls of a run, when the API server can't watch it, or of an
+// operation. They grow exponentially from initialPollInterval to maxPollInterval, and are
+// reset every time the status of the run or the progress of the operation changes.
+var (
+	initialPollInterval = time.Second
+	maxPollInterval     = 30 * time.Second
+)
+
+// RunUpdateFunc is called by WaitForRunCompletion with the run every time its status
+// changes.
+type RunUpdateFunc func(runDetail *api.RunDetail)
+
+// IsRunFinished returns whether the run has completed, successfully or not.
+func IsRunFinished(run *api.Run) bool {
+	return util.IsFinalCondition(run.Status)
+}
+
+// WaitForRunCompletion blocks until the run reaches a final state, and returns it with
+// its metrics. The run is watched through the WatchRun API, or polled with an
+// exponential backoff if the API server doesn't implement it or the watch is
+// interrupted. onUpdate, if not nil, is called with the current state of the run and
+// then every time its status changes.
+func (c *Client) WaitForRunCompletion(ctx context.Context, runId string,
+	onUpdate RunUpdateFunc) (*api.RunDetail, error) {
+	updates := &runUpdates{onUpdate: onUpdate}
+	runDetail, err := c.watchRun(ctx, runId, updates)
+	switch {
+	case err == nil && runDetail != nil:
+		return runDetail, nil
+	case err == nil, IsUnavailable(err), Code(err) == codes.Unimplemented:
+		return c.pollRun(ctx, runId, updates)
+	default:
+		return nil, err
+	}
+}
+
+// watchRun returns the run once it's finished, or nil if the watch ended before.
+func (c *Client) watchRun(ctx context.Context, runId string, updates *runUpdates) (*api.RunDetail, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.Runs.WatchRun(ctx, &api.WatchRunRequest{RunId: runId})
+	if err != nil {
+		return nil, toError(err)
+	}
+	for {
+		runDetail, err := stream.Recv()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, toError(err)
+		}
+		updates.update(runDetail)
+		if IsRunFinished(runDetail.Run) {
+			return runDetail, nil
+		}
+	}
+}
+
+func (c *Client) pollRun(ctx context.Context, runId string, updates *runUpdates) (*api.RunDetail, error) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = initialPollInterval
+	b.MaxInterval = maxPollInterval
+	b.MaxElapsedTime = 0
+	b.Reset()
+	for {
+		runDetail, err := c.Runs.GetRun(ctx, &api.GetRunRequest{RunId: runId})
+		if err != nil {
+			return nil, err
+		}
+		if updates.update(runDetail) {
+			b.Reset()
+		}
+		if IsRunFinished(runDetail.Run) {
+			return runDetail, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(b.NextBackOff()):
+		}
+	}
+}
+
+// IsOperationFinished returns whether the operation succeeded, failed or was cancelled.
+func IsOperationFinished(operation *api.Operation) bool {
+	switch operation.State {
+	case api.Operation_SUCCEEDED, api.Operation_FAILED, api.Operation_CANCELLED:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitForOperation blocks until the operation is finished, and returns it. The operation is
+// polled with an exponential backoff, reset every time it makes progress, so that the long
+// operations aren't bound to the deadline of a call.
+func (c *Client) WaitForOperation(ctx context.Context, operationId string) (*api.Operation, error) {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = initialPollInterval
+	b.MaxInterval = maxPollInterval
+	b.MaxElapsedTime = 0
+	b.Reset()
+	processed := int32(-1)
+	for {
+		operation, err := c.Operations.GetOperation(ctx, &api.GetOperationRequest{Id: operationId})
+		if err != nil {
+			return nil, err
+		}
+		if IsOperationFinished(operation) {
+			return operation, nil
+		}
+		if operation.Processed != processed {
+			processed = operation.Processed
+			b.Reset()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(b.NextBackOff()):
+		}
+	}
+}
+
+// runUpdates calls the RunUpdateFunc of WaitForRunCompletion on the status changes,
+// whether the run is watched or polled.
+type runUpdates struct {
+	onUpdate   RunUpdateFunc
+	started    bool
+	lastStatus string
+}
+
+// update returns whether the status of the run changed.
+func (u *runUpdates) update(runDetail *api.RunDetail) bool {
+	if u.started && runDetail.Run.Status == u.lastStatus {
+		return false
+	}
+	u.started = true
+	u.lastStatus = runDetail.Run.Status
+	if u.onUpdate != nil {
+		u.onUpdate(runDetail)
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp_test
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestWaitForRunCompletion_PollsWhenWatchIsUnimplemented(t *testing.T) {
+	defer kfp.SetFastPolling()()
+	client := kfpfake.NewClient()
+	runs := client.Runs.(*kfpfake.RunClient)
+	created, err := runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "run"}})
+	assert.Nil(t, err)
+
+	// Advances the run every time its status change is noticed.
+	var statuses []string
+	runDetail, err := client.WaitForRunCompletion(context.Background(), created.Run.Id, func(runDetail *api.RunDetail) {
+		statuses = append(statuses, runDetail.Run.Status)
+		switch runDetail.Run.Status {
+		case "":
+			runs.SetStatus(created.Run.Id, "Running")
+		case "Running":
+			runs.SetStatus(created.Run.Id, "Failed")
+		}
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"", "Running", "Failed"}, statuses)
+	assert.Equal(t, "Failed", runDetail.Run.Status)
+}
+
+func TestWaitForRunCompletion_PollingCanceled(t *testing.T) {
+	defer kfp.SetFastPolling()()
+	client := kfpfake.NewClient()
+	created, err := client.Runs.CreateRun(context.Background(), &api.CreateRunRequest{Run: &api.Run{Name: "run"}})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.WaitForRunCompletion(ctx, created.Run.Id, nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWaitForOperation(t *testing.T) {
+	defer kfp.SetFastPolling()()
+	client := kfpfake.NewClient()
+	operations := client.Operations.(*kfpfake.OperationClient)
+	operationId := operations.AddOperation(&api.Operation{State: api.Operation_RUNNING})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.WaitForOperation(ctx, operationId)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	_, err = operations.CancelOperation(context.Background(), &api.CancelOperationRequest{Id: operationId})
+	assert.Nil(t, err)
+	operation, err := client.WaitForOperation(context.Background(), operationId)
+	assert.Nil(t, err)
+	assert.Equal(t, api.Operation_CANCELLED, operation.State)
+}
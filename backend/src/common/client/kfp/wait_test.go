@@ -0,0 +1,93 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kfp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// fakeWatchRunServer streams a run through the statuses "", "Running" and "Succeeded".
+type fakeWatchRunServer struct {
+	fakeRunServer
+}
+
+func (s *fakeWatchRunServer) WatchRun(request *api.WatchRunRequest, stream api.RunService_WatchRunServer) error {
+	if request.RunId != "run-1" {
+		return util.ToGRPCError(util.NewResourceNotFoundError("Run", request.RunId))
+	}
+	for _, status := range []string{"", "Running", "Succeeded"} {
+		runDetail := &api.RunDetail{Run: &api.Run{Id: request.RunId, Status: status}}
+		if status == "Succeeded" {
+			runDetail.Run.Metrics = []*api.RunMetric{
+				{Name: "accuracy", Value: &api.RunMetric_NumberValue{NumberValue: 0.9}}}
+		}
+		if err := stream.Send(runDetail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startFakeWatchRunServer(t *testing.T) (*Client, func()) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	api.RegisterRunServiceServer(server, &fakeWatchRunServer{})
+	go server.Serve(listener)
+	client, err := NewClient(listener.Addr().String(), WithInsecure())
+	assert.Nil(t, err)
+	return client, func() {
+		client.Close()
+		server.Stop()
+	}
+}
+
+func TestWaitForRunCompletion_Watches(t *testing.T) {
+	client, stop := startFakeWatchRunServer(t)
+	defer stop()
+
+	var statuses []string
+	runDetail, err := client.WaitForRunCompletion(context.Background(), "run-1", func(runDetail *api.RunDetail) {
+		statuses = append(statuses, runDetail.Run.Status)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"", "Running", "Succeeded"}, statuses)
+	assert.Equal(t, "Succeeded", runDetail.Run.Status)
+	assert.Equal(t, "accuracy", runDetail.Run.Metrics[0].Name)
+}
+
+func TestWaitForRunCompletion_WatchNotFound(t *testing.T) {
+	client, stop := startFakeWatchRunServer(t)
+	defer stop()
+
+	_, err := client.WaitForRunCompletion(context.Background(), "unknown", nil)
+	assert.True(t, IsNotFound(err))
+}
+
+func setFastPolling() func() {
+	initial, max := initialPollInterval, maxPollInterval
+	initialPollInterval, maxPollInterval = time.Millisecond, time.Millisecond
+	return func() {
+		initialPollInterval, maxPollInterval = initial, max
+	}
+}
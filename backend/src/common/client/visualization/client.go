@@ -0,0 +1,120 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package visualization is the client of the visualization server, which generates the
+// visualizations of the artifacts of the runs apart from the API server.
+package visualization
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"golang.org/x/net/context"
+)
+
+// GeneratePath is the path of the visualization server generating the visualizations.
+const GeneratePath = "/generate"
+
+// GenerateRequest is a request to generate a visualization from the content of an artifact. The
+// visualization server doesn't read the artifacts itself, so that it needs no access to the object
+// store or to the cluster.
+type GenerateRequest struct {
+	Type api.Visualization_Type `json:"type"`
+	// The arguments of the visualization, as a JSON object.
+	Arguments string `json:"arguments"`
+	// The content of the artifact visualized.
+	Content []byte `json:"content"`
+}
+
+// GenerateResponse is the visualization generated, or why it couldn't be generated from the
+// artifact and the arguments.
+type GenerateResponse struct {
+	Html  string `json:"html"`
+	Error string `json:"error"`
+}
+
+type VisualizationClientInterface interface {
+	Generate(ctx context.Context, request *GenerateRequest) (*GenerateResponse, error)
+}
+
+// VisualizationClient calls the visualization server over HTTP.
+type VisualizationClient struct {
+	address    string
+	maxSize    int
+	httpClient *http.Client
+}
+
+// NewVisualizationClient creates the client of the visualization server at address (host:port),
+// sending artifacts of at most maxSize bytes, or of any size if maxSize isn't positive.
+func NewVisualizationClient(address string, timeout time.Duration, maxSize int) *VisualizationClient {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	return &VisualizationClient{
+		address:    strings.TrimSuffix(address, "/"),
+		maxSize:    maxSize,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *VisualizationClient) Generate(ctx context.Context, request *GenerateRequest) (*GenerateResponse, error) {
+	if c.maxSize > 0 && len(request.Content) > c.maxSize {
+		return nil, util.NewInvalidInputError(
+			"The artifact is %v bytes, more than the %v bytes that can be visualized.", len(request.Content), c.maxSize)
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to encode the visualization request")
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, c.address+GeneratePath, bytes.NewReader(body))
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create the visualization request")
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpResponse, err := c.httpClient.Do(httpRequest.WithContext(ctx))
+	if err != nil {
+		return nil, util.NewUnavailableError(err, "Failed to call the visualization server")
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := ioutil.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, util.NewUnavailableError(err, "Failed to read the response of the visualization server")
+	}
+	switch {
+	case httpResponse.StatusCode == http.StatusOK:
+	case httpResponse.StatusCode == http.StatusBadRequest ||
+		httpResponse.StatusCode == http.StatusRequestEntityTooLarge:
+		return nil, util.NewInvalidInputError("The visualization server refused the request: %s",
+			strings.TrimSpace(string(responseBody)))
+	case httpResponse.StatusCode == http.StatusServiceUnavailable:
+		return nil, util.NewUnavailableError(fmt.Errorf("status %v", httpResponse.StatusCode),
+			"The visualization server is busy")
+	default:
+		return nil, util.NewInternalServerError(
+			fmt.Errorf("status %v: %s", httpResponse.StatusCode, strings.TrimSpace(string(responseBody))),
+			"The visualization server failed")
+	}
+	response := &GenerateResponse{}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the response of the visualization server")
+	}
+	return response, nil
+}
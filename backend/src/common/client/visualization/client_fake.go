@@ -0,0 +1,58 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visualization
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// FakeVisualizationClient records the requests and returns the response or the error set.
+type FakeVisualizationClient struct {
+	mutex    sync.Mutex
+	requests []*GenerateRequest
+	response *GenerateResponse
+	err      error
+}
+
+func NewFakeVisualizationClient() *FakeVisualizationClient {
+	return &FakeVisualizationClient{response: &GenerateResponse{Html: "<p>visualization</p>"}}
+}
+
+func (c *FakeVisualizationClient) Generate(ctx context.Context, request *GenerateRequest) (*GenerateResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.requests = append(c.requests, request)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.response, nil
+}
+
+// SetResponse makes the next calls return the response, or fail with err if it isn't nil.
+func (c *FakeVisualizationClient) SetResponse(response *GenerateResponse, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.response = response
+	c.err = err
+}
+
+// Requests returns the requests received so far.
+func (c *FakeVisualizationClient) Requests() []*GenerateRequest {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]*GenerateRequest(nil), c.requests...)
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package visualization
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+func TestGenerate(t *testing.T) {
+	var received GenerateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, GeneratePath, r.URL.Path)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte(`{"html": "<table></table>"}`))
+	}))
+	defer server.Close()
+
+	client := NewVisualizationClient(server.URL, time.Minute, 0)
+	response, err := client.Generate(context.Background(), &GenerateRequest{
+		Type: api.Visualization_TABLE, Arguments: `{"max_rows": 5}`, Content: []byte("a,b\n1,2\n")})
+	assert.Nil(t, err)
+	assert.Equal(t, &GenerateResponse{Html: "<table></table>"}, response)
+	assert.Equal(t, GenerateRequest{
+		Type: api.Visualization_TABLE, Arguments: `{"max_rows": 5}`, Content: []byte("a,b\n1,2\n")}, received)
+}
+
+func TestGenerate_TooLarge(t *testing.T) {
+	client := NewVisualizationClient("localhost:1", time.Minute, 3)
+	_, err := client.Generate(context.Background(), &GenerateRequest{Content: []byte("a,b\n")})
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode())
+}
+
+func TestGenerate_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		code   codes.Code
+	}{
+		{http.StatusBadRequest, codes.InvalidArgument},
+		{http.StatusServiceUnavailable, codes.Unavailable},
+		{http.StatusInternalServerError, codes.Internal},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "failed", test.status)
+		}))
+		client := NewVisualizationClient(server.URL, time.Minute, 0)
+		_, err := client.Generate(context.Background(), &GenerateRequest{})
+		assert.NotNil(t, err)
+		assert.Equal(t, test.code, err.(*util.UserError).ExternalStatusCode(), test.status)
+		server.Close()
+	}
+}
+
+func TestGenerate_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client := NewVisualizationClient(server.URL, time.Minute, 0)
+	_, err := client.Generate(context.Background(), &GenerateRequest{})
+	assert.NotNil(t, err)
+	assert.True(t, util.IsUnavailableError(err))
+}
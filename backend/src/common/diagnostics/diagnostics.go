@@ -0,0 +1,73 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostics serves the runtime profiling endpoints and the effective configuration
+// of a binary on a dedicated admin port, so they are never exposed on the public ports.
+package diagnostics
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	runtimepprof "runtime/pprof"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// GoroutinesPath serves a full dump of the stacks of all the goroutines.
+	GoroutinesPath = "/debug/goroutines"
+	// VarsPath serves the expvar variables, including memstats and cmdline.
+	VarsPath = "/debug/vars"
+	// PprofPath is the prefix of the net/http/pprof endpoints.
+	PprofPath = "/debug/pprof/"
+	// ConfigPath serves the effective configuration of the binary.
+	ConfigPath = "/debug/config"
+)
+
+// NewHandler returns a handler serving pprof, expvar, the goroutine dump and, if config
+// isn't nil, the effective configuration.
+func NewHandler(config http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath, pprof.Index)
+	mux.HandleFunc(PprofPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"trace", pprof.Trace)
+	mux.Handle(VarsPath, expvar.Handler())
+	mux.HandleFunc(GoroutinesPath, dumpGoroutines)
+	if config != nil {
+		mux.Handle(ConfigPath, config)
+	}
+	return mux
+}
+
+// ListenAndServe serves the diagnostics endpoints on the given address. It is meant
+// to be run in its own goroutine, and does nothing if the address is empty.
+func ListenAndServe(address string, config http.Handler) {
+	if address == "" {
+		return
+	}
+	glog.Infof("Serving diagnostics endpoints on %s", address)
+	if err := http.ListenAndServe(address, NewHandler(config)); err != nil {
+		glog.Errorf("Failed to serve diagnostics endpoints on %s: %v", address, err)
+	}
+}
+
+func dumpGoroutines(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := runtimepprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func get(path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	NewHandler(nil).ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+	return recorder
+}
+
+func TestGoroutines(t *testing.T) {
+	response := get(GoroutinesPath)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "goroutine")
+	assert.Contains(t, response.Body.String(), "TestGoroutines")
+}
+
+func TestVars(t *testing.T) {
+	response := get(VarsPath)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "memstats")
+}
+
+func TestPprofIndex(t *testing.T) {
+	response := get(PprofPath)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "heap")
+}
+
+func TestConfig(t *testing.T) {
+	config := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Workers": "4"}`))
+	})
+	recorder := httptest.NewRecorder()
+	NewHandler(config).ServeHTTP(recorder, httptest.NewRequest("GET", ConfigPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `{"Workers": "4"}`, recorder.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, get(ConfigPath).Code)
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package features gates the features enabled gradually, e.g. while they land, in the API
+// server and in the controllers alike. The features are set with a list of name=bool pairs,
+// e.g. Caching=true,V2API=false, in the FeatureConfig.Gates setting of the API server and in
+// the featureGates flag of the controllers, which can also be set from their ConfigMap.
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is the name of a feature, e.g. Caching.
+type Feature string
+
+const (
+	// Caching reuses the outputs of the steps already run with the same inputs.
+	Caching Feature = "Caching"
+	// MultiUser isolates the resources of the users in their namespaces.
+	MultiUser Feature = "MultiUser"
+	// V2API serves the v2 API alongside the v1beta1 one.
+	V2API Feature = "V2API"
+)
+
+// Stage is how mature a feature is.
+type Stage string
+
+const (
+	// Alpha features are disabled by default, and may change or go away.
+	Alpha Stage = "ALPHA"
+	// Beta features are enabled by default, and can still be disabled.
+	Beta Stage = "BETA"
+	// GA features are always enabled. Their gate is removed a release later.
+	GA Stage = "GA"
+)
+
+// Spec is how mature a feature is, and whether it's enabled unless set.
+type Spec struct {
+	Default bool
+	Stage   Stage
+}
+
+// The features known by the binaries, added as alpha while they land.
+var knownFeatures = map[Feature]Spec{
+	Caching:   {Default: false, Stage: Alpha},
+	MultiUser: {Default: false, Stage: Alpha},
+	V2API:     {Default: false, Stage: Alpha},
+}
+
+// State is the state of a feature in a Gate.
+type State struct {
+	Feature Feature
+	Spec
+	Enabled bool
+}
+
+// Gate tells which features are enabled. It's a flag.Value, so that the controllers can
+// set it as a flag. It's safe for concurrent use.
+type Gate struct {
+	mutex sync.RWMutex
+	known map[Feature]Spec
+	// The features set explicitly, by name.
+	set map[Feature]bool
+}
+
+// NewGate returns a Gate of the known features, with their default state.
+func NewGate() *Gate {
+	return NewGateWithFeatures(knownFeatures)
+}
+
+// NewGateWithFeatures returns a Gate of the given features, e.g. to test a feature at a
+// given stage.
+func NewGateWithFeatures(known map[Feature]Spec) *Gate {
+	return &Gate{known: known, set: make(map[Feature]bool)}
+}
+
+// Enabled tells whether a feature is enabled. The unknown features are disabled.
+func (g *Gate) Enabled(feature Feature) bool {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	spec, ok := g.known[feature]
+	if !ok {
+		return false
+	}
+	if enabled, ok := g.set[feature]; ok {
+		return enabled
+	}
+	return spec.Default
+}
+
+// Set sets the features of a list of name=bool pairs separated by commas, e.g.
+// Caching=true,V2API=false, and resets the others to their default state. The gate is left
+// as it is if the list sets an unknown feature, or disables a GA one.
+func (g *Gate) Set(value string) error {
+	set := make(map[Feature]bool)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid feature gate %q, expected name=true or name=false", pair)
+		}
+		feature := Feature(strings.TrimSpace(parts[0]))
+		spec, ok := g.known[feature]
+		if !ok {
+			return fmt.Errorf("unknown feature %s", feature)
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return fmt.Errorf("invalid value %q of feature %s, expected true or false", parts[1], feature)
+		}
+		if spec.Stage == GA && !enabled {
+			return fmt.Errorf("feature %s is GA and can't be disabled anymore", feature)
+		}
+		set[feature] = enabled
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.set = set
+	return nil
+}
+
+// String returns the features set explicitly, sorted, in the format of Set.
+func (g *Gate) String() string {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	pairs := make([]string, 0, len(g.set))
+	for feature, enabled := range g.set {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", feature, enabled))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// States returns the state of the known features, sorted by name.
+func (g *Gate) States() []State {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	states := make([]State, 0, len(g.known))
+	for feature, spec := range g.known {
+		enabled, ok := g.set[feature]
+		if !ok {
+			enabled = spec.Default
+		}
+		states = append(states, State{Feature: feature, Spec: spec, Enabled: enabled})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Feature < states[j].Feature
+	})
+	return states
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	alphaFeature Feature = "AlphaFeature"
+	betaFeature  Feature = "BetaFeature"
+	gaFeature    Feature = "GAFeature"
+)
+
+func newTestGate() *Gate {
+	return NewGateWithFeatures(map[Feature]Spec{
+		alphaFeature: {Default: false, Stage: Alpha},
+		betaFeature:  {Default: true, Stage: Beta},
+		gaFeature:    {Default: true, Stage: GA},
+	})
+}
+
+func TestGate_Defaults(t *testing.T) {
+	gate := newTestGate()
+	assert.False(t, gate.Enabled(alphaFeature))
+	assert.True(t, gate.Enabled(betaFeature))
+	assert.True(t, gate.Enabled(gaFeature))
+	assert.False(t, gate.Enabled("Unknown"))
+	assert.Equal(t, "", gate.String())
+}
+
+func TestGate_Set(t *testing.T) {
+	gate := newTestGate()
+	assert.Nil(t, gate.Set(" AlphaFeature=true, BetaFeature=false,GAFeature=1"))
+	assert.True(t, gate.Enabled(alphaFeature))
+	assert.False(t, gate.Enabled(betaFeature))
+	assert.True(t, gate.Enabled(gaFeature))
+	assert.Equal(t, "AlphaFeature=true,BetaFeature=false,GAFeature=true", gate.String())
+	assert.Equal(t, []State{
+		{Feature: alphaFeature, Spec: Spec{Default: false, Stage: Alpha}, Enabled: true},
+		{Feature: betaFeature, Spec: Spec{Default: true, Stage: Beta}, Enabled: false},
+		{Feature: gaFeature, Spec: Spec{Default: true, Stage: GA}, Enabled: true},
+	}, gate.States())
+
+	// The features not set anymore are back to their default state.
+	assert.Nil(t, gate.Set("AlphaFeature=true"))
+	assert.True(t, gate.Enabled(betaFeature))
+	assert.Nil(t, gate.Set(""))
+	assert.False(t, gate.Enabled(alphaFeature))
+}
+
+func TestGate_SetInvalid(t *testing.T) {
+	gate := newTestGate()
+	assert.Nil(t, gate.Set("AlphaFeature=true"))
+	for value, message := range map[string]string{
+		"Unknown=true":                      "unknown feature Unknown",
+		"AlphaFeature":                      "expected name=true or name=false",
+		"AlphaFeature=yes":                  "invalid value",
+		"BetaFeature=false,GAFeature=false": "can't be disabled anymore",
+	} {
+		err := gate.Set(value)
+		assert.NotNil(t, err, value)
+		assert.Contains(t, err.Error(), message)
+		// The gate is left as it is.
+		assert.Equal(t, "AlphaFeature=true", gate.String())
+	}
+}
+
+func TestGate_Flag(t *testing.T) {
+	gate := newTestGate()
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Var(gate, "featureGates", "")
+	assert.Nil(t, flags.Parse([]string{"--featureGates", "AlphaFeature=true"}))
+	assert.True(t, gate.Enabled(alphaFeature))
+	assert.Equal(t, "AlphaFeature=true", flags.Lookup("featureGates").Value.String())
+}
+
+func TestNewGate(t *testing.T) {
+	gate := NewGate()
+	for _, state := range gate.States() {
+		assert.Equal(t, state.Default, state.Enabled, string(state.Feature))
+		assert.Equal(t, state.Default, state.Stage != Alpha, string(state.Feature))
+	}
+}
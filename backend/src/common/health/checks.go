@@ -0,0 +1,114 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/http"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SQLPingCheck verifies that the database accepts connections.
+func SQLPingCheck(db *sql.DB) Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// SQLOpenCheck verifies that the database server accepts the connections to the data
+// source, opening a connection for each check.
+func SQLOpenCheck(driverName string, dataSourceName string) Check {
+	return func(ctx context.Context) error {
+		db, err := sql.Open(driverName, dataSourceName)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return db.PingContext(ctx)
+	}
+}
+
+// TCPDialCheck verifies that a server accepts TCP connections on the address (host:port).
+func TCPDialCheck(address string) Check {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// APIResourceCheck verifies that the Kubernetes API server serves the resource of the
+// group version, e.g. that its CRD is installed.
+func APIResourceCheck(client discovery.ServerResourcesInterface, groupVersion string, resource string) Check {
+	return func(ctx context.Context) error {
+		resources, err := client.ServerResourcesForGroupVersion(groupVersion)
+		if err != nil {
+			return err
+		}
+		for _, apiResource := range resources.APIResources {
+			if apiResource.Name == resource {
+				return nil
+			}
+		}
+		return fmt.Errorf("resource %v of %v is not served, its CRD may be missing", resource, groupVersion)
+	}
+}
+
+// KubernetesAPICheck verifies that the Kubernetes API server is reachable.
+func KubernetesAPICheck(client discovery.ServerVersionInterface) Check {
+	return func(ctx context.Context) error {
+		_, err := client.ServerVersion()
+		return err
+	}
+}
+
+// CacheSyncedCheck verifies that the informer caches completed their initial sync.
+func CacheSyncedCheck(synced ...cache.InformerSynced) Check {
+	return func(ctx context.Context) error {
+		for _, hasSynced := range synced {
+			if !hasSynced() {
+				return fmt.Errorf("informer caches are not synced yet")
+			}
+		}
+		return nil
+	}
+}
+
+// HTTPGetCheck verifies that a GET request to the url succeeds with a 2xx status.
+func HTTPGetCheck(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		response, err := client.Do(request.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		if response.StatusCode < 200 || response.StatusCode >= 300 {
+			return fmt.Errorf("GET %s returned status %d", url, response.StatusCode)
+		}
+		return nil
+	}
+}
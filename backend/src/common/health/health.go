@@ -0,0 +1,186 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health serves liveness and readiness probes that run checks against the
+// actual dependencies of a binary.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+
+	statusOK     = "ok"
+	statusFailed = "failed"
+)
+
+// Check verifies that a dependency is available. It should return promptly once the
+// context is done.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// CheckResult is the outcome of a single check.
+type CheckResult struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// Report is the body returned by the probe handlers.
+type Report struct {
+	Status string         `json:"status"`
+	Checks []*CheckResult `json:"checks"`
+}
+
+// Checker holds the liveness and the readiness checks of a binary.
+type Checker struct {
+	timeout         time.Duration
+	mutex           sync.RWMutex
+	livenessChecks  []namedCheck
+	readinessChecks []namedCheck
+}
+
+// NewChecker creates a Checker in which every check is given at most timeout to complete.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// AddLivenessCheck adds a check that is run by both the liveness and the readiness probes.
+// Only checks whose failure cannot be recovered without a restart belong here.
+func (c *Checker) AddLivenessCheck(name string, check Check) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.livenessChecks = append(c.livenessChecks, namedCheck{name: name, check: check})
+}
+
+// AddReadinessCheck adds a check that is run by the readiness probe.
+func (c *Checker) AddReadinessCheck(name string, check Check) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.readinessChecks = append(c.readinessChecks, namedCheck{name: name, check: check})
+}
+
+// Live runs the liveness checks.
+func (c *Checker) Live(ctx context.Context) *Report {
+	c.mutex.RLock()
+	checks := append([]namedCheck(nil), c.livenessChecks...)
+	c.mutex.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// Ready runs the liveness and the readiness checks.
+func (c *Checker) Ready(ctx context.Context) *Report {
+	c.mutex.RLock()
+	checks := append(append([]namedCheck(nil), c.livenessChecks...), c.readinessChecks...)
+	c.mutex.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// LivenessHandler returns an http handler serving the liveness probe.
+func (c *Checker) LivenessHandler() http.Handler {
+	return newHandler(c.Live)
+}
+
+// ReadinessHandler returns an http handler serving the readiness probe.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return newHandler(c.Ready)
+}
+
+// RegisterHandlers registers the liveness and the readiness probes on their default paths.
+func (c *Checker) RegisterHandlers(mux *http.ServeMux) {
+	mux.Handle(LivenessPath, c.LivenessHandler())
+	mux.Handle(ReadinessPath, c.ReadinessHandler())
+}
+
+func (c *Checker) run(ctx context.Context, checks []namedCheck) *Report {
+	report := &Report{Status: statusOK, Checks: make([]*CheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check namedCheck) {
+			defer wg.Done()
+			report.Checks[i] = c.runOne(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+	for _, result := range report.Checks {
+		if result.Status != statusOK {
+			report.Status = statusFailed
+		}
+	}
+	return report
+}
+
+func (c *Checker) runOne(ctx context.Context, check namedCheck) *CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("check panicked: %v", r)
+			}
+		}()
+		done <- check.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("check did not complete within %v", c.timeout)
+	}
+
+	result := &CheckResult{
+		Name:       check.name,
+		Status:     statusOK,
+		DurationMs: int64(time.Since(start) / time.Millisecond),
+	}
+	if err != nil {
+		result.Status = statusFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func newHandler(probe func(ctx context.Context) *Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := probe(r.Context())
+		body, err := json.Marshal(report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status != statusOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write(body)
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func okCheck(ctx context.Context) error {
+	return nil
+}
+
+func failedCheck(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func blockingCheck(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func serve(handler http.Handler) (int, *Report) {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+	var report Report
+	json.Unmarshal(recorder.Body.Bytes(), &report)
+	return recorder.Code, &report
+}
+
+func TestChecker_AllChecksPass(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.AddLivenessCheck("process", okCheck)
+	checker.AddReadinessCheck("database", okCheck)
+
+	code, report := serve(checker.ReadinessHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, statusOK, report.Status)
+	assert.Equal(t, 2, len(report.Checks))
+	assert.Equal(t, "process", report.Checks[0].Name)
+	assert.Equal(t, "database", report.Checks[1].Name)
+}
+
+func TestChecker_ReadinessCheckFails(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.AddReadinessCheck("database", okCheck)
+	checker.AddReadinessCheck("object_store", failedCheck)
+
+	code, report := serve(checker.ReadinessHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, statusFailed, report.Status)
+	assert.Equal(t, statusOK, report.Checks[0].Status)
+	assert.Equal(t, statusFailed, report.Checks[1].Status)
+	assert.Equal(t, "connection refused", report.Checks[1].Error)
+
+	// Readiness failures do not affect liveness.
+	code, report = serve(checker.LivenessHandler())
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 0, len(report.Checks))
+}
+
+func TestChecker_CheckTimesOut(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.AddLivenessCheck("kubernetes", blockingCheck)
+
+	code, report := serve(checker.LivenessHandler())
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, report.Checks[0].Error, "did not complete within")
+}
+
+func TestHTTPGetCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	assert.Nil(t, HTTPGetCheck(server.Client(), server.URL+"/ok")(context.Background()))
+	assert.NotNil(t, HTTPGetCheck(server.Client(), server.URL+"/bad")(context.Background()))
+}
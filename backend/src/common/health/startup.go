@@ -0,0 +1,132 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+)
+
+var (
+	startupBlockedGauge = metrics.NewGaugeVec("startup_dependency_blocked",
+		"Whether the startup of the binary is waiting for the dependency.", "dependency")
+	startupWaitGauge = metrics.NewGaugeVec("startup_dependency_wait_seconds",
+		"How long the startup of the binary waited for the dependency.", "dependency")
+)
+
+func init() {
+	metrics.MustRegister(startupBlockedGauge, startupWaitGauge)
+}
+
+type startupDependency struct {
+	name    string
+	timeout time.Duration
+	check   Check
+}
+
+// StartupGate holds the startup of a binary until its dependencies, e.g. the database or
+// the CRDs, are available, instead of letting it crash-loop while they're deployed. Each
+// dependency is checked with an exponential backoff until it passes, or fails for good
+// once its own timeout elapsed.
+type StartupGate struct {
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	checkTimeout   time.Duration
+	dependencies   []startupDependency
+
+	mutex sync.Mutex
+	// The dependency waited for, if any, and whether all of them are available.
+	blocking string
+	done     bool
+}
+
+// NewStartupGate creates a gate retrying the checks from initialBackoff up to maxBackoff
+// apart, giving each check at most checkTimeout to complete.
+func NewStartupGate(initialBackoff time.Duration, maxBackoff time.Duration,
+	checkTimeout time.Duration) *StartupGate {
+	return &StartupGate{initialBackoff: initialBackoff, maxBackoff: maxBackoff, checkTimeout: checkTimeout}
+}
+
+// Add adds a dependency waited for up to timeout. Dependencies are added before Wait is
+// called.
+func (g *StartupGate) Add(name string, timeout time.Duration, check Check) {
+	g.dependencies = append(g.dependencies, startupDependency{name: name, timeout: timeout, check: check})
+}
+
+// Wait waits for the dependencies in the order they were added. It returns an error naming
+// the first dependency still unavailable after its timeout.
+func (g *StartupGate) Wait() error {
+	for _, dependency := range g.dependencies {
+		if err := g.wait(dependency); err != nil {
+			return err
+		}
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.blocking = ""
+	g.done = true
+	return nil
+}
+
+func (g *StartupGate) wait(dependency startupDependency) error {
+	g.mutex.Lock()
+	g.blocking = dependency.name
+	g.mutex.Unlock()
+
+	start := time.Now()
+	startupBlockedGauge.Set(1, dependency.name)
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = g.initialBackoff
+	b.MaxInterval = g.maxBackoff
+	b.MaxElapsedTime = dependency.timeout
+	err := backoff.RetryNotify(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), g.checkTimeout)
+		defer cancel()
+		return dependency.check(ctx)
+	}, b, func(err error, next time.Duration) {
+		glog.Warningf("Startup is blocked by dependency %v, checking it again in %v. Error: %v",
+			dependency.name, next.Round(time.Millisecond), err)
+	})
+	waited := time.Since(start)
+	startupWaitGauge.Set(waited.Seconds(), dependency.name)
+	if err != nil {
+		return fmt.Errorf("dependency %v is still unavailable after %v: %v", dependency.name, dependency.timeout, err)
+	}
+	startupBlockedGauge.Set(0, dependency.name)
+	glog.Infof("Dependency %v is available, after waiting for %v", dependency.name, waited.Round(time.Millisecond))
+	return nil
+}
+
+// ReadinessCheck returns a check failing until all the dependencies are available, naming
+// the one waited for.
+func (g *StartupGate) ReadinessCheck() Check {
+	return func(ctx context.Context) error {
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+		if g.done {
+			return nil
+		}
+		if g.blocking == "" {
+			return fmt.Errorf("startup didn't start waiting for the dependencies")
+		}
+		return fmt.Errorf("startup is waiting for dependency %v", g.blocking)
+	}
+}
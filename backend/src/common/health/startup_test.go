@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestStartupGate_WaitsForDependencies(t *testing.T) {
+	gate := NewStartupGate(time.Millisecond, 5*time.Millisecond, time.Second)
+	attempts := 0
+	gate.Add("database", time.Minute, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	gate.Add("object_store", time.Minute, okCheck)
+	assert.Contains(t, gate.ReadinessCheck()(context.Background()).Error(), "didn't start waiting")
+
+	assert.Nil(t, gate.Wait())
+	assert.Equal(t, 3, attempts)
+	assert.Nil(t, gate.ReadinessCheck()(context.Background()))
+	assert.Equal(t, 0.0, startupBlockedGauge.Value("database"))
+}
+
+func TestStartupGate_Timeout(t *testing.T) {
+	gate := NewStartupGate(time.Millisecond, 5*time.Millisecond, time.Second)
+	gate.Add("database", time.Minute, okCheck)
+	gate.Add("workflow_crd", 20*time.Millisecond, failedCheck)
+	gate.Add("object_store", time.Minute, okCheck)
+
+	err := gate.Wait()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "dependency workflow_crd is still unavailable")
+	assert.Contains(t, err.Error(), "connection refused")
+	// The readiness check names the dependency blocking the startup.
+	assert.Contains(t, gate.ReadinessCheck()(context.Background()).Error(), "workflow_crd")
+	assert.Equal(t, 1.0, startupBlockedGauge.Value("workflow_crd"))
+	assert.Equal(t, 0.0, startupBlockedGauge.Value("object_store"))
+}
+
+func TestTCPDialCheck(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	address := server.Listener.Addr().String()
+	assert.Nil(t, TCPDialCheck(address)(context.Background()))
+
+	server.Close()
+	_, port, _ := net.SplitHostPort(address)
+	assert.NotNil(t, TCPDialCheck(net.JoinHostPort("127.0.0.1", port))(context.Background()))
+}
+
+func TestAPIResourceCheck(t *testing.T) {
+	client := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	client.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "argoproj.io/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "workflows"}},
+	}}
+
+	assert.Nil(t, APIResourceCheck(client, "argoproj.io/v1alpha1", "workflows")(context.Background()))
+	err := APIResourceCheck(client, "argoproj.io/v1alpha1", "workflowtemplates")(context.Background())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "CRD may be missing")
+	assert.NotNil(t, APIResourceCheck(client, "kubeflow.org/v1alpha1", "scheduledworkflows")(context.Background()))
+}
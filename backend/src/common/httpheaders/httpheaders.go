@@ -0,0 +1,154 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpheaders adds the CORS headers and the security headers to the responses of
+// an HTTP server, so that the custom frontends on other origins can call it from the browsers.
+package httpheaders
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// anyOrigin allows the requests from every origin.
+const anyOrigin = "*"
+
+// CORSOptions are the cross-origin requests allowed. CORS is disabled if no origin is allowed.
+type CORSOptions struct {
+	// The origins allowed, e.g. https://ui.example.com, or "*" for any origin. Any origin
+	// can't be allowed with credentials.
+	AllowedOrigins []string
+	AllowedMethods []string
+	// The request headers allowed, e.g. Content-Type.
+	AllowedHeaders []string
+	// Whether the requests may carry cookies.
+	AllowCredentials bool
+	// How long the browsers may cache the results of the preflight requests.
+	MaxAge time.Duration
+}
+
+// SecurityOptions are the security headers of the responses, the empty ones being omitted.
+type SecurityOptions struct {
+	// Adds X-Content-Type-Options: nosniff if true.
+	NoSniff                 bool
+	FrameOptions            string
+	ContentSecurityPolicy   string
+	ReferrerPolicy          string
+	StrictTransportSecurity string
+}
+
+// Handler adds the headers to the responses of an HTTP handler, and answers the CORS
+// preflight requests.
+type Handler struct {
+	handler  http.Handler
+	cors     CORSOptions
+	security SecurityOptions
+	origins  map[string]bool
+	methods  map[string]bool
+}
+
+// NewHandler returns an error if any origin is allowed with credentials, which would give
+// every site the access of the users to the server.
+func NewHandler(handler http.Handler, cors CORSOptions, security SecurityOptions) (*Handler, error) {
+	h := &Handler{
+		handler:  handler,
+		cors:     cors,
+		security: security,
+		origins:  map[string]bool{},
+		methods:  map[string]bool{},
+	}
+	for _, origin := range cors.AllowedOrigins {
+		h.origins[strings.TrimSuffix(origin, "/")] = true
+	}
+	for _, method := range cors.AllowedMethods {
+		h.methods[strings.ToUpper(method)] = true
+	}
+	if h.origins[anyOrigin] && cors.AllowCredentials {
+		return nil, fmt.Errorf("the requests with credentials can't be allowed from any origin, " +
+			"the origins allowed must be listed")
+	}
+	return h, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.addSecurityHeaders(w.Header())
+	origin := r.Header.Get("Origin")
+	if origin == "" || len(h.origins) == 0 {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+	w.Header().Add("Vary", "Origin")
+	preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+	if !h.isOriginAllowed(origin) {
+		if preflight {
+			http.Error(w, "Origin "+origin+" is not allowed", http.StatusForbidden)
+			return
+		}
+		// The browser rejects the response without the CORS headers.
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+	h.addOriginHeaders(w.Header(), origin)
+	if !preflight {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+	method := r.Header.Get("Access-Control-Request-Method")
+	if !h.methods[strings.ToUpper(method)] {
+		http.Error(w, "Method "+method+" is not allowed", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Methods", strings.Join(h.cors.AllowedMethods, ", "))
+	if len(h.cors.AllowedHeaders) > 0 {
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(h.cors.AllowedHeaders, ", "))
+	}
+	if h.cors.MaxAge > 0 {
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(h.cors.MaxAge.Seconds())))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) isOriginAllowed(origin string) bool {
+	return h.origins[anyOrigin] || h.origins[origin]
+}
+
+func (h *Handler) addOriginHeaders(header http.Header, origin string) {
+	if h.origins[anyOrigin] {
+		header.Set("Access-Control-Allow-Origin", anyOrigin)
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if h.cors.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (h *Handler) addSecurityHeaders(header http.Header) {
+	if h.security.NoSniff {
+		header.Set("X-Content-Type-Options", "nosniff")
+	}
+	setIfNotEmpty(header, "X-Frame-Options", h.security.FrameOptions)
+	setIfNotEmpty(header, "Content-Security-Policy", h.security.ContentSecurityPolicy)
+	setIfNotEmpty(header, "Referrer-Policy", h.security.ReferrerPolicy)
+	setIfNotEmpty(header, "Strict-Transport-Security", h.security.StrictTransportSecurity)
+}
+
+func setIfNotEmpty(header http.Header, key string, value string) {
+	if value != "" {
+		header.Set(key, value)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpheaders
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("ok"))
+})
+
+func serve(handler http.Handler, method string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, "/apis/v1beta1/pipelines", nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func newCORSHandler(origins ...string) *Handler {
+	handler, _ := NewHandler(okHandler, CORSOptions{
+		AllowedOrigins: origins,
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type"},
+		MaxAge:         10 * time.Minute,
+	}, SecurityOptions{})
+	return handler
+}
+
+func TestHandler_CORSDisabled(t *testing.T) {
+	response := serve(newCORSHandler(), http.MethodGet, map[string]string{"Origin": "https://ui.example.com"})
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Empty(t, response.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestHandler_AllowedOrigin(t *testing.T) {
+	handler := newCORSHandler("https://ui.example.com/")
+	response := serve(handler, http.MethodGet, map[string]string{"Origin": "https://ui.example.com"})
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "ok", response.Body.String())
+	assert.Equal(t, "https://ui.example.com", response.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", response.Header().Get("Vary"))
+
+	response = serve(handler, http.MethodGet, map[string]string{"Origin": "https://evil.example.com"})
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Empty(t, response.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestHandler_AnyOrigin(t *testing.T) {
+	response := serve(newCORSHandler("*"), http.MethodGet, map[string]string{"Origin": "https://ui.example.com"})
+	assert.Equal(t, "*", response.Header().Get("Access-Control-Allow-Origin"))
+
+	// Any origin can't be allowed with credentials.
+	_, err := NewHandler(okHandler, CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+		SecurityOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestHandler_AllowCredentials(t *testing.T) {
+	handler, err := NewHandler(okHandler, CORSOptions{AllowedOrigins: []string{"https://ui.example.com"},
+		AllowCredentials: true}, SecurityOptions{})
+	assert.Nil(t, err)
+	response := serve(handler, http.MethodGet, map[string]string{"Origin": "https://ui.example.com"})
+	assert.Equal(t, "https://ui.example.com", response.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", response.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestHandler_Preflight(t *testing.T) {
+	handler := newCORSHandler("https://ui.example.com")
+	response := serve(handler, http.MethodOptions, map[string]string{
+		"Origin": "https://ui.example.com", "Access-Control-Request-Method": "POST"})
+	assert.Equal(t, http.StatusNoContent, response.Code)
+	assert.Empty(t, response.Body.String())
+	assert.Equal(t, "GET, POST", response.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type", response.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", response.Header().Get("Access-Control-Max-Age"))
+
+	response = serve(handler, http.MethodOptions, map[string]string{
+		"Origin": "https://ui.example.com", "Access-Control-Request-Method": "DELETE"})
+	assert.Equal(t, http.StatusForbidden, response.Code)
+
+	response = serve(handler, http.MethodOptions, map[string]string{
+		"Origin": "https://evil.example.com", "Access-Control-Request-Method": "GET"})
+	assert.Equal(t, http.StatusForbidden, response.Code)
+}
+
+func TestHandler_SecurityHeaders(t *testing.T) {
+	handler, err := NewHandler(okHandler, CORSOptions{}, SecurityOptions{
+		NoSniff:               true,
+		FrameOptions:          "DENY",
+		ContentSecurityPolicy: "default-src 'none'",
+		ReferrerPolicy:        "no-referrer",
+	})
+	assert.Nil(t, err)
+	response := serve(handler, http.MethodGet, nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "nosniff", response.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", response.Header().Get("X-Frame-Options"))
+	assert.Equal(t, "default-src 'none'", response.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "no-referrer", response.Header().Get("Referrer-Policy"))
+	assert.Empty(t, response.Header().Get("Strict-Transport-Security"))
+}
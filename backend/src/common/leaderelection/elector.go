@@ -0,0 +1,258 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+var isLeaderGauge = metrics.NewGaugeVec("leader_election_is_leader",
+	"Whether this replica holds the lease running the tasks which must not run concurrently.", "lease")
+
+func init() {
+	metrics.MustRegister(isLeaderGauge)
+}
+
+type task struct {
+	name string
+	run  func(stopCh <-chan struct{})
+}
+
+// Elector runs the tasks which must not run concurrently, e.g. the reconcilers of the
+// API server or the controllers, on a single replica: the one holding a Kubernetes
+// lease. The leader renews the lease every retry period. If it fails to renew it within
+// the renew deadline, which must be shorter than the lease duration, it stops the tasks
+// before another replica can take the lease over.
+//
+// The lease is considered expired once it wasn't renewed for its duration, as measured
+// by the local clock since the lease last changed, so that the clock skew between the
+// replicas doesn't matter.
+type Elector struct {
+	client        LeaseClientInterface
+	name          string
+	identity      string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+	time          util.TimeInterface
+	tasks         []task
+
+	mutex   sync.Mutex
+	leading bool
+	// The lease as last observed, and when it was observed to change.
+	observedLease *Lease
+	observedTime  time.Time
+}
+
+func NewElector(client LeaseClientInterface, name string, identity string, leaseDuration time.Duration,
+	renewDeadline time.Duration, retryPeriod time.Duration, time util.TimeInterface) *Elector {
+	return &Elector{
+		client:        client,
+		name:          name,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		renewDeadline: renewDeadline,
+		retryPeriod:   retryPeriod,
+		time:          time,
+	}
+}
+
+// NewReplicaElector creates an elector campaigning for the lease in the namespace with
+// the host name of the replica, i.e. the name of its pod, as identity.
+func NewReplicaElector(restClient rest.Interface, namespace string, name string, leaseDuration time.Duration,
+	renewDeadline time.Duration, retryPeriod time.Duration, time util.TimeInterface) (*Elector, error) {
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the identity of the replica for leader election")
+	}
+	return NewElector(NewLeaseClient(restClient, namespace), name, identity, leaseDuration, renewDeadline,
+		retryPeriod, time), nil
+}
+
+// Register adds a task run while this replica leads. The task must return once its
+// stop channel is closed. Tasks are registered before Run is called.
+func (e *Elector) Register(name string, run func(stopCh <-chan struct{})) {
+	e.tasks = append(e.tasks, task{name: name, run: run})
+}
+
+// IsLeader returns whether this replica holds the lease and runs the tasks.
+func (e *Elector) IsLeader() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.leading
+}
+
+// Run campaigns for the lease and leads while holding it, until stopCh is closed. The
+// lease is then released, so that another replica takes over without waiting for it to
+// expire.
+func (e *Elector) Run(stopCh <-chan struct{}) {
+	glog.Infof("Campaigning for lease %v as %v", e.name, e.identity)
+	for {
+		if !e.acquire(stopCh) {
+			return
+		}
+		if stopped := e.lead(stopCh); stopped {
+			e.release()
+			return
+		}
+	}
+}
+
+// acquire blocks until the lease is acquired, or until stopCh is closed.
+func (e *Elector) acquire(stopCh <-chan struct{}) bool {
+	for {
+		if e.tryAcquireOrRenew() {
+			return true
+		}
+		select {
+		case <-stopCh:
+			return false
+		case <-time.After(e.retryPeriod):
+		}
+	}
+}
+
+// lead runs the tasks and renews the lease until it's lost or stopCh is closed. It
+// returns whether stopCh was closed.
+func (e *Elector) lead(stopCh <-chan struct{}) bool {
+	glog.Infof("Acquired lease %v, running %v background tasks", e.name, len(e.tasks))
+	e.setLeading(true)
+	tasksStopCh := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, t := range e.tasks {
+		wg.Add(1)
+		go func(t task) {
+			defer wg.Done()
+			t.run(tasksStopCh)
+			glog.Infof("Stopped background task %v", t.name)
+		}(t)
+	}
+
+	stopped := false
+	renewedAt := e.time.Now()
+	for leading := true; leading; {
+		select {
+		case <-stopCh:
+			stopped, leading = true, false
+		case <-time.After(e.retryPeriod):
+			if e.tryAcquireOrRenew() {
+				renewedAt = e.time.Now()
+			} else if e.time.Now().Sub(renewedAt) >= e.renewDeadline {
+				glog.Errorf("Lost lease %v, stopping the background tasks", e.name)
+				leading = false
+			}
+		}
+	}
+	close(tasksStopCh)
+	wg.Wait()
+	e.setLeading(false)
+	return stopped
+}
+
+// tryAcquireOrRenew takes the lease if it's free or expired, or renews it if this
+// replica holds it. It returns whether this replica holds the lease.
+func (e *Elector) tryAcquireOrRenew() bool {
+	now := e.time.Now()
+	renewTime := v1.NewMicroTime(now)
+	lease, err := e.client.Get(e.name)
+	if util.IsUserErrorCodeMatch(err, codes.NotFound) {
+		lease, err = e.client.Create(&Lease{
+			ObjectMeta: v1.ObjectMeta{Name: e.name},
+			Spec: LeaseSpec{
+				HolderIdentity:       e.identity,
+				LeaseDurationSeconds: int32(e.leaseDuration / time.Second),
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		})
+		if err != nil {
+			glog.Warningf("Failed to create lease %v: %v", e.name, err)
+			return false
+		}
+		e.observe(lease, now)
+		return true
+	}
+	if err != nil {
+		glog.Warningf("Failed to get lease %v: %v", e.name, err)
+		return false
+	}
+	e.observe(lease, now)
+
+	holder := lease.Spec.HolderIdentity
+	if holder != "" && holder != e.identity && now.Before(e.observedTime.Add(e.leaseDuration)) {
+		return false
+	}
+	if holder != e.identity {
+		lease.Spec.HolderIdentity = e.identity
+		lease.Spec.AcquireTime = &renewTime
+		lease.Spec.LeaseTransitions++
+	}
+	lease.Spec.LeaseDurationSeconds = int32(e.leaseDuration / time.Second)
+	lease.Spec.RenewTime = &renewTime
+	lease, err = e.client.Update(lease)
+	if err != nil {
+		// Another replica updated the lease first.
+		glog.Warningf("Failed to update lease %v: %v", e.name, err)
+		return false
+	}
+	e.observe(lease, now)
+	return true
+}
+
+// observe records the lease as read, and when it was observed to change.
+func (e *Elector) observe(lease *Lease, now time.Time) {
+	if e.observedLease == nil || e.observedLease.ResourceVersion != lease.ResourceVersion {
+		e.observedLease = lease
+		e.observedTime = now
+	}
+}
+
+// release gives the lease up if this replica holds it.
+func (e *Elector) release() {
+	lease, err := e.client.Get(e.name)
+	if err != nil {
+		glog.Warningf("Failed to release lease %v: %v", e.name, err)
+		return
+	}
+	if lease.Spec.HolderIdentity != e.identity {
+		return
+	}
+	lease.Spec.HolderIdentity = ""
+	if _, err := e.client.Update(lease); err != nil {
+		glog.Warningf("Failed to release lease %v: %v", e.name, err)
+		return
+	}
+	glog.Infof("Released lease %v", e.name)
+}
+
+func (e *Elector) setLeading(leading bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.leading = leading
+	value := 0.0
+	if leading {
+		value = 1
+	}
+	isLeaderGauge.Set(value, e.name)
+}
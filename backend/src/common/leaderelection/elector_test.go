@@ -0,0 +1,127 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryAcquireOrRenew(t *testing.T) {
+	client := NewFakeLeaseClient()
+	a := NewElector(client, "lease", "a", 10*time.Second, 5*time.Second, time.Second, util.NewFakeTimeForEpoch())
+	b := NewElector(client, "lease", "b", 10*time.Second, 5*time.Second, time.Second, util.NewFakeTimeForEpoch())
+
+	assert.True(t, a.tryAcquireOrRenew())
+	assert.False(t, b.tryAcquireOrRenew())
+	assert.True(t, a.tryAcquireOrRenew())
+	assert.False(t, b.tryAcquireOrRenew())
+
+	// Once a stops renewing, b takes the lease over after the lease duration, as
+	// measured by its own clock.
+	acquired := 0
+	for i := 0; i < 20 && acquired == 0; i++ {
+		if b.tryAcquireOrRenew() {
+			acquired = i
+		}
+	}
+	assert.True(t, acquired >= 4, "The lease was taken over after %v attempts", acquired)
+	lease, err := client.Get("lease")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", lease.Spec.HolderIdentity)
+	assert.Equal(t, int32(1), lease.Spec.LeaseTransitions)
+	assert.Equal(t, int32(10), lease.Spec.LeaseDurationSeconds)
+	assert.False(t, a.tryAcquireOrRenew())
+}
+
+func TestRelease(t *testing.T) {
+	client := NewFakeLeaseClient()
+	a := NewElector(client, "lease", "a", time.Hour, time.Minute, time.Second, util.NewFakeTimeForEpoch())
+	b := NewElector(client, "lease", "b", time.Hour, time.Minute, time.Second, util.NewFakeTimeForEpoch())
+	assert.True(t, a.tryAcquireOrRenew())
+	assert.False(t, b.tryAcquireOrRenew())
+
+	a.release()
+	assert.True(t, b.tryAcquireOrRenew())
+	// Releasing a lease held by another replica does nothing.
+	a.release()
+	lease, err := client.Get("lease")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", lease.Spec.HolderIdentity)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	for i := 0; i < 200; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("The condition wasn't met in time")
+}
+
+func TestRun(t *testing.T) {
+	client := NewFakeLeaseClient()
+	elector := NewElector(client, "lease", "a", time.Hour, time.Minute, 10*time.Millisecond, util.NewRealTime())
+	taskStopped := make(chan struct{})
+	elector.Register("task", func(stopCh <-chan struct{}) {
+		<-stopCh
+		close(taskStopped)
+	})
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		elector.Run(stopCh)
+		close(done)
+	}()
+	waitFor(t, elector.IsLeader)
+	assert.Equal(t, float64(1), isLeaderGauge.Value("lease"))
+
+	close(stopCh)
+	<-done
+	<-taskStopped
+	assert.False(t, elector.IsLeader())
+	assert.Equal(t, float64(0), isLeaderGauge.Value("lease"))
+	lease, err := client.Get("lease")
+	assert.Nil(t, err)
+	assert.Equal(t, "", lease.Spec.HolderIdentity)
+}
+
+func TestRun_LostLease(t *testing.T) {
+	client := NewFakeLeaseClient()
+	elector := NewElector(client, "lost", "a", time.Hour, 50*time.Millisecond, 10*time.Millisecond, util.NewRealTime())
+	taskStopped := make(chan struct{})
+	elector.Register("task", func(stopCh <-chan struct{}) {
+		<-stopCh
+		close(taskStopped)
+	})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go elector.Run(stopCh)
+	waitFor(t, elector.IsLeader)
+
+	// Another replica takes the lease, e.g. because this one was partitioned away.
+	lease, err := client.Get("lost")
+	assert.Nil(t, err)
+	lease.Spec.HolderIdentity = "b"
+	_, err = client.Update(lease)
+	assert.Nil(t, err)
+
+	<-taskStopped
+	waitFor(t, func() bool { return !elector.IsLeader() })
+}
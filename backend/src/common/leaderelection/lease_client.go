@@ -0,0 +1,112 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const leaseAPIVersion = "coordination.k8s.io/v1"
+
+// Lease is a coordination.k8s.io/v1 Lease, whose type the vendored client-go lacks.
+type Lease struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec          LeaseSpec `json:"spec,omitempty"`
+}
+
+type LeaseSpec struct {
+	// The identity of the holder of the lease. Empty if the lease was released.
+	HolderIdentity       string        `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int32         `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          *v1.MicroTime `json:"acquireTime,omitempty"`
+	RenewTime            *v1.MicroTime `json:"renewTime,omitempty"`
+	LeaseTransitions     int32         `json:"leaseTransitions,omitempty"`
+}
+
+// LeaseClientInterface reads and writes the leases of a namespace. The updates are
+// rejected if the lease changed since it was read.
+type LeaseClientInterface interface {
+	// Get returns a NotFound error if the lease doesn't exist.
+	Get(name string) (*Lease, error)
+	Create(lease *Lease) (*Lease, error)
+	Update(lease *Lease) (*Lease, error)
+}
+
+// LeaseClient reads and writes the leases through the REST API of Kubernetes.
+type LeaseClient struct {
+	restClient rest.Interface
+	namespace  string
+}
+
+func NewLeaseClient(restClient rest.Interface, namespace string) *LeaseClient {
+	return &LeaseClient{restClient: restClient, namespace: namespace}
+}
+
+func (c *LeaseClient) Get(name string) (*Lease, error) {
+	return c.do(c.restClient.Get().AbsPath(c.path(name)), name)
+}
+
+func (c *LeaseClient) Create(lease *Lease) (*Lease, error) {
+	lease.APIVersion, lease.Kind = leaseAPIVersion, "Lease"
+	body, err := json.Marshal(lease)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal lease %v", lease.Name)
+	}
+	request := c.restClient.Post().AbsPath(c.path("")).SetHeader("Content-Type", "application/json").Body(body)
+	return c.do(request, lease.Name)
+}
+
+func (c *LeaseClient) Update(lease *Lease) (*Lease, error) {
+	lease.APIVersion, lease.Kind = leaseAPIVersion, "Lease"
+	body, err := json.Marshal(lease)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to marshal lease %v", lease.Name)
+	}
+	request := c.restClient.Put().AbsPath(c.path(lease.Name)).SetHeader("Content-Type", "application/json").Body(body)
+	return c.do(request, lease.Name)
+}
+
+func (c *LeaseClient) path(name string) string {
+	path := fmt.Sprintf("/apis/%s/namespaces/%s/leases", leaseAPIVersion, c.namespace)
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
+
+func (c *LeaseClient) do(request *rest.Request, name string) (*Lease, error) {
+	var statusCode int
+	body, err := request.Do().StatusCode(&statusCode).Raw()
+	switch {
+	case statusCode == http.StatusNotFound:
+		return nil, util.NewResourceNotFoundError("Lease", c.namespace+"/"+name)
+	case statusCode == http.StatusConflict:
+		return nil, util.NewAlreadyExistError("Lease %v/%v was changed concurrently", c.namespace, name)
+	case err != nil:
+		return nil, util.NewInternalServerError(err, "Failed to access lease %v/%v", c.namespace, name)
+	}
+	var lease Lease
+	if err := json.Unmarshal(body, &lease); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to parse lease %v/%v", c.namespace, name)
+	}
+	return &lease, nil
+}
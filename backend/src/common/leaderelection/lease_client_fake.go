@@ -0,0 +1,73 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// FakeLeaseClient keeps the leases in memory. Like the Kubernetes API, it rejects the
+// updates of a lease whose resource version changed since it was read.
+type FakeLeaseClient struct {
+	mutex   sync.Mutex
+	leases  map[string]Lease
+	version int
+}
+
+func NewFakeLeaseClient() *FakeLeaseClient {
+	return &FakeLeaseClient{leases: map[string]Lease{}}
+}
+
+func (c *FakeLeaseClient) Get(name string) (*Lease, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	lease, ok := c.leases[name]
+	if !ok {
+		return nil, util.NewResourceNotFoundError("Lease", name)
+	}
+	return &lease, nil
+}
+
+func (c *FakeLeaseClient) Create(lease *Lease) (*Lease, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.leases[lease.Name]; ok {
+		return nil, util.NewAlreadyExistError("Lease %v already exists", lease.Name)
+	}
+	return c.put(*lease), nil
+}
+
+func (c *FakeLeaseClient) Update(lease *Lease) (*Lease, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	stored, ok := c.leases[lease.Name]
+	if !ok {
+		return nil, util.NewResourceNotFoundError("Lease", lease.Name)
+	}
+	if stored.ResourceVersion != lease.ResourceVersion {
+		return nil, util.NewAlreadyExistError("Lease %v was changed concurrently", lease.Name)
+	}
+	return c.put(*lease), nil
+}
+
+func (c *FakeLeaseClient) put(lease Lease) *Lease {
+	c.version++
+	lease.ResourceVersion = strconv.Itoa(c.version)
+	c.leases[lease.Name] = lease
+	return &lease
+}
@@ -0,0 +1,369 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/golang/protobuf/proto"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	mlmd "github.com/kubeflow/pipelines/third_party/ml-metadata/go/ml_metadata"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const (
+	// The MLMD types of the contexts, executions and artifacts recorded for the runs.
+	RunContextTypeName = "kfp.PipelineRun"
+	ExecutionTypeName  = "kfp.ContainerExecution"
+	ArtifactTypeName   = "kfp.Artifact"
+
+	// The properties of the recorded contexts, executions and artifacts.
+	PropertyRunId           = "run_id"
+	PropertyPipelineId      = "pipeline_id"
+	PropertyExperimentId    = "experiment_id"
+	PropertyNamespace       = "namespace"
+	PropertyNodeId          = "node_id"
+	PropertyDisplayName     = "display_name"
+	PropertyTemplateName    = "template_name"
+	PropertyStartedAtInSec  = "started_at_in_sec"
+	PropertyFinishedAtInSec = "finished_at_in_sec"
+	PropertyName            = "name"
+)
+
+var (
+	runContextType = &mlmd.ContextType{
+		Name: proto.String(RunContextTypeName),
+		Properties: map[string]mlmd.PropertyType{
+			PropertyRunId:        mlmd.PropertyType_STRING,
+			PropertyPipelineId:   mlmd.PropertyType_STRING,
+			PropertyExperimentId: mlmd.PropertyType_STRING,
+			PropertyNamespace:    mlmd.PropertyType_STRING,
+		},
+	}
+	executionType = &mlmd.ExecutionType{
+		Name: proto.String(ExecutionTypeName),
+		Properties: map[string]mlmd.PropertyType{
+			PropertyRunId:           mlmd.PropertyType_STRING,
+			PropertyNodeId:          mlmd.PropertyType_STRING,
+			PropertyDisplayName:     mlmd.PropertyType_STRING,
+			PropertyTemplateName:    mlmd.PropertyType_STRING,
+			PropertyStartedAtInSec:  mlmd.PropertyType_INT,
+			PropertyFinishedAtInSec: mlmd.PropertyType_INT,
+		},
+	}
+	artifactType = &mlmd.ArtifactType{
+		Name: proto.String(ArtifactTypeName),
+		Properties: map[string]mlmd.PropertyType{
+			PropertyName: mlmd.PropertyType_STRING,
+		},
+	}
+)
+
+type MetadataStoreInterface interface {
+	// RecordWorkflow records the executions of the steps of a completed workflow, the
+	// artifacts they consumed and produced, and the context of its run.
+	RecordWorkflow(workflow *util.Workflow) error
+	// GetRunLineage returns the executions and the artifacts recorded for a run.
+	GetRunLineage(runId string) (*RunLineage, error)
+}
+
+// RunLineage is the lineage recorded in MLMD for the steps of a run.
+type RunLineage struct {
+	Executions []*mlmd.Execution
+	Artifacts  []*mlmd.Artifact
+	// The events linking the executions to the artifacts they consumed and produced.
+	Events []*mlmd.Event
+}
+
+// MetadataStore records the lineage of the runs in an ML Metadata store.
+type MetadataStore struct {
+	client  mlmd.MetadataStoreServiceClient
+	timeout time.Duration
+
+	mutex sync.Mutex
+	// The IDs of the types, once registered.
+	runContextTypeId int64
+	executionTypeId  int64
+	artifactTypeId   int64
+}
+
+// NewMetadataStore creates a store recording the lineage through the client of an
+// MLMD server.
+func NewMetadataStore(client mlmd.MetadataStoreServiceClient, timeout time.Duration) *MetadataStore {
+	return &MetadataStore{client: client, timeout: timeout}
+}
+
+// DialMetadataStore connects to the MLMD gRPC server at address (host:port).
+func DialMetadataStore(address string, timeout time.Duration, dialOptions ...grpc.DialOption) (*MetadataStore, error) {
+	conn, err := grpc.Dial(address, append([]grpc.DialOption{grpc.WithInsecure()}, dialOptions...)...)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to connect to the metadata store at %v", address)
+	}
+	return NewMetadataStore(mlmd.NewMetadataStoreServiceClient(conn), timeout), nil
+}
+
+func (s *MetadataStore) RecordWorkflow(workflow *util.Workflow) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.registerTypes(ctx); err != nil {
+		return err
+	}
+	runId := string(workflow.UID)
+	// The context of the run is recorded after its executions and artifacts: a run whose
+	// context exists is not recorded again.
+	existing, err := s.client.GetContextByTypeAndName(ctx, &mlmd.GetContextByTypeAndNameRequest{
+		TypeName:    proto.String(RunContextTypeName),
+		ContextName: proto.String(runId),
+	})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to get the metadata context of run %v", runId)
+	}
+	if existing.GetContext() != nil {
+		return nil
+	}
+
+	executions, artifacts, events := s.lineageOfWorkflow(workflow)
+	var executionIds, artifactIds []int64
+	if len(executions) > 0 {
+		response, err := s.client.PutExecutions(ctx, &mlmd.PutExecutionsRequest{Executions: executions})
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to record the executions of run %v", runId)
+		}
+		executionIds = response.GetExecutionIds()
+	}
+	if len(artifacts) > 0 {
+		response, err := s.client.PutArtifacts(ctx, &mlmd.PutArtifactsRequest{Artifacts: artifacts})
+		if err != nil {
+			return util.NewInternalServerError(err, "Failed to record the artifacts of run %v", runId)
+		}
+		artifactIds = response.GetArtifactIds()
+	}
+	if len(executionIds) != len(executions) || len(artifactIds) != len(artifacts) {
+		return util.NewInternalServerError(fmt.Errorf("unexpected number of IDs"),
+			"Failed to record the executions and artifacts of run %v", runId)
+	}
+	// Until then, the events reference the executions and artifacts by index.
+	for _, event := range events {
+		event.ExecutionId = proto.Int64(executionIds[event.GetExecutionId()])
+		event.ArtifactId = proto.Int64(artifactIds[event.GetArtifactId()])
+	}
+	if len(events) > 0 {
+		if _, err := s.client.PutEvents(ctx, &mlmd.PutEventsRequest{Events: events}); err != nil {
+			return util.NewInternalServerError(err, "Failed to record the events of run %v", runId)
+		}
+	}
+
+	contexts, err := s.client.PutContexts(ctx, &mlmd.PutContextsRequest{Contexts: []*mlmd.Context{{
+		Name:   proto.String(runId),
+		TypeId: proto.Int64(s.runContextTypeId),
+		Properties: map[string]*mlmd.Value{
+			PropertyRunId:        stringValue(runId),
+			PropertyPipelineId:   stringValue(workflow.PipelineIdOrEmpty()),
+			PropertyExperimentId: stringValue(workflow.ExperimentIdOrEmpty()),
+			PropertyNamespace:    stringValue(workflow.Namespace),
+		},
+	}}})
+	if err == nil && len(contexts.GetContextIds()) != 1 {
+		err = fmt.Errorf("unexpected number of IDs")
+	}
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to record the metadata context of run %v", runId)
+	}
+	contextId := contexts.GetContextIds()[0]
+	links := &mlmd.PutAttributionsAndAssociationsRequest{}
+	for _, id := range executionIds {
+		links.Associations = append(links.Associations,
+			&mlmd.Association{ExecutionId: proto.Int64(id), ContextId: proto.Int64(contextId)})
+	}
+	for _, id := range artifactIds {
+		links.Attributions = append(links.Attributions,
+			&mlmd.Attribution{ArtifactId: proto.Int64(id), ContextId: proto.Int64(contextId)})
+	}
+	if _, err := s.client.PutAttributionsAndAssociations(ctx, links); err != nil {
+		return util.NewInternalServerError(err, "Failed to link the metadata of run %v to its context", runId)
+	}
+	return nil
+}
+
+// lineageOfWorkflow returns the executions of the pods of the workflow, the artifacts
+// they consumed or produced, and the events linking them. The IDs of the events are
+// the indices of their execution and artifact.
+func (s *MetadataStore) lineageOfWorkflow(workflow *util.Workflow) (
+	[]*mlmd.Execution, []*mlmd.Artifact, []*mlmd.Event) {
+	nodeIds := make([]string, 0, len(workflow.Status.Nodes))
+	for id, node := range workflow.Status.Nodes {
+		if node.Type == workflowapi.NodeTypePod {
+			nodeIds = append(nodeIds, id)
+		}
+	}
+	sort.Strings(nodeIds)
+
+	var executions []*mlmd.Execution
+	var artifacts []*mlmd.Artifact
+	var events []*mlmd.Event
+	// An artifact produced by a step and consumed by another is recorded once.
+	artifactIndexByURI := map[string]int64{}
+	addEvents := func(executionIndex int64, nodeArtifacts []workflowapi.Artifact, eventType mlmd.Event_Type,
+		timestamp int64) {
+		for _, artifact := range nodeArtifacts {
+			uri := util.ArtifactURI(artifact)
+			if uri == "" {
+				continue
+			}
+			index, ok := artifactIndexByURI[uri]
+			if !ok {
+				index = int64(len(artifacts))
+				artifactIndexByURI[uri] = index
+				artifacts = append(artifacts, &mlmd.Artifact{
+					TypeId:     proto.Int64(s.artifactTypeId),
+					Uri:        proto.String(uri),
+					Properties: map[string]*mlmd.Value{PropertyName: stringValue(artifact.Name)},
+					State:      mlmd.Artifact_LIVE.Enum(),
+				})
+			}
+			events = append(events, &mlmd.Event{
+				ExecutionId: proto.Int64(executionIndex),
+				ArtifactId:  proto.Int64(index),
+				Type:        eventType.Enum(),
+				Path: &mlmd.Event_Path{Steps: []*mlmd.Event_Path_Step{
+					{Value: &mlmd.Event_Path_Step_Key{Key: artifact.Name}},
+				}},
+				MillisecondsSinceEpoch: proto.Int64(timestamp),
+			})
+		}
+	}
+	for _, id := range nodeIds {
+		node := workflow.Status.Nodes[id]
+		executionIndex := int64(len(executions))
+		executions = append(executions, &mlmd.Execution{
+			TypeId:         proto.Int64(s.executionTypeId),
+			LastKnownState: executionState(node.Phase).Enum(),
+			Properties: map[string]*mlmd.Value{
+				PropertyRunId:           stringValue(string(workflow.UID)),
+				PropertyNodeId:          stringValue(node.ID),
+				PropertyDisplayName:     stringValue(node.DisplayName),
+				PropertyTemplateName:    stringValue(node.TemplateName),
+				PropertyStartedAtInSec:  intValue(node.StartedAt.Unix()),
+				PropertyFinishedAtInSec: intValue(node.FinishedAt.Unix()),
+			},
+		})
+		if node.Inputs != nil {
+			addEvents(executionIndex, node.Inputs.Artifacts, mlmd.Event_INPUT, node.StartedAt.Unix()*1000)
+		}
+		if node.Outputs != nil {
+			addEvents(executionIndex, node.Outputs.Artifacts, mlmd.Event_OUTPUT, node.FinishedAt.Unix()*1000)
+		}
+	}
+	return executions, artifacts, events
+}
+
+func (s *MetadataStore) GetRunLineage(runId string) (*RunLineage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	response, err := s.client.GetContextByTypeAndName(ctx, &mlmd.GetContextByTypeAndNameRequest{
+		TypeName:    proto.String(RunContextTypeName),
+		ContextName: proto.String(runId),
+	})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the metadata context of run %v", runId)
+	}
+	if response.GetContext() == nil {
+		return nil, util.NewResourceNotFoundError("Run lineage", runId)
+	}
+	contextId := response.GetContext().GetId()
+	executions, err := s.client.GetExecutionsByContext(ctx,
+		&mlmd.GetExecutionsByContextRequest{ContextId: proto.Int64(contextId)})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the executions of run %v", runId)
+	}
+	artifacts, err := s.client.GetArtifactsByContext(ctx,
+		&mlmd.GetArtifactsByContextRequest{ContextId: proto.Int64(contextId)})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the artifacts of run %v", runId)
+	}
+	lineage := &RunLineage{Executions: executions.GetExecutions(), Artifacts: artifacts.GetArtifacts()}
+	if len(lineage.Executions) == 0 {
+		return lineage, nil
+	}
+	executionIds := make([]int64, 0, len(lineage.Executions))
+	for _, execution := range lineage.Executions {
+		executionIds = append(executionIds, execution.GetId())
+	}
+	events, err := s.client.GetEventsByExecutionIDs(ctx,
+		&mlmd.GetEventsByExecutionIDsRequest{ExecutionIds: executionIds})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to get the events of run %v", runId)
+	}
+	lineage.Events = events.GetEvents()
+	return lineage, nil
+}
+
+// registerTypes registers the types of the recorded metadata, once.
+func (s *MetadataStore) registerTypes(ctx context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.runContextTypeId != 0 {
+		return nil
+	}
+	contextType, err := s.client.PutContextType(ctx,
+		&mlmd.PutContextTypeRequest{ContextType: runContextType, CanAddFields: proto.Bool(true)})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to register the metadata type %v", RunContextTypeName)
+	}
+	execution, err := s.client.PutExecutionType(ctx,
+		&mlmd.PutExecutionTypeRequest{ExecutionType: executionType, CanAddFields: proto.Bool(true)})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to register the metadata type %v", ExecutionTypeName)
+	}
+	artifact, err := s.client.PutArtifactType(ctx,
+		&mlmd.PutArtifactTypeRequest{ArtifactType: artifactType, CanAddFields: proto.Bool(true)})
+	if err != nil {
+		return util.NewInternalServerError(err, "Failed to register the metadata type %v", ArtifactTypeName)
+	}
+	s.executionTypeId = execution.GetTypeId()
+	s.artifactTypeId = artifact.GetTypeId()
+	s.runContextTypeId = contextType.GetTypeId()
+	return nil
+}
+
+func executionState(phase workflowapi.NodePhase) mlmd.Execution_State {
+	switch phase {
+	case workflowapi.NodePending:
+		return mlmd.Execution_NEW
+	case workflowapi.NodeRunning:
+		return mlmd.Execution_RUNNING
+	case workflowapi.NodeSucceeded:
+		return mlmd.Execution_COMPLETE
+	case workflowapi.NodeFailed, workflowapi.NodeError:
+		return mlmd.Execution_FAILED
+	case workflowapi.NodeSkipped:
+		return mlmd.Execution_CANCELED
+	default:
+		return mlmd.Execution_UNKNOWN
+	}
+}
+
+func stringValue(value string) *mlmd.Value {
+	return &mlmd.Value{Value: &mlmd.Value_StringValue{StringValue: value}}
+}
+
+func intValue(value int64) *mlmd.Value {
+	return &mlmd.Value{Value: &mlmd.Value_IntValue{IntValue: value}}
+}
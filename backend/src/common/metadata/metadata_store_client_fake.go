@@ -0,0 +1,207 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	mlmd "github.com/kubeflow/pipelines/third_party/ml-metadata/go/ml_metadata"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// FakeMetadataStoreClient is an in-memory MLMD server.
+type FakeMetadataStoreClient struct {
+	mutex        sync.Mutex
+	lastId       int64
+	typeIds      map[string]int64
+	executions   []*mlmd.Execution
+	artifacts    []*mlmd.Artifact
+	events       []*mlmd.Event
+	contexts     []*mlmd.Context
+	attributions []*mlmd.Attribution
+	associations []*mlmd.Association
+}
+
+func NewFakeMetadataStoreClient() *FakeMetadataStoreClient {
+	return &FakeMetadataStoreClient{typeIds: map[string]int64{}}
+}
+
+// NewFakeMetadataStore creates a MetadataStore backed by an in-memory MLMD server.
+func NewFakeMetadataStore() *MetadataStore {
+	return NewMetadataStore(NewFakeMetadataStoreClient(), time.Minute)
+}
+
+func (c *FakeMetadataStoreClient) nextId() int64 {
+	c.lastId++
+	return c.lastId
+}
+
+func (c *FakeMetadataStoreClient) putType(name string) int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if id, ok := c.typeIds[name]; ok {
+		return id
+	}
+	c.typeIds[name] = c.nextId()
+	return c.typeIds[name]
+}
+
+func (c *FakeMetadataStoreClient) PutArtifactType(ctx context.Context, in *mlmd.PutArtifactTypeRequest,
+	opts ...grpc.CallOption) (*mlmd.PutArtifactTypeResponse, error) {
+	return &mlmd.PutArtifactTypeResponse{TypeId: proto.Int64(c.putType(in.GetArtifactType().GetName()))}, nil
+}
+
+func (c *FakeMetadataStoreClient) PutExecutionType(ctx context.Context, in *mlmd.PutExecutionTypeRequest,
+	opts ...grpc.CallOption) (*mlmd.PutExecutionTypeResponse, error) {
+	return &mlmd.PutExecutionTypeResponse{TypeId: proto.Int64(c.putType(in.GetExecutionType().GetName()))}, nil
+}
+
+func (c *FakeMetadataStoreClient) PutContextType(ctx context.Context, in *mlmd.PutContextTypeRequest,
+	opts ...grpc.CallOption) (*mlmd.PutContextTypeResponse, error) {
+	return &mlmd.PutContextTypeResponse{TypeId: proto.Int64(c.putType(in.GetContextType().GetName()))}, nil
+}
+
+func (c *FakeMetadataStoreClient) PutArtifacts(ctx context.Context, in *mlmd.PutArtifactsRequest,
+	opts ...grpc.CallOption) (*mlmd.PutArtifactsResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &mlmd.PutArtifactsResponse{}
+	for _, artifact := range in.GetArtifacts() {
+		artifact = proto.Clone(artifact).(*mlmd.Artifact)
+		artifact.Id = proto.Int64(c.nextId())
+		c.artifacts = append(c.artifacts, artifact)
+		response.ArtifactIds = append(response.ArtifactIds, artifact.GetId())
+	}
+	return response, nil
+}
+
+func (c *FakeMetadataStoreClient) PutExecutions(ctx context.Context, in *mlmd.PutExecutionsRequest,
+	opts ...grpc.CallOption) (*mlmd.PutExecutionsResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &mlmd.PutExecutionsResponse{}
+	for _, execution := range in.GetExecutions() {
+		execution = proto.Clone(execution).(*mlmd.Execution)
+		execution.Id = proto.Int64(c.nextId())
+		c.executions = append(c.executions, execution)
+		response.ExecutionIds = append(response.ExecutionIds, execution.GetId())
+	}
+	return response, nil
+}
+
+func (c *FakeMetadataStoreClient) PutEvents(ctx context.Context, in *mlmd.PutEventsRequest,
+	opts ...grpc.CallOption) (*mlmd.PutEventsResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, event := range in.GetEvents() {
+		c.events = append(c.events, proto.Clone(event).(*mlmd.Event))
+	}
+	return &mlmd.PutEventsResponse{}, nil
+}
+
+func (c *FakeMetadataStoreClient) PutContexts(ctx context.Context, in *mlmd.PutContextsRequest,
+	opts ...grpc.CallOption) (*mlmd.PutContextsResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &mlmd.PutContextsResponse{}
+	for _, metadataContext := range in.GetContexts() {
+		metadataContext = proto.Clone(metadataContext).(*mlmd.Context)
+		metadataContext.Id = proto.Int64(c.nextId())
+		c.contexts = append(c.contexts, metadataContext)
+		response.ContextIds = append(response.ContextIds, metadataContext.GetId())
+	}
+	return response, nil
+}
+
+func (c *FakeMetadataStoreClient) PutAttributionsAndAssociations(ctx context.Context,
+	in *mlmd.PutAttributionsAndAssociationsRequest,
+	opts ...grpc.CallOption) (*mlmd.PutAttributionsAndAssociationsResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.attributions = append(c.attributions, in.GetAttributions()...)
+	c.associations = append(c.associations, in.GetAssociations()...)
+	return &mlmd.PutAttributionsAndAssociationsResponse{}, nil
+}
+
+func (c *FakeMetadataStoreClient) GetContextByTypeAndName(ctx context.Context,
+	in *mlmd.GetContextByTypeAndNameRequest,
+	opts ...grpc.CallOption) (*mlmd.GetContextByTypeAndNameResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	typeId := c.typeIds[in.GetTypeName()]
+	for _, metadataContext := range c.contexts {
+		if metadataContext.GetTypeId() == typeId && metadataContext.GetName() == in.GetContextName() {
+			return &mlmd.GetContextByTypeAndNameResponse{Context: metadataContext}, nil
+		}
+	}
+	return &mlmd.GetContextByTypeAndNameResponse{}, nil
+}
+
+func (c *FakeMetadataStoreClient) GetArtifactsByContext(ctx context.Context, in *mlmd.GetArtifactsByContextRequest,
+	opts ...grpc.CallOption) (*mlmd.GetArtifactsByContextResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &mlmd.GetArtifactsByContextResponse{}
+	for _, attribution := range c.attributions {
+		if attribution.GetContextId() != in.GetContextId() {
+			continue
+		}
+		for _, artifact := range c.artifacts {
+			if artifact.GetId() == attribution.GetArtifactId() {
+				response.Artifacts = append(response.Artifacts, artifact)
+			}
+		}
+	}
+	return response, nil
+}
+
+func (c *FakeMetadataStoreClient) GetExecutionsByContext(ctx context.Context, in *mlmd.GetExecutionsByContextRequest,
+	opts ...grpc.CallOption) (*mlmd.GetExecutionsByContextResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	response := &mlmd.GetExecutionsByContextResponse{}
+	for _, association := range c.associations {
+		if association.GetContextId() != in.GetContextId() {
+			continue
+		}
+		for _, execution := range c.executions {
+			if execution.GetId() == association.GetExecutionId() {
+				response.Executions = append(response.Executions, execution)
+			}
+		}
+	}
+	return response, nil
+}
+
+func (c *FakeMetadataStoreClient) GetEventsByExecutionIDs(ctx context.Context,
+	in *mlmd.GetEventsByExecutionIDsRequest,
+	opts ...grpc.CallOption) (*mlmd.GetEventsByExecutionIDsResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	executionIds := map[int64]bool{}
+	for _, id := range in.GetExecutionIds() {
+		executionIds[id] = true
+	}
+	response := &mlmd.GetEventsByExecutionIDsResponse{}
+	for _, event := range c.events {
+		if executionIds[event.GetExecutionId()] {
+			response.Events = append(response.Events, event)
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	mlmd "github.com/kubeflow/pipelines/third_party/ml-metadata/go/ml_metadata"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func s3Artifact(name string, key string) workflowapi.Artifact {
+	return workflowapi.Artifact{Name: name, ArtifactLocation: workflowapi.ArtifactLocation{
+		S3: &workflowapi.S3Artifact{S3Bucket: workflowapi.S3Bucket{Bucket: "mlpipeline"}, Key: key},
+	}}
+}
+
+// A workflow whose "train" step consumes the output of its "preprocess" step.
+func newTwoStepWorkflow() *util.Workflow {
+	return util.NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "run-name",
+			Namespace: "kubeflow",
+			UID:       "run1",
+			Labels:    map[string]string{util.LabelKeyWorkflowPipelineId: "pipeline1"},
+		},
+		Status: workflowapi.WorkflowStatus{
+			Phase: workflowapi.NodeSucceeded,
+			Nodes: map[string]workflowapi.NodeStatus{
+				"run1": {ID: "run1", Type: workflowapi.NodeTypeDAG, Phase: workflowapi.NodeSucceeded},
+				"run1-1": {
+					ID:           "run1-1",
+					DisplayName:  "preprocess",
+					TemplateName: "preprocess",
+					Type:         workflowapi.NodeTypePod,
+					Phase:        workflowapi.NodeSucceeded,
+					StartedAt:    metav1.NewTime(time.Unix(10, 0)),
+					FinishedAt:   metav1.NewTime(time.Unix(20, 0)),
+					Outputs: &workflowapi.Outputs{Artifacts: []workflowapi.Artifact{
+						s3Artifact("data", "artifacts/run1/data.tgz"),
+					}},
+				},
+				"run1-2": {
+					ID:           "run1-2",
+					DisplayName:  "train",
+					TemplateName: "train",
+					Type:         workflowapi.NodeTypePod,
+					Phase:        workflowapi.NodeFailed,
+					StartedAt:    metav1.NewTime(time.Unix(30, 0)),
+					FinishedAt:   metav1.NewTime(time.Unix(40, 0)),
+					Inputs: &workflowapi.Inputs{Artifacts: []workflowapi.Artifact{
+						s3Artifact("data", "artifacts/run1/data.tgz"),
+					}},
+				},
+			},
+		},
+	})
+}
+
+func TestRecordWorkflow(t *testing.T) {
+	store := NewMetadataStore(NewFakeMetadataStoreClient(), time.Second)
+	assert.Nil(t, store.RecordWorkflow(newTwoStepWorkflow()))
+
+	lineage, err := store.GetRunLineage("run1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(lineage.Executions))
+	preprocess, train := lineage.Executions[0], lineage.Executions[1]
+	assert.Equal(t, "preprocess", preprocess.GetProperties()[PropertyDisplayName].GetStringValue())
+	assert.Equal(t, mlmd.Execution_COMPLETE, preprocess.GetLastKnownState())
+	assert.Equal(t, int64(20), preprocess.GetProperties()[PropertyFinishedAtInSec].GetIntValue())
+	assert.Equal(t, "train", train.GetProperties()[PropertyDisplayName].GetStringValue())
+	assert.Equal(t, mlmd.Execution_FAILED, train.GetLastKnownState())
+
+	// The artifact passed between the steps is recorded once.
+	assert.Equal(t, 1, len(lineage.Artifacts))
+	data := lineage.Artifacts[0]
+	assert.Equal(t, "s3://mlpipeline/artifacts/run1/data.tgz", data.GetUri())
+	assert.Equal(t, "data", data.GetProperties()[PropertyName].GetStringValue())
+
+	assert.Equal(t, 2, len(lineage.Events))
+	assert.Equal(t, preprocess.GetId(), lineage.Events[0].GetExecutionId())
+	assert.Equal(t, data.GetId(), lineage.Events[0].GetArtifactId())
+	assert.Equal(t, mlmd.Event_OUTPUT, lineage.Events[0].GetType())
+	assert.Equal(t, "data", lineage.Events[0].GetPath().GetSteps()[0].GetKey())
+	assert.Equal(t, train.GetId(), lineage.Events[1].GetExecutionId())
+	assert.Equal(t, data.GetId(), lineage.Events[1].GetArtifactId())
+	assert.Equal(t, mlmd.Event_INPUT, lineage.Events[1].GetType())
+}
+
+func TestRecordWorkflow_OnlyOnce(t *testing.T) {
+	client := NewFakeMetadataStoreClient()
+	store := NewMetadataStore(client, time.Second)
+	assert.Nil(t, store.RecordWorkflow(newTwoStepWorkflow()))
+	assert.Nil(t, store.RecordWorkflow(newTwoStepWorkflow()))
+
+	assert.Equal(t, 2, len(client.executions))
+	assert.Equal(t, 1, len(client.contexts))
+}
+
+func TestGetRunLineage_NotRecorded(t *testing.T) {
+	store := NewMetadataStore(NewFakeMetadataStoreClient(), time.Second)
+	_, err := store.GetRunLineage("run1")
+	assert.True(t, util.IsUserErrorCodeMatch(err, codes.NotFound))
+}
@@ -0,0 +1,332 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics is a small, dependency free implementation of labeled counters,
+// gauges and histograms that can be exposed in the Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefBuckets are the default histogram buckets, in seconds, suited to measure the
+// duration of pipeline runs.
+var DefBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200, 14400, 28800, 86400}
+
+// Metric is a named collection of time series that can be written in the
+// Prometheus text exposition format.
+type Metric interface {
+	Name() string
+	Write(w io.Writer) error
+	// setSinks sets the sinks the updates of the metric are forwarded to.
+	setSinks(sinks *sinkList)
+}
+
+type desc struct {
+	name       string
+	help       string
+	metricType string
+	labelNames []string
+	sinks      *sinkList
+}
+
+func (d *desc) Name() string {
+	return d.name
+}
+
+func (d *desc) setSinks(sinks *sinkList) {
+	d.sinks = sinks
+}
+
+// sinkLabels pairs the label names of the metric with their values.
+func (d *desc) sinkLabels(labelValues []string) []Label {
+	labels := make([]Label, len(labelValues))
+	for i, value := range labelValues {
+		labels[i] = Label{Name: d.labelNames[i], Value: value}
+	}
+	return labels
+}
+
+func (d *desc) key(labelValues []string) string {
+	if len(labelValues) != len(d.labelNames) {
+		panic(fmt.Sprintf("metric %s: expected %d label values but got %d",
+			d.name, len(d.labelNames), len(labelValues)))
+	}
+	return strings.Join(labelValues, "\xff")
+}
+
+func (d *desc) writeHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.metricType)
+	return err
+}
+
+func (d *desc) labels(labelValues []string, extraName string, extraValue string) string {
+	pairs := make([]string, 0, len(labelValues)+1)
+	for i, name := range d.labelNames {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(labelValues[i])))
+	}
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extraName, escapeLabelValue(extraValue)))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// series is a single labeled value of a counter or a gauge.
+type series struct {
+	labelValues []string
+	value       float64
+}
+
+// valueVec is the shared implementation of CounterVec and GaugeVec.
+type valueVec struct {
+	desc
+	mutex  sync.Mutex
+	series map[string]*series
+}
+
+func (v *valueVec) get(labelValues []string) *series {
+	key := v.key(labelValues)
+	s, ok := v.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		v.series[key] = s
+	}
+	return s
+}
+
+// Value returns the current value of the series with the given label values.
+func (v *valueVec) Value(labelValues ...string) float64 {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if s, ok := v.series[v.key(labelValues)]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (v *valueVec) Write(w io.Writer) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if err := v.writeHeader(w); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(v.series) {
+		s := v.series[key]
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", v.name, v.labels(s.labelValues, "", ""), formatFloat(s.value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CounterVec is a set of monotonically increasing counters partitioned by labels.
+type CounterVec struct {
+	valueVec
+}
+
+// NewCounterVec creates a counter with the given name, help text and label names.
+func NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{valueVec{
+		desc:   desc{name: name, help: help, metricType: "counter", labelNames: labelNames},
+		series: make(map[string]*series),
+	}}
+}
+
+// Inc increments the counter with the given label values by one.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter with the given label values by delta, which must not be negative.
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metric %s: counter cannot decrease", c.name))
+	}
+	c.mutex.Lock()
+	c.get(labelValues).value += delta
+	c.mutex.Unlock()
+	for _, sink := range c.sinks.get() {
+		sink.Count(c.name, delta, c.sinkLabels(labelValues))
+	}
+}
+
+// GaugeVec is a set of values that can go up and down, partitioned by labels.
+type GaugeVec struct {
+	valueVec
+}
+
+// NewGaugeVec creates a gauge with the given name, help text and label names.
+func NewGaugeVec(name string, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{valueVec{
+		desc:   desc{name: name, help: help, metricType: "gauge", labelNames: labelNames},
+		series: make(map[string]*series),
+	}}
+}
+
+// Set sets the gauge with the given label values.
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.mutex.Lock()
+	g.get(labelValues).value = value
+	g.mutex.Unlock()
+	g.notify(value, labelValues)
+}
+
+// Add adds delta, which may be negative, to the gauge with the given label values.
+func (g *GaugeVec) Add(delta float64, labelValues ...string) {
+	g.mutex.Lock()
+	s := g.get(labelValues)
+	s.value += delta
+	value := s.value
+	g.mutex.Unlock()
+	g.notify(value, labelValues)
+}
+
+func (g *GaugeVec) notify(value float64, labelValues []string) {
+	for _, sink := range g.sinks.get() {
+		sink.Gauge(g.name, value, g.sinkLabels(labelValues))
+	}
+}
+
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// HistogramVec samples observations into cumulative buckets, partitioned by labels.
+type HistogramVec struct {
+	desc
+	buckets []float64
+	mutex   sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+// NewHistogramVec creates a histogram with the given name, help text, bucket upper
+// bounds and label names. DefBuckets is used if no bucket is provided.
+func NewHistogramVec(name string, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &HistogramVec{
+		desc:    desc{name: name, help: help, metricType: "histogram", labelNames: labelNames},
+		buckets: sorted,
+		series:  make(map[string]*histogramSeries),
+	}
+}
+
+// Observe adds a single observation to the histogram with the given label values.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.mutex.Lock()
+	key := h.key(labelValues)
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+	for i, upperBound := range h.buckets {
+		if value <= upperBound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += value
+	h.mutex.Unlock()
+	for _, sink := range h.sinks.get() {
+		sink.Observe(h.name, value, h.sinkLabels(labelValues))
+	}
+}
+
+// Count returns the number of observations of the histogram with the given label values.
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if s, ok := h.series[h.key(labelValues)]; ok {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) Write(w io.Writer) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if err := h.writeHeader(w); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+		for i, upperBound := range h.buckets {
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n",
+				h.name, h.labels(s.labelValues, "le", formatFloat(upperBound)), s.counts[i]); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, h.labels(s.labelValues, "le", "+Inf"), s.count,
+			h.name, h.labels(s.labelValues, "", ""), formatFloat(s.sum),
+			h.name, h.labels(s.labelValues, "", ""), s.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch typed := m.(type) {
+	case map[string]*series:
+		for k := range typed {
+			keys = append(keys, k)
+		}
+	case map[string]*histogramSeries:
+		for k := range typed {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(s)
+}
@@ -0,0 +1,117 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterVec(t *testing.T) {
+	counter := NewCounterVec("runs_total", "Number of runs.", "pipeline", "status")
+	counter.Inc("p1", "Succeeded")
+	counter.Inc("p1", "Succeeded")
+	counter.Add(3, "p2", "Failed")
+
+	assert.Equal(t, 2.0, counter.Value("p1", "Succeeded"))
+	assert.Equal(t, 3.0, counter.Value("p2", "Failed"))
+	assert.Equal(t, 0.0, counter.Value("p2", "Succeeded"))
+
+	var buffer bytes.Buffer
+	assert.Nil(t, counter.Write(&buffer))
+	assert.Equal(t, `# HELP runs_total Number of runs.
+# TYPE runs_total counter
+runs_total{pipeline="p1",status="Succeeded"} 2
+runs_total{pipeline="p2",status="Failed"} 3
+`, buffer.String())
+}
+
+func TestCounterVec_WrongLabelCount(t *testing.T) {
+	counter := NewCounterVec("runs_total", "Number of runs.", "pipeline")
+	assert.Panics(t, func() { counter.Inc("p1", "extra") })
+}
+
+func TestGaugeVec(t *testing.T) {
+	gauge := NewGaugeVec("active_runs", "Number of active runs.")
+	gauge.Set(5)
+	gauge.Add(-2)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, gauge.Write(&buffer))
+	assert.Equal(t, `# HELP active_runs Number of active runs.
+# TYPE active_runs gauge
+active_runs 3
+`, buffer.String())
+}
+
+func TestHistogramVec(t *testing.T) {
+	histogram := NewHistogramVec("run_duration_seconds", "Run duration.", []float64{10, 1}, "pipeline")
+	histogram.Observe(0.5, "p\"1")
+	histogram.Observe(5, "p\"1")
+	histogram.Observe(50, "p\"1")
+
+	assert.Equal(t, uint64(3), histogram.Count("p\"1"))
+
+	var buffer bytes.Buffer
+	assert.Nil(t, histogram.Write(&buffer))
+	assert.Equal(t, `# HELP run_duration_seconds Run duration.
+# TYPE run_duration_seconds histogram
+run_duration_seconds_bucket{pipeline="p\"1",le="1"} 1
+run_duration_seconds_bucket{pipeline="p\"1",le="10"} 2
+run_duration_seconds_bucket{pipeline="p\"1",le="+Inf"} 3
+run_duration_seconds_sum{pipeline="p\"1"} 55.5
+run_duration_seconds_count{pipeline="p\"1"} 3
+`, buffer.String())
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	registry := NewRegistry()
+	counter := NewCounterVec("b_total", "B.")
+	gauge := NewGaugeVec("a", "A.")
+	registry.MustRegister(counter, gauge)
+	counter.Inc()
+	gauge.Set(1)
+
+	assert.NotNil(t, registry.Register(NewGaugeVec("a", "Duplicate.")))
+
+	recorder := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, textContentType, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP a A.
+# TYPE a gauge
+a 1
+# HELP b_total B.
+# TYPE b_total counter
+b_total 1
+`, recorder.Body.String())
+}
+
+func TestWorkqueueMetricsProvider(t *testing.T) {
+	provider := NewWorkqueueMetricsProvider(NewRegistry())
+	depth := provider.NewDepthMetric("Workflow")
+	depth.Inc()
+	depth.Inc()
+	depth.Dec()
+	provider.NewAddsMetric("Workflow").Inc()
+	provider.NewLatencyMetric("Workflow").Observe(2e6)
+
+	assert.Equal(t, 1.0, provider.depth.Value("Workflow"))
+	assert.Equal(t, 1.0, provider.adds.Value("Workflow"))
+	assert.Equal(t, uint64(1), provider.latency.Count("Workflow"))
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+const textContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Registry holds the metrics exposed by a binary.
+type Registry struct {
+	mutex   sync.Mutex
+	metrics map[string]Metric
+	sinks   *sinkList
+}
+
+// DefaultRegistry is the registry used by the package level functions.
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]Metric), sinks: &sinkList{}}
+}
+
+// Register adds a metric to the registry. It fails if a metric with the same name
+// is already registered.
+func (r *Registry) Register(metric Metric) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.metrics[metric.Name()]; ok {
+		return fmt.Errorf("metric %s is already registered", metric.Name())
+	}
+	r.metrics[metric.Name()] = metric
+	metric.setSinks(r.sinks)
+	return nil
+}
+
+// AddSink forwards the updates of the registered metrics, including the ones registered
+// before, to a sink.
+func (r *Registry) AddSink(sink Sink) {
+	r.sinks.add(sink)
+}
+
+// MustRegister registers the metrics and panics on failure.
+func (r *Registry) MustRegister(metrics ...Metric) {
+	for _, metric := range metrics {
+		if err := r.Register(metric); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Write writes all the registered metrics, sorted by name, in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mutex.Lock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	metrics := make([]Metric, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		metrics = append(metrics, r.metrics[name])
+	}
+	r.mutex.Unlock()
+
+	for _, metric := range metrics {
+		if err := metric.Write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler returns an http handler serving the registered metrics.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var buffer bytes.Buffer
+		if err := r.Write(&buffer); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", textContentType)
+		w.Write(buffer.Bytes())
+	})
+}
+
+// MustRegister registers the metrics to the default registry and panics on failure.
+func MustRegister(metrics ...Metric) {
+	DefaultRegistry.MustRegister(metrics...)
+}
+
+// AddSink forwards the updates of the metrics of the default registry to a sink.
+func AddSink(sink Sink) {
+	DefaultRegistry.AddSink(sink)
+}
+
+// Handler returns an http handler serving the metrics of the default registry.
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
+
+// ListenAndServe serves the metrics of the default registry on the /metrics path of
+// the given address. It is meant to be run in its own goroutine.
+func ListenAndServe(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	glog.Infof("Serving metrics on %s/metrics", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		glog.Errorf("Failed to serve metrics on %s: %v", address, err)
+	}
+}
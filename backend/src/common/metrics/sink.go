@@ -0,0 +1,85 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Names of the exporters of the metrics, in addition to the Prometheus endpoint.
+const (
+	ExporterPrometheus = "prometheus"
+	ExporterStatsD     = "statsd"
+	ExporterDogStatsD  = "dogstatsd"
+)
+
+// Label is the name and the value of a label of a time series.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sink receives every update of the metrics of a registry, to forward it to a
+// monitoring system that doesn't scrape the Prometheus endpoint. Its methods must not
+// block.
+type Sink interface {
+	// Count is called when a counter is incremented by delta.
+	Count(name string, delta float64, labels []Label)
+	// Gauge is called with the new value of a gauge.
+	Gauge(name string, value float64, labels []Label)
+	// Observe is called with each observation of a histogram.
+	Observe(name string, value float64, labels []Label)
+}
+
+// NewSink creates the sink of an exporter. It returns nil for the Prometheus exporter,
+// whose metrics are scraped rather than sent.
+func NewSink(exporter string, address string, prefix string) (Sink, error) {
+	switch exporter {
+	case ExporterPrometheus, "":
+		return nil, nil
+	case ExporterStatsD, ExporterDogStatsD:
+		sink, err := NewStatsDSink(address, prefix, exporter == ExporterDogStatsD)
+		if err != nil {
+			return nil, err
+		}
+		return sink, nil
+	default:
+		return nil, fmt.Errorf("unknown metrics exporter %q: expected %s, %s or %s",
+			exporter, ExporterPrometheus, ExporterStatsD, ExporterDogStatsD)
+	}
+}
+
+// sinkList is the set of sinks of a registry. It's shared with the registered metrics
+// so that the sinks added after they are registered receive their updates.
+type sinkList struct {
+	mutex sync.RWMutex
+	sinks []Sink
+}
+
+func (l *sinkList) add(sink Sink) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.sinks = append(l.sinks, sink)
+}
+
+func (l *sinkList) get() []Sink {
+	if l == nil {
+		return nil
+	}
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.sinks
+}
@@ -0,0 +1,174 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// The maximum size of the packets sent to the StatsD server, which fits in the
+	// usual MTU of the networks.
+	statsdMaxPacketBytes = 1432
+	statsdFlushInterval  = time.Second
+	// The number of updates buffered between two flushes. The updates are dropped
+	// when it's full, rather than blocking the instrumented code.
+	statsdQueueSize = 10000
+)
+
+var (
+	statsdNameReplacer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_")
+	// The label values are joined to the names of the StatsD metrics with dots.
+	statsdLabelValueReplacer = strings.NewReplacer(".", "_", ":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_", "\n", "_")
+	statsdTagReplacer        = strings.NewReplacer("|", "_", "#", "_", ",", "_", "\n", "_")
+)
+
+// StatsDSink sends the metrics to a StatsD server over UDP, batching the updates of
+// each flush interval in packets.
+//
+// With the DogStatsD flavor, the labels are sent as tags and the observations of the
+// histograms as histograms. Otherwise the label values are appended to the names, and
+// the observations are sent as timers in milliseconds, converted from seconds for the
+// histograms whose name ends with _seconds.
+type StatsDSink struct {
+	conn      net.Conn
+	prefix    string
+	dogStatsD bool
+	lines     chan string
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// NewStatsDSink creates a sink sending the metrics to the StatsD server at address
+// (host:port), with their names prefixed by prefix.
+func NewStatsDSink(address string, prefix string, dogStatsD bool) (*StatsDSink, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	sink := &StatsDSink{
+		conn:      conn,
+		prefix:    prefix,
+		dogStatsD: dogStatsD,
+		lines:     make(chan string, statsdQueueSize),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go sink.run()
+	return sink, nil
+}
+
+func (s *StatsDSink) Count(name string, delta float64, labels []Label) {
+	s.send(name, formatFloat(delta), "c", labels)
+}
+
+func (s *StatsDSink) Gauge(name string, value float64, labels []Label) {
+	if value < 0 && !s.dogStatsD {
+		// StatsD takes a signed value as a change of the gauge.
+		s.send(name, "0", "g", labels)
+	}
+	s.send(name, formatFloat(value), "g", labels)
+}
+
+func (s *StatsDSink) Observe(name string, value float64, labels []Label) {
+	if s.dogStatsD {
+		s.send(name, formatFloat(value), "h", labels)
+		return
+	}
+	if strings.HasSuffix(name, "_seconds") {
+		name = strings.TrimSuffix(name, "_seconds") + "_milliseconds"
+		value *= 1000
+	}
+	s.send(name, formatFloat(value), "ms", labels)
+}
+
+// Close flushes the pending updates and closes the connection to the server.
+func (s *StatsDSink) Close() error {
+	close(s.done)
+	<-s.stopped
+	return s.conn.Close()
+}
+
+func (s *StatsDSink) send(name string, value string, metricType string, labels []Label) {
+	line := s.prefix + statsdNameReplacer.Replace(name)
+	var tags []string
+	for _, label := range labels {
+		if s.dogStatsD {
+			tags = append(tags, statsdTagReplacer.Replace(label.Name)+":"+statsdTagReplacer.Replace(label.Value))
+		} else {
+			line += "." + statsdLabelValueReplacer.Replace(label.Value)
+		}
+	}
+	line += ":" + value + "|" + metricType
+	if len(tags) > 0 {
+		line += "|#" + strings.Join(tags, ",")
+	}
+	select {
+	case s.lines <- line:
+	default:
+		glog.Warningf("Dropped the update of metric %s: the StatsD queue is full", name)
+	}
+}
+
+func (s *StatsDSink) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(statsdFlushInterval)
+	defer ticker.Stop()
+	var packet bytes.Buffer
+	for {
+		select {
+		case line := <-s.lines:
+			s.append(&packet, line)
+		case <-ticker.C:
+			s.flush(&packet)
+		case <-s.done:
+			for {
+				select {
+				case line := <-s.lines:
+					s.append(&packet, line)
+				default:
+					s.flush(&packet)
+					return
+				}
+			}
+		}
+	}
+}
+
+// append adds a line to the packet, after sending the packet if the line doesn't fit.
+func (s *StatsDSink) append(packet *bytes.Buffer, line string) {
+	if packet.Len() > 0 && packet.Len()+1+len(line) > statsdMaxPacketBytes {
+		s.flush(packet)
+	}
+	if packet.Len() > 0 {
+		packet.WriteByte('\n')
+	}
+	packet.WriteString(line)
+}
+
+func (s *StatsDSink) flush(packet *bytes.Buffer) {
+	if packet.Len() == 0 {
+		return
+	}
+	if _, err := s.conn.Write(packet.Bytes()); err != nil {
+		glog.Warningf("Failed to send the metrics to the StatsD server: %v", err)
+	}
+	packet.Reset()
+}
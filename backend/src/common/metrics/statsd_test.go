@@ -0,0 +1,84 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Sends the updates of the metrics of a registry through a StatsD sink, and returns
+// the lines received by the server.
+func receiveStatsDLines(t *testing.T, dogStatsD bool, update func(registry *Registry)) []string {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer server.Close()
+	sink, err := NewStatsDSink(server.LocalAddr().String(), "kfp.", dogStatsD)
+	assert.Nil(t, err)
+	registry := NewRegistry()
+	registry.AddSink(sink)
+	update(registry)
+	assert.Nil(t, sink.Close())
+
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buffer := make([]byte, statsdMaxPacketBytes)
+	n, _, err := server.ReadFrom(buffer)
+	assert.Nil(t, err)
+	lines := strings.Split(string(buffer[:n]), "\n")
+	sort.Strings(lines)
+	return lines
+}
+
+func updateMetrics(registry *Registry) {
+	counter := NewCounterVec("runs_total", "Number of runs.", "pipeline")
+	gauge := NewGaugeVec("active_runs", "Number of active runs.")
+	histogram := NewHistogramVec("run_duration_seconds", "Run duration.", nil, "pipeline")
+	registry.MustRegister(counter, gauge, histogram)
+	counter.Add(2, "p.1")
+	gauge.Set(-3)
+	histogram.Observe(1.5, "p.1")
+}
+
+func TestStatsDSink(t *testing.T) {
+	lines := receiveStatsDLines(t, false, updateMetrics)
+	assert.Equal(t, []string{
+		"kfp.active_runs:-3|g",
+		"kfp.active_runs:0|g",
+		"kfp.run_duration_milliseconds.p_1:1500|ms",
+		"kfp.runs_total.p_1:2|c",
+	}, lines)
+}
+
+func TestStatsDSink_DogStatsD(t *testing.T) {
+	lines := receiveStatsDLines(t, true, updateMetrics)
+	assert.Equal(t, []string{
+		"kfp.active_runs:-3|g",
+		"kfp.run_duration_seconds:1.5|h|#pipeline:p.1",
+		"kfp.runs_total:2|c|#pipeline:p.1",
+	}, lines)
+}
+
+func TestNewSink(t *testing.T) {
+	sink, err := NewSink(ExporterPrometheus, "", "")
+	assert.Nil(t, err)
+	assert.Nil(t, sink)
+	_, err = NewSink("graphite", "", "")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Buckets, in seconds, of the time items spend in a work queue or being processed.
+var workqueueBuckets = []float64{0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600}
+
+// WorkqueueMetricsProvider exposes the depth, the latency and the retries of the
+// named client-go work queues. Install it with workqueue.SetProvider before the
+// queues are created.
+type WorkqueueMetricsProvider struct {
+	depth        *GaugeVec
+	adds         *CounterVec
+	latency      *HistogramVec
+	workDuration *HistogramVec
+	retries      *CounterVec
+}
+
+// NewWorkqueueMetricsProvider creates a provider whose metrics are registered in registry.
+func NewWorkqueueMetricsProvider(registry *Registry) *WorkqueueMetricsProvider {
+	provider := &WorkqueueMetricsProvider{
+		depth: NewGaugeVec("workqueue_depth",
+			"Current number of items waiting in the work queue.", "name"),
+		adds: NewCounterVec("workqueue_adds_total",
+			"Number of items added to the work queue.", "name"),
+		latency: NewHistogramVec("workqueue_queue_duration_seconds",
+			"Time an item stays in the work queue before being processed.", workqueueBuckets, "name"),
+		workDuration: NewHistogramVec("workqueue_work_duration_seconds",
+			"Time spent processing an item of the work queue.", workqueueBuckets, "name"),
+		retries: NewCounterVec("workqueue_retries_total",
+			"Number of items re-queued after a failure.", "name"),
+	}
+	registry.MustRegister(provider.depth, provider.adds, provider.latency, provider.workDuration, provider.retries)
+	return provider
+}
+
+func (p *WorkqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return &gauge{vec: p.depth, labelValues: []string{name}}
+}
+
+func (p *WorkqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return &counter{vec: p.adds, labelValues: []string{name}}
+}
+
+func (p *WorkqueueMetricsProvider) NewLatencyMetric(name string) workqueue.SummaryMetric {
+	return &microsecondsHistogram{vec: p.latency, labelValues: []string{name}}
+}
+
+func (p *WorkqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.SummaryMetric {
+	return &microsecondsHistogram{vec: p.workDuration, labelValues: []string{name}}
+}
+
+func (p *WorkqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return &counter{vec: p.retries, labelValues: []string{name}}
+}
+
+type gauge struct {
+	vec         *GaugeVec
+	labelValues []string
+}
+
+func (g *gauge) Inc() { g.vec.Add(1, g.labelValues...) }
+func (g *gauge) Dec() { g.vec.Add(-1, g.labelValues...) }
+
+type counter struct {
+	vec         *CounterVec
+	labelValues []string
+}
+
+func (c *counter) Inc() { c.vec.Inc(c.labelValues...) }
+
+// The work queues report durations in microseconds.
+type microsecondsHistogram struct {
+	vec         *HistogramVec
+	labelValues []string
+}
+
+func (h *microsecondsHistogram) Observe(microseconds float64) {
+	h.vec.Observe(microseconds/1e6, h.labelValues...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reload
+
+import (
+	"bytes"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// FileWatcher polls a file and passes its content to a handler when it changes. It polls
+// instead of watching the file system events, since the files mounted from a ConfigMap are
+// updated by swapping a symbolic link of their directory.
+type FileWatcher struct {
+	path     string
+	interval time.Duration
+	onChange func(content []byte) error
+	// The content of the file at the last check, nil before the first.
+	content []byte
+}
+
+func NewFileWatcher(path string, interval time.Duration, onChange func(content []byte) error) *FileWatcher {
+	return &FileWatcher{path: path, interval: interval, onChange: onChange}
+}
+
+// Check reads the file, and passes its content to the handler on the first check or if it
+// changed since the last one. A content the handler failed on isn't passed again until it
+// changes, since the handler would fail on it again. Check isn't safe to call concurrently.
+func (w *FileWatcher) Check() error {
+	content, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	if w.content != nil && bytes.Equal(content, w.content) {
+		return nil
+	}
+	w.content = content
+	return w.onChange(content)
+}
+
+// Run checks the file every interval until stopCh is closed.
+func (w *FileWatcher) Run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.Check(); err != nil {
+				glog.Errorf("Failed to reload %s: %v", w.path, err)
+			}
+		case <-stopCh:
+			return
+		}
+	}
+}
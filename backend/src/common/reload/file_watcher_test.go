@@ -0,0 +1,83 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reload
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWatcher_Check(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("v1"), 0644))
+	var contents []string
+	watcher := NewFileWatcher(path, time.Hour, func(content []byte) error {
+		contents = append(contents, string(content))
+		if string(content) == "invalid" {
+			return errors.New("invalid content")
+		}
+		return nil
+	})
+
+	assert.Nil(t, watcher.Check())
+	assert.Nil(t, watcher.Check())
+	assert.Nil(t, ioutil.WriteFile(path, []byte("invalid"), 0644))
+	assert.NotNil(t, watcher.Check())
+	// The content is passed again once it changes.
+	assert.Nil(t, watcher.Check())
+	assert.Nil(t, ioutil.WriteFile(path, []byte("v2"), 0644))
+	assert.Nil(t, watcher.Check())
+	assert.Equal(t, []string{"v1", "invalid", "v2"}, contents)
+
+	assert.Nil(t, os.Remove(path))
+	assert.NotNil(t, watcher.Check())
+}
+
+func TestFileWatcher_Run(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config.json")
+	assert.Nil(t, ioutil.WriteFile(path, []byte("v1"), 0644))
+	changed := make(chan string, 10)
+	watcher := NewFileWatcher(path, time.Millisecond, func(content []byte) error {
+		changed <- string(content)
+		return nil
+	})
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		watcher.Run(stopCh)
+		close(done)
+	}()
+
+	assert.Equal(t, "v1", <-changed)
+	// The file is replaced, like the files mounted from a ConfigMap, so that it isn't read
+	// half written.
+	assert.Nil(t, ioutil.WriteFile(path+".new", []byte("v2"), 0644))
+	assert.Nil(t, os.Rename(path+".new", path))
+	assert.Equal(t, "v2", <-changed)
+	close(stopCh)
+	<-done
+}
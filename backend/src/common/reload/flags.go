@@ -0,0 +1,83 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reload
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// RegisterFlags registers the flags of a binary, only changed by a restart unless they're
+// registered again with an ApplyFunc.
+func (r *Registry) RegisterFlags(flags *flag.FlagSet) {
+	flags.VisitAll(func(f *flag.Flag) {
+		r.Register(f.Name, f.Value.String(), nil)
+	})
+}
+
+// SetFlags sets the flags set by a JSON object, e.g. {"numWorker": 20}, at startup. Those set
+// by the object override those of the command line.
+func SetFlags(flags *flag.FlagSet, content []byte) error {
+	values, err := ParseJSON(content)
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		if flags.Lookup(name) == nil {
+			return fmt.Errorf("unknown flag %s", name)
+		}
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("invalid value %q of flag %s: %v", value, name, err)
+		}
+	}
+	return nil
+}
+
+// UpdateFlags applies the values of the flags set by a JSON object, e.g.
+// {"workflowGCGracePeriod": "1h"}, which changed.
+func (r *Registry) UpdateFlags(flags *flag.FlagSet, content []byte) error {
+	values, err := ParseJSON(content)
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		if f := flags.Lookup(name); f != nil {
+			values[name] = normalizeFlagValue(f, value)
+		}
+	}
+	return r.Update(values)
+}
+
+// normalizeFlagValue formats a value the way its flag formats its own, e.g. 24h as 24h0m0s,
+// so that the values which didn't change are recognized.
+func normalizeFlagValue(f *flag.Flag, value string) string {
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return value
+	}
+	switch getter.Get().(type) {
+	case time.Duration:
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration.String()
+		}
+	case bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	}
+	return value
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reload
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateFlags(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Duration("gracePeriod", 24*time.Hour, "")
+	flags.Duration("timeout", time.Minute, "")
+	flags.Bool("archiveLogs", false, "")
+	flags.Int("numWorker", 10, "")
+	registry := NewRegistry()
+	registry.RegisterFlags(flags)
+	var gracePeriod time.Duration
+	registry.Register("gracePeriod", "24h0m0s", Duration(func(value time.Duration) error {
+		gracePeriod = value
+		return nil
+	}))
+
+	// The values which didn't change, formatted differently, are left as they are.
+	err := registry.UpdateFlags(flags, []byte(`{"gracePeriod": "1h", "timeout": "1m", "archiveLogs": "0",
+		"numWorker": 10, "unknown": "value"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, gracePeriod)
+	expected := map[string]string{
+		"gracePeriod": "1h0m0s",
+		"timeout":     "1m0s",
+		"archiveLogs": "false",
+		"numWorker":   "10",
+	}
+	assert.Equal(t, expected, registry.Effective())
+
+	err = registry.UpdateFlags(flags, []byte(`{"numWorker": 20}`))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "numWorker can't be changed without a restart")
+	err = registry.UpdateFlags(flags, []byte(`invalid`))
+	assert.NotNil(t, err)
+}
+
+func TestSetFlags(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	gracePeriod := flags.Duration("gracePeriod", 24*time.Hour, "")
+	numWorker := flags.Int("numWorker", 10, "")
+
+	assert.Nil(t, SetFlags(flags, []byte(`{"gracePeriod": "1h", "numWorker": 20}`)))
+	assert.Equal(t, time.Hour, *gracePeriod)
+	assert.Equal(t, 20, *numWorker)
+
+	err := SetFlags(flags, []byte(`{"numWorkers": 20}`))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unknown flag numWorkers")
+	err = SetFlags(flags, []byte(`{"numWorker": "many"}`))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid value")
+}
@@ -0,0 +1,202 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package reload applies the settings of a binary which are safe to change without a
+// restart when its configuration file, e.g. mounted from a ConfigMap, changes, and reports
+// the effective value of its settings.
+package reload
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// The value reported for the settings holding credentials.
+const redactedValue = "<redacted>"
+
+// The words in the name of the settings holding credentials, in lower case.
+var secretWords = []string{"secret", "password", "accesskey", "token", "datasourcename"}
+
+// ApplyFunc applies a new value of a setting. The setting keeps its previous value if it fails.
+type ApplyFunc func(value string) error
+
+type setting struct {
+	name  string
+	value string
+	apply ApplyFunc
+}
+
+// Registry holds the effective value of the settings of a binary, and applies the changes
+// of those which can be reloaded. The names of the settings are case insensitive, like the
+// keys of viper.
+type Registry struct {
+	mutex sync.Mutex
+	// The settings by lower case name.
+	settings map[string]*setting
+}
+
+func NewRegistry() *Registry {
+	return &Registry{settings: make(map[string]*setting)}
+}
+
+// Register registers a setting along with its current value, replacing the setting of the
+// same name if any. A nil apply means the setting is only changed by a restart.
+func (r *Registry) Register(name string, value string, apply ApplyFunc) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.settings[strings.ToLower(name)] = &setting{name: name, value: value, apply: apply}
+}
+
+// Names returns the names of the registered settings, sorted.
+func (r *Registry) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	names := make([]string, 0, len(r.settings))
+	for _, s := range r.settings {
+		names = append(names, s.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Update applies the values of the settings which changed. The settings missing from the
+// values, or not registered, are left as they are. It returns the errors of the settings
+// which couldn't be changed, including those which are only changed by a restart.
+func (r *Registry) Update(values map[string]string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []string
+	for _, name := range names {
+		s, ok := r.settings[strings.ToLower(name)]
+		value := values[name]
+		if !ok || s.value == value {
+			continue
+		}
+		if s.apply == nil {
+			errs = append(errs, fmt.Sprintf("%s can't be changed without a restart", s.name))
+			continue
+		}
+		if err := s.apply(value); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to change %s: %v", s.name, err))
+			continue
+		}
+		s.value = value
+		glog.Infof("Reloaded the setting %s: %s", s.name, s.reportedValue())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Effective returns the effective value of the settings by name, with the credentials redacted.
+func (r *Registry) Effective() map[string]string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	values := make(map[string]string, len(r.settings))
+	for _, s := range r.settings {
+		values[s.name] = s.reportedValue()
+	}
+	return values
+}
+
+// ServeHTTP serves the effective value of the settings as a JSON object.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := json.MarshalIndent(r.Effective(), "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func (s *setting) reportedValue() string {
+	if isSecret(s.name) && s.value != "" {
+		return redactedValue
+	}
+	return s.value
+}
+
+func isSecret(name string) bool {
+	lowerName := strings.ToLower(name)
+	for _, word := range secretWords {
+		if strings.Contains(lowerName, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Duration returns an ApplyFunc applying the values parsed as durations, e.g. 30s.
+func Duration(apply func(value time.Duration) error) ApplyFunc {
+	return func(value string) error {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		return apply(duration)
+	}
+}
+
+// ParseJSON flattens a JSON object into the values of the settings it sets, named by their
+// path in the object, e.g. ObjectStoreConfig.AccessKey.
+func ParseJSON(content []byte) (map[string]string, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal(content, &object); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+	values := make(map[string]string)
+	flatten("", object, values)
+	return values, nil
+}
+
+func flatten(prefix string, object map[string]interface{}, values map[string]string) {
+	for key, value := range object {
+		if child, ok := value.(map[string]interface{}); ok {
+			flatten(prefix+key+".", child, values)
+		} else {
+			values[prefix+key] = FormatValue(value)
+		}
+	}
+}
+
+// FormatValue formats the value of a setting decoded from a configuration file: the strings
+// as they are, and the other values in JSON, e.g. 10 or ["a","b"].
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		// Not in the exponent format of the large numbers, e.g. 16777216.
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	formatted, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(formatted)
+}
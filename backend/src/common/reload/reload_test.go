@@ -0,0 +1,99 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reload
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	registry := NewRegistry()
+	var timeout time.Duration
+	registry.Register("Webhook.Timeout", "10s", Duration(func(value time.Duration) error {
+		timeout = value
+		return nil
+	}))
+	registry.Register("Port", "8888", nil)
+
+	err := registry.Update(map[string]string{"webhook.timeout": "1m", "Port": "8888", "Unknown": "value"})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, timeout)
+	assert.Equal(t, map[string]string{"Webhook.Timeout": "1m", "Port": "8888"}, registry.Effective())
+	assert.Equal(t, []string{"Port", "Webhook.Timeout"}, registry.Names())
+}
+
+func TestUpdate_KeepsValuesFailingToApply(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("Timeout", "10s", Duration(func(value time.Duration) error {
+		return nil
+	}))
+	registry.Register("Mode", "delete", func(value string) error {
+		return errors.New("unknown mode")
+	})
+	registry.Register("Port", "8888", nil)
+
+	err := registry.Update(map[string]string{"Timeout": "soon", "Mode": "drop", "Port": "8080"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to change Mode: unknown mode")
+	assert.Contains(t, err.Error(), "failed to change Timeout")
+	assert.Contains(t, err.Error(), "Port can't be changed without a restart")
+	assert.Equal(t, map[string]string{"Timeout": "10s", "Mode": "delete", "Port": "8888"}, registry.Effective())
+}
+
+func TestEffective_RedactsCredentials(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("ObjectStoreConfig.AccessKey", "minio", nil)
+	registry.Register("ObjectStoreConfig.SecretAccessKey", "minio123", nil)
+	registry.Register("DBConfig.DataSourceName", "", nil)
+	registry.Register("ObjectStoreConfig.BucketName", "mlpipeline", nil)
+
+	expected := map[string]string{
+		"ObjectStoreConfig.AccessKey":       redactedValue,
+		"ObjectStoreConfig.SecretAccessKey": redactedValue,
+		"DBConfig.DataSourceName":           "",
+		"ObjectStoreConfig.BucketName":      "mlpipeline",
+	}
+	assert.Equal(t, expected, registry.Effective())
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/config", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"ObjectStoreConfig.BucketName": "mlpipeline"`)
+	assert.NotContains(t, recorder.Body.String(), "minio123")
+}
+
+func TestParseJSON(t *testing.T) {
+	values, err := ParseJSON([]byte(`{"DBConfig": {"DriverName": "mysql", "DialTimeout": "5s"}, "Workers": 4,
+		"Enabled": true, "Users": ["alice", "bob"], "MaxSize": 16777216}`))
+	assert.Nil(t, err)
+	expected := map[string]string{
+		"DBConfig.DriverName":  "mysql",
+		"DBConfig.DialTimeout": "5s",
+		"Workers":              "4",
+		"Enabled":              "true",
+		"Users":                `["alice","bob"]`,
+		"MaxSize":              "16777216",
+	}
+	assert.Equal(t, expected, values)
+
+	_, err = ParseJSON([]byte(`["not", "an", "object"]`))
+	assert.NotNil(t, err)
+}
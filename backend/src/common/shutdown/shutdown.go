@@ -0,0 +1,124 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shutdown coordinates the graceful shutdown of a binary: the servers stop
+// accepting requests and drain the in-flight ones, then the background workers stop,
+// all within a single drain timeout.
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Step stops a component. It should return promptly once the context is done.
+type Step func(ctx context.Context) error
+
+type namedStep struct {
+	name string
+	step Step
+}
+
+// Coordinator runs the steps of the shutdown in the order they are registered, so
+// that a component is stopped before the ones it depends on.
+type Coordinator struct {
+	drainTimeout time.Duration
+
+	mutex        sync.Mutex
+	steps        []namedStep
+	shuttingDown bool
+}
+
+func NewCoordinator(drainTimeout time.Duration) *Coordinator {
+	return &Coordinator{drainTimeout: drainTimeout}
+}
+
+// Register adds a step to the shutdown.
+func (c *Coordinator) Register(name string, step Step) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.steps = append(c.steps, namedStep{name: name, step: step})
+}
+
+// Shutdown runs the steps, sharing the drain timeout. A step is still run when the
+// previous ones failed or timed out, with the remainder of the timeout.
+func (c *Coordinator) Shutdown() error {
+	c.mutex.Lock()
+	if c.shuttingDown {
+		c.mutex.Unlock()
+		return fmt.Errorf("the shutdown already started")
+	}
+	c.shuttingDown = true
+	steps := c.steps
+	c.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.drainTimeout)
+	defer cancel()
+	var errs []error
+	for _, s := range steps {
+		start := time.Now()
+		if err := s.step(ctx); err != nil {
+			glog.Errorf("Failed to stop %v: %v", s.name, err)
+			errs = append(errs, fmt.Errorf("failed to stop %v: %v", s.name, err))
+			continue
+		}
+		glog.Infof("Stopped %v in %v", s.name, time.Since(start))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// GrpcServerStep stops a gRPC server once its in-flight calls complete, and cancels
+// them when the context is done.
+func GrpcServerStep(server *grpc.Server) Step {
+	return func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			server.Stop()
+			return fmt.Errorf("cancelled the in-flight calls: %v", ctx.Err())
+		}
+	}
+}
+
+// StartWorker runs a background worker until its step closes its stop channel. The
+// step waits for the worker to return.
+func StartWorker(run func(stopCh <-chan struct{})) Step {
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		run(stopCh)
+		close(done)
+	}()
+	return func(ctx context.Context) error {
+		close(stopCh)
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
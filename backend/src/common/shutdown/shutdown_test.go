@@ -0,0 +1,96 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestShutdown(t *testing.T) {
+	coordinator := NewCoordinator(time.Second)
+	var stopped []string
+	coordinator.Register("server", func(ctx context.Context) error {
+		stopped = append(stopped, "server")
+		return nil
+	})
+	coordinator.Register("queue", func(ctx context.Context) error {
+		stopped = append(stopped, "queue")
+		return fmt.Errorf("queue error")
+	})
+	coordinator.Register("database", func(ctx context.Context) error {
+		stopped = append(stopped, "database")
+		return nil
+	})
+
+	err := coordinator.Shutdown()
+	// A failed step doesn't stop the following ones.
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "failed to stop queue: queue error")
+	assert.Equal(t, []string{"server", "queue", "database"}, stopped)
+	assert.NotNil(t, coordinator.Shutdown())
+}
+
+func TestShutdown_DrainTimeout(t *testing.T) {
+	coordinator := NewCoordinator(10 * time.Millisecond)
+	coordinator.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	var remaining error
+	coordinator.Register("next", func(ctx context.Context) error {
+		remaining = ctx.Err()
+		return nil
+	})
+	err := coordinator.Shutdown()
+	assert.Contains(t, err.Error(), "failed to stop slow")
+	assert.Equal(t, context.DeadlineExceeded, remaining)
+}
+
+func TestStartWorker(t *testing.T) {
+	stopping := make(chan struct{})
+	step := StartWorker(func(stopCh <-chan struct{}) {
+		<-stopCh
+		close(stopping)
+		time.Sleep(10 * time.Millisecond)
+	})
+	assert.Nil(t, step(context.Background()))
+	<-stopping
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	step = StartWorker(func(stopCh <-chan struct{}) {
+		time.Sleep(time.Second)
+	})
+	assert.Equal(t, context.Canceled, step(ctx))
+}
+
+func TestGrpcServerStep(t *testing.T) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	assert.Nil(t, err)
+	server := grpc.NewServer()
+	served := make(chan error)
+	go func() { served <- server.Serve(listener) }()
+
+	assert.Nil(t, GrpcServerStep(server)(context.Background()))
+	// Serve returns once the server stopped.
+	<-served
+}
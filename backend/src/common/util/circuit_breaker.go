@@ -0,0 +1,110 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/pkg/errors"
+)
+
+var circuitBreakerOpenGauge = metrics.NewGaugeVec("circuit_breaker_open",
+	"Whether the circuit breaker of a dependency is open, failing the calls fast.", "name")
+
+func init() {
+	metrics.MustRegister(circuitBreakerOpenGauge)
+}
+
+// CircuitBreaker fails the calls to a dependency fast once consecutive calls failed
+// because it is unavailable, instead of letting every call wait for a timeout. Once the
+// open timeout elapses, a single call probes the dependency, closing the circuit if it
+// succeeds.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	openTimeout      time.Duration
+	now              func() time.Time
+
+	mutex    sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates the circuit breaker of the named dependency, opening after
+// failureThreshold consecutive failures. The circuit never opens if the threshold isn't
+// positive.
+func NewCircuitBreaker(name string, failureThreshold int, openTimeout time.Duration) *CircuitBreaker {
+	circuitBreakerOpenGauge.Set(0, name)
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		openTimeout:      openTimeout,
+		now:              time.Now,
+	}
+}
+
+// Allow returns an UNAVAILABLE error if the call must fail fast. Otherwise the outcome of
+// the call must be recorded with Record.
+func (b *CircuitBreaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.open {
+		return nil
+	}
+	if !b.probing && b.now().Sub(b.openedAt) >= b.openTimeout {
+		b.probing = true
+		return nil
+	}
+	return NewUnavailableError(errors.Errorf("the circuit breaker of %v is open", b.name),
+		"The %v is unavailable, retry later", b.name)
+}
+
+// Record records the outcome of an allowed call. unavailable tells whether the call failed
+// because the dependency is unavailable, the other failures closing the circuit like
+// successes.
+func (b *CircuitBreaker) Record(unavailable bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !unavailable {
+		b.failures = 0
+		if b.open {
+			glog.Infof("Closing the circuit breaker of the %v", b.name)
+			circuitBreakerOpenGauge.Set(0, b.name)
+		}
+		b.open, b.probing = false, false
+		return
+	}
+	b.failures++
+	if b.open {
+		// The probe failed.
+		b.openedAt, b.probing = b.now(), false
+	} else if b.failureThreshold > 0 && b.failures >= b.failureThreshold {
+		glog.Errorf("Opening the circuit breaker of the %v after %d consecutive failures", b.name, b.failures)
+		b.open, b.openedAt = true, b.now()
+		circuitBreakerOpenGauge.Set(1, b.name)
+	}
+}
+
+// IsOpen tells whether the calls fail fast.
+func (b *CircuitBreaker) IsOpen() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.open
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	breaker := NewCircuitBreaker("database", 2, 10*time.Second)
+	breaker.now = func() time.Time { return now }
+
+	// The failures other than the unavailability don't count.
+	assert.Nil(t, breaker.Allow())
+	breaker.Record(true)
+	assert.Nil(t, breaker.Allow())
+	breaker.Record(false)
+	assert.Nil(t, breaker.Allow())
+	breaker.Record(true)
+	assert.False(t, breaker.IsOpen())
+
+	assert.Nil(t, breaker.Allow())
+	breaker.Record(true)
+	assert.True(t, breaker.IsOpen())
+	err := breaker.Allow()
+	assert.Equal(t, codes.Unavailable, err.(*UserError).ExternalStatusCode())
+	assert.Contains(t, err.(*UserError).ExternalMessage(), "The database is unavailable")
+
+	// A single call probes the database once the open timeout elapsed.
+	now = now.Add(10 * time.Second)
+	assert.Nil(t, breaker.Allow())
+	assert.NotNil(t, breaker.Allow())
+	breaker.Record(true)
+	assert.NotNil(t, breaker.Allow())
+
+	now = now.Add(10 * time.Second)
+	assert.Nil(t, breaker.Allow())
+	breaker.Record(false)
+	assert.False(t, breaker.IsOpen())
+	assert.Nil(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	breaker := NewCircuitBreaker("database", 0, 10*time.Second)
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, breaker.Allow())
+		breaker.Record(true)
+	}
+	assert.False(t, breaker.IsOpen())
+}
@@ -39,4 +39,104 @@ const (
 	// LabelKeyWorkflowScheduledWorkflowName is a label on a Workflow.
 	// It captures whether the name of the owning ScheduledWorkflow.
 	LabelKeyWorkflowScheduledWorkflowName = constants.FullName + "/scheduledWorkflowName"
+
+	// LabelKeyWorkflowPipelineId is a label on a Workflow and a ScheduledWorkflow.
+	// It captures the ID of the pipeline the workflow was created from, if any.
+	LabelKeyWorkflowPipelineId = "pipelines.kubeflow.org/pipelineId"
+	// LabelKeyWorkflowExperimentId is a label on a Workflow and a ScheduledWorkflow.
+	// It captures the ID of the experiment the workflow belongs to, if any.
+	LabelKeyWorkflowExperimentId = "pipelines.kubeflow.org/experimentId"
+	// LabelKeyWorkflowPodGCStrategy is a label on a Workflow and a ScheduledWorkflow.
+	// It captures when the pods of the workflow are deleted once it completes, if ever.
+	LabelKeyWorkflowPodGCStrategy = "pipelines.kubeflow.org/podGCStrategy"
+	// LabelKeyWorkflowQueued is a label on a Workflow.
+	// It marks a suspended workflow waiting for the active runs of its pipeline to be
+	// fewer than the pipeline allows.
+	LabelKeyWorkflowQueued = "pipelines.kubeflow.org/queued"
+	// LabelKeyExecutionTargetKubeconfig is a label on a Secret.
+	// It marks, with "true", a Secret holding the kubeconfig of an execution target. The
+	// API server reads no other Secret.
+	LabelKeyExecutionTargetKubeconfig = "pipelines.kubeflow.org/executionTargetKubeconfig"
+
+	// The pod GC strategies of the workflows, as in Argo's spec.podGC.
+	PodGCStrategyOnWorkflowCompletion = "OnWorkflowCompletion"
+	PodGCStrategyOnWorkflowSuccess    = "OnWorkflowSuccess"
+
+	// The output artifacts read as tar.gz archives by the pipeline system, whatever the
+	// archive strategy of the workflow.
+	ArtifactNameMetrics    = "mlpipeline-metrics"
+	ArtifactNameUIMetadata = "mlpipeline-ui-metadata"
+
+	// AnnotationKeyWorkflowModels is an annotation on a Workflow.
+	// It declares the output artifacts registered as models when the workflow succeeds,
+	// as a JSON list of ModelDeclaration.
+	AnnotationKeyWorkflowModels = "pipelines.kubeflow.org/models"
+
+	// AnnotationKeyWorkflowParameters is an annotation on a Workflow.
+	// It declares the types and the allowed values of the parameters of the workflow, as
+	// a JSON list of ParameterDeclaration. It's copied to the ScheduledWorkflows of the
+	// jobs, and from them to their workflows, so that their secret parameters are known.
+	AnnotationKeyWorkflowParameters = "pipelines.kubeflow.org/parameters"
+
+	// AnnotationKeyWorkflowConcurrency is an annotation on a Workflow.
+	// It declares the max number of active runs of the pipeline, as a JSON
+	// ConcurrencyDeclaration.
+	AnnotationKeyWorkflowConcurrency = "pipelines.kubeflow.org/concurrency"
+
+	// AnnotationKeyRequestId is an annotation on the Workflows and the ScheduledWorkflows
+	// created by the API server. It's the ID of the call which created them, so that it
+	// can be traced across the components.
+	AnnotationKeyRequestId = "pipelines.kubeflow.org/request_id"
+
+	// AnnotationKeyWorkflowPodPriorityClassName and AnnotationKeyWorkflowPodPreemptionPolicy
+	// are annotations on a Workflow. They're the priority class and the preemption policy of
+	// the pods of the workflow, which the vendored schema of Argo doesn't have. They're set
+	// in the schema of the installed Argo when the workflow is created.
+	AnnotationKeyWorkflowPodPriorityClassName = "pipelines.kubeflow.org/pod_priority_class_name"
+	AnnotationKeyWorkflowPodPreemptionPolicy  = "pipelines.kubeflow.org/pod_preemption_policy"
+
+	// The types of the parameters declared in AnnotationKeyWorkflowParameters.
+	ParameterTypeString  = "string"
+	ParameterTypeInteger = "integer"
+	ParameterTypeFloat   = "float"
+	ParameterTypeBoolean = "boolean"
+	ParameterTypeJSON    = "json"
+	// A string restricted to the allowed values.
+	ParameterTypeEnum = "enum"
+	// A string without default value, which the clients shouldn't display.
+	ParameterTypeSecret = "secret"
+
+	// The prefix of the stored values of the secret parameters, versioned so that the values
+	// stored in a previous format can be told apart. Only the workflows created in the
+	// cluster have the actual values.
+	SecretParameterValuePrefix = "secret-v1:"
+	// The prefix of the stored values of the secret parameters hashed with the key of the
+	// server, followed by the hex HMAC-SHA256 of the value.
+	SecretParameterHashPrefix = SecretParameterValuePrefix + "hmac-sha256:"
+	// The stored value of the secret parameters when the server has no key to hash them with.
+	SecretParameterRedactedValue = SecretParameterValuePrefix + "redacted"
+	// The prefix of the values of the secret parameters encrypted with the key of the server
+	// until a workflow is created with them, followed by the base64 nonce and ciphertext.
+	SecretParameterEncryptionPrefix = SecretParameterValuePrefix + "aes-256-gcm:"
+	// The value of the secret parameters returned by the API.
+	MaskedParameterValue = "********"
+
+	// The policies applied to the runs created while a pipeline has its max number of
+	// active runs. The queued runs start as the active ones complete.
+	ConcurrencyPolicyQueue  = "queue"
+	ConcurrencyPolicyReject = "reject"
+
+	// The preemption policies of the pods, as named by Kubernetes.
+	PreemptionPolicyLowerPriority = "PreemptLowerPriority"
+	PreemptionPolicyNever         = "Never"
+
+	// RunConditionQueued is the condition of a run whose workflow is queued.
+	RunConditionQueued = "Queued"
+
+	// EnvKeyRunId, EnvKeyNodeId and EnvKeyMetricsPushToken are environment variables
+	// of the steps of a run. They identify the step and authenticate it when it pushes
+	// its metrics to the API server.
+	EnvKeyRunId            = "KFP_RUN_ID"
+	EnvKeyNodeId           = "KFP_NODE_ID"
+	EnvKeyMetricsPushToken = "KFP_METRICS_PUSH_TOKEN"
 )
@@ -138,12 +138,32 @@ func ExtractErrorForCLI(err error, isDebugMode bool) error {
 func NewInternalServerError(err error, internalMessageFormat string,
 	a ...interface{}) *UserError {
 	internalMessage := fmt.Sprintf(internalMessageFormat, a...)
+	// The calls to a dependency that is unavailable fail as UNAVAILABLE, so that they are retried.
+	if IsUnavailableError(err) {
+		return errors.Cause(err).(*UserError).wrapf("InternalServerError: %v", internalMessage)
+	}
 	return newUserError(
 		errors.Wrapf(err, fmt.Sprintf("InternalServerError: %v", internalMessage)),
 		"Internal Server Error",
 		codes.Internal)
 }
 
+// NewUnavailableError creates the error of a call failing fast because a dependency is
+// unavailable.
+func NewUnavailableError(err error, messageFormat string, a ...interface{}) *UserError {
+	message := fmt.Sprintf(messageFormat, a...)
+	return newUserError(
+		errors.Wrapf(err, "UnavailableError: %v", message),
+		message,
+		codes.Unavailable)
+}
+
+// IsUnavailableError tells whether an error is, or wraps, an error of NewUnavailableError.
+func IsUnavailableError(err error) bool {
+	userError, ok := errors.Cause(err).(*UserError)
+	return ok && userError.externalStatusCode == codes.Unavailable
+}
+
 func NewResourceNotFoundError(resourceType string, resourceName string) *UserError {
 	externalMessage := fmt.Sprintf("%s %s not found.", resourceType, resourceName)
 	return newUserError(
@@ -185,6 +205,30 @@ func NewBadRequestError(err error, externalFormat string, a ...interface{}) *Use
 		codes.Aborted)
 }
 
+func NewUnauthenticatedError(messageFormat string, a ...interface{}) *UserError {
+	message := fmt.Sprintf(messageFormat, a...)
+	return newUserError(errors.Errorf("Unauthenticated error: %v", message), message, codes.Unauthenticated)
+}
+
+func NewPermissionDeniedError(messageFormat string, a ...interface{}) *UserError {
+	message := fmt.Sprintf(messageFormat, a...)
+	return newUserError(errors.Errorf("Permission denied error: %v", message), message, codes.PermissionDenied)
+}
+
+// NewFailedPreconditionError creates the error of a call rejected in the current state of the
+// system, e.g. a write in read-only mode.
+func NewFailedPreconditionError(messageFormat string, a ...interface{}) *UserError {
+	message := fmt.Sprintf(messageFormat, a...)
+	return newUserError(errors.Errorf("Failed precondition error: %v", message), message, codes.FailedPrecondition)
+}
+
+// NewResourceExhaustedError creates the error of a call rejected because a resource reached
+// its limit, e.g. the max number of active runs of a pipeline.
+func NewResourceExhaustedError(messageFormat string, a ...interface{}) *UserError {
+	message := fmt.Sprintf(messageFormat, a...)
+	return newUserError(errors.Errorf("Resource exhausted error: %v", message), message, codes.ResourceExhausted)
+}
+
 func (e *UserError) ExternalMessage() string {
 	return e.externalMessage
 }
@@ -15,9 +15,11 @@
 package util
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -26,3 +28,15 @@ func TestIsNotFound(t *testing.T) {
 	assert.Equal(t, true, IsNotFound(errors.NewNotFound(schema.GroupResource{}, "NAME")))
 	assert.Equal(t, false, IsNotFound(errors.NewAlreadyExists(schema.GroupResource{}, "NAME")))
 }
+
+func TestIsUnavailableError(t *testing.T) {
+	err := NewUnavailableError(fmt.Errorf("connection refused"), "The database is unavailable")
+	assert.True(t, IsUnavailableError(err))
+	assert.True(t, IsUnavailableError(Wrap(err, "List runs failed")))
+	assert.False(t, IsUnavailableError(NewInternalServerError(fmt.Errorf("syntax error"), "Failed to list runs")))
+
+	// The internal errors wrapping an unavailable error keep its code.
+	internalError := NewInternalServerError(err, "Failed to list runs")
+	assert.Equal(t, codes.Unavailable, internalError.ExternalStatusCode())
+	assert.Contains(t, internalError.Error(), "Failed to list runs")
+}
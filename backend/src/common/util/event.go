@@ -0,0 +1,35 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+// Reasons of the Kubernetes events recorded by the ML pipeline backend on the
+// Workflow and ScheduledWorkflow objects it manages.
+const (
+	// EventReasonRunCreated is recorded on a Workflow created by the API server for a run.
+	EventReasonRunCreated = "RunCreated"
+	// EventReasonJobCreated is recorded on a ScheduledWorkflow created by the API server.
+	EventReasonJobCreated = "JobCreated"
+	// EventReasonJobEnabled is recorded on a ScheduledWorkflow enabled through the API.
+	EventReasonJobEnabled = "JobEnabled"
+	// EventReasonJobDisabled is recorded on a ScheduledWorkflow disabled through the API.
+	EventReasonJobDisabled = "JobDisabled"
+	// EventReasonWorkflowTriggered is recorded on a Workflow created by a ScheduledWorkflow.
+	EventReasonWorkflowTriggered = "WorkflowTriggered"
+	// EventReasonRunTerminated is recorded on a Workflow terminated through the API.
+	EventReasonRunTerminated = "RunTerminated"
+	// EventReasonWorkflowArchived is recorded on a Workflow labeled as archived by the
+	// persistence agent.
+	EventReasonWorkflowArchived = "WorkflowArchived"
+)
@@ -0,0 +1,173 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+)
+
+var faultsInjectedCounter = metrics.NewCounterVec("faults_injected_total",
+	"Number of faults injected in the calls to the dependencies, for resilience testing.", "operation", "fault")
+
+func init() {
+	metrics.MustRegister(faultsInjectedCounter)
+}
+
+// FaultRule picks the calls of an operation to delay or fail.
+type FaultRule struct {
+	// The operation of the calls, e.g. "db.exec", or the operations starting with a prefix
+	// if it ends with "*", e.g. "db.*".
+	Operation string
+	// The probability that a call is picked. Every call is picked if it isn't positive.
+	Probability float64
+	// The number of calls picked, after which the rule no longer applies. Unlimited if it
+	// isn't positive.
+	Count int
+	// How long the picked calls are delayed.
+	Delay time.Duration
+	// Whether the picked calls fail, after their delay.
+	Fail bool
+}
+
+func (r FaultRule) matches(operation string) bool {
+	if strings.HasSuffix(r.Operation, "*") {
+		return strings.HasPrefix(operation, strings.TrimSuffix(r.Operation, "*"))
+	}
+	return r.Operation == operation
+}
+
+// ParseFaultRules parses rules separated by ";", each of them an operation followed by
+// comma-separated settings:
+//
+//	db.*:fail,probability=0.1;objectstore.get:delay=2s,count=3
+//
+// The settings are "fail", "delay=<duration>", "probability=<float>" and "count=<int>".
+func ParseFaultRules(spec string) ([]FaultRule, error) {
+	var rules []FaultRule
+	for _, ruleSpec := range strings.Split(spec, ";") {
+		ruleSpec = strings.TrimSpace(ruleSpec)
+		if ruleSpec == "" {
+			continue
+		}
+		parts := strings.SplitN(ruleSpec, ":", 2)
+		rule := FaultRule{Operation: strings.TrimSpace(parts[0])}
+		if rule.Operation == "" {
+			return nil, NewInvalidInputError("Fault rule %q has no operation", ruleSpec)
+		}
+		if len(parts) == 1 {
+			return nil, NewInvalidInputError("Fault rule %q neither delays nor fails the calls", ruleSpec)
+		}
+		for _, setting := range strings.Split(parts[1], ",") {
+			key, value := strings.TrimSpace(setting), ""
+			if i := strings.Index(key, "="); i >= 0 {
+				key, value = strings.TrimSpace(key[:i]), strings.TrimSpace(key[i+1:])
+			}
+			var err error
+			switch key {
+			case "fail":
+				rule.Fail = true
+			case "delay":
+				rule.Delay, err = time.ParseDuration(value)
+			case "probability":
+				rule.Probability, err = strconv.ParseFloat(value, 64)
+			case "count":
+				rule.Count, err = strconv.Atoi(value)
+			default:
+				return nil, NewInvalidInputError("Fault rule %q has an unknown setting %q", ruleSpec, key)
+			}
+			if err != nil {
+				return nil, NewInvalidInputError("Fault rule %q has an invalid %v: %v", ruleSpec, key, err)
+			}
+		}
+		if !rule.Fail && rule.Delay <= 0 {
+			return nil, NewInvalidInputError("Fault rule %q neither delays nor fails the calls", ruleSpec)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// FaultInjector delays or fails the calls to the dependencies picked by its rules, to
+// test how the callers recover. The calls are picked deterministically for a seed. A nil
+// FaultInjector injects no fault, so that the callers don't need to check whether fault
+// injection is enabled.
+type FaultInjector struct {
+	mutex  sync.Mutex
+	rules  []FaultRule
+	picked []int
+	random *rand.Rand
+	sleep  func(time.Duration)
+}
+
+// NewFaultInjector creates a FaultInjector applying rules, or nil if there are none.
+func NewFaultInjector(rules []FaultRule, seed int64) *FaultInjector {
+	if len(rules) == 0 {
+		return nil
+	}
+	for _, rule := range rules {
+		glog.Warningf("Injecting faults in the calls to %v: %+v", rule.Operation, rule)
+	}
+	return &FaultInjector{
+		rules:  rules,
+		picked: make([]int, len(rules)),
+		random: rand.New(rand.NewSource(seed)),
+		sleep:  time.Sleep,
+	}
+}
+
+// Inject applies the rules matching operation to a call, sleeping for their delays, and
+// returns true if the call must fail.
+func (f *FaultInjector) Inject(operation string) bool {
+	if f == nil {
+		return false
+	}
+	f.mutex.Lock()
+	var delay time.Duration
+	fail := false
+	for i, rule := range f.rules {
+		if !rule.matches(operation) || (rule.Count > 0 && f.picked[i] >= rule.Count) {
+			continue
+		}
+		if rule.Probability > 0 && f.random.Float64() >= rule.Probability {
+			continue
+		}
+		f.picked[i]++
+		delay += rule.Delay
+		fail = fail || rule.Fail
+	}
+	f.mutex.Unlock()
+
+	if delay > 0 {
+		faultsInjectedCounter.Inc(operation, "delay")
+		f.sleep(delay)
+	}
+	if fail {
+		faultsInjectedCounter.Inc(operation, "fail")
+	}
+	return fail
+}
+
+// InjectedFaultMessage is the message of the errors of the calls failed by a FaultInjector.
+func InjectedFaultMessage(operation string) string {
+	return fmt.Sprintf("Fault injected in %v", operation)
+}
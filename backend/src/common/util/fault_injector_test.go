@@ -0,0 +1,89 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFaultRules(t *testing.T) {
+	rules, err := ParseFaultRules(" db.*:fail,probability=0.5 ; objectstore.get:delay=2s,count=3;")
+	assert.Nil(t, err)
+	assert.Equal(t, []FaultRule{
+		{Operation: "db.*", Fail: true, Probability: 0.5},
+		{Operation: "objectstore.get", Delay: 2 * time.Second, Count: 3},
+	}, rules)
+
+	rules, err = ParseFaultRules("")
+	assert.Nil(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestParseFaultRules_Invalid(t *testing.T) {
+	for _, spec := range []string{"db.exec", ":fail", "db.exec:count=3", "db.exec:fail,delay=soon", "db.exec:crash"} {
+		_, err := ParseFaultRules(spec)
+		assert.NotNil(t, err, spec)
+	}
+}
+
+func TestFaultInjector_Nil(t *testing.T) {
+	var faults *FaultInjector
+	assert.False(t, faults.Inject("db.exec"))
+	assert.Nil(t, NewFaultInjector(nil, 0))
+}
+
+func TestFaultInjector_Count(t *testing.T) {
+	faults := NewFaultInjector([]FaultRule{{Operation: "db.*", Fail: true, Count: 2}}, 0)
+	assert.False(t, faults.Inject("objectstore.get"))
+	assert.True(t, faults.Inject("db.exec"))
+	assert.True(t, faults.Inject("db.query"))
+	assert.False(t, faults.Inject("db.exec"))
+}
+
+func TestFaultInjector_Delay(t *testing.T) {
+	faults := NewFaultInjector([]FaultRule{
+		{Operation: "objectstore.get", Delay: time.Second},
+		{Operation: "objectstore.*", Delay: 2 * time.Second, Fail: true},
+	}, 0)
+	var slept []time.Duration
+	faults.sleep = func(delay time.Duration) { slept = append(slept, delay) }
+
+	assert.True(t, faults.Inject("objectstore.get"))
+	assert.True(t, faults.Inject("objectstore.put"))
+	assert.Equal(t, []time.Duration{3 * time.Second, 2 * time.Second}, slept)
+}
+
+func TestFaultInjector_ProbabilityIsDeterministic(t *testing.T) {
+	pick := func(seed int64) []bool {
+		faults := NewFaultInjector([]FaultRule{{Operation: "db.exec", Fail: true, Probability: 0.5}}, seed)
+		var picked []bool
+		for i := 0; i < 100; i++ {
+			picked = append(picked, faults.Inject("db.exec"))
+		}
+		return picked
+	}
+	picked := pick(42)
+	assert.Equal(t, picked, pick(42))
+	count := 0
+	for _, p := range picked {
+		if p {
+			count++
+		}
+	}
+	assert.True(t, count > 20 && count < 80, "%v calls failed", count)
+}
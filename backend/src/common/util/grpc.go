@@ -0,0 +1,77 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// GzipCompressorName is the name of the gzip compression of the gRPC messages. A server
+// compresses its responses with gzip when the requests are compressed with it.
+const GzipCompressorName = "gzip"
+
+func init() {
+	encoding.RegisterCompressor(&gzipCompressor{})
+}
+
+type gzipCompressor struct {
+	writers sync.Pool
+}
+
+func (c *gzipCompressor) Name() string {
+	return GzipCompressorName
+}
+
+func (c *gzipCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	writer, ok := c.writers.Get().(*gzip.Writer)
+	if !ok {
+		return &pooledGzipWriter{Writer: gzip.NewWriter(w), pool: &c.writers}, nil
+	}
+	writer.Reset(w)
+	return &pooledGzipWriter{Writer: writer, pool: &c.writers}, nil
+}
+
+func (c *gzipCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+// pooledGzipWriter returns its writer to the pool once closed.
+type pooledGzipWriter struct {
+	*gzip.Writer
+	pool *sync.Pool
+}
+
+func (w *pooledGzipWriter) Close() error {
+	defer w.pool.Put(w.Writer)
+	return w.Writer.Close()
+}
+
+// GrpcCallOptions sets the maximum size of the messages a gRPC client receives and sends, and
+// whether it compresses its requests with gzip.
+func GrpcCallOptions(maxRecvMsgSize int, maxSendMsgSize int, compress bool) grpc.DialOption {
+	options := []grpc.CallOption{
+		grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
+		grpc.MaxCallSendMsgSize(maxSendMsgSize),
+	}
+	if compress {
+		options = append(options, grpc.UseCompressor(GzipCompressorName))
+	}
+	return grpc.WithDefaultCallOptions(options...)
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestGzipCompressor(t *testing.T) {
+	compressor := encoding.GetCompressor(GzipCompressorName)
+	assert.NotNil(t, compressor)
+	message := strings.Repeat("apiVersion: argoproj.io/v1alpha1\n", 1000)
+
+	// The writers are pooled: compress twice to reuse one.
+	for i := 0; i < 2; i++ {
+		var compressed bytes.Buffer
+		writer, err := compressor.Compress(&compressed)
+		assert.Nil(t, err)
+		_, err = writer.Write([]byte(message))
+		assert.Nil(t, err)
+		assert.Nil(t, writer.Close())
+		assert.True(t, compressed.Len() < len(message)/10)
+
+		reader, err := compressor.Decompress(&compressed)
+		assert.Nil(t, err)
+		decompressed, err := ioutil.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.Equal(t, message, string(decompressed))
+	}
+}
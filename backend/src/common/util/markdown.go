@@ -0,0 +1,100 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	// The HTML elements dropped along with their content.
+	markdownDroppedElement = regexp.MustCompile(
+		`(?is)<(script|style|iframe|object|embed|noscript)\b.*?</(script|style|iframe|object|embed|noscript)\s*>`)
+	markdownHTMLComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+	markdownHTMLTag     = regexp.MustCompile(`</?[a-zA-Z][a-zA-Z0-9-]*(\s[^<>]*)?/?>`)
+	// What's left of the HTML, e.g. an unclosed tag, which is escaped. The autolinks, e.g.
+	// <https://www.kubeflow.org>, are kept.
+	markdownHTMLOpening = regexp.MustCompile(`<([/!?]|[a-zA-Z][a-zA-Z0-9-]*([\s/>]|$))`)
+	// The destinations of the inline links and images, of the link reference definitions and
+	// of the autolinks.
+	markdownInlineLink    = regexp.MustCompile(`(\]\(\s*<?)((?:[^()\s>]|\([^()\s]*\))*)`)
+	markdownReferenceLink = regexp.MustCompile(`(?m)^( {0,3}\[[^\]]+\]:[ \t]*<?)([^\s>]*)`)
+	markdownAutolink      = regexp.MustCompile(`(<)([a-zA-Z][a-zA-Z0-9+.-]*:[^\s<>]*)>`)
+	// The schemes of the URLs allowed in the links, the relative URLs being allowed too.
+	markdownSafeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+)
+
+// SanitizeMarkdown makes Markdown uploaded by users, e.g. the README of a pipeline, safe to
+// render: the raw HTML is removed, and the links to URLs of unsafe schemes, e.g. javascript:,
+// are replaced by #. The fenced code blocks are kept as is.
+func SanitizeMarkdown(markdown string) string {
+	markdown = strings.ToValidUTF8(markdown, "�")
+	var sanitized, text strings.Builder
+	var fence string
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case fence != "":
+			sanitized.WriteString(line)
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			sanitized.WriteString(sanitizeMarkdownText(text.String()))
+			text.Reset()
+			sanitized.WriteString(line)
+			fence = trimmed[:3]
+		default:
+			text.WriteString(line)
+		}
+	}
+	sanitized.WriteString(sanitizeMarkdownText(text.String()))
+	return sanitized.String()
+}
+
+// sanitizeMarkdownText sanitizes Markdown without fenced code blocks.
+func sanitizeMarkdownText(text string) string {
+	text = markdownDroppedElement.ReplaceAllString(text, "")
+	text = markdownHTMLComment.ReplaceAllString(text, "")
+	text = markdownHTMLTag.ReplaceAllString(text, "")
+	text = markdownHTMLOpening.ReplaceAllString(text, "&lt;$1")
+	for _, link := range []*regexp.Regexp{markdownInlineLink, markdownReferenceLink, markdownAutolink} {
+		link := link
+		text = link.ReplaceAllStringFunc(text, func(match string) string {
+			groups := link.FindStringSubmatch(match)
+			if isSafeMarkdownURL(groups[2]) {
+				return match
+			}
+			if link == markdownAutolink {
+				return ""
+			}
+			return groups[1] + "#" + strings.TrimPrefix(match, groups[1]+groups[2])
+		})
+	}
+	return text
+}
+
+// isSafeMarkdownURL tells whether a URL is relative or of a safe scheme, once its character
+// references, e.g. &#106;, are decoded as they will be by the renderers.
+func isSafeMarkdownURL(url string) bool {
+	url = html.UnescapeString(url)
+	i := strings.IndexAny(url, ":/?#")
+	if i < 0 || url[i] != ':' {
+		return true
+	}
+	return markdownSafeSchemes[strings.ToLower(url[:i])]
+}
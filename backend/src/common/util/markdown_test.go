@@ -0,0 +1,54 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeMarkdown(t *testing.T) {
+	readme := "# Usage\n" +
+		"<p align=\"center\"><img src=\"logo.png\" onerror=\"alert(1)\"></p>\n" +
+		"<script>alert(1)</script><!-- hidden -->\n" +
+		"Run it with [the UI](https://www.kubeflow.org/docs) or [this](javascript:alert(1)).\n" +
+		"![diagram](docs/diagram.png) <https://www.kubeflow.org> <javascript:alert(1)>\n" +
+		"[ref]: JavaScript:alert(1)\n" +
+		"<div onclick=\"alert(1)\"\n" +
+		"1 < 2\n" +
+		"```html\n" +
+		"<b>kept</b>\n" +
+		"```\n" +
+		"<i>dropped</i>\n"
+
+	assert.Equal(t, "# Usage\n"+
+		"\n"+
+		"\n"+
+		"Run it with [the UI](https://www.kubeflow.org/docs) or [this](#).\n"+
+		"![diagram](docs/diagram.png) <https://www.kubeflow.org> \n"+
+		"[ref]: #\n"+
+		"&lt;div onclick=\"alert(1)\"\n"+
+		"1 < 2\n"+
+		"```html\n"+
+		"<b>kept</b>\n"+
+		"```\n"+
+		"dropped\n", SanitizeMarkdown(readme))
+}
+
+func TestSanitizeMarkdown_EncodedScheme(t *testing.T) {
+	assert.Equal(t, "[x](#)", SanitizeMarkdown("[x](&#106;avascript:alert(1))"))
+	assert.Equal(t, "[x](mailto:team@example.com)", SanitizeMarkdown("[x](mailto:team@example.com)"))
+}
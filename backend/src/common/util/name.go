@@ -0,0 +1,63 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxNameLength is the maximum number of characters of the names of the resources, which the
+// columns of the database hold.
+const MaxNameLength = 128
+
+// The last character of the Basic Multilingual Plane, the characters the database can store.
+const maxStorableRune = '\uFFFF'
+
+// NormalizeName returns the form a name is stored in: in the Unicode normalization form C,
+// without leading and trailing spaces. The same name typed on different systems is then
+// stored, and matched, the same.
+func NormalizeName(name string) string {
+	return strings.TrimSpace(norm.NFC.String(name))
+}
+
+// ValidateName normalizes a name, then checks that it can be stored and displayed: UTF-8,
+// without control characters nor characters outside the Basic Multilingual Plane, which the
+// utf8 charset of the database can't store, and at most maxLength characters long.
+func ValidateName(name string, maxLength int) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", NewInvalidInputError("The name %q isn't valid UTF-8.", name)
+	}
+	name = NormalizeName(name)
+	if name == "" {
+		return "", NewInvalidInputError("The name is empty.")
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) {
+			return "", NewInvalidInputError("The name %q has the control character %U.", name, r)
+		}
+		if r > maxStorableRune {
+			return "", NewInvalidInputError("The name %q has the character %U, which can't be stored.", name, r)
+		}
+	}
+	if length := utf8.RuneCountInString(name); length > maxLength {
+		return "", NewInvalidInputError("The name is %v characters long, longer than the maximum of %v.",
+			length, maxLength)
+	}
+	return name, nil
+}
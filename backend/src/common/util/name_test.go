@@ -0,0 +1,44 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestNormalizeName(t *testing.T) {
+	// The "e" followed by the combining acute accent is composed into the single "é".
+	assert.Equal(t, "caf\u00e9", NormalizeName(" cafe\u0301\t"))
+	assert.Equal(t, "my pipeline", NormalizeName("my pipeline"))
+}
+
+func TestValidateName(t *testing.T) {
+	name, err := ValidateName(" 训练 cafe\u0301 ", MaxNameLength)
+	assert.Nil(t, err)
+	assert.Equal(t, "训练 caf\u00e9", name)
+
+	// The length is in characters, not bytes.
+	name, err = ValidateName(strings.Repeat("模", MaxNameLength), MaxNameLength)
+	assert.Nil(t, err)
+	assert.Equal(t, strings.Repeat("模", MaxNameLength), name)
+}
+
+func TestValidateName_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		expectedError string
+	}{
+		{" ", "The name is empty"},
+		{"\xff\xfe", "isn't valid UTF-8"},
+		{"line\nbreak", "control character U+000A"},
+		{"rocket \U0001F680", "character U+1F680, which can't be stored"},
+		{strings.Repeat("a", MaxNameLength+1), "The name is 129 characters long, longer than the maximum of 128"},
+	}
+	for _, test := range tests {
+		_, err := ValidateName(test.name, MaxNameLength)
+		assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument), test.name)
+		assert.Contains(t, err.Error(), test.expectedError, test.name)
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+)
+
+var (
+	// The references to the parameters of the workflow in its templates.
+	workflowParameterReference = regexp.MustCompile(`{{\s*workflow\.parameters\.([^}\s]+)\s*}}`)
+	// The macros substituted by the scheduled workflows, e.g. [[schedule]].
+	scheduledWorkflowMacro = regexp.MustCompile(`\[\[(.*?)\]\]`)
+)
+
+// ParameterDeclaration declares the type of a parameter of a workflow and, optionally, the
+// values it's restricted to. The declared parameters without a default value in the
+// workflow are required.
+type ParameterDeclaration struct {
+	Name string `json:"name"`
+	// One of the ParameterType constants, string if empty.
+	Type string   `json:"type,omitempty"`
+	Enum []string `json:"enum,omitempty"`
+}
+
+// ParameterDeclarations returns the parameters declared in the annotations of the workflow.
+func (w *Workflow) ParameterDeclarations() ([]ParameterDeclaration, error) {
+	return parameterDeclarations(w.Annotations)
+}
+
+func parameterDeclarations(annotations map[string]string) ([]ParameterDeclaration, error) {
+	value, ok := annotations[AnnotationKeyWorkflowParameters]
+	if !ok {
+		return nil, nil
+	}
+	var declarations []ParameterDeclaration
+	if err := json.Unmarshal([]byte(value), &declarations); err != nil {
+		return nil, NewInvalidInputErrorWithDetails(err,
+			fmt.Sprintf("Failed to parse the parameters declared in annotation %v", AnnotationKeyWorkflowParameters))
+	}
+	return declarations, nil
+}
+
+// SecretParameterNames returns the names of the parameters the workflow declares secret.
+func (w *Workflow) SecretParameterNames() map[string]bool {
+	return secretParameterNames(w.Annotations)
+}
+
+// SecretParameterValues returns the values of the secret parameters in the arguments of the
+// workflow.
+func (w *Workflow) SecretParameterValues() map[string]string {
+	secrets := w.SecretParameterNames()
+	values := make(map[string]string)
+	for _, param := range w.Spec.Arguments.Parameters {
+		if secrets[param.Name] && param.Value != nil {
+			values[param.Name] = *param.Value
+		}
+	}
+	return values
+}
+
+// secretParameterNames returns the names of the parameters declared secret in the
+// annotations. The declarations are validated when the pipelines, the runs and the jobs are
+// created, so the invalid ones are ignored.
+func secretParameterNames(annotations map[string]string) map[string]bool {
+	declarations, err := parameterDeclarations(annotations)
+	if err != nil {
+		return nil
+	}
+	var names map[string]bool
+	for _, declaration := range declarations {
+		if declaration.Type != ParameterTypeSecret {
+			continue
+		}
+		if names == nil {
+			names = make(map[string]bool)
+		}
+		names[declaration.Name] = true
+	}
+	return names
+}
+
+// The key of the HMAC of the values of the secret parameters, set by the API server.
+var secretParameterKey []byte
+
+// SetSecretParameterKey sets the server-side key the values of the secret parameters are
+// hashed with. Only the values hashed with the same key can be compared.
+func SetSecretParameterKey(key []byte) {
+	secretParameterKey = key
+}
+
+// HashSecretParameterValue returns the value of a secret parameter as it's stored: its
+// HMAC-SHA256 keyed with the key of the server, which tells whether runs used the same value
+// without revealing it, even if the value is guessable, e.g. a password. The value is
+// redacted if the server has no key. A value which looks hashed is hashed too, since only the
+// callers know whether it comes from the store.
+func HashSecretParameterValue(value string) string {
+	if len(secretParameterKey) == 0 {
+		return SecretParameterRedactedValue
+	}
+	mac := hmac.New(sha256.New, secretParameterKey)
+	mac.Write([]byte(value))
+	return SecretParameterHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsHashedSecretParameterValue returns whether a stored parameter value is the hashed or
+// redacted value of a secret parameter.
+func IsHashedSecretParameterValue(value string) bool {
+	return strings.HasPrefix(value, SecretParameterValuePrefix)
+}
+
+// HasSecretParameterKey returns whether the server has a key to hash and encrypt the values
+// of the secret parameters with.
+func HasSecretParameterKey() bool {
+	return len(secretParameterKey) > 0
+}
+
+// EncryptSecretParameterValues encrypts the values of secret parameters, to keep them until
+// a workflow is created with them. They're encrypted with AES-256-GCM, keyed with a key
+// derived from the one of the server.
+func EncryptSecretParameterValues(values map[string]string) (string, error) {
+	gcm, err := secretParameterCipher()
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return "", NewInternalServerError(err, "Failed to marshal the values of the secret parameters")
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", NewInternalServerError(err, "Failed to generate the nonce of the secret parameters")
+	}
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+	return SecretParameterEncryptionPrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptSecretParameterValues decrypts the values of secret parameters encrypted by
+// EncryptSecretParameterValues.
+func DecryptSecretParameterValues(encrypted string) (map[string]string, error) {
+	if !strings.HasPrefix(encrypted, SecretParameterEncryptionPrefix) {
+		return nil, NewInternalServerError(fmt.Errorf("unknown format"), "Failed to decrypt the values of the secret parameters")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encrypted, SecretParameterEncryptionPrefix))
+	if err != nil {
+		return nil, NewInternalServerError(err, "Failed to decode the values of the secret parameters")
+	}
+	gcm, err := secretParameterCipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, NewInternalServerError(fmt.Errorf("ciphertext too short"), "Failed to decrypt the values of the secret parameters")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, NewInternalServerError(err, "Failed to decrypt the values of the secret parameters")
+	}
+	values := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, NewInternalServerError(err, "Failed to unmarshal the values of the secret parameters")
+	}
+	return values, nil
+}
+
+// secretParameterCipher returns the cipher of the values of the secret parameters. Its key is
+// derived from the one of the server, so that it differs from the key of their HMAC.
+func secretParameterCipher() (cipher.AEAD, error) {
+	if !HasSecretParameterKey() {
+		return nil, NewFailedPreconditionError("No key is configured to encrypt the values of the secret parameters")
+	}
+	mac := hmac.New(sha256.New, secretParameterKey)
+	mac.Write([]byte("secret-parameter-encryption"))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, NewInternalServerError(err, "Failed to create the cipher of the secret parameters")
+	}
+	return cipher.NewGCM(block)
+}
+
+// MaskSecretParameters replaces the values of the secret parameters of the workflow by their
+// hashes, in its arguments and in the parameters of its nodes, which Argo passes the values
+// down to. A value embedded in a longer one, e.g. in a flag, isn't found.
+func (w *Workflow) MaskSecretParameters() {
+	secrets := w.SecretParameterNames()
+	values := make(map[string]bool)
+	for i, param := range w.Spec.Arguments.Parameters {
+		if !secrets[param.Name] || param.Value == nil {
+			continue
+		}
+		if *param.Value != "" {
+			values[*param.Value] = true
+		}
+		hashed := HashSecretParameterValue(*param.Value)
+		w.Spec.Arguments.Parameters[i].Value = &hashed
+	}
+	if len(values) == 0 {
+		return
+	}
+	mask := func(params []workflowapi.Parameter) {
+		for i, param := range params {
+			if param.Value != nil && values[*param.Value] {
+				hashed := HashSecretParameterValue(*param.Value)
+				params[i].Value = &hashed
+			}
+		}
+	}
+	for _, node := range w.Status.Nodes {
+		if node.Inputs != nil {
+			mask(node.Inputs.Parameters)
+		}
+		if node.Outputs != nil {
+			mask(node.Outputs.Parameters)
+		}
+	}
+}
+
+// ParameterSchema is a parameter declared by a workflow, with whether it's required.
+type ParameterSchema struct {
+	ParameterDeclaration
+	Required bool `json:"required,omitempty"`
+}
+
+// ParameterSchema returns the parameters declared in the annotations of the workflow, in the
+// order of their declarations. The declarations must name parameters of the workflow and
+// have known types, which their allowed and default values must match. The secret
+// parameters can't have a default value, which anyone reading the pipeline would see.
+func (w *Workflow) ParameterSchema() ([]ParameterSchema, error) {
+	declarations, err := w.ParameterDeclarations()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]*string)
+	for _, param := range w.Spec.Arguments.Parameters {
+		values[param.Name] = param.Value
+	}
+	var problems []string
+	schema := make([]ParameterSchema, 0, len(declarations))
+	for _, declaration := range declarations {
+		value, ok := values[declaration.Name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("declared parameter %v isn't a parameter of the workflow", declaration.Name))
+			continue
+		}
+		if problem := validateParameterType(declaration); problem != "" {
+			problems = append(problems, problem)
+			continue
+		}
+		if declaration.Type != ParameterTypeEnum {
+			unrestricted := ParameterDeclaration{Name: declaration.Name, Type: declaration.Type}
+			for _, allowed := range declaration.Enum {
+				if problem := validateParameterValue(unrestricted, allowed); problem != "" {
+					problems = append(problems, problem)
+				}
+			}
+		}
+		switch {
+		case value == nil:
+		case declaration.Type == ParameterTypeSecret:
+			problems = append(problems, fmt.Sprintf("secret parameter %v can't have a default value", declaration.Name))
+		case !scheduledWorkflowMacro.MatchString(*value):
+			if problem := validateParameterValue(declaration, *value); problem != "" {
+				problems = append(problems, problem)
+			}
+		}
+		schema = append(schema, ParameterSchema{ParameterDeclaration: declaration, Required: value == nil})
+	}
+	if len(problems) > 0 {
+		return nil, NewInvalidInputError("Invalid parameter declarations: %v", strings.Join(problems, "; "))
+	}
+	return schema, nil
+}
+
+// ValidateParameters validates the parameters of a run or a job against the parameters of
+// the workflow: they must be known and, if declared, required ones must be set and the
+// values must match their types and allowed values. The references to unknown parameters
+// in the templates are rejected too, as Argo would fail the workflow. All the problems are
+// reported in the same error.
+func (w *Workflow) ValidateParameters(desiredParams map[string]string) error {
+	declarations, err := w.ParameterDeclarations()
+	if err != nil {
+		return err
+	}
+	values := make(map[string]*string)
+	for _, param := range w.Spec.Arguments.Parameters {
+		values[param.Name] = param.Value
+	}
+
+	var problems []string
+	var unknown []string
+	for name := range desiredParams {
+		if _, ok := values[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		problems = append(problems, fmt.Sprintf("Unrecognized input parameter: %v", name))
+	}
+	for name, value := range desiredParams {
+		if _, ok := values[name]; ok {
+			value := value
+			values[name] = &value
+		}
+	}
+	for _, declaration := range declarations {
+		value, ok := values[declaration.Name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("declared parameter %v isn't a parameter of the workflow", declaration.Name))
+			continue
+		}
+		if value == nil {
+			problems = append(problems, fmt.Sprintf("parameter %v is required", declaration.Name))
+			continue
+		}
+		if scheduledWorkflowMacro.MatchString(*value) {
+			// The values of the macros are only known once the workflow is scheduled.
+			continue
+		}
+		if problem := validateParameterValue(declaration, *value); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	spec, err := json.Marshal(w.Spec.Templates)
+	if err != nil {
+		return NewInternalServerError(err, "Failed to marshal the templates of the workflow")
+	}
+	referenced := make(map[string]bool)
+	for _, match := range workflowParameterReference.FindAllStringSubmatch(string(spec), -1) {
+		name := match[1]
+		if _, ok := values[name]; !ok && !referenced[name] {
+			problems = append(problems, fmt.Sprintf("the templates reference undefined parameter %v", name))
+		}
+		referenced[name] = true
+	}
+
+	if len(problems) > 0 {
+		return NewInvalidInputError("Invalid parameters: %v", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateParameterType returns the problem with the type of a declared parameter, if any.
+func validateParameterType(declaration ParameterDeclaration) string {
+	switch declaration.Type {
+	case "", ParameterTypeString, ParameterTypeInteger, ParameterTypeFloat, ParameterTypeBoolean, ParameterTypeJSON,
+		ParameterTypeSecret:
+		return ""
+	case ParameterTypeEnum:
+		if len(declaration.Enum) == 0 {
+			return fmt.Sprintf("parameter %v of type enum has no allowed value", declaration.Name)
+		}
+		return ""
+	default:
+		return fmt.Sprintf("parameter %v has unknown type %q", declaration.Name, declaration.Type)
+	}
+}
+
+// validateParameterValue returns the problem with the value of a declared parameter, if any.
+func validateParameterValue(declaration ParameterDeclaration, value string) string {
+	if problem := validateParameterType(declaration); problem != "" {
+		return problem
+	}
+	var err error
+	switch declaration.Type {
+	case "", ParameterTypeString, ParameterTypeEnum, ParameterTypeSecret:
+	case ParameterTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case ParameterTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case ParameterTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case ParameterTypeJSON:
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("invalid JSON")
+		}
+	}
+	if err != nil {
+		return fmt.Sprintf("parameter %v must be of type %v, got %q", declaration.Name, declaration.Type, value)
+	}
+	if len(declaration.Enum) == 0 {
+		return ""
+	}
+	for _, allowed := range declaration.Enum {
+		if value == allowed {
+			return ""
+		}
+	}
+	return fmt.Sprintf("parameter %v must be one of %v, got %q",
+		declaration.Name, strings.Join(declaration.Enum, ", "), value)
+}
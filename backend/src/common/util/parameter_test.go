@@ -0,0 +1,264 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func declaredParametersWorkflow() *Workflow {
+	return NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "WORKFLOW_NAME",
+			Annotations: map[string]string{AnnotationKeyWorkflowParameters: `[
+				{"name": "epochs", "type": "integer"},
+				{"name": "rate", "type": "float"},
+				{"name": "optimizer", "enum": ["sgd", "adam"]},
+				{"name": "config", "type": "json"}]`},
+		},
+		Spec: workflowapi.WorkflowSpec{
+			Arguments: workflowapi.Arguments{
+				Parameters: []workflowapi.Parameter{
+					{Name: "epochs"},
+					{Name: "rate", Value: StringPointer("0.1")},
+					{Name: "optimizer", Value: StringPointer("sgd")},
+					{Name: "config", Value: StringPointer("{}")},
+					{Name: "untyped"},
+				},
+			},
+			Templates: []workflowapi.Template{{
+				Name: "train",
+				Container: &corev1.Container{
+					Args: []string{"{{workflow.parameters.epochs}}", "{{ workflow.parameters.rate }}"},
+				},
+			}},
+		},
+	})
+}
+
+func TestValidateParameters(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	assert.Nil(t, workflow.ValidateParameters(map[string]string{"epochs": "10", "optimizer": "adam"}))
+	// The values of the macros are only known once scheduled.
+	assert.Nil(t, workflow.ValidateParameters(map[string]string{"epochs": "[[index]]"}))
+}
+
+func TestValidateParameters_Invalid(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	err := workflow.ValidateParameters(map[string]string{
+		"rate": "fast", "optimizer": "rmsprop", "config": "{", "unknown": "1"})
+	assert.NotNil(t, err)
+	assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "Unrecognized input parameter: unknown")
+	assert.Contains(t, err.Error(), "parameter epochs is required")
+	assert.Contains(t, err.Error(), `parameter rate must be of type float, got "fast"`)
+	assert.Contains(t, err.Error(), `parameter optimizer must be one of sgd, adam, got "rmsprop"`)
+	assert.Contains(t, err.Error(), `parameter config must be of type json, got "{"`)
+}
+
+func TestValidateParameters_UndefinedReference(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	workflow.Spec.Templates[0].Container.Command = []string{"{{workflow.parameters.missing}}"}
+
+	err := workflow.ValidateParameters(map[string]string{"epochs": "10"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the templates reference undefined parameter missing")
+}
+
+func TestValidateParameters_InvalidDeclarations(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	workflow.Annotations[AnnotationKeyWorkflowParameters] = `[{"name": "other"}, {"name": "epochs", "type": "int"}]`
+
+	err := workflow.ValidateParameters(map[string]string{"epochs": "10"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "declared parameter other isn't a parameter of the workflow")
+	assert.Contains(t, err.Error(), `parameter epochs has unknown type "int"`)
+
+	workflow.Annotations[AnnotationKeyWorkflowParameters] = "not json"
+	err = workflow.ValidateParameters(nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to parse the parameters declared")
+}
+
+func TestValidateParameters_EnumAndSecret(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	workflow.Annotations[AnnotationKeyWorkflowParameters] = `[
+		{"name": "optimizer", "type": "enum", "enum": ["sgd", "adam"]},
+		{"name": "untyped", "type": "secret"}]`
+
+	assert.Nil(t, workflow.ValidateParameters(map[string]string{"epochs": "10", "untyped": "token"}))
+	err := workflow.ValidateParameters(map[string]string{"optimizer": "rmsprop"})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `parameter optimizer must be one of sgd, adam, got "rmsprop"`)
+	assert.Contains(t, err.Error(), "parameter untyped is required")
+}
+
+func TestParameterSchema(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	workflow.Annotations[AnnotationKeyWorkflowParameters] = `[
+		{"name": "optimizer", "type": "enum", "enum": ["sgd", "adam"]},
+		{"name": "untyped", "type": "secret"},
+		{"name": "epochs", "type": "integer", "enum": ["10", "20"]}]`
+
+	schema, err := workflow.ParameterSchema()
+	assert.Nil(t, err)
+	assert.Equal(t, []ParameterSchema{
+		{ParameterDeclaration: ParameterDeclaration{Name: "optimizer", Type: ParameterTypeEnum, Enum: []string{"sgd", "adam"}}},
+		{ParameterDeclaration: ParameterDeclaration{Name: "untyped", Type: ParameterTypeSecret}, Required: true},
+		{ParameterDeclaration: ParameterDeclaration{Name: "epochs", Type: ParameterTypeInteger, Enum: []string{"10", "20"}},
+			Required: true},
+	}, schema)
+}
+
+func TestParameterSchema_NoDeclaration(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	delete(workflow.Annotations, AnnotationKeyWorkflowParameters)
+
+	schema, err := workflow.ParameterSchema()
+	assert.Nil(t, err)
+	assert.Empty(t, schema)
+}
+
+func TestParameterSchema_InvalidDeclarations(t *testing.T) {
+	workflow := declaredParametersWorkflow()
+	workflow.Spec.Arguments.Parameters[0].Value = StringPointer("[[index]]")
+	workflow.Annotations[AnnotationKeyWorkflowParameters] = `[
+		{"name": "other"},
+		{"name": "epochs", "type": "integer", "enum": ["ten"]},
+		{"name": "rate", "type": "enum"},
+		{"name": "optimizer", "type": "boolean"},
+		{"name": "config", "type": "secret"}]`
+
+	_, err := workflow.ParameterSchema()
+	assert.NotNil(t, err)
+	assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "declared parameter other isn't a parameter of the workflow")
+	assert.Contains(t, err.Error(), `parameter epochs must be of type integer, got "ten"`)
+	assert.Contains(t, err.Error(), "parameter rate of type enum has no allowed value")
+	assert.Contains(t, err.Error(), `parameter optimizer must be of type boolean, got "sgd"`)
+	assert.Contains(t, err.Error(), "secret parameter config can't have a default value")
+}
+
+func secretParameterWorkflow() *Workflow {
+	return NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "WORKFLOW_NAME",
+			Annotations: map[string]string{AnnotationKeyWorkflowParameters: `[{"name": "token", "type": "secret"}]`},
+		},
+		Spec: workflowapi.WorkflowSpec{Arguments: workflowapi.Arguments{Parameters: []workflowapi.Parameter{
+			{Name: "token", Value: StringPointer("hunter2")},
+			{Name: "user", Value: StringPointer("alice")},
+		}}},
+		Status: workflowapi.WorkflowStatus{Nodes: map[string]workflowapi.NodeStatus{
+			"wf-1": {ID: "wf-1", Inputs: &workflowapi.Inputs{Parameters: []workflowapi.Parameter{
+				{Name: "password", Value: StringPointer("hunter2")},
+				{Name: "user", Value: StringPointer("alice")},
+			}}},
+		}},
+	})
+}
+
+func TestHashSecretParameterValue(t *testing.T) {
+	SetSecretParameterKey([]byte("test-key"))
+	defer SetSecretParameterKey(nil)
+
+	assert.Equal(t, SecretParameterHashPrefix+"65f93b070e9be4bccdf648502c4984608c04a63d393603725a9a59aba8f2c14e",
+		HashSecretParameterValue("hunter2"))
+	// A value which looks hashed may be a secret sent as is, so it's hashed too.
+	assert.Equal(t, SecretParameterHashPrefix+"4fde26cf18f1b45ee8253a9ec27f6f4bd3cc51efb1dd449a5f90b2a6aba6b941",
+		HashSecretParameterValue(SecretParameterHashPrefix+"hunter2"))
+	assert.True(t, IsHashedSecretParameterValue(HashSecretParameterValue("hunter2")))
+	assert.False(t, IsHashedSecretParameterValue("hunter2"))
+
+	// The values hashed with another key differ.
+	SetSecretParameterKey([]byte("other-key"))
+	assert.NotEqual(t, SecretParameterHashPrefix+"65f93b070e9be4bccdf648502c4984608c04a63d393603725a9a59aba8f2c14e",
+		HashSecretParameterValue("hunter2"))
+
+	// Without a key, the values are redacted.
+	SetSecretParameterKey(nil)
+	assert.Equal(t, SecretParameterRedactedValue, HashSecretParameterValue("hunter2"))
+	assert.True(t, IsHashedSecretParameterValue(SecretParameterRedactedValue))
+}
+
+func TestEncryptSecretParameterValues(t *testing.T) {
+	_, err := EncryptSecretParameterValues(map[string]string{"token": "hunter2"})
+	assert.True(t, IsUserErrorCodeMatch(err, codes.FailedPrecondition))
+
+	SetSecretParameterKey([]byte("test-key"))
+	defer SetSecretParameterKey(nil)
+	encrypted, err := EncryptSecretParameterValues(map[string]string{"token": "hunter2"})
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, SecretParameterEncryptionPrefix))
+	assert.NotContains(t, encrypted, "hunter2")
+	values, err := DecryptSecretParameterValues(encrypted)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"token": "hunter2"}, values)
+
+	// The values encrypted with another key can't be decrypted.
+	SetSecretParameterKey([]byte("other-key"))
+	_, err = DecryptSecretParameterValues(encrypted)
+	assert.NotNil(t, err)
+}
+
+func TestMaskSecretParameters(t *testing.T) {
+	SetSecretParameterKey([]byte("test-key"))
+	defer SetSecretParameterKey(nil)
+	hashed := SecretParameterHashPrefix + "65f93b070e9be4bccdf648502c4984608c04a63d393603725a9a59aba8f2c14e"
+	workflow := secretParameterWorkflow()
+	assert.Equal(t, map[string]bool{"token": true}, workflow.SecretParameterNames())
+
+	workflow.MaskSecretParameters()
+	assert.Equal(t, hashed, *workflow.Spec.Arguments.Parameters[0].Value)
+	assert.Equal(t, "alice", *workflow.Spec.Arguments.Parameters[1].Value)
+	inputs := workflow.Status.Nodes["wf-1"].Inputs.Parameters
+	assert.Equal(t, hashed, *inputs[0].Value)
+	assert.Equal(t, "alice", *inputs[1].Value)
+
+	// A value sent with the prefix of the hashed ones isn't stored as is.
+	workflow = secretParameterWorkflow()
+	workflow.Spec.Arguments.Parameters[0].Value = StringPointer(SecretParameterHashPrefix + "hunter2")
+	workflow.MaskSecretParameters()
+	assert.NotContains(t, *workflow.Spec.Arguments.Parameters[0].Value, "hunter2")
+}
+
+func TestToStringForStore_MasksSecretParameters(t *testing.T) {
+	workflow := secretParameterWorkflow()
+
+	assert.NotContains(t, workflow.ToStringForStore(), "hunter2")
+	assert.Contains(t, workflow.ToStringForStore(), SecretParameterRedactedValue)
+	// The workflow itself keeps the values.
+	assert.Equal(t, "hunter2", *workflow.Spec.Arguments.Parameters[0].Value)
+	assert.Contains(t, workflow.ToStringWithSecrets(), "hunter2")
+}
+
+func TestToStringForStore_KeepsHashedValuesOfStoredWorkflow(t *testing.T) {
+	SetSecretParameterKey([]byte("test-key"))
+	defer SetSecretParameterKey(nil)
+	hashed := SecretParameterHashPrefix + "65f93b070e9be4bccdf648502c4984608c04a63d393603725a9a59aba8f2c14e"
+	stored := secretParameterWorkflow()
+	stored.Spec.Arguments.Parameters[0].Value = StringPointer(hashed)
+
+	workflow := NewWorkflowFromStore(stored.Workflow)
+	assert.Contains(t, workflow.ToStringForStore(), hashed)
+	assert.Contains(t, workflow.GetSpec().ToStringForStore(), hashed)
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+)
+
+var (
+	retriesCounter = metrics.NewCounterVec("client_retries_total",
+		"Number of retries of the calls to a dependency that failed with a transient error.", "client")
+	retryBudgetExhaustedCounter = metrics.NewCounterVec("client_retry_budget_exhausted_total",
+		"Number of transient errors not retried because the retry budget of the client was exhausted.", "client")
+)
+
+func init() {
+	metrics.MustRegister(retriesCounter, retryBudgetExhaustedCounter)
+}
+
+// RetryPolicy is the exponential backoff of the calls to a dependency that fail with a
+// transient error.
+type RetryPolicy struct {
+	// The number of retries after the first attempt. Zero disables the retries.
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// The fraction by which the backoffs are randomized, so that the calls that failed
+	// together aren't retried together.
+	Jitter float64
+	// The retries of a client are capped to this fraction of its calls, with a burst of
+	// BudgetBurst retries, so that the retries don't overload a dependency that is
+	// already failing. Zero disables the budget.
+	BudgetRatio float64
+	BudgetBurst int
+}
+
+// Retrier retries the calls to a dependency that fail with a transient error.
+type Retrier struct {
+	name   string
+	policy RetryPolicy
+
+	mutex sync.Mutex
+	// The retries left in the budget.
+	tokens float64
+}
+
+// NewRetrier creates a retrier for the calls of the named client. The calls share the
+// retry budget.
+func NewRetrier(name string, policy RetryPolicy) *Retrier {
+	return &Retrier{
+		name:   name,
+		policy: policy,
+		tokens: float64(policy.BudgetBurst),
+	}
+}
+
+// Do calls operation until it succeeds, it fails with an error that isTransient rejects,
+// the retries or the retry budget are exhausted, or the context is done. It returns the
+// error of the last attempt.
+func (r *Retrier) Do(ctx context.Context, isTransient func(err error) bool, operation func() error) error {
+	b := &backoff.ExponentialBackOff{
+		InitialInterval:     r.policy.InitialBackoff,
+		RandomizationFactor: r.policy.Jitter,
+		Multiplier:          2,
+		MaxInterval:         r.policy.MaxBackoff,
+		Clock:               backoff.SystemClock,
+	}
+	b.Reset()
+	r.deposit()
+	for attempt := 0; ; attempt++ {
+		err := operation()
+		if err == nil || attempt >= r.policy.MaxRetries || !isTransient(err) {
+			return err
+		}
+		if !r.withdraw() {
+			retryBudgetExhaustedCounter.Inc(r.name)
+			return err
+		}
+		retriesCounter.Inc(r.name)
+		timer := time.NewTimer(b.NextBackOff())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// deposit earns a fraction of a retry for a call.
+func (r *Retrier) deposit() {
+	if r.policy.BudgetRatio <= 0 {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.tokens += r.policy.BudgetRatio
+	if max := float64(r.policy.BudgetBurst); r.tokens > max {
+		r.tokens = max
+	}
+}
+
+// withdraw spends a retry, returning false if the budget is exhausted.
+func (r *Retrier) withdraw() bool {
+	if r.policy.BudgetRatio <= 0 {
+		return true
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.tokens < 1 {
+		return false
+	}
+	r.tokens--
+	return true
+}
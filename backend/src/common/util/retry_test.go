@@ -0,0 +1,95 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTransient = fmt.Errorf("transient")
+
+func isTestTransient(err error) bool {
+	return err == errTransient
+}
+
+// failingOperation fails with err the first failures calls.
+func failingOperation(failures int, err error, calls *int) func() error {
+	return func() error {
+		*calls++
+		if *calls <= failures {
+			return err
+		}
+		return nil
+	}
+}
+
+var testRetryPolicy = RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Jitter: 0.5}
+
+func TestRetrier(t *testing.T) {
+	retrier := NewRetrier("test", testRetryPolicy)
+
+	calls := 0
+	assert.Nil(t, retrier.Do(context.Background(), isTestTransient, failingOperation(3, errTransient, &calls)))
+	assert.Equal(t, 4, calls)
+
+	// The retries are exhausted.
+	calls = 0
+	assert.Equal(t, errTransient, retrier.Do(context.Background(), isTestTransient, failingOperation(10, errTransient, &calls)))
+	assert.Equal(t, 4, calls)
+
+	// The errors that aren't transient aren't retried.
+	calls = 0
+	permanent := fmt.Errorf("permanent")
+	assert.Equal(t, permanent, retrier.Do(context.Background(), isTestTransient, failingOperation(10, permanent, &calls)))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetrier_Context(t *testing.T) {
+	policy := testRetryPolicy
+	policy.InitialBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+	retrier := NewRetrier("test", policy)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	assert.Equal(t, errTransient, retrier.Do(ctx, isTestTransient, failingOperation(10, errTransient, &calls)))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetrier_Budget(t *testing.T) {
+	policy := testRetryPolicy
+	policy.BudgetRatio = 0.5
+	policy.BudgetBurst = 2
+	retrier := NewRetrier("test", policy)
+
+	// The burst is spent by the first call.
+	calls := 0
+	assert.Equal(t, errTransient, retrier.Do(context.Background(), isTestTransient, failingOperation(10, errTransient, &calls)))
+	assert.Equal(t, 3, calls)
+
+	// Each call earns half a retry.
+	calls = 0
+	assert.Equal(t, errTransient, retrier.Do(context.Background(), isTestTransient, failingOperation(10, errTransient, &calls)))
+	assert.Equal(t, 1, calls)
+	calls = 0
+	assert.Equal(t, errTransient, retrier.Do(context.Background(), isTestTransient, failingOperation(10, errTransient, &calls)))
+	assert.Equal(t, 2, calls)
+}
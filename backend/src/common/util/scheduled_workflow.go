@@ -95,6 +95,8 @@ func (s *ScheduledWorkflow) ConditionSummary() string {
 	return string(s.Status.Conditions[len(s.Status.Conditions)-1].Type)
 }
 
+// ParametersAsString returns the JSON of the parameters of the workflows to store, with the
+// values of the secret ones hashed.
 func (s *ScheduledWorkflow) ParametersAsString() (string, error) {
 	workflowParams := make([]workflowapi.Parameter, 0)
 
@@ -102,7 +104,7 @@ func (s *ScheduledWorkflow) ParametersAsString() (string, error) {
 	if s.ScheduledWorkflow.Spec.Workflow == nil {
 		params = make([]swfapi.Parameter, 0)
 	} else {
-		params = s.ScheduledWorkflow.Spec.Workflow.Parameters
+		params = s.maskedParameters()
 	}
 
 	for _, param := range params {
@@ -121,7 +123,45 @@ func (s *ScheduledWorkflow) ParametersAsString() (string, error) {
 	return string(paramsBytes), nil
 }
 
+// SecretParameterNames returns the names of the parameters the workflows of the scheduled
+// workflow declare secret.
+func (s *ScheduledWorkflow) SecretParameterNames() map[string]bool {
+	return secretParameterNames(s.Annotations)
+}
+
+// maskedParameters returns the parameters of the workflows, with the values of the secret
+// ones hashed.
+func (s *ScheduledWorkflow) maskedParameters() []swfapi.Parameter {
+	secrets := s.SecretParameterNames()
+	params := make([]swfapi.Parameter, 0, len(s.Spec.Workflow.Parameters))
+	for _, param := range s.Spec.Workflow.Parameters {
+		if secrets[param.Name] {
+			param.Value = HashSecretParameterValue(param.Value)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// ToStringForStore returns the JSON of the scheduled workflow to store, with the values of
+// the secret parameters of its workflows hashed.
 func (s *ScheduledWorkflow) ToStringForStore() string {
+	scheduledWorkflow := s.ScheduledWorkflow
+	if s.Spec.Workflow != nil && len(s.SecretParameterNames()) > 0 {
+		scheduledWorkflow = s.DeepCopy()
+		scheduledWorkflow.Spec.Workflow.Parameters = s.maskedParameters()
+	}
+	swf, err := json.Marshal(scheduledWorkflow)
+	if err != nil {
+		glog.Errorf("Could not marshal the scheduled workflow: %v", s.ScheduledWorkflow)
+		return ""
+	}
+	return string(swf)
+}
+
+// ToStringWithSecrets returns the JSON of the scheduled workflow with the values of the
+// secret parameters of its workflows, to report it to the API server, which hashes them.
+func (s *ScheduledWorkflow) ToStringWithSecrets() string {
 	swf, err := json.Marshal(s.ScheduledWorkflow)
 	if err != nil {
 		glog.Errorf("Could not marshal the scheduled workflow: %v", s.ScheduledWorkflow)
@@ -153,4 +153,22 @@ func TestScheduledWorkflow_ParametersAsString(t *testing.T) {
 
 	assert.Equal(t, "[]", result)
 
+	// The values of the secret parameters are hashed.
+	workflow = NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			AnnotationKeyWorkflowParameters: `[{"name": "PARAM1", "type": "secret"}]`}},
+		Spec: swfapi.ScheduledWorkflowSpec{
+			Workflow: &swfapi.WorkflowResource{
+				Parameters: []swfapi.Parameter{{Name: "PARAM1", Value: "hunter2"}},
+			},
+		},
+	})
+
+	SetSecretParameterKey([]byte("test-key"))
+	defer SetSecretParameterKey(nil)
+	result, err = workflow.ParametersAsString()
+	assert.Nil(t, err)
+	assert.Equal(t, "[{\"name\":\"PARAM1\",\"value\":\"secret-v1:hmac-sha256:65f93b070e9be4bccdf648502c4984608c04a63d393603725a9a59aba8f2c14e\"}]", result)
+	assert.NotContains(t, workflow.ToStringForStore(), "hunter2")
+	assert.Equal(t, "hunter2", workflow.Spec.Workflow.Parameters[0].Value)
 }
@@ -111,7 +111,7 @@ func GetKubernetesClientFromClientConfig(clientConfig clientcmd.ClientConfig) (
 }
 
 func GetRpcConnection(namespace string, serviceName string, servicePort string,
-	masterurl string, kubeconfig string) (*grpc.ClientConn, error) {
+	masterurl string, kubeconfig string, dialOptions ...grpc.DialOption) (*grpc.ClientConn, error) {
 	clientSet, _, err := GetKubernetesClient(masterurl, kubeconfig)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to get K8s client set when getting RPC connection")
@@ -121,7 +121,7 @@ func GetRpcConnection(namespace string, serviceName string, servicePort string,
 		return nil, errors.Wrapf(err, "Failed to get ml-pipeline service")
 	}
 	rpcAddress := svc.Spec.ClusterIP + ":" + servicePort
-	conn, err := grpc.Dial(rpcAddress, grpc.WithInsecure())
+	conn, err := grpc.Dial(rpcAddress, append([]grpc.DialOption{grpc.WithInsecure()}, dialOptions...)...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to create gRPC connection")
 	}
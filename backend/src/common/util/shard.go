@@ -0,0 +1,59 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// Shard is the part of the objects a replica of a controller processes, when the objects
+// are split between several replicas. Each object belongs to the shard selected by the
+// hash of its namespace/name key, so that every replica agrees on it without
+// coordination. A nil shard holds every object.
+type Shard struct {
+	index int
+	count int
+}
+
+// NewShard returns the shard index out of count. It returns nil if count is 1, i.e. if
+// the objects aren't split.
+func NewShard(index int, count int) (*Shard, error) {
+	if count < 1 || index < 0 || index >= count {
+		return nil, NewInvalidInputError(
+			"Invalid shard %v out of %v. The index must be between 0 and the count minus one.", index, count)
+	}
+	if count == 1 {
+		return nil, nil
+	}
+	return &Shard{index: index, count: count}, nil
+}
+
+// Owns returns whether the object with the namespace/name key belongs to the shard.
+func (s *Shard) Owns(key string) bool {
+	if s == nil {
+		return true
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32()%uint32(s.count)) == s.index
+}
+
+func (s *Shard) String() string {
+	if s == nil {
+		return "0/1"
+	}
+	return fmt.Sprintf("%v/%v", s.index, s.count)
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShard(t *testing.T) {
+	shard, err := NewShard(0, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, shard)
+	assert.True(t, shard.Owns("namespace/name"))
+
+	shard, err = NewShard(1, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "1/3", shard.String())
+
+	for _, invalid := range [][]int{{0, 0}, {-1, 2}, {2, 2}} {
+		_, err = NewShard(invalid[0], invalid[1])
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "Invalid shard")
+	}
+}
+
+func TestShard_Owns(t *testing.T) {
+	var shards []*Shard
+	for i := 0; i < 3; i++ {
+		shard, err := NewShard(i, 3)
+		assert.Nil(t, err)
+		shards = append(shards, shard)
+	}
+
+	// Every key belongs to exactly one shard, and every shard gets some keys.
+	counts := make([]int, len(shards))
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("namespace/name-%v", i)
+		owners := 0
+		for j, shard := range shards {
+			if shard.Owns(key) {
+				owners++
+				counts[j]++
+			}
+		}
+		assert.Equal(t, 1, owners, key)
+	}
+	for _, count := range counts {
+		assert.True(t, count > 0)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"k8s.io/api/core/v1"
+)
+
+// TemplateChange is how a parameter or a step differs between two pipeline templates.
+type TemplateChange string
+
+const (
+	TemplateChangeAdded    TemplateChange = "ADDED"
+	TemplateChangeRemoved  TemplateChange = "REMOVED"
+	TemplateChangeModified TemplateChange = "MODIFIED"
+)
+
+type ParameterDiff struct {
+	Name        string
+	Change      TemplateChange
+	BaseValue   string
+	TargetValue string
+}
+
+// StepDiff is the difference between the templates of the Argo workflows, which are
+// the steps of the pipelines.
+type StepDiff struct {
+	Name          string
+	Change        TemplateChange
+	BaseImage     string
+	TargetImage   string
+	ChangedFields []string
+}
+
+// TemplateDiff lists the parameters and the steps which differ between two pipeline
+// templates, sorted by name.
+type TemplateDiff struct {
+	Parameters []ParameterDiff
+	Steps      []StepDiff
+}
+
+// The fields of the steps compared by DiffTemplates, in the order of StepDiff.ChangedFields.
+var stepFields = []struct {
+	name  string
+	value func(template *v1alpha1.Template) interface{}
+}{
+	{"image", func(t *v1alpha1.Template) interface{} { return stepImage(t) }},
+	{"command", func(t *v1alpha1.Template) interface{} { return stepContainer(t).Command }},
+	{"args", func(t *v1alpha1.Template) interface{} { return stepContainer(t).Args }},
+	{"env", func(t *v1alpha1.Template) interface{} { return stepContainer(t).Env }},
+	{"resources", func(t *v1alpha1.Template) interface{} { return stepContainer(t).Resources }},
+	{"source", func(t *v1alpha1.Template) interface{} {
+		if t.Script == nil {
+			return ""
+		}
+		return t.Script.Source
+	}},
+	{"inputs", func(t *v1alpha1.Template) interface{} { return t.Inputs }},
+	{"outputs", func(t *v1alpha1.Template) interface{} { return t.Outputs }},
+	{"steps", func(t *v1alpha1.Template) interface{} { return t.Steps }},
+	{"dag", func(t *v1alpha1.Template) interface{} { return t.DAG }},
+	{"resource", func(t *v1alpha1.Template) interface{} { return t.Resource }},
+	{"sidecars", func(t *v1alpha1.Template) interface{} { return t.Sidecars }},
+	{"retryStrategy", func(t *v1alpha1.Template) interface{} { return t.RetryStrategy }},
+	{"activeDeadlineSeconds", func(t *v1alpha1.Template) interface{} { return t.ActiveDeadlineSeconds }},
+	{"nodeSelector", func(t *v1alpha1.Template) interface{} { return t.NodeSelector }},
+	{"tolerations", func(t *v1alpha1.Template) interface{} { return t.Tolerations }},
+	{"affinity", func(t *v1alpha1.Template) interface{} { return t.Affinity }},
+}
+
+// DiffTemplates compares the parameters and the steps of two pipeline templates.
+func DiffTemplates(base []byte, target []byte) (*TemplateDiff, error) {
+	baseWorkflow, err := ValidateWorkflow(base)
+	if err != nil {
+		return nil, Wrap(err, "Failed to parse the base template")
+	}
+	targetWorkflow, err := ValidateWorkflow(target)
+	if err != nil {
+		return nil, Wrap(err, "Failed to parse the target template")
+	}
+	return &TemplateDiff{
+		Parameters: diffParameters(baseWorkflow.Spec.Arguments.Parameters, targetWorkflow.Spec.Arguments.Parameters),
+		Steps:      diffSteps(baseWorkflow.Spec.Templates, targetWorkflow.Spec.Templates),
+	}, nil
+}
+
+func diffParameters(base []v1alpha1.Parameter, target []v1alpha1.Parameter) []ParameterDiff {
+	baseValues := parameterValues(base)
+	targetValues := parameterValues(target)
+	var diffs []ParameterDiff
+	for _, name := range sortedNames(baseValues, targetValues) {
+		baseValue, inBase := baseValues[name]
+		targetValue, inTarget := targetValues[name]
+		diff := ParameterDiff{Name: name, BaseValue: baseValue, TargetValue: targetValue}
+		switch {
+		case !inBase:
+			diff.Change = TemplateChangeAdded
+		case !inTarget:
+			diff.Change = TemplateChangeRemoved
+		case baseValue != targetValue:
+			diff.Change = TemplateChangeModified
+		default:
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func parameterValues(parameters []v1alpha1.Parameter) map[string]string {
+	values := make(map[string]string)
+	for _, parameter := range parameters {
+		values[parameter.Name] = ""
+		if parameter.Value != nil {
+			values[parameter.Name] = *parameter.Value
+		}
+	}
+	return values
+}
+
+func diffSteps(base []v1alpha1.Template, target []v1alpha1.Template) []StepDiff {
+	baseSteps := make(map[string]*v1alpha1.Template)
+	for i := range base {
+		baseSteps[base[i].Name] = &base[i]
+	}
+	targetSteps := make(map[string]*v1alpha1.Template)
+	for i := range target {
+		targetSteps[target[i].Name] = &target[i]
+	}
+	names := make(map[string]bool)
+	for name := range baseSteps {
+		names[name] = true
+	}
+	for name := range targetSteps {
+		names[name] = true
+	}
+
+	var diffs []StepDiff
+	for _, name := range sortedKeys(names) {
+		baseStep, inBase := baseSteps[name]
+		targetStep, inTarget := targetSteps[name]
+		diff := StepDiff{Name: name}
+		switch {
+		case !inBase:
+			diff.Change = TemplateChangeAdded
+			diff.TargetImage = stepImage(targetStep)
+		case !inTarget:
+			diff.Change = TemplateChangeRemoved
+			diff.BaseImage = stepImage(baseStep)
+		default:
+			for _, field := range stepFields {
+				if !reflect.DeepEqual(field.value(baseStep), field.value(targetStep)) {
+					diff.ChangedFields = append(diff.ChangedFields, field.name)
+				}
+			}
+			if len(diff.ChangedFields) == 0 {
+				continue
+			}
+			diff.Change = TemplateChangeModified
+			diff.BaseImage = stepImage(baseStep)
+			diff.TargetImage = stepImage(targetStep)
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// stepContainer returns the container of a container or script step, or an empty
+// container for the other steps.
+func stepContainer(template *v1alpha1.Template) *v1.Container {
+	switch {
+	case template.Container != nil:
+		return template.Container
+	case template.Script != nil:
+		return &template.Script.Container
+	default:
+		return &v1.Container{}
+	}
+}
+
+func stepImage(template *v1alpha1.Template) string {
+	return stepContainer(template).Image
+}
+
+func sortedNames(base map[string]string, target map[string]string) []string {
+	names := make(map[string]bool)
+	for name := range base {
+		names[name] = true
+	}
+	for name := range target {
+		names[name] = true
+	}
+	return sortedKeys(names)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
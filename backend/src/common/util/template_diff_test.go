@@ -0,0 +1,116 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+var baseDiffTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+spec:
+  arguments:
+    parameters:
+    - name: epochs
+      value: "10"
+    - name: model
+      value: resnet
+    - name: unused
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: train
+        template: train
+  - name: train
+    container:
+      image: trainer:1
+      args: [--epochs, "{{inputs.parameters.epochs}}"]
+  - name: deploy
+    container:
+      image: deployer:1
+  - name: unchanged
+    container:
+      image: unchanged:1
+`
+
+var targetDiffTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+spec:
+  arguments:
+    parameters:
+    - name: epochs
+      value: "20"
+    - name: model
+      value: resnet
+    - name: learning-rate
+      value: "0.1"
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: train
+        template: train
+      - name: evaluate
+        template: evaluate
+  - name: train
+    container:
+      image: trainer:2
+      args: [--epochs, "{{inputs.parameters.epochs}}", --fast]
+  - name: evaluate
+    script:
+      image: python:3
+      source: print(1)
+  - name: unchanged
+    container:
+      image: unchanged:1
+`
+
+func TestDiffTemplates(t *testing.T) {
+	diff, err := DiffTemplates([]byte(baseDiffTemplate), []byte(targetDiffTemplate))
+	assert.Nil(t, err)
+	assert.Equal(t, &TemplateDiff{
+		Parameters: []ParameterDiff{
+			{Name: "epochs", Change: TemplateChangeModified, BaseValue: "10", TargetValue: "20"},
+			{Name: "learning-rate", Change: TemplateChangeAdded, TargetValue: "0.1"},
+			{Name: "unused", Change: TemplateChangeRemoved},
+		},
+		Steps: []StepDiff{
+			{Name: "deploy", Change: TemplateChangeRemoved, BaseImage: "deployer:1"},
+			{Name: "evaluate", Change: TemplateChangeAdded, TargetImage: "python:3"},
+			{Name: "main", Change: TemplateChangeModified, ChangedFields: []string{"dag"}},
+			{Name: "train", Change: TemplateChangeModified, BaseImage: "trainer:1", TargetImage: "trainer:2",
+				ChangedFields: []string{"image", "args"}},
+		},
+	}, diff)
+}
+
+func TestDiffTemplates_Identical(t *testing.T) {
+	diff, err := DiffTemplates([]byte(baseDiffTemplate), []byte(baseDiffTemplate))
+	assert.Nil(t, err)
+	assert.Equal(t, &TemplateDiff{}, diff)
+}
+
+func TestDiffTemplates_InvalidTemplate(t *testing.T) {
+	_, err := DiffTemplates([]byte(baseDiffTemplate), []byte("kind: Pod"))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Failed to parse the target template")
+	assert.Equal(t, codes.InvalidArgument, err.(*UserError).ExternalStatusCode())
+}
@@ -26,11 +26,105 @@ const (
 	argoK8sResource = "Workflow"
 )
 
+// PipelineStep is a template of a pipeline, and the templates it runs.
+type PipelineStep struct {
+	Name string
+	// One of container, script, resource, dag, steps or suspend.
+	Type  string
+	Image string
+	// The names of the templates run by a dag or steps template, in order.
+	Children []string
+}
+
+// CompiledPipeline is what the API server needs of the template of a pipeline, extracted when
+// the pipeline is uploaded.
+type CompiledPipeline struct {
+	// The parameters of the pipeline, marshalled into JSON.
+	Parameters string
+	// The workflow marshalled into JSON, which is much faster to parse than its YAML template.
+	WorkflowManifest string
+	Steps            []PipelineStep
+	// The schema of the declared parameters marshalled into JSON, empty if none is declared.
+	ParameterSchema string
+}
+
+// CompilePipeline parses the template of a pipeline once for all.
+func CompilePipeline(template []byte) (*CompiledPipeline, error) {
+	wf, err := ValidateWorkflow(template)
+	if err != nil {
+		return nil, Wrap(err, "Failed to compile the pipeline")
+	}
+	parameters, err := marshalParameters(wf)
+	if err != nil {
+		return nil, err
+	}
+	workflow := NewWorkflow(wf)
+	if _, err := workflow.ConcurrencyDeclaration(); err != nil {
+		return nil, err
+	}
+	schema, err := workflow.ParameterSchema()
+	if err != nil {
+		return nil, err
+	}
+	var parameterSchema string
+	if len(schema) > 0 {
+		schemaBytes, err := json.Marshal(schema)
+		if err != nil {
+			return nil, NewInternalServerError(err, "Failed to marshal the parameter schema")
+		}
+		parameterSchema = string(schemaBytes)
+	}
+	return &CompiledPipeline{
+		Parameters:       parameters,
+		WorkflowManifest: workflow.ToStringForStore(),
+		Steps:            GetSteps(wf),
+		ParameterSchema:  parameterSchema,
+	}, nil
+}
+
+// GetSteps returns the templates of a workflow, in order.
+func GetSteps(wf *v1alpha1.Workflow) []PipelineStep {
+	steps := make([]PipelineStep, 0, len(wf.Spec.Templates))
+	for _, template := range wf.Spec.Templates {
+		step := PipelineStep{Name: template.Name}
+		switch {
+		case template.Container != nil:
+			step.Type = "container"
+			step.Image = template.Container.Image
+		case template.Script != nil:
+			step.Type = "script"
+			step.Image = template.Script.Image
+		case template.Resource != nil:
+			step.Type = "resource"
+		case template.DAG != nil:
+			step.Type = "dag"
+			for _, task := range template.DAG.Tasks {
+				step.Children = append(step.Children, task.Template)
+			}
+		case template.Steps != nil:
+			step.Type = "steps"
+			for _, parallelSteps := range template.Steps {
+				for _, workflowStep := range parallelSteps {
+					step.Children = append(step.Children, workflowStep.Template)
+				}
+			}
+		case template.Suspend != nil:
+			step.Type = "suspend"
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
 func GetParameters(template []byte) (string, error) {
 	wf, err := ValidateWorkflow(template)
 	if err != nil {
 		return "", Wrap(err, "Failed to get parameters from the workflow")
 	}
+	return marshalParameters(wf)
+}
+
+func marshalParameters(wf *v1alpha1.Workflow) (string, error) {
 	if wf.Spec.Arguments.Parameters == nil {
 		return "[]", nil
 	}
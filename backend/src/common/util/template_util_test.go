@@ -15,6 +15,8 @@
 package util
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
@@ -47,3 +49,100 @@ func TestGetParameters_ParametersTooLong(t *testing.T) {
 	_, err := GetParameters(templateBytes)
 	assert.Equal(t, codes.InvalidArgument, err.(*UserError).ExternalStatusCode())
 }
+
+func TestCompilePipeline(t *testing.T) {
+	template := []byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+    - name: message
+      value: hello
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: say
+        template: say
+      - name: apply
+        template: apply
+        dependencies: [say]
+  - name: say
+    container:
+      image: alpine
+  - name: apply
+    resource:
+      action: create
+`)
+	compiled, err := CompilePipeline(template)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"message","value":"hello"}]`, compiled.Parameters)
+	assert.Equal(t, []PipelineStep{
+		{Name: "main", Type: "dag", Children: []string{"say", "apply"}},
+		{Name: "say", Type: "container", Image: "alpine"},
+		{Name: "apply", Type: "resource"},
+	}, compiled.Steps)
+	var workflow v1alpha1.Workflow
+	assert.Nil(t, json.Unmarshal([]byte(compiled.WorkflowManifest), &workflow))
+	assert.Equal(t, "hello-", workflow.GenerateName)
+	assert.Equal(t, "main", workflow.Spec.Entrypoint)
+	assert.Empty(t, compiled.ParameterSchema)
+}
+
+func TestCompilePipeline_ParameterSchema(t *testing.T) {
+	template := []byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-
+  annotations:
+    pipelines.kubeflow.org/parameters: '[{"name": "count", "type": "integer"}, {"name": "token", "type": "secret"}]'
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+    - name: count
+      value: "1"
+    - name: token
+  templates:
+  - name: main
+    container:
+      image: alpine
+`)
+	compiled, err := CompilePipeline(template)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"name":"count","type":"integer"},{"name":"token","type":"secret","required":true}]`,
+		compiled.ParameterSchema)
+
+	_, err = CompilePipeline([]byte(strings.Replace(string(template), "- name: token", "- name: token\n      value: abc", 1)))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "secret parameter token can't have a default value")
+}
+
+func TestCompilePipeline_InvalidConcurrency(t *testing.T) {
+	_, err := CompilePipeline([]byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: hello-
+  annotations:
+    pipelines.kubeflow.org/concurrency: '{"maxActiveRuns": 0}'
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: alpine
+`))
+	assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	assert.Contains(t, err.Error(), "must allow at least 1 active run")
+}
+
+func TestCompilePipeline_InvalidTemplate(t *testing.T) {
+	_, err := CompilePipeline([]byte("apiVersion: v1\nkind: Pod"))
+	assert.Equal(t, codes.InvalidArgument, err.(*UserError).ExternalStatusCode())
+}
@@ -16,6 +16,7 @@ package util
 
 import (
 	"math"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
@@ -57,6 +58,37 @@ func (f *FakeTime) Now() time.Time {
 	return f.now
 }
 
+// ManualFakeTime is a fake implementation of the TimeInterface whose time only moves when a test
+// sets or advances it, e.g. past a grace period.
+type ManualFakeTime struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewManualFakeTime(now time.Time) *ManualFakeTime {
+	return &ManualFakeTime{now: now.UTC()}
+}
+
+func (f *ManualFakeTime) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance moves the time forward by the duration.
+func (f *ManualFakeTime) Advance(duration time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(duration)
+}
+
+// Set sets the time.
+func (f *ManualFakeTime) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now.UTC()
+}
+
 func ParseTimeOrFatal(value string) time.Time {
 	result, err := time.Parse(time.RFC3339, value)
 	if err != nil {
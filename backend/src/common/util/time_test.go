@@ -0,0 +1,34 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualFakeTime(t *testing.T) {
+	fakeTime := NewManualFakeTime(time.Unix(100, 0))
+	assert.Equal(t, time.Unix(100, 0).UTC(), fakeTime.Now())
+	assert.Equal(t, time.Unix(100, 0).UTC(), fakeTime.Now())
+
+	fakeTime.Advance(time.Minute)
+	assert.Equal(t, time.Unix(160, 0).UTC(), fakeTime.Now())
+
+	fakeTime.Set(time.Unix(10, 0))
+	assert.Equal(t, time.Unix(10, 0).UTC(), fakeTime.Now())
+}
@@ -0,0 +1,209 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// URLGuardOptions configure the URLs which the requests made on behalf of the users, e.g.
+// to import a pipeline or to notify a webhook, are allowed to reach.
+type URLGuardOptions struct {
+	// The name of the policy in the errors, e.g. URL import.
+	Policy string
+	// The schemes of the URLs allowed, e.g. https.
+	AllowedSchemes []string
+	// The hosts of the URLs allowed, e.g. github.com, or *.example.com for all its subdomains.
+	// All the hosts are allowed if empty. With blocked networks, the hosts which only the
+	// proxy can resolve, e.g. the ones of an internal DNS, must be listed to be allowed.
+	AllowedHosts []string
+	// The hosts of the URLs denied, even if allowed, e.g. metadata.google.internal.
+	DeniedHosts []string
+	// The networks the URLs can't resolve to, in CIDR notation, e.g. 169.254.0.0/16 for the
+	// metadata endpoints of the clouds, except for the allowed ones, e.g. of an internal mirror.
+	BlockedNetworks []string
+	AllowedNetworks []string
+}
+
+// URLGuard checks the URLs against a policy before they are requested. The addresses of the
+// hosts are checked against the blocked networks again when the connections are made, so
+// that a host can't resolve to an internal address after it's checked. The proxies are
+// exempted, so the hosts which can't be resolved before they are sent to the proxy are
+// denied unless they are listed in the allowed hosts.
+type URLGuard struct {
+	options         URLGuardOptions
+	blockedNetworks []*net.IPNet
+	allowedNetworks []*net.IPNet
+	// The addresses of the proxies used, as host:port.
+	proxies sync.Map
+}
+
+func NewURLGuard(options URLGuardOptions) (*URLGuard, error) {
+	blockedNetworks, err := parseNetworks(options.BlockedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	allowedNetworks, err := parseNetworks(options.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	return &URLGuard{options: options, blockedNetworks: blockedNetworks, allowedNetworks: allowedNetworks}, nil
+}
+
+// CheckURL checks that the scheme and the host of a URL are allowed, and that the host doesn't
+// resolve to a blocked address. The host is resolved again by the connection, or by the proxy.
+func (g *URLGuard) CheckURL(ctx context.Context, u *url.URL) error {
+	if !containsFold(g.options.AllowedSchemes, u.Scheme) {
+		return NewInvalidInputError("The scheme of %v isn't allowed. The schemes allowed are %v",
+			u, g.options.AllowedSchemes)
+	}
+	host := strings.ToLower(u.Hostname())
+	if len(g.options.AllowedHosts) > 0 && !matchesHost(g.options.AllowedHosts, host) {
+		return NewInvalidInputError("The host of %v isn't allowed. The hosts allowed are %v",
+			u, g.options.AllowedHosts)
+	}
+	if matchesHost(g.options.DeniedHosts, host) {
+		return g.policyError(u, fmt.Sprintf("the host %v is denied", host))
+	}
+	if len(g.blockedNetworks) == 0 {
+		return nil
+	}
+	ips := []net.IP{net.ParseIP(host)}
+	if ips[0] == nil {
+		addresses, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			// The proxy may resolve the host to a blocked address, which the connection to the
+			// proxy can't check. Only the hosts the operators listed are trusted.
+			if matchesHost(g.options.AllowedHosts, host) {
+				return nil
+			}
+			return g.policyError(u, fmt.Sprintf("the host %v can't be resolved to check its address", host))
+		}
+		ips = ips[:0]
+		for _, address := range addresses {
+			ips = append(ips, address.IP)
+		}
+	}
+	for _, ip := range ips {
+		if g.isBlocked(ip) {
+			return g.policyError(u, fmt.Sprintf("the host %v resolves to the blocked address %v", host, ip))
+		}
+	}
+	return nil
+}
+
+// Proxy wraps the proxy function of a transport, so that the connections to the proxies it
+// returns aren't checked against the blocked networks.
+func (g *URLGuard) Proxy(proxy func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(request *http.Request) (*url.URL, error) {
+		proxyURL, err := proxy(request)
+		if proxyURL != nil {
+			g.proxies.Store(proxyAddress(proxyURL), true)
+		}
+		return proxyURL, err
+	}
+}
+
+// Dial connects to the proxies, and to the hosts whose address isn't blocked. It's the
+// DialContext of a transport, with the timeout of the connections.
+func (g *URLGuard) Dial(timeout time.Duration) func(ctx context.Context, network string, address string) (
+	net.Conn, error) {
+	return func(ctx context.Context, network string, address string) (net.Conn, error) {
+		dialer := &net.Dialer{Timeout: timeout}
+		if _, ok := g.proxies.Load(address); !ok {
+			dialer.Control = func(network string, address string, c syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || g.isBlocked(ip) {
+					return NewPermissionDeniedError("Connecting to the blocked address %v is denied by the %v policy",
+						host, g.options.Policy)
+				}
+				return nil
+			}
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+}
+
+func (g *URLGuard) isBlocked(ip net.IP) bool {
+	for _, network := range g.allowedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	for _, network := range g.blockedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyError is the error of the URLs denied by the policy of the operators.
+func (g *URLGuard) policyError(u *url.URL, reason string) error {
+	return NewPermissionDeniedError("The URL %v is denied by the %v policy: %v. "+
+		"Please ask the operators of Kubeflow Pipelines to allow it", u, g.options.Policy, reason)
+}
+
+// matchesHost tells whether a host is one of the hosts, or a subdomain of a *.domain one.
+func matchesHost(hosts []string, host string) bool {
+	for _, h := range hosts {
+		h = strings.ToLower(h)
+		if host == h || (strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyAddress returns the host:port the transport connects to for a proxy.
+func proxyAddress(proxyURL *url.URL) string {
+	port := proxyURL.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443", "socks5": "1080"}[proxyURL.Scheme]
+	}
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
+
+func parseNetworks(cidrs []string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, NewInvalidInputError("Invalid network %v: %v", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
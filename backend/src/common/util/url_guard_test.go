@@ -0,0 +1,52 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func checkURLForTest(guard *URLGuard, rawURL string) error {
+	u, _ := url.Parse(rawURL)
+	return guard.CheckURL(context.Background(), u)
+}
+
+func TestURLGuard_CheckURL(t *testing.T) {
+	guard, err := NewURLGuard(URLGuardOptions{
+		Policy:          "test",
+		AllowedSchemes:  []string{"https"},
+		DeniedHosts:     []string{"*.internal"},
+		BlockedNetworks: []string{"10.0.0.0/8"},
+		AllowedNetworks: []string{"10.0.0.1/32"},
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, checkURLForTest(guard, "https://10.0.0.1/hook"))
+	err = checkURLForTest(guard, "http://10.0.0.1/hook")
+	assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument))
+	err = checkURLForTest(guard, "https://10.0.0.2/hook")
+	assert.True(t, IsUserErrorCodeMatch(err, codes.PermissionDenied))
+	assert.Contains(t, err.Error(), "is denied by the test policy: the host 10.0.0.2 resolves to the blocked address")
+	err = checkURLForTest(guard, "https://metadata.google.internal/hook")
+	assert.True(t, IsUserErrorCodeMatch(err, codes.PermissionDenied))
+
+	_, err = NewURLGuard(URLGuardOptions{BlockedNetworks: []string{"10.0.0.0"}})
+	assert.NotNil(t, err)
+}
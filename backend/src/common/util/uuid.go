@@ -15,6 +15,9 @@
 package util
 
 import (
+	"encoding/binary"
+	"sync"
+
 	"github.com/golang/glog"
 	"github.com/google/uuid"
 )
@@ -59,3 +62,25 @@ func NewFakeUUIDGeneratorOrFatal(uuidStringToReturn string, errToReturn error) *
 func (f *FakeUUIDGenerator) NewRandom() (uuid.UUID, error) {
 	return f.uuidToReturn, f.errToReturn
 }
+
+// SequentialFakeUUIDGenerator is a fake implementation of the UUIDGeneratorInterface used for
+// testing. It generates distinct UUIDs in order: 00000000-0000-0000-0000-000000000001, then
+// 00000000-0000-0000-0000-000000000002 and so on, so that the resources created by a test sort
+// by their ID in the order they were created.
+type SequentialFakeUUIDGenerator struct {
+	mu   sync.Mutex
+	last uint64
+}
+
+func NewSequentialFakeUUIDGenerator() *SequentialFakeUUIDGenerator {
+	return &SequentialFakeUUIDGenerator{}
+}
+
+func (f *SequentialFakeUUIDGenerator) NewRandom() (uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.last++
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[8:], f.last)
+	return id, nil
+}
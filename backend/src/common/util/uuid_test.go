@@ -0,0 +1,31 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequentialFakeUUIDGenerator(t *testing.T) {
+	generator := NewSequentialFakeUUIDGenerator()
+	first, err := generator.NewRandom()
+	assert.Nil(t, err)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", first.String())
+	second, err := generator.NewRandom()
+	assert.Nil(t, err)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", second.String())
+}
@@ -15,10 +15,17 @@
 package util
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
 	swfregister "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow"
 	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/json"
@@ -27,12 +34,23 @@ import (
 // Workflow is a type to help manipulate Workflow objects.
 type Workflow struct {
 	*workflowapi.Workflow
+	// Whether the values of the secret parameters are hashed already.
+	secretsHashed bool
 }
 
 // NewWorkflow creates a Workflow.
 func NewWorkflow(workflow *workflowapi.Workflow) *Workflow {
 	return &Workflow{
-		workflow,
+		Workflow: workflow,
+	}
+}
+
+// NewWorkflowFromStore creates a Workflow from a stored manifest, whose secret parameters
+// have their values hashed already.
+func NewWorkflowFromStore(workflow *workflowapi.Workflow) *Workflow {
+	return &Workflow{
+		Workflow:      workflow,
+		secretsHashed: true,
 	}
 }
 
@@ -135,12 +153,238 @@ func (w *Workflow) ScheduledAtInSecOr0() int64 {
 	return 0
 }
 
+// Condition returns the phase of the workflow, or Queued while the workflow waits for the
+// active runs of its pipeline to be fewer than the pipeline allows.
 func (w *Workflow) Condition() string {
-	return string(w.Status.Phase)
+	phase := string(w.Status.Phase)
+	if w.IsQueued() && !IsFinalCondition(phase) {
+		return RunConditionQueued
+	}
+	return phase
+}
+
+// IsQueued returns whether the workflow is suspended until its pipeline allows it to run.
+func (w *Workflow) IsQueued() bool {
+	return w.Labels[LabelKeyWorkflowQueued] == "true"
+}
+
+// Queue suspends the workflow until its pipeline allows it to run.
+func (w *Workflow) Queue() {
+	suspend := true
+	w.Spec.Suspend = &suspend
+	w.SetLabels(LabelKeyWorkflowQueued, "true")
+}
+
+// IsInFinalState returns whether the workflow has completed, successfully or not.
+func (w *Workflow) IsInFinalState() bool {
+	return IsFinalCondition(w.Condition())
+}
+
+// IsFinalCondition returns whether a run with the condition has completed, successfully
+// or not.
+func IsFinalCondition(condition string) bool {
+	switch workflowapi.NodePhase(condition) {
+	case workflowapi.NodeSucceeded, workflowapi.NodeFailed, workflowapi.NodeError:
+		return true
+	default:
+		return false
+	}
+}
+
+// DurationInSecOr0 returns the duration between the start and the end of a completed
+// workflow, or 0 if the workflow has not completed.
+func (w *Workflow) DurationInSecOr0() float64 {
+	if w.Status.StartedAt.IsZero() || w.Status.FinishedAt.IsZero() {
+		return 0
+	}
+	return w.Status.FinishedAt.Sub(w.Status.StartedAt.Time).Seconds()
+}
+
+// LastStatusChangeTime returns the most recent time at which the workflow or one of
+// its nodes started or finished.
+func (w *Workflow) LastStatusChangeTime() time.Time {
+	last := w.Status.StartedAt.Time
+	if w.Status.FinishedAt.After(last) {
+		last = w.Status.FinishedAt.Time
+	}
+	for _, node := range w.Status.Nodes {
+		if node.StartedAt.After(last) {
+			last = node.StartedAt.Time
+		}
+		if node.FinishedAt.After(last) {
+			last = node.FinishedAt.Time
+		}
+	}
+	return last
 }
 
+// PipelineIdOrEmpty returns the ID of the pipeline the workflow was created from.
+func (w *Workflow) PipelineIdOrEmpty() string {
+	return w.Labels[LabelKeyWorkflowPipelineId]
+}
+
+// ExperimentIdOrEmpty returns the ID of the experiment the workflow belongs to.
+func (w *Workflow) ExperimentIdOrEmpty() string {
+	return w.Labels[LabelKeyWorkflowExperimentId]
+}
+
+// ModelDeclaration declares that an output artifact of a template is a model.
+type ModelDeclaration struct {
+	// The name of the model the artifact is a version of.
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	Artifact string `json:"artifact"`
+}
+
+// ModelDeclarations returns the models declared in the annotations of the workflow.
+func (w *Workflow) ModelDeclarations() ([]ModelDeclaration, error) {
+	value, ok := w.Annotations[AnnotationKeyWorkflowModels]
+	if !ok {
+		return nil, nil
+	}
+	var declarations []ModelDeclaration
+	if err := json.Unmarshal([]byte(value), &declarations); err != nil {
+		return nil, NewInvalidInputErrorWithDetails(err,
+			fmt.Sprintf("Failed to parse the models declared in annotation %v", AnnotationKeyWorkflowModels))
+	}
+	for _, declaration := range declarations {
+		if declaration.Name == "" || declaration.Template == "" || declaration.Artifact == "" {
+			return nil, NewInvalidInputError("The models declared in annotation %v must have a name, a template and an artifact",
+				AnnotationKeyWorkflowModels)
+		}
+	}
+	return declarations, nil
+}
+
+// ConcurrencyDeclaration declares the max number of runs of a pipeline which are active
+// at once, and what happens to the runs created beyond it.
+type ConcurrencyDeclaration struct {
+	MaxActiveRuns int `json:"maxActiveRuns"`
+	// Either queue, the default, or reject.
+	Policy string `json:"policy,omitempty"`
+}
+
+// ConcurrencyDeclaration returns the concurrency declared in the annotations of the
+// workflow, or nil if there is none.
+func (w *Workflow) ConcurrencyDeclaration() (*ConcurrencyDeclaration, error) {
+	value, ok := w.Annotations[AnnotationKeyWorkflowConcurrency]
+	if !ok {
+		return nil, nil
+	}
+	var declaration ConcurrencyDeclaration
+	if err := json.Unmarshal([]byte(value), &declaration); err != nil {
+		return nil, NewInvalidInputErrorWithDetails(err,
+			fmt.Sprintf("Failed to parse the concurrency declared in annotation %v", AnnotationKeyWorkflowConcurrency))
+	}
+	if declaration.MaxActiveRuns < 1 {
+		return nil, NewInvalidInputError("The concurrency declared in annotation %v must allow at least 1 active run",
+			AnnotationKeyWorkflowConcurrency)
+	}
+	switch declaration.Policy {
+	case "":
+		declaration.Policy = ConcurrencyPolicyQueue
+	case ConcurrencyPolicyQueue, ConcurrencyPolicyReject:
+	default:
+		return nil, NewInvalidInputError("Unknown concurrency policy %q declared in annotation %v: it must be %v or %v",
+			declaration.Policy, AnnotationKeyWorkflowConcurrency, ConcurrencyPolicyQueue, ConcurrencyPolicyReject)
+	}
+	return &declaration, nil
+}
+
+// The kinds of the serving resources whose readiness is tracked after a run deploys them.
+var deploymentKinds = map[string]bool{"InferenceService": true, "SeldonDeployment": true}
+
+// DeploymentReference identifies a serving resource deployed by a step of a workflow.
+type DeploymentReference struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// Deployments returns the InferenceServices and SeldonDeployments created by the
+// succeeded resource steps of the workflow. The resources whose name is generated, or
+// depends on a variable other than the inputs of the step and the name, namespace and
+// UID of the workflow, are not returned.
+func (w *Workflow) Deployments() []DeploymentReference {
+	templates := make(map[string]*workflowapi.Template)
+	for i := range w.Spec.Templates {
+		templates[w.Spec.Templates[i].Name] = &w.Spec.Templates[i]
+	}
+	found := make(map[DeploymentReference]bool)
+	var deployments []DeploymentReference
+	for _, node := range w.Status.Nodes {
+		template, ok := templates[node.TemplateName]
+		if !ok || template.Resource == nil || node.Phase != workflowapi.NodeSucceeded {
+			continue
+		}
+		switch template.Resource.Action {
+		case "create", "apply", "replace":
+		default:
+			continue
+		}
+		replacements := []string{
+			"{{workflow.name}}", w.Name,
+			"{{workflow.namespace}}", w.Namespace,
+			"{{workflow.uid}}", string(w.UID),
+		}
+		if node.Inputs != nil {
+			for _, parameter := range node.Inputs.Parameters {
+				if parameter.Value != nil {
+					replacements = append(replacements, "{{inputs.parameters."+parameter.Name+"}}", *parameter.Value)
+				}
+			}
+		}
+		var manifest struct {
+			APIVersion string            `json:"apiVersion"`
+			Kind       string            `json:"kind"`
+			Metadata   metav1.ObjectMeta `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(strings.NewReplacer(replacements...).Replace(template.Resource.Manifest)), &manifest); err != nil {
+			glog.Warningf("Failed to parse the manifest of template %v of workflow %v: %v", template.Name, w.Name, err)
+			continue
+		}
+		deployment := DeploymentReference{
+			APIVersion: manifest.APIVersion,
+			Kind:       manifest.Kind,
+			Namespace:  manifest.Metadata.Namespace,
+			Name:       manifest.Metadata.Name,
+		}
+		if deployment.Namespace == "" {
+			deployment.Namespace = w.Namespace
+		}
+		if !deploymentKinds[deployment.Kind] || deployment.Name == "" || strings.Contains(deployment.Name, "{{") ||
+			strings.Contains(deployment.Namespace, "{{") || found[deployment] {
+			continue
+		}
+		found[deployment] = true
+		deployments = append(deployments, deployment)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Kind+"/"+deployments[i].Namespace+"/"+deployments[i].Name <
+			deployments[j].Kind+"/"+deployments[j].Namespace+"/"+deployments[j].Name
+	})
+	return deployments
+}
+
+// ToStringForStore returns the JSON of the workflow to store, with the values of its secret
+// parameters hashed and its metrics push token masked.
 func (w *Workflow) ToStringForStore() string {
+	hashSecrets := !w.secretsHashed && len(w.SecretParameterNames()) > 0
+	if !hashSecrets && !w.hasMetricsPushToken() {
+		return w.ToStringWithSecrets()
+	}
+	masked := NewWorkflow(w.DeepCopy())
+	if hashSecrets {
+		masked.MaskSecretParameters()
+	}
+	masked.MaskMetricsPushToken()
+	return masked.ToStringWithSecrets()
+}
 
+// ToStringWithSecrets returns the JSON of the workflow with the values of its secret
+// parameters, to create it from.
+func (w *Workflow) ToStringWithSecrets() string {
 	workflow, err := json.Marshal(w.Workflow)
 	if err != nil {
 		glog.Errorf("Could not marshal the workflow: %v", w.Workflow)
@@ -156,7 +400,7 @@ func (w *Workflow) HasScheduledWorkflowAsParent() bool {
 func (w *Workflow) GetSpec() *Workflow {
 	spec := w.DeepCopy()
 	spec.Status = workflowapi.WorkflowStatus{}
-	return NewWorkflow(spec)
+	return &Workflow{Workflow: spec, secretsHashed: w.secretsHashed}
 }
 
 // OverrideName sets the name of a Workflow.
@@ -183,6 +427,414 @@ func (w *Workflow) SetLabels(key string, value string) {
 	w.Labels[key] = value
 }
 
+func (w *Workflow) SetAnnotations(key string, value string) {
+	if w.Annotations == nil {
+		w.Annotations = make(map[string]string)
+	}
+	w.Annotations[key] = value
+}
+
+// SetContainerEnv sets environment variables in the containers of the container and
+// script templates of a Workflow, replacing the variables with the same name.
+func (w *Workflow) SetContainerEnv(envs []corev1.EnvVar) {
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		if template.Container != nil {
+			template.Container.Env = mergeEnv(template.Container.Env, envs)
+		}
+		if template.Script != nil {
+			template.Script.Env = mergeEnv(template.Script.Env, envs)
+		}
+	}
+}
+
+// MaskMetricsPushToken masks the metrics push token in the environment of the containers of
+// a Workflow, so that whoever reads the stored workflow can't push metrics with it.
+func (w *Workflow) MaskMetricsPushToken() {
+	mask := func(envs []corev1.EnvVar) {
+		for i := range envs {
+			if envs[i].Name == EnvKeyMetricsPushToken && envs[i].Value != "" {
+				envs[i].Value = MaskedParameterValue
+			}
+		}
+	}
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		if template.Container != nil {
+			mask(template.Container.Env)
+		}
+		if template.Script != nil {
+			mask(template.Script.Env)
+		}
+	}
+}
+
+// hasMetricsPushToken returns whether the containers of a Workflow have a metrics push token
+// in their environment.
+func (w *Workflow) hasMetricsPushToken() bool {
+	for _, template := range w.Spec.Templates {
+		var envs []corev1.EnvVar
+		if template.Container != nil {
+			envs = append(envs, template.Container.Env...)
+		}
+		if template.Script != nil {
+			envs = append(envs, template.Script.Env...)
+		}
+		for _, env := range envs {
+			if env.Name == EnvKeyMetricsPushToken {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func mergeEnv(current []corev1.EnvVar, envs []corev1.EnvVar) []corev1.EnvVar {
+	overridden := make(map[string]bool)
+	for _, env := range envs {
+		overridden[env.Name] = true
+	}
+	merged := make([]corev1.EnvVar, 0, len(current)+len(envs))
+	for _, env := range current {
+		if !overridden[env.Name] {
+			merged = append(merged, env)
+		}
+	}
+	return append(merged, envs...)
+}
+
+// SetArtifactArchive sets whether the output artifacts of the templates of a Workflow are
+// stored as tar.gz archives or as they are. The metrics and the UI metadata are always
+// archived.
+func (w *Workflow) SetArtifactArchive(archive bool) {
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		for j := range template.Outputs.Artifacts {
+			artifact := &template.Outputs.Artifacts[j]
+			if artifact.Name == ArtifactNameMetrics || artifact.Name == ArtifactNameUIMetadata {
+				continue
+			}
+			if archive {
+				artifact.Archive = &workflowapi.ArchiveStrategy{Tar: &workflowapi.TarStrategy{}}
+			} else {
+				artifact.Archive = &workflowapi.ArchiveStrategy{None: &workflowapi.NoneStrategy{}}
+			}
+		}
+	}
+}
+
+// SetArtifactRepository sets the bucket and prefixes the keys of the S3 output artifacts
+// and archive locations of a Workflow. The metrics and the UI metadata of the steps are
+// left in place, since they're read from the repository of the server. An empty bucket
+// keeps the bucket of each location.
+func (w *Workflow) SetArtifactRepository(bucket string, keyPrefix string) {
+	relocate := func(s3 *workflowapi.S3Artifact) {
+		if bucket != "" {
+			s3.Bucket = bucket
+		}
+		if keyPrefix != "" && s3.Key != "" {
+			s3.Key = strings.TrimSuffix(keyPrefix, "/") + "/" + strings.TrimPrefix(s3.Key, "/")
+		}
+	}
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		for j := range template.Outputs.Artifacts {
+			artifact := &template.Outputs.Artifacts[j]
+			if artifact.S3 == nil || artifact.Name == ArtifactNameMetrics || artifact.Name == ArtifactNameUIMetadata {
+				continue
+			}
+			relocate(artifact.S3)
+		}
+		if template.ArchiveLocation != nil && template.ArchiveLocation.S3 != nil {
+			relocate(template.ArchiveLocation.S3)
+		}
+	}
+}
+
+// SetArchiveLogs sets whether the logs of the steps of a Workflow are archived to the
+// artifact repository.
+func (w *Workflow) SetArchiveLogs(archiveLogs bool) {
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		if template.Container == nil && template.Script == nil {
+			continue
+		}
+		if template.ArchiveLocation == nil {
+			template.ArchiveLocation = &workflowapi.ArtifactLocation{}
+		}
+		value := archiveLogs
+		template.ArchiveLocation.ArchiveLogs = &value
+	}
+}
+
+// SetDefaultRetryStrategy sets the retry strategy of the container and script templates of
+// a Workflow not setting their own.
+func (w *Workflow) SetDefaultRetryStrategy(limit int32) {
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		if (template.Container == nil && template.Script == nil) || template.RetryStrategy != nil {
+			continue
+		}
+		value := limit
+		template.RetryStrategy = &workflowapi.RetryStrategy{Limit: &value}
+	}
+}
+
+// SetParallelism sets the maximum number of pods of a Workflow running at once.
+func (w *Workflow) SetParallelism(parallelism int64) {
+	value := parallelism
+	w.Spec.Parallelism = &value
+}
+
+// SetPodPriority sets the priority class and the preemption policy of the pods of a Workflow,
+// the empty ones being left as they are.
+func (w *Workflow) SetPodPriority(priorityClassName string, preemptionPolicy string) {
+	if priorityClassName != "" {
+		w.SetAnnotations(AnnotationKeyWorkflowPodPriorityClassName, priorityClassName)
+	}
+	if preemptionPolicy != "" {
+		w.SetAnnotations(AnnotationKeyWorkflowPodPreemptionPolicy, preemptionPolicy)
+	}
+}
+
+// SetPodScheduling merges scheduling constraints into the container and script templates of
+// a Workflow. The node selector is merged into the one of each template, overriding the
+// labels both set, and the tolerations are added to theirs. A non nil affinity replaces the
+// affinity of the templates.
+func (w *Workflow) SetPodScheduling(nodeSelector map[string]string, tolerations []corev1.Toleration,
+	affinity *corev1.Affinity) {
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		if template.Container == nil && template.Script == nil {
+			continue
+		}
+		if len(nodeSelector) > 0 && template.NodeSelector == nil {
+			template.NodeSelector = make(map[string]string)
+		}
+		for key, value := range nodeSelector {
+			template.NodeSelector[key] = value
+		}
+		template.Tolerations = append(template.Tolerations, tolerations...)
+		if affinity != nil {
+			template.Affinity = affinity.DeepCopy()
+		}
+	}
+}
+
+// SetImagePullSecrets adds secrets to the ones the pods of a Workflow pull their images with.
+func (w *Workflow) SetImagePullSecrets(secrets []string) {
+	for _, secret := range secrets {
+		found := false
+		for _, reference := range w.Spec.ImagePullSecrets {
+			if reference.Name == secret {
+				found = true
+				break
+			}
+		}
+		if !found {
+			w.Spec.ImagePullSecrets = append(w.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		}
+	}
+}
+
+// SetImageRegistryMirrors pulls the images of the containers and sidecars of a Workflow from
+// mirrors. The mirrors replace the registries or repositories they're keyed by, e.g. docker.io
+// or gcr.io/ml-pipeline, the longest one matching an image winning.
+func (w *Workflow) SetImageRegistryMirrors(mirrors map[string]string) {
+	mirror := func(image string) string {
+		qualified := QualifiedImageName(image)
+		prefix, replacement := "", ""
+		for registry, mirror := range mirrors {
+			registry = strings.TrimSuffix(registry, "/")
+			if strings.HasPrefix(qualified, registry+"/") && len(registry) > len(prefix) {
+				prefix, replacement = registry, strings.TrimSuffix(mirror, "/")
+			}
+		}
+		if prefix == "" {
+			return image
+		}
+		return replacement + strings.TrimPrefix(qualified, prefix)
+	}
+	for i := range w.Spec.Templates {
+		template := &w.Spec.Templates[i]
+		if template.Container != nil {
+			template.Container.Image = mirror(template.Container.Image)
+		}
+		if template.Script != nil {
+			template.Script.Image = mirror(template.Script.Image)
+		}
+		for j := range template.Sidecars {
+			template.Sidecars[j].Image = mirror(template.Sidecars[j].Image)
+		}
+	}
+}
+
+// QualifiedImageName returns the name of an image with its registry. The images without a
+// registry are pulled from Docker Hub, the official ones from docker.io/library.
+func QualifiedImageName(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	switch {
+	case len(parts) == 1:
+		return "docker.io/library/" + image
+	case !strings.ContainsAny(parts[0], ".:") && parts[0] != "localhost":
+		return "docker.io/" + image
+	}
+	return image
+}
+
+// SetServiceAccount sets the service account the pods of a Workflow run as.
+func (w *Workflow) SetServiceAccount(serviceAccount string) {
+	w.Spec.ServiceAccountName = serviceAccount
+}
+
+// SetDefaultTTLSecondsAfterFinished sets how long a Workflow is kept once it finishes, unless
+// it sets its own TTL.
+func (w *Workflow) SetDefaultTTLSecondsAfterFinished(ttlSeconds int32) {
+	if w.Spec.TTLSecondsAfterFinished != nil {
+		return
+	}
+	value := ttlSeconds
+	w.Spec.TTLSecondsAfterFinished = &value
+}
+
+// StepAttempts are the attempts of a step retried by Argo, i.e. the children of its retry
+// node.
+type StepAttempts struct {
+	// The retry node of the step.
+	Node workflowapi.NodeStatus
+	// The maximum number of retries of the step, from its retry strategy.
+	RetryLimit int32
+	// The pod nodes of the attempts, in the order they started.
+	Attempts []workflowapi.NodeStatus
+}
+
+// StepAttempts returns the attempts of the steps of the workflow with a retry strategy, in
+// the order the steps started.
+func (w *Workflow) StepAttempts() []StepAttempts {
+	retryLimits := make(map[string]int32)
+	for _, template := range w.Spec.Templates {
+		if template.RetryStrategy != nil && template.RetryStrategy.Limit != nil {
+			retryLimits[template.Name] = *template.RetryStrategy.Limit
+		}
+	}
+	var steps []StepAttempts
+	for _, node := range w.Status.Nodes {
+		if node.Type != workflowapi.NodeTypeRetry {
+			continue
+		}
+		step := StepAttempts{Node: node, RetryLimit: retryLimits[node.TemplateName]}
+		for _, child := range node.Children {
+			if attempt, ok := w.Status.Nodes[child]; ok {
+				step.Attempts = append(step.Attempts, attempt)
+			}
+		}
+		sortNodesByStartTime(step.Attempts)
+		steps = append(steps, step)
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return nodeStartedBefore(steps[i].Node, steps[j].Node)
+	})
+	return steps
+}
+
+// ExitHandler is the status of the onExit template of a workflow, which Argo runs once the
+// main DAG of the workflow completes, e.g. to clean up.
+type ExitHandler struct {
+	// The node of the onExit template.
+	Node workflowapi.NodeStatus
+	// The IDs of the nodes run by the onExit template, the onExit node excluded.
+	NodeIDs []string
+}
+
+// Succeeded returns whether the exit handler completed successfully.
+func (e *ExitHandler) Succeeded() bool {
+	return e.Node.Phase == workflowapi.NodeSucceeded
+}
+
+// ExitHandler returns the status of the onExit template of the workflow, or nil if the
+// workflow has none or Argo hasn't started it yet.
+func (w *Workflow) ExitHandler() *ExitHandler {
+	if w.Spec.OnExit == "" {
+		return nil
+	}
+	// Argo names the onExit node after the workflow, like the root node of the main DAG.
+	exitNodeName := w.Name + ".onExit"
+	for _, node := range w.Status.Nodes {
+		if node.Name != exitNodeName {
+			continue
+		}
+		handler := &ExitHandler{Node: node}
+		visited := map[string]bool{node.ID: true}
+		pending := append([]string(nil), node.Children...)
+		for len(pending) > 0 {
+			id := pending[0]
+			pending = pending[1:]
+			child, ok := w.Status.Nodes[id]
+			if !ok || visited[id] {
+				continue
+			}
+			visited[id] = true
+			handler.NodeIDs = append(handler.NodeIDs, id)
+			pending = append(pending, child.Children...)
+		}
+		sort.Strings(handler.NodeIDs)
+		return handler
+	}
+	return nil
+}
+
+// MainPhase returns the phase of the main DAG of the workflow, which, unlike the phase of
+// the workflow, an exit handler failing doesn't affect. It is empty until Argo starts the
+// workflow.
+func (w *Workflow) MainPhase() string {
+	// The root node of the main DAG has the name of the workflow.
+	for _, node := range w.Status.Nodes {
+		if node.Name == w.Name {
+			return string(node.Phase)
+		}
+	}
+	return ""
+}
+
+// ApprovalGates returns the nodes of the approval gates of the workflow, i.e. of its
+// suspend steps, which wait for a decision while they're running, in the order they
+// started.
+func (w *Workflow) ApprovalGates() []workflowapi.NodeStatus {
+	var gates []workflowapi.NodeStatus
+	for _, node := range w.Status.Nodes {
+		if node.Type == workflowapi.NodeTypeSuspend {
+			gates = append(gates, node)
+		}
+	}
+	sortNodesByStartTime(gates)
+	return gates
+}
+
+// WaitingApprovalGates returns the nodes of the approval gates of the workflow waiting for
+// a decision, in the order they started.
+func (w *Workflow) WaitingApprovalGates() []workflowapi.NodeStatus {
+	var gates []workflowapi.NodeStatus
+	for _, gate := range w.ApprovalGates() {
+		if gate.Phase == workflowapi.NodeRunning {
+			gates = append(gates, gate)
+		}
+	}
+	return gates
+}
+
+func sortNodesByStartTime(nodes []workflowapi.NodeStatus) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodeStartedBefore(nodes[i], nodes[j])
+	})
+}
+
+func nodeStartedBefore(a workflowapi.NodeStatus, b workflowapi.NodeStatus) bool {
+	if !a.StartedAt.Equal(&b.StartedAt) {
+		return a.StartedAt.Before(&b.StartedAt)
+	}
+	return a.ID < b.ID
+}
+
 func (w *Workflow) SetCannonicalLabels(name string, nextScheduledEpoch int64, index int64) {
 	w.SetLabels(LabelKeyWorkflowScheduledWorkflowName, name)
 	w.SetLabels(LabelKeyWorkflowEpoch, FormatInt64ForLabel(nextScheduledEpoch))
@@ -212,3 +864,21 @@ func (w *Workflow) FindObjectStoreArtifactKeyOrEmpty(nodeID string, artifactName
 	}
 	return s3Key
 }
+
+// ArtifactURI returns the URI of the location an artifact is stored at, or empty if the
+// artifact is not stored outside of the workflow. S3 artifacts are identified by their
+// bucket and key, regardless of the endpoint they are accessed through.
+func ArtifactURI(artifact workflowapi.Artifact) string {
+	switch {
+	case artifact.S3 != nil && artifact.S3.Key != "":
+		return fmt.Sprintf("s3://%s/%s", artifact.S3.Bucket, artifact.S3.Key)
+	case artifact.Git != nil:
+		return artifact.Git.Repo
+	case artifact.HTTP != nil:
+		return artifact.HTTP.URL
+	case artifact.Artifactory != nil:
+		return artifact.Artifactory.URL
+	default:
+		return ""
+	}
+}
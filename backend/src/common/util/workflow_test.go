@@ -16,10 +16,13 @@ package util
 
 import (
 	"testing"
+	"time"
 
 	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -149,6 +152,20 @@ func TestCondition(t *testing.T) {
 	assert.Equal(t, "", workflow.Condition())
 }
 
+func TestCondition_Queued(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{
+		Status: workflowapi.WorkflowStatus{Phase: workflowapi.NodeRunning},
+	})
+	workflow.Queue()
+	assert.True(t, *workflow.Spec.Suspend)
+	assert.True(t, workflow.IsQueued())
+	assert.Equal(t, RunConditionQueued, workflow.Condition())
+
+	// A queued workflow terminated before it started
+	workflow.Status.Phase = workflowapi.NodeFailed
+	assert.Equal(t, "Failed", workflow.Condition())
+}
+
 func TestToStringForStore(t *testing.T) {
 	workflow := NewWorkflow(&workflowapi.Workflow{
 		ObjectMeta: metav1.ObjectMeta{
@@ -410,3 +427,440 @@ func TestFindS3ArtifactKey_NodeNotFound(t *testing.T) {
 
 	assert.Empty(t, actualPath)
 }
+
+func TestLastStatusChangeTime(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{
+		Status: workflowapi.WorkflowStatus{
+			StartedAt: metav1.NewTime(time.Unix(10, 0)),
+			Nodes: map[string]workflowapi.NodeStatus{
+				"node-1": {StartedAt: metav1.NewTime(time.Unix(11, 0)), FinishedAt: metav1.NewTime(time.Unix(15, 0))},
+				"node-2": {StartedAt: metav1.NewTime(time.Unix(12, 0))},
+			},
+		},
+	})
+	assert.Equal(t, int64(15), workflow.LastStatusChangeTime().Unix())
+
+	assert.True(t, NewWorkflow(&workflowapi.Workflow{}).LastStatusChangeTime().IsZero())
+}
+
+func TestArtifactURI(t *testing.T) {
+	s3 := workflowapi.Artifact{Name: "model", ArtifactLocation: workflowapi.ArtifactLocation{
+		S3: &workflowapi.S3Artifact{
+			S3Bucket: workflowapi.S3Bucket{Endpoint: "minio-service:9000", Bucket: "mlpipeline"},
+			Key:      "artifacts/run1/model.tgz",
+		},
+	}}
+	assert.Equal(t, "s3://mlpipeline/artifacts/run1/model.tgz", ArtifactURI(s3))
+
+	http := workflowapi.Artifact{ArtifactLocation: workflowapi.ArtifactLocation{
+		HTTP: &workflowapi.HTTPArtifact{URL: "https://example.com/data.csv"},
+	}}
+	assert.Equal(t, "https://example.com/data.csv", ArtifactURI(http))
+
+	raw := workflowapi.Artifact{ArtifactLocation: workflowapi.ArtifactLocation{
+		Raw: &workflowapi.RawArtifact{Data: "hello"},
+	}}
+	assert.Equal(t, "", ArtifactURI(raw))
+}
+
+func TestModelDeclarations(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			AnnotationKeyWorkflowModels: `[{"name": "mnist", "template": "train", "artifact": "model"}]`,
+		},
+	}})
+	declarations, err := workflow.ModelDeclarations()
+	assert.Nil(t, err)
+	assert.Equal(t, []ModelDeclaration{{Name: "mnist", Template: "train", Artifact: "model"}}, declarations)
+
+	declarations, err = NewWorkflow(&workflowapi.Workflow{}).ModelDeclarations()
+	assert.Nil(t, err)
+	assert.Empty(t, declarations)
+}
+
+func TestModelDeclarations_Invalid(t *testing.T) {
+	for _, annotation := range []string{`not json`, `[{"name": "mnist", "template": "train"}]`} {
+		workflow := NewWorkflow(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AnnotationKeyWorkflowModels: annotation},
+		}})
+		_, err := workflow.ModelDeclarations()
+		assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument), annotation)
+	}
+}
+
+func TestConcurrencyDeclaration(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{AnnotationKeyWorkflowConcurrency: `{"maxActiveRuns": 2}`},
+	}})
+	declaration, err := workflow.ConcurrencyDeclaration()
+	assert.Nil(t, err)
+	assert.Equal(t, &ConcurrencyDeclaration{MaxActiveRuns: 2, Policy: ConcurrencyPolicyQueue}, declaration)
+
+	workflow.Annotations[AnnotationKeyWorkflowConcurrency] = `{"maxActiveRuns": 1, "policy": "reject"}`
+	declaration, err = workflow.ConcurrencyDeclaration()
+	assert.Nil(t, err)
+	assert.Equal(t, &ConcurrencyDeclaration{MaxActiveRuns: 1, Policy: ConcurrencyPolicyReject}, declaration)
+
+	declaration, err = NewWorkflow(&workflowapi.Workflow{}).ConcurrencyDeclaration()
+	assert.Nil(t, err)
+	assert.Nil(t, declaration)
+}
+
+func TestConcurrencyDeclaration_Invalid(t *testing.T) {
+	for _, annotation := range []string{`not json`, `{"maxActiveRuns": 0}`, `{"maxActiveRuns": 1, "policy": "drop"}`} {
+		workflow := NewWorkflow(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AnnotationKeyWorkflowConcurrency: annotation},
+		}})
+		_, err := workflow.ConcurrencyDeclaration()
+		assert.True(t, IsUserErrorCodeMatch(err, codes.InvalidArgument), annotation)
+	}
+}
+
+func TestSetContainerEnv(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{
+			{Name: "dag", DAG: &workflowapi.DAGTemplate{}},
+			{Name: "container", Container: &corev1.Container{Env: []corev1.EnvVar{
+				{Name: "A", Value: "1"},
+				{Name: "B", Value: "2"},
+			}}},
+			{Name: "script", Script: &workflowapi.ScriptTemplate{Source: "echo"}},
+		},
+	}})
+	workflow.SetContainerEnv([]corev1.EnvVar{{Name: "B", Value: "3"}, {Name: "C", Value: "4"}})
+
+	assert.Nil(t, workflow.Spec.Templates[0].Container)
+	assert.Equal(t, []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "3"}, {Name: "C", Value: "4"}},
+		workflow.Spec.Templates[1].Container.Env)
+	assert.Equal(t, []corev1.EnvVar{{Name: "B", Value: "3"}, {Name: "C", Value: "4"}},
+		workflow.Spec.Templates[2].Script.Env)
+}
+
+func TestToStringForStore_MasksMetricsPushToken(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{
+			{Name: "container", Container: &corev1.Container{}},
+			{Name: "script", Script: &workflowapi.ScriptTemplate{Source: "echo"}},
+		},
+	}})
+	workflow.SetContainerEnv([]corev1.EnvVar{{Name: EnvKeyRunId, Value: "{{workflow.uid}}"},
+		{Name: EnvKeyMetricsPushToken, Value: "0123abcd"}})
+
+	stored := workflow.ToStringForStore()
+	assert.NotContains(t, stored, "0123abcd")
+	assert.Contains(t, stored, MaskedParameterValue)
+	assert.Contains(t, stored, "{{workflow.uid}}")
+	// The workflow itself keeps the token.
+	assert.Equal(t, "0123abcd", workflow.Spec.Templates[1].Script.Env[1].Value)
+	assert.Contains(t, workflow.ToStringWithSecrets(), "0123abcd")
+}
+
+func TestSetArtifactArchive(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{
+			{Name: "container", Container: &corev1.Container{}, Outputs: workflowapi.Outputs{
+				Artifacts: []workflowapi.Artifact{{Name: "model"}, {Name: ArtifactNameMetrics}, {Name: ArtifactNameUIMetadata}},
+			}},
+		},
+	}})
+	workflow.SetArtifactArchive(false)
+
+	artifacts := workflow.Spec.Templates[0].Outputs.Artifacts
+	assert.Equal(t, &workflowapi.ArchiveStrategy{None: &workflowapi.NoneStrategy{}}, artifacts[0].Archive)
+	assert.Nil(t, artifacts[1].Archive)
+	assert.Nil(t, artifacts[2].Archive)
+
+	workflow.SetArtifactArchive(true)
+	assert.Equal(t, &workflowapi.ArchiveStrategy{Tar: &workflowapi.TarStrategy{}}, artifacts[0].Archive)
+}
+
+func TestSetArchiveLogs(t *testing.T) {
+	archiveLogs := false
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{
+			{Name: "dag", DAG: &workflowapi.DAGTemplate{}},
+			{Name: "container", Container: &corev1.Container{}},
+			{Name: "script", Script: &workflowapi.ScriptTemplate{Source: "echo"}, ArchiveLocation: &workflowapi.ArtifactLocation{
+				ArchiveLogs: &archiveLogs,
+			}},
+		},
+	}})
+	workflow.SetArchiveLogs(true)
+
+	assert.Nil(t, workflow.Spec.Templates[0].ArchiveLocation)
+	assert.True(t, *workflow.Spec.Templates[1].ArchiveLocation.ArchiveLogs)
+	assert.True(t, *workflow.Spec.Templates[2].ArchiveLocation.ArchiveLogs)
+	assert.False(t, archiveLogs)
+}
+
+func TestSetArtifactRepository(t *testing.T) {
+	s3 := func(bucket string, key string) *workflowapi.S3Artifact {
+		return &workflowapi.S3Artifact{S3Bucket: workflowapi.S3Bucket{Endpoint: "minio:9000", Bucket: bucket}, Key: key}
+	}
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{{
+			Name:      "train",
+			Container: &corev1.Container{},
+			Outputs: workflowapi.Outputs{Artifacts: []workflowapi.Artifact{
+				{Name: "model", S3: s3("mlpipeline", "runs/model.tgz")},
+				{Name: ArtifactNameMetrics, S3: s3("mlpipeline", "runs/metrics.tgz")},
+				{Name: "no-location"},
+			}},
+			ArchiveLocation: &workflowapi.ArtifactLocation{S3: s3("mlpipeline", "runs/logs")},
+		}},
+	}})
+	workflow.SetArtifactRepository("team-a", "team-a/")
+
+	template := workflow.Spec.Templates[0]
+	assert.Equal(t, s3("team-a", "team-a/runs/model.tgz"), template.Outputs.Artifacts[0].S3)
+	assert.Equal(t, s3("mlpipeline", "runs/metrics.tgz"), template.Outputs.Artifacts[1].S3)
+	assert.Nil(t, template.Outputs.Artifacts[2].S3)
+	assert.Equal(t, s3("team-a", "team-a/runs/logs"), template.ArchiveLocation.S3)
+
+	// An empty bucket keeps the bucket of the locations.
+	workflow.SetArtifactRepository("", "v2")
+	assert.Equal(t, s3("team-a", "v2/team-a/runs/model.tgz"), workflow.Spec.Templates[0].Outputs.Artifacts[0].S3)
+}
+
+func TestSetDefaultRetryStrategy(t *testing.T) {
+	limit := int32(1)
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{
+			{Name: "dag", DAG: &workflowapi.DAGTemplate{}},
+			{Name: "container", Container: &corev1.Container{}},
+			{Name: "script", Script: &workflowapi.ScriptTemplate{Source: "echo"},
+				RetryStrategy: &workflowapi.RetryStrategy{Limit: &limit}},
+		},
+	}})
+	workflow.SetDefaultRetryStrategy(3)
+
+	assert.Nil(t, workflow.Spec.Templates[0].RetryStrategy)
+	assert.Equal(t, int32(3), *workflow.Spec.Templates[1].RetryStrategy.Limit)
+	assert.Equal(t, int32(1), *workflow.Spec.Templates[2].RetryStrategy.Limit)
+}
+
+func TestSetPodScheduling(t *testing.T) {
+	spot := corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	gpu := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{
+			{Name: "dag", DAG: &workflowapi.DAGTemplate{}},
+			{Name: "train", Container: &corev1.Container{},
+				NodeSelector: map[string]string{"accelerator": "k80", "pool": "default"},
+				Tolerations:  []corev1.Toleration{gpu}},
+			{Name: "script", Script: &workflowapi.ScriptTemplate{Source: "echo"}},
+		},
+	}})
+	affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{Weight: 1}},
+	}}
+	workflow.SetPodScheduling(map[string]string{"pool": "spot"}, []corev1.Toleration{spot}, affinity)
+
+	dag := workflow.Spec.Templates[0]
+	assert.Nil(t, dag.NodeSelector)
+	assert.Nil(t, dag.Tolerations)
+	assert.Nil(t, dag.Affinity)
+	train := workflow.Spec.Templates[1]
+	assert.Equal(t, map[string]string{"accelerator": "k80", "pool": "spot"}, train.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{gpu, spot}, train.Tolerations)
+	assert.Equal(t, affinity, train.Affinity)
+	script := workflow.Spec.Templates[2]
+	assert.Equal(t, map[string]string{"pool": "spot"}, script.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{spot}, script.Tolerations)
+}
+
+func TestSetPodPriority(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{AnnotationKeyWorkflowPodPreemptionPolicy: PreemptionPolicyNever},
+	}})
+	workflow.SetPodPriority("production", "")
+	assert.Equal(t, map[string]string{
+		AnnotationKeyWorkflowPodPriorityClassName: "production",
+		AnnotationKeyWorkflowPodPreemptionPolicy:  PreemptionPolicyNever,
+	}, workflow.Annotations)
+
+	workflow.SetPodPriority("", PreemptionPolicyLowerPriority)
+	assert.Equal(t, PreemptionPolicyLowerPriority, workflow.Annotations[AnnotationKeyWorkflowPodPreemptionPolicy])
+}
+
+func TestSetImagePullSecrets(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-a"}},
+	}})
+	workflow.SetImagePullSecrets([]string{"registry-a", "registry-b"})
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry-a"}, {Name: "registry-b"}},
+		workflow.Spec.ImagePullSecrets)
+}
+
+func TestSetImageRegistryMirrors(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{Spec: workflowapi.WorkflowSpec{
+		Templates: []workflowapi.Template{
+			{Name: "train", Container: &corev1.Container{Image: "gcr.io/ml-pipeline/ml-pipeline-kubeflow-tf:0.1"},
+				Sidecars: []workflowapi.Sidecar{{Container: corev1.Container{Image: "python:3.7"}}}},
+			{Name: "script", Script: &workflowapi.ScriptTemplate{Container: corev1.Container{Image: "gcr.io/other/image"}}},
+			{Name: "private", Container: &corev1.Container{Image: "registry.corp:5000/image"}},
+		},
+	}})
+	workflow.SetImageRegistryMirrors(map[string]string{
+		"docker.io":          "mirror.corp/dockerhub",
+		"gcr.io":             "mirror.corp/gcr/",
+		"gcr.io/ml-pipeline": "mirror.corp/kfp",
+	})
+
+	assert.Equal(t, "mirror.corp/kfp/ml-pipeline-kubeflow-tf:0.1", workflow.Spec.Templates[0].Container.Image)
+	assert.Equal(t, "mirror.corp/dockerhub/library/python:3.7", workflow.Spec.Templates[0].Sidecars[0].Image)
+	assert.Equal(t, "mirror.corp/gcr/other/image", workflow.Spec.Templates[1].Script.Image)
+	assert.Equal(t, "registry.corp:5000/image", workflow.Spec.Templates[2].Container.Image)
+}
+
+func TestSetDefaultTTLSecondsAfterFinished(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{})
+	workflow.SetDefaultTTLSecondsAfterFinished(3600)
+	assert.Equal(t, int32(3600), *workflow.Spec.TTLSecondsAfterFinished)
+
+	// The TTL of the workflow is kept.
+	workflow.SetDefaultTTLSecondsAfterFinished(60)
+	assert.Equal(t, int32(3600), *workflow.Spec.TTLSecondsAfterFinished)
+}
+
+func TestStepAttempts(t *testing.T) {
+	limit := int32(2)
+	start := metav1.NewTime(time.Unix(100, 0))
+	workflow := NewWorkflow(&workflowapi.Workflow{
+		Spec: workflowapi.WorkflowSpec{Templates: []workflowapi.Template{
+			{Name: "train", Container: &corev1.Container{}, RetryStrategy: &workflowapi.RetryStrategy{Limit: &limit}},
+		}},
+		Status: workflowapi.WorkflowStatus{Nodes: map[string]workflowapi.NodeStatus{
+			"wf": {ID: "wf", Type: workflowapi.NodeTypeDAG, Children: []string{"wf-1"}},
+			"wf-1": {ID: "wf-1", Type: workflowapi.NodeTypeRetry, TemplateName: "train", StartedAt: start,
+				Children: []string{"wf-3", "wf-2"}},
+			"wf-2": {ID: "wf-2", Type: workflowapi.NodeTypePod, Phase: workflowapi.NodeFailed, StartedAt: start,
+				Message: "failed with exit code 1"},
+			"wf-3": {ID: "wf-3", Type: workflowapi.NodeTypePod, Phase: workflowapi.NodeRunning,
+				StartedAt: metav1.NewTime(time.Unix(200, 0))},
+		}},
+	})
+
+	steps := workflow.StepAttempts()
+	assert.Len(t, steps, 1)
+	assert.Equal(t, "wf-1", steps[0].Node.ID)
+	assert.Equal(t, int32(2), steps[0].RetryLimit)
+	assert.Len(t, steps[0].Attempts, 2)
+	assert.Equal(t, "wf-2", steps[0].Attempts[0].ID)
+	assert.Equal(t, "failed with exit code 1", steps[0].Attempts[0].Message)
+	assert.Equal(t, "wf-3", steps[0].Attempts[1].ID)
+}
+
+func TestExitHandler(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "wf"},
+		Spec:       workflowapi.WorkflowSpec{OnExit: "cleanup"},
+		Status: workflowapi.WorkflowStatus{Phase: workflowapi.NodeFailed, Nodes: map[string]workflowapi.NodeStatus{
+			"wf":   {ID: "wf", Name: "wf", Phase: workflowapi.NodeSucceeded, Children: []string{"wf-4"}},
+			"wf-4": {ID: "wf-4", Name: "wf.train", Phase: workflowapi.NodeSucceeded},
+			"wf-1": {ID: "wf-1", Name: "wf.onExit", Phase: workflowapi.NodeSucceeded, Children: []string{"wf-2"}},
+			"wf-2": {ID: "wf-2", Name: "wf.onExit[0]", Phase: workflowapi.NodeSucceeded, Children: []string{"wf-3"}},
+			"wf-3": {ID: "wf-3", Name: "wf.onExit[0].delete", Phase: workflowapi.NodeSucceeded},
+		}},
+	})
+
+	handler := workflow.ExitHandler()
+	assert.Equal(t, "wf-1", handler.Node.ID)
+	assert.Equal(t, []string{"wf-2", "wf-3"}, handler.NodeIDs)
+	assert.True(t, handler.Succeeded())
+	assert.Equal(t, "Succeeded", workflow.MainPhase())
+}
+
+func TestExitHandler_NotStarted(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "wf"},
+		Spec:       workflowapi.WorkflowSpec{OnExit: "cleanup"},
+	})
+	assert.Nil(t, workflow.ExitHandler())
+	assert.Equal(t, "", workflow.MainPhase())
+
+	workflow = NewWorkflow(&workflowapi.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "wf"}})
+	assert.Nil(t, workflow.ExitHandler())
+}
+
+func TestApprovalGates(t *testing.T) {
+	workflow := NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "wf"},
+		Status: workflowapi.WorkflowStatus{Nodes: map[string]workflowapi.NodeStatus{
+			"wf": {ID: "wf", Name: "wf", Type: workflowapi.NodeTypeDAG, Phase: workflowapi.NodeRunning},
+			"wf-1": {ID: "wf-1", Type: workflowapi.NodeTypeSuspend, Phase: workflowapi.NodeRunning,
+				StartedAt: metav1.NewTime(time.Unix(200, 0))},
+			"wf-2": {ID: "wf-2", Type: workflowapi.NodeTypeSuspend, Phase: workflowapi.NodeSucceeded,
+				StartedAt: metav1.NewTime(time.Unix(100, 0))},
+			"wf-3": {ID: "wf-3", Type: workflowapi.NodeTypePod, Phase: workflowapi.NodeRunning},
+		}},
+	})
+
+	gates := workflow.ApprovalGates()
+	assert.Len(t, gates, 2)
+	assert.Equal(t, "wf-2", gates[0].ID)
+	assert.Equal(t, "wf-1", gates[1].ID)
+	waiting := workflow.WaitingApprovalGates()
+	assert.Len(t, waiting, 1)
+	assert.Equal(t, "wf-1", waiting[0].ID)
+}
+
+func TestDeployments(t *testing.T) {
+	name := "mnist"
+	workflow := NewWorkflow(&workflowapi.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "run1", Namespace: "kubeflow"},
+		Spec: workflowapi.WorkflowSpec{Templates: []workflowapi.Template{
+			{Name: "deploy", Resource: &workflowapi.ResourceTemplate{Action: "apply", Manifest: `
+apiVersion: serving.kubeflow.org/v1alpha1
+kind: InferenceService
+metadata:
+  name: "{{inputs.parameters.name}}-{{workflow.name}}"
+`}},
+			{Name: "seldon", Resource: &workflowapi.ResourceTemplate{Action: "create", Manifest: `
+apiVersion: machinelearning.seldon.io/v1alpha2
+kind: SeldonDeployment
+metadata:
+  name: seldon
+  namespace: serving
+`}},
+			{Name: "generated", Resource: &workflowapi.ResourceTemplate{Action: "create", Manifest: `
+apiVersion: serving.kubeflow.org/v1alpha1
+kind: InferenceService
+metadata:
+  generateName: generated-
+`}},
+			{Name: "delete", Resource: &workflowapi.ResourceTemplate{Action: "delete", Manifest: `
+apiVersion: serving.kubeflow.org/v1alpha1
+kind: InferenceService
+metadata:
+  name: old
+`}},
+			{Name: "configmap", Resource: &workflowapi.ResourceTemplate{Action: "create", Manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+`}},
+		}},
+		Status: workflowapi.WorkflowStatus{Nodes: map[string]workflowapi.NodeStatus{
+			"node1": {TemplateName: "deploy", Phase: workflowapi.NodeSucceeded, Inputs: &workflowapi.Inputs{
+				Parameters: []workflowapi.Parameter{{Name: "name", Value: &name}},
+			}},
+			"node2": {TemplateName: "seldon", Phase: workflowapi.NodeSucceeded},
+			"node3": {TemplateName: "seldon", Phase: workflowapi.NodeSucceeded},
+			"node4": {TemplateName: "generated", Phase: workflowapi.NodeSucceeded},
+			"node5": {TemplateName: "delete", Phase: workflowapi.NodeSucceeded},
+			"node6": {TemplateName: "configmap", Phase: workflowapi.NodeSucceeded},
+		}},
+	})
+	assert.Equal(t, []DeploymentReference{
+		{APIVersion: "serving.kubeflow.org/v1alpha1", Kind: "InferenceService", Namespace: "kubeflow", Name: "mnist-run1"},
+		{APIVersion: "machinelearning.seldon.io/v1alpha2", Kind: "SeldonDeployment", Namespace: "serving", Name: "seldon"},
+	}, workflow.Deployments())
+
+	// The resources of the failed steps aren't deployed.
+	node := workflow.Status.Nodes["node1"]
+	node.Phase = workflowapi.NodeFailed
+	workflow.Status.Nodes["node1"] = node
+	assert.Equal(t, 1, len(workflow.Deployments()))
+}
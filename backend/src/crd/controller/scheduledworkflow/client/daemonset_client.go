@@ -0,0 +1,74 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	wraperror "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+)
+
+type DaemonSetClientInterface interface {
+	CreateIfNotExist(daemonSet *appsv1.DaemonSet) (bool, error)
+	DeleteIfExists(namespace string, name string) (bool, error)
+}
+
+// DaemonSetClient is a client to create and delete the DaemonSets pre-pulling the images
+// of the ScheduledWorkflows.
+type DaemonSetClient struct {
+	kubeClientSet kubernetes.Interface
+}
+
+// NewDaemonSetClient creates an instance of the DaemonSetClient.
+func NewDaemonSetClient(kubeClientSet kubernetes.Interface) *DaemonSetClient {
+	return &DaemonSetClient{kubeClientSet: kubeClientSet}
+}
+
+// CreateIfNotExist creates a DaemonSet, unless a DaemonSet of the same name exists. It
+// returns whether the DaemonSet was created.
+func (c *DaemonSetClient) CreateIfNotExist(daemonSet *appsv1.DaemonSet) (bool, error) {
+	daemonSets := c.kubeClientSet.AppsV1().DaemonSets(daemonSet.Namespace)
+	_, err := daemonSets.Get(daemonSet.Name, metav1.GetOptions{})
+	if err == nil {
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, wraperror.Wrapf(err, "Error getting DaemonSet (%v) in namespace (%v)", daemonSet.Name,
+			daemonSet.Namespace)
+	}
+	if _, err := daemonSets.Create(daemonSet); err != nil && !apierrors.IsAlreadyExists(err) {
+		return false, wraperror.Wrapf(err, "Error creating DaemonSet (%v) in namespace (%v)", daemonSet.Name,
+			daemonSet.Namespace)
+	}
+	return true, nil
+}
+
+// DeleteIfExists deletes a DaemonSet and its pods, if it exists. It returns whether the
+// DaemonSet was deleted.
+func (c *DaemonSetClient) DeleteIfExists(namespace string, name string) (bool, error) {
+	propagation := metav1.DeletePropagationBackground
+	err := c.kubeClientSet.AppsV1().DaemonSets(namespace).Delete(name,
+		&metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, wraperror.Wrapf(err, "Error deleting DaemonSet (%v) in namespace (%v)", name, namespace)
+	}
+	return true, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// DaemonSetClientFake keeps the DaemonSets in memory.
+type DaemonSetClientFake struct {
+	DaemonSets map[string]*appsv1.DaemonSet
+	// The number of calls made, to check that the DaemonSets are not created or deleted
+	// on every sync.
+	Calls int
+	err   error
+}
+
+func NewDaemonSetClientFake() *DaemonSetClientFake {
+	return &DaemonSetClientFake{DaemonSets: make(map[string]*appsv1.DaemonSet)}
+}
+
+func (c *DaemonSetClientFake) CreateIfNotExist(daemonSet *appsv1.DaemonSet) (bool, error) {
+	c.Calls++
+	if c.err != nil {
+		return false, c.err
+	}
+	key := daemonSet.Namespace + "/" + daemonSet.Name
+	if _, ok := c.DaemonSets[key]; ok {
+		return false, nil
+	}
+	c.DaemonSets[key] = daemonSet
+	return true, nil
+}
+
+func (c *DaemonSetClientFake) DeleteIfExists(namespace string, name string) (bool, error) {
+	c.Calls++
+	if c.err != nil {
+		return false, c.err
+	}
+	key := namespace + "/" + name
+	if _, ok := c.DaemonSets[key]; !ok {
+		return false, nil
+	}
+	delete(c.DaemonSets, key)
+	return true, nil
+}
+
+// SetError makes the calls fail with an error.
+func (c *DaemonSetClientFake) SetError(err error) {
+	c.err = err
+}
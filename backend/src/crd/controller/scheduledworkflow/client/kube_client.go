@@ -17,6 +17,8 @@ package client
 import (
 	"fmt"
 
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	commonutil "github.com/kubeflow/pipelines/backend/src/common/util"
 	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -47,6 +49,12 @@ func NewKubeClient(kubeClientSet kubernetes.Interface, recorder record.EventReco
 	}
 }
 
+// RecordWorkflowTriggered records, on a workflow, that it was created by a scheduled workflow.
+func (k *KubeClient) RecordWorkflowTriggered(swf *swfapi.ScheduledWorkflow, workflow *workflowapi.Workflow) {
+	k.recorder.Eventf(workflow, corev1.EventTypeNormal, commonutil.EventReasonWorkflowTriggered,
+		"Workflow triggered by ScheduledWorkflow %v", swf.Name)
+}
+
 // RecordSyncSuccess records the success of a sync.
 func (k *KubeClient) RecordSyncSuccess(swf *swfapi.ScheduledWorkflow, message string) {
 	k.recorder.Event(swf, corev1.EventTypeNormal, successSynced,
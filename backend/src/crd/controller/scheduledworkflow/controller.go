@@ -20,6 +20,7 @@ import (
 
 	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
 	workflowclientset "github.com/argoproj/argo/pkg/client/clientset/versioned"
+	workflowscheme "github.com/argoproj/argo/pkg/client/clientset/versioned/scheme"
 	workflowinformers "github.com/argoproj/argo/pkg/client/informers/externalversions"
 	commonutil "github.com/kubeflow/pipelines/backend/src/common/util"
 	"github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/client"
@@ -71,6 +72,13 @@ type Controller struct {
 
 	// An interface to generate the current time.
 	time commonutil.TimeInterface
+
+	// The part of the ScheduledWorkflows processed by this replica. Nil to process all of
+	// them.
+	shard *commonutil.Shard
+
+	// Nil if the images are not pulled ahead of the triggers.
+	prePuller *PrePuller
 }
 
 // NewController returns a new sample controller
@@ -80,7 +88,9 @@ func NewController(
 	workflowClientSet workflowclientset.Interface,
 	swfInformerFactory swfinformers.SharedInformerFactory,
 	workflowInformerFactory workflowinformers.SharedInformerFactory,
-	time commonutil.TimeInterface) *Controller {
+	time commonutil.TimeInterface,
+	shard *commonutil.Shard,
+	prePuller *PrePuller) *Controller {
 
 	// obtain references to shared informers
 	swfInformer := swfInformerFactory.Scheduledworkflow().V1alpha1().ScheduledWorkflows()
@@ -89,6 +99,7 @@ func NewController(
 	// Add controller types to the default Kubernetes Scheme so Events can be
 	// logged for controller types.
 	swfScheme.AddToScheme(scheme.Scheme)
+	workflowscheme.AddToScheme(scheme.Scheme)
 
 	// Create event broadcaster
 	log.Info("Creating event broadcaster")
@@ -103,7 +114,9 @@ func NewController(
 		workflowClient: client.NewWorkflowClient(workflowClientSet, workflowInformer),
 		workqueue: workqueue.NewNamedRateLimitingQueue(
 			workqueue.NewItemExponentialFailureRateLimiter(DefaultJobBackOff, MaxJobBackOff), swfregister.Kind),
-		time: time,
+		time:      time,
+		shard:     shard,
+		prePuller: prePuller,
 	}
 
 	log.Info("Setting up event handlers")
@@ -175,6 +188,11 @@ func (c *Controller) Run(threadiness int, stopCh <-chan struct{}) error {
 	return nil
 }
 
+// HasSynced returns whether the informer caches of the controller are synced.
+func (c *Controller) HasSynced() bool {
+	return c.workflowClient.HasSynced()() && c.swfClient.HasSynced()()
+}
+
 // runWorker is a long-running function that will continually call the
 // processNextWorkItem function in order to read and process a message on the
 // workqueue. It enforces that the syncHandler is never invoked concurrently with the same key.
@@ -193,12 +211,15 @@ func (c *Controller) enqueueScheduledWorkflow(obj interface{}) {
 		runtime.HandleError(fmt.Errorf("Equeuing object: error: %v: %+v", err, obj))
 		return
 	}
+	if !c.shard.Owns(key) {
+		return
+	}
 	c.workqueue.AddRateLimited(key)
 }
 
 func (c *Controller) enqueueScheduledWorkflowForDelete(obj interface{}) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-	if err == nil {
+	if err == nil && c.shard.Owns(key) {
 		c.workqueue.Add(key)
 	}
 }
@@ -378,6 +399,9 @@ func (c *Controller) syncHandler(key string) (
 	// Get the ScheduledWorkflow with this namespace/name
 	swf, err = c.swfClient.Get(namespace, name)
 	if err != nil {
+		if c.prePuller != nil {
+			c.prePuller.Forget(key)
+		}
 		// Permanent failure.
 		// The ScheduledWorkflow may no longer exist, we stop processing and do not retry.
 		return false, false, nil,
@@ -419,6 +443,15 @@ func (c *Controller) syncHandler(key string) (
 			wraperror.Wrapf(err, "Syncing ScheduledWorkflow (%v): transient failure, can't update swf status: %v", name, err)
 	}
 
+	if c.prePuller != nil {
+		if err := c.prePuller.Sync(swf, nextScheduledEpoch, nowEpoch); err != nil {
+			// The images are pulled by the runs instead. The pre-pull is retried on the next sync.
+			log.WithFields(log.Fields{
+				ScheduledWorkflow: name,
+			}).Errorf("Syncing ScheduledWorkflow (%v): failed to pre-pull the images: %v", name, err)
+		}
+	}
+
 	if workflow != nil {
 		// Success. Since we created a new workflow, sync again soon since there might be one more
 		// resource to create.
@@ -501,6 +534,7 @@ func (c *Controller) submitNewWorkflowIfNotAlreadySubmitted(
 	if err != nil {
 		return nil, err
 	}
+	c.kubeClient.RecordWorkflowTriggered(swf.Get(), createdWorkflow.Get())
 	return createdWorkflow, nil
 }
 
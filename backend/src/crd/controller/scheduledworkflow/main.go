@@ -16,11 +16,22 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
 	"time"
 
 	workflowclientSet "github.com/argoproj/argo/pkg/client/clientset/versioned"
 	workflowinformers "github.com/argoproj/argo/pkg/client/informers/externalversions"
+	"github.com/kubeflow/pipelines/backend/src/common/diagnostics"
+	"github.com/kubeflow/pipelines/backend/src/common/features"
+	"github.com/kubeflow/pipelines/backend/src/common/health"
+	"github.com/kubeflow/pipelines/backend/src/common/leaderelection"
+	"github.com/kubeflow/pipelines/backend/src/common/metrics"
+	"github.com/kubeflow/pipelines/backend/src/common/reload"
 	commonutil "github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/client"
 	swfclientset "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/clientset/versioned"
 	swfinformers "github.com/kubeflow/pipelines/backend/src/crd/pkg/client/informers/externalversions"
 	"github.com/kubeflow/pipelines/backend/src/crd/pkg/signals"
@@ -28,15 +39,56 @@ import (
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
 )
 
+// The features of the controller, set by the featureGates flag.
+var featureGate = features.NewGate()
+
 var (
-	masterURL  string
-	kubeconfig string
+	masterURL            string
+	kubeconfig           string
+	metricsAddress       string
+	healthCheckTimeout   time.Duration
+	metricsExporter      string
+	statsdAddress        string
+	statsdPrefix         string
+	crdWaitTimeout       time.Duration
+	leaderElect          bool
+	leaseNamespace       string
+	leaseName            string
+	leaseDuration        time.Duration
+	renewDeadline        time.Duration
+	retryPeriod          time.Duration
+	shardIndex           int
+	shardCount           int
+	prePullLeadTime      time.Duration
+	prePullPauseImage    string
+	diagnosticsAddress   string
+	configFile           string
+	configReloadInterval time.Duration
 )
 
 func main() {
 	flag.Parse()
+	if configFile != "" {
+		content, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			log.Fatalf("Error reading the config file: %v", err)
+		}
+		if err := reload.SetFlags(flag.CommandLine, content); err != nil {
+			log.Fatalf("Error applying the config file: %v", err)
+		}
+	}
+
+	for _, state := range featureGate.States() {
+		log.Infof("Feature %s (%s) enabled: %t", state.Feature, state.Stage, state.Enabled)
+	}
+
+	// The diagnostics port also serves the effective flags.
+	config := reload.NewRegistry()
+	config.RegisterFlags(flag.CommandLine)
+	go diagnostics.ListenAndServe(diagnosticsAddress, config)
 
 	// set up signals so we handle the first shutdown signal gracefully
 	stopCh := signals.SetupSignalHandler()
@@ -64,23 +116,129 @@ func main() {
 	scheduleInformerFactory := swfinformers.NewSharedInformerFactory(scheduleClient, time.Second*30)
 	workflowInformerFactory := workflowinformers.NewSharedInformerFactory(workflowClient, time.Second*30)
 
+	// Expose the depth and the latency of the work queue of the controller.
+	workqueue.SetProvider(metrics.NewWorkqueueMetricsProvider(metrics.DefaultRegistry))
+	sink, err := metrics.NewSink(metricsExporter, statsdAddress, statsdPrefix)
+	if err != nil {
+		log.Fatalf("Error creating the metrics exporter: %v", err)
+	}
+	if sink != nil {
+		metrics.AddSink(sink)
+	}
+
+	shard, err := commonutil.NewShard(shardIndex, shardCount)
+	if err != nil {
+		log.Fatalf("Error configuring the shard: %v", err)
+	}
+
+	prePuller := NewPrePuller(client.NewDaemonSetClient(kubeClient), prePullLeadTime, prePullPauseImage)
+	if prePuller != nil {
+		config.Register("prePullLeadTime", prePullLeadTime.String(), reload.Duration(prePuller.SetLeadTime))
+	}
+	if configFile != "" {
+		// The first check only records the content of the config file applied above.
+		watcher := reload.NewFileWatcher(configFile, configReloadInterval, func(content []byte) error {
+			return config.UpdateFlags(flag.CommandLine, content)
+		})
+		if err := watcher.Check(); err != nil {
+			log.Errorf("Error applying the config file: %v", err)
+		}
+		go watcher.Run(stopCh)
+	}
+
 	controller := NewController(
 		kubeClient,
 		scheduleClient,
 		workflowClient,
 		scheduleInformerFactory,
 		workflowInformerFactory,
-		commonutil.NewRealTime())
+		commonutil.NewRealTime(),
+		shard,
+		prePuller)
+
+	// Wait for the CRDs instead of crash-looping until they're installed.
+	gate := health.NewStartupGate(time.Second, 15*time.Second, healthCheckTimeout)
+	gate.Add("workflow_crd", crdWaitTimeout,
+		health.APIResourceCheck(kubeClient.Discovery(), "argoproj.io/v1alpha1", "workflows"))
+	gate.Add("scheduledworkflow_crd", crdWaitTimeout,
+		health.APIResourceCheck(kubeClient.Discovery(), "kubeflow.org/v1alpha1", "scheduledworkflows"))
+
+	if metricsAddress != "" {
+		checker := health.NewChecker(healthCheckTimeout)
+		checker.AddReadinessCheck("startup", gate.ReadinessCheck())
+		checker.AddReadinessCheck("kubernetes", health.KubernetesAPICheck(kubeClient.Discovery()))
+		checker.AddReadinessCheck("informers", health.CacheSyncedCheck(controller.HasSynced))
+		go serveMonitoring(metricsAddress, checker)
+	}
+
+	if err = gate.Wait(); err != nil {
+		log.Fatalf("Error waiting for the dependencies of the controller: %v", err)
+	}
 
 	go scheduleInformerFactory.Start(stopCh)
 	go workflowInformerFactory.Start(stopCh)
 
-	if err = controller.Run(2, stopCh); err != nil {
-		log.Fatalf("Error running controller: %s", err.Error())
+	if !leaderElect {
+		if err = controller.Run(2, stopCh); err != nil {
+			log.Fatalf("Error running controller: %s", err.Error())
+		}
+		return
 	}
+
+	// Only the replica holding the lease of the shard runs the controller, so that the
+	// other replicas take over right away when it's stopped, e.g. during an upgrade.
+	if shard != nil {
+		leaseName = fmt.Sprintf("%s-%d", leaseName, shardIndex)
+	}
+	elector, err := leaderelection.NewReplicaElector(kubeClient.CoreV1().RESTClient(), leaseNamespace, leaseName,
+		leaseDuration, renewDeadline, retryPeriod, commonutil.NewRealTime())
+	if err != nil {
+		log.Fatalf("Error creating the leader elector: %v", err)
+	}
+	elector.Register("ScheduledWorkflow controller", func(leaderStopCh <-chan struct{}) {
+		if err := controller.Run(2, leaderStopCh); err != nil {
+			log.Fatalf("Error running controller: %s", err.Error())
+		}
+		// The work queue of the controller can't be restarted once shut down.
+		select {
+		case <-stopCh:
+		default:
+			log.Fatalf("Lost lease %v, exiting", leaseName)
+		}
+	})
+	elector.Run(stopCh)
 }
 
 func init() {
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	flag.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	flag.StringVar(&metricsAddress, "metricsAddress", ":8080", "The address serving the Prometheus metrics and the health probes. Empty to disable.")
+	flag.DurationVar(&healthCheckTimeout, "healthCheckTimeout", 5*time.Second, "Duration to wait for each dependency check of the health probes.")
+	flag.StringVar(&metricsExporter, "metricsExporter", metrics.ExporterPrometheus, "Where the metrics are exported to in addition to the Prometheus endpoint: prometheus (nowhere else), statsd or dogstatsd.")
+	flag.StringVar(&statsdAddress, "statsdAddress", "localhost:8125", "Address (host:port) of the StatsD server the metrics are sent to by the statsd and dogstatsd exporters.")
+	flag.StringVar(&statsdPrefix, "statsdPrefix", "kfp.", "Prefix of the names of the metrics sent to the StatsD server.")
+	flag.DurationVar(&crdWaitTimeout, "crdWaitTimeout", 5*time.Minute, "Duration to wait at startup for each of the CRDs of the workflows and the scheduled workflows to be installed. 0 to wait forever.")
+	flag.BoolVar(&leaderElect, "leaderElect", false, "Whether only the replica holding a Kubernetes lease runs the controller, so that several replicas can be deployed.")
+	flag.StringVar(&leaseNamespace, "leaseNamespace", os.Getenv("POD_NAMESPACE"), "The namespace of the lease of the leader election.")
+	flag.StringVar(&leaseName, "leaseName", "ml-pipeline-scheduledworkflow", "The name of the lease of the leader election, suffixed by the shard index if the ScheduledWorkflows are sharded.")
+	flag.DurationVar(&leaseDuration, "leaseDuration", 15*time.Second, "Duration after which the lease is taken over if its holder didn't renew it.")
+	flag.DurationVar(&renewDeadline, "renewDeadline", 10*time.Second, "Duration the holder of the lease tries to renew it before it stops the controller. Shorter than the lease duration.")
+	flag.DurationVar(&retryPeriod, "retryPeriod", 2*time.Second, "Duration between the attempts to acquire or renew the lease.")
+	flag.IntVar(&shardIndex, "shardIndex", 0, "Index of the shard of the ScheduledWorkflows processed by this deployment, from 0 to the shard count minus one.")
+	flag.IntVar(&shardCount, "shardCount", 1, "Number of deployments the ScheduledWorkflows are split between by the hash of their namespace/name. 1 to process all of them.")
+	flag.DurationVar(&prePullLeadTime, "prePullLeadTime", 0, "Duration before the next trigger of each ScheduledWorkflow during which its images are pulled on the nodes its workflows run on. 0 to disable the pre-pull.")
+	flag.StringVar(&prePullPauseImage, "prePullPauseImage", "k8s.gcr.io/pause:3.1", "The image the pre-pull pods idle in once they pulled the images of a ScheduledWorkflow.")
+	flag.StringVar(&diagnosticsAddress, "diagnosticsAddress", "", "Address of the admin port serving pprof, expvar, goroutine dumps and the effective flags. Disabled if empty.")
+	flag.StringVar(&configFile, "configFile", "", "Path to a JSON file, e.g. mounted from a ConfigMap, setting the flags by name over the command line. The changes of prePullLeadTime are applied without a restart. Disabled if empty.")
+	flag.DurationVar(&configReloadInterval, "configReloadInterval", 30*time.Second, "How often the config file is checked for changes.")
+	flag.Var(featureGate, "featureGates", "The features enabled while they land, e.g. Caching=true,V2API=false. Set like the FeatureConfig.Gates of the ML pipeline API server.")
+}
+
+func serveMonitoring(address string, checker *health.Checker) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	checker.RegisterHandlers(mux)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		log.Errorf("Failed to serve monitoring endpoints on %s: %v", address, err)
+	}
 }
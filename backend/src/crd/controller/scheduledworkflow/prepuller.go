@@ -0,0 +1,119 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	commonutil "github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/client"
+	"github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// PrePuller pulls the images of the ScheduledWorkflows on the nodes ahead of their next
+// trigger, so that their runs don't wait for cold image pulls. The images are pulled by a
+// DaemonSet created once the trigger is within the lead time, and deleted once it passed.
+type PrePuller struct {
+	client     client.DaemonSetClientInterface
+	pauseImage string
+
+	mutex sync.Mutex
+	// The lead time, which can be changed while the ScheduledWorkflows are synced.
+	leadTime time.Duration
+	// Whether the DaemonSet of each ScheduledWorkflow exists, by namespace/name. The
+	// ScheduledWorkflows missing are synced after a restart, so that their DaemonSet is
+	// created or deleted once instead of on every sync.
+	prePulling map[string]bool
+}
+
+// NewPrePuller creates a PrePuller. It returns nil if the lead time is 0, i.e. the images
+// are pulled by the runs.
+func NewPrePuller(client client.DaemonSetClientInterface, leadTime time.Duration, pauseImage string) *PrePuller {
+	if leadTime <= 0 {
+		return nil
+	}
+	return &PrePuller{
+		client:     client,
+		leadTime:   leadTime,
+		pauseImage: pauseImage,
+		prePulling: make(map[string]bool),
+	}
+}
+
+// Sync creates the DaemonSet of a ScheduledWorkflow whose next trigger is within the lead
+// time, and deletes it otherwise.
+func (p *PrePuller) Sync(swf *util.ScheduledWorkflow, nextScheduledEpoch int64, nowEpoch int64) error {
+	key := swf.Namespace + "/" + swf.Name
+	p.mutex.Lock()
+	leadTime := p.leadTime
+	prePulling, known := p.prePulling[key]
+	p.mutex.Unlock()
+	shouldPrePull := swf.ShouldPrePull(nextScheduledEpoch, nowEpoch, int64(leadTime.Seconds())) &&
+		len(swf.Images()) > 0
+
+	if known && prePulling == shouldPrePull {
+		return nil
+	}
+
+	if shouldPrePull {
+		created, err := p.client.CreateIfNotExist(swf.NewPrePullDaemonSet(p.pauseImage))
+		if err != nil {
+			return err
+		}
+		if created {
+			log.WithFields(log.Fields{
+				ScheduledWorkflow: swf.Name,
+			}).Infof("Pre-pulling the images of ScheduledWorkflow (%v) ahead of its trigger at %v.",
+				swf.Name, commonutil.FormatTimeForLogging(nextScheduledEpoch))
+		}
+	} else {
+		deleted, err := p.client.DeleteIfExists(swf.Namespace, swf.PrePullDaemonSetName())
+		if err != nil {
+			return err
+		}
+		if deleted {
+			log.WithFields(log.Fields{
+				ScheduledWorkflow: swf.Name,
+			}).Infof("Stopped pre-pulling the images of ScheduledWorkflow (%v).", swf.Name)
+		}
+	}
+
+	p.mutex.Lock()
+	p.prePulling[key] = shouldPrePull
+	p.mutex.Unlock()
+	return nil
+}
+
+// SetLeadTime changes the lead time of the ScheduledWorkflows synced from now on. The pre-pull
+// is only disabled by a restart.
+func (p *PrePuller) SetLeadTime(leadTime time.Duration) error {
+	if leadTime <= 0 {
+		return fmt.Errorf("the lead time of the pre-pull must be positive, not %v", leadTime)
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.leadTime = leadTime
+	return nil
+}
+
+// Forget forgets a deleted ScheduledWorkflow. Its DaemonSet is deleted with it.
+func (p *PrePuller) Forget(key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.prePulling, key)
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	"github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/client"
+	"github.com/kubeflow/pipelines/backend/src/crd/controller/scheduledworkflow/util"
+	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestScheduledWorkflow() *util.ScheduledWorkflow {
+	return util.NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "SCHEDULE1", Namespace: "NAMESPACE1"},
+		Spec: swfapi.ScheduledWorkflowSpec{
+			Enabled: true,
+			Workflow: &swfapi.WorkflowResource{Spec: workflowapi.WorkflowSpec{
+				Templates: []workflowapi.Template{{Name: "main", Container: &corev1.Container{Image: "image:1"}}},
+			}},
+		},
+	})
+}
+
+func TestNewPrePuller_Disabled(t *testing.T) {
+	assert.Nil(t, NewPrePuller(client.NewDaemonSetClientFake(), 0, "pause"))
+}
+
+func TestPrePuller_Sync(t *testing.T) {
+	daemonSets := client.NewDaemonSetClientFake()
+	prePuller := NewPrePuller(daemonSets, 10*time.Minute, "pause")
+	swf := newTestScheduledWorkflow()
+
+	// Not due yet. The DaemonSet left by a previous replica is deleted once.
+	assert.Nil(t, prePuller.Sync(swf, 3600, 0))
+	assert.Nil(t, prePuller.Sync(swf, 3600, 30))
+	assert.Equal(t, 1, daemonSets.Calls)
+	assert.Empty(t, daemonSets.DaemonSets)
+
+	// Within the lead time.
+	assert.Nil(t, prePuller.Sync(swf, 3600, 3300))
+	assert.Nil(t, prePuller.Sync(swf, 3600, 3330))
+	assert.Equal(t, 2, daemonSets.Calls)
+	assert.Contains(t, daemonSets.DaemonSets, "NAMESPACE1/SCHEDULE1-prepull")
+
+	// Triggered.
+	assert.Nil(t, prePuller.Sync(swf, 3600, 3600))
+	assert.Equal(t, 3, daemonSets.Calls)
+	assert.Empty(t, daemonSets.DaemonSets)
+}
+
+func TestPrePuller_SetLeadTime(t *testing.T) {
+	daemonSets := client.NewDaemonSetClientFake()
+	prePuller := NewPrePuller(daemonSets, 10*time.Minute, "pause")
+	swf := newTestScheduledWorkflow()
+
+	assert.NotNil(t, prePuller.SetLeadTime(0))
+	assert.Nil(t, prePuller.SetLeadTime(time.Hour))
+	assert.Nil(t, prePuller.Sync(swf, 3600, 30))
+	assert.Contains(t, daemonSets.DaemonSets, "NAMESPACE1/SCHEDULE1-prepull")
+}
+
+func TestPrePuller_Sync_Error(t *testing.T) {
+	daemonSets := client.NewDaemonSetClientFake()
+	daemonSets.SetError(errors.New("Error"))
+	prePuller := NewPrePuller(daemonSets, 10*time.Minute, "pause")
+	swf := newTestScheduledWorkflow()
+
+	assert.NotNil(t, prePuller.Sync(swf, 3600, 3300))
+
+	// Retried on the next sync.
+	daemonSets.SetError(nil)
+	assert.Nil(t, prePuller.Sync(swf, 3600, 3330))
+	assert.Contains(t, daemonSets.DaemonSets, "NAMESPACE1/SCHEDULE1-prepull")
+}
+
+func TestPrePuller_Forget(t *testing.T) {
+	daemonSets := client.NewDaemonSetClientFake()
+	prePuller := NewPrePuller(daemonSets, 10*time.Minute, "pause")
+	swf := newTestScheduledWorkflow()
+
+	assert.Nil(t, prePuller.Sync(swf, 3600, 0))
+	prePuller.Forget("NAMESPACE1/SCHEDULE1")
+	assert.Nil(t, prePuller.Sync(swf, 3600, 0))
+	assert.Equal(t, 2, daemonSets.Calls)
+}
@@ -0,0 +1,145 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	swfregister "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow"
+	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// The label of the pre-pull DaemonSets, to the name of their ScheduledWorkflow.
+	LabelKeyPrePullScheduledWorkflowName = "pipelines.kubeflow.org/prePullFor"
+
+	prePullNameSuffix = "-prepull"
+	maxNameLength     = 63
+)
+
+// PrePullDaemonSetName returns the name of the DaemonSet pre-pulling the images of a
+// ScheduledWorkflow.
+func (s *ScheduledWorkflow) PrePullDaemonSetName() string {
+	name := s.Name
+	if len(name)+len(prePullNameSuffix) > maxNameLength {
+		name = name[:maxNameLength-len(prePullNameSuffix)]
+	}
+	return name + prePullNameSuffix
+}
+
+// ShouldPrePull returns whether the images of a ScheduledWorkflow are pulled ahead of its
+// next trigger, i.e. whether the trigger is due within the lead time.
+func (s *ScheduledWorkflow) ShouldPrePull(nextScheduledEpoch int64, nowEpoch int64, leadTimeInSec int64) bool {
+	if !s.enabled() || s.Spec.Workflow == nil {
+		return false
+	}
+	return nowEpoch < nextScheduledEpoch && nowEpoch >= nextScheduledEpoch-leadTimeInSec
+}
+
+// Images returns the images of the steps of the workflows of a ScheduledWorkflow, sorted.
+// The images set by a parameter of the workflow are resolved at run time, so they're left
+// out.
+func (s *ScheduledWorkflow) Images() []string {
+	if s.Spec.Workflow == nil {
+		return nil
+	}
+	images := make(map[string]bool)
+	addImage := func(image string) {
+		if image != "" && !strings.Contains(image, "{{") {
+			images[image] = true
+		}
+	}
+	for _, template := range s.Spec.Workflow.Spec.Templates {
+		if template.Container != nil {
+			addImage(template.Container.Image)
+		}
+		if template.Script != nil {
+			addImage(template.Script.Image)
+		}
+		for _, container := range template.Sidecars {
+			addImage(container.Image)
+		}
+	}
+	result := make([]string, 0, len(images))
+	for image := range images {
+		result = append(result, image)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// NewPrePullDaemonSet creates the DaemonSet pre-pulling the images of a ScheduledWorkflow
+// on the nodes its workflows can be scheduled on. Each image is pulled by an init
+// container exiting right away, which requires a shell in the image, and the pod then
+// idles in the pause image until the DaemonSet is deleted.
+func (s *ScheduledWorkflow) NewPrePullDaemonSet(pauseImage string) *appsv1.DaemonSet {
+	var spec workflowapi.WorkflowSpec
+	if s.Spec.Workflow != nil {
+		spec = s.Spec.Workflow.Spec
+	}
+	labels := map[string]string{LabelKeyPrePullScheduledWorkflowName: s.Name}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("16Mi"),
+		},
+	}
+	var initContainers []corev1.Container
+	for i, image := range s.Images() {
+		initContainers = append(initContainers, corev1.Container{
+			Name:            fmt.Sprintf("prepull-%d", i),
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{"sh", "-c", "exit 0"},
+			Resources:       resources,
+		})
+	}
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.PrePullDaemonSetName(),
+			Namespace: s.Namespace,
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(s.ScheduledWorkflow, schema.GroupVersionKind{
+					Group:   swfapi.SchemeGroupVersion.Group,
+					Version: swfapi.SchemeGroupVersion.Version,
+					Kind:    swfregister.Kind,
+				}),
+			},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					InitContainers:   initContainers,
+					Containers:       []corev1.Container{{Name: "pause", Image: pauseImage, Resources: resources}},
+					NodeSelector:     spec.NodeSelector,
+					Affinity:         spec.Affinity,
+					Tolerations:      spec.Tolerations,
+					ImagePullSecrets: spec.ImagePullSecrets,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	workflowapi "github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	swfapi "github.com/kubeflow/pipelines/backend/src/crd/pkg/apis/scheduledworkflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPrePullScheduledWorkflow() *ScheduledWorkflow {
+	return NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "SCHEDULE1", Namespace: "NAMESPACE1", UID: "UID1"},
+		Spec: swfapi.ScheduledWorkflowSpec{
+			Enabled: true,
+			Workflow: &swfapi.WorkflowResource{Spec: workflowapi.WorkflowSpec{
+				NodeSelector: map[string]string{"pool": "gpu"},
+				Templates: []workflowapi.Template{
+					{Name: "dag", DAG: &workflowapi.DAGTemplate{}},
+					{Name: "train", Container: &corev1.Container{Image: "trainer:1"},
+						Sidecars: []workflowapi.Sidecar{{Container: corev1.Container{Image: "proxy:1"}}}},
+					{Name: "eval", Script: &workflowapi.ScriptTemplate{Container: corev1.Container{Image: "trainer:1"}}},
+					{Name: "param", Container: &corev1.Container{Image: "{{inputs.parameters.image}}"}},
+				},
+			}},
+		},
+	})
+}
+
+func TestScheduledWorkflow_Images(t *testing.T) {
+	assert.Equal(t, []string{"proxy:1", "trainer:1"}, newPrePullScheduledWorkflow().Images())
+	assert.Empty(t, NewScheduledWorkflow(&swfapi.ScheduledWorkflow{}).Images())
+}
+
+func TestScheduledWorkflow_ShouldPrePull(t *testing.T) {
+	swf := newPrePullScheduledWorkflow()
+	assert.False(t, swf.ShouldPrePull(1000, 100, 600))
+	assert.True(t, swf.ShouldPrePull(1000, 400, 600))
+	assert.False(t, swf.ShouldPrePull(1000, 1000, 600))
+
+	swf.Spec.Enabled = false
+	assert.False(t, swf.ShouldPrePull(1000, 400, 600))
+}
+
+func TestScheduledWorkflow_NewPrePullDaemonSet(t *testing.T) {
+	daemonSet := newPrePullScheduledWorkflow().NewPrePullDaemonSet("pause:1")
+
+	assert.Equal(t, "SCHEDULE1-prepull", daemonSet.Name)
+	assert.Equal(t, "NAMESPACE1", daemonSet.Namespace)
+	assert.Equal(t, "SCHEDULE1", daemonSet.Spec.Selector.MatchLabels[LabelKeyPrePullScheduledWorkflowName])
+	assert.Equal(t, "UID1", string(daemonSet.OwnerReferences[0].UID))
+	pod := daemonSet.Spec.Template.Spec
+	assert.Equal(t, map[string]string{"pool": "gpu"}, pod.NodeSelector)
+	assert.Equal(t, []string{"proxy:1", "trainer:1"}, []string{pod.InitContainers[0].Image, pod.InitContainers[1].Image})
+	assert.Equal(t, "pause:1", pod.Containers[0].Image)
+}
+
+func TestScheduledWorkflow_PrePullDaemonSetName_Truncated(t *testing.T) {
+	swf := NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 63)},
+	})
+	name := swf.PrePullDaemonSetName()
+	assert.Len(t, name, 63)
+	assert.True(t, strings.HasSuffix(name, "-prepull"))
+}
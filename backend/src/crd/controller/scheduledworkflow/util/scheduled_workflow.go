@@ -188,6 +188,16 @@ func (s *ScheduledWorkflow) NewWorkflow(
 	result.OverrideParameters(formattedParams)
 
 	result.SetCannonicalLabels(s.Name, nextScheduledEpoch, s.nextIndex())
+	for _, key := range []string{commonutil.LabelKeyWorkflowPipelineId, commonutil.LabelKeyWorkflowExperimentId,
+		commonutil.LabelKeyWorkflowPodGCStrategy} {
+		if value, ok := s.Labels[key]; ok {
+			result.SetLabels(key, value)
+		}
+	}
+	// The declarations of the parameters tell the API server which values to mask.
+	if value, ok := s.Annotations[commonutil.AnnotationKeyWorkflowParameters]; ok {
+		result.SetAnnotations(commonutil.AnnotationKeyWorkflowParameters, value)
+	}
 
 	// The the owner references.
 	result.SetOwnerReferences(s.ScheduledWorkflow)
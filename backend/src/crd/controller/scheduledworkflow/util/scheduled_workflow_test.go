@@ -675,3 +675,29 @@ func TestScheduledWorkflow_NewWorkflow_Parameterized(t *testing.T) {
 
 	assert.Equal(t, expected, result.Get())
 }
+
+func TestScheduledWorkflow_NewWorkflow_CopiesParameterDeclarations(t *testing.T) {
+	schedule := NewScheduledWorkflow(&swfapi.ScheduledWorkflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "SCHEDULE1",
+			Annotations: map[string]string{commonutil.AnnotationKeyWorkflowParameters: `[{"name": "PARAM1", "type": "secret"}]`},
+		},
+		Spec: swfapi.ScheduledWorkflowSpec{
+			Workflow: &swfapi.WorkflowResource{
+				Parameters: []swfapi.Parameter{{Name: "PARAM1", Value: "hunter2"}},
+				Spec: workflowapi.WorkflowSpec{
+					Arguments: workflowapi.Arguments{
+						Parameters: []workflowapi.Parameter{{Name: "PARAM1"}},
+					},
+				},
+			},
+		},
+	})
+
+	result := schedule.NewWorkflow(int64(10*hour), int64(11*hour))
+
+	assert.Equal(t, `[{"name": "PARAM1", "type": "secret"}]`,
+		result.Annotations[commonutil.AnnotationKeyWorkflowParameters])
+	assert.Equal(t, map[string]bool{"PARAM1": true}, result.SecretParameterNames())
+	assert.Equal(t, "hunter2", *result.Spec.Arguments.Parameters[0].Value)
+}
@@ -0,0 +1,67 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"html/template"
+	"sort"
+)
+
+var confusionMatrixTemplate = template.Must(template.New("confusion_matrix").Parse(
+	`<table class="kfp-confusion-matrix"><thead><tr><th>target \ predicted</th>` +
+		`{{range .Labels}}<th>{{.}}</th>{{end}}</tr></thead><tbody>` +
+		`{{range $i, $row := .Counts}}<tr><th>{{index $.Labels $i}}</th>` +
+		`{{range $row}}<td>{{.}}</td>{{end}}</tr>{{end}}</tbody></table>`))
+
+// generateConfusionMatrix renders the number of rows of each pair of true and predicted labels.
+func generateConfusionMatrix(source []byte, args *Arguments) (string, error) {
+	columns, err := readColumns(source, args.TargetColumn, args.PredictedColumn)
+	if err != nil {
+		return "", err
+	}
+	targets, predictions := columns[0], columns[1]
+	labels, indexes := confusionMatrixLabels(targets, predictions)
+	counts := make([][]int, len(labels))
+	for i := range counts {
+		counts[i] = make([]int, len(labels))
+	}
+	for i := range targets {
+		counts[indexes[targets[i]]][indexes[predictions[i]]]++
+	}
+	return render(confusionMatrixTemplate, struct {
+		Labels []string
+		Counts [][]int
+	}{labels, counts})
+}
+
+// confusionMatrixLabels returns the sorted labels found in the true and the predicted labels,
+// and the index of each.
+func confusionMatrixLabels(targets []string, predictions []string) ([]string, map[string]int) {
+	indexes := map[string]int{}
+	var labels []string
+	for _, values := range [][]string{targets, predictions} {
+		for _, value := range values {
+			if _, ok := indexes[value]; !ok {
+				indexes[value] = 0
+				labels = append(labels, value)
+			}
+		}
+	}
+	sort.Strings(labels)
+	for i, label := range labels {
+		indexes[label] = i
+	}
+	return labels, indexes
+}
@@ -0,0 +1,142 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generator renders the visualizations of the artifacts of the runs as self-contained
+// HTML, without scripts, so that they can be embedded in the UI.
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"html/template"
+	"io"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+const (
+	defaultTargetColumn    = "target"
+	defaultScoreColumn     = "score"
+	defaultPredictedColumn = "predicted"
+	defaultPositiveLabel   = "1"
+	defaultMaxRows         = 100
+	// The maximum number of rows of a table preview, whatever the arguments.
+	maxRowsLimit = 1000
+)
+
+// Arguments are the arguments of the visualizations, as a JSON object in the requests.
+type Arguments struct {
+	// The column of the true labels, for the ROC curves and the confusion matrices.
+	TargetColumn string `json:"target_column"`
+	// The column of the scores of the positive label, for the ROC curves.
+	ScoreColumn string `json:"score_column"`
+	// The column of the predicted labels, for the confusion matrices.
+	PredictedColumn string `json:"predicted_column"`
+	// The label of the positive class, for the ROC curves.
+	PositiveLabel string `json:"positive_label"`
+	// The number of rows of the table previews.
+	MaxRows int `json:"max_rows"`
+}
+
+// ParseArguments decodes the arguments of a visualization, filling in the defaults of the ones
+// not set.
+func ParseArguments(arguments string) (*Arguments, error) {
+	args := &Arguments{}
+	if arguments != "" {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(arguments)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(args); err != nil {
+			return nil, util.NewInvalidInputError("The arguments %q aren't a valid JSON object: %v", arguments, err)
+		}
+	}
+	if args.TargetColumn == "" {
+		args.TargetColumn = defaultTargetColumn
+	}
+	if args.ScoreColumn == "" {
+		args.ScoreColumn = defaultScoreColumn
+	}
+	if args.PredictedColumn == "" {
+		args.PredictedColumn = defaultPredictedColumn
+	}
+	if args.PositiveLabel == "" {
+		args.PositiveLabel = defaultPositiveLabel
+	}
+	if args.MaxRows < 0 || args.MaxRows > maxRowsLimit {
+		return nil, util.NewInvalidInputError("The number of rows must be between 0 and %v, got %v.",
+			maxRowsLimit, args.MaxRows)
+	}
+	if args.MaxRows == 0 {
+		args.MaxRows = defaultMaxRows
+	}
+	return args, nil
+}
+
+// Generate renders the visualization of a type from the content of a CSV file with a header.
+func Generate(visualizationType api.Visualization_Type, source []byte, args *Arguments) (string, error) {
+	switch visualizationType {
+	case api.Visualization_ROC_CURVE:
+		return generateROCCurve(source, args)
+	case api.Visualization_CONFUSION_MATRIX:
+		return generateConfusionMatrix(source, args)
+	case api.Visualization_TABLE:
+		return generateTable(source, args)
+	default:
+		return "", util.NewInvalidInputError("Unsupported visualization type %v.", visualizationType)
+	}
+}
+
+// readColumns reads the values of the columns of a CSV file, in the order of the names.
+func readColumns(source []byte, names ...string) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(source))
+	header, err := reader.Read()
+	if err != nil {
+		return nil, util.NewInvalidInputError("Failed to read the header of the CSV source: %v", err)
+	}
+	indexes := make([]int, len(names))
+	for i, name := range names {
+		indexes[i] = -1
+		for j, column := range header {
+			if column == name {
+				indexes[i] = j
+				break
+			}
+		}
+		if indexes[i] < 0 {
+			return nil, util.NewInvalidInputError("The CSV source has no column %q.", name)
+		}
+	}
+	columns := make([][]string, len(names))
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return columns, nil
+		}
+		if err != nil {
+			return nil, util.NewInvalidInputError("Failed to read the CSV source: %v", err)
+		}
+		for i, index := range indexes {
+			columns[i] = append(columns[i], record[index])
+		}
+	}
+}
+
+func render(t *template.Template, data interface{}) (string, error) {
+	var buffer bytes.Buffer
+	if err := t.Execute(&buffer, data); err != nil {
+		return "", util.NewInternalServerError(err, "Failed to render the visualization")
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestParseArguments_Defaults(t *testing.T) {
+	args, err := ParseArguments("")
+	assert.Nil(t, err)
+	assert.Equal(t, &Arguments{
+		TargetColumn:    "target",
+		ScoreColumn:     "score",
+		PredictedColumn: "predicted",
+		PositiveLabel:   "1",
+		MaxRows:         100,
+	}, args)
+}
+
+func TestParseArguments(t *testing.T) {
+	args, err := ParseArguments(`{"target_column": "label", "score_column": "probability", "max_rows": 5}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "label", args.TargetColumn)
+	assert.Equal(t, "probability", args.ScoreColumn)
+	assert.Equal(t, 5, args.MaxRows)
+}
+
+func TestParseArguments_Invalid(t *testing.T) {
+	for _, arguments := range []string{`[1]`, `{"unknown": 1}`, `{"max_rows": 5000}`, `{"max_rows": -1}`} {
+		_, err := ParseArguments(arguments)
+		assert.NotNil(t, err, arguments)
+		assert.Equal(t, codes.InvalidArgument, err.(*util.UserError).ExternalStatusCode(), arguments)
+	}
+}
+
+func TestRocCurve(t *testing.T) {
+	curve, err := rocCurve([]bool{true, false, true, false}, []float64{0.9, 0.8, 0.7, 0.1})
+	assert.Nil(t, err)
+	assert.Equal(t, []rocPoint{{0, 0}, {0, 0.5}, {0.5, 0.5}, {0.5, 1}, {1, 1}}, curve)
+	assert.Equal(t, 0.75, rocArea(curve))
+}
+
+func TestRocCurve_TiedScores(t *testing.T) {
+	curve, err := rocCurve([]bool{true, false}, []float64{0.5, 0.5})
+	assert.Nil(t, err)
+	assert.Equal(t, []rocPoint{{0, 0}, {1, 1}}, curve)
+	assert.Equal(t, 0.5, rocArea(curve))
+}
+
+func TestRocCurve_SingleClass(t *testing.T) {
+	_, err := rocCurve([]bool{true, true}, []float64{0.5, 0.7})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "both positive and negative rows")
+}
+
+func TestGenerate_RocCurve(t *testing.T) {
+	args, _ := ParseArguments(`{"target_column": "label", "score_column": "probability"}`)
+	html, err := Generate(api.Visualization_ROC_CURVE,
+		[]byte("label,probability\n1,0.9\n0,0.8\n1,0.7\n0,0.1\n"), args)
+	assert.Nil(t, err)
+	assert.Contains(t, html, "<svg")
+	assert.Contains(t, html, "AUC: 0.7500")
+}
+
+func TestGenerate_RocCurve_InvalidScore(t *testing.T) {
+	args, _ := ParseArguments("")
+	_, err := Generate(api.Visualization_ROC_CURVE, []byte("target,score\n1,high\n"), args)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `The score "high" of row 1 isn't a number.`)
+}
+
+func TestGenerate_MissingColumn(t *testing.T) {
+	args, _ := ParseArguments("")
+	_, err := Generate(api.Visualization_CONFUSION_MATRIX, []byte("target,score\n1,0.5\n"), args)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `The CSV source has no column "predicted".`)
+}
+
+func TestGenerate_ConfusionMatrix(t *testing.T) {
+	args, _ := ParseArguments("")
+	html, err := Generate(api.Visualization_CONFUSION_MATRIX,
+		[]byte("target,predicted\ncat,cat\ncat,dog\ndog,dog\nbird,dog\n"), args)
+	assert.Nil(t, err)
+	assert.Contains(t, html, "<th>bird</th><th>cat</th><th>dog</th>")
+	assert.Contains(t, html, "<tr><th>bird</th><td>0</td><td>0</td><td>1</td></tr>")
+	assert.Contains(t, html, "<tr><th>cat</th><td>0</td><td>1</td><td>1</td></tr>")
+	assert.Contains(t, html, "<tr><th>dog</th><td>0</td><td>0</td><td>1</td></tr>")
+}
+
+func TestGenerate_Table(t *testing.T) {
+	args, _ := ParseArguments(`{"max_rows": 2}`)
+	html, err := Generate(api.Visualization_TABLE, []byte("a,b\n1,<b>2</b>\n3,4\n5,6\n"), args)
+	assert.Nil(t, err)
+	assert.Contains(t, html, "<th>a</th><th>b</th>")
+	assert.Contains(t, html, "<td>&lt;b&gt;2&lt;/b&gt;</td>")
+	assert.Contains(t, html, "<td>3</td>")
+	assert.False(t, strings.Contains(html, "<td>5</td>"))
+	assert.Contains(t, html, "Showing the first 2 rows.")
+}
+
+func TestGenerate_UnsupportedType(t *testing.T) {
+	args, _ := ParseArguments("")
+	_, err := Generate(api.Visualization_UNSPECIFIED, []byte("a\n1\n"), args)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Unsupported visualization type")
+}
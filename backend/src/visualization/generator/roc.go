@@ -0,0 +1,124 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+// The size in pixels of the plot of the ROC curves.
+const rocPlotSize = 400
+
+var rocCurveTemplate = template.Must(template.New("roc_curve").Parse(
+	`<svg class="kfp-roc-curve" xmlns="http://www.w3.org/2000/svg" width="{{.Size}}" height="{{.Size}}" ` +
+		`viewBox="0 0 {{.Size}} {{.Size}}"><rect width="{{.Size}}" height="{{.Size}}" fill="none" stroke="#999"/>` +
+		`<line x1="0" y1="{{.Size}}" x2="{{.Size}}" y2="0" stroke="#ccc" stroke-dasharray="4"/>` +
+		`<polyline fill="none" stroke="#1a73e8" stroke-width="2" points="{{.Points}}"/></svg>` +
+		`<p>AUC: {{printf "%.4f" .AUC}}</p>`))
+
+// rocPoint is a point of a ROC curve, the rates of false and true positives at a threshold.
+type rocPoint struct {
+	FalsePositiveRate float64
+	TruePositiveRate  float64
+}
+
+// generateROCCurve renders the ROC curve of the scores of the positive label, and its area.
+func generateROCCurve(source []byte, args *Arguments) (string, error) {
+	columns, err := readColumns(source, args.TargetColumn, args.ScoreColumn)
+	if err != nil {
+		return "", err
+	}
+	positives := make([]bool, len(columns[0]))
+	for i, target := range columns[0] {
+		positives[i] = target == args.PositiveLabel
+	}
+	scores := make([]float64, len(columns[1]))
+	for i, score := range columns[1] {
+		if scores[i], err = strconv.ParseFloat(strings.TrimSpace(score), 64); err != nil {
+			return "", util.NewInvalidInputError("The score %q of row %v isn't a number.", score, i+1)
+		}
+	}
+	curve, err := rocCurve(positives, scores)
+	if err != nil {
+		return "", err
+	}
+	points := make([]string, len(curve))
+	for i, point := range curve {
+		points[i] = fmt.Sprintf("%.1f,%.1f", point.FalsePositiveRate*rocPlotSize,
+			(1-point.TruePositiveRate)*rocPlotSize)
+	}
+	return render(rocCurveTemplate, struct {
+		Size   int
+		Points string
+		AUC    float64
+	}{rocPlotSize, strings.Join(points, " "), rocArea(curve)})
+}
+
+// rocCurve returns the points of the ROC curve at each distinct score, from the highest.
+func rocCurve(positives []bool, scores []float64) ([]rocPoint, error) {
+	var positiveCount, negativeCount int
+	for _, positive := range positives {
+		if positive {
+			positiveCount++
+		} else {
+			negativeCount++
+		}
+	}
+	if positiveCount == 0 || negativeCount == 0 {
+		return nil, util.NewInvalidInputError(
+			"A ROC curve needs both positive and negative rows, got %v positive and %v negative.",
+			positiveCount, negativeCount)
+	}
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+
+	curve := []rocPoint{{0, 0}}
+	var truePositives, falsePositives int
+	for i, index := range order {
+		if positives[index] {
+			truePositives++
+		} else {
+			falsePositives++
+		}
+		// The rows with the same score are on the same side of any threshold.
+		if i+1 < len(order) && scores[order[i+1]] == scores[index] {
+			continue
+		}
+		curve = append(curve, rocPoint{
+			FalsePositiveRate: float64(falsePositives) / float64(negativeCount),
+			TruePositiveRate:  float64(truePositives) / float64(positiveCount),
+		})
+	}
+	return curve, nil
+}
+
+// rocArea returns the area under a ROC curve, by the trapezoidal rule.
+func rocArea(curve []rocPoint) float64 {
+	area := 0.0
+	for i := 1; i < len(curve); i++ {
+		area += (curve[i].FalsePositiveRate - curve[i-1].FalsePositiveRate) *
+			(curve[i].TruePositiveRate + curve[i-1].TruePositiveRate) / 2
+	}
+	return area
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"html/template"
+	"io"
+
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+)
+
+var tableTemplate = template.Must(template.New("table").Parse(
+	`<table class="kfp-table"><thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead><tbody>` +
+		`{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>{{end}}</tbody></table>` +
+		`{{if .Truncated}}<p>Showing the first {{len .Rows}} rows.</p>{{end}}`))
+
+// generateTable renders the header and the first rows of a CSV file.
+func generateTable(source []byte, args *Arguments) (string, error) {
+	reader := csv.NewReader(bytes.NewReader(source))
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return "", util.NewInvalidInputError("Failed to read the header of the CSV source: %v", err)
+	}
+	var rows [][]string
+	truncated := false
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", util.NewInvalidInputError("Failed to read the CSV source: %v", err)
+		}
+		if len(rows) == args.MaxRows {
+			truncated = true
+			break
+		}
+		rows = append(rows, record)
+	}
+	return render(tableTemplate, struct {
+		Header    []string
+		Rows      [][]string
+		Truncated bool
+	}{header, rows, truncated})
+}
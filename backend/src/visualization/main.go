@@ -0,0 +1,53 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/client/visualization"
+)
+
+var (
+	listenAddress  = flag.String("listenAddress", ":8888", "The address the visualization server listens on.")
+	maxRequestSize = flag.Int64("maxRequestSize", 16<<20,
+		"The maximum size in bytes of the requests, including the content of the artifact visualized.")
+	timeout     = flag.Duration("timeout", 30*time.Second, "The maximum time to generate a visualization.")
+	concurrency = flag.Int("concurrency", 4, "The maximum number of visualizations generated at the same time.")
+)
+
+func main() {
+	flag.Parse()
+
+	mux := http.NewServeMux()
+	mux.Handle(visualization.GeneratePath, http.TimeoutHandler(
+		newVisualizationServer(*maxRequestSize, *concurrency), *timeout, "Generating the visualization timed out."))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	server := &http.Server{
+		Addr:         *listenAddress,
+		Handler:      mux,
+		ReadTimeout:  *timeout,
+		WriteTimeout: *timeout + 5*time.Second,
+	}
+	glog.Infof("Starting the visualization server on %v", *listenAddress)
+	if err := server.ListenAndServe(); err != nil {
+		glog.Fatalf("Failed to serve the visualizations: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/client/visualization"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/kubeflow/pipelines/backend/src/visualization/generator"
+	"google.golang.org/grpc/codes"
+)
+
+// visualizationServer generates the visualizations requested by the API server. It only renders
+// the content it receives, and needs no access to the object store or to the cluster.
+type visualizationServer struct {
+	// The maximum size of the requests, in bytes.
+	maxRequestSize int64
+	// The requests generated at the same time. The requests beyond are refused.
+	slots chan struct{}
+}
+
+func newVisualizationServer(maxRequestSize int64, concurrency int) *visualizationServer {
+	return &visualizationServer{maxRequestSize: maxRequestSize, slots: make(chan struct{}, concurrency)}
+}
+
+func (s *visualizationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST is supported.", http.StatusMethodNotAllowed)
+		return
+	}
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	default:
+		http.Error(w, "Too many visualizations are being generated.", http.StatusServiceUnavailable)
+		return
+	}
+
+	request := &visualization.GenerateRequest{}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxRequestSize))
+	if err := decoder.Decode(request); err != nil {
+		http.Error(w, "Invalid visualization request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	response, err := generate(request)
+	if err != nil {
+		glog.Errorf("Failed to generate a visualization of type %v: %+v", request.Type, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		glog.Errorf("Failed to send a visualization: %v", err)
+	}
+}
+
+// generate renders a visualization. The errors of the artifact or of the arguments are returned
+// in the response, to show to the user.
+func generate(request *visualization.GenerateRequest) (*visualization.GenerateResponse, error) {
+	args, err := generator.ParseArguments(request.Arguments)
+	if err == nil {
+		var html string
+		if html, err = generator.Generate(request.Type, request.Content, args); err == nil {
+			return &visualization.GenerateResponse{Html: html}, nil
+		}
+	}
+	if userError, ok := err.(*util.UserError); ok && userError.ExternalStatusCode() != codes.Internal {
+		return &visualization.GenerateResponse{Error: userError.ExternalMessage()}, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/visualization"
+	"github.com/stretchr/testify/assert"
+)
+
+func postGenerateRequest(server *visualizationServer, body []byte) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, visualization.GeneratePath, bytes.NewReader(body)))
+	return recorder
+}
+
+func TestServeHTTP(t *testing.T) {
+	body, _ := json.Marshal(&visualization.GenerateRequest{
+		Type: api.Visualization_TABLE, Content: []byte("a,b\n1,2\n")})
+	recorder := postGenerateRequest(newVisualizationServer(1<<20, 1), body)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	response := &visualization.GenerateResponse{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	assert.Contains(t, response.Html, "<td>1</td><td>2</td>")
+	assert.Empty(t, response.Error)
+}
+
+func TestServeHTTP_InvalidArtifact(t *testing.T) {
+	body, _ := json.Marshal(&visualization.GenerateRequest{
+		Type: api.Visualization_ROC_CURVE, Content: []byte("a,b\n1,2\n")})
+	recorder := postGenerateRequest(newVisualizationServer(1<<20, 1), body)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	response := &visualization.GenerateResponse{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), response))
+	assert.Empty(t, response.Html)
+	assert.Equal(t, `The CSV source has no column "target".`, response.Error)
+}
+
+func TestServeHTTP_InvalidRequest(t *testing.T) {
+	recorder := postGenerateRequest(newVisualizationServer(1<<20, 1), []byte("{"))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestServeHTTP_TooLarge(t *testing.T) {
+	body, _ := json.Marshal(&visualization.GenerateRequest{
+		Type: api.Visualization_TABLE, Content: bytes.Repeat([]byte("a\n"), 100)})
+	recorder := postGenerateRequest(newVisualizationServer(64, 1), body)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestServeHTTP_Busy(t *testing.T) {
+	server := newVisualizationServer(1<<20, 1)
+	server.slots <- struct{}{}
+	recorder := postGenerateRequest(server, []byte("{}"))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
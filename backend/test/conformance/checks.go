@@ -0,0 +1,249 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/test/testharness"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// The page size of the pagination checks, smaller than the number of resources they
+	// create so that these span several pages.
+	paginationPageSize = 2
+	// The number of pages after which a list is considered to never end.
+	maxPages = 1000
+)
+
+// DefaultChecks returns the checks of the suite.
+func DefaultChecks() []Check {
+	return []Check{
+		{
+			Name:        "experiment/duplicate-name",
+			Description: "Creating an experiment with the name of another one fails as an invalid argument.",
+			Run:         checkExperimentDuplicateName,
+		},
+		{
+			Name:        "experiment/pagination",
+			Description: "The experiments are listed page by page, sorted by name in both orders.",
+			Run:         checkExperimentPagination,
+		},
+		{
+			Name:        "experiment/invalid-sort",
+			Description: "Listing the experiments sorted by a field that isn't sortable fails as an invalid argument.",
+			Run:         checkExperimentInvalidSort,
+		},
+		{
+			Name:        "pipeline/duplicate-name",
+			Description: "Importing a pipeline with the name of another one fails as an invalid argument.",
+			Run:         checkPipelineDuplicateName,
+		},
+		{
+			Name:        "pipeline/pagination",
+			Description: "The pipelines are listed page by page, sorted by name in both orders.",
+			Run:         checkPipelinePagination,
+		},
+		{
+			Name:        "pipeline/invalid-sort",
+			Description: "Listing the pipelines sorted by a field that isn't sortable fails as an invalid argument.",
+			Run:         checkPipelineInvalidSort,
+		},
+		{
+			Name:        "run/invalid-sort",
+			Description: "Listing the runs sorted by a field that isn't sortable fails as an invalid argument.",
+			Run:         checkRunInvalidSort,
+		},
+	}
+}
+
+// The sortings that aren't supported, for the resources sortable by name, and the ones
+// that are badly formatted.
+var invalidSorts = []string{"description", "name ascending", "name desc created_at"}
+
+// expectCode returns an error if the call didn't fail with code.
+func expectCode(call string, err error, code codes.Code) error {
+	if err == nil {
+		return fmt.Errorf("%v succeeded, want it to fail with code %v", call, code)
+	}
+	if kfp.Code(err) != code {
+		return fmt.Errorf("%v failed with %v, want code %v", call, err, code)
+	}
+	return nil
+}
+
+func checkExperimentDuplicateName(ctx context.Context, h *testharness.Harness, config Config) error {
+	if _, err := h.CreateExperiment(ctx, &api.Experiment{Name: "duplicate"}); err != nil {
+		return fmt.Errorf("Failed to create the experiment: %v", err)
+	}
+	_, err := h.CreateExperiment(ctx, &api.Experiment{Name: "duplicate"})
+	return expectCode("Creating the experiment again", err, codes.InvalidArgument)
+}
+
+func checkExperimentPagination(ctx context.Context, h *testharness.Harness, config Config) error {
+	var names []string
+	for _, name := range []string{"c", "a", "e", "b", "d"} {
+		experiment, err := h.CreateExperiment(ctx, &api.Experiment{Name: name})
+		if err != nil {
+			return fmt.Errorf("Failed to create the experiment: %v", err)
+		}
+		names = append(names, experiment.Name)
+	}
+	return checkPagination(names, func(sortBy string, pageToken string) ([]string, string, error) {
+		response, err := h.Client.Experiments.ListExperiment(ctx, &api.ListExperimentsRequest{
+			PageSize: paginationPageSize, PageToken: pageToken, SortBy: sortBy})
+		if err != nil {
+			return nil, "", err
+		}
+		var names []string
+		for _, experiment := range response.Experiments {
+			names = append(names, experiment.Name)
+		}
+		return names, response.NextPageToken, nil
+	})
+}
+
+func checkExperimentInvalidSort(ctx context.Context, h *testharness.Harness, config Config) error {
+	for _, sortBy := range invalidSorts {
+		_, err := h.Client.Experiments.ListExperiment(ctx, &api.ListExperimentsRequest{SortBy: sortBy})
+		if err := expectCode(fmt.Sprintf("Listing the experiments sorted by %q", sortBy), err,
+			codes.InvalidArgument); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkPipelineDuplicateName(ctx context.Context, h *testharness.Harness, config Config) error {
+	if _, err := h.CreatePipelineFromURL(ctx, "duplicate", config.PipelineURL); err != nil {
+		return fmt.Errorf("Failed to import the pipeline: %v", err)
+	}
+	_, err := h.CreatePipelineFromURL(ctx, "duplicate", config.PipelineURL)
+	return expectCode("Importing the pipeline again", err, codes.InvalidArgument)
+}
+
+func checkPipelinePagination(ctx context.Context, h *testharness.Harness, config Config) error {
+	var names []string
+	for _, name := range []string{"b", "c", "a"} {
+		pipeline, err := h.CreatePipelineFromURL(ctx, name, config.PipelineURL)
+		if err != nil {
+			return fmt.Errorf("Failed to import the pipeline: %v", err)
+		}
+		names = append(names, pipeline.Name)
+	}
+	return checkPagination(names, func(sortBy string, pageToken string) ([]string, string, error) {
+		response, err := h.Client.Pipelines.ListPipelines(ctx, &api.ListPipelinesRequest{
+			PageSize: paginationPageSize, PageToken: pageToken, SortBy: sortBy})
+		if err != nil {
+			return nil, "", err
+		}
+		var names []string
+		for _, pipeline := range response.Pipelines {
+			names = append(names, pipeline.Name)
+		}
+		return names, response.NextPageToken, nil
+	})
+}
+
+func checkPipelineInvalidSort(ctx context.Context, h *testharness.Harness, config Config) error {
+	for _, sortBy := range invalidSorts {
+		_, err := h.Client.Pipelines.ListPipelines(ctx, &api.ListPipelinesRequest{SortBy: sortBy})
+		if err := expectCode(fmt.Sprintf("Listing the pipelines sorted by %q", sortBy), err,
+			codes.InvalidArgument); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkRunInvalidSort(ctx context.Context, h *testharness.Harness, config Config) error {
+	// Unlike the other resources, the runs can't be sorted by ID.
+	for _, sortBy := range append([]string{"id"}, invalidSorts...) {
+		_, err := h.Client.Runs.ListRuns(ctx, &api.ListRunsRequest{SortBy: sortBy})
+		if err := expectCode(fmt.Sprintf("Listing the runs sorted by %q", sortBy), err,
+			codes.InvalidArgument); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPagination lists all the resources sorted by name, in ascending then descending
+// order, and checks that the full pages have paginationPageSize resources, that no
+// resource is listed twice and that the created resources, named names, are all listed
+// in order. The resources of the other users of the API server may be listed too, but
+// their order isn't checked since it depends on the collation of the database.
+func checkPagination(names []string,
+	listPage func(sortBy string, pageToken string) ([]string, string, error)) error {
+	created := make(map[string]bool)
+	for _, name := range names {
+		created[name] = true
+	}
+	for _, sortBy := range []string{"name", "name desc"} {
+		var listed []string
+		pageToken := ""
+		for page := 1; ; page++ {
+			if page > maxPages {
+				return fmt.Errorf("Listing sorted by %q didn't end after %v pages", sortBy, maxPages)
+			}
+			pageNames, nextPageToken, err := listPage(sortBy, pageToken)
+			if err != nil {
+				return fmt.Errorf("Failed to list page %v sorted by %q: %v", page, sortBy, err)
+			}
+			if nextPageToken != "" && len(pageNames) != paginationPageSize {
+				return fmt.Errorf("Page %v sorted by %q has %v resources, want %v", page, sortBy,
+					len(pageNames), paginationPageSize)
+			}
+			listed = append(listed, pageNames...)
+			if pageToken = nextPageToken; pageToken == "" {
+				break
+			}
+		}
+
+		listedCount := make(map[string]int)
+		var listedCreated []string
+		for _, name := range listed {
+			listedCount[name]++
+			if listedCount[name] > 1 {
+				return fmt.Errorf("Listing sorted by %q returned %v more than once", sortBy, name)
+			}
+			if created[name] {
+				listedCreated = append(listedCreated, name)
+			}
+		}
+		for _, name := range names {
+			if listedCount[name] == 0 {
+				return fmt.Errorf("Listing sorted by %q didn't return %v", sortBy, name)
+			}
+		}
+		descending := strings.HasSuffix(sortBy, " desc")
+		sorted := sort.SliceIsSorted(listedCreated, func(i, j int) bool {
+			if descending {
+				return listedCreated[i] > listedCreated[j]
+			}
+			return listedCreated[i] < listedCreated[j]
+		})
+		if !sorted {
+			return fmt.Errorf("Listing sorted by %q returned %v in this order", sortBy, listedCreated)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command conformance runs the conformance suite against the API server at an endpoint,
+// and exits with a non-zero status if a check fails:
+//
+//	conformance --endpoint=ml-pipeline.example.com:443 --tls --token=$TOKEN
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/test/conformance"
+	"github.com/kubeflow/pipelines/backend/test/testharness"
+	"golang.org/x/net/context"
+)
+
+var (
+	endpoint    = flag.String("endpoint", "localhost:8887", "The host:port of the gRPC API of the API server.")
+	useTLS      = flag.Bool("tls", false, "Whether to connect to the endpoint with TLS.")
+	token       = flag.String("token", os.Getenv("KFP_TOKEN"), "The bearer token authenticating the calls. Defaults to $KFP_TOKEN.")
+	timeout     = flag.Duration("timeout", 30*time.Second, "The timeout of every call to the API server.")
+	checks      = flag.String("checks", ".*", "A regular expression matching the names of the checks to run.")
+	pipelineURL = flag.String("pipeline-url", conformance.DefaultPipelineURL, "The URL of a pipeline the API server can import.")
+	namePrefix  = flag.String("name-prefix", "", "The prefix of the names of the created resources. Defaults to a random one.")
+	list        = flag.Bool("list", false, "List the checks instead of running them.")
+	version     = flag.Bool("version", false, "Print the version of the suite.")
+)
+
+func main() {
+	flag.Parse()
+	if *version {
+		fmt.Println(conformance.Version)
+		return
+	}
+	pattern, err := regexp.Compile(*checks)
+	if err != nil {
+		exitf("Invalid checks %q: %v", *checks, err)
+	}
+	selected := conformance.Select(conformance.DefaultChecks(), pattern)
+	if *list {
+		for _, check := range selected {
+			fmt.Printf("%v\t%v\n", check.Name, check.Description)
+		}
+		return
+	}
+	if len(selected) == 0 {
+		exitf("No check matches %q", *checks)
+	}
+
+	options := []kfp.Option{kfp.WithTimeout(*timeout)}
+	if !*useTLS {
+		options = append(options, kfp.WithInsecure())
+	}
+	if *token != "" {
+		options = append(options, kfp.WithBearerToken(*token))
+	}
+	client, err := kfp.NewClient(*endpoint, options...)
+	if err != nil {
+		exitf("Failed to connect to %v: %v", *endpoint, err)
+	}
+	h, err := testharness.NewHarnessForClients(client, nil, nil, "", *namePrefix)
+	if err != nil {
+		exitf("Failed to create the harness: %v", err)
+	}
+	defer h.Close()
+
+	fmt.Printf("Conformance suite %v against %v\n", conformance.Version, *endpoint)
+	failed := 0
+	results := conformance.Run(context.Background(), h, conformance.Config{PipelineURL: *pipelineURL}, selected)
+	for _, result := range results {
+		if result.Passed() {
+			fmt.Printf("PASS\t%v\t(%v)\n", result.Name, result.Duration.Round(time.Millisecond))
+		} else {
+			failed++
+			fmt.Printf("FAIL\t%v\t(%v)\n\t%v\n", result.Name, result.Duration.Round(time.Millisecond), result.Err)
+		}
+	}
+	fmt.Printf("%v/%v checks passed\n", len(results)-failed, len(results))
+	if failed > 0 {
+		h.Close()
+		os.Exit(1)
+	}
+}
+
+func exitf(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(2)
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package conformance checks that a deployment of the ML pipeline API server, or of a
+// fork of it, behaves as the API server of this repository does: how duplicated names
+// are rejected, how the lists are ordered and paged, and how their sorting is
+// validated. The checks only use the gRPC API of the API server, and create resources
+// named with the prefix of a testharness.Harness, which are deleted after each check
+// when the API can delete them.
+package conformance
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/kubeflow/pipelines/backend/test/testharness"
+	"golang.org/x/net/context"
+)
+
+// Version is the version of the suite. It changes whenever a check is added or its
+// assertions change, so that two deployments passing the same version behave the same.
+const Version = "v1beta1-1"
+
+// DefaultPipelineURL is the pipeline imported by the checks creating pipelines.
+const DefaultPipelineURL = "https://storage.googleapis.com/ml-pipeline-dataset/sequential.yaml"
+
+// Config configures the checks.
+type Config struct {
+	// The URL of a pipeline the API server can import.
+	PipelineURL string
+}
+
+// Check is a behavior of the API server.
+type Check struct {
+	// Name is "<resource>/<behavior>", e.g. "pipeline/duplicate-name".
+	Name        string
+	Description string
+	// Run returns an error describing how the API server doesn't behave as expected.
+	Run func(ctx context.Context, h *testharness.Harness, config Config) error
+}
+
+// Result is the outcome of a check.
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Passed returns true if the API server behaves as checked.
+func (r Result) Passed() bool {
+	return r.Err == nil
+}
+
+// Select returns the checks whose name matches pattern.
+func Select(checks []Check, pattern *regexp.Regexp) []Check {
+	var selected []Check
+	for _, check := range checks {
+		if pattern.MatchString(check.Name) {
+			selected = append(selected, check)
+		}
+	}
+	return selected
+}
+
+// Run runs the checks one after the other, cleaning up the resources of each check
+// before the next one. A check whose cleanup fails fails too, since the API server
+// should delete what it created.
+func Run(ctx context.Context, h *testharness.Harness, config Config, checks []Check) []Result {
+	if config.PipelineURL == "" {
+		config.PipelineURL = DefaultPipelineURL
+	}
+	var results []Result
+	for _, check := range checks {
+		start := time.Now()
+		err := runCheck(ctx, h, config, check)
+		if cleanupErr := h.Cleanup(); cleanupErr != nil && err == nil {
+			err = fmt.Errorf("Failed to clean up: %v", cleanupErr)
+		}
+		results = append(results, Result{Name: check.Name, Err: err, Duration: time.Since(start)})
+	}
+	return results
+}
+
+// runCheck runs a check, failing it if it panics.
+func runCheck(ctx context.Context, h *testharness.Harness, config Config, check Check) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Panic: %v", r)
+		}
+	}()
+	return check.Run(ctx, h, config)
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conformance
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/kubeflow/pipelines/backend/test/testharness"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func newFakeHarness(t *testing.T) *testharness.Harness {
+	h, err := testharness.NewHarnessForClients(kfpfake.NewClient(), nil, nil, "", "test-1")
+	assert.Nil(t, err)
+	return h
+}
+
+func TestRun(t *testing.T) {
+	h := newFakeHarness(t)
+	cleanedUp := false
+	checks := []Check{
+		{Name: "a/pass", Run: func(ctx context.Context, h *testharness.Harness, config Config) error {
+			assert.Equal(t, DefaultPipelineURL, config.PipelineURL)
+			h.Defer("cleanup", func() error {
+				cleanedUp = true
+				return nil
+			})
+			return nil
+		}},
+		{Name: "a/fail", Run: func(ctx context.Context, h *testharness.Harness, config Config) error {
+			return errors.New("bad")
+		}},
+		{Name: "b/panic", Run: func(ctx context.Context, h *testharness.Harness, config Config) error {
+			panic("boom")
+		}},
+		{Name: "b/cleanup", Run: func(ctx context.Context, h *testharness.Harness, config Config) error {
+			h.Defer("cleanup", func() error { return errors.New("can't delete") })
+			return nil
+		}},
+	}
+
+	results := Run(context.Background(), h, Config{}, checks)
+	assert.Len(t, results, 4)
+	assert.True(t, results[0].Passed())
+	assert.True(t, cleanedUp)
+	assert.EqualError(t, results[1].Err, "bad")
+	assert.EqualError(t, results[2].Err, "Panic: boom")
+	assert.Contains(t, results[3].Err.Error(), "can't delete")
+}
+
+func TestSelect(t *testing.T) {
+	selected := Select(DefaultChecks(), regexp.MustCompile("^pipeline/"))
+	var names []string
+	for _, check := range selected {
+		names = append(names, check.Name)
+	}
+	assert.Equal(t, []string{"pipeline/duplicate-name", "pipeline/pagination", "pipeline/invalid-sort"}, names)
+}
+
+func TestExperimentDuplicateName_FailsAgainstFake(t *testing.T) {
+	// The fakes don't enforce the uniqueness of the names.
+	results := Run(context.Background(), newFakeHarness(t), Config{},
+		Select(DefaultChecks(), regexp.MustCompile("^experiment/duplicate-name$")))
+	assert.Len(t, results, 1)
+	assert.EqualError(t, results[0].Err,
+		"Creating the experiment again succeeded, want it to fail with code InvalidArgument")
+}
+
+// listPages returns a listPage function serving pages for the ascending and descending
+// sortings.
+func listPages(ascending [][]string, descending [][]string) func(string, string) ([]string, string, error) {
+	return func(sortBy string, pageToken string) ([]string, string, error) {
+		pages := ascending
+		if sortBy == "name desc" {
+			pages = descending
+		}
+		page := 0
+		if pageToken != "" {
+			page = int(pageToken[0] - '0')
+		}
+		nextPageToken := ""
+		if page+1 < len(pages) {
+			nextPageToken = string('0' + byte(page+1))
+		}
+		return pages[page], nextPageToken, nil
+	}
+}
+
+func TestCheckPagination(t *testing.T) {
+	names := []string{"p-b", "p-a", "p-c"}
+	err := checkPagination(names, listPages(
+		[][]string{{"Other", "p-a"}, {"p-b", "p-c"}},
+		[][]string{{"p-c", "p-b"}, {"p-a", "Other"}}))
+	assert.Nil(t, err)
+
+	err = checkPagination(names, listPages(
+		[][]string{{"p-a", "p-c"}, {"p-b"}},
+		[][]string{{"p-c", "p-b"}, {"p-a"}}))
+	assert.EqualError(t, err, `Listing sorted by "name" returned [p-a p-c p-b] in this order`)
+
+	err = checkPagination(names, listPages(
+		[][]string{{"p-a"}, {"p-b", "p-c"}},
+		[][]string{{"p-c", "p-b"}, {"p-a"}}))
+	assert.EqualError(t, err, `Page 1 sorted by "name" has 1 resources, want 2`)
+
+	err = checkPagination(names, listPages(
+		[][]string{{"p-a", "p-b"}, {"p-b", "p-c"}},
+		[][]string{{"p-c", "p-b"}, {"p-a"}}))
+	assert.EqualError(t, err, `Listing sorted by "name" returned p-b more than once`)
+
+	err = checkPagination(names, listPages(
+		[][]string{{"p-a", "p-b"}, {"p-c"}},
+		[][]string{{"p-c", "p-a"}}))
+	assert.EqualError(t, err, `Listing sorted by "name desc" didn't return p-b`)
+}
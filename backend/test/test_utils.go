@@ -16,7 +16,6 @@ package test
 
 import (
 	"bytes"
-	"fmt"
 	"io"
 	"mime/multipart"
 	"os"
@@ -25,8 +24,9 @@ import (
 
 	"flag"
 
-	"github.com/cenkalti/backoff"
 	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/test/testharness"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -62,26 +62,7 @@ func waitForReady(namespace string, initializeTimeout time.Duration) error {
 	if err != nil {
 		return errors.Wrapf(err, "Failed to get K8s client set when waiting for ML pipeline to be ready")
 	}
-
-	var operation = func() error {
-		response := clientSet.RESTClient().Get().
-			AbsPath(fmt.Sprintf(mlPipelineAPIServerBase, namespace, "healthz")).Do()
-		if response.Error() == nil {
-			return nil
-		}
-		var code int
-		response.StatusCode(&code)
-		// we wait only on 503 service unavailable. Stop retry otherwise.
-		if code != 503 {
-			return backoff.Permanent(errors.Wrapf(response.Error(), "Waiting for ml pipeline failed with non retriable error."))
-		}
-		return response.Error()
-	}
-
-	b := backoff.NewExponentialBackOff()
-	b.MaxElapsedTime = initializeTimeout
-	err = backoff.Retry(operation, b)
-	return errors.Wrapf(err, "Waiting for ml pipeline failed after all attempts.")
+	return testharness.WaitForReady(clientSet.RESTClient(), namespace, initializeTimeout)
 }
 
 func uploadPipelineFileOrFail(path string) (*bytes.Buffer, *multipart.Writer) {
@@ -120,7 +101,7 @@ func getRpcConnection(namespace string) (*grpc.ClientConn, error) {
 		return nil, errors.Wrapf(err, "Failed to get ml-pipeline service")
 	}
 	rpcAddress := svc.Spec.ClusterIP + ":8887"
-	conn, err := grpc.Dial(rpcAddress, grpc.WithInsecure())
+	conn, err := grpc.Dial(rpcAddress, kfp.DialOptions(kfp.WithInsecure())...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to create gRPC connection")
 	}
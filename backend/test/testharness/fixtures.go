@@ -0,0 +1,155 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testharness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateExperiment creates an experiment named with the prefix of the harness. The API
+// server can't delete experiments, so they are left behind, recognizable by their name.
+func (h *Harness) CreateExperiment(ctx context.Context, experiment *api.Experiment) (*api.Experiment, error) {
+	experiment.Name = h.Name(experiment.Name)
+	return h.Client.Experiments.CreateExperiment(ctx, &api.CreateExperimentRequest{Experiment: experiment})
+}
+
+// CreatePipelineFromURL imports the pipeline at url, named with the prefix of the harness.
+// It's deleted on Cleanup.
+func (h *Harness) CreatePipelineFromURL(ctx context.Context, name string, url string) (*api.Pipeline, error) {
+	pipeline, err := h.Client.Pipelines.CreatePipeline(ctx, &api.CreatePipelineRequest{
+		Url:  &api.Url{PipelineUrl: url},
+		Name: h.Name(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.DeferDeletePipeline(pipeline.Id)
+	return pipeline, nil
+}
+
+// UploadPipeline uploads the pipeline file at path, e.g. a YAML file or a tarball, named
+// after the file with the prefix of the harness. It's deleted on Cleanup.
+func (h *Harness) UploadPipeline(path string) (*api.Pipeline, error) {
+	if h.proxy == nil {
+		return nil, errors.New("Pipelines can't be uploaded without the proxy of the Kubernetes API server")
+	}
+	body, contentType, err := newPipelineUploadBody(path)
+	if err != nil {
+		return nil, err
+	}
+	response, err := h.proxy.Post().
+		AbsPath(fmt.Sprintf(apiServerProxyPath, h.namespace, "pipelines/upload")).
+		Param("name", h.Name(filepath.Base(path))).
+		SetHeader("Content-Type", contentType).
+		Body(body).Do().Raw()
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to upload the pipeline %v", path)
+	}
+	var pipeline api.Pipeline
+	if err := json.Unmarshal(response, &pipeline); err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse the uploaded pipeline %v", path)
+	}
+	h.DeferDeletePipeline(pipeline.Id)
+	return &pipeline, nil
+}
+
+func newPipelineUploadBody(path string) (*bytes.Buffer, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Failed to open the pipeline file")
+	}
+	defer file.Close()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("uploadfile", filepath.Base(path))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Failed to create the form file")
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, "", errors.Wrap(err, "Failed to copy the pipeline file")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", errors.Wrap(err, "Failed to close the multipart writer")
+	}
+	return body, writer.FormDataContentType(), nil
+}
+
+// CreateRun creates a run named with the prefix of the harness. The API server can't
+// delete runs, so the workflow of the run is deleted on Cleanup, stopping the run and
+// deleting its pods.
+func (h *Harness) CreateRun(ctx context.Context, run *api.Run) (*api.RunDetail, error) {
+	run.Name = h.Name(run.Name)
+	runDetail, err := h.Client.Runs.CreateRun(ctx, &api.CreateRunRequest{Run: run})
+	if err != nil {
+		return nil, err
+	}
+	h.DeferDeleteRunWorkflow(runDetail)
+	return runDetail, nil
+}
+
+// CreateJob creates a job named with the prefix of the harness. It's deleted on Cleanup.
+func (h *Harness) CreateJob(ctx context.Context, job *api.Job) (*api.Job, error) {
+	job.Name = h.Name(job.Name)
+	newJob, err := h.Client.Jobs.CreateJob(ctx, &api.CreateJobRequest{Job: job})
+	if err != nil {
+		return nil, err
+	}
+	h.DeferDeleteJob(newJob.Id)
+	return newJob, nil
+}
+
+// DeferDeletePipeline deletes a pipeline on Cleanup.
+func (h *Harness) DeferDeletePipeline(id string) {
+	h.Defer(fmt.Sprintf("delete pipeline %v", id), func() error {
+		_, err := h.Client.Pipelines.DeletePipeline(context.Background(), &api.DeletePipelineRequest{Id: id})
+		return err
+	})
+}
+
+// DeferDeleteJob deletes a job on Cleanup, which stops it from creating runs.
+func (h *Harness) DeferDeleteJob(id string) {
+	h.Defer(fmt.Sprintf("delete job %v", id), func() error {
+		_, err := h.Client.Jobs.DeleteJob(context.Background(), &api.DeleteJobRequest{Id: id})
+		return err
+	})
+}
+
+// DeferDeleteRunWorkflow deletes the workflow of a run on Cleanup. It does nothing if the
+// harness has no workflow client.
+func (h *Harness) DeferDeleteRunWorkflow(runDetail *api.RunDetail) {
+	h.Defer(fmt.Sprintf("delete the workflow of run %v", runDetail.GetRun().GetId()), func() error {
+		if h.workflows == nil {
+			return nil
+		}
+		var workflow util.Workflow
+		if err := json.Unmarshal([]byte(runDetail.GetPipelineRuntime().GetWorkflowManifest()), &workflow); err != nil {
+			return errors.Wrap(err, "Failed to parse the workflow of the run")
+		}
+		return h.workflows.Delete(workflow.Name, &metav1.DeleteOptions{})
+	})
+}
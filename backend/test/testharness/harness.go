@@ -0,0 +1,245 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testharness sets up the integration tests of the ML pipeline API server, and
+// deletes the resources they create even when they fail:
+//
+//	h, err := testharness.NewHarness(testharness.Config{Namespace: "kubeflow"})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer h.Close()
+//	experiment, err := h.CreateExperiment(ctx, &api.Experiment{Name: "hello world"})
+//	...
+//
+// The names of the resources are prefixed with the one of the harness, so that the
+// resources of concurrent tests, or left by a killed test, are told apart.
+package testharness
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	argoclient "github.com/argoproj/argo/pkg/client/clientset/versioned"
+	workflowclient "github.com/argoproj/argo/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	"github.com/cenkalti/backoff"
+	"github.com/golang/glog"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/src/common/util"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// The REST API of the API server, through the proxy of the Kubernetes API server.
+	apiServerProxyPath = "/api/v1/namespaces/%s/services/ml-pipeline:8888/proxy/apis/v1beta1/%s"
+	apiServerService   = "ml-pipeline"
+	apiServerRpcPort   = 8887
+
+	defaultInitializeTimeout = 2 * time.Minute
+)
+
+// Config is how a Harness connects to the pipeline system.
+type Config struct {
+	// The namespace the pipeline system is deployed to.
+	Namespace string
+	// The kubeconfig to connect with from outside the cluster, through port forwarding.
+	// The service account of the pod is used when empty.
+	Kubeconfig string
+	// How long to wait for the API server to be ready. Two minutes when zero.
+	InitializeTimeout time.Duration
+	// The prefix of the names of the created resources. A random one is generated when
+	// empty.
+	NamePrefix string
+}
+
+// Harness creates the resources of a test and deletes them on Cleanup. It is safe for
+// concurrent use.
+type Harness struct {
+	// Client is connected to the API server.
+	Client *kfp.Client
+
+	namespace string
+	prefix    string
+	// The proxy of the Kubernetes API server, to upload pipelines. Nil if unavailable.
+	proxy rest.Interface
+	// The workflows of the runs, which are deleted to stop them. Nil if unavailable.
+	workflows workflowclient.WorkflowInterface
+
+	mutex    sync.Mutex
+	cleanups []cleanup
+}
+
+type cleanup struct {
+	description string
+	run         func() error
+}
+
+// NewHarness waits for the API server of the namespace to be ready, and connects to it.
+func NewHarness(config Config) (*Harness, error) {
+	var restConfig *rest.Config
+	var err error
+	if config.Kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", config.Kubeconfig)
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get the cluster config")
+	}
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the Kubernetes client")
+	}
+	argoClientSet, err := argoclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the Argo client")
+	}
+	timeout := config.InitializeTimeout
+	if timeout == 0 {
+		timeout = defaultInitializeTimeout
+	}
+	proxy := clientSet.CoreV1().RESTClient()
+	if err := WaitForReady(proxy, config.Namespace, timeout); err != nil {
+		return nil, err
+	}
+
+	var client *kfp.Client
+	if config.Kubeconfig != "" {
+		dialer, err := kfp.NewPortForwardDialer(clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: config.Kubeconfig}, &clientcmd.ConfigOverrides{}))
+		if err != nil {
+			return nil, err
+		}
+		client, err = kfp.NewClient(fmt.Sprintf("%s.%s:%d", apiServerService, config.Namespace, apiServerRpcPort),
+			kfp.WithPortForward(dialer))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		service, err := clientSet.CoreV1().Services(config.Namespace).Get(apiServerService, metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to get the %v service", apiServerService)
+		}
+		client, err = kfp.NewClient(fmt.Sprintf("%s:%d", service.Spec.ClusterIP, apiServerRpcPort), kfp.WithInsecure())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewHarnessForClients(client, proxy, argoClientSet.ArgoprojV1alpha1().Workflows(config.Namespace),
+		config.Namespace, config.NamePrefix)
+}
+
+// NewHarnessForClients creates a Harness using existing clients, e.g. fakes. The pipelines
+// can't be uploaded without proxy, and the runs aren't stopped without workflows.
+func NewHarnessForClients(client *kfp.Client, proxy rest.Interface, workflows workflowclient.WorkflowInterface,
+	namespace string, prefix string) (*Harness, error) {
+	if prefix == "" {
+		uuid, err := util.NewUUIDGenerator().NewRandom()
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to generate the name prefix")
+		}
+		prefix = "test-" + strings.Replace(uuid.String(), "-", "", -1)[:8]
+	}
+	return &Harness{Client: client, namespace: namespace, prefix: prefix, proxy: proxy, workflows: workflows}, nil
+}
+
+// WaitForReady waits for the API server of the namespace to be ready, through the proxy
+// of the Kubernetes API server. Only the unavailability of the API server is retried.
+func WaitForReady(proxy rest.Interface, namespace string, timeout time.Duration) error {
+	operation := func() error {
+		response := proxy.Get().AbsPath(fmt.Sprintf(apiServerProxyPath, namespace, "healthz")).Do()
+		if response.Error() == nil {
+			return nil
+		}
+		var code int
+		response.StatusCode(&code)
+		if code != 503 {
+			return backoff.Permanent(errors.Wrap(response.Error(), "Waiting for ml pipeline failed with non retriable error."))
+		}
+		return response.Error()
+	}
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = timeout
+	return errors.Wrap(backoff.Retry(operation, b), "Waiting for ml pipeline failed after all attempts.")
+}
+
+// Namespace returns the namespace of the pipeline system.
+func (h *Harness) Namespace() string {
+	return h.namespace
+}
+
+// Name returns name prefixed with the prefix of the harness.
+func (h *Harness) Name(name string) string {
+	return h.prefix + "-" + name
+}
+
+// Defer registers a function called by Cleanup, e.g. to delete a resource created without
+// the harness. The functions are called in the reverse order of their registration. A
+// NotFound error means that the resource is already gone.
+func (h *Harness) Defer(description string, f func() error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.cleanups = append(h.cleanups, cleanup{description: description, run: f})
+}
+
+// Cleanup deletes the resources created through the harness, the last created first, and
+// returns the errors of the ones that couldn't be. Call it with defer, so that it's also
+// called when the test fails or panics.
+func (h *Harness) Cleanup() error {
+	h.mutex.Lock()
+	cleanups := h.cleanups
+	h.cleanups = nil
+	h.mutex.Unlock()
+
+	var errs []error
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := runCleanup(cleanups[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// runCleanup runs a cleanup function, which must not prevent the next ones from running by
+// panicking.
+func runCleanup(c cleanup) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Failed to %v: panic: %v", c.description, r)
+		}
+	}()
+	if err := c.run(); err != nil && !kfp.IsNotFound(err) && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "Failed to %v", c.description)
+	}
+	glog.V(4).Infof("Test cleanup: %v", c.description)
+	return nil
+}
+
+// Close cleans up, logging the errors, and closes the connection to the API server.
+func (h *Harness) Close() {
+	if err := h.Cleanup(); err != nil {
+		glog.Errorf("Failed to clean up the test resources: %v", err)
+	}
+	if err := h.Client.Close(); err != nil {
+		glog.Errorf("Failed to close the connection to the API server: %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testharness
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo/pkg/apis/workflow/v1alpha1"
+	api "github.com/kubeflow/pipelines/backend/api/go_client"
+	"github.com/kubeflow/pipelines/backend/src/apiserver/storage"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp"
+	"github.com/kubeflow/pipelines/backend/src/common/client/kfp/kfpfake"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newFakeHarness(t *testing.T) (*Harness, *storage.FakeWorkflowClient) {
+	workflows := storage.NewWorkflowClientFake()
+	h, err := NewHarnessForClients(kfpfake.NewClient(), nil, workflows, "kubeflow", "test-1")
+	assert.Nil(t, err)
+	return h, workflows
+}
+
+func TestNewHarnessForClients_GeneratesPrefix(t *testing.T) {
+	h, err := NewHarnessForClients(kfpfake.NewClient(), nil, nil, "kubeflow", "")
+	assert.Nil(t, err)
+	assert.Regexp(t, "^test-[0-9a-f]{8}-hello$", h.Name("hello"))
+}
+
+func TestCleanup(t *testing.T) {
+	h, _ := newFakeHarness(t)
+	ctx := context.Background()
+	pipeline, err := h.CreatePipelineFromURL(ctx, "sequential", "https://example.com/sequential.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "test-1-sequential", pipeline.Name)
+	job, err := h.CreateJob(ctx, &api.Job{Name: "hourly"})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-1-hourly", job.Name)
+	// Already deleted by the test.
+	_, err = h.Client.Jobs.DeleteJob(ctx, &api.DeleteJobRequest{Id: job.Id})
+	assert.Nil(t, err)
+
+	assert.Nil(t, h.Cleanup())
+	_, err = h.Client.Pipelines.GetPipeline(ctx, &api.GetPipelineRequest{Id: pipeline.Id})
+	assert.True(t, kfp.IsNotFound(err))
+	// The resources are only deleted once.
+	assert.Nil(t, h.Cleanup())
+}
+
+func TestCleanup_ReverseOrderDespiteFailures(t *testing.T) {
+	h, _ := newFakeHarness(t)
+	var order []string
+	h.Defer("delete the first resource", func() error {
+		order = append(order, "first")
+		return nil
+	})
+	h.Defer("delete the second resource", func() error {
+		order = append(order, "second")
+		return errors.New("bad")
+	})
+	h.Defer("delete the third resource", func() error {
+		order = append(order, "third")
+		panic("worse")
+	})
+
+	err := h.Cleanup()
+	assert.Equal(t, []string{"third", "second", "first"}, order)
+	assert.Contains(t, err.Error(), "Failed to delete the third resource: panic: worse")
+	assert.Contains(t, err.Error(), "Failed to delete the second resource: bad")
+}
+
+func TestCleanup_OnPanic(t *testing.T) {
+	h, _ := newFakeHarness(t)
+	ctx := context.Background()
+	var pipeline *api.Pipeline
+	func() {
+		defer func() { recover() }()
+		defer h.Cleanup()
+		pipeline, _ = h.CreatePipelineFromURL(ctx, "sequential", "https://example.com/sequential.yaml")
+		panic("test failed")
+	}()
+
+	_, err := h.Client.Pipelines.GetPipeline(ctx, &api.GetPipelineRequest{Id: pipeline.Id})
+	assert.True(t, kfp.IsNotFound(err))
+}
+
+func TestDeferDeleteRunWorkflow(t *testing.T) {
+	h, workflows := newFakeHarness(t)
+	_, err := workflows.Create(&v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "hello-world-abc"}})
+	assert.Nil(t, err)
+	h.DeferDeleteRunWorkflow(&api.RunDetail{
+		Run:             &api.Run{Id: "run1"},
+		PipelineRuntime: &api.PipelineRuntime{WorkflowManifest: `{"metadata": {"name": "hello-world-abc"}}`},
+	})
+
+	assert.Nil(t, h.Cleanup())
+	assert.Equal(t, 0, workflows.GetWorkflowCount())
+}
+
+func TestUploadPipeline_NoProxy(t *testing.T) {
+	h, _ := newFakeHarness(t)
+	_, err := h.UploadPipeline("../resources/hello-world.yaml")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,80 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { backoffMs, createApiFetch, DEFAULT_RETRY_POLICY } from './ApiFetch';
+
+describe('ApiFetch', () => {
+  const noSleep = () => Promise.resolve();
+
+  it('passes the request through', async () => {
+    const spy = jest.fn(() => Promise.resolve({ status: 200 }));
+    window.fetch = spy;
+    const response = await createApiFetch({ sleep: noSleep })('apis/v1beta1/runs', { method: 'GET' });
+    expect(response.status).toEqual(200);
+    expect(spy).toHaveBeenCalledWith('apis/v1beta1/runs', { method: 'GET' });
+  });
+
+  it('adds the bearer token to the headers', async () => {
+    const spy = jest.fn(() => Promise.resolve({ status: 200 }));
+    window.fetch = spy;
+    await createApiFetch({ sleep: noSleep, token: 'token' })(
+      'apis/v1beta1/runs', { headers: { 'Content-Type': 'application/json' }, method: 'POST' });
+    expect(spy).toHaveBeenCalledWith('apis/v1beta1/runs', {
+      headers: { 'Authorization': 'Bearer token', 'Content-Type': 'application/json' },
+      method: 'POST',
+    });
+  });
+
+  it('retries while the API server is unavailable', async () => {
+    const spy = jest.fn()
+      .mockReturnValueOnce(Promise.resolve({ status: 503 }))
+      .mockReturnValueOnce(Promise.reject(new TypeError('Failed to fetch')))
+      .mockReturnValueOnce(Promise.resolve({ status: 200 }));
+    window.fetch = spy;
+    const sleep = jest.fn(noSleep);
+    const response = await createApiFetch({ sleep })('apis/v1beta1/runs');
+    expect(response.status).toEqual(200);
+    expect(spy).toHaveBeenCalledTimes(3);
+    expect(sleep.mock.calls).toEqual([[500], [1000]]);
+  });
+
+  it('does not retry the other errors', async () => {
+    const spy = jest.fn(() => Promise.resolve({ status: 404 }));
+    window.fetch = spy;
+    const response = await createApiFetch({ sleep: noSleep })('apis/v1beta1/runs/run1');
+    expect(response.status).toEqual(404);
+    expect(spy).toHaveBeenCalledTimes(1);
+  });
+
+  it('returns the last response after the retries', async () => {
+    const spy = jest.fn(() => Promise.resolve({ status: 503 }));
+    window.fetch = spy;
+    const policy = { initialBackoffMs: 1, maxBackoffMs: 1, maxRetries: 2 };
+    const response = await createApiFetch({ retryPolicy: policy, sleep: noSleep })('apis/v1beta1/runs');
+    expect(response.status).toEqual(503);
+    expect(spy).toHaveBeenCalledTimes(3);
+  });
+
+  it('throws the last error after the retries', async () => {
+    window.fetch = jest.fn(() => Promise.reject(new TypeError('Failed to fetch')));
+    const policy = { initialBackoffMs: 1, maxBackoffMs: 1, maxRetries: 1 };
+    await expect(createApiFetch({ retryPolicy: policy, sleep: noSleep })('apis/v1beta1/runs'))
+      .rejects.toThrowError('Failed to fetch');
+  });
+
+  it('doubles the backoff up to the maximum', () => {
+    expect([0, 1, 2, 3, 4].map(retry => backoffMs(DEFAULT_RETRY_POLICY, retry)))
+      .toEqual([500, 1000, 2000, 4000, 5000]);
+  });
+});
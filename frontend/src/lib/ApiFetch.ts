@@ -0,0 +1,88 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// The fetch function of the generated API clients (../apis). It authenticates and retries
+// the requests like the Go client of the API server
+// (backend/src/common/client/kfp), so that all the clients behave the same.
+
+export interface RetryPolicy {
+  // The number of retries after the first attempt. Zero disables the retries.
+  maxRetries: number;
+  initialBackoffMs: number;
+  maxBackoffMs: number;
+}
+
+export const DEFAULT_RETRY_POLICY: RetryPolicy = {
+  initialBackoffMs: 500,
+  maxBackoffMs: 5000,
+  maxRetries: 5,
+};
+
+export interface ApiFetchOptions {
+  // The bearer token sent in the Authorization header of the requests.
+  token?: string;
+  retryPolicy?: RetryPolicy;
+  // Overridden by the tests.
+  sleep?: (ms: number) => Promise<void>;
+}
+
+export type FetchFunction = (url: string, init?: any) => Promise<Response>;
+
+// The status of the responses of the API server when it's unavailable.
+const UNAVAILABLE_STATUS = 503;
+
+/**
+ * Returns the backoff before the given retry, starting from 0, which doubles after each
+ * retry up to the maximum backoff of the policy.
+ */
+export function backoffMs(policy: RetryPolicy, retry: number): number {
+  return Math.min(policy.initialBackoffMs * Math.pow(2, retry), policy.maxBackoffMs);
+}
+
+/**
+ * Returns a fetch function retrying the requests which fail because the API server is
+ * unavailable or unreachable, with an exponential backoff. The other errors are
+ * returned to the generated clients, which throw them.
+ */
+export function createApiFetch(options: ApiFetchOptions = {}): FetchFunction {
+  const policy = options.retryPolicy || DEFAULT_RETRY_POLICY;
+  const sleep = options.sleep || ((ms: number) => new Promise<void>(resolve => setTimeout(resolve, ms)));
+  return async (url: string, init?: any) => {
+    if (options.token) {
+      init = Object.assign({}, init, {
+        headers: Object.assign({}, init && init.headers, { Authorization: 'Bearer ' + options.token }),
+      });
+    }
+    for (let retry = 0; ; retry++) {
+      let response: Response | undefined;
+      let error: any;
+      try {
+        // The global fetch is read at each request, so that it can be mocked.
+        response = await fetch(url, init);
+      } catch (err) {
+        error = err;
+      }
+      if (response && response.status !== UNAVAILABLE_STATUS) {
+        return response;
+      }
+      if (retry >= policy.maxRetries) {
+        if (response) {
+          return response;
+        }
+        throw error;
+      }
+      await sleep(backoffMs(policy, retry));
+    }
+  };
+}
@@ -13,6 +13,7 @@
 // limitations under the License.
 
 import * as Utils from './Utils';
+import { createApiFetch } from './ApiFetch';
 import { ExperimentServiceApi } from '../apis/experiment';
 import { JobServiceApi } from '../apis/job';
 import { RunServiceApi } from '../apis/run';
@@ -46,28 +47,28 @@ export class Apis {
 
   public static get experimentServiceApi(): ExperimentServiceApi {
     if (!this._experimentServiceApi) {
-      this._experimentServiceApi = new ExperimentServiceApi({ basePath: this.basePath });
+      this._experimentServiceApi = new ExperimentServiceApi({ basePath: this.basePath }, undefined, this._apiFetch);
     }
     return this._experimentServiceApi;
   }
 
   public static get jobServiceApi(): JobServiceApi {
     if (!this._jobServiceApi) {
-      this._jobServiceApi = new JobServiceApi({ basePath: this.basePath });
+      this._jobServiceApi = new JobServiceApi({ basePath: this.basePath }, undefined, this._apiFetch);
     }
     return this._jobServiceApi;
   }
 
   public static get pipelineServiceApi(): PipelineServiceApi {
     if (!this._pipelineServiceApi) {
-      this._pipelineServiceApi = new PipelineServiceApi({ basePath: this.basePath });
+      this._pipelineServiceApi = new PipelineServiceApi({ basePath: this.basePath }, undefined, this._apiFetch);
     }
     return this._pipelineServiceApi;
   }
 
   public static get runServiceApi(): RunServiceApi {
     if (!this._runServiceApi) {
-      this._runServiceApi = new RunServiceApi({ basePath: this.basePath });
+      this._runServiceApi = new RunServiceApi({ basePath: this.basePath }, undefined, this._apiFetch);
     }
     return this._runServiceApi;
   }
@@ -130,6 +131,8 @@ export class Apis {
       });
   }
 
+  // Retries the requests of the generated clients while the API server is unavailable.
+  private static _apiFetch = createApiFetch();
   private static _experimentServiceApi?: ExperimentServiceApi;
   private static _jobServiceApi?: JobServiceApi;
   private static _pipelineServiceApi?: PipelineServiceApi;
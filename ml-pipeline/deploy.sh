@@ -38,6 +38,9 @@ SCHEDULED_WORKFLOW_IMAGE="${SCHEDULED_WORKFLOW_IMAGE:-gcr.io/ml-pipeline/schedul
 # Default ml pipeline persistence agent image
 PERSISTENCE_AGENT_IMAGE="${PERSISTENCE_AGENT_IMAGE:-gcr.io/ml-pipeline/persistenceagent:${RELEASE_VERSION}}"
 
+# Default ml pipeline visualization server image
+VISUALIZATION_SERVER_IMAGE="${VISUALIZATION_SERVER_IMAGE:-gcr.io/ml-pipeline/visualizationserver:${RELEASE_VERSION}}"
+
 # Default ml pipeline ui image
 UI_IMAGE="${UI_IMAGE:-gcr.io/ml-pipeline/frontend:${RELEASE_VERSION}}"
 
@@ -61,6 +64,7 @@ usage()
     [-a | --api_image ml-pipeline apiserver docker image to use ]
     [-w | --scheduled_workflow_image ml-pipeline scheduled workflow controller image]
     [-p | --persistence_agent_image ml-pipeline persistence agent image]
+    [-v | --visualization_server_image ml-pipeline visualization server image]
     [-u | --ui_image ml-pipeline frontend UI docker image]
     [-r | --report_usage deploy roles or not. Roles are needed for GKE]
     [--with_kubeflow whether to include kubeflow or not]
@@ -83,6 +87,9 @@ while [ "$1" != "" ]; do
         -p | --persistence_agent_image )     shift
                                              PERSISTENCE_AGENT_IMAGE=$1
                                              ;;
+        -v | --visualization_server_image )  shift
+                                             VISUALIZATION_SERVER_IMAGE=$1
+                                             ;;
         -u | --ui_image )                    shift
                                              UI_IMAGE=$1
                                              ;;
@@ -149,6 +156,7 @@ fi
 ( cd ${APP_DIR} && ks param set ml-pipeline api_image ${API_SERVER_IMAGE} )
 ( cd ${APP_DIR} && ks param set ml-pipeline scheduledworkflow_image ${SCHEDULED_WORKFLOW_IMAGE} )
 ( cd ${APP_DIR} && ks param set ml-pipeline persistenceagent_image ${PERSISTENCE_AGENT_IMAGE} )
+( cd ${APP_DIR} && ks param set ml-pipeline visualizationserver_image ${VISUALIZATION_SERVER_IMAGE} )
 ( cd ${APP_DIR} && ks param set ml-pipeline ui_image ${UI_IMAGE} )
 ( cd ${APP_DIR} && ks param set ml-pipeline deploy_argo ${DEPLOY_ARGO} )
 ( cd ${APP_DIR} && ks param set ml-pipeline report_usage ${REPORT_USAGE} )
@@ -6,6 +6,7 @@
     local pipeline_apiserver = import "ml-pipeline/ml-pipeline/pipeline-apiserver.libsonnet",
     local pipeline_scheduledworkflow = import "ml-pipeline/ml-pipeline/pipeline-scheduledworkflow.libsonnet",
     local pipeline_persistenceagent = import "ml-pipeline/ml-pipeline/pipeline-persistenceagent.libsonnet",
+    local pipeline_visualizationserver = import "ml-pipeline/ml-pipeline/pipeline-visualizationserver.libsonnet",
     local pipeline_ui = import "ml-pipeline/ml-pipeline/pipeline-ui.libsonnet",
     local spartakus = import "ml-pipeline/ml-pipeline/spartakus.libsonnet",
 
@@ -14,6 +15,7 @@
     local api_image = params.api_image,
     local scheduledworkflow_image = params.scheduledworkflow_image,
     local persistenceagent_image = params.persistenceagent_image,
+    local visualizationserver_image = params.visualizationserver_image,
     local ui_image = params.ui_image,
     local deploy_argo = params.deploy_argo,
     local report_usage = params.report_usage,
@@ -29,6 +31,7 @@
           pipeline_apiserver.all(namespace,api_image) +
           pipeline_scheduledworkflow.all(namespace,scheduledworkflow_image) +
           pipeline_persistenceagent.all(namespace,persistenceagent_image) +
+          pipeline_visualizationserver.all(namespace,visualizationserver_image) +
           pipeline_ui.all(namespace,ui_image) +
           $.parts(params).argo +
           $.parts(params).reporting,
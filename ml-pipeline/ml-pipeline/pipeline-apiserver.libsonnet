@@ -69,6 +69,9 @@
             "watch",
             "update",
             "patch",
+            // Deleting the orphaned workflows, unless they're adopted or the reconciliation
+            // is a dry run.
+            "delete",
           ],
         },
         {
@@ -87,6 +90,109 @@
             "delete",
           ],
         },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            "events",
+          ],
+          verbs: [
+            "create",
+            "patch",
+          ],
+        },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            // Reading the logs of the steps of the runs not archived yet.
+            "pods/log",
+          ],
+          verbs: [
+            "get",
+          ],
+        },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            // Deleting the pods of the failed steps of the retried runs.
+            "pods",
+          ],
+          verbs: [
+            "delete",
+          ],
+        },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            // Reading the configuration of the namespace of the runs, if enabled.
+            "configmaps",
+          ],
+          verbs: [
+            "get",
+          ],
+        },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            // Reading the kubeconfigs of the execution targets. The API server only reads
+            // the Secrets labeled pipelines.kubeflow.org/executionTargetKubeconfig=true.
+            "secrets",
+          ],
+          verbs: [
+            "get",
+          ],
+        },
+        {
+          apiGroups: [
+            "apps",
+          ],
+          resources: [
+            // Detecting the Argo version from the image of its workflow controller.
+            "deployments",
+          ],
+          verbs: [
+            "get",
+          ],
+        },
+        {
+          apiGroups: [
+            "tekton.dev",
+          ],
+          resources: [
+            // Running the workflows with the Tekton engine, if configured.
+            "pipelineruns",
+          ],
+          verbs: [
+            "create",
+            "get",
+            "list",
+            "patch",
+            "delete",
+          ],
+        },
+        {
+          apiGroups: [
+            "coordination.k8s.io",
+          ],
+          resources: [
+            // Electing the replica running the background tasks, if enabled.
+            "leases",
+          ],
+          verbs: [
+            "get",
+            "create",
+            "update",
+          ],
+        },
       ],
     },  // role
 
@@ -169,7 +275,25 @@
                       },
                     },
                   },
+                  {
+                    // The visualizations are generated by the visualization server.
+                    name: "VISUALIZATIONCONFIG.ADDRESS",
+                    value: "ml-pipeline-visualizationserver:8888",
+                  },
                 ],
+                livenessProbe: {
+                  httpGet: {
+                    path: "/healthz",
+                    port: 8888,
+                  },
+                },
+                readinessProbe: {
+                  httpGet: {
+                    path: "/readyz",
+                    port: 8888,
+                  },
+                  timeoutSeconds: 10,
+                },
               },
             ],
             serviceAccountName: "ml-pipeline",
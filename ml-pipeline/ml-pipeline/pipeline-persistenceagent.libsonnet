@@ -62,6 +62,9 @@
             "get",
             "list",
             "watch",
+            // Deleting or labeling the completed workflows, if configured.
+            "delete",
+            "patch",
           ],
         },
         {
@@ -77,6 +80,57 @@
             "watch",
           ],
         },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            "pods",
+          ],
+          verbs: [
+            // Deleting the pods of the completed workflows, as set by their pod GC strategy.
+            "list",
+            "delete",
+          ],
+        },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            "pods/log",
+          ],
+          verbs: [
+            // Archiving the logs of the completed workflows.
+            "get",
+          ],
+        },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            "events",
+          ],
+          verbs: [
+            // Recording the workflows labeled as archived.
+            "create",
+            "patch",
+          ],
+        },
+        {
+          apiGroups: [
+            "coordination.k8s.io",
+          ],
+          resources: [
+            "leases",
+          ],
+          verbs: [
+            "get",
+            "create",
+            "update",
+          ],
+        },
       ],
     },  // role
 
@@ -118,6 +172,19 @@
                     },
                   },
                 ],
+                livenessProbe: {
+                  httpGet: {
+                    path: "/healthz",
+                    port: 8080,
+                  },
+                },
+                readinessProbe: {
+                  httpGet: {
+                    path: "/readyz",
+                    port: 8080,
+                  },
+                  timeoutSeconds: 10,
+                },
               },
             ],
             serviceAccountName: "ml-pipeline-persistenceagent",
@@ -86,6 +86,45 @@
             "delete",
           ],
         },
+        {
+          apiGroups: [
+            "",
+          ],
+          resources: [
+            "events",
+          ],
+          verbs: [
+            "create",
+            "patch",
+          ],
+        },
+        {
+          apiGroups: [
+            "apps",
+          ],
+          resources: [
+            "daemonsets",
+          ],
+          verbs: [
+            // Pre-pulling the images of the scheduled workflows, if configured.
+            "create",
+            "get",
+            "delete",
+          ],
+        },
+        {
+          apiGroups: [
+            "coordination.k8s.io",
+          ],
+          resources: [
+            "leases",
+          ],
+          verbs: [
+            "get",
+            "create",
+            "update",
+          ],
+        },
       ],
     },  // role
 
@@ -127,6 +166,19 @@
                     },
                   },
                 ],
+                livenessProbe: {
+                  httpGet: {
+                    path: "/healthz",
+                    port: 8080,
+                  },
+                },
+                readinessProbe: {
+                  httpGet: {
+                    path: "/readyz",
+                    port: 8080,
+                  },
+                  timeoutSeconds: 10,
+                },
               },
             ],
             serviceAccountName: "ml-pipeline-scheduledworkflow",
@@ -0,0 +1,141 @@
+{
+  all(namespace, visualizationserver_image):: [
+    $.parts(namespace).service,
+    $.parts(namespace).networkPolicy,
+    $.parts(namespace).deploy(visualizationserver_image),
+  ],
+
+  parts(namespace):: {
+    service: {
+      apiVersion: "v1",
+      kind: "Service",
+      metadata: {
+        labels: {
+          app: "ml-pipeline-visualizationserver",
+        },
+        name: "ml-pipeline-visualizationserver",
+        namespace: namespace,
+      },
+      spec: {
+        ports: [
+          {
+            name: "http",
+            port: 8888,
+            protocol: "TCP",
+            targetPort: 8888,
+          },
+        ],
+        selector: {
+          app: "ml-pipeline-visualizationserver",
+        },
+      },
+    },  // service
+
+    // The visualization server is only called by the API server, and calls nothing: it receives
+    // the content of the artifacts it visualizes.
+    networkPolicy: {
+      apiVersion: "networking.k8s.io/v1",
+      kind: "NetworkPolicy",
+      metadata: {
+        name: "ml-pipeline-visualizationserver",
+        namespace: namespace,
+      },
+      spec: {
+        podSelector: {
+          matchLabels: {
+            app: "ml-pipeline-visualizationserver",
+          },
+        },
+        policyTypes: [
+          "Ingress",
+          "Egress",
+        ],
+        ingress: [
+          {
+            from: [
+              {
+                podSelector: {
+                  matchLabels: {
+                    app: "ml-pipeline",
+                  },
+                },
+              },
+            ],
+            ports: [
+              {
+                port: 8888,
+                protocol: "TCP",
+              },
+            ],
+          },
+        ],
+        egress: [],
+      },
+    },  // network policy
+
+    deploy(image): {
+      apiVersion: "apps/v1beta2",
+      kind: "Deployment",
+      metadata: {
+        "labels": {
+          "app": "ml-pipeline-visualizationserver",
+        },
+        name: "ml-pipeline-visualizationserver",
+        namespace: namespace,
+      },
+      spec: {
+        selector: {
+          matchLabels: {
+            app: "ml-pipeline-visualizationserver",
+          },
+        },
+        template: {
+          metadata: {
+            labels: {
+              app: "ml-pipeline-visualizationserver",
+            },
+          },
+          spec: {
+            containers: [
+              {
+                name: "ml-pipeline-visualizationserver",
+                image: image,
+                imagePullPolicy: 'Always',
+                ports: [
+                  {
+                    containerPort: 8888,
+                  },
+                ],
+                livenessProbe: {
+                  httpGet: {
+                    path: "/healthz",
+                    port: 8888,
+                  },
+                },
+                resources: {
+                  limits: {
+                    cpu: "1",
+                    memory: "512Mi",
+                  },
+                },
+                securityContext: {
+                  runAsNonRoot: true,
+                  runAsUser: 65534,
+                  readOnlyRootFilesystem: true,
+                  allowPrivilegeEscalation: false,
+                  capabilities: {
+                    drop: [
+                      "ALL",
+                    ],
+                  },
+                },
+              },
+            ],
+            // The visualization server doesn't call the Kubernetes API.
+            automountServiceAccountToken: false,
+          },
+        },
+      },
+    }, // deploy
+  },  // parts
+}
@@ -28,12 +28,8 @@
 
 DIR=$(mktemp -d)
 
-# Generate python code from swagger json.
-echo "{\"packageName\": \"kfp_experiment\"}" > /tmp/config.json
-java -jar /tmp/swagger-codegen-cli.jar generate -l python -i ../../backend/api/swagger/experiment.swagger.json -o $DIR -c /tmp/config.json
-echo "{\"packageName\": \"kfp_run\"}" > /tmp/config.json
-java -jar /tmp/swagger-codegen-cli.jar generate -l python -i ../../backend/api/swagger/run.swagger.json -o $DIR -c /tmp/config.json
-rm /tmp/config.json
+# Generate python code from swagger json, like the clients of the other languages.
+make -C ../../backend/api python_client PYTHON_CLIENT_DIR=$DIR SWAGGER_CODEGEN_CLI=/tmp/swagger-codegen-cli.jar
 
 # Merge generated code with the rest code (setup.py, seira_client, etc).
 cp -r kfp $DIR
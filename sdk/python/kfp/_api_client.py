@@ -0,0 +1,91 @@
+# Copyright 2018 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+"""Creates the clients generated from the swagger specification of the API server
+(see backend/api/Makefile), authenticated and retried like the Go client of the API
+server (backend/src/common/client/kfp), so that all the clients behave the same.
+"""
+
+import functools
+import logging
+import time
+
+import urllib3
+
+
+class RetryPolicy(object):
+  """The retries of the requests failing because the API server is unavailable.
+
+  Args:
+    max_retries: the number of retries after the first attempt. Zero disables the retries.
+    initial_backoff: the backoff in seconds before the first retry. It doubles after each
+      retry, up to max_backoff.
+    max_backoff: the maximum backoff in seconds.
+  """
+
+  def __init__(self, max_retries=5, initial_backoff=0.5, max_backoff=5.0):
+    self.max_retries = max_retries
+    self.initial_backoff = initial_backoff
+    self.max_backoff = max_backoff
+
+  def backoff(self, retry):
+    """Returns the backoff in seconds before the given retry, starting from 0."""
+    return min(self.initial_backoff * (2 ** retry), self.max_backoff)
+
+
+DEFAULT_RETRY_POLICY = RetryPolicy()
+
+# The status of the responses of the API server when it's unavailable.
+_UNAVAILABLE_STATUS = 503
+
+
+def create_api_client(package, host, token=None, retry_policy=DEFAULT_RETRY_POLICY, sleep=time.sleep):
+  """Creates the ApiClient of a generated package, e.g. kfp_run.
+
+  Args:
+    package: the generated package.
+    host: the host of the HTTP API of the API server.
+    token: the bearer token sent in the Authorization header of the requests.
+    retry_policy: the retries of the requests while the API server is unavailable.
+    sleep: the function sleeping between the retries. Overridden by the tests.
+  Returns:
+    The ApiClient, to be passed to the API classes of the package.
+  """
+  config = package.configuration.Configuration()
+  config.host = host
+  api_client = package.api_client.ApiClient(config)
+  if token:
+    api_client.set_default_header('Authorization', 'Bearer ' + token)
+  api_client.call_api = _with_retries(api_client.call_api, package.rest.ApiException, retry_policy, sleep)
+  return api_client
+
+
+def _with_retries(call_api, api_exception, retry_policy, sleep):
+  @functools.wraps(call_api)
+  def call_api_with_retries(*args, **kwargs):
+    retry = 0
+    while True:
+      try:
+        return call_api(*args, **kwargs)
+      except (api_exception, urllib3.exceptions.HTTPError) as e:
+        if isinstance(e, api_exception) and e.status != _UNAVAILABLE_STATUS:
+          raise
+        if retry >= retry_policy.max_retries:
+          raise
+        backoff = retry_policy.backoff(retry)
+        logging.warning('The API server is unavailable, retrying in %s seconds: %s', backoff, e)
+        sleep(backoff)
+        retry += 1
+  return call_api_with_retries
@@ -22,16 +22,21 @@ import tarfile
 import yaml
 from datetime import datetime
 
+from ._api_client import create_api_client, DEFAULT_RETRY_POLICY
+
 
 class Client(object):
   """ API Client for KubeFlow Pipeline.
   """
 
-  def __init__(self, host='ml-pipeline.kubeflow.svc.cluster.local:8888'):
+  def __init__(self, host='ml-pipeline.kubeflow.svc.cluster.local:8888', token=None,
+               retry_policy=DEFAULT_RETRY_POLICY):
     """Create a new instance of kfp client.
 
     Args:
       host: the API host. If running inside the cluster as a Pod, default value should work.
+      token: the bearer token authenticating the requests, if the API server requires one.
+      retry_policy: the retries of the requests while the API server is unavailable.
     """
 
     try:
@@ -44,14 +49,10 @@ class Client(object):
     except ImportError:
       raise Exception('This module requires installation of kfp_run')
 
-    config = kfp_run.configuration.Configuration()
-    config.host = host
-    api_client = kfp_run.api_client.ApiClient(config)
+    api_client = create_api_client(kfp_run, host, token, retry_policy)
     self._run_api = kfp_run.api.run_service_api.RunServiceApi(api_client)
 
-    config = kfp_experiment.configuration.Configuration()
-    config.host = host
-    api_client = kfp_experiment.api_client.ApiClient(config)
+    api_client = create_api_client(kfp_experiment, host, token, retry_policy)
     self._experiment_api = \
         kfp_experiment.api.experiment_service_api.ExperimentServiceApi(api_client)
 
@@ -37,6 +37,12 @@ setup(
       'kfp_experiment',
       'kfp_experiment.api',
       'kfp_experiment.models',
+      'kfp_job',
+      'kfp_job.api',
+      'kfp_job.models',
+      'kfp_pipeline',
+      'kfp_pipeline.api',
+      'kfp_pipeline.models',
       'kfp_run',
       'kfp_run.api',
       'kfp_run.models',
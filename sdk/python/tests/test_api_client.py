@@ -0,0 +1,98 @@
+# Copyright 2018 Google LLC
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+import types
+import unittest
+
+import urllib3
+
+from kfp._api_client import create_api_client, RetryPolicy
+
+
+class FakeApiException(Exception):
+  def __init__(self, status):
+    super(FakeApiException, self).__init__('HTTP %s' % status)
+    self.status = status
+
+
+class FakeApiClient(object):
+  """Like the ApiClient of the generated packages, returning or raising the results."""
+
+  def __init__(self, config):
+    self.config = config
+    self.default_headers = {}
+    self.results = []
+    self.calls = 0
+
+  def set_default_header(self, name, value):
+    self.default_headers[name] = value
+
+  def call_api(self, *args, **kwargs):
+    self.calls += 1
+    result = self.results.pop(0)
+    if isinstance(result, Exception):
+      raise result
+    return result
+
+
+fake_package = types.SimpleNamespace(
+    configuration=types.SimpleNamespace(Configuration=types.SimpleNamespace),
+    api_client=types.SimpleNamespace(ApiClient=FakeApiClient),
+    rest=types.SimpleNamespace(ApiException=FakeApiException))
+
+
+class ApiClientTestCase(unittest.TestCase):
+  def setUp(self):
+    self.sleeps = []
+
+  def create(self, results, token=None, retry_policy=RetryPolicy()):
+    api_client = create_api_client(fake_package, 'localhost:8888', token, retry_policy, self.sleeps.append)
+    api_client.results = results
+    return api_client
+
+  def test_host_and_token(self):
+    api_client = self.create([], token='token')
+    self.assertEqual('localhost:8888', api_client.config.host)
+    self.assertEqual({'Authorization': 'Bearer token'}, api_client.default_headers)
+
+  def test_no_token(self):
+    api_client = self.create([])
+    self.assertEqual({}, api_client.default_headers)
+
+  def test_retries_while_unavailable(self):
+    api_client = self.create([
+        FakeApiException(503), urllib3.exceptions.NewConnectionError(None, 'refused'), 'response'])
+    self.assertEqual('response', api_client.call_api('/apis/v1beta1/runs', 'GET'))
+    self.assertEqual(3, api_client.calls)
+    self.assertEqual([0.5, 1.0], self.sleeps)
+
+  def test_does_not_retry_other_errors(self):
+    api_client = self.create([FakeApiException(404), 'response'])
+    with self.assertRaises(FakeApiException):
+      api_client.call_api('/apis/v1beta1/runs/run1', 'GET')
+    self.assertEqual(1, api_client.calls)
+
+  def test_raises_after_the_retries(self):
+    api_client = self.create([FakeApiException(503)] * 3, retry_policy=RetryPolicy(max_retries=2))
+    with self.assertRaises(FakeApiException):
+      api_client.call_api('/apis/v1beta1/runs', 'GET')
+    self.assertEqual(3, api_client.calls)
+
+  def test_backoff(self):
+    self.assertEqual([0.5, 1.0, 2.0, 4.0, 5.0], [RetryPolicy().backoff(retry) for retry in range(5)])
+
+
+if __name__ == '__main__':
+  unittest.main()
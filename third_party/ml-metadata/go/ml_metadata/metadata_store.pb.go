@@ -0,0 +1,1115 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: ml_metadata/proto/metadata_store.proto
+
+package ml_metadata
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type PropertyType int32
+
+const (
+	PropertyType_UNKNOWN PropertyType = 0
+	PropertyType_INT     PropertyType = 1
+	PropertyType_DOUBLE  PropertyType = 2
+	PropertyType_STRING  PropertyType = 3
+)
+
+var PropertyType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "INT",
+	2: "DOUBLE",
+	3: "STRING",
+}
+
+var PropertyType_value = map[string]int32{
+	"UNKNOWN": 0,
+	"INT":     1,
+	"DOUBLE":  2,
+	"STRING":  3,
+}
+
+func (x PropertyType) Enum() *PropertyType {
+	p := new(PropertyType)
+	*p = x
+	return p
+}
+
+func (x PropertyType) String() string {
+	return proto.EnumName(PropertyType_name, int32(x))
+}
+
+func (x *PropertyType) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(PropertyType_value, data, "PropertyType")
+	if err != nil {
+		return err
+	}
+	*x = PropertyType(value)
+	return nil
+}
+
+func (PropertyType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{0}
+}
+
+type Artifact_State int32
+
+const (
+	Artifact_UNKNOWN             Artifact_State = 0
+	Artifact_PENDING             Artifact_State = 1
+	Artifact_LIVE                Artifact_State = 2
+	Artifact_MARKED_FOR_DELETION Artifact_State = 3
+	Artifact_DELETED             Artifact_State = 4
+)
+
+var Artifact_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PENDING",
+	2: "LIVE",
+	3: "MARKED_FOR_DELETION",
+	4: "DELETED",
+}
+
+var Artifact_State_value = map[string]int32{
+	"UNKNOWN":             0,
+	"PENDING":             1,
+	"LIVE":                2,
+	"MARKED_FOR_DELETION": 3,
+	"DELETED":             4,
+}
+
+func (x Artifact_State) Enum() *Artifact_State {
+	p := new(Artifact_State)
+	*p = x
+	return p
+}
+
+func (x Artifact_State) String() string {
+	return proto.EnumName(Artifact_State_name, int32(x))
+}
+
+func (x *Artifact_State) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Artifact_State_value, data, "Artifact_State")
+	if err != nil {
+		return err
+	}
+	*x = Artifact_State(value)
+	return nil
+}
+
+func (Artifact_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{1, 0}
+}
+
+type Event_Type int32
+
+const (
+	Event_UNKNOWN         Event_Type = 0
+	Event_DECLARED_OUTPUT Event_Type = 1
+	Event_DECLARED_INPUT  Event_Type = 2
+	Event_INPUT           Event_Type = 3
+	Event_OUTPUT          Event_Type = 4
+	Event_INTERNAL_INPUT  Event_Type = 5
+	Event_INTERNAL_OUTPUT Event_Type = 6
+)
+
+var Event_Type_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "DECLARED_OUTPUT",
+	2: "DECLARED_INPUT",
+	3: "INPUT",
+	4: "OUTPUT",
+	5: "INTERNAL_INPUT",
+	6: "INTERNAL_OUTPUT",
+}
+
+var Event_Type_value = map[string]int32{
+	"UNKNOWN":         0,
+	"DECLARED_OUTPUT": 1,
+	"DECLARED_INPUT":  2,
+	"INPUT":           3,
+	"OUTPUT":          4,
+	"INTERNAL_INPUT":  5,
+	"INTERNAL_OUTPUT": 6,
+}
+
+func (x Event_Type) Enum() *Event_Type {
+	p := new(Event_Type)
+	*p = x
+	return p
+}
+
+func (x Event_Type) String() string {
+	return proto.EnumName(Event_Type_name, int32(x))
+}
+
+func (x *Event_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Event_Type_value, data, "Event_Type")
+	if err != nil {
+		return err
+	}
+	*x = Event_Type(value)
+	return nil
+}
+
+func (Event_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{3, 0}
+}
+
+type Execution_State int32
+
+const (
+	Execution_UNKNOWN  Execution_State = 0
+	Execution_NEW      Execution_State = 1
+	Execution_RUNNING  Execution_State = 2
+	Execution_COMPLETE Execution_State = 3
+	Execution_FAILED   Execution_State = 4
+	Execution_CACHED   Execution_State = 5
+	Execution_CANCELED Execution_State = 6
+)
+
+var Execution_State_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "NEW",
+	2: "RUNNING",
+	3: "COMPLETE",
+	4: "FAILED",
+	5: "CACHED",
+	6: "CANCELED",
+}
+
+var Execution_State_value = map[string]int32{
+	"UNKNOWN":  0,
+	"NEW":      1,
+	"RUNNING":  2,
+	"COMPLETE": 3,
+	"FAILED":   4,
+	"CACHED":   5,
+	"CANCELED": 6,
+}
+
+func (x Execution_State) Enum() *Execution_State {
+	p := new(Execution_State)
+	*p = x
+	return p
+}
+
+func (x Execution_State) String() string {
+	return proto.EnumName(Execution_State_name, int32(x))
+}
+
+func (x *Execution_State) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Execution_State_value, data, "Execution_State")
+	if err != nil {
+		return err
+	}
+	*x = Execution_State(value)
+	return nil
+}
+
+func (Execution_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{4, 0}
+}
+
+type Value struct {
+	// Types that are valid to be assigned to Value:
+	//	*Value_IntValue
+	//	*Value_DoubleValue
+	//	*Value_StringValue
+	Value                isValue_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Value) Reset()         { *m = Value{} }
+func (m *Value) String() string { return proto.CompactTextString(m) }
+func (*Value) ProtoMessage()    {}
+func (*Value) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{0}
+}
+
+func (m *Value) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Value.Unmarshal(m, b)
+}
+func (m *Value) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Value.Marshal(b, m, deterministic)
+}
+func (m *Value) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Value.Merge(m, src)
+}
+func (m *Value) XXX_Size() int {
+	return xxx_messageInfo_Value.Size(m)
+}
+func (m *Value) XXX_DiscardUnknown() {
+	xxx_messageInfo_Value.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Value proto.InternalMessageInfo
+
+type isValue_Value interface {
+	isValue_Value()
+}
+
+type Value_IntValue struct {
+	IntValue int64 `protobuf:"varint,1,opt,name=int_value,json=intValue,oneof"`
+}
+
+type Value_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,2,opt,name=double_value,json=doubleValue,oneof"`
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,3,opt,name=string_value,json=stringValue,oneof"`
+}
+
+func (*Value_IntValue) isValue_Value() {}
+
+func (*Value_DoubleValue) isValue_Value() {}
+
+func (*Value_StringValue) isValue_Value() {}
+
+func (m *Value) GetValue() isValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Value) GetIntValue() int64 {
+	if x, ok := m.GetValue().(*Value_IntValue); ok {
+		return x.IntValue
+	}
+	return 0
+}
+
+func (m *Value) GetDoubleValue() float64 {
+	if x, ok := m.GetValue().(*Value_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (m *Value) GetStringValue() string {
+	if x, ok := m.GetValue().(*Value_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Value) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Value_IntValue)(nil),
+		(*Value_DoubleValue)(nil),
+		(*Value_StringValue)(nil),
+	}
+}
+
+type Artifact struct {
+	// The id of the artifact, assigned by the store.
+	Id *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// The name of the artifact, unique within its type.
+	Name   *string `protobuf:"bytes,7,opt,name=name" json:"name,omitempty"`
+	TypeId *int64  `protobuf:"varint,2,opt,name=type_id,json=typeId" json:"type_id,omitempty"`
+	// The uniform resource identifier of the physical artifact.
+	Uri                  *string           `protobuf:"bytes,3,opt,name=uri" json:"uri,omitempty"`
+	Properties           map[string]*Value `protobuf:"bytes,4,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CustomProperties     map[string]*Value `protobuf:"bytes,5,rep,name=custom_properties,json=customProperties" json:"custom_properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	State                *Artifact_State   `protobuf:"varint,6,opt,name=state,enum=ml_metadata.Artifact_State" json:"state,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Artifact) Reset()         { *m = Artifact{} }
+func (m *Artifact) String() string { return proto.CompactTextString(m) }
+func (*Artifact) ProtoMessage()    {}
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{1}
+}
+
+func (m *Artifact) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Artifact.Unmarshal(m, b)
+}
+func (m *Artifact) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Artifact.Marshal(b, m, deterministic)
+}
+func (m *Artifact) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Artifact.Merge(m, src)
+}
+func (m *Artifact) XXX_Size() int {
+	return xxx_messageInfo_Artifact.Size(m)
+}
+func (m *Artifact) XXX_DiscardUnknown() {
+	xxx_messageInfo_Artifact.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Artifact proto.InternalMessageInfo
+
+func (m *Artifact) GetId() int64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *Artifact) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *Artifact) GetTypeId() int64 {
+	if m != nil && m.TypeId != nil {
+		return *m.TypeId
+	}
+	return 0
+}
+
+func (m *Artifact) GetUri() string {
+	if m != nil && m.Uri != nil {
+		return *m.Uri
+	}
+	return ""
+}
+
+func (m *Artifact) GetProperties() map[string]*Value {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Artifact) GetCustomProperties() map[string]*Value {
+	if m != nil {
+		return m.CustomProperties
+	}
+	return nil
+}
+
+func (m *Artifact) GetState() Artifact_State {
+	if m != nil && m.State != nil {
+		return *m.State
+	}
+	return Artifact_UNKNOWN
+}
+
+type ArtifactType struct {
+	Id                   *int64                  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name                 *string                 `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Properties           map[string]PropertyType `protobuf:"bytes,3,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=ml_metadata.PropertyType"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ArtifactType) Reset()         { *m = ArtifactType{} }
+func (m *ArtifactType) String() string { return proto.CompactTextString(m) }
+func (*ArtifactType) ProtoMessage()    {}
+func (*ArtifactType) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{2}
+}
+
+func (m *ArtifactType) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ArtifactType.Unmarshal(m, b)
+}
+func (m *ArtifactType) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ArtifactType.Marshal(b, m, deterministic)
+}
+func (m *ArtifactType) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactType.Merge(m, src)
+}
+func (m *ArtifactType) XXX_Size() int {
+	return xxx_messageInfo_ArtifactType.Size(m)
+}
+func (m *ArtifactType) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactType.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArtifactType proto.InternalMessageInfo
+
+func (m *ArtifactType) GetId() int64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *ArtifactType) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ArtifactType) GetProperties() map[string]PropertyType {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+// An event links an artifact to the execution that consumed or produced it.
+type Event struct {
+	ArtifactId  *int64 `protobuf:"varint,1,opt,name=artifact_id,json=artifactId" json:"artifact_id,omitempty"`
+	ExecutionId *int64 `protobuf:"varint,2,opt,name=execution_id,json=executionId" json:"execution_id,omitempty"`
+	// The path of the artifact in the inputs or outputs of the execution.
+	Path                   *Event_Path `protobuf:"bytes,3,opt,name=path" json:"path,omitempty"`
+	Type                   *Event_Type `protobuf:"varint,4,opt,name=type,enum=ml_metadata.Event_Type" json:"type,omitempty"`
+	MillisecondsSinceEpoch *int64      `protobuf:"varint,5,opt,name=milliseconds_since_epoch,json=millisecondsSinceEpoch" json:"milliseconds_since_epoch,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}    `json:"-"`
+	XXX_unrecognized       []byte      `json:"-"`
+	XXX_sizecache          int32       `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{3}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetArtifactId() int64 {
+	if m != nil && m.ArtifactId != nil {
+		return *m.ArtifactId
+	}
+	return 0
+}
+
+func (m *Event) GetExecutionId() int64 {
+	if m != nil && m.ExecutionId != nil {
+		return *m.ExecutionId
+	}
+	return 0
+}
+
+func (m *Event) GetPath() *Event_Path {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *Event) GetType() Event_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return Event_UNKNOWN
+}
+
+func (m *Event) GetMillisecondsSinceEpoch() int64 {
+	if m != nil && m.MillisecondsSinceEpoch != nil {
+		return *m.MillisecondsSinceEpoch
+	}
+	return 0
+}
+
+type Event_Path struct {
+	Steps                []*Event_Path_Step `protobuf:"bytes,1,rep,name=steps" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Event_Path) Reset()         { *m = Event_Path{} }
+func (m *Event_Path) String() string { return proto.CompactTextString(m) }
+func (*Event_Path) ProtoMessage()    {}
+func (*Event_Path) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{3, 0}
+}
+
+func (m *Event_Path) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event_Path.Unmarshal(m, b)
+}
+func (m *Event_Path) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event_Path.Marshal(b, m, deterministic)
+}
+func (m *Event_Path) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event_Path.Merge(m, src)
+}
+func (m *Event_Path) XXX_Size() int {
+	return xxx_messageInfo_Event_Path.Size(m)
+}
+func (m *Event_Path) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event_Path.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event_Path proto.InternalMessageInfo
+
+func (m *Event_Path) GetSteps() []*Event_Path_Step {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type Event_Path_Step struct {
+	// Types that are valid to be assigned to Value:
+	//	*Event_Path_Step_Index
+	//	*Event_Path_Step_Key
+	Value                isEvent_Path_Step_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *Event_Path_Step) Reset()         { *m = Event_Path_Step{} }
+func (m *Event_Path_Step) String() string { return proto.CompactTextString(m) }
+func (*Event_Path_Step) ProtoMessage()    {}
+func (*Event_Path_Step) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{3, 0, 0}
+}
+
+func (m *Event_Path_Step) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event_Path_Step.Unmarshal(m, b)
+}
+func (m *Event_Path_Step) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event_Path_Step.Marshal(b, m, deterministic)
+}
+func (m *Event_Path_Step) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event_Path_Step.Merge(m, src)
+}
+func (m *Event_Path_Step) XXX_Size() int {
+	return xxx_messageInfo_Event_Path_Step.Size(m)
+}
+func (m *Event_Path_Step) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event_Path_Step.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event_Path_Step proto.InternalMessageInfo
+
+type isEvent_Path_Step_Value interface {
+	isEvent_Path_Step_Value()
+}
+
+type Event_Path_Step_Index struct {
+	Index int64 `protobuf:"varint,1,opt,name=index,oneof"`
+}
+
+type Event_Path_Step_Key struct {
+	Key string `protobuf:"bytes,2,opt,name=key,oneof"`
+}
+
+func (*Event_Path_Step_Index) isEvent_Path_Step_Value() {}
+
+func (*Event_Path_Step_Key) isEvent_Path_Step_Value() {}
+
+func (m *Event_Path_Step) GetValue() isEvent_Path_Step_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *Event_Path_Step) GetIndex() int64 {
+	if x, ok := m.GetValue().(*Event_Path_Step_Index); ok {
+		return x.Index
+	}
+	return 0
+}
+
+func (m *Event_Path_Step) GetKey() string {
+	if x, ok := m.GetValue().(*Event_Path_Step_Key); ok {
+		return x.Key
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Event_Path_Step) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Event_Path_Step_Index)(nil),
+		(*Event_Path_Step_Key)(nil),
+	}
+}
+
+type Execution struct {
+	// The id of the execution, assigned by the store.
+	Id *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// The name of the execution, unique within its type.
+	Name                 *string           `protobuf:"bytes,6,opt,name=name" json:"name,omitempty"`
+	TypeId               *int64            `protobuf:"varint,2,opt,name=type_id,json=typeId" json:"type_id,omitempty"`
+	LastKnownState       *Execution_State  `protobuf:"varint,3,opt,name=last_known_state,json=lastKnownState,enum=ml_metadata.Execution_State" json:"last_known_state,omitempty"`
+	Properties           map[string]*Value `protobuf:"bytes,4,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CustomProperties     map[string]*Value `protobuf:"bytes,5,rep,name=custom_properties,json=customProperties" json:"custom_properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Execution) Reset()         { *m = Execution{} }
+func (m *Execution) String() string { return proto.CompactTextString(m) }
+func (*Execution) ProtoMessage()    {}
+func (*Execution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{4}
+}
+
+func (m *Execution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Execution.Unmarshal(m, b)
+}
+func (m *Execution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Execution.Marshal(b, m, deterministic)
+}
+func (m *Execution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Execution.Merge(m, src)
+}
+func (m *Execution) XXX_Size() int {
+	return xxx_messageInfo_Execution.Size(m)
+}
+func (m *Execution) XXX_DiscardUnknown() {
+	xxx_messageInfo_Execution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Execution proto.InternalMessageInfo
+
+func (m *Execution) GetId() int64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *Execution) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *Execution) GetTypeId() int64 {
+	if m != nil && m.TypeId != nil {
+		return *m.TypeId
+	}
+	return 0
+}
+
+func (m *Execution) GetLastKnownState() Execution_State {
+	if m != nil && m.LastKnownState != nil {
+		return *m.LastKnownState
+	}
+	return Execution_UNKNOWN
+}
+
+func (m *Execution) GetProperties() map[string]*Value {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Execution) GetCustomProperties() map[string]*Value {
+	if m != nil {
+		return m.CustomProperties
+	}
+	return nil
+}
+
+type ExecutionType struct {
+	Id                   *int64                  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name                 *string                 `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Properties           map[string]PropertyType `protobuf:"bytes,3,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=ml_metadata.PropertyType"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ExecutionType) Reset()         { *m = ExecutionType{} }
+func (m *ExecutionType) String() string { return proto.CompactTextString(m) }
+func (*ExecutionType) ProtoMessage()    {}
+func (*ExecutionType) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{5}
+}
+
+func (m *ExecutionType) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecutionType.Unmarshal(m, b)
+}
+func (m *ExecutionType) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecutionType.Marshal(b, m, deterministic)
+}
+func (m *ExecutionType) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecutionType.Merge(m, src)
+}
+func (m *ExecutionType) XXX_Size() int {
+	return xxx_messageInfo_ExecutionType.Size(m)
+}
+func (m *ExecutionType) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecutionType.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecutionType proto.InternalMessageInfo
+
+func (m *ExecutionType) GetId() int64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *ExecutionType) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ExecutionType) GetProperties() map[string]PropertyType {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+type ContextType struct {
+	Id                   *int64                  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Name                 *string                 `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Properties           map[string]PropertyType `protobuf:"bytes,3,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=ml_metadata.PropertyType"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ContextType) Reset()         { *m = ContextType{} }
+func (m *ContextType) String() string { return proto.CompactTextString(m) }
+func (*ContextType) ProtoMessage()    {}
+func (*ContextType) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{6}
+}
+
+func (m *ContextType) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContextType.Unmarshal(m, b)
+}
+func (m *ContextType) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContextType.Marshal(b, m, deterministic)
+}
+func (m *ContextType) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContextType.Merge(m, src)
+}
+func (m *ContextType) XXX_Size() int {
+	return xxx_messageInfo_ContextType.Size(m)
+}
+func (m *ContextType) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContextType.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContextType proto.InternalMessageInfo
+
+func (m *ContextType) GetId() int64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *ContextType) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ContextType) GetProperties() map[string]PropertyType {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+// A context groups the artifacts and the executions, e.g. of a run.
+type Context struct {
+	// The id of the context, assigned by the store.
+	Id *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// The name of the context, unique within its type.
+	Name                 *string           `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	TypeId               *int64            `protobuf:"varint,2,opt,name=type_id,json=typeId" json:"type_id,omitempty"`
+	Properties           map[string]*Value `protobuf:"bytes,4,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CustomProperties     map[string]*Value `protobuf:"bytes,5,rep,name=custom_properties,json=customProperties" json:"custom_properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Context) Reset()         { *m = Context{} }
+func (m *Context) String() string { return proto.CompactTextString(m) }
+func (*Context) ProtoMessage()    {}
+func (*Context) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{7}
+}
+
+func (m *Context) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Context.Unmarshal(m, b)
+}
+func (m *Context) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Context.Marshal(b, m, deterministic)
+}
+func (m *Context) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Context.Merge(m, src)
+}
+func (m *Context) XXX_Size() int {
+	return xxx_messageInfo_Context.Size(m)
+}
+func (m *Context) XXX_DiscardUnknown() {
+	xxx_messageInfo_Context.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Context proto.InternalMessageInfo
+
+func (m *Context) GetId() int64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *Context) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *Context) GetTypeId() int64 {
+	if m != nil && m.TypeId != nil {
+		return *m.TypeId
+	}
+	return 0
+}
+
+func (m *Context) GetProperties() map[string]*Value {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *Context) GetCustomProperties() map[string]*Value {
+	if m != nil {
+		return m.CustomProperties
+	}
+	return nil
+}
+
+// Attribution links an artifact to a context.
+type Attribution struct {
+	ArtifactId           *int64   `protobuf:"varint,1,opt,name=artifact_id,json=artifactId" json:"artifact_id,omitempty"`
+	ContextId            *int64   `protobuf:"varint,2,opt,name=context_id,json=contextId" json:"context_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Attribution) Reset()         { *m = Attribution{} }
+func (m *Attribution) String() string { return proto.CompactTextString(m) }
+func (*Attribution) ProtoMessage()    {}
+func (*Attribution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{8}
+}
+
+func (m *Attribution) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attribution.Unmarshal(m, b)
+}
+func (m *Attribution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attribution.Marshal(b, m, deterministic)
+}
+func (m *Attribution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attribution.Merge(m, src)
+}
+func (m *Attribution) XXX_Size() int {
+	return xxx_messageInfo_Attribution.Size(m)
+}
+func (m *Attribution) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attribution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attribution proto.InternalMessageInfo
+
+func (m *Attribution) GetArtifactId() int64 {
+	if m != nil && m.ArtifactId != nil {
+		return *m.ArtifactId
+	}
+	return 0
+}
+
+func (m *Attribution) GetContextId() int64 {
+	if m != nil && m.ContextId != nil {
+		return *m.ContextId
+	}
+	return 0
+}
+
+// Association links an execution to a context.
+type Association struct {
+	ExecutionId          *int64   `protobuf:"varint,1,opt,name=execution_id,json=executionId" json:"execution_id,omitempty"`
+	ContextId            *int64   `protobuf:"varint,2,opt,name=context_id,json=contextId" json:"context_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Association) Reset()         { *m = Association{} }
+func (m *Association) String() string { return proto.CompactTextString(m) }
+func (*Association) ProtoMessage()    {}
+func (*Association) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb70a53ff03ab011, []int{9}
+}
+
+func (m *Association) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Association.Unmarshal(m, b)
+}
+func (m *Association) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Association.Marshal(b, m, deterministic)
+}
+func (m *Association) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Association.Merge(m, src)
+}
+func (m *Association) XXX_Size() int {
+	return xxx_messageInfo_Association.Size(m)
+}
+func (m *Association) XXX_DiscardUnknown() {
+	xxx_messageInfo_Association.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Association proto.InternalMessageInfo
+
+func (m *Association) GetExecutionId() int64 {
+	if m != nil && m.ExecutionId != nil {
+		return *m.ExecutionId
+	}
+	return 0
+}
+
+func (m *Association) GetContextId() int64 {
+	if m != nil && m.ContextId != nil {
+		return *m.ContextId
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("ml_metadata.PropertyType", PropertyType_name, PropertyType_value)
+	proto.RegisterEnum("ml_metadata.Artifact_State", Artifact_State_name, Artifact_State_value)
+	proto.RegisterEnum("ml_metadata.Event_Type", Event_Type_name, Event_Type_value)
+	proto.RegisterEnum("ml_metadata.Execution_State", Execution_State_name, Execution_State_value)
+	proto.RegisterType((*Value)(nil), "ml_metadata.Value")
+	proto.RegisterType((*Artifact)(nil), "ml_metadata.Artifact")
+	proto.RegisterMapType((map[string]*Value)(nil), "ml_metadata.Artifact.CustomPropertiesEntry")
+	proto.RegisterMapType((map[string]*Value)(nil), "ml_metadata.Artifact.PropertiesEntry")
+	proto.RegisterType((*ArtifactType)(nil), "ml_metadata.ArtifactType")
+	proto.RegisterMapType((map[string]PropertyType)(nil), "ml_metadata.ArtifactType.PropertiesEntry")
+	proto.RegisterType((*Event)(nil), "ml_metadata.Event")
+	proto.RegisterType((*Event_Path)(nil), "ml_metadata.Event.Path")
+	proto.RegisterType((*Event_Path_Step)(nil), "ml_metadata.Event.Path.Step")
+	proto.RegisterType((*Execution)(nil), "ml_metadata.Execution")
+	proto.RegisterMapType((map[string]*Value)(nil), "ml_metadata.Execution.CustomPropertiesEntry")
+	proto.RegisterMapType((map[string]*Value)(nil), "ml_metadata.Execution.PropertiesEntry")
+	proto.RegisterType((*ExecutionType)(nil), "ml_metadata.ExecutionType")
+	proto.RegisterMapType((map[string]PropertyType)(nil), "ml_metadata.ExecutionType.PropertiesEntry")
+	proto.RegisterType((*ContextType)(nil), "ml_metadata.ContextType")
+	proto.RegisterMapType((map[string]PropertyType)(nil), "ml_metadata.ContextType.PropertiesEntry")
+	proto.RegisterType((*Context)(nil), "ml_metadata.Context")
+	proto.RegisterMapType((map[string]*Value)(nil), "ml_metadata.Context.CustomPropertiesEntry")
+	proto.RegisterMapType((map[string]*Value)(nil), "ml_metadata.Context.PropertiesEntry")
+	proto.RegisterType((*Attribution)(nil), "ml_metadata.Attribution")
+	proto.RegisterType((*Association)(nil), "ml_metadata.Association")
+}
+
+func init() {
+	proto.RegisterFile("ml_metadata/proto/metadata_store.proto", fileDescriptor_cb70a53ff03ab011)
+}
+
+var fileDescriptor_cb70a53ff03ab011 = []byte{
+	// 957 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xe1, 0x72, 0xdb, 0x44,
+	0x10, 0x8e, 0x2c, 0xc9, 0x8e, 0x57, 0xae, 0x2b, 0xae, 0x43, 0x63, 0x0c, 0x1d, 0x8c, 0x81, 0x8e,
+	0x49, 0x19, 0x67, 0xf0, 0xaf, 0x0e, 0xf0, 0x47, 0xb1, 0x14, 0x22, 0xe2, 0xc8, 0xee, 0xc5, 0x6e,
+	0x0a, 0x3f, 0xd0, 0xa8, 0xd2, 0x41, 0x34, 0xb5, 0x25, 0x8d, 0x74, 0x6e, 0xe3, 0x19, 0x5e, 0x82,
+	0xf7, 0xe0, 0x1d, 0x78, 0x0a, 0x60, 0x78, 0x1b, 0xe6, 0x4e, 0xb2, 0x23, 0xbb, 0x72, 0xf0, 0x0c,
+	0x3f, 0x32, 0xf0, 0xef, 0x76, 0xef, 0xd3, 0xb7, 0x7b, 0x7b, 0xbb, 0xdf, 0x09, 0x1e, 0xcf, 0xa6,
+	0xf6, 0x8c, 0x50, 0xc7, 0x73, 0xa8, 0x73, 0x14, 0xc5, 0x21, 0x0d, 0x8f, 0x96, 0xa6, 0x9d, 0xd0,
+	0x30, 0x26, 0x5d, 0xee, 0x44, 0x4a, 0x0e, 0xd7, 0x5e, 0x80, 0xfc, 0xdc, 0x99, 0xce, 0x09, 0x7a,
+	0x04, 0x55, 0x3f, 0xa0, 0xf6, 0x6b, 0x66, 0x34, 0x84, 0x96, 0xd0, 0x11, 0x4f, 0xf7, 0xf0, 0xbe,
+	0x1f, 0xd0, 0x74, 0xfb, 0x63, 0xa8, 0x79, 0xe1, 0xfc, 0xe5, 0x94, 0x64, 0x88, 0x52, 0x4b, 0xe8,
+	0x08, 0xa7, 0x7b, 0x58, 0x49, 0xbd, 0x2b, 0x50, 0x42, 0x63, 0x3f, 0xf8, 0x29, 0x03, 0x89, 0x2d,
+	0xa1, 0x53, 0x65, 0xa0, 0xd4, 0xcb, 0x41, 0xc7, 0x15, 0x90, 0xf9, 0x6e, 0xfb, 0x57, 0x09, 0xf6,
+	0xb5, 0x98, 0xfa, 0x3f, 0x3a, 0x2e, 0x45, 0x75, 0x28, 0xf9, 0x5e, 0x1a, 0x17, 0x97, 0x7c, 0x0f,
+	0x21, 0x90, 0x02, 0x67, 0x46, 0x1a, 0x15, 0x46, 0x81, 0xf9, 0x1a, 0x1d, 0x40, 0x85, 0x2e, 0x22,
+	0x62, 0xfb, 0x1e, 0x0f, 0x2f, 0xe2, 0x32, 0x33, 0x4d, 0x0f, 0xa9, 0x20, 0xce, 0x63, 0x3f, 0x0d,
+	0x87, 0xd9, 0x12, 0x19, 0x00, 0x51, 0x1c, 0x46, 0x24, 0xa6, 0x3e, 0x49, 0x1a, 0x52, 0x4b, 0xec,
+	0x28, 0xbd, 0x4f, 0xbb, 0xb9, 0x83, 0x77, 0x97, 0x91, 0xbb, 0xa3, 0x15, 0xce, 0x08, 0x68, 0xbc,
+	0xc0, 0xb9, 0x0f, 0xd1, 0x0b, 0x78, 0xc7, 0x9d, 0x27, 0x34, 0x9c, 0xd9, 0x39, 0x36, 0x99, 0xb3,
+	0x3d, 0x29, 0x66, 0xeb, 0x73, 0xf8, 0x26, 0xa7, 0xea, 0x6e, 0xb8, 0xd1, 0x17, 0x20, 0x27, 0xd4,
+	0xa1, 0xa4, 0x51, 0x6e, 0x09, 0x9d, 0x7a, 0xef, 0xfd, 0x62, 0xb6, 0x0b, 0x06, 0xc1, 0x29, 0xb2,
+	0xf9, 0x0c, 0xee, 0x6f, 0xf0, 0xb2, 0x83, 0xbf, 0x22, 0x0b, 0x5e, 0xb6, 0x2a, 0x66, 0x4b, 0xd4,
+	0xc9, 0xaa, 0xcb, 0x2b, 0xa4, 0xf4, 0xd0, 0x1a, 0x2f, 0xbf, 0x00, 0x9c, 0x02, 0xbe, 0x2c, 0x3d,
+	0x15, 0x9a, 0x97, 0xf0, 0x6e, 0x61, 0xc2, 0xff, 0x96, 0xb8, 0xfd, 0x0c, 0x64, 0x9e, 0x3b, 0x52,
+	0xa0, 0x32, 0xb1, 0xce, 0xac, 0xe1, 0xa5, 0xa5, 0xee, 0x31, 0x63, 0x64, 0x58, 0xba, 0x69, 0x7d,
+	0xa3, 0x0a, 0x68, 0x1f, 0xa4, 0x81, 0xf9, 0xdc, 0x50, 0x4b, 0xe8, 0x00, 0x1e, 0x9c, 0x6b, 0xf8,
+	0xcc, 0xd0, 0xed, 0x93, 0x21, 0xb6, 0x75, 0x63, 0x60, 0x8c, 0xcd, 0xa1, 0xa5, 0x8a, 0x0c, 0xcf,
+	0x2d, 0x43, 0x57, 0xa5, 0xf6, 0x9f, 0x02, 0xd4, 0x96, 0x85, 0x19, 0x2f, 0x22, 0xb2, 0xb5, 0x65,
+	0x4a, 0xb9, 0x96, 0x31, 0xd7, 0xfa, 0x40, 0xe4, 0x37, 0xf7, 0x59, 0x61, 0xad, 0x19, 0xe5, 0x6d,
+	0xbd, 0xd0, 0x7c, 0xb1, 0x4b, 0xf9, 0x8f, 0xf2, 0x55, 0xaa, 0xf7, 0xde, 0x5b, 0x0b, 0x95, 0x7d,
+	0xbe, 0x60, 0xa1, 0xf2, 0xc5, 0xfa, 0x43, 0x04, 0xd9, 0x78, 0x4d, 0x02, 0x8a, 0x3e, 0x04, 0xc5,
+	0xc9, 0xf2, 0xb1, 0x57, 0x67, 0x83, 0xa5, 0xcb, 0xf4, 0xd0, 0x47, 0x50, 0x23, 0xd7, 0xc4, 0x9d,
+	0x53, 0x3f, 0x0c, 0x6e, 0xe6, 0x40, 0x59, 0xf9, 0x4c, 0x0f, 0x3d, 0x01, 0x29, 0x72, 0xe8, 0x15,
+	0x9f, 0x06, 0xa5, 0x77, 0xb0, 0x96, 0x01, 0x8f, 0xd2, 0x1d, 0x39, 0xf4, 0x0a, 0x73, 0x10, 0x03,
+	0xb3, 0x19, 0x6a, 0x48, 0x3c, 0xdd, 0x22, 0x30, 0x4f, 0x96, 0x83, 0xd0, 0x53, 0x68, 0xcc, 0xfc,
+	0xe9, 0xd4, 0x4f, 0x88, 0x1b, 0x06, 0x5e, 0x62, 0x27, 0x7e, 0xe0, 0x12, 0x9b, 0x44, 0xa1, 0x7b,
+	0xd5, 0x90, 0x79, 0x22, 0x0f, 0xf3, 0xfb, 0x17, 0x6c, 0xdb, 0x60, 0xbb, 0xcd, 0x37, 0x20, 0xb1,
+	0xa0, 0xa8, 0xc7, 0xba, 0x9e, 0x44, 0x49, 0x43, 0xe0, 0x37, 0xf1, 0xc1, 0x96, 0xe4, 0xba, 0x17,
+	0x94, 0x44, 0x38, 0x85, 0x36, 0xbf, 0x02, 0x89, 0x99, 0xe8, 0x21, 0xc8, 0x7e, 0xe0, 0x91, 0xeb,
+	0x95, 0x38, 0xa5, 0x26, 0x42, 0xe9, 0x25, 0x94, 0x32, 0xad, 0x61, 0xc6, 0x8d, 0xc6, 0xfc, 0x0c,
+	0x12, 0xef, 0x95, 0xb5, 0x36, 0x7c, 0x00, 0xf7, 0x75, 0xa3, 0x3f, 0xd0, 0xb0, 0xa1, 0xdb, 0xc3,
+	0xc9, 0x78, 0x34, 0x19, 0xab, 0x02, 0x42, 0x50, 0x5f, 0x39, 0x4d, 0x8b, 0xf9, 0x4a, 0xa8, 0x0a,
+	0x72, 0xba, 0x14, 0x11, 0x40, 0x39, 0x83, 0x4a, 0x0c, 0x6a, 0x5a, 0x63, 0x03, 0x5b, 0xda, 0x20,
+	0x83, 0xca, 0x8c, 0x73, 0xe5, 0xcb, 0x80, 0xe5, 0xf6, 0x6f, 0x12, 0x54, 0x8d, 0xe5, 0xd5, 0x6c,
+	0xed, 0xd7, 0xf2, 0x2e, 0x12, 0x77, 0x02, 0xea, 0xd4, 0x49, 0xa8, 0xfd, 0x2a, 0x08, 0xdf, 0x04,
+	0x76, 0x2a, 0x1d, 0x22, 0xbf, 0xb4, 0x8d, 0x22, 0x2e, 0xc3, 0x65, 0xda, 0x51, 0x67, 0x5f, 0x9d,
+	0xb1, 0x8f, 0xb8, 0x8d, 0x4e, 0x0a, 0x84, 0xf1, 0xf1, 0x16, 0x86, 0xdb, 0x94, 0xf1, 0xbb, 0xed,
+	0xca, 0xf8, 0xf9, 0x16, 0xba, 0x1d, 0xa5, 0xf1, 0x3f, 0xa5, 0x73, 0x3f, 0x14, 0xea, 0x5c, 0x05,
+	0x44, 0xcb, 0xb8, 0x54, 0x05, 0xe6, 0xc5, 0x13, 0xcb, 0x62, 0x82, 0x57, 0x42, 0x35, 0xd8, 0xef,
+	0x0f, 0xcf, 0x47, 0x4c, 0xcf, 0xd2, 0x86, 0x3a, 0xd1, 0xcc, 0x01, 0x93, 0x36, 0xb6, 0xee, 0x6b,
+	0xfd, 0x53, 0x43, 0x57, 0x65, 0x8e, 0xd2, 0xac, 0xbe, 0xc1, 0x76, 0xca, 0xed, 0xbf, 0x04, 0xb8,
+	0xb7, 0xaa, 0xe0, 0xce, 0xaa, 0xf7, 0x6d, 0x81, 0xea, 0x1d, 0x16, 0xdf, 0xca, 0x1d, 0xca, 0xde,
+	0xef, 0x02, 0x28, 0xfd, 0x30, 0xa0, 0xe4, 0x7a, 0x77, 0x3d, 0x3f, 0x2d, 0x38, 0x59, 0x67, 0x2d,
+	0x5a, 0x8e, 0xf1, 0x8e, 0xce, 0xf5, 0x8b, 0x08, 0x95, 0x2c, 0x8b, 0xad, 0x67, 0x12, 0x77, 0x99,
+	0x79, 0xbd, 0x60, 0x56, 0x3f, 0x29, 0x3a, 0xec, 0xad, 0x93, 0x7a, 0xb9, 0x7d, 0x52, 0x0f, 0x0b,
+	0xc9, 0xfe, 0x8f, 0x73, 0x7a, 0x0e, 0x8a, 0x46, 0x69, 0xec, 0xbf, 0x4c, 0xa5, 0xf8, 0x1f, 0xdf,
+	0xd9, 0x47, 0x00, 0x6e, 0x5a, 0x8e, 0x9b, 0x6b, 0xa9, 0x66, 0x1e, 0xd3, 0x6b, 0x0f, 0x41, 0xd1,
+	0x92, 0x24, 0x74, 0x7d, 0x87, 0xd3, 0x6d, 0xbe, 0xca, 0xc2, 0xdb, 0xaf, 0xf2, 0xed, 0x84, 0x87,
+	0x5f, 0x43, 0x2d, 0xdf, 0x4e, 0x6f, 0xc9, 0x89, 0x69, 0xb1, 0x37, 0x0a, 0xa0, 0xac, 0x0f, 0x27,
+	0xc7, 0x03, 0xf6, 0xd3, 0x04, 0x50, 0xbe, 0x18, 0x63, 0xa6, 0x2c, 0xe2, 0xf1, 0xbd, 0xef, 0xf3,
+	0xff, 0xf4, 0x7f, 0x0f, 0x00, 0xeb, 0xb0, 0xac, 0xa3, 0x09, 0x0c, 0x00, 0x00,
+}
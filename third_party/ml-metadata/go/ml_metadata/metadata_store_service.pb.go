@@ -0,0 +1,1580 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: ml_metadata/proto/metadata_store_service.proto
+
+package ml_metadata
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type PutArtifactTypeRequest struct {
+	ArtifactType *ArtifactType `protobuf:"bytes,1,opt,name=artifact_type,json=artifactType" json:"artifact_type,omitempty"`
+	// Whether new properties can be added to an existing type.
+	CanAddFields         *bool    `protobuf:"varint,2,opt,name=can_add_fields,json=canAddFields" json:"can_add_fields,omitempty"`
+	CanDeleteFields      *bool    `protobuf:"varint,3,opt,name=can_delete_fields,json=canDeleteFields" json:"can_delete_fields,omitempty"`
+	AllFieldsMatch       *bool    `protobuf:"varint,4,opt,name=all_fields_match,json=allFieldsMatch,def=1" json:"all_fields_match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutArtifactTypeRequest) Reset()         { *m = PutArtifactTypeRequest{} }
+func (m *PutArtifactTypeRequest) String() string { return proto.CompactTextString(m) }
+func (*PutArtifactTypeRequest) ProtoMessage()    {}
+func (*PutArtifactTypeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{0}
+}
+
+func (m *PutArtifactTypeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutArtifactTypeRequest.Unmarshal(m, b)
+}
+func (m *PutArtifactTypeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutArtifactTypeRequest.Marshal(b, m, deterministic)
+}
+func (m *PutArtifactTypeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutArtifactTypeRequest.Merge(m, src)
+}
+func (m *PutArtifactTypeRequest) XXX_Size() int {
+	return xxx_messageInfo_PutArtifactTypeRequest.Size(m)
+}
+func (m *PutArtifactTypeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutArtifactTypeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutArtifactTypeRequest proto.InternalMessageInfo
+
+const Default_PutArtifactTypeRequest_AllFieldsMatch bool = true
+
+func (m *PutArtifactTypeRequest) GetArtifactType() *ArtifactType {
+	if m != nil {
+		return m.ArtifactType
+	}
+	return nil
+}
+
+func (m *PutArtifactTypeRequest) GetCanAddFields() bool {
+	if m != nil && m.CanAddFields != nil {
+		return *m.CanAddFields
+	}
+	return false
+}
+
+func (m *PutArtifactTypeRequest) GetCanDeleteFields() bool {
+	if m != nil && m.CanDeleteFields != nil {
+		return *m.CanDeleteFields
+	}
+	return false
+}
+
+func (m *PutArtifactTypeRequest) GetAllFieldsMatch() bool {
+	if m != nil && m.AllFieldsMatch != nil {
+		return *m.AllFieldsMatch
+	}
+	return Default_PutArtifactTypeRequest_AllFieldsMatch
+}
+
+type PutArtifactTypeResponse struct {
+	TypeId               *int64   `protobuf:"varint,1,opt,name=type_id,json=typeId" json:"type_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutArtifactTypeResponse) Reset()         { *m = PutArtifactTypeResponse{} }
+func (m *PutArtifactTypeResponse) String() string { return proto.CompactTextString(m) }
+func (*PutArtifactTypeResponse) ProtoMessage()    {}
+func (*PutArtifactTypeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{1}
+}
+
+func (m *PutArtifactTypeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutArtifactTypeResponse.Unmarshal(m, b)
+}
+func (m *PutArtifactTypeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutArtifactTypeResponse.Marshal(b, m, deterministic)
+}
+func (m *PutArtifactTypeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutArtifactTypeResponse.Merge(m, src)
+}
+func (m *PutArtifactTypeResponse) XXX_Size() int {
+	return xxx_messageInfo_PutArtifactTypeResponse.Size(m)
+}
+func (m *PutArtifactTypeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutArtifactTypeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutArtifactTypeResponse proto.InternalMessageInfo
+
+func (m *PutArtifactTypeResponse) GetTypeId() int64 {
+	if m != nil && m.TypeId != nil {
+		return *m.TypeId
+	}
+	return 0
+}
+
+type PutExecutionTypeRequest struct {
+	ExecutionType        *ExecutionType `protobuf:"bytes,1,opt,name=execution_type,json=executionType" json:"execution_type,omitempty"`
+	CanAddFields         *bool          `protobuf:"varint,2,opt,name=can_add_fields,json=canAddFields" json:"can_add_fields,omitempty"`
+	CanDeleteFields      *bool          `protobuf:"varint,3,opt,name=can_delete_fields,json=canDeleteFields" json:"can_delete_fields,omitempty"`
+	AllFieldsMatch       *bool          `protobuf:"varint,4,opt,name=all_fields_match,json=allFieldsMatch,def=1" json:"all_fields_match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PutExecutionTypeRequest) Reset()         { *m = PutExecutionTypeRequest{} }
+func (m *PutExecutionTypeRequest) String() string { return proto.CompactTextString(m) }
+func (*PutExecutionTypeRequest) ProtoMessage()    {}
+func (*PutExecutionTypeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{2}
+}
+
+func (m *PutExecutionTypeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutExecutionTypeRequest.Unmarshal(m, b)
+}
+func (m *PutExecutionTypeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutExecutionTypeRequest.Marshal(b, m, deterministic)
+}
+func (m *PutExecutionTypeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutExecutionTypeRequest.Merge(m, src)
+}
+func (m *PutExecutionTypeRequest) XXX_Size() int {
+	return xxx_messageInfo_PutExecutionTypeRequest.Size(m)
+}
+func (m *PutExecutionTypeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutExecutionTypeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutExecutionTypeRequest proto.InternalMessageInfo
+
+const Default_PutExecutionTypeRequest_AllFieldsMatch bool = true
+
+func (m *PutExecutionTypeRequest) GetExecutionType() *ExecutionType {
+	if m != nil {
+		return m.ExecutionType
+	}
+	return nil
+}
+
+func (m *PutExecutionTypeRequest) GetCanAddFields() bool {
+	if m != nil && m.CanAddFields != nil {
+		return *m.CanAddFields
+	}
+	return false
+}
+
+func (m *PutExecutionTypeRequest) GetCanDeleteFields() bool {
+	if m != nil && m.CanDeleteFields != nil {
+		return *m.CanDeleteFields
+	}
+	return false
+}
+
+func (m *PutExecutionTypeRequest) GetAllFieldsMatch() bool {
+	if m != nil && m.AllFieldsMatch != nil {
+		return *m.AllFieldsMatch
+	}
+	return Default_PutExecutionTypeRequest_AllFieldsMatch
+}
+
+type PutExecutionTypeResponse struct {
+	TypeId               *int64   `protobuf:"varint,1,opt,name=type_id,json=typeId" json:"type_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutExecutionTypeResponse) Reset()         { *m = PutExecutionTypeResponse{} }
+func (m *PutExecutionTypeResponse) String() string { return proto.CompactTextString(m) }
+func (*PutExecutionTypeResponse) ProtoMessage()    {}
+func (*PutExecutionTypeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{3}
+}
+
+func (m *PutExecutionTypeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutExecutionTypeResponse.Unmarshal(m, b)
+}
+func (m *PutExecutionTypeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutExecutionTypeResponse.Marshal(b, m, deterministic)
+}
+func (m *PutExecutionTypeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutExecutionTypeResponse.Merge(m, src)
+}
+func (m *PutExecutionTypeResponse) XXX_Size() int {
+	return xxx_messageInfo_PutExecutionTypeResponse.Size(m)
+}
+func (m *PutExecutionTypeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutExecutionTypeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutExecutionTypeResponse proto.InternalMessageInfo
+
+func (m *PutExecutionTypeResponse) GetTypeId() int64 {
+	if m != nil && m.TypeId != nil {
+		return *m.TypeId
+	}
+	return 0
+}
+
+type PutContextTypeRequest struct {
+	ContextType          *ContextType `protobuf:"bytes,1,opt,name=context_type,json=contextType" json:"context_type,omitempty"`
+	CanAddFields         *bool        `protobuf:"varint,2,opt,name=can_add_fields,json=canAddFields" json:"can_add_fields,omitempty"`
+	CanDeleteFields      *bool        `protobuf:"varint,3,opt,name=can_delete_fields,json=canDeleteFields" json:"can_delete_fields,omitempty"`
+	AllFieldsMatch       *bool        `protobuf:"varint,4,opt,name=all_fields_match,json=allFieldsMatch,def=1" json:"all_fields_match,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PutContextTypeRequest) Reset()         { *m = PutContextTypeRequest{} }
+func (m *PutContextTypeRequest) String() string { return proto.CompactTextString(m) }
+func (*PutContextTypeRequest) ProtoMessage()    {}
+func (*PutContextTypeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{4}
+}
+
+func (m *PutContextTypeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutContextTypeRequest.Unmarshal(m, b)
+}
+func (m *PutContextTypeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutContextTypeRequest.Marshal(b, m, deterministic)
+}
+func (m *PutContextTypeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutContextTypeRequest.Merge(m, src)
+}
+func (m *PutContextTypeRequest) XXX_Size() int {
+	return xxx_messageInfo_PutContextTypeRequest.Size(m)
+}
+func (m *PutContextTypeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutContextTypeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutContextTypeRequest proto.InternalMessageInfo
+
+const Default_PutContextTypeRequest_AllFieldsMatch bool = true
+
+func (m *PutContextTypeRequest) GetContextType() *ContextType {
+	if m != nil {
+		return m.ContextType
+	}
+	return nil
+}
+
+func (m *PutContextTypeRequest) GetCanAddFields() bool {
+	if m != nil && m.CanAddFields != nil {
+		return *m.CanAddFields
+	}
+	return false
+}
+
+func (m *PutContextTypeRequest) GetCanDeleteFields() bool {
+	if m != nil && m.CanDeleteFields != nil {
+		return *m.CanDeleteFields
+	}
+	return false
+}
+
+func (m *PutContextTypeRequest) GetAllFieldsMatch() bool {
+	if m != nil && m.AllFieldsMatch != nil {
+		return *m.AllFieldsMatch
+	}
+	return Default_PutContextTypeRequest_AllFieldsMatch
+}
+
+type PutContextTypeResponse struct {
+	TypeId               *int64   `protobuf:"varint,1,opt,name=type_id,json=typeId" json:"type_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutContextTypeResponse) Reset()         { *m = PutContextTypeResponse{} }
+func (m *PutContextTypeResponse) String() string { return proto.CompactTextString(m) }
+func (*PutContextTypeResponse) ProtoMessage()    {}
+func (*PutContextTypeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{5}
+}
+
+func (m *PutContextTypeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutContextTypeResponse.Unmarshal(m, b)
+}
+func (m *PutContextTypeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutContextTypeResponse.Marshal(b, m, deterministic)
+}
+func (m *PutContextTypeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutContextTypeResponse.Merge(m, src)
+}
+func (m *PutContextTypeResponse) XXX_Size() int {
+	return xxx_messageInfo_PutContextTypeResponse.Size(m)
+}
+func (m *PutContextTypeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutContextTypeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutContextTypeResponse proto.InternalMessageInfo
+
+func (m *PutContextTypeResponse) GetTypeId() int64 {
+	if m != nil && m.TypeId != nil {
+		return *m.TypeId
+	}
+	return 0
+}
+
+type PutArtifactsRequest struct {
+	Artifacts            []*Artifact `protobuf:"bytes,1,rep,name=artifacts" json:"artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PutArtifactsRequest) Reset()         { *m = PutArtifactsRequest{} }
+func (m *PutArtifactsRequest) String() string { return proto.CompactTextString(m) }
+func (*PutArtifactsRequest) ProtoMessage()    {}
+func (*PutArtifactsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{6}
+}
+
+func (m *PutArtifactsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutArtifactsRequest.Unmarshal(m, b)
+}
+func (m *PutArtifactsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutArtifactsRequest.Marshal(b, m, deterministic)
+}
+func (m *PutArtifactsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutArtifactsRequest.Merge(m, src)
+}
+func (m *PutArtifactsRequest) XXX_Size() int {
+	return xxx_messageInfo_PutArtifactsRequest.Size(m)
+}
+func (m *PutArtifactsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutArtifactsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutArtifactsRequest proto.InternalMessageInfo
+
+func (m *PutArtifactsRequest) GetArtifacts() []*Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+type PutArtifactsResponse struct {
+	// The ids of the artifacts, in the order of the request.
+	ArtifactIds          []int64  `protobuf:"varint,1,rep,name=artifact_ids,json=artifactIds" json:"artifact_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutArtifactsResponse) Reset()         { *m = PutArtifactsResponse{} }
+func (m *PutArtifactsResponse) String() string { return proto.CompactTextString(m) }
+func (*PutArtifactsResponse) ProtoMessage()    {}
+func (*PutArtifactsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{7}
+}
+
+func (m *PutArtifactsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutArtifactsResponse.Unmarshal(m, b)
+}
+func (m *PutArtifactsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutArtifactsResponse.Marshal(b, m, deterministic)
+}
+func (m *PutArtifactsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutArtifactsResponse.Merge(m, src)
+}
+func (m *PutArtifactsResponse) XXX_Size() int {
+	return xxx_messageInfo_PutArtifactsResponse.Size(m)
+}
+func (m *PutArtifactsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutArtifactsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutArtifactsResponse proto.InternalMessageInfo
+
+func (m *PutArtifactsResponse) GetArtifactIds() []int64 {
+	if m != nil {
+		return m.ArtifactIds
+	}
+	return nil
+}
+
+type PutExecutionsRequest struct {
+	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PutExecutionsRequest) Reset()         { *m = PutExecutionsRequest{} }
+func (m *PutExecutionsRequest) String() string { return proto.CompactTextString(m) }
+func (*PutExecutionsRequest) ProtoMessage()    {}
+func (*PutExecutionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{8}
+}
+
+func (m *PutExecutionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutExecutionsRequest.Unmarshal(m, b)
+}
+func (m *PutExecutionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutExecutionsRequest.Marshal(b, m, deterministic)
+}
+func (m *PutExecutionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutExecutionsRequest.Merge(m, src)
+}
+func (m *PutExecutionsRequest) XXX_Size() int {
+	return xxx_messageInfo_PutExecutionsRequest.Size(m)
+}
+func (m *PutExecutionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutExecutionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutExecutionsRequest proto.InternalMessageInfo
+
+func (m *PutExecutionsRequest) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type PutExecutionsResponse struct {
+	// The ids of the executions, in the order of the request.
+	ExecutionIds         []int64  `protobuf:"varint,1,rep,name=execution_ids,json=executionIds" json:"execution_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutExecutionsResponse) Reset()         { *m = PutExecutionsResponse{} }
+func (m *PutExecutionsResponse) String() string { return proto.CompactTextString(m) }
+func (*PutExecutionsResponse) ProtoMessage()    {}
+func (*PutExecutionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{9}
+}
+
+func (m *PutExecutionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutExecutionsResponse.Unmarshal(m, b)
+}
+func (m *PutExecutionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutExecutionsResponse.Marshal(b, m, deterministic)
+}
+func (m *PutExecutionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutExecutionsResponse.Merge(m, src)
+}
+func (m *PutExecutionsResponse) XXX_Size() int {
+	return xxx_messageInfo_PutExecutionsResponse.Size(m)
+}
+func (m *PutExecutionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutExecutionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutExecutionsResponse proto.InternalMessageInfo
+
+func (m *PutExecutionsResponse) GetExecutionIds() []int64 {
+	if m != nil {
+		return m.ExecutionIds
+	}
+	return nil
+}
+
+type PutEventsRequest struct {
+	Events               []*Event `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutEventsRequest) Reset()         { *m = PutEventsRequest{} }
+func (m *PutEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*PutEventsRequest) ProtoMessage()    {}
+func (*PutEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{10}
+}
+
+func (m *PutEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutEventsRequest.Unmarshal(m, b)
+}
+func (m *PutEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *PutEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutEventsRequest.Merge(m, src)
+}
+func (m *PutEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_PutEventsRequest.Size(m)
+}
+func (m *PutEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutEventsRequest proto.InternalMessageInfo
+
+func (m *PutEventsRequest) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type PutEventsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutEventsResponse) Reset()         { *m = PutEventsResponse{} }
+func (m *PutEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*PutEventsResponse) ProtoMessage()    {}
+func (*PutEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{11}
+}
+
+func (m *PutEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutEventsResponse.Unmarshal(m, b)
+}
+func (m *PutEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *PutEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutEventsResponse.Merge(m, src)
+}
+func (m *PutEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_PutEventsResponse.Size(m)
+}
+func (m *PutEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutEventsResponse proto.InternalMessageInfo
+
+type PutContextsRequest struct {
+	Contexts             []*Context `protobuf:"bytes,1,rep,name=contexts" json:"contexts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PutContextsRequest) Reset()         { *m = PutContextsRequest{} }
+func (m *PutContextsRequest) String() string { return proto.CompactTextString(m) }
+func (*PutContextsRequest) ProtoMessage()    {}
+func (*PutContextsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{12}
+}
+
+func (m *PutContextsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutContextsRequest.Unmarshal(m, b)
+}
+func (m *PutContextsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutContextsRequest.Marshal(b, m, deterministic)
+}
+func (m *PutContextsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutContextsRequest.Merge(m, src)
+}
+func (m *PutContextsRequest) XXX_Size() int {
+	return xxx_messageInfo_PutContextsRequest.Size(m)
+}
+func (m *PutContextsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutContextsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutContextsRequest proto.InternalMessageInfo
+
+func (m *PutContextsRequest) GetContexts() []*Context {
+	if m != nil {
+		return m.Contexts
+	}
+	return nil
+}
+
+type PutContextsResponse struct {
+	// The ids of the contexts, in the order of the request.
+	ContextIds           []int64  `protobuf:"varint,1,rep,name=context_ids,json=contextIds" json:"context_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutContextsResponse) Reset()         { *m = PutContextsResponse{} }
+func (m *PutContextsResponse) String() string { return proto.CompactTextString(m) }
+func (*PutContextsResponse) ProtoMessage()    {}
+func (*PutContextsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{13}
+}
+
+func (m *PutContextsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutContextsResponse.Unmarshal(m, b)
+}
+func (m *PutContextsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutContextsResponse.Marshal(b, m, deterministic)
+}
+func (m *PutContextsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutContextsResponse.Merge(m, src)
+}
+func (m *PutContextsResponse) XXX_Size() int {
+	return xxx_messageInfo_PutContextsResponse.Size(m)
+}
+func (m *PutContextsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutContextsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutContextsResponse proto.InternalMessageInfo
+
+func (m *PutContextsResponse) GetContextIds() []int64 {
+	if m != nil {
+		return m.ContextIds
+	}
+	return nil
+}
+
+type PutAttributionsAndAssociationsRequest struct {
+	Attributions         []*Attribution `protobuf:"bytes,1,rep,name=attributions" json:"attributions,omitempty"`
+	Associations         []*Association `protobuf:"bytes,2,rep,name=associations" json:"associations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PutAttributionsAndAssociationsRequest) Reset()         { *m = PutAttributionsAndAssociationsRequest{} }
+func (m *PutAttributionsAndAssociationsRequest) String() string { return proto.CompactTextString(m) }
+func (*PutAttributionsAndAssociationsRequest) ProtoMessage()    {}
+func (*PutAttributionsAndAssociationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{14}
+}
+
+func (m *PutAttributionsAndAssociationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutAttributionsAndAssociationsRequest.Unmarshal(m, b)
+}
+func (m *PutAttributionsAndAssociationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutAttributionsAndAssociationsRequest.Marshal(b, m, deterministic)
+}
+func (m *PutAttributionsAndAssociationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutAttributionsAndAssociationsRequest.Merge(m, src)
+}
+func (m *PutAttributionsAndAssociationsRequest) XXX_Size() int {
+	return xxx_messageInfo_PutAttributionsAndAssociationsRequest.Size(m)
+}
+func (m *PutAttributionsAndAssociationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutAttributionsAndAssociationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutAttributionsAndAssociationsRequest proto.InternalMessageInfo
+
+func (m *PutAttributionsAndAssociationsRequest) GetAttributions() []*Attribution {
+	if m != nil {
+		return m.Attributions
+	}
+	return nil
+}
+
+func (m *PutAttributionsAndAssociationsRequest) GetAssociations() []*Association {
+	if m != nil {
+		return m.Associations
+	}
+	return nil
+}
+
+type PutAttributionsAndAssociationsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutAttributionsAndAssociationsResponse) Reset() {
+	*m = PutAttributionsAndAssociationsResponse{}
+}
+func (m *PutAttributionsAndAssociationsResponse) String() string { return proto.CompactTextString(m) }
+func (*PutAttributionsAndAssociationsResponse) ProtoMessage()    {}
+func (*PutAttributionsAndAssociationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{15}
+}
+
+func (m *PutAttributionsAndAssociationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutAttributionsAndAssociationsResponse.Unmarshal(m, b)
+}
+func (m *PutAttributionsAndAssociationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutAttributionsAndAssociationsResponse.Marshal(b, m, deterministic)
+}
+func (m *PutAttributionsAndAssociationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutAttributionsAndAssociationsResponse.Merge(m, src)
+}
+func (m *PutAttributionsAndAssociationsResponse) XXX_Size() int {
+	return xxx_messageInfo_PutAttributionsAndAssociationsResponse.Size(m)
+}
+func (m *PutAttributionsAndAssociationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutAttributionsAndAssociationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutAttributionsAndAssociationsResponse proto.InternalMessageInfo
+
+type GetContextByTypeAndNameRequest struct {
+	TypeName             *string  `protobuf:"bytes,1,opt,name=type_name,json=typeName" json:"type_name,omitempty"`
+	ContextName          *string  `protobuf:"bytes,2,opt,name=context_name,json=contextName" json:"context_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetContextByTypeAndNameRequest) Reset()         { *m = GetContextByTypeAndNameRequest{} }
+func (m *GetContextByTypeAndNameRequest) String() string { return proto.CompactTextString(m) }
+func (*GetContextByTypeAndNameRequest) ProtoMessage()    {}
+func (*GetContextByTypeAndNameRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{16}
+}
+
+func (m *GetContextByTypeAndNameRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetContextByTypeAndNameRequest.Unmarshal(m, b)
+}
+func (m *GetContextByTypeAndNameRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetContextByTypeAndNameRequest.Marshal(b, m, deterministic)
+}
+func (m *GetContextByTypeAndNameRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetContextByTypeAndNameRequest.Merge(m, src)
+}
+func (m *GetContextByTypeAndNameRequest) XXX_Size() int {
+	return xxx_messageInfo_GetContextByTypeAndNameRequest.Size(m)
+}
+func (m *GetContextByTypeAndNameRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetContextByTypeAndNameRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetContextByTypeAndNameRequest proto.InternalMessageInfo
+
+func (m *GetContextByTypeAndNameRequest) GetTypeName() string {
+	if m != nil && m.TypeName != nil {
+		return *m.TypeName
+	}
+	return ""
+}
+
+func (m *GetContextByTypeAndNameRequest) GetContextName() string {
+	if m != nil && m.ContextName != nil {
+		return *m.ContextName
+	}
+	return ""
+}
+
+type GetContextByTypeAndNameResponse struct {
+	// Unset if the context doesn't exist.
+	Context              *Context `protobuf:"bytes,1,opt,name=context" json:"context,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetContextByTypeAndNameResponse) Reset()         { *m = GetContextByTypeAndNameResponse{} }
+func (m *GetContextByTypeAndNameResponse) String() string { return proto.CompactTextString(m) }
+func (*GetContextByTypeAndNameResponse) ProtoMessage()    {}
+func (*GetContextByTypeAndNameResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{17}
+}
+
+func (m *GetContextByTypeAndNameResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetContextByTypeAndNameResponse.Unmarshal(m, b)
+}
+func (m *GetContextByTypeAndNameResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetContextByTypeAndNameResponse.Marshal(b, m, deterministic)
+}
+func (m *GetContextByTypeAndNameResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetContextByTypeAndNameResponse.Merge(m, src)
+}
+func (m *GetContextByTypeAndNameResponse) XXX_Size() int {
+	return xxx_messageInfo_GetContextByTypeAndNameResponse.Size(m)
+}
+func (m *GetContextByTypeAndNameResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetContextByTypeAndNameResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetContextByTypeAndNameResponse proto.InternalMessageInfo
+
+func (m *GetContextByTypeAndNameResponse) GetContext() *Context {
+	if m != nil {
+		return m.Context
+	}
+	return nil
+}
+
+type GetArtifactsByContextRequest struct {
+	ContextId            *int64   `protobuf:"varint,1,opt,name=context_id,json=contextId" json:"context_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetArtifactsByContextRequest) Reset()         { *m = GetArtifactsByContextRequest{} }
+func (m *GetArtifactsByContextRequest) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactsByContextRequest) ProtoMessage()    {}
+func (*GetArtifactsByContextRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{18}
+}
+
+func (m *GetArtifactsByContextRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactsByContextRequest.Unmarshal(m, b)
+}
+func (m *GetArtifactsByContextRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactsByContextRequest.Marshal(b, m, deterministic)
+}
+func (m *GetArtifactsByContextRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactsByContextRequest.Merge(m, src)
+}
+func (m *GetArtifactsByContextRequest) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactsByContextRequest.Size(m)
+}
+func (m *GetArtifactsByContextRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactsByContextRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArtifactsByContextRequest proto.InternalMessageInfo
+
+func (m *GetArtifactsByContextRequest) GetContextId() int64 {
+	if m != nil && m.ContextId != nil {
+		return *m.ContextId
+	}
+	return 0
+}
+
+type GetArtifactsByContextResponse struct {
+	Artifacts            []*Artifact `protobuf:"bytes,1,rep,name=artifacts" json:"artifacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetArtifactsByContextResponse) Reset()         { *m = GetArtifactsByContextResponse{} }
+func (m *GetArtifactsByContextResponse) String() string { return proto.CompactTextString(m) }
+func (*GetArtifactsByContextResponse) ProtoMessage()    {}
+func (*GetArtifactsByContextResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{19}
+}
+
+func (m *GetArtifactsByContextResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetArtifactsByContextResponse.Unmarshal(m, b)
+}
+func (m *GetArtifactsByContextResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetArtifactsByContextResponse.Marshal(b, m, deterministic)
+}
+func (m *GetArtifactsByContextResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetArtifactsByContextResponse.Merge(m, src)
+}
+func (m *GetArtifactsByContextResponse) XXX_Size() int {
+	return xxx_messageInfo_GetArtifactsByContextResponse.Size(m)
+}
+func (m *GetArtifactsByContextResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetArtifactsByContextResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetArtifactsByContextResponse proto.InternalMessageInfo
+
+func (m *GetArtifactsByContextResponse) GetArtifacts() []*Artifact {
+	if m != nil {
+		return m.Artifacts
+	}
+	return nil
+}
+
+type GetExecutionsByContextRequest struct {
+	ContextId            *int64   `protobuf:"varint,1,opt,name=context_id,json=contextId" json:"context_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetExecutionsByContextRequest) Reset()         { *m = GetExecutionsByContextRequest{} }
+func (m *GetExecutionsByContextRequest) String() string { return proto.CompactTextString(m) }
+func (*GetExecutionsByContextRequest) ProtoMessage()    {}
+func (*GetExecutionsByContextRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{20}
+}
+
+func (m *GetExecutionsByContextRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExecutionsByContextRequest.Unmarshal(m, b)
+}
+func (m *GetExecutionsByContextRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExecutionsByContextRequest.Marshal(b, m, deterministic)
+}
+func (m *GetExecutionsByContextRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExecutionsByContextRequest.Merge(m, src)
+}
+func (m *GetExecutionsByContextRequest) XXX_Size() int {
+	return xxx_messageInfo_GetExecutionsByContextRequest.Size(m)
+}
+func (m *GetExecutionsByContextRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExecutionsByContextRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExecutionsByContextRequest proto.InternalMessageInfo
+
+func (m *GetExecutionsByContextRequest) GetContextId() int64 {
+	if m != nil && m.ContextId != nil {
+		return *m.ContextId
+	}
+	return 0
+}
+
+type GetExecutionsByContextResponse struct {
+	Executions           []*Execution `protobuf:"bytes,1,rep,name=executions" json:"executions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *GetExecutionsByContextResponse) Reset()         { *m = GetExecutionsByContextResponse{} }
+func (m *GetExecutionsByContextResponse) String() string { return proto.CompactTextString(m) }
+func (*GetExecutionsByContextResponse) ProtoMessage()    {}
+func (*GetExecutionsByContextResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{21}
+}
+
+func (m *GetExecutionsByContextResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetExecutionsByContextResponse.Unmarshal(m, b)
+}
+func (m *GetExecutionsByContextResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetExecutionsByContextResponse.Marshal(b, m, deterministic)
+}
+func (m *GetExecutionsByContextResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetExecutionsByContextResponse.Merge(m, src)
+}
+func (m *GetExecutionsByContextResponse) XXX_Size() int {
+	return xxx_messageInfo_GetExecutionsByContextResponse.Size(m)
+}
+func (m *GetExecutionsByContextResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetExecutionsByContextResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetExecutionsByContextResponse proto.InternalMessageInfo
+
+func (m *GetExecutionsByContextResponse) GetExecutions() []*Execution {
+	if m != nil {
+		return m.Executions
+	}
+	return nil
+}
+
+type GetEventsByExecutionIDsRequest struct {
+	ExecutionIds         []int64  `protobuf:"varint,1,rep,name=execution_ids,json=executionIds" json:"execution_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEventsByExecutionIDsRequest) Reset()         { *m = GetEventsByExecutionIDsRequest{} }
+func (m *GetEventsByExecutionIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetEventsByExecutionIDsRequest) ProtoMessage()    {}
+func (*GetEventsByExecutionIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{22}
+}
+
+func (m *GetEventsByExecutionIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEventsByExecutionIDsRequest.Unmarshal(m, b)
+}
+func (m *GetEventsByExecutionIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEventsByExecutionIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetEventsByExecutionIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEventsByExecutionIDsRequest.Merge(m, src)
+}
+func (m *GetEventsByExecutionIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetEventsByExecutionIDsRequest.Size(m)
+}
+func (m *GetEventsByExecutionIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEventsByExecutionIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEventsByExecutionIDsRequest proto.InternalMessageInfo
+
+func (m *GetEventsByExecutionIDsRequest) GetExecutionIds() []int64 {
+	if m != nil {
+		return m.ExecutionIds
+	}
+	return nil
+}
+
+type GetEventsByExecutionIDsResponse struct {
+	Events               []*Event `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetEventsByExecutionIDsResponse) Reset()         { *m = GetEventsByExecutionIDsResponse{} }
+func (m *GetEventsByExecutionIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetEventsByExecutionIDsResponse) ProtoMessage()    {}
+func (*GetEventsByExecutionIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_213a3bbc78fe9ccb, []int{23}
+}
+
+func (m *GetEventsByExecutionIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetEventsByExecutionIDsResponse.Unmarshal(m, b)
+}
+func (m *GetEventsByExecutionIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetEventsByExecutionIDsResponse.Marshal(b, m, deterministic)
+}
+func (m *GetEventsByExecutionIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetEventsByExecutionIDsResponse.Merge(m, src)
+}
+func (m *GetEventsByExecutionIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_GetEventsByExecutionIDsResponse.Size(m)
+}
+func (m *GetEventsByExecutionIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetEventsByExecutionIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetEventsByExecutionIDsResponse proto.InternalMessageInfo
+
+func (m *GetEventsByExecutionIDsResponse) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PutArtifactTypeRequest)(nil), "ml_metadata.PutArtifactTypeRequest")
+	proto.RegisterType((*PutArtifactTypeResponse)(nil), "ml_metadata.PutArtifactTypeResponse")
+	proto.RegisterType((*PutExecutionTypeRequest)(nil), "ml_metadata.PutExecutionTypeRequest")
+	proto.RegisterType((*PutExecutionTypeResponse)(nil), "ml_metadata.PutExecutionTypeResponse")
+	proto.RegisterType((*PutContextTypeRequest)(nil), "ml_metadata.PutContextTypeRequest")
+	proto.RegisterType((*PutContextTypeResponse)(nil), "ml_metadata.PutContextTypeResponse")
+	proto.RegisterType((*PutArtifactsRequest)(nil), "ml_metadata.PutArtifactsRequest")
+	proto.RegisterType((*PutArtifactsResponse)(nil), "ml_metadata.PutArtifactsResponse")
+	proto.RegisterType((*PutExecutionsRequest)(nil), "ml_metadata.PutExecutionsRequest")
+	proto.RegisterType((*PutExecutionsResponse)(nil), "ml_metadata.PutExecutionsResponse")
+	proto.RegisterType((*PutEventsRequest)(nil), "ml_metadata.PutEventsRequest")
+	proto.RegisterType((*PutEventsResponse)(nil), "ml_metadata.PutEventsResponse")
+	proto.RegisterType((*PutContextsRequest)(nil), "ml_metadata.PutContextsRequest")
+	proto.RegisterType((*PutContextsResponse)(nil), "ml_metadata.PutContextsResponse")
+	proto.RegisterType((*PutAttributionsAndAssociationsRequest)(nil), "ml_metadata.PutAttributionsAndAssociationsRequest")
+	proto.RegisterType((*PutAttributionsAndAssociationsResponse)(nil), "ml_metadata.PutAttributionsAndAssociationsResponse")
+	proto.RegisterType((*GetContextByTypeAndNameRequest)(nil), "ml_metadata.GetContextByTypeAndNameRequest")
+	proto.RegisterType((*GetContextByTypeAndNameResponse)(nil), "ml_metadata.GetContextByTypeAndNameResponse")
+	proto.RegisterType((*GetArtifactsByContextRequest)(nil), "ml_metadata.GetArtifactsByContextRequest")
+	proto.RegisterType((*GetArtifactsByContextResponse)(nil), "ml_metadata.GetArtifactsByContextResponse")
+	proto.RegisterType((*GetExecutionsByContextRequest)(nil), "ml_metadata.GetExecutionsByContextRequest")
+	proto.RegisterType((*GetExecutionsByContextResponse)(nil), "ml_metadata.GetExecutionsByContextResponse")
+	proto.RegisterType((*GetEventsByExecutionIDsRequest)(nil), "ml_metadata.GetEventsByExecutionIDsRequest")
+	proto.RegisterType((*GetEventsByExecutionIDsResponse)(nil), "ml_metadata.GetEventsByExecutionIDsResponse")
+}
+
+func init() {
+	proto.RegisterFile("ml_metadata/proto/metadata_store_service.proto", fileDescriptor_213a3bbc78fe9ccb)
+}
+
+var fileDescriptor_213a3bbc78fe9ccb = []byte{
+	// 922 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x57, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0x66, 0xe3, 0xaa, 0x8d, 0x8f, 0x7f, 0xda, 0x4e, 0x9d, 0xc4, 0x2c, 0xc4, 0x76, 0x36, 0x6d,
+	0x65, 0xd2, 0xca, 0x05, 0x47, 0xaa, 0x04, 0x94, 0x48, 0x36, 0x6d, 0xa2, 0x20, 0x12, 0x85, 0x4d,
+	0x90, 0x22, 0x90, 0x58, 0x86, 0xdd, 0x89, 0x58, 0xc9, 0xde, 0x35, 0xde, 0x71, 0x14, 0x3f, 0x03,
+	0x8f, 0xc1, 0x3b, 0x71, 0xc1, 0x05, 0x8f, 0xc0, 0x33, 0xa0, 0x9d, 0x9d, 0x99, 0x9d, 0xb1, 0x77,
+	0x6d, 0x27, 0x57, 0xb9, 0xdc, 0x33, 0xdf, 0xf9, 0xe6, 0x9c, 0xef, 0xfc, 0x8c, 0x0d, 0x9d, 0xe1,
+	0xc0, 0x19, 0x12, 0x8a, 0x3d, 0x4c, 0xf1, 0x9b, 0xd1, 0x38, 0xa4, 0xe1, 0x1b, 0xf1, 0xe9, 0x44,
+	0x34, 0x1c, 0x13, 0x27, 0x22, 0xe3, 0x6b, 0xdf, 0x25, 0x1d, 0x76, 0x88, 0x4a, 0x0a, 0xde, 0x7c,
+	0xb9, 0xcc, 0x39, 0x71, 0xb2, 0xfe, 0x31, 0x60, 0xf3, 0x6c, 0x42, 0x7b, 0x63, 0xea, 0x5f, 0x61,
+	0x97, 0x5e, 0x4c, 0x47, 0xc4, 0x26, 0x7f, 0x4c, 0x48, 0x44, 0xd1, 0x01, 0x54, 0x30, 0x37, 0x3b,
+	0x74, 0x3a, 0x22, 0x75, 0xa3, 0x65, 0xb4, 0x4b, 0xdd, 0x8f, 0xd5, 0xb8, 0x3a, 0x9a, 0x63, 0x19,
+	0x2b, 0x5f, 0xe8, 0x39, 0x54, 0x5d, 0x1c, 0x38, 0xd8, 0xf3, 0x9c, 0x2b, 0x9f, 0x0c, 0xbc, 0xa8,
+	0xbe, 0xd6, 0x32, 0xda, 0xeb, 0x76, 0xd9, 0xc5, 0x41, 0xcf, 0xf3, 0x0e, 0x99, 0x0d, 0xed, 0xc1,
+	0xd3, 0x18, 0xe5, 0x91, 0x01, 0xa1, 0x44, 0x00, 0x0b, 0x0c, 0xf8, 0xd8, 0xc5, 0xc1, 0x7b, 0x66,
+	0xe7, 0xd8, 0x0e, 0x3c, 0xc1, 0x83, 0x01, 0x07, 0x39, 0x43, 0x4c, 0xdd, 0xdf, 0xeb, 0x0f, 0x62,
+	0xe8, 0x57, 0x0f, 0xe8, 0x78, 0x42, 0xec, 0x2a, 0x1e, 0x0c, 0x12, 0xe8, 0x49, 0x7c, 0x66, 0x75,
+	0x61, 0x6b, 0x2e, 0xb7, 0x68, 0x14, 0x06, 0x11, 0x41, 0x5b, 0xf0, 0x28, 0xce, 0xc9, 0xf1, 0x3d,
+	0x96, 0x56, 0xc1, 0x7e, 0x18, 0x7f, 0x1e, 0x7b, 0xd6, 0xbf, 0x06, 0x73, 0xfa, 0x70, 0x43, 0xdc,
+	0x09, 0xf5, 0xc3, 0x40, 0x55, 0xa4, 0x07, 0x55, 0x22, 0xec, 0xaa, 0x24, 0xa6, 0x26, 0x89, 0xee,
+	0x5a, 0x21, 0xea, 0xe7, 0x3d, 0x10, 0x65, 0x1f, 0xea, 0xf3, 0xf9, 0x2d, 0x53, 0xe5, 0x6f, 0x03,
+	0x36, 0xce, 0x26, 0xf4, 0xdb, 0x30, 0xa0, 0xe4, 0x46, 0xeb, 0x92, 0xaf, 0xa1, 0xec, 0x26, 0x56,
+	0x55, 0x91, 0xba, 0xa6, 0x88, 0xea, 0x56, 0x72, 0xd3, 0x8f, 0x7b, 0xa0, 0xc6, 0x17, 0xac, 0xfd,
+	0xb5, 0xbc, 0x96, 0x69, 0xf1, 0x1d, 0x3c, 0x53, 0xba, 0x2a, 0x12, 0x42, 0xec, 0x43, 0x51, 0xb4,
+	0x7f, 0x54, 0x37, 0x5a, 0x85, 0x76, 0xa9, 0xbb, 0x91, 0x39, 0x2a, 0x76, 0x8a, 0xb3, 0xbe, 0x84,
+	0x9a, 0xce, 0xc5, 0x2f, 0xdf, 0x01, 0x39, 0x4b, 0x8e, 0xef, 0x25, 0x7c, 0x05, 0xbb, 0x24, 0x6c,
+	0xc7, 0x5e, 0x64, 0x9d, 0x42, 0x4d, 0xad, 0xa3, 0x8c, 0xe3, 0x2d, 0x80, 0x6c, 0x39, 0x11, 0xc8,
+	0x66, 0x76, 0x83, 0xda, 0x0a, 0xd2, 0x7a, 0xc7, 0x2a, 0xac, 0xf2, 0xf1, 0x58, 0x76, 0x21, 0xed,
+	0x61, 0x25, 0x98, 0xb2, 0x34, 0xc6, 0xd1, 0x1c, 0xc0, 0x93, 0xd8, 0xfb, 0x9a, 0x04, 0xa9, 0x22,
+	0x7b, 0xf0, 0x90, 0x30, 0x03, 0x8f, 0x02, 0xe9, 0x51, 0xc4, 0x47, 0x36, 0x47, 0x58, 0xcf, 0xe0,
+	0xa9, 0xe2, 0x9f, 0xdc, 0x6c, 0x1d, 0x02, 0x4a, 0x8b, 0x23, 0x69, 0x3f, 0x87, 0x75, 0xde, 0x43,
+	0x82, 0xb8, 0x96, 0xd5, 0x6d, 0xb6, 0x44, 0x59, 0x6f, 0x59, 0xc5, 0x52, 0x1e, 0x9e, 0x58, 0x13,
+	0x44, 0x33, 0x2a, 0x69, 0x01, 0x37, 0xc5, 0x49, 0xfd, 0x65, 0xc0, 0x8b, 0xb8, 0x3c, 0x94, 0x8e,
+	0xfd, 0xdf, 0x12, 0x55, 0x7a, 0x81, 0xd7, 0x8b, 0xa2, 0xd0, 0xf5, 0xb1, 0x26, 0xfa, 0x3b, 0x28,
+	0x63, 0x05, 0xc5, 0xe3, 0xd2, 0xa7, 0x40, 0xa1, 0xb1, 0x35, 0x34, 0xf3, 0x56, 0x48, 0xeb, 0x6b,
+	0x59, 0xde, 0x29, 0xc0, 0xd6, 0xd0, 0x56, 0x1b, 0x5e, 0x2e, 0x0b, 0x92, 0xeb, 0xf9, 0x2b, 0x34,
+	0x8e, 0x88, 0xd0, 0xa1, 0x3f, 0x8d, 0xdb, 0xbd, 0x17, 0x78, 0xa7, 0x78, 0x28, 0xa7, 0xf9, 0x13,
+	0x28, 0xb2, 0xa6, 0x0f, 0xf0, 0x30, 0x19, 0xe5, 0xa2, 0xbd, 0x1e, 0x1b, 0x62, 0x4c, 0xdc, 0x94,
+	0x42, 0x2f, 0x76, 0xbe, 0xc6, 0xce, 0x85, 0x86, 0x31, 0xc4, 0xfa, 0x01, 0x9a, 0xb9, 0x37, 0x70,
+	0xd5, 0x3b, 0xf0, 0x88, 0x7b, 0xf0, 0x5d, 0x91, 0x5d, 0x3d, 0x01, 0xb2, 0xbe, 0x81, 0x4f, 0x8f,
+	0x48, 0x3a, 0x22, 0xfd, 0xa9, 0x40, 0xf0, 0x90, 0xb7, 0x01, 0xd2, 0x2a, 0xf2, 0x51, 0x2d, 0xca,
+	0x22, 0x5a, 0x17, 0xb0, 0x9d, 0xe3, 0xce, 0xe3, 0xb9, 0xd3, 0xdc, 0x1e, 0x30, 0xd6, 0x74, 0x58,
+	0x6e, 0x1b, 0xd5, 0x25, 0x34, 0xf2, 0xfc, 0x79, 0x58, 0x77, 0x1d, 0xe3, 0x0f, 0x09, 0x33, 0x1b,
+	0xa4, 0xfe, 0x54, 0x62, 0x8e, 0xdf, 0xcb, 0x5e, 0x5d, 0x69, 0x9e, 0x4f, 0xa0, 0x99, 0x4b, 0xc3,
+	0x23, 0xbc, 0xc5, 0x78, 0x77, 0xff, 0x2b, 0x42, 0xed, 0x84, 0x1f, 0x9d, 0xd3, 0x70, 0x4c, 0xce,
+	0x93, 0x9f, 0x2e, 0xe8, 0x17, 0x78, 0x3c, 0xf3, 0x44, 0xa3, 0x5d, 0x8d, 0x27, 0xfb, 0xc7, 0x89,
+	0xf9, 0x7c, 0x31, 0x88, 0x37, 0xfc, 0x47, 0x08, 0x27, 0x7b, 0x49, 0x7f, 0x83, 0x67, 0x7d, 0xb3,
+	0x1e, 0x7b, 0xf3, 0xc5, 0x12, 0x94, 0xbc, 0xe2, 0x67, 0xa8, 0xea, 0x4f, 0x08, 0xb2, 0x66, 0x5d,
+	0xe7, 0xdf, 0x4d, 0x73, 0x77, 0x21, 0x46, 0x92, 0xff, 0x08, 0x65, 0xf5, 0x81, 0x40, 0xad, 0xbc,
+	0xbc, 0x45, 0x79, 0xcd, 0x9d, 0x05, 0x08, 0x49, 0x7b, 0x09, 0x15, 0x6d, 0xd9, 0xa3, 0x9d, 0xdc,
+	0x6c, 0x25, 0xb1, 0xb5, 0x08, 0x22, 0x99, 0xbf, 0x87, 0xa2, 0x5c, 0xe4, 0x68, 0x7b, 0xce, 0x45,
+	0x7d, 0x20, 0xcc, 0x46, 0xde, 0xb1, 0x64, 0xb3, 0xa1, 0xa4, 0x6c, 0x6e, 0xd4, 0xcc, 0x11, 0x4d,
+	0x32, 0xb6, 0xf2, 0x01, 0x92, 0xf3, 0x4f, 0x03, 0x1a, 0x8b, 0x17, 0x26, 0xea, 0xce, 0x69, 0xb8,
+	0xf4, 0x09, 0x30, 0xf7, 0x6f, 0xe5, 0x23, 0xa3, 0xb9, 0x86, 0xad, 0x9c, 0x8d, 0x89, 0x5e, 0x69,
+	0x8c, 0x8b, 0x37, 0xb7, 0xf9, 0x7a, 0x35, 0xb0, 0xbc, 0x77, 0x04, 0x1b, 0x99, 0x7b, 0x11, 0x7d,
+	0x36, 0x4b, 0x94, 0xbb, 0x7a, 0xcd, 0xbd, 0x55, 0xa0, 0xf2, 0xc6, 0x08, 0x36, 0xb3, 0x77, 0x1e,
+	0x9a, 0xe3, 0xc9, 0x5f, 0xac, 0xe6, 0xab, 0x95, 0xb0, 0x33, 0xf2, 0x66, 0xed, 0xb1, 0x79, 0x79,
+	0x17, 0x2c, 0x4d, 0xf3, 0xf5, 0x6a, 0x60, 0x71, 0x6f, 0xbf, 0xf2, 0x93, 0xfa, 0x77, 0xec, 0xff,
+	0x01, 0x00, 0xe8, 0x21, 0xc0, 0xf6, 0xcc, 0x0d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MetadataStoreServiceClient is the client API for MetadataStoreService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MetadataStoreServiceClient interface {
+	// Creates a type, or returns the id of the existing type of the same name.
+	PutArtifactType(ctx context.Context, in *PutArtifactTypeRequest, opts ...grpc.CallOption) (*PutArtifactTypeResponse, error)
+	PutExecutionType(ctx context.Context, in *PutExecutionTypeRequest, opts ...grpc.CallOption) (*PutExecutionTypeResponse, error)
+	PutContextType(ctx context.Context, in *PutContextTypeRequest, opts ...grpc.CallOption) (*PutContextTypeResponse, error)
+	// Creates the artifacts without an id, and updates the others.
+	PutArtifacts(ctx context.Context, in *PutArtifactsRequest, opts ...grpc.CallOption) (*PutArtifactsResponse, error)
+	// Creates the executions without an id, and updates the others.
+	PutExecutions(ctx context.Context, in *PutExecutionsRequest, opts ...grpc.CallOption) (*PutExecutionsResponse, error)
+	PutEvents(ctx context.Context, in *PutEventsRequest, opts ...grpc.CallOption) (*PutEventsResponse, error)
+	// Creates the contexts without an id, and updates the others.
+	PutContexts(ctx context.Context, in *PutContextsRequest, opts ...grpc.CallOption) (*PutContextsResponse, error)
+	PutAttributionsAndAssociations(ctx context.Context, in *PutAttributionsAndAssociationsRequest, opts ...grpc.CallOption) (*PutAttributionsAndAssociationsResponse, error)
+	GetContextByTypeAndName(ctx context.Context, in *GetContextByTypeAndNameRequest, opts ...grpc.CallOption) (*GetContextByTypeAndNameResponse, error)
+	GetArtifactsByContext(ctx context.Context, in *GetArtifactsByContextRequest, opts ...grpc.CallOption) (*GetArtifactsByContextResponse, error)
+	GetExecutionsByContext(ctx context.Context, in *GetExecutionsByContextRequest, opts ...grpc.CallOption) (*GetExecutionsByContextResponse, error)
+	GetEventsByExecutionIDs(ctx context.Context, in *GetEventsByExecutionIDsRequest, opts ...grpc.CallOption) (*GetEventsByExecutionIDsResponse, error)
+}
+
+type metadataStoreServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMetadataStoreServiceClient(cc *grpc.ClientConn) MetadataStoreServiceClient {
+	return &metadataStoreServiceClient{cc}
+}
+
+func (c *metadataStoreServiceClient) PutArtifactType(ctx context.Context, in *PutArtifactTypeRequest, opts ...grpc.CallOption) (*PutArtifactTypeResponse, error) {
+	out := new(PutArtifactTypeResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutArtifactType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) PutExecutionType(ctx context.Context, in *PutExecutionTypeRequest, opts ...grpc.CallOption) (*PutExecutionTypeResponse, error) {
+	out := new(PutExecutionTypeResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutExecutionType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) PutContextType(ctx context.Context, in *PutContextTypeRequest, opts ...grpc.CallOption) (*PutContextTypeResponse, error) {
+	out := new(PutContextTypeResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutContextType", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) PutArtifacts(ctx context.Context, in *PutArtifactsRequest, opts ...grpc.CallOption) (*PutArtifactsResponse, error) {
+	out := new(PutArtifactsResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutArtifacts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) PutExecutions(ctx context.Context, in *PutExecutionsRequest, opts ...grpc.CallOption) (*PutExecutionsResponse, error) {
+	out := new(PutExecutionsResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutExecutions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) PutEvents(ctx context.Context, in *PutEventsRequest, opts ...grpc.CallOption) (*PutEventsResponse, error) {
+	out := new(PutEventsResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) PutContexts(ctx context.Context, in *PutContextsRequest, opts ...grpc.CallOption) (*PutContextsResponse, error) {
+	out := new(PutContextsResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutContexts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) PutAttributionsAndAssociations(ctx context.Context, in *PutAttributionsAndAssociationsRequest, opts ...grpc.CallOption) (*PutAttributionsAndAssociationsResponse, error) {
+	out := new(PutAttributionsAndAssociationsResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/PutAttributionsAndAssociations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) GetContextByTypeAndName(ctx context.Context, in *GetContextByTypeAndNameRequest, opts ...grpc.CallOption) (*GetContextByTypeAndNameResponse, error) {
+	out := new(GetContextByTypeAndNameResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/GetContextByTypeAndName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) GetArtifactsByContext(ctx context.Context, in *GetArtifactsByContextRequest, opts ...grpc.CallOption) (*GetArtifactsByContextResponse, error) {
+	out := new(GetArtifactsByContextResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/GetArtifactsByContext", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) GetExecutionsByContext(ctx context.Context, in *GetExecutionsByContextRequest, opts ...grpc.CallOption) (*GetExecutionsByContextResponse, error) {
+	out := new(GetExecutionsByContextResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/GetExecutionsByContext", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metadataStoreServiceClient) GetEventsByExecutionIDs(ctx context.Context, in *GetEventsByExecutionIDsRequest, opts ...grpc.CallOption) (*GetEventsByExecutionIDsResponse, error) {
+	out := new(GetEventsByExecutionIDsResponse)
+	err := c.cc.Invoke(ctx, "/ml_metadata.MetadataStoreService/GetEventsByExecutionIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetadataStoreServiceServer is the server API for MetadataStoreService service.
+type MetadataStoreServiceServer interface {
+	// Creates a type, or returns the id of the existing type of the same name.
+	PutArtifactType(context.Context, *PutArtifactTypeRequest) (*PutArtifactTypeResponse, error)
+	PutExecutionType(context.Context, *PutExecutionTypeRequest) (*PutExecutionTypeResponse, error)
+	PutContextType(context.Context, *PutContextTypeRequest) (*PutContextTypeResponse, error)
+	// Creates the artifacts without an id, and updates the others.
+	PutArtifacts(context.Context, *PutArtifactsRequest) (*PutArtifactsResponse, error)
+	// Creates the executions without an id, and updates the others.
+	PutExecutions(context.Context, *PutExecutionsRequest) (*PutExecutionsResponse, error)
+	PutEvents(context.Context, *PutEventsRequest) (*PutEventsResponse, error)
+	// Creates the contexts without an id, and updates the others.
+	PutContexts(context.Context, *PutContextsRequest) (*PutContextsResponse, error)
+	PutAttributionsAndAssociations(context.Context, *PutAttributionsAndAssociationsRequest) (*PutAttributionsAndAssociationsResponse, error)
+	GetContextByTypeAndName(context.Context, *GetContextByTypeAndNameRequest) (*GetContextByTypeAndNameResponse, error)
+	GetArtifactsByContext(context.Context, *GetArtifactsByContextRequest) (*GetArtifactsByContextResponse, error)
+	GetExecutionsByContext(context.Context, *GetExecutionsByContextRequest) (*GetExecutionsByContextResponse, error)
+	GetEventsByExecutionIDs(context.Context, *GetEventsByExecutionIDsRequest) (*GetEventsByExecutionIDsResponse, error)
+}
+
+func RegisterMetadataStoreServiceServer(s *grpc.Server, srv MetadataStoreServiceServer) {
+	s.RegisterService(&_MetadataStoreService_serviceDesc, srv)
+}
+
+func _MetadataStoreService_PutArtifactType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutArtifactTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutArtifactType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutArtifactType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutArtifactType(ctx, req.(*PutArtifactTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_PutExecutionType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutExecutionTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutExecutionType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutExecutionType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutExecutionType(ctx, req.(*PutExecutionTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_PutContextType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutContextTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutContextType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutContextType",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutContextType(ctx, req.(*PutContextTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_PutArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutArtifactsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutArtifacts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutArtifacts(ctx, req.(*PutArtifactsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_PutExecutions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutExecutionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutExecutions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutExecutions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutExecutions(ctx, req.(*PutExecutionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_PutEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutEvents(ctx, req.(*PutEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_PutContexts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutContextsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutContexts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutContexts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutContexts(ctx, req.(*PutContextsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_PutAttributionsAndAssociations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutAttributionsAndAssociationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).PutAttributionsAndAssociations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/PutAttributionsAndAssociations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).PutAttributionsAndAssociations(ctx, req.(*PutAttributionsAndAssociationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_GetContextByTypeAndName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContextByTypeAndNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).GetContextByTypeAndName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/GetContextByTypeAndName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).GetContextByTypeAndName(ctx, req.(*GetContextByTypeAndNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_GetArtifactsByContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArtifactsByContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).GetArtifactsByContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/GetArtifactsByContext",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).GetArtifactsByContext(ctx, req.(*GetArtifactsByContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_GetExecutionsByContext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExecutionsByContextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).GetExecutionsByContext(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/GetExecutionsByContext",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).GetExecutionsByContext(ctx, req.(*GetExecutionsByContextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MetadataStoreService_GetEventsByExecutionIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventsByExecutionIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetadataStoreServiceServer).GetEventsByExecutionIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ml_metadata.MetadataStoreService/GetEventsByExecutionIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetadataStoreServiceServer).GetEventsByExecutionIDs(ctx, req.(*GetEventsByExecutionIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MetadataStoreService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ml_metadata.MetadataStoreService",
+	HandlerType: (*MetadataStoreServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PutArtifactType",
+			Handler:    _MetadataStoreService_PutArtifactType_Handler,
+		},
+		{
+			MethodName: "PutExecutionType",
+			Handler:    _MetadataStoreService_PutExecutionType_Handler,
+		},
+		{
+			MethodName: "PutContextType",
+			Handler:    _MetadataStoreService_PutContextType_Handler,
+		},
+		{
+			MethodName: "PutArtifacts",
+			Handler:    _MetadataStoreService_PutArtifacts_Handler,
+		},
+		{
+			MethodName: "PutExecutions",
+			Handler:    _MetadataStoreService_PutExecutions_Handler,
+		},
+		{
+			MethodName: "PutEvents",
+			Handler:    _MetadataStoreService_PutEvents_Handler,
+		},
+		{
+			MethodName: "PutContexts",
+			Handler:    _MetadataStoreService_PutContexts_Handler,
+		},
+		{
+			MethodName: "PutAttributionsAndAssociations",
+			Handler:    _MetadataStoreService_PutAttributionsAndAssociations_Handler,
+		},
+		{
+			MethodName: "GetContextByTypeAndName",
+			Handler:    _MetadataStoreService_GetContextByTypeAndName_Handler,
+		},
+		{
+			MethodName: "GetArtifactsByContext",
+			Handler:    _MetadataStoreService_GetArtifactsByContext_Handler,
+		},
+		{
+			MethodName: "GetExecutionsByContext",
+			Handler:    _MetadataStoreService_GetExecutionsByContext_Handler,
+		},
+		{
+			MethodName: "GetEventsByExecutionIDs",
+			Handler:    _MetadataStoreService_GetEventsByExecutionIDs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ml_metadata/proto/metadata_store_service.proto",
+}